@@ -0,0 +1,139 @@
+package apis
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/spf13/cast"
+)
+
+// bindAnalyticsApi registers the usage analytics api endpoints.
+func bindAnalyticsApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/analytics")
+	sub.GET("", analyticsSummary).Bind(RequireSuperuserAuth())
+	sub.POST("/events", analyticsTrackPage).Bind(BodyLimit(analyticsMaxBodySize))
+}
+
+const (
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 366
+	defaultAnalyticsTop  = 10
+	maxAnalyticsTop      = 100
+
+	// analyticsMaxBodySize is the max allowed public page view event body size.
+	analyticsMaxBodySize = 2 << 10
+)
+
+// analyticsTrackRateLimitRule is the per-client page view events rate limit rule
+// (it is applied regardless of the app rate limits settings because
+// the endpoint is public and each event is persisted in the aux db).
+var analyticsTrackRateLimitRule = core.RateLimitRule{MaxRequests: 30, Duration: 60}
+
+// analyticsSummary returns the rolled up analytics of the requested days range
+// (by default the last 30 days).
+func analyticsSummary(e *core.RequestEvent) error {
+	query := e.Request.URL.Query()
+
+	to := time.Now().UTC()
+	if raw := query.Get("to"); raw != "" {
+		parsed, err := time.Parse(core.AnalyticsDayLayout, raw)
+		if err != nil {
+			return e.BadRequestError("Invalid to date (the expected format is YYYY-MM-DD).", err)
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if raw := query.Get("from"); raw != "" {
+		parsed, err := time.Parse(core.AnalyticsDayLayout, raw)
+		if err != nil {
+			return e.BadRequestError("Invalid from date (the expected format is YYYY-MM-DD).", err)
+		}
+		from = parsed
+	}
+
+	if from.After(to) || to.Sub(from) > maxAnalyticsDays*24*time.Hour {
+		return e.BadRequestError("The from-to range must be between 1 and 366 days.", nil)
+	}
+
+	top := defaultAnalyticsTop
+	if raw := query.Get("top"); raw != "" {
+		top = min(max(cast.ToInt(raw), 0), maxAnalyticsTop)
+	}
+
+	summary, err := e.App.FindAnalyticsSummary(from, to, top)
+	if err != nil {
+		return e.InternalServerError("Failed to load the analytics summary.", err)
+	}
+
+	return e.JSON(http.StatusOK, summary)
+}
+
+// analyticsTrackPage stores a client page view analytics event.
+//
+// It silently does nothing if the app analytics are disabled.
+func analyticsTrackPage(e *core.RequestEvent) error {
+	config := e.App.Settings().Analytics
+	if !config.Enabled || e.HasSuperuserAuth() {
+		return e.NoContent(http.StatusNoContent)
+	}
+
+	if err := checkRateLimit(e, "@pb_analytics_track", analyticsTrackRateLimitRule); err != nil {
+		return err
+	}
+
+	form := &analyticsPageForm{}
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	// strip the query parameters and fragment as they could contain identifying data
+	form.Path, _, _ = strings.Cut(form.Path, "?")
+	form.Path, _, _ = strings.Cut(form.Path, "#")
+
+	if err := form.validate(); err != nil {
+		return e.BadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	if config.IsExcluded(form.Path) {
+		return e.NoContent(http.StatusNoContent)
+	}
+
+	event := &core.AnalyticsEvent{
+		Type: core.AnalyticsEventPage,
+		Path: form.Path,
+	}
+
+	if err := e.App.TrackAnalyticsEvent(event, e.Auth); err != nil {
+		return e.InternalServerError("Failed to track the page view.", err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// -------------------------------------------------------------------
+
+type analyticsPageForm struct {
+	// Path is the viewed page path (e.g. "/pricing").
+	Path string `form:"path" json:"path"`
+}
+
+func (form *analyticsPageForm) validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Path,
+			validation.Required,
+			validation.Length(1, 500),
+			validation.By(func(value any) error {
+				if !strings.HasPrefix(form.Path, "/") {
+					return validation.NewError("validation_invalid_path", "The path must start with /.")
+				}
+				return nil
+			}),
+		),
+	)
+}
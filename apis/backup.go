@@ -19,11 +19,18 @@ func bindBackupApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
 	sub.GET("", backupsList).Bind(RequireSuperuserAuth())
 	sub.POST("", backupCreate).Bind(RequireSuperuserAuth())
 	sub.POST("/upload", backupUpload).Bind(BodyLimit(0), RequireSuperuserAuth())
+	sub.POST("/prune", backupPrune).Bind(RequireSuperuserAuth())
 	sub.GET("/{key}", backupDownload) // relies on superuser file token
 	sub.DELETE("/{key}", backupDelete).Bind(RequireSuperuserAuth())
 	sub.POST("/{key}/restore", backupRestore).Bind(RequireSuperuserAuth())
 }
 
+type backupRestoreForm struct {
+	// Collections is an optional list of collection names or ids
+	// to restore (if empty, the full backup is restored).
+	Collections []string `form:"collections" json:"collections"`
+}
+
 type backupFileInfo struct {
 	Modified types.DateTime `json:"modified"`
 	Key      string         `json:"key"`
@@ -138,6 +145,24 @@ func backupRestore(e *core.RequestEvent) error {
 		return e.BadRequestError("Missing or invalid backup file.", err)
 	}
 
+	form := new(backupRestoreForm)
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	// selective restore (doesn't require app restart)
+	if len(form.Collections) > 0 {
+		// wait max 10 minutes to fetch and restore the backup
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		if err := e.App.RestoreBackupCollections(ctx, key, form.Collections); err != nil {
+			return e.BadRequestError("Failed to restore the backup collections.", err)
+		}
+
+		return e.NoContent(http.StatusNoContent)
+	}
+
 	routine.FireAndForget(func() {
 		// give some optimistic time to write the response before restarting the app
 		time.Sleep(1 * time.Second)
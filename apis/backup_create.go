@@ -27,7 +27,11 @@ func backupCreate(e *core.RequestEvent) error {
 		return e.BadRequestError("An error occurred while validating the submitted data.", err)
 	}
 
-	err = e.App.CreateBackup(context.Background(), form.Name)
+	if form.Base != "" {
+		err = e.App.CreateIncrementalBackup(context.Background(), form.Name, form.Base)
+	} else {
+		err = e.App.CreateBackup(context.Background(), form.Name)
+	}
 	if err != nil {
 		return e.BadRequestError("Failed to create backup.", err)
 	}
@@ -45,6 +49,10 @@ type backupCreateForm struct {
 	app core.App
 
 	Name string `form:"name" json:"name"`
+
+	// Base is the optional name of an existing backup to create
+	// an incremental backup from (storing only the changed data).
+	Base string `form:"base" json:"base"`
 }
 
 func (form *backupCreateForm) validate() error {
@@ -55,6 +63,11 @@ func (form *backupCreateForm) validate() error {
 			validation.Match(backupNameRegex),
 			validation.By(form.checkUniqueName),
 		),
+		validation.Field(
+			&form.Base,
+			validation.Length(1, 150),
+			validation.By(form.checkExistingBase),
+		),
 	)
 }
 
@@ -76,3 +89,22 @@ func (form *backupCreateForm) checkUniqueName(value any) error {
 
 	return nil
 }
+
+func (form *backupCreateForm) checkExistingBase(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	fsys, err := form.app.NewBackupsFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	if exists, err := fsys.Exists(v); err != nil || !exists {
+		return validation.NewError("validation_backup_base_missing", "The base backup file is invalid or doesn't exist.")
+	}
+
+	return nil
+}
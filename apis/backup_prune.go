@@ -0,0 +1,32 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type backupPruneForm struct {
+	// DryRun reports the autogenerated backups that would be removed
+	// without actually deleting them.
+	DryRun bool `form:"dryRun" json:"dryRun"`
+}
+
+func backupPrune(e *core.RequestEvent) error {
+	form := new(backupPruneForm)
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	report, err := e.App.PruneBackups(ctx, form.DryRun)
+	if err != nil {
+		return e.BadRequestError("Failed to prune the backups.", err)
+	}
+
+	return e.JSON(http.StatusOK, report)
+}
@@ -3,8 +3,11 @@ package apis
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"mime"
 	"net/http"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -28,24 +31,46 @@ func NewRouter(app core.App) (*router.Router[*core.RequestEvent], error) {
 
 	// register default middlewares
 	pbRouter.Bind(activityLogger())
+	pbRouter.Bind(analyticsTracker())
+	pbRouter.Bind(requestCapture())
 	pbRouter.Bind(panicRecover())
 	pbRouter.Bind(rateLimit())
+	pbRouter.Bind(customRoutes())
 	pbRouter.Bind(loadAuthToken())
+	pbRouter.Bind(ipFilter())
 	pbRouter.Bind(securityHeaders())
+	pbRouter.Bind(maintenance())
 	pbRouter.Bind(BodyLimit(DefaultMaxBodySize))
+	pbRouter.Bind(transform())
+
+	// settings defined route rewrites (applied before the route matching)
+	pbRouter.Rewrite(customRoutesRewrite(app))
 
 	apiGroup := pbRouter.Group("/api")
 	bindSettingsApi(app, apiGroup)
 	bindCollectionApi(app, apiGroup)
 	bindRecordCrudApi(app, apiGroup)
+	bindCollectionTagsApi(app, apiGroup)
 	bindRecordAuthApi(app, apiGroup)
+	bindPoliciesApi(app, apiGroup)
 	bindLogsApi(app, apiGroup)
 	bindBackupApi(app, apiGroup)
 	bindCronApi(app, apiGroup)
+	bindPluginsApi(app, apiGroup)
 	bindFileApi(app, apiGroup)
 	bindBatchApi(app, apiGroup)
+	bindGraphQLApi(app, apiGroup)
+	bindOpenAPIApi(app, apiGroup)
 	bindRealtimeApi(app, apiGroup)
+	bindMailInboundApi(app, apiGroup)
+	bindMailSuppressionApi(app, apiGroup)
+	bindAnalyticsApi(app, apiGroup)
+	bindCapturesApi(app, apiGroup)
+	bindPushApi(app, apiGroup)
 	bindHealthApi(app, apiGroup)
+	bindProfilingApi(app, apiGroup)
+	bindHookStatsApi(app, apiGroup)
+	bindDBQueriesApi(app, apiGroup)
 
 	return pbRouter, nil
 }
@@ -84,6 +109,40 @@ func MustSubFS(fsys fs.FS, dir string) fs.FS {
 	return sub
 }
 
+// StaticConfig defines the config for the [StaticWithConfig] handler.
+type StaticConfig struct {
+	// IndexFallback forwards the requests for missing file resources
+	// to the FallbackPage (useful for SPA with pretty urls).
+	IndexFallback bool
+
+	// FallbackPage is the fsys root file to render when IndexFallback is set
+	// (default to "index.html").
+	FallbackPage string
+
+	// Precompressed enables serving the precompressed ".br" and ".gz"
+	// variants of the requested file (if they exist and the client accepts them).
+	//
+	// For example, a request to "app.js" with "Accept-Encoding: br, gzip"
+	// will be served with the "app.js.br" file content.
+	Precompressed bool
+
+	// CacheControl is an optional list of Cache-Control header rules.
+	//
+	// The first rule matching the served file is applied.
+	CacheControl []StaticCacheControlRule
+}
+
+// StaticCacheControlRule defines a single static files Cache-Control header rule.
+type StaticCacheControlRule struct {
+	// Pattern is a [path.Match] pattern for the served file path (e.g. "assets/*").
+	//
+	// Patterns without "/" are matched against the file base name (e.g. "*.html").
+	Pattern string
+
+	// Value is the Cache-Control header value (e.g. "max-age=31536000, immutable").
+	Value string
+}
+
 // Static is a handler function to serve static directory content from fsys.
 //
 // If a file resource is missing and indexFallback is set, the request
@@ -101,10 +160,46 @@ func MustSubFS(fsys fs.FS, dir string) fs.FS {
 //	fsys := os.DirFS("./pb_public")
 //	router.GET("/files/{path...}", apis.Static(fsys, false))
 func Static(fsys fs.FS, indexFallback bool) func(*core.RequestEvent) error {
+	return StaticWithConfig(fsys, StaticConfig{IndexFallback: indexFallback})
+}
+
+// StaticWithConfig is similar to [Static] but allows specifying
+// a custom fallback page, precompressed files detection and Cache-Control rules.
+//
+// Example:
+//
+//	fsys := os.DirFS("./pb_public")
+//	router.GET("/app/{path...}", apis.StaticWithConfig(fsys, apis.StaticConfig{
+//		IndexFallback: true,
+//		Precompressed: true,
+//		CacheControl: []apis.StaticCacheControlRule{
+//			{Pattern: "assets/*", Value: "max-age=31536000, immutable"},
+//			{Pattern: "*.html", Value: "no-cache"},
+//		},
+//	}))
+func StaticWithConfig(fsys fs.FS, config StaticConfig) func(*core.RequestEvent) error {
 	if fsys == nil {
 		panic("Static: the provided fs.FS argument is nil")
 	}
 
+	if config.FallbackPage == "" {
+		config.FallbackPage = router.IndexPage
+	}
+	config.FallbackPage = strings.TrimPrefix(config.FallbackPage, "/")
+
+	for _, rule := range config.CacheControl {
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			panic(fmt.Errorf("Static: invalid cache control pattern %q: %w", rule.Pattern, err))
+		}
+	}
+
+	fallback := func(e *core.RequestEvent, filename string) error {
+		if config.IndexFallback && filename != config.FallbackPage {
+			return serveStaticFile(e, fsys, config.FallbackPage, config)
+		}
+		return router.ErrFileNotFound
+	}
+
 	return func(e *core.RequestEvent) error {
 		// disable the activity logger to avoid flooding with messages
 		//
@@ -121,25 +216,23 @@ func Static(fsys fs.FS, indexFallback bool) func(*core.RequestEvent) error {
 		// note: this is just out of an abundance of caution because the fs.FS implementation could be non-std,
 		// but usually shouldn't be necessary since os.DirFS.Open is expected to fail if the filename starts with dots
 		if len(filename) > 2 && filename[0] == '.' && filename[1] == '.' && (filename[2] == '/' || filename[2] == '\\') {
-			if indexFallback && filename != router.IndexPage {
-				return e.FileFS(fsys, router.IndexPage)
-			}
-			return router.ErrFileNotFound
+			return fallback(e, filename)
 		}
 
 		fi, err := fs.Stat(fsys, filename)
 		if err != nil {
-			if indexFallback && filename != router.IndexPage {
-				return e.FileFS(fsys, router.IndexPage)
-			}
-			return router.ErrFileNotFound
+			return fallback(e, filename)
 		}
 
+		target := filename
+
 		if fi.IsDir() {
 			// redirect to a canonical dir url, aka. with trailing slash
 			if !strings.HasSuffix(e.Request.URL.Path, "/") {
 				return e.Redirect(http.StatusMovedPermanently, safeRedirectPath(e.Request.URL.Path+"/"))
 			}
+
+			target = path.Join(filename, router.IndexPage)
 		} else {
 			urlPath := e.Request.URL.Path
 			if strings.HasSuffix(urlPath, "/") {
@@ -154,16 +247,121 @@ func Static(fsys fs.FS, indexFallback bool) func(*core.RequestEvent) error {
 			}
 		}
 
-		fileErr := e.FileFS(fsys, filename)
+		fileErr := serveStaticFile(e, fsys, target, config)
 
-		if fileErr != nil && indexFallback && filename != router.IndexPage && errors.Is(fileErr, router.ErrFileNotFound) {
-			return e.FileFS(fsys, router.IndexPage)
+		if fileErr != nil && errors.Is(fileErr, router.ErrFileNotFound) {
+			return fallback(e, filename)
 		}
 
 		return fileErr
 	}
 }
 
+// precompressedEncodings lists the supported precompressed
+// file encodings and extensions in order of preference.
+var precompressedEncodings = []struct {
+	encoding  string
+	extension string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// serveStaticFile writes the fsys filename content (or its precompressed
+// variant) applying the matching config Cache-Control rule.
+func serveStaticFile(e *core.RequestEvent, fsys fs.FS, filename string, config StaticConfig) error {
+	if _, err := fs.Stat(fsys, filename); err != nil {
+		return router.ErrFileNotFound
+	}
+
+	for _, rule := range config.CacheControl {
+		if staticPatternMatch(rule.Pattern, filename) {
+			e.Response.Header().Set("Cache-Control", rule.Value)
+			break
+		}
+	}
+
+	if !config.Precompressed {
+		return e.FileFS(fsys, filename)
+	}
+
+	e.Response.Header().Add("Vary", "Accept-Encoding")
+
+	acceptEncoding := e.Request.Header.Get("Accept-Encoding")
+
+	for _, pe := range precompressedEncodings {
+		if !acceptsEncoding(acceptEncoding, pe.encoding) {
+			continue
+		}
+
+		f, err := fsys.Open(filename + pe.extension)
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+
+		fi, err := f.Stat()
+		if err != nil || fi.IsDir() {
+			continue
+		}
+
+		rs, ok := f.(io.ReadSeeker)
+		if !ok {
+			continue
+		}
+
+		// set explicitly the original file type to prevent sniffing the compressed content
+		contentType := mime.TypeByExtension(path.Ext(filename))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		e.Response.Header().Set("Content-Type", contentType)
+		e.Response.Header().Set("Content-Encoding", pe.encoding)
+
+		http.ServeContent(e.Response, e.Request, path.Base(filename), fi.ModTime(), rs)
+
+		return nil
+	}
+
+	return e.FileFS(fsys, filename)
+}
+
+// staticPatternMatch reports whether the static file path matches the provided pattern.
+//
+// Patterns without "/" are matched against the file base name.
+func staticPatternMatch(pattern string, filename string) bool {
+	if !strings.Contains(pattern, "/") {
+		filename = path.Base(filename)
+	}
+
+	matched, _ := path.Match(strings.TrimPrefix(pattern, "/"), filename)
+
+	return matched
+}
+
+// acceptsEncoding loosely checks whether the Accept-Encoding header
+// value allows the specified content encoding.
+func acceptsEncoding(acceptEncoding string, encoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.TrimSpace(name)
+
+		if name != encoding && name != "*" {
+			continue
+		}
+
+		// explicitly disabled (e.g. "br;q=0")
+		q := strings.ReplaceAll(params, " ", "")
+		if q == "q=0" || strings.HasPrefix(q, "q=0.0") && strings.Trim(q[5:], "0") == "" {
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
 // safeRedirectPath normalizes the path string by replacing all beginning slashes
 // (`\\`, `//`, `\/`) with a single forward slash to prevent open redirect attacks
 func safeRedirectPath(path string) string {
@@ -7,10 +7,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/pocketbase/pocketbase/apis"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/router"
 )
 
@@ -233,6 +235,177 @@ func TestStatic(t *testing.T) {
 	}
 }
 
+func TestStaticWithConfig(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	fsys := fstest.MapFS{
+		"index.html":          {Data: []byte("root index.html")},
+		"app/index.html":      {Data: []byte("app index.html")},
+		"app/200.html":        {Data: []byte("app 200.html")},
+		"assets/app.js":       {Data: []byte("app.js")},
+		"assets/app.js.br":    {Data: []byte("app.js.br")},
+		"assets/app.js.gz":    {Data: []byte("app.js.gz")},
+		"assets/style.css":    {Data: []byte("style.css")},
+		"assets/style.css.gz": {Data: []byte("style.css.gz")},
+	}
+
+	config := apis.StaticConfig{
+		Precompressed: true,
+		CacheControl: []apis.StaticCacheControlRule{
+			{Pattern: "assets/*", Value: "max-age=31536000, immutable"},
+			{Pattern: "*.html", Value: "no-cache"},
+		},
+	}
+
+	spaConfig := config
+	spaConfig.IndexFallback = true
+	spaConfig.FallbackPage = "app/200.html"
+
+	scenarios := []struct {
+		name            string
+		path            string
+		acceptEncoding  string
+		config          apis.StaticConfig
+		gzip            bool
+		expectError     bool
+		expectBody      string
+		expectedHeaders map[string]string
+	}{
+		{
+			name:        "missing file without fallback",
+			path:        "missing",
+			config:      config,
+			expectError: true,
+		},
+		{
+			name:       "missing file with custom fallback page",
+			path:       "missing/a/b",
+			config:     spaConfig,
+			expectBody: "app 200.html",
+			expectedHeaders: map[string]string{
+				"Cache-Control":    "no-cache",
+				"Content-Encoding": "",
+			},
+		},
+		{
+			name:       "dir index cache control rule",
+			path:       "app/",
+			config:     config,
+			expectBody: "app index.html",
+			expectedHeaders: map[string]string{
+				"Cache-Control": "no-cache",
+			},
+		},
+		{
+			name:       "no accepted encoding",
+			path:       "assets/app.js",
+			config:     config,
+			expectBody: "app.js",
+			expectedHeaders: map[string]string{
+				"Cache-Control":    "max-age=31536000, immutable",
+				"Content-Encoding": "",
+				"Vary":             "Accept-Encoding",
+			},
+		},
+		{
+			name:           "brotli precompressed file",
+			path:           "assets/app.js",
+			acceptEncoding: "gzip, deflate, br",
+			config:         config,
+			expectBody:     "app.js.br",
+			expectedHeaders: map[string]string{
+				"Content-Encoding": "br",
+				"Content-Type":     "text/javascript; charset=utf-8",
+			},
+		},
+		{
+			name:           "disabled brotli encoding",
+			path:           "assets/app.js",
+			acceptEncoding: "gzip, br;q=0",
+			config:         config,
+			expectBody:     "app.js.gz",
+			expectedHeaders: map[string]string{
+				"Content-Encoding": "gzip",
+			},
+		},
+		{
+			name:           "missing brotli precompressed file",
+			path:           "assets/style.css",
+			acceptEncoding: "br, gzip",
+			config:         config,
+			expectBody:     "style.css.gz",
+			expectedHeaders: map[string]string{
+				"Content-Encoding": "gzip",
+				"Content-Type":     "text/css; charset=utf-8",
+			},
+		},
+		{
+			name:           "disabled precompressed files",
+			path:           "assets/app.js",
+			acceptEncoding: "br, gzip",
+			config:         apis.StaticConfig{},
+			expectBody:     "app.js",
+			expectedHeaders: map[string]string{
+				"Content-Encoding": "",
+				"Cache-Control":    "",
+			},
+		},
+		{
+			name:           "precompressed file with gzip middleware",
+			path:           "assets/app.js",
+			acceptEncoding: "gzip",
+			config:         config,
+			gzip:           true,
+			expectBody:     "app.js.gz",
+			expectedHeaders: map[string]string{
+				"Content-Encoding": "gzip",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/"+s.path, nil)
+			req.SetPathValue(apis.StaticWildcardParam, s.path)
+			if s.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", s.acceptEncoding)
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := new(core.RequestEvent)
+			e.App = app
+			e.Request = req
+			e.Response = rec
+
+			h := &hook.Hook[*core.RequestEvent]{}
+			if s.gzip {
+				h.Bind(apis.Gzip())
+			}
+
+			err := h.Trigger(e, apis.StaticWithConfig(fsys, s.config))
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if body := rec.Body.String(); body != s.expectBody {
+				t.Fatalf("Expected body %q, got %q", s.expectBody, body)
+			}
+
+			for k, v := range s.expectedHeaders {
+				if h := rec.Header().Get(k); h != v {
+					t.Fatalf("Expected %q header %q, got %q", k, v, h)
+				}
+			}
+		})
+	}
+}
+
 func TestMustSubFS(t *testing.T) {
 	t.Parallel()
 
@@ -353,6 +353,7 @@ func processInternalRequest(
 	event := &core.RequestEvent{}
 	event.App = activeApp
 	event.Auth = baseEvent.Auth
+	initCaptchaState(baseEvent) // share the captcha verification state with the subrequests
 	event.SetAll(baseEvent.GetAll())
 
 	// load RequestInfo context
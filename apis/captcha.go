@@ -0,0 +1,77 @@
+package apis
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// CaptchaTokenHeader is the request header with the client captcha token
+// of the collection actions with enabled captcha protection.
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+const captchaStateKey = "pbCaptchaState"
+
+// captchaState stores the last successfully verified token of a request
+// to allow reusing it within the internal batch and GraphQL subrequests.
+type captchaState struct {
+	verified string
+	mu       sync.Mutex
+}
+
+// initCaptchaState returns the request event captcha state, initializing it if missing.
+//
+// It should be called before cloning the event store for the internal
+// subrequests so that the state could be shared with them.
+func initCaptchaState(e *core.RequestEvent) *captchaState {
+	state, ok := e.Get(captchaStateKey).(*captchaState)
+	if !ok {
+		state = &captchaState{}
+		e.Set(captchaStateKey, state)
+	}
+
+	return state
+}
+
+// checkCaptcha verifies the request captcha token in case the collection action
+// requires it and the captcha settings are enabled.
+//
+// Superusers are not required to provide a captcha token.
+func checkCaptcha(e *core.RequestEvent, collection *core.Collection, action string) error {
+	if !collection.Captcha.Requires(action) || !e.App.Settings().Captcha.Enabled || e.HasSuperuserAuth() {
+		return nil
+	}
+
+	token := e.Request.Header.Get(CaptchaTokenHeader)
+
+	state := initCaptchaState(e)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if token != "" && state.verified == token {
+		return nil // already verified
+	}
+
+	event := new(core.CaptchaVerifyEvent)
+	event.RequestEvent = e
+	event.Collection = collection
+	event.Verifier = e.App.NewCaptchaVerifier()
+	event.Token = token
+	event.Action = action
+
+	err := e.App.OnCaptchaVerify().Trigger(event, func(e *core.CaptchaVerifyEvent) error {
+		if e.Verifier == nil {
+			return errors.New("missing captcha verifier")
+		}
+
+		return e.Verifier.Verify(e.Request.Context(), e.Token, e.RealIP())
+	})
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Missing or invalid captcha token.", err))
+	}
+
+	state.verified = token
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/logger"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// captureReplayRouterStoreKey is the app store key of the serve router
+// used to replay the captured requests (including the OnServe registered routes).
+const captureReplayRouterStoreKey = "pbCaptureReplayRouter"
+
+// bindCapturesApi registers the debug traffic capture api endpoints.
+func bindCapturesApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	app.OnServe().Bind(&hook.Handler[*core.ServeEvent]{
+		Id: "__pbCaptureReplayRouter__",
+		Func: func(e *core.ServeEvent) error {
+			e.App.Store().Set(captureReplayRouterStoreKey, e.Router)
+
+			return e.Next()
+		},
+	})
+
+	sub := rg.Group("/captures").Bind(RequireSuperuserAuth(), SkipSuccessActivityLog())
+	sub.GET("", capturesList)
+	sub.DELETE("", capturesDelete)
+	sub.GET("/{id}", capturesView)
+	sub.POST("/{id}/replay", capturesReplay)
+}
+
+var captureFilterFields = []string{
+	"id", "created", "method", "url", "route", "status", "duration",
+	"authCollection", "authRecord", "requestTruncated", "responseTruncated",
+}
+
+func capturesList(e *core.RequestEvent) error {
+	fieldResolver := search.NewSimpleFieldResolver(captureFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(e.App.AuxModelQuery(&core.CapturedRequest{})).
+		ParseAndExec(e.Request.URL.Query().Encode(), &[]*core.CapturedRequest{})
+
+	if err != nil {
+		return e.BadRequestError("", err)
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+func capturesView(e *core.RequestEvent) error {
+	id := e.Request.PathValue("id")
+	if id == "" {
+		return e.NotFoundError("", nil)
+	}
+
+	capture, err := e.App.FindCapturedRequestById(id)
+	if err != nil {
+		return e.NotFoundError("", err)
+	}
+
+	return e.JSON(http.StatusOK, capture)
+}
+
+func capturesDelete(e *core.RequestEvent) error {
+	if err := e.App.DeleteCapturedRequests(); err != nil {
+		return e.BadRequestError("Failed to delete the captured requests.", err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+type captureReplayForm struct {
+	// Body is an optional request body to replay instead of the
+	// captured one (e.g. to restore a redacted or truncated value).
+	Body string `form:"body" json:"body"`
+
+	// Auth indicates whether to replay the request
+	// as the captured request auth record (if any).
+	Auth bool `form:"auth" json:"auth"`
+}
+
+// capturesReplay executes the captured request against the current
+// app routes and returns the new response next to the captured one.
+func capturesReplay(e *core.RequestEvent) error {
+	id := e.Request.PathValue("id")
+	if id == "" {
+		return e.NotFoundError("", nil)
+	}
+
+	capture, err := e.App.FindCapturedRequestById(id)
+	if err != nil {
+		return e.NotFoundError("", err)
+	}
+
+	form := new(captureReplayForm)
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	body := capture.RequestBody
+	if form.Body != "" {
+		body = form.Body
+	} else if capture.RequestTruncated {
+		return e.BadRequestError("The captured request body is truncated and cannot be replayed without a body replacement.", nil)
+	}
+
+	serveRouter, _ := e.App.Store().Get(captureReplayRouterStoreKey).(*router.Router[*core.RequestEvent])
+	if serveRouter == nil {
+		return e.BadRequestError("The captured requests could be replayed only while the app is serving.", nil)
+	}
+
+	mux, err := serveRouter.BuildMux()
+	if err != nil {
+		return e.InternalServerError("Failed to initialize the replay router.", err)
+	}
+
+	ctx := context.WithValue(e.Request.Context(), captureReplayContextKey{}, true)
+
+	req, err := http.NewRequestWithContext(ctx, capture.Method, capture.URL, strings.NewReader(body))
+	if err != nil {
+		return e.BadRequestError("Invalid captured request.", err)
+	}
+	req.RemoteAddr = e.Request.RemoteAddr
+
+	// the redacted headers (credentials, cookies, etc.) are never replayed
+	for k, v := range capture.RequestHeaders {
+		if strings.Contains(v, logger.DefaultRedactReplacement) || strings.EqualFold(k, "Content-Length") {
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+
+	if form.Auth && capture.AuthRecord != "" {
+		authRecord, err := e.App.FindRecordById(capture.AuthCollection, capture.AuthRecord)
+		if err != nil {
+			return e.BadRequestError("The captured request auth record no longer exists.", err)
+		}
+
+		token, err := authRecord.NewAuthToken()
+		if err != nil {
+			return e.InternalServerError("Failed to generate the replay auth token.", err)
+		}
+
+		req.Header.Set("Authorization", token)
+	}
+
+	rec := httptest.NewRecorder()
+
+	started := time.Now()
+
+	mux.ServeHTTP(rec, req)
+
+	replay := &core.CapturedRequest{
+		Method:          capture.Method,
+		URL:             capture.URL,
+		Route:           capture.Route,
+		RequestHeaders:  captureHeaders(req.Header),
+		RequestBody:     body,
+		Status:          rec.Code,
+		ResponseHeaders: captureHeaders(rec.Header()),
+		ResponseBody:    rec.Body.String(),
+		Duration:        float64(time.Since(started)) / float64(time.Millisecond),
+	}
+	replay.Created = types.NowDateTime()
+	if form.Auth {
+		replay.AuthCollection = capture.AuthCollection
+		replay.AuthRecord = capture.AuthRecord
+	}
+
+	e.App.RedactCapturedRequest(replay)
+
+	return e.JSON(http.StatusOK, map[string]any{
+		"captured": capture,
+		"replay":   replay,
+	})
+}
@@ -21,8 +21,17 @@ func bindCollectionApi(app core.App, rg *router.RouterGroup[*core.RequestEvent])
 	subGroup.PATCH("/{collection}", collectionUpdate)
 	subGroup.DELETE("/{collection}", collectionDelete)
 	subGroup.DELETE("/{collection}/truncate", collectionTruncate)
+	subGroup.POST("/{collection}/rules/test", collectionRuleTest)
+	subGroup.GET("/{collection}/archive", collectionArchiveList)
+	subGroup.POST("/{collection}/archive", collectionArchive)
+	subGroup.POST("/{collection}/archive/restore", collectionArchiveRestore)
 	subGroup.PUT("/import", collectionsImport)
 	subGroup.GET("/meta/scaffolds", collectionScaffolds)
+	subGroup.GET("/meta/snapshots", collectionSnapshotsList)
+	subGroup.POST("/meta/snapshots", collectionSnapshotCreate)
+	subGroup.GET("/meta/snapshots/{id}", collectionSnapshotView)
+	subGroup.GET("/meta/snapshots/{id}/diff", collectionSnapshotDiff)
+	subGroup.POST("/meta/snapshots/{id}/rollback", collectionSnapshotRollback)
 }
 
 func collectionsList(e *core.RequestEvent) error {
@@ -0,0 +1,108 @@
+package apis
+
+import (
+	"math"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+func collectionArchiveList(e *core.RequestEvent) error {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return e.NotFoundError("", err)
+	}
+
+	query := e.Request.URL.Query()
+
+	page := max(cast.ToInt(query.Get(search.PageQueryParam)), 1)
+
+	perPage := cast.ToInt(query.Get(search.PerPageQueryParam))
+	if perPage <= 0 {
+		perPage = search.DefaultPerPage
+	}
+	perPage = min(perPage, search.MaxPerPage)
+
+	total, err := e.App.CountArchivedRecords(collection)
+	if err != nil {
+		return e.InternalServerError("Failed to count the archived records.", err)
+	}
+
+	records, err := e.App.FindArchivedRecords(collection, perPage, (page-1)*perPage)
+	if err != nil {
+		return e.InternalServerError("Failed to load the archived records.", err)
+	}
+
+	return e.JSON(http.StatusOK, &search.Result{
+		Items:      records,
+		Page:       page,
+		PerPage:    perPage,
+		TotalItems: int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+	})
+}
+
+type collectionArchiveForm struct {
+	Filter string `form:"filter" json:"filter"`
+}
+
+func (form *collectionArchiveForm) validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Filter, validation.Required, validation.Length(1, 3500)),
+	)
+}
+
+func collectionArchive(e *core.RequestEvent) error {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return e.NotFoundError("", err)
+	}
+
+	if collection.IsView() {
+		return e.BadRequestError("View collections cannot be archived since they don't store their own records.", nil)
+	}
+
+	form := new(collectionArchiveForm)
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err := form.validate(); err != nil {
+		return e.BadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	total, err := e.App.ArchiveRecords(collection, form.Filter)
+	if err != nil {
+		return e.BadRequestError("Failed to archive the collection records.", err)
+	}
+
+	return e.JSON(http.StatusOK, map[string]int{"total": total})
+}
+
+type collectionArchiveRestoreForm struct {
+	// Ids is an optional list of the archived records to restore
+	// (if empty, all archived collection records are restored).
+	Ids []string `form:"ids" json:"ids"`
+}
+
+func collectionArchiveRestore(e *core.RequestEvent) error {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return e.NotFoundError("", err)
+	}
+
+	form := new(collectionArchiveRestoreForm)
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	total, err := e.App.RestoreArchivedRecords(collection, form.Ids...)
+	if err != nil {
+		return e.BadRequestError("Failed to restore the archived records.", err)
+	}
+
+	return e.JSON(http.StatusOK, map[string]int{"total": total})
+}
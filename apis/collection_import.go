@@ -27,8 +27,15 @@ func collectionsImport(e *core.RequestEvent) error {
 	event.DeleteMissing = form.DeleteMissing
 
 	return event.App.OnCollectionsImportRequest().Trigger(event, func(e *core.CollectionsImportRequestEvent) error {
-		importErr := e.App.ImportCollections(e.CollectionsData, form.DeleteMissing)
+		options := form.CollectionsImportOptions
+		options.DeleteMissing = e.DeleteMissing
+
+		diff, importErr := e.App.ImportCollectionsWithOptions(e.CollectionsData, options)
 		if importErr == nil {
+			if options.DryRun {
+				return e.JSON(http.StatusOK, diff)
+			}
+
 			return execAfterSuccessTx(true, e.App, func() error {
 				return e.NoContent(http.StatusNoContent)
 			})
@@ -51,8 +58,9 @@ func collectionsImport(e *core.RequestEvent) error {
 // -------------------------------------------------------------------
 
 type collectionsImportForm struct {
-	Collections   []map[string]any `form:"collections" json:"collections"`
-	DeleteMissing bool             `form:"deleteMissing" json:"deleteMissing"`
+	Collections []map[string]any `form:"collections" json:"collections"`
+
+	core.CollectionsImportOptions
 }
 
 func (form *collectionsImportForm) validate() error {
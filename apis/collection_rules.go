@@ -0,0 +1,370 @@
+package apis
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ganigeorgiev/fexpr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// supported rule test actions
+const (
+	ruleActionList   = "list"
+	ruleActionView   = "view"
+	ruleActionCreate = "create"
+	ruleActionUpdate = "update"
+	ruleActionDelete = "delete"
+	ruleActionAuth   = "auth"
+	ruleActionManage = "manage"
+)
+
+// ruleActionMethods holds the default request method of each rule test action.
+var ruleActionMethods = map[string]string{
+	ruleActionList:   http.MethodGet,
+	ruleActionView:   http.MethodGet,
+	ruleActionCreate: http.MethodPost,
+	ruleActionUpdate: http.MethodPatch,
+	ruleActionDelete: http.MethodDelete,
+	ruleActionAuth:   http.MethodPost,
+	ruleActionManage: http.MethodPatch,
+}
+
+type ruleTestForm struct {
+	Rule           *string           `form:"rule" json:"rule"`
+	Body           map[string]any    `form:"body" json:"body"`
+	Query          map[string]string `form:"query" json:"query"`
+	Headers        map[string]string `form:"headers" json:"headers"`
+	Action         string            `form:"action" json:"action"`
+	RecordId       string            `form:"recordId" json:"recordId"`
+	AuthCollection string            `form:"authCollection" json:"authCollection"`
+	AuthId         string            `form:"authId" json:"authId"`
+	Method         string            `form:"method" json:"method"`
+	Context        string            `form:"context" json:"context"`
+
+	collection *core.Collection
+}
+
+func (form *ruleTestForm) validate() error {
+	actions := []any{ruleActionList, ruleActionView, ruleActionCreate, ruleActionUpdate, ruleActionDelete}
+	if form.collection.IsAuth() {
+		actions = append(actions, ruleActionAuth, ruleActionManage)
+	}
+
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Action, validation.Required, validation.In(actions...)),
+		validation.Field(
+			&form.RecordId,
+			validation.When(
+				form.Action != ruleActionList && form.Action != ruleActionCreate,
+				validation.Required,
+			).Else(
+				validation.When(form.Action == ruleActionCreate, validation.Empty),
+			),
+		),
+		validation.Field(&form.AuthCollection, validation.When(form.AuthId != "", validation.Required)),
+		validation.Field(&form.AuthId, validation.When(form.AuthCollection != "", validation.Required)),
+	)
+}
+
+// ruleTestClause represents the evaluation result of a single rule expression or group.
+type ruleTestClause struct {
+	Join    string            `json:"join,omitempty"`
+	Expr    string            `json:"expr"`
+	Passed  bool              `json:"passed"`
+	Matches *int              `json:"matches,omitempty"`
+	Error   string            `json:"error,omitempty"`
+	Clauses []*ruleTestClause `json:"clauses,omitempty"`
+}
+
+// ruleTestResult represents the rule test endpoint response.
+type ruleTestResult struct {
+	Action  string            `json:"action"`
+	Rule    *string           `json:"rule"`
+	Allowed bool              `json:"allowed"`
+	Message string            `json:"message"`
+	Matches *int              `json:"matches,omitempty"`
+	Clauses []*ruleTestClause `json:"clauses"`
+}
+
+// collectionRuleTest evaluates a collection API rule against a
+// hypothetical request context and reports the result of each rule clause.
+func collectionRuleTest(e *core.RequestEvent) error {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return e.NotFoundError("", err)
+	}
+
+	form := &ruleTestForm{collection: collection}
+	if err = e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err = form.validate(); err != nil {
+		return e.BadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	requestInfo := &core.RequestInfo{
+		Context: form.Context,
+		Method:  strings.ToUpper(form.Method),
+		Query:   form.Query,
+		Headers: make(map[string]string, len(form.Headers)),
+		Body:    form.Body,
+	}
+	if requestInfo.Context == "" {
+		requestInfo.Context = core.RequestInfoContextDefault
+	}
+	if requestInfo.Method == "" {
+		requestInfo.Method = ruleActionMethods[form.Action]
+	}
+	if requestInfo.Query == nil {
+		requestInfo.Query = map[string]string{}
+	}
+	if requestInfo.Body == nil {
+		requestInfo.Body = map[string]any{}
+	}
+	// normalize the header keys the same way as in the real request info ("X-Token" -> "x_token")
+	for k, v := range form.Headers {
+		requestInfo.Headers[inflector.Snakecase(k)] = v
+	}
+
+	if form.AuthCollection != "" {
+		requestInfo.Auth, err = e.App.FindRecordById(form.AuthCollection, form.AuthId)
+		if err != nil || !requestInfo.Auth.Collection().IsAuth() {
+			return e.BadRequestError("Missing or invalid auth record.", err)
+		}
+	}
+
+	result := &ruleTestResult{
+		Action:  form.Action,
+		Rule:    ruleByAction(collection, form.Action),
+		Clauses: []*ruleTestClause{},
+	}
+	if form.Rule != nil {
+		result.Rule = form.Rule
+	}
+
+	switch {
+	case requestInfo.HasSuperuserAuth():
+		result.Allowed = true
+		result.Message = "Superusers can perform all actions."
+		return e.JSON(http.StatusOK, result)
+	case result.Rule == nil:
+		result.Message = "The rule is not set, aka. only superusers can perform this action."
+		return e.JSON(http.StatusOK, result)
+	case *result.Rule == "":
+		result.Allowed = true
+		result.Message = "The rule is empty, aka. everyone can perform this action."
+		return e.JSON(http.StatusOK, result)
+	}
+
+	parsed, err := fexpr.Parse(*result.Rule)
+	if err != nil {
+		return e.BadRequestError("Failed to parse the rule.", validation.Errors{"rule": validation.NewError("validation_invalid_rule", err.Error())})
+	}
+
+	// prepare the rule target
+	// ---
+	targetCollection := collection
+	var newTargetQuery func() *dbx.SelectQuery
+
+	switch form.Action {
+	case ruleActionCreate:
+		record := core.NewRecord(collection)
+		requestInfo.Body = record.ReplaceModifiers(requestInfo.Body)
+		record.Load(requestInfo.Body)
+
+		targetCollection, newTargetQuery, err = newDummyRecordQuery(e.App, record)
+		if err != nil {
+			return e.BadRequestError("Failed to load the create record data.", err)
+		}
+	default:
+		var record *core.Record
+		if form.RecordId != "" {
+			record, err = e.App.FindRecordById(collection, form.RecordId)
+			if err != nil {
+				return e.NotFoundError("Missing rule test record.", err)
+			}
+
+			if form.Action == ruleActionUpdate {
+				requestInfo.Body = record.ReplaceModifiers(requestInfo.Body)
+			}
+		}
+
+		newTargetQuery = func() *dbx.SelectQuery {
+			q := e.App.RecordQuery(collection).Select("(1)")
+			if record != nil {
+				q.AndWhere(dbx.HashExp{collection.Name + ".id": record.Id})
+			}
+			return q
+		}
+	}
+	// ---
+
+	countMatches := form.Action == ruleActionList && form.RecordId == ""
+
+	evalExpr := func(data []fexpr.ExprGroup) (bool, *int, error) {
+		resolver := core.NewRecordFieldResolver(e.App, targetCollection, requestInfo, true)
+
+		expr, err := search.BuildParsedExpr(data, resolver)
+		if err != nil {
+			return false, nil, err
+		}
+
+		query := newTargetQuery().AndWhere(expr)
+
+		if err := resolver.UpdateQuery(query); err != nil {
+			return false, nil, err
+		}
+
+		if countMatches {
+			var total int
+			err := query.Distinct(false).
+				Select("COUNT(DISTINCT [[" + targetCollection.Name + ".id]])").
+				GroupBy( /* reset */ ).
+				Row(&total)
+			if err != nil {
+				return false, nil, err
+			}
+			return total > 0, &total, nil
+		}
+
+		var exists int
+		err = query.Limit(1).Row(&exists)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return false, nil, err
+		}
+
+		return exists > 0, nil, nil
+	}
+
+	var evalGroups func(groups []fexpr.ExprGroup) []*ruleTestClause
+	evalGroups = func(groups []fexpr.ExprGroup) []*ruleTestClause {
+		clauses := make([]*ruleTestClause, 0, len(groups))
+
+		for i, group := range groups {
+			clause := &ruleTestClause{}
+			if i > 0 {
+				clause.Join = string(group.Join)
+			}
+
+			var data []fexpr.ExprGroup
+			switch item := group.Item.(type) {
+			case fexpr.Expr:
+				data = []fexpr.ExprGroup{{Item: item}}
+				clause.Expr = ruleExprString(item)
+			case []fexpr.ExprGroup:
+				data = item
+				clause.Expr = "(" + ruleGroupsString(item) + ")"
+				clause.Clauses = evalGroups(item)
+			default:
+				clause.Error = "unsupported expression item"
+				clauses = append(clauses, clause)
+				continue
+			}
+
+			var err error
+			clause.Passed, clause.Matches, err = evalExpr(data)
+			if err != nil {
+				clause.Error = err.Error()
+			}
+
+			clauses = append(clauses, clause)
+		}
+
+		return clauses
+	}
+
+	result.Clauses = evalGroups(parsed)
+
+	result.Allowed, result.Matches, err = evalExpr(parsed)
+	if err != nil {
+		return e.BadRequestError("Failed to evaluate the rule.", validation.Errors{"rule": validation.NewError("validation_invalid_rule", err.Error())})
+	}
+
+	if result.Allowed {
+		result.Message = "The rule is satisfied."
+	} else {
+		failed := []string{}
+		for _, c := range result.Clauses {
+			if !c.Passed {
+				failed = append(failed, strconv.Quote(c.Expr))
+			}
+		}
+		result.Message = "The rule is not satisfied. Failed clauses: " + strings.Join(failed, ", ") + "."
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+// ruleByAction returns the collection rule associated with the specified action.
+func ruleByAction(collection *core.Collection, action string) *string {
+	switch action {
+	case ruleActionList:
+		return collection.ListRule
+	case ruleActionView:
+		return collection.ViewRule
+	case ruleActionCreate:
+		return collection.CreateRule
+	case ruleActionUpdate:
+		return collection.UpdateRule
+	case ruleActionDelete:
+		return collection.DeleteRule
+	case ruleActionAuth:
+		return collection.AuthRule
+	case ruleActionManage:
+		return collection.ManageRule
+	}
+
+	return nil
+}
+
+// ruleGroupsString serializes back the parsed rule groups.
+func ruleGroupsString(groups []fexpr.ExprGroup) string {
+	var sb strings.Builder
+
+	for i, group := range groups {
+		if i > 0 {
+			sb.WriteString(" " + string(group.Join) + " ")
+		}
+
+		switch item := group.Item.(type) {
+		case fexpr.Expr:
+			sb.WriteString(ruleExprString(item))
+		case []fexpr.ExprGroup:
+			sb.WriteString("(" + ruleGroupsString(item) + ")")
+		}
+	}
+
+	return sb.String()
+}
+
+// ruleExprString serializes back a single parsed rule expression.
+func ruleExprString(expr fexpr.Expr) string {
+	return ruleTokenString(expr.Left) + " " + string(expr.Op) + " " + ruleTokenString(expr.Right)
+}
+
+func ruleTokenString(token fexpr.Token) string {
+	switch token.Type {
+	case fexpr.TokenText:
+		return `"` + strings.ReplaceAll(token.Literal, `"`, `\"`) + `"`
+	case fexpr.TokenFunction:
+		args, _ := token.Meta.([]fexpr.Token)
+
+		parts := make([]string, len(args))
+		for i, arg := range args {
+			parts[i] = ruleTokenString(arg)
+		}
+
+		return token.Literal + "(" + strings.Join(parts, ", ") + ")"
+	default:
+		return token.Literal
+	}
+}
@@ -0,0 +1,76 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func collectionSnapshotsList(e *core.RequestEvent) error {
+	snapshots, err := e.App.FindCollectionSnapshots(0)
+	if err != nil {
+		return e.InternalServerError("Failed to load the collection snapshots.", err)
+	}
+
+	return e.JSON(http.StatusOK, snapshots)
+}
+
+func collectionSnapshotCreate(e *core.RequestEvent) error {
+	snapshot, err := e.App.CreateCollectionSnapshot(core.CollectionSnapshotTriggerManual, "")
+	if err != nil {
+		return e.BadRequestError("Failed to create collections snapshot.", err)
+	}
+
+	return e.JSON(http.StatusOK, snapshot)
+}
+
+func collectionSnapshotView(e *core.RequestEvent) error {
+	snapshot, err := e.App.FindCollectionSnapshotById(e.Request.PathValue("id"))
+	if err != nil {
+		return e.NotFoundError("", err)
+	}
+
+	return e.JSON(http.StatusOK, snapshot)
+}
+
+func collectionSnapshotDiff(e *core.RequestEvent) error {
+	snapshot, err := e.App.FindCollectionSnapshotById(e.Request.PathValue("id"))
+	if err != nil {
+		return e.NotFoundError("", err)
+	}
+
+	// compare with the current collections configuration if "with" is not set
+	var other *core.CollectionSnapshot
+	if with := e.Request.URL.Query().Get("with"); with != "" {
+		other, err = e.App.FindCollectionSnapshotById(with)
+		if err != nil {
+			return e.NotFoundError("Missing or invalid snapshot to compare with.", err)
+		}
+	} else {
+		other, err = core.NewCollectionSnapshot(e.App, "", "")
+		if err != nil {
+			return e.InternalServerError("Failed to load the current collections.", err)
+		}
+	}
+
+	diff, err := core.DiffCollectionSnapshots(snapshot, other)
+	if err != nil {
+		return e.BadRequestError("Failed to compare the collection snapshots.", err)
+	}
+
+	return e.JSON(http.StatusOK, diff)
+}
+
+func collectionSnapshotRollback(e *core.RequestEvent) error {
+	snapshot, err := e.App.FindCollectionSnapshotById(e.Request.PathValue("id"))
+	if err != nil {
+		return e.NotFoundError("", err)
+	}
+
+	result, err := e.App.RollbackCollectionSnapshot(snapshot.Id)
+	if err != nil {
+		return e.BadRequestError("Failed to rollback the collections snapshot.", err)
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
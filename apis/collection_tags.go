@@ -0,0 +1,181 @@
+package apis
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/spf13/cast"
+)
+
+// bindCollectionTagsApi registers the collection tags fields suggestions
+// and management api endpoints.
+func bindCollectionTagsApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/collections/{collection}/tags")
+	sub.GET("", collectionTagsSuggestions)
+	sub.POST("/rename", collectionTagsRename).Bind(RequireSuperuserAuth())
+	sub.POST("/merge", collectionTagsMerge).Bind(RequireSuperuserAuth())
+}
+
+type tagSuggestion struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// collectionTagsSuggestions returns the most used collection tags starting with the "prefix" query parameter.
+//
+// The suggestions are available for the non-superusers only if the collection
+// list API rule is not superusers-only and the tags field is not hidden.
+func collectionTagsSuggestions(e *core.RequestEvent) error {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return e.NotFoundError("Missing collection context.", err)
+	}
+
+	err = checkCollectionAPIAccess(e, collection, false)
+	if err != nil {
+		return err
+	}
+
+	query := e.Request.URL.Query()
+
+	field, err := findCollectionTagsField(collection, query.Get("field"))
+	if err != nil {
+		return e.BadRequestError(err.Error(), nil)
+	}
+
+	if !e.HasSuperuserAuth() && (collection.ListRule == nil || field.Hidden) {
+		return e.ForbiddenError("Only superusers can perform this action.", nil)
+	}
+
+	tags, err := e.App.FindTagSuggestions(collection, field.Name, query.Get("prefix"), min(cast.ToInt(query.Get("limit")), 100))
+	if err != nil {
+		return e.BadRequestError("Failed to load the tag suggestions.", err)
+	}
+
+	result := make([]tagSuggestion, len(tags))
+	for i, t := range tags {
+		result[i] = tagSuggestion{Tag: t.Tag, Count: t.Count}
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+type tagsChangeForm interface {
+	validate() error
+	fieldName() string
+}
+
+type tagsFieldForm struct {
+	// Field is the name of the tags field to update
+	// (could be empty if the collection has a single tags field).
+	Field string `form:"field" json:"field"`
+}
+
+func (form *tagsFieldForm) fieldName() string {
+	return form.Field
+}
+
+type tagsRenameForm struct {
+	tagsFieldForm
+
+	From string `form:"from" json:"from"`
+	To   string `form:"to" json:"to"`
+}
+
+func (form *tagsRenameForm) validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.From, validation.Required),
+		validation.Field(&form.To, validation.Required),
+	)
+}
+
+func collectionTagsRename(e *core.RequestEvent) error {
+	form := new(tagsRenameForm)
+
+	return handleTagsChange(e, form, func(collection *core.Collection, field *core.TagsField) (int, error) {
+		return e.App.RenameTag(collection, field.Name, form.From, form.To)
+	})
+}
+
+type tagsMergeForm struct {
+	tagsFieldForm
+
+	Target  string   `form:"target" json:"target"`
+	Sources []string `form:"sources" json:"sources"`
+}
+
+func (form *tagsMergeForm) validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Target, validation.Required),
+		validation.Field(&form.Sources, validation.Required, validation.Length(1, 100)),
+	)
+}
+
+func collectionTagsMerge(e *core.RequestEvent) error {
+	form := new(tagsMergeForm)
+
+	return handleTagsChange(e, form, func(collection *core.Collection, field *core.TagsField) (int, error) {
+		return e.App.MergeTags(collection, field.Name, form.Target, form.Sources...)
+	})
+}
+
+func handleTagsChange(
+	e *core.RequestEvent,
+	form tagsChangeForm,
+	change func(collection *core.Collection, field *core.TagsField) (int, error),
+) error {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return e.NotFoundError("Missing collection context.", err)
+	}
+
+	if err = e.BindBody(form); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while loading the submitted data.", err))
+	}
+
+	if err = form.validate(); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while validating the submitted data.", err))
+	}
+
+	field, err := findCollectionTagsField(collection, form.fieldName())
+	if err != nil {
+		return e.BadRequestError(err.Error(), nil)
+	}
+
+	total, err := change(collection, field)
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to update the tags.", err))
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{"updated": total})
+}
+
+// findCollectionTagsField returns the collection tags field with the specified name
+// (or the only collection tags field if name is empty).
+func findCollectionTagsField(collection *core.Collection, name string) (*core.TagsField, error) {
+	if name != "" {
+		field, ok := collection.Fields.GetByName(name).(*core.TagsField)
+		if !ok {
+			return nil, validation.NewError("validation_invalid_tags_field", "Missing or invalid tags field.")
+		}
+		return field, nil
+	}
+
+	var result *core.TagsField
+	for _, f := range collection.Fields {
+		if tagsField, ok := f.(*core.TagsField); ok {
+			if result != nil {
+				return nil, validation.NewError("validation_missing_tags_field", "The collection has multiple tags fields - specify the field name.")
+			}
+			result = tagsField
+		}
+	}
+
+	if result == nil {
+		return nil, validation.NewError("validation_invalid_tags_field", "Missing or invalid tags field.")
+	}
+
+	return result, nil
+}
@@ -1,6 +1,8 @@
 package apis
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"slices"
 	"strings"
@@ -9,6 +11,7 @@ import (
 	"github.com/pocketbase/pocketbase/tools/cron"
 	"github.com/pocketbase/pocketbase/tools/router"
 	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 // bindCronApi registers the crons api endpoint.
@@ -16,6 +19,17 @@ func bindCronApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
 	subGroup := rg.Group("/crons").Bind(RequireSuperuserAuth())
 	subGroup.GET("", cronsList)
 	subGroup.POST("/{id}", cronRun)
+	subGroup.GET("/{id}/runs", cronRunsList)
+	subGroup.POST("/{id}/disable", cronDisable)
+	subGroup.POST("/{id}/enable", cronEnable)
+}
+
+type cronJobInfo struct {
+	Id            string         `json:"id"`
+	Expression    string         `json:"expression"`
+	Disabled      bool           `json:"disabled"`
+	DisabledUntil types.DateTime `json:"disabledUntil"`
+	LastRun       *core.CronRun  `json:"lastRun"`
 }
 
 func cronsList(e *core.RequestEvent) error {
@@ -31,29 +45,111 @@ func cronsList(e *core.RequestEvent) error {
 		return strings.Compare(a.Id(), b.Id())
 	})
 
-	return e.JSON(http.StatusOK, jobs)
+	result := make([]*cronJobInfo, len(jobs))
+
+	for i, job := range jobs {
+		info := &cronJobInfo{
+			Id:         job.Id(),
+			Expression: job.Expression(),
+		}
+
+		state, err := e.App.FindCronJobState(job.Id())
+		if err == nil {
+			info.Disabled = state.IsDisabledAt(types.NowDateTime().Time())
+			if info.Disabled {
+				info.DisabledUntil = state.DisabledUntil
+			}
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return e.InternalServerError("Failed to load the cron job state.", err)
+		}
+
+		runs, err := e.App.FindCronRuns(job.Id(), 1)
+		if err != nil {
+			return e.InternalServerError("Failed to load the cron job runs.", err)
+		}
+		if len(runs) > 0 {
+			info.LastRun = runs[0]
+		}
+
+		result[i] = info
+	}
+
+	return e.JSON(http.StatusOK, result)
 }
 
 func cronRun(e *core.RequestEvent) error {
 	cronId := e.Request.PathValue("id")
 
-	var foundJob *cron.Job
+	if !hasCronJob(e.App, cronId) {
+		return e.NotFoundError("Missing or invalid cron job", nil)
+	}
 
-	jobs := e.App.Cron().Jobs()
-	for _, j := range jobs {
-		if j.Id() == cronId {
-			foundJob = j
-			break
+	app := e.App
+	routine.FireAndForget(func() {
+		if err := app.RunCronJob(cronId); err != nil {
+			app.Logger().Error("Failed to run cron job", "id", cronId, "error", err.Error())
 		}
+	})
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+func cronRunsList(e *core.RequestEvent) error {
+	cronId := e.Request.PathValue("id")
+
+	if !hasCronJob(e.App, cronId) {
+		return e.NotFoundError("Missing or invalid cron job", nil)
+	}
+
+	runs, err := e.App.FindCronRuns(cronId, 0)
+	if err != nil {
+		return e.InternalServerError("Failed to load the cron job runs.", err)
 	}
 
-	if foundJob == nil {
+	return e.JSON(http.StatusOK, runs)
+}
+
+type cronDisableForm struct {
+	// Until is an optional date until which the job should be disabled
+	// (if empty, the job is disabled until explicitly enabled).
+	Until types.DateTime `form:"until" json:"until"`
+}
+
+func cronDisable(e *core.RequestEvent) error {
+	cronId := e.Request.PathValue("id")
+
+	if !hasCronJob(e.App, cronId) {
 		return e.NotFoundError("Missing or invalid cron job", nil)
 	}
 
-	routine.FireAndForget(func() {
-		foundJob.Run()
-	})
+	form := new(cronDisableForm)
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err := e.App.DisableCronJob(cronId, form.Until.Time()); err != nil {
+		return e.BadRequestError("Failed to disable the cron job.", err)
+	}
 
 	return e.NoContent(http.StatusNoContent)
 }
+
+func cronEnable(e *core.RequestEvent) error {
+	cronId := e.Request.PathValue("id")
+
+	if !hasCronJob(e.App, cronId) {
+		return e.NotFoundError("Missing or invalid cron job", nil)
+	}
+
+	if err := e.App.EnableCronJob(cronId); err != nil {
+		return e.BadRequestError("Failed to enable the cron job.", err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+func hasCronJob(app core.App, id string) bool {
+	return slices.ContainsFunc(app.Cron().Jobs(), func(j *cron.Job) bool {
+		return j.Id() == id
+	})
+}
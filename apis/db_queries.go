@@ -0,0 +1,97 @@
+package apis
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// bindDBQueriesApi registers the running db queries, indexes advisor
+// and collection statistics api endpoints.
+func bindDBQueriesApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/debug/queries").Bind(RequireSuperuserAuth())
+	sub.GET("", dbQueriesList)
+	sub.GET("/slow", dbSlowQueriesList)
+	sub.DELETE("/{id}", dbQueryCancel)
+
+	indexes := rg.Group("/debug/indexes").Bind(RequireSuperuserAuth())
+	indexes.GET("", dbIndexesAdvise)
+	indexes.POST("", dbIndexesApply)
+
+	rg.GET("/debug/collections", dbCollectionStats).Bind(RequireSuperuserAuth())
+}
+
+func dbQueriesList(e *core.RequestEvent) error {
+	return e.JSON(http.StatusOK, e.App.RunningQueries())
+}
+
+func dbSlowQueriesList(e *core.RequestEvent) error {
+	return e.JSON(http.StatusOK, e.App.SlowQueries())
+}
+
+func dbQueryCancel(e *core.RequestEvent) error {
+	if !e.App.CancelRunningQuery(e.Request.PathValue("id")) {
+		return e.NotFoundError("Missing or already completed query.", nil)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// dbCollectionStats returns the lazily refreshed collection statistics
+// (the "refresh" query parameter forces their recalculation).
+func dbCollectionStats(e *core.RequestEvent) error {
+	var report *core.CollectionStatsReport
+	var err error
+
+	if refresh, _ := strconv.ParseBool(e.Request.URL.Query().Get("refresh")); refresh {
+		report, err = e.App.RefreshCollectionStats()
+	} else {
+		report, err = e.App.CollectionStats()
+	}
+	if err != nil {
+		return e.BadRequestError("Failed to collect the collection statistics.", err)
+	}
+
+	return e.JSON(http.StatusOK, report)
+}
+
+func dbIndexesAdvise(e *core.RequestEvent) error {
+	suggestions, err := e.App.AdviseIndexes()
+	if err != nil {
+		return e.BadRequestError("Failed to analyze the collection indexes.", err)
+	}
+
+	return e.JSON(http.StatusOK, suggestions)
+}
+
+// dbIndexesApply creates the currently suggested indexes
+// (optionally filtered by the "indexes" body field names).
+func dbIndexesApply(e *core.RequestEvent) error {
+	data := struct {
+		Indexes []string `form:"indexes" json:"indexes"`
+	}{}
+	if err := e.BindBody(&data); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	suggestions, err := e.App.AdviseIndexes()
+	if err != nil {
+		return e.BadRequestError("Failed to analyze the collection indexes.", err)
+	}
+
+	if len(data.Indexes) > 0 {
+		suggestions = slices.DeleteFunc(suggestions, func(s *core.IndexSuggestion) bool {
+			return !slices.Contains(data.Indexes, dbutils.ParseIndex(s.Index).IndexName)
+		})
+	}
+
+	if err := e.App.ApplyIndexSuggestions(suggestions); err != nil {
+		return e.BadRequestError("Failed to create the suggested indexes.", err)
+	}
+
+	return e.JSON(http.StatusOK, suggestions)
+}
@@ -0,0 +1,946 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/graphql"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/spf13/cast"
+)
+
+// bindGraphQLApi registers the collections GraphQL api endpoints.
+//
+// The GraphQL schema is generated from the collections and each root field is
+// resolved with the related records api handler, aka. it honors the same API rules,
+// expand semantics and hooks as the regular records api (files upload is not supported).
+//
+// Queries:
+//
+//	{collection}List(filter: String, sort: String, page: Int, perPage: Int, skipTotal: Boolean)
+//	{collection}View(id: ID!)
+//
+// Mutations:
+//
+//	{collection}Create(data: JSON!)
+//	{collection}Update(id: ID!, data: JSON!)
+//	{collection}Delete(id: ID!)
+//
+// Subscriptions (streamed as "next" SSE events using the realtime api access checks):
+//
+//	{collection}(id: ID, filter: String)
+//
+// Relations are expanded by selecting them under the record "expand" field, e.g.:
+//
+//	{ postsList { items { id title expand { author { name } } } } }
+func bindGraphQLApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/graphql")
+	sub.GET("", graphqlExecute)
+	sub.POST("", graphqlExecute)
+	sub.GET("/schema", graphqlSchema).Bind(RequireSuperuserAuth())
+}
+
+type graphqlRequest struct {
+	Variables     map[string]any `form:"variables" json:"variables"`
+	Query         string         `form:"query" json:"query"`
+	OperationName string         `form:"operationName" json:"operationName"`
+}
+
+type graphqlError struct {
+	Extensions map[string]any `json:"extensions,omitempty"`
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+}
+
+type graphqlResponse struct {
+	Data   *graphqlObject  `json:"data"`
+	Errors []*graphqlError `json:"errors,omitempty"`
+}
+
+func graphqlExecute(e *core.RequestEvent) error {
+	if !e.App.Settings().GraphQL.Enabled {
+		return e.ForbiddenError("The GraphQL API is not enabled.", nil)
+	}
+
+	req := new(graphqlRequest)
+
+	if e.Request.Method == http.MethodGet {
+		query := e.Request.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if raw := query.Get("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				return e.BadRequestError("Invalid GraphQL variables.", err)
+			}
+		}
+	} else if err := e.BindBody(req); err != nil {
+		return e.BadRequestError("Failed to read the submitted GraphQL request.", err)
+	}
+
+	doc, err := graphql.Parse(req.Query)
+	if err != nil {
+		return graphqlRequestError(e, err)
+	}
+
+	op, err := doc.Operation(req.OperationName)
+	if err != nil {
+		return graphqlRequestError(e, err)
+	}
+
+	if op.Type == graphql.OperationMutation && e.Request.Method != http.MethodPost {
+		return graphqlRequestError(e, errors.New("mutations can be executed only with POST requests"))
+	}
+
+	variables, err := graphqlVariables(op, req.Variables)
+	if err != nil {
+		return graphqlRequestError(e, err)
+	}
+
+	executor := &graphqlExecutor{event: e, doc: doc, variables: variables}
+
+	if op.Type == graphql.OperationSubscription {
+		return executor.subscribe(op)
+	}
+
+	data, errs := executor.execute(op)
+
+	return e.JSON(http.StatusOK, &graphqlResponse{Data: data, Errors: errs})
+}
+
+// graphqlRequestError writes a GraphQL request error response (aka. an error before the execution start).
+func graphqlRequestError(e *core.RequestEvent, err error) error {
+	return e.JSON(http.StatusBadRequest, map[string]any{
+		"errors": []*graphqlError{{Message: err.Error()}},
+	})
+}
+
+// graphqlVariables returns the operation variables values
+// (applying their default values and required checks).
+func graphqlVariables(op *graphql.Operation, provided map[string]any) (map[string]any, error) {
+	result := make(map[string]any, len(op.Variables))
+
+	for _, def := range op.Variables {
+		v, ok := provided[def.Name]
+		if !ok && def.HasDefault {
+			v = graphql.ResolveValue(def.DefaultValue, nil)
+		}
+
+		if v == nil && def.IsRequired() {
+			return nil, fmt.Errorf("variable \"$%s\" of required type %q was not provided", def.Name, def.Type)
+		}
+
+		result[def.Name] = v
+	}
+
+	return result, nil
+}
+
+// -------------------------------------------------------------------
+
+const graphqlTypenameField = "__typename"
+
+// graphqlRootFields defines the supported root field suffixes and their arguments.
+var graphqlRootFields = map[string]map[string][]string{
+	graphql.OperationQuery: {
+		"List": {"filter", "sort", "page", "perPage", "skipTotal"},
+		"View": {"id"},
+	},
+	graphql.OperationMutation: {
+		"Create": {"data"},
+		"Update": {"id", "data"},
+		"Delete": {"id"},
+	},
+}
+
+type graphqlExecutor struct {
+	event     *core.RequestEvent
+	doc       *graphql.Document
+	variables map[string]any
+}
+
+// execute resolves the query or mutation operation root fields (serially).
+func (ex *graphqlExecutor) execute(op *graphql.Operation) (*graphqlObject, []*graphqlError) {
+	fields, err := ex.doc.CollectFields(op.SelectionSet, ex.variables)
+	if err != nil {
+		return nil, []*graphqlError{{Message: err.Error()}}
+	}
+
+	data := newGraphqlObject()
+
+	var errs []*graphqlError
+
+	for _, field := range fields {
+		if field.Name == graphqlTypenameField {
+			data.set(field.ResponseKey(), graphqlRootTypeName(op.Type))
+			continue
+		}
+
+		value, err := ex.resolveRootField(op.Type, field)
+		if err != nil {
+			errs = append(errs, newGraphqlError(err, field.ResponseKey()))
+			data.set(field.ResponseKey(), nil)
+			continue
+		}
+
+		data.set(field.ResponseKey(), value)
+	}
+
+	return data, errs
+}
+
+func (ex *graphqlExecutor) resolveRootField(opType string, field *graphql.Field) (any, error) {
+	var collection *core.Collection
+	var action string
+
+	for suffix := range graphqlRootFields[opType] {
+		name, ok := strings.CutSuffix(field.Name, suffix)
+		if !ok || name == "" {
+			continue
+		}
+
+		c, err := ex.event.App.FindCachedCollectionByNameOrId(name)
+		if err == nil && (opType == graphql.OperationQuery || !c.IsView()) {
+			collection = c
+			action = suffix
+			break
+		}
+	}
+
+	if collection == nil {
+		return nil, router.NewBadRequestError(fmt.Sprintf("Cannot query field %q on type %q.", field.Name, graphqlRootTypeName(opType)), nil)
+	}
+
+	args := field.ArgumentValues(ex.variables)
+	for name := range args {
+		if !slices.Contains(graphqlRootFields[opType][action], name) {
+			return nil, router.NewBadRequestError(fmt.Sprintf("Unknown argument %q on field %q.", name, field.Name), nil)
+		}
+	}
+
+	id := cast.ToString(args["id"])
+	if slices.Contains(graphqlRootFields[opType][action], "id") && id == "" {
+		return nil, router.NewBadRequestError(fmt.Sprintf("Missing required argument \"id\" on field %q.", field.Name), nil)
+	}
+
+	var body map[string]any
+	if slices.Contains(graphqlRootFields[opType][action], "data") {
+		var ok bool
+		body, ok = args["data"].(map[string]any)
+		if !ok {
+			return nil, router.NewBadRequestError(fmt.Sprintf("Missing or invalid required argument \"data\" on field %q.", field.Name), nil)
+		}
+	}
+
+	if action == "Delete" {
+		if len(field.SelectionSet) > 0 {
+			return nil, router.NewBadRequestError(fmt.Sprintf("Field %q must not have a selection since type \"Boolean\" has no subfields.", field.Name), nil)
+		}
+
+		_, err := ex.dispatch(recordDelete(true, nil), http.MethodDelete, collection, id, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return true, nil
+	}
+
+	if len(field.SelectionSet) == 0 {
+		return nil, router.NewBadRequestError(fmt.Sprintf("Field %q must have a selection of subfields.", field.Name), nil)
+	}
+
+	query := url.Values{}
+
+	// the list records are resolved from the "items" field selection
+	recordsSelectionSet := field.SelectionSet
+	if action == "List" {
+		recordsSelectionSet = nil
+
+		fields, err := ex.doc.CollectFields(field.SelectionSet, ex.variables)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			if f.Name == "items" {
+				recordsSelectionSet = append(recordsSelectionSet, f.SelectionSet...)
+			}
+		}
+
+		for name, value := range args {
+			query.Set(name, cast.ToString(value))
+		}
+	}
+
+	expand, err := ex.expandPaths(recordsSelectionSet, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(expand) > 0 {
+		query.Set(expandQueryParam, strings.Join(expand, ","))
+	}
+
+	var result any
+	switch action {
+	case "List":
+		result, err = ex.dispatch(recordsList, http.MethodGet, collection, "", query, nil)
+	case "View":
+		result, err = ex.dispatch(recordView, http.MethodGet, collection, id, query, nil)
+	case "Create":
+		result, err = ex.dispatch(recordCreate(true, nil), http.MethodPost, collection, "", query, body)
+	case "Update":
+		result, err = ex.dispatch(recordUpdate(true, nil), http.MethodPatch, collection, id, query, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if action == "List" {
+		return ex.projectList(result, field)
+	}
+
+	return ex.projectRecord(result, field)
+}
+
+// dispatch executes the provided records api handler with a new internal
+// request event that inherits the auth state and headers of the GraphQL request.
+//
+// Returns the decoded JSON response body.
+func (ex *graphqlExecutor) dispatch(
+	handler func(*core.RequestEvent) error,
+	method string,
+	collection *core.Collection,
+	id string,
+	query url.Values,
+	body map[string]any,
+) (any, error) {
+	target := "/api/collections/" + url.PathEscape(collection.Name) + "/records"
+	if id != "" {
+		target += "/" + url.PathEscape(id)
+	}
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var rawBody []byte
+	if body != nil {
+		var err error
+		rawBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	baseEvent := ex.event
+
+	r, err := http.NewRequestWithContext(baseEvent.Request.Context(), method, target, bytes.NewReader(rawBody))
+	if err != nil {
+		return nil, err
+	}
+
+	r.SetPathValue("collection", collection.Name)
+	if id != "" {
+		r.SetPathValue("id", id)
+	}
+
+	r.RequestURI = r.URL.RequestURI()
+	r.Proto = baseEvent.Request.Proto
+	r.ProtoMajor = baseEvent.Request.ProtoMajor
+	r.ProtoMinor = baseEvent.Request.ProtoMinor
+	r.Host = baseEvent.Request.Host
+	r.RemoteAddr = baseEvent.Request.RemoteAddr
+	r.TLS = baseEvent.Request.TLS
+	if baseEvent.Request.Header != nil {
+		r.Header = baseEvent.Request.Header.Clone()
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Del("Content-Length")
+
+	event := &core.RequestEvent{}
+	event.App = baseEvent.App
+	event.Auth = baseEvent.Auth
+	initCaptchaState(baseEvent) // share the captcha verification state with the subrequests
+	event.SetAll(baseEvent.GetAll())
+	event.Set(core.RequestEventKeyInfoContext, core.RequestInfoContextGraphQL)
+	event.Request = r
+	event.Request.Body = &router.RereadableReadCloser{ReadCloser: r.Body}
+
+	rec := httptest.NewRecorder()
+	event.Response = &router.ResponseWriter{ResponseWriter: rec}
+
+	if err := handler(event); err != nil {
+		return nil, err
+	}
+
+	var result any
+	if rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+			return nil, fmt.Errorf("failed to decode the records api response: %w", err)
+		}
+	}
+
+	// e.g. a custom hook that has written directly an error response
+	if rec.Code >= 400 {
+		message, _ := result.(map[string]any)["message"].(string)
+		return nil, router.NewApiError(rec.Code, message, nil)
+	}
+
+	return result, nil
+}
+
+// expandPaths returns the expand paths of the record "expand" field selections.
+func (ex *graphqlExecutor) expandPaths(selectionSet []graphql.Selection, prefix string) ([]string, error) {
+	fields, err := ex.doc.CollectFields(selectionSet, ex.variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+
+	for _, field := range fields {
+		if field.Name != "expand" {
+			continue
+		}
+
+		relFields, err := ex.doc.CollectFields(field.SelectionSet, ex.variables)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rel := range relFields {
+			if rel.Name == graphqlTypenameField {
+				continue
+			}
+
+			path := prefix + rel.Name
+			if !slices.Contains(result, path) {
+				result = append(result, path)
+			}
+
+			nested, err := ex.expandPaths(rel.SelectionSet, path+".")
+			if err != nil {
+				return nil, err
+			}
+
+			for _, p := range nested {
+				if !slices.Contains(result, p) {
+					result = append(result, p)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (ex *graphqlExecutor) projectList(raw any, field *graphql.Field) (any, error) {
+	data, _ := raw.(map[string]any)
+	if data == nil {
+		return nil, nil
+	}
+
+	fields, err := ex.doc.CollectFields(field.SelectionSet, ex.variables)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newGraphqlObject()
+
+	for _, f := range fields {
+		key := f.ResponseKey()
+
+		switch f.Name {
+		case graphqlTypenameField:
+			result.set(key, strings.TrimSuffix(field.Name, "List")+"ListResult")
+		case "page", "perPage", "totalItems", "totalPages":
+			result.set(key, data[f.Name])
+		case "items":
+			items, err := ex.projectRecord(data["items"], f)
+			if err != nil {
+				return nil, err
+			}
+			result.set(key, items)
+		default:
+			return nil, router.NewBadRequestError(fmt.Sprintf("Cannot query field %q on type %q.", f.Name, strings.TrimSuffix(field.Name, "List")+"ListResult"), nil)
+		}
+	}
+
+	return result, nil
+}
+
+// projectRecord projects the decoded record(s) json value according to the field selection.
+func (ex *graphqlExecutor) projectRecord(raw any, field *graphql.Field) (any, error) {
+	switch v := raw.(type) {
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			projected, err := ex.projectRecord(item, field)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = projected
+		}
+		return result, nil
+	case map[string]any:
+		if len(field.SelectionSet) == 0 {
+			return nil, router.NewBadRequestError(fmt.Sprintf("Field %q must have a selection of subfields.", field.Name), nil)
+		}
+
+		fields, err := ex.doc.CollectFields(field.SelectionSet, ex.variables)
+		if err != nil {
+			return nil, err
+		}
+
+		collectionName, _ := v["collectionName"].(string)
+		collection, _ := ex.event.App.FindCachedCollectionByNameOrId(collectionName)
+
+		result := newGraphqlObject()
+
+		for _, f := range fields {
+			key := f.ResponseKey()
+
+			switch f.Name {
+			case graphqlTypenameField:
+				result.set(key, collectionName)
+			case "expand":
+				expandData, _ := v["expand"].(map[string]any)
+
+				relFields, err := ex.doc.CollectFields(f.SelectionSet, ex.variables)
+				if err != nil {
+					return nil, err
+				}
+
+				expand := newGraphqlObject()
+				for _, rel := range relFields {
+					if rel.Name == graphqlTypenameField {
+						expand.set(rel.ResponseKey(), collectionName+"Expand")
+						continue
+					}
+
+					projected, err := ex.projectRecord(expandData[rel.Name], rel)
+					if err != nil {
+						return nil, err
+					}
+					expand.set(rel.ResponseKey(), projected)
+				}
+				result.set(key, expand)
+			default:
+				value, ok := v[f.Name]
+				if !ok && collection != nil && collection.Fields.GetByName(f.Name) == nil && f.Name != "collectionId" && f.Name != "collectionName" {
+					return nil, router.NewBadRequestError(fmt.Sprintf("Cannot query field %q on type %q.", f.Name, collectionName), nil)
+				}
+				result.set(key, ex.projectValue(value, f))
+			}
+		}
+
+		return result, nil
+	default:
+		return raw, nil
+	}
+}
+
+// projectValue projects a regular field value (e.g. json object) according to its selection (if any).
+func (ex *graphqlExecutor) projectValue(value any, field *graphql.Field) any {
+	if len(field.SelectionSet) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = ex.projectValue(item, field)
+		}
+		return result
+	case map[string]any:
+		fields, err := ex.doc.CollectFields(field.SelectionSet, ex.variables)
+		if err != nil {
+			return nil
+		}
+
+		result := newGraphqlObject()
+		for _, f := range fields {
+			result.set(f.ResponseKey(), ex.projectValue(v[f.Name], f))
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// subscribe streams the collection record changes matching the
+// subscription operation as "next" SSE events until the connection is closed.
+func (ex *graphqlExecutor) subscribe(op *graphql.Operation) error {
+	e := ex.event
+
+	fields, err := ex.doc.CollectFields(op.SelectionSet, ex.variables)
+	if err != nil {
+		return graphqlRequestError(e, err)
+	}
+
+	if len(fields) != 1 {
+		return graphqlRequestError(e, errors.New("subscription operations must select exactly one root field"))
+	}
+
+	field := fields[0]
+
+	collection, err := e.App.FindCachedCollectionByNameOrId(field.Name)
+	if err != nil || collection.IsView() {
+		return graphqlRequestError(e, fmt.Errorf("cannot query field %q on type \"Subscription\"", field.Name))
+	}
+
+	if len(field.SelectionSet) == 0 {
+		return graphqlRequestError(e, fmt.Errorf("field %q must have a selection of subfields", field.Name))
+	}
+
+	args := field.ArgumentValues(ex.variables)
+	for name := range args {
+		if name != "id" && name != "filter" {
+			return graphqlRequestError(e, fmt.Errorf("unknown argument %q on field %q", name, field.Name))
+		}
+	}
+
+	subFields, err := ex.doc.CollectFields(field.SelectionSet, ex.variables)
+	if err != nil {
+		return graphqlRequestError(e, err)
+	}
+
+	var recordSelectionSet []graphql.Selection
+	for _, f := range subFields {
+		switch f.Name {
+		case "record":
+			recordSelectionSet = append(recordSelectionSet, f.SelectionSet...)
+		case "action", graphqlTypenameField:
+		default:
+			return graphqlRequestError(e, fmt.Errorf("cannot query field %q on type %q", f.Name, collection.Name+"Event"))
+		}
+	}
+
+	options := map[string]string{}
+
+	if filter := cast.ToString(args["filter"]); filter != "" {
+		options["filter"] = filter
+	}
+
+	expand, err := ex.expandPaths(recordSelectionSet, "")
+	if err != nil {
+		return graphqlRequestError(e, err)
+	}
+	if len(expand) > 0 {
+		options[expandQueryParam] = strings.Join(expand, ",")
+	}
+
+	rawOptions, err := json.Marshal(map[string]any{"query": options})
+	if err != nil {
+		return err
+	}
+
+	topic := collection.Name + "/*"
+	if id := cast.ToString(args["id"]); id != "" {
+		topic = collection.Name + "/" + id
+	}
+	topic += "?options=" + url.QueryEscape(string(rawOptions))
+
+	// disable global write deadline for the SSE connection
+	rc := http.NewResponseController(e.Response)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return e.InternalServerError("Failed to initialize SSE connection.", err)
+	}
+
+	e.Response.Header().Set("Content-Type", "text/event-stream")
+	e.Response.Header().Set("Cache-Control", "no-store")
+	e.Response.Header().Set("X-Accel-Buffering", "no")
+
+	client := subscriptions.NewDefaultClient()
+	client.Set(RealtimeClientAuthKey, e.Auth)
+	client.Subscribe(topic)
+
+	e.App.SubscriptionsBroker().Register(client)
+	defer e.App.SubscriptionsBroker().Unregister(client.Id())
+
+	if err := e.Flush(); err != nil {
+		return nil
+	}
+
+	for {
+		select {
+		case msg, ok := <-client.Channel():
+			if !ok {
+				complete := subscriptions.Message{Name: "complete"}
+				if err := complete.WriteSSE(e.Response, client.Id()); err == nil {
+					e.Flush()
+				}
+				return nil
+			}
+
+			response := ex.subscriptionResponse(msg, field, subFields)
+
+			rawResponse, err := json.Marshal(response)
+			if err != nil {
+				e.App.Logger().Debug("GraphQL subscription message marshal error", slog.String("error", err.Error()))
+				continue
+			}
+
+			next := subscriptions.Message{Name: "next", Data: rawResponse}
+			if err := next.WriteSSE(e.Response, client.Id()); err != nil {
+				return nil
+			}
+			if err := e.Flush(); err != nil {
+				return nil
+			}
+		case <-e.Request.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (ex *graphqlExecutor) subscriptionResponse(msg subscriptions.Message, field *graphql.Field, subFields []*graphql.Field) *graphqlResponse {
+	key := field.ResponseKey()
+
+	response := &graphqlResponse{Data: newGraphqlObject()}
+
+	payload := struct {
+		Record any    `json:"record"`
+		Action string `json:"action"`
+	}{}
+	if err := json.Unmarshal(msg.Data, &payload); err != nil {
+		response.Data.set(key, nil)
+		response.Errors = []*graphqlError{newGraphqlError(err, key)}
+		return response
+	}
+
+	event := newGraphqlObject()
+
+	for _, f := range subFields {
+		switch f.Name {
+		case graphqlTypenameField:
+			event.set(f.ResponseKey(), field.Name+"Event")
+		case "action":
+			event.set(f.ResponseKey(), payload.Action)
+		case "record":
+			record, err := ex.projectRecord(payload.Record, f)
+			if err != nil {
+				response.Data.set(key, nil)
+				response.Errors = []*graphqlError{newGraphqlError(err, key)}
+				return response
+			}
+			event.set(f.ResponseKey(), record)
+		}
+	}
+
+	response.Data.set(key, event)
+
+	return response
+}
+
+func graphqlRootTypeName(opType string) string {
+	switch opType {
+	case graphql.OperationMutation:
+		return "Mutation"
+	case graphql.OperationSubscription:
+		return "Subscription"
+	default:
+		return "Query"
+	}
+}
+
+func newGraphqlError(err error, path ...any) *graphqlError {
+	apiErr := router.ToApiError(err)
+
+	result := &graphqlError{
+		Message:    apiErr.Message,
+		Path:       path,
+		Extensions: map[string]any{"status": apiErr.Status},
+	}
+
+	if len(apiErr.Data) > 0 {
+		result.Extensions["data"] = apiErr.Data
+	}
+
+	return result
+}
+
+// -------------------------------------------------------------------
+
+// graphqlObject defines a JSON object that preserves the keys insertion order
+// (GraphQL responses are serialized in the order of the selection set).
+type graphqlObject struct {
+	values map[string]any
+	keys   []string
+}
+
+func newGraphqlObject() *graphqlObject {
+	return &graphqlObject{values: map[string]any{}}
+}
+
+func (o *graphqlObject) set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (o *graphqlObject) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("null"), nil
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		rawKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(rawKey)
+
+		buf.WriteByte(':')
+
+		rawValue, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(rawValue)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// -------------------------------------------------------------------
+
+func graphqlSchema(e *core.RequestEvent) error {
+	collections, err := e.App.FindAllCollections()
+	if err != nil {
+		return e.InternalServerError("Failed to load the collections.", err)
+	}
+
+	return e.String(http.StatusOK, graphqlSDL(collections))
+}
+
+// graphqlSDL generates the GraphQL schema definition of the provided collections.
+func graphqlSDL(collections []*core.Collection) string {
+	var types, queries, mutations, subscriptionFields strings.Builder
+
+	for _, c := range collections {
+		if strings.HasPrefix(c.Name, "__") {
+			continue // reserved GraphQL name prefix
+		}
+
+		fmt.Fprintf(&queries, "  %sList(filter: String, sort: String, page: Int, perPage: Int, skipTotal: Boolean): %sListResult!\n", c.Name, c.Name)
+		fmt.Fprintf(&queries, "  %sView(id: ID!): %s!\n", c.Name, c.Name)
+
+		if !c.IsView() {
+			fmt.Fprintf(&mutations, "  %sCreate(data: JSON!): %s!\n", c.Name, c.Name)
+			fmt.Fprintf(&mutations, "  %sUpdate(id: ID!, data: JSON!): %s!\n", c.Name, c.Name)
+			fmt.Fprintf(&mutations, "  %sDelete(id: ID!): Boolean!\n", c.Name)
+			fmt.Fprintf(&subscriptionFields, "  %s(id: ID, filter: String): %sEvent!\n", c.Name, c.Name)
+		}
+
+		var relations strings.Builder
+
+		fmt.Fprintf(&types, "\ntype %s {\n  collectionId: String!\n  collectionName: String!\n", c.Name)
+		for _, f := range c.Fields {
+			if f.Type() == core.FieldTypePassword {
+				continue
+			}
+
+			typ := graphqlFieldType(f)
+			if f.GetName() == core.FieldNameId {
+				typ = "ID!"
+			}
+			fmt.Fprintf(&types, "  %s: %s\n", f.GetName(), typ)
+
+			if rel, ok := f.(*core.RelationField); ok {
+				target := collectionNameById(collections, rel.CollectionId)
+				if target == "" {
+					continue
+				}
+				if rel.IsMultiple() {
+					fmt.Fprintf(&relations, "  %s: [%s!]\n", rel.Name, target)
+				} else {
+					fmt.Fprintf(&relations, "  %s: %s\n", rel.Name, target)
+				}
+			}
+		}
+		if relations.Len() > 0 {
+			fmt.Fprintf(&types, "  expand: %sExpand\n", c.Name)
+		}
+		types.WriteString("}\n")
+
+		if relations.Len() > 0 {
+			fmt.Fprintf(&types, "\ntype %sExpand {\n%s}\n", c.Name, relations.String())
+		}
+
+		fmt.Fprintf(&types, "\ntype %sListResult {\n  page: Int!\n  perPage: Int!\n  totalItems: Int!\n  totalPages: Int!\n  items: [%s!]!\n}\n", c.Name, c.Name)
+
+		if !c.IsView() {
+			fmt.Fprintf(&types, "\ntype %sEvent {\n  action: String!\n  record: %s!\n}\n", c.Name, c.Name)
+		}
+	}
+
+	var sdl strings.Builder
+
+	sdl.WriteString("scalar JSON\n\n")
+	sdl.WriteString("type Query {\n" + queries.String() + "}\n")
+	if mutations.Len() > 0 {
+		sdl.WriteString("\ntype Mutation {\n" + mutations.String() + "}\n")
+		sdl.WriteString("\ntype Subscription {\n" + subscriptionFields.String() + "}\n")
+	}
+	sdl.WriteString(types.String())
+
+	return sdl.String()
+}
+
+func graphqlFieldType(f core.Field) string {
+	var typ string
+
+	switch f.Type() {
+	case core.FieldTypeBool:
+		typ = "Boolean"
+	case core.FieldTypeNumber:
+		typ = "Float"
+	case core.FieldTypeOrder:
+		typ = "Int"
+	case core.FieldTypeJSON, core.FieldTypeGeoPoint:
+		typ = "JSON"
+	case core.FieldTypeText, core.FieldTypeEmail, core.FieldTypeURL, core.FieldTypeEditor,
+		core.FieldTypeDate, core.FieldTypeAutodate, core.FieldTypeSelect, core.FieldTypeTags, core.FieldTypeFile, core.FieldTypeRelation:
+		typ = "String"
+	default:
+		typ = "JSON"
+	}
+
+	if mv, ok := f.(core.MultiValuer); ok && mv.IsMultiple() {
+		typ = "[" + typ + "!]"
+	}
+
+	return typ
+}
+
+func collectionNameById(collections []*core.Collection, id string) string {
+	for _, c := range collections {
+		if c.Id == id {
+			return c.Name
+		}
+	}
+
+	return ""
+}
@@ -26,9 +26,14 @@ func healthCheck(e *core.RequestEvent) error {
 	}
 
 	if e.HasSuperuserAuth() {
-		resp.Data = make(map[string]any, 3)
+		resp.Data = make(map[string]any, 5)
 		resp.Data["canBackup"] = !e.App.Store().Has(core.StoreKeyActiveBackup)
+
+		if v, ok := e.App.Store().Get(core.StoreKeyLastBackupVerification).(*core.BackupVerification); ok {
+			resp.Data["lastBackupVerification"] = v
+		}
 		resp.Data["realIP"] = e.RealIP()
+		resp.Data["maintenance"] = e.App.Settings().Maintenance.Enabled
 
 		// loosely check if behind a reverse proxy
 		// (usually used in the dashboard to remind superusers in case deployed behind reverse-proxy)
@@ -0,0 +1,29 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// bindHookStatsApi registers the hook handlers execution stats api endpoints
+// (the stats are collected only when app.Settings().HookTracing.Enabled is set).
+func bindHookStatsApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/debug/hooks").Bind(RequireSuperuserAuth())
+	sub.GET("", hookStatsList)
+	sub.DELETE("", hookStatsReset)
+}
+
+func hookStatsList(e *core.RequestEvent) error {
+	return e.JSON(http.StatusOK, map[string]any{
+		"enabled": e.App.Settings().HookTracing.Enabled,
+		"items":   e.App.HookStats(),
+	})
+}
+
+func hookStatsReset(e *core.RequestEvent) error {
+	e.App.ResetHookStats()
+
+	return e.NoContent(http.StatusNoContent)
+}
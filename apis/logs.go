@@ -7,6 +7,7 @@ import (
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tools/router"
 	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
 )
 
 // bindLogsApi registers the request logs api endpoints.
@@ -14,6 +15,8 @@ func bindLogsApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
 	sub := rg.Group("/logs").Bind(RequireSuperuserAuth(), SkipSuccessActivityLog())
 	sub.GET("", logsList)
 	sub.GET("/stats", logsStats)
+	sub.GET("/aggregate", logsAggregate)
+	sub.GET("/facets", logsFacets)
 	sub.GET("/{id}", logsView)
 }
 
@@ -37,17 +40,9 @@ func logsList(e *core.RequestEvent) error {
 }
 
 func logsStats(e *core.RequestEvent) error {
-	fieldResolver := search.NewSimpleFieldResolver(logFilterFields...)
-
-	filter := e.Request.URL.Query().Get(search.FilterQueryParam)
-
-	var expr dbx.Expression
-	if filter != "" {
-		var err error
-		expr, err = search.FilterData(filter).BuildExpr(fieldResolver)
-		if err != nil {
-			return e.BadRequestError("Invalid filter format.", err)
-		}
+	expr, err := logsFilterExpr(e)
+	if err != nil {
+		return e.BadRequestError("Invalid filter format.", err)
 	}
 
 	stats, err := e.App.LogsStats(expr)
@@ -58,6 +53,62 @@ func logsStats(e *core.RequestEvent) error {
 	return e.JSON(http.StatusOK, stats)
 }
 
+func logsAggregate(e *core.RequestEvent) error {
+	expr, err := logsFilterExpr(e)
+	if err != nil {
+		return e.BadRequestError("Invalid filter format.", err)
+	}
+
+	query := e.Request.URL.Query()
+
+	interval := query.Get("interval")
+	if interval == "" {
+		interval = core.LogsIntervalHour
+	}
+
+	result, err := e.App.LogsAggregate(interval, query.Get("groupBy"), expr)
+	if err != nil {
+		return e.BadRequestError("Failed to aggregate logs.", err)
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+func logsFacets(e *core.RequestEvent) error {
+	expr, err := logsFilterExpr(e)
+	if err != nil {
+		return e.BadRequestError("Invalid filter format.", err)
+	}
+
+	query := e.Request.URL.Query()
+
+	field := query.Get("field")
+	if field == "" {
+		return e.BadRequestError("Missing required field query parameter.", nil)
+	}
+
+	limit := min(cast.ToInt(query.Get("limit")), 100)
+
+	result, err := e.App.LogsFacets(field, limit, expr)
+	if err != nil {
+		return e.BadRequestError("Failed to generate logs facets.", err)
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+// logsFilterExpr builds the optional logs filter expression from the request query.
+func logsFilterExpr(e *core.RequestEvent) (dbx.Expression, error) {
+	filter := e.Request.URL.Query().Get(search.FilterQueryParam)
+	if filter == "" {
+		return nil, nil
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver(logFilterFields...)
+
+	return search.FilterData(filter).BuildExpr(fieldResolver)
+}
+
 func logsView(e *core.RequestEvent) error {
 	id := e.Request.PathValue("id")
 	if id == "" {
@@ -0,0 +1,274 @@
+package apis
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// Supported inbound email webhook adapters.
+const (
+	MailInboundProviderMIME    = "mime"
+	MailInboundProviderMailgun = "mailgun"
+	MailInboundProviderSES     = "ses"
+)
+
+// MailInboundTokenHeader is the name of the header that could be used
+// as alternative to the "token" query parameter for authorizing the
+// inbound email and delivery events webhooks.
+const MailInboundTokenHeader = "X-Inbound-Token"
+
+// bindMailInboundApi registers the inbound email webhook api endpoints.
+func bindMailInboundApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/mails/inbound")
+	sub.POST("/{provider}", mailInbound)
+}
+
+func mailInbound(e *core.RequestEvent) error {
+	if err := checkMailWebhookToken(e); err != nil {
+		return err
+	}
+
+	provider := e.Request.PathValue("provider")
+
+	var message *mailer.Message
+	var err error
+
+	switch provider {
+	case MailInboundProviderMIME:
+		message, err = mailer.ParseMIME(e.Request.Body)
+	case MailInboundProviderMailgun:
+		message, err = parseMailgunInbound(e)
+	case MailInboundProviderSES:
+		message, err = parseSESInbound(e)
+	default:
+		return e.NotFoundError("Unsupported inbound email provider.", nil)
+	}
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to parse the inbound email.", err))
+	}
+
+	// SNS control message (eg. subscription confirmation)
+	if message == nil {
+		return e.NoContent(http.StatusNoContent)
+	}
+
+	event := new(core.MailReceiveEvent)
+	event.App = e.App
+	event.Provider = provider
+	event.Message = message
+
+	err = e.App.OnMailReceive().Trigger(event, func(me *core.MailReceiveEvent) error {
+		return nil
+	})
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to process the inbound email.", err))
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// checkMailWebhookToken checks whether the request has a valid mail webhooks token.
+func checkMailWebhookToken(e *core.RequestEvent) error {
+	expectedToken := e.App.Settings().Mailer.InboundToken
+	if expectedToken == "" {
+		return e.NotFoundError("Mail webhooks are not enabled.", nil)
+	}
+
+	token := e.Request.URL.Query().Get("token")
+	if token == "" {
+		token = e.Request.Header.Get(MailInboundTokenHeader)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) != 1 {
+		return e.UnauthorizedError("Missing or invalid mail webhooks token.", nil)
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// parseMailgunInbound parses a Mailgun route "forward" webhook request.
+//
+// Both "raw MIME" (URL ending with "mime") and "parsed" route payloads are supported.
+//
+// https://documentation.mailgun.com/docs/mailgun/user-manual/receive-forward-store/
+func parseMailgunInbound(e *core.RequestEvent) (*mailer.Message, error) {
+	if err := e.Request.ParseMultipartForm(router.DefaultMaxMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return nil, err
+	}
+
+	form := e.Request.Form
+
+	if raw := form.Get("body-mime"); raw != "" {
+		return mailer.ParseMIME(strings.NewReader(raw))
+	}
+
+	message := &mailer.Message{
+		Subject:           form.Get("subject"),
+		Text:              form.Get("body-plain"),
+		HTML:              form.Get("body-html"),
+		Headers:           map[string]string{},
+		Attachments:       map[string]io.Reader{},
+		InlineAttachments: map[string]io.Reader{},
+	}
+
+	from := form.Get("from")
+	if from == "" {
+		from = form.Get("sender")
+	}
+	if addr, err := mail.ParseAddress(from); err == nil {
+		message.From = *addr
+	}
+
+	to := form.Get("To")
+	if to == "" {
+		to = form.Get("recipient")
+	}
+	if to != "" {
+		list, err := mail.ParseAddressList(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient: %w", err)
+		}
+		for _, addr := range list {
+			message.To = append(message.To, *addr)
+		}
+	}
+
+	if rawHeaders := form.Get("message-headers"); rawHeaders != "" {
+		// serialized as [["name", "value"], ...]
+		var headers [][]string
+		if err := json.Unmarshal([]byte(rawHeaders), &headers); err != nil {
+			return nil, fmt.Errorf("invalid message-headers: %w", err)
+		}
+		for _, h := range headers {
+			if len(h) == 2 {
+				message.Headers[h[0]] = h[1]
+			}
+		}
+	}
+
+	if e.Request.MultipartForm != nil {
+		for _, files := range e.Request.MultipartForm.File {
+			for _, fh := range files {
+				f, err := fh.Open()
+				if err != nil {
+					return nil, err
+				}
+
+				content, err := io.ReadAll(f)
+				f.Close()
+				if err != nil {
+					return nil, err
+				}
+
+				message.Attachments[fh.Filename] = bytes.NewReader(content)
+			}
+		}
+	}
+
+	return message, nil
+}
+
+// -------------------------------------------------------------------
+
+type snsMessage struct {
+	Type         string `json:"Type"`
+	Message      string `json:"Message"`
+	SubscribeURL string `json:"SubscribeURL"`
+}
+
+type sesReceivedNotification struct {
+	NotificationType string `json:"notificationType"`
+	Content          string `json:"content"`
+	Receipt          struct {
+		Action struct {
+			Encoding string `json:"encoding"`
+		} `json:"action"`
+	} `json:"receipt"`
+}
+
+// parseSESInbound parses an Amazon SES receipt rule SNS action notification.
+//
+// Returns nil message for the SNS subscription confirmation and other non-email messages.
+//
+// https://docs.aws.amazon.com/ses/latest/dg/receiving-email-action-sns.html
+func parseSESInbound(e *core.RequestEvent) (*mailer.Message, error) {
+	sns := snsMessage{}
+	if err := json.NewDecoder(e.Request.Body).Decode(&sns); err != nil {
+		return nil, err
+	}
+
+	switch sns.Type {
+	case "SubscriptionConfirmation":
+		return nil, confirmSNSSubscription(sns.SubscribeURL)
+	case "Notification":
+		// continue below
+	default:
+		return nil, nil
+	}
+
+	notification := sesReceivedNotification{}
+	if err := json.Unmarshal([]byte(sns.Message), &notification); err != nil {
+		return nil, err
+	}
+
+	if notification.NotificationType != "Received" {
+		return nil, nil
+	}
+
+	if notification.Content == "" {
+		return nil, errors.New("missing email content (make sure that the SNS action doesn't exceed the 150KB limit)")
+	}
+
+	raw := []byte(notification.Content)
+	if strings.EqualFold(notification.Receipt.Action.Encoding, "BASE64") {
+		decoded, err := base64.StdEncoding.DecodeString(notification.Content)
+		if err != nil {
+			return nil, err
+		}
+		raw = decoded
+	}
+
+	return mailer.ParseMIME(bytes.NewReader(raw))
+}
+
+// confirmSNSSubscription confirms an SNS topic subscription by visiting its SubscribeURL.
+func confirmSNSSubscription(subscribeURL string) error {
+	u, err := url.Parse(subscribeURL)
+	if err != nil {
+		return err
+	}
+
+	// minimal guard against SSRF
+	if u.Scheme != "https" || !strings.HasPrefix(u.Hostname(), "sns.") || !strings.HasSuffix(u.Hostname(), ".amazonaws.com") {
+		return errors.New("invalid SNS SubscribeURL")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	res, err := client.Get(u.String())
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to confirm the SNS subscription (status %d)", res.StatusCode)
+	}
+
+	return nil
+}
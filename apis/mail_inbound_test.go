@@ -0,0 +1,244 @@
+package apis_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestMailInbound(t *testing.T) {
+	t.Parallel()
+
+	const token = "test_inbound_token"
+
+	rawMIME := "From: John <john@example.com>\r\nTo: support@example.com\r\nSubject: test_subject\r\n\r\ntest_text"
+
+	enableInbound := func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+		app.Settings().Mailer.InboundToken = token
+	}
+
+	checkMessage := func(provider string) func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+		return func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+			enableInbound(t, app, e)
+
+			app.OnMailReceive().BindFunc(func(me *core.MailReceiveEvent) error {
+				if me.Provider != provider {
+					t.Errorf("Expected provider %q, got %q", provider, me.Provider)
+				}
+
+				if me.Message.From.Address != "john@example.com" {
+					t.Errorf("Expected from john@example.com, got %q", me.Message.From.Address)
+				}
+
+				if len(me.Message.To) != 1 || me.Message.To[0].Address != "support@example.com" {
+					t.Errorf("Expected to support@example.com, got %v", me.Message.To)
+				}
+
+				if me.Message.Subject != "test_subject" {
+					t.Errorf("Expected subject test_subject, got %q", me.Message.Subject)
+				}
+
+				if me.Message.Text != "test_text" {
+					t.Errorf("Expected text test_text, got %q", me.Message.Text)
+				}
+
+				return me.Next()
+			})
+		}
+	}
+
+	mailgunMIME, mailgunMIMEWriter, err := tests.MockMultipartData(map[string]string{
+		"body-mime": rawMIME,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mailgunParsed, mailgunParsedWriter, err := tests.MockMultipartData(map[string]string{
+		"from":            "John <john@example.com>",
+		"recipient":       "support@example.com",
+		"subject":         "test_subject",
+		"body-plain":      "test_text",
+		"message-headers": `[["Message-Id","<abc@example.com>"]]`,
+	}, "attachment-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sesNotification, _ := json.Marshal(map[string]any{
+		"notificationType": "Received",
+		"content":          base64.StdEncoding.EncodeToString([]byte(rawMIME)),
+		"receipt": map[string]any{
+			"action": map[string]any{"type": "SNS", "encoding": "BASE64"},
+		},
+	})
+	sesBody, _ := json.Marshal(map[string]any{
+		"Type":    "Notification",
+		"Message": string(sesNotification),
+	})
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "disabled inbound processing",
+			Method:          http.MethodPost,
+			URL:             "/api/mails/inbound/mime?token=" + token,
+			Body:            strings.NewReader(rawMIME),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:            "missing token",
+			Method:          http.MethodPost,
+			URL:             "/api/mails/inbound/mime",
+			Body:            strings.NewReader(rawMIME),
+			BeforeTestFunc:  enableInbound,
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:            "invalid token",
+			Method:          http.MethodPost,
+			URL:             "/api/mails/inbound/mime?token=invalid",
+			Body:            strings.NewReader(rawMIME),
+			BeforeTestFunc:  enableInbound,
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:            "unknown provider",
+			Method:          http.MethodPost,
+			URL:             "/api/mails/inbound/unknown?token=" + token,
+			Body:            strings.NewReader(rawMIME),
+			BeforeTestFunc:  enableInbound,
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:            "invalid MIME",
+			Method:          http.MethodPost,
+			URL:             "/api/mails/inbound/mime?token=" + token,
+			Body:            strings.NewReader(""),
+			BeforeTestFunc:  enableInbound,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:           "raw MIME (query token)",
+			Method:         http.MethodPost,
+			URL:            "/api/mails/inbound/mime?token=" + token,
+			Body:           strings.NewReader(rawMIME),
+			BeforeTestFunc: checkMessage("mime"),
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{"*": 0, "OnMailReceive": 1},
+		},
+		{
+			Name:   "raw MIME (header token)",
+			Method: http.MethodPost,
+			URL:    "/api/mails/inbound/mime",
+			Body:   strings.NewReader(rawMIME),
+			Headers: map[string]string{
+				"X-Inbound-Token": token,
+			},
+			BeforeTestFunc: checkMessage("mime"),
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{"*": 0, "OnMailReceive": 1},
+		},
+		{
+			Name:   "mailgun (body-mime)",
+			Method: http.MethodPost,
+			URL:    "/api/mails/inbound/mailgun?token=" + token,
+			Body:   mailgunMIME,
+			Headers: map[string]string{
+				"Content-Type": mailgunMIMEWriter.FormDataContentType(),
+			},
+			BeforeTestFunc: checkMessage("mailgun"),
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{"*": 0, "OnMailReceive": 1},
+		},
+		{
+			Name:   "mailgun (parsed)",
+			Method: http.MethodPost,
+			URL:    "/api/mails/inbound/mailgun?token=" + token,
+			Body:   mailgunParsed,
+			Headers: map[string]string{
+				"Content-Type": mailgunParsedWriter.FormDataContentType(),
+			},
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				checkMessage("mailgun")(t, app, e)
+
+				app.OnMailReceive().BindFunc(func(me *core.MailReceiveEvent) error {
+					if me.Message.Headers["Message-Id"] != "<abc@example.com>" {
+						t.Errorf("Expected Message-Id header, got %v", me.Message.Headers)
+					}
+
+					if len(me.Message.Attachments) != 1 {
+						t.Errorf("Expected 1 attachment, got %d", len(me.Message.Attachments))
+					}
+
+					return me.Next()
+				})
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{"*": 0, "OnMailReceive": 1},
+		},
+		{
+			Name:           "ses notification",
+			Method:         http.MethodPost,
+			URL:            "/api/mails/inbound/ses?token=" + token,
+			Body:           strings.NewReader(string(sesBody)),
+			BeforeTestFunc: checkMessage("ses"),
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{"*": 0, "OnMailReceive": 1},
+		},
+		{
+			Name:            "ses subscription confirmation with invalid SubscribeURL",
+			Method:          http.MethodPost,
+			URL:             "/api/mails/inbound/ses?token=" + token,
+			Body:            strings.NewReader(`{"Type":"SubscriptionConfirmation","SubscribeURL":"https://example.com/confirm"}`),
+			BeforeTestFunc:  enableInbound,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:           "ses unsubscribe confirmation (ignored)",
+			Method:         http.MethodPost,
+			URL:            "/api/mails/inbound/ses?token=" + token,
+			Body:           strings.NewReader(`{"Type":"UnsubscribeConfirmation"}`),
+			BeforeTestFunc: enableInbound,
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{"*": 0},
+		},
+		{
+			Name:   "hook error",
+			Method: http.MethodPost,
+			URL:    "/api/mails/inbound/mime?token=" + token,
+			Body:   strings.NewReader(rawMIME),
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				enableInbound(t, app, e)
+
+				app.OnMailReceive().BindFunc(func(me *core.MailReceiveEvent) error {
+					return errors.New("test_error")
+				})
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents:  map[string]int{"*": 0, "OnMailReceive": 1},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
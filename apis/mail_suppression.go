@@ -0,0 +1,277 @@
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindMailSuppressionApi registers the mail suppressions and delivery events api endpoints.
+func bindMailSuppressionApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/mails/suppressions").Bind(RequireSuperuserAuth())
+	sub.GET("", mailSuppressionsList)
+	sub.GET("/{id}", mailSuppressionView)
+	sub.DELETE("/{id}", mailSuppressionDelete)
+
+	rg.POST("/mails/events/{provider}", mailEvents)
+}
+
+var mailSuppressionFilterFields = []string{"id", "email", "reason", "detail", "created", "updated"}
+
+func mailSuppressionsList(e *core.RequestEvent) error {
+	fieldResolver := search.NewSimpleFieldResolver(mailSuppressionFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(e.App.MailSuppressionQuery()).
+		ParseAndExec(e.Request.URL.Query().Encode(), &[]*core.MailSuppression{})
+
+	if err != nil {
+		return e.BadRequestError("", err)
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+func mailSuppressionView(e *core.RequestEvent) error {
+	id := e.Request.PathValue("id")
+	if id == "" {
+		return e.NotFoundError("", nil)
+	}
+
+	suppression, err := e.App.FindMailSuppressionById(id)
+	if err != nil || suppression == nil {
+		return e.NotFoundError("", err)
+	}
+
+	return e.JSON(http.StatusOK, suppression)
+}
+
+func mailSuppressionDelete(e *core.RequestEvent) error {
+	id := e.Request.PathValue("id")
+	if id == "" {
+		return e.NotFoundError("", nil)
+	}
+
+	suppression, err := e.App.FindMailSuppressionById(id)
+	if err != nil || suppression == nil {
+		return e.NotFoundError("", err)
+	}
+
+	if err := e.App.Delete(suppression); err != nil {
+		return e.BadRequestError("Failed to delete mail suppression.", err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// -------------------------------------------------------------------
+
+// mailDeliveryEvent defines a single normalized provider bounce or complaint event.
+type mailDeliveryEvent struct {
+	Email  string
+	Reason string
+	Detail string
+}
+
+// mailEvents handles the provider delivery events webhooks and
+// suppresses the hard bounced and complained email addresses.
+func mailEvents(e *core.RequestEvent) error {
+	if err := checkMailWebhookToken(e); err != nil {
+		return err
+	}
+
+	var events []mailDeliveryEvent
+	var err error
+
+	switch e.Request.PathValue("provider") {
+	case core.MailerProviderSendGrid:
+		events, err = parseSendGridEvents(e)
+	case core.MailerProviderMailgun:
+		events, err = parseMailgunEvents(e)
+	case core.MailerProviderResend:
+		events, err = parseResendEvents(e)
+	case core.MailerProviderSES:
+		events, err = parseSESEvents(e)
+	default:
+		return e.NotFoundError("Unsupported mail events provider.", nil)
+	}
+	if err != nil {
+		return e.BadRequestError("Failed to parse the mail events.", err)
+	}
+
+	for _, event := range events {
+		if event.Email == "" {
+			continue
+		}
+
+		_, err := e.App.SuppressMailAddress(event.Email, event.Reason, event.Detail)
+		if err != nil {
+			return e.BadRequestError("Failed to suppress mail address.", err)
+		}
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// parseSendGridEvents parses a SendGrid Event Webhook payload.
+//
+// https://www.twilio.com/docs/sendgrid/for-developers/tracking-events/event
+func parseSendGridEvents(e *core.RequestEvent) ([]mailDeliveryEvent, error) {
+	var payload []struct {
+		Email  string `json:"email"`
+		Event  string `json:"event"`
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(e.Request.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	result := make([]mailDeliveryEvent, 0, len(payload))
+	for _, item := range payload {
+		switch {
+		case item.Event == "bounce" && item.Type != "blocked":
+			result = append(result, mailDeliveryEvent{item.Email, core.MailSuppressionReasonBounce, item.Reason})
+		case item.Event == "spamreport":
+			result = append(result, mailDeliveryEvent{item.Email, core.MailSuppressionReasonComplaint, ""})
+		}
+	}
+
+	return result, nil
+}
+
+// parseMailgunEvents parses a Mailgun webhook payload.
+//
+// https://documentation.mailgun.com/docs/mailgun/user-manual/events/webhooks/
+func parseMailgunEvents(e *core.RequestEvent) ([]mailDeliveryEvent, error) {
+	var payload struct {
+		EventData struct {
+			Event          string `json:"event"`
+			Severity       string `json:"severity"`
+			Recipient      string `json:"recipient"`
+			DeliveryStatus struct {
+				Description string `json:"description"`
+				Message     string `json:"message"`
+			} `json:"delivery-status"`
+		} `json:"event-data"`
+	}
+	if err := json.NewDecoder(e.Request.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	data := payload.EventData
+
+	switch {
+	case data.Event == "failed" && data.Severity == "permanent":
+		detail := data.DeliveryStatus.Description
+		if detail == "" {
+			detail = data.DeliveryStatus.Message
+		}
+		return []mailDeliveryEvent{{data.Recipient, core.MailSuppressionReasonBounce, detail}}, nil
+	case data.Event == "complained":
+		return []mailDeliveryEvent{{data.Recipient, core.MailSuppressionReasonComplaint, ""}}, nil
+	}
+
+	return nil, nil
+}
+
+// parseResendEvents parses a Resend webhook payload.
+//
+// https://resend.com/docs/dashboard/webhooks/event-types
+func parseResendEvents(e *core.RequestEvent) ([]mailDeliveryEvent, error) {
+	var payload struct {
+		Type string `json:"type"`
+		Data struct {
+			To     []string `json:"to"`
+			Bounce struct {
+				Type    string `json:"type"`
+				Message string `json:"message"`
+			} `json:"bounce"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(e.Request.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	var reason, detail string
+	switch payload.Type {
+	case "email.bounced":
+		// transient bounces are not suppressed
+		if strings.EqualFold(payload.Data.Bounce.Type, "Transient") {
+			return nil, nil
+		}
+		reason = core.MailSuppressionReasonBounce
+		detail = payload.Data.Bounce.Message
+	case "email.complained":
+		reason = core.MailSuppressionReasonComplaint
+	default:
+		return nil, nil
+	}
+
+	result := make([]mailDeliveryEvent, len(payload.Data.To))
+	for i, email := range payload.Data.To {
+		result[i] = mailDeliveryEvent{email, reason, detail}
+	}
+
+	return result, nil
+}
+
+// parseSESEvents parses an Amazon SES bounce and complaint SNS notification.
+//
+// https://docs.aws.amazon.com/ses/latest/dg/notification-contents.html
+func parseSESEvents(e *core.RequestEvent) ([]mailDeliveryEvent, error) {
+	sns := snsMessage{}
+	if err := json.NewDecoder(e.Request.Body).Decode(&sns); err != nil {
+		return nil, err
+	}
+
+	switch sns.Type {
+	case "SubscriptionConfirmation":
+		return nil, confirmSNSSubscription(sns.SubscribeURL)
+	case "Notification":
+		// continue below
+	default:
+		return nil, nil
+	}
+
+	var notification struct {
+		NotificationType string `json:"notificationType"`
+		Bounce           struct {
+			BounceType        string `json:"bounceType"`
+			BouncedRecipients []struct {
+				EmailAddress   string `json:"emailAddress"`
+				DiagnosticCode string `json:"diagnosticCode"`
+			} `json:"bouncedRecipients"`
+		} `json:"bounce"`
+		Complaint struct {
+			ComplainedRecipients []struct {
+				EmailAddress string `json:"emailAddress"`
+			} `json:"complainedRecipients"`
+		} `json:"complaint"`
+	}
+	if err := json.Unmarshal([]byte(sns.Message), &notification); err != nil {
+		return nil, err
+	}
+
+	var result []mailDeliveryEvent
+
+	switch notification.NotificationType {
+	case "Bounce":
+		if notification.Bounce.BounceType != "Permanent" {
+			return nil, nil
+		}
+		for _, r := range notification.Bounce.BouncedRecipients {
+			result = append(result, mailDeliveryEvent{r.EmailAddress, core.MailSuppressionReasonBounce, r.DiagnosticCode})
+		}
+	case "Complaint":
+		for _, r := range notification.Complaint.ComplainedRecipients {
+			result = append(result, mailDeliveryEvent{r.EmailAddress, core.MailSuppressionReasonComplaint, ""})
+		}
+	}
+
+	return result, nil
+}
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
-	"runtime"
+	"regexp"
+	"runtime/debug"
 	"slices"
 	"strings"
 	"time"
@@ -14,14 +16,17 @@ import (
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/logger"
 	"github.com/pocketbase/pocketbase/tools/router"
 	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/spf13/cast"
 )
 
 // Common request event store keys used by the middlewares and api handlers.
 const (
-	RequestEventKeyLogMeta = "pbLogMeta" // extra data to store with the request activity log
+	RequestEventKeyLogMeta   = "pbLogMeta"   // extra data to store with the request activity log
+	RequestEventKeyRequestId = "pbRequestId" // the unique request id (available only when the logs requestId enrichment is enabled)
 
 	requestEventKeyExecStart              = "__execStart"                 // the value must be time.Time
 	requestEventKeySkipSuccessActivityLog = "__skipSuccessActivityLogger" // the value must be bool
@@ -31,6 +36,12 @@ const (
 	DefaultWWWRedirectMiddlewarePriority = -99999
 	DefaultWWWRedirectMiddlewareId       = "pbWWWRedirect"
 
+	DefaultHSTSMiddlewarePriority = DefaultWWWRedirectMiddlewarePriority + 1
+	DefaultHSTSMiddlewareId       = "pbHSTS"
+
+	DefaultAltSvcMiddlewarePriority = DefaultWWWRedirectMiddlewarePriority + 2
+	DefaultAltSvcMiddlewareId       = "pbAltSvc"
+
 	DefaultActivityLoggerMiddlewarePriority   = DefaultRateLimitMiddlewarePriority - 40
 	DefaultActivityLoggerMiddlewareId         = "pbActivityLogger"
 	DefaultSkipSuccessActivityLogMiddlewareId = "pbSkipSuccessActivityLog"
@@ -50,6 +61,7 @@ const (
 	DefaultRequireSuperuserAuthMiddlewareId             = "pbRequireSuperuserAuth"
 	DefaultRequireSuperuserOrOwnerAuthMiddlewareId      = "pbRequireSuperuserOrOwnerAuth"
 	DefaultRequireSameCollectionContextAuthMiddlewareId = "pbRequireSameCollectionContextAuth"
+	DefaultCollectionAPIAccessMiddlewareId              = "pbCollectionAPIAccess"
 )
 
 // RequireGuestOnly middleware requires a request to NOT have a valid
@@ -168,6 +180,32 @@ func RequireSameCollectionContextAuth(collectionPathParam string) *hook.Handler[
 	}
 }
 
+// collectionPathAPIAccess rejects the non-superuser requests to a collection
+// with disabled API (see [core.CollectionAPI]).
+//
+// The collection is resolved from the route path parameter (default to "collection" if collectionParam is empty).
+func collectionPathAPIAccess(collectionPathParam string) *hook.Handler[*core.RequestEvent] {
+	if collectionPathParam == "" {
+		collectionPathParam = "collection"
+	}
+
+	return &hook.Handler[*core.RequestEvent]{
+		Id: DefaultCollectionAPIAccessMiddlewareId,
+		Func: func(e *core.RequestEvent) error {
+			collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue(collectionPathParam))
+			if err != nil {
+				return e.NotFoundError("Missing or invalid collection context.", err)
+			}
+
+			if err := checkCollectionAPIAccess(e, collection, false); err != nil {
+				return err
+			}
+
+			return e.Next()
+		},
+	}
+}
+
 // loadAuthToken attempts to load the auth context based on the "Authorization: TOKEN" header value.
 //
 // This middleware does nothing in case of:
@@ -257,17 +295,19 @@ func panicRecover() *hook.Handler[*core.RequestEvent] {
 					return
 				}
 
-				recoverErr, ok := recoverResult.(error)
-				if !ok {
-					recoverErr = fmt.Errorf("%v", recoverResult)
-				} else if errors.Is(recoverErr, http.ErrAbortHandler) {
+				if recoverErr, ok := recoverResult.(error); ok && errors.Is(recoverErr, http.ErrAbortHandler) {
 					// don't recover ErrAbortHandler so the response to the client can be aborted
 					panic(recoverResult)
 				}
 
-				stack := make([]byte, 2<<10) // 2 KB
-				length := runtime.Stack(stack, true)
-				err = e.InternalServerError("", fmt.Errorf("[PANIC RECOVER] %w %s", recoverErr, stack[:length]))
+				// note: the panic details are logged only once by the report
+				// and the error response intentionally doesn't wrap them
+				err = e.InternalServerError("", nil)
+
+				reportErr := e.App.ReportPanic(core.PanicSourceRequest, e.Request.Method+" "+e.Request.URL.Path, recoverResult, debug.Stack())
+				if reportErr != nil {
+					e.App.Logger().Error("Failed to report panic", "error", reportErr)
+				}
 			}()
 
 			err = e.Next()
@@ -326,6 +366,8 @@ func activityLogger() *hook.Handler[*core.RequestEvent] {
 		Func: func(e *core.RequestEvent) error {
 			e.Set(requestEventKeyExecStart, time.Now())
 
+			enrichRequestLogContext(e)
+
 			err := e.Next()
 
 			logRequest(e, err)
@@ -335,9 +377,67 @@ func activityLogger() *hook.Handler[*core.RequestEvent] {
 	}
 }
 
+// requestIdRegex is used to validate the client provided X-Request-Id header value.
+var requestIdRegex = regexp.MustCompile(`^[\w\-.:]{1,100}$`)
+
+// enrichRequestLogContext attaches the configured log enrichment
+// attributes to the request context so that they are included
+// in all logs written with it (including the request activity log).
+//
+// Example:
+//
+//	e.App.Logger().InfoContext(e.Request.Context(), "Example message")
+func enrichRequestLogContext(e *core.RequestEvent) {
+	logsSettings := e.App.Settings().Logs
+
+	attrs := make([]slog.Attr, 0, 3)
+
+	if logsSettings.Enrichment.RequestId {
+		requestId := e.Request.Header.Get("X-Request-Id")
+		if !requestIdRegex.MatchString(requestId) {
+			requestId = security.RandomString(20)
+		}
+
+		e.Set(RequestEventKeyRequestId, requestId)
+		e.Response.Header().Set("X-Request-Id", requestId)
+
+		attrs = append(attrs, slog.String("requestId", requestId))
+	}
+
+	if logsSettings.Enrichment.TenantHeader != "" {
+		tenant := cutStr(e.Request.Header.Get(logsSettings.Enrichment.TenantHeader), 255)
+		if tenant != "" {
+			attrs = append(attrs, slog.String("tenant", tenant))
+		}
+	}
+
+	if logsSettings.LogAuthId {
+		// lazily resolved because the auth state is loaded by a later middleware
+		attrs = append(attrs, slog.Any("authId", requestAuthIdValuer{e}))
+	}
+
+	if len(attrs) > 0 {
+		e.Request = e.Request.WithContext(logger.ContextWithAttrs(e.Request.Context(), attrs...))
+	}
+}
+
+// requestAuthIdValuer resolves the current request auth record id on log write.
+type requestAuthIdValuer struct {
+	event *core.RequestEvent
+}
+
+// LogValue implements the [slog.LogValuer] interface.
+func (v requestAuthIdValuer) LogValue() slog.Value {
+	if v.event.Auth == nil {
+		return slog.StringValue("")
+	}
+
+	return slog.StringValue(v.event.Auth.Id)
+}
+
 func logRequest(event *core.RequestEvent, err error) {
-	// no logs retention
-	if event.App.Settings().Logs.MaxDays == 0 {
+	// no logs retention or export
+	if event.App.Settings().Logs.MaxDays == 0 && len(event.App.Settings().Logs.Sinks) == 0 {
 		return
 	}
 
@@ -346,6 +446,23 @@ func logRequest(event *core.RequestEvent, err error) {
 		return
 	}
 
+	status := event.Status()
+	method := cutStr(strings.ToUpper(event.Request.Method), 50)
+	requestUri := cutStr(event.Request.URL.RequestURI(), 3000)
+
+	// the status header wasn't written yet
+	apiErr, isPlainApiError := err.(*router.ApiError)
+	isApiError := isPlainApiError || (err != nil && errors.As(err, &apiErr))
+	if isApiError && status == 0 {
+		status = apiErr.Status
+	}
+
+	// apply the sampling rules (if any)
+	rate := event.App.Settings().Logs.RequestSampleRate(method, event.Request.URL.Path, status)
+	if rate < 1 && (rate <= 0 || rand.Float64() >= rate) {
+		return
+	}
+
 	attrs := make([]any, 0, 15)
 
 	attrs = append(attrs, slog.String("type", "request"))
@@ -359,19 +476,9 @@ func logRequest(event *core.RequestEvent, err error) {
 		attrs = append(attrs, slog.Any("meta", meta))
 	}
 
-	status := event.Status()
-	method := cutStr(strings.ToUpper(event.Request.Method), 50)
-	requestUri := cutStr(event.Request.URL.RequestURI(), 3000)
-
 	// parse the request error
 	if err != nil {
-		apiErr, isPlainApiError := err.(*router.ApiError)
-		if isPlainApiError || errors.As(err, &apiErr) {
-			// the status header wasn't written yet
-			if status == 0 {
-				status = apiErr.Status
-			}
-
+		if isApiError {
 			var errMsg string
 			if isPlainApiError {
 				errMsg = apiErr.Message
@@ -428,10 +535,13 @@ func logRequest(event *core.RequestEvent, err error) {
 			message += requestUri
 		}
 
+		// note: the request context is used only for its enrichment attributes
+		ctx := event.Request.Context()
+
 		if err != nil {
-			event.App.Logger().Error(message, attrs...)
+			event.App.Logger().ErrorContext(ctx, message, attrs...)
 		} else {
-			event.App.Logger().Info(message, attrs...)
+			event.App.Logger().InfoContext(ctx, message, attrs...)
 		}
 	})
 }
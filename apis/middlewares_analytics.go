@@ -0,0 +1,81 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+const (
+	DefaultAnalyticsMiddlewareId       = "pbAnalytics"
+	DefaultAnalyticsMiddlewarePriority = DefaultActivityLoggerMiddlewarePriority + 1
+)
+
+// analyticsTracker defines the middleware that stores the API requests
+// as analytics events when the app Settings().Analytics are enabled.
+//
+// The superuser requests, the not matched routes and the analytics
+// endpoints themselves are not tracked.
+//
+// This middleware is registered by default for all routes.
+func analyticsTracker() *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       DefaultAnalyticsMiddlewareId,
+		Priority: DefaultAnalyticsMiddlewarePriority,
+		Func: func(e *core.RequestEvent) error {
+			config := e.App.Settings().Analytics
+			if !config.Enabled {
+				return e.Next()
+			}
+
+			started := time.Now()
+
+			err := e.Next()
+
+			path := e.Request.URL.Path
+			if e.Request.Method == http.MethodOptions ||
+				!strings.HasPrefix(path, "/api/") ||
+				strings.HasPrefix(path, "/api/analytics") ||
+				config.IsExcluded(path) ||
+				e.HasSuperuserAuth() {
+				return err
+			}
+
+			// the route pattern is stored instead of the path
+			// to avoid tracking ids and other identifying segments
+			_, route, _ := strings.Cut(e.Request.Pattern, " ")
+			if route == "" {
+				route = e.Request.Pattern
+			}
+
+			status := e.Status()
+			if status == 0 {
+				var apiErr *router.ApiError
+				if errors.As(err, &apiErr) {
+					status = apiErr.Status
+				} else if err != nil {
+					status = http.StatusInternalServerError
+				}
+			}
+
+			event := &core.AnalyticsEvent{
+				Type:     core.AnalyticsEventAPI,
+				Method:   e.Request.Method,
+				Path:     route,
+				Status:   status,
+				Duration: float64(time.Since(started)) / float64(time.Millisecond),
+			}
+
+			if trackErr := e.App.TrackAnalyticsEvent(event, e.Auth); trackErr != nil {
+				e.App.Logger().Debug("Failed to track analytics event", "error", trackErr)
+			}
+
+			return err
+		},
+	}
+}
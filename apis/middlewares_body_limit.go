@@ -54,7 +54,9 @@ func dynamicCollectionBodyLimit(collectionPathParam string) *hook.Handler[*core.
 			}
 
 			limitBytes := DefaultMaxBodySize
-			if !collection.IsView() {
+			if collection.Limits.MaxBodySize > 0 {
+				limitBytes = collection.Limits.MaxBodySize
+			} else if !collection.IsView() {
 				for _, f := range collection.Fields {
 					if calc, ok := f.(core.MaxBodySizeCalculator); ok {
 						limitBytes += calc.CalculateMaxBodySize()
@@ -0,0 +1,180 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const (
+	DefaultCaptureMiddlewareId       = "pbCapture"
+	DefaultCaptureMiddlewarePriority = DefaultAnalyticsMiddlewarePriority + 1
+)
+
+// captureReplayContextKey marks the replayed requests so that they are not captured again.
+type captureReplayContextKey struct{}
+
+// requestCapture defines the middleware that stores the full
+// request/response pairs of the routes matching the app
+// Settings().Capture configuration.
+//
+// The captures endpoints themselves and the replayed requests are not captured.
+//
+// This middleware is registered by default for all routes.
+func requestCapture() *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       DefaultCaptureMiddlewareId,
+		Priority: DefaultCaptureMiddlewarePriority,
+		Func: func(e *core.RequestEvent) error {
+			config := e.App.Settings().Capture
+			if !config.Enabled ||
+				strings.HasPrefix(e.Request.URL.Path, "/api/captures") ||
+				e.Request.Context().Value(captureReplayContextKey{}) != nil ||
+				!config.Match(e.Request.Method, e.Request.URL.Path) {
+				return e.Next()
+			}
+
+			maxBodySize := config.MaxBodySize
+			if maxBodySize <= 0 {
+				maxBodySize = core.DefaultCaptureMaxBodySize
+			}
+
+			capture := &core.CapturedRequest{
+				Method:         e.Request.Method,
+				URL:            e.Request.URL.RequestURI(),
+				RequestHeaders: captureHeaders(e.Request.Header),
+			}
+
+			// read up to maxBodySize+1 bytes and restore the request body
+			// so that it could be consumed as usual by the next handlers
+			if e.Request.Body != nil {
+				original := e.Request.Body
+
+				head, _ := io.ReadAll(io.LimitReader(original, int64(maxBodySize)+1))
+				if len(head) > maxBodySize {
+					capture.RequestTruncated = true
+					capture.RequestBody = string(head[:maxBodySize])
+				} else {
+					capture.RequestBody = string(head)
+				}
+
+				e.Request.Body = &captureRequestBody{
+					Reader: io.MultiReader(bytes.NewReader(head), original),
+					Closer: original,
+				}
+			}
+
+			originalResponse := e.Response
+			rw := &captureResponseWriter{ResponseWriter: originalResponse, maxBodySize: maxBodySize}
+			e.Response = rw
+			defer func() {
+				e.Response = originalResponse
+			}()
+
+			started := time.Now()
+
+			err := e.Next()
+
+			capture.Duration = float64(time.Since(started)) / float64(time.Millisecond)
+
+			_, capture.Route, _ = strings.Cut(e.Request.Pattern, " ")
+
+			if e.Auth != nil {
+				capture.AuthCollection = e.Auth.Collection().Id
+				capture.AuthRecord = e.Auth.Id
+			}
+
+			capture.ResponseHeaders = captureHeaders(originalResponse.Header())
+			capture.ResponseTruncated = rw.exceeded
+
+			if rw.status != 0 {
+				capture.Status = rw.status
+				capture.ResponseBody = rw.buf.String()
+			} else if err != nil {
+				// the error response is written later by the router error handler
+				apiErr := router.ToApiError(err)
+				capture.Status = apiErr.Status
+				raw, _ := json.Marshal(apiErr)
+				capture.ResponseBody = string(raw)
+			}
+
+			if saveErr := e.App.SaveCapturedRequest(capture); saveErr != nil {
+				e.App.Logger().Debug("Failed to save captured request", "error", saveErr)
+			}
+
+			return err
+		},
+	}
+}
+
+// captureHeaders converts the provided headers into a flat map
+// (the multiple values of the same header are joined with ", ").
+func captureHeaders(h http.Header) types.JSONMap[string] {
+	result := make(types.JSONMap[string], len(h))
+
+	for k, v := range h {
+		result[k] = strings.Join(v, ", ")
+	}
+
+	return result
+}
+
+// captureRequestBody is the replaced request body that
+// replays the already read captured bytes.
+type captureRequestBody struct {
+	io.Reader
+	io.Closer
+}
+
+// captureResponseWriter is a [http.ResponseWriter] that keeps
+// a copy of the written response body (up to maxBodySize).
+type captureResponseWriter struct {
+	http.ResponseWriter
+
+	buf         bytes.Buffer
+	maxBodySize int
+	status      int
+	exceeded    bool
+}
+
+func (w *captureResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *captureResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if !w.exceeded {
+		if remaining := w.maxBodySize - w.buf.Len(); len(b) > remaining {
+			w.exceeded = true
+			w.buf.Write(b[:remaining])
+		} else {
+			w.buf.Write(b)
+		}
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *captureResponseWriter) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying ResponseWritter instance (usually used by [http.ResponseController]).
+func (w *captureResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
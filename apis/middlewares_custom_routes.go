@@ -0,0 +1,138 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+const (
+	DefaultCustomRoutesMiddlewareId       = "pbCustomRoutes"
+	DefaultCustomRoutesMiddlewarePriority = DefaultBodyLimitMiddlewarePriority + 5 // after the body limit so that the proxied request body is limited
+)
+
+// customRoutes defines the middleware that handles the app
+// Settings().Routes "redirect" and "proxy" rules.
+//
+// This middleware is registered by default for all routes.
+func customRoutes() *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       DefaultCustomRoutesMiddlewareId,
+		Priority: DefaultCustomRoutesMiddlewarePriority,
+		Func: func(e *core.RequestEvent) error {
+			config := e.App.Settings().Routes
+
+			rule, target, ok := config.FindRouteRule(
+				e.Request.URL.Path,
+				core.RouteRuleTypeRedirect,
+				core.RouteRuleTypeProxy,
+			)
+			if !ok {
+				return e.Next()
+			}
+
+			if rule.Type == core.RouteRuleTypeProxy {
+				return proxyRequest(e, rule, target)
+			}
+
+			status := rule.Status
+			if status == 0 {
+				status = http.StatusFound
+			}
+
+			return e.Redirect(status, appendRawQuery(target, e.Request.URL.RawQuery))
+		},
+	}
+}
+
+// customRoutesRewrite returns a router rewrite function that handles
+// the app Settings().Routes "rewrite" rules.
+func customRoutesRewrite(app core.App) func(r *http.Request) {
+	return func(r *http.Request) {
+		config := app.Settings().Routes
+
+		_, target, ok := config.FindRouteRule(r.URL.Path, core.RouteRuleTypeRewrite)
+		if !ok {
+			return
+		}
+
+		rewritten, err := url.Parse(appendRawQuery(target, r.URL.RawQuery))
+		if err != nil {
+			app.Logger().Warn("Invalid route rewrite target", "path", r.URL.Path, "target", target, "error", err)
+			return
+		}
+
+		r.URL.Path = rewritten.Path
+		r.URL.RawPath = rewritten.RawPath
+		r.URL.RawQuery = rewritten.RawQuery
+	}
+}
+
+// proxyRequest forwards the current request to the specified target url
+// and writes back its response.
+//
+// The client Authorization and Cookie headers are not forwarded
+// unless explicitly allowed with the rule ForwardAuth option.
+func proxyRequest(e *core.RequestEvent, rule core.RouteRule, target string) error {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return e.InternalServerError("Invalid proxy target.", err)
+	}
+
+	var proxyErr error
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL.Scheme = targetURL.Scheme
+			pr.Out.URL.Host = targetURL.Host
+			pr.Out.URL.Path = targetURL.Path
+			pr.Out.URL.RawPath = targetURL.RawPath
+			pr.Out.URL.RawQuery = joinRawQuery(targetURL.RawQuery, pr.In.URL.RawQuery)
+			pr.Out.Host = ""
+			pr.SetXForwarded()
+
+			if !rule.ForwardAuth {
+				pr.Out.Header.Del("Authorization")
+				pr.Out.Header.Del("Cookie")
+			}
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			proxyErr = err
+		},
+	}
+
+	proxy.ServeHTTP(e.Response, e.Request)
+
+	if proxyErr != nil {
+		if errors.Is(proxyErr, e.Request.Context().Err()) {
+			return nil // the client has disconnected
+		}
+
+		return e.Error(http.StatusBadGateway, "Failed to proxy the request.", proxyErr)
+	}
+
+	return nil
+}
+
+func appendRawQuery(target string, rawQuery string) string {
+	if rawQuery == "" {
+		return target
+	}
+
+	base, targetQuery, _ := strings.Cut(target, "?")
+
+	return base + "?" + joinRawQuery(targetQuery, rawQuery)
+}
+
+func joinRawQuery(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+
+	return a + "&" + b
+}
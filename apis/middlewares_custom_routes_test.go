@@ -0,0 +1,182 @@
+package apis_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCustomRoutes(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Upstream", "1")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, r.Method+" "+r.URL.RequestURI()+" "+r.Header.Get("X-Forwarded-Host")+" "+string(body))
+		io.WriteString(w, " auth:"+r.Header.Get("Authorization")+" cookie:"+r.Header.Get("Cookie"))
+	}))
+	defer upstream.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Routes = core.RoutesConfig{
+		Enabled: true,
+		Rules: []core.RouteRule{
+			{Type: core.RouteRuleTypeRedirect, Path: "/old", Target: "/new?a=1"},
+			{Type: core.RouteRuleTypeRedirect, Path: "/docs/*", Target: "https://example.com/*", Status: http.StatusMovedPermanently},
+			{Type: core.RouteRuleTypeRewrite, Path: "/posts/*", Target: "/api/collections/demo2/records/*"},
+			{Type: core.RouteRuleTypeRewrite, Path: "/hello", Target: "/custom?x=1"},
+			{Type: core.RouteRuleTypeProxy, Path: "/svc/*", Target: upstream.URL + "/base/*?token=abc"},
+			{Type: core.RouteRuleTypeProxy, Path: "/svc-auth", Target: upstream.URL, ForwardAuth: true},
+			{Type: core.RouteRuleTypeProxy, Path: "/down", Target: "http://127.0.0.1:1"},
+		},
+	}
+
+	pbRouter, err := apis.NewRouter(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbRouter.GET("/custom", func(e *core.RequestEvent) error {
+		return e.String(200, "custom:"+e.Request.URL.RawQuery)
+	})
+
+	mux, err := pbRouter.BuildMux()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name            string
+		method          string
+		url             string
+		body            string
+		contentLength   int64
+		headers         map[string]string
+		disabled        bool
+		expectedStatus  int
+		expectedHeaders map[string]string
+		expectedContent []string
+	}{
+		{
+			name:            "redirect with exact path and query",
+			method:          http.MethodGet,
+			url:             "/old?b=2",
+			expectedStatus:  http.StatusFound,
+			expectedHeaders: map[string]string{"Location": "/new?a=1&b=2"},
+		},
+		{
+			name:            "redirect with wildcard path",
+			method:          http.MethodGet,
+			url:             "/docs/a/b",
+			expectedStatus:  http.StatusMovedPermanently,
+			expectedHeaders: map[string]string{"Location": "https://example.com/a/b"},
+		},
+		{
+			name:            "rewrite to an api route",
+			method:          http.MethodGet,
+			url:             "/posts/0yxhwia2amd8gec?fields=id",
+			expectedStatus:  200,
+			expectedContent: []string{`{"id":"0yxhwia2amd8gec"}`},
+		},
+		{
+			name:            "rewrite with target query",
+			method:          http.MethodGet,
+			url:             "/hello?y=2",
+			expectedStatus:  200,
+			expectedContent: []string{`custom:x=1&y=2`},
+		},
+		{
+			name:            "proxy",
+			method:          http.MethodPost,
+			url:             "/svc/a/b?c=1",
+			body:            "test_body",
+			expectedStatus:  http.StatusCreated,
+			expectedHeaders: map[string]string{"X-Upstream": "1"},
+			expectedContent: []string{`POST /base/a/b?token=abc&c=1 example.com test_body`},
+		},
+		{
+			name:            "proxy without auth headers",
+			method:          http.MethodGet,
+			url:             "/svc/a",
+			headers:         map[string]string{"Authorization": "test_token", "Cookie": "pb_auth=test_token"},
+			expectedStatus:  http.StatusCreated,
+			expectedContent: []string{`auth: cookie:`},
+		},
+		{
+			name:            "proxy with forwarded auth headers",
+			method:          http.MethodGet,
+			url:             "/svc-auth",
+			headers:         map[string]string{"Authorization": "test_token", "Cookie": "pb_auth=test_token"},
+			expectedStatus:  http.StatusCreated,
+			expectedContent: []string{`auth:test_token cookie:pb_auth=test_token`},
+		},
+		{
+			name:            "proxy with too large body",
+			method:          http.MethodPost,
+			url:             "/svc/a",
+			body:            "test_body",
+			contentLength:   apis.DefaultMaxBodySize + 1,
+			expectedStatus:  http.StatusRequestEntityTooLarge,
+			expectedContent: []string{`"status":413`},
+		},
+		{
+			name:            "proxy with unavailable target",
+			method:          http.MethodGet,
+			url:             "/down",
+			expectedStatus:  http.StatusBadGateway,
+			expectedContent: []string{`"message":"Failed to proxy the request."`},
+		},
+		{
+			name:           "non-matching path",
+			method:         http.MethodGet,
+			url:            "/old/a",
+			expectedStatus: 404,
+		},
+		{
+			name:           "disabled routes",
+			method:         http.MethodGet,
+			url:            "/old",
+			disabled:       true,
+			expectedStatus: 404,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app.Settings().Routes.Enabled = !s.disabled
+
+			req := httptest.NewRequest(s.method, s.url, strings.NewReader(s.body))
+			if s.contentLength > 0 {
+				req.ContentLength = s.contentLength
+			}
+			for k, v := range s.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != s.expectedStatus {
+				t.Fatalf("Expected status %d, got %d (%s)", s.expectedStatus, rec.Code, rec.Body.String())
+			}
+
+			for k, v := range s.expectedHeaders {
+				if h := rec.Header().Get(k); h != v {
+					t.Fatalf("Expected header %s %q, got %q", k, v, h)
+				}
+			}
+
+			body := rec.Body.String()
+			for _, str := range s.expectedContent {
+				if !strings.Contains(body, str) {
+					t.Fatalf("Cannot find %s in response body\n%s", str, body)
+				}
+			}
+		})
+	}
+}
@@ -117,6 +117,10 @@ func GzipWithConfig(config GzipConfig) *hook.Handler[*core.RequestEvent] {
 						// See issue echo#424, echo#407.
 						e.Response = rw
 						w.Reset(io.Discard)
+					} else if grw.passthrough {
+						// already encoded response (e.g. precompressed static file)
+						e.Response = rw
+						w.Reset(io.Discard)
 					} else if !grw.minLengthExceeded {
 						// Write uncompressed response
 						e.Response = rw
@@ -147,6 +151,7 @@ type gzipResponseWriter struct {
 	wroteHeader       bool
 	wroteBody         bool
 	minLengthExceeded bool
+	passthrough       bool
 }
 
 func (w *gzipResponseWriter) WriteHeader(code int) {
@@ -159,6 +164,20 @@ func (w *gzipResponseWriter) WriteHeader(code int) {
 }
 
 func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+
+	// the response is already encoded by the handler
+	if !w.wroteBody && w.Header().Get("Content-Encoding") != "" {
+		w.wroteBody = true
+		w.passthrough = true
+		if w.wroteHeader {
+			w.ResponseWriter.WriteHeader(w.code)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
 	if w.Header().Get("Content-Type") == "" {
 		w.Header().Set("Content-Type", http.DetectContentType(b))
 	}
@@ -187,6 +206,11 @@ func (w *gzipResponseWriter) Write(b []byte) (int, error) {
 }
 
 func (w *gzipResponseWriter) Flush() {
+	if w.passthrough {
+		_ = http.NewResponseController(w.ResponseWriter).Flush()
+		return
+	}
+
 	if !w.minLengthExceeded {
 		// Enforce compression because we will not know how much more data will come
 		w.minLengthExceeded = true
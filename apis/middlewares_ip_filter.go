@@ -0,0 +1,70 @@
+package apis
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+var ErrIPNotAllowed = router.NewApiError(http.StatusForbidden, "Access from your IP address is not allowed.", nil)
+
+const (
+	DefaultIPFilterMiddlewareId       = "pbIPFilter"
+	DefaultIPFilterMiddlewarePriority = DefaultLoadAuthTokenMiddlewarePriority + 5 // after the auth token load to allow checking for superusers
+)
+
+// ipFilter defines the middleware that rejects the requests from
+// client IPs that are not allowed by the app Settings().IPFilter lists.
+//
+// This middleware is registered by default for all routes.
+func ipFilter() *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       DefaultIPFilterMiddlewareId,
+		Priority: DefaultIPFilterMiddlewarePriority,
+		Func: func(e *core.RequestEvent) error {
+			config := e.App.Settings().IPFilter
+			if !config.Enabled {
+				return e.Next()
+			}
+
+			ip := e.RealIP()
+
+			if !config.IsAllowed(ip, defaultRateLimitLabels(e)...) {
+				return ErrIPNotAllowed
+			}
+
+			if len(config.SuperusersAllow) > 0 &&
+				isSuperuserAccess(e) &&
+				!core.MatchIPOrCIDR(config.SuperusersAllow, ip) {
+				return ErrIPNotAllowed
+			}
+
+			return e.Next()
+		},
+	}
+}
+
+// isSuperuserAccess loosely checks whether the request is superuser authenticated,
+// targets one of the superusers auth endpoints or the dashboard.
+func isSuperuserAccess(e *core.RequestEvent) bool {
+	if e.HasSuperuserAuth() {
+		return true
+	}
+
+	path := e.Request.URL.Path
+
+	if path == "/_" || strings.HasPrefix(path, "/_/") {
+		return true
+	}
+
+	// superusers auth routes
+	if collectionParam := e.Request.PathValue("collection"); collectionParam != "" {
+		collection, err := e.App.FindCachedCollectionByNameOrId(collectionParam)
+		return err == nil && collection.Name == core.CollectionNameSuperusers
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package apis
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+const (
+	DefaultMaintenanceMiddlewareId       = "pbMaintenance"
+	DefaultMaintenanceMiddlewarePriority = DefaultSecurityHeadersMiddlewarePriority + 1 // after the auth token load but before the rate limit
+)
+
+// maintenance defines the middleware that rejects the non-superuser
+// API requests while the app Settings().Maintenance mode is enabled.
+//
+// The superusers auth and the health check endpoints remain available
+// so that superusers could still sign in and manage the app.
+//
+// This middleware is registered by default for all routes.
+func maintenance() *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       DefaultMaintenanceMiddlewareId,
+		Priority: DefaultMaintenanceMiddlewarePriority,
+		Func: func(e *core.RequestEvent) error {
+			config := e.App.Settings().Maintenance
+
+			if !config.Enabled || !isMaintenanceRestricted(e) {
+				return e.Next()
+			}
+
+			if config.RetryAfter > 0 {
+				e.Response.Header().Set("Retry-After", strconv.Itoa(config.RetryAfter))
+			}
+
+			return router.NewApiError(http.StatusServiceUnavailable, config.ErrorMessage(), nil)
+		},
+	}
+}
+
+// isMaintenanceRestricted reports whether the request should be rejected in maintenance mode.
+func isMaintenanceRestricted(e *core.RequestEvent) bool {
+	path := e.Request.URL.Path
+
+	// only the API routes are restricted
+	if path != "/api" && !strings.HasPrefix(path, "/api/") {
+		return false
+	}
+
+	if e.HasSuperuserAuth() {
+		return false
+	}
+
+	if path == "/api/health" || strings.HasPrefix(path, "/api/health/") {
+		return false
+	}
+
+	// superusers auth routes
+	if collectionParam := e.Request.PathValue("collection"); collectionParam != "" {
+		collection, err := e.App.FindCachedCollectionByNameOrId(collectionParam)
+		if err == nil && collection.Name == core.CollectionNameSuperusers {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,414 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/cache"
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+const (
+	DefaultResponseCacheMiddlewareId = "pbResponseCache"
+)
+
+const (
+	responseCacheStoreKey       = "__pbResponseCache__"
+	responseCacheSettingsHookId = "__pbResponseCacheSettingsHook__"
+	responseCacheKeyPrefix      = "pb_response_cache:"
+
+	// responseCacheMaxBodySize is the max response body size that could be cached.
+	responseCacheMaxBodySize = 5 << 20
+
+	// responseCacheHeader is the response header that indicates
+	// whether the response was served from the cache ("HIT") or not ("MISS").
+	responseCacheHeader = "X-Cache"
+)
+
+// responseCacheHeadersRegex matches the "@request.headers.*" rule identifiers.
+var responseCacheHeadersRegex = regexp.MustCompile(`@request\.headers\.([\w\-]+)`)
+
+// responseCache defines a records list/view middleware that caches the
+// successful guest responses of the collections listed in the app
+// Settings().ResponseCache.Collections configuration.
+//
+// The cache key is bound to the collection API rule of the action and
+// to the request headers referenced in it. The collection cached entries
+// are invalidated automatically on record or collection change
+// (see bindResponseCacheEvents).
+//
+// Note that the cached responses are served without triggering the
+// related OnRecordsListRequest/OnRecordViewRequest and OnRecordEnrich hooks.
+func responseCache(action string) *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id: DefaultResponseCacheMiddlewareId,
+		Func: func(e *core.RequestEvent) error {
+			// only "public" responses are cached
+			//
+			// note: the expand responses are skipped because they depend on
+			// the data and API rules of other collections
+			if e.Auth != nil ||
+				e.Request.Method != http.MethodGet ||
+				e.Request.URL.Query().Has(expandQueryParam) {
+				return e.Next()
+			}
+
+			collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+			if err != nil {
+				return e.Next()
+			}
+
+			ttl, ok := e.App.Settings().ResponseCache.CollectionTTL(collection)
+			if !ok {
+				return e.Next()
+			}
+
+			rule := collection.ListRule
+			if action == "view" {
+				rule = collection.ViewRule
+			}
+			if rule == nil {
+				return e.Next() // superusers only
+			}
+
+			backend := responseCacheBackend(e.App)
+			if backend == nil {
+				return e.Next()
+			}
+
+			key, err := responseCacheKey(e, backend, collection, action, *rule)
+			if err != nil {
+				e.App.Logger().Debug("Failed to generate response cache key", slog.String("error", err.Error()))
+				return e.Next()
+			}
+
+			raw, err := backend.Get(e.Request.Context(), key)
+			if err == nil {
+				entry := responseCacheEntry{}
+				if err := json.Unmarshal(raw, &entry); err == nil {
+					return entry.write(e)
+				}
+			} else if !errors.Is(err, cache.ErrMiss) {
+				e.App.Logger().Debug("Failed to load cached response", slog.String("error", err.Error()))
+				return e.Next()
+			}
+
+			e.Response.Header().Set(responseCacheHeader, "MISS")
+
+			original := e.Response
+			rw := &responseCacheWriter{ResponseWriter: original}
+			e.Response = rw
+			defer func() {
+				e.Response = original
+			}()
+
+			if err := e.Next(); err != nil {
+				return err
+			}
+
+			if rw.status != http.StatusOK || rw.exceeded {
+				return nil
+			}
+
+			entry := responseCacheEntry{
+				Headers: map[string]string{},
+				Body:    rw.buf.Bytes(),
+			}
+			for _, h := range []string{"Content-Type", "ETag"} {
+				if v := original.Header().Get(h); v != "" {
+					entry.Headers[h] = v
+				}
+			}
+
+			raw, err = json.Marshal(entry)
+			if err == nil {
+				err = backend.Set(context.WithoutCancel(e.Request.Context()), key, raw, ttl)
+			}
+			if err != nil {
+				e.App.Logger().Debug("Failed to cache response", slog.String("error", err.Error()))
+			}
+
+			return nil
+		},
+	}
+}
+
+// responseCacheKey generates the cache key of the current request.
+func responseCacheKey(
+	e *core.RequestEvent,
+	backend cache.Backend,
+	collection *core.Collection,
+	action string,
+	rule string,
+) (string, error) {
+	version, err := backend.Get(e.Request.Context(), responseCacheVersionKey(collection.Id))
+	if err != nil && !errors.Is(err, cache.ErrMiss) {
+		return "", err
+	}
+
+	info, err := e.RequestInfo()
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(action))
+	h.Write([]byte{0})
+	h.Write([]byte(e.Request.URL.Path))
+	h.Write([]byte{0})
+	h.Write([]byte(e.Request.URL.Query().Encode()))
+	h.Write([]byte{0})
+	h.Write([]byte(rule))
+
+	// include the values of the rule referenced headers
+	headers := responseCacheHeadersRegex.FindAllStringSubmatch(rule, -1)
+	names := make([]string, 0, len(headers))
+	for _, match := range headers {
+		names = append(names, match[1])
+	}
+	slices.Sort(names)
+	for _, name := range slices.Compact(names) {
+		h.Write([]byte{0})
+		h.Write([]byte(name + "=" + info.Headers[name]))
+	}
+
+	return responseCacheKeyPrefix + collection.Id + ":" + string(version) + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func responseCacheVersionKey(collectionId string) string {
+	return responseCacheKeyPrefix + "v:" + collectionId
+}
+
+// responseCacheBackend returns the app response cache backend
+// (it is initialized lazily based on the app settings).
+func responseCacheBackend(app core.App) cache.Backend {
+	backend, _ := app.Store().GetOrSet(responseCacheStoreKey, func() any {
+		return initResponseCacheBackend(app)
+	}).(cache.Backend)
+
+	return backend
+}
+
+func initResponseCacheBackend(app core.App) cache.Backend {
+	app.OnSettingsReload().Bind(&hook.Handler[*core.SettingsReloadEvent]{
+		Id: responseCacheSettingsHookId,
+		Func: func(e *core.SettingsReloadEvent) error {
+			err := e.Next()
+			if err != nil {
+				return err
+			}
+
+			// reset
+			destroyResponseCacheBackend(e.App)
+
+			return nil
+		},
+	})
+
+	config := app.Settings().ResponseCache
+
+	if config.Backend == core.ResponseCacheBackendRedis {
+		backend, err := cache.NewRedis(config.RedisURL, config.RedisPassword)
+		if err != nil {
+			app.Logger().Warn("Failed to initialize the Redis response cache", slog.String("error", err.Error()))
+			return nil
+		}
+		return backend
+	}
+
+	return cache.NewMemory(config.MaxItems)
+}
+
+func destroyResponseCacheBackend(app core.App) {
+	app.OnSettingsReload().Unbind(responseCacheSettingsHookId)
+
+	if backend, ok := app.Store().Get(responseCacheStoreKey).(cache.Backend); ok {
+		if err := backend.Close(); err != nil {
+			app.Logger().Debug("Failed to close the response cache backend", slog.String("error", err.Error()))
+		}
+	}
+
+	app.Store().Remove(responseCacheStoreKey)
+}
+
+// InvalidateResponseCache invalidates the cached list and view responses
+// of the specified collection and of the cached collections that depends on it
+// (views, related collections and collections with rules that reference it).
+func InvalidateResponseCache(app core.App, collection *core.Collection) error {
+	config := app.Settings().ResponseCache
+	if !config.Enabled || len(config.Collections) == 0 || collection == nil {
+		return nil
+	}
+
+	backend := responseCacheBackend(app)
+	if backend == nil {
+		return nil
+	}
+
+	ids := []string{collection.Id}
+
+	for _, item := range config.Collections {
+		cached, err := app.FindCachedCollectionByNameOrId(item.Collection)
+		if err != nil || slices.Contains(ids, cached.Id) {
+			continue
+		}
+
+		if responseCacheDependsOn(cached, collection) {
+			ids = append(ids, cached.Id)
+		}
+	}
+
+	var errs []error
+	for _, id := range ids {
+		if _, err := backend.Incr(context.Background(), responseCacheVersionKey(id)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// responseCacheDependsOn reports whether the responses of the collection
+// could depend on the records of the target collection.
+func responseCacheDependsOn(collection *core.Collection, target *core.Collection) bool {
+	if collection.IsView() {
+		return true // the view query could reference any collection
+	}
+
+	for _, f := range collection.Fields {
+		if rel, ok := f.(*core.RelationField); ok && rel.CollectionId == target.Id {
+			return true
+		}
+	}
+
+	// back-relations
+	for _, f := range target.Fields {
+		if rel, ok := f.(*core.RelationField); ok && rel.CollectionId == collection.Id {
+			return true
+		}
+	}
+
+	for _, rule := range []*string{collection.ListRule, collection.ViewRule} {
+		if rule != nil && strings.Contains(*rule, "@collection."+target.Name+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func bindResponseCacheEvents(app core.App) {
+	invalidate := func(e *core.ModelEvent) error {
+		if !e.App.Settings().ResponseCache.Enabled {
+			return e.Next()
+		}
+
+		var collection *core.Collection
+		if c, ok := e.Model.(*core.Collection); ok {
+			collection = c
+		} else {
+			collection = realtimeResolveRecordCollection(e.App, e.Model)
+		}
+
+		if err := InvalidateResponseCache(e.App, collection); err != nil {
+			e.App.Logger().Warn(
+				"Failed to invalidate the collection response cache",
+				slog.String("model", e.Model.TableName()),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		return e.Next()
+	}
+
+	app.OnModelAfterCreateSuccess().Bind(&hook.Handler[*core.ModelEvent]{
+		Func:     invalidate,
+		Priority: -99,
+	})
+
+	app.OnModelAfterUpdateSuccess().Bind(&hook.Handler[*core.ModelEvent]{
+		Func:     invalidate,
+		Priority: -99,
+	})
+
+	app.OnModelAfterDeleteSuccess().Bind(&hook.Handler[*core.ModelEvent]{
+		Func:     invalidate,
+		Priority: -99,
+	})
+}
+
+// -------------------------------------------------------------------
+
+type responseCacheEntry struct {
+	Headers map[string]string `json:"h"`
+	Body    []byte            `json:"b"`
+}
+
+func (entry responseCacheEntry) write(e *core.RequestEvent) error {
+	for k, v := range entry.Headers {
+		e.Response.Header().Set(k, v)
+	}
+	e.Response.Header().Set(responseCacheHeader, "HIT")
+
+	etag := entry.Headers["ETag"]
+	ifNoneMatch := e.Request.Header.Get("If-None-Match")
+	if etag != "" && ifNoneMatch != "" && etagMatches(ifNoneMatch, etag, true) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.Blob(http.StatusOK, entry.Headers["Content-Type"], entry.Body)
+}
+
+// responseCacheWriter is a [http.ResponseWriter] that keeps
+// a copy of the written response body.
+type responseCacheWriter struct {
+	http.ResponseWriter
+
+	buf      bytes.Buffer
+	status   int
+	exceeded bool
+}
+
+func (w *responseCacheWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseCacheWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if !w.exceeded {
+		if w.buf.Len()+len(b) > responseCacheMaxBodySize {
+			w.exceeded = true
+			w.buf.Reset()
+		} else {
+			w.buf.Write(b)
+		}
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseCacheWriter) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying ResponseWritter instance (usually used by [http.ResponseController]).
+func (w *responseCacheWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,268 @@
+package apis
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+const (
+	DefaultTransformMiddlewareId       = "pbTransform"
+	DefaultTransformMiddlewarePriority = DefaultBodyLimitMiddlewarePriority + 1 // after the body limit so that the request body read is limited
+)
+
+// transformMaxResponseSize is the max JSON response size that could be transformed.
+//
+// Larger responses are sent as they are.
+const transformMaxResponseSize = 10 << 20
+
+// transform defines the middleware that triggers the app
+// OnRequestBodyTransform and OnResponseTransform hooks.
+//
+// This middleware is registered by default for all routes.
+func transform() *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       DefaultTransformMiddlewareId,
+		Priority: DefaultTransformMiddlewarePriority,
+		Func: func(e *core.RequestEvent) error {
+			if e.App.OnRequestBodyTransform().Length() > 0 {
+				if err := transformRequestBody(e); err != nil {
+					return err
+				}
+			}
+
+			if e.App.OnResponseTransform().Length() == 0 {
+				return e.Next()
+			}
+
+			original := e.Response
+			tw := &transformResponseWriter{ResponseWriter: original, isHead: e.Request.Method == http.MethodHead}
+			e.Response = tw
+			defer func() {
+				e.Response = original
+			}()
+
+			if err := e.Next(); err != nil {
+				if tw.buffering {
+					if tw.wroteBody {
+						tw.passthrough()
+						return err
+					}
+
+					// nothing was written yet (e.g. only status code) so that the router could write the error response
+					original.Header().Del("Content-Length")
+				}
+				return err
+			}
+
+			if !tw.buffering {
+				return nil
+			}
+
+			return transformResponse(e, original, tw)
+		},
+	}
+}
+
+// transformRequestBody decodes the JSON object request body,
+// triggers the OnRequestBodyTransform hook and replaces the request
+// body with its serialized modified state.
+func transformRequestBody(e *core.RequestEvent) error {
+	if e.Request.ContentLength == 0 ||
+		!strings.HasPrefix(e.Request.Header.Get("Content-Type"), "application/json") {
+		return nil
+	}
+
+	raw, err := io.ReadAll(e.Request.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ErrRequestEntityTooLarge
+		}
+		return e.BadRequestError("Failed to read the request body.", err)
+	}
+
+	if rr, ok := e.Request.Body.(router.Rereader); ok {
+		rr.Reread()
+	}
+
+	body := map[string]any{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil // not a JSON object - leave the body handling to the route handler
+	}
+
+	event := new(core.RequestBodyTransformEvent)
+	event.RequestEvent = e
+	event.Body = body
+
+	return e.App.OnRequestBodyTransform().Trigger(event, func(te *core.RequestBodyTransformEvent) error {
+		raw, err := json.Marshal(te.Body)
+		if err != nil {
+			return err
+		}
+
+		te.Request.Body = &router.RereadableReadCloser{ReadCloser: io.NopCloser(bytes.NewReader(raw))}
+		te.Request.ContentLength = int64(len(raw))
+		te.Request.Header.Set("Content-Length", strconv.Itoa(len(raw)))
+
+		return te.Next()
+	})
+}
+
+// transformResponse decodes the buffered JSON response payload,
+// triggers the OnResponseTransform hook and writes its serialized modified state.
+func transformResponse(e *core.RequestEvent, original http.ResponseWriter, tw *transformResponseWriter) error {
+	var data any
+
+	dec := json.NewDecoder(bytes.NewReader(tw.buf.Bytes()))
+	dec.UseNumber() // preserve the numbers precision
+	if err := dec.Decode(&data); err != nil {
+		tw.passthrough()
+		return nil
+	}
+
+	event := new(core.ResponseTransformEvent)
+	event.RequestEvent = e
+	event.Status = tw.status
+	event.Data = data
+
+	e.Response = original
+
+	return e.App.OnResponseTransform().Trigger(event, func(te *core.ResponseTransformEvent) error {
+		original.Header().Del("Content-Length")
+		original.WriteHeader(te.Status)
+
+		if tw.isHead {
+			return te.Next()
+		}
+
+		if err := json.NewEncoder(original).Encode(te.Data); err != nil {
+			return err
+		}
+
+		return te.Next()
+	})
+}
+
+// transformResponseWriter buffers the JSON responses and passes
+// through as they are all other (e.g. file or streamed) responses.
+type transformResponseWriter struct {
+	http.ResponseWriter
+
+	buf         bytes.Buffer
+	status      int
+	isHead      bool
+	wroteHeader bool
+	wroteBody   bool
+	buffering   bool
+}
+
+func (w *transformResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+
+	header := w.Header()
+
+	if status >= 200 &&
+		status != http.StatusNoContent &&
+		status != http.StatusPartialContent &&
+		status != http.StatusNotModified &&
+		header.Get("Content-Encoding") == "" &&
+		strings.HasPrefix(header.Get("Content-Type"), "application/json") {
+		w.buffering = true
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *transformResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.buffering {
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.wroteBody = true
+
+	if w.buf.Len()+len(b) > transformMaxResponseSize {
+		w.passthrough()
+		return w.ResponseWriter.Write(b)
+	}
+
+	return w.buf.Write(b)
+}
+
+// passthrough stops the buffering and writes the already buffered response.
+func (w *transformResponseWriter) passthrough() {
+	if !w.buffering {
+		return
+	}
+	w.buffering = false
+
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.buf.WriteTo(w.ResponseWriter)
+}
+
+// Flush implements [http.Flusher] and disables the response
+// buffering to allow streaming the response as it is.
+func (w *transformResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	w.passthrough()
+
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// ReadFrom implements [io.ReaderFrom] to allow the platform
+// specific fast-paths (e.g. sendfile) for the passed through responses.
+func (w *transformResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buffering {
+		// wrap to prevent infinite recursion
+		return io.Copy(struct{ io.Writer }{w}, r)
+	}
+
+	rw := w.ResponseWriter
+	for {
+		switch rf := rw.(type) {
+		case io.ReaderFrom:
+			return rf.ReadFrom(r)
+		case router.RWUnwrapper:
+			rw = rf.Unwrap()
+		default:
+			return io.Copy(w.ResponseWriter, r)
+		}
+	}
+}
+
+func (w *transformResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+func (w *transformResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,228 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestTransformMiddleware(t *testing.T) {
+	t.Parallel()
+
+	registerRoutes := func(e *core.ServeEvent) {
+		e.Router.POST("/my/echo", func(e *core.RequestEvent) error {
+			data := map[string]any{}
+			if err := e.BindBody(&data); err != nil {
+				return err
+			}
+			return e.JSON(http.StatusOK, data)
+		})
+
+		e.Router.GET("/my/json", func(e *core.RequestEvent) error {
+			return e.JSON(http.StatusOK, map[string]any{"id": "test", "secret": "123", "big": 9007199254740993})
+		})
+
+		e.Router.GET("/my/text", func(e *core.RequestEvent) error {
+			return e.String(http.StatusOK, `{"secret":"123"}`)
+		})
+
+		e.Router.GET("/my/stream", func(e *core.RequestEvent) error {
+			e.Response.Header().Set("Content-Type", "application/json")
+			e.Response.WriteHeader(http.StatusOK)
+			e.Response.Write([]byte(`{"secret":`))
+			e.Flush()
+			e.Response.Write([]byte(`"123"}`))
+			return nil
+		})
+	}
+
+	stripSecret := func(e *core.ResponseTransformEvent) error {
+		if data, ok := e.Data.(map[string]any); ok {
+			delete(data, "secret")
+			data["transformed"] = true
+		}
+		return e.Next()
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "no transform handlers",
+			Method: http.MethodGet,
+			URL:    "/my/json",
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"secret":"123"`, `"big":9007199254740993`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:   "request body transform",
+			Method: http.MethodPost,
+			URL:    "/my/echo",
+			Body:   strings.NewReader(`{"title":"test","role":"admin"}`),
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+
+				app.OnRequestBodyTransform("POST /my/echo").BindFunc(func(e *core.RequestBodyTransformEvent) error {
+					delete(e.Body, "role")
+					e.Body["computed"] = strings.ToUpper(e.Body["title"].(string))
+					return e.Next()
+				})
+			},
+			ExpectedStatus:     200,
+			ExpectedContent:    []string{`"title":"test"`, `"computed":"TEST"`},
+			NotExpectedContent: []string{`"role"`},
+			ExpectedEvents:     map[string]int{"*": 0},
+		},
+		{
+			Name:   "request body transform for different route",
+			Method: http.MethodPost,
+			URL:    "/my/echo",
+			Body:   strings.NewReader(`{"title":"test","role":"admin"}`),
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+
+				app.OnRequestBodyTransform("/my/other").BindFunc(func(e *core.RequestBodyTransformEvent) error {
+					delete(e.Body, "role")
+					return e.Next()
+				})
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"title":"test"`, `"role":"admin"`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:   "request body transform error",
+			Method: http.MethodPost,
+			URL:    "/my/echo",
+			Body:   strings.NewReader(`{"title":"test"}`),
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+
+				app.OnRequestBodyTransform().BindFunc(func(e *core.RequestBodyTransformEvent) error {
+					return e.BadRequestError("invalid title", nil)
+				})
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"message":"Invalid title."`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:   "response transform (path only tag)",
+			Method: http.MethodGet,
+			URL:    "/my/json",
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+
+				app.OnResponseTransform("/my/json").BindFunc(stripSecret)
+			},
+			ExpectedStatus:     200,
+			ExpectedContent:    []string{`"id":"test"`, `"transformed":true`, `"big":9007199254740993`},
+			NotExpectedContent: []string{`"secret"`},
+			ExpectedEvents:     map[string]int{"*": 0},
+		},
+		{
+			Name:   "response transform for different route",
+			Method: http.MethodGet,
+			URL:    "/my/json",
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+
+				app.OnResponseTransform("GET /my/other").BindFunc(stripSecret)
+			},
+			ExpectedStatus:     200,
+			ExpectedContent:    []string{`"secret":"123"`},
+			NotExpectedContent: []string{`"transformed"`},
+			ExpectedEvents:     map[string]int{"*": 0},
+		},
+		{
+			Name:   "response transform with custom status",
+			Method: http.MethodGet,
+			URL:    "/my/json",
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+
+				app.OnResponseTransform().BindFunc(func(e *core.ResponseTransformEvent) error {
+					e.Status = http.StatusAccepted
+					e.Data = []string{"a", "b"}
+					return e.Next()
+				})
+			},
+			ExpectedStatus:  202,
+			ExpectedContent: []string{`["a","b"]`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:   "response transform error",
+			Method: http.MethodGet,
+			URL:    "/my/json",
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+
+				app.OnResponseTransform().BindFunc(func(e *core.ResponseTransformEvent) error {
+					return errors.New("test")
+				})
+			},
+			ExpectedStatus:     400,
+			ExpectedContent:    []string{`"data":{}`},
+			NotExpectedContent: []string{`"secret"`},
+			ExpectedEvents:     map[string]int{"*": 0},
+		},
+		{
+			Name:   "non JSON response",
+			Method: http.MethodGet,
+			URL:    "/my/text",
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+
+				app.OnResponseTransform().BindFunc(stripSecret)
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`{"secret":"123"}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:   "streamed JSON response",
+			Method: http.MethodGet,
+			URL:    "/my/stream",
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				registerRoutes(e)
+
+				app.OnResponseTransform().BindFunc(stripSecret)
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`{"secret":"123"}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:   "API error response",
+			Method: http.MethodGet,
+			URL:    "/api/collections/missing/records",
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				app.OnResponseTransform().BindFunc(stripSecret)
+			},
+			ExpectedStatus:     404,
+			ExpectedContent:    []string{`"data":{}`},
+			NotExpectedContent: []string{`"transformed"`},
+			ExpectedEvents:     map[string]int{"*": 0},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
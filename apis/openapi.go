@@ -0,0 +1,598 @@
+package apis
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// bindOpenAPIApi registers the OpenAPI specification api endpoint.
+func bindOpenAPIApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	rg.GET("/openapi.json", openapiSpec).Bind(RequireSuperuserAuth())
+}
+
+func openapiSpec(e *core.RequestEvent) error {
+	spec, err := OpenAPISpec(e.App)
+	if err != nil {
+		return e.InternalServerError("Failed to generate the OpenAPI specification.", err)
+	}
+
+	return e.JSON(http.StatusOK, spec)
+}
+
+// OpenAPIVersion is the OpenAPI specification version of the generated document.
+const OpenAPIVersion = "3.0.3"
+
+// OpenAPISpec generates an OpenAPI 3 specification document from the
+// current app collections (records crud, auth and files endpoints).
+//
+// The operations security requirements are derived from the collection API rules:
+//   - nil (superusers only) rules and rules referencing "@request.auth.*" require an auth token
+//   - all other rules allow optionally an auth token
+//
+// The raw rule is also available in the "x-pocketbase-rule" operation extension.
+func OpenAPISpec(app core.App) (map[string]any, error) {
+	collections, err := app.FindAllCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]any{}
+
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"status":  map[string]any{"type": "integer"},
+				"message": map[string]any{"type": "string"},
+				"data":    map[string]any{"type": "object", "additionalProperties": true},
+			},
+		},
+	}
+
+	for _, c := range collections {
+		openapiCollectionSchemas(c, collections, schemas)
+		openapiCollectionPaths(c, paths)
+	}
+
+	paths["/api/files/token"] = map[string]any{
+		"post": map[string]any{
+			"tags":        []any{"Files"},
+			"operationId": "createFileToken",
+			"summary":     "Generate a short-lived file token for accessing protected files.",
+			"security":    openapiSecurity(true),
+			"responses": openapiResponses(map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"token": map[string]any{"type": "string"}},
+			}, "400"),
+		},
+	}
+
+	settings := app.Settings()
+
+	spec := map[string]any{
+		"openapi": OpenAPIVersion,
+		"info": map[string]any{
+			"title":   settings.Meta.AppName + " API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"authToken": map[string]any{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+	}
+
+	if settings.Meta.AppURL != "" {
+		spec["servers"] = []any{map[string]any{"url": settings.Meta.AppURL}}
+	}
+
+	return spec, nil
+}
+
+func openapiCollectionSchemas(c *core.Collection, collections []*core.Collection, schemas map[string]any) {
+	recordProps := map[string]any{
+		"collectionId":   map[string]any{"type": "string"},
+		"collectionName": map[string]any{"type": "string"},
+	}
+	bodyProps := map[string]any{}
+	bodyRequired := []any{}
+	expandProps := map[string]any{}
+
+	for _, f := range c.Fields {
+		name := f.GetName()
+
+		schema := openapiFieldSchema(f)
+
+		if !f.GetHidden() && f.Type() != core.FieldTypePassword {
+			recordProps[name] = schema
+		}
+
+		if rel, ok := f.(*core.RelationField); ok {
+			for _, target := range collections {
+				if target.Id != rel.CollectionId {
+					continue
+				}
+
+				ref := map[string]any{"$ref": "#/components/schemas/" + openapiSchemaName(target, "Record")}
+				if rel.IsMultiple() {
+					expandProps[name] = map[string]any{"type": "array", "items": ref}
+				} else {
+					expandProps[name] = ref
+				}
+			}
+		}
+
+		if c.IsView() || f.Type() == core.FieldTypeAutodate || (f.Type() == core.FieldTypeText && name == core.FieldNameTokenKey) {
+			continue
+		}
+
+		bodyProps[name] = schema
+		if f.Type() == core.FieldTypePassword {
+			bodyProps[name+"Confirm"] = schema
+		}
+
+		if openapiFieldIsRequired(f) && name != core.FieldNameId {
+			bodyRequired = append(bodyRequired, name)
+		}
+	}
+
+	if len(expandProps) > 0 {
+		recordProps["expand"] = map[string]any{
+			"type":       "object",
+			"properties": expandProps,
+		}
+	}
+
+	schemas[openapiSchemaName(c, "Record")] = map[string]any{
+		"type":       "object",
+		"properties": recordProps,
+	}
+
+	schemas[openapiSchemaName(c, "ListResult")] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"page":       map[string]any{"type": "integer"},
+			"perPage":    map[string]any{"type": "integer"},
+			"totalItems": map[string]any{"type": "integer"},
+			"totalPages": map[string]any{"type": "integer"},
+			"nextCursor": map[string]any{"type": "string"},
+			"items": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"$ref": "#/components/schemas/" + openapiSchemaName(c, "Record")},
+			},
+		},
+	}
+
+	if !c.IsView() {
+		body := map[string]any{
+			"type":       "object",
+			"properties": bodyProps,
+		}
+		if len(bodyRequired) > 0 {
+			body["required"] = bodyRequired
+		}
+		schemas[openapiSchemaName(c, "Body")] = body
+	}
+
+	if c.IsAuth() {
+		schemas[openapiSchemaName(c, "AuthResponse")] = map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"token":  map[string]any{"type": "string"},
+				"record": map[string]any{"$ref": "#/components/schemas/" + openapiSchemaName(c, "Record")},
+				"meta":   map[string]any{"type": "object", "additionalProperties": true},
+			},
+		}
+	}
+}
+
+func openapiCollectionPaths(c *core.Collection, paths map[string]any) {
+	tags := []any{c.Name}
+	basePath := "/api/collections/" + c.Name
+
+	recordRef := map[string]any{"$ref": "#/components/schemas/" + openapiSchemaName(c, "Record")}
+	bodyRef := map[string]any{"$ref": "#/components/schemas/" + openapiSchemaName(c, "Body")}
+	idParam := map[string]any{"name": "id", "in": "path", "required": true, "schema": map[string]any{"type": "string"}}
+
+	recordsPath := map[string]any{
+		"get": openapiRuleOperation(c.ListRule, map[string]any{
+			"tags":        tags,
+			"operationId": "list" + inflector.Camelize(c.Name),
+			"summary":     "Returns a paginated " + c.Name + " records list.",
+			"parameters": append(
+				openapiQueryParams(
+					[2]string{"page", "integer"},
+					[2]string{"perPage", "integer"},
+					[2]string{"sort", "string"},
+					[2]string{"filter", "string"},
+					[2]string{"skipTotal", "boolean"},
+					[2]string{"pagination", "string"},
+					[2]string{"cursor", "string"},
+				),
+				openapiQueryParams([2]string{"expand", "string"}, [2]string{"fields", "string"})...,
+			),
+			"responses": openapiResponses(map[string]any{"$ref": "#/components/schemas/" + openapiSchemaName(c, "ListResult")}, "400", "403"),
+		}),
+	}
+
+	recordPath := map[string]any{
+		"parameters": []any{idParam},
+		"get": openapiRuleOperation(c.ViewRule, map[string]any{
+			"tags":        tags,
+			"operationId": "view" + inflector.Camelize(c.Name),
+			"summary":     "Returns a single " + c.Name + " record.",
+			"parameters":  openapiQueryParams([2]string{"expand", "string"}, [2]string{"fields", "string"}),
+			"responses":   openapiResponses(recordRef, "403", "404"),
+		}),
+	}
+
+	if !c.IsView() {
+		requestBody := map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json":    map[string]any{"schema": bodyRef},
+				"multipart/form-data": map[string]any{"schema": bodyRef},
+			},
+		}
+
+		recordsPath["post"] = openapiRuleOperation(c.CreateRule, map[string]any{
+			"tags":        tags,
+			"operationId": "create" + inflector.Camelize(c.Name),
+			"summary":     "Creates a new " + c.Name + " record.",
+			"parameters":  openapiQueryParams([2]string{"expand", "string"}, [2]string{"fields", "string"}),
+			"requestBody": requestBody,
+			"responses":   openapiResponses(recordRef, "400", "403"),
+		})
+
+		recordPath["patch"] = openapiRuleOperation(c.UpdateRule, map[string]any{
+			"tags":        tags,
+			"operationId": "update" + inflector.Camelize(c.Name),
+			"summary":     "Updates an existing " + c.Name + " record.",
+			"parameters":  openapiQueryParams([2]string{"expand", "string"}, [2]string{"fields", "string"}),
+			"requestBody": requestBody,
+			"responses":   openapiResponses(recordRef, "400", "403", "404"),
+		})
+
+		recordPath["delete"] = openapiRuleOperation(c.DeleteRule, map[string]any{
+			"tags":        tags,
+			"operationId": "delete" + inflector.Camelize(c.Name),
+			"summary":     "Deletes a single " + c.Name + " record.",
+			"responses": map[string]any{
+				"204": map[string]any{"description": "No Content"},
+				"400": openapiErrorResponse(),
+				"403": openapiErrorResponse(),
+				"404": openapiErrorResponse(),
+			},
+		})
+	}
+
+	paths[basePath+"/records"] = recordsPath
+	paths[basePath+"/records/{id}"] = recordPath
+
+	if c.IsAuth() {
+		openapiAuthPaths(c, paths)
+	}
+
+	if slices.ContainsFunc(c.Fields, func(f core.Field) bool { return f.Type() == core.FieldTypeFile }) {
+		paths["/api/files/"+c.Name+"/{recordId}/{filename}"] = map[string]any{
+			"get": map[string]any{
+				"tags":        tags,
+				"operationId": "download" + inflector.Camelize(c.Name) + "File",
+				"summary":     "Downloads a single " + c.Name + " record file.",
+				"parameters": append(
+					[]any{
+						map[string]any{"name": "recordId", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+						map[string]any{"name": "filename", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+					},
+					openapiQueryParams([2]string{"thumb", "string"}, [2]string{"token", "string"}, [2]string{"download", "boolean"})...,
+				),
+				"x-pocketbase-rule": c.ViewRule,
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": "The file content.",
+						"content": map[string]any{
+							"application/octet-stream": map[string]any{"schema": map[string]any{"type": "string", "format": "binary"}},
+						},
+					},
+					"400": openapiErrorResponse(),
+					"404": openapiErrorResponse(),
+				},
+			},
+		}
+	}
+}
+
+func openapiAuthPaths(c *core.Collection, paths map[string]any) {
+	tags := []any{c.Name}
+	basePath := "/api/collections/" + c.Name
+	name := inflector.Camelize(c.Name)
+	authResponseRef := map[string]any{"$ref": "#/components/schemas/" + openapiSchemaName(c, "AuthResponse")}
+	noContent := map[string]any{
+		"204": map[string]any{"description": "No Content"},
+		"400": openapiErrorResponse(),
+	}
+
+	bodyOf := func(required []string, props ...string) map[string]any {
+		properties := make(map[string]any, len(props))
+		for _, p := range props {
+			properties[p] = map[string]any{"type": "string"}
+		}
+
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			req := make([]any, len(required))
+			for i, r := range required {
+				req[i] = r
+			}
+			schema["required"] = req
+		}
+
+		return map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": schema},
+			},
+		}
+	}
+
+	operation := func(id string, summary string, requiresAuth bool, body map[string]any, responses map[string]any) map[string]any {
+		op := map[string]any{
+			"tags":        tags,
+			"operationId": id + name,
+			"summary":     summary,
+			"security":    openapiSecurity(requiresAuth),
+			"responses":   responses,
+		}
+		if body != nil {
+			op["requestBody"] = body
+		}
+		return map[string]any{"post": op}
+	}
+
+	paths[basePath+"/auth-methods"] = map[string]any{
+		"get": map[string]any{
+			"tags":        tags,
+			"operationId": "listAuthMethods" + name,
+			"summary":     "Returns the public list with the allowed " + c.Name + " authentication methods.",
+			"security":    openapiSecurity(false),
+			"responses":   openapiResponses(map[string]any{"type": "object", "additionalProperties": true}),
+		},
+	}
+
+	paths[basePath+"/auth-with-password"] = operation(
+		"authWithPassword", "Authenticates a "+c.Name+" record with a combination of identity and password.", false,
+		bodyOf([]string{"identity", "password"}, "identity", "password", "identityField"),
+		openapiResponses(authResponseRef, "400"),
+	)
+
+	paths[basePath+"/auth-with-oauth2"] = operation(
+		"authWithOAuth2", "Authenticates a "+c.Name+" record with an OAuth2 provider.", false,
+		bodyOf([]string{"provider", "code", "redirectURL"}, "provider", "code", "codeVerifier", "redirectURL"),
+		openapiResponses(authResponseRef, "400"),
+	)
+
+	paths[basePath+"/request-otp"] = operation(
+		"requestOTP", "Sends a "+c.Name+" record one-time password.", false,
+		bodyOf(nil, "email", "phone"),
+		openapiResponses(map[string]any{"type": "object", "properties": map[string]any{"otpId": map[string]any{"type": "string"}}}, "400"),
+	)
+
+	paths[basePath+"/auth-with-otp"] = operation(
+		"authWithOTP", "Authenticates a "+c.Name+" record with a one-time password.", false,
+		bodyOf([]string{"otpId", "password"}, "otpId", "password"),
+		openapiResponses(authResponseRef, "400"),
+	)
+
+	paths[basePath+"/auth-refresh"] = operation(
+		"authRefresh", "Returns a new auth response (token and record data) for an already authenticated "+c.Name+" record.", true,
+		nil,
+		openapiResponses(authResponseRef, "401", "403", "404"),
+	)
+
+	paths[basePath+"/request-password-reset"] = operation(
+		"requestPasswordReset", "Sends a "+c.Name+" record password reset email.", false,
+		bodyOf([]string{"email"}, "email"),
+		noContent,
+	)
+
+	paths[basePath+"/confirm-password-reset"] = operation(
+		"confirmPasswordReset", "Confirms a "+c.Name+" record password reset request.", false,
+		bodyOf([]string{"token", "password", "passwordConfirm"}, "token", "password", "passwordConfirm"),
+		noContent,
+	)
+
+	paths[basePath+"/request-verification"] = operation(
+		"requestVerification", "Sends a "+c.Name+" record verification email.", false,
+		bodyOf([]string{"email"}, "email"),
+		noContent,
+	)
+
+	paths[basePath+"/confirm-verification"] = operation(
+		"confirmVerification", "Confirms a "+c.Name+" record email verification request.", false,
+		bodyOf([]string{"token"}, "token"),
+		noContent,
+	)
+
+	paths[basePath+"/request-email-change"] = operation(
+		"requestEmailChange", "Sends a "+c.Name+" record email change request.", true,
+		bodyOf([]string{"newEmail"}, "newEmail"),
+		noContent,
+	)
+
+	paths[basePath+"/confirm-email-change"] = operation(
+		"confirmEmailChange", "Confirms a "+c.Name+" record email change request.", false,
+		bodyOf([]string{"token", "password"}, "token", "password"),
+		noContent,
+	)
+}
+
+// openapiRuleOperation sets the operation security requirements and rule extension.
+func openapiRuleOperation(rule *string, operation map[string]any) map[string]any {
+	operation["x-pocketbase-rule"] = rule
+
+	if rule == nil {
+		operation["security"] = openapiSecurity(true)
+		operation["description"] = "Only superusers can perform this action."
+		return operation
+	}
+
+	operation["security"] = openapiSecurity(strings.Contains(*rule, "@request.auth."))
+
+	return operation
+}
+
+// openapiSecurity returns a required or optional bearer token security requirement.
+func openapiSecurity(required bool) []any {
+	token := map[string]any{"authToken": []any{}}
+
+	if required {
+		return []any{token}
+	}
+
+	return []any{map[string]any{}, token}
+}
+
+func openapiQueryParams(params ...[2]string) []any {
+	result := make([]any, len(params))
+
+	for i, p := range params {
+		result[i] = map[string]any{
+			"name":   p[0],
+			"in":     "query",
+			"schema": map[string]any{"type": p[1]},
+		}
+	}
+
+	return result
+}
+
+func openapiResponses(successSchema map[string]any, errorCodes ...string) map[string]any {
+	result := map[string]any{
+		"200": map[string]any{
+			"description": "OK",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": successSchema},
+			},
+		},
+	}
+
+	for _, code := range errorCodes {
+		result[code] = openapiErrorResponse()
+	}
+
+	return result
+}
+
+func openapiErrorResponse() map[string]any {
+	return map[string]any{
+		"description": "Error",
+		"content": map[string]any{
+			"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/Error"},
+			},
+		},
+	}
+}
+
+func openapiFieldSchema(f core.Field) map[string]any {
+	var schema map[string]any
+
+	switch field := f.(type) {
+	case *core.BoolField:
+		schema = map[string]any{"type": "boolean"}
+	case *core.NumberField:
+		if field.OnlyInt {
+			schema = map[string]any{"type": "integer"}
+		} else {
+			schema = map[string]any{"type": "number"}
+		}
+	case *core.EmailField:
+		schema = map[string]any{"type": "string", "format": "email"}
+	case *core.URLField:
+		schema = map[string]any{"type": "string", "format": "uri"}
+	case *core.SelectField:
+		values := make([]any, 0, len(field.Values)+1)
+		for _, v := range field.Values {
+			values = append(values, v)
+		}
+		if !field.Required && !field.IsMultiple() {
+			values = append(values, "")
+		}
+		schema = map[string]any{"type": "string", "enum": values}
+	case *core.FileField:
+		schema = map[string]any{"type": "string", "description": "The stored file name (use multipart/form-data for uploads)."}
+	case *core.JSONField:
+		schema = map[string]any{"nullable": true}
+	case *core.GeoPointField:
+		schema = map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"lon": map[string]any{"type": "number"},
+				"lat": map[string]any{"type": "number"},
+			},
+		}
+	case *core.PasswordField:
+		schema = map[string]any{"type": "string", "writeOnly": true}
+	case *core.DateField, *core.AutodateField:
+		schema = map[string]any{"type": "string", "description": "Datetime string in the format \"Y-m-d H:i:s.uZ\"."}
+	case *core.TextField, *core.EditorField, *core.RelationField:
+		schema = map[string]any{"type": "string"}
+	default:
+		schema = map[string]any{}
+	}
+
+	if mv, ok := f.(core.MultiValuer); ok && mv.IsMultiple() {
+		schema = map[string]any{"type": "array", "items": schema}
+	}
+
+	return schema
+}
+
+func openapiFieldIsRequired(f core.Field) bool {
+	switch field := f.(type) {
+	case *core.TextField:
+		return field.Required && field.AutogeneratePattern == ""
+	case *core.NumberField:
+		return field.Required
+	case *core.BoolField:
+		return field.Required
+	case *core.EmailField:
+		return field.Required
+	case *core.URLField:
+		return field.Required
+	case *core.EditorField:
+		return field.Required
+	case *core.DateField:
+		return field.Required
+	case *core.SelectField:
+		return field.Required
+	case *core.FileField:
+		return field.Required
+	case *core.RelationField:
+		return field.Required
+	case *core.JSONField:
+		return field.Required
+	case *core.GeoPointField:
+		return field.Required
+	case *core.PasswordField:
+		return field.Required
+	default:
+		return false
+	}
+}
+
+// openapiSchemaName returns the components schema name of the collection.
+func openapiSchemaName(c *core.Collection, suffix string) string {
+	return inflector.Camelize(c.Name) + suffix
+}
@@ -0,0 +1,66 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// bindPluginsApi registers the plugins api endpoint.
+func bindPluginsApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	subGroup := rg.Group("/plugins").Bind(RequireSuperuserAuth())
+	subGroup.GET("", pluginsList)
+}
+
+type pluginInfo struct {
+	Settings   map[string]any `json:"settings"`
+	Name       string         `json:"name"`
+	CronJobs   []string       `json:"cronJobs"`
+	Enabled    bool           `json:"enabled"`
+	Migrations bool           `json:"migrations"`
+	Routes     bool           `json:"routes"`
+}
+
+func pluginsList(e *core.RequestEvent) error {
+	plugins := e.App.Plugins()
+
+	sensitive := map[string][]string{}
+	for _, ext := range e.App.SettingsExtensions() {
+		sensitive[ext.Name] = ext.Sensitive
+	}
+
+	result := make([]*pluginInfo, len(plugins))
+
+	for i, p := range plugins {
+		info := &pluginInfo{
+			Name:     p.Name(),
+			Enabled:  e.App.IsPluginEnabled(p.Name()),
+			Settings: e.App.PluginSettings(p.Name()),
+			CronJobs: []string{},
+		}
+
+		// similar to the settings API, exclude the sensitive keys
+		for _, key := range sensitive[p.Name()] {
+			delete(info.Settings, key)
+		}
+
+		if mp, ok := p.(core.PluginWithMigrations); ok {
+			info.Migrations = len(mp.Migrations()) > 0
+		}
+
+		if _, ok := p.(core.PluginWithRoutes); ok {
+			info.Routes = true
+		}
+
+		if cp, ok := p.(core.PluginWithCronJobs); ok {
+			for _, job := range cp.CronJobs() {
+				info.CronJobs = append(info.CronJobs, p.Name()+":"+job.Id)
+			}
+		}
+
+		result[i] = info
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
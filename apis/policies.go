@@ -0,0 +1,91 @@
+package apis
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// bindPoliciesApi registers the policy documents api endpoints.
+func bindPoliciesApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/policies")
+	sub.GET("/{name}", policyViewLatest)
+	sub.POST("", policyPublish).Bind(RequireSuperuserAuth())
+}
+
+// policyViewLatest returns the latest version of the path policy document.
+func policyViewLatest(e *core.RequestEvent) error {
+	policy, err := e.App.FindLatestPolicy(e.Request.PathValue("name"))
+	if err != nil {
+		return e.NotFoundError("", err)
+	}
+
+	return e.JSON(http.StatusOK, policy)
+}
+
+// policyPublish publishes a new version of the submitted policy document.
+func policyPublish(e *core.RequestEvent) error {
+	form := &policyPublishForm{}
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	policy := &core.Policy{
+		Name:    form.Name,
+		Title:   form.Title,
+		Content: form.Content,
+		URL:     form.URL,
+	}
+
+	if err := e.App.PublishPolicy(policy); err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to publish policy.", err))
+	}
+
+	return e.JSON(http.StatusOK, policy)
+}
+
+// recordAcceptPolicy stores the current authenticated record acceptance of the submitted policy version.
+func recordAcceptPolicy(e *core.RequestEvent) error {
+	form := &acceptPolicyForm{}
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+	if err := form.validate(); err != nil {
+		return e.BadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	acceptance, err := e.App.AcceptPolicy(e.Auth, form.Policy, form.Version, e.RealIP())
+	if err != nil {
+		return e.BadRequestError("An error occurred while validating the submitted data.", validation.Errors{
+			"policy": validation.NewError("validation_missing_policy", "Missing or invalid policy version."),
+		})
+	}
+
+	return e.JSON(http.StatusOK, acceptance)
+}
+
+// -------------------------------------------------------------------
+
+type policyPublishForm struct {
+	Name    string `form:"name" json:"name"`
+	Title   string `form:"title" json:"title"`
+	Content string `form:"content" json:"content"`
+	URL     string `form:"url" json:"url"`
+}
+
+type acceptPolicyForm struct {
+	// Policy is the name of the policy document to accept.
+	Policy string `form:"policy" json:"policy"`
+
+	// Version is the policy version to accept (if not set, the latest one).
+	Version int `form:"version" json:"version"`
+}
+
+func (form *acceptPolicyForm) validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Policy, validation.Required),
+		validation.Field(&form.Version, validation.Min(0)),
+	)
+}
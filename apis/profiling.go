@@ -0,0 +1,40 @@
+package apis
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// bindProfilingApi registers the runtime profiling api endpoints
+// (the net/http/pprof handlers behind a superuser auth and the
+// app.Settings().Profiling.Enabled toggle).
+func bindProfilingApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/debug/pprof").
+		Bind(RequireSuperuserAuth()).
+		BindFunc(requireProfilingEnabled)
+	sub.GET("/{$}", WrapStdHandler(http.HandlerFunc(pprof.Index)))
+	sub.GET("/cmdline", WrapStdHandler(http.HandlerFunc(pprof.Cmdline)))
+	sub.GET("/profile", WrapStdHandler(http.HandlerFunc(pprof.Profile)))
+	sub.GET("/symbol", WrapStdHandler(http.HandlerFunc(pprof.Symbol)))
+	sub.POST("/symbol", WrapStdHandler(http.HandlerFunc(pprof.Symbol)))
+	sub.GET("/trace", WrapStdHandler(http.HandlerFunc(pprof.Trace)))
+	sub.GET("/{name}", profilingNamedProfile)
+}
+
+func requireProfilingEnabled(e *core.RequestEvent) error {
+	if !e.App.Settings().Profiling.Enabled {
+		return e.NotFoundError("", nil)
+	}
+
+	return e.Next()
+}
+
+// profilingNamedProfile serves the runtime/pprof named profiles (heap, goroutine, allocs, etc.).
+func profilingNamedProfile(e *core.RequestEvent) error {
+	pprof.Handler(e.Request.PathValue("name")).ServeHTTP(e.Response, e.Request)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package apis
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/push"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// bindPushApi registers the push notifications api endpoints.
+//
+// Note that the registered devices could be listed and deleted by
+// their owners with the regular records api of the "_pushDevices" collection.
+func bindPushApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/push")
+	sub.GET("/vapid-key", pushVAPIDKey)
+	sub.POST("/devices", pushRegisterDevice).Bind(RequireAuth())
+}
+
+func pushVAPIDKey(e *core.RequestEvent) error {
+	settings := e.App.Settings().Push.WebPush
+	if !settings.Enabled {
+		return e.NotFoundError("WebPush is not enabled.", nil)
+	}
+
+	return e.JSON(http.StatusOK, map[string]string{"publicKey": settings.PublicKey})
+}
+
+func pushRegisterDevice(e *core.RequestEvent) error {
+	form := &pushDeviceForm{}
+	if err := e.BindBody(form); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while loading the submitted data.", err))
+	}
+	if err := form.validate(); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while validating the submitted data.", err))
+	}
+
+	// reuse the existing device (if any) to allow transferring
+	// the token to another auth record (eg. on user switch)
+	device, err := e.App.FindPushDeviceByToken(form.Platform, form.Token)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return e.InternalServerError("", err)
+		}
+
+		device = core.NewPushDevice(e.App)
+		device.SetPlatform(form.Platform)
+		device.SetToken(form.Token)
+	}
+
+	device.SetCollectionRef(e.Auth.Collection().Id)
+	device.SetRecordRef(e.Auth.Id)
+	device.SetP256dh(form.P256dh)
+	device.SetAuth(form.Auth)
+
+	if err := e.App.Save(device); err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to register the push device.", err))
+	}
+
+	return e.JSON(http.StatusOK, device)
+}
+
+// -------------------------------------------------------------------
+
+type pushDeviceForm struct {
+	Platform string `form:"platform" json:"platform"`
+	Token    string `form:"token" json:"token"`
+
+	// WebPush subscription keys
+	P256dh string `form:"p256dh" json:"p256dh"`
+	Auth   string `form:"auth" json:"auth"`
+}
+
+func (form pushDeviceForm) validate() error {
+	isWebPush := form.Platform == push.PlatformWebPush
+
+	return validation.ValidateStruct(&form,
+		validation.Field(
+			&form.Platform,
+			validation.Required,
+			validation.In(push.PlatformFCM, push.PlatformAPNs, push.PlatformWebPush),
+		),
+		validation.Field(
+			&form.Token,
+			validation.Required,
+			validation.Length(1, 2000),
+			validation.When(isWebPush, validation.By(checkWebPushEndpoint)),
+		),
+		validation.Field(&form.P256dh, validation.When(isWebPush, validation.Required), validation.Length(0, 255)),
+		validation.Field(&form.Auth, validation.When(isWebPush, validation.Required), validation.Length(0, 255)),
+	)
+}
+
+// checkWebPushEndpoint rejects non-https and non-public host WebPush endpoints
+// (the server sends requests to the endpoint so it shouldn't point to internal services).
+func checkWebPushEndpoint(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if err := push.ValidateWebPushEndpoint(v); err != nil {
+		return validation.NewError("validation_invalid_webpush_endpoint", err.Error())
+	}
+
+	return nil
+}
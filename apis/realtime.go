@@ -268,6 +268,55 @@ func realtimeUnsetClientsAuthState(app core.App, authModel core.Model) error {
 }
 
 func bindRealtimeEvents(app core.App) {
+	// notify the record subscribers for the moderation state changes
+	app.OnRecordModerate().Bind(&hook.Handler[*core.RecordModerateEvent]{
+		Func: func(e *core.RecordModerateEvent) error {
+			if err := e.Next(); err != nil {
+				return err
+			}
+
+			record := e.Record.Fresh()
+
+			return e.App.AfterCommit(func(txApp core.App) error {
+				err := realtimeBroadcastRecord(txApp, RealtimeActionModerate, record, false)
+				if err != nil {
+					txApp.Logger().Debug(
+						"Failed to broadcast record moderation",
+						slog.String("id", record.Id),
+						slog.String("collectionName", record.Collection().Name),
+						slog.String("error", err.Error()),
+					)
+				}
+
+				return nil
+			})
+		},
+		Priority: -99,
+	})
+
+	// notify the record subscribers for the reached publishing boundaries
+	app.OnRecordPublish().Bind(&hook.Handler[*core.RecordPublishEvent]{
+		Func: func(e *core.RecordPublishEvent) error {
+			if err := e.Next(); err != nil {
+				return err
+			}
+
+			err := realtimeBroadcastRecord(e.App, e.Action, e.Record, false)
+			if err != nil {
+				e.App.Logger().Debug(
+					"Failed to broadcast record publishing",
+					slog.String("id", e.Record.Id),
+					slog.String("collectionName", e.Record.Collection().Name),
+					slog.String("action", e.Action),
+					slog.String("error", err.Error()),
+				)
+			}
+
+			return nil
+		},
+		Priority: -99,
+	})
+
 	// update the clients that has auth record association
 	app.OnModelAfterUpdateSuccess().Bind(&hook.Handler[*core.ModelEvent]{
 		Func: func(e *core.ModelEvent) error {
@@ -508,6 +557,14 @@ func realtimeBroadcastRecord(app core.App, action string, record *core.Record, d
 
 	dryCacheKey := getDryCacheKey(action, record)
 
+	// records outside of their publishing window are visible only to superusers
+	// (except for the "unpublish" message itself)
+	isUnpublished := action != core.RecordPublishActionUnpublish && !record.IsPublished()
+
+	// expired records are visible only to superusers
+	// (except for the "delete" message of the purged records)
+	isExpired := action != "delete" && record.IsExpired()
+
 	group := new(errgroup.Group)
 
 	accessCheckApp := app
@@ -540,6 +597,10 @@ func realtimeBroadcastRecord(app core.App, action string, record *core.Record, d
 							Auth:    clientAuth,
 						}
 
+						if (isUnpublished || isExpired) && !requestInfo.HasSuperuserAuth() {
+							continue
+						}
+
 						if !realtimeCanAccessRecord(accessCheckApp, record, requestInfo, rule) {
 							continue
 						}
@@ -738,6 +799,12 @@ func realtimeCanAccessRecord(
 	requestInfo *core.RequestInfo,
 	accessRule *string,
 ) bool {
+	// check the collection API restrictions
+	// ---
+	if record.Collection().API.Disabled && !requestInfo.HasSuperuserAuth() {
+		return false
+	}
+
 	// check the access rule
 	// ---
 	if ok, _ := app.CanAccessRecord(record, requestInfo, accessRule); !ok {
@@ -17,7 +17,7 @@ func bindRecordAuthApi(app core.App, rg *router.RouterGroup[*core.RequestEvent])
 		SkipSuccessActivityLog(), // skip success log as it could contain sensitive information in the url
 	)
 
-	sub := rg.Group("/collections/{collection}")
+	sub := rg.Group("/collections/{collection}").Bind(collectionPathAPIAccess(""))
 
 	sub.GET("/auth-methods", recordAuthMethods).Bind(
 		collectionPathRateLimit("", "listAuthMethods"),
@@ -66,6 +66,34 @@ func bindRecordAuthApi(app core.App, rg *router.RouterGroup[*core.RequestEvent])
 	)
 
 	sub.POST("/impersonate/{id}", recordAuthImpersonate).Bind(RequireSuperuserAuth())
+
+	sub.GET("/export-data", recordAuthExportData).Bind(
+		collectionPathRateLimit("", "exportData"),
+		RequireSameCollectionContextAuth(""),
+	)
+	sub.POST("/erase-data", recordAuthEraseData).Bind(
+		collectionPathRateLimit("", "eraseData"),
+		RequireSameCollectionContextAuth(""),
+	)
+
+	sub.POST("/accept-policy", recordAcceptPolicy).Bind(
+		collectionPathRateLimit("", "acceptPolicy"),
+		RequireSameCollectionContextAuth(""),
+	)
+
+	sub.POST("/accept-invite", recordAcceptInvite).Bind(
+		collectionPathRateLimit("", "acceptInvite"),
+	)
+
+	invites := sub.Group("/invites").Bind(RequireSuperuserAuth())
+	invites.GET("", recordInvitesList)
+	invites.POST("", recordInviteCreate)
+	invites.GET("/{id}", recordInviteView)
+	invites.POST("/{id}/resend", recordInviteResend)
+	invites.DELETE("/{id}", recordInviteDelete)
+
+	sub.GET("/duplicates", recordAuthDuplicates).Bind(RequireSuperuserAuth())
+	sub.POST("/merge/{id}", recordAuthMerge).Bind(RequireSuperuserAuth())
 }
 
 func findAuthCollection(e *core.RequestEvent) (*core.Collection, error) {
@@ -0,0 +1,86 @@
+package apis
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// recordAuthDuplicates returns the groups of likely duplicated records
+// of the specified auth collection.
+func recordAuthDuplicates(e *core.RequestEvent) error {
+	collection, err := findAuthCollection(e)
+	if err != nil {
+		return err
+	}
+
+	groups, err := e.App.FindAuthRecordDuplicates(collection)
+	if err != nil {
+		return e.BadRequestError("Failed to find duplicated records.", err)
+	}
+
+	for _, group := range groups {
+		if err := EnrichRecords(e, group.Records); err != nil {
+			return firstApiError(err, e.InternalServerError("Failed to enrich records", err))
+		}
+	}
+
+	return e.JSON(http.StatusOK, groups)
+}
+
+// recordAuthMerge merges the submitted source auth records into the path one.
+func recordAuthMerge(e *core.RequestEvent) error {
+	collection, err := findAuthCollection(e)
+	if err != nil {
+		return err
+	}
+
+	target, err := e.App.FindRecordById(collection, e.Request.PathValue("id"))
+	if err != nil {
+		return e.NotFoundError("", err)
+	}
+
+	form := &mergeForm{}
+	if err = e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+	if err = form.validate(target.Id); err != nil {
+		return e.BadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	sources, err := e.App.FindRecordsByIds(collection, form.Sources)
+	if err != nil || len(sources) != len(form.Sources) {
+		return e.BadRequestError("An error occurred while validating the submitted data.", validation.Errors{
+			"sources": validation.NewError("validation_missing_merge_sources", "Missing or invalid source records."),
+		})
+	}
+
+	if err = e.App.MergeAuthRecordsWithContext(e.Request.Context(), target, sources...); err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to merge records.", err))
+	}
+
+	err = EnrichRecord(e, target)
+	if err != nil {
+		return firstApiError(err, e.InternalServerError("Failed to enrich record", err))
+	}
+
+	return e.JSON(http.StatusOK, target)
+}
+
+// -------------------------------------------------------------------
+
+type mergeForm struct {
+	// Sources is the list of the auth record ids to merge into the target.
+	Sources []string `form:"sources" json:"sources"`
+}
+
+func (form *mergeForm) validate(targetId string) error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Sources,
+			validation.Required,
+			validation.Each(validation.Required, validation.NotIn(targetId).Error("The target record cannot be also a source.")),
+		),
+	)
+}
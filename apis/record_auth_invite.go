@@ -0,0 +1,217 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/core/validators"
+	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// recordAcceptInvite accepts the submitted invite token and returns
+// an auth response for the invited (new or existing) auth record.
+func recordAcceptInvite(e *core.RequestEvent) error {
+	collection, err := findAuthCollection(e)
+	if err != nil {
+		return err
+	}
+
+	if !collection.Invites.Enabled {
+		return e.ForbiddenError("The collection is not configured to allow invites.", nil)
+	}
+
+	form := new(recordAcceptInviteForm)
+	form.collection = collection
+	if err = e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+	if err = form.validate(); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while validating the submitted data.", err))
+	}
+
+	invite, err := e.App.FindInviteByToken(collection, form.Token)
+	if err != nil || invite.IsAccepted() || invite.IsExpired() {
+		return e.BadRequestError("Invalid or expired invite token.", err)
+	}
+
+	// the password is required only for the new auth records
+	// (the existing ones are just linked to the invite)
+	_, findErr := e.App.FindAuthRecordByEmail(collection, invite.Email)
+	if findErr != nil && form.Password == "" {
+		return e.BadRequestError("An error occurred while validating the submitted data.", validation.Errors{
+			"password": validation.ErrRequired,
+		})
+	}
+
+	data := map[string]any{}
+	if findErr != nil {
+		data[core.FieldNamePassword] = form.Password
+	}
+
+	authRecord, err := e.App.AcceptInvite(invite, data)
+	if err != nil {
+		if errors.Is(err, core.ErrInviteAccepted) || errors.Is(err, core.ErrInviteExpired) {
+			return e.BadRequestError("Invalid or expired invite token.", err)
+		}
+		return firstApiError(err, e.BadRequestError("Failed to accept the invite.", err))
+	}
+
+	return RecordAuthResponse(e, authRecord, "invite", nil)
+}
+
+// -------------------------------------------------------------------
+
+var inviteFilterFields = []string{"id", "email", "expires", "accepted", "recordRef", "created", "updated"}
+
+func recordInvitesList(e *core.RequestEvent) error {
+	collection, err := findAuthCollection(e)
+	if err != nil {
+		return err
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver(inviteFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(e.App.InviteQuery().AndWhere(dbx.HashExp{"collectionRef": collection.Id})).
+		ParseAndExec(e.Request.URL.Query().Encode(), &[]*core.Invite{})
+
+	if err != nil {
+		return e.BadRequestError("", err)
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+func recordInviteView(e *core.RequestEvent) error {
+	invite, err := findCollectionInvite(e)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, invite)
+}
+
+func recordInviteCreate(e *core.RequestEvent) error {
+	collection, err := findAuthCollection(e)
+	if err != nil {
+		return err
+	}
+
+	form := new(recordInviteCreateForm)
+	if err = e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+	if err = form.validate(); err != nil {
+		return e.BadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	invite, token, err := e.App.CreateInvite(collection, form.Email, form.Data)
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to create invite.", err))
+	}
+
+	if !form.SkipEmail {
+		if err := mails.SendRecordInvite(e.App, invite, token); err != nil {
+			return e.BadRequestError("Failed to send invite email.", err)
+		}
+	}
+
+	return e.JSON(http.StatusOK, inviteWithToken{Invite: invite, Token: token})
+}
+
+func recordInviteResend(e *core.RequestEvent) error {
+	invite, err := findCollectionInvite(e)
+	if err != nil {
+		return err
+	}
+
+	token, err := e.App.RefreshInviteToken(invite)
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to refresh the invite token.", err))
+	}
+
+	if err := mails.SendRecordInvite(e.App, invite, token); err != nil {
+		return e.BadRequestError("Failed to send invite email.", err)
+	}
+
+	return e.JSON(http.StatusOK, inviteWithToken{Invite: invite, Token: token})
+}
+
+func recordInviteDelete(e *core.RequestEvent) error {
+	invite, err := findCollectionInvite(e)
+	if err != nil {
+		return err
+	}
+
+	if err := e.App.Delete(invite); err != nil {
+		return e.BadRequestError("Failed to delete invite.", err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+func findCollectionInvite(e *core.RequestEvent) (*core.Invite, error) {
+	collection, err := findAuthCollection(e)
+	if err != nil {
+		return nil, err
+	}
+
+	invite, err := e.App.FindInviteById(e.Request.PathValue("id"))
+	if err != nil || invite.CollectionRef != collection.Id {
+		return nil, e.NotFoundError("", err)
+	}
+
+	return invite, nil
+}
+
+// -------------------------------------------------------------------
+
+// inviteWithToken is the invite response of the token generating
+// endpoints (the plain token is not stored and it is returned only once).
+type inviteWithToken struct {
+	*core.Invite
+
+	Token string `json:"token"`
+}
+
+type recordInviteCreateForm struct {
+	Email string         `form:"email" json:"email"`
+	Data  map[string]any `form:"data" json:"data"`
+
+	// SkipEmail disables the invite email sending
+	// (e.g. when the invite link is delivered by other means).
+	SkipEmail bool `form:"skipEmail" json:"skipEmail"`
+}
+
+func (form *recordInviteCreateForm) validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
+	)
+}
+
+type recordAcceptInviteForm struct {
+	collection *core.Collection
+
+	Token           string `form:"token" json:"token"`
+	Password        string `form:"password" json:"password"`
+	PasswordConfirm string `form:"passwordConfirm" json:"passwordConfirm"`
+}
+
+func (form *recordAcceptInviteForm) validate() error {
+	min := 1
+	passField, ok := form.collection.Fields.GetByName(core.FieldNamePassword).(*core.PasswordField)
+	if ok && passField != nil && passField.Min > 0 {
+		min = passField.Min
+	}
+
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Token, validation.Required),
+		validation.Field(&form.Password, validation.Length(min, 255)), // the FieldPassword validator will check further the specicic length constraints
+		validation.Field(&form.PasswordConfirm, validation.When(form.Password != "", validation.Required), validation.By(validators.Equal(form.Password))),
+	)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/sms"
 	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/tools/security"
 )
@@ -32,7 +33,25 @@ func recordRequestOTP(e *core.RequestEvent) error {
 		return firstApiError(err, e.BadRequestError("An error occurred while validating the submitted data.", err))
 	}
 
-	record, err := e.App.FindAuthRecordByEmail(collection, form.Email)
+	// the OTP target identity (email, phone number or any other identity field value)
+	identity := form.Email
+
+	var record *core.Record
+	switch {
+	case form.Phone != "":
+		if collection.OTP.PhoneField == "" {
+			return e.ForbiddenError("The collection is not configured to allow OTP delivery via SMS.", nil)
+		}
+
+		identity = form.Phone
+		record, err = e.App.FindFirstRecordByData(collection, collection.OTP.PhoneField, form.Phone)
+	case form.Identity != "":
+		// e.g. username login with the OTP delivered to the record email
+		identity = form.Identity
+		record, err = findRecordByIdentity(e.App, collection, form.Identity)
+	default:
+		record, err = e.App.FindAuthRecordByEmail(collection, form.Email)
+	}
 
 	// ignore not found errors to allow custom record find implementations
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -54,7 +73,7 @@ func recordRequestOTP(e *core.RequestEvent) error {
 				"otpId": core.GenerateDefaultRandomId(),
 			})
 
-			return fmt.Errorf("missing or invalid %s OTP auth record with email %s", collection.Name, form.Email)
+			return fmt.Errorf("missing or invalid %s OTP auth record with identity %s", collection.Name, identity)
 		}
 
 		var otp *core.OTP
@@ -76,7 +95,7 @@ func recordRequestOTP(e *core.RequestEvent) error {
 					otp = otps[0] // otps are DESC sorted
 					e.App.Logger().Warn(
 						"Too many OTP requests - reusing the last issued",
-						"email", form.Email,
+						"identity", identity,
 						"recordId", e.Record.Id,
 						"otpId", existingOTP.Id,
 					)
@@ -97,10 +116,18 @@ func recordRequestOTP(e *core.RequestEvent) error {
 				return err
 			}
 
-			// send OTP email
+			// send OTP email or SMS
 			// (in the background as a very basic timing attacks and emails enumeration protection)
 			// ---
 			routine.FireAndForget(func() {
+				if form.Phone != "" {
+					err = sms.SendRecordOTP(originalApp, e.Record, otp.Id, e.Password)
+					if err != nil {
+						originalApp.Logger().Error("Failed to send OTP SMS", "error", errors.Join(err, originalApp.Delete(otp)))
+					}
+					return
+				}
+
 				err = mails.SendRecordOTP(originalApp, e.Record, otp.Id, e.Password)
 				if err != nil {
 					originalApp.Logger().Error("Failed to send OTP email", "error", errors.Join(err, originalApp.Delete(otp)))
@@ -118,10 +145,27 @@ func recordRequestOTP(e *core.RequestEvent) error {
 
 type createOTPForm struct {
 	Email string `form:"email" json:"email"`
+	Phone string `form:"phone" json:"phone"`
+
+	// Identity is the value of any of the collection identity fields
+	// (e.g. username) for which to send the OTP to the record email.
+	Identity string `form:"identity" json:"identity"`
 }
 
 func (form createOTPForm) validate() error {
 	return validation.ValidateStruct(&form,
-		validation.Field(&form.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
+		validation.Field(
+			&form.Email,
+			validation.When(form.Phone == "" && form.Identity == "", validation.Required),
+			validation.When(form.Phone != "" || form.Identity != "", validation.Empty.Error("Only one of email, phone or identity could be set.")),
+			validation.Length(1, 255),
+			is.EmailFormat,
+		),
+		validation.Field(
+			&form.Phone,
+			validation.When(form.Identity != "", validation.Empty.Error("Only one of email, phone or identity could be set.")),
+			validation.Length(1, 50),
+		),
+		validation.Field(&form.Identity, validation.Length(1, 255)),
 	)
 }
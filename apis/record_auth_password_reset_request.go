@@ -23,6 +23,11 @@ func recordRequestPasswordReset(e *core.RequestEvent) error {
 		return e.BadRequestError("The collection is not configured to allow password authentication.", nil)
 	}
 
+	err = checkCaptcha(e, collection, core.CaptchaActionPasswordReset)
+	if err != nil {
+		return err
+	}
+
 	form := new(recordRequestPasswordResetForm)
 	if err = e.BindBody(form); err != nil {
 		return firstApiError(err, e.BadRequestError("An error occurred while loading the submitted data.", err))
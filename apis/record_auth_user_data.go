@@ -0,0 +1,58 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// recordAuthExportData returns a zip archive with the data of the current authenticated record.
+func recordAuthExportData(e *core.RequestEvent) error {
+	if !e.App.Settings().UserData.SelfService {
+		return e.ForbiddenError("The user data self-service is not enabled.", nil)
+	}
+
+	requestInfo, err := e.RequestInfo()
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("", err))
+	}
+
+	data, err := e.App.ExportUserDataWithRequestInfo(e.Request.Context(), requestInfo)
+	if err != nil {
+		return e.InternalServerError("Failed to export the user data.", err)
+	}
+
+	e.Response.Header().Set("Content-Disposition", fmt.Sprintf(
+		`attachment; filename="%s_%s_data.zip"`,
+		e.Auth.Collection().Name,
+		e.Auth.Id,
+	))
+
+	return e.Blob(http.StatusOK, "application/zip", data)
+}
+
+// recordAuthEraseData deletes the current authenticated record and
+// applies the configured erasure strategies to its referencing records
+// (the referencing collections API rules are checked).
+func recordAuthEraseData(e *core.RequestEvent) error {
+	if !e.App.Settings().UserData.SelfService {
+		return e.ForbiddenError("The user data self-service is not enabled.", nil)
+	}
+
+	requestInfo, err := e.RequestInfo()
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("", err))
+	}
+
+	err = e.App.EraseUserDataWithRequestInfo(e.Request.Context(), requestInfo)
+	if err != nil {
+		if errors.Is(err, core.ErrUserDataAccessDenied) {
+			return e.ForbiddenError("You are not allowed to erase some of the records referencing your account.", err)
+		}
+		return firstApiError(err, e.BadRequestError("Failed to erase the user data.", err))
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
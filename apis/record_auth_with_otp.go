@@ -60,6 +60,10 @@ func recordAuthWithOTP(e *core.RequestEvent) error {
 	}
 
 	if !event.OTP.ValidatePassword(form.Password) {
+		if collection.OTP.MaxAttempts > 0 && otpAttemptsExceeded(e.App, event.OTP, collection.OTP) {
+			return e.TooManyRequestsError("Too many attempts, please try again later with a new OTP.", nil)
+		}
+
 		return e.BadRequestError("Invalid or expired OTP", errors.New("incorrect password"))
 	}
 	// ---
@@ -86,11 +90,41 @@ func recordAuthWithOTP(e *core.RequestEvent) error {
 		if err != nil {
 			e.App.Logger().Error("Failed to delete used OTP", "error", err, "otpId", e.OTP.Id)
 		}
+		e.App.KV().Namespace(otpAttemptsKVNamespace).Delete(e.OTP.Id)
 
 		return RecordAuthResponse(e.RequestEvent, e.Record, core.MFAMethodOTP, nil)
 	})
 }
 
+// otpAttemptsKVNamespace is the app KV store namespace with the OTP failed attempts counters.
+const otpAttemptsKVNamespace = "_otpAttempts"
+
+// otpAttemptsExceeded registers a new failed OTP password attempt and
+// reports whether the OTP max allowed attempts were reached.
+//
+// The OTP is deleted once its attempts are exceeded
+// or if the attempts counter couldn't be updated.
+func otpAttemptsExceeded(app core.App, otp *core.OTP, config core.OTPConfig) bool {
+	kv := app.KV().Namespace(otpAttemptsKVNamespace)
+
+	// expire the counter together with the OTP
+	attempts, err := kv.IncrementWithTTL(otp.Id, 1, config.DurationTime())
+	if err != nil {
+		// fail closed to prevent silently disabling the attempts limit
+		app.Logger().Error("Failed to increment the OTP attempts", "error", err, "otpId", otp.Id)
+	} else if attempts < config.MaxAttempts {
+		return false
+	}
+
+	if err := app.Delete(otp); err != nil {
+		app.Logger().Error("Failed to delete OTP with exceeded attempts", "error", err, "otpId", otp.Id)
+	}
+
+	kv.Delete(otp.Id)
+
+	return true
+}
+
 // -------------------------------------------------------------------
 
 type authWithOTPForm struct {
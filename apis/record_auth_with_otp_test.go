@@ -606,3 +606,89 @@ func TestRecordAuthWithOTPManualRateLimiterCheck(t *testing.T) {
 		}).Test(t)
 	}
 }
+
+func TestRecordAuthWithOTPMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	otpId := strings.Repeat("a", 15)
+
+	scenarios := []struct {
+		name             string
+		prevAttempts     int
+		maxAttempts      int
+		missingKVTable   bool
+		expectedStatus   int
+		expectOTPDeleted bool
+	}{
+		{"no limit", 10, 0, false, 400, false},
+		{"below the limit", 1, 3, false, 400, false},
+		{"reached the limit", 2, 3, false, 429, true},
+		{"failed attempts counter increment", 0, 3, true, 429, true},
+	}
+
+	for _, s := range scenarios {
+		(&tests.ApiScenario{
+			Name:   s.name,
+			Method: http.MethodPost,
+			URL:    "/api/collections/users/auth-with-otp",
+			Body: strings.NewReader(`{
+				"otpId":"` + otpId + `",
+				"password":"wrong"
+			}`),
+			BeforeTestFunc: func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+				user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				user.Collection().OTP.MaxAttempts = s.maxAttempts
+				if err := app.Save(user.Collection()); err != nil {
+					t.Fatal(err)
+				}
+
+				otp := core.NewOTP(app)
+				otp.Id = otpId
+				otp.SetCollectionRef(user.Collection().Id)
+				otp.SetRecordRef(user.Id)
+				otp.SetPassword("123456")
+				if err := app.Save(otp); err != nil {
+					t.Fatal(err)
+				}
+
+				if s.missingKVTable {
+					if _, err := app.AuxDB().DropTable("_kv").Execute(); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				if s.prevAttempts > 0 {
+					if err := app.KV().Namespace("_otpAttempts").Set(otpId, s.prevAttempts); err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			ExpectedStatus:  s.expectedStatus,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents: func() map[string]int {
+				if !s.expectOTPDeleted {
+					return map[string]int{"*": 0}
+				}
+				return map[string]int{
+					"*":                          0,
+					"OnModelDelete":              1,
+					"OnModelDeleteExecute":       1,
+					"OnModelAfterDeleteSuccess":  1,
+					"OnRecordDelete":             1,
+					"OnRecordDeleteExecute":      1,
+					"OnRecordAfterDeleteSuccess": 1,
+				}
+			}(),
+			AfterTestFunc: func(t testing.TB, app *tests.TestApp, res *http.Response) {
+				_, err := app.FindOTPById(otpId)
+				if deleted := err != nil; deleted != s.expectOTPDeleted {
+					t.Fatalf("Expected OTP deleted %v, got %v", s.expectOTPDeleted, deleted)
+				}
+			},
+		}).Test(t)
+	}
+}
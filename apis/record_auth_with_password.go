@@ -40,34 +40,7 @@ func recordAuthWithPassword(e *core.RequestEvent) error {
 	if form.IdentityField != "" {
 		foundRecord, foundErr = findRecordByIdentityField(e.App, collection, form.IdentityField, form.Identity)
 	} else {
-		identityFields := collection.PasswordAuth.IdentityFields
-
-		// @todo consider removing with the stable release or moving it in the collection save
-		//
-		// prioritize email lookup to minimize breaking changes with earlier versions
-		if len(identityFields) > 1 && identityFields[0] != core.FieldNameEmail {
-			identityFields = slices.Clone(identityFields)
-			slices.SortStableFunc(identityFields, func(a, b string) int {
-				if a == "email" {
-					return -1
-				}
-				if b == "email" {
-					return 1
-				}
-				return 0
-			})
-		}
-
-		for _, name := range identityFields {
-			if name == core.FieldNameEmail && is.EmailFormat.Validate(form.Identity) != nil {
-				continue // no need to query the database if we know that the submitted value is not an email
-			}
-
-			foundRecord, foundErr = findRecordByIdentityField(e.App, collection, name, form.Identity)
-			if foundErr == nil {
-				break
-			}
-		}
+		foundRecord, foundErr = findRecordByIdentity(e.App, collection, form.Identity)
 	}
 
 	// ignore not found errors to allow custom record find implementations
@@ -115,6 +88,44 @@ func (form *authWithPasswordForm) validate(collection *core.Collection) error {
 	)
 }
 
+// findRecordByIdentity searches for an auth record matching the identity value
+// in any of the collection identity fields (the email field is checked first).
+func findRecordByIdentity(app core.App, collection *core.Collection, identity string) (*core.Record, error) {
+	identityFields := collection.PasswordAuth.IdentityFields
+
+	// @todo consider removing with the stable release or moving it in the collection save
+	//
+	// prioritize email lookup to minimize breaking changes with earlier versions
+	if len(identityFields) > 1 && identityFields[0] != core.FieldNameEmail {
+		identityFields = slices.Clone(identityFields)
+		slices.SortStableFunc(identityFields, func(a, b string) int {
+			if a == "email" {
+				return -1
+			}
+			if b == "email" {
+				return 1
+			}
+			return 0
+		})
+	}
+
+	foundErr := error(sql.ErrNoRows)
+
+	for _, name := range identityFields {
+		if name == core.FieldNameEmail && is.EmailFormat.Validate(identity) != nil {
+			continue // no need to query the database if we know that the submitted value is not an email
+		}
+
+		var foundRecord *core.Record
+		foundRecord, foundErr = findRecordByIdentityField(app, collection, name, identity)
+		if foundErr == nil {
+			return foundRecord, nil
+		}
+	}
+
+	return nil, foundErr
+}
+
 func findRecordByIdentityField(app core.App, collection *core.Collection, field string, value any) (*core.Record, error) {
 	if !slices.Contains(collection.PasswordAuth.IdentityFields, field) {
 		return nil, errors.New("invalid identity field " + field)
@@ -1,7 +1,9 @@
 package apis
 
 import (
+	"bytes"
 	cryptoRand "crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -9,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/forms"
@@ -26,11 +29,19 @@ import (
 // note: the rate limiter is "inlined" because some of the crud actions are also used in the batch APIs
 func bindRecordCrudApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
 	subGroup := rg.Group("/collections/{collection}/records").Unbind(DefaultRateLimitMiddlewareId)
-	subGroup.GET("", recordsList)
-	subGroup.GET("/{id}", recordView)
+	subGroup.GET("", recordsList).Bind(responseCache("list"))
+	subGroup.GET("/{id}", recordView).Bind(responseCache("view"))
 	subGroup.POST("", recordCreate(true, nil)).Bind(dynamicCollectionBodyLimit(""))
 	subGroup.PATCH("/{id}", recordUpdate(true, nil)).Bind(dynamicCollectionBodyLimit(""))
 	subGroup.DELETE("/{id}", recordDelete(true, nil))
+	subGroup.POST("/{id}/moderate", recordModerate)
+	subGroup.POST("/{id}/duplicate", recordDuplicate).Bind(RequireSuperuserAuth())
+	subGroup.POST("/{id}/move", recordMove)
+	subGroup.GET("/{id}/members", recordMembersList)
+	subGroup.POST("/{id}/members", recordMemberAdd)
+	subGroup.DELETE("/{id}/members/{memberId}", recordMemberRemove)
+
+	bindResponseCacheEvents(app)
 }
 
 func recordsList(e *core.RequestEvent) error {
@@ -39,6 +50,11 @@ func recordsList(e *core.RequestEvent) error {
 		return e.NotFoundError("Missing collection context.", err)
 	}
 
+	err = checkCollectionAPIAccess(e, collection, false)
+	if err != nil {
+		return err
+	}
+
 	err = checkCollectionRateLimit(e, collection, "list")
 	if err != nil {
 		return err
@@ -59,7 +75,48 @@ func recordsList(e *core.RequestEvent) error {
 		return err
 	}
 
-	query := e.App.RecordQuery(collection)
+	// abort the db queries in case the client disconnects
+	query := e.App.RecordQuery(collection).WithContext(e.Request.Context())
+
+	urlQuery := e.Request.URL.Query()
+
+	var cursor *recordsCursor
+	switch urlQuery.Get(paginationQueryParam) {
+	case "", paginationOffset:
+		// default page-offset pagination
+	case paginationCursor:
+		cursor, err = newRecordsCursor(
+			collection,
+			urlQuery.Get(search.SortQueryParam),
+			urlQuery.Get(cursorQueryParam),
+			requestInfo.HasSuperuserAuth(),
+		)
+		if err != nil {
+			return e.BadRequestError("Invalid cursor pagination parameters.", err)
+		}
+
+		cursor.apply(query)
+
+		// the cursor takes the place of the page and the total counts
+		urlQuery.Del(search.PageQueryParam)
+		urlQuery.Set(search.SkipTotalQueryParam, "1")
+		urlQuery.Set(search.SortQueryParam, cursor.sortString())
+	default:
+		return e.BadRequestError("Invalid pagination parameter - expected offset or cursor.", nil)
+	}
+
+	// fetch only the columns required for the requested response fields
+	if rawFields := urlQuery.Get(fieldsQueryParam); rawFields != "" {
+		var extraFields []string
+		if cursor != nil {
+			extraFields = cursor.fieldNames()
+		}
+
+		err = projectRecordsQuery(e.App, collection, query, rawFields, urlQuery.Get(expandQueryParam), extraFields...)
+		if err != nil {
+			return e.BadRequestError("Invalid fields parameter.", err)
+		}
+	}
 
 	fieldsResolver := core.NewRecordFieldResolver(e.App, collection, requestInfo, true)
 
@@ -74,6 +131,16 @@ func recordsList(e *core.RequestEvent) error {
 		// fieldsResolver.UpdateQuery(query)
 	}
 
+	// scheduled records are visible only to superusers
+	if !requestInfo.HasSuperuserAuth() && collection.Publishing.Enabled {
+		query.AndWhere(core.PublishedRecordsExpr(collection.Name, time.Now()))
+	}
+
+	// expired records are visible only to superusers
+	if !requestInfo.HasSuperuserAuth() && collection.Expiration.Field != "" {
+		query.AndWhere(core.UnexpiredRecordsExpr(collection, time.Now()))
+	}
+
 	// hidden fields are searchable only by superusers
 	fieldsResolver.SetAllowHiddenFields(requestInfo.HasSuperuserAuth())
 
@@ -85,11 +152,19 @@ func recordsList(e *core.RequestEvent) error {
 	}
 
 	records := []*core.Record{}
-	result, err := searchProvider.ParseAndExec(e.Request.URL.Query().Encode(), &records)
+	result, err := searchProvider.ParseAndExec(urlQuery.Encode(), &records)
 	if err != nil {
 		return firstApiError(err, e.BadRequestError("", err))
 	}
 
+	// a full page could have more items after it
+	if cursor != nil && len(records) > 0 && len(records) == result.PerPage {
+		result.NextCursor, err = cursor.next(records[len(records)-1])
+		if err != nil {
+			return e.InternalServerError("Failed to generate the next cursor.", err)
+		}
+	}
+
 	event := new(core.RecordsListRequestEvent)
 	event.RequestEvent = e
 	event.Collection = collection
@@ -147,6 +222,11 @@ func recordView(e *core.RequestEvent) error {
 		return e.NotFoundError("Missing collection context.", err)
 	}
 
+	err = checkCollectionAPIAccess(e, collection, false)
+	if err != nil {
+		return err
+	}
+
 	err = checkCollectionRateLimit(e, collection, "view")
 	if err != nil {
 		return err
@@ -167,6 +247,14 @@ func recordView(e *core.RequestEvent) error {
 	}
 
 	ruleFunc := func(q *dbx.SelectQuery) error {
+		if !requestInfo.HasSuperuserAuth() && collection.Publishing.Enabled {
+			q.AndWhere(core.PublishedRecordsExpr(collection.Name, time.Now()))
+		}
+
+		if !requestInfo.HasSuperuserAuth() && collection.Expiration.Field != "" {
+			q.AndWhere(core.UnexpiredRecordsExpr(collection, time.Now()))
+		}
+
 		if !requestInfo.HasSuperuserAuth() && collection.ViewRule != nil && *collection.ViewRule != "" {
 			resolver := core.NewRecordFieldResolver(e.App, collection, requestInfo, true)
 
@@ -185,7 +273,7 @@ func recordView(e *core.RequestEvent) error {
 		return nil
 	}
 
-	record, fetchErr := e.App.FindRecordById(collection, recordId, ruleFunc)
+	record, fetchErr := e.App.FindRecordByIdWithContext(e.Request.Context(), collection, recordId, ruleFunc)
 	if fetchErr != nil || record == nil {
 		return firstApiError(err, e.NotFoundError("", fetchErr))
 	}
@@ -196,6 +284,18 @@ func recordView(e *core.RequestEvent) error {
 	event.Record = record
 
 	return e.App.OnRecordViewRequest().Trigger(event, func(e *core.RecordRequestEvent) error {
+		etag, err := setRecordETag(e.RequestEvent, e.Record)
+		if err != nil {
+			return e.InternalServerError("Failed to generate the record ETag.", err)
+		}
+
+		// the expanded relations are not part of the ETag so skip the
+		// "not modified" check to avoid serving stale expand data
+		ifNoneMatch := e.Request.Header.Get("If-None-Match")
+		if ifNoneMatch != "" && e.Request.URL.Query().Get(expandQueryParam) == "" && etagMatches(ifNoneMatch, etag, true) {
+			return e.NoContent(http.StatusNotModified)
+		}
+
 		if err := EnrichRecord(e.RequestEvent, e.Record); err != nil {
 			return firstApiError(err, e.InternalServerError("Failed to enrich record", err))
 		}
@@ -217,6 +317,11 @@ func recordCreate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 			return e.BadRequestError("Unsupported collection type.", nil)
 		}
 
+		err = checkCollectionAPIAccess(e, collection, true)
+		if err != nil {
+			return err
+		}
+
 		err = checkCollectionRateLimit(e, collection, "create")
 		if err != nil {
 			return err
@@ -232,6 +337,11 @@ func recordCreate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 			return e.ForbiddenError("Only superusers can perform this action.", nil)
 		}
 
+		err = checkCaptcha(e, collection, core.CaptchaActionCreate)
+		if err != nil {
+			return err
+		}
+
 		record := core.NewRecord(collection)
 
 		data, err := recordDataFromRequest(e, record)
@@ -259,6 +369,13 @@ func recordCreate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 		}
 		form.Load(data)
 
+		err = scoreRecordCreate(e, record)
+		if err != nil {
+			return err
+		}
+
+		protectModerationFields(e, record, hasSuperuserAuth)
+
 		if skipPlainPasswordRecordValidators {
 			// unset the plain value to skip the plain password field validators
 			if raw, ok := record.GetRaw(core.FieldNamePassword).(*core.PasswordFieldValue); ok {
@@ -268,47 +385,16 @@ func recordCreate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 
 		// check the request and record data against the create and manage rules
 		if !hasSuperuserAuth && collection.CreateRule != nil {
-			dummyRecord := record.Clone()
-
-			dummyRandomPart := "__pb_create__" + security.PseudorandomString(6)
-
-			// set an id if it doesn't have already
-			// (the value doesn't matter; it is used only to minimize the breaking changes with earlier versions)
-			if dummyRecord.Id == "" {
-				dummyRecord.Id = "__temp_id__" + dummyRandomPart
-			}
-
-			// unset the verified field to prevent manage API rule misuse in case the rule relies on it
-			dummyRecord.SetVerified(false)
-
-			// export the dummy record data into db params
-			dummyExport, err := dummyRecord.DBExport(e.App)
+			dummyCollection, dummyQuery, err := newDummyRecordQuery(e.App, record)
 			if err != nil {
-				return e.BadRequestError("Failed to create record", fmt.Errorf("dummy DBExport error: %w", err))
+				return e.BadRequestError("Failed to create record", err)
 			}
 
-			dummyParams := make(dbx.Params, len(dummyExport))
-			selects := make([]string, 0, len(dummyExport))
-			var param string
-			for k, v := range dummyExport {
-				k = inflector.Columnify(k) // columnify is just as extra measure in case of custom fields
-				param = "__pb_create__" + k
-				dummyParams[param] = v
-				selects = append(selects, "{:"+param+"} AS [["+k+"]]")
-			}
-
-			// shallow clone the current collection
-			dummyCollection := *collection
-			dummyCollection.Id += dummyRandomPart
-			dummyCollection.Name += inflector.Columnify(dummyRandomPart)
-
-			withFrom := fmt.Sprintf("WITH {{%s}} as (SELECT %s)", dummyCollection.Name, strings.Join(selects, ","))
-
 			// check non-empty create rule
 			if *dummyCollection.CreateRule != "" {
-				ruleQuery := e.App.ConcurrentDB().Select("(1)").PreFragment(withFrom).From(dummyCollection.Name).AndBind(dummyParams)
+				ruleQuery := dummyQuery()
 
-				resolver := core.NewRecordFieldResolver(e.App, &dummyCollection, requestInfo, true)
+				resolver := core.NewRecordFieldResolver(e.App, dummyCollection, requestInfo, true)
 
 				expr, err := search.FilterData(*dummyCollection.CreateRule).BuildExpr(resolver)
 				if err != nil {
@@ -329,9 +415,9 @@ func recordCreate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 			}
 
 			// check for manage rule access
-			manageRuleQuery := e.App.ConcurrentDB().Select("(1)").PreFragment(withFrom).From(dummyCollection.Name).AndBind(dummyParams)
+			manageRuleQuery := dummyQuery()
 			if !form.HasManageAccess() &&
-				hasAuthManageAccess(e.App, requestInfo, &dummyCollection, manageRuleQuery) {
+				hasAuthManageAccess(e.App, requestInfo, dummyCollection, manageRuleQuery) {
 				form.GrantManagerAccess()
 			}
 		}
@@ -352,6 +438,11 @@ func recordCreate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 				return firstApiError(err, e.BadRequestError("Failed to create record", err))
 			}
 
+			_, err = setRecordETag(e.RequestEvent, e.Record)
+			if err != nil {
+				return firstApiError(err, e.InternalServerError("Failed to generate the record ETag.", err))
+			}
+
 			err = EnrichRecord(e.RequestEvent, e.Record)
 			if err != nil {
 				return firstApiError(err, e.InternalServerError("Failed to enrich record", err))
@@ -389,6 +480,51 @@ func recordCreate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 	}
 }
 
+// newDummyRecordQuery returns a shallow copy of the record collection and
+// a factory for "SELECT (1)" queries against a virtual (CTE) table with the
+// unsaved record data so that its rules could be checked before persisting it.
+func newDummyRecordQuery(app core.App, record *core.Record) (*core.Collection, func() *dbx.SelectQuery, error) {
+	dummyRecord := record.Clone()
+
+	dummyRandomPart := "__pb_create__" + security.PseudorandomString(6)
+
+	// set an id if it doesn't have already
+	// (the value doesn't matter; it is used only to minimize the breaking changes with earlier versions)
+	if dummyRecord.Id == "" {
+		dummyRecord.Id = "__temp_id__" + dummyRandomPart
+	}
+
+	// unset the verified field to prevent manage API rule misuse in case the rule relies on it
+	dummyRecord.SetVerified(false)
+
+	// export the dummy record data into db params
+	dummyExport, err := dummyRecord.DBExport(app)
+	if err != nil {
+		return nil, nil, fmt.Errorf("dummy DBExport error: %w", err)
+	}
+
+	dummyParams := make(dbx.Params, len(dummyExport))
+	selects := make([]string, 0, len(dummyExport))
+	var param string
+	for k, v := range dummyExport {
+		k = inflector.Columnify(k) // columnify is just as extra measure in case of custom fields
+		param = "__pb_create__" + k
+		dummyParams[param] = v
+		selects = append(selects, "{:"+param+"} AS [["+k+"]]")
+	}
+
+	// shallow clone the current collection
+	dummyCollection := *record.Collection()
+	dummyCollection.Id += dummyRandomPart
+	dummyCollection.Name += inflector.Columnify(dummyRandomPart)
+
+	withFrom := fmt.Sprintf("WITH {{%s}} as (SELECT %s)", dummyCollection.Name, strings.Join(selects, ","))
+
+	return &dummyCollection, func() *dbx.SelectQuery {
+		return app.ConcurrentDB().Select("(1)").PreFragment(withFrom).From(dummyCollection.Name).AndBind(dummyParams)
+	}, nil
+}
+
 func recordUpdate(responseWriteAfterTx bool, optFinalizer func(data any) error) func(e *core.RequestEvent) error {
 	return func(e *core.RequestEvent) error {
 		collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
@@ -400,6 +536,11 @@ func recordUpdate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 			return e.BadRequestError("Unsupported collection type.", nil)
 		}
 
+		err = checkCollectionAPIAccess(e, collection, true)
+		if err != nil {
+			return err
+		}
+
 		err = checkCollectionRateLimit(e, collection, "update")
 		if err != nil {
 			return err
@@ -461,12 +602,19 @@ func recordUpdate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 			return firstApiError(err, e.NotFoundError("", err))
 		}
 
+		err = checkRecordIfMatch(e, record)
+		if err != nil {
+			return err
+		}
+
 		form := forms.NewRecordUpsert(e.App, record)
 		if hasSuperuserAuth {
 			form.GrantSuperuserAccess()
 		}
 		form.Load(data)
 
+		protectModerationFields(e, record, hasSuperuserAuth)
+
 		manageRuleQuery := e.App.ConcurrentDB().Select("(1)").From(collection.Name).AndWhere(dbx.HashExp{
 			collection.Name + ".id": record.Id,
 		})
@@ -491,6 +639,11 @@ func recordUpdate(responseWriteAfterTx bool, optFinalizer func(data any) error)
 				return firstApiError(err, e.BadRequestError("Failed to update record.", err))
 			}
 
+			_, err = setRecordETag(e.RequestEvent, e.Record)
+			if err != nil {
+				return firstApiError(err, e.InternalServerError("Failed to generate the record ETag.", err))
+			}
+
 			err = EnrichRecord(e.RequestEvent, e.Record)
 			if err != nil {
 				return firstApiError(err, e.InternalServerError("Failed to enrich record", err))
@@ -539,6 +692,11 @@ func recordDelete(responseWriteAfterTx bool, optFinalizer func(data any) error)
 			return e.BadRequestError("Unsupported collection type.", nil)
 		}
 
+		err = checkCollectionAPIAccess(e, collection, true)
+		if err != nil {
+			return err
+		}
+
 		err = checkCollectionRateLimit(e, collection, "delete")
 		if err != nil {
 			return err
@@ -582,6 +740,11 @@ func recordDelete(responseWriteAfterTx bool, optFinalizer func(data any) error)
 			return e.NotFoundError("", err)
 		}
 
+		err = checkRecordIfMatch(e, record)
+		if err != nil {
+			return err
+		}
+
 		var isOptFinalizerCalled bool
 
 		event := new(core.RecordRequestEvent)
@@ -634,14 +797,20 @@ func recordDataFromRequest(e *core.RequestEvent, record *core.Record) (map[strin
 		return nil, err
 	}
 
-	// resolve regular fields
-	result := record.ReplaceModifiers(info.Body)
-
 	// resolve uploaded files
 	uploadedFiles, err := extractUploadedFiles(e, record.Collection(), "")
 	if err != nil {
 		return nil, err
 	}
+
+	err = checkRecordDataLimits(record.Collection(), info.Body, uploadedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve regular fields
+	result := record.ReplaceModifiers(info.Body)
+
 	if len(uploadedFiles) > 0 {
 		for k, files := range uploadedFiles {
 			uploaded := make([]any, 0, len(files))
@@ -687,6 +856,104 @@ func recordDataFromRequest(e *core.RequestEvent, record *core.Record) (map[strin
 	return result, nil
 }
 
+// checkRecordDataLimits checks the submitted record data against
+// the collection payload limits (if any).
+func checkRecordDataLimits(collection *core.Collection, body map[string]any, uploadedFiles map[string][]*filesystem.File) error {
+	limits := collection.Limits
+	if limits.MaxItems <= 0 && limits.MaxJSONSize <= 0 && limits.MaxJSONDepth <= 0 {
+		return nil
+	}
+
+	errs := validation.Errors{}
+
+	// count the submitted items per field name (including the modifier keys)
+	items := map[string]int{}
+
+	for k, v := range body {
+		name := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(k, "+"), "+"), "-")
+
+		switch f := collection.Fields.GetByName(name).(type) {
+		case core.MultiValuer:
+			if f.IsMultiple() {
+				items[name] += len(list.ToUniqueStringSlice(v))
+			}
+		case *core.JSONField:
+			if err := checkJSONLimits(v, limits); err != nil {
+				errs[name] = err
+			}
+		}
+	}
+
+	for k, files := range uploadedFiles {
+		name := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(k, "+"), "+"), "-")
+		items[name] += len(files)
+	}
+
+	if limits.MaxItems > 0 {
+		for name, total := range items {
+			if total > limits.MaxItems {
+				errs[name] = validation.NewError(
+					"validation_max_items_limit",
+					"Too many submitted items (max {{.maxItems}}).",
+				).SetParams(map[string]any{"maxItems": limits.MaxItems})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// checkJSONLimits checks a single submitted json field value against the json payload limits.
+func checkJSONLimits(value any, limits core.CollectionLimits) error {
+	var raw []byte
+	if str, ok := value.(string); ok {
+		raw = []byte(str)
+	} else {
+		var err error
+		raw, err = json.Marshal(value)
+		if err != nil {
+			return validation.NewError("validation_invalid_json", "Must be a valid json value.")
+		}
+	}
+
+	if limits.MaxJSONSize > 0 && int64(len(raw)) > limits.MaxJSONSize {
+		return validation.NewError(
+			"validation_json_size_limit",
+			"The maximum allowed JSON size is {{.maxSize}} bytes.",
+		).SetParams(map[string]any{"maxSize": limits.MaxJSONSize})
+	}
+
+	if limits.MaxJSONDepth > 0 {
+		depth := 0
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		for {
+			t, err := dec.Token()
+			if err != nil {
+				break // EOF or plain string (the value is further validated by the field)
+			}
+
+			switch t {
+			case json.Delim('{'), json.Delim('['):
+				depth++
+				if depth > limits.MaxJSONDepth {
+					return validation.NewError(
+						"validation_json_depth_limit",
+						"The maximum allowed JSON nesting depth is {{.maxDepth}}.",
+					).SetParams(map[string]any{"maxDepth": limits.MaxJSONDepth})
+				}
+			case json.Delim('}'), json.Delim(']'):
+				depth--
+			}
+		}
+	}
+
+	return nil
+}
+
 func extractUploadedFiles(re *core.RequestEvent, collection *core.Collection, prefix string) (map[string][]*filesystem.File, error) {
 	contentType := re.Request.Header.Get("content-type")
 	if !strings.HasPrefix(contentType, "multipart/form-data") {
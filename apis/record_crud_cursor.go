@@ -0,0 +1,199 @@
+package apis
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+const (
+	paginationQueryParam = "pagination"
+	cursorQueryParam     = "cursor"
+
+	paginationCursor = "cursor"
+	paginationOffset = "offset"
+)
+
+// recordsCursor implements keyset (aka. cursor) pagination for the records list API.
+//
+// Instead of OFFSET, the next page is fetched with a WHERE condition based
+// on the sort fields values of the last returned record, ensuring that
+// deep pages remain fast and that concurrent writes don't result in
+// duplicated or missing items.
+type recordsCursor struct {
+	collection *core.Collection
+	sort       []search.SortField
+	values     []any // the decoded cursor values (if any)
+}
+
+type recordsCursorData struct {
+	Sort   string `json:"s"`
+	Values []any  `json:"v"`
+}
+
+// newRecordsCursor validates the sort expression and the optional raw cursor
+// and initializes a new recordsCursor.
+//
+// The sort fields must be direct single value collection fields.
+// The "id" field is always appended as a tiebreaker (if not already part of the sort).
+//
+// Because the cursor exposes the raw sort values of the last record,
+// the hidden and the visibility-gated auth "email" fields are allowed
+// only if allowHidden is set (e.g. for superusers).
+func newRecordsCursor(collection *core.Collection, rawSort string, rawCursor string, allowHidden bool) (*recordsCursor, error) {
+	c := &recordsCursor{collection: collection}
+
+	var hasId bool
+
+	if strings.TrimSpace(rawSort) != "" {
+		fields := search.ParseSortFromString(rawSort)
+		if len(fields) > search.DefaultSortExprLimit {
+			return nil, search.ErrSortExprLimit
+		}
+
+		for _, sf := range fields {
+			field := collection.Fields.GetByName(sf.Name)
+			if field == nil || (!allowHidden && (field.GetHidden() || isVisibilityGatedField(collection, field))) {
+				return nil, fmt.Errorf("invalid or unsupported cursor sort field %q", sf.Name)
+			}
+
+			switch f := field.(type) {
+			case *core.JSONField, *core.GeoPointField, *core.PasswordField:
+				return nil, fmt.Errorf("cursor sort field %q must be a scalar value field", sf.Name)
+			case core.MultiValuer:
+				if f.IsMultiple() {
+					return nil, fmt.Errorf("cursor sort field %q must be a single value field", sf.Name)
+				}
+			}
+
+			if sf.Name == core.FieldNameId {
+				hasId = true
+			}
+
+			c.sort = append(c.sort, sf)
+		}
+	}
+
+	if !hasId {
+		c.sort = append(c.sort, search.SortField{Name: core.FieldNameId, Direction: search.SortAsc})
+	}
+
+	if rawCursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(rawCursor)
+		if err != nil {
+			return nil, errors.New("malformed cursor")
+		}
+
+		data := recordsCursorData{}
+		if err := json.Unmarshal(decoded, &data); err != nil {
+			return nil, errors.New("malformed cursor")
+		}
+
+		if data.Sort != c.sortString() || len(data.Values) != len(c.sort) {
+			return nil, errors.New("the cursor doesn't match with the current sort expression")
+		}
+
+		c.values = data.Values
+	}
+
+	return c, nil
+}
+
+// isVisibilityGatedField reports whether the value of the specified
+// collection field is visible only under certain conditions
+// (e.g. the auth record email depends on its emailVisibility state).
+func isVisibilityGatedField(collection *core.Collection, field core.Field) bool {
+	return collection.IsAuth() && field.GetName() == core.FieldNameEmail
+}
+
+// sortString returns the normalized sort expression of the cursor.
+func (c *recordsCursor) sortString() string {
+	parts := make([]string, len(c.sort))
+
+	for i, sf := range c.sort {
+		if sf.Direction == search.SortDesc {
+			parts[i] = "-" + sf.Name
+		} else {
+			parts[i] = sf.Name
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// fieldNames returns the names of the cursor sort fields.
+func (c *recordsCursor) fieldNames() []string {
+	names := make([]string, len(c.sort))
+
+	for i, sf := range c.sort {
+		names[i] = sf.Name
+	}
+
+	return names
+}
+
+// apply adds the cursor keyset condition (if any) to the provided query, e.g.
+// for "-a,id" sort: `(a < {:a}) OR (a = {:a} AND id > {:id})`.
+func (c *recordsCursor) apply(query *dbx.SelectQuery) {
+	if len(c.values) == 0 {
+		return
+	}
+
+	exprs := make([]dbx.Expression, len(c.sort))
+
+	for i := range c.sort {
+		parts := make([]string, 0, i+1)
+		params := make(dbx.Params, i+1)
+
+		for j := 0; j <= i; j++ {
+			op := "="
+			if j == i {
+				if c.sort[j].Direction == search.SortDesc {
+					op = "<"
+				} else {
+					op = ">"
+				}
+			}
+
+			paramName := "cursor" + strconv.Itoa(j)
+			params[paramName] = c.values[j]
+			parts = append(parts, "[["+c.collection.Name+"."+c.sort[j].Name+"]] "+op+" {:"+paramName+"}")
+		}
+
+		exprs[i] = dbx.NewExp(strings.Join(parts, " AND "), params)
+	}
+
+	query.AndWhere(dbx.Or(exprs...))
+}
+
+// next returns the encoded cursor pointing after the specified record.
+func (c *recordsCursor) next(record *core.Record) (string, error) {
+	data := recordsCursorData{
+		Sort:   c.sortString(),
+		Values: make([]any, len(c.sort)),
+	}
+
+	for i, sf := range c.sort {
+		switch v := record.Get(sf.Name).(type) {
+		case interface{ String() string }:
+			// e.g. types.DateTime
+			data.Values[i] = v.String()
+		default:
+			data.Values[i] = v
+		}
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,201 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordCrudETag(t *testing.T) {
+	t.Parallel()
+
+	etag := func() string {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		record, err := app.FindRecordById("demo2", "0yxhwia2amd8gec")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		etag, err := record.ETag()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return etag
+	}()
+
+	checkETag := func(expectedChange bool) func(testing.TB, *tests.TestApp, *http.Response) {
+		return func(t testing.TB, app *tests.TestApp, res *http.Response) {
+			header := res.Header.Get("ETag")
+			if header == "" {
+				t.Fatal("Expected non-empty ETag header")
+			}
+
+			if changed := header != etag; changed != expectedChange {
+				t.Fatalf("Expected ETag change %v, got %v (%q vs %q)", expectedChange, changed, header, etag)
+			}
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:           "view without If-None-Match",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records/0yxhwia2amd8gec",
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"0yxhwia2amd8gec"`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                   0,
+				"OnRecordViewRequest": 1,
+				"OnRecordEnrich":      1,
+			},
+			AfterTestFunc: checkETag(false),
+		},
+		{
+			Name:           "view with non-matching If-None-Match",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records/0yxhwia2amd8gec",
+			Headers:        map[string]string{"If-None-Match": `"abc", W/"def"`},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"0yxhwia2amd8gec"`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                   0,
+				"OnRecordViewRequest": 1,
+				"OnRecordEnrich":      1,
+			},
+			AfterTestFunc: checkETag(false),
+		},
+		{
+			Name:           "view with matching If-None-Match",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records/0yxhwia2amd8gec",
+			Headers:        map[string]string{"If-None-Match": `"abc", ` + etag},
+			ExpectedStatus: 304,
+			ExpectedEvents: map[string]int{
+				"*":                   0,
+				"OnRecordViewRequest": 1,
+			},
+			AfterTestFunc: checkETag(false),
+		},
+		{
+			Name:           "view with matching weak If-None-Match",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records/0yxhwia2amd8gec",
+			Headers:        map[string]string{"If-None-Match": "W/" + etag},
+			ExpectedStatus: 304,
+			ExpectedEvents: map[string]int{
+				"*":                   0,
+				"OnRecordViewRequest": 1,
+			},
+			AfterTestFunc: checkETag(false),
+		},
+		{
+			Name:           "view with matching If-None-Match and expand",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records/0yxhwia2amd8gec?expand=missing",
+			Headers:        map[string]string{"If-None-Match": etag},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"0yxhwia2amd8gec"`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                   0,
+				"OnRecordViewRequest": 1,
+				"OnRecordEnrich":      1,
+			},
+			AfterTestFunc: checkETag(false),
+		},
+		{
+			Name:            "update with non-matching If-Match",
+			Method:          http.MethodPatch,
+			URL:             "/api/collections/demo2/records/0yxhwia2amd8gec",
+			Headers:         map[string]string{"If-Match": `"abc"`},
+			Body:            strings.NewReader(`{"title":"new"}`),
+			ExpectedStatus:  412,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:            "update with weak matching If-Match",
+			Method:          http.MethodPatch,
+			URL:             "/api/collections/demo2/records/0yxhwia2amd8gec",
+			Headers:         map[string]string{"If-Match": "W/" + etag},
+			Body:            strings.NewReader(`{"title":"new"}`),
+			ExpectedStatus:  412,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:           "update with matching If-Match",
+			Method:         http.MethodPatch,
+			URL:            "/api/collections/demo2/records/0yxhwia2amd8gec",
+			Headers:        map[string]string{"If-Match": `"abc", ` + etag},
+			Body:           strings.NewReader(`{"title":"new"}`),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"0yxhwia2amd8gec"`,
+				`"title":"new"`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                          0,
+				"OnRecordUpdateRequest":      1,
+				"OnModelUpdate":              1,
+				"OnModelUpdateExecute":       1,
+				"OnModelAfterUpdateSuccess":  1,
+				"OnRecordUpdate":             1,
+				"OnRecordUpdateExecute":      1,
+				"OnRecordAfterUpdateSuccess": 1,
+				"OnModelValidate":            1,
+				"OnRecordValidate":           1,
+				"OnRecordEnrich":             1,
+			},
+			AfterTestFunc: checkETag(true),
+		},
+		{
+			Name:           "update with wildcard If-Match",
+			Method:         http.MethodPatch,
+			URL:            "/api/collections/demo2/records/0yxhwia2amd8gec",
+			Headers:        map[string]string{"If-Match": "*"},
+			Body:           strings.NewReader(`{}`),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"0yxhwia2amd8gec"`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                          0,
+				"OnRecordUpdateRequest":      1,
+				"OnModelUpdate":              1,
+				"OnModelUpdateExecute":       1,
+				"OnModelAfterUpdateSuccess":  1,
+				"OnRecordUpdate":             1,
+				"OnRecordUpdateExecute":      1,
+				"OnRecordAfterUpdateSuccess": 1,
+				"OnModelValidate":            1,
+				"OnRecordValidate":           1,
+				"OnRecordEnrich":             1,
+			},
+			AfterTestFunc: checkETag(true),
+		},
+		{
+			Name:            "delete with non-matching If-Match",
+			Method:          http.MethodDelete,
+			URL:             "/api/collections/demo2/records/0yxhwia2amd8gec",
+			Headers:         map[string]string{"If-Match": `"abc"`},
+			ExpectedStatus:  412,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
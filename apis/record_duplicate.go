@@ -0,0 +1,44 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// recordDuplicate creates a copy of the specified record
+// (the request body is used as optional overrides data).
+func recordDuplicate(e *core.RequestEvent) error {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return e.NotFoundError("Missing collection context.", err)
+	}
+
+	if collection.IsView() {
+		return e.BadRequestError("View collection records cannot be duplicated.", nil)
+	}
+
+	record, err := e.App.FindRecordById(collection, e.Request.PathValue("id"))
+	if err != nil {
+		return firstApiError(err, e.NotFoundError("", err))
+	}
+
+	overrides := map[string]any{}
+	if e.Request.ContentLength > 0 {
+		if err := e.BindBody(&overrides); err != nil {
+			return firstApiError(err, e.BadRequestError("An error occurred while loading the submitted data.", err))
+		}
+	}
+
+	duplicate, err := e.App.DuplicateRecordWithContext(e.Request.Context(), record, overrides)
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to duplicate record.", err))
+	}
+
+	err = EnrichRecord(e, duplicate)
+	if err != nil {
+		return firstApiError(err, e.InternalServerError("Failed to enrich record", err))
+	}
+
+	return e.JSON(http.StatusOK, duplicate)
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/tools/picker"
 	"github.com/pocketbase/pocketbase/tools/router"
 	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/tools/search"
@@ -305,6 +307,13 @@ func (ri *iterator[T]) next() T {
 }
 
 func triggerRecordEnrichHooks(app core.App, requestInfo *core.RequestInfo, records []*core.Record, finalizer func() error) error {
+	// resolve the localized fields values of the requested locale
+	if lang := requestInfo.Query[core.I18nQueryParam]; lang != "" {
+		for _, record := range records {
+			core.LocalizeRecord(record, lang)
+		}
+	}
+
 	it := iterator[*core.Record]{items: records}
 
 	enrichHook := app.OnRecordEnrich()
@@ -382,6 +391,14 @@ func expandFetch(app core.App, originalRequestInfo *core.RequestInfo) core.Expan
 				return fmt.Errorf("only superusers can view collection %q records", relCollection.Name)
 			}
 
+			if relCollection.Publishing.Enabled {
+				q.AndWhere(core.PublishedRecordsExpr(relCollection.Name, time.Now()))
+			}
+
+			if relCollection.Expiration.Field != "" {
+				q.AndWhere(core.UnexpiredRecordsExpr(relCollection, time.Now()))
+			}
+
 			if *relCollection.ViewRule != "" {
 				resolver := core.NewRecordFieldResolver(app, relCollection, requestInfoPtr, true)
 
@@ -500,6 +517,112 @@ func autoResolveRecordsFlags(app core.App, records []*core.Record, requestInfo *
 	return nil
 }
 
+// projectRecordsQuery limits the records query selected columns only to the
+// collection fields required to resolve the rawFields expression
+// (plus the extra ones and the ones needed internally as the "id" and the expanded relations).
+//
+// JSON fields that are projected only through a single `field:path(...)`
+// expression are partially extracted at the db level.
+//
+// Note that the not projected fields of the fetched records remain with their zero value.
+func projectRecordsQuery(
+	app core.App,
+	collection *core.Collection,
+	query *dbx.SelectQuery,
+	rawFields string,
+	rawExpand string,
+	extraFields ...string,
+) error {
+	fieldNames := collection.Fields.FieldNames()
+
+	projected, err := picker.Projection(rawFields, fieldNames)
+	if err != nil {
+		return err
+	}
+
+	required := make(map[string][]string, len(projected)+1)
+	for _, p := range projected {
+		required[p.Name] = p.Path
+	}
+
+	required[core.FieldNameId] = nil
+
+	for _, name := range extraFields {
+		required[name] = nil
+	}
+
+	for _, expand := range strings.Split(rawExpand, ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(expand), ".")
+		if collection.Fields.GetByName(name) != nil {
+			required[name] = nil
+		}
+	}
+
+	// the email field visibility depends on emailVisibility
+	if _, ok := required[core.FieldNameEmail]; ok && collection.IsAuth() {
+		required[core.FieldNameEmailVisibility] = nil
+	}
+
+	db := app.ConcurrentDB()
+	tableName := db.QuoteSimpleColumnName(collection.Name)
+
+	columns := make([]string, 0, len(required))
+	var hasPartial bool
+	for _, name := range fieldNames {
+		path, ok := required[name]
+		if !ok {
+			continue
+		}
+
+		column := tableName + "." + db.QuoteSimpleColumnName(name)
+
+		if _, isJSON := collection.Fields.GetByName(name).(*core.JSONField); isJSON && len(path) > 0 {
+			if expr := jsonPathProjectionExpr(column, path); expr != "" {
+				columns = append(columns, expr+" AS "+db.QuoteSimpleColumnName(name))
+				hasPartial = true
+				continue
+			}
+		}
+
+		columns = append(columns, column)
+	}
+
+	if len(columns) == len(fieldNames) && !hasPartial {
+		return nil // all columns are needed
+	}
+
+	query.Select(columns...)
+
+	return nil
+}
+
+var jsonPathKeySegmentRegex = regexp.MustCompile(`^[\w\-]*[a-zA-Z_\-][\w\-]*$`)
+
+// jsonPathProjectionExpr returns an SQL expression that builds a json
+// object with only the specified column json path value, e.g.
+// `{"a":{"b":column.a.b}}` for ["a", "b"].
+//
+// Returns an empty string if the path contains an array index segment
+// (in which case the json structure cannot be reconstructed).
+func jsonPathProjectionExpr(column string, path []string) string {
+	jsonPath := "$"
+	for _, segment := range path {
+		// the path segments are already validated by the picker
+		// but ensure again that they don't contain special characters
+		if !jsonPathKeySegmentRegex.MatchString(segment) {
+			return ""
+		}
+		jsonPath += `."` + segment + `"`
+	}
+
+	expr := column + " -> '" + jsonPath + "'"
+	for i := len(path) - 1; i >= 0; i-- {
+		expr = "json_object('" + path[i] + "', " + expr + ")"
+	}
+
+	return "iif(json_valid(" + column + "), " + expr + ", NULL)"
+}
+
 var ruleQueryParams = []string{search.FilterQueryParam, search.SortQueryParam}
 var superuserOnlyRuleFields = []string{"@collection.", "@request."}
 
@@ -526,6 +649,26 @@ func checkForSuperuserOnlyRuleFields(requestInfo *core.RequestInfo) error {
 	return nil
 }
 
+// checkCollectionAPIAccess returns an error if the collection web API is
+// disabled (or read-only and write is true) for the current request auth.
+//
+// Superusers are not affected by the collection API restrictions.
+func checkCollectionAPIAccess(e *core.RequestEvent, collection *core.Collection, write bool) error {
+	if collection.API.IsZero() || e.HasSuperuserAuth() {
+		return nil
+	}
+
+	if collection.API.Disabled {
+		return e.NotFoundError("Missing collection context.", nil)
+	}
+
+	if write && collection.API.ReadOnly {
+		return e.ForbiddenError("The collection API is read-only.", nil)
+	}
+
+	return nil
+}
+
 // firstApiError returns the first ApiError from the errors list
 // (this is used usually to prevent unnecessary wraping and to allow bubling ApiError from nested hooks)
 //
@@ -575,6 +718,63 @@ func execAfterSuccessTx(checkTx bool, app core.App, fn func() error) error {
 	return fn()
 }
 
+// setRecordETag generates the record ETag and sets it as response header.
+func setRecordETag(e *core.RequestEvent, record *core.Record) (string, error) {
+	etag, err := record.ETag()
+	if err != nil {
+		return "", err
+	}
+
+	e.Response.Header().Set("ETag", etag)
+
+	return etag, nil
+}
+
+// checkRecordIfMatch returns a 412 error if the request has an If-Match header
+// that doesn't match with the current record ETag (aka. the record was modified
+// after the client has last retrieved it).
+func checkRecordIfMatch(e *core.RequestEvent, record *core.Record) error {
+	ifMatch := e.Request.Header.Get("If-Match")
+	if ifMatch == "" {
+		return nil
+	}
+
+	etag, err := record.ETag()
+	if err != nil {
+		return e.InternalServerError("Failed to generate the record ETag.", err)
+	}
+
+	if !etagMatches(ifMatch, etag, false) {
+		return e.Error(http.StatusPreconditionFailed, "The record was modified since it was last retrieved.", nil)
+	}
+
+	return nil
+}
+
+// etagMatches reports whether etag matches with any of the entity tags
+// from the comma separated If-Match/If-None-Match header value.
+//
+// When weak is set, the "W/" prefix of the header tags is ignored
+// (aka. weak comparison, as required for If-None-Match).
+func etagMatches(header string, etag string, weak bool) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
 // -------------------------------------------------------------------
 
 const maxAuthOrigins = 5
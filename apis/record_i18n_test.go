@@ -0,0 +1,118 @@
+package apis_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordI18nFields(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t testing.TB, app *tests.TestApp, e *core.ServeEvent) {
+		demo2, err := app.FindCollectionByNameOrId("demo2")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		title, _ := demo2.Fields.GetByName("title").(*core.TextField)
+		title.I18n = []string{"en", "de"}
+		if err := app.Save(demo2); err != nil {
+			t.Fatal(err)
+		}
+
+		record, err := app.FindRecordById(demo2, "0yxhwia2amd8gec")
+		if err != nil {
+			t.Fatal(err)
+		}
+		record.Set("title", map[string]any{"en": "test3", "de": "Test drei"})
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:           "view without lang",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records/0yxhwia2amd8gec",
+			BeforeTestFunc: setup,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"title":{"de":"Test drei","en":"test3"}`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                   0,
+				"OnRecordViewRequest": 1,
+				"OnRecordEnrich":      1,
+			},
+		},
+		{
+			Name:           "view with lang",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records/0yxhwia2amd8gec?lang=de-AT",
+			BeforeTestFunc: setup,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"title":"Test drei"`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                   0,
+				"OnRecordViewRequest": 1,
+				"OnRecordEnrich":      1,
+			},
+		},
+		{
+			Name:           "view legacy plain value with lang",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records/achvryl401bhse3?lang=de",
+			BeforeTestFunc: setup,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"title":"test2"`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                   0,
+				"OnRecordViewRequest": 1,
+				"OnRecordEnrich":      1,
+			},
+		},
+		{
+			Name:           "list filter on the active locale",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records?lang=de&filter=title~'drei'",
+			BeforeTestFunc: setup,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":1`,
+				`"id":"0yxhwia2amd8gec"`,
+				`"title":"Test drei"`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                    0,
+				"OnRecordsListRequest": 1,
+				"OnRecordEnrich":       1,
+			},
+		},
+		{
+			Name:           "list filter on the default locale",
+			Method:         http.MethodGet,
+			URL:            "/api/collections/demo2/records?filter=title~'drei'",
+			BeforeTestFunc: setup,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":0`,
+			},
+			ExpectedEvents: map[string]int{
+				"*":                    0,
+				"OnRecordsListRequest": 1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
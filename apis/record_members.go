@@ -0,0 +1,178 @@
+package apis
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+var membershipFilterFields = []string{"id", "memberRef", "role", "created", "updated"}
+
+type recordMemberAddForm struct {
+	// Member is the id of the auth record from the organization members collection.
+	Member string `form:"member" json:"member"`
+
+	Role string `form:"role" json:"role"`
+}
+
+func (form *recordMemberAddForm) validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Member, validation.Required),
+		validation.Field(&form.Role, validation.Length(0, 100)),
+	)
+}
+
+// recordMembersList returns the paginated memberships of an organization record.
+//
+// The members are visible to everyone who can view the organization record.
+func recordMembersList(e *core.RequestEvent) error {
+	collection, organization, err := findOrganizationRecord(e, false)
+	if err != nil {
+		return err
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver(membershipFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(e.App.MembershipQuery().AndWhere(dbx.HashExp{
+			"collectionRef":   collection.Id,
+			"organizationRef": organization.Id,
+		})).
+		ParseAndExec(e.Request.URL.Query().Encode(), &[]*core.Membership{})
+
+	if err != nil {
+		return e.BadRequestError("", err)
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+// recordMemberAdd adds a new organization member (or updates the role of an existing one).
+func recordMemberAdd(e *core.RequestEvent) error {
+	collection, organization, err := findOrganizationRecord(e, true)
+	if err != nil {
+		return err
+	}
+
+	if err := checkOrganizationManageRule(e, collection, organization); err != nil {
+		return err
+	}
+
+	form := new(recordMemberAddForm)
+	if err = e.BindBody(form); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while loading the submitted data.", err))
+	}
+
+	if err = form.validate(); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while validating the submitted data.", err))
+	}
+
+	member, err := e.App.FindRecordById(collection.Organization.MembersCollection, form.Member)
+	if err != nil {
+		return e.BadRequestError("An error occurred while validating the submitted data.", validation.Errors{
+			"member": validation.NewError("validation_missing_member", "Missing or invalid member record."),
+		})
+	}
+
+	membership, err := e.App.AddOrganizationMember(organization, member, form.Role)
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to add member.", err))
+	}
+
+	return e.JSON(http.StatusOK, membership)
+}
+
+// recordMemberRemove removes an organization member.
+//
+// In addition to the organization managers, members are allowed to remove (aka. leave) themselves.
+func recordMemberRemove(e *core.RequestEvent) error {
+	collection, organization, err := findOrganizationRecord(e, true)
+	if err != nil {
+		return err
+	}
+
+	member, err := e.App.FindRecordById(collection.Organization.MembersCollection, e.Request.PathValue("memberId"))
+	if err != nil {
+		return e.NotFoundError("", err)
+	}
+
+	if e.Auth == nil || e.Auth.Id != member.Id || e.Auth.Collection().Id != member.Collection().Id {
+		if err := checkOrganizationManageRule(e, collection, organization); err != nil {
+			return err
+		}
+	}
+
+	if _, err := e.App.FindOrganizationMembership(organization, member); err != nil {
+		return e.NotFoundError("", err)
+	}
+
+	if err := e.App.RemoveOrganizationMember(organization, member); err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to remove member.", err))
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// findOrganizationRecord loads the path organization record and checks
+// whether the current request can view it.
+func findOrganizationRecord(e *core.RequestEvent, write bool) (*core.Collection, *core.Record, error) {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return nil, nil, e.NotFoundError("Missing collection context.", err)
+	}
+
+	if !collection.Organization.Enabled {
+		return nil, nil, e.BadRequestError("The collection is not an organization collection.", nil)
+	}
+
+	err = checkCollectionAPIAccess(e, collection, write)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	organization, err := e.App.FindRecordById(collection, e.Request.PathValue("id"))
+	if err != nil {
+		return nil, nil, firstApiError(err, e.NotFoundError("", err))
+	}
+
+	requestInfo, err := e.RequestInfo()
+	if err != nil {
+		return nil, nil, firstApiError(err, e.BadRequestError("", err))
+	}
+
+	if !requestInfo.HasSuperuserAuth() {
+		if collection.ViewRule == nil {
+			return nil, nil, e.ForbiddenError("Only superusers can perform this action.", nil)
+		}
+
+		if ok, _ := e.App.CanAccessRecord(organization, requestInfo, collection.ViewRule); !ok {
+			return nil, nil, e.NotFoundError("", nil)
+		}
+	}
+
+	return collection, organization, nil
+}
+
+func checkOrganizationManageRule(e *core.RequestEvent, collection *core.Collection, organization *core.Record) error {
+	requestInfo, err := e.RequestInfo()
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("", err))
+	}
+
+	if requestInfo.HasSuperuserAuth() {
+		return nil
+	}
+
+	if collection.Organization.ManageRule == nil {
+		return e.ForbiddenError("Only superusers can perform this action.", nil)
+	}
+
+	if ok, _ := e.App.CanAccessRecord(organization, requestInfo, collection.Organization.ManageRule); !ok {
+		return e.ForbiddenError("You are not allowed to manage the organization members.", nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package apis
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// RealtimeActionModerate is the realtime record message action
+// sent after a record moderation state change.
+const RealtimeActionModerate = "moderate"
+
+type recordModerateForm struct {
+	// Reviewer is the optional reviewer record id to assign
+	// (empty string unassigns the current reviewer).
+	Reviewer *string `form:"reviewer" json:"reviewer"`
+
+	State string `form:"state" json:"state"`
+}
+
+func (form *recordModerateForm) validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.State, validation.Required, validation.In(list.ToInterfaceSlice(core.ModerationStates)...)),
+	)
+}
+
+func recordModerate(e *core.RequestEvent) error {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return e.NotFoundError("Missing collection context.", err)
+	}
+
+	if !collection.Moderation.Enabled {
+		return e.BadRequestError("The collection doesn't have enabled moderation.", nil)
+	}
+
+	err = checkCollectionAPIAccess(e, collection, true)
+	if err != nil {
+		return err
+	}
+
+	record, err := e.App.FindRecordById(collection, e.Request.PathValue("id"))
+	if err != nil {
+		return firstApiError(err, e.NotFoundError("", err))
+	}
+
+	form := new(recordModerateForm)
+	if err = e.BindBody(form); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while loading the submitted data.", err))
+	}
+
+	if err = form.validate(); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while validating the submitted data.", err))
+	}
+
+	from := record.GetString(core.FieldNameModerationState)
+
+	rule, ok := collection.Moderation.TransitionRule(from, form.State)
+	if !ok {
+		return e.BadRequestError("The moderation state transition is not allowed.", nil)
+	}
+
+	rules := []*string{rule}
+
+	reviewer := record.GetString(core.FieldNameModerationReviewer)
+	if form.Reviewer != nil && *form.Reviewer != reviewer {
+		if collection.Moderation.ReviewersCollection == "" {
+			return e.BadRequestError("The collection doesn't have a reviewers collection.", nil)
+		}
+
+		reviewer = *form.Reviewer
+		rules = append(rules, collection.Moderation.ReviewRule)
+	}
+
+	requestInfo, err := e.RequestInfo()
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("", err))
+	}
+
+	if !requestInfo.HasSuperuserAuth() {
+		for _, r := range rules {
+			if r == nil {
+				return e.ForbiddenError("Only superusers can perform this action.", nil)
+			}
+
+			if ok, _ := e.App.CanAccessRecord(record, requestInfo, r); !ok {
+				return e.ForbiddenError("You are not allowed to perform this moderation transition.", nil)
+			}
+		}
+	}
+
+	err = e.App.ModerateRecordWithContext(e.Request.Context(), record, form.State, reviewer)
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to moderate record.", err))
+	}
+
+	err = EnrichRecord(e, record)
+	if err != nil {
+		return firstApiError(err, e.InternalServerError("Failed to enrich record", err))
+	}
+
+	return e.JSON(http.StatusOK, record)
+}
+
+// protectModerationFields ensures that the record moderation fields
+// could be changed only by superusers.
+//
+// For new records the default collection moderation state is set
+// (or "pending" if the record spam score reached the quarantine score).
+func protectModerationFields(e *core.RequestEvent, record *core.Record, hasSuperuserAuth bool) {
+	moderation := record.Collection().Moderation
+
+	if !moderation.Enabled {
+		return
+	}
+
+	if !hasSuperuserAuth {
+		original := record.Original()
+
+		for _, name := range []string{core.FieldNameModerationState, core.FieldNameModerationReviewer} {
+			if record.Collection().Fields.GetByName(name) == nil {
+				continue
+			}
+
+			if record.IsNew() {
+				record.Set(name, "")
+			} else {
+				record.Set(name, original.Get(name))
+			}
+		}
+
+		if record.IsNew() {
+			record.Set(core.FieldNameModerationState, moderation.DefaultState())
+		}
+	}
+
+	if record.IsNew() && moderation.QuarantineScore > 0 {
+		score, _ := e.Get(core.RequestEventKeySpamScore).(float64)
+		if score >= moderation.QuarantineScore {
+			record.Set(core.FieldNameModerationState, core.ModerationStatePending)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package apis
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type recordMoveForm struct {
+	// Field is the name of the "order" field to reorder.
+	//
+	// If empty, the record is moved in the collection tree hierarchy.
+	Field string `form:"field" json:"field"`
+
+	// Before is the id of the record to place the moved one in front of (order field only).
+	Before string `form:"before" json:"before"`
+
+	// After is the id of the record to place the moved one after (order field only).
+	//
+	// If both Before and After are empty, the record is moved at the end of its order scope.
+	After string `form:"after" json:"after"`
+
+	// Parent is the new parent record id (empty string for root).
+	Parent string `form:"parent" json:"parent"`
+
+	// Position is the new 1-based siblings position
+	// (zero or out of range values move the record as last sibling).
+	Position int `form:"position" json:"position"`
+}
+
+func (form *recordMoveForm) validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.After, validation.When(form.Before != "", validation.Empty.Error("Before and after cannot be set at the same time."))),
+		validation.Field(&form.Position, validation.Min(0)),
+	)
+}
+
+// recordMove moves the specified record in the ordering of an "order" field
+// (before/after another record) or under a new tree parent and/or siblings position.
+//
+// The request is guarded by the collection update API rule.
+func recordMove(e *core.RequestEvent) error {
+	collection, err := e.App.FindCachedCollectionByNameOrId(e.Request.PathValue("collection"))
+	if err != nil || collection == nil {
+		return e.NotFoundError("Missing collection context.", err)
+	}
+
+	err = checkCollectionAPIAccess(e, collection, true)
+	if err != nil {
+		return err
+	}
+
+	record, err := e.App.FindRecordById(collection, e.Request.PathValue("id"))
+	if err != nil {
+		return firstApiError(err, e.NotFoundError("", err))
+	}
+
+	form := new(recordMoveForm)
+	if err = e.BindBody(form); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while loading the submitted data.", err))
+	}
+
+	if err = form.validate(); err != nil {
+		return firstApiError(err, e.BadRequestError("An error occurred while validating the submitted data.", err))
+	}
+
+	if form.Field != "" {
+		if _, ok := collection.Fields.GetByName(form.Field).(*core.OrderField); !ok {
+			return e.BadRequestError("Missing or invalid order field.", nil)
+		}
+	} else if !collection.Tree.Enabled {
+		return e.BadRequestError("The collection doesn't have enabled tree hierarchy.", nil)
+	}
+
+	requestInfo, err := e.RequestInfo()
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("", err))
+	}
+
+	if !requestInfo.HasSuperuserAuth() {
+		if collection.UpdateRule == nil {
+			return e.ForbiddenError("Only superusers can perform this action.", nil)
+		}
+
+		if ok, _ := e.App.CanAccessRecord(record, requestInfo, collection.UpdateRule); !ok {
+			return e.NotFoundError("", nil)
+		}
+	}
+
+	if form.Field != "" {
+		if form.Before != "" {
+			err = e.App.MoveOrderedRecordWithContext(e.Request.Context(), record, form.Field, form.Before, false)
+		} else {
+			err = e.App.MoveOrderedRecordWithContext(e.Request.Context(), record, form.Field, form.After, true)
+		}
+	} else {
+		err = e.App.MoveTreeRecordWithContext(e.Request.Context(), record, form.Parent, form.Position)
+	}
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to move record.", err))
+	}
+
+	err = EnrichRecord(e, record)
+	if err != nil {
+		return firstApiError(err, e.InternalServerError("Failed to enrich record", err))
+	}
+
+	return e.JSON(http.StatusOK, record)
+}
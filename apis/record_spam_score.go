@@ -0,0 +1,41 @@
+package apis
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// scoreRecordCreate calculates the spam/abuse score of the new record
+// for collections with enabled SpamScore option.
+//
+// The score is stored in the request event store and, if configured,
+// in the collection score field. Superusers are not scored.
+func scoreRecordCreate(e *core.RequestEvent, record *core.Record) error {
+	collection := record.Collection()
+
+	if !collection.SpamScore.Enabled || e.HasSuperuserAuth() {
+		return nil
+	}
+
+	event := new(core.RecordCreateScoreEvent)
+	event.RequestEvent = e
+	event.Collection = collection
+	event.Record = record
+	event.Signals = map[string]float64{}
+
+	err := e.App.OnRecordCreateScore().Trigger(event, func(e *core.RecordCreateScoreEvent) error {
+		score := e.Score()
+
+		e.Set(core.RequestEventKeySpamScore, score)
+
+		if collection.SpamScore.ScoreField != "" {
+			e.Record.Set(collection.SpamScore.ScoreField, score)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return firstApiError(err, e.BadRequestError("Failed to create record.", err))
+	}
+
+	return nil
+}
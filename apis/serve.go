@@ -3,7 +3,9 @@ package apis
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
@@ -13,15 +15,20 @@ import (
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/acmedns"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/ui"
-	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 )
 
+// settingsSyncInterval is the interval for checking for settings
+// changes made by another process (see syncExternalSettingsChanges).
+const settingsSyncInterval = 5 * time.Second
+
 // ServeConfig defines a configuration struct for apis.Serve().
 type ServeConfig struct {
 	// ShowStartBanner indicates whether to show or hide the server start console message.
@@ -46,6 +53,60 @@ type ServeConfig struct {
 
 	// StaticRouteEnabled indicates whether to enable the default static route.
 	StaticRouteEnabled bool
+
+	// ReusePort enables SO_REUSEPORT on the server listener socket (unix only).
+	//
+	// This allows a new app process to bind to the same address and start
+	// accepting connections while the current one is still draining
+	// its in-flight requests, enabling deploys without downtime.
+	//
+	// Note that the option is ignored when the process is started with
+	// systemd socket activation (the passed socket is used as it is).
+	ReusePort bool
+
+	// ShutdownTimeout is the max duration to wait for the in-flight
+	// requests to complete on graceful shutdown (default to 1s).
+	//
+	// The long running requests like the realtime SSE connections are
+	// closed immediately so that the clients could reconnect to the new process.
+	ShutdownTimeout time.Duration
+
+	// HTTP3 enables serving HTTP/3 (QUIC) on the UDP port of HttpsAddr
+	// in addition to the HTTP/1.1 and HTTP/2 TCP server.
+	//
+	// The HTTP/3 availability is advertised to the clients with the Alt-Svc header.
+	//
+	// Note that the HTTP/3 server is available only when the app is built
+	// with the http3 tag (e.g. "go build -tags http3"), otherwise Serve returns an error.
+	HTTP3 bool
+
+	// TLSMinVersion is the minimum accepted TLS version of the HTTPS server
+	// (default to tls.VersionTLS12).
+	TLSMinVersion uint16
+
+	// TLSCipherSuites is an optional list of the enabled TLS 1.0-1.2 cipher suites.
+	//
+	// If not set, the Go default cipher suites list is used.
+	// Note that the TLS 1.3 cipher suites are not configurable.
+	TLSCipherSuites []uint16
+
+	// HSTSMaxAge enables the Strict-Transport-Security response header
+	// for the HTTPS requests with the specified max-age (0 disables the header).
+	HSTSMaxAge time.Duration
+
+	// HSTSIncludeSubdomains adds the "includeSubDomains" directive to the HSTS header.
+	HSTSIncludeSubdomains bool
+
+	// HSTSPreload adds the "preload" directive to the HSTS header.
+	HSTSPreload bool
+
+	// ClientAuth specifies the HTTPS server policy for the TLS client
+	// certificate authentication (default to tls.NoClientCert).
+	ClientAuth tls.ClientAuthType
+
+	// ClientCAs is the set of root certificate authorities used to
+	// verify the TLS client certificates.
+	ClientCAs *x509.CertPool
 }
 
 // Serve starts a new app web server.
@@ -64,6 +125,10 @@ func Serve(app core.App, config ServeConfig) error {
 		config.AllowedOrigins = []string{"*"}
 	}
 
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = 1 * time.Second
+	}
+
 	// ensure that the latest migrations are applied before starting the server
 	err := app.RunAllMigrations()
 	if err != nil {
@@ -76,8 +141,9 @@ func Serve(app core.App, config ServeConfig) error {
 	}
 
 	pbRouter.Bind(CORS(CORSConfig{
-		AllowOrigins: config.AllowedOrigins,
-		AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
+		AllowOrigins:  config.AllowedOrigins,
+		AllowMethods:  []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
+		ExposeHeaders: []string{"ETag"},
 	}))
 
 	if config.StaticRouteEnabled {
@@ -130,6 +196,19 @@ func Serve(app core.App, config ServeConfig) error {
 		pbRouter.Bind(wwwRedirect(wwwRedirects))
 	}
 
+	if config.HttpsAddr != "" && config.HSTSMaxAge > 0 {
+		pbRouter.Bind(hsts(config.HSTSMaxAge, config.HSTSIncludeSubdomains, config.HSTSPreload))
+	}
+
+	var h3Server http3Server
+	if config.HttpsAddr != "" && config.HTTP3 {
+		h3Server, err = newHTTP3Server()
+		if err != nil {
+			return fmt.Errorf("failed to initialize the HTTP/3 server (make sure that the app is built with the http3 tag): %w", err)
+		}
+		pbRouter.Bind(altSvc(h3Server))
+	}
+
 	certManager := &autocert.Manager{
 		Prompt:     autocert.AcceptTOS,
 		Cache:      autocert.DirCache(filepath.Join(app.DataDir(), core.LocalAutocertCacheDirName)),
@@ -141,12 +220,21 @@ func Serve(app core.App, config ServeConfig) error {
 	baseCtx, cancelBaseCtx := context.WithCancel(context.Background())
 	defer cancelBaseCtx()
 
+	// optional DNS-01 certificates (e.g. for wildcard domains)
+	getCertificate := certManager.GetCertificate
+	dnsCertManager := newACMEDNSManager(app, certManager.Cache)
+	if dnsCertManager != nil {
+		getCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			if dnsCertManager.HasDomain(hello.ServerName) {
+				return dnsCertManager.GetCertificate(hello)
+			}
+
+			return certManager.GetCertificate(hello)
+		}
+	}
+
 	server := &http.Server{
-		TLSConfig: &tls.Config{
-			MinVersion:     tls.VersionTLS12,
-			GetCertificate: certManager.GetCertificate,
-			NextProtos:     []string{acme.ALPNProto},
-		},
+		TLSConfig: newServeTLSConfig(config, getCertificate),
 		// higher defaults to accommodate large file uploads/downloads
 		WriteTimeout:      5 * time.Minute,
 		ReadTimeout:       5 * time.Minute,
@@ -172,13 +260,17 @@ func Serve(app core.App, config ServeConfig) error {
 		Func: func(te *core.TerminateEvent) error {
 			cancelBaseCtx()
 
-			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
 			defer cancel()
 
 			wg.Add(1)
 
 			_ = server.Shutdown(ctx)
 
+			if h3Server != nil {
+				_ = h3Server.Shutdown(ctx)
+			}
+
 			if te.IsRestart {
 				// wait for execve and other handlers up to 3 seconds before exit
 				time.AfterFunc(3*time.Second, func() {
@@ -243,7 +335,7 @@ func Serve(app core.App, config ServeConfig) error {
 		}
 
 		if e.Listener == nil {
-			listener, err = net.Listen("tcp", addr)
+			listener, err = newServeListener(addr, config.ReusePort)
 			if err != nil {
 				return err
 			}
@@ -288,6 +380,8 @@ func Serve(app core.App, config ServeConfig) error {
 		regular.Printf("└─ Dashboard: %s\n", color.CyanString("%s/_/", baseURL))
 	}
 
+	go syncExternalSettingsChanges(baseCtx, app, settingsSyncInterval)
+
 	var serveErr error
 	if config.HttpsAddr != "" {
 		if config.HttpAddr != "" {
@@ -295,6 +389,26 @@ func Serve(app core.App, config ServeConfig) error {
 			go http.ListenAndServe(config.HttpAddr, certManager.HTTPHandler(nil))
 		}
 
+		if dnsCertManager != nil {
+			go renewACMEDNSCertificate(baseCtx, app, dnsCertManager)
+		}
+
+		if h3Server != nil {
+			packetConn, err := net.ListenPacket("udp", listener.Addr().String())
+			if err != nil {
+				return err
+			}
+			defer packetConn.Close()
+
+			// start HTTP/3 server
+			go func() {
+				err := h3Server.Serve(packetConn, serveEvent.Server.Handler, serveEvent.Server.TLSConfig)
+				if err != nil && !errors.Is(err, http.ErrServerClosed) && !errors.Is(err, net.ErrClosed) {
+					app.Logger().Error("HTTP/3 server error", "error", err)
+				}
+			}()
+		}
+
 		// start HTTPS server
 		serveErr = serveEvent.Server.ServeTLS(listener, "", "")
 	} else {
@@ -308,6 +422,110 @@ func Serve(app core.App, config ServeConfig) error {
 	return nil
 }
 
+// newACMEDNSManager creates a new DNS-01 certificates manager
+// from the app ACME settings.
+//
+// Returns nil if the DNS-01 challenge is not configured.
+func newACMEDNSManager(app core.App, cache autocert.Cache) *acmedns.Manager {
+	config := app.Settings().ACME
+
+	var provider acmedns.Provider
+
+	switch config.DNSProvider {
+	case core.ACMEDNSProviderCloudflare:
+		provider = &acmedns.Cloudflare{
+			APIToken: config.Cloudflare.APIToken,
+		}
+	case core.ACMEDNSProviderRoute53:
+		provider = &acmedns.Route53{
+			HostedZoneId: config.Route53.HostedZoneId,
+			AccessKey:    config.Route53.AccessKey,
+			Secret:       config.Route53.Secret,
+		}
+	case core.ACMEDNSProviderRFC2136:
+		provider = &acmedns.RFC2136{
+			Nameserver:    config.RFC2136.Nameserver,
+			Zone:          config.RFC2136.Zone,
+			TSIGKey:       config.RFC2136.TSIGKey,
+			TSIGSecret:    config.RFC2136.TSIGSecret,
+			TSIGAlgorithm: config.RFC2136.TSIGAlgorithm,
+		}
+	default:
+		return nil
+	}
+
+	if len(config.Domains) == 0 {
+		return nil
+	}
+
+	return &acmedns.Manager{
+		Provider:     provider,
+		Domains:      config.Domains,
+		Email:        config.Email,
+		DirectoryURL: config.DirectoryURL,
+		Cache:        cache,
+	}
+}
+
+// renewACMEDNSCertificate obtains (if missing) and periodically
+// renews the DNS-01 certificate until ctx is canceled.
+func renewACMEDNSCertificate(ctx context.Context, app core.App, m *acmedns.Manager) {
+	ticker := time.NewTicker(12 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Renew(ctx); err != nil && ctx.Err() == nil {
+			app.Logger().Error("Failed to obtain or renew the DNS-01 certificate", "domains", m.Domains, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncExternalSettingsChanges periodically checks the stored settings
+// and reloads the app ones if they were changed by another process
+// (e.g. with the "settings set" or "maintenance" commands) until ctx is canceled.
+func syncExternalSettingsChanges(ctx context.Context, app core.App, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastUpdated := storedSettingsUpdated(app)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		updated := storedSettingsUpdated(app)
+		if updated == "" || updated == lastUpdated {
+			continue
+		}
+		lastUpdated = updated
+
+		if err := app.ReloadSettings(); err != nil {
+			app.Logger().Error("Failed to reload the externally changed settings", "error", err)
+		}
+	}
+}
+
+// storedSettingsUpdated returns the last modified date of the stored app settings.
+func storedSettingsUpdated(app core.App) string {
+	var updated string
+
+	_ = app.DB().Select("updated").
+		From((&core.Param{}).TableName()).
+		Where(dbx.HashExp{"id": app.Settings().PK()}).
+		Row(&updated)
+
+	return updated
+}
+
 // serverAddrToHost loosely converts http.Server.Addr string into a host to print.
 func serverAddrToHost(addr string) string {
 	if addr == "" || strings.HasSuffix(addr, ":http") || strings.HasSuffix(addr, ":https") {
@@ -0,0 +1,36 @@
+//go:build http3
+
+package apis
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/quic-go/quic-go/http3"
+)
+
+// newHTTP3Server creates a new QUIC based HTTP/3 server.
+func newHTTP3Server() (http3Server, error) {
+	return &quicServer{}, nil
+}
+
+type quicServer struct {
+	server http3.Server
+}
+
+func (s *quicServer) SetQUICHeaders(hdr http.Header) error {
+	return s.server.SetQUICHeaders(hdr)
+}
+
+func (s *quicServer) Serve(conn net.PacketConn, handler http.Handler, tlsConfig *tls.Config) error {
+	s.server.Handler = handler
+	s.server.TLSConfig = tlsConfig
+
+	return s.server.Serve(conn)
+}
+
+func (s *quicServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,10 @@
+//go:build !http3
+
+package apis
+
+import "errors"
+
+// newHTTP3Server is not supported without the http3 build tag.
+func newHTTP3Server() (http3Server, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,21 @@
+//go:build !http3
+
+package apis
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewHTTP3ServerWithoutTag(t *testing.T) {
+	t.Parallel()
+
+	server, err := newHTTP3Server()
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("Expected ErrUnsupported, got %v", err)
+	}
+
+	if server != nil {
+		t.Fatalf("Expected nil server, got %v", server)
+	}
+}
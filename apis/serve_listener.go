@@ -0,0 +1,59 @@
+package apis
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+)
+
+// systemdListenFdsStart is the first file descriptor passed
+// by the systemd socket activation (see sd_listen_fds(3)).
+const systemdListenFdsStart = 3
+
+// newServeListener creates the web server tcp listener.
+//
+// If the process was started with systemd socket activation, the
+// first passed socket is used instead of binding to the specified address.
+//
+// If reusePort is set, the listener socket is created with SO_REUSEPORT
+// allowing another process to bind to the same address, e.g. a new
+// app process taking over the traffic while the old one is still draining.
+func newServeListener(addr string, reusePort bool) (net.Listener, error) {
+	listener, err := systemdListener()
+	if listener != nil || err != nil {
+		return listener, err
+	}
+
+	if reusePort {
+		lc := net.ListenConfig{Control: reusePortControl}
+
+		return lc.Listen(context.Background(), "tcp", addr)
+	}
+
+	return net.Listen("tcp", addr)
+}
+
+// systemdListener returns the first systemd socket activation listener
+// or nil if the process wasn't started with socket activation.
+func systemdListener() (net.Listener, error) {
+	if os.Getenv("LISTEN_PID") != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+
+	fds, _ := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if fds < 1 {
+		return nil, nil
+	}
+
+	// unset the variables so that they aren't inherited by child processes
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	f := os.NewFile(systemdListenFdsStart, "LISTEN_FD_"+strconv.Itoa(systemdListenFdsStart))
+	defer f.Close()
+
+	// note: FileListener duplicates the file descriptor
+	return net.FileListener(f)
+}
@@ -0,0 +1,55 @@
+package apis
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestNewServeListenerReusePort(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "solaris" || runtime.GOOS == "illumos" {
+		t.Skip("SO_REUSEPORT is not supported")
+	}
+
+	l1, err := newServeListener("127.0.0.1:0", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l1.Close()
+
+	addr := l1.Addr().String()
+
+	// without SO_REUSEPORT
+	if l, err := newServeListener(addr, false); err == nil {
+		l.Close()
+		t.Fatalf("Expected bind error for %s without SO_REUSEPORT", addr)
+	}
+
+	// with SO_REUSEPORT
+	l2, err := newServeListener(addr, true)
+	if err != nil {
+		t.Fatalf("Expected the second listener to bind to %s, got %v", addr, err)
+	}
+	defer l2.Close()
+
+	// the old listener can be closed while the new one continues accepting connections
+	l1.Close()
+
+	if l2.Addr().String() != addr {
+		t.Fatalf("Expected address %s, got %s", addr, l2.Addr().String())
+	}
+}
+
+func TestSystemdListenerWithoutActivation(t *testing.T) {
+	t.Setenv("LISTEN_PID", "")
+	t.Setenv("LISTEN_FDS", "1")
+
+	l, err := systemdListener()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l != nil {
+		l.Close()
+		t.Fatal("Expected nil listener for non-matching LISTEN_PID")
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !unix || solaris
+
+package apis
+
+import (
+	"errors"
+	"syscall"
+)
+
+// reusePortControl is not supported on this platform.
+func reusePortControl(network, address string, c syscall.RawConn) error {
+	return errors.ErrUnsupported
+}
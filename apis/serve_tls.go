@@ -0,0 +1,133 @@
+package apis
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"golang.org/x/crypto/acme"
+)
+
+// newServeTLSConfig creates the TLS configuration of the HTTPS server.
+func newServeTLSConfig(config ServeConfig, getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)) *tls.Config {
+	minVersion := config.TLSMinVersion
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS12
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     minVersion,
+		CipherSuites:   config.TLSCipherSuites,
+		GetCertificate: getCertificate,
+		NextProtos:     []string{acme.ALPNProto},
+		ClientAuth:     config.ClientAuth,
+		ClientCAs:      config.ClientCAs,
+	}
+
+	if tlsConfig.ClientAuth != tls.NoClientCert {
+		// the ACME TLS-ALPN-01 validation requests don't have a client certificate
+		acmeConfig := tlsConfig.Clone()
+		acmeConfig.ClientAuth = tls.NoClientCert
+
+		tlsConfig.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			if len(hello.SupportedProtos) == 1 && hello.SupportedProtos[0] == acme.ALPNProto {
+				return acmeConfig, nil
+			}
+
+			return nil, nil // use the default config
+		}
+	}
+
+	return tlsConfig
+}
+
+// hsts returns a middleware that sets the Strict-Transport-Security header
+// for the TLS requests.
+func hsts(maxAge time.Duration, includeSubdomains bool, preload bool) *hook.Handler[*core.RequestEvent] {
+	value := "max-age=" + strconv.FormatInt(int64(maxAge.Seconds()), 10)
+	if includeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if preload {
+		value += "; preload"
+	}
+
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       DefaultHSTSMiddlewareId,
+		Priority: DefaultHSTSMiddlewarePriority,
+		Func: func(e *core.RequestEvent) error {
+			if e.IsTLS() {
+				e.Response.Header().Set("Strict-Transport-Security", value)
+			}
+
+			return e.Next()
+		},
+	}
+}
+
+// http3Server is the optional HTTP/3 server
+// (available only when built with the http3 tag).
+type http3Server interface {
+	SetQUICHeaders(hdr http.Header) error
+	Serve(conn net.PacketConn, handler http.Handler, tlsConfig *tls.Config) error
+	Shutdown(ctx context.Context) error
+}
+
+// altSvc returns a middleware that advertises the HTTP/3 server
+// port to the non HTTP/3 TLS requests.
+func altSvc(h3Server http3Server) *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       DefaultAltSvcMiddlewareId,
+		Priority: DefaultAltSvcMiddlewarePriority,
+		Func: func(e *core.RequestEvent) error {
+			if e.IsTLS() && e.Request.ProtoMajor < 3 {
+				// ignore the error in case the HTTP/3 server is not started yet
+				_ = h3Server.SetQUICHeaders(e.Response.Header())
+			}
+
+			return e.Next()
+		},
+	}
+}
+
+// TLSVersionByName returns the TLS version constant from its short
+// name ("1.0", "1.1", "1.2" or "1.3").
+func TLSVersionByName(name string) (uint16, bool) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "tls") {
+	case "1.0", "10":
+		return tls.VersionTLS10, true
+	case "1.1", "11":
+		return tls.VersionTLS11, true
+	case "1.2", "12":
+		return tls.VersionTLS12, true
+	case "1.3", "13":
+		return tls.VersionTLS13, true
+	}
+
+	return 0, false
+}
+
+// TLSCipherSuiteByName returns the id of the secure TLS 1.0-1.2
+// cipher suite with the specified name (e.g. "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384").
+//
+// The TLS 1.3 cipher suites are not configurable and are not matched.
+func TLSCipherSuiteByName(name string) (uint16, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name && slices.ContainsFunc(suite.SupportedVersions, func(v uint16) bool {
+			return v < tls.VersionTLS13
+		}) {
+			return suite.ID, true
+		}
+	}
+
+	return 0, false
+}
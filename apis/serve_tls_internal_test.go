@@ -0,0 +1,163 @@
+package apis
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"golang.org/x/crypto/acme"
+)
+
+func TestNewServeTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		c := newServeTLSConfig(ServeConfig{}, nil)
+
+		if c.MinVersion != tls.VersionTLS12 {
+			t.Fatalf("Expected MinVersion %d, got %d", tls.VersionTLS12, c.MinVersion)
+		}
+
+		if c.ClientAuth != tls.NoClientCert {
+			t.Fatalf("Expected no client auth, got %v", c.ClientAuth)
+		}
+
+		if c.GetConfigForClient != nil {
+			t.Fatal("Expected nil GetConfigForClient")
+		}
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		c := newServeTLSConfig(ServeConfig{
+			TLSMinVersion:   tls.VersionTLS13,
+			TLSCipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+			ClientAuth:      tls.RequireAndVerifyClientCert,
+		}, nil)
+
+		if c.MinVersion != tls.VersionTLS13 {
+			t.Fatalf("Expected MinVersion %d, got %d", tls.VersionTLS13, c.MinVersion)
+		}
+
+		if len(c.CipherSuites) != 1 || c.CipherSuites[0] != tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384 {
+			t.Fatalf("Expected the custom cipher suite, got %v", c.CipherSuites)
+		}
+
+		if c.ClientAuth != tls.RequireAndVerifyClientCert {
+			t.Fatalf("Expected client auth %v, got %v", tls.RequireAndVerifyClientCert, c.ClientAuth)
+		}
+
+		// regular client
+		regular, err := c.GetConfigForClient(&tls.ClientHelloInfo{SupportedProtos: []string{"h2", "http/1.1"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if regular != nil {
+			t.Fatal("Expected the default config for the regular clients")
+		}
+
+		// ACME TLS-ALPN-01 validation
+		acmeConfig, err := c.GetConfigForClient(&tls.ClientHelloInfo{SupportedProtos: []string{acme.ALPNProto}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acmeConfig == nil || acmeConfig.ClientAuth != tls.NoClientCert {
+			t.Fatal("Expected config without client auth for the ACME validation requests")
+		}
+	})
+}
+
+func TestHSTS(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name              string
+		tls               bool
+		includeSubdomains bool
+		preload           bool
+		expected          string
+	}{
+		{"non-TLS request", false, true, true, ""},
+		{"TLS request", true, false, false, "max-age=3600"},
+		{"TLS request with includeSubDomains", true, true, false, "max-age=3600; includeSubDomains"},
+		{"TLS request with all directives", true, true, true, "max-age=3600; includeSubDomains; preload"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if s.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			rec := httptest.NewRecorder()
+
+			e := new(core.RequestEvent)
+			e.Request = req
+			e.Response = rec
+
+			err := hsts(time.Hour, s.includeSubdomains, s.preload).Func(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v := rec.Header().Get("Strict-Transport-Security"); v != s.expected {
+				t.Fatalf("Expected header %q, got %q", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestTLSVersionByName(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name     string
+		expected uint16
+		ok       bool
+	}{
+		{"", 0, false},
+		{"1.4", 0, false},
+		{"1.0", tls.VersionTLS10, true},
+		{"1.1", tls.VersionTLS11, true},
+		{"1.2", tls.VersionTLS12, true},
+		{" TLS1.3 ", tls.VersionTLS13, true},
+		{"tls13", tls.VersionTLS13, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			v, ok := TLSVersionByName(s.name)
+			if ok != s.ok || v != s.expected {
+				t.Fatalf("Expected (%d, %v), got (%d, %v)", s.expected, s.ok, v, ok)
+			}
+		})
+	}
+}
+
+func TestTLSCipherSuiteByName(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name     string
+		expected uint16
+		ok       bool
+	}{
+		{"", 0, false},
+		{"missing", 0, false},
+		{"TLS_AES_128_GCM_SHA256", 0, false},   // TLS 1.3
+		{"TLS_RSA_WITH_RC4_128_SHA", 0, false}, // insecure
+		{"tls_ecdhe_ecdsa_with_aes_256_gcm_sha384", tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, true},
+		{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256", tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			v, ok := TLSCipherSuiteByName(s.name)
+			if ok != s.ok || v != s.expected {
+				t.Fatalf("Expected (%d, %v), got (%d, %v)", s.expected, s.ok, v, ok)
+			}
+		})
+	}
+}
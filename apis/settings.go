@@ -1,7 +1,9 @@
 package apis
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/pocketbase/core"
@@ -17,6 +19,7 @@ func bindSettingsApi(app core.App, rg *router.RouterGroup[*core.RequestEvent]) {
 	subGroup.POST("/test/s3", settingsTestS3)
 	subGroup.POST("/test/email", settingsTestEmail)
 	subGroup.POST("/apple/generate-client-secret", settingsGenerateAppleClientSecret)
+	subGroup.POST("/data-retention/run", settingsRunDataRetention)
 }
 
 func settingsList(e *core.RequestEvent) error {
@@ -141,3 +144,26 @@ func settingsGenerateAppleClientSecret(e *core.RequestEvent) error {
 		"secret": secret,
 	})
 }
+
+type dataRetentionRunForm struct {
+	// DryRun reports the number of the expired records
+	// without actually processing them.
+	DryRun bool `form:"dryRun" json:"dryRun"`
+}
+
+func settingsRunDataRetention(e *core.RequestEvent) error {
+	form := new(dataRetentionRunForm)
+	if err := e.BindBody(form); err != nil {
+		return e.BadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	result, err := e.App.RunDataRetention(ctx, form.DryRun)
+	if err != nil {
+		return e.BadRequestError("Failed to run the data retention rules.", err)
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewBackupCommand creates and returns new command for managing
+// the app backups (currently only verify).
+func NewBackupCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "backup",
+		Short: "Manage app backups",
+	}
+
+	command.AddCommand(backupVerifyCommand(app))
+
+	return command
+}
+
+func backupVerifyCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "verify",
+		Example:      "backup verify pb_backup_20240101000000.zip",
+		Short:        "Verifies that a backup could be restored by extracting it and running integrity checks",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 || args[0] == "" {
+				return errors.New("missing backup name argument")
+			}
+
+			result, err := app.VerifyBackup(context.Background(), args[0])
+			if err != nil {
+				return fmt.Errorf("backup %q verification failed: %w", args[0], err)
+			}
+
+			names := make([]string, 0, len(result.Collections))
+			for name := range result.Collections {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+
+			for _, name := range names {
+				fmt.Printf("%s: %d records\n", name, result.Collections[name])
+			}
+
+			color.Green("Backup %q is valid (verified in %s)!", result.Name, result.Duration)
+			return nil
+		},
+	}
+
+	return command
+}
@@ -0,0 +1,586 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cobra"
+)
+
+// benchOps lists the supported optional benchmark operations.
+//
+// The "create" and "delete" operations are always executed because
+// they are used to generate and cleanup the synthetic records.
+var benchOps = []string{"view", "list", "update", "realtime"}
+
+// NewBenchCommand creates and returns new command for benchmarking
+// the records CRUD, list and realtime throughput of a running app instance.
+func NewBenchCommand() *cobra.Command {
+	var baseURL string
+	var token string
+	var email string
+	var password string
+	var collection string
+	var records int
+	var concurrency int
+	var ops []string
+	var keep bool
+	var asJSON bool
+
+	command := &cobra.Command{
+		Use:          "bench",
+		Example:      "bench --url=https://example.com --email=test@example.com --password=1234567890 --collection=posts --records=1000 --concurrency=20 --ops=view,list,update,realtime",
+		Short:        "Measures the records CRUD, list and realtime throughput of a running instance",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if collection == "" {
+				return errors.New("missing --collection")
+			}
+
+			if records < 1 {
+				return errors.New("the records must be at least 1")
+			}
+
+			if concurrency < 1 {
+				return errors.New("the concurrency must be at least 1")
+			}
+
+			for _, op := range ops {
+				if !slices.Contains(benchOps, op) {
+					return fmt.Errorf("unsupported operation %q", op)
+				}
+			}
+
+			b := &benchRunner{
+				client:      &http.Client{Timeout: time.Minute},
+				baseURL:     strings.TrimRight(baseURL, "/"),
+				token:       token,
+				collection:  collection,
+				concurrency: concurrency,
+			}
+
+			if b.token == "" {
+				if email == "" || password == "" {
+					return errors.New("missing --token or --email and --password superuser credentials")
+				}
+
+				var err error
+				b.token, err = profileSuperuserAuth(b.client, b.baseURL, email, password)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := b.loadCollection(); err != nil {
+				return err
+			}
+
+			results, err := b.run(records, ops, keep)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				encoder := json.NewEncoder(command.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(results)
+			}
+
+			w := command.OutOrStdout()
+
+			printBenchResults(w, results)
+
+			if failed := slices.ContainsFunc(results, func(r *benchResult) bool { return r.Errors > 0 }); failed {
+				color.New(color.FgYellow).Fprintln(w, "Some of the requests failed (see the errors column).")
+			} else {
+				color.New(color.FgGreen).Fprintf(w, "Successfully completed the %q benchmark.\n", collection)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&baseURL, "url", "http://127.0.0.1:8090", "the running app instance base url")
+	command.Flags().StringVar(&token, "token", os.Getenv("PB_SUPERUSER_TOKEN"), "superuser auth token (default to the PB_SUPERUSER_TOKEN env variable)")
+	command.Flags().StringVar(&email, "email", "", "superuser email (used if --token is not set)")
+	command.Flags().StringVar(&password, "password", "", "superuser password (used if --token is not set)")
+	command.Flags().StringVar(&collection, "collection", "", "the name or id of the collection where to generate the synthetic records")
+	command.Flags().IntVar(&records, "records", 1000, "the number of synthetic records to generate (aka. the number of requests per operation)")
+	command.Flags().IntVar(&concurrency, "concurrency", 10, "the number of concurrent requests")
+	command.Flags().StringSliceVar(&ops, "ops", []string{"view", "list", "update"}, "comma separated list of the operations to measure in addition to create and delete (view, list, update, realtime)")
+	command.Flags().BoolVar(&keep, "keep", false, "keep the generated records instead of deleting them at the end")
+	command.Flags().BoolVar(&asJSON, "json", false, "print the results as JSON")
+
+	return command
+}
+
+// benchResult defines the measurements of a single benchmark operation.
+type benchResult struct {
+	Op       string  `json:"op"`
+	Requests int     `json:"requests"`
+	Errors   int     `json:"errors"`
+	Duration float64 `json:"duration"` // in seconds
+	RPS      float64 `json:"rps"`
+	P50      float64 `json:"p50"` // in milliseconds
+	P90      float64 `json:"p90"` // in milliseconds
+	P99      float64 `json:"p99"` // in milliseconds
+	Max      float64 `json:"max"` // in milliseconds
+}
+
+type benchField struct {
+	Name                string   `json:"name"`
+	Type                string   `json:"type"`
+	System              bool     `json:"system"`
+	Min                 any      `json:"min"`
+	Max                 any      `json:"max"`
+	OnlyInt             bool     `json:"onlyInt"`
+	AutogeneratePattern string   `json:"autogeneratePattern"`
+	PrimaryKey          bool     `json:"primaryKey"`
+	Values              []string `json:"values"`
+	MaxSelect           int      `json:"maxSelect"`
+}
+
+type benchRunner struct {
+	client      *http.Client
+	baseURL     string
+	token       string
+	collection  string
+	concurrency int
+	fields      []benchField
+}
+
+func (b *benchRunner) recordsURL() string {
+	return b.baseURL + "/api/collections/" + url.PathEscape(b.collection) + "/records"
+}
+
+// loadCollection fetches the target collection fields schema.
+func (b *benchRunner) loadCollection() error {
+	res, err := b.request(http.MethodGet, b.baseURL+"/api/collections/"+url.PathEscape(b.collection), nil)
+	if err != nil {
+		return fmt.Errorf("failed to load collection %q: %w", b.collection, err)
+	}
+
+	data := struct {
+		Type   string       `json:"type"`
+		Fields []benchField `json:"fields"`
+	}{}
+	if err := json.Unmarshal(res, &data); err != nil {
+		return err
+	}
+
+	if data.Type == core.CollectionTypeView {
+		return fmt.Errorf("collection %q is a view and cannot be benchmarked", b.collection)
+	}
+
+	b.fields = data.Fields
+
+	return nil
+}
+
+func (b *benchRunner) run(records int, ops []string, keep bool) ([]*benchResult, error) {
+	ids := make([]string, records)
+	results := []*benchResult{}
+
+	create := b.measure("create", records, b.concurrency, func(i int) error {
+		res, err := b.request(http.MethodPost, b.recordsURL(), b.generateData(true))
+		if err != nil {
+			return err
+		}
+
+		record := struct {
+			Id string `json:"id"`
+		}{}
+		if err := json.Unmarshal(res, &record); err != nil {
+			return err
+		}
+		ids[i] = record.Id
+
+		return nil
+	})
+	results = append(results, create)
+
+	ids = slices.DeleteFunc(ids, func(id string) bool { return id == "" })
+	if len(ids) == 0 {
+		return results, fmt.Errorf("failed to create any of the %q records (%d errors)", b.collection, create.Errors)
+	}
+
+	var opsErr error
+
+	for _, op := range benchOps {
+		if !slices.Contains(ops, op) {
+			continue
+		}
+
+		switch op {
+		case "view":
+			results = append(results, b.measure(op, len(ids), b.concurrency, func(i int) error {
+				_, err := b.request(http.MethodGet, b.recordsURL()+"/"+url.PathEscape(ids[i]), nil)
+				return err
+			}))
+		case "list":
+			perPage := 30
+			pages := max(1, len(ids)/perPage)
+			results = append(results, b.measure(op, len(ids), b.concurrency, func(i int) error {
+				query := url.Values{}
+				query.Set("page", strconv.Itoa(i%pages+1))
+				query.Set("perPage", strconv.Itoa(perPage))
+				query.Set("skipTotal", "1")
+				_, err := b.request(http.MethodGet, b.recordsURL()+"?"+query.Encode(), nil)
+				return err
+			}))
+		case "update":
+			results = append(results, b.measure(op, len(ids), b.concurrency, func(i int) error {
+				_, err := b.request(http.MethodPatch, b.recordsURL()+"/"+url.PathEscape(ids[i]), b.generateData(false))
+				return err
+			}))
+		case "realtime":
+			result, err := b.measureRealtime(ids)
+			if err != nil {
+				opsErr = err
+			} else {
+				results = append(results, result)
+			}
+		}
+
+		if opsErr != nil {
+			break // still cleanup the generated records
+		}
+	}
+
+	if !keep {
+		results = append(results, b.measure("delete", len(ids), b.concurrency, func(i int) error {
+			_, err := b.request(http.MethodDelete, b.recordsURL()+"/"+url.PathEscape(ids[i]), nil)
+			return err
+		}))
+	}
+
+	return results, opsErr
+}
+
+// measure calls fn n times with the specified concurrency and
+// returns the aggregated throughput and latency measurements.
+func (b *benchRunner) measure(op string, n int, concurrency int, fn func(i int) error) *benchResult {
+	var mu sync.Mutex
+	var errs int
+	latencies := make([]time.Duration, 0, n)
+
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+
+	start := time.Now()
+
+	for range min(concurrency, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				reqStart := time.Now()
+				err := fn(i)
+				latency := time.Since(reqStart)
+
+				mu.Lock()
+				if err != nil {
+					errs++
+				} else {
+					latencies = append(latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range n {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return newBenchResult(op, n, errs, time.Since(start), latencies)
+}
+
+// measureRealtime subscribes to the collection record changes and
+// measures the time from sending a record update until receiving its
+// realtime event (the updates are sent sequentially).
+func (b *benchRunner) measureRealtime(ids []string) (*benchResult, error) {
+	// no client timeout because the connection is long-lived
+	req, err := http.NewRequest(http.MethodGet, b.baseURL+"/api/realtime", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := (&http.Client{}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the realtime server: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to connect to the realtime server (status %d)", res.StatusCode)
+	}
+
+	events := make(chan benchRealtimeEvent, 100)
+	go readBenchRealtimeEvents(res.Body, events)
+
+	var clientId string
+	select {
+	case e, ok := <-events:
+		if !ok || e.name != "PB_CONNECT" {
+			return nil, errors.New("missing realtime PB_CONNECT event")
+		}
+		clientId = e.data.ClientId
+	case <-time.After(10 * time.Second):
+		return nil, errors.New("realtime PB_CONNECT event timeout")
+	}
+
+	topic := b.collection + "/*"
+	if _, err := b.request(http.MethodPost, b.baseURL+"/api/realtime", map[string]any{
+		"clientId":      clientId,
+		"subscriptions": []string{topic},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %q: %w", topic, err)
+	}
+
+	return b.measure("realtime", len(ids), 1, func(i int) error {
+		if _, err := b.request(http.MethodPatch, b.recordsURL()+"/"+url.PathEscape(ids[i]), b.generateData(false)); err != nil {
+			return err
+		}
+
+		timeout := time.After(10 * time.Second)
+		for {
+			select {
+			case e, ok := <-events:
+				if !ok {
+					return errors.New("the realtime connection was closed")
+				}
+				if e.name == topic && e.data.Action == "update" && e.data.Record.Id == ids[i] {
+					return nil
+				}
+			case <-timeout:
+				return fmt.Errorf("realtime event timeout for record %q", ids[i])
+			}
+		}
+	}), nil
+}
+
+type benchRealtimeEvent struct {
+	name string
+	data struct {
+		ClientId string `json:"clientId"`
+		Action   string `json:"action"`
+		Record   struct {
+			Id string `json:"id"`
+		} `json:"record"`
+	}
+}
+
+// readBenchRealtimeEvents parses the SSE stream messages until the reader is closed.
+func readBenchRealtimeEvents(r io.Reader, events chan<- benchRealtimeEvent) {
+	defer close(events)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10<<20)
+
+	var event benchRealtimeEvent
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if event.name != "" {
+				events <- event
+			}
+			event = benchRealtimeEvent{}
+		case strings.HasPrefix(line, "event:"):
+			event.name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), &event.data)
+		}
+	}
+}
+
+// request sends an authorized JSON request and returns the response body
+// (non-2xx responses are returned as error).
+func (b *benchRunner) request(method string, reqURL string, body any) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, reqURL, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", b.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("status %d: %s", res.StatusCode, raw)
+	}
+
+	return raw, nil
+}
+
+// generateData generates synthetic record data based on the collection fields.
+//
+// Relation, file, autodate and the other system managed fields are skipped.
+// The auth collections password fields are generated only for new records.
+func (b *benchRunner) generateData(isNew bool) map[string]any {
+	data := map[string]any{}
+
+	for _, f := range b.fields {
+		if f.PrimaryKey || f.AutogeneratePattern != "" {
+			continue
+		}
+
+		switch f.Type {
+		case core.FieldTypeText:
+			minLen, _ := benchNumber(f.Min)
+			maxLen, hasMax := benchNumber(f.Max)
+			length := max(10, int(minLen))
+			if hasMax && maxLen > 0 {
+				length = min(length, int(maxLen))
+			}
+			data[f.Name] = "bench_" + security.RandomString(max(0, length-6))
+		case core.FieldTypeEditor:
+			data[f.Name] = "<p>bench " + security.RandomString(20) + "</p>"
+		case core.FieldTypeNumber:
+			minVal, hasMin := benchNumber(f.Min)
+			maxVal, hasMax := benchNumber(f.Max)
+			if !hasMin {
+				minVal = 0
+			}
+			if !hasMax {
+				maxVal = minVal + 1000
+			}
+			v := minVal + rand.Float64()*(maxVal-minVal)
+			if f.OnlyInt {
+				v = math.Floor(v)
+			}
+			data[f.Name] = v
+		case core.FieldTypeBool:
+			data[f.Name] = rand.IntN(2) == 1
+		case core.FieldTypeEmail:
+			if f.System && !isNew {
+				continue // don't change the auth record email
+			}
+			data[f.Name] = "bench_" + strings.ToLower(security.RandomString(15)) + "@example.com"
+		case core.FieldTypeURL:
+			data[f.Name] = "https://example.com/bench/" + security.RandomString(10)
+		case core.FieldTypeDate:
+			data[f.Name] = time.Now().UTC().Add(-time.Duration(rand.IntN(86400)) * time.Second).Format(time.RFC3339)
+		case core.FieldTypeSelect:
+			if len(f.Values) == 0 {
+				continue
+			}
+			value := f.Values[rand.IntN(len(f.Values))]
+			if f.MaxSelect > 1 {
+				data[f.Name] = []string{value}
+			} else {
+				data[f.Name] = value
+			}
+		case core.FieldTypeJSON:
+			data[f.Name] = map[string]any{"bench": security.RandomString(10)}
+		case core.FieldTypeGeoPoint:
+			data[f.Name] = map[string]float64{
+				"lon": rand.Float64()*360 - 180,
+				"lat": rand.Float64()*180 - 90,
+			}
+		case core.FieldTypePassword:
+			if !isNew {
+				continue
+			}
+			minLen, _ := benchNumber(f.Min)
+			pass := security.RandomString(max(16, int(minLen)))
+			data[f.Name] = pass
+			data[f.Name+"Confirm"] = pass
+		}
+	}
+
+	return data
+}
+
+func benchNumber(v any) (float64, bool) {
+	n, ok := v.(float64)
+	return n, ok
+}
+
+func newBenchResult(op string, requests int, errs int, duration time.Duration, latencies []time.Duration) *benchResult {
+	result := &benchResult{
+		Op:       op,
+		Requests: requests,
+		Errors:   errs,
+		Duration: duration.Seconds(),
+	}
+
+	if duration > 0 {
+		result.RPS = float64(requests-errs) / duration.Seconds()
+	}
+
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		result.P50 = benchPercentile(latencies, 50)
+		result.P90 = benchPercentile(latencies, 90)
+		result.P99 = benchPercentile(latencies, 99)
+		result.Max = benchMilliseconds(latencies[len(latencies)-1])
+	}
+
+	return result
+}
+
+// benchPercentile returns the nearest-rank percentile of the sorted latencies in milliseconds.
+func benchPercentile(sorted []time.Duration, p float64) float64 {
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+
+	return benchMilliseconds(sorted[rank])
+}
+
+func benchMilliseconds(d time.Duration) float64 {
+	return math.Round(float64(d.Microseconds())/10) / 100
+}
+
+func printBenchResults(w io.Writer, results []*benchResult) {
+	fmt.Fprintf(w, "%-10s %10s %8s %10s %10s %10s %10s %10s %10s\n", "op", "requests", "errors", "duration", "req/s", "p50", "p90", "p99", "max")
+	for _, r := range results {
+		fmt.Fprintf(
+			w,
+			"%-10s %10d %8d %9.2fs %10.1f %8.2fms %8.2fms %8.2fms %8.2fms\n",
+			r.Op, r.Requests, r.Errors, r.Duration, r.RPS, r.P50, r.P90, r.P99, r.Max,
+		)
+	}
+}
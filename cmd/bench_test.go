@@ -0,0 +1,178 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestBenchCommand(t *testing.T) {
+	t.Parallel()
+
+	posts := core.NewBaseCollection("posts")
+	posts.Fields.Add(
+		&core.TextField{Name: "title", Required: true, Max: 8},
+		&core.NumberField{Name: "views", Min: types.Pointer(10.0), Max: types.Pointer(20.0), OnlyInt: true},
+		&core.BoolField{Name: "active"},
+		&core.SelectField{Name: "status", Values: []string{"a", "b"}, MaxSelect: 1, Required: true},
+		&core.EmailField{Name: "contact", Required: true},
+		&core.JSONField{Name: "meta"},
+	)
+
+	app := tests.NewTestAppBuilder(t).InMemory().WithCollections(posts).Build()
+
+	superusers, err := app.FindCachedCollectionByNameOrId(core.CollectionNameSuperusers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	superuser := core.NewRecord(superusers)
+	superuser.SetEmail("test@example.com")
+	superuser.SetPassword("1234567890")
+	if err := app.Save(superuser); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := superuser.NewAuthToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := apis.NewRouter(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux, err := r.BuildMux()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	countPosts := func() int64 {
+		total, err := app.CountRecords("posts")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return total
+	}
+
+	scenarios := []struct {
+		name          string
+		args          []string
+		expectError   bool
+		expectedOps   []string
+		expectedPosts int64
+	}{
+		{
+			"missing collection",
+			[]string{"--token=" + token},
+			true,
+			nil,
+			0,
+		},
+		{
+			"unsupported operation",
+			[]string{"--token=" + token, "--collection=posts", "--ops=missing"},
+			true,
+			nil,
+			0,
+		},
+		{
+			"missing credentials",
+			[]string{"--token=", "--collection=posts"},
+			true,
+			nil,
+			0,
+		},
+		{
+			"invalid token",
+			[]string{"--token=invalid", "--collection=posts"},
+			true,
+			nil,
+			0,
+		},
+		{
+			"missing target collection",
+			[]string{"--token=" + token, "--collection=missing"},
+			true,
+			nil,
+			0,
+		},
+		{
+			"all operations with cleanup",
+			[]string{"--token=" + token, "--collection=posts", "--records=20", "--concurrency=4", "--ops=view,list,update,realtime"},
+			false,
+			[]string{"create", "view", "list", "update", "realtime", "delete"},
+			0,
+		},
+		{
+			"email/password auth with kept records",
+			[]string{"--email=test@example.com", "--password=1234567890", "--collection=posts", "--records=5", "--ops=list", "--keep"},
+			false,
+			[]string{"create", "list"},
+			5,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+
+			command := cmd.NewBenchCommand()
+			command.SetOut(out)
+			command.SetArgs(append([]string{"--url=" + server.URL, "--json"}, s.args...))
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			results := []struct {
+				Op       string  `json:"op"`
+				Requests int     `json:"requests"`
+				Errors   int     `json:"errors"`
+				P50      float64 `json:"p50"`
+				Max      float64 `json:"max"`
+			}{}
+			if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+				t.Fatalf("Failed to unmarshal output: %v\n%s", err, out.String())
+			}
+
+			if len(results) != len(s.expectedOps) {
+				t.Fatalf("Expected %d results, got %d\n%s", len(s.expectedOps), len(results), out.String())
+			}
+
+			for i, r := range results {
+				if r.Op != s.expectedOps[i] {
+					t.Errorf("Expected result %d to be %q, got %q", i, s.expectedOps[i], r.Op)
+				}
+				if r.Errors != 0 {
+					t.Errorf("Expected no %s errors, got %d", r.Op, r.Errors)
+				}
+				if r.Requests == 0 || r.Max < r.P50 {
+					t.Errorf("Invalid %s measurements: %+v", r.Op, r)
+				}
+			}
+
+			if total := countPosts(); total != s.expectedPosts {
+				t.Fatalf("Expected %d posts, got %d", s.expectedPosts, total)
+			}
+		})
+	}
+}
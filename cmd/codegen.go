@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// codegenHeader is the first line of all generated files.
+//
+// It is also used to detect the stale generated files that should be removed on regeneration.
+const codegenHeader = "Code generated by pocketbase codegen. DO NOT EDIT."
+
+// NewCodegenCommand creates and returns new command for generating
+// typed models from the app collections.
+func NewCodegenCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "codegen",
+		Short: "Generates typed models from the app collections",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	command.AddCommand(codegenGoCommand(app))
+	command.AddCommand(codegenTSCommand(app))
+
+	return command
+}
+
+func codegenGoCommand(app core.App) *cobra.Command {
+	var out string
+	var pkg string
+
+	command := &cobra.Command{
+		Use:          "go",
+		Example:      "codegen go --out ./models",
+		Short:        "Generates typed Go record proxies for the app collections",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if pkg == "" {
+				pkg = goPackageName(out)
+			}
+
+			files, err := generateGoModels(app, pkg)
+			if err != nil {
+				return err
+			}
+
+			if err := writeCodegenFiles(out, ".go", files); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(command.OutOrStdout(), "Successfully generated %d file(s) in %q.\n", len(files), out)
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&out, "out", "./models", "the output directory of the generated files")
+	command.Flags().StringVar(&pkg, "package", "", "the generated files package name (default to the output directory name)")
+
+	return command
+}
+
+// writeCodegenFiles writes the generated files in the specified directory and
+// removes the previously generated files with the same extension that are no longer needed
+// (e.g. because their collection was deleted).
+func writeCodegenFiles(dir string, ext string, files map[string][]byte) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create the output directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ext {
+			continue
+		}
+
+		if _, ok := files[entry.Name()]; ok {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if isCodegenFile(path) {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to delete stale generated file %q: %w", path, err)
+			}
+		}
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %q: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// isCodegenFile checks whether the first line of the specified file contains the codegen header.
+func isCodegenFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	line, _ := bufio.NewReader(f).ReadBytes('\n')
+
+	return bytes.Contains(line, []byte(codegenHeader))
+}
+
+// codegenCollections returns the non-system app collections sorted by their name.
+func codegenCollections(app core.App) ([]*core.Collection, error) {
+	collections := []*core.Collection{}
+
+	err := app.CollectionQuery().OrderBy("name ASC").All(&collections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the app collections: %w", err)
+	}
+
+	return slices.DeleteFunc(collections, func(c *core.Collection) bool {
+		return c.System
+	}), nil
+}
+
+var identifierPartsRegex = regexp.MustCompile(`[A-Za-z][a-z0-9]*|[0-9]+|[A-Z]+`)
+
+// pascalCase converts the specified collection or field name
+// (e.g. "rel_one", "_superusers", "fooBar") into exported identifier.
+func pascalCase(name string) string {
+	var sb strings.Builder
+
+	for _, part := range identifierPartsRegex.FindAllString(name, -1) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+
+	result := sb.String()
+	if result == "" || unicode.IsDigit([]rune(result)[0]) {
+		result = "X" + result
+	}
+
+	return result
+}
+
+// goPackageName returns a valid Go package name from the specified directory path.
+func goPackageName(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err == nil {
+		dir = abs
+	}
+
+	name := strings.ToLower(filepath.Base(dir))
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, name)
+
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "models" + name
+	}
+
+	return name
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"go/format"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// goAuthSystemFields lists the auth collection fields that already
+// have dedicated typed methods in the [core.Record] model.
+var goAuthSystemFields = []string{
+	core.FieldNameEmail,
+	core.FieldNameEmailVisibility,
+	core.FieldNameVerified,
+	core.FieldNamePassword,
+	core.FieldNameTokenKey,
+}
+
+type goField struct {
+	field  core.Field
+	name   string // the method name (e.g. "Title")
+	typ    string // the Go type (e.g. "string")
+	getter string // the getter expression format (e.g. `m.GetString(%s)`)
+}
+
+// generateGoModels generates a typed Go record proxy file for each non-system app collection.
+//
+// The returned map keys are the generated file names.
+func generateGoModels(app core.App, pkg string) (map[string][]byte, error) {
+	collections, err := codegenCollections(app)
+	if err != nil {
+		return nil, err
+	}
+
+	// reserve the type and helper names of each collection to avoid collisions
+	usedNames := map[string]bool{}
+	typeNames := make([]string, len(collections))
+	for i, c := range collections {
+		typeNames[i] = uniqueName(usedNames, pascalCase(c.Name), "Record")
+		for _, helper := range []string{"New%s", "Wrap%s", "Query%s", "Find%sById", "Find%sByFilter"} {
+			usedNames[fmt.Sprintf(helper, typeNames[i])] = true
+		}
+	}
+
+	files := make(map[string][]byte, len(collections))
+
+	for i, c := range collections {
+		src, err := generateGoModel(c, typeNames[i], pkg, usedNames)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %q model: %w", c.Name, err)
+		}
+
+		files[strings.ToLower(strings.Trim(c.Name, "_"))+".go"] = src
+	}
+
+	return files, nil
+}
+
+func generateGoModel(collection *core.Collection, typeName string, pkg string, usedNames map[string]bool) ([]byte, error) {
+	fields := goFields(collection)
+
+	imports := []string{`"github.com/pocketbase/dbx"`, `"github.com/pocketbase/pocketbase/core"`}
+	for _, f := range fields {
+		if strings.HasPrefix(f.typ, "types.") {
+			imports = append(imports, `"github.com/pocketbase/pocketbase/tools/types"`)
+			break
+		}
+	}
+
+	collectionConst := uniqueName(usedNames, typeName+"Collection", "Name")
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "// %s\n\n", codegenHeader)
+	fmt.Fprintf(&sb, "package %s\n\n", pkg)
+	fmt.Fprintf(&sb, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+
+	// constants
+	sb.WriteString("const (\n")
+	fmt.Fprintf(&sb, "// %s is the %q collection name.\n", collectionConst, collection.Name)
+	fmt.Fprintf(&sb, "%s = %q\n\n", collectionConst, collection.Name)
+	fieldConsts := make([]string, len(fields))
+	for i, f := range fields {
+		fieldConsts[i] = uniqueName(usedNames, typeName+"Field"+pascalCase(f.field.GetName()), "Name")
+		fmt.Fprintf(&sb, "%s = %q\n", fieldConsts[i], f.field.GetName())
+	}
+	sb.WriteString(")\n\n")
+
+	// type
+	fmt.Fprintf(&sb, "var _ core.RecordProxy = (*%s)(nil)\n\n", typeName)
+	fmt.Fprintf(&sb, "// %s defines a typed Record proxy for the %q collection.\n", typeName, collection.Name)
+	fmt.Fprintf(&sb, "type %s struct {\n\tcore.BaseRecordProxy\n}\n\n", typeName)
+
+	if !collection.IsView() {
+		fmt.Fprintf(&sb, "// New%s instantiates a new blank %q record proxy.\n", typeName, collection.Name)
+		fmt.Fprintf(&sb, "func New%s(app core.App) (*%s, error) {\n", typeName, typeName)
+		fmt.Fprintf(&sb, "collection, err := app.FindCachedCollectionByNameOrId(%s)\n", collectionConst)
+		sb.WriteString("if err != nil {\nreturn nil, err\n}\n\n")
+		fmt.Fprintf(&sb, "m := &%s{}\nm.SetProxyRecord(core.NewRecord(collection))\n\nreturn m, nil\n}\n\n", typeName)
+	}
+
+	fmt.Fprintf(&sb, "// Wrap%s wraps the specified %q record into a typed proxy.\n", typeName, collection.Name)
+	fmt.Fprintf(&sb, "func Wrap%s(record *core.Record) *%s {\n", typeName, typeName)
+	fmt.Fprintf(&sb, "m := &%s{}\nm.SetProxyRecord(record)\n\nreturn m\n}\n\n", typeName)
+
+	// getters and setters
+	for i, f := range fields {
+		fmt.Fprintf(&sb, "// %s returns the %q field value.\n", f.name, f.field.GetName())
+		fmt.Fprintf(&sb, "func (m *%s) %s() %s {\n", typeName, f.name, f.typ)
+		fmt.Fprintf(&sb, "return "+f.getter+"\n}\n\n", fieldConsts[i])
+
+		if collection.IsView() {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "// Set%s updates the %q field value.\n", f.name, f.field.GetName())
+		fmt.Fprintf(&sb, "func (m *%s) Set%s(value %s) {\n", typeName, f.name, f.typ)
+		fmt.Fprintf(&sb, "m.Set(%s, value)\n}\n\n", fieldConsts[i])
+	}
+
+	// query helpers
+	fmt.Fprintf(&sb, "// Query%s returns a new %q records select query.\n", typeName, collection.Name)
+	sb.WriteString("//\n// The query result could be loaded directly in the typed proxy, e.g.:\n//\n")
+	fmt.Fprintf(&sb, "//\tresult := []*%s{}\n//\terr := Query%s(app).Where(dbx.HashExp{%s: \"...\"}).All(&result)\n", typeName, typeName, `"id"`)
+	fmt.Fprintf(&sb, "func Query%s(app core.App) *dbx.SelectQuery {\n", typeName)
+	fmt.Fprintf(&sb, "return app.RecordQuery(%s)\n}\n\n", collectionConst)
+
+	fmt.Fprintf(&sb, "// Find%sById finds the %q record with the specified id.\n", typeName, collection.Name)
+	fmt.Fprintf(&sb, "func Find%sById(app core.App, id string) (*%s, error) {\n", typeName, typeName)
+	fmt.Fprintf(&sb, "m := &%s{}\n\n", typeName)
+	fmt.Fprintf(&sb, "err := Query%s(app).AndWhere(dbx.HashExp{%q: id}).Limit(1).One(m)\n", typeName, core.FieldNameId)
+	sb.WriteString("if err != nil {\nreturn nil, err\n}\n\nreturn m, nil\n}\n\n")
+
+	fmt.Fprintf(&sb, "// Find%sByFilter returns the %q records matching the specified filter\n", typeName, collection.Name)
+	sb.WriteString("// (see [core.App.FindRecordsByFilter] for the arguments details).\n")
+	fmt.Fprintf(&sb, "func Find%sByFilter(app core.App, filter string, sort string, limit int, offset int, params ...dbx.Params) ([]*%s, error) {\n", typeName, typeName)
+	fmt.Fprintf(&sb, "records, err := app.FindRecordsByFilter(%s, filter, sort, limit, offset, params...)\n", collectionConst)
+	sb.WriteString("if err != nil {\nreturn nil, err\n}\n\n")
+	fmt.Fprintf(&sb, "result := make([]*%s, len(records))\nfor i, record := range records {\nresult[i] = Wrap%s(record)\n}\n\nreturn result, nil\n}\n", typeName, typeName)
+
+	return format.Source([]byte(sb.String()))
+}
+
+// goFields returns the typed accessors info for the collection fields
+// (excluding the id and the auth system fields which have dedicated [core.Record] methods).
+func goFields(collection *core.Collection) []*goField {
+	recordType := reflect.TypeOf(&core.Record{})
+	proxyType := reflect.TypeOf(&core.BaseRecordProxy{})
+
+	usedNames := map[string]bool{}
+
+	result := make([]*goField, 0, len(collection.Fields))
+
+	for _, f := range collection.Fields {
+		name := f.GetName()
+
+		if name == core.FieldNameId {
+			continue
+		}
+
+		if collection.IsAuth() && slices.Contains(goAuthSystemFields, name) {
+			continue
+		}
+
+		gf := &goField{field: f}
+		gf.typ, gf.getter = goFieldType(f)
+
+		// avoid shadowing the embedded record methods (e.g. "collection" -> "CollectionField")
+		methodName := pascalCase(name)
+		for _, t := range []reflect.Type{recordType, proxyType} {
+			_, hasGetter := t.MethodByName(methodName)
+			_, hasSetter := t.MethodByName("Set" + methodName)
+			if hasGetter || hasSetter {
+				methodName += "Field"
+				break
+			}
+		}
+		gf.name = uniqueName(usedNames, methodName, "Field")
+
+		result = append(result, gf)
+	}
+
+	return result
+}
+
+// goFieldType returns the Go type and the getter expression format for the specified field.
+func goFieldType(f core.Field) (string, string) {
+	switch field := f.(type) {
+	case *core.BoolField:
+		return "bool", "m.GetBool(%s)"
+	case *core.NumberField:
+		if field.OnlyInt {
+			return "int", "m.GetInt(%s)"
+		}
+		return "float64", "m.GetFloat(%s)"
+	case *core.DateField, *core.AutodateField:
+		return "types.DateTime", "m.GetDateTime(%s)"
+	case *core.GeoPointField:
+		return "types.GeoPoint", "m.GetGeoPoint(%s)"
+	case *core.JSONField:
+		return "types.JSONRaw", "types.JSONRaw(m.GetString(%s))"
+	case *core.SelectField:
+		if field.IsMultiple() {
+			return "[]string", "m.GetStringSlice(%s)"
+		}
+	case *core.RelationField:
+		if field.IsMultiple() {
+			return "[]string", "m.GetStringSlice(%s)"
+		}
+	case *core.FileField:
+		if field.IsMultiple() {
+			return "[]string", "m.GetStringSlice(%s)"
+		}
+	}
+
+	return "string", "m.GetString(%s)"
+}
+
+// uniqueName returns the first name that is not in the used names map
+// by appending the suffix (and a number if needed) and marks it as used.
+func uniqueName(used map[string]bool, name string, suffix string) string {
+	result := name
+	for i := 1; used[result]; i++ {
+		if i == 1 {
+			result = name + suffix
+		} else {
+			result = fmt.Sprintf("%s%s%d", name, suffix, i)
+		}
+	}
+
+	used[result] = true
+
+	return result
+}
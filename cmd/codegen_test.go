@@ -0,0 +1,227 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCodegenGoCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	dir := filepath.Join(t.TempDir(), "models")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	staleFile := filepath.Join(dir, "deleted_collection.go")
+	if err := os.WriteFile(staleFile, []byte("// Code generated by pocketbase codegen. DO NOT EDIT.\n\npackage models\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	customFile := filepath.Join(dir, "custom.go")
+	if err := os.WriteFile(customFile, []byte("package models\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	command := cmd.NewCodegenCommand(app)
+	command.SetArgs([]string{"go", "--out", dir})
+	command.SetOut(new(bytes.Buffer))
+	command.SetErr(new(bytes.Buffer))
+
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(staleFile); err == nil {
+		t.Fatal("Expected the stale generated file to be deleted")
+	}
+
+	if _, err := os.Stat(customFile); err != nil {
+		t.Fatalf("Expected the custom file to be preserved, got %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "superusers.go")); err == nil {
+		t.Fatal("Expected no generated file for the system collections")
+	}
+
+	scenarios := []struct {
+		file        string
+		expected    []string
+		notExpected []string
+	}{
+		{
+			"demo1.go",
+			[]string{
+				"package models\n",
+				`Demo1Collection = "demo1"`,
+				`Demo1FieldSelectMany = "select_many"`,
+				"type Demo1 struct {\n\tcore.BaseRecordProxy\n}",
+				"func NewDemo1(app core.App) (*Demo1, error)",
+				"func WrapDemo1(record *core.Record) *Demo1",
+				"func (m *Demo1) Text() string {\n\treturn m.GetString(Demo1FieldText)",
+				"func (m *Demo1) SetText(value string) {\n\tm.Set(Demo1FieldText, value)",
+				"func (m *Demo1) SelectMany() []string",
+				"func (m *Demo1) Number() float64",
+				"func (m *Demo1) Datetime() types.DateTime",
+				"func (m *Demo1) Json() types.JSONRaw",
+				"func (m *Demo1) Point() types.GeoPoint",
+				// shouldn't shadow the Record.Email() method
+				"func (m *Demo1) EmailField() string {\n\treturn m.GetString(Demo1FieldEmail)",
+				"func QueryDemo1(app core.App) *dbx.SelectQuery",
+				"func FindDemo1ById(app core.App, id string) (*Demo1, error)",
+				"func FindDemo1ByFilter(app core.App, filter string, sort string, limit int, offset int, params ...dbx.Params) ([]*Demo1, error)",
+			},
+			[]string{
+				"func (m *Demo1) Id()",
+			},
+		},
+		{
+			"users.go",
+			[]string{
+				"func (m *Users) Name() string",
+				"func (m *Users) Avatar() string",
+			},
+			[]string{
+				"func (m *Users) Email()",
+				"func (m *Users) Verified()",
+				"func (m *Users) SetPassword(",
+				"func (m *Users) TokenKey()",
+			},
+		},
+		{
+			"view1.go",
+			[]string{
+				"func (m *View1) Text() string",
+				"func WrapView1(",
+				"func FindView1ById(",
+			},
+			[]string{
+				"func NewView1(",
+				"func (m *View1) SetText(",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.file, func(t *testing.T) {
+			raw, err := os.ReadFile(filepath.Join(dir, s.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			content := string(raw)
+
+			for _, str := range s.expected {
+				if !strings.Contains(content, str) {
+					t.Errorf("Missing %q in\n%s", str, content)
+				}
+			}
+
+			for _, str := range s.notExpected {
+				if strings.Contains(content, str) {
+					t.Errorf("Didn't expect %q in\n%s", str, content)
+				}
+			}
+		})
+	}
+}
+
+func TestCodegenTSCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expected    []string
+		notExpected []string
+	}{
+		{
+			"types only",
+			nil,
+			[]string{
+				"// Code generated by pocketbase codegen. DO NOT EDIT.\n",
+				"export enum Collections {\n",
+				"\tDemo1 = \"demo1\",",
+				"\tUsers = \"users\",",
+				"export enum Demo1SelectManyOptions {\n\t\"optionA\" = \"optionA\",",
+				"export interface Demo1Record extends BaseRecord {\n\ttext: string\n",
+				"\tbool: boolean\n",
+				"\tselect_one: \"\" | Demo1SelectOneOptions\n",
+				"\tselect_many: Demo1SelectManyOptions[]\n",
+				"\tfile_many: string[]\n",
+				"\tnumber: number\n",
+				"\tdatetime: IsoDateString\n",
+				"\tjson: unknown\n",
+				"\trel_one: RecordIdString\n",
+				"\trel_many: RecordIdString[]\n",
+				"\tpoint: GeoPoint\n",
+				"export interface Demo1Expand {\n\trel_one?: Demo1Response\n\trel_many?: UsersResponse[]\n",
+				"export type Demo1Response<Texpand = Demo1Expand> = Demo1Record & { expand?: Texpand }",
+				"export interface UsersRecord extends BaseRecord {",
+				"\ttokenKey?: string\n",
+			},
+			[]string{
+				"Superusers",
+				"password:",
+				"TypedPocketBase",
+				"import",
+			},
+		},
+		{
+			"with sdk",
+			[]string{"--sdk"},
+			[]string{
+				"import type PocketBase from \"pocketbase\"\n",
+				"export interface TypedPocketBase extends PocketBase {",
+				"\tcollection(idOrName: \"demo1\"): RecordService<Demo1Response>\n",
+				"\tcollection(idOrName: string): RecordService\n}",
+			},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "types", "pb_types.ts")
+
+			command := cmd.NewCodegenCommand(app)
+			command.SetArgs(append([]string{"ts", "--out", file}, s.args...))
+			command.SetOut(new(bytes.Buffer))
+			command.SetErr(new(bytes.Buffer))
+
+			if err := command.Execute(); err != nil {
+				t.Fatal(err)
+			}
+
+			raw, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			content := string(raw)
+
+			for _, str := range s.expected {
+				if !strings.Contains(content, str) {
+					t.Errorf("Missing %q in\n%s", str, content)
+				}
+			}
+
+			for _, str := range s.notExpected {
+				if strings.Contains(content, str) {
+					t.Errorf("Didn't expect %q in\n%s", str, content)
+				}
+			}
+		})
+	}
+}
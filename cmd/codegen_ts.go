@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+func codegenTSCommand(app core.App) *cobra.Command {
+	var out string
+	var withSDK bool
+
+	command := &cobra.Command{
+		Use:          "ts",
+		Example:      "codegen ts --out ./src/pb_types.ts --sdk",
+		Short:        "Generates TypeScript types (and optionally a typed JS SDK client) for the app collections",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if err := WriteTypeScript(app, out, withSDK); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(command.OutOrStdout(), "Successfully generated %q.\n", out)
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&out, "out", "./pb_types.ts", "the output TypeScript file")
+	command.Flags().BoolVar(&withSDK, "sdk", false, "generate also a typed wrapper over the PocketBase JS SDK")
+
+	return command
+}
+
+// WriteTypeScript generates the TypeScript types of the app collections
+// and writes them in the specified file (see [GenerateTypeScript]).
+func WriteTypeScript(app core.App, file string, withSDK bool) error {
+	content, err := GenerateTypeScript(app, withSDK)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create the output directory: %w", err)
+	}
+
+	if err := os.WriteFile(file, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %q: %w", file, err)
+	}
+
+	return nil
+}
+
+// GenerateTypeScript generates TypeScript types for the non-system app collections.
+//
+// For each collection it generates:
+//   - {Name}Record interface with the collection fields
+//   - {Name}{Field}Options enum for each select field
+//   - {Name}Expand interface with the possible relation expands
+//   - {Name}Response type (the record with its optional expand)
+//
+// If withSDK is set, it also generates a TypedPocketBase interface
+// that could be used to type the PocketBase JS SDK instance, e.g.:
+//
+//	const pb = new PocketBase("http://127.0.0.1:8090") as TypedPocketBase
+func GenerateTypeScript(app core.App, withSDK bool) ([]byte, error) {
+	collections, err := codegenCollections(app)
+	if err != nil {
+		return nil, err
+	}
+
+	typeNames := make(map[string]string, len(collections))
+	usedNames := map[string]bool{}
+	for _, c := range collections {
+		typeNames[c.Id] = uniqueName(usedNames, pascalCase(c.Name), "Collection")
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "// %s\n\n", codegenHeader)
+
+	if withSDK {
+		sb.WriteString("import type PocketBase from \"pocketbase\"\n")
+		sb.WriteString("import type { RecordService } from \"pocketbase\"\n\n")
+	}
+
+	sb.WriteString("export enum Collections {\n")
+	for _, c := range collections {
+		fmt.Fprintf(&sb, "\t%s = %s,\n", typeNames[c.Id], strconv.Quote(c.Name))
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("export type IsoDateString = string\n")
+	sb.WriteString("export type RecordIdString = string\n")
+	sb.WriteString("export type GeoPoint = { lon: number; lat: number }\n\n")
+
+	sb.WriteString("export interface BaseRecord {\n")
+	sb.WriteString("\tid: RecordIdString\n")
+	sb.WriteString("\tcollectionId: string\n")
+	sb.WriteString("\tcollectionName: Collections\n")
+	sb.WriteString("}\n\n")
+
+	// fallback for the relations to the system collections
+	sb.WriteString("export type UnknownResponse = BaseRecord & { [key: string]: unknown }\n")
+
+	for _, c := range collections {
+		writeTSCollection(&sb, c, typeNames)
+	}
+
+	if withSDK {
+		sb.WriteString("\nexport interface TypedPocketBase extends PocketBase {\n")
+		for _, c := range collections {
+			fmt.Fprintf(&sb, "\tcollection(idOrName: %s): RecordService<%sResponse>\n", strconv.Quote(c.Name), typeNames[c.Id])
+		}
+		sb.WriteString("\tcollection(idOrName: string): RecordService\n")
+		sb.WriteString("}\n")
+	}
+
+	return []byte(sb.String()), nil
+}
+
+func writeTSCollection(sb *strings.Builder, collection *core.Collection, typeNames map[string]string) {
+	typeName := typeNames[collection.Id]
+
+	sb.WriteString("\n")
+
+	// select options
+	for _, f := range collection.Fields {
+		field, ok := f.(*core.SelectField)
+		if !ok || len(field.Values) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(sb, "export enum %s%sOptions {\n", typeName, pascalCase(field.Name))
+		for _, v := range field.Values {
+			fmt.Fprintf(sb, "\t%s = %s,\n", strconv.Quote(v), strconv.Quote(v))
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	// record fields
+	fmt.Fprintf(sb, "export interface %sRecord extends BaseRecord {\n", typeName)
+	for _, f := range collection.Fields {
+		if f.GetName() == core.FieldNameId {
+			continue
+		}
+
+		// never returned in the API responses
+		if f.Type() == core.FieldTypePassword {
+			continue
+		}
+
+		optional := ""
+		if f.GetHidden() {
+			optional = "?"
+		}
+
+		fmt.Fprintf(sb, "\t%s%s: %s\n", tsPropertyName(f.GetName()), optional, tsFieldType(f, typeName))
+	}
+	sb.WriteString("}\n\n")
+
+	// expand
+	fmt.Fprintf(sb, "export interface %sExpand {\n", typeName)
+	for _, f := range collection.Fields {
+		field, ok := f.(*core.RelationField)
+		if !ok {
+			continue
+		}
+
+		relType := "UnknownResponse"
+		if name, ok := typeNames[field.CollectionId]; ok {
+			relType = name + "Response"
+		}
+		if field.IsMultiple() {
+			relType += "[]"
+		}
+
+		fmt.Fprintf(sb, "\t%s?: %s\n", tsPropertyName(field.Name), relType)
+	}
+	sb.WriteString("\t[key: string]: unknown\n")
+	sb.WriteString("}\n\n")
+
+	fmt.Fprintf(sb, "export type %sResponse<Texpand = %sExpand> = %sRecord & { expand?: Texpand }\n", typeName, typeName, typeName)
+}
+
+// tsFieldType returns the TypeScript type of the specified field.
+func tsFieldType(f core.Field, typeName string) string {
+	switch field := f.(type) {
+	case *core.BoolField:
+		return "boolean"
+	case *core.NumberField:
+		return "number"
+	case *core.DateField, *core.AutodateField:
+		return "IsoDateString"
+	case *core.GeoPointField:
+		return "GeoPoint"
+	case *core.JSONField:
+		return "unknown"
+	case *core.SelectField:
+		t := "string"
+		if len(field.Values) > 0 {
+			t = typeName + pascalCase(field.Name) + "Options"
+		}
+		if field.IsMultiple() {
+			return t + "[]"
+		}
+		if field.Required {
+			return t
+		}
+		return `"" | ` + t
+	case *core.RelationField:
+		if field.IsMultiple() {
+			return "RecordIdString[]"
+		}
+		return "RecordIdString"
+	case *core.FileField:
+		if field.IsMultiple() {
+			return "string[]"
+		}
+	}
+
+	return "string"
+}
+
+// tsPropertyName quotes the property name if it is not a valid identifier.
+func tsPropertyName(name string) string {
+	for i, r := range name {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return strconv.Quote(name)
+	}
+
+	return name
+}
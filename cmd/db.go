@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewDBCommand creates and returns new command for the app databases maintenance.
+func NewDBCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the app databases",
+	}
+
+	command.AddCommand(dbOptimizeCommand(app))
+	command.AddCommand(dbAdviseCommand(app))
+	command.AddCommand(dbCheckRelationsCommand(app))
+
+	return command
+}
+
+func dbOptimizeCommand(app core.App) *cobra.Command {
+	var vacuum bool
+	var showIndexes bool
+
+	command := &cobra.Command{
+		Use:          "optimize",
+		Example:      "db optimize --vacuum=false",
+		Short:        "Checkpoints the WAL, vacuums and analyzes the app databases",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			report, err := app.OptimizeDB(context.Background(), vacuum)
+			if err != nil {
+				return fmt.Errorf("failed to optimize the databases: %w", err)
+			}
+
+			for _, db := range report.Databases {
+				fmt.Printf(
+					"%s: %s -> %s (free pages %d -> %d)\n",
+					db.Name,
+					formatBytes(db.SizeBefore),
+					formatBytes(db.SizeAfter),
+					db.FreePagesBefore,
+					db.FreePagesAfter,
+				)
+
+				if showIndexes {
+					for _, idx := range db.Indexes {
+						fmt.Printf("  %s.%s: %s\n", idx.Table, idx.Index, idx.Stat)
+					}
+				}
+			}
+
+			color.Green("Successfully optimized the databases (%s)!", report.Duration)
+			return nil
+		},
+	}
+
+	command.Flags().BoolVar(&vacuum, "vacuum", true, "reclaim the unused disk space with VACUUM (blocks the writes while running)")
+	command.Flags().BoolVar(&showIndexes, "indexes", false, "print the collected indexes statistics")
+
+	return command
+}
+
+func dbAdviseCommand(app core.App) *cobra.Command {
+	var apply bool
+
+	command := &cobra.Command{
+		Use:          "advise",
+		Example:      "db advise --apply",
+		Short:        "Suggests missing collection indexes based on the API rules and the recorded slow queries",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			suggestions, err := app.AdviseIndexes()
+			if err != nil {
+				return fmt.Errorf("failed to analyze the collection indexes: %w", err)
+			}
+
+			if len(suggestions) == 0 {
+				color.Green("No missing indexes were found.")
+				return nil
+			}
+
+			for _, s := range suggestions {
+				fmt.Printf("%s.%s (%s): %s\n", s.Collection, s.Column, strings.Join(s.Sources, ", "), s.Index)
+			}
+
+			if !apply {
+				return nil
+			}
+
+			if err := app.ApplyIndexSuggestions(suggestions); err != nil {
+				return fmt.Errorf("failed to create the suggested indexes: %w", err)
+			}
+
+			color.Green("Successfully created %d indexes!", len(suggestions))
+			return nil
+		},
+	}
+
+	command.Flags().BoolVar(&apply, "apply", false, "create the suggested indexes (and their migrations if automigrate is enabled)")
+
+	return command
+}
+
+func dbCheckRelationsCommand(app core.App) *cobra.Command {
+	var repair bool
+
+	command := &cobra.Command{
+		Use:          "check-relations [collections...]",
+		Example:      "db check-relations posts comments --repair",
+		Short:        "Finds (and optionally removes) the relation references to missing records",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			dangling, err := app.FindDanglingRelations(args...)
+			if err != nil {
+				return fmt.Errorf("failed to check the relations: %w", err)
+			}
+
+			if len(dangling) == 0 {
+				color.Green("No dangling relations were found.")
+				return nil
+			}
+
+			var lastCollection string
+			for _, d := range dangling {
+				if d.Collection != lastCollection {
+					fmt.Printf("%s:\n", d.Collection)
+					lastCollection = d.Collection
+				}
+				fmt.Printf("  %s.%s -> %s\n", d.RecordId, d.Field, strings.Join(d.MissingIds, ", "))
+			}
+
+			if !repair {
+				return fmt.Errorf("found %d record(s) with dangling relations (use --repair to remove them)", len(dangling))
+			}
+
+			if err := app.RepairDanglingRelations(dangling); err != nil {
+				return fmt.Errorf("failed to repair the dangling relations: %w", err)
+			}
+
+			color.Green("Successfully removed the dangling relations of %d record(s)!", len(dangling))
+			return nil
+		},
+	}
+
+	command.Flags().BoolVar(&repair, "repair", false, "remove the dangling ids from the relation fields (the single relation values are cleared)")
+
+	return command
+}
+
+// formatBytes returns a human readable representation of the specified bytes size.
+func formatBytes(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,96 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestDBOptimizeCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name string
+		args []string
+	}{
+		{"with vacuum", []string{"optimize"}},
+		{"without vacuum", []string{"optimize", "--vacuum=false", "--indexes"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			command := cmd.NewDBCommand(app)
+			command.SetArgs(s.args)
+
+			if err := command.Execute(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestDBAdviseCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	command := cmd.NewDBCommand(app)
+	command.SetArgs([]string{"advise", "--apply"})
+
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	suggestions, err := app.AdviseIndexes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(suggestions) != 0 {
+		t.Fatalf("Expected all suggested indexes to be applied, got %d remaining", len(suggestions))
+	}
+}
+
+func TestDBCheckRelationsCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	_, err := app.DB().Update("demo1", dbx.Params{"rel_one": "missing"}, dbx.HashExp{"id": "al1h9ijdeojtsjy"}).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{"missing collection", []string{"check-relations", "missing"}, true},
+		{"unrelated collection", []string{"check-relations", "demo2"}, false},
+		{"without repair", []string{"check-relations"}, true},
+		{"with repair", []string{"check-relations", "demo1", "--repair"}, false},
+		{"after repair", []string{"check-relations"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			command := cmd.NewDBCommand(app)
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
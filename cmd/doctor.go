@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewDoctorCommand creates and returns new command for diagnosing
+// the most common app operational problems and misconfigurations.
+func NewDoctorCommand(app core.App) *cobra.Command {
+	var options core.DiagnosticsOptions
+	var jsonOutput bool
+
+	command := &cobra.Command{
+		Use:          "doctor",
+		Example:      "doctor --clock-url=https://example.com",
+		Short:        "Checks the app for common operational problems and misconfigurations",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			findings, err := app.Diagnose(command.Context(), options)
+			if err != nil {
+				return fmt.Errorf("failed to run the diagnostics: %w", err)
+			}
+
+			var errorsCount int
+			for _, f := range findings {
+				if f.Severity == core.DiagnosticSeverityError {
+					errorsCount++
+				}
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(command.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(findings); err != nil {
+					return err
+				}
+			} else {
+				printDiagnosticFindings(command, findings)
+			}
+
+			if errorsCount > 0 {
+				return fmt.Errorf("found %d problem(s)", errorsCount)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&options.ClockURL, "clock-url", "", "HTTP(S) url whose Date header to use as reference for the clock skew check (skipped if not set)")
+	command.Flags().BoolVar(&options.SkipNetwork, "skip-network", false, "skip the checks that require network access (S3, SMTP, clock skew)")
+	command.Flags().DurationVar(&options.Timeout, "timeout", 10*time.Second, "max duration of a single network check")
+	command.Flags().BoolVar(&jsonOutput, "json", false, "print the findings as JSON array")
+
+	return command
+}
+
+func printDiagnosticFindings(command *cobra.Command, findings []*core.DiagnosticFinding) {
+	w := command.OutOrStdout()
+
+	for _, f := range findings {
+		switch f.Severity {
+		case core.DiagnosticSeverityError:
+			color.New(color.FgRed).Fprintf(w, "[%s] ✗ %s\n", f.Check, f.Message)
+		case core.DiagnosticSeverityWarning:
+			color.New(color.FgYellow).Fprintf(w, "[%s] ! %s\n", f.Check, f.Message)
+		default:
+			color.New(color.FgGreen).Fprintf(w, "[%s] ✓ %s\n", f.Check, f.Message)
+		}
+
+		if f.Hint != "" {
+			fmt.Fprintf(w, "    └─ %s\n", f.Hint)
+		}
+	}
+}
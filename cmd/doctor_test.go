@@ -0,0 +1,74 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestDoctorCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	t.Run("plain", func(t *testing.T) {
+		out := &bytes.Buffer{}
+
+		command := cmd.NewDoctorCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"--skip-network"})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(out.String(), "The data.db integrity check passed.") {
+			t.Fatalf("Missing integrity finding in\n%s", out.String())
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := &bytes.Buffer{}
+
+		command := cmd.NewDoctorCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"--skip-network", "--json"})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		findings := []*core.DiagnosticFinding{}
+		if err := json.Unmarshal(out.Bytes(), &findings); err != nil {
+			t.Fatalf("Failed to unmarshal output: %v\n%s", err, out.String())
+		}
+
+		if len(findings) == 0 {
+			t.Fatal("Expected at least 1 finding")
+		}
+	})
+
+	t.Run("with errors", func(t *testing.T) {
+		app.Settings().SMTP.Enabled = true
+		app.Settings().SMTP.Host = "invalid host"
+		app.Settings().SMTP.Port = 25
+		defer func() {
+			app.Settings().SMTP.Enabled = false
+		}()
+
+		command := cmd.NewDoctorCommand(app)
+		command.SetOut(&bytes.Buffer{})
+		command.SetErr(&bytes.Buffer{})
+		command.SetArgs([]string{"--timeout=1s"})
+
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+}
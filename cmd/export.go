@@ -22,6 +22,7 @@ func NewExportCommand(app core.App) *cobra.Command {
 	var pretty bool // 是否格式化 JSON 输出
 	var batchSize int
 	var outputFile string // 输出文件路径
+	var anonymize string  // 脱敏规则文件路径
 
 	cmd := &cobra.Command{
 		Use:   "export [集合名称]",
@@ -36,7 +37,17 @@ func NewExportCommand(app core.App) *cobra.Command {
 				outputFile = fmt.Sprintf("%s_export.json", collectionName)
 			}
 
-			return exportData(app, collectionName, outputFile, pretty, batchSize)
+			// 加载脱敏规则（可选）
+			var masking *core.ExportMasking
+			if anonymize != "" {
+				var err error
+				masking, err = core.LoadExportMaskingFile(anonymize)
+				if err != nil {
+					return err
+				}
+			}
+
+			return exportData(app, collectionName, outputFile, pretty, batchSize, masking)
 		},
 	}
 
@@ -44,18 +55,26 @@ func NewExportCommand(app core.App) *cobra.Command {
 	cmd.Flags().BoolVarP(&pretty, "pretty", "p", false, "是否格式化JSON输出")
 	cmd.Flags().IntVarP(&batchSize, "batch-size", "b", 5000, "每批保存的记录数，默认5000")
 	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "输出文件路径（默认为：集合名称_export.json）")
+	cmd.Flags().StringVar(&anonymize, "anonymize", "", "脱敏规则文件路径（JSON/YAML），导出时按字段规则替换敏感数据（email、name、hash、null）")
 
 	return cmd
 }
 
 // exportData 处理数据导出的主流程
-func exportData(app core.App, collectionName, outputFile string, pretty bool, batchSize int) error {
+func exportData(app core.App, collectionName, outputFile string, pretty bool, batchSize int, masking *core.ExportMasking) error {
 	// 获取目标集合
 	collection, err := app.FindCollectionByNameOrId(collectionName)
 	if err != nil {
 		return fmt.Errorf("找不到集合 %s: %v", collectionName, err)
 	}
 
+	// 检查脱敏规则中的字段，避免因拼写错误导出未脱敏的数据
+	if masking != nil {
+		if err := masking.CheckFields(collection); err != nil {
+			return fmt.Errorf("脱敏规则无效: %v", err)
+		}
+	}
+
 	// 创建输出文件
 	file, err := os.Create(outputFile)
 	if err != nil {
@@ -109,7 +128,14 @@ func exportData(app core.App, collectionName, outputFile string, pretty bool, ba
 		}
 
 		for _, record := range records {
-			if err := writeRecordToFile(file, record, pretty, isFirstRecord); err != nil {
+			var item any = record
+			if masking != nil {
+				data := record.PublicExport()
+				masking.Apply(collection, data)
+				item = data
+			}
+
+			if err := writeRecordToFile(file, item, pretty, isFirstRecord); err != nil {
 				close(progressDone)
 				return err
 			}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewGenerateCommand creates and returns new command for creating
+// fake records based on the collection field types and constraints.
+func NewGenerateCommand(app core.App) *cobra.Command {
+	var options core.RecordsGenerateOptions
+
+	command := &cobra.Command{
+		Use:          "generate [collection]",
+		Example:      "generate posts --count 10000",
+		Short:        "Creates fake records based on the collection field types and constraints",
+		Long:         "Creates fake records based on the collection field types and constraints (e.g. for demo environments and performance testing).\nThe relation fields are populated with randomly chosen existing related records, so generate the referenced collections first.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			collection, err := app.FindCollectionByNameOrId(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to find collection %q: %w", args[0], err)
+			}
+
+			start := time.Now()
+
+			created, err := app.GenerateRecords(command.Context(), collection, options)
+			if err != nil {
+				return fmt.Errorf("failed to generate the %q records (%d created): %w", collection.Name, created, err)
+			}
+
+			w := command.OutOrStdout()
+
+			color.New(color.FgGreen).Fprintf(w, "Successfully generated %d %q record(s) in %s.\n", created, collection.Name, time.Since(start).Round(time.Millisecond))
+
+			if collection.IsAuth() {
+				fmt.Fprintf(w, "The generated auth records password is %q.\n", options.Password)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().IntVar(&options.Count, "count", 10, "the number of records to generate")
+	command.Flags().IntVar(&options.BatchSize, "batch", core.DefaultRecordsGenerateBatchSize, "the number of records to create in a single transaction")
+	command.Flags().StringVar(&options.Password, "password", core.DefaultRecordsGeneratePassword, "the password of the generated auth records")
+
+	return command
+}
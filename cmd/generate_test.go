@@ -0,0 +1,70 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestGenerateCommand(t *testing.T) {
+	t.Parallel()
+
+	posts := core.NewBaseCollection("posts")
+	posts.Fields.Add(&core.TextField{Name: "title", Required: true})
+
+	app := tests.NewTestAppBuilder(t).InMemory().WithCollections(posts).Build()
+
+	t.Run("missing collection", func(t *testing.T) {
+		command := cmd.NewGenerateCommand(app)
+		command.SetOut(&bytes.Buffer{})
+		command.SetArgs([]string{"missing"})
+
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+
+	t.Run("base collection", func(t *testing.T) {
+		out := &bytes.Buffer{}
+
+		command := cmd.NewGenerateCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"posts", "--count", "25", "--batch", "10"})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(out.String(), `Successfully generated 25 "posts" record(s)`) {
+			t.Fatalf("Unexpected output:\n%s", out.String())
+		}
+
+		total, err := app.CountRecords("posts")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 25 {
+			t.Fatalf("Expected 25 posts, got %d", total)
+		}
+	})
+
+	t.Run("auth collection", func(t *testing.T) {
+		out := &bytes.Buffer{}
+
+		command := cmd.NewGenerateCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"users", "--count", "2", "--password", "test_password"})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(out.String(), `password is "test_password"`) {
+			t.Fatalf("Expected the password in the output:\n%s", out.String())
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewMaintenanceCommand creates and returns new command for
+// toggling the app maintenance mode.
+func NewMaintenanceCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Manage the app maintenance mode",
+		Long:  "Manage the app maintenance mode.\n\nWhile enabled, the API responds with 503 to all non-superuser requests\nexcept the superusers auth and the health check endpoints.",
+	}
+
+	command.AddCommand(maintenanceOnCommand(app))
+	command.AddCommand(maintenanceOffCommand(app))
+	command.AddCommand(maintenanceStatusCommand(app))
+
+	return command
+}
+
+func maintenanceOnCommand(app core.App) *cobra.Command {
+	var message string
+	var retryAfter int
+
+	command := &cobra.Command{
+		Use:          "on",
+		Example:      `maintenance on --message="Importing data, back in 10 minutes." --retry-after=600`,
+		Short:        "Enables the maintenance mode",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			return saveMaintenanceConfig(app, func(config *core.MaintenanceConfig) {
+				config.Enabled = true
+
+				if command.Flags().Changed("message") {
+					config.Message = message
+				}
+
+				if command.Flags().Changed("retry-after") {
+					config.RetryAfter = retryAfter
+				}
+			})
+		},
+	}
+
+	command.Flags().StringVar(&message, "message", "", "custom API error message (if not set, the previous or the default one is used)")
+	command.Flags().IntVar(&retryAfter, "retry-after", 0, "optional Retry-After response header value in seconds")
+
+	return command
+}
+
+func maintenanceOffCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "off",
+		Short:        "Disables the maintenance mode",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			return saveMaintenanceConfig(app, func(config *core.MaintenanceConfig) {
+				config.Enabled = false
+			})
+		},
+	}
+
+	return command
+}
+
+func maintenanceStatusCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "status",
+		Short:        "Prints the current maintenance mode state",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			config := app.Settings().Maintenance
+
+			if !config.Enabled {
+				fmt.Fprintln(command.OutOrStdout(), "off")
+				return nil
+			}
+
+			fmt.Fprintln(command.OutOrStdout(), "on")
+			fmt.Fprintf(command.OutOrStdout(), "message: %s\n", config.ErrorMessage())
+			if config.RetryAfter > 0 {
+				fmt.Fprintf(command.OutOrStdout(), "retry after: %ds\n", config.RetryAfter)
+			}
+
+			return nil
+		},
+	}
+
+	return command
+}
+
+func saveMaintenanceConfig(app core.App, modify func(config *core.MaintenanceConfig)) error {
+	isEnvOverridden := slices.ContainsFunc(app.Settings().EnvOverrides(), func(path string) bool {
+		return strings.EqualFold(path, "maintenance") || strings.EqualFold(path, "maintenance.enabled")
+	})
+	if isEnvOverridden {
+		return errors.New("the maintenance mode is controlled by a " + core.SettingsEnvPrefix + " environment variable")
+	}
+
+	settings, err := app.Settings().Clone()
+	if err != nil {
+		return fmt.Errorf("failed to clone the app settings: %w", err)
+	}
+
+	modify(&settings.Maintenance)
+
+	if err := app.Save(settings); err != nil {
+		return fmt.Errorf("failed to save the settings: %w", err)
+	}
+
+	if settings.Maintenance.Enabled {
+		color.Yellow("Maintenance mode enabled.")
+	} else {
+		color.Green("Maintenance mode disabled.")
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestMaintenanceCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name             string
+		args             []string
+		expectError      bool
+		expectedConfig   core.MaintenanceConfig
+		expectedContains []string
+	}{
+		{
+			"initial status",
+			[]string{"status"},
+			false,
+			core.MaintenanceConfig{},
+			[]string{"off"},
+		},
+		{
+			"on with invalid retry after",
+			[]string{"on", "--retry-after=-1"},
+			true,
+			core.MaintenanceConfig{},
+			nil,
+		},
+		{
+			"on with default message",
+			[]string{"on"},
+			false,
+			core.MaintenanceConfig{Enabled: true},
+			nil,
+		},
+		{
+			"status (on with default message)",
+			[]string{"status"},
+			false,
+			core.MaintenanceConfig{Enabled: true},
+			[]string{"on", core.DefaultMaintenanceMessage},
+		},
+		{
+			"on with custom message",
+			[]string{"on", "--message=test", "--retry-after=60"},
+			false,
+			core.MaintenanceConfig{Enabled: true, Message: "test", RetryAfter: 60},
+			nil,
+		},
+		{
+			"off",
+			[]string{"off"},
+			false,
+			core.MaintenanceConfig{Message: "test", RetryAfter: 60},
+			nil,
+		},
+		{
+			"on preserving the previous message",
+			[]string{"on"},
+			false,
+			core.MaintenanceConfig{Enabled: true, Message: "test", RetryAfter: 60},
+			nil,
+		},
+		{
+			"status (on with custom message)",
+			[]string{"status"},
+			false,
+			core.MaintenanceConfig{Enabled: true, Message: "test", RetryAfter: 60},
+			[]string{"on", "message: test", "retry after: 60s"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+
+			command := cmd.NewMaintenanceCommand(app)
+			command.SetOut(out)
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if err := app.ReloadSettings(); err != nil {
+				t.Fatal(err)
+			}
+
+			if config := app.Settings().Maintenance; config != s.expectedConfig {
+				t.Fatalf("Expected config %#v, got %#v", s.expectedConfig, config)
+			}
+
+			for _, str := range s.expectedContains {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in\n%s", str, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewOpenAPICommand creates and returns new command for generating
+// the OpenAPI specification of the current app collections.
+func NewOpenAPICommand(app core.App) *cobra.Command {
+	var format string
+
+	command := &cobra.Command{
+		Use:          "openapi",
+		Example:      "openapi > spec.yaml\nopenapi --format=json > spec.json",
+		Short:        "Prints the OpenAPI specification generated from the app collections",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if format != "yaml" && format != "json" {
+				return fmt.Errorf("unsupported format %q (expected yaml or json)", format)
+			}
+
+			spec, err := apis.OpenAPISpec(app)
+			if err != nil {
+				return fmt.Errorf("failed to generate the OpenAPI specification: %w", err)
+			}
+
+			raw, err := json.MarshalIndent(spec, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			if format == "yaml" {
+				var data any
+				if err := json.Unmarshal(raw, &data); err != nil {
+					return err
+				}
+
+				var sb strings.Builder
+				writeYAML(&sb, data, 0)
+				raw = []byte(sb.String())
+			} else {
+				raw = append(raw, '\n')
+			}
+
+			_, err = command.OutOrStdout().Write(raw)
+
+			return err
+		},
+	}
+
+	command.Flags().StringVar(&format, "format", "yaml", "the output format (yaml or json)")
+
+	return command
+}
+
+var plainYAMLKeyRegex = regexp.MustCompile(`^[A-Za-z_/$][\w./{}$-]*$`)
+
+// writeYAML writes the JSON decoded value (map[string]any, []any or scalar) as YAML block.
+//
+// Strings are always double quoted (using JSON escaping) to avoid ambiguous scalars.
+func writeYAML(sb *strings.Builder, value any, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			sb.WriteString(pad)
+			sb.WriteString(yamlKey(k))
+			sb.WriteString(":")
+			writeYAMLNested(sb, v[k], indent+2)
+		}
+	case []any:
+		for _, item := range v {
+			var nested strings.Builder
+			switch itemValue := item.(type) {
+			case map[string]any:
+				if len(itemValue) == 0 {
+					nested.WriteString(pad + "  {}\n")
+				} else {
+					writeYAML(&nested, itemValue, indent+2)
+				}
+			case []any:
+				if len(itemValue) == 0 {
+					nested.WriteString(pad + "  []\n")
+				} else {
+					writeYAML(&nested, itemValue, indent+2)
+				}
+			default:
+				writeYAML(&nested, itemValue, indent+2)
+			}
+
+			// inline the first nested line after the list item indicator
+			sb.WriteString(pad)
+			sb.WriteString("- ")
+			sb.WriteString(nested.String()[indent+2:])
+		}
+	default:
+		sb.WriteString(pad)
+		sb.WriteString(yamlScalar(v))
+		sb.WriteString("\n")
+	}
+}
+
+// writeYAMLNested writes a map key or list item value.
+func writeYAMLNested(sb *strings.Builder, value any, indent int) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			sb.WriteString(" {}\n")
+			return
+		}
+		sb.WriteString("\n")
+		writeYAML(sb, v, indent)
+	case []any:
+		if len(v) == 0 {
+			sb.WriteString(" []\n")
+			return
+		}
+		sb.WriteString("\n")
+		writeYAML(sb, v, indent)
+	default:
+		sb.WriteString(" ")
+		sb.WriteString(yamlScalar(v))
+		sb.WriteString("\n")
+	}
+}
+
+func yamlKey(key string) string {
+	if plainYAMLKeyRegex.MatchString(key) {
+		return key
+	}
+
+	quoted, _ := json.Marshal(key)
+
+	return string(quoted)
+}
+
+func yamlScalar(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		quoted, _ := json.Marshal(v)
+		return string(quoted)
+	}
+}
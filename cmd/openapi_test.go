@@ -0,0 +1,81 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestOpenAPICommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	t.Run("invalid format", func(t *testing.T) {
+		command := cmd.NewOpenAPICommand(app)
+		command.SetArgs([]string{"--format=xml"})
+		command.SetOut(new(bytes.Buffer))
+		command.SetErr(new(bytes.Buffer))
+
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected unsupported format error")
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := new(bytes.Buffer)
+
+		command := cmd.NewOpenAPICommand(app)
+		command.SetArgs([]string{"--format=json"})
+		command.SetOut(out)
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		spec := map[string]any{}
+		if err := json.Unmarshal(out.Bytes(), &spec); err != nil {
+			t.Fatal(err)
+		}
+
+		if spec["openapi"] != "3.0.3" {
+			t.Fatalf("Expected openapi 3.0.3, got %v", spec["openapi"])
+		}
+
+		paths, _ := spec["paths"].(map[string]any)
+		if _, ok := paths["/api/collections/demo1/records/{id}"]; !ok {
+			t.Fatal("Expected demo1 record path")
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		out := new(bytes.Buffer)
+
+		command := cmd.NewOpenAPICommand(app)
+		command.SetOut(out)
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectedParts := []string{
+			"openapi: \"3.0.3\"\n",
+			"paths:\n  /api/collections/_authOrigins/records:\n",
+			"      responses:\n        \"200\":\n",
+			"      security:\n        - {}\n        - authToken: []\n",
+			"      parameters:\n        - in: \"query\"\n          name: \"page\"\n          schema:\n            type: \"integer\"\n",
+			"      x-pocketbase-rule: null\n",
+		}
+
+		for _, part := range expectedParts {
+			if !strings.Contains(out.String(), part) {
+				t.Fatalf("Missing expected %q in\n%s", part, out.String())
+			}
+		}
+	})
+}
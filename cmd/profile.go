@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewProfileCommand creates and returns new command for downloading
+// runtime profiles from a running app instance
+// (requires enabled app.Settings().Profiling).
+func NewProfileCommand() *cobra.Command {
+	var baseURL string
+	var token string
+	var email string
+	var password string
+	var duration time.Duration
+	var profiles []string
+	var dir string
+
+	command := &cobra.Command{
+		Use:          "profile",
+		Example:      "profile --url=https://example.com --email=test@example.com --password=1234567890 --duration=30s --profiles=cpu,heap",
+		Short:        "Downloads CPU/heap/trace profiles from a running instance",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if duration < time.Second {
+				return errors.New("the duration must be at least 1s")
+			}
+
+			client := &http.Client{Timeout: duration + time.Minute}
+
+			baseURL = strings.TrimRight(baseURL, "/")
+
+			if token == "" {
+				if email == "" || password == "" {
+					return errors.New("missing --token or --email and --password superuser credentials")
+				}
+
+				var err error
+				token, err = profileSuperuserAuth(client, baseURL, email, password)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+				return err
+			}
+
+			seconds := strconv.Itoa(int(duration.Seconds()))
+			now := time.Now().Format("20060102150405")
+
+			for _, name := range profiles {
+				var path string
+				ext := ".pprof"
+
+				switch name {
+				case "cpu":
+					path = "/profile?seconds=" + seconds
+				case "trace":
+					path = "/trace?seconds=" + seconds
+					ext = ".trace"
+				case "heap", "allocs", "goroutine", "block", "mutex", "threadcreate":
+					path = "/" + name
+				default:
+					return fmt.Errorf("unsupported profile %q", name)
+				}
+
+				if name == "cpu" || name == "trace" {
+					fmt.Printf("Collecting %s profile for %s...\n", name, duration)
+				}
+
+				file := filepath.Join(dir, name+"_"+now+ext)
+
+				if err := profileDownload(client, baseURL+"/api/debug/pprof"+path, token, file); err != nil {
+					return fmt.Errorf("failed to download %s profile: %w", name, err)
+				}
+
+				color.Green("Saved %s profile in %q.", name, file)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&baseURL, "url", "http://127.0.0.1:8090", "the running app instance base url")
+	command.Flags().StringVar(&token, "token", os.Getenv("PB_SUPERUSER_TOKEN"), "superuser auth token (default to the PB_SUPERUSER_TOKEN env variable)")
+	command.Flags().StringVar(&email, "email", "", "superuser email (used if --token is not set)")
+	command.Flags().StringVar(&password, "password", "", "superuser password (used if --token is not set)")
+	command.Flags().DurationVar(&duration, "duration", 30*time.Second, "the cpu and trace profiles collection duration")
+	command.Flags().StringSliceVar(&profiles, "profiles", []string{"cpu", "heap"}, "comma separated list of profiles to download (cpu, heap, allocs, goroutine, block, mutex, threadcreate, trace)")
+	command.Flags().StringVar(&dir, "dir", ".", "the directory where to save the downloaded profiles")
+
+	return command
+}
+
+func profileSuperuserAuth(client *http.Client, baseURL string, email string, password string) (string, error) {
+	body, err := json.Marshal(map[string]string{"identity": email, "password": password})
+	if err != nil {
+		return "", err
+	}
+
+	authURL := baseURL + "/api/collections/" + url.PathEscape(core.CollectionNameSuperusers) + "/auth-with-password"
+
+	res, err := client.Post(authURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to authenticate as superuser (status %d)", res.StatusCode)
+	}
+
+	data := struct {
+		Token string `json:"token"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
+		return "", err
+	}
+
+	return data.Token, nil
+}
+
+func profileDownload(client *http.Client, profileURL string, token string, file string) error {
+	req, err := http.NewRequest(http.MethodGet, profileURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", token)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		if res.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("status 404 (make sure that the profiling is enabled in the app settings): %s", msg)
+		}
+		return fmt.Errorf("status %d: %s", res.StatusCode, msg)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, res.Body); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
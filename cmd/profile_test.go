@@ -0,0 +1,104 @@
+package cmd_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+)
+
+func TestProfileCommand(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/collections/_superusers/auth-with-password":
+			w.Write([]byte(`{"token":"test_token"}`))
+		case "/api/debug/pprof/profile", "/api/debug/pprof/heap":
+			if r.Header.Get("Authorization") != "test_token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(r.URL.Path + "?" + r.URL.RawQuery))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	scenarios := []struct {
+		name          string
+		args          []string
+		expectError   bool
+		expectedFiles map[string]string
+	}{
+		{
+			"missing credentials",
+			[]string{"--token="},
+			true,
+			nil,
+		},
+		{
+			"invalid token",
+			[]string{"--token=invalid", "--profiles=heap"},
+			true,
+			nil,
+		},
+		{
+			"unsupported profile",
+			[]string{"--token=test_token", "--profiles=missing"},
+			true,
+			nil,
+		},
+		{
+			"disabled profile endpoint",
+			[]string{"--token=test_token", "--profiles=goroutine"},
+			true,
+			nil,
+		},
+		{
+			"email/password auth",
+			[]string{"--email=test@example.com", "--password=1234567890", "--duration=2s"},
+			false,
+			map[string]string{
+				"cpu":  "/api/debug/pprof/profile?seconds=2",
+				"heap": "/api/debug/pprof/heap?",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			command := cmd.NewProfileCommand()
+			command.SetArgs(append([]string{"--url=" + server.URL, "--dir=" + dir}, s.args...))
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for name, expected := range s.expectedFiles {
+				matches, _ := filepath.Glob(filepath.Join(dir, name+"_*.pprof"))
+				if len(matches) != 1 {
+					t.Fatalf("Expected 1 %s profile file, got %v", name, matches)
+				}
+
+				content, err := os.ReadFile(matches[0])
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if string(content) != expected {
+					t.Fatalf("Expected %s profile content %q, got %q", name, expected, content)
+				}
+			}
+		})
+	}
+}
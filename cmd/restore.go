@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewRestoreCommand creates and returns new command for restoring
+// the records and files of specific collections from a backup
+// or for restoring the app data in the state at specific point in time.
+func NewRestoreCommand(app core.App) *cobra.Command {
+	var collections []string
+	var to string
+
+	command := &cobra.Command{
+		Use: "restore",
+		Example: "restore --collections posts,users pb_backup_20240101000000.zip\n" +
+			"restore --to 2024-05-01T12:00:00Z",
+		Short:        "Restores the selected collections from a backup or the app data at specific point in time",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if to != "" {
+				return restorePointInTime(app, to, args)
+			}
+
+			if len(args) != 1 || args[0] == "" {
+				return errors.New("missing backup name argument")
+			}
+
+			names := make([]string, 0, len(collections))
+			for _, c := range collections {
+				c = strings.TrimSpace(c)
+				if c != "" {
+					names = append(names, c)
+				}
+			}
+
+			if len(names) == 0 {
+				return errors.New("missing --collections flag (the full backup restore is available only via the Dashboard or the Web API)")
+			}
+
+			if err := app.RestoreBackupCollections(context.Background(), args[0], names); err != nil {
+				return fmt.Errorf("failed to restore backup %q: %w", args[0], err)
+			}
+
+			color.Green("Successfully restored %s from backup %q!", strings.Join(names, ", "), args[0])
+			return nil
+		},
+	}
+
+	command.Flags().StringSliceVar(
+		&collections,
+		"collections",
+		nil,
+		"Comma separated list of collection names or ids to restore",
+	)
+
+	command.Flags().StringVar(
+		&to,
+		"to",
+		"",
+		"RFC3339 date to restore the app data at (using the latest backup or WAL segment before it)",
+	)
+
+	command.MarkFlagsMutuallyExclusive("collections", "to")
+
+	return command
+}
+
+func restorePointInTime(app core.App, rawTime string, args []string) error {
+	if len(args) > 0 {
+		return errors.New("the backup name argument is not supported with the --to flag")
+	}
+
+	t, err := time.Parse(time.RFC3339, rawTime)
+	if err != nil {
+		return fmt.Errorf("invalid --to date (expected RFC3339 format, e.g. 2024-05-01T12:00:00Z): %w", err)
+	}
+
+	// the restore is performed outside of the serve process
+	// so there is no need to restart the current one
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		if e.IsRestart {
+			return nil
+		}
+		return e.Next()
+	})
+
+	if err := app.RestorePointInTime(context.Background(), t); err != nil {
+		return fmt.Errorf("failed to restore the app data at %s: %w", t.UTC().Format(time.RFC3339), err)
+	}
+
+	color.Green("Successfully restored the app data at %s!", t.UTC().Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,153 @@
+package cmd_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRestoreCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := app.CreateBackup(context.Background(), "test.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	// delete all demo1 records
+	records, err := app.FindAllRecords("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if err := app.Delete(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{
+			"missing backup name",
+			[]string{"--collections", "demo1"},
+			true,
+		},
+		{
+			"missing collections",
+			[]string{"test.zip"},
+			true,
+		},
+		{
+			"empty collections",
+			[]string{"--collections", " , ", "test.zip"},
+			true,
+		},
+		{
+			"missing backup",
+			[]string{"--collections", "demo1", "missing.zip"},
+			true,
+		},
+		{
+			"missing collection",
+			[]string{"--collections", "demo1,missing", "test.zip"},
+			true,
+		},
+		{
+			"valid",
+			[]string{"--collections", "demo1, demo2", "test.zip"},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			command := cmd.NewRestoreCommand(app)
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			total, err := app.CountRecords("demo1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if expected := int64(len(records)); !hasErr && total != expected {
+				t.Fatalf("Expected %d restored demo1 records, got %d", expected, total)
+			}
+		})
+	}
+}
+
+func TestRestoreCommandPointInTime(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	beforeBackup := time.Now().Add(-1 * time.Second).UTC().Format(time.RFC3339)
+
+	if err := app.CreateBackup(context.Background(), "test.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	afterBackup := time.Now().Add(1 * time.Second).UTC().Format(time.RFC3339)
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{
+			"invalid date",
+			[]string{"--to", "invalid"},
+			true,
+		},
+		{
+			"with backup name",
+			[]string{"--to", afterBackup, "test.zip"},
+			true,
+		},
+		{
+			"with collections",
+			[]string{"--to", afterBackup, "--collections", "demo1"},
+			true,
+		},
+		{
+			"no backup before the date",
+			[]string{"--to", beforeBackup},
+			true,
+		},
+		{
+			"valid",
+			[]string{"--to", afterBackup},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			command := cmd.NewRestoreCommand(app)
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewSeedCommand creates and returns new command for seeding
+// the app with the registered Go seed sets and the JSON/YAML seed files.
+func NewSeedCommand(app core.App) *cobra.Command {
+	var dir string
+	var profile string
+
+	command := &cobra.Command{
+		Use:          "seed [set names...]",
+		Example:      "seed\nseed --profile=test\nseed users posts",
+		Short:        "Seeds the app with fixture records",
+		Long:         "Seeds the app with the fixture records of the core.AppSeeds Go sets and the JSON/YAML files in the seeds directory.\nIf set names are specified, only those sets are seeded (regardless of their profiles).",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if dir == "" {
+				dir = filepath.Join(app.DataDir(), "../pb_seeds")
+			}
+
+			seeder := core.Seeder{}
+			seeder.Copy(core.AppSeeds)
+
+			if err := seeder.LoadDir(dir); err != nil {
+				return fmt.Errorf("failed to load the seed files: %w", err)
+			}
+
+			result, err := seeder.Run(app, profile, args...)
+			if err != nil {
+				return fmt.Errorf("failed to seed: %w", err)
+			}
+
+			if len(result.Seeded) == 0 {
+				color.Yellow("No seed sets for profile %q.", profile)
+				return nil
+			}
+
+			for _, name := range result.Seeded {
+				color.Green("Successfully seeded %q.", name)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&dir, "dir", "", "the seed files directory (default to pb_data/../pb_seeds)")
+	command.Flags().StringVar(&profile, "profile", "dev", "the seeding environment profile")
+
+	return command
+}
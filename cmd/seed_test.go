@@ -0,0 +1,80 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSeedCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "demo.yaml"), []byte(`
+profiles: [test]
+records:
+  - collection: demo1
+    ref: first
+    data:
+      text: seeded
+  - collection: demo1
+    data:
+      text: seeded
+      rel_one: "@ref:first"
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	countSeeded := func() int {
+		records, err := app.FindAllRecords("demo1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var total int
+		for _, r := range records {
+			if r.GetString("text") == "seeded" {
+				total++
+			}
+		}
+		return total
+	}
+
+	run := func(args ...string) error {
+		command := cmd.NewSeedCommand(app)
+		command.SetArgs(append([]string{"--dir", dir}, args...))
+		command.SetOut(new(bytes.Buffer))
+		command.SetErr(new(bytes.Buffer))
+		return command.Execute()
+	}
+
+	// the default "dev" profile doesn't match
+	if err := run(); err != nil {
+		t.Fatal(err)
+	}
+	if total := countSeeded(); total != 0 {
+		t.Fatalf("Expected no seeded records, got %d", total)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := run("--profile", "test"); err != nil {
+			t.Fatal(err)
+		}
+		if total := countSeeded(); total != 2 {
+			t.Fatalf("[%d] Expected 2 seeded records, got %d", i, total)
+		}
+	}
+
+	if err := run("missing"); err == nil {
+		t.Fatal("Expected missing seed set error")
+	}
+}
@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/pocketbase/pocketbase/apis"
 	"github.com/pocketbase/pocketbase/core"
@@ -15,6 +20,16 @@ func NewServeCommand(app core.App, showStartBanner bool, staticRouteEnabled bool
 	var allowedOrigins []string
 	var httpAddr string
 	var httpsAddr string
+	var reusePort bool
+	var shutdownTimeout time.Duration
+	var http3 bool
+	var tlsMinVersion string
+	var tlsCipherSuites []string
+	var hstsMaxAge time.Duration
+	var hstsIncludeSubdomains bool
+	var hstsPreload bool
+	var clientCAFile string
+	var clientAuth string
 
 	command := &cobra.Command{
 		Use:          "serve [domain(s)]",
@@ -36,14 +51,26 @@ func NewServeCommand(app core.App, showStartBanner bool, staticRouteEnabled bool
 				}
 			}
 
-			err := apis.Serve(app, apis.ServeConfig{
-				HttpAddr:           httpAddr,
-				HttpsAddr:          httpsAddr,
-				ShowStartBanner:    showStartBanner,
-				AllowedOrigins:     allowedOrigins,
-				CertificateDomains: args,
-				StaticRouteEnabled: staticRouteEnabled,
-			})
+			config := apis.ServeConfig{
+				HttpAddr:              httpAddr,
+				HttpsAddr:             httpsAddr,
+				ShowStartBanner:       showStartBanner,
+				AllowedOrigins:        allowedOrigins,
+				CertificateDomains:    args,
+				StaticRouteEnabled:    staticRouteEnabled,
+				ReusePort:             reusePort,
+				ShutdownTimeout:       shutdownTimeout,
+				HTTP3:                 http3,
+				HSTSMaxAge:            hstsMaxAge,
+				HSTSIncludeSubdomains: hstsIncludeSubdomains,
+				HSTSPreload:           hstsPreload,
+			}
+
+			if err := applyServeTLSFlags(&config, tlsMinVersion, tlsCipherSuites, clientCAFile, clientAuth); err != nil {
+				return err
+			}
+
+			err := apis.Serve(app, config)
 
 			if errors.Is(err, http.ErrServerClosed) {
 				return nil
@@ -74,5 +101,125 @@ func NewServeCommand(app core.App, showStartBanner bool, staticRouteEnabled bool
 		"TCP address to listen for the HTTPS server\n(if domain args are specified - default to 0.0.0.0:443, otherwise - default to empty string, aka. no TLS)\nThe incoming HTTP traffic also will be auto redirected to the HTTPS version",
 	)
 
+	command.PersistentFlags().BoolVar(
+		&reusePort,
+		"reuse-port",
+		false,
+		"Enable SO_REUSEPORT on the server socket allowing a new process to take over\nthe listener address while the old one is still draining (unix only)",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&shutdownTimeout,
+		"shutdown-timeout",
+		1*time.Second,
+		"Max duration to wait for the in-flight requests to complete on graceful shutdown",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&http3,
+		"http3",
+		false,
+		"Serve also HTTP/3 (QUIC) on the UDP port of the HTTPS server address\n(requires the app to be built with the http3 tag)",
+	)
+
+	command.PersistentFlags().StringVar(
+		&tlsMinVersion,
+		"tls-min-version",
+		"1.2",
+		"Minimum accepted TLS version of the HTTPS server (1.0, 1.1, 1.2 or 1.3)",
+	)
+
+	command.PersistentFlags().StringSliceVar(
+		&tlsCipherSuites,
+		"tls-ciphers",
+		nil,
+		"Comma separated list of the enabled TLS 1.0-1.2 cipher suites\n(e.g. TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384; default to the Go defaults)",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&hstsMaxAge,
+		"hsts-max-age",
+		0,
+		"Enable the Strict-Transport-Security header for the HTTPS requests with the specified max-age (e.g. 8760h)",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&hstsIncludeSubdomains,
+		"hsts-include-subdomains",
+		false,
+		"Add the includeSubDomains directive to the Strict-Transport-Security header",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&hstsPreload,
+		"hsts-preload",
+		false,
+		"Add the preload directive to the Strict-Transport-Security header",
+	)
+
+	command.PersistentFlags().StringVar(
+		&clientCAFile,
+		"client-ca",
+		"",
+		"PEM file with the CA certificate(s) used to verify the TLS client certificates",
+	)
+
+	command.PersistentFlags().StringVar(
+		&clientAuth,
+		"client-auth",
+		"",
+		"TLS client certificate authentication policy (require or optional)\n(default to require if --client-ca is set)",
+	)
+
 	return command
 }
+
+// applyServeTLSFlags resolves the TLS related serve flags into the provided config.
+func applyServeTLSFlags(config *apis.ServeConfig, minVersion string, cipherSuites []string, clientCAFile string, clientAuth string) error {
+	if minVersion != "" {
+		version, ok := apis.TLSVersionByName(minVersion)
+		if !ok {
+			return fmt.Errorf("invalid --tls-min-version %q", minVersion)
+		}
+		config.TLSMinVersion = version
+	}
+
+	for _, name := range cipherSuites {
+		id, ok := apis.TLSCipherSuiteByName(name)
+		if !ok {
+			return fmt.Errorf("unknown or insecure --tls-ciphers cipher suite %q", name)
+		}
+		config.TLSCipherSuites = append(config.TLSCipherSuites, id)
+	}
+
+	switch clientAuth {
+	case "":
+		if clientCAFile != "" {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	case "require":
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	case "optional":
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	default:
+		return fmt.Errorf("invalid --client-auth %q (must be require or optional)", clientAuth)
+	}
+
+	if config.ClientAuth != tls.NoClientCert {
+		if clientCAFile == "" {
+			return errors.New("--client-auth requires --client-ca")
+		}
+
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read --client-ca: %w", err)
+		}
+
+		config.ClientCAs = x509.NewCertPool()
+		if !config.ClientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no valid certificates found in %q", clientCAFile)
+		}
+	}
+
+	return nil
+}
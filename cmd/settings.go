@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewSettingsCommand creates and returns new command for reading
+// and updating the app settings (get, set).
+func NewSettingsCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "settings",
+		Short: "Manage the app settings",
+	}
+
+	command.AddCommand(settingsGetCommand(app))
+	command.AddCommand(settingsSetCommand(app))
+
+	return command
+}
+
+func settingsGetCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "get",
+		Example:      "settings get smtp.host",
+		Short:        "Prints the value of a single settings path (or all settings with masked secrets if no path is specified)",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			var value any = app.Settings()
+
+			if len(args) > 0 {
+				var err error
+				value, err = app.Settings().GetPath(args[0])
+				if err != nil {
+					return err
+				}
+			}
+
+			// print strings as they are to simplify their usage in scripts
+			if str, ok := value.(string); ok {
+				fmt.Println(str)
+				return nil
+			}
+
+			raw, err := json.MarshalIndent(value, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to serialize the settings value: %w", err)
+			}
+
+			fmt.Println(string(raw))
+			return nil
+		},
+	}
+
+	return command
+}
+
+func settingsSetCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "set",
+		Example:      "settings set smtp.enabled true",
+		Short:        "Validates and saves a new value of a single settings path (non-string values are parsed as JSON)",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("missing settings path and value arguments")
+			}
+
+			settings, err := app.Settings().Clone()
+			if err != nil {
+				return fmt.Errorf("failed to clone the app settings: %w", err)
+			}
+
+			if err := settings.SetPath(args[0], args[1]); err != nil {
+				return err
+			}
+
+			// note: the settings are encrypted on save if the app EncryptionEnv is set
+			if err := app.Save(settings); err != nil {
+				return fmt.Errorf("failed to save the settings: %w", err)
+			}
+
+			color.Green("Successfully updated %q!", args[0])
+			return nil
+		},
+	}
+
+	return command
+}
@@ -0,0 +1,51 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSettingsCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{"get all", []string{"get"}, false},
+		{"get path", []string{"get", "meta.appName"}, false},
+		{"get missing path", []string{"get", "meta.missing"}, true},
+		{"set missing args", []string{"set", "meta.appName"}, true},
+		{"set invalid value", []string{"set", "smtp.port", "abc"}, true},
+		{"set failing validation", []string{"set", "meta.appName", ""}, true},
+		{"set valid value", []string{"set", "meta.appName", "settings_cmd_test"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			command := cmd.NewSettingsCommand(app)
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+
+	if err := app.ReloadSettings(); err != nil {
+		t.Fatal(err)
+	}
+
+	if name := app.Settings().Meta.AppName; name != "settings_cmd_test" {
+		t.Fatalf("Expected the persisted appName %q, got %q", "settings_cmd_test", name)
+	}
+}
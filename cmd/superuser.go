@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -12,18 +15,80 @@ import (
 )
 
 // NewSuperuserCommand creates and returns new command for managing
-// superuser accounts (create, update, upsert, delete).
+// superuser accounts (list, create, update, upsert, delete, disable, etc.).
 func NewSuperuserCommand(app core.App) *cobra.Command {
 	command := &cobra.Command{
 		Use:   "superuser",
 		Short: "Manage superusers",
 	}
 
+	command.AddCommand(superuserListCommand(app))
 	command.AddCommand(superuserUpsertCommand(app))
 	command.AddCommand(superuserCreateCommand(app))
 	command.AddCommand(superuserUpdateCommand(app))
 	command.AddCommand(superuserDeleteCommand(app))
+	command.AddCommand(superuserDisableCommand(app))
 	command.AddCommand(superuserOTPCommand(app))
+	command.AddCommand(superuserResetOTPCommand(app))
+
+	return command
+}
+
+// superuserRandomPasswordLength is the length of the
+// generated "superuser create --random-password" passwords.
+const superuserRandomPasswordLength = 24
+
+func superuserListCommand(app core.App) *cobra.Command {
+	var jsonOutput bool
+
+	command := &cobra.Command{
+		Use:          "list",
+		Example:      "superuser list --json",
+		Short:        "Lists all superusers",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			superusers, err := app.FindAllRecords(core.CollectionNameSuperusers)
+			if err != nil {
+				return fmt.Errorf("failed to fetch superusers: %w", err)
+			}
+
+			slices.SortFunc(superusers, func(a, b *core.Record) int {
+				return strings.Compare(a.Email(), b.Email())
+			})
+
+			if jsonOutput {
+				result := make([]map[string]any, 0, len(superusers))
+				for _, superuser := range superusers {
+					result = append(result, map[string]any{
+						"id":      superuser.Id,
+						"email":   superuser.Email(),
+						"created": superuser.GetDateTime("created"),
+						"updated": superuser.GetDateTime("updated"),
+					})
+				}
+
+				return printSuperuserJSON(command, result)
+			}
+
+			if len(superusers) == 0 {
+				color.Yellow("No superusers found.")
+				return nil
+			}
+
+			for _, superuser := range superusers {
+				fmt.Fprintf(command.OutOrStdout(), "%s\t%s\t%s\n", superuser.Id, superuser.Email(), superuser.GetDateTime("created").String())
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().BoolVar(
+		&jsonOutput,
+		"json",
+		false,
+		"print the superusers as JSON array",
+	)
 
 	return command
 }
@@ -69,12 +134,22 @@ func superuserUpsertCommand(app core.App) *cobra.Command {
 }
 
 func superuserCreateCommand(app core.App) *cobra.Command {
+	var randomPassword bool
+	var jsonOutput bool
+
 	command := &cobra.Command{
 		Use:          "create",
-		Example:      "superuser create test@example.com 1234567890",
+		Example:      "superuser create test@example.com 1234567890\nsuperuser create test@example.com --random-password --json",
 		Short:        "Creates a new superuser",
 		SilenceUsage: true,
 		RunE: func(command *cobra.Command, args []string) error {
+			if randomPassword {
+				if len(args) != 1 {
+					return errors.New("missing email argument (the password argument must be omitted when --random-password is set)")
+				}
+				args = append(args, security.RandomString(superuserRandomPasswordLength))
+			}
+
 			if len(args) != 2 {
 				return errors.New("missing email and password arguments")
 			}
@@ -96,11 +171,41 @@ func superuserCreateCommand(app core.App) *cobra.Command {
 				return fmt.Errorf("failed to create new superuser account: %w", err)
 			}
 
+			if jsonOutput {
+				result := map[string]string{
+					"id":    superuser.Id,
+					"email": superuser.Email(),
+				}
+				if randomPassword {
+					result["password"] = args[1]
+				}
+
+				return printSuperuserJSON(command, result)
+			}
+
 			color.Green("Successfully created new superuser %q!", superuser.Email())
+			if randomPassword {
+				color.Green("└─ Password: %s", args[1])
+			}
+
 			return nil
 		},
 	}
 
+	command.PersistentFlags().BoolVar(
+		&randomPassword,
+		"random-password",
+		false,
+		"generate a random password for the new superuser (the password argument must be omitted)",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&jsonOutput,
+		"json",
+		false,
+		"print the created superuser id, email and generated password (if any) as JSON",
+	)
+
 	return command
 }
 
@@ -209,3 +314,79 @@ func superuserOTPCommand(app core.App) *cobra.Command {
 
 	return command
 }
+
+func superuserDisableCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "disable",
+		Example: "superuser disable test@example.com",
+		Short:   "Disables an existing superuser by invalidating its password, auth tokens and OTPs",
+		Long: "Disables an existing superuser by replacing its password with a random unknown one, " +
+			"invalidating all of its previously issued auth tokens and deleting its OTPs.\n" +
+			"The superuser could be enabled again by setting a new password with \"superuser update\".",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) == 0 || args[0] == "" || is.EmailFormat.Validate(args[0]) != nil {
+				return errors.New("invalid or missing email address")
+			}
+
+			superuser, err := app.FindAuthRecordByEmail(core.CollectionNameSuperusers, args[0])
+			if err != nil {
+				return fmt.Errorf("superuser with email %q doesn't exist", args[0])
+			}
+
+			err = app.RunInTransaction(func(txApp core.App) error {
+				// note: changing the password also refreshes the token key
+				superuser.SetPassword(security.RandomString(superuserRandomPasswordLength))
+
+				if err := txApp.Save(superuser); err != nil {
+					return err
+				}
+
+				return txApp.DeleteAllOTPsByRecord(superuser)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to disable superuser %q: %w", superuser.Email(), err)
+			}
+
+			color.Green("Successfully disabled superuser %q!", superuser.Email())
+			return nil
+		},
+	}
+
+	return command
+}
+
+func superuserResetOTPCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "reset-otp",
+		Example:      "superuser reset-otp test@example.com",
+		Short:        "Deletes all OTPs of the specified superuser",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) == 0 || args[0] == "" || is.EmailFormat.Validate(args[0]) != nil {
+				return errors.New("invalid or missing email address")
+			}
+
+			superuser, err := app.FindAuthRecordByEmail(core.CollectionNameSuperusers, args[0])
+			if err != nil {
+				return fmt.Errorf("superuser with email %q doesn't exist", args[0])
+			}
+
+			if err := app.DeleteAllOTPsByRecord(superuser); err != nil {
+				return fmt.Errorf("failed to delete superuser %q OTPs: %w", superuser.Email(), err)
+			}
+
+			color.Green("Successfully deleted all OTPs of superuser %q!", superuser.Email())
+			return nil
+		},
+	}
+
+	return command
+}
+
+func printSuperuserJSON(command *cobra.Command, data any) error {
+	encoder := json.NewEncoder(command.OutOrStdout())
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(data)
+}
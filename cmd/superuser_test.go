@@ -1,6 +1,9 @@
 package cmd_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/pocketbase/pocketbase/cmd"
@@ -401,3 +404,279 @@ func TestSuperuserOTPCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestSuperuserListCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	superusers, err := app.FindAllRecords(core.CollectionNameSuperusers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("plain", func(t *testing.T) {
+		out := &bytes.Buffer{}
+
+		command := cmd.NewSuperuserCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"list"})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if len(lines) != len(superusers) {
+			t.Fatalf("Expected %d lines, got %d:\n%s", len(superusers), len(lines), out.String())
+		}
+
+		for _, superuser := range superusers {
+			if !strings.Contains(out.String(), superuser.Id+"\t"+superuser.Email()) {
+				t.Fatalf("Missing superuser %q in\n%s", superuser.Email(), out.String())
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := &bytes.Buffer{}
+
+		command := cmd.NewSuperuserCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"list", "--json"})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		result := []map[string]any{}
+		if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+			t.Fatalf("Failed to unmarshal output: %v\n%s", err, out.String())
+		}
+
+		if len(result) != len(superusers) {
+			t.Fatalf("Expected %d superusers, got %d", len(superusers), len(result))
+		}
+
+		for _, item := range result {
+			if item["id"] == "" || item["email"] == "" {
+				t.Fatalf("Expected non-empty id and email, got %v", item)
+			}
+		}
+	})
+}
+
+func TestSuperuserCreateCommandRandomPassword(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{
+			"with password argument",
+			[]string{"create", "test_new@example.com", "1234567890", "--random-password", "--json"},
+			true,
+		},
+		{
+			"duplicated email",
+			[]string{"create", "test@example.com", "--random-password", "--json"},
+			true,
+		},
+		{
+			"valid email",
+			[]string{"create", "test_new@example.com", "--random-password", "--json"},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+
+			command := cmd.NewSuperuserCommand(app)
+			command.SetOut(out)
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			result := map[string]string{}
+			if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+				t.Fatalf("Failed to unmarshal output: %v\n%s", err, out.String())
+			}
+
+			if result["password"] == "" {
+				t.Fatal("Expected non-empty generated password")
+			}
+
+			superuser, err := app.FindAuthRecordByEmail(core.CollectionNameSuperusers, result["email"])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if superuser.Id != result["id"] {
+				t.Fatalf("Expected id %q, got %q", superuser.Id, result["id"])
+			}
+
+			if !superuser.ValidatePassword(result["password"]) {
+				t.Fatal("Expected the superuser password to match the generated one")
+			}
+		})
+	}
+}
+
+func TestSuperuserDisableCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		email       string
+		expectError bool
+	}{
+		{
+			"empty email",
+			"",
+			true,
+		},
+		{
+			"invalid email",
+			"invalid",
+			true,
+		},
+		{
+			"nonexisting superuser",
+			"test_missing@example.com",
+			true,
+		},
+		{
+			"existing superuser",
+			"test@example.com",
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			var oldTokenKey string
+			if old, err := app.FindAuthRecordByEmail(core.CollectionNameSuperusers, s.email); err == nil {
+				oldTokenKey = old.TokenKey()
+			}
+
+			command := cmd.NewSuperuserCommand(app)
+			command.SetArgs([]string{"disable", s.email})
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			superuser, err := app.FindAuthRecordByEmail(core.CollectionNameSuperusers, s.email)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if superuser.ValidatePassword("1234567890") {
+				t.Fatal("Expected the old superuser password to be invalidated")
+			}
+
+			if superuser.TokenKey() == oldTokenKey {
+				t.Fatal("Expected the superuser token key to be refreshed")
+			}
+
+			otps, _ := app.FindAllOTPsByRecord(superuser)
+			if total := len(otps); total != 0 {
+				t.Fatalf("Expected 0 OTPs, got %d", total)
+			}
+		})
+	}
+}
+
+func TestSuperuserResetOTPCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	superuser, err := app.FindAuthRecordByEmail(core.CollectionNameSuperusers, "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		otp := core.NewOTP(app)
+		otp.SetCollectionRef(superuser.Collection().Id)
+		otp.SetRecordRef(superuser.Id)
+		otp.SetPassword("123456")
+		if err := app.Save(otp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		name        string
+		email       string
+		expectError bool
+	}{
+		{
+			"invalid email",
+			"invalid",
+			true,
+		},
+		{
+			"nonexisting superuser",
+			"test_missing@example.com",
+			true,
+		},
+		{
+			"existing superuser",
+			"test@example.com",
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			command := cmd.NewSuperuserCommand(app)
+			command.SetArgs([]string{"reset-otp", s.email})
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			otps, _ := app.FindAllOTPsByRecord(superuser)
+			if total := len(otps); total != 0 {
+				t.Fatalf("Expected 0 OTPs, got %d", total)
+			}
+		})
+	}
+}
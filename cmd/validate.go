@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewValidateCommand creates and returns new command for re-validating
+// the stored records of a collection against its current fields schema.
+func NewValidateCommand(app core.App) *cobra.Command {
+	var options core.RecordsValidationOptions
+	var jsonOutput bool
+
+	command := &cobra.Command{
+		Use:          "validate [collection]",
+		Example:      "validate posts --fix=trim,select",
+		Short:        "Re-runs the field validators over all stored records of a collection",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			report, err := app.ValidateRecords(command.Context(), args[0], options)
+			if err != nil {
+				return fmt.Errorf("failed to validate the %q records: %w", args[0], err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(command.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(report); err != nil {
+					return err
+				}
+			} else {
+				printRecordsValidationReport(command, report)
+			}
+
+			if report.Invalid > 0 {
+				return fmt.Errorf("found %d invalid record(s)", report.Invalid)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringSliceVar(&options.Fixes, "fix", nil, "comma separated list of trivial coercions to apply and save for the invalid records ("+strings.Join(core.RecordsValidationFixes, ", ")+")")
+	command.Flags().IntVar(&options.BatchSize, "batch", core.DefaultRecordsValidationBatchSize, "the number of records to load at once")
+	command.Flags().BoolVar(&jsonOutput, "json", false, "print the validation report as JSON")
+
+	return command
+}
+
+func printRecordsValidationReport(command *cobra.Command, report *core.RecordsValidationReport) {
+	w := command.OutOrStdout()
+
+	for _, issue := range report.Issues {
+		fields := make([]string, 0, len(issue.Errors))
+		for name := range issue.Errors {
+			fields = append(fields, name)
+		}
+		slices.Sort(fields)
+
+		if len(issue.Fixed) > 0 {
+			color.New(color.FgGreen).Fprintf(w, "[%s] ✓ fixed %s\n", issue.RecordId, strings.Join(issue.Fixed, ", "))
+		} else {
+			color.New(color.FgRed).Fprintf(w, "[%s] ✗ invalid\n", issue.RecordId)
+		}
+
+		for _, name := range fields {
+			fmt.Fprintf(w, "    └─ %s: %s\n", name, issue.Errors[name])
+		}
+
+		if issue.FixError != "" {
+			fmt.Fprintf(w, "    └─ fix failed: %s\n", issue.FixError)
+		}
+	}
+
+	fmt.Fprintf(w, "Checked %d %q record(s): %d invalid, %d fixed.\n", report.Total, report.Collection, report.Invalid, report.Fixed)
+}
@@ -0,0 +1,103 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestValidateCommand(t *testing.T) {
+	t.Parallel()
+
+	newApp := func(t *testing.T) *tests.TestApp {
+		posts := core.NewBaseCollection("posts")
+		posts.Fields.Add(&core.SelectField{Name: "status", Values: []string{"a", "b"}, MaxSelect: 1})
+
+		app := tests.NewTestAppBuilder(t).InMemory().WithCollections(posts).Build()
+
+		rows := []dbx.Params{
+			{"id": "valid_000000000", "status": "a"},
+			{"id": "invalid_0000000", "status": "c"},
+		}
+		for _, row := range rows {
+			if _, err := app.DB().Insert("posts", row).Execute(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return app
+	}
+
+	t.Run("missing collection", func(t *testing.T) {
+		app := newApp(t)
+
+		command := cmd.NewValidateCommand(app)
+		command.SetOut(&bytes.Buffer{})
+		command.SetArgs([]string{"missing"})
+
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		app := newApp(t)
+
+		out := &bytes.Buffer{}
+
+		command := cmd.NewValidateCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"posts"})
+
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected invalid records error, got nil")
+		}
+
+		for _, str := range []string{
+			"[invalid_0000000] ✗ invalid",
+			"status: Invalid value c",
+			`Checked 2 "posts" record(s): 1 invalid, 0 fixed.`,
+		} {
+			if !strings.Contains(out.String(), str) {
+				t.Errorf("Missing %q in\n%s", str, out.String())
+			}
+		}
+	})
+
+	t.Run("json with fixes", func(t *testing.T) {
+		app := newApp(t)
+
+		out := &bytes.Buffer{}
+
+		command := cmd.NewValidateCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"posts", "--fix=select", "--json"})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		report := core.RecordsValidationReport{}
+		if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+			t.Fatalf("Failed to unmarshal output: %v\n%s", err, out.String())
+		}
+
+		if report.Total != 2 || report.Invalid != 0 || report.Fixed != 1 {
+			t.Fatalf("Expected 2 total, 0 invalid and 1 fixed records, got %d, %d and %d", report.Total, report.Invalid, report.Fixed)
+		}
+
+		record, err := app.FindRecordById("posts", "invalid_0000000")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status := record.GetString("status"); status != "" {
+			t.Fatalf("Expected the invalid status to be cleared, got %q", status)
+		}
+	})
+}
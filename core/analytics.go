@@ -0,0 +1,426 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*AnalyticsEvent)(nil)
+
+const (
+	AnalyticsEventsTableName  = "_analyticsEvents"
+	AnalyticsRollupsTableName = "_analyticsRollups"
+)
+
+// The supported AnalyticsEvent.Type values.
+const (
+	AnalyticsEventAPI  = "api"
+	AnalyticsEventPage = "page"
+)
+
+// The daily analytics rollup metrics.
+//
+// The endpoint and page metrics are keyed by the endpoint route
+// (e.g. "GET /api/collections/{collection}/records") or the page path.
+const (
+	AnalyticsMetricRequests     = "requests"
+	AnalyticsMetricClientErrors = "clientErrors"
+	AnalyticsMetricServerErrors = "serverErrors"
+	AnalyticsMetricUniqueAuths  = "uniqueAuths"
+	AnalyticsMetricPageViews    = "pageViews"
+	AnalyticsMetricEndpoint     = "endpoint"
+	AnalyticsMetricPage         = "page"
+)
+
+// AnalyticsDayLayout is the date layout of the analytics rollups day.
+const AnalyticsDayLayout = time.DateOnly
+
+const analyticsCronId = "__pbAnalytics__"
+
+// AnalyticsConfig defines the opt-in usage analytics settings.
+//
+// The analytics don't store IPs or user agents and the auth record ids are
+// replaced with a daily rotated salted hash, aka. the unique auths could be
+// counted only within the same day.
+type AnalyticsConfig struct {
+	// ExcludedPaths is a list of request path prefixes that are not tracked
+	// (the superuser requests are never tracked).
+	ExcludedPaths []string `form:"excludedPaths" json:"excludedPaths"`
+
+	// EventsMaxDays is the retention period of the raw events
+	// (they are no longer needed once rolled up).
+	EventsMaxDays int `form:"eventsMaxDays" json:"eventsMaxDays"`
+
+	// RollupsMaxDays is the retention period of the daily rollups
+	// (0 means no limit).
+	RollupsMaxDays int `form:"rollupsMaxDays" json:"rollupsMaxDays"`
+
+	Enabled bool `form:"enabled" json:"enabled"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c AnalyticsConfig) MarshalJSON() ([]byte, error) {
+	type alias AnalyticsConfig
+
+	// serialize as empty array
+	if c.ExcludedPaths == nil {
+		c.ExcludedPaths = []string{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes AnalyticsConfig validatable by implementing [validation.Validatable] interface.
+func (c AnalyticsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.ExcludedPaths, validation.Each(validation.Required, validation.By(checkAnalyticsPath))),
+		validation.Field(&c.EventsMaxDays, validation.When(c.Enabled, validation.Required), validation.Min(0)),
+		validation.Field(&c.RollupsMaxDays, validation.Min(0)),
+	)
+}
+
+// IsExcluded reports whether the specified request path shouldn't be tracked.
+func (c AnalyticsConfig) IsExcluded(path string) bool {
+	for _, prefix := range c.ExcludedPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkAnalyticsPath(value any) error {
+	v, _ := value.(string)
+	if !strings.HasPrefix(v, "/") {
+		return validation.NewError("validation_invalid_path", "The path must start with /.")
+	}
+
+	return nil
+}
+
+// AnalyticsEvent defines a single raw API request or page view event.
+type AnalyticsEvent struct {
+	BaseModel
+
+	Created types.DateTime `db:"created" json:"created"`
+	Type    string         `db:"type" json:"type"`
+	Method  string         `db:"method" json:"method"`
+
+	// Path is the matched route pattern of the API event or the page path.
+	Path string `db:"path" json:"path"`
+
+	// Visitor is the daily rotated salted hash of the auth record (if any).
+	Visitor string `db:"visitor" json:"visitor"`
+
+	Status   int     `db:"status" json:"status"`
+	Duration float64 `db:"duration" json:"duration"` // in ms
+}
+
+func (m *AnalyticsEvent) TableName() string {
+	return AnalyticsEventsTableName
+}
+
+// AnalyticsCount defines a single keyed analytics counter.
+type AnalyticsCount struct {
+	Key   string `db:"key" json:"key"`
+	Count int    `db:"count" json:"count"`
+}
+
+// AnalyticsDay defines the rolled up analytics of a single day.
+type AnalyticsDay struct {
+	Day          string  `json:"day"`
+	Requests     int     `json:"requests"`
+	ClientErrors int     `json:"clientErrors"`
+	ServerErrors int     `json:"serverErrors"`
+	ErrorRate    float64 `json:"errorRate"` // the server errors requests ratio
+	UniqueAuths  int     `json:"uniqueAuths"`
+	PageViews    int     `json:"pageViews"`
+}
+
+// AnalyticsSummary defines the rolled up analytics of a range of days.
+type AnalyticsSummary struct {
+	Days         []*AnalyticsDay   `json:"days"`
+	TopEndpoints []*AnalyticsCount `json:"topEndpoints"`
+	TopPages     []*AnalyticsCount `json:"topPages"`
+}
+
+// TrackAnalyticsEvent stores the provided analytics event
+// (it does nothing if the app analytics are disabled).
+//
+// If authRecord is set, it is stored as anonymized daily visitor hash.
+func (app *BaseApp) TrackAnalyticsEvent(event *AnalyticsEvent, authRecord *Record) error {
+	if !app.Settings().Analytics.Enabled {
+		return nil
+	}
+
+	if event.Id == "" {
+		event.Id = GenerateDefaultRandomId()
+	}
+
+	if event.Created.IsZero() {
+		event.Created = types.NowDateTime()
+	}
+
+	if authRecord != nil {
+		visitor, err := analyticsVisitor(app, authRecord, event.Created.Time())
+		if err != nil {
+			return err
+		}
+		event.Visitor = visitor
+	}
+
+	return app.AuxSaveNoValidate(event)
+}
+
+// RollupAnalytics (re)computes the daily rollups of the day of the specified time (in UTC).
+func (app *BaseApp) RollupAnalytics(day time.Time) error {
+	dayStr := day.UTC().Format(AnalyticsDayLayout)
+
+	start, _ := types.ParseDateTime(dayStr)
+	end := start.Add(24 * time.Hour)
+
+	params := dbx.Params{"day": dayStr, "start": start.String(), "end": end.String()}
+
+	return app.AuxRunInTransaction(func(txApp App) error {
+		_, err := txApp.AuxDB().Delete(AnalyticsRollupsTableName, dbx.HashExp{"day": dayStr}).Execute()
+		if err != nil {
+			return err
+		}
+
+		type metricQuery struct {
+			metric string
+			key    string
+			value  string
+			where  string
+		}
+
+		queries := []metricQuery{
+			{AnalyticsMetricRequests, `''`, "COUNT(*)", "[[type]] = 'api'"},
+			{AnalyticsMetricClientErrors, `''`, "COUNT(*)", "[[type]] = 'api' AND [[status]] >= 400 AND [[status]] < 500"},
+			{AnalyticsMetricServerErrors, `''`, "COUNT(*)", "[[type]] = 'api' AND [[status]] >= 500"},
+			{AnalyticsMetricUniqueAuths, `''`, "COUNT(DISTINCT [[visitor]])", "[[visitor]] != ''"},
+			{AnalyticsMetricPageViews, `''`, "COUNT(*)", "[[type]] = 'page'"},
+			{AnalyticsMetricEndpoint, "[[method]] || ' ' || [[path]]", "COUNT(*)", "[[type]] = 'api' AND [[path]] != ''"},
+			{AnalyticsMetricPage, "[[path]]", "COUNT(*)", "[[type]] = 'page'"},
+		}
+
+		for _, q := range queries {
+			sql := "INSERT INTO {{" + AnalyticsRollupsTableName + "}} ([[day]], [[metric]], [[key]], [[value]]) " +
+				"SELECT {:day}, '" + q.metric + "', " + q.key + ", " + q.value + " " +
+				"FROM {{" + AnalyticsEventsTableName + "}} " +
+				"WHERE [[created]] >= {:start} AND [[created]] < {:end} AND " + q.where + " " +
+				"GROUP BY 1, 2, 3 HAVING " + q.value + " > 0"
+
+			if _, err := txApp.AuxDB().NewQuery(sql).Bind(params).Execute(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FindAnalyticsSummary returns the rolled up analytics of the days
+// between from and to (inclusive) with up to topLimit most requested
+// endpoints and viewed pages.
+func (app *BaseApp) FindAnalyticsSummary(from time.Time, to time.Time, topLimit int) (*AnalyticsSummary, error) {
+	params := dbx.Params{
+		"from": from.UTC().Format(AnalyticsDayLayout),
+		"to":   to.UTC().Format(AnalyticsDayLayout),
+	}
+
+	rows := []struct {
+		Day    string  `db:"day"`
+		Metric string  `db:"metric"`
+		Value  float64 `db:"value"`
+	}{}
+
+	err := app.AuxDB().Select("day", "metric", "value").
+		From(AnalyticsRollupsTableName).
+		Where(dbx.NewExp("[[day]] >= {:from} AND [[day]] <= {:to} AND [[key]] = ''", params)).
+		OrderBy("day ASC").
+		All(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AnalyticsSummary{
+		Days:         []*AnalyticsDay{},
+		TopEndpoints: []*AnalyticsCount{},
+		TopPages:     []*AnalyticsCount{},
+	}
+
+	var current *AnalyticsDay
+	for _, row := range rows {
+		if current == nil || current.Day != row.Day {
+			current = &AnalyticsDay{Day: row.Day}
+			summary.Days = append(summary.Days, current)
+		}
+
+		switch row.Metric {
+		case AnalyticsMetricRequests:
+			current.Requests = int(row.Value)
+		case AnalyticsMetricClientErrors:
+			current.ClientErrors = int(row.Value)
+		case AnalyticsMetricServerErrors:
+			current.ServerErrors = int(row.Value)
+		case AnalyticsMetricUniqueAuths:
+			current.UniqueAuths = int(row.Value)
+		case AnalyticsMetricPageViews:
+			current.PageViews = int(row.Value)
+		}
+	}
+
+	for _, day := range summary.Days {
+		if day.Requests > 0 {
+			day.ErrorRate = float64(day.ServerErrors) / float64(day.Requests)
+		}
+	}
+
+	if topLimit > 0 {
+		for metric, dest := range map[string]*[]*AnalyticsCount{
+			AnalyticsMetricEndpoint: &summary.TopEndpoints,
+			AnalyticsMetricPage:     &summary.TopPages,
+		} {
+			metricParams := dbx.Params{"metric": metric}
+			maps.Copy(metricParams, params)
+
+			err := app.AuxDB().Select("key", "CAST(SUM([[value]]) AS INTEGER) as count").
+				From(AnalyticsRollupsTableName).
+				Where(dbx.NewExp("[[day]] >= {:from} AND [[day]] <= {:to} AND [[metric]] = {:metric}", metricParams)).
+				GroupBy("key").
+				OrderBy("count DESC", "key ASC").
+				Limit(int64(topLimit)).
+				All(dest)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// DeleteOldAnalytics deletes the raw analytics events created before
+// eventsBefore and the daily rollups older than rollupsBefore
+// (zero rollupsBefore keeps all rollups).
+func (app *BaseApp) DeleteOldAnalytics(eventsBefore time.Time, rollupsBefore time.Time) error {
+	eventsDate, err := types.ParseDateTime(eventsBefore.UTC())
+	if err != nil {
+		return err
+	}
+
+	_, err = app.AuxNonconcurrentDB().Delete(
+		AnalyticsEventsTableName,
+		dbx.NewExp("[[created]] < {:date}", dbx.Params{"date": eventsDate.String()}),
+	).Execute()
+	if err != nil || rollupsBefore.IsZero() {
+		return err
+	}
+
+	_, err = app.AuxNonconcurrentDB().Delete(
+		AnalyticsRollupsTableName,
+		dbx.NewExp("[[day]] < {:day}", dbx.Params{"day": rollupsBefore.UTC().Format(AnalyticsDayLayout)}),
+	).Execute()
+
+	return err
+}
+
+// analyticsVisitor returns the daily salted hash of the provided auth record.
+func analyticsVisitor(app App, authRecord *Record, t time.Time) (string, error) {
+	store := app.KV().Namespace("_analytics")
+
+	var salt string
+	if err := store.Get("salt", &salt); err != nil || salt == "" {
+		salt = security.RandomString(50)
+		if err := store.Set("salt", salt); err != nil {
+			return "", fmt.Errorf("failed to store the analytics salt: %w", err)
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(t.UTC().Format(AnalyticsDayLayout)))
+	mac.Write([]byte{0})
+	mac.Write([]byte(authRecord.Collection().Id))
+	mac.Write([]byte{0})
+	mac.Write([]byte(authRecord.Id))
+
+	return hex.EncodeToString(mac.Sum(nil))[:32], nil
+}
+
+func (app *BaseApp) registerAnalyticsHooks() {
+	loadJob := func() {
+		config := app.Settings().Analytics
+
+		if !config.Enabled {
+			app.Cron().Remove(analyticsCronId)
+			return
+		}
+
+		// refresh the current and the previous (in case of a day change) rollups
+		app.Cron().Add(analyticsCronId, "*/10 * * * *", func() {
+			config := app.Settings().Analytics
+
+			now := time.Now()
+
+			for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+				if err := app.RollupAnalytics(day); err != nil {
+					app.Logger().Warn(
+						"[Analytics cron] Failed to rollup the analytics events",
+						slog.String("day", day.UTC().Format(AnalyticsDayLayout)),
+						slog.String("error", err.Error()),
+					)
+					return
+				}
+			}
+
+			// keep at least the events of the rolled up days
+			eventsBefore := now.AddDate(0, 0, -1*max(config.EventsMaxDays, 2))
+
+			var rollupsBefore time.Time
+			if config.RollupsMaxDays > 0 {
+				rollupsBefore = now.AddDate(0, 0, -1*config.RollupsMaxDays)
+			}
+
+			if err := app.DeleteOldAnalytics(eventsBefore, rollupsBefore); err != nil {
+				app.Logger().Warn(
+					"[Analytics cron] Failed to delete old analytics",
+					slog.String("error", err.Error()),
+				)
+			}
+		})
+	}
+
+	app.OnBootstrap().BindFunc(func(e *BootstrapEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	})
+
+	app.OnSettingsReload().BindFunc(func(e *SettingsReloadEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	})
+}
@@ -0,0 +1,222 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestAnalyticsConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		config         core.AnalyticsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.AnalyticsConfig{},
+			[]string{},
+		},
+		{
+			"enabled with invalid data",
+			core.AnalyticsConfig{
+				Enabled:        true,
+				RollupsMaxDays: -1,
+				ExcludedPaths:  []string{"", "api"},
+			},
+			[]string{"eventsMaxDays", "rollupsMaxDays", "excludedPaths"},
+		},
+		{
+			"enabled with valid data",
+			core.AnalyticsConfig{
+				Enabled:       true,
+				EventsMaxDays: 7,
+				ExcludedPaths: []string{"/api/health"},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			tests.TestValidationErrors(t, s.config.Validate(), s.expectedErrors)
+		})
+	}
+}
+
+func TestTrackAnalyticsEvent(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	countEvents := func() int {
+		var total int
+		if err := app.AuxDB().Select("count(*)").From(core.AnalyticsEventsTableName).Row(&total); err != nil {
+			t.Fatal(err)
+		}
+		return total
+	}
+
+	// disabled
+	if err := app.TrackAnalyticsEvent(&core.AnalyticsEvent{Type: core.AnalyticsEventPage, Path: "/"}, user1); err != nil {
+		t.Fatal(err)
+	}
+	if total := countEvents(); total != 0 {
+		t.Fatalf("Expected no events to be stored while disabled, got %d", total)
+	}
+
+	app.Settings().Analytics.Enabled = true
+
+	events := []*core.AnalyticsEvent{
+		{Type: core.AnalyticsEventPage, Path: "/"},
+		{Type: core.AnalyticsEventPage, Path: "/"},
+		{Type: core.AnalyticsEventPage, Path: "/"},
+		{Type: core.AnalyticsEventPage, Path: "/"},
+	}
+
+	for i, auth := range []*core.Record{user1, user1, user2, nil} {
+		if err := app.TrackAnalyticsEvent(events[i], auth); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if total := countEvents(); total != len(events) {
+		t.Fatalf("Expected %d stored events, got %d", len(events), total)
+	}
+
+	if events[0].Visitor == "" || strings.Contains(events[0].Visitor, user1.Id) {
+		t.Fatalf("Expected anonymized visitor hash, got %q", events[0].Visitor)
+	}
+
+	if events[0].Visitor != events[1].Visitor {
+		t.Fatalf("Expected the same daily visitor hash, got %q and %q", events[0].Visitor, events[1].Visitor)
+	}
+
+	if events[0].Visitor == events[2].Visitor {
+		t.Fatal("Expected different visitor hashes for different auth records")
+	}
+
+	if events[3].Visitor != "" {
+		t.Fatalf("Expected empty guest visitor, got %q", events[3].Visitor)
+	}
+
+	// different day
+	nextDayEvent := &core.AnalyticsEvent{Type: core.AnalyticsEventPage, Path: "/"}
+	nextDayEvent.Created = types.NowDateTime().Add(24 * time.Hour)
+	if err := app.TrackAnalyticsEvent(nextDayEvent, user1); err != nil {
+		t.Fatal(err)
+	}
+	if nextDayEvent.Visitor == events[0].Visitor {
+		t.Fatal("Expected the visitor hash to rotate daily")
+	}
+}
+
+func TestRollupAnalyticsAndSummary(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Analytics.Enabled = true
+
+	day1 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 1, 2, 23, 59, 0, 0, time.UTC)
+
+	user, err := app.FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	track := func(created time.Time, event *core.AnalyticsEvent, auth *core.Record) {
+		event.Created, _ = types.ParseDateTime(created)
+		if err := app.TrackAnalyticsEvent(event, auth); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records := "/api/collections/{collection}/records"
+
+	track(day1, &core.AnalyticsEvent{Type: core.AnalyticsEventAPI, Method: "GET", Path: records, Status: 200}, user)
+	track(day1, &core.AnalyticsEvent{Type: core.AnalyticsEventAPI, Method: "GET", Path: records, Status: 200}, user)
+	track(day1, &core.AnalyticsEvent{Type: core.AnalyticsEventAPI, Method: "GET", Path: records, Status: 404}, nil)
+	track(day1, &core.AnalyticsEvent{Type: core.AnalyticsEventAPI, Method: "POST", Path: records, Status: 500}, nil)
+	track(day1, &core.AnalyticsEvent{Type: core.AnalyticsEventPage, Path: "/pricing"}, nil)
+	track(day2, &core.AnalyticsEvent{Type: core.AnalyticsEventAPI, Method: "POST", Path: records, Status: 200}, user)
+	track(day2, &core.AnalyticsEvent{Type: core.AnalyticsEventPage, Path: "/"}, user)
+	track(day2, &core.AnalyticsEvent{Type: core.AnalyticsEventPage, Path: "/pricing"}, nil)
+	track(day2.Add(time.Minute), &core.AnalyticsEvent{Type: core.AnalyticsEventAPI, Method: "GET", Path: records, Status: 200}, nil) // next day
+
+	// rollup twice to ensure that the rollups are replaced
+	for i := 0; i < 2; i++ {
+		for _, day := range []time.Time{day1, day2} {
+			if err := app.RollupAnalytics(day); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	summary, err := app.FindAnalyticsSummary(day1, day2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summary.Days) != 2 {
+		t.Fatalf("Expected 2 summary days, got %d", len(summary.Days))
+	}
+
+	expectedDays := []core.AnalyticsDay{
+		{Day: "2026-01-01", Requests: 4, ClientErrors: 1, ServerErrors: 1, ErrorRate: 0.25, UniqueAuths: 1, PageViews: 1},
+		{Day: "2026-01-02", Requests: 1, UniqueAuths: 1, PageViews: 2},
+	}
+	for i, expected := range expectedDays {
+		if *summary.Days[i] != expected {
+			t.Fatalf("Expected day %d\n%#v\ngot\n%#v", i, expected, *summary.Days[i])
+		}
+	}
+
+	if len(summary.TopEndpoints) != 1 || summary.TopEndpoints[0].Key != "GET "+records || summary.TopEndpoints[0].Count != 3 {
+		t.Fatalf("Unexpected top endpoints %v", summary.TopEndpoints)
+	}
+
+	if len(summary.TopPages) != 1 || summary.TopPages[0].Key != "/pricing" || summary.TopPages[0].Count != 2 {
+		t.Fatalf("Unexpected top pages %v", summary.TopPages)
+	}
+
+	// retention
+	if err := app.DeleteOldAnalytics(day2, day2); err != nil {
+		t.Fatal(err)
+	}
+
+	var totalEvents int
+	if err := app.AuxDB().Select("count(*)").From(core.AnalyticsEventsTableName).Row(&totalEvents); err != nil {
+		t.Fatal(err)
+	}
+	if totalEvents != 4 {
+		t.Fatalf("Expected only the day2 events to remain, got %d", totalEvents)
+	}
+
+	summary, err = app.FindAnalyticsSummary(day1, day2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Days) != 1 || summary.Days[0].Day != "2026-01-02" {
+		t.Fatalf("Expected only the day2 rollups to remain, got %v", summary.Days)
+	}
+}
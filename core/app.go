@@ -5,14 +5,18 @@ package core
 
 import (
 	"context"
+	"iter"
 	"log/slog"
+	"net/mail"
 	"time"
 
 	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/captcha"
 	"github.com/pocketbase/pocketbase/tools/cron"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/notifier"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
 )
@@ -38,6 +42,16 @@ type App interface {
 	// If the application is not bootstrapped yet, fallbacks to slog.Default().
 	Logger() *slog.Logger
 
+	// FlushLogs writes immediately all accumulated app logs
+	// without waiting for the batch size or flush interval thresholds.
+	FlushLogs() error
+
+	// ReportPanic reports a recovered panic value by triggering the [App.OnPanic] hook.
+	//
+	// source is the origin of the panic (e.g. [PanicSourceRequest]) and
+	// name is an optional identifier of the origin (e.g. the cron job id).
+	ReportPanic(source string, name string, recovered any, stack []byte) error
+
 	// IsBootstrapped checks if the application was initialized
 	// (aka. whether Bootstrap() was called).
 	IsBootstrapped() bool
@@ -51,6 +65,12 @@ type App interface {
 	// the related app transaction completes using `app.TxInfo().OnAfterFunc(callback)`.
 	TxInfo() *TxAppInfo
 
+	// AfterCommit executes fn after the current app transaction commits
+	// (or immediately if the app is not part of a transaction).
+	//
+	// See [BaseApp.AfterCommit] for more details.
+	AfterCommit(fn func(app App) error) error
+
 	// Bootstrap initializes the application
 	// (aka. create data dir, open db connections, load settings, etc.).
 	//
@@ -76,19 +96,128 @@ type App interface {
 	// Settings returns the loaded app settings.
 	Settings() *Settings
 
+	// RegisterSettingsExtension registers a new custom typed app settings
+	// section (see [SettingsExtension]).
+	//
+	// It is expected to be called before the app bootstrap.
+	RegisterSettingsExtension(ext SettingsExtension) error
+
+	// SettingsExtensions returns the registered custom app settings sections.
+	SettingsExtensions() []SettingsExtension
+
 	// Store returns the app runtime store.
 	Store() *store.Store[string, any]
 
+	// KV returns the app persistent key-value store.
+	//
+	// Use [KVStore.Namespace] to scope the store items (e.g. per plugin).
+	KV() *KVStore
+
 	// Cron returns the app cron instance.
 	Cron() *cron.Cron
 
+	// FindCronJobState finds the persisted state of the cron job with the specified id.
+	FindCronJobState(jobId string) (*CronJobState, error)
+
+	// FindCronRuns returns the most recent runs of the cron job
+	// with the specified id (newest first).
+	//
+	// If limit <= 0 all stored runs are returned.
+	FindCronRuns(jobId string, limit int) ([]*CronRun, error)
+
+	// DisableCronJob disables the scheduled execution of the registered
+	// cron job with the specified id until the specified time
+	// (use zero until to disable it until explicitly enabled).
+	DisableCronJob(jobId string, until time.Time) error
+
+	// EnableCronJob enables the scheduled execution of
+	// the previously disabled cron job with the specified id.
+	EnableCronJob(jobId string) error
+
+	// RunCronJob executes immediately the registered cron job with the
+	// specified id (regardless of its disabled state) and stores the run in its history.
+	RunCronJob(jobId string) error
+
+	// ScheduleAt persists a new one-off task with the specified name and
+	// JSON serializable payload to be executed at least once at the specified time
+	// by triggering the [App.OnScheduledTaskRun] hook.
+	ScheduleAt(runAt time.Time, name string, payload any) (*ScheduledTask, error)
+
+	// FindScheduledTask finds the scheduled task with the specified id.
+	FindScheduledTask(id string) (*ScheduledTask, error)
+
+	// CancelScheduledTask deletes the scheduled task with the specified id.
+	CancelScheduledTask(id string) error
+
+	// RunDueScheduledTasks executes all pending scheduled tasks which RunAt time has passed.
+	//
+	// The due tasks are usually executed automatically by the app while serving
+	// and normally you don't have to call this method manually.
+	RunDueScheduledTasks() error
+
+	// RegisterPlugin registers the provided plugin and its lifecycle hooks
+	// (migrations, routes, cron jobs, etc.).
+	//
+	// It is expected to be called before the app bootstrap.
+	RegisterPlugin(p Plugin) error
+
+	// Plugins returns the registered app plugins.
+	Plugins() []Plugin
+
+	// FindPlugin returns the registered plugin with the specified name (or nil if missing).
+	FindPlugin(name string) Plugin
+
+	// IsPluginEnabled reports whether the plugin with the specified name
+	// is registered and not disabled in the app settings.
+	IsPluginEnabled(name string) bool
+
+	// PluginSettings returns the settings section of the plugin with the
+	// specified name merged on top of its default settings.
+	PluginSettings(name string) map[string]any
+
+	// CreateCollectionSnapshot stores a new snapshot of the current
+	// collections configuration and prunes the old ones (see [MaxCollectionSnapshots]).
+	CreateCollectionSnapshot(trigger string, collectionName string) (*CollectionSnapshot, error)
+
+	// FindCollectionSnapshots returns the most recent collection snapshots
+	// (newest first) without their collections data.
+	//
+	// If limit <= 0 all stored snapshots are returned.
+	FindCollectionSnapshots(limit int) ([]*CollectionSnapshot, error)
+
+	// FindCollectionSnapshotById finds the collection snapshot with the specified id.
+	FindCollectionSnapshotById(id string) (*CollectionSnapshot, error)
+
+	// RollbackCollectionSnapshot restores the collections configuration
+	// from the snapshot with the specified id.
+	//
+	// NB! All collections and fields that are not present in the snapshot
+	// WILL BE DELETED (including their related records data).
+	RollbackCollectionSnapshot(id string) (*CollectionSnapshot, error)
+
 	// SubscriptionsBroker returns the app realtime subscriptions broker instance.
 	SubscriptionsBroker() *subscriptions.Broker
 
-	// NewMailClient creates and returns a new SMTP or Sendmail client
+	// NewMailClient creates and returns a new HTTP API provider, SMTP or Sendmail client
 	// based on the current app settings.
 	NewMailClient() mailer.Mailer
 
+	// NewSMSClient creates and returns a new text messages (SMS) client
+	// based on the current app settings.
+	NewSMSClient() notifier.Notifier
+
+	// NewCaptchaVerifier creates and returns a new captcha token verifier
+	// based on the current app settings.
+	//
+	// It returns nil if the captcha verification is not enabled
+	// or the settings provider is "custom".
+	NewCaptchaVerifier() captcha.Verifier
+
+	// Notifications returns the app push notifications service
+	// that could be used to send push messages to the registered
+	// devices of auth records.
+	Notifications() *PushNotifications
+
 	// NewFilesystem creates a new local or S3 filesystem instance
 	// for managing regular app files (ex. record uploads)
 	// based on the current app settings.
@@ -115,6 +244,24 @@ type App interface {
 	// for details on the backup procedures.
 	CreateBackup(ctx context.Context, name string) error
 
+	// CreateIncrementalBackup creates a new backup of the current app pb_data
+	// directory that stores only the changes since the specified base backup.
+	//
+	// Please refer to the godoc of the specific core.App implementation
+	// for details on the backup procedures.
+	CreateIncrementalBackup(ctx context.Context, name string, base string) error
+
+	// ExtractBackup extracts the content of the backup with the specified
+	// name into dest (resolving the incremental backups chain, if any).
+	ExtractBackup(ctx context.Context, name string, dest string) error
+
+	// VerifyBackup verifies that the backup with the specified name could be
+	// restored by extracting it in a temp location and running integrity checks.
+	//
+	// Please refer to the godoc of the specific core.App implementation
+	// for details on the verification procedures.
+	VerifyBackup(ctx context.Context, name string) (*BackupVerification, error)
+
 	// RestoreBackup restores the backup with the specified name and restarts
 	// the current running application process.
 	//
@@ -127,6 +274,50 @@ type App interface {
 	// NB! This feature is experimental and currently is expected to work only on UNIX based systems.
 	RestoreBackup(ctx context.Context, name string) error
 
+	// RestoreBackupCollections restores only the records and files of the
+	// specified collections from the backup with the specified name into
+	// the current running application (without restart).
+	//
+	// Please refer to the godoc of the specific core.App implementation
+	// for details on the restore procedures.
+	RestoreBackupCollections(ctx context.Context, name string, collections []string) error
+
+	// PruneBackups removes the autogenerated backups that are no longer needed
+	// based on the app backups CronMaxKeep and Retention settings.
+	//
+	// If dryRun is true, no backups are removed and only the report is returned.
+	//
+	// Please refer to the godoc of the specific core.App implementation
+	// for details on the retention rules.
+	PruneBackups(ctx context.Context, dryRun bool) (*BackupsRetentionReport, error)
+
+	// RunDataRetention executes the app data retention rules by deleting
+	// (or archiving) the collections records older than the rule period.
+	//
+	// If dryRun is true, no records are processed and only the number
+	// of the matching records is reported.
+	//
+	// Please refer to the godoc of the specific core.App implementation
+	// for details on the processing of the rules.
+	RunDataRetention(ctx context.Context, dryRun bool) ([]*DataRetentionStats, error)
+
+	// ArchiveWALSegment archives the main database changes since the previous
+	// point-in-time recovery segment (or the latest backup) as a new segment
+	// in the backups filesystem.
+	//
+	// Returns the name of the new segment or an empty string if there were no changes.
+	ArchiveWALSegment(ctx context.Context) (string, error)
+
+	// RestorePointInTime restores the app data in the state at the specified
+	// time (using the latest backup or point-in-time recovery segment created
+	// before it) and restarts the current running application process.
+	//
+	// Please refer to the godoc of the specific core.App implementation
+	// for details on the restore procedures.
+	//
+	// NB! This feature is experimental and currently is expected to work only on UNIX based systems.
+	RestorePointInTime(ctx context.Context, t time.Time) error
+
 	// Restart restarts (aka. replaces) the current running application process.
 	//
 	// NB! It relies on execve which is supported only on UNIX based systems.
@@ -273,6 +464,72 @@ type App interface {
 	// AuxVacuum executes VACUUM on the auxiliary.db in order to reclaim unused auxiliary db disk space.
 	AuxVacuum() error
 
+	// OptimizeDB checkpoints the WAL, optionally vacuums and collects the query
+	// planner statistics of the main and auxiliary databases and returns
+	// a report with their size before and after the optimization.
+	OptimizeDB(ctx context.Context, vacuum bool) (*DBOptimizeReport, error)
+
+	// HookStats returns the collected hook handlers execution stats
+	// (available only while the HookTracing setting is enabled)
+	// sorted by their total execution time (desc).
+	HookStats() []*HookHandlerStats
+
+	// ResetHookStats clears the collected hook handlers execution stats.
+	ResetHookStats()
+
+	// RunningQueries returns the currently executing db queries
+	// from the concurrent db pool sorted by their start time.
+	RunningQueries() []*RunningQuery
+
+	// CancelRunningQuery cancels the currently executing db query with the specified id.
+	//
+	// Returns false if there is no running query with the specified id.
+	CancelRunningQuery(id string) bool
+
+	// SlowQueries returns the most recently recorded slow db queries
+	// from the concurrent db pool in the order of their completion.
+	SlowQueries() []*SlowQuery
+
+	// AdviseIndexes analyzes the collections API rules and the recorded
+	// slow queries and returns suggestions for the missing collection indexes.
+	AdviseIndexes() ([]*IndexSuggestion, error)
+
+	// ApplyIndexSuggestions adds the indexes of the provided suggestions
+	// to their collections and persists the changes.
+	ApplyIndexSuggestions(suggestions []*IndexSuggestion) error
+
+	// FindDanglingRelations scans the relation fields of the specified
+	// collections (or of all collections if none are specified)
+	// and returns the references to missing records.
+	FindDanglingRelations(collectionNamesOrIds ...string) ([]*DanglingRelation, error)
+
+	// RepairDanglingRelations removes the missing ids of the provided
+	// dangling relations from their records.
+	RepairDanglingRelations(dangling []*DanglingRelation) error
+
+	// CollectionStats returns the lazily refreshed storage statistics
+	// (row counts, approximate disk size, largest records and
+	// index statistics) of all non-view collections.
+	CollectionStats() (*CollectionStatsReport, error)
+
+	// RefreshCollectionStats recalculates and caches the collection statistics.
+	RefreshCollectionStats() (*CollectionStatsReport, error)
+
+	// Diagnose runs a set of checks for the most common operational
+	// problems (data dir permissions, databases integrity, pending migrations, etc.)
+	// and returns their findings.
+	Diagnose(ctx context.Context, options DiagnosticsOptions) ([]*DiagnosticFinding, error)
+
+	// ValidateRecords re-runs the field validators over all stored
+	// records of the specified collection and reports the invalid ones
+	// (optionally applying trivial coercions to fix them).
+	ValidateRecords(ctx context.Context, collectionModelOrIdentifier any, options RecordsValidationOptions) (*RecordsValidationReport, error)
+
+	// GenerateRecords creates the specified number of fake records
+	// based on the collection field types and constraints
+	// (e.g. for demo environments and performance testing).
+	GenerateRecords(ctx context.Context, collectionModelOrIdentifier any, options RecordsGenerateOptions) (int, error)
+
 	// ---------------------------------------------------------------
 
 	// ModelQuery creates a new preconfigured select data.db query with preset
@@ -350,11 +607,19 @@ type App interface {
 	// It is safe to nest RunInTransaction calls as long as you use the callback's txApp.
 	RunInTransaction(fn func(txApp App) error) error
 
+	// RunInTransactionWithContext is the same as [App.RunInTransaction]
+	// but allows specifying a context to limit the transaction execution.
+	RunInTransactionWithContext(ctx context.Context, fn func(txApp App) error) error
+
 	// AuxRunInTransaction wraps fn into a transaction for the auxiliary app database.
 	//
 	// It is safe to nest RunInTransaction calls as long as you use the callback's txApp.
 	AuxRunInTransaction(fn func(txApp App) error) error
 
+	// AuxRunInTransactionWithContext is the same as [App.AuxRunInTransaction]
+	// but allows specifying a context to limit the transaction execution.
+	AuxRunInTransactionWithContext(ctx context.Context, fn func(txApp App) error) error
+
 	// ---------------------------------------------------------------
 
 	// LogQuery returns a new Log select query.
@@ -366,11 +631,205 @@ type App interface {
 	// LogsStatsItem returns hourly grouped logs statistics.
 	LogsStats(expr dbx.Expression) ([]*LogsStatsItem, error)
 
+	// LogsAggregate returns the logs total grouped by the specified time
+	// interval ("minute", "hour" or "day") and optional groupBy field
+	// (e.g. "level" to count the logs by level per hour).
+	//
+	// groupBy could be "level", "message" or a "data.*" JSON path (e.g. "data.status").
+	LogsAggregate(interval string, groupBy string, expr dbx.Expression) ([]*LogsAggregateItem, error)
+
+	// LogsFacets returns the most common values (up to limit) of the
+	// specified field with their total number of logs (in DESC order).
+	//
+	// field could be "level", "message" or a "data.*" JSON path (e.g. "data.status").
+	LogsFacets(field string, limit int, expr dbx.Expression) ([]*LogsFacetItem, error)
+
 	// DeleteOldLogs delete all logs that are created before createdBefore.
 	DeleteOldLogs(createdBefore time.Time) error
 
 	// ---------------------------------------------------------------
 
+	// TrackAnalyticsEvent stores the provided analytics event
+	// (it does nothing if the app analytics are disabled).
+	//
+	// If authRecord is set, it is stored as anonymized daily visitor hash.
+	TrackAnalyticsEvent(event *AnalyticsEvent, authRecord *Record) error
+
+	// RollupAnalytics (re)computes the daily rollups of the day of the specified time (in UTC).
+	RollupAnalytics(day time.Time) error
+
+	// FindAnalyticsSummary returns the rolled up analytics of the days
+	// between from and to (inclusive) with up to topLimit most requested
+	// endpoints and viewed pages.
+	FindAnalyticsSummary(from time.Time, to time.Time, topLimit int) (*AnalyticsSummary, error)
+
+	// DeleteOldAnalytics deletes the raw analytics events created before
+	// eventsBefore and the daily rollups older than rollupsBefore
+	// (zero rollupsBefore keeps all rollups).
+	DeleteOldAnalytics(eventsBefore time.Time, rollupsBefore time.Time) error
+
+	// ---------------------------------------------------------------
+
+	// SaveCapturedRequest redacts and stores the provided captured request
+	// (it does nothing if the app traffic capture is disabled).
+	//
+	// Only the most recent Settings().Capture.MaxItems captured requests are kept.
+	SaveCapturedRequest(capture *CapturedRequest) error
+
+	// RedactCapturedRequest masks in place the sensitive information
+	// of the provided captured request headers and bodies.
+	RedactCapturedRequest(capture *CapturedRequest)
+
+	// FindCapturedRequestById finds a single captured request by its id.
+	FindCapturedRequestById(id string) (*CapturedRequest, error)
+
+	// DeleteCapturedRequests deletes all stored captured requests.
+	DeleteCapturedRequests() error
+
+	// ---------------------------------------------------------------
+
+	// TagQuery returns a new Tag select query.
+	TagQuery() *dbx.SelectQuery
+
+	// FindTagSuggestions returns the most used tags of the specified collection tags field
+	// that start with the provided prefix (ASCII case-insensitive).
+	//
+	// If limit <= 0, fallbacks to DefaultTagSuggestionsLimit.
+	FindTagSuggestions(collectionModelOrIdentifier any, fieldName string, prefix string, limit int) ([]*Tag, error)
+
+	// RenameTag replaces the oldTag with newTag in all records of the specified
+	// collection tags field and returns the number of the updated records.
+	RenameTag(collectionModelOrIdentifier any, fieldName string, oldTag string, newTag string) (int, error)
+
+	// MergeTags replaces the source tags with the target tag in all records of the specified
+	// collection tags field and returns the number of the updated records.
+	MergeTags(collectionModelOrIdentifier any, fieldName string, target string, sources ...string) (int, error)
+
+	// RebuildTagsCounts recalculates the usage counts of all tags fields
+	// of the specified collection from the stored records values
+	// (e.g. after importing records with raw db queries).
+	RebuildTagsCounts(collectionModelOrIdentifier any) error
+
+	// ---------------------------------------------------------------
+
+	// PolicyQuery returns a new Policy select query.
+	PolicyQuery() *dbx.SelectQuery
+
+	// PolicyAcceptanceQuery returns a new PolicyAcceptance select query.
+	PolicyAcceptanceQuery() *dbx.SelectQuery
+
+	// FindLatestPolicy returns the latest version of the named policy document.
+	FindLatestPolicy(name string) (*Policy, error)
+
+	// FindPolicy returns the specified version of the named policy document.
+	FindPolicy(name string, version int) (*Policy, error)
+
+	// PublishPolicy saves the provided policy as a new version of its named document
+	// (the version number is incremented automatically, starting from 1).
+	PublishPolicy(policy *Policy) error
+
+	// AcceptPolicy stores the auth record acceptance of the specified policy version
+	// (if version is <= 0, the latest policy version is accepted).
+	AcceptPolicy(authRecord *Record, name string, version int, ip string) (*PolicyAcceptance, error)
+
+	// FindAcceptedPolicyVersion returns the highest accepted by the auth record
+	// version of the named policy (0 if no version was accepted).
+	FindAcceptedPolicyVersion(authRecord *Record, name string) (int, error)
+
+	// ---------------------------------------------------------------
+
+	// InviteQuery returns a new Invite select query.
+	InviteQuery() *dbx.SelectQuery
+
+	// FindInviteById finds a single Invite model by its id.
+	FindInviteById(id string) (*Invite, error)
+
+	// FindInviteByToken finds a single Invite model of the specified
+	// auth collection by its plain token.
+	//
+	// Note that the returned invite could be already accepted or expired.
+	FindInviteByToken(collectionModelOrIdentifier any, token string) (*Invite, error)
+
+	// CreateInvite creates a new invite for the specified auth collection and email
+	// and returns it together with its plain token (only its hash is stored in the db).
+	//
+	// The optional data argument contains the preset field values
+	// (e.g. roles) that will be applied to the auth record on invite acceptance.
+	//
+	// If there is already a pending (not accepted) invite for the same email,
+	// it is updated with the new data, token and expiration.
+	CreateInvite(collectionModelOrIdentifier any, email string, data map[string]any) (*Invite, string, error)
+
+	// RefreshInviteToken generates and persists a new invite token
+	// and expiration date and returns the new plain token.
+	//
+	// The previous invite token is invalidated.
+	RefreshInviteToken(invite *Invite) (string, error)
+
+	// AcceptInvite accepts the provided invite and returns the invited auth record.
+	//
+	// If an auth record with the invite email already exists, it is linked to the invite.
+	// Otherwise a new auth record is created using the optional data argument
+	// (e.g. name, password, etc.; a random password is set if not provided).
+	//
+	// In both cases the invite preset fields are applied on top and the
+	// auth record is marked as verified since the invite confirms the email ownership.
+	AcceptInvite(invite *Invite, data map[string]any) (*Record, error)
+
+	// ---------------------------------------------------------------
+
+	// MembershipQuery returns a new Membership select query.
+	MembershipQuery() *dbx.SelectQuery
+
+	// FindMembershipById finds a single Membership model by its id.
+	FindMembershipById(id string) (*Membership, error)
+
+	// FindOrganizationMembership returns the membership of the
+	// member auth record in the specified organization record.
+	FindOrganizationMembership(organization *Record, member *Record) (*Membership, error)
+
+	// FindOrganizationMemberships returns all memberships of the
+	// specified organization record ordered by their creation date.
+	FindOrganizationMemberships(organization *Record) ([]*Membership, error)
+
+	// FindMemberOrganizations returns the organization records of the specified
+	// collection in which the member auth record is a member.
+	FindMemberOrganizations(collectionModelOrIdentifier any, member *Record) ([]*Record, error)
+
+	// AddOrganizationMember adds the member auth record to the specified
+	// organization record with the provided role.
+	//
+	// If the auth record is already a member, only its role is updated.
+	AddOrganizationMember(organization *Record, member *Record, role string) (*Membership, error)
+
+	// RemoveOrganizationMember removes the member auth record from the specified organization record.
+	//
+	// Removing a non-member is a no-op.
+	RemoveOrganizationMember(organization *Record, member *Record) error
+
+	// ---------------------------------------------------------------
+
+	// MailSuppressionQuery returns a new MailSuppression select query.
+	MailSuppressionQuery() *dbx.SelectQuery
+
+	// FindMailSuppressionById finds a single MailSuppression entry by its id.
+	FindMailSuppressionById(id string) (*MailSuppression, error)
+
+	// FindMailSuppressionByEmail finds a single MailSuppression entry by its email (case insensitive).
+	FindMailSuppressionByEmail(email string) (*MailSuppression, error)
+
+	// SuppressMailAddress creates (or updates the existing) suppression entry for the specified email.
+	//
+	// Suppressed addresses are automatically excluded from the recipients
+	// of all emails sent via the [App.NewMailClient] instances.
+	SuppressMailAddress(email string, reason string, detail string) (*MailSuppression, error)
+
+	// FilterSuppressedMailAddresses returns a new slice with only the
+	// addresses from the provided list that are not suppressed.
+	FilterSuppressedMailAddresses(addresses []mail.Address) ([]mail.Address, error)
+
+	// ---------------------------------------------------------------
+
 	// CollectionQuery returns a new Collection select query.
 	CollectionQuery() *dbx.SelectQuery
 
@@ -464,6 +923,14 @@ type App interface {
 	// (this includes their related records data).
 	ImportCollections(toImport []map[string]any, deleteMissing bool) error
 
+	// ImportCollectionsWithOptions imports the provided collections data in a
+	// single transaction using the specified conflict strategies and returns
+	// the applied collections changes.
+	//
+	// If options.DryRun is set, the import transaction is rolled back and only the
+	// changes that would have been applied are returned.
+	ImportCollectionsWithOptions(toImport []map[string]any, options CollectionsImportOptions) ([]*CollectionSnapshotDiff, error)
+
 	// ImportCollectionsByMarshaledJSON is the same as [ImportCollections]
 	// but accept marshaled json array as import data (usually used for the autogenerated snapshots).
 	ImportCollectionsByMarshaledJSON(rawSliceOfMaps []byte, deleteMissing bool) error
@@ -550,6 +1017,22 @@ type App interface {
 
 	// ---------------------------------------------------------------
 
+	// FindAllPushDevicesByRecord returns all PushDevice models linked to the provided auth record (in DESC order).
+	FindAllPushDevicesByRecord(authRecord *Record) ([]*PushDevice, error)
+
+	// FindPushDeviceById returns a single PushDevice model by its id.
+	FindPushDeviceById(id string) (*PushDevice, error)
+
+	// FindPushDeviceByToken returns a single PushDevice model by its platform and token.
+	FindPushDeviceByToken(platform string, token string) (*PushDevice, error)
+
+	// DeleteAllPushDevicesByRecord deletes all PushDevice models associated with the provided record.
+	//
+	// Returns a combined error with the failed deletes.
+	DeleteAllPushDevicesByRecord(authRecord *Record) error
+
+	// ---------------------------------------------------------------
+
 	// RecordQuery returns a new Record select query from a collection model, id or name.
 	//
 	// In case a collection id or name is provided and that collection doesn't
@@ -560,10 +1043,18 @@ type App interface {
 	// FindRecordById finds the Record model by its id.
 	FindRecordById(collectionModelOrIdentifier any, recordId string, optFilters ...func(q *dbx.SelectQuery) error) (*Record, error)
 
+	// FindRecordByIdWithContext is the same as [App.FindRecordById]
+	// but allows specifying a context to limit the db execution.
+	FindRecordByIdWithContext(ctx context.Context, collectionModelOrIdentifier any, recordId string, optFilters ...func(q *dbx.SelectQuery) error) (*Record, error)
+
 	// FindRecordsByIds finds all records by the specified ids.
 	// If no records are found, returns an empty slice.
 	FindRecordsByIds(collectionModelOrIdentifier any, recordIds []string, optFilters ...func(q *dbx.SelectQuery) error) ([]*Record, error)
 
+	// FindRecordsByIdsWithContext is the same as [App.FindRecordsByIds]
+	// but allows specifying a context to limit the db execution.
+	FindRecordsByIdsWithContext(ctx context.Context, collectionModelOrIdentifier any, recordIds []string, optFilters ...func(q *dbx.SelectQuery) error) ([]*Record, error)
+
 	// FindAllRecords finds all records matching specified db expressions.
 	//
 	// Returns all collection records if no expression is provided.
@@ -581,10 +1072,18 @@ type App interface {
 	//	app.FindAllRecords("example", expr1, expr2)
 	FindAllRecords(collectionModelOrIdentifier any, exprs ...dbx.Expression) ([]*Record, error)
 
+	// FindAllRecordsWithContext is the same as [App.FindAllRecords]
+	// but allows specifying a context to limit the db execution.
+	FindAllRecordsWithContext(ctx context.Context, collectionModelOrIdentifier any, exprs ...dbx.Expression) ([]*Record, error)
+
 	// FindFirstRecordByData returns the first found record matching
 	// the provided key-value pair.
 	FindFirstRecordByData(collectionModelOrIdentifier any, key string, value any) (*Record, error)
 
+	// FindFirstRecordByDataWithContext is the same as [App.FindFirstRecordByData]
+	// but allows specifying a context to limit the db execution.
+	FindFirstRecordByDataWithContext(ctx context.Context, collectionModelOrIdentifier any, key string, value any) (*Record, error)
+
 	// FindRecordsByFilter returns limit number of records matching the
 	// provided string filter.
 	//
@@ -620,6 +1119,18 @@ type App interface {
 		params ...dbx.Params,
 	) ([]*Record, error)
 
+	// FindRecordsByFilterWithContext is the same as [App.FindRecordsByFilter]
+	// but allows specifying a context to limit the db execution.
+	FindRecordsByFilterWithContext(
+		ctx context.Context,
+		collectionModelOrIdentifier any,
+		filter string,
+		sort string,
+		limit int,
+		offset int,
+		params ...dbx.Params,
+	) ([]*Record, error)
+
 	// FindFirstRecordByFilter returns the first available record matching the provided filter (if any).
 	//
 	// NB! Use the last params argument to bind untrusted user variables!
@@ -636,9 +1147,161 @@ type App interface {
 		params ...dbx.Params,
 	) (*Record, error)
 
+	// FindFirstRecordByFilterWithContext is the same as [App.FindFirstRecordByFilter]
+	// but allows specifying a context to limit the db execution.
+	FindFirstRecordByFilterWithContext(
+		ctx context.Context,
+		collectionModelOrIdentifier any,
+		filter string,
+		params ...dbx.Params,
+	) (*Record, error)
+
 	// CountRecords returns the total number of records in a collection.
 	CountRecords(collectionModelOrIdentifier any, exprs ...dbx.Expression) (int64, error)
 
+	// CountRecordsWithContext is the same as [App.CountRecords]
+	// but allows specifying a context to limit the db execution.
+	CountRecordsWithContext(ctx context.Context, collectionModelOrIdentifier any, exprs ...dbx.Expression) (int64, error)
+
+	// EachRecord executes the provided record select query and invokes fn
+	// for each result row, streaming the rows instead of loading the full
+	// result set in memory.
+	//
+	// The iteration stops on the first fn error and that error is returned.
+	EachRecord(query *dbx.SelectQuery, fn func(record *Record) error) error
+
+	// IterateRecords returns an iterator that streams the result records
+	// of the provided record select query.
+	IterateRecords(query *dbx.SelectQuery) iter.Seq2[*Record, error]
+
+	// CachedRecord is similar to FindRecordById but for collections with
+	// enabled RecordCache option the found record is cached in memory
+	// until it is updated or deleted.
+	//
+	// The returned record is always a clone, so it is safe to be modified.
+	CachedRecord(collectionModelOrIdentifier any, recordId string) (*Record, error)
+
+	// ClearCachedRecords removes the specified records from the in-memory
+	// records cache (or all collection records if no ids are specified).
+	ClearCachedRecords(collectionModelOrIdentifier any, recordIds ...string) error
+
+	// ArchiveRecords moves the collection records matching the specified
+	// filter expression into the aux archive table (excluding them from
+	// the regular record queries) and returns their total.
+	ArchiveRecords(collectionModelOrIdentifier any, filter string, params ...dbx.Params) (int, error)
+
+	// CountArchivedRecords returns the total number of the archived collection records.
+	CountArchivedRecords(collectionModelOrIdentifier any) (int64, error)
+
+	// FindArchivedRecords returns the archived collection records
+	// (the most recently archived first).
+	//
+	// If limit <= 0 all archived collection records are returned.
+	FindArchivedRecords(collectionModelOrIdentifier any, limit int, offset int) ([]*Record, error)
+
+	// RestoreArchivedRecords moves the archived collection records with the
+	// specified ids (or all if none) back into the collection table and returns their total.
+	RestoreArchivedRecords(collectionModelOrIdentifier any, ids ...string) (int, error)
+
+	// ModerateRecord changes the moderation state of the specified record
+	// (and optionally its assigned reviewer) and persists it.
+	//
+	// Note that the collection moderation API rules are not checked.
+	ModerateRecord(record *Record, state string, optReviewerId ...string) error
+
+	// ModerateRecordWithContext is the same as [App.ModerateRecord]
+	// but allows specifying a custom context.
+	ModerateRecordWithContext(ctx context.Context, record *Record, state string, optReviewerId ...string) error
+
+	// DuplicateRecord creates and persists a copy of the provided record
+	// (including copies of its stored files) with the optional overrides data.
+	//
+	// The values of the single column unique text, email and url fields
+	// are suffixed to avoid unique constraint failures.
+	DuplicateRecord(record *Record, overrides map[string]any) (*Record, error)
+
+	// DuplicateRecordWithContext is the same as [App.DuplicateRecord]
+	// but allows specifying a custom context.
+	DuplicateRecordWithContext(ctx context.Context, record *Record, overrides map[string]any) (*Record, error)
+
+	// FindAuthRecordDuplicates returns the groups of likely duplicated
+	// records of the specified auth collection (same normalized email,
+	// similar emails or names).
+	FindAuthRecordDuplicates(collectionModelOrIdentifier any) ([]*AuthRecordDuplicates, error)
+
+	// MergeAuthRecords merges the source auth records into the target one and deletes them.
+	//
+	// The source relation references, external auth providers and files
+	// are moved to the target record.
+	MergeAuthRecords(target *Record, sources ...*Record) error
+
+	// MergeAuthRecordsWithContext is the same as [App.MergeAuthRecords]
+	// but allows specifying a custom context.
+	MergeAuthRecordsWithContext(ctx context.Context, target *Record, sources ...*Record) error
+
+	// ExportUserData returns a zip archive with all data of the specified
+	// auth record and the records referencing it, including their files.
+	ExportUserData(authRecord *Record) ([]byte, error)
+
+	// ExportUserDataWithContext is the same as [App.ExportUserData]
+	// but allows specifying a custom context.
+	ExportUserDataWithContext(ctx context.Context, authRecord *Record) ([]byte, error)
+
+	// ExportUserDataWithRequestInfo is the self-service version of
+	// [App.ExportUserDataWithContext] that exports the data of requestInfo.Auth
+	// with only the non-hidden fields of its referencing records.
+	ExportUserDataWithRequestInfo(ctx context.Context, requestInfo *RequestInfo) ([]byte, error)
+
+	// EraseUserData deletes the specified auth record and applies the
+	// configured in the app settings erasure strategy to each of its
+	// referencing records (by default the referencing records are unlinked).
+	EraseUserData(authRecord *Record) error
+
+	// EraseUserDataWithContext is the same as [App.EraseUserData]
+	// but allows specifying a custom context.
+	EraseUserDataWithContext(ctx context.Context, authRecord *Record) error
+
+	// EraseUserDataWithRequestInfo is the self-service version of
+	// [App.EraseUserDataWithContext] that erases the data of requestInfo.Auth
+	// and checks the referencing collections update and delete API rules
+	// before applying their erasure strategy.
+	EraseUserDataWithRequestInfo(ctx context.Context, requestInfo *RequestInfo) error
+
+	// FindRecordTreeAncestors returns the tree ancestors of the specified
+	// record ordered from the root to the direct parent.
+	FindRecordTreeAncestors(record *Record) ([]*Record, error)
+
+	// MoveTreeRecord moves the specified record under the parent with parentId
+	// (empty string for root) at the specified 1-based siblings position,
+	// shifting the positions of the following siblings.
+	MoveTreeRecord(record *Record, parentId string, position int) error
+
+	// MoveTreeRecordWithContext is the same as [App.MoveTreeRecord]
+	// but allows specifying a custom context.
+	MoveTreeRecordWithContext(ctx context.Context, record *Record, parentId string, position int) error
+
+	// MoveOrderedRecord moves the record right before (or after) the target record
+	// in the ordering of the specified "order" field and rebalances the positions
+	// of all records in the target scope.
+	//
+	// If targetId is empty, the record is moved at the start (or the end if after is true) of its current scope.
+	MoveOrderedRecord(record *Record, fieldName string, targetId string, after bool) error
+
+	// MoveOrderedRecordWithContext is the same as [App.MoveOrderedRecord]
+	// but allows specifying a custom context.
+	MoveOrderedRecordWithContext(ctx context.Context, record *Record, fieldName string, targetId string, after bool) error
+
+	// PublishScheduledRecords triggers the [App.OnRecordPublish] hook for all
+	// records whose publishAt or unpublishAt time is within the (from, to] interval
+	// and returns the total number of the triggered events.
+	PublishScheduledRecords(ctx context.Context, from time.Time, to time.Time) (int, error)
+
+	// PurgeExpiredRecords triggers the [App.OnRecordExpire] hook for all
+	// records whose expiration time plus their collection grace period
+	// was reached before the specified time and returns the total number
+	// of the triggered events.
+	PurgeExpiredRecords(ctx context.Context, t time.Time) (int, error)
+
 	// FindAuthRecordByToken finds the auth record associated with the provided JWT
 	// (auth, file, verifyEmail, changeEmail, passwordReset types).
 	//
@@ -710,11 +1373,41 @@ type App interface {
 	// OnBackupCreate hook is triggered on each [App.CreateBackup] call.
 	OnBackupCreate() *hook.Hook[*BackupEvent]
 
-	// OnBackupRestore hook is triggered before app backup restore (aka. [App.RestoreBackup] call).
+	// OnBackupRestore hook is triggered before app backup restore
+	// (aka. [App.RestoreBackup] and [App.RestoreBackupCollections] calls).
 	//
-	// Note that by default on success the application is restarted and the after state of the hook is ignored.
+	// Note that by default on full restore success the application is restarted
+	// and the after state of the hook is ignored.
 	OnBackupRestore() *hook.Hook[*BackupEvent]
 
+	// OnPanic hook is triggered when a recovered panic is reported
+	// (aka. [App.ReportPanic] call), e.g. from a route handler or a cron job.
+	//
+	// By default the panic is logged and, if enabled in the settings,
+	// forwarded to the configured Sentry DSN.
+	OnPanic() *hook.Hook[*PanicEvent]
+
+	// OnTransactionCommit hook is triggered after a successful commit of
+	// a RunInTransaction (or AuxRunInTransaction) db transaction.
+	//
+	// It is triggered only once for the outermost transaction (nested
+	// RunInTransaction calls are part of the same transaction) and only
+	// after all model/record After*Success hooks of the transaction.
+	//
+	// It is not triggered if the transaction is rollbacked.
+	OnTransactionCommit() *hook.Hook[*TransactionEvent]
+
+	// OnScheduledTaskRun hook is triggered on each execution of a
+	// one-off task created with [App.ScheduleAt].
+	//
+	// If the optional "tags" list (task names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	//
+	// Returning an error marks the execution as failed and the task
+	// will be retried later (see [MaxScheduledTaskAttempts]).
+	OnScheduledTaskRun(tags ...string) *hook.TaggedHook[*ScheduledTaskEvent]
+
 	// ---------------------------------------------------------------
 	// DB models event hooks
 	// ---------------------------------------------------------------
@@ -1089,6 +1782,37 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnRecordAfterDeleteError(tags ...string) *hook.TaggedHook[*RecordErrorEvent]
 
+	// OnRecordModerate hook is triggered every time when the moderation
+	// state of a record is changed with [App.ModerateRecord].
+	//
+	// Could be used to validate the transition or to notify the record
+	// author or reviewer. Call e.Next() to persist the record.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordModerate(tags ...string) *hook.TaggedHook[*RecordModerateEvent]
+
+	// OnRecordPublish hook is triggered by the app cron every time when
+	// the publishAt or unpublishAt time of a record is reached
+	// (see [CollectionPublishing]).
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordPublish(tags ...string) *hook.TaggedHook[*RecordPublishEvent]
+
+	// OnRecordExpire hook is triggered by the app cron for each expired
+	// record whose collection grace period has passed (see [CollectionExpiration]).
+	//
+	// The default action deletes the record, so you can skip calling e.Next()
+	// to keep the record (e.g. to archive it or to extend its expiration).
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordExpire(tags ...string) *hook.TaggedHook[*RecordExpireEvent]
+
 	// ---------------------------------------------------------------
 	// Collection models event hooks
 	// ---------------------------------------------------------------
@@ -1200,6 +1924,12 @@ type App interface {
 	// It allows intercepting the email message or to use a custom mailer client.
 	OnMailerSend() *hook.Hook[*MailerEvent]
 
+	// OnMailReceive hook is triggered every time when a new inbound email
+	// is received through the inbound email webhooks (see apis.bindMailInboundApi).
+	//
+	// It could be used for building reply-by-email, support tickets and other similar flows.
+	OnMailReceive() *hook.Hook[*MailReceiveEvent]
+
 	// OnMailerRecordAuthAlertSend hook is triggered when
 	// sending a new device login auth alert email, allowing you to
 	// intercept and customize the email message that is being sent.
@@ -1209,6 +1939,18 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnMailerRecordAuthAlertSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent]
 
+	// OnMailerRecordInviteSend hook is triggered when sending an
+	// auth collection invite email, allowing you to intercept and
+	// customize the email message that is being sent.
+	//
+	// The event Record is an unsaved auth record loaded with the invite
+	// email and preset data (the invite itself is available in the event Meta).
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnMailerRecordInviteSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent]
+
 	// OnMailerBeforeRecordResetPasswordSend hook is triggered when
 	// sending a password reset email to an auth record, allowing
 	// you to intercept and customize the email message that is being sent.
@@ -1245,6 +1987,50 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnMailerRecordOTPSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent]
 
+	// ---------------------------------------------------------------
+	// SMS event hooks
+	// ---------------------------------------------------------------
+
+	// OnSMSSend hook is triggered every time when a new text message is
+	// being sent using the [App.NewSMSClient()] instance.
+	//
+	// It allows intercepting the message or to use a custom notifier client.
+	OnSMSSend() *hook.Hook[*SMSEvent]
+
+	// OnSMSRecordOTPSend hook is triggered when sending an OTP text message
+	// to an auth record, allowing you to intercept and customize the
+	// message that is being sent.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnSMSRecordOTPSend(tags ...string) *hook.TaggedHook[*SMSRecordEvent]
+
+	// ---------------------------------------------------------------
+	// Captcha event hooks
+	// ---------------------------------------------------------------
+
+	// OnCaptchaVerify hook is triggered every time when a captcha token
+	// is verified for a protected collection records API action.
+	//
+	// It allows replacing the default verifier with a custom provider
+	// (e.g. by assigning e.Verifier or directly returning the verification result without calling e.Next()).
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnCaptchaVerify(tags ...string) *hook.TaggedHook[*CaptchaVerifyEvent]
+
+	// ---------------------------------------------------------------
+	// Push notifications event hooks
+	// ---------------------------------------------------------------
+
+	// OnPushSend hook is triggered every time when a new push notification
+	// is being sent to a single device using the [App.Notifications()] service.
+	//
+	// It allows intercepting the message or to use a custom push driver.
+	OnPushSend() *hook.Hook[*PushSendEvent]
+
 	// ---------------------------------------------------------------
 	// Realtime API event hooks
 	// ---------------------------------------------------------------
@@ -1468,6 +2254,20 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnRecordCreateRequest(tags ...string) *hook.TaggedHook[*RecordRequestEvent]
 
+	// OnRecordCreateScore hook is triggered on API Record create request
+	// for collections with enabled SpamScore option, right after loading
+	// the submitted data and before checking the create API rule.
+	//
+	// It could be used to add custom spam/abuse signals with e.AddSignal()
+	// in addition to the builtin heuristics (link density, disposable email domains and velocity per IP).
+	// The total e.Score() is stored in the configured collection score field
+	// (if any) and in the request event store under [RequestEventKeySpamScore].
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordCreateScore(tags ...string) *hook.TaggedHook[*RecordCreateScoreEvent]
+
 	// OnRecordUpdateRequest hook is triggered on each API Record update request.
 	//
 	// Could be used to additionally validate the request data or implement
@@ -1535,4 +2335,31 @@ type App interface {
 	//
 	// Could be used to additionally validate or modify the submitted batch requests.
 	OnBatchRequest() *hook.Hook[*BatchRequestEvent]
+
+	// ---------------------------------------------------------------
+	// Router transform event hooks
+	// ---------------------------------------------------------------
+
+	// OnRequestBodyTransform hook is triggered before the route handler
+	// on each request with a JSON object body, allowing you to
+	// rewrite the submitted data (e.g. to inject computed values).
+	//
+	// If the optional "tags" list (route patterns like "POST /api/hello"
+	// or "/api/hello") is specified, then all event handlers registered
+	// via the created hook will be triggered and called only if the
+	// matched request route pattern is one of the listed ones.
+	OnRequestBodyTransform(tags ...string) *hook.TaggedHook[*RequestBodyTransformEvent]
+
+	// OnResponseTransform hook is triggered after the route handler
+	// on each JSON response, allowing you to rewrite the response payload
+	// (e.g. to strip fields based on the auth state).
+	//
+	// Non JSON and streamed responses (e.g. files and realtime messages)
+	// are sent as they are without triggering the hook.
+	//
+	// If the optional "tags" list (route patterns like "GET /api/hello"
+	// or "/api/hello") is specified, then all event handlers registered
+	// via the created hook will be triggered and called only if the
+	// matched request route pattern is one of the listed ones.
+	OnResponseTransform(tags ...string) *hook.TaggedHook[*ResponseTransformEvent]
 }
@@ -11,16 +11,20 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/captcha"
 	"github.com/pocketbase/pocketbase/tools/cron"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/logger"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/notifier"
 	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
@@ -65,6 +69,17 @@ type BaseAppConfig struct {
 	AuxMaxOpenConns  int
 	AuxMaxIdleConns  int
 	IsDev            bool
+
+	// CronLocker is an optional factory of the advisory lock used to ensure
+	// that the scheduled cron jobs run only on a single app instance
+	// when several of them share the same data (see [NewDBCronLocker]).
+	CronLocker func(app App) CronLocker
+
+	// KVCache enables the in-memory caching of the read [KVStore] items.
+	//
+	// It should be enabled only if the auxiliary database is not shared
+	// with other app instances.
+	KVCache bool
 }
 
 // ensures that the BaseApp implements the App interface.
@@ -76,6 +91,12 @@ type BaseApp struct {
 	txInfo              *TxAppInfo
 	store               *store.Store[string, any]
 	cron                *cron.Cron
+	cronLocker          CronLocker
+	scheduledTasks      *scheduledTasksWorker
+	kv                  *KVStore
+	hookStats           *hookStatsCollector
+	runningQueries      *runningQueries
+	collectionStats     *collectionStatsCache
 	settings            *Settings
 	subscriptionsBroker *subscriptions.Broker
 	logger              *slog.Logger
@@ -83,6 +104,7 @@ type BaseApp struct {
 	nonconcurrentDB     dbx.Builder
 	auxConcurrentDB     dbx.Builder
 	auxNonconcurrentDB  dbx.Builder
+	plugins             []Plugin
 
 	// app event hooks
 	onBootstrap     *hook.Hook[*BootstrapEvent]
@@ -90,6 +112,13 @@ type BaseApp struct {
 	onTerminate     *hook.Hook[*TerminateEvent]
 	onBackupCreate  *hook.Hook[*BackupEvent]
 	onBackupRestore *hook.Hook[*BackupEvent]
+	onPanic         *hook.Hook[*PanicEvent]
+
+	// db transaction hooks
+	onTransactionCommit *hook.Hook[*TransactionEvent]
+
+	// scheduled tasks hooks
+	onScheduledTaskRun *hook.Hook[*ScheduledTaskEvent]
 
 	// db model hooks
 	onModelValidate           *hook.Hook[*ModelEvent]
@@ -121,6 +150,9 @@ type BaseApp struct {
 	onRecordDeleteExecute      *hook.Hook[*RecordEvent]
 	onRecordAfterDeleteSuccess *hook.Hook[*RecordEvent]
 	onRecordAfterDeleteError   *hook.Hook[*RecordErrorEvent]
+	onRecordModerate           *hook.Hook[*RecordModerateEvent]
+	onRecordPublish            *hook.Hook[*RecordPublishEvent]
+	onRecordExpire             *hook.Hook[*RecordExpireEvent]
 
 	// db collection hooks
 	onCollectionValidate           *hook.Hook[*CollectionEvent]
@@ -139,11 +171,23 @@ type BaseApp struct {
 
 	// mailer event hooks
 	onMailerSend                    *hook.Hook[*MailerEvent]
+	onMailReceive                   *hook.Hook[*MailReceiveEvent]
 	onMailerRecordPasswordResetSend *hook.Hook[*MailerRecordEvent]
 	onMailerRecordVerificationSend  *hook.Hook[*MailerRecordEvent]
 	onMailerRecordEmailChangeSend   *hook.Hook[*MailerRecordEvent]
 	onMailerRecordOTPSend           *hook.Hook[*MailerRecordEvent]
 	onMailerRecordAuthAlertSend     *hook.Hook[*MailerRecordEvent]
+	onMailerRecordInviteSend        *hook.Hook[*MailerRecordEvent]
+
+	// sms event hooks
+	onSMSSend          *hook.Hook[*SMSEvent]
+	onSMSRecordOTPSend *hook.Hook[*SMSRecordEvent]
+
+	// captcha event hooks
+	onCaptchaVerify *hook.Hook[*CaptchaVerifyEvent]
+
+	// push notifications event hooks
+	onPushSend *hook.Hook[*PushSendEvent]
 
 	// realtime api event hooks
 	onRealtimeConnectRequest   *hook.Hook[*RealtimeConnectRequestEvent]
@@ -177,6 +221,7 @@ type BaseApp struct {
 	onRecordsListRequest  *hook.Hook[*RecordsListRequestEvent]
 	onRecordViewRequest   *hook.Hook[*RecordRequestEvent]
 	onRecordCreateRequest *hook.Hook[*RecordRequestEvent]
+	onRecordCreateScore   *hook.Hook[*RecordCreateScoreEvent]
 	onRecordUpdateRequest *hook.Hook[*RecordRequestEvent]
 	onRecordDeleteRequest *hook.Hook[*RecordRequestEvent]
 
@@ -189,6 +234,10 @@ type BaseApp struct {
 	onCollectionsImportRequest *hook.Hook[*CollectionsImportRequestEvent]
 
 	onBatchRequest *hook.Hook[*BatchRequestEvent]
+
+	// router transform event hooks
+	onRequestBodyTransform *hook.Hook[*RequestBodyTransformEvent]
+	onResponseTransform    *hook.Hook[*ResponseTransformEvent]
 }
 
 // NewBaseApp creates and returns a new BaseApp instance
@@ -200,6 +249,7 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		settings:            newDefaultSettings(),
 		store:               store.New[string, any](nil),
 		cron:                cron.New(),
+		scheduledTasks:      newScheduledTasksWorker(),
 		subscriptionsBroker: subscriptions.NewBroker(),
 		config:              &config,
 	}
@@ -224,9 +274,23 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		app.config.QueryTimeout = DefaultQueryTimeout
 	}
 
+	if app.config.CronLocker != nil {
+		app.cronLocker = app.config.CronLocker(app)
+	}
+
+	app.kv = newKVStore(app, app.config.KVCache)
+	app.hookStats = &hookStatsCollector{app: app, stats: map[string]*HookHandlerStats{}}
+	app.runningQueries = newRunningQueries()
+	app.collectionStats = &collectionStatsCache{}
+
 	app.initHooks()
 	app.registerBaseHooks()
 
+	// report the scheduled cron jobs panics
+	app.cron.SetPanicHandler(func(job *cron.Job, recovered any, stack []byte) {
+		app.ReportPanic(PanicSourceCron, job.Id(), recovered, stack)
+	})
+
 	return app
 }
 
@@ -238,6 +302,13 @@ func (app *BaseApp) initHooks() {
 	app.onTerminate = &hook.Hook[*TerminateEvent]{}
 	app.onBackupCreate = &hook.Hook[*BackupEvent]{}
 	app.onBackupRestore = &hook.Hook[*BackupEvent]{}
+	app.onPanic = &hook.Hook[*PanicEvent]{}
+
+	// db transaction hooks
+	app.onTransactionCommit = &hook.Hook[*TransactionEvent]{}
+
+	// scheduled tasks hooks
+	app.onScheduledTaskRun = &hook.Hook[*ScheduledTaskEvent]{}
 
 	// db model hooks
 	app.onModelValidate = &hook.Hook[*ModelEvent]{}
@@ -269,6 +340,9 @@ func (app *BaseApp) initHooks() {
 	app.onRecordDeleteExecute = &hook.Hook[*RecordEvent]{}
 	app.onRecordAfterDeleteSuccess = &hook.Hook[*RecordEvent]{}
 	app.onRecordAfterDeleteError = &hook.Hook[*RecordErrorEvent]{}
+	app.onRecordModerate = &hook.Hook[*RecordModerateEvent]{}
+	app.onRecordPublish = &hook.Hook[*RecordPublishEvent]{}
+	app.onRecordExpire = &hook.Hook[*RecordExpireEvent]{}
 
 	// db collection hooks
 	app.onCollectionValidate = &hook.Hook[*CollectionEvent]{}
@@ -287,11 +361,23 @@ func (app *BaseApp) initHooks() {
 
 	// mailer event hooks
 	app.onMailerSend = &hook.Hook[*MailerEvent]{}
+	app.onMailReceive = &hook.Hook[*MailReceiveEvent]{}
 	app.onMailerRecordPasswordResetSend = &hook.Hook[*MailerRecordEvent]{}
 	app.onMailerRecordVerificationSend = &hook.Hook[*MailerRecordEvent]{}
 	app.onMailerRecordEmailChangeSend = &hook.Hook[*MailerRecordEvent]{}
 	app.onMailerRecordOTPSend = &hook.Hook[*MailerRecordEvent]{}
 	app.onMailerRecordAuthAlertSend = &hook.Hook[*MailerRecordEvent]{}
+	app.onMailerRecordInviteSend = &hook.Hook[*MailerRecordEvent]{}
+
+	// sms event hooks
+	app.onSMSSend = &hook.Hook[*SMSEvent]{}
+	app.onSMSRecordOTPSend = &hook.Hook[*SMSRecordEvent]{}
+
+	// captcha event hooks
+	app.onCaptchaVerify = &hook.Hook[*CaptchaVerifyEvent]{}
+
+	// push notifications event hooks
+	app.onPushSend = &hook.Hook[*PushSendEvent]{}
 
 	// realtime API event hooks
 	app.onRealtimeConnectRequest = &hook.Hook[*RealtimeConnectRequestEvent]{}
@@ -325,6 +411,7 @@ func (app *BaseApp) initHooks() {
 	app.onRecordsListRequest = &hook.Hook[*RecordsListRequestEvent]{}
 	app.onRecordViewRequest = &hook.Hook[*RecordRequestEvent]{}
 	app.onRecordCreateRequest = &hook.Hook[*RecordRequestEvent]{}
+	app.onRecordCreateScore = &hook.Hook[*RecordCreateScoreEvent]{}
 	app.onRecordUpdateRequest = &hook.Hook[*RecordRequestEvent]{}
 	app.onRecordDeleteRequest = &hook.Hook[*RecordRequestEvent]{}
 
@@ -337,6 +424,10 @@ func (app *BaseApp) initHooks() {
 	app.onCollectionsImportRequest = &hook.Hook[*CollectionsImportRequestEvent]{}
 
 	app.onBatchRequest = &hook.Hook[*BatchRequestEvent]{}
+
+	// router transform event hooks
+	app.onRequestBodyTransform = &hook.Hook[*RequestBodyTransformEvent]{}
+	app.onResponseTransform = &hook.Hook[*ResponseTransformEvent]{}
 }
 
 // UnsafeWithoutHooks returns a shallow copy of the current app WITHOUT any registered hooks.
@@ -364,6 +455,16 @@ func (app *BaseApp) Logger() *slog.Logger {
 	return app.logger
 }
 
+// FlushLogs writes immediately all accumulated app logs
+// without waiting for the batch size or flush interval thresholds.
+func (app *BaseApp) FlushLogs() error {
+	if h, ok := app.Logger().Handler().(*logger.BatchHandler); ok {
+		return h.WriteAll(context.Background())
+	}
+
+	return nil
+}
+
 // TxInfo returns the transaction associated with the current app instance (if any).
 //
 // Could be used if you want to execute indirectly a function after
@@ -449,6 +550,7 @@ type closer interface {
 // (closing db connections, stopping cron ticker, etc.).
 func (app *BaseApp) ResetBootstrapState() error {
 	app.Cron().Stop()
+	app.scheduledTasks.stopAndWait()
 
 	var errs []error
 
@@ -604,6 +706,11 @@ func (app *BaseApp) Store() *store.Store[string, any] {
 	return app.store
 }
 
+// KV returns the app persistent key-value store.
+func (app *BaseApp) KV() *KVStore {
+	return app.kv
+}
+
 // Cron returns the app cron instance.
 func (app *BaseApp) Cron() *cron.Cron {
 	return app.cron
@@ -614,13 +721,49 @@ func (app *BaseApp) SubscriptionsBroker() *subscriptions.Broker {
 	return app.subscriptionsBroker
 }
 
-// NewMailClient creates and returns a new SMTP or Sendmail client
+// NewMailClient creates and returns a new HTTP API provider, SMTP or Sendmail client
 // based on the current app settings.
 func (app *BaseApp) NewMailClient() mailer.Mailer {
 	var client mailer.Mailer
 
+	provider := app.Settings().Mailer
+
 	// init mailer client
-	if app.Settings().SMTP.Enabled {
+	switch {
+	case provider.Provider == MailerProviderSES:
+		client = &mailer.SESClient{
+			Region:           provider.Region,
+			AccessKey:        provider.AccessKey,
+			Secret:           provider.Secret,
+			Endpoint:         provider.Endpoint,
+			ConfigurationSet: provider.ConfigurationSet,
+			Tags:             mailer.ParseTags(provider.Tags),
+		}
+	case provider.Provider == MailerProviderSendGrid:
+		client = &mailer.SendGridClient{
+			APIKey:      provider.APIKey,
+			Endpoint:    provider.Endpoint,
+			Categories:  provider.Tags,
+			TrackOpens:  provider.TrackOpens,
+			TrackClicks: provider.TrackClicks,
+		}
+	case provider.Provider == MailerProviderMailgun:
+		client = &mailer.MailgunClient{
+			Domain:      provider.Domain,
+			APIKey:      provider.APIKey,
+			Region:      provider.Region,
+			Endpoint:    provider.Endpoint,
+			Tags:        provider.Tags,
+			TrackOpens:  provider.TrackOpens,
+			TrackClicks: provider.TrackClicks,
+		}
+	case provider.Provider == MailerProviderResend:
+		client = &mailer.ResendClient{
+			APIKey:   provider.APIKey,
+			Endpoint: provider.Endpoint,
+			Tags:     mailer.ParseTags(provider.Tags),
+		}
+	case app.Settings().SMTP.Enabled:
 		client = &mailer.SMTPClient{
 			Host:       app.Settings().SMTP.Host,
 			Port:       app.Settings().SMTP.Port,
@@ -630,7 +773,7 @@ func (app *BaseApp) NewMailClient() mailer.Mailer {
 			AuthMethod: app.Settings().SMTP.AuthMethod,
 			LocalName:  app.Settings().SMTP.LocalName,
 		}
-	} else {
+	default:
 		client = &mailer.Sendmail{}
 	}
 
@@ -644,9 +787,22 @@ func (app *BaseApp) NewMailClient() mailer.Mailer {
 				appEvent.Mailer = client
 				appEvent.Message = e.Message
 
-				return app.OnMailerSend().Trigger(appEvent, func(ae *MailerEvent) error {
+				err := app.OnMailerSend().Trigger(appEvent, func(ae *MailerEvent) error {
 					e.Message = ae.Message
 
+					// exclude the suppressed recipients
+					hasRecipients, err := app.excludeSuppressedRecipients(ae.Message)
+					if err != nil {
+						return err
+					}
+					if !hasRecipients {
+						app.Logger().Debug(
+							"Skipped sending email because all recipients are suppressed",
+							slog.String("subject", ae.Message.Subject),
+						)
+						return nil
+					}
+
 					// print the mail in the console to assist with the debugging
 					if app.IsDev() {
 						logDate := new(strings.Builder)
@@ -696,6 +852,107 @@ func (app *BaseApp) NewMailClient() mailer.Mailer {
 						return ae.Mailer.Send(e.Message)
 					}
 
+					return e.Next()
+				})
+				if err != nil {
+					app.suppressSMTPHardBounce(appEvent.Message, err)
+				}
+
+				return err
+			},
+		})
+	}
+
+	return client
+}
+
+// NewCaptchaVerifier creates and returns a new captcha token verifier
+// based on the current app settings.
+//
+// It returns nil if the captcha verification is not enabled
+// or the settings provider is "custom" (in which case the verification
+// is expected to be handled by an OnCaptchaVerify hook).
+func (app *BaseApp) NewCaptchaVerifier() captcha.Verifier {
+	settings := app.Settings().Captcha
+
+	if !settings.Enabled || settings.Provider == CaptchaProviderCustom {
+		return nil
+	}
+
+	return &captcha.Client{
+		Provider: settings.Provider,
+		Secret:   settings.Secret,
+		Endpoint: settings.Endpoint,
+		MinScore: settings.MinScore,
+	}
+}
+
+// NewSMSClient creates and returns a new text messages (SMS) client
+// based on the current app settings.
+//
+// If the SMS delivery is not enabled, it returns a [notifier.DisabledClient]
+// (the OnSMSSend hook is still triggered allowing to register a custom client).
+func (app *BaseApp) NewSMSClient() notifier.Notifier {
+	var client notifier.Notifier
+
+	settings := app.Settings().SMS
+
+	// init notifier client
+	switch {
+	case !settings.Enabled:
+		client = &notifier.DisabledClient{}
+	case settings.Provider == SMSProviderTwilio:
+		client = &notifier.TwilioClient{
+			AccountSID: settings.AccountId,
+			AuthToken:  settings.Secret,
+			From:       settings.From,
+			Endpoint:   settings.Endpoint,
+		}
+	case settings.Provider == SMSProviderVonage:
+		client = &notifier.VonageClient{
+			APIKey:    settings.AccountId,
+			APISecret: settings.Secret,
+			From:      settings.From,
+			Endpoint:  settings.Endpoint,
+		}
+	case settings.Provider == SMSProviderPlivo:
+		client = &notifier.PlivoClient{
+			AuthId:    settings.AccountId,
+			AuthToken: settings.Secret,
+			From:      settings.From,
+			Endpoint:  settings.Endpoint,
+		}
+	default:
+		client = &notifier.HTTPClient{
+			URL:   settings.Endpoint,
+			Token: settings.Secret,
+			From:  settings.From,
+		}
+	}
+
+	// register the app level hook
+	if h, ok := client.(notifier.SendInterceptor); ok {
+		h.OnSend().Bind(&hook.Handler[*notifier.SendEvent]{
+			Id: "__pbSMSOnSend__",
+			Func: func(e *notifier.SendEvent) error {
+				appEvent := new(SMSEvent)
+				appEvent.App = app
+				appEvent.Notifier = client
+				appEvent.Message = e.Message
+
+				return app.OnSMSSend().Trigger(appEvent, func(ae *SMSEvent) error {
+					e.Message = ae.Message
+
+					// print the message in the console to assist with the debugging
+					if app.IsDev() {
+						color.HiBlack("SMS sent\n├─ From: %s\n├─ To: %s\n└─ Body: %s", ae.Message.From, ae.Message.To, ae.Message.Body)
+					}
+
+					// send the message with the new notifier in case it was replaced
+					if client != ae.Notifier {
+						return ae.Notifier.Send(e.Message)
+					}
+
 					return e.Next()
 				})
 			},
@@ -825,6 +1082,22 @@ func (app *BaseApp) OnBackupRestore() *hook.Hook[*BackupEvent] {
 	return app.onBackupRestore
 }
 
+func (app *BaseApp) OnPanic() *hook.Hook[*PanicEvent] {
+	return app.onPanic
+}
+
+// ---------------------------------------------------------------
+
+func (app *BaseApp) OnTransactionCommit() *hook.Hook[*TransactionEvent] {
+	return app.onTransactionCommit
+}
+
+// ---------------------------------------------------------------
+
+func (app *BaseApp) OnScheduledTaskRun(tags ...string) *hook.TaggedHook[*ScheduledTaskEvent] {
+	return hook.NewTaggedHook(app.onScheduledTaskRun, tags...)
+}
+
 // ---------------------------------------------------------------
 
 func (app *BaseApp) OnModelCreate(tags ...string) *hook.TaggedHook[*ModelEvent] {
@@ -935,6 +1208,18 @@ func (app *BaseApp) OnRecordAfterDeleteError(tags ...string) *hook.TaggedHook[*R
 	return hook.NewTaggedHook(app.onRecordAfterDeleteError, tags...)
 }
 
+func (app *BaseApp) OnRecordModerate(tags ...string) *hook.TaggedHook[*RecordModerateEvent] {
+	return hook.NewTaggedHook(app.onRecordModerate, tags...)
+}
+
+func (app *BaseApp) OnRecordPublish(tags ...string) *hook.TaggedHook[*RecordPublishEvent] {
+	return hook.NewTaggedHook(app.onRecordPublish, tags...)
+}
+
+func (app *BaseApp) OnRecordExpire(tags ...string) *hook.TaggedHook[*RecordExpireEvent] {
+	return hook.NewTaggedHook(app.onRecordExpire, tags...)
+}
+
 func (app *BaseApp) OnCollectionValidate(tags ...string) *hook.TaggedHook[*CollectionEvent] {
 	return hook.NewTaggedHook(app.onCollectionValidate, tags...)
 }
@@ -995,6 +1280,10 @@ func (app *BaseApp) OnMailerSend() *hook.Hook[*MailerEvent] {
 	return app.onMailerSend
 }
 
+func (app *BaseApp) OnMailReceive() *hook.Hook[*MailReceiveEvent] {
+	return app.onMailReceive
+}
+
 func (app *BaseApp) OnMailerRecordPasswordResetSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent] {
 	return hook.NewTaggedHook(app.onMailerRecordPasswordResetSend, tags...)
 }
@@ -1015,6 +1304,38 @@ func (app *BaseApp) OnMailerRecordAuthAlertSend(tags ...string) *hook.TaggedHook
 	return hook.NewTaggedHook(app.onMailerRecordAuthAlertSend, tags...)
 }
 
+func (app *BaseApp) OnMailerRecordInviteSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent] {
+	return hook.NewTaggedHook(app.onMailerRecordInviteSend, tags...)
+}
+
+// -------------------------------------------------------------------
+// SMS event hooks
+// -------------------------------------------------------------------
+
+func (app *BaseApp) OnSMSSend() *hook.Hook[*SMSEvent] {
+	return app.onSMSSend
+}
+
+func (app *BaseApp) OnSMSRecordOTPSend(tags ...string) *hook.TaggedHook[*SMSRecordEvent] {
+	return hook.NewTaggedHook(app.onSMSRecordOTPSend, tags...)
+}
+
+// -------------------------------------------------------------------
+// Captcha event hooks
+// -------------------------------------------------------------------
+
+func (app *BaseApp) OnCaptchaVerify(tags ...string) *hook.TaggedHook[*CaptchaVerifyEvent] {
+	return hook.NewTaggedHook(app.onCaptchaVerify, tags...)
+}
+
+// -------------------------------------------------------------------
+// Push notifications event hooks
+// -------------------------------------------------------------------
+
+func (app *BaseApp) OnPushSend() *hook.Hook[*PushSendEvent] {
+	return app.onPushSend
+}
+
 // -------------------------------------------------------------------
 // Realtime API event hooks
 // -------------------------------------------------------------------
@@ -1127,6 +1448,10 @@ func (app *BaseApp) OnRecordCreateRequest(tags ...string) *hook.TaggedHook[*Reco
 	return hook.NewTaggedHook(app.onRecordCreateRequest, tags...)
 }
 
+func (app *BaseApp) OnRecordCreateScore(tags ...string) *hook.TaggedHook[*RecordCreateScoreEvent] {
+	return hook.NewTaggedHook(app.onRecordCreateScore, tags...)
+}
+
 func (app *BaseApp) OnRecordUpdateRequest(tags ...string) *hook.TaggedHook[*RecordRequestEvent] {
 	return hook.NewTaggedHook(app.onRecordUpdateRequest, tags...)
 }
@@ -1167,6 +1492,16 @@ func (app *BaseApp) OnBatchRequest() *hook.Hook[*BatchRequestEvent] {
 	return app.onBatchRequest
 }
 
+// ---------------------------------------------------------------
+
+func (app *BaseApp) OnRequestBodyTransform(tags ...string) *hook.TaggedHook[*RequestBodyTransformEvent] {
+	return hook.NewTaggedHook(app.onRequestBodyTransform, tags...)
+}
+
+func (app *BaseApp) OnResponseTransform(tags ...string) *hook.TaggedHook[*ResponseTransformEvent] {
+	return hook.NewTaggedHook(app.onResponseTransform, tags...)
+}
+
 // -------------------------------------------------------------------
 // Helpers
 // -------------------------------------------------------------------
@@ -1373,8 +1708,45 @@ func (app *BaseApp) registerBaseHooks() {
 		}
 	})
 
+	// give the pending async hook handlers a chance to complete
+	app.OnTerminate().Bind(&hook.Handler[*TerminateEvent]{
+		Id: "__pbAsyncHooksOnTerminate__",
+		Func: func(e *TerminateEvent) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if err := hook.DefaultAsyncPool().Wait(ctx); err != nil {
+				app.Logger().Warn("Not all async hook handlers have completed", slog.String("error", err.Error()))
+			}
+
+			return e.Next()
+		},
+	})
+
 	app.registerSettingsHooks()
+	app.registerSettingsExtensionsHooks()
 	app.registerAutobackupHooks()
+	app.registerPITRHooks()
+	app.registerCronHistoryHooks()
+	app.registerScheduledTasksHooks()
+	app.registerCollectionSnapshotsHooks()
+	app.registerKVHooks()
+	app.registerDataRetentionHooks()
+	app.registerAnalyticsHooks()
+	app.registerRecordArchiveHooks()
+	app.registerRecordCacheHooks()
+	app.registerSpamScoreHooks()
+	app.registerModerationHooks()
+	app.registerPublishingHooks()
+	app.registerExpirationHooks()
+	app.registerTreeHooks()
+	app.registerTagsHooks()
+	app.registerPolicyHooks()
+	app.registerInviteHooks()
+	app.registerMembershipHooks()
+	app.registerDBMaintenanceHooks()
+	app.registerHookTracingHooks()
+	app.registerQueriesHooks()
 	app.registerCollectionHooks()
 	app.registerRecordHooks()
 	app.registerSuperuserHooks()
@@ -1382,6 +1754,8 @@ func (app *BaseApp) registerBaseHooks() {
 	app.registerMFAHooks()
 	app.registerOTPHooks()
 	app.registerAuthOriginHooks()
+	app.registerPushDeviceHooks()
+	app.registerPushTriggerHooks()
 }
 
 // getLoggerMinLevel returns the logger min level based on the
@@ -1406,14 +1780,21 @@ func getLoggerMinLevel(app App) slog.Level {
 }
 
 func (app *BaseApp) initLogger() error {
-	duration := 3 * time.Second
-	ticker := time.NewTicker(duration)
+	duration := &atomic.Int64{}
+	duration.Store(int64(DefaultLogsFlushInterval * time.Second))
+	ticker := time.NewTicker(time.Duration(duration.Load()))
 	done := make(chan bool, 1)
+	sinks := &logSinks{}
+	redactor := &atomic.Pointer[logger.Redactor]{}
 
 	handler := logger.NewBatchHandler(logger.BatchOptions{
-		Level:     getLoggerMinLevel(app),
-		BatchSize: 200,
+		Level:       getLoggerMinLevel(app),
+		BatchSize:   DefaultLogsBatchSize,
+		MaxBuffered: DefaultLogsMaxBuffered,
 		BeforeAddFunc: func(ctx context.Context, log *logger.Log) bool {
+			// mask sensitive information before printing, persisting or exporting the log
+			redactor.Load().Redact(log)
+
 			if app.IsDev() {
 				printLog(log)
 
@@ -1423,9 +1804,13 @@ func (app *BaseApp) initLogger() error {
 				}
 			}
 
-			ticker.Reset(duration)
+			if slices.Contains(app.Settings().Logs.ExcludedLevels, int(log.Level)) {
+				return false
+			}
+
+			ticker.Reset(time.Duration(duration.Load()))
 
-			return app.Settings().Logs.MaxDays > 0
+			return app.Settings().Logs.MaxDays > 0 || len(app.Settings().Logs.Sinks) > 0
 		},
 		WriteFunc: func(ctx context.Context, logs []*logger.Log) error {
 			if !app.IsBootstrapped() || app.Settings().Logs.MaxDays == 0 {
@@ -1477,6 +1862,8 @@ func (app *BaseApp) initLogger() error {
 		Func: func(e *TerminateEvent) error {
 			handler.WriteAll(context.Background())
 
+			sinks.close(handler)
+
 			ticker.Stop()
 
 			// don't block in case OnTerminate is triggered more than once
@@ -1505,6 +1892,19 @@ func (app *BaseApp) initLogger() error {
 				}
 			}
 
+			// reload the logs batching options
+			handler.SetBatchSize(e.App.Settings().Logs.batchSize())
+			handler.SetMaxBuffered(e.App.Settings().Logs.MaxBuffered)
+			if interval := e.App.Settings().Logs.flushInterval(); duration.Swap(int64(interval)) != int64(interval) {
+				ticker.Reset(interval)
+			}
+
+			// reload the external log sinks
+			sinks.reload(e.App, handler)
+
+			// reload the logs redaction rules
+			redactor.Store(e.App.Settings().Logs.Redaction.Redactor())
+
 			// try to clear old logs not matching the new settings
 			createdBefore := types.NowDateTime().AddDate(0, 0, -1*e.App.Settings().Logs.MaxDays)
 			expr := dbx.NewExp("[[created]] <= {:date} OR [[level]] < {:level}", dbx.Params{
@@ -5,15 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pocketbase/pocketbase/tools/archive"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/filesystem/blob"
 	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/osutils"
 	"github.com/pocketbase/pocketbase/tools/security"
@@ -42,6 +46,29 @@ const (
 //
 // Backups can be stored on S3 if it is configured in app.Settings().Backups.
 func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
+	return app.createBackup(ctx, name, "")
+}
+
+// CreateIncrementalBackup creates a new backup of the current app pb_data directory
+// that stores only the changed file blocks since the specified base backup.
+//
+// If name is empty, it will be autogenerated.
+// If backup with the same name exists, the new backup file will replace it.
+//
+// The base backup could be either a full or another incremental backup
+// created with a manifest (aka. by the current or newer app version).
+//
+// Note that restoring an incremental backup requires all backups
+// from its chain (up to the first full backup) to be available.
+func (app *BaseApp) CreateIncrementalBackup(ctx context.Context, name string, base string) error {
+	if base == "" {
+		return errors.New("the base backup name is required")
+	}
+
+	return app.createBackup(ctx, name, base)
+}
+
+func (app *BaseApp) createBackup(ctx context.Context, name string, base string) error {
 	if app.Store().Has(StoreKeyActiveBackup) {
 		return errors.New("try again later - another backup/restore operation has already been started")
 	}
@@ -53,13 +80,18 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 	event.App = app
 	event.Context = ctx
 	event.Name = name
+	event.Base = base
 	// default root dir entries to exclude from the backup generation
 	event.Exclude = []string{LocalBackupsDirName, LocalTempDirName, LocalAutocertCacheDirName, lostFoundDirName}
 
 	return app.OnBackupCreate().Trigger(event, func(e *BackupEvent) error {
 		// generate a default name if missing
 		if e.Name == "" {
-			e.Name = generateBackupName(e.App, "pb_backup_")
+			if e.Base != "" {
+				e.Name = generateBackupName(e.App, "pb_delta_")
+			} else {
+				e.Name = generateBackupName(e.App, "pb_backup_")
+			}
 		}
 
 		// make sure that the special temp directory exists
@@ -69,6 +101,30 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 			return fmt.Errorf("failed to create a temp dir: %w", err)
 		}
 
+		fsys, err := e.App.NewBackupsFilesystem()
+		if err != nil {
+			return err
+		}
+		defer fsys.Close()
+
+		fsys.SetContext(e.Context)
+
+		// load the base backup manifest (if any)
+		// ---
+		var baseManifest *archive.Manifest
+		if e.Base != "" {
+			basePath, cleanup, err := localBackupPath(e.App, fsys, e.Base, localTempDir)
+			if err != nil {
+				return fmt.Errorf("failed to load the base backup %q: %w", e.Base, err)
+			}
+
+			baseManifest, err = archive.ReadManifest(basePath)
+			cleanup()
+			if err != nil {
+				return fmt.Errorf("failed to read the base backup %q manifest: %w", e.Base, err)
+			}
+		}
+
 		// archive pb_data in a temp directory, exluding the "backups" and the temp dirs
 		//
 		// run in transaction to temporary block other writes (transactions uses the NonconcurrentDB connection)
@@ -81,7 +137,13 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 				txApp.DB().NewQuery("PRAGMA wal_checkpoint(TRUNCATE)").Execute()
 				txApp.AuxDB().NewQuery("PRAGMA wal_checkpoint(TRUNCATE)").Execute()
 
-				return archive.Create(txApp.DataDir(), tempPath, e.Exclude...)
+				_, err := archive.CreateDelta(txApp.DataDir(), tempPath, archive.DeltaOptions{
+					Base:      baseManifest,
+					BaseName:  e.Base,
+					SkipPaths: e.Exclude,
+				})
+
+				return err
 			})
 		})
 		if createErr != nil {
@@ -89,17 +151,21 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 		}
 		defer os.Remove(tempPath)
 
-		// persist the backup in the backups filesystem
+		// encrypt the archive (if enabled)
 		// ---
-		fsys, err := e.App.NewBackupsFilesystem()
-		if err != nil {
-			return err
-		}
-		defer fsys.Close()
+		uploadPath := tempPath
+		if e.App.Settings().Backups.Encryption.Enabled {
+			uploadPath = tempPath + "_encrypted"
+			defer os.Remove(uploadPath)
 
-		fsys.SetContext(e.Context)
+			if err := encryptBackupFile(e.App, tempPath, uploadPath); err != nil {
+				return fmt.Errorf("failed to encrypt the backup: %w", err)
+			}
+		}
 
-		file, err := filesystem.NewFileFromPath(tempPath)
+		// persist the backup in the backups filesystem
+		// ---
+		file, err := filesystem.NewFileFromPath(uploadPath)
 		if err != nil {
 			return err
 		}
@@ -114,6 +180,99 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 	})
 }
 
+// ExtractBackup extracts the content of the backup with the specified name into dest.
+//
+// If the backup is incremental, its full backups chain is resolved
+// and applied in order (starting from the first full backup).
+func (app *BaseApp) ExtractBackup(ctx context.Context, name string, dest string) error {
+	// make sure that the special temp directory exists
+	localTempDir := filepath.Join(app.DataDir(), LocalTempDirName)
+	if err := os.MkdirAll(localTempDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create a temp dir: %w", err)
+	}
+
+	fsys, err := app.NewBackupsFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	fsys.SetContext(ctx)
+
+	if ok, _ := fsys.Exists(name); !ok {
+		return fmt.Errorf("missing or invalid backup file %q", name)
+	}
+
+	// resolve the backups chain (from the newest to the full backup)
+	// ---
+	var names []string
+	var chain []string
+	var cleanups []func()
+
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+	}()
+
+	current := name
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if slices.Contains(names, current) {
+			return fmt.Errorf("circular backups chain at %q", current)
+		}
+		names = append(names, current)
+
+		p, cleanup, err := localBackupPath(app, fsys, current, localTempDir)
+		if err != nil {
+			return fmt.Errorf("failed to load backup %q: %w", current, err)
+		}
+		cleanups = append(cleanups, cleanup)
+		chain = append(chain, p)
+
+		manifest, err := archive.ReadManifest(p)
+		if err != nil {
+			if errors.Is(err, archive.ErrMissingManifest) {
+				break // full backup created before the manifests introduction
+			}
+			return fmt.Errorf("failed to read backup %q manifest: %w", current, err)
+		}
+
+		if !manifest.IsIncremental() {
+			break
+		}
+
+		current = manifest.Base
+	}
+
+	// extract the full backup and apply the incremental ones on top of it
+	// ---
+	if err := archive.Extract(chain[len(chain)-1], dest); err != nil {
+		return err
+	}
+
+	for i := len(chain) - 2; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := archive.ApplyDelta(chain[i], dest); err != nil {
+			return fmt.Errorf("failed to apply incremental backup: %w", err)
+		}
+	}
+
+	// remove the full backup manifest (if any)
+	err = os.Remove(filepath.Join(dest, archive.ManifestName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
 // RestoreBackup restores the backup with the specified name and restarts
 // the current running application process.
 //
@@ -126,9 +285,12 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 //
 //  1. Download the backup with the specified name in a temp location
 //     (this is in case of S3; otherwise it creates a temp copy of the zip)
+//     and decrypt it if it was created with the backups encryption enabled.
 //
 //  2. Extract the backup in a temp directory inside the app "pb_data"
 //     (eg. "pb_data/.pb_temp_to_delete/pb_restore").
+//     If the backup is incremental, its chain is extracted starting from
+//     the first full backup (see [BaseApp.ExtractBackup]).
 //
 //  3. Move the current app "pb_data" content (excluding the local backups and the special temp dir)
 //     under another temp sub dir that will be deleted on the next app start up
@@ -173,67 +335,11 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 			return fmt.Errorf("failed to create a temp dir: %w", err)
 		}
 
-		fsys, err := e.App.NewBackupsFilesystem()
-		if err != nil {
-			return err
-		}
-		defer fsys.Close()
-
-		fsys.SetContext(e.Context)
-
-		if ok, _ := fsys.Exists(name); !ok {
-			return fmt.Errorf("missing or invalid backup file %q to restore", name)
-		}
-
 		extractedDataDir := filepath.Join(localTempDir, "pb_restore_"+security.PseudorandomString(8))
 		defer os.RemoveAll(extractedDataDir)
 
-		// extract the zip
-		if e.App.Settings().Backups.S3.Enabled {
-			br, err := fsys.GetReader(name)
-			if err != nil {
-				return err
-			}
-			defer br.Close()
-
-			// create a temp zip file from the blob.Reader and try to extract it
-			tempZip, err := os.CreateTemp(localTempDir, "pb_restore_zip")
-			if err != nil {
-				return err
-			}
-			defer os.Remove(tempZip.Name())
-			defer tempZip.Close() // note: this technically shouldn't be necessary but it is here to workaround platforms discrepancies
-
-			_, err = io.Copy(tempZip, br)
-			if err != nil {
-				return err
-			}
-
-			err = archive.Extract(tempZip.Name(), extractedDataDir)
-			if err != nil {
-				return err
-			}
-
-			// remove the temp zip file since we no longer need it
-			// (this is in case the app restarts and the defer calls are not called)
-			_ = tempZip.Close()
-			err = os.Remove(tempZip.Name())
-			if err != nil {
-				e.App.Logger().Warn(
-					"[RestoreBackup] Failed to remove the temp zip backup file",
-					slog.String("file", tempZip.Name()),
-					slog.String("error", err.Error()),
-				)
-			}
-		} else {
-			// manually construct the local path to avoid creating a copy of the zip file
-			// since the blob reader currently doesn't implement ReaderAt
-			zipPath := filepath.Join(e.App.DataDir(), LocalBackupsDirName, filepath.Base(name))
-
-			err = archive.Extract(zipPath, extractedDataDir)
-			if err != nil {
-				return err
-			}
+		if err := e.App.ExtractBackup(e.Context, e.Name, extractedDataDir); err != nil {
+			return err
 		}
 
 		// ensure that at least a database file exists
@@ -306,24 +412,6 @@ func (app *BaseApp) registerAutobackupHooks() {
 		}
 
 		app.Cron().Add(jobId, rawSchedule, func() {
-			const autoPrefix = "@auto_pb_backup_"
-
-			name := generateBackupName(app, autoPrefix)
-
-			if err := app.CreateBackup(context.Background(), name); err != nil {
-				app.Logger().Error(
-					"[Backup cron] Failed to create backup",
-					slog.String("name", name),
-					slog.String("error", err.Error()),
-				)
-			}
-
-			maxKeep := app.Settings().Backups.CronMaxKeep
-
-			if maxKeep == 0 {
-				return // no explicit limit
-			}
-
 			fsys, err := app.NewBackupsFilesystem()
 			if err != nil {
 				app.Logger().Error(
@@ -334,36 +422,67 @@ func (app *BaseApp) registerAutobackupHooks() {
 			}
 			defer fsys.Close()
 
-			files, err := fsys.List(autoPrefix)
-			if err != nil {
-				app.Logger().Error(
-					"[Backup cron] Failed to list autogenerated backups",
-					slog.String("error", err.Error()),
-				)
-				return
-			}
+			config := app.Settings().Backups
 
-			if maxKeep >= len(files) {
-				return // nothing to remove
+			var base string
+			if config.CronMode == BackupModeIncremental || config.CronMode == BackupModeDifferential {
+				files, err := listAutoBackups(fsys)
+				if err != nil {
+					app.Logger().Error(
+						"[Backup cron] Failed to list autogenerated backups",
+						slog.String("error", err.Error()),
+					)
+				} else {
+					base = autoBackupBase(config.CronMode, config.cronFullEvery(), files)
+				}
 			}
 
-			// sort desc
-			sort.Slice(files, func(i, j int) bool {
-				return files[i].ModTime.After(files[j].ModTime)
-			})
+			var created string
+
+			if base != "" {
+				name := generateBackupName(app, autoDeltaBackupPrefix)
+
+				if err := app.CreateIncrementalBackup(context.Background(), name, base); err != nil {
+					app.Logger().Error(
+						"[Backup cron] Failed to create incremental backup, falling back to a full one",
+						slog.String("name", name),
+						slog.String("base", base),
+						slog.String("error", err.Error()),
+					)
+				} else {
+					created = name
+				}
+			}
 
-			// keep only the most recent n auto backup files
-			toRemove := files[maxKeep:]
+			if created == "" {
+				name := generateBackupName(app, autoBackupPrefix)
 
-			for _, f := range toRemove {
-				if err := fsys.Delete(f.Key); err != nil {
+				if err := app.CreateBackup(context.Background(), name); err != nil {
 					app.Logger().Error(
-						"[Backup cron] Failed to remove old autogenerated backup",
-						slog.String("key", f.Key),
+						"[Backup cron] Failed to create backup",
+						slog.String("name", name),
 						slog.String("error", err.Error()),
 					)
+				} else {
+					created = name
 				}
 			}
+
+			// note: the verification result is logged by VerifyBackup
+			if created != "" && config.CronVerify {
+				app.VerifyBackup(context.Background(), created)
+			}
+
+			if config.CronMaxKeep == 0 && !config.Retention.IsEnabled() {
+				return // no explicit limit
+			}
+
+			if _, err := app.PruneBackups(context.Background(), false); err != nil {
+				app.Logger().Error(
+					"[Backup cron] Failed to remove old autogenerated backups",
+					slog.String("error", err.Error()),
+				)
+			}
 		})
 	}
 
@@ -388,6 +507,96 @@ func (app *BaseApp) registerAutobackupHooks() {
 	})
 }
 
+// localBackupPath returns the local file path of the plain zip backup with the specified name.
+//
+// If the backups are stored on S3 or the backup is encrypted, the backup is
+// downloaded/decrypted in a temp file inside tempDir and the returned
+// cleanup function takes care to remove it.
+func localBackupPath(app App, fsys *filesystem.System, name string, tempDir string) (string, func(), error) {
+	path, cleanup, err := downloadBackup(app, fsys, name, tempDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	encrypted, err := isEncryptedBackupFile(path)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	if !encrypted {
+		return path, cleanup, nil
+	}
+
+	// decrypt into a temp zip file
+	decryptedPath := filepath.Join(tempDir, "pb_backup_decrypted_"+security.PseudorandomString(8))
+
+	decryptedCleanup := func() {
+		cleanup()
+
+		if err := os.Remove(decryptedPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			app.Logger().Warn(
+				"Failed to remove the temp decrypted backup file",
+				slog.String("file", decryptedPath),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	if err := decryptBackupFile(app, path, decryptedPath); err != nil {
+		decryptedCleanup()
+		return "", nil, fmt.Errorf("failed to decrypt backup: %w", err)
+	}
+
+	return decryptedPath, decryptedCleanup, nil
+}
+
+// downloadBackup returns the local path of the specified backup file as it is
+// stored in the backups filesystem (in case of S3 it is downloaded in a temp file).
+func downloadBackup(app App, fsys *filesystem.System, name string, tempDir string) (string, func(), error) {
+	if !app.Settings().Backups.S3.Enabled {
+		// manually construct the local path to avoid creating a copy of the zip file
+		// since the blob reader currently doesn't implement ReaderAt
+		return filepath.Join(app.DataDir(), LocalBackupsDirName, filepath.Base(name)), func() {}, nil
+	}
+
+	br, err := fsys.GetReader(name)
+	if err != nil {
+		return "", nil, err
+	}
+	defer br.Close()
+
+	// create a temp zip file from the blob.Reader
+	tempZip, err := os.CreateTemp(tempDir, "pb_backup_zip")
+	if err != nil {
+		return "", nil, err
+	}
+
+	cleanup := func() {
+		_ = tempZip.Close() // note: this technically shouldn't be necessary but it is here to workaround platforms discrepancies
+
+		if err := os.Remove(tempZip.Name()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			app.Logger().Warn(
+				"Failed to remove the temp zip backup file",
+				slog.String("file", tempZip.Name()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	if _, err := io.Copy(tempZip, br); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	if err := tempZip.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return tempZip.Name(), cleanup, nil
+}
+
 func generateBackupName(app App, prefix string) string {
 	appName := inflector.Snakecase(app.Settings().Meta.AppName)
 	if len(appName) > 50 {
@@ -401,3 +610,56 @@ func generateBackupName(app App, prefix string) string {
 		time.Now().UTC().Format("20060102150405"),
 	)
 }
+
+const (
+	autoBackupPrefix      = "@auto_pb_backup_"
+	autoDeltaBackupPrefix = "@auto_pb_delta_"
+)
+
+// listAutoBackups returns all autogenerated full and incremental
+// backup files sorted by their modification date (newest first).
+func listAutoBackups(fsys *filesystem.System) ([]*blob.ListObject, error) {
+	files, err := fsys.List(autoBackupPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	deltas, err := fsys.List(autoDeltaBackupPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	files = append(files, deltas...)
+
+	// sort desc
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].ModTime.After(files[j].ModTime)
+	})
+
+	return files, nil
+}
+
+// autoBackupBase returns the name of the base backup that the next
+// autogenerated backup should be created from.
+//
+// Returns an empty string if a new full backup should be created instead
+// (aka. there is no full backup yet or fullEvery incremental backups were
+// already created since the last full one).
+//
+// files are expected to be sorted desc by their modification date.
+func autoBackupBase(mode string, fullEvery int, files []*blob.ListObject) string {
+	lastFull := slices.IndexFunc(files, func(f *blob.ListObject) bool {
+		return !strings.HasPrefix(f.Key, autoDeltaBackupPrefix)
+	})
+
+	// lastFull is also the number of incremental backups created after it
+	if lastFull < 0 || lastFull >= fullEvery {
+		return ""
+	}
+
+	if mode == BackupModeDifferential {
+		return files[lastFull].Key
+	}
+
+	return files[0].Key
+}
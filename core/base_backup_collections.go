@@ -0,0 +1,285 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// RestoreBackupCollections restores only the records and files of the specified
+// collections (names or ids) from the backup with the specified name into the
+// current running application (without restart).
+//
+// The performed steps are:
+//
+//  1. Extract the backup in a temp directory inside the app "pb_data"
+//     (see [BaseApp.ExtractBackup]).
+//
+//  2. For each collection, replace in a single transaction all of its current
+//     records with the backup ones (only the columns that exist both in the
+//     backup and in the current collection table are restored).
+//
+//  3. For each collection, replace its current files with the backup ones
+//     (if the backup doesn't contain the collection storage dir, e.g. because
+//     S3 is used for the uploaded files, the current files are left untouched).
+//
+// The restored collections must exist both in the backup and in the current app
+// (they are matched by id and then by name) and must not be views.
+//
+// Note that the records are restored as they are stored in the backup,
+// aka. without triggering the model hooks and without updating
+// the other collections records that may reference them.
+func (app *BaseApp) RestoreBackupCollections(ctx context.Context, name string, collections []string) error {
+	if len(collections) == 0 {
+		return errors.New("at least one collection to restore is required")
+	}
+
+	if app.Store().Has(StoreKeyActiveBackup) {
+		return errors.New("try again later - another backup/restore operation has already been started")
+	}
+
+	app.Store().Set(StoreKeyActiveBackup, name)
+	defer app.Store().Remove(StoreKeyActiveBackup)
+
+	event := new(BackupEvent)
+	event.App = app
+	event.Context = ctx
+	event.Name = name
+	event.Collections = collections
+
+	return app.OnBackupRestore().Trigger(event, func(e *BackupEvent) error {
+		// make sure that the special temp directory exists
+		localTempDir := filepath.Join(e.App.DataDir(), LocalTempDirName)
+		if err := os.MkdirAll(localTempDir, os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create a temp dir: %w", err)
+		}
+
+		extractedDataDir := filepath.Join(localTempDir, "pb_restore_"+security.PseudorandomString(8))
+		defer os.RemoveAll(extractedDataDir)
+
+		if err := e.App.ExtractBackup(e.Context, e.Name, extractedDataDir); err != nil {
+			return err
+		}
+
+		backupDB, err := app.config.DBConnect(filepath.Join(extractedDataDir, "data.db"))
+		if err != nil {
+			return fmt.Errorf("failed to open the backup data.db: %w", err)
+		}
+		defer backupDB.Close()
+
+		// resolve the collections pairs
+		// ---
+		pairs := make([]*restoreCollectionPair, 0, len(e.Collections))
+		for _, nameOrId := range e.Collections {
+			pair, err := resolveRestoreCollectionPair(e.App, backupDB, nameOrId)
+			if err != nil {
+				return err
+			}
+			pairs = append(pairs, pair)
+		}
+
+		// restore the records
+		// ---
+		err = e.App.RunInTransaction(func(txApp App) error {
+			for _, pair := range pairs {
+				if err := e.Context.Err(); err != nil {
+					return err
+				}
+
+				if err := restoreCollectionRecords(txApp, backupDB, pair); err != nil {
+					return fmt.Errorf("failed to restore %q records: %w", pair.current.Name, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// restore the files
+		// ---
+		for _, pair := range pairs {
+			if err := e.Context.Err(); err != nil {
+				return err
+			}
+
+			if err := restoreCollectionFiles(e.App, extractedDataDir, pair); err != nil {
+				return fmt.Errorf("failed to restore %q files: %w", pair.current.Name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+type backupCollectionRow struct {
+	Id   string `db:"id"`
+	Name string `db:"name"`
+	Type string `db:"type"`
+}
+
+type restoreCollectionPair struct {
+	current     *Collection
+	backupId    string
+	backupTable string
+}
+
+func resolveRestoreCollectionPair(app App, backupDB *dbx.DB, nameOrId string) (*restoreCollectionPair, error) {
+	current, err := app.FindCollectionByNameOrId(nameOrId)
+	if err != nil {
+		return nil, fmt.Errorf("missing collection %q", nameOrId)
+	}
+
+	if current.IsView() {
+		return nil, fmt.Errorf("view collection %q cannot be restored", current.Name)
+	}
+
+	backupCollections := []*backupCollectionRow{}
+	err = backupDB.Select("id", "name", "type").
+		From((&Collection{}).TableName()).
+		Where(dbx.Or(dbx.HashExp{"id": current.Id}, dbx.HashExp{"name": current.Name})).
+		All(&backupCollections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backup collection %q: %w", current.Name, err)
+	}
+
+	if len(backupCollections) == 0 {
+		return nil, fmt.Errorf("collection %q doesn't exist in the backup", current.Name)
+	}
+
+	// prefer the id match in case the collection was renamed
+	match := backupCollections[0]
+	for _, c := range backupCollections {
+		if c.Id == current.Id {
+			match = c
+			break
+		}
+	}
+
+	if match.Type == CollectionTypeView {
+		return nil, fmt.Errorf("backup collection %q is a view and cannot be restored", match.Name)
+	}
+
+	return &restoreCollectionPair{
+		current:     current,
+		backupId:    match.Id,
+		backupTable: match.Name,
+	}, nil
+}
+
+func restoreCollectionRecords(txApp App, backupDB *dbx.DB, pair *restoreCollectionPair) error {
+	currentColumns, err := txApp.TableColumns(pair.current.Name)
+	if err != nil {
+		return err
+	}
+
+	backupColumns := []string{}
+	err = backupDB.NewQuery("SELECT name FROM PRAGMA_TABLE_INFO({:tableName})").
+		Bind(dbx.Params{"tableName": pair.backupTable}).
+		Column(&backupColumns)
+	if err != nil {
+		return err
+	}
+
+	columns := make([]string, 0, len(currentColumns))
+	for _, col := range currentColumns {
+		if slices.Contains(backupColumns, col) {
+			columns = append(columns, col)
+		}
+	}
+
+	if !slices.Contains(columns, FieldNameId) {
+		return errors.New("the backup table is missing the id column")
+	}
+
+	_, err = txApp.DB().Delete(pair.current.Name, nil).Execute()
+	if err != nil {
+		return err
+	}
+
+	rows, err := backupDB.Select(columns...).From(pair.backupTable).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+
+		params := make(dbx.Params, len(columns))
+		for i, col := range columns {
+			params[col] = values[i]
+		}
+
+		if _, err := txApp.DB().Insert(pair.current.Name, params).Execute(); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func restoreCollectionFiles(app App, extractedDataDir string, pair *restoreCollectionPair) error {
+	backupStorageDir := filepath.Join(extractedDataDir, LocalStorageDirName, pair.backupId)
+
+	if _, err := os.Stat(backupStorageDir); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil // nothing to restore
+		}
+		return err
+	}
+
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	if errs := fsys.DeletePrefix(pair.current.Id + "/"); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return filepath.WalkDir(backupStorageDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(backupStorageDir, path)
+		if err != nil {
+			return err
+		}
+
+		// skip the blob attributes files (they are recreated on upload)
+		if filepath.Ext(rel) == ".attrs" {
+			return nil
+		}
+
+		file, err := filesystem.NewFileFromPath(path)
+		if err != nil {
+			return err
+		}
+
+		return fsys.UploadFile(file, pair.current.Id+"/"+filepath.ToSlash(rel))
+	})
+}
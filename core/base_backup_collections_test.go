@@ -0,0 +1,134 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRestoreBackupCollections(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := app.CreateBackup(context.Background(), "test.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	demo1Total := countTestRecords(t, app, "demo1")
+	if demo1Total == 0 {
+		t.Fatal("Expected demo1 records")
+	}
+
+	fileKey := "wsmn24bux7wo113/84nmscqy84lsi1t/test_MaWC6mWyrP.txt"
+	filePath := filepath.Join(app.DataDir(), core.LocalStorageDirName, filepath.FromSlash(fileKey))
+
+	// delete all demo1 records
+	records, err := app.FindAllRecords("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if err := app.Delete(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// change a demo3 record to ensure that the other collections are not affected
+	demo3Record, err := app.FindFirstRecordByFilter("demo3", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo3Record.Set("title", "test_changed")
+	if err := app.Save(demo3Record); err != nil {
+		t.Fatal(err)
+	}
+
+	// ensure that all demo1 files are removed
+	if err := os.RemoveAll(filepath.Join(app.DataDir(), core.LocalStorageDirName, "wsmn24bux7wo113")); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("validations", func(t *testing.T) {
+		scenarios := []struct {
+			name        string
+			backup      string
+			collections []string
+		}{
+			{"no collections", "test.zip", nil},
+			{"missing backup", "missing.zip", []string{"demo1"}},
+			{"missing collection", "test.zip", []string{"missing"}},
+			{"view collection", "test.zip", []string{"view1"}},
+		}
+
+		for _, s := range scenarios {
+			t.Run(s.name, func(t *testing.T) {
+				if err := app.RestoreBackupCollections(context.Background(), s.backup, s.collections); err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+			})
+		}
+	})
+
+	t.Run("pending backup", func(t *testing.T) {
+		app.Store().Set(core.StoreKeyActiveBackup, "")
+		defer app.Store().Remove(core.StoreKeyActiveBackup)
+
+		if err := app.RestoreBackupCollections(context.Background(), "test.zip", []string{"demo1"}); err == nil {
+			t.Fatal("Expected pending error, got nil")
+		}
+	})
+
+	var event *core.BackupEvent
+	app.OnBackupRestore().BindFunc(func(e *core.BackupEvent) error {
+		event = e
+		return e.Next()
+	})
+
+	// restore only demo1 (using its id)
+	err = app.RestoreBackupCollections(context.Background(), "test.zip", []string{"wsmn24bux7wo113"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event == nil || len(event.Collections) != 1 || event.Collections[0] != "wsmn24bux7wo113" {
+		t.Fatalf("Expected OnBackupRestore to be triggered with the restored collections, got %v", event)
+	}
+
+	if total := countTestRecords(t, app, "demo1"); total != demo1Total {
+		t.Fatalf("Expected %d demo1 records, got %d", demo1Total, total)
+	}
+
+	demo3Record, err = app.FindRecordById("demo3", demo3Record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title := demo3Record.GetString("title"); title != "test_changed" {
+		t.Fatalf("Expected demo3 to not be restored, got title %q", title)
+	}
+
+	if _, err := os.Stat(filePath); err != nil {
+		t.Fatalf("Expected %q to be restored: %v", fileKey, err)
+	}
+
+	// the restored records should be usable as regular records
+	record, err := app.FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Save(record); err != nil {
+		t.Fatalf("Failed to save restored record: %v", err)
+	}
+}
+
+func countTestRecords(t *testing.T, app core.App, collection string) int64 {
+	total, err := app.CountRecords(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return total
+}
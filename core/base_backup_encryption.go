@@ -0,0 +1,152 @@
+package core
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pocketbase/pocketbase/tools/age"
+)
+
+const backupIdentityInfo = "pocketbase backups encryption"
+
+// backupIdentity returns the backups age identity derived from
+// the app EncryptionEnv secret.
+//
+// Returns nil if the EncryptionEnv secret is not set.
+func backupIdentity(app App) (*age.X25519Identity, error) {
+	secret := os.Getenv(app.EncryptionEnv())
+	if secret == "" {
+		return nil, nil
+	}
+
+	key, err := hkdf.Key(sha256.New, []byte(secret), nil, backupIdentityInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return age.NewX25519Identity(key)
+}
+
+// backupRecipients returns the age recipients that the new backups
+// should be encrypted for based on the app backups encryption settings.
+func backupRecipients(app App) ([]age.Recipient, error) {
+	var recipients []age.Recipient
+
+	if raw := app.Settings().Backups.Encryption.Recipient; raw != "" {
+		recipient, err := age.ParseX25519Recipient(raw)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	identity, err := backupIdentity(app)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		recipients = append(recipients, identity.Recipient())
+	}
+
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("the backups encryption requires either a recipient or the %q env variable to be set", app.EncryptionEnv())
+	}
+
+	return recipients, nil
+}
+
+// encryptBackupFile encrypts the src backup file into dest
+// for the configured backups encryption recipients.
+func encryptBackupFile(app App, src string, dest string) error {
+	recipients, err := backupRecipients(app)
+	if err != nil {
+		return err
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	destFile, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer destFile.Close()
+
+	w, err := age.Encrypt(destFile, recipients...)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(w, srcFile); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return destFile.Close()
+}
+
+// isEncryptedBackupFile reports whether the specified backup file is age encrypted.
+func isEncryptedBackupFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	header := make([]byte, len(age.Intro))
+
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+
+	return age.IsEncrypted(header[:n]), nil
+}
+
+// decryptBackupFile decrypts the src backup file into dest
+// using the identity derived from the app EncryptionEnv secret.
+func decryptBackupFile(app App, src string, dest string) error {
+	identity, err := backupIdentity(app)
+	if err != nil {
+		return err
+	}
+	if identity == nil {
+		return fmt.Errorf("the backup is encrypted but the %q env variable is not set", app.EncryptionEnv())
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	r, err := age.Decrypt(srcFile, identity)
+	if err != nil {
+		if errors.Is(err, age.ErrIncorrectIdentity) {
+			return errors.New("the backup was encrypted for a different key and must be decrypted manually with its private key")
+		}
+		return err
+	}
+
+	destFile, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer destFile.Close()
+
+	if _, err := io.Copy(destFile, r); err != nil {
+		return err
+	}
+
+	return destFile.Close()
+}
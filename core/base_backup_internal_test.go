@@ -0,0 +1,329 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/filesystem/blob"
+)
+
+func TestAutoBackupBase(t *testing.T) {
+	t.Parallel()
+
+	full := autoBackupPrefix
+	delta := autoDeltaBackupPrefix
+
+	scenarios := []struct {
+		name      string
+		mode      string
+		fullEvery int
+		files     []string
+		expected  string
+	}{
+		{"no backups", BackupModeIncremental, 3, nil, ""},
+		{"only deltas", BackupModeIncremental, 3, []string{delta + "2", delta + "1"}, ""},
+		{"incremental after full", BackupModeIncremental, 3, []string{full + "1"}, full + "1"},
+		{"incremental after delta", BackupModeIncremental, 3, []string{delta + "2", full + "1"}, delta + "2"},
+		{"differential after delta", BackupModeDifferential, 3, []string{delta + "2", full + "1"}, full + "1"},
+		{"full every reached", BackupModeIncremental, 2, []string{delta + "3", delta + "2", full + "1"}, ""},
+		{"full every not reached", BackupModeDifferential, 3, []string{delta + "3", delta + "2", full + "1"}, full + "1"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := autoBackupBase(s.mode, s.fullEvery, testListObjects(s.files))
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestAutoBackupsRetention(t *testing.T) {
+	t.Parallel()
+
+	full := autoBackupPrefix
+	delta := autoDeltaBackupPrefix
+
+	scenarios := []struct {
+		name      string
+		keepLast  int
+		retention BackupsRetentionConfig
+		files     []string
+		expected  map[string][]string
+	}{
+		{
+			"no limit",
+			0,
+			BackupsRetentionConfig{},
+			[]string{full + "2", full + "1"},
+			map[string][]string{full + "2": {"last"}, full + "1": {"last"}},
+		},
+		{
+			"below limit",
+			3,
+			BackupsRetentionConfig{},
+			[]string{full + "2", full + "1"},
+			map[string][]string{full + "2": {"last"}, full + "1": {"last"}},
+		},
+		{
+			"only full",
+			1,
+			BackupsRetentionConfig{},
+			[]string{full + "3", full + "2", full + "1"},
+			map[string][]string{full + "3": {"last"}},
+		},
+		{
+			"keep chain",
+			2,
+			BackupsRetentionConfig{},
+			[]string{delta + "5", delta + "4", delta + "3", full + "2", full + "1"},
+			map[string][]string{
+				delta + "5": {"last"},
+				delta + "4": {"last"},
+				delta + "3": {"chain"},
+				full + "2":  {"chain"},
+			},
+		},
+		{
+			"chain ending on full",
+			2,
+			BackupsRetentionConfig{},
+			[]string{delta + "4", full + "3", delta + "2", full + "1"},
+			map[string][]string{delta + "4": {"last"}, full + "3": {"last"}},
+		},
+		{
+			"broken chain",
+			1,
+			BackupsRetentionConfig{},
+			[]string{delta + "2", delta + "1"},
+			map[string][]string{delta + "2": {"last"}, delta + "1": {"chain"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := autoBackupsRetention(testListObjects(s.files), s.keepLast, s.retention, nil)
+
+			assertRetention(t, s.expected, result)
+		})
+	}
+}
+
+func TestAutoBackupsRetentionPITR(t *testing.T) {
+	t.Parallel()
+
+	full := autoBackupPrefix
+	delta := autoDeltaBackupPrefix
+
+	files := testListObjects([]string{delta + "5", full + "4", delta + "3", delta + "2", full + "1"})
+
+	scenarios := []struct {
+		name      string
+		pitrBases []string
+		expected  map[string][]string
+	}{
+		{
+			"no bases",
+			nil,
+			map[string][]string{delta + "5": {"last"}, full + "4": {"chain"}},
+		},
+		{
+			"full base",
+			[]string{full + "1", "manual.zip"},
+			map[string][]string{delta + "5": {"last"}, full + "4": {"chain"}, full + "1": {"pitr"}},
+		},
+		{
+			"delta base",
+			[]string{delta + "3"},
+			map[string][]string{
+				delta + "5": {"last"},
+				full + "4":  {"chain"},
+				delta + "3": {"pitr"},
+				delta + "2": {"chain"},
+				full + "1":  {"chain"},
+			},
+		},
+		{
+			"already retained base",
+			[]string{delta + "5"},
+			map[string][]string{delta + "5": {"last", "pitr"}, full + "4": {"chain"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := autoBackupsRetention(files, 1, BackupsRetentionConfig{}, s.pitrBases)
+
+			assertRetention(t, s.expected, result)
+		})
+	}
+}
+
+func TestAutoBackupsRetentionPeriods(t *testing.T) {
+	t.Parallel()
+
+	full := autoBackupPrefix
+	delta := autoDeltaBackupPrefix
+
+	// sorted desc
+	files := []*blob.ListObject{
+		{Key: full + "10", ModTime: testDate(t, "2024-05-06 10:00:00")}, // Monday, week 19
+		{Key: full + "9", ModTime: testDate(t, "2024-05-06 01:00:00")},
+		{Key: delta + "8", ModTime: testDate(t, "2024-05-05 10:00:00")}, // Sunday, week 18
+		{Key: full + "7", ModTime: testDate(t, "2024-05-04 10:00:00")},
+		{Key: full + "6", ModTime: testDate(t, "2024-04-20 10:00:00")}, // week 16
+		{Key: full + "5", ModTime: testDate(t, "2024-04-01 10:00:00")}, // week 14
+		{Key: full + "4", ModTime: testDate(t, "2024-03-15 10:00:00")},
+		{Key: full + "3", ModTime: testDate(t, "2024-02-15 10:00:00")},
+	}
+
+	scenarios := []struct {
+		name      string
+		keepLast  int
+		retention BackupsRetentionConfig
+		expected  map[string][]string
+	}{
+		{
+			"daily",
+			0,
+			BackupsRetentionConfig{KeepDaily: 2},
+			map[string][]string{
+				full + "10": {"daily"},
+				delta + "8": {"daily"},
+				full + "7":  {"chain"},
+			},
+		},
+		{
+			"weekly",
+			0,
+			BackupsRetentionConfig{KeepWeekly: 3},
+			map[string][]string{
+				full + "10": {"weekly"},
+				delta + "8": {"weekly"},
+				full + "7":  {"chain"},
+				full + "6":  {"weekly"},
+			},
+		},
+		{
+			"monthly",
+			0,
+			BackupsRetentionConfig{KeepMonthly: 3},
+			map[string][]string{
+				full + "10": {"monthly"},
+				full + "6":  {"monthly"},
+				full + "4":  {"monthly"},
+			},
+		},
+		{
+			"combined",
+			1,
+			BackupsRetentionConfig{KeepDaily: 1, KeepWeekly: 1, KeepMonthly: 4},
+			map[string][]string{
+				full + "10": {"last", "daily", "weekly", "monthly"},
+				full + "6":  {"monthly"},
+				full + "4":  {"monthly"},
+				full + "3":  {"monthly"},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := autoBackupsRetention(files, s.keepLast, s.retention, nil)
+
+			assertRetention(t, s.expected, result)
+		})
+	}
+}
+
+func assertRetention(t *testing.T, expected map[string][]string, result map[string][]string) {
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+
+	for key, reasons := range expected {
+		if !slices.Equal(result[key], reasons) {
+			t.Fatalf("Expected %q reasons %v, got %v", key, reasons, result[key])
+		}
+	}
+}
+
+func testDate(t *testing.T, raw string) time.Time {
+	d, err := time.Parse(time.DateTime, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestPITRChains(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	segment := func(days int) *blob.ListObject {
+		created := now.AddDate(0, 0, days)
+		return &blob.ListObject{
+			Key:     pitrSegmentPrefix + created.Format(pitrSegmentTimeLayout) + ".zip",
+			ModTime: now, // should be ignored
+		}
+	}
+
+	backup := func(name string, days int) *blob.ListObject {
+		return &blob.ListObject{Key: name, ModTime: now.AddDate(0, 0, days)}
+	}
+
+	objects := []*blob.ListObject{
+		segment(-9),
+		backup("b2.zip", -5),
+		segment(-3),
+		backup("b1.zip", -8),
+		segment(-7),
+		segment(-2),
+		backup("b3.zip", -1),
+		segment(-10),
+	}
+
+	chains := pitrChains(objects)
+
+	expected := []struct {
+		base     string
+		segments []*blob.ListObject
+	}{
+		{"", []*blob.ListObject{segment(-9), segment(-10)}},
+		{"b1.zip", []*blob.ListObject{segment(-7)}},
+		{"b2.zip", []*blob.ListObject{segment(-2), segment(-3)}},
+	}
+
+	if len(chains) != len(expected) {
+		t.Fatalf("Expected %d chains, got %d", len(expected), len(chains))
+	}
+
+	for i, e := range expected {
+		if chains[i].base != e.base {
+			t.Fatalf("[%d] Expected base %q, got %q", i, e.base, chains[i].base)
+		}
+
+		if len(chains[i].segments) != len(e.segments) {
+			t.Fatalf("[%d] Expected %d segments, got %d", i, len(e.segments), len(chains[i].segments))
+		}
+
+		for j, seg := range e.segments {
+			if chains[i].segments[j].name != seg.Key {
+				t.Fatalf("[%d:%d] Expected segment %q, got %q", i, j, seg.Key, chains[i].segments[j].name)
+			}
+		}
+	}
+}
+
+func testListObjects(keys []string) []*blob.ListObject {
+	result := make([]*blob.ListObject, len(keys))
+
+	for i, key := range keys {
+		result[i] = &blob.ListObject{Key: key}
+	}
+
+	return result
+}
@@ -0,0 +1,462 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/archive"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/filesystem/blob"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+const (
+	// pitrSegmentPrefix is the name prefix of the point-in-time recovery segments.
+	pitrSegmentPrefix = "@pitr_"
+
+	// pitrSegmentTimeLayout is the segment name time layout (always in UTC).
+	pitrSegmentTimeLayout = "20060102150405.000"
+
+	// storeKeyPITRBase is the app store key holding the
+	// last archived segment (or backup) that the next segment will be based on.
+	storeKeyPITRBase = "@pitrBase"
+)
+
+// pitrBase describes the backup that the next segment is based on.
+type pitrBase struct {
+	name     string
+	manifest *archive.Manifest
+}
+
+// ArchiveWALSegment checkpoints the main database WAL and archives the
+// data.db changes since the previous segment (or since the latest backup)
+// as a new point-in-time recovery segment in the backups filesystem.
+//
+// The segments are regular incremental backups named "@pitr_{UTC time}.zip"
+// and they are usually created periodically when app.Settings().Backups.PITR is enabled.
+//
+// Returns the name of the new segment or an empty string
+// if there were no changes since the previous one.
+//
+// After a new segment is archived, the segment chains older than
+// app.Settings().Backups.PITR.MaxDays are removed.
+//
+// At least one backup created with a manifest (aka. by the current or newer app version)
+// is required to be available in the backups filesystem.
+func (app *BaseApp) ArchiveWALSegment(ctx context.Context) (string, error) {
+	if app.Store().Has(StoreKeyActiveBackup) {
+		return "", errors.New("try again later - another backup/restore operation has already been started")
+	}
+
+	// the segment name is not known yet but it is always a new file
+	// so it is enough to just mark that there is an active operation
+	app.Store().Set(StoreKeyActiveBackup, pitrSegmentPrefix)
+	defer app.Store().Remove(StoreKeyActiveBackup)
+
+	// make sure that the special temp directory exists
+	// note: it needs to be inside the current pb_data to avoid "cross-device link" errors
+	localTempDir := filepath.Join(app.DataDir(), LocalTempDirName)
+	if err := os.MkdirAll(localTempDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create a temp dir: %w", err)
+	}
+
+	fsys, err := app.NewBackupsFilesystem()
+	if err != nil {
+		return "", err
+	}
+	defer fsys.Close()
+
+	fsys.SetContext(ctx)
+
+	base, _ := app.Store().Get(storeKeyPITRBase).(*pitrBase)
+	if base == nil {
+		base, err = latestPITRBase(app, fsys, localTempDir)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	name := pitrSegmentPrefix + time.Now().UTC().Format(pitrSegmentTimeLayout) + ".zip"
+
+	// move the committed WAL frames into the db file
+	//
+	// note: it runs outside of the below transaction because
+	// SQLite doesn't allow checkpoints with an open write transaction
+	_, err = app.NonconcurrentDB().NewQuery("PRAGMA wal_checkpoint(TRUNCATE)").Execute()
+	if err != nil {
+		return "", fmt.Errorf("failed to checkpoint the WAL: %w", err)
+	}
+
+	// archive the changed data.db blocks
+	//
+	// run in transaction to temporary block other writes (transactions uses the NonconcurrentDB connection)
+	// ---
+	var manifest *archive.Manifest
+
+	tempPath := filepath.Join(localTempDir, "pb_pitr_"+security.PseudorandomString(6))
+	createErr := app.RunInTransaction(func(txApp App) error {
+		var err error
+		manifest, err = archive.CreateDelta(txApp.DataDir(), tempPath, archive.DeltaOptions{
+			Base:     base.manifest,
+			BaseName: base.name,
+			Paths:    []string{"data.db"},
+		})
+
+		return err
+	})
+	if createErr != nil {
+		return "", createErr
+	}
+	defer os.Remove(tempPath)
+
+	if !pitrDataChanged(base.manifest, manifest) {
+		return "", nil
+	}
+
+	// encrypt the segment (if enabled)
+	// ---
+	uploadPath := tempPath
+	if app.Settings().Backups.Encryption.Enabled {
+		uploadPath = tempPath + "_encrypted"
+		defer os.Remove(uploadPath)
+
+		if err := encryptBackupFile(app, tempPath, uploadPath); err != nil {
+			return "", fmt.Errorf("failed to encrypt the segment: %w", err)
+		}
+	}
+
+	// persist the segment in the backups filesystem
+	// ---
+	file, err := filesystem.NewFileFromPath(uploadPath)
+	if err != nil {
+		return "", err
+	}
+	file.OriginalName = name
+	file.Name = name
+
+	if err := fsys.UploadFile(file, file.Name); err != nil {
+		return "", err
+	}
+
+	app.Store().Set(storeKeyPITRBase, &pitrBase{name: name, manifest: manifest})
+
+	if err := prunePITRSegments(fsys, app.Settings().Backups.PITR.maxDays(), time.Now()); err != nil {
+		app.Logger().Warn(
+			"[PITR] Failed to prune the old segments",
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return name, nil
+}
+
+// RestorePointInTime restores the app data in the state at the specified time
+// and restarts the current running application process.
+//
+// The restored backup is the latest point-in-time recovery segment created
+// before or at t (its incremental chain is replayed on top of the base full backup).
+// If there is a regular backup that is more recent than the segment but still
+// before t, it is restored instead.
+//
+// Please refer to [BaseApp.RestoreBackup] for details on the restore procedures.
+//
+// NB! This feature is experimental and currently is expected to work only on UNIX based systems.
+func (app *BaseApp) RestorePointInTime(ctx context.Context, t time.Time) error {
+	fsys, err := app.NewBackupsFilesystem()
+	if err != nil {
+		return err
+	}
+
+	fsys.SetContext(ctx)
+
+	name, err := pointInTimeBackup(fsys, t)
+
+	fsys.Close()
+
+	if err != nil {
+		return err
+	}
+
+	return app.RestoreBackup(ctx, name)
+}
+
+// registerPITRHooks registers the point-in-time recovery archiving app hooks.
+func (app *BaseApp) registerPITRHooks() {
+	var mu sync.Mutex
+	var serving bool
+	var stop chan struct{}
+
+	stopArchiver := func() {
+		if stop != nil {
+			close(stop)
+			stop = nil
+		}
+	}
+
+	loadArchiver := func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		stopArchiver()
+
+		config := app.Settings().Backups.PITR
+		if !serving || !config.Enabled {
+			return
+		}
+
+		stop = make(chan struct{})
+
+		go func(stop chan struct{}, interval time.Duration) {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					if _, err := app.ArchiveWALSegment(context.Background()); err != nil {
+						app.Logger().Error(
+							"[PITR] Failed to archive WAL segment",
+							slog.String("error", err.Error()),
+						)
+					}
+				}
+			}
+		}(stop, config.interval())
+	}
+
+	app.OnServe().BindFunc(func(e *ServeEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		serving = true
+		mu.Unlock()
+
+		loadArchiver()
+
+		return nil
+	})
+
+	app.OnSettingsReload().BindFunc(func(e *SettingsReloadEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadArchiver()
+
+		return nil
+	})
+
+	app.OnTerminate().BindFunc(func(e *TerminateEvent) error {
+		mu.Lock()
+		serving = false
+		stopArchiver()
+		mu.Unlock()
+
+		return e.Next()
+	})
+
+	// rebase the next segment on the newly created backup
+	app.OnBackupCreate().BindFunc(func(e *BackupEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		e.App.Store().Remove(storeKeyPITRBase)
+
+		return nil
+	})
+}
+
+// latestPITRBase returns the most recent backup (or segment)
+// with a readable manifest that contains the main database file.
+func latestPITRBase(app App, fsys *filesystem.System, tempDir string) (*pitrBase, error) {
+	files, err := fsys.List("")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range toPITRBackupFiles(files) {
+		p, cleanup, err := localBackupPath(app, fsys, f.name, tempDir)
+		if err != nil {
+			continue
+		}
+
+		manifest, err := archive.ReadManifest(p)
+		cleanup()
+		if err != nil || manifest.Files["data.db"] == nil {
+			continue
+		}
+
+		return &pitrBase{name: f.name, manifest: manifest}, nil
+	}
+
+	return nil, errors.New("missing base backup - create at least one backup before archiving WAL segments")
+}
+
+// pointInTimeBackup returns the name of the most recent
+// backup (or segment) created before or at t.
+func pointInTimeBackup(fsys *filesystem.System, t time.Time) (string, error) {
+	files, err := fsys.List("")
+	if err != nil {
+		return "", err
+	}
+
+	var match *pitrBackupFile
+	for _, f := range toPITRBackupFiles(files) {
+		if f.time.After(t) {
+			continue
+		}
+
+		if match == nil || f.time.After(match.time) {
+			match = f
+		}
+	}
+
+	if match == nil {
+		return "", fmt.Errorf("no backup or WAL segment found before %s", t.UTC().Format(time.RFC3339))
+	}
+
+	return match.name, nil
+}
+
+type pitrBackupFile struct {
+	name string
+	time time.Time
+}
+
+// toPITRBackupFiles converts the listed backups filesystem objects
+// into a list of backup files sorted by their creation time (newest first).
+//
+// The segments time is resolved from their name and for the other backups
+// their modification date is used.
+func toPITRBackupFiles(objects []*blob.ListObject) []*pitrBackupFile {
+	result := make([]*pitrBackupFile, 0, len(objects))
+
+	for _, obj := range objects {
+		f := &pitrBackupFile{name: obj.Key, time: obj.ModTime}
+
+		if strings.HasPrefix(obj.Key, pitrSegmentPrefix) {
+			raw := strings.TrimSuffix(strings.TrimPrefix(obj.Key, pitrSegmentPrefix), ".zip")
+
+			parsed, err := time.ParseInLocation(pitrSegmentTimeLayout, raw, time.UTC)
+			if err != nil {
+				continue // not a valid segment
+			}
+
+			f.time = parsed
+		}
+
+		result = append(result, f)
+	}
+
+	slices.SortStableFunc(result, func(a, b *pitrBackupFile) int {
+		return b.time.Compare(a.time)
+	})
+
+	return result
+}
+
+// pitrChain describes a chain of point-in-time recovery segments
+// that are created on top of a single base backup.
+type pitrChain struct {
+	// base is the name of the chain base backup
+	// (empty if there is no older regular backup).
+	base string
+
+	// segments are the chain segments sorted by their creation time (newest first).
+	segments []*pitrBackupFile
+}
+
+// pitrChains groups the listed backups filesystem segments
+// by their base backup (the chains are sorted oldest first).
+//
+// Because each new backup rebases the next archived segment, the base of
+// a segment is resolved to the most recent regular backup created before it
+// (the segment manifests are not read to avoid downloading the files).
+func pitrChains(objects []*blob.ListObject) []*pitrChain {
+	files := toPITRBackupFiles(objects)
+
+	var chains []*pitrChain
+	var current *pitrChain
+	var lastBase string
+
+	for i := len(files) - 1; i >= 0; i-- {
+		f := files[i]
+
+		if !strings.HasPrefix(f.name, pitrSegmentPrefix) {
+			lastBase = f.name
+			current = nil
+			continue
+		}
+
+		if current == nil {
+			current = &pitrChain{base: lastBase}
+			chains = append(chains, current)
+		}
+
+		current.segments = slices.Insert(current.segments, 0, f)
+	}
+
+	return chains
+}
+
+// prunePITRSegments removes the point-in-time recovery segment chains
+// whose newest segment is older than maxDays.
+//
+// The segments are always removed per chain because a segment can be
+// restored only together with all older segments from its chain.
+// The most recent chain is never removed since the next segment could be based on it.
+func prunePITRSegments(fsys *filesystem.System, maxDays int, now time.Time) error {
+	files, err := fsys.List("")
+	if err != nil {
+		return err
+	}
+
+	chains := pitrChains(files)
+	if len(chains) <= 1 {
+		return nil
+	}
+
+	cutoff := now.AddDate(0, 0, -maxDays)
+
+	var errs []error
+
+	for _, chain := range chains[:len(chains)-1] {
+		if !chain.segments[0].time.Before(cutoff) {
+			continue
+		}
+
+		for _, segment := range chain.segments {
+			if err := fsys.Delete(segment.name); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete segment %q: %w", segment.name, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// pitrDataChanged reports whether the main database file state
+// described in the segment manifest differs from the base one.
+func pitrDataChanged(base *archive.Manifest, segment *archive.Manifest) bool {
+	old := base.Files["data.db"]
+	current := segment.Files["data.db"]
+
+	if old == nil || current == nil {
+		return true
+	}
+
+	return old.Size != current.Size || !slices.Equal(old.Blocks, current.Blocks)
+}
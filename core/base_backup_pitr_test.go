@@ -0,0 +1,255 @@
+package core_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/archive"
+)
+
+func TestArchiveWALSegment(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	ctx := context.Background()
+
+	// no base backup
+	if _, err := app.ArchiveWALSegment(ctx); err == nil {
+		t.Fatal("Expected missing base backup error, got nil")
+	}
+
+	if err := app.CreateBackup(ctx, "base.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	// no changes
+	name, err := app.ArchiveWALSegment(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "" {
+		t.Fatalf("Expected no segment to be created, got %q", name)
+	}
+
+	deleteFirstTestRecord(t, app, "demo1")
+
+	segment1, err := app.ArchiveWALSegment(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSegmentBase(t, app, segment1, "base.zip")
+
+	deleteFirstTestRecord(t, app, "demo1")
+
+	segment2, err := app.ArchiveWALSegment(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSegmentBase(t, app, segment2, segment1)
+
+	// the segments should be restorable on top of the base backup
+	for name, expected := range map[string]int64{"base.zip": 3, segment1: 2, segment2: 1} {
+		result, err := app.VerifyBackup(ctx, name)
+		if err != nil {
+			t.Fatalf("[%s] %v", name, err)
+		}
+
+		if total := result.Collections["demo1"]; total != expected {
+			t.Fatalf("[%s] Expected %d demo1 records, got %d", name, expected, total)
+		}
+	}
+
+	// rebase on new backup
+	if err := app.CreateBackup(ctx, "base2.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	deleteFirstTestRecord(t, app, "demo1")
+
+	segment3, err := app.ArchiveWALSegment(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSegmentBase(t, app, segment3, "base2.zip")
+}
+
+func TestArchiveWALSegmentActiveBackup(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	ctx := context.Background()
+
+	if err := app.CreateBackup(ctx, "base.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	deleteFirstTestRecord(t, app, "demo1")
+
+	app.Store().Set(core.StoreKeyActiveBackup, "test.zip")
+
+	if _, err := app.ArchiveWALSegment(ctx); err == nil {
+		t.Fatal("Expected active backup error, got nil")
+	}
+
+	app.Store().Remove(core.StoreKeyActiveBackup)
+
+	name, err := app.ArchiveWALSegment(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name == "" {
+		t.Fatal("Expected new segment to be created")
+	}
+
+	// the active backup mark should be removed after the archiving
+	if app.Store().Has(core.StoreKeyActiveBackup) {
+		t.Fatal("Expected the active backup store key to be removed")
+	}
+}
+
+func TestArchiveWALSegmentPrune(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	ctx := context.Background()
+
+	now := time.Now()
+
+	segmentName := func(t time.Time) string {
+		return "@pitr_" + t.UTC().Format("20060102150405.000") + ".zip"
+	}
+
+	oldChain := []string{segmentName(now.AddDate(0, 0, -19)), segmentName(now.AddDate(0, 0, -18))}
+	recentChain := []string{segmentName(now.AddDate(0, 0, -9))}
+
+	createTestBackupFiles(t, app, map[string]time.Time{
+		"@auto_pb_backup_1.zip": now.AddDate(0, 0, -20),
+		oldChain[0]:             now.AddDate(0, 0, -19),
+		oldChain[1]:             now.AddDate(0, 0, -18),
+		"@auto_pb_backup_2.zip": now.AddDate(0, 0, -10),
+		recentChain[0]:          now.AddDate(0, 0, -9),
+	})
+
+	app.Settings().Backups.PITR.MaxDays = 10
+
+	if err := app.CreateBackup(ctx, "base.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	deleteFirstTestRecord(t, app, "demo1")
+
+	segment, err := app.ArchiveWALSegment(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSegmentBase(t, app, segment, "base.zip")
+
+	assertBackupFilesExist(t, app, oldChain, false)
+	assertBackupFilesExist(t, app, append(recentChain, segment, "@auto_pb_backup_1.zip", "@auto_pb_backup_2.zip"), true)
+
+	// with the default max days
+	app.Settings().Backups.PITR.MaxDays = 0
+
+	deleteFirstTestRecord(t, app, "demo1")
+
+	if _, err := app.ArchiveWALSegment(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	assertBackupFilesExist(t, app, recentChain, false)
+	assertBackupFilesExist(t, app, []string{segment}, true)
+}
+
+func TestRestorePointInTime(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	ctx := context.Background()
+
+	// skip the process restart
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		if e.IsRestart {
+			return nil
+		}
+		return e.Next()
+	})
+
+	beforeBackup := time.Now().Add(-1 * time.Second)
+
+	if err := app.CreateBackup(ctx, "base.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	deleteFirstTestRecord(t, app, "demo1")
+
+	if _, err := app.ArchiveWALSegment(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	afterSegment1 := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	deleteFirstTestRecord(t, app, "demo1")
+
+	if _, err := app.ArchiveWALSegment(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.RestorePointInTime(ctx, beforeBackup); err == nil {
+		t.Fatal("Expected error for time before the first backup, got nil")
+	}
+
+	if err := app.RestorePointInTime(ctx, afterSegment1); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := core.DefaultDBConnect(filepath.Join(app.DataDir(), "data.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var total int
+	if err := db.Select("count(*)").From("demo1").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 2 {
+		t.Fatalf("Expected 2 restored demo1 records, got %d", total)
+	}
+}
+
+func deleteFirstTestRecord(t *testing.T, app core.App, collection string) {
+	records, err := app.FindAllRecords(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) == 0 {
+		t.Fatalf("Missing %q records", collection)
+	}
+
+	if err := app.Delete(records[0]); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertSegmentBase(t *testing.T, app core.App, segment string, expectedBase string) {
+	if segment == "" {
+		t.Fatal("Expected new segment to be created")
+	}
+
+	manifest, err := archive.ReadManifest(filepath.Join(app.DataDir(), core.LocalBackupsDirName, segment))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if manifest.Base != expectedBase {
+		t.Fatalf("Expected segment %q base %q, got %q", segment, expectedBase, manifest.Base)
+	}
+}
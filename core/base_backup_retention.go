@@ -0,0 +1,229 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/filesystem/blob"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// The supported BackupRetentionItem.Reasons values.
+const (
+	BackupRetentionLast    = "last"
+	BackupRetentionDaily   = "daily"
+	BackupRetentionWeekly  = "weekly"
+	BackupRetentionMonthly = "monthly"
+	BackupRetentionChain   = "chain"
+	BackupRetentionPITR    = "pitr"
+)
+
+// BackupsRetentionReport defines the result of a backups prune operation.
+type BackupsRetentionReport struct {
+	// Keep lists the retained autogenerated backups.
+	Keep []*BackupRetentionItem `json:"keep"`
+
+	// Remove lists the removed (or to be removed in case of a dry-run) autogenerated backups.
+	Remove []*BackupRetentionItem `json:"remove"`
+
+	// DryRun indicates whether the Remove backups were actually deleted.
+	DryRun bool `json:"dryRun"`
+}
+
+// BackupRetentionItem describes a single autogenerated backup retention state.
+type BackupRetentionItem struct {
+	Modified types.DateTime `json:"modified"`
+	Key      string         `json:"key"`
+	Size     int64          `json:"size"`
+
+	// Reasons lists the retention rules that the backup matches
+	// (empty for the removed backups).
+	Reasons []string `json:"reasons"`
+}
+
+// PruneBackups removes the autogenerated backups that are no longer
+// needed based on the app.Settings().Backups CronMaxKeep and Retention policy.
+//
+// The retained backups are:
+//   - the most recent CronMaxKeep ones ("last")
+//   - the newest backup of each of the most recent Retention.KeepDaily days,
+//     Retention.KeepWeekly ISO weeks and Retention.KeepMonthly months
+//     ("daily", "weekly", "monthly")
+//   - the older backups that are part of the base chain of a retained
+//     incremental or differential backup ("chain")
+//   - the base backups of the existing point-in-time recovery segments
+//     together with their own base chains ("pitr")
+//
+// The periods are resolved from the backups modification date (in UTC).
+//
+// If neither CronMaxKeep nor Retention is configured, all backups are retained.
+// The manually created backups are never removed.
+//
+// If dryRun is true, no backups are actually removed and only the report is returned.
+func (app *BaseApp) PruneBackups(ctx context.Context, dryRun bool) (*BackupsRetentionReport, error) {
+	fsys, err := app.NewBackupsFilesystem()
+	if err != nil {
+		return nil, err
+	}
+	defer fsys.Close()
+
+	fsys.SetContext(ctx)
+
+	files, err := listAutoBackups(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list autogenerated backups: %w", err)
+	}
+
+	// the PITR segments can be restored only on top of their base backup
+	allFiles, err := fsys.List("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the backups: %w", err)
+	}
+
+	var pitrBases []string
+	for _, chain := range pitrChains(allFiles) {
+		if chain.base != "" {
+			pitrBases = append(pitrBases, chain.base)
+		}
+	}
+
+	config := app.Settings().Backups
+
+	retained := autoBackupsRetention(files, config.CronMaxKeep, config.Retention, pitrBases)
+
+	report := &BackupsRetentionReport{
+		Keep:   []*BackupRetentionItem{},
+		Remove: []*BackupRetentionItem{},
+		DryRun: dryRun,
+	}
+
+	activeBackup := cast.ToString(app.Store().Get(StoreKeyActiveBackup))
+
+	var errs []error
+
+	for _, f := range files {
+		modified, _ := types.ParseDateTime(f.ModTime)
+
+		item := &BackupRetentionItem{
+			Key:      f.Key,
+			Size:     f.Size,
+			Modified: modified,
+			Reasons:  retained[f.Key],
+		}
+
+		if len(item.Reasons) > 0 || f.Key == activeBackup {
+			if item.Reasons == nil {
+				item.Reasons = []string{}
+			}
+			report.Keep = append(report.Keep, item)
+			continue
+		}
+
+		item.Reasons = []string{}
+
+		if !dryRun {
+			if err := fsys.Delete(f.Key); err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove %q: %w", f.Key, err))
+				report.Keep = append(report.Keep, item)
+				continue
+			}
+		}
+
+		report.Remove = append(report.Remove, item)
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// autoBackupsRetention returns the retention reasons of the autogenerated
+// backup files that should be kept based on the specified policy
+// (the files that are not in the returned map could be removed).
+//
+// pitrBases lists the base backups of the existing PITR segments
+// that are always kept (including their base chains).
+//
+// Returns a map with all files if neither keepLast nor retention is configured.
+//
+// files are expected to be sorted desc by their modification date.
+func autoBackupsRetention(files []*blob.ListObject, keepLast int, retention BackupsRetentionConfig, pitrBases []string) map[string][]string {
+	result := make(map[string][]string, len(files))
+
+	if keepLast <= 0 && !retention.IsEnabled() {
+		for _, f := range files {
+			result[f.Key] = []string{BackupRetentionLast}
+		}
+		return result
+	}
+
+	for i, f := range files {
+		if i >= keepLast {
+			break
+		}
+		result[f.Key] = append(result[f.Key], BackupRetentionLast)
+	}
+
+	periods := []struct {
+		reason string
+		limit  int
+		key    func(f *blob.ListObject) string
+	}{
+		{BackupRetentionDaily, retention.KeepDaily, func(f *blob.ListObject) string {
+			return f.ModTime.UTC().Format("2006-01-02")
+		}},
+		{BackupRetentionWeekly, retention.KeepWeekly, func(f *blob.ListObject) string {
+			year, week := f.ModTime.UTC().ISOWeek()
+			return fmt.Sprintf("%d-%d", year, week)
+		}},
+		{BackupRetentionMonthly, retention.KeepMonthly, func(f *blob.ListObject) string {
+			return f.ModTime.UTC().Format("2006-01")
+		}},
+	}
+
+	for _, period := range periods {
+		seen := map[string]struct{}{}
+
+		for _, f := range files {
+			if len(seen) >= period.limit {
+				break
+			}
+
+			key := period.key(f)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			result[f.Key] = append(result[f.Key], period.reason)
+		}
+	}
+
+	for _, f := range files {
+		if slices.Contains(pitrBases, f.Key) {
+			result[f.Key] = append(result[f.Key], BackupRetentionPITR)
+		}
+	}
+
+	// keep the base chains of the retained incremental backups
+	// (all older backups up to and including the first full one)
+	for i := 0; i < len(files); i++ {
+		if _, ok := result[files[i].Key]; !ok || !strings.HasPrefix(files[i].Key, autoDeltaBackupPrefix) {
+			continue
+		}
+
+		for j := i + 1; j < len(files); j++ {
+			if _, ok := result[files[j].Key]; !ok {
+				result[files[j].Key] = []string{BackupRetentionChain}
+			}
+
+			if !strings.HasPrefix(files[j].Key, autoDeltaBackupPrefix) {
+				break
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,164 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestPruneBackups(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now()
+
+	createTestBackupFiles(t, app, map[string]time.Time{
+		"@auto_pb_backup_4.zip": now,
+		"@auto_pb_backup_3.zip": now.AddDate(0, 0, -1),
+		"@auto_pb_backup_2.zip": now.AddDate(0, 0, -2),
+		"@auto_pb_backup_1.zip": now.AddDate(0, 0, -3),
+		"manual.zip":            now.AddDate(0, 0, -10),
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		report, err := app.PruneBackups(context.Background(), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertRetentionReportKeys(t, report.Keep, []string{
+			"@auto_pb_backup_4.zip",
+			"@auto_pb_backup_3.zip",
+			"@auto_pb_backup_2.zip",
+			"@auto_pb_backup_1.zip",
+		})
+		assertRetentionReportKeys(t, report.Remove, []string{})
+	})
+
+	app.Settings().Backups.CronMaxKeep = 1
+	app.Settings().Backups.Retention.KeepDaily = 2
+
+	t.Run("dry-run", func(t *testing.T) {
+		report, err := app.PruneBackups(context.Background(), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !report.DryRun {
+			t.Fatal("Expected dry-run report")
+		}
+
+		assertRetentionReportKeys(t, report.Keep, []string{"@auto_pb_backup_4.zip", "@auto_pb_backup_3.zip"})
+		assertRetentionReportKeys(t, report.Remove, []string{"@auto_pb_backup_2.zip", "@auto_pb_backup_1.zip"})
+
+		if !slices.Equal(report.Keep[0].Reasons, []string{core.BackupRetentionLast, core.BackupRetentionDaily}) {
+			t.Fatalf("Unexpected %q reasons %v", report.Keep[0].Key, report.Keep[0].Reasons)
+		}
+
+		// nothing should be deleted
+		assertBackupFilesExist(t, app, []string{"@auto_pb_backup_2.zip", "@auto_pb_backup_1.zip"}, true)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		// simulate active backup
+		app.Store().Set(core.StoreKeyActiveBackup, "@auto_pb_backup_1.zip")
+		defer app.Store().Remove(core.StoreKeyActiveBackup)
+
+		report, err := app.PruneBackups(context.Background(), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if report.DryRun {
+			t.Fatal("Expected non dry-run report")
+		}
+
+		assertRetentionReportKeys(t, report.Keep, []string{
+			"@auto_pb_backup_4.zip",
+			"@auto_pb_backup_3.zip",
+			"@auto_pb_backup_1.zip",
+		})
+		assertRetentionReportKeys(t, report.Remove, []string{"@auto_pb_backup_2.zip"})
+
+		assertBackupFilesExist(t, app, []string{"@auto_pb_backup_2.zip"}, false)
+		assertBackupFilesExist(t, app, []string{"@auto_pb_backup_4.zip", "@auto_pb_backup_3.zip", "@auto_pb_backup_1.zip", "manual.zip"}, true)
+	})
+}
+
+func TestPruneBackupsPITR(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now()
+
+	segment := "@pitr_" + now.AddDate(0, 0, -2).UTC().Format("20060102150405.000") + ".zip"
+
+	createTestBackupFiles(t, app, map[string]time.Time{
+		"@auto_pb_backup_3.zip": now,
+		"@auto_pb_backup_2.zip": now.AddDate(0, 0, -1),
+		segment:                 now.AddDate(0, 0, -2),
+		"@auto_pb_backup_1.zip": now.AddDate(0, 0, -3),
+	})
+
+	app.Settings().Backups.CronMaxKeep = 1
+
+	report, err := app.PruneBackups(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertRetentionReportKeys(t, report.Keep, []string{"@auto_pb_backup_3.zip", "@auto_pb_backup_1.zip"})
+	assertRetentionReportKeys(t, report.Remove, []string{"@auto_pb_backup_2.zip"})
+
+	if !slices.Equal(report.Keep[1].Reasons, []string{core.BackupRetentionPITR}) {
+		t.Fatalf("Unexpected %q reasons %v", report.Keep[1].Key, report.Keep[1].Reasons)
+	}
+
+	assertBackupFilesExist(t, app, []string{segment, "@auto_pb_backup_1.zip"}, true)
+}
+
+func createTestBackupFiles(t *testing.T, app core.App, files map[string]time.Time) {
+	dir := filepath.Join(app.DataDir(), core.LocalBackupsDirName)
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, modified := range files {
+		p := filepath.Join(dir, name)
+
+		if err := os.WriteFile(p, []byte("test"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(p, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func assertRetentionReportKeys(t *testing.T, items []*core.BackupRetentionItem, expected []string) {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = item.Key
+	}
+
+	if !slices.Equal(keys, expected) {
+		t.Fatalf("Expected keys %v, got %v", expected, keys)
+	}
+}
+
+func assertBackupFilesExist(t *testing.T, app core.App, names []string, exist bool) {
+	for _, name := range names {
+		_, err := os.Stat(filepath.Join(app.DataDir(), core.LocalBackupsDirName, name))
+		if exists := err == nil; exists != exist {
+			t.Fatalf("Expected %q exists %v, got %v", name, exist, exists)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package core_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/age"
 	"github.com/pocketbase/pocketbase/tools/archive"
 	"github.com/pocketbase/pocketbase/tools/list"
 )
@@ -110,8 +113,256 @@ func TestRestoreBackup(t *testing.T) {
 	}
 }
 
+func TestCreateIncrementalBackup(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := app.CreateBackup(context.Background(), "full.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	// missing base
+	if err := app.CreateIncrementalBackup(context.Background(), "test.zip", ""); err == nil {
+		t.Fatal("Expected error for empty base, got nil")
+	}
+
+	// nonexisting base
+	if err := app.CreateIncrementalBackup(context.Background(), "test.zip", "missing.zip"); err == nil {
+		t.Fatal("Expected error for missing base, got nil")
+	}
+
+	// change the data so that there is something to store
+	app.Settings().Meta.AppName = "test_incremental"
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.CreateIncrementalBackup(context.Background(), "", "full.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	backupsDir := filepath.Join(app.DataDir(), core.LocalBackupsDirName)
+
+	entries, err := os.ReadDir(backupsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var deltaName string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "pb_delta_") && !strings.HasSuffix(entry.Name(), ".attrs") {
+			deltaName = entry.Name()
+			break
+		}
+	}
+	if deltaName == "" {
+		t.Fatalf("Expected a pb_delta_ backup file, got %v", getEntryNames(entries))
+	}
+
+	manifest, err := archive.ReadManifest(filepath.Join(backupsDir, deltaName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if manifest.Base != "full.zip" {
+		t.Fatalf("Expected base full.zip, got %q", manifest.Base)
+	}
+}
+
+func TestExtractBackup(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := app.CreateBackup(context.Background(), "full.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().Meta.AppName = "test_inc1"
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.CreateIncrementalBackup(context.Background(), "inc1.zip", "full.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().Meta.AppName = "test_inc2"
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.CreateIncrementalBackup(context.Background(), "inc2.zip", "inc1.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	// full backup of the latest state to compare with
+	if err := app.CreateBackup(context.Background(), "full2.zip"); err != nil {
+		t.Fatal(err)
+	}
+	expectedDir := t.TempDir()
+	if err := archive.Extract(filepath.Join(app.DataDir(), core.LocalBackupsDirName, "full2.zip"), expectedDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// missing backup
+	if err := app.ExtractBackup(context.Background(), "missing.zip", t.TempDir()); err == nil {
+		t.Fatal("Expected error for missing backup, got nil")
+	}
+
+	for _, name := range []string{"full.zip", "inc1.zip", "inc2.zip"} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			if err := app.ExtractBackup(context.Background(), name, dir); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, file := range []string{"data.db", "auxiliary.db"} {
+				if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+					t.Fatalf("Expected %q to be extracted: %v", file, err)
+				}
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, archive.ManifestName)); err == nil {
+				t.Fatal("Expected the backup manifest to be removed")
+			}
+		})
+	}
+
+	// the end of the chain should match the latest full backup
+	dir := t.TempDir()
+	if err := app.ExtractBackup(context.Background(), "inc2.zip", dir); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{"data.db", "data.db-wal", "auxiliary.db"} {
+		expected, err := os.ReadFile(filepath.Join(expectedDir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		extracted, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(expected, extracted) {
+			t.Fatalf("Expected the extracted %q to match the latest full backup one", file)
+		}
+	}
+}
+
+func TestCreateBackupEncrypted(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	backupsDir := filepath.Join(app.DataDir(), core.LocalBackupsDirName)
+
+	app.Settings().Backups.Encryption.Enabled = true
+
+	t.Run("missing recipient and env key", func(t *testing.T) {
+		t.Setenv(app.EncryptionEnv(), "")
+
+		if err := app.CreateBackup(context.Background(), "test_missing.zip"); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+
+	t.Run("env key", func(t *testing.T) {
+		t.Setenv(app.EncryptionEnv(), strings.Repeat("a", 32))
+
+		if err := app.CreateBackup(context.Background(), "test_env.zip"); err != nil {
+			t.Fatal(err)
+		}
+
+		path := filepath.Join(backupsDir, "test_env.zip")
+
+		if !isAgeFile(t, path) {
+			t.Fatal("Expected the backup to be encrypted")
+		}
+
+		// the encrypted backup could be used as incremental base
+		if err := app.CreateIncrementalBackup(context.Background(), "test_env_inc.zip", "test_env.zip"); err != nil {
+			t.Fatal(err)
+		}
+
+		dir := t.TempDir()
+		if err := app.ExtractBackup(context.Background(), "test_env_inc.zip", dir); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "data.db")); err != nil {
+			t.Fatalf("Expected data.db to be extracted: %v", err)
+		}
+
+		// different env key
+		t.Setenv(app.EncryptionEnv(), strings.Repeat("b", 32))
+		if err := app.ExtractBackup(context.Background(), "test_env.zip", t.TempDir()); err == nil {
+			t.Fatal("Expected decryption error with different env key, got nil")
+		}
+	})
+
+	t.Run("recipient only", func(t *testing.T) {
+		t.Setenv(app.EncryptionEnv(), "")
+
+		identity, err := age.GenerateX25519Identity()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		app.Settings().Backups.Encryption.Recipient = identity.Recipient().String()
+
+		if err := app.CreateBackup(context.Background(), "test_recipient.zip"); err != nil {
+			t.Fatal(err)
+		}
+
+		path := filepath.Join(backupsDir, "test_recipient.zip")
+
+		if !isAgeFile(t, path) {
+			t.Fatal("Expected the backup to be encrypted")
+		}
+
+		// the app shouldn't be able to read the backup
+		if err := app.ExtractBackup(context.Background(), "test_recipient.zip", t.TempDir()); err == nil {
+			t.Fatal("Expected extract error, got nil")
+		}
+
+		// manually decrypt with the recipient identity
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		r, err := age.Decrypt(f, identity)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decryptedPath := filepath.Join(t.TempDir(), "decrypted.zip")
+		decrypted, err := os.Create(decryptedPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer decrypted.Close()
+
+		if _, err := io.Copy(decrypted, r); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := verifyBackupContent(app, decryptedPath); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
 // -------------------------------------------------------------------
 
+func isAgeFile(t *testing.T, path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return age.IsEncrypted(data)
+}
+
 func verifyBackupContent(app core.App, path string) error {
 	dir, err := os.MkdirTemp("", "backup_test")
 	if err != nil {
@@ -132,6 +383,7 @@ func verifyBackupContent(app core.App, path string) error {
 		"auxiliary.db-shm",
 		"auxiliary.db-wal",
 		".gitignore",
+		archive.ManifestName,
 	}
 
 	entries, err := os.ReadDir(dir)
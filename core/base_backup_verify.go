@@ -0,0 +1,181 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// StoreKeyLastBackupVerification is the app store key holding
+// the result of the last backup verification (see [BaseApp.VerifyBackup]).
+const StoreKeyLastBackupVerification = "@lastBackupVerification"
+
+// BackupVerification defines the result of a single backup verification.
+type BackupVerification struct {
+	// Name is the name of the verified backup.
+	Name string `json:"name"`
+
+	// Error is the verification failure reason (empty if the backup is valid).
+	Error string `json:"error"`
+
+	// Collections contains the number of records per collection
+	// (excluding views) found in the backup data.db.
+	Collections map[string]int64 `json:"collections"`
+
+	// Duration is the total verification time.
+	Duration time.Duration `json:"duration"`
+
+	// Created is the verification date.
+	Created types.DateTime `json:"created"`
+}
+
+// Valid reports whether the backup verification has passed.
+func (v *BackupVerification) Valid() bool {
+	return v.Error == ""
+}
+
+// VerifyBackup verifies that the backup with the specified name could be restored.
+//
+// The performed steps are:
+//
+//  1. Extract the backup in a temp directory inside the app "pb_data"
+//     (see [BaseApp.ExtractBackup]).
+//
+//  2. Run "PRAGMA integrity_check" on the extracted data.db and auxiliary.db (if any).
+//
+//  3. Count the records of each non-view collection stored in the extracted data.db.
+//
+// The result is logged and stored in the app store under [StoreKeyLastBackupVerification]
+// (it is also available to the superusers as part of the health API response).
+//
+// The returned error is non-nil if the verification has failed
+// (in this case the result Error field holds the same message).
+func (app *BaseApp) VerifyBackup(ctx context.Context, name string) (*BackupVerification, error) {
+	start := time.Now()
+
+	result := &BackupVerification{
+		Name:        name,
+		Collections: map[string]int64{},
+		Created:     types.NowDateTime(),
+	}
+
+	err := app.verifyBackup(ctx, result)
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	result.Duration = time.Since(start)
+
+	app.Store().Set(StoreKeyLastBackupVerification, result)
+
+	attrs := []any{
+		slog.String("type", "backup_verification"),
+		slog.String("name", result.Name),
+		slog.Any("collections", result.Collections),
+		slog.Duration("duration", result.Duration),
+	}
+	if err != nil {
+		app.Logger().Error("Backup verification failed", append(attrs, slog.String("error", result.Error))...)
+	} else {
+		app.Logger().Info("Backup verification succeeded", attrs...)
+	}
+
+	return result, err
+}
+
+func (app *BaseApp) verifyBackup(ctx context.Context, result *BackupVerification) error {
+	// make sure that the special temp directory exists
+	localTempDir := filepath.Join(app.DataDir(), LocalTempDirName)
+	if err := os.MkdirAll(localTempDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create a temp dir: %w", err)
+	}
+
+	extractedDataDir := filepath.Join(localTempDir, "pb_verify_"+security.PseudorandomString(8))
+	defer os.RemoveAll(extractedDataDir)
+
+	if err := app.ExtractBackup(ctx, result.Name, extractedDataDir); err != nil {
+		return fmt.Errorf("failed to extract the backup: %w", err)
+	}
+
+	dataDBPath := filepath.Join(extractedDataDir, "data.db")
+	if _, err := os.Stat(dataDBPath); err != nil {
+		return fmt.Errorf("data.db file is missing or invalid: %w", err)
+	}
+
+	dataDB, err := app.config.DBConnect(dataDBPath)
+	if err != nil {
+		return fmt.Errorf("failed to open data.db: %w", err)
+	}
+	defer dataDB.Close()
+
+	if err := checkDBIntegrity(dataDB); err != nil {
+		return fmt.Errorf("data.db integrity check failed: %w", err)
+	}
+
+	auxDBPath := filepath.Join(extractedDataDir, "auxiliary.db")
+	if _, err := os.Stat(auxDBPath); err == nil {
+		auxDB, err := app.config.DBConnect(auxDBPath)
+		if err != nil {
+			return fmt.Errorf("failed to open auxiliary.db: %w", err)
+		}
+		defer auxDB.Close()
+
+		if err := checkDBIntegrity(auxDB); err != nil {
+			return fmt.Errorf("auxiliary.db integrity check failed: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	// count the collections records
+	// ---
+	collections := []*backupCollectionRow{}
+	err = dataDB.Select("id", "name", "type").
+		From((&Collection{}).TableName()).
+		Where(dbx.NewExp("[[type]] != {:view}", dbx.Params{"view": CollectionTypeView})).
+		All(&collections)
+	if err != nil {
+		return fmt.Errorf("failed to load the backup collections: %w", err)
+	}
+
+	for _, c := range collections {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var total int64
+		err := dataDB.Select("count(*)").From(c.Name).Row(&total)
+		if err != nil {
+			return fmt.Errorf("failed to count %q records: %w", c.Name, err)
+		}
+
+		result.Collections[c.Name] = total
+	}
+
+	return nil
+}
+
+func checkDBIntegrity(db dbx.Builder) error {
+	messages := []string{}
+
+	err := db.NewQuery("PRAGMA integrity_check").Column(&messages)
+	if err != nil {
+		return err
+	}
+
+	if len(messages) != 1 || messages[0] != "ok" {
+		return errors.New(strings.Join(messages, "; "))
+	}
+
+	return nil
+}
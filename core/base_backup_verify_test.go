@@ -0,0 +1,92 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestVerifyBackup(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Logs.MaxDays = 1
+
+	if err := app.CreateBackup(context.Background(), "test.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("missing backup", func(t *testing.T) {
+		result, err := app.VerifyBackup(context.Background(), "missing.zip")
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+
+		if result.Valid() || result.Error != err.Error() {
+			t.Fatalf("Expected invalid result with error %q, got %q", err.Error(), result.Error)
+		}
+
+		stored, _ := app.Store().Get(core.StoreKeyLastBackupVerification).(*core.BackupVerification)
+		if stored != result {
+			t.Fatal("Expected the verification result to be stored")
+		}
+	})
+
+	t.Run("corrupted backup", func(t *testing.T) {
+		path := filepath.Join(app.DataDir(), core.LocalBackupsDirName, "corrupted.zip")
+		if err := os.WriteFile(path, []byte("test"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := app.VerifyBackup(context.Background(), "corrupted.zip"); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+
+	t.Run("valid backup", func(t *testing.T) {
+		result, err := app.VerifyBackup(context.Background(), "test.zip")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !result.Valid() {
+			t.Fatalf("Expected valid result, got %q", result.Error)
+		}
+
+		expectedTotal, err := app.CountRecords("demo1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if total, ok := result.Collections["demo1"]; !ok || total != expectedTotal {
+			t.Fatalf("Expected %d demo1 records, got %d", expectedTotal, total)
+		}
+
+		if _, ok := result.Collections["view1"]; ok {
+			t.Fatal("Expected the view collections to be skipped")
+		}
+
+		// check the stored verification log
+		if err := app.FlushLogs(); err != nil {
+			t.Fatal(err)
+		}
+
+		log := &core.Log{}
+		err = app.LogQuery().
+			AndWhere(dbx.HashExp{"message": "Backup verification succeeded"}).
+			OrderBy("created DESC").
+			One(log)
+		if err != nil {
+			t.Fatalf("Expected backup verification log: %v", err)
+		}
+
+		if log.Data["name"] != "test.zip" {
+			t.Fatalf("Expected log data name test.zip, got %v", log.Data["name"])
+		}
+	})
+}
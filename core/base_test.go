@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"log/slog"
 	"os"
+	"reflect"
 	"slices"
 	"testing"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/logger"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/notifier"
 )
 
 func TestNewBaseApp(t *testing.T) {
@@ -205,6 +207,82 @@ func TestBaseAppNewMailClient(t *testing.T) {
 	if m2.OnSend() == nil || m2.OnSend().Length() == 0 {
 		t.Fatal("Expected OnSend hook to be registered")
 	}
+
+	providers := []struct {
+		name     string
+		expected any
+	}{
+		{core.MailerProviderSES, &mailer.SESClient{}},
+		{core.MailerProviderSendGrid, &mailer.SendGridClient{}},
+		{core.MailerProviderMailgun, &mailer.MailgunClient{}},
+		{core.MailerProviderResend, &mailer.ResendClient{}},
+	}
+
+	for _, p := range providers {
+		t.Run(p.name, func(t *testing.T) {
+			app.Settings().Mailer.Provider = p.name
+
+			client := app.NewMailClient()
+
+			if reflect.TypeOf(client) != reflect.TypeOf(p.expected) {
+				t.Fatalf("Expected %T instance, got %T", p.expected, client)
+			}
+
+			interceptor, ok := client.(mailer.SendInterceptor)
+			if !ok || interceptor.OnSend().Length() == 0 {
+				t.Fatal("Expected OnSend hook to be registered")
+			}
+		})
+	}
+}
+
+func TestBaseAppNewSMSClient(t *testing.T) {
+	const testDataDir = "./pb_base_app_test_data_dir/"
+	defer os.RemoveAll(testDataDir)
+
+	app := core.NewBaseApp(core.BaseAppConfig{
+		DataDir:       testDataDir,
+		EncryptionEnv: "pb_test_env",
+	})
+	defer app.ResetBootstrapState()
+
+	client1 := app.NewSMSClient()
+	n1, ok := client1.(*notifier.DisabledClient)
+	if !ok {
+		t.Fatalf("Expected notifier.DisabledClient instance, got %v", n1)
+	}
+	if n1.OnSend() == nil || n1.OnSend().Length() == 0 {
+		t.Fatal("Expected OnSend hook to be registered")
+	}
+
+	app.Settings().SMS.Enabled = true
+
+	providers := []struct {
+		name     string
+		expected any
+	}{
+		{core.SMSProviderTwilio, &notifier.TwilioClient{}},
+		{core.SMSProviderVonage, &notifier.VonageClient{}},
+		{core.SMSProviderPlivo, &notifier.PlivoClient{}},
+		{core.SMSProviderHTTP, &notifier.HTTPClient{}},
+	}
+
+	for _, p := range providers {
+		t.Run(p.name, func(t *testing.T) {
+			app.Settings().SMS.Provider = p.name
+
+			client := app.NewSMSClient()
+
+			if reflect.TypeOf(client) != reflect.TypeOf(p.expected) {
+				t.Fatalf("Expected %T instance, got %T", p.expected, client)
+			}
+
+			interceptor, ok := client.(notifier.SendInterceptor)
+			if !ok || interceptor.OnSend().Length() == 0 {
+				t.Fatal("Expected OnSend hook to be registered")
+			}
+		})
+	}
 }
 
 func TestBaseAppNewFilesystem(t *testing.T) {
@@ -401,6 +479,142 @@ func TestBaseAppRefreshSettingsLoggerMinLevelEnabled(t *testing.T) {
 	}
 }
 
+func TestBaseAppFlushLogs(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// reset
+	if err := app.DeleteOldLogs(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().Logs.MaxDays = 1
+	app.Settings().Logs.BatchSize = 5
+	app.Settings().Logs.FlushInterval = 60
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	totalLogs := func() int {
+		var total int
+		if err := app.LogQuery().Select("count(*)").Row(&total); err != nil {
+			t.Fatalf("Failed to fetch total logs: %v", err)
+		}
+		return total
+	}
+
+	for i := 0; i < 4; i++ {
+		app.Logger().Error("test")
+	}
+
+	if total := totalLogs(); total != 0 {
+		t.Fatalf("Expected no logs before the flush, got %d", total)
+	}
+
+	if err := app.FlushLogs(); err != nil {
+		t.Fatal(err)
+	}
+
+	if total := totalLogs(); total != 4 {
+		t.Fatalf("Expected %d logs after the flush, got %d", 4, total)
+	}
+
+	// should trigger the (custom) batch size write
+	for i := 0; i < 5; i++ {
+		app.Logger().Error("test")
+	}
+
+	if total := totalLogs(); total != 9 {
+		t.Fatalf("Expected %d logs after the batch write, got %d", 9, total)
+	}
+}
+
+func TestBaseAppLoggerRedaction(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// reset
+	if err := app.DeleteOldLogs(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().Logs.MaxDays = 1
+	app.Settings().Logs.Redaction = core.LogsRedactionConfig{
+		Emails:   true,
+		Fields:   []string{"password"},
+		Patterns: []string{`secret_\d+`},
+	}
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Logger().Error("test@example.com login", "password", "123456", "other", "secret_123")
+
+	if err := app.FlushLogs(); err != nil {
+		t.Fatal(err)
+	}
+
+	log := &core.Log{}
+	if err := app.LogQuery().One(log); err != nil {
+		t.Fatal(err)
+	}
+
+	if log.Message != "[REDACTED] login" {
+		t.Fatalf("Expected redacted message, got %q", log.Message)
+	}
+
+	raw, _ := log.Data.MarshalJSON()
+	expected := `{"other":"[REDACTED]","password":"[REDACTED]"}`
+	if string(raw) != expected {
+		t.Fatalf("Expected data\n%s\ngot\n%s", expected, raw)
+	}
+}
+
+func TestBaseAppLoggerExcludedLevels(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// reset
+	if err := app.DeleteOldLogs(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().Logs.MaxDays = 1
+	app.Settings().Logs.ExcludedLevels = []int{int(slog.LevelInfo), int(slog.LevelDebug)}
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Logger().Info("info_log")
+	app.Logger().Warn("warn_log")
+	app.Logger().Error("error_log")
+
+	if err := app.FlushLogs(); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := []*core.Log{}
+	if err := app.LogQuery().OrderBy("message ASC").All(&logs); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := make([]string, 0, len(logs))
+	for _, l := range logs {
+		messages = append(messages, l.Message)
+	}
+
+	expected := []string{"error_log", "warn_log"}
+	if !slices.Equal(messages, expected) {
+		t.Fatalf("Expected logs %v, got %v", expected, messages)
+	}
+}
+
 func TestBaseAppDBDualBuilder(t *testing.T) {
 	t.Parallel()
 
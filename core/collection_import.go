@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/spf13/cast"
@@ -26,6 +28,35 @@ func (app *BaseApp) ImportCollectionsByMarshaledJSON(rawSliceOfMaps []byte, dele
 	return app.ImportCollections(data, deleteMissing)
 }
 
+// errCollectionsImportDryRun is used to rollback the dry-run import transaction.
+var errCollectionsImportDryRun = errors.New("collections import dry-run")
+
+// CollectionsImportOptions defines the collections import conflict strategies.
+type CollectionsImportOptions struct {
+	// DeleteMissing deletes ALL NON-SYSTEM COLLECTIONS AND SCHEMA FIELDS
+	// that are not present in the imported configuration
+	// (this includes their related records data).
+	DeleteMissing bool `form:"deleteMissing" json:"deleteMissing"`
+
+	// MergeFields keeps the existing collection fields that are not
+	// present in the imported configuration even if DeleteMissing is set
+	// (aka. only the missing collections are deleted).
+	MergeFields bool `form:"mergeFields" json:"mergeFields"`
+
+	// SkipDeletions prevents deleting any existing collection or field,
+	// including the fields that would be otherwise replaced because of
+	// a changed type (it takes precedence over DeleteMissing).
+	SkipDeletions bool `form:"skipDeletions" json:"skipDeletions"`
+
+	// RenameOnClash renames the new imported collections whose name is
+	// already used by another existing collection (e.g. "posts" -> "posts_2")
+	// instead of failing the import.
+	RenameOnClash bool `form:"renameOnClash" json:"renameOnClash"`
+
+	// DryRun rollbacks the import transaction after computing the changes diff.
+	DryRun bool `form:"dryRun" json:"dryRun"`
+}
+
 // ImportCollections imports the provided collections data in a single transaction.
 //
 // For existing matching collections, the imported data is unmarshaled on top of the existing model.
@@ -34,9 +65,33 @@ func (app *BaseApp) ImportCollectionsByMarshaledJSON(rawSliceOfMaps []byte, dele
 // that are not present in the imported configuration, WILL BE DELETED
 // (this includes their related records data).
 func (app *BaseApp) ImportCollections(toImport []map[string]any, deleteMissing bool) error {
+	_, err := app.ImportCollectionsWithOptions(toImport, CollectionsImportOptions{DeleteMissing: deleteMissing})
+
+	return err
+}
+
+// ImportCollectionsWithOptions imports the provided collections data in a
+// single transaction using the specified conflict strategies and returns
+// the applied collections changes.
+//
+// If options.DryRun is set, the import transaction is rolled back and only the
+// changes that would have been applied are returned.
+func (app *BaseApp) ImportCollectionsWithOptions(toImport []map[string]any, options CollectionsImportOptions) ([]*CollectionSnapshotDiff, error) {
 	if len(toImport) == 0 {
 		// prevent accidentally deleting all collections
-		return errors.New("no collections to import")
+		return nil, errors.New("no collections to import")
+	}
+
+	deleteCollections := options.DeleteMissing && !options.SkipDeletions
+	deleteFields := deleteCollections && !options.MergeFields
+
+	importedIds := make(map[string]struct{}, len(toImport))
+	usedNames := map[string]struct{}{}
+	for _, data := range toImport {
+		if id := cast.ToString(data["id"]); id != "" {
+			importedIds[id] = struct{}{}
+		}
+		usedNames[strings.ToLower(cast.ToString(data["name"]))] = struct{}{}
 	}
 
 	importedCollections := make([]*Collection, len(toImport))
@@ -54,35 +109,35 @@ func (app *BaseApp) ImportCollections(toImport []map[string]any, deleteMissing b
 
 		existing, err := app.FindCollectionByNameOrId(identifier)
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
-			return err
+			return nil, err
 		}
 
 		if existing != nil {
 			// refetch for deep copy
 			imported, err = app.FindCollectionByNameOrId(existing.Id)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// ensure that the fields will be cleared
-			if data["fields"] == nil && deleteMissing {
+			if data["fields"] == nil && deleteFields {
 				data["fields"] = []map[string]any{}
 			}
 
 			rawData, err := json.Marshal(data)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// load the imported data
 			err = json.Unmarshal(rawData, imported)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// extend with the existing fields if necessary
 			for _, f := range existing.Fields {
-				if !f.GetSystem() && deleteMissing {
+				if !f.GetSystem() && deleteFields {
 					continue
 				}
 				if imported.Fields.GetById(f.GetId()) == nil {
@@ -91,6 +146,9 @@ func (app *BaseApp) ImportCollections(toImport []map[string]any, deleteMissing b
 					found := imported.Fields.GetByName(f.GetName())
 					if found != nil && found.Type() == f.Type() {
 						found.SetId(f.GetId())
+					} else if found != nil && options.SkipDeletions {
+						// keep the existing field instead of replacing its column
+						imported.Fields.RemoveByName(found.GetName())
 					}
 					imported.Fields.Add(f)
 				}
@@ -100,13 +158,28 @@ func (app *BaseApp) ImportCollections(toImport []map[string]any, deleteMissing b
 
 			rawData, err := json.Marshal(data)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// load the imported data
 			err = json.Unmarshal(rawData, imported)
 			if err != nil {
-				return err
+				return nil, err
+			}
+
+			if options.RenameOnClash {
+				clashing, err := app.FindCollectionByNameOrId(imported.Name)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return nil, err
+				}
+
+				// rename only if the clashing collection is not going to be deleted
+				if clashing != nil && clashing.Id != imported.Id {
+					_, isImported := importedIds[clashing.Id]
+					if !deleteCollections || clashing.System || isImported {
+						imported.Name = uniqueCollectionImportName(app, imported.Name, usedNames)
+					}
+				}
 			}
 		}
 
@@ -138,7 +211,14 @@ func (app *BaseApp) ImportCollections(toImport []map[string]any, deleteMissing b
 		return res
 	})
 
-	return app.RunInTransaction(func(txApp App) error {
+	var diff []*CollectionSnapshotDiff
+
+	err := app.RunInTransaction(func(txApp App) error {
+		before, err := NewCollectionSnapshot(txApp, CollectionSnapshotTriggerManual, "")
+		if err != nil {
+			return err
+		}
+
 		existingCollections := []*Collection{}
 		if err := txApp.CollectionQuery().OrderBy("updated ASC").All(&existingCollections); err != nil {
 			return err
@@ -151,7 +231,7 @@ func (app *BaseApp) ImportCollections(toImport []map[string]any, deleteMissing b
 
 		// delete old collections not available in the new configuration
 		// (before saving the imports in case a deleted collection name is being reused)
-		if deleteMissing {
+		if deleteCollections {
 			for _, existing := range existingCollections {
 				if mappedImported[existing.Id] != nil || existing.System {
 					continue // exist or system
@@ -195,6 +275,45 @@ func (app *BaseApp) ImportCollections(toImport []map[string]any, deleteMissing b
 			}
 		}
 
+		after, err := NewCollectionSnapshot(txApp, CollectionSnapshotTriggerManual, "")
+		if err != nil {
+			return err
+		}
+
+		diff, err = DiffCollectionSnapshots(before, after)
+		if err != nil {
+			return err
+		}
+
+		if options.DryRun {
+			return errCollectionsImportDryRun
+		}
+
 		return nil
 	})
+	if err != nil && !errors.Is(err, errCollectionsImportDryRun) {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// uniqueCollectionImportName returns a new unique collection name
+// by appending a numeric suffix to the specified name.
+func uniqueCollectionImportName(app App, name string, usedNames map[string]struct{}) string {
+	for i := 2; ; i++ {
+		newName := name + "_" + strconv.Itoa(i)
+
+		if _, ok := usedNames[strings.ToLower(newName)]; ok {
+			continue
+		}
+
+		if _, err := app.FindCollectionByNameOrId(newName); err == nil {
+			continue
+		}
+
+		usedNames[strings.ToLower(newName)] = struct{}{}
+
+		return newName
+	}
 }
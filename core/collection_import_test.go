@@ -474,3 +474,159 @@ func TestImportCollectionsCreateRules(t *testing.T) {
 		}
 	}
 }
+
+func TestImportCollectionsWithOptions(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name          string
+		data          []map[string]any
+		options       core.CollectionsImportOptions
+		expectError   bool
+		expectActions map[string]string // collection name -> diff action
+		afterTestFunc func(t *testing.T, app *tests.TestApp)
+	}{
+		{
+			name: "dry run",
+			data: []map[string]any{
+				{"name": "import_dry", "fields": []map[string]any{{"name": "title", "type": "text"}}},
+			},
+			options:       core.CollectionsImportOptions{DryRun: true},
+			expectActions: map[string]string{"import_dry": core.CollectionSnapshotDiffCreated},
+			afterTestFunc: func(t *testing.T, app *tests.TestApp) {
+				if _, err := app.FindCollectionByNameOrId("import_dry"); err == nil {
+					t.Fatal("Expected the dry run collection to not be created")
+				}
+			},
+		},
+		{
+			name: "dry run with delete missing",
+			data: []map[string]any{
+				{"id": "wzlqyes4orhoygb", "name": "demo3", "indexes": []string{}},
+			},
+			options:       core.CollectionsImportOptions{DryRun: true, DeleteMissing: true},
+			expectActions: map[string]string{"demo3": core.CollectionSnapshotDiffUpdated, "demo2": core.CollectionSnapshotDiffDeleted},
+			afterTestFunc: func(t *testing.T, app *tests.TestApp) {
+				demo3, err := app.FindCollectionByNameOrId("demo3")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if demo3.Fields.GetByName("title") == nil {
+					t.Fatal("Expected the demo3.title field to be kept")
+				}
+
+				if _, err := app.FindCollectionByNameOrId("demo2"); err != nil {
+					t.Fatalf("Expected demo2 to be kept, got %v", err)
+				}
+			},
+		},
+		{
+			name: "merge fields",
+			data: []map[string]any{
+				{"id": "wzlqyes4orhoygb", "name": "demo3", "fields": []map[string]any{{"name": "new_field", "type": "text"}}},
+			},
+			options:       core.CollectionsImportOptions{DeleteMissing: true, MergeFields: true},
+			expectActions: map[string]string{"demo3": core.CollectionSnapshotDiffUpdated, "demo2": core.CollectionSnapshotDiffDeleted},
+			afterTestFunc: func(t *testing.T, app *tests.TestApp) {
+				demo3, err := app.FindCollectionByNameOrId("demo3")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, name := range []string{"title", "files", "new_field"} {
+					if demo3.Fields.GetByName(name) == nil {
+						t.Fatalf("Missing demo3.%s field", name)
+					}
+				}
+
+				if _, err := app.FindCollectionByNameOrId("demo2"); err == nil {
+					t.Fatal("Expected demo2 to be deleted")
+				}
+			},
+		},
+		{
+			name: "skip deletions",
+			data: []map[string]any{
+				{"id": "wsmn24bux7wo113", "name": "demo1", "fields": []map[string]any{{"name": "text", "type": "number"}}},
+			},
+			options:       core.CollectionsImportOptions{DeleteMissing: true, SkipDeletions: true},
+			expectActions: map[string]string{},
+			afterTestFunc: func(t *testing.T, app *tests.TestApp) {
+				demo1, err := app.FindCollectionByNameOrId("demo1")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if f := demo1.Fields.GetByName("text"); f == nil || f.Type() != core.FieldTypeText {
+					t.Fatalf("Expected the demo1.text field to be kept unchanged, got %v", f)
+				}
+
+				if _, err := app.FindCollectionByNameOrId("demo2"); err != nil {
+					t.Fatalf("Expected demo2 to be kept, got %v", err)
+				}
+			},
+		},
+		{
+			name: "name clash without rename",
+			data: []map[string]any{
+				{"name": "demo1", "id": "import_clash1", "fields": []map[string]any{{"name": "title", "type": "text"}}},
+			},
+			expectError: true,
+		},
+		{
+			name: "name clash with rename",
+			data: []map[string]any{
+				{"name": "demo1", "id": "import_clash1", "fields": []map[string]any{{"name": "title", "type": "text"}}},
+			},
+			options:       core.CollectionsImportOptions{RenameOnClash: true},
+			expectActions: map[string]string{"demo1_2": core.CollectionSnapshotDiffCreated},
+			afterTestFunc: func(t *testing.T, app *tests.TestApp) {
+				renamed, err := app.FindCollectionByNameOrId("import_clash1")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if renamed.Name != "demo1_2" {
+					t.Fatalf("Expected the imported collection to be renamed to demo1_2, got %q", renamed.Name)
+				}
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			diff, err := testApp.ImportCollectionsWithOptions(s.data, s.options)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr to be %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			actions := map[string]string{}
+			for _, d := range diff {
+				actions[d.Name] = d.Action
+			}
+
+			for name, action := range s.expectActions {
+				if actions[name] != action {
+					t.Fatalf("Expected %q diff action %q, got %q (%v)", name, action, actions[name], actions)
+				}
+			}
+
+			if len(s.expectActions) == 0 && len(diff) != 0 {
+				t.Fatalf("Expected no changes, got %v", actions)
+			}
+
+			if s.afterTestFunc != nil {
+				s.afterTestFunc(t, testApp)
+			}
+		})
+	}
+}
@@ -3,12 +3,14 @@ package core
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/tools/dbutils"
 	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
@@ -380,6 +382,8 @@ type baseCollection struct {
 // Collection defines the table, fields and various options related to a set of records.
 type Collection struct {
 	baseCollection
+	collectionAPIOptions
+	collectionBaseOptions
 	collectionAuthOptions
 	collectionViewOptions
 }
@@ -506,14 +510,21 @@ func (m *Collection) unmarshalRawOptions() error {
 		return nil
 	}
 
+	if err := json.Unmarshal(raw, &m.collectionAPIOptions); err != nil {
+		return err
+	}
+
 	switch m.Type {
 	case CollectionTypeView:
 		return json.Unmarshal(raw, &m.collectionViewOptions)
 	case CollectionTypeAuth:
+		if err := json.Unmarshal(raw, &m.collectionBaseOptions); err != nil {
+			return err
+		}
 		return json.Unmarshal(raw, &m.collectionAuthOptions)
+	default:
+		return json.Unmarshal(raw, &m.collectionBaseOptions)
 	}
-
-	return nil
 }
 
 // UnmarshalJSON implements the [json.Unmarshaler] interface.
@@ -551,13 +562,16 @@ func (m Collection) MarshalJSON() ([]byte, error) {
 	case CollectionTypeView:
 		return json.Marshal(struct {
 			baseCollection
+			collectionAPIOptions
 			collectionViewOptions
-		}{m.baseCollection, m.collectionViewOptions})
+		}{m.baseCollection, m.collectionAPIOptions, m.collectionViewOptions})
 	case CollectionTypeAuth:
 		alias := struct {
 			baseCollection
+			collectionAPIOptions
+			collectionBaseOptions
 			collectionAuthOptions
-		}{m.baseCollection, m.collectionAuthOptions}
+		}{m.baseCollection, m.collectionAPIOptions, m.collectionBaseOptions, m.collectionAuthOptions}
 
 		// ensure that it is always returned as array
 		if alias.OAuth2.Providers == nil {
@@ -576,7 +590,11 @@ func (m Collection) MarshalJSON() ([]byte, error) {
 
 		return json.Marshal(alias)
 	default:
-		return json.Marshal(m.baseCollection)
+		return json.Marshal(struct {
+			baseCollection
+			collectionAPIOptions
+			collectionBaseOptions
+		}{m.baseCollection, m.collectionAPIOptions, m.collectionBaseOptions})
 	}
 }
 
@@ -607,17 +625,35 @@ func (m *Collection) DBExport(app App) (map[string]any, error) {
 
 	switch m.Type {
 	case CollectionTypeView:
-		if raw, err := types.ParseJSONRaw(m.collectionViewOptions); err == nil {
+		if raw, err := types.ParseJSONRaw(struct {
+			collectionAPIOptions
+			collectionViewOptions
+		}{m.collectionAPIOptions, m.collectionViewOptions}); err == nil {
 			result["options"] = raw
 		} else {
 			return nil, err
 		}
 	case CollectionTypeAuth:
-		if raw, err := types.ParseJSONRaw(m.collectionAuthOptions); err == nil {
+		if raw, err := types.ParseJSONRaw(struct {
+			collectionAPIOptions
+			collectionBaseOptions
+			collectionAuthOptions
+		}{m.collectionAPIOptions, m.collectionBaseOptions, m.collectionAuthOptions}); err == nil {
 			result["options"] = raw
 		} else {
 			return nil, err
 		}
+	default:
+		if m.collectionAPIOptions != (collectionAPIOptions{}) || !reflect.ValueOf(m.collectionBaseOptions).IsZero() {
+			if raw, err := types.ParseJSONRaw(struct {
+				collectionAPIOptions
+				collectionBaseOptions
+			}{m.collectionAPIOptions, m.collectionBaseOptions}); err == nil {
+				result["options"] = raw
+			} else {
+				return nil, err
+			}
+		}
 	}
 
 	return result, nil
@@ -662,6 +698,23 @@ func (m *Collection) AddIndex(name string, unique bool, columnsExpr string, optW
 	m.Indexes = append(m.Indexes, idx.String())
 }
 
+// AddJSONPathIndex adds a new expression index into the current collection
+// for the specified json field dot-notation path (e.g. "meta.tags.0.name").
+//
+// The index expression is the same as the one generated by the
+// "jsonField.path.to.key" filter and sort identifiers, allowing the
+// SQLite query planner to use it when filtering or sorting by the path.
+//
+// If the collection has an existing index matching the new name it will be replaced with the new one.
+func (m *Collection) AddJSONPathIndex(name string, unique bool, fieldName string, path string) {
+	expr := dbutils.JSONExtract(inflector.Columnify(fieldName), jsonExtractPath(strings.Split(path, ".")))
+
+	// replace the dbx column placeholders with regular quoted identifiers
+	expr = strings.NewReplacer("[[", "`", "]]", "`").Replace(expr)
+
+	m.AddIndex(name, unique, expr, "")
+}
+
 // RemoveIndex removes a single index with the specified name from the current collection.
 func (m *Collection) RemoveIndex(name string) {
 	for i, idx := range m.Indexes {
@@ -807,12 +860,22 @@ func onCollectionSave(e *CollectionEvent) error {
 
 	e.Collection.initDefaultFields()
 
+	if !e.Collection.IsView() {
+		e.Collection.initModerationFields()
+		e.Collection.initPublishingFields()
+	}
+
 	if e.Collection.IsAuth() {
 		e.Collection.unsetMissingOAuth2MappedFields()
 	}
 
 	e.Collection.updateGeneratedIdIfExists(e.App)
 
+	// after the id update because of the parent self relation
+	if !e.Collection.IsView() {
+		e.Collection.initTreeFields()
+	}
+
 	return e.Next()
 }
 
@@ -0,0 +1,48 @@
+package core
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ optionsValidator = (*collectionAPIOptions)(nil)
+
+// collectionAPIOptions defines the options that are available for all collection types.
+type collectionAPIOptions struct {
+	// API defines optional restrictions for the collection records web APIs.
+	API CollectionAPI `form:"api" json:"api,omitzero"`
+}
+
+func (o *collectionAPIOptions) validate(cv *collectionValidator) error {
+	return validation.ValidateStruct(o,
+		validation.Field(&o.API, validation.By(cv.ensureNoSuperusersAPIRestriction)),
+	)
+}
+
+// CollectionAPI defines the access restrictions of the collection records web APIs.
+//
+// The restrictions are applied on top of the collection API rules and they
+// don't apply for superusers or for the server-side (Go/JS) records access.
+type CollectionAPI struct {
+	// Disabled hides the collection from the records crud, auth, file,
+	// realtime and GraphQL web APIs (they respond as if the collection doesn't exist).
+	Disabled bool `form:"disabled" json:"disabled"`
+
+	// ReadOnly rejects the records create, update and delete web API requests
+	// (including when submitted as part of a batch or GraphQL mutation).
+	ReadOnly bool `form:"readOnly" json:"readOnly"`
+}
+
+// IsZero reports whether none of the API restrictions are set.
+func (a CollectionAPI) IsZero() bool {
+	return a == CollectionAPI{}
+}
+
+func (validator *collectionValidator) ensureNoSuperusersAPIRestriction(value any) error {
+	v, _ := value.(CollectionAPI)
+
+	if !v.IsZero() && validator.new.Name == CollectionNameSuperusers {
+		return validation.NewError("validation_collection_superusers_api", "The superusers collection API cannot be restricted.")
+	}
+
+	return nil
+}
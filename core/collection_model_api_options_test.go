@@ -0,0 +1,154 @@
+package core_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCollectionAPIOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		collection     func(app core.App) (*core.Collection, error)
+		expectedErrors []string
+	}{
+		{
+			name: "base with zero api options",
+			collection: func(app core.App) (*core.Collection, error) {
+				c := core.NewBaseCollection("new_base")
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "base with api restrictions",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.API = core.CollectionAPI{Disabled: true, ReadOnly: true}
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "auth with api restrictions",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("users")
+				c.API.ReadOnly = true
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "view with api restrictions",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("view1")
+				c.API.Disabled = true
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "system collection with api restrictions",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId(core.CollectionNameOTPs)
+				c.API.Disabled = true
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "superusers with api restrictions",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId(core.CollectionNameSuperusers)
+				c.API.Disabled = true
+				return c, nil
+			},
+			expectedErrors: []string{"api"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			collection, err := s.collection(app)
+			if err != nil {
+				t.Fatalf("Failed to retrieve test collection: %v", err)
+			}
+
+			result := app.Validate(collection)
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestCollectionAPIPersistence(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	api := core.CollectionAPI{Disabled: true, ReadOnly: true}
+
+	for _, name := range []string{"demo1", "nologin", "view1"} {
+		t.Run(name, func(t *testing.T) {
+			collection, err := app.FindCollectionByNameOrId(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			raw, _ := json.Marshal(collection)
+			if strings.Contains(string(raw), `"api"`) {
+				t.Fatalf("Expected zero api options to be omitted, got\n%s", raw)
+			}
+
+			collection.API = api
+			if err := app.Save(collection); err != nil {
+				t.Fatal(err)
+			}
+
+			collection, err = app.FindCollectionByNameOrId(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if collection.API != api {
+				t.Fatalf("Expected api options %v, got %v", api, collection.API)
+			}
+
+			raw, _ = json.Marshal(collection)
+			expected := `"api":{"disabled":true,"readOnly":true}`
+			if !strings.Contains(string(raw), expected) {
+				t.Fatalf("Expected %s in\n%s", expected, raw)
+			}
+
+			// unmarshal into a new collection
+			unmarshaled := &core.Collection{}
+			if err := json.Unmarshal(raw, unmarshaled); err != nil {
+				t.Fatal(err)
+			}
+			if unmarshaled.API != api {
+				t.Fatalf("Expected unmarshaled api options %v, got %v", api, unmarshaled.API)
+			}
+		})
+	}
+}
+
+func TestCollectionAPIIsZero(t *testing.T) {
+	t.Parallel()
+
+	if !(core.CollectionAPI{}).IsZero() {
+		t.Fatal("Expected zero api options")
+	}
+
+	if (core.CollectionAPI{ReadOnly: true}).IsZero() {
+		t.Fatal("Expected non-zero api options")
+	}
+}
@@ -66,6 +66,7 @@ func (m *Collection) setDefaultAuthOptions() {
 			Enabled:       false,
 			Duration:      180, // 3min
 			Length:        8,
+			MaxAttempts:   5,
 			EmailTemplate: defaultOTPTemplate,
 		},
 		AuthToken: TokenConfig{
@@ -131,6 +132,9 @@ type collectionAuthOptions struct {
 	// OTP defines options related to the One-time password authentication (OTP).
 	OTP OTPConfig `form:"otp" json:"otp"`
 
+	// Invites defines options related to the auth collection invitations.
+	Invites InvitesConfig `form:"invites" json:"invites,omitzero"`
+
 	// Various token configurations
 	// ---
 	AuthToken          TokenConfig `form:"authToken" json:"authToken"`
@@ -164,6 +168,7 @@ func (o *collectionAuthOptions) validate(cv *collectionValidator) error {
 		validation.Field(&o.OAuth2),
 		validation.Field(&o.OTP),
 		validation.Field(&o.MFA),
+		validation.Field(&o.Invites),
 		validation.Field(&o.AuthToken),
 		validation.Field(&o.PasswordResetToken),
 		validation.Field(&o.EmailChangeToken),
@@ -231,6 +236,23 @@ func (o *collectionAuthOptions) validate(cv *collectionValidator) error {
 		}
 	}
 
+	// extra check to ensure that the OTP phone field is unique text field
+	if o.OTP.PhoneField != "" {
+		err = validation.Validate([]string{o.OTP.PhoneField}, validation.By(cv.checkFieldsForUniqueIndex))
+		if err == nil {
+			if _, ok := cv.new.Fields.GetByName(o.OTP.PhoneField).(*TextField); !ok {
+				err = validation.NewError("validation_invalid_phone_field", "The OTP phone field must be a text field.")
+			}
+		}
+		if err != nil {
+			return validation.Errors{
+				"otp": validation.Errors{
+					"phoneField": err,
+				},
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -249,6 +271,11 @@ func (t EmailTemplate) Validate() error {
 	)
 }
 
+// IsZero reports whether both the template subject and body are empty.
+func (t EmailTemplate) IsZero() bool {
+	return t.Subject == "" && t.Body == ""
+}
+
 // Resolve replaces the placeholder parameters in the current email
 // template and returns its components as ready-to-use strings.
 func (t EmailTemplate) Resolve(placeholders map[string]any) (subject, body string) {
@@ -317,11 +344,28 @@ type OTPConfig struct {
 	// Length specifies the auto generated password length.
 	Length int `form:"length" json:"length"`
 
+	// MaxAttempts specifies the max allowed failed password attempts
+	// for a single OTP after which the OTP is invalidated
+	// (0 means no limit other than the default per IP rate limit).
+	MaxAttempts int `form:"maxAttempts" json:"maxAttempts"`
+
 	// EmailTemplate is the default OTP email template that will be send to the auth record.
 	//
 	// In addition to the system placeholders you can also make use of
 	// [core.EmailPlaceholderOTPId] and [core.EmailPlaceholderOTP].
 	EmailTemplate EmailTemplate `form:"emailTemplate" json:"emailTemplate"`
+
+	// PhoneField is the name of an optional unique text field with the
+	// auth record phone number that allows requesting the OTP via SMS
+	// (leave it empty to disable the SMS OTP delivery).
+	PhoneField string `form:"phoneField" json:"phoneField"`
+
+	// SMSTemplate is the OTP text message that will be send to the auth record phone
+	// (leave it empty to fallback to the default sms.DefaultOTPTemplate).
+	//
+	// In addition to the system placeholders you can also make use of
+	// [core.EmailPlaceholderOTPId] and [core.EmailPlaceholderOTP].
+	SMSTemplate string `form:"smsTemplate" json:"smsTemplate"`
 }
 
 // Validate makes OTPConfig validatable by implementing [validation.Validatable] interface.
@@ -329,9 +373,11 @@ func (c OTPConfig) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.Duration, validation.When(c.Enabled, validation.Required, validation.Min(10), validation.Max(86400))),
 		validation.Field(&c.Length, validation.When(c.Enabled, validation.Required, validation.Min(4))),
+		validation.Field(&c.MaxAttempts, validation.Min(0), validation.Max(100)),
 		// note: for now always run the email template validations even
 		// if not enabled since it could be used separately
 		validation.Field(&c.EmailTemplate),
+		validation.Field(&c.SMSTemplate, validation.Length(0, 1600)),
 	)
 }
 
@@ -342,6 +388,54 @@ func (c OTPConfig) DurationTime() time.Duration {
 
 // -------------------------------------------------------------------
 
+// DefaultInviteDuration is the fallback invite duration (in seconds)
+// used when [InvitesConfig.Duration] is not set.
+const DefaultInviteDuration int64 = 604800 // 7 days
+
+type InvitesConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Duration specifies how long an invite to be valid (in seconds)
+	// (leave it empty to fallback to [DefaultInviteDuration]).
+	Duration int64 `form:"duration" json:"duration"`
+
+	// EmailTemplate is the invite email template that will be send to the invited email
+	// (leave it empty to fallback to the default invite template).
+	//
+	// The [core.EmailPlaceholderToken] placeholder contains the plain invite token.
+	EmailTemplate EmailTemplate `form:"emailTemplate" json:"emailTemplate,omitzero"`
+}
+
+// Validate makes InvitesConfig validatable by implementing [validation.Validatable] interface.
+func (c InvitesConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Duration, validation.Min(0), validation.Max(94670856)), // ~3y max
+		validation.Field(&c.EmailTemplate, validation.Skip.When(c.EmailTemplate.IsZero())),
+	)
+}
+
+// DurationTime returns the current Duration as [time.Duration]
+// (or [DefaultInviteDuration] if not set).
+func (c InvitesConfig) DurationTime() time.Duration {
+	if c.Duration <= 0 {
+		return time.Duration(DefaultInviteDuration) * time.Second
+	}
+
+	return time.Duration(c.Duration) * time.Second
+}
+
+// Template returns the configured invite email template
+// (or the default one if not set).
+func (c InvitesConfig) Template() EmailTemplate {
+	if c.EmailTemplate.IsZero() {
+		return defaultInviteTemplate
+	}
+
+	return c.EmailTemplate
+}
+
+// -------------------------------------------------------------------
+
 type MFAConfig struct {
 	Enabled bool `form:"enabled" json:"enabled"`
 
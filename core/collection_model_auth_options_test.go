@@ -167,6 +167,47 @@ func TestCollectionAuthOptionsValidate(t *testing.T) {
 			},
 			expectedErrors: []string{"otp"},
 		},
+		{
+			name: "otp with missing phone field",
+			collection: func(app core.App) (*core.Collection, error) {
+				c := core.NewAuthCollection("new_auth")
+				c.OTP.PhoneField = "missing"
+				return c, nil
+			},
+			expectedErrors: []string{"otp"},
+		},
+		{
+			name: "otp with non-unique phone field",
+			collection: func(app core.App) (*core.Collection, error) {
+				c := core.NewAuthCollection("new_auth")
+				c.Fields.Add(&core.TextField{Name: "phone"})
+				c.OTP.PhoneField = "phone"
+				return c, nil
+			},
+			expectedErrors: []string{"otp"},
+		},
+		{
+			name: "otp with non-text phone field",
+			collection: func(app core.App) (*core.Collection, error) {
+				c := core.NewAuthCollection("new_auth")
+				c.Fields.Add(&core.NumberField{Name: "phone"})
+				c.AddIndex("auth_phone_idx", true, "phone", "")
+				c.OTP.PhoneField = "phone"
+				return c, nil
+			},
+			expectedErrors: []string{"otp"},
+		},
+		{
+			name: "otp with valid phone field",
+			collection: func(app core.App) (*core.Collection, error) {
+				c := core.NewAuthCollection("new_auth")
+				c.Fields.Add(&core.TextField{Name: "phone"})
+				c.AddIndex("auth_phone_idx", true, "phone", "")
+				c.OTP.PhoneField = "phone"
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
 
 		// mfa
 		{
@@ -613,6 +654,77 @@ func TestOTPConfigDurationTime(t *testing.T) {
 	}
 }
 
+func TestInvitesConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.InvitesConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.InvitesConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			core.InvitesConfig{Enabled: true},
+			[]string{},
+		},
+		{
+			"invalid duration (< 0)",
+			core.InvitesConfig{Enabled: true, Duration: -1},
+			[]string{"duration"},
+		},
+		{
+			"invalid duration (> 94670856)",
+			core.InvitesConfig{Enabled: true, Duration: 94670857},
+			[]string{"duration"},
+		},
+		{
+			"invalid template (triggering EmailTemplate validations)",
+			core.InvitesConfig{Enabled: true, EmailTemplate: core.EmailTemplate{Subject: "b"}},
+			[]string{"emailTemplate"},
+		},
+		{
+			"valid data",
+			core.InvitesConfig{
+				Enabled:       true,
+				Duration:      100,
+				EmailTemplate: core.EmailTemplate{Body: "a", Subject: "b"},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestInvitesConfigDurationTime(t *testing.T) {
+	scenarios := []struct {
+		config   core.InvitesConfig
+		expected time.Duration
+	}{
+		{core.InvitesConfig{}, time.Duration(core.DefaultInviteDuration) * time.Second},
+		{core.InvitesConfig{Duration: 1234}, 1234 * time.Second},
+	}
+
+	for i, s := range scenarios {
+		t.Run(fmt.Sprintf("%d_%d", i, s.config.Duration), func(t *testing.T) {
+			result := s.config.DurationTime()
+
+			if result != s.expected {
+				t.Fatalf("Expected duration %d, got %d", s.expected, result)
+			}
+		})
+	}
+}
+
 func TestMFAConfigValidate(t *testing.T) {
 	scenarios := []struct {
 		name           string
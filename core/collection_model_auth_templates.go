@@ -75,3 +75,18 @@ var defaultAuthAlertTemplate = EmailTemplate{
   ` + EmailPlaceholderAppName + ` team
 </p>`,
 }
+
+var defaultInviteTemplate = EmailTemplate{
+	Subject: "You have been invited to join " + EmailPlaceholderAppName,
+	Body: `<p>Hello,</p>
+<p>You have been invited to join ` + EmailPlaceholderAppName + `.</p>
+<p>Click on the button below to accept the invitation and set up your account.</p>
+<p>
+  <a class="btn" href="` + EmailPlaceholderAppURL + "/accept-invite?token=" + EmailPlaceholderToken + `" target="_blank" rel="noopener">Accept invite</a>
+</p>
+<p><i>If you weren't expecting this invitation, you can ignore this email.</i></p>
+<p>
+  Thanks,<br/>
+  ` + EmailPlaceholderAppName + ` team
+</p>`,
+}
@@ -1,11 +1,496 @@
 package core
 
+import (
+	"regexp"
+	"slices"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
 var _ optionsValidator = (*collectionBaseOptions)(nil)
 
-// collectionBaseOptions defines the options for the "base" type collection.
+// collectionBaseOptions defines the options for the "base" type collection
+// (they are also available for the "auth" type collection).
 type collectionBaseOptions struct {
+	// Limits defines optional payload limits for the record create and update requests.
+	Limits CollectionLimits `form:"limits" json:"limits,omitzero"`
+
+	// RecordCache defines the optional in-memory records cache options (see [BaseApp.CachedRecord]).
+	RecordCache CollectionRecordCache `form:"recordCache" json:"recordCache,omitzero"`
+
+	// Captcha defines the collection actions that require a valid captcha token
+	// (see [Settings.Captcha]).
+	Captcha CollectionCaptcha `form:"captcha" json:"captcha,omitzero"`
+
+	// SpamScore defines the optional record create requests spam/abuse
+	// scoring options (see [App.OnRecordCreateScore]).
+	SpamScore CollectionSpamScore `form:"spamScore" json:"spamScore,omitzero"`
+
+	// Moderation defines the optional records moderation workflow options
+	// (see [BaseApp.ModerateRecord]).
+	Moderation CollectionModeration `form:"moderation" json:"moderation,omitzero"`
+
+	// Publishing defines the optional records scheduled publishing options
+	// (see [Record.IsPublished]).
+	Publishing CollectionPublishing `form:"publishing" json:"publishing,omitzero"`
+
+	// Tree defines the optional records tree hierarchy options
+	// (see [BaseApp.MoveTreeRecord]).
+	Tree CollectionTree `form:"tree" json:"tree,omitzero"`
+
+	// Expiration defines the optional records expiration options
+	// (see [Record.IsExpired]).
+	Expiration CollectionExpiration `form:"expiration" json:"expiration,omitzero"`
+
+	// Organization defines the optional organization (aka. team/tenant)
+	// membership options (see [BaseApp.AddOrganizationMember]).
+	Organization CollectionOrganization `form:"organization" json:"organization,omitzero"`
 }
 
 func (o *collectionBaseOptions) validate(cv *collectionValidator) error {
+	return validation.ValidateStruct(o,
+		validation.Field(&o.Limits),
+		validation.Field(&o.RecordCache),
+		validation.Field(&o.Captcha, validation.By(cv.checkCaptchaActions)),
+		validation.Field(&o.SpamScore, validation.By(cv.checkSpamScoreField)),
+		validation.Field(&o.Moderation, validation.By(cv.checkModeration)),
+		validation.Field(&o.Expiration, validation.By(cv.checkExpiration)),
+		validation.Field(&o.Organization, validation.By(cv.checkOrganization)),
+	)
+}
+
+// CollectionLimits defines the record create and update request payload limits of a collection.
+//
+// The limits are checked before loading the submitted data into the record
+// (aka. before the fields validators), with zero values meaning "no explicit limit".
+type CollectionLimits struct {
+	// MaxBodySize is the max allowed request body size in bytes.
+	//
+	// If not set, fallbacks to the default limit calculated from the collection fields.
+	MaxBodySize int64 `form:"maxBodySize" json:"maxBodySize"`
+
+	// MaxItems is the max allowed number of submitted items for a single
+	// multi-value field (relation, file and select), including the ones
+	// submitted with the "+" and "-" modifiers.
+	MaxItems int `form:"maxItems" json:"maxItems"`
+
+	// MaxJSONSize is the max allowed serialized size in bytes of a single submitted json field value.
+	MaxJSONSize int64 `form:"maxJSONSize" json:"maxJSONSize"`
+
+	// MaxJSONDepth is the max allowed nesting depth of a single submitted json field value.
+	MaxJSONDepth int `form:"maxJSONDepth" json:"maxJSONDepth"`
+}
+
+// Validate makes CollectionLimits validatable by implementing [validation.Validatable] interface.
+func (l CollectionLimits) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.MaxBodySize, validation.Min(0)),
+		validation.Field(&l.MaxItems, validation.Min(0)),
+		validation.Field(&l.MaxJSONSize, validation.Min(0)),
+		validation.Field(&l.MaxJSONDepth, validation.Min(0), validation.Max(1000)),
+	)
+}
+
+// IsZero reports whether none of the limits are set.
+func (l CollectionLimits) IsZero() bool {
+	return l == CollectionLimits{}
+}
+
+// DefaultRecordCacheMaxRecords is the default max number of cached records per collection.
+const DefaultRecordCacheMaxRecords = 1000
+
+// CollectionRecordCache defines the in-memory records cache options of a collection.
+//
+// It is intended for small and frequently read collections
+// (e.g. settings-like lookups from the app hooks).
+type CollectionRecordCache struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// MaxRecords is the max number of cached records of the collection
+	// (if not set, fallbacks to DefaultRecordCacheMaxRecords).
+	MaxRecords int `form:"maxRecords" json:"maxRecords"`
+
+	// TTL is an optional cached record lifetime in seconds
+	// (zero means that the records are kept until invalidated).
+	TTL int64 `form:"ttl" json:"ttl"`
+}
+
+// Validate makes CollectionRecordCache validatable by implementing [validation.Validatable] interface.
+func (c CollectionRecordCache) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxRecords, validation.Min(0), validation.Max(100000)),
+		validation.Field(&c.TTL, validation.Min(0)),
+	)
+}
+
+// Collection actions that could be protected with captcha verification.
+const (
+	CaptchaActionCreate        = "create"
+	CaptchaActionPasswordReset = "passwordReset"
+)
+
+// CollectionCaptcha defines the collection records API actions
+// that require a valid client captcha token.
+//
+// The captcha verification is applied only when [Settings.Captcha] is enabled
+// and it is skipped for superusers.
+type CollectionCaptcha struct {
+	// Create enables the captcha verification for the record create
+	// (aka. auth register) requests.
+	Create bool `form:"create" json:"create"`
+
+	// PasswordReset enables the captcha verification for the
+	// auth record password reset requests.
+	PasswordReset bool `form:"passwordReset" json:"passwordReset"`
+}
+
+// IsZero reports whether none of the captcha actions are enabled.
+func (c CollectionCaptcha) IsZero() bool {
+	return c == CollectionCaptcha{}
+}
+
+// Requires reports whether the specified action requires captcha verification.
+func (c CollectionCaptcha) Requires(action string) bool {
+	switch action {
+	case CaptchaActionCreate:
+		return c.Create
+	case CaptchaActionPasswordReset:
+		return c.PasswordReset
+	}
+
+	return false
+}
+
+func (validator *collectionValidator) checkCaptchaActions(value any) error {
+	v, _ := value.(CollectionCaptcha)
+
+	if v.PasswordReset && !validator.new.IsAuth() {
+		return validation.Errors{
+			"passwordReset": validation.NewError("validation_captcha_auth_only", "The password reset captcha is available only for auth collections."),
+		}
+	}
+
+	return nil
+}
+
+// CollectionSpamScore defines the record create requests spam/abuse scoring options of a collection.
+//
+// The scoring is skipped for superusers.
+type CollectionSpamScore struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// ScoreField is the optional name of a collection number field where
+	// the calculated record score (0-1) will be stored, allowing it to be
+	// used in the API rules and filters (e.g. "spamScore < 0.5").
+	//
+	// The submitted client value of the field is always replaced.
+	ScoreField string `form:"scoreField" json:"scoreField"`
+}
+
+// IsZero reports whether the spam scoring options are not set.
+func (c CollectionSpamScore) IsZero() bool {
+	return c == CollectionSpamScore{}
+}
+
+func (validator *collectionValidator) checkSpamScoreField(value any) error {
+	v, _ := value.(CollectionSpamScore)
+
+	if v.ScoreField == "" {
+		return nil
+	}
+
+	if _, ok := validator.new.Fields.GetByName(v.ScoreField).(*NumberField); !ok {
+		return validation.Errors{
+			"scoreField": validation.NewError("validation_invalid_spam_score_field", "The score field must be an existing number field."),
+		}
+	}
+
+	return nil
+}
+
+// CollectionModeration defines the records moderation workflow options of a collection.
+//
+// When enabled, the collection is extended with the system "moderationState"
+// select field (and optionally with the "moderationReviewer" relation field)
+// that could be changed only by superusers or via the records moderate API
+// according to the transition rules (see [CollectionModeration.TransitionRule]).
+type CollectionModeration struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// InitialState is the moderation state of the newly created records
+	// ("draft" or "pending"; if empty, fallbacks to "draft").
+	InitialState string `form:"initialState" json:"initialState"`
+
+	// SubmitRule is the API rule guarding the author transitions
+	// (draft -> pending, pending -> draft and rejected -> draft|pending).
+	//
+	// Leave it nil to allow the transitions only for superusers.
+	SubmitRule *string `form:"submitRule" json:"submitRule"`
+
+	// ReviewRule is the API rule guarding the reviewer transitions
+	// (pending -> approved|rejected and approved -> pending|rejected)
+	// and the reviewer assignment.
+	//
+	// Leave it nil to allow the transitions only for superusers.
+	ReviewRule *string `form:"reviewRule" json:"reviewRule"`
+
+	// ReviewersCollection is the optional id of the auth collection
+	// whose records could be assigned as reviewers.
+	ReviewersCollection string `form:"reviewersCollection" json:"reviewersCollection"`
+
+	// QuarantineScore is the optional min spam score (see [CollectionSpamScore])
+	// at which the new records created via the API are forced in "pending" state.
+	//
+	// Set to zero to disable.
+	QuarantineScore float64 `form:"quarantineScore" json:"quarantineScore"`
+}
+
+// IsZero reports whether the moderation options are not set.
+func (c CollectionModeration) IsZero() bool {
+	return c == CollectionModeration{}
+}
+
+// DefaultState returns the moderation state of the newly created records.
+func (c CollectionModeration) DefaultState() string {
+	if c.InitialState == "" {
+		return ModerationStateDraft
+	}
+
+	return c.InitialState
+}
+
+// TransitionRule returns the API rule guarding the moderation state transition
+// and whether the transition is allowed at all.
+//
+// Transitions to the same state (e.g. for assigning a reviewer) are guarded by the ReviewRule.
+func (c CollectionModeration) TransitionRule(from string, to string) (*string, bool) {
+	if from == "" {
+		from = c.DefaultState()
+	}
+
+	if from == to {
+		return c.ReviewRule, slices.Contains(ModerationStates, to)
+	}
+
+	switch from + ":" + to {
+	case ModerationStateDraft + ":" + ModerationStatePending,
+		ModerationStatePending + ":" + ModerationStateDraft,
+		ModerationStateRejected + ":" + ModerationStateDraft,
+		ModerationStateRejected + ":" + ModerationStatePending:
+		return c.SubmitRule, true
+	case ModerationStatePending + ":" + ModerationStateApproved,
+		ModerationStatePending + ":" + ModerationStateRejected,
+		ModerationStateApproved + ":" + ModerationStatePending,
+		ModerationStateApproved + ":" + ModerationStateRejected:
+		return c.ReviewRule, true
+	}
+
+	return nil, false
+}
+
+func (validator *collectionValidator) checkModeration(value any) error {
+	v, _ := value.(CollectionModeration)
+
+	if !v.Enabled {
+		return nil
+	}
+
+	return validation.ValidateStruct(&v,
+		validation.Field(&v.InitialState, validation.In(ModerationStateDraft, ModerationStatePending)),
+		validation.Field(&v.SubmitRule, validation.By(validator.checkRule)),
+		validation.Field(&v.ReviewRule, validation.By(validator.checkRule)),
+		validation.Field(&v.ReviewersCollection, validation.By(validator.checkReviewersCollection)),
+		validation.Field(&v.QuarantineScore, validation.Min(0.0), validation.Max(1.0)),
+	)
+}
+
+func (validator *collectionValidator) checkReviewersCollection(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil
+	}
+
+	collection, err := validator.app.FindCachedCollectionByNameOrId(v)
+	if err != nil || !collection.IsAuth() || collection.Id != v {
+		return validation.NewError("validation_invalid_reviewers_collection", "The reviewers collection must be an existing auth collection id.")
+	}
+
+	return nil
+}
+
+// CollectionPublishing defines the records scheduled publishing options of a collection.
+//
+// When enabled, the collection is extended with the system "publishAt" and
+// "unpublishAt" date fields and the records are visible to the non-superusers
+// list/view requests (and realtime subscriptions) only within their publishing window.
+//
+// Every minute the app cron triggers the [App.OnRecordPublish] hook for
+// the records whose publishAt or unpublishAt time was reached.
+type CollectionPublishing struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+}
+
+// IsZero reports whether the publishing options are not set.
+func (c CollectionPublishing) IsZero() bool {
+	return c == CollectionPublishing{}
+}
+
+// CollectionTree defines the records tree hierarchy options of a collection
+// (e.g. for categories, org charts and nested comments).
+//
+// When enabled, the collection is extended with the system "parent" self relation field,
+// the "treePath" text field with the materialized path of the record ancestors
+// (maintained automatically on save) and the "treePosition" siblings order number field.
+//
+// The descendants of a record could be filtered with the `descendantsOf(id)` filter function.
+type CollectionTree struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// CascadeDelete specifies whether to delete the descendants on parent record delete.
+	//
+	// If disabled, the direct children of the deleted record become root records.
+	CascadeDelete bool `form:"cascadeDelete" json:"cascadeDelete"`
+}
+
+// IsZero reports whether the tree options are not set.
+func (c CollectionTree) IsZero() bool {
+	return c == CollectionTree{}
+}
+
+// CollectionExpiration defines the records expiration (aka. TTL) options of a collection
+// (e.g. for OTPs, invites and other ephemeral content).
+//
+// The expired records (aka. those with reached Field date) are not visible
+// to the non-superusers list/view requests (and realtime subscriptions)
+// and every minute the app cron triggers the [App.OnRecordExpire] hook
+// (which by default deletes the record) for the expired records
+// whose GracePeriod has passed.
+type CollectionExpiration struct {
+	// Field is the name of the collection date field with the record expiration time
+	// (the records with empty field value never expire).
+	//
+	// Leave it empty to disable the records expiration.
+	Field string `form:"field" json:"field"`
+
+	// GracePeriod is the number of seconds after the record expiration
+	// before it is purged (zero means immediately with the next cron run).
+	GracePeriod int64 `form:"gracePeriod" json:"gracePeriod"`
+}
+
+// IsZero reports whether the expiration options are not set.
+func (c CollectionExpiration) IsZero() bool {
+	return c == CollectionExpiration{}
+}
+
+func (validator *collectionValidator) checkExpiration(value any) error {
+	v, _ := value.(CollectionExpiration)
+
+	if v.IsZero() {
+		return nil
+	}
+
+	return validation.ValidateStruct(&v,
+		validation.Field(&v.Field, validation.Required, validation.By(func(value any) error {
+			name, _ := value.(string)
+			if _, ok := validator.new.Fields.GetByName(name).(*DateField); !ok {
+				return validation.NewError("validation_invalid_expiration_field", "The expiration field must be an existing date field.")
+			}
+			return nil
+		})),
+		validation.Field(&v.GracePeriod, validation.Min(0)),
+	)
+}
+
+var organizationRoleRegex = regexp.MustCompile(`^\w[\w\-]*$`)
+
+// CollectionOrganization defines the organization (aka. team/tenant) options of a collection.
+//
+// When enabled, the collection records act as organizations whose members
+// are auth records of the MembersCollection with an optional role
+// (the memberships are stored in the system "_memberships" table).
+//
+// The organization membership of the current authenticated record could be checked
+// in the API rules with the `@request.auth.memberOf(org, [role1, role2, ...])` filter function
+// (e.g. `@request.auth.memberOf(org, "owner", "admin") = true`).
+type CollectionOrganization struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// MembersCollection is the id of the auth collection
+	// whose records could be added as members.
+	MembersCollection string `form:"membersCollection" json:"membersCollection"`
+
+	// Roles is an optional list of the allowed membership roles
+	// (leave it empty to allow any role).
+	Roles []string `form:"roles" json:"roles"`
+
+	// ManageRule is the API rule (checked against the organization record)
+	// guarding the add, update and remove members requests
+	// (e.g. `@request.auth.memberOf(id, "owner") = true`).
+	//
+	// Leave it nil to allow managing the members only by superusers.
+	ManageRule *string `form:"manageRule" json:"manageRule"`
+}
+
+// IsZero reports whether the organization options are not set.
+func (c CollectionOrganization) IsZero() bool {
+	return !c.Enabled && c.MembersCollection == "" && len(c.Roles) == 0 && c.ManageRule == nil
+}
+
+// HasRole reports whether the specified membership role is allowed.
+func (c CollectionOrganization) HasRole(role string) bool {
+	if len(c.Roles) == 0 {
+		return role == "" || organizationRoleRegex.MatchString(role)
+	}
+
+	return slices.Contains(c.Roles, role)
+}
+
+func (validator *collectionValidator) checkOrganization(value any) error {
+	v, _ := value.(CollectionOrganization)
+
+	if !v.Enabled {
+		return nil
+	}
+
+	return validation.ValidateStruct(&v,
+		validation.Field(&v.MembersCollection, validation.Required, validation.By(validator.checkMembersCollection)),
+		validation.Field(&v.Roles, validation.By(checkOrganizationRoles)),
+		validation.Field(&v.ManageRule, validation.By(validator.checkRule)),
+	)
+}
+
+func (validator *collectionValidator) checkMembersCollection(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil
+	}
+
+	collection, err := validator.app.FindCachedCollectionByNameOrId(v)
+	if err != nil || !collection.IsAuth() || collection.Id != v {
+		return validation.NewError("validation_invalid_members_collection", "The members collection must be an existing auth collection id.")
+	}
+
+	return nil
+}
+
+func checkOrganizationRoles(value any) error {
+	roles, _ := value.([]string)
+
+	for i, role := range roles {
+		if !organizationRoleRegex.MatchString(role) || len(role) > 100 {
+			return validation.Errors{
+				strconv.Itoa(i): validation.NewError("validation_invalid_role", "Invalid role name (allowed characters: letters, digits, _ and -)."),
+			}
+		}
+
+		if slices.Index(roles, role) != i {
+			return validation.Errors{
+				strconv.Itoa(i): validation.NewError("validation_duplicated_role", "Duplicated role {{.role}}.").
+					SetParams(map[string]any{"role": role}),
+			}
+		}
+	}
+
 	return nil
 }
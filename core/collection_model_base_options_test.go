@@ -0,0 +1,318 @@
+package core_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestCollectionBaseOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		collection     func(app core.App) (*core.Collection, error)
+		expectedErrors []string
+	}{
+		{
+			name: "base with zero limits",
+			collection: func(app core.App) (*core.Collection, error) {
+				c := core.NewBaseCollection("new_base")
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "base with invalid limits",
+			collection: func(app core.App) (*core.Collection, error) {
+				c := core.NewBaseCollection("new_base")
+				c.Limits = core.CollectionLimits{MaxBodySize: -1, MaxItems: -1, MaxJSONSize: -1, MaxJSONDepth: 1001}
+				return c, nil
+			},
+			expectedErrors: []string{"limits"},
+		},
+		{
+			name: "auth with invalid limits",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("users")
+				c.Limits.MaxItems = -1
+				return c, nil
+			},
+			expectedErrors: []string{"limits"},
+		},
+		{
+			name: "base with valid limits",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Limits = core.CollectionLimits{MaxBodySize: 100, MaxItems: 2, MaxJSONSize: 10, MaxJSONDepth: 3}
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "base with invalid record cache",
+			collection: func(app core.App) (*core.Collection, error) {
+				c := core.NewBaseCollection("new_base")
+				c.RecordCache = core.CollectionRecordCache{Enabled: true, MaxRecords: -1, TTL: -1}
+				return c, nil
+			},
+			expectedErrors: []string{"recordCache"},
+		},
+		{
+			name: "base with valid record cache",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.RecordCache = core.CollectionRecordCache{Enabled: true, MaxRecords: 10, TTL: 60}
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "base with missing spam score field",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.SpamScore = core.CollectionSpamScore{Enabled: true, ScoreField: "missing"}
+				return c, nil
+			},
+			expectedErrors: []string{"spamScore"},
+		},
+		{
+			name: "base with non-number spam score field",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.SpamScore = core.CollectionSpamScore{Enabled: true, ScoreField: "text"}
+				return c, nil
+			},
+			expectedErrors: []string{"spamScore"},
+		},
+		{
+			name: "base with valid spam score field",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.SpamScore = core.CollectionSpamScore{Enabled: true, ScoreField: "number"}
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "base with invalid moderation",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Moderation = core.CollectionModeration{
+					Enabled:             true,
+					InitialState:        core.ModerationStateApproved,
+					SubmitRule:          types.Pointer("missing = 1"),
+					ReviewersCollection: "demo2",
+					QuarantineScore:     1.1,
+				}
+				return c, nil
+			},
+			expectedErrors: []string{"moderation"},
+		},
+		{
+			name: "base with valid moderation",
+			collection: func(app core.App) (*core.Collection, error) {
+				users, _ := app.FindCollectionByNameOrId("users")
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Moderation = core.CollectionModeration{
+					Enabled:             true,
+					InitialState:        core.ModerationStatePending,
+					SubmitRule:          types.Pointer("@request.auth.id != ''"),
+					ReviewRule:          types.Pointer(""),
+					ReviewersCollection: users.Id,
+					QuarantineScore:     0.5,
+				}
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "base with missing expiration field",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Expiration = core.CollectionExpiration{GracePeriod: 60}
+				return c, nil
+			},
+			expectedErrors: []string{"expiration"},
+		},
+		{
+			name: "base with non-date expiration field",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Expiration = core.CollectionExpiration{Field: "text"}
+				return c, nil
+			},
+			expectedErrors: []string{"expiration"},
+		},
+		{
+			name: "base with negative expiration grace period",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Expiration = core.CollectionExpiration{Field: "datetime", GracePeriod: -1}
+				return c, nil
+			},
+			expectedErrors: []string{"expiration"},
+		},
+		{
+			name: "base with valid expiration",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Expiration = core.CollectionExpiration{Field: "datetime", GracePeriod: 60}
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "base with disabled invalid organization",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Organization = core.CollectionOrganization{MembersCollection: "missing", Roles: []string{"invalid role"}}
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "base with missing organization members collection",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Organization = core.CollectionOrganization{Enabled: true}
+				return c, nil
+			},
+			expectedErrors: []string{"organization"},
+		},
+		{
+			name: "base with non-auth organization members collection",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Organization = core.CollectionOrganization{Enabled: true, MembersCollection: "wsmn24bux7wo113"}
+				return c, nil
+			},
+			expectedErrors: []string{"organization"},
+		},
+		{
+			name: "base with duplicated organization roles",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Organization = core.CollectionOrganization{Enabled: true, MembersCollection: "_pb_users_auth_", Roles: []string{"owner", "owner"}}
+				return c, nil
+			},
+			expectedErrors: []string{"organization"},
+		},
+		{
+			name: "base with invalid organization role",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Organization = core.CollectionOrganization{Enabled: true, MembersCollection: "_pb_users_auth_", Roles: []string{"invalid role"}}
+				return c, nil
+			},
+			expectedErrors: []string{"organization"},
+		},
+		{
+			name: "base with invalid organization manage rule",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Organization = core.CollectionOrganization{Enabled: true, MembersCollection: "_pb_users_auth_", ManageRule: types.Pointer("missing = 1")}
+				return c, nil
+			},
+			expectedErrors: []string{"organization"},
+		},
+		{
+			name: "base with valid organization",
+			collection: func(app core.App) (*core.Collection, error) {
+				c, _ := app.FindCollectionByNameOrId("demo1")
+				c.Organization = core.CollectionOrganization{
+					Enabled:           true,
+					MembersCollection: "_pb_users_auth_",
+					Roles:             []string{"owner", "team-member"},
+					ManageRule:        types.Pointer(`@request.auth.memberOf(id, "owner") = true`),
+				}
+				return c, nil
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			collection, err := s.collection(app)
+			if err != nil {
+				t.Fatalf("Failed to retrieve test collection: %v", err)
+			}
+
+			result := app.Validate(collection)
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestCollectionLimitsPersistence(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	limits := core.CollectionLimits{MaxBodySize: 100, MaxItems: 2, MaxJSONSize: 10, MaxJSONDepth: 3}
+
+	for _, name := range []string{"demo1", "nologin"} {
+		t.Run(name, func(t *testing.T) {
+			collection, err := app.FindCollectionByNameOrId(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			raw, _ := json.Marshal(collection)
+			if strings.Contains(string(raw), `"limits"`) {
+				t.Fatalf("Expected zero limits to be omitted, got\n%s", raw)
+			}
+
+			collection.Limits = limits
+			if err := app.Save(collection); err != nil {
+				t.Fatal(err)
+			}
+
+			collection, err = app.FindCollectionByNameOrId(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if collection.Limits != limits {
+				t.Fatalf("Expected limits %v, got %v", limits, collection.Limits)
+			}
+
+			raw, _ = json.Marshal(collection)
+			expected := `"limits":{"maxBodySize":100,"maxItems":2,"maxJSONSize":10,"maxJSONDepth":3}`
+			if !strings.Contains(string(raw), expected) {
+				t.Fatalf("Expected %s in\n%s", expected, raw)
+			}
+
+			// unmarshal into a new collection
+			unmarshaled := &core.Collection{}
+			if err := json.Unmarshal(raw, unmarshaled); err != nil {
+				t.Fatal(err)
+			}
+			if unmarshaled.Limits != limits {
+				t.Fatalf("Expected unmarshaled limits %v, got %v", limits, unmarshaled.Limits)
+			}
+		})
+	}
+}
+
+func TestCollectionLimitsIsZero(t *testing.T) {
+	t.Parallel()
+
+	if !(core.CollectionLimits{}).IsZero() {
+		t.Fatal("Expected zero limits")
+	}
+
+	if (core.CollectionLimits{MaxJSONDepth: 1}).IsZero() {
+		t.Fatal("Expected non-zero limits")
+	}
+}
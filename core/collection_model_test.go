@@ -15,6 +15,7 @@ import (
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/dbutils"
 	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -789,7 +790,7 @@ func TestCollectionDBExport(t *testing.T) {
 		},
 		{
 			core.CollectionTypeAuth,
-			`{"createRule":"1=3","created":"2024-07-01 01:02:03.456Z","deleteRule":"1=5","fields":[{"hidden":false,"id":"f1_id","name":"f1","presentable":false,"required":false,"system":true,"type":"bool"},{"hidden":false,"id":"f2_id","name":"f2","presentable":false,"required":true,"system":false,"type":"bool"}],"id":"test_id","indexes":["CREATE INDEX idx1 on test_name(id)","CREATE INDEX idx2 on test_name(id)"],"listRule":"1=1","name":"test_name","options":{"authRule":null,"manageRule":"1=6","authAlert":{"enabled":false,"emailTemplate":{"subject":"","body":""}},"oauth2":{"providers":null,"mappedFields":{"id":"","name":"","username":"","avatarURL":""},"enabled":false},"passwordAuth":{"enabled":false,"identityFields":null},"mfa":{"enabled":false,"duration":0,"rule":""},"otp":{"enabled":false,"duration":0,"length":0,"emailTemplate":{"subject":"","body":""}},"authToken":{"duration":0},"passwordResetToken":{"duration":0},"emailChangeToken":{"duration":0},"verificationToken":{"duration":0},"fileToken":{"duration":0},"verificationTemplate":{"subject":"","body":""},"resetPasswordTemplate":{"subject":"","body":""},"confirmEmailChangeTemplate":{"subject":"","body":""}},"system":true,"type":"auth","updateRule":"1=4","updated":"2024-07-01 01:02:03.456Z","viewRule":"1=7"}`,
+			`{"createRule":"1=3","created":"2024-07-01 01:02:03.456Z","deleteRule":"1=5","fields":[{"hidden":false,"id":"f1_id","name":"f1","presentable":false,"required":false,"system":true,"type":"bool"},{"hidden":false,"id":"f2_id","name":"f2","presentable":false,"required":true,"system":false,"type":"bool"}],"id":"test_id","indexes":["CREATE INDEX idx1 on test_name(id)","CREATE INDEX idx2 on test_name(id)"],"listRule":"1=1","name":"test_name","options":{"authRule":null,"manageRule":"1=6","authAlert":{"enabled":false,"emailTemplate":{"subject":"","body":""}},"oauth2":{"providers":null,"mappedFields":{"id":"","name":"","username":"","avatarURL":""},"enabled":false},"passwordAuth":{"enabled":false,"identityFields":null},"mfa":{"enabled":false,"duration":0,"rule":""},"otp":{"enabled":false,"duration":0,"length":0,"maxAttempts":0,"emailTemplate":{"subject":"","body":""},"phoneField":"","smsTemplate":""},"authToken":{"duration":0},"passwordResetToken":{"duration":0},"emailChangeToken":{"duration":0},"verificationToken":{"duration":0},"fileToken":{"duration":0},"verificationTemplate":{"subject":"","body":""},"resetPasswordTemplate":{"subject":"","body":""},"confirmEmailChangeTemplate":{"subject":"","body":""}},"system":true,"type":"auth","updateRule":"1=4","updated":"2024-07-01 01:02:03.456Z","viewRule":"1=7"}`,
 		},
 	}
 
@@ -880,6 +881,56 @@ func TestCollectionIndexHelpers(t *testing.T) {
 	}
 }
 
+func TestCollectionAddJSONPathIndex(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	c := core.NewBaseCollection("test")
+	c.AddJSONPathIndex("idx1", false, "data", "items.0.name")
+	c.AddJSONPathIndex("idx2", true, "data", "a")
+
+	expectedIndexes := []string{
+		"CREATE INDEX `idx1` ON `test` ((CASE WHEN json_valid(`data`) THEN JSON_EXTRACT(`data`, '$.items[0].name') ELSE JSON_EXTRACT(json_object('pb', `data`), '$.pb.items[0].name') END))",
+		"CREATE UNIQUE INDEX `idx2` ON `test` ((CASE WHEN json_valid(`data`) THEN JSON_EXTRACT(`data`, '$.a') ELSE JSON_EXTRACT(json_object('pb', `data`), '$.pb.a') END))",
+	}
+	if !slices.Equal(c.Indexes, expectedIndexes) {
+		t.Fatalf("Expected indexes\n%v\ngot\n%v", expectedIndexes, c.Indexes)
+	}
+
+	// ensure that the index is used by the json path filter
+	demo4, err := app.FindCollectionByNameOrId("demo4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo4.AddJSONPathIndex("idx_json_path", false, "json_object", "a.b")
+	if err := app.Save(demo4); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := core.NewRecordFieldResolver(app, demo4, nil, false)
+	expr, err := search.FilterData("json_object.a.b = 1").BuildExpr(resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := app.RecordQuery(demo4).AndWhere(expr)
+	if err := resolver.UpdateQuery(query); err != nil {
+		t.Fatal(err)
+	}
+	built := query.Build()
+
+	plan := []dbx.NullStringMap{}
+	err = app.DB().NewQuery("EXPLAIN QUERY PLAN " + built.SQL()).Bind(built.Params()).All(&plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plan) == 0 || !strings.Contains(plan[0]["detail"].String, "USING INDEX idx_json_path") {
+		t.Fatalf("Expected the json path index to be used, got %v", plan)
+	}
+}
+
 // -------------------------------------------------------------------
 
 func TestCollectionDelete(t *testing.T) {
@@ -55,6 +55,9 @@ func (app *BaseApp) ReloadCachedCollections() error {
 
 	app.Store().Set(StoreKeyCachedCollections, collections)
 
+	// reset the resolved fields cache since it depends on the collections schema
+	app.Store().Remove(StoreKeyResolverCache)
+
 	return nil
 }
 
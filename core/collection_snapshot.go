@@ -0,0 +1,448 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+var _ Model = (*CollectionSnapshot)(nil)
+
+const CollectionSnapshotsTableName = "_collectionSnapshots"
+
+// The supported CollectionSnapshot.Trigger values.
+const (
+	CollectionSnapshotTriggerInit     = "init"
+	CollectionSnapshotTriggerCreate   = "create"
+	CollectionSnapshotTriggerUpdate   = "update"
+	CollectionSnapshotTriggerDelete   = "delete"
+	CollectionSnapshotTriggerManual   = "manual"
+	CollectionSnapshotTriggerRollback = "rollback"
+)
+
+// The supported CollectionSnapshotDiff.Action values.
+const (
+	CollectionSnapshotDiffCreated = "created"
+	CollectionSnapshotDiffUpdated = "updated"
+	CollectionSnapshotDiffDeleted = "deleted"
+)
+
+// MaxCollectionSnapshots is the max number of the most recent
+// collection snapshots that are stored (the older ones are deleted).
+const MaxCollectionSnapshots = 100
+
+const collectionSnapshotsPausedKey = "__pbCollectionSnapshotsPaused__"
+
+// CollectionSnapshot defines a snapshot of the full collections configuration.
+type CollectionSnapshot struct {
+	BaseModel
+
+	Created types.DateTime `db:"created" json:"created"`
+
+	// Trigger is the snapshot trigger (create, update, delete, manual, etc.).
+	Trigger string `db:"trigger" json:"trigger"`
+
+	// Collection is the name of the changed collection that triggered the snapshot (if any).
+	Collection string `db:"collection" json:"collection"`
+
+	// Hash is the checksum of the snapshot Collections.
+	Hash string `db:"hash" json:"hash"`
+
+	// Collections is the serialized collections configuration
+	// (in the same format as the collections import).
+	Collections types.JSONRaw `db:"collections" json:"collections,omitempty"`
+}
+
+func (m *CollectionSnapshot) TableName() string {
+	return CollectionSnapshotsTableName
+}
+
+// CollectionSnapshotDiff describes the changes of a single collection between two snapshots.
+type CollectionSnapshotDiff struct {
+	Id      string                      `json:"id"`
+	Name    string                      `json:"name"`
+	Action  string                      `json:"action"`
+	Changes []*CollectionSnapshotChange `json:"changes"`
+}
+
+// CollectionSnapshotChange describes a single collection option or field change.
+//
+// The Path is either the collection option name (eg. "listRule")
+// or "fields.{fieldName}" for the collection fields.
+type CollectionSnapshotChange struct {
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+	Path string `json:"path"`
+}
+
+// NewCollectionSnapshot creates a new (unsaved) snapshot
+// of the current app collections configuration.
+func NewCollectionSnapshot(app App, trigger string, collectionName string) (*CollectionSnapshot, error) {
+	collections := []*Collection{}
+	if err := app.CollectionQuery().OrderBy("created ASC", "id ASC").All(&collections); err != nil {
+		return nil, err
+	}
+
+	data := make([]map[string]any, len(collections))
+	for i, c := range collections {
+		raw, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+
+		item := map[string]any{}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, err
+		}
+
+		// the timestamps are excluded to allow comparing the snapshots by their hash
+		delete(item, "created")
+		delete(item, "updated")
+
+		// the view fields are autogenerated from the view query on every save
+		if c.IsView() {
+			delete(item, "fields")
+		}
+
+		data[i] = item
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(raw)
+
+	snapshot := &CollectionSnapshot{
+		Trigger:     trigger,
+		Collection:  collectionName,
+		Hash:        hex.EncodeToString(hash[:]),
+		Collections: raw,
+	}
+	snapshot.Id = GenerateDefaultRandomId()
+	snapshot.Created = types.NowDateTime()
+
+	return snapshot, nil
+}
+
+// CreateCollectionSnapshot stores a new snapshot of the current
+// app collections configuration and prunes the old ones.
+func (app *BaseApp) CreateCollectionSnapshot(trigger string, collectionName string) (*CollectionSnapshot, error) {
+	snapshot, err := NewCollectionSnapshot(app, trigger, collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	// insert directly to avoid triggering the model hooks for the internal snapshot records
+	_, err = app.AuxNonconcurrentDB().Insert(CollectionSnapshotsTableName, dbx.Params{
+		"id":          snapshot.Id,
+		"created":     snapshot.Created,
+		"trigger":     snapshot.Trigger,
+		"collection":  snapshot.Collection,
+		"hash":        snapshot.Hash,
+		"collections": snapshot.Collections,
+	}).Execute()
+	if err != nil {
+		return nil, err
+	}
+	snapshot.MarkAsNotNew()
+
+	// delete the old snapshots
+	_, err = app.AuxNonconcurrentDB().NewQuery(fmt.Sprintf(
+		"DELETE FROM {{%s}} WHERE [[id]] NOT IN (SELECT [[id]] FROM {{%s}} ORDER BY [[created]] DESC, [[rowid]] DESC LIMIT {:limit})",
+		CollectionSnapshotsTableName,
+		CollectionSnapshotsTableName,
+	)).Bind(dbx.Params{"limit": MaxCollectionSnapshots}).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// FindCollectionSnapshots returns the most recent collection snapshots (newest first)
+// WITHOUT their Collections data (use [BaseApp.FindCollectionSnapshotById] to load it).
+//
+// If limit <= 0 all stored snapshots are returned.
+func (app *BaseApp) FindCollectionSnapshots(limit int) ([]*CollectionSnapshot, error) {
+	result := []*CollectionSnapshot{}
+
+	query := app.AuxModelQuery(&CollectionSnapshot{}).
+		Select("id", "created", "trigger", "collection", "hash").
+		OrderBy("created DESC", "rowid DESC")
+
+	if limit > 0 {
+		query.Limit(int64(limit))
+	}
+
+	if err := query.All(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindCollectionSnapshotById finds the collection snapshot with the specified id.
+func (app *BaseApp) FindCollectionSnapshotById(id string) (*CollectionSnapshot, error) {
+	model := &CollectionSnapshot{}
+
+	err := app.AuxModelQuery(model).
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// RollbackCollectionSnapshot restores the collections configuration from
+// the snapshot with the specified id and stores a new "rollback" snapshot.
+//
+// NB! All non-system collections and fields that are not present in the
+// snapshot WILL BE DELETED (this includes their related records data).
+func (app *BaseApp) RollbackCollectionSnapshot(id string) (*CollectionSnapshot, error) {
+	snapshot, err := app.FindCollectionSnapshotById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// pause the automatic snapshots of the individual collection changes
+	app.Store().Set(collectionSnapshotsPausedKey, true)
+	err = app.ImportCollectionsByMarshaledJSON(snapshot.Collections, true)
+	app.Store().Remove(collectionSnapshotsPausedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.CreateCollectionSnapshot(CollectionSnapshotTriggerRollback, "")
+}
+
+// DiffCollectionSnapshots returns the collection changes between the old and new snapshots.
+func DiffCollectionSnapshots(oldSnapshot *CollectionSnapshot, newSnapshot *CollectionSnapshot) ([]*CollectionSnapshotDiff, error) {
+	oldList := []map[string]any{}
+	if err := json.Unmarshal(oldSnapshot.Collections, &oldList); err != nil {
+		return nil, fmt.Errorf("failed to parse the old snapshot: %w", err)
+	}
+
+	newList := []map[string]any{}
+	if err := json.Unmarshal(newSnapshot.Collections, &newList); err != nil {
+		return nil, fmt.Errorf("failed to parse the new snapshot: %w", err)
+	}
+
+	findById := func(list []map[string]any, id string) map[string]any {
+		for _, item := range list {
+			if cast.ToString(item["id"]) == id {
+				return item
+			}
+		}
+		return nil
+	}
+
+	result := []*CollectionSnapshotDiff{}
+
+	for _, newItem := range newList {
+		id := cast.ToString(newItem["id"])
+
+		oldItem := findById(oldList, id)
+		if oldItem == nil {
+			result = append(result, &CollectionSnapshotDiff{
+				Id:      id,
+				Name:    cast.ToString(newItem["name"]),
+				Action:  CollectionSnapshotDiffCreated,
+				Changes: []*CollectionSnapshotChange{},
+			})
+			continue
+		}
+
+		changes := diffCollectionSnapshotItems(oldItem, newItem)
+		if len(changes) > 0 {
+			result = append(result, &CollectionSnapshotDiff{
+				Id:      id,
+				Name:    cast.ToString(newItem["name"]),
+				Action:  CollectionSnapshotDiffUpdated,
+				Changes: changes,
+			})
+		}
+	}
+
+	for _, oldItem := range oldList {
+		id := cast.ToString(oldItem["id"])
+
+		if findById(newList, id) == nil {
+			result = append(result, &CollectionSnapshotDiff{
+				Id:      id,
+				Name:    cast.ToString(oldItem["name"]),
+				Action:  CollectionSnapshotDiffDeleted,
+				Changes: []*CollectionSnapshotChange{},
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func diffCollectionSnapshotItems(oldItem, newItem map[string]any) []*CollectionSnapshotChange {
+	changes := []*CollectionSnapshotChange{}
+
+	keys := []string{}
+	for k := range oldItem {
+		keys = append(keys, k)
+	}
+	for k := range newItem {
+		if _, ok := oldItem[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		if k == "fields" {
+			continue
+		}
+
+		if !reflect.DeepEqual(oldItem[k], newItem[k]) {
+			changes = append(changes, &CollectionSnapshotChange{Path: k, Old: oldItem[k], New: newItem[k]})
+		}
+	}
+
+	// fields (matched by their id)
+	oldFields, _ := oldItem["fields"].([]any)
+	newFields, _ := newItem["fields"].([]any)
+
+	fieldById := func(fields []any, id string) map[string]any {
+		for _, f := range fields {
+			if m, ok := f.(map[string]any); ok && cast.ToString(m["id"]) == id {
+				return m
+			}
+		}
+		return nil
+	}
+
+	for _, f := range newFields {
+		newField, _ := f.(map[string]any)
+		oldField := fieldById(oldFields, cast.ToString(newField["id"]))
+
+		if oldField == nil {
+			changes = append(changes, &CollectionSnapshotChange{Path: "fields." + cast.ToString(newField["name"]), New: newField})
+		} else if !reflect.DeepEqual(oldField, newField) {
+			changes = append(changes, &CollectionSnapshotChange{Path: "fields." + cast.ToString(newField["name"]), Old: oldField, New: newField})
+		}
+	}
+
+	for _, f := range oldFields {
+		oldField, _ := f.(map[string]any)
+
+		if fieldById(newFields, cast.ToString(oldField["id"])) == nil {
+			changes = append(changes, &CollectionSnapshotChange{Path: "fields." + cast.ToString(oldField["name"]), Old: oldField})
+		}
+	}
+
+	return changes
+}
+
+// registerCollectionSnapshotsHooks registers the automatic collection snapshots app hooks.
+func (app *BaseApp) registerCollectionSnapshotsHooks() {
+	snapshot := func(a App, trigger string, collection string) {
+		if paused, _ := a.Store().Get(collectionSnapshotsPausedKey).(bool); paused {
+			return
+		}
+
+		// e.g. the system migrations that are executed before the snapshots table creation
+		if !a.AuxHasTable(CollectionSnapshotsTableName) {
+			return
+		}
+
+		var err error
+		defer func() {
+			if err != nil {
+				a.Logger().Warn(
+					"Failed to create collections snapshot",
+					slog.String("collection", collection),
+					slog.String("error", err.Error()),
+				)
+			}
+		}()
+
+		current, err := NewCollectionSnapshot(a, trigger, collection)
+		if err != nil {
+			return
+		}
+
+		// skip if there are no actual changes since the last snapshot
+		latest := &CollectionSnapshot{}
+		err = a.AuxModelQuery(latest).
+			Select("id", "hash").
+			OrderBy("created DESC", "rowid DESC").
+			Limit(1).
+			One(latest)
+		if err == nil && latest.Hash == current.Hash {
+			return
+		}
+
+		_, err = a.CreateCollectionSnapshot(trigger, collection)
+	}
+
+	handler := func(trigger string) func(e *CollectionEvent) error {
+		return func(e *CollectionEvent) error {
+			if err := e.Next(); err != nil {
+				return err
+			}
+
+			snapshot(e.App, trigger, e.Collection.Name)
+
+			return nil
+		}
+	}
+
+	app.OnCollectionAfterCreateSuccess().Bind(&hook.Handler[*CollectionEvent]{
+		Id:       "__pbCollectionSnapshotCreate__",
+		Func:     handler(CollectionSnapshotTriggerCreate),
+		Priority: 999,
+	})
+
+	app.OnCollectionAfterUpdateSuccess().Bind(&hook.Handler[*CollectionEvent]{
+		Id:       "__pbCollectionSnapshotUpdate__",
+		Func:     handler(CollectionSnapshotTriggerUpdate),
+		Priority: 999,
+	})
+
+	app.OnCollectionAfterDeleteSuccess().Bind(&hook.Handler[*CollectionEvent]{
+		Id:       "__pbCollectionSnapshotDelete__",
+		Func:     handler(CollectionSnapshotTriggerDelete),
+		Priority: 999,
+	})
+
+	// initial snapshot
+	app.OnBootstrap().BindFunc(func(e *BootstrapEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		if !e.App.AuxHasTable(CollectionSnapshotsTableName) {
+			return nil
+		}
+
+		var total int
+		err := e.App.AuxDB().Select("count(*)").From(CollectionSnapshotsTableName).Row(&total)
+		if err == nil && total == 0 {
+			_, err = e.App.CreateCollectionSnapshot(CollectionSnapshotTriggerInit, "")
+		}
+		if err != nil {
+			e.App.Logger().Warn("Failed to create the initial collections snapshot", slog.String("error", err.Error()))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,227 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCollectionSnapshotsAutoCreate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	snapshots, err := app.FindCollectionSnapshots(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) == 0 {
+		t.Fatal("Expected at least one snapshot after the app bootstrap")
+	}
+	if len(snapshots[0].Collections) != 0 {
+		t.Fatal("Expected the snapshots list to not load the collections data")
+	}
+	initial := snapshots[0]
+	total := len(snapshots)
+
+	collection := core.NewBaseCollection("snapshot_test")
+	collection.Fields.Add(&core.TextField{Name: "title"})
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// no actual changes
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Fields.Add(&core.BoolField{Name: "active"})
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Delete(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err = app.FindCollectionSnapshots(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		core.CollectionSnapshotTriggerDelete,
+		core.CollectionSnapshotTriggerUpdate,
+		core.CollectionSnapshotTriggerCreate,
+	}
+	if len(snapshots) != total+len(expected) {
+		t.Fatalf("Expected %d snapshots, got %d", total+len(expected), len(snapshots))
+	}
+	for i, trigger := range expected {
+		if snapshots[i].Trigger != trigger {
+			t.Fatalf("Expected snapshot %d trigger %q, got %q", i, trigger, snapshots[i].Trigger)
+		}
+		if snapshots[i].Collection != "snapshot_test" {
+			t.Fatalf("Expected snapshot %d collection snapshot_test, got %q", i, snapshots[i].Collection)
+		}
+	}
+
+	// the initial and the delete snapshots have the same collections configuration
+	if snapshots[0].Hash != initial.Hash {
+		t.Fatalf("Expected the initial and delete snapshots hashes to match, got %q vs %q", initial.Hash, snapshots[0].Hash)
+	}
+
+	limited, err := app.FindCollectionSnapshots(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(limited))
+	}
+}
+
+func TestDiffCollectionSnapshots(t *testing.T) {
+	t.Parallel()
+
+	oldSnapshot := &core.CollectionSnapshot{Collections: []byte(`[
+		{"id":"a","name":"a","listRule":null,"fields":[{"id":"f1","name":"title","type":"text"},{"id":"f2","name":"old","type":"bool"}]},
+		{"id":"b","name":"b","fields":[]},
+		{"id":"c","name":"c","fields":[]}
+	]`)}
+
+	newSnapshot := &core.CollectionSnapshot{Collections: []byte(`[
+		{"id":"a","name":"a","listRule":"","fields":[{"id":"f1","name":"title","type":"text","max":10},{"id":"f3","name":"new","type":"bool"}]},
+		{"id":"b","name":"b","fields":[]},
+		{"id":"d","name":"d","fields":[]}
+	]`)}
+
+	diff, err := core.DiffCollectionSnapshots(oldSnapshot, newSnapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diff) != 3 {
+		t.Fatalf("Expected 3 changed collections, got %d", len(diff))
+	}
+
+	expected := []struct {
+		id     string
+		action string
+		paths  []string
+	}{
+		{"a", core.CollectionSnapshotDiffUpdated, []string{"listRule", "fields.title", "fields.new", "fields.old"}},
+		{"d", core.CollectionSnapshotDiffCreated, nil},
+		{"c", core.CollectionSnapshotDiffDeleted, nil},
+	}
+
+	for i, e := range expected {
+		if diff[i].Id != e.id || diff[i].Action != e.action {
+			t.Fatalf("[%d] Expected %s %s, got %s %s", i, e.action, e.id, diff[i].Action, diff[i].Id)
+		}
+
+		if len(diff[i].Changes) != len(e.paths) {
+			t.Fatalf("[%d] Expected %d changes, got %d", i, len(e.paths), len(diff[i].Changes))
+		}
+		for j, path := range e.paths {
+			if diff[i].Changes[j].Path != path {
+				t.Fatalf("[%d] Expected change %d path %q, got %q", i, j, path, diff[i].Changes[j].Path)
+			}
+		}
+	}
+
+	if _, err := core.DiffCollectionSnapshots(&core.CollectionSnapshot{Collections: []byte(`invalid`)}, newSnapshot); err == nil {
+		t.Fatal("Expected invalid snapshot error")
+	}
+}
+
+func TestRollbackCollectionSnapshot(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.RollbackCollectionSnapshot("missing"); err == nil {
+		t.Fatal("Expected missing snapshot error")
+	}
+
+	initial, err := app.CreateCollectionSnapshot(core.CollectionSnapshotTriggerManual, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection := core.NewBaseCollection("snapshot_test")
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	demo2, err := app.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo2.ListRule = nil
+	demo2.Fields.Add(&core.TextField{Name: "extra"})
+	if err := app.Save(demo2); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := app.RollbackCollectionSnapshot(initial.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Trigger != core.CollectionSnapshotTriggerRollback {
+		t.Fatalf("Expected rollback snapshot, got %q", result.Trigger)
+	}
+	if result.Hash != initial.Hash {
+		t.Fatalf("Expected the rollback snapshot hash to match the initial one, got %q vs %q", result.Hash, initial.Hash)
+	}
+
+	if _, err := app.FindCollectionByNameOrId("snapshot_test"); err == nil {
+		t.Fatal("Expected the snapshot_test collection to be deleted")
+	}
+
+	demo2, err = app.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if demo2.ListRule == nil || demo2.Fields.GetByName("extra") != nil {
+		t.Fatalf("Expected demo2 listRule to be restored and extra field to be deleted")
+	}
+
+	// no individual collection snapshots should be created during the rollback
+	snapshots, err := app.FindCollectionSnapshots(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Id != result.Id {
+		t.Fatalf("Expected the latest snapshot to be the rollback one, got %#v", snapshots)
+	}
+}
+
+func TestCreateCollectionSnapshotPrune(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	for range core.MaxCollectionSnapshots + 5 {
+		if _, err := app.CreateCollectionSnapshot(core.CollectionSnapshotTriggerManual, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshots, err := app.FindCollectionSnapshots(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != core.MaxCollectionSnapshots {
+		t.Fatalf("Expected %d snapshots, got %d", core.MaxCollectionSnapshots, len(snapshots))
+	}
+	for _, s := range snapshots {
+		if s.Trigger != core.CollectionSnapshotTriggerManual {
+			t.Fatalf("Expected only manual snapshots, got %q", s.Trigger)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const (
+	// DefaultCollectionStatsTTL is the duration after which the cached
+	// collection statistics are lazily refreshed (see [BaseApp.CollectionStats]).
+	DefaultCollectionStatsTTL = 5 * time.Minute
+
+	// DefaultCollectionStatsLargestRecords is the number of the
+	// largest records reported per collection.
+	DefaultCollectionStatsLargestRecords = 5
+)
+
+// CollectionStatsReport defines the result of [BaseApp.CollectionStats].
+type CollectionStatsReport struct {
+	Collections []*CollectionStats `json:"collections"`
+	Generated   types.DateTime     `json:"generated"`
+}
+
+// CollectionStats describes the storage statistics of a single collection.
+type CollectionStats struct {
+	// Collection is the collection name.
+	Collection string `json:"collection"`
+
+	// Rows is the number of the collection records.
+	Rows int64 `json:"rows"`
+
+	// DataSize is the approximate size in bytes of the stored
+	// records data (the sum of all column values lengths).
+	DataSize int64 `json:"dataSize"`
+
+	// Size is the approximate disk size in bytes of the collection
+	// table and its indexes (0 if the SQLite "dbstat" virtual table is not available).
+	Size int64 `json:"size"`
+
+	// LargestRecords lists the records with the largest DataSize (sorted DESC).
+	LargestRecords []*RecordSizeStats `json:"largestRecords"`
+
+	// Indexes lists the statistics of the collection table indexes.
+	Indexes []*CollectionIndexStats `json:"indexes"`
+}
+
+// RecordSizeStats describes the approximate data size of a single record.
+type RecordSizeStats struct {
+	Id   string `db:"id" json:"id"`
+	Size int64  `db:"size" json:"size"`
+}
+
+// CollectionIndexStats describes the statistics of a single collection index.
+//
+// Note that SQLite doesn't track the index usage at runtime so the
+// index hit statistics are based on the ANALYZE collected data
+// (see [BaseApp.OptimizeDB]) and on the recorded slow queries.
+type CollectionIndexStats struct {
+	// Name is the index name.
+	Name string `json:"name"`
+
+	// Columns lists the indexed columns.
+	Columns []string `json:"columns"`
+
+	// Unique indicates whether the index is UNIQUE.
+	Unique bool `json:"unique"`
+
+	// Size is the approximate disk size of the index in bytes
+	// (0 if the SQLite "dbstat" virtual table is not available).
+	Size int64 `json:"size"`
+
+	// Rows is the ANALYZE approximate number of the indexed rows
+	// (0 if the database wasn't analyzed yet).
+	Rows int64 `json:"rows"`
+
+	// RowsPerKey is the ANALYZE average number of rows matched by
+	// a single lookup of all index columns (aka. the lower the better).
+	RowsPerKey int64 `json:"rowsPerKey"`
+
+	// SlowQueryHits is the number of the currently recorded slow
+	// queries (see [BaseApp.SlowQueries]) filtering by the index leading column.
+	SlowQueryHits int `json:"slowQueryHits"`
+}
+
+type collectionStatsCache struct {
+	mu     sync.Mutex
+	report *CollectionStatsReport
+}
+
+// CollectionStats returns the storage statistics (row counts, approximate
+// disk size, largest records and index statistics) of all non-view collections.
+//
+// The statistics are computed lazily and cached for [DefaultCollectionStatsTTL]
+// (use [BaseApp.RefreshCollectionStats] to force their recalculation).
+func (app *BaseApp) CollectionStats() (*CollectionStatsReport, error) {
+	app.collectionStats.mu.Lock()
+	defer app.collectionStats.mu.Unlock()
+
+	report := app.collectionStats.report
+	if report != nil && time.Since(report.Generated.Time()) < DefaultCollectionStatsTTL {
+		return report, nil
+	}
+
+	return app.refreshCollectionStats()
+}
+
+// RefreshCollectionStats recalculates and caches the collection statistics.
+func (app *BaseApp) RefreshCollectionStats() (*CollectionStatsReport, error) {
+	app.collectionStats.mu.Lock()
+	defer app.collectionStats.mu.Unlock()
+
+	return app.refreshCollectionStats()
+}
+
+func (app *BaseApp) refreshCollectionStats() (*CollectionStatsReport, error) {
+	collections, err := app.FindAllCollections(CollectionTypeBase, CollectionTypeAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(collections, func(a, b *Collection) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// per table/index disk size (dbstat is optional and it may not be compiled in custom drivers)
+	sizes := map[string]int64{}
+	sizeRows := []struct {
+		Name string `db:"name"`
+		Size int64  `db:"size"`
+	}{}
+	if err := app.DB().NewQuery("SELECT [[name]], SUM([[pgsize]]) AS [[size]] FROM dbstat GROUP BY [[name]]").All(&sizeRows); err == nil {
+		for _, row := range sizeRows {
+			sizes[row.Name] = row.Size
+		}
+	}
+
+	// ANALYZE stats (sqlite_stat1 exists only after the first ANALYZE)
+	analyzed := map[string]string{}
+	statRows := []*DBIndexStat{}
+	err = app.DB().Select("tbl", "idx", "stat").
+		From("sqlite_stat1").
+		Where(dbx.NewExp("[[idx]] IS NOT NULL")).
+		All(&statRows)
+	if err == nil {
+		for _, row := range statRows {
+			analyzed[row.Index] = row.Stat
+		}
+	}
+
+	// slow queries leading columns usage
+	slowColumns := map[string]int{}
+	for _, q := range app.SlowQueries() {
+		for _, match := range extractSQLWhereColumns(q.SQL) {
+			slowColumns[strings.ToLower(match[0]+"."+match[1])]++
+		}
+	}
+
+	report := &CollectionStatsReport{
+		Collections: make([]*CollectionStats, 0, len(collections)),
+		Generated:   types.NowDateTime(),
+	}
+
+	for _, collection := range collections {
+		stats, err := app.computeCollectionStats(collection, sizes, analyzed, slowColumns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect the %q stats: %w", collection.Name, err)
+		}
+
+		report.Collections = append(report.Collections, stats)
+	}
+
+	app.collectionStats.report = report
+
+	return report, nil
+}
+
+func (app *BaseApp) computeCollectionStats(
+	collection *Collection,
+	sizes map[string]int64,
+	analyzed map[string]string,
+	slowColumns map[string]int,
+) (*CollectionStats, error) {
+	stats := &CollectionStats{
+		Collection:     collection.Name,
+		Size:           sizes[collection.Name],
+		LargestRecords: []*RecordSizeStats{},
+		Indexes:        []*CollectionIndexStats{},
+	}
+
+	columnSizes := make([]string, 0, len(collection.Fields))
+	for _, name := range collection.Fields.FieldNames() {
+		columnSizes = append(columnSizes, "COALESCE(LENGTH(CAST([["+name+"]] AS BLOB)), 0)")
+	}
+	sizeExpr := strings.Join(columnSizes, " + ")
+
+	totals := struct {
+		Rows     int64 `db:"rows"`
+		DataSize int64 `db:"dataSize"`
+	}{}
+	err := app.DB().Select("COUNT(*) AS [[rows]]", "COALESCE(SUM("+sizeExpr+"), 0) AS [[dataSize]]").
+		From(collection.Name).
+		One(&totals)
+	if err != nil {
+		return nil, err
+	}
+	stats.Rows = totals.Rows
+	stats.DataSize = totals.DataSize
+
+	if stats.Rows > 0 {
+		err = app.DB().Select("id", "("+sizeExpr+") AS [[size]]").
+			From(collection.Name).
+			OrderBy("size DESC", "id ASC").
+			Limit(DefaultCollectionStatsLargestRecords).
+			All(&stats.LargestRecords)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	indexes := []struct {
+		Name   string `db:"name"`
+		Unique bool   `db:"unique"`
+	}{}
+	err = app.DB().NewQuery("SELECT [[name]], [[unique]] FROM pragma_index_list({:table}) ORDER BY [[name]]").
+		Bind(dbx.Params{"table": collection.Name}).
+		All(&indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, idx := range indexes {
+		indexStats := &CollectionIndexStats{
+			Name:    idx.Name,
+			Unique:  idx.Unique,
+			Size:    sizes[idx.Name],
+			Columns: []string{},
+		}
+
+		err := app.DB().NewQuery("SELECT COALESCE([[name]], '') FROM pragma_index_info({:index}) ORDER BY [[seqno]]").
+			Bind(dbx.Params{"index": idx.Name}).
+			Column(&indexStats.Columns)
+		if err != nil {
+			return nil, err
+		}
+
+		// "rows avgRowsPerColumn1 avgRowsPerColumn2..."
+		if parts := strings.Fields(analyzed[idx.Name]); len(parts) > 0 {
+			indexStats.Rows, _ = strconv.ParseInt(parts[0], 10, 64)
+			if len(parts) > 1 {
+				indexStats.RowsPerKey, _ = strconv.ParseInt(parts[len(parts)-1], 10, 64)
+			}
+		}
+
+		if len(indexStats.Columns) > 0 {
+			indexStats.SlowQueryHits = slowColumns[strings.ToLower(collection.Name+"."+indexStats.Columns[0])]
+		}
+
+		stats.Indexes = append(stats.Indexes, indexStats)
+		stats.Size += indexStats.Size
+	}
+
+	return stats, nil
+}
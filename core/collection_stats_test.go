@@ -0,0 +1,130 @@
+package core_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCollectionStats(t *testing.T) {
+	t.Parallel()
+
+	posts := core.NewBaseCollection("posts")
+	posts.Fields.Add(&core.TextField{Name: "title"})
+	posts.AddIndex("idx_posts_title", true, "title", "")
+
+	app := tests.NewTestAppBuilder(t).
+		WithCollections(posts).
+		WithRecords("posts",
+			map[string]any{"id": "postsmall000000", "title": "a"},
+			map[string]any{"id": "postlarge000000", "title": strings.Repeat("b", 1000)},
+			map[string]any{"id": "postmedium00000", "title": strings.Repeat("c", 100)},
+		).
+		Build()
+
+	findPosts := func(report *core.CollectionStatsReport) *core.CollectionStats {
+		for _, c := range report.Collections {
+			if c.Collection == "posts" {
+				return c
+			}
+		}
+		t.Fatal("Missing posts stats")
+		return nil
+	}
+
+	report, err := app.CollectionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range report.Collections {
+		if c.Collection == "posts" {
+			continue
+		}
+		if !strings.HasPrefix(c.Collection, "_") && c.Collection != "users" {
+			t.Fatalf("Unexpected collection %q", c.Collection)
+		}
+	}
+
+	stats := findPosts(report)
+
+	if stats.Rows != 3 {
+		t.Fatalf("Expected 3 rows, got %d", stats.Rows)
+	}
+
+	if stats.DataSize < 1101 {
+		t.Fatalf("Expected data size of at least 1101 bytes, got %d", stats.DataSize)
+	}
+
+	if stats.Size <= 0 {
+		t.Fatalf("Expected positive disk size, got %d", stats.Size)
+	}
+
+	expectedOrder := []string{"postlarge000000", "postmedium00000", "postsmall000000"}
+	if len(stats.LargestRecords) != len(expectedOrder) {
+		t.Fatalf("Expected %d largest records, got %d", len(expectedOrder), len(stats.LargestRecords))
+	}
+	for i, id := range expectedOrder {
+		if stats.LargestRecords[i].Id != id {
+			t.Fatalf("Expected largest record %d to be %q, got %q", i, id, stats.LargestRecords[i].Id)
+		}
+	}
+
+	var titleIndex *core.CollectionIndexStats
+	for _, idx := range stats.Indexes {
+		if idx.Name == "idx_posts_title" {
+			titleIndex = idx
+		}
+	}
+	if titleIndex == nil {
+		t.Fatal("Missing idx_posts_title stats")
+	}
+	if !titleIndex.Unique || len(titleIndex.Columns) != 1 || titleIndex.Columns[0] != "title" {
+		t.Fatalf("Unexpected index stats %+v", titleIndex)
+	}
+	if titleIndex.Rows != 0 {
+		t.Fatalf("Expected no ANALYZE rows before optimize, got %d", titleIndex.Rows)
+	}
+
+	// new records are not visible until the cache is refreshed
+	collection, err := app.FindCollectionByNameOrId("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := core.NewRecord(collection)
+	record.Set("title", "d")
+	if err := app.Save(record); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = app.CollectionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows := findPosts(report).Rows; rows != 3 {
+		t.Fatalf("Expected the cached 3 rows, got %d", rows)
+	}
+
+	if _, err := app.OptimizeDB(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = app.RefreshCollectionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats = findPosts(report)
+	if stats.Rows != 4 {
+		t.Fatalf("Expected 4 rows after refresh, got %d", stats.Rows)
+	}
+
+	for _, idx := range stats.Indexes {
+		if idx.Name == "idx_posts_title" && (idx.Rows != 4 || idx.RowsPerKey != 1) {
+			t.Fatalf("Expected ANALYZE stats 4 rows and 1 row per key, got %d and %d", idx.Rows, idx.RowsPerKey)
+		}
+	}
+}
@@ -685,10 +685,22 @@ func (cv *collectionValidator) checkIndexes(value any) error {
 func (validator *collectionValidator) validateOptions() error {
 	switch validator.new.Type {
 	case CollectionTypeAuth:
-		return validator.new.collectionAuthOptions.validate(validator)
+		return validators.JoinValidationErrors(
+			validator.new.collectionAPIOptions.validate(validator),
+			validators.JoinValidationErrors(
+				validator.new.collectionBaseOptions.validate(validator),
+				validator.new.collectionAuthOptions.validate(validator),
+			),
+		)
 	case CollectionTypeView:
-		return validator.new.collectionViewOptions.validate(validator)
+		return validators.JoinValidationErrors(
+			validator.new.collectionAPIOptions.validate(validator),
+			validator.new.collectionViewOptions.validate(validator),
+		)
+	default:
+		return validators.JoinValidationErrors(
+			validator.new.collectionAPIOptions.validate(validator),
+			validator.new.collectionBaseOptions.validate(validator),
+		)
 	}
-
-	return nil
 }
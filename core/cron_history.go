@@ -0,0 +1,338 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var (
+	_ Model = (*CronJobState)(nil)
+	_ Model = (*CronRun)(nil)
+)
+
+const (
+	CronJobsTableName = "_cronJobs"
+	CronRunsTableName = "_cronRuns"
+)
+
+// The supported CronRun.Trigger values.
+const (
+	CronTriggerSchedule = "schedule"
+	CronTriggerManual   = "manual"
+)
+
+// The supported CronRun.Status values.
+const (
+	CronRunStatusSuccess = "success"
+	CronRunStatusError   = "error"
+	CronRunStatusSkipped = "skipped"
+)
+
+// MaxCronRunsPerJob is the max number of the most recent runs
+// that are stored for each cron job (the older ones are deleted).
+const MaxCronRunsPerJob = 100
+
+// CronJobState defines the persisted state of a registered cron job.
+type CronJobState struct {
+	BaseModel
+
+	Created       types.DateTime `db:"created" json:"created"`
+	Updated       types.DateTime `db:"updated" json:"updated"`
+	DisabledUntil types.DateTime `db:"disabledUntil" json:"disabledUntil"`
+	Expression    string         `db:"expression" json:"expression"`
+	Disabled      bool           `db:"disabled" json:"disabled"`
+}
+
+func (m *CronJobState) TableName() string {
+	return CronJobsTableName
+}
+
+// IsDisabledAt reports whether the job is disabled at the specified time.
+//
+// A job with zero DisabledUntil is disabled until explicitly enabled.
+func (m *CronJobState) IsDisabledAt(t time.Time) bool {
+	if !m.Disabled {
+		return false
+	}
+
+	return m.DisabledUntil.IsZero() || t.Before(m.DisabledUntil.Time())
+}
+
+// CronRun defines a single cron job execution.
+type CronRun struct {
+	BaseModel
+
+	Started  types.DateTime `db:"started" json:"started"`
+	Job      string         `db:"job" json:"job"`
+	Trigger  string         `db:"trigger" json:"trigger"`
+	Status   string         `db:"status" json:"status"`
+	Error    string         `db:"error" json:"error"`
+	Duration int64          `db:"duration" json:"duration"` // in ms
+	Attempts int            `db:"attempts" json:"attempts"`
+}
+
+func (m *CronRun) TableName() string {
+	return CronRunsTableName
+}
+
+// FindCronJobState finds the persisted state of the cron job with the specified id.
+func (app *BaseApp) FindCronJobState(jobId string) (*CronJobState, error) {
+	model := &CronJobState{}
+
+	err := app.AuxModelQuery(model).
+		AndWhere(dbx.HashExp{"id": jobId}).
+		Limit(1).
+		One(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindCronRuns returns the most recent runs of the cron job
+// with the specified id (newest first).
+//
+// If limit <= 0 all stored runs are returned.
+func (app *BaseApp) FindCronRuns(jobId string, limit int) ([]*CronRun, error) {
+	result := []*CronRun{}
+
+	query := app.AuxModelQuery(&CronRun{}).
+		AndWhere(dbx.HashExp{"job": jobId}).
+		OrderBy("started DESC", "rowid DESC")
+
+	if limit > 0 {
+		query.Limit(int64(limit))
+	}
+
+	if err := query.All(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DisableCronJob disables the scheduled execution of the
+// registered cron job with the specified id until the specified time
+// (use zero until to disable it until explicitly enabled with [BaseApp.EnableCronJob]).
+//
+// Note that the job could still be triggered manually with [BaseApp.RunCronJob].
+func (app *BaseApp) DisableCronJob(jobId string, until time.Time) error {
+	job := findCronJob(app, jobId)
+	if job == nil {
+		return fmt.Errorf("missing cron job %q", jobId)
+	}
+
+	state, err := syncCronJobState(app, job)
+	if err != nil {
+		return err
+	}
+
+	state.Disabled = true
+	state.DisabledUntil, _ = types.ParseDateTime(until)
+	state.Updated = types.NowDateTime()
+
+	return app.AuxSaveNoValidate(state)
+}
+
+// EnableCronJob enables the scheduled execution of
+// the previously disabled cron job with the specified id.
+func (app *BaseApp) EnableCronJob(jobId string) error {
+	job := findCronJob(app, jobId)
+	if job == nil {
+		return fmt.Errorf("missing cron job %q", jobId)
+	}
+
+	state, err := syncCronJobState(app, job)
+	if err != nil {
+		return err
+	}
+
+	state.Disabled = false
+	state.DisabledUntil = types.DateTime{}
+	state.Updated = types.NowDateTime()
+
+	return app.AuxSaveNoValidate(state)
+}
+
+// RunCronJob executes immediately the registered cron job with the specified id
+// (regardless of its disabled state) and stores the run in its history.
+//
+// The job timeout, retries and overlap options apply only for the scheduled runs.
+//
+// Returns an error if the job is missing or if the history couldn't be stored
+// (the job panics are recovered and reported with [App.ReportPanic]).
+func (app *BaseApp) RunCronJob(jobId string) error {
+	job := findCronJob(app, jobId)
+	if job == nil {
+		return fmt.Errorf("missing cron job %q", jobId)
+	}
+
+	return trackCronRun(app, job, CronTriggerManual, func() (result cron.RunResult) {
+		result.Attempts = 1
+
+		defer func() {
+			if r := recover(); r != nil {
+				result.Error = fmt.Errorf("panic: %v", r)
+				if reportErr := app.ReportPanic(PanicSourceCron, job.Id(), r, debug.Stack()); reportErr != nil {
+					app.Logger().Error("Failed to report panic", slog.String("source", PanicSourceCron), slog.String("error", reportErr.Error()))
+				}
+			}
+		}()
+
+		result.Error = job.RunContext(context.Background())
+
+		return result
+	})
+}
+
+// cronRunWrapper returns the app cron [cron.RunWrapper] that skips
+// the disabled jobs and stores the history of the scheduled runs.
+//
+// If locker is set, the scheduled run is executed only if its lock is acquired
+// (aka. it hasn't been already executed by another app instance).
+func cronRunWrapper(app App, locker CronLocker) cron.RunWrapper {
+	return func(job *cron.Job, scheduled time.Time, run func() cron.RunResult) {
+		state, err := app.FindCronJobState(job.Id())
+		if err == nil && state.IsDisabledAt(time.Now()) {
+			return
+		}
+
+		if locker != nil {
+			key := job.Id() + "@" + scheduled.UTC().Format(time.RFC3339Nano)
+
+			acquired, err := locker.TryLock(context.Background(), key, DefaultCronLockTTL)
+			if err != nil {
+				app.Logger().Warn(
+					"Failed to acquire the cron job run lock",
+					slog.String("job", job.Id()),
+					slog.String("error", err.Error()),
+				)
+				return
+			}
+
+			if !acquired {
+				return // already executed by another instance
+			}
+		}
+
+		if err := trackCronRun(app, job, CronTriggerSchedule, run); err != nil {
+			app.Logger().Warn(
+				"Failed to store the cron job run",
+				slog.String("job", job.Id()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// trackCronRun executes run and stores its result as new job CronRun.
+func trackCronRun(app App, job *cron.Job, trigger string, run func() cron.RunResult) error {
+	started := time.Now()
+
+	result := run()
+
+	model := &CronRun{
+		Job:      job.Id(),
+		Trigger:  trigger,
+		Status:   CronRunStatusSuccess,
+		Attempts: result.Attempts,
+		Duration: time.Since(started).Milliseconds(),
+	}
+	model.Id = GenerateDefaultRandomId()
+	model.Started, _ = types.ParseDateTime(started)
+	if result.Error != nil {
+		model.Error = result.Error.Error()
+		if errors.Is(result.Error, cron.ErrRunSkipped) {
+			model.Status = CronRunStatusSkipped
+		} else {
+			model.Status = CronRunStatusError
+		}
+	}
+
+	if _, err := syncCronJobState(app, job); err != nil {
+		return err
+	}
+
+	if err := app.AuxSaveNoValidate(model); err != nil {
+		return err
+	}
+
+	// keep only the most recent runs
+	_, err := app.AuxNonconcurrentDB().NewQuery(
+		"DELETE FROM {{" + CronRunsTableName + "}} WHERE [[job]] = {:job} AND [[id]] NOT IN (" +
+			"SELECT [[id]] FROM {{" + CronRunsTableName + "}} WHERE [[job]] = {:job} ORDER BY [[started]] DESC, [[rowid]] DESC LIMIT {:limit}" +
+			")",
+	).Bind(dbx.Params{"job": job.Id(), "limit": MaxCronRunsPerJob}).Execute()
+
+	return err
+}
+
+// syncCronJobState returns the persisted state of the specified job
+// (creating or updating it if the job expression has changed).
+func syncCronJobState(app App, job *cron.Job) (*CronJobState, error) {
+	state, err := app.FindCronJobState(job.Id())
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		state = &CronJobState{}
+		state.Id = job.Id()
+		state.Created = types.NowDateTime()
+	} else if state.Expression == job.Expression() {
+		return state, nil
+	}
+
+	state.Expression = job.Expression()
+	state.Updated = types.NowDateTime()
+
+	if err := app.AuxSaveNoValidate(state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func findCronJob(app App, jobId string) *cron.Job {
+	for _, j := range app.Cron().Jobs() {
+		if j.Id() == jobId {
+			return j
+		}
+	}
+
+	return nil
+}
+
+// registerCronHistoryHooks registers the cron jobs state persistence app hooks.
+func (app *BaseApp) registerCronHistoryHooks() {
+	app.cron.SetRunWrapper(cronRunWrapper(app, app.cronLocker))
+
+	// persist the definitions of the jobs registered until the app start
+	app.OnServe().BindFunc(func(e *ServeEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		for _, job := range e.App.Cron().Jobs() {
+			if _, err := syncCronJobState(e.App, job); err != nil {
+				e.App.Logger().Warn(
+					"Failed to persist the cron job state",
+					slog.String("job", job.Id()),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+
+		return nil
+	})
+}
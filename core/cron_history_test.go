@@ -0,0 +1,265 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestCronJobStateIsDisabledAt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	future, _ := types.ParseDateTime(now.Add(1 * time.Hour))
+	past, _ := types.ParseDateTime(now.Add(-1 * time.Hour))
+
+	scenarios := []struct {
+		name     string
+		state    *core.CronJobState
+		expected bool
+	}{
+		{"enabled", &core.CronJobState{}, false},
+		{"enabled with until date", &core.CronJobState{DisabledUntil: future}, false},
+		{"disabled without until date", &core.CronJobState{Disabled: true}, true},
+		{"disabled with future until date", &core.CronJobState{Disabled: true, DisabledUntil: future}, true},
+		{"disabled with past until date", &core.CronJobState{Disabled: true, DisabledUntil: past}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if v := s.state.IsDisabledAt(now); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestRunCronJob(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := app.RunCronJob("missing"); err == nil {
+		t.Fatal("Expected missing job error")
+	}
+
+	var calls atomic.Int32
+	app.Cron().MustAdd("test", "* * * * *", func() {
+		if calls.Add(1) == 2 {
+			panic("test_panic")
+		}
+	})
+
+	for range core.MaxCronRunsPerJob + 2 {
+		if err := app.RunCronJob("test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state, err := app.FindCronJobState("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Expression != "* * * * *" || state.Disabled {
+		t.Fatalf("Unexpected job state %#v", state)
+	}
+
+	runs, err := app.FindCronRuns("test", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(runs) != core.MaxCronRunsPerJob {
+		t.Fatalf("Expected %d runs, got %d", core.MaxCronRunsPerJob, len(runs))
+	}
+
+	for _, r := range runs {
+		if r.Trigger != core.CronTriggerManual || r.Job != "test" {
+			t.Fatalf("Unexpected run %#v", r)
+		}
+	}
+
+	// the oldest panicked run should have been pruned
+	for _, r := range runs {
+		if r.Error != "" {
+			t.Fatalf("Expected the panicked run to be pruned, got %#v", r)
+		}
+	}
+
+	limited, err := app.FindCronRuns("test", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 5 || limited[0].Id != runs[0].Id {
+		t.Fatalf("Expected the 5 most recent runs, got %d", len(limited))
+	}
+}
+
+func TestRunCronJobPanic(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Cron().MustAdd("test", "* * * * *", func() {
+		panic("test_panic")
+	})
+
+	if err := app.RunCronJob("test"); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := app.FindCronRuns("test", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(runs) != 1 || runs[0].Error != "panic: test_panic" {
+		t.Fatalf("Expected 1 run with panic error, got %v", runs)
+	}
+
+	if runs[0].Status != core.CronRunStatusError || runs[0].Attempts != 1 {
+		t.Fatalf("Expected error run with 1 attempt, got %#v", runs[0])
+	}
+}
+
+func TestCronJobOptionsHistory(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// exclude the system jobs from the scheduled runs
+	app.Cron().RemoveAll()
+
+	release := make(chan struct{})
+
+	app.Cron().AddWithContext("retry", "* * * * *", cron.JobOptions{Retries: 2}, func(ctx context.Context) error {
+		return errors.New("test_error")
+	})
+
+	app.Cron().AddWithContext("skip", "* * * * *", cron.JobOptions{Overlap: cron.OverlapSkip}, func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	app.Cron().SetInterval(20 * time.Millisecond)
+	app.Cron().Start()
+	time.Sleep(150 * time.Millisecond)
+	app.Cron().Stop()
+
+	close(release)
+
+	// wait for the running jobs to complete
+	time.Sleep(100 * time.Millisecond)
+
+	retryRuns, err := app.FindCronRuns("retry", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(retryRuns) == 0 {
+		t.Fatal("Expected at least one retry job run")
+	}
+	for _, r := range retryRuns {
+		if r.Status != core.CronRunStatusError || r.Attempts != 3 || r.Error != "test_error" {
+			t.Fatalf("Expected error run with 3 attempts, got %#v", r)
+		}
+	}
+
+	skipRuns, err := app.FindCronRuns("skip", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := map[string]int{}
+	for _, r := range skipRuns {
+		statuses[r.Status]++
+	}
+	if statuses[core.CronRunStatusSuccess] != 1 || statuses[core.CronRunStatusSkipped] == 0 {
+		t.Fatalf("Expected 1 successful and at least 1 skipped run, got %v", statuses)
+	}
+}
+
+func TestDisableAndEnableCronJob(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := app.DisableCronJob("missing", time.Time{}); err == nil {
+		t.Fatal("Expected missing job disable error")
+	}
+
+	if err := app.EnableCronJob("missing"); err == nil {
+		t.Fatal("Expected missing job enable error")
+	}
+
+	// exclude the system jobs from the scheduled runs
+	app.Cron().RemoveAll()
+
+	var calls atomic.Int32
+	app.Cron().MustAdd("test", "* * * * *", func() {
+		calls.Add(1)
+	})
+
+	if err := app.DisableCronJob("test", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// scheduled runs should be skipped
+	app.Cron().SetInterval(10 * time.Millisecond)
+	app.Cron().Start()
+	time.Sleep(100 * time.Millisecond)
+	app.Cron().Stop()
+
+	if total := calls.Load(); total != 0 {
+		t.Fatalf("Expected no scheduled runs for the disabled job, got %d", total)
+	}
+
+	// manual runs are still allowed
+	if err := app.RunCronJob("test"); err != nil {
+		t.Fatal(err)
+	}
+	if total := calls.Load(); total != 1 {
+		t.Fatalf("Expected 1 manual run, got %d", total)
+	}
+
+	if err := app.EnableCronJob("test"); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Cron().Start()
+	time.Sleep(100 * time.Millisecond)
+	app.Cron().Stop()
+
+	// wait for the running jobs to complete
+	time.Sleep(50 * time.Millisecond)
+
+	if total := calls.Load(); total < 2 {
+		t.Fatalf("Expected scheduled runs for the enabled job, got %d", total-1)
+	}
+
+	runs, err := app.FindCronRuns("test", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var scheduled int
+	for _, r := range runs {
+		if r.Trigger == core.CronTriggerSchedule {
+			scheduled++
+		}
+	}
+	if scheduled == 0 {
+		t.Fatal("Expected at least one scheduled run in the history")
+	}
+}
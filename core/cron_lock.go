@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const CronLocksTableName = "_cronLocks"
+
+// DefaultCronLockTTL is the default expiration duration of the
+// acquired scheduled cron job run locks.
+const DefaultCronLockTTL = 1 * time.Hour
+
+// CronLocker defines an advisory lock interface that is used
+// to ensure that when several app instances share the same data
+// (e.g. the same database and backups storage) each scheduled
+// cron job run is executed by only one of the instances.
+//
+// The lock could be backed by the shared database (see [NewDBCronLocker])
+// or by an external service (e.g. Redis "SET key value NX PX ttl").
+type CronLocker interface {
+	// TryLock tries to acquire the lock with the specified key for ttl duration.
+	//
+	// It returns false (without an error) if the lock is already
+	// acquired and not expired (aka. held by another app instance).
+	//
+	// The acquired locks are not released explicitly and they
+	// are expected to be considered free once ttl has elapsed.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// NewDBCronLocker creates a new [CronLocker] that stores
+// the locks in the app auxiliary database "_cronLocks" table.
+//
+// It is suitable for instances sharing the same pb_data directory
+// (or the same auxiliary database when a custom DBConnect is used).
+func NewDBCronLocker(app App) CronLocker {
+	return &dbCronLocker{app: app}
+}
+
+type dbCronLocker struct {
+	app App
+}
+
+// TryLock implements [CronLocker.TryLock].
+func (l *dbCronLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	now := types.NowDateTime()
+
+	expires, err := types.ParseDateTime(now.Time().Add(ttl))
+	if err != nil {
+		return false, err
+	}
+
+	acquired := false
+
+	err = l.app.AuxRunInTransaction(func(txApp App) error {
+		// cleanup the expired locks
+		_, err := txApp.AuxDB().Delete(CronLocksTableName, dbx.NewExp("[[expires]] <= {:now}", dbx.Params{"now": now.String()})).
+			WithContext(ctx).
+			Execute()
+		if err != nil {
+			return err
+		}
+
+		result, err := txApp.AuxDB().NewQuery(
+			"INSERT OR IGNORE INTO {{" + CronLocksTableName + "}} ([[id]], [[expires]]) VALUES ({:id}, {:expires})",
+		).Bind(dbx.Params{
+			"id":      key,
+			"expires": expires.String(),
+		}).WithContext(ctx).Execute()
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		acquired = affected > 0
+
+		return nil
+	})
+
+	return acquired, err
+}
@@ -0,0 +1,106 @@
+package core_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestDBCronLockerTryLock(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	locker := core.NewDBCronLocker(app)
+
+	ctx := context.Background()
+
+	scenarios := []struct {
+		name     string
+		key      string
+		ttl      time.Duration
+		expected bool
+	}{
+		{"new lock", "test1", 1 * time.Hour, true},
+		{"existing lock", "test1", 1 * time.Hour, false},
+		{"different key", "test2", -1 * time.Hour, true},
+		{"expired lock", "test2", 1 * time.Hour, true},
+		{"reacquired expired lock", "test2", 1 * time.Hour, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			acquired, err := locker.TryLock(ctx, s.key, s.ttl)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if acquired != s.expected {
+				t.Fatalf("Expected acquired %v, got %v", s.expected, acquired)
+			}
+		})
+	}
+}
+
+func TestCronLockerScheduledRuns(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	var calls atomic.Int32
+
+	// simulate multiple instances sharing the same data
+	instances := make([]*core.BaseApp, 3)
+	for i := range instances {
+		app := core.NewBaseApp(core.BaseAppConfig{
+			DataDir:    testApp.DataDir(),
+			CronLocker: core.NewDBCronLocker,
+		})
+		if err := app.Bootstrap(); err != nil {
+			t.Fatal(err)
+		}
+		defer app.ResetBootstrapState()
+
+		app.Cron().RemoveAll()
+		app.Cron().MustAdd("test", "* * * * *", func() {
+			calls.Add(1)
+		})
+		app.Cron().SetInterval(50 * time.Millisecond)
+
+		instances[i] = app
+	}
+
+	for _, app := range instances {
+		app.Cron().Start()
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	for _, app := range instances {
+		app.Cron().Stop()
+	}
+
+	// wait for the running jobs to complete
+	time.Sleep(50 * time.Millisecond)
+
+	var locks int
+	err := testApp.AuxDB().Select("count(*)").
+		From(core.CronLocksTableName).
+		Where(dbx.Like("id", "test@").Match(false, true)).
+		Row(&locks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total := int(calls.Load())
+	if total == 0 || total != locks {
+		t.Fatalf("Expected %d runs (one per scheduled tick), got %d", locks, total)
+	}
+}
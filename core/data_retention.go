@@ -0,0 +1,424 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// The supported DataRetentionRule.Action values.
+const (
+	DataRetentionActionDelete  = "delete"
+	DataRetentionActionArchive = "archive"
+)
+
+const (
+	// DefaultDataRetentionCron is the default data retention cron expression (every day at 03:00).
+	DefaultDataRetentionCron = "0 3 * * *"
+
+	// DefaultDataRetentionBatchSize is the default max number of records processed in a single batch.
+	DefaultDataRetentionBatchSize = 500
+
+	// DataRetentionArchivePrefix is the storage filesystem key prefix
+	// of the archived by the data retention rules records.
+	DataRetentionArchivePrefix = "@retention/"
+)
+
+const dataRetentionCronId = "__pbDataRetention__"
+
+// DataRetentionConfig defines the per collection data retention policies
+// (usually used for logs-like collections that would otherwise grow unbounded).
+type DataRetentionConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Cron is the cron expression of the scheduled retention run
+	// (if not set, fallbacks to DefaultDataRetentionCron).
+	Cron string `form:"cron" json:"cron"`
+
+	// BatchSize is the max number of records processed in a single
+	// transaction (if not set, fallbacks to DefaultDataRetentionBatchSize).
+	BatchSize int `form:"batchSize" json:"batchSize"`
+
+	// Rules is the list of the collection retention rules.
+	Rules []DataRetentionRule `form:"rules" json:"rules"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c DataRetentionConfig) MarshalJSON() ([]byte, error) {
+	type alias DataRetentionConfig
+
+	// serialize as empty array
+	if c.Rules == nil {
+		c.Rules = []DataRetentionRule{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes DataRetentionConfig validatable by implementing [validation.Validatable] interface.
+func (c DataRetentionConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Cron, validation.By(checkCronExpression)),
+		validation.Field(&c.BatchSize, validation.Min(0), validation.Max(10000)),
+		validation.Field(&c.Rules),
+	)
+}
+
+// DataRetentionRule defines the retention policy of a single collection.
+type DataRetentionRule struct {
+	// Collection is the name or id of the collection whose records to process.
+	Collection string `form:"collection" json:"collection"`
+
+	// Field is the name of the date or autodate field to compare
+	// with the retention period (if not set, fallbacks to "created").
+	Field string `form:"field" json:"field"`
+
+	// Days is the number of days after which the records are processed.
+	Days int `form:"days" json:"days"`
+
+	// Action specifies what to do with the expired records:
+	//  - "delete" (default) - the records are deleted
+	//  - "archive" - the records are exported as gzipped JSON lines file
+	//    in the app storage (under DataRetentionArchivePrefix) and then deleted
+	Action string `form:"action" json:"action"`
+}
+
+// Validate makes DataRetentionRule validatable by implementing [validation.Validatable] interface.
+func (r DataRetentionRule) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Collection, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.Field, validation.Length(0, 255)),
+		validation.Field(&r.Days, validation.Required, validation.Min(1)),
+		validation.Field(&r.Action, validation.In(DataRetentionActionDelete, DataRetentionActionArchive)),
+	)
+}
+
+// DateField returns the name of the rule date field.
+func (r DataRetentionRule) DateField() string {
+	if r.Field == "" {
+		return "created"
+	}
+
+	return r.Field
+}
+
+// IsArchive reports whether the rule expired records should be archived before their deletion.
+func (r DataRetentionRule) IsArchive() bool {
+	return r.Action == DataRetentionActionArchive
+}
+
+// validateDataRetentionSettings checks whether the rules collections and date fields exist.
+func validateDataRetentionSettings(app App, config DataRetentionConfig) error {
+	errs := validation.Errors{}
+
+	for i, rule := range config.Rules {
+		if rule.Collection == "" {
+			continue // already checked by the rule Validate
+		}
+
+		collection, err := app.FindCachedCollectionByNameOrId(rule.Collection)
+		if err != nil {
+			errs[fmt.Sprint(i)] = validation.Errors{
+				"collection": validation.NewError("validation_missing_collection", "Missing or invalid collection."),
+			}
+			continue
+		}
+
+		if collection.IsView() {
+			errs[fmt.Sprint(i)] = validation.Errors{
+				"collection": validation.NewError("validation_view_collection", "View collections are not supported."),
+			}
+			continue
+		}
+
+		field := collection.Fields.GetByName(rule.DateField())
+		if field == nil || (field.Type() != FieldTypeDate && field.Type() != FieldTypeAutodate) {
+			errs[fmt.Sprint(i)] = validation.Errors{
+				"field": validation.NewError("validation_invalid_date_field", "Missing or invalid date field."),
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return validation.Errors{"rules": errs}
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// DataRetentionStats describes the result of a single data retention rule run.
+type DataRetentionStats struct {
+	Collection string         `json:"collection"`
+	Field      string         `json:"field"`
+	Action     string         `json:"action"`
+	Cutoff     types.DateTime `json:"cutoff"`
+
+	// Matched is the total number of the expired records at the start of the run.
+	Matched int64 `json:"matched"`
+
+	// Processed is the number of the deleted (or archived) records
+	// (always 0 for a dry-run).
+	Processed int64 `json:"processed"`
+
+	// Archives lists the storage keys of the created archive files.
+	Archives []string `json:"archives"`
+
+	// Error is the rule processing error message (if any).
+	Error string `json:"error,omitempty"`
+}
+
+// RunDataRetention executes the app.Settings().DataRetention rules
+// by deleting (or archiving and then deleting) the records which
+// rule date field value is older than the rule Days.
+//
+// The records are processed in batches of DataRetentionConfig.BatchSize,
+// each batch in a separate transaction, and the regular record delete
+// hooks and cascade delete rules are applied for each record.
+//
+// If dryRun is true, no records are processed and only the number of
+// the matching records is reported.
+//
+// A failure of a single rule doesn't stop the processing of the
+// other ones and it is reported in the returned rule stats.
+func (app *BaseApp) RunDataRetention(ctx context.Context, dryRun bool) ([]*DataRetentionStats, error) {
+	config := app.Settings().DataRetention
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultDataRetentionBatchSize
+	}
+
+	result := make([]*DataRetentionStats, 0, len(config.Rules))
+
+	for _, rule := range config.Rules {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		action := rule.Action
+		if action == "" {
+			action = DataRetentionActionDelete
+		}
+
+		stats := &DataRetentionStats{
+			Collection: rule.Collection,
+			Field:      rule.DateField(),
+			Action:     action,
+			Archives:   []string{},
+		}
+		result = append(result, stats)
+
+		if err := app.runDataRetentionRule(ctx, rule, batchSize, dryRun, stats); err != nil {
+			stats.Error = err.Error()
+		}
+	}
+
+	return result, nil
+}
+
+func (app *BaseApp) runDataRetentionRule(
+	ctx context.Context,
+	rule DataRetentionRule,
+	batchSize int,
+	dryRun bool,
+	stats *DataRetentionStats,
+) error {
+	collection, err := app.FindCachedCollectionByNameOrId(rule.Collection)
+	if err != nil {
+		return err
+	}
+
+	if collection.Fields.GetByName(stats.Field) == nil {
+		return fmt.Errorf("missing date field %q", stats.Field)
+	}
+
+	stats.Cutoff, err = types.ParseDateTime(time.Now().AddDate(0, 0, -rule.Days))
+	if err != nil {
+		return err
+	}
+
+	column := "[[" + inflector.Columnify(stats.Field) + "]]"
+	expr := dbx.NewExp(
+		column+" != '' AND "+column+" < {:cutoff}",
+		dbx.Params{"cutoff": stats.Cutoff.String()},
+	)
+
+	stats.Matched, err = app.CountRecords(collection, expr)
+	if err != nil {
+		return err
+	}
+
+	if dryRun || stats.Matched == 0 {
+		return nil
+	}
+
+	// limit the processing to the initially matched records
+	// to prevent endless loops in case of a failing delete hook
+	for stats.Processed < stats.Matched {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		records := []*Record{}
+
+		err := app.RecordQuery(collection).
+			AndWhere(expr).
+			OrderBy(column+" ASC", "[[id]] ASC").
+			Limit(int64(batchSize)).
+			All(&records)
+		if err != nil {
+			return err
+		}
+
+		if len(records) == 0 {
+			break
+		}
+
+		if rule.IsArchive() {
+			key, err := app.archiveDataRetentionBatch(ctx, collection, records)
+			if err != nil {
+				return err
+			}
+			stats.Archives = append(stats.Archives, key)
+		}
+
+		err = app.RunInTransaction(func(txApp App) error {
+			for _, record := range records {
+				if err := txApp.DeleteWithContext(ctx, record); err != nil {
+					return fmt.Errorf("failed to delete record %q: %w", record.Id, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		stats.Processed += int64(len(records))
+	}
+
+	return nil
+}
+
+// archiveDataRetentionBatch exports the provided records as gzipped JSON lines
+// file in the app storage and returns the created file key.
+func (app *BaseApp) archiveDataRetentionBatch(ctx context.Context, collection *Collection, records []*Record) (string, error) {
+	var buf bytes.Buffer
+
+	gw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(gw)
+
+	for _, record := range records {
+		// export all fields, including the hidden ones
+		export := record.Fresh().
+			IgnoreEmailVisibility(true).
+			Unhide(collection.Fields.FieldNames()...).
+			PublicExport()
+
+		if err := enc.Encode(export); err != nil {
+			return "", err
+		}
+	}
+
+	if err := gw.Close(); err != nil {
+		return "", err
+	}
+
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		return "", err
+	}
+	defer fsys.Close()
+
+	fsys.SetContext(ctx)
+
+	key := DataRetentionArchivePrefix + collection.Id + "/" +
+		time.Now().UTC().Format("20060102150405.000") + "_" + records[0].Id + ".jsonl.gz"
+
+	if err := fsys.Upload(buf.Bytes(), key); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (app *BaseApp) registerDataRetentionHooks() {
+	loadJob := func() {
+		config := app.Settings().DataRetention
+
+		if !config.Enabled || len(config.Rules) == 0 {
+			app.Cron().Remove(dataRetentionCronId)
+			return
+		}
+
+		cronExpr := config.Cron
+		if cronExpr == "" {
+			cronExpr = DefaultDataRetentionCron
+		}
+
+		app.Cron().Add(dataRetentionCronId, cronExpr, func() {
+			result, err := app.RunDataRetention(context.Background(), false)
+			if err != nil {
+				app.Logger().Error(
+					"[Data retention cron] Failed to run the retention rules",
+					slog.String("error", err.Error()),
+				)
+			}
+
+			var errs []string
+			for _, stats := range result {
+				if stats.Error != "" {
+					errs = append(errs, stats.Collection+": "+stats.Error)
+				}
+
+				if stats.Processed > 0 {
+					app.Logger().Debug(
+						"[Data retention cron] Processed expired records",
+						slog.String("collection", stats.Collection),
+						slog.String("action", stats.Action),
+						slog.Int64("total", stats.Processed),
+					)
+				}
+			}
+
+			if len(errs) > 0 {
+				app.Logger().Error(
+					"[Data retention cron] Failed to process some of the retention rules",
+					slog.String("error", strings.Join(errs, "; ")),
+				)
+			}
+		})
+	}
+
+	app.OnBootstrap().BindFunc(func(e *BootstrapEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	})
+
+	app.OnSettingsReload().BindFunc(func(e *SettingsReloadEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	})
+}
@@ -0,0 +1,224 @@
+package core_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestDataRetentionSettingsValidation(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name           string
+		rules          []core.DataRetentionRule
+		expectedErrors []string
+	}{
+		{
+			"empty rule",
+			[]core.DataRetentionRule{{}},
+			[]string{"dataRetention"},
+		},
+		{
+			"missing collection",
+			[]core.DataRetentionRule{{Collection: "missing", Days: 1}},
+			[]string{"dataRetention"},
+		},
+		{
+			"non-date field",
+			[]core.DataRetentionRule{{Collection: "demo2", Field: "title", Days: 1}},
+			[]string{"dataRetention"},
+		},
+		{
+			"invalid action",
+			[]core.DataRetentionRule{{Collection: "demo2", Days: 1, Action: "invalid"}},
+			[]string{"dataRetention"},
+		},
+		{
+			"valid rules",
+			[]core.DataRetentionRule{
+				{Collection: "demo2", Days: 1},
+				{Collection: "demo2", Field: "updated", Days: 10, Action: core.DataRetentionActionArchive},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			settings, err := app.Settings().Clone()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			settings.DataRetention.Rules = s.rules
+
+			err = app.Validate(settings)
+
+			tests.TestValidationErrors(t, err, s.expectedErrors)
+		})
+	}
+}
+
+func TestRunDataRetention(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// new record that shouldn't be processed
+	fresh := core.NewRecord(collection)
+	fresh.Set("title", "fresh")
+	if err := app.Save(fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().DataRetention = core.DataRetentionConfig{
+		Enabled:   true,
+		BatchSize: 2,
+		Rules: []core.DataRetentionRule{
+			{Collection: "demo2", Days: 30, Action: core.DataRetentionActionArchive},
+			{Collection: "missing", Days: 30},
+		},
+	}
+
+	// dry-run
+	stats, err := app.RunDataRetention(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("Expected 2 rule stats, got %d", len(stats))
+	}
+	if stats[0].Matched != 3 || stats[0].Processed != 0 || len(stats[0].Archives) != 0 {
+		t.Fatalf("Expected 3 matched and 0 processed records, got %#v", stats[0])
+	}
+	if stats[1].Error == "" {
+		t.Fatal("Expected the missing collection rule to fail")
+	}
+	if total, _ := app.CountRecords(collection); total != 4 {
+		t.Fatalf("Expected the records to be preserved on dry-run, got %d", total)
+	}
+
+	// actual run
+	stats, err = app.RunDataRetention(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats[0].Error != "" {
+		t.Fatal(stats[0].Error)
+	}
+	if stats[0].Matched != 3 || stats[0].Processed != 3 {
+		t.Fatalf("Expected 3 matched and processed records, got %#v", stats[0])
+	}
+	if len(stats[0].Archives) != 2 {
+		t.Fatalf("Expected 2 archive batches, got %v", stats[0].Archives)
+	}
+
+	records, err := app.FindAllRecords(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Id != fresh.Id {
+		t.Fatalf("Expected only the fresh record to remain, got %v", records)
+	}
+
+	// check the archived records
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+
+	var archived int
+	for _, key := range stats[0].Archives {
+		if !strings.HasPrefix(key, core.DataRetentionArchivePrefix+collection.Id+"/") {
+			t.Fatalf("Unexpected archive key %q", key)
+		}
+
+		r, err := fsys.GetReader(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			r.Close()
+			t.Fatal(err)
+		}
+
+		scanner := bufio.NewScanner(gr)
+		for scanner.Scan() {
+			item := map[string]any{}
+			if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+				t.Fatal(err)
+			}
+			if item["id"] == "" || item["title"] == nil {
+				t.Fatalf("Expected the full record export, got %v", item)
+			}
+			archived++
+		}
+
+		gr.Close()
+		r.Close()
+	}
+
+	if archived != 3 {
+		t.Fatalf("Expected 3 archived records, got %d", archived)
+	}
+}
+
+func TestDataRetentionCron(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	hasJob := func() bool {
+		for _, job := range app.Cron().Jobs() {
+			if job.Id() == "__pbDataRetention__" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if hasJob() {
+		t.Fatal("Expected the data retention job to not be registered by default")
+	}
+
+	app.Settings().DataRetention = core.DataRetentionConfig{
+		Enabled: true,
+		Rules:   []core.DataRetentionRule{{Collection: "demo2", Days: 30}},
+	}
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !hasJob() {
+		t.Fatal("Expected the data retention job to be registered")
+	}
+
+	app.Settings().DataRetention.Enabled = false
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	if hasJob() {
+		t.Fatal("Expected the data retention job to be removed")
+	}
+}
@@ -81,7 +81,7 @@ func (app *BaseApp) modelQuery(db dbx.Builder, m Model) *dbx.SelectQuery {
 		Select("{{" + tableName + "}}.*").
 		From(tableName).
 		WithBuildHook(func(query *dbx.Query) {
-			query.WithExecHook(execLockRetry(app.config.QueryTimeout, defaultMaxLockRetries))
+			query.WithExecHook(app.queryExecHook())
 		})
 }
 
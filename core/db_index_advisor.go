@@ -0,0 +1,255 @@
+package core
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+)
+
+// IndexSuggestionSourceSlowQuery is the [IndexSuggestion.Sources]
+// value used when the column was found in a recorded slow query.
+const IndexSuggestionSourceSlowQuery = "slowQuery"
+
+var (
+	sqlWhereRegex        = regexp.MustCompile(`(?is)\swhere\s(.+?)(?:\sgroup\s+by\s|\sorder\s+by\s|\slimit\s|$)`)
+	sqlQuotedColumnRegex = regexp.MustCompile("\\[\\[(\\w+)\\.(\\w+)\\]\\]|[`\"](\\w+)[`\"]\\.[`\"](\\w+)[`\"]")
+)
+
+// IndexSuggestion describes a single missing collection index suggestion.
+type IndexSuggestion struct {
+	// Collection is the name of the collection the index is suggested for.
+	Collection string `json:"collection"`
+
+	// Column is the name of the suggested indexed column.
+	Column string `json:"column"`
+
+	// Index is the suggested "CREATE INDEX" SQL expression.
+	Index string `json:"index"`
+
+	// Sources lists where the column usage was found
+	// (the collection API rule names and/or IndexSuggestionSourceSlowQuery).
+	Sources []string `json:"sources"`
+
+	// SlowQueries is the number of the recorded slow queries filtering by the column.
+	SlowQueries int `json:"slowQueries"`
+}
+
+// AdviseIndexes analyzes the collections API rules and the recorded
+// slow queries (see [BaseApp.SlowQueries]) and returns suggestions for
+// the missing single column collection indexes.
+//
+// The suggestions are sorted by their relevance (most used first).
+func (app *BaseApp) AdviseIndexes() ([]*IndexSuggestion, error) {
+	collections, err := app.FindAllCollections(CollectionTypeBase, CollectionTypeAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*Collection, len(collections))
+	for _, c := range collections {
+		byName[strings.ToLower(c.Name)] = c
+	}
+
+	suggestions := map[string]*IndexSuggestion{}
+
+	add := func(collection *Collection, column string, source string) {
+		if !isIndexAdvisable(collection, column) {
+			return
+		}
+
+		key := collection.Id + "." + column
+
+		s, ok := suggestions[key]
+		if !ok {
+			s = &IndexSuggestion{
+				Collection: collection.Name,
+				Column:     column,
+				Sources:    []string{},
+				Index: dbutils.Index{
+					IndexName: collection.fieldIndexName(column),
+					TableName: collection.Name,
+					Columns:   []dbutils.IndexColumn{{Name: column}},
+				}.Build(),
+			}
+			suggestions[key] = s
+		}
+
+		if source == IndexSuggestionSourceSlowQuery {
+			s.SlowQueries++
+		}
+
+		if !slices.Contains(s.Sources, source) {
+			s.Sources = append(s.Sources, source)
+		}
+	}
+
+	for _, c := range collections {
+		if c.System {
+			continue
+		}
+
+		rules := []struct {
+			name string
+			rule *string
+		}{
+			{"listRule", c.ListRule},
+			{"viewRule", c.ViewRule},
+			{"updateRule", c.UpdateRule},
+			{"deleteRule", c.DeleteRule},
+		}
+
+		for _, r := range rules {
+			if r.rule == nil || *r.rule == "" {
+				continue
+			}
+
+			for _, column := range extractFilterColumns(*r.rule) {
+				add(c, column, r.name)
+			}
+		}
+	}
+
+	for _, q := range app.SlowQueries() {
+		for _, match := range extractSQLWhereColumns(q.SQL) {
+			if c, ok := byName[strings.ToLower(match[0])]; ok {
+				add(c, match[1], IndexSuggestionSourceSlowQuery)
+			}
+		}
+	}
+
+	result := make([]*IndexSuggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		result = append(result, s)
+	}
+
+	slices.SortFunc(result, func(a, b *IndexSuggestion) int {
+		return cmp.Or(
+			cmp.Compare(b.SlowQueries+len(b.Sources), a.SlowQueries+len(a.Sources)),
+			cmp.Compare(a.Collection, b.Collection),
+			cmp.Compare(a.Column, b.Column),
+		)
+	})
+
+	return result, nil
+}
+
+// ApplyIndexSuggestions adds the indexes of the provided suggestions
+// to their collections and persists the changes.
+//
+// Note that when the migratecmd plugin Automigrate option is enabled,
+// the collections changes are also stored as migration files.
+func (app *BaseApp) ApplyIndexSuggestions(suggestions []*IndexSuggestion) error {
+	return app.RunInTransaction(func(txApp App) error {
+		for _, s := range suggestions {
+			collection, err := txApp.FindCollectionByNameOrId(s.Collection)
+			if err != nil {
+				return err
+			}
+
+			if !isIndexAdvisable(collection, s.Column) {
+				continue // already indexed
+			}
+
+			collection.AddIndex(collection.fieldIndexName(s.Column), false, "`"+s.Column+"`", "")
+
+			if err := txApp.Save(collection); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// isIndexAdvisable reports whether the non-system collection column exists
+// and it is not already the leading column of another index.
+func isIndexAdvisable(collection *Collection, column string) bool {
+	if collection.IsView() || collection.System || column == FieldNameId || collection.Fields.GetByName(column) == nil {
+		return false
+	}
+
+	for _, raw := range collection.Indexes {
+		idx := dbutils.ParseIndex(raw)
+		if len(idx.Columns) == 0 || idx.Where != "" {
+			continue
+		}
+
+		if strings.EqualFold(strings.Trim(idx.Columns[0].Name, "`\"[]"), column) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// extractFilterColumns returns the unique collection field names
+// referenced by the specified filter expression (e.g. an API rule).
+//
+// Relation paths (e.g. "author.name") are resolved to their first segment
+// and the "@request.*", "@collection.*", etc. identifiers are ignored.
+func extractFilterColumns(filter string) []string {
+	groups, err := fexpr.Parse(filter)
+	if err != nil {
+		return nil
+	}
+
+	result := []string{}
+
+	addToken := func(t fexpr.Token) {
+		if t.Type != fexpr.TokenIdentifier || strings.HasPrefix(t.Literal, "@") {
+			return
+		}
+
+		name, _, _ := strings.Cut(t.Literal, ".")
+		name, _, _ = strings.Cut(name, ":")
+
+		if name != "" && !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	var walk func(groups []fexpr.ExprGroup)
+	walk = func(groups []fexpr.ExprGroup) {
+		for _, g := range groups {
+			switch item := g.Item.(type) {
+			case fexpr.Expr:
+				addToken(item.Left)
+				addToken(item.Right)
+			case fexpr.ExprGroup:
+				walk([]fexpr.ExprGroup{item})
+			case []fexpr.ExprGroup:
+				walk(item)
+			}
+		}
+	}
+	walk(groups)
+
+	return result
+}
+
+// extractSQLWhereColumns returns the [table, column] pairs of the
+// qualified column names (either quoted or in the "[[table.column]]"
+// placeholder form) from the WHERE clause of the specified SQL.
+func extractSQLWhereColumns(sql string) [][2]string {
+	where := sqlWhereRegex.FindStringSubmatch(sql)
+	if len(where) < 2 {
+		return nil
+	}
+
+	matches := sqlQuotedColumnRegex.FindAllStringSubmatch(where[1], -1)
+
+	result := make([][2]string, 0, len(matches))
+	for _, m := range matches {
+		if m[1] != "" {
+			result = append(result, [2]string{m[1], m[2]})
+		} else {
+			result = append(result, [2]string{m[3], m[4]})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+package core_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestAdviseIndexes(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Queries.SlowThreshold = 1
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	// run a slow query filtering by demo1.text
+	_, err := app.FindAllRecords("demo1", dbx.NewExp("[[demo1.text]] != '' AND (WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c LIMIT 100000) SELECT count(*) FROM c) > 0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total := len(app.SlowQueries()); total == 0 {
+		t.Fatal("Expected the slow query to be recorded")
+	}
+
+	suggestions, err := app.AdviseIndexes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]string{
+		"demo1.text":        {"slowQuery"},
+		"demo5.rel_many":    {"viewRule"},
+		"demo5.select_many": {"listRule"},
+	}
+
+	if len(suggestions) != len(expected) {
+		t.Fatalf("Expected %d suggestions, got %d", len(expected), len(suggestions))
+	}
+
+	for _, s := range suggestions {
+		key := s.Collection + "." + s.Column
+
+		sources, ok := expected[key]
+		if !ok {
+			t.Fatalf("Unexpected suggestion %q", key)
+		}
+
+		if !slices.Equal(s.Sources, sources) {
+			t.Fatalf("Expected %q sources %v, got %v", key, sources, s.Sources)
+		}
+
+		if !strings.HasPrefix(s.Index, "CREATE INDEX `idx_"+s.Column+"_") {
+			t.Fatalf("Unexpected %q index %q", key, s.Index)
+		}
+	}
+
+	if err := app.ApplyIndexSuggestions(suggestions); err != nil {
+		t.Fatal(err)
+	}
+
+	demo1, err := app.FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if idx := demo1.GetIndex("idx_text_" + demo1.Id); idx == "" {
+		t.Fatalf("Expected the demo1.text index to be created, got %v", demo1.Indexes)
+	}
+
+	suggestions, err = app.AdviseIndexes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(suggestions) != 0 {
+		t.Fatalf("Expected no suggestions after apply, got %d", len(suggestions))
+	}
+}
@@ -0,0 +1,243 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+)
+
+// DefaultDBMaintenanceCron is the default db maintenance window
+// cron expression (every Sunday at 04:00).
+const DefaultDBMaintenanceCron = "0 4 * * 0"
+
+const dbMaintenanceCronId = "__pbDBMaintenance__"
+
+// DBMaintenanceConfig defines the scheduled database maintenance window settings.
+type DBMaintenanceConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Cron is the cron expression of the maintenance window
+	// (if not set, fallbacks to DefaultDBMaintenanceCron).
+	Cron string `form:"cron" json:"cron"`
+
+	// Vacuum enables the VACUUM of the databases during the maintenance
+	// (note that it blocks all writes while running and requires
+	// up to twice the size of the database as temporary disk space).
+	Vacuum bool `form:"vacuum" json:"vacuum"`
+}
+
+// Validate makes DBMaintenanceConfig validatable by implementing [validation.Validatable] interface.
+func (c DBMaintenanceConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Cron, validation.By(checkCronExpression)),
+	)
+}
+
+// DBOptimizeReport defines the result of a database optimize operation.
+type DBOptimizeReport struct {
+	Databases []*DBOptimizeResult `json:"databases"`
+	Duration  time.Duration       `json:"duration"`
+}
+
+// DBOptimizeResult describes the optimize result of a single database.
+type DBOptimizeResult struct {
+	// Name is the database name ("data" or "auxiliary").
+	Name string `json:"name"`
+
+	// SizeBefore and SizeAfter are the database size in bytes
+	// before and after the optimization (excluding the WAL file).
+	SizeBefore int64 `json:"sizeBefore"`
+	SizeAfter  int64 `json:"sizeAfter"`
+
+	// FreePagesBefore and FreePagesAfter are the number of the
+	// unused database pages before and after the optimization.
+	FreePagesBefore int64 `json:"freePagesBefore"`
+	FreePagesAfter  int64 `json:"freePagesAfter"`
+
+	// Vacuumed indicates whether VACUUM was executed.
+	Vacuumed bool `json:"vacuumed"`
+
+	// Indexes lists the ANALYZE collected statistics of the database indexes.
+	Indexes []*DBIndexStat `json:"indexes"`
+}
+
+// DBIndexStat describes the ANALYZE statistics of a single index.
+type DBIndexStat struct {
+	Table string `db:"tbl" json:"table"`
+	Index string `db:"idx" json:"index"`
+
+	// Stat is the raw sqlite_stat1 stat value
+	// (the approximate number of rows followed by the average
+	// number of rows per each indexed column prefix).
+	Stat string `db:"stat" json:"stat"`
+}
+
+// OptimizeDB runs a maintenance procedure on the app main and auxiliary databases:
+//   - checkpoints and truncates the WAL file
+//   - reclaims the unused disk space with VACUUM (if vacuum is true)
+//   - collects the query planner statistics with ANALYZE and PRAGMA optimize
+//
+// It returns a report with the databases size before and after the optimization.
+func (app *BaseApp) OptimizeDB(ctx context.Context, vacuum bool) (*DBOptimizeReport, error) {
+	start := time.Now()
+
+	report := &DBOptimizeReport{}
+
+	dbs := []struct {
+		name string
+		db   dbx.Builder
+	}{
+		{"data", app.NonconcurrentDB()},
+		{"auxiliary", app.AuxNonconcurrentDB()},
+	}
+
+	for _, item := range dbs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, err := optimizeDB(ctx, item.db, vacuum)
+		if err != nil {
+			return nil, err
+		}
+		result.Name = item.name
+
+		report.Databases = append(report.Databases, result)
+	}
+
+	report.Duration = time.Since(start)
+
+	return report, nil
+}
+
+func optimizeDB(ctx context.Context, db dbx.Builder, vacuum bool) (*DBOptimizeResult, error) {
+	result := &DBOptimizeResult{Indexes: []*DBIndexStat{}}
+
+	var err error
+
+	result.SizeBefore, result.FreePagesBefore, err = dbSizeInfo(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []string{"PRAGMA wal_checkpoint(TRUNCATE)"}
+	if vacuum {
+		queries = append(queries, "VACUUM", "PRAGMA wal_checkpoint(TRUNCATE)")
+	}
+	queries = append(queries, "ANALYZE", "PRAGMA optimize")
+
+	for _, q := range queries {
+		if _, err := db.NewQuery(q).WithContext(ctx).Execute(); err != nil {
+			return nil, err
+		}
+	}
+	result.Vacuumed = vacuum
+
+	result.SizeAfter, result.FreePagesAfter, err = dbSizeInfo(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// sqlite_stat1 is created by ANALYZE only if there is at least one index
+	var hasStatTable int
+	err = db.Select("count(*)").
+		From("sqlite_schema").
+		Where(dbx.HashExp{"type": "table", "name": "sqlite_stat1"}).
+		WithContext(ctx).
+		Row(&hasStatTable)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasStatTable > 0 {
+		err = db.Select("tbl", "idx", "stat").
+			From("sqlite_stat1").
+			Where(dbx.NewExp("[[idx]] IS NOT NULL")).
+			OrderBy("tbl ASC", "idx ASC").
+			WithContext(ctx).
+			All(&result.Indexes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func dbSizeInfo(ctx context.Context, db dbx.Builder) (size int64, freePages int64, err error) {
+	var pageCount, pageSize int64
+
+	if err = db.NewQuery("PRAGMA page_count").WithContext(ctx).Row(&pageCount); err != nil {
+		return
+	}
+
+	if err = db.NewQuery("PRAGMA page_size").WithContext(ctx).Row(&pageSize); err != nil {
+		return
+	}
+
+	if err = db.NewQuery("PRAGMA freelist_count").WithContext(ctx).Row(&freePages); err != nil {
+		return
+	}
+
+	return pageCount * pageSize, freePages, nil
+}
+
+func (app *BaseApp) registerDBMaintenanceHooks() {
+	loadJob := func() {
+		config := app.Settings().DBMaintenance
+
+		if !config.Enabled {
+			app.Cron().Remove(dbMaintenanceCronId)
+			return
+		}
+
+		cronExpr := config.Cron
+		if cronExpr == "" {
+			cronExpr = DefaultDBMaintenanceCron
+		}
+
+		app.Cron().Add(dbMaintenanceCronId, cronExpr, func() {
+			report, err := app.OptimizeDB(context.Background(), config.Vacuum)
+			if err != nil {
+				app.Logger().Error(
+					"[DB maintenance cron] Failed to optimize the databases",
+					slog.String("error", err.Error()),
+				)
+				return
+			}
+
+			for _, db := range report.Databases {
+				app.Logger().Info(
+					"[DB maintenance cron] Optimized database",
+					slog.String("name", db.Name),
+					slog.Int64("sizeBefore", db.SizeBefore),
+					slog.Int64("sizeAfter", db.SizeAfter),
+					slog.Bool("vacuumed", db.Vacuumed),
+				)
+			}
+		})
+	}
+
+	app.OnBootstrap().BindFunc(func(e *BootstrapEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	})
+
+	app.OnSettingsReload().BindFunc(func(e *SettingsReloadEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	})
+}
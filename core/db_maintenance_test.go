@@ -0,0 +1,76 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestOptimizeDB(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	for _, vacuum := range []bool{false, true} {
+		report, err := app.OptimizeDB(context.Background(), vacuum)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(report.Databases) != 2 {
+			t.Fatalf("Expected 2 databases, got %d", len(report.Databases))
+		}
+
+		data := report.Databases[0]
+		if data.Name != "data" || data.SizeBefore <= 0 || data.SizeAfter <= 0 {
+			t.Fatalf("Unexpected data db result %#v", data)
+		}
+
+		if data.Vacuumed != vacuum {
+			t.Fatalf("Expected Vacuumed %v, got %v", vacuum, data.Vacuumed)
+		}
+
+		if vacuum && data.FreePagesAfter != 0 {
+			t.Fatalf("Expected no free pages after vacuum, got %d", data.FreePagesAfter)
+		}
+
+		if len(data.Indexes) == 0 {
+			t.Fatal("Expected the data db indexes stats to be collected")
+		}
+	}
+}
+
+func TestDBMaintenanceCron(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	hasJob := func() bool {
+		for _, job := range app.Cron().Jobs() {
+			if job.Id() == "__pbDBMaintenance__" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if hasJob() {
+		t.Fatal("Expected the db maintenance job to not be registered by default")
+	}
+
+	app.Settings().DBMaintenance = core.DBMaintenanceConfig{Enabled: true, Cron: "invalid"}
+	tests.TestValidationErrors(t, app.Save(app.Settings()), []string{"dbMaintenance"})
+
+	app.Settings().DBMaintenance.Cron = ""
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !hasJob() {
+		t.Fatal("Expected the db maintenance job to be registered")
+	}
+}
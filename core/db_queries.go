@@ -0,0 +1,237 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// DefaultQuerySlowThreshold is the default db query execution time
+// in milliseconds after which the query is recorded as slow.
+const DefaultQuerySlowThreshold = 500
+
+// maxSlowQueries is the max number of the recorded slow queries.
+const maxSlowQueries = 200
+
+// ErrQueryCanceled is returned by the db queries canceled with [BaseApp.CancelRunningQuery].
+var ErrQueryCanceled = errors.New("the db query was canceled")
+
+// QueriesConfig defines the app db queries execution settings.
+type QueriesConfig struct {
+	// Timeout is the default max execution time in seconds of a single
+	// db query from the concurrent db pool (aka. the queries created
+	// with [BaseApp.RecordQuery] and [BaseApp.ModelQuery]).
+	//
+	// The timeout is applied only to the queries without a context deadline.
+	//
+	// If not set, fallbacks to the app QueryTimeout config.
+	Timeout int `form:"timeout" json:"timeout"`
+
+	// SlowThreshold is the query execution time in milliseconds after
+	// which the query is recorded as slow (if not set, fallbacks to DefaultQuerySlowThreshold).
+	//
+	// The recorded slow queries are used by the [BaseApp.AdviseIndexes] analysis.
+	SlowThreshold int `form:"slowThreshold" json:"slowThreshold"`
+}
+
+// Validate makes QueriesConfig validatable by implementing [validation.Validatable] interface.
+func (c QueriesConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Timeout, validation.Min(0)),
+		validation.Field(&c.SlowThreshold, validation.Min(0)),
+	)
+}
+
+// RunningQuery describes a currently executing db query.
+type RunningQuery struct {
+	Id      string         `json:"id"`
+	SQL     string         `json:"sql"`
+	Started types.DateTime `json:"started"`
+	Elapsed float64        `json:"elapsed"` // in ms
+}
+
+// SlowQuery describes a single recorded slow db query.
+type SlowQuery struct {
+	SQL      string         `json:"sql"`
+	Started  types.DateTime `json:"started"`
+	Duration float64        `json:"duration"` // in ms
+}
+
+// RunningQueries returns the currently executing db queries
+// from the concurrent db pool sorted by their start time.
+func (app *BaseApp) RunningQueries() []*RunningQuery {
+	return app.runningQueries.list()
+}
+
+// CancelRunningQuery cancels the currently executing db query with the specified id
+// (the canceled query will fail with an [ErrQueryCanceled] error).
+//
+// Returns false if there is no running query with the specified id.
+func (app *BaseApp) CancelRunningQuery(id string) bool {
+	return app.runningQueries.cancel(id)
+}
+
+// SlowQueries returns the most recently recorded slow db queries
+// from the concurrent db pool in the order of their completion.
+func (app *BaseApp) SlowQueries() []*SlowQuery {
+	return app.runningQueries.slowList()
+}
+
+// queryExecHook returns the exec hook of the concurrent db pool queries.
+func (app *BaseApp) queryExecHook() dbx.ExecHookFunc {
+	timeout := app.config.QueryTimeout
+	if settingsTimeout := app.runningQueries.timeout.Load(); settingsTimeout > 0 {
+		timeout = time.Duration(settingsTimeout)
+	}
+
+	return execLockRetry(timeout, defaultMaxLockRetries, app.runningQueries)
+}
+
+func (app *BaseApp) registerQueriesHooks() {
+	loadTimeout := func() {
+		timeout := time.Duration(app.Settings().Queries.Timeout) * time.Second
+		app.runningQueries.timeout.Store(int64(timeout))
+
+		threshold := app.Settings().Queries.SlowThreshold
+		if threshold <= 0 {
+			threshold = DefaultQuerySlowThreshold
+		}
+		app.runningQueries.slowThreshold.Store(int64(time.Duration(threshold) * time.Millisecond))
+	}
+
+	app.OnBootstrap().BindFunc(func(e *BootstrapEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadTimeout()
+
+		return nil
+	})
+
+	app.OnSettingsReload().BindFunc(func(e *SettingsReloadEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadTimeout()
+
+		return nil
+	})
+}
+
+// -------------------------------------------------------------------
+
+type runningQuery struct {
+	sql     string
+	started time.Time
+	cancel  context.CancelCauseFunc
+}
+
+// runningQueries keeps track of the currently executing db queries
+// and records the slow ones.
+type runningQueries struct {
+	items         map[string]*runningQuery
+	slow          []*SlowQuery
+	mu            sync.Mutex
+	lastId        atomic.Uint64
+	timeout       atomic.Int64 // the settings query timeout (0 = not set)
+	slowThreshold atomic.Int64
+}
+
+func newRunningQueries() *runningQueries {
+	r := &runningQueries{items: map[string]*runningQuery{}}
+	r.slowThreshold.Store(int64(DefaultQuerySlowThreshold * time.Millisecond))
+
+	return r
+}
+
+func (r *runningQueries) add(sql string, cancel context.CancelCauseFunc) string {
+	id := strconv.FormatUint(r.lastId.Add(1), 10)
+
+	r.mu.Lock()
+	r.items[id] = &runningQuery{sql: sql, started: time.Now(), cancel: cancel}
+	r.mu.Unlock()
+
+	return id
+}
+
+func (r *runningQueries) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok {
+		return
+	}
+
+	delete(r.items, id)
+
+	duration := time.Since(item.started)
+	if duration < time.Duration(r.slowThreshold.Load()) {
+		return
+	}
+
+	started, _ := types.ParseDateTime(item.started)
+	r.slow = append(r.slow, &SlowQuery{
+		SQL:      item.sql,
+		Started:  started,
+		Duration: float64(duration.Microseconds()) / 1000,
+	})
+	if len(r.slow) > maxSlowQueries {
+		r.slow = slices.Delete(r.slow, 0, len(r.slow)-maxSlowQueries)
+	}
+}
+
+func (r *runningQueries) cancel(id string) bool {
+	r.mu.Lock()
+	item, ok := r.items[id]
+	r.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	item.cancel(ErrQueryCanceled)
+
+	return true
+}
+
+func (r *runningQueries) list() []*RunningQuery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	result := make([]*RunningQuery, 0, len(r.items))
+	for id, item := range r.items {
+		started, _ := types.ParseDateTime(item.started)
+		result = append(result, &RunningQuery{
+			Id:      id,
+			SQL:     item.sql,
+			Started: started,
+			Elapsed: float64(now.Sub(item.started).Microseconds()) / 1000,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b *RunningQuery) int {
+		return a.Started.Time().Compare(b.Started.Time())
+	})
+
+	return result
+}
+
+func (r *runningQueries) slowList() []*SlowQuery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append(make([]*SlowQuery, 0, len(r.slow)), r.slow...)
+}
@@ -0,0 +1,129 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// slowQueryExpr is a filter expression that takes a long time to evaluate.
+var slowQueryExpr = dbx.NewExp("(WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c LIMIT 1000000000) SELECT count(*) FROM c) > 0")
+
+func TestQueriesConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name        string
+		config      core.QueriesConfig
+		expectedErr bool
+	}{
+		{"zero value", core.QueriesConfig{}, false},
+		{"negative timeout", core.QueriesConfig{Timeout: -1}, true},
+		{"positive timeout", core.QueriesConfig{Timeout: 10}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectedErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectedErr, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestCancelRunningQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if app.CancelRunningQuery("missing") {
+		t.Fatal("Expected false for missing query")
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := app.FindAllRecords("demo1", slowQueryExpr)
+		errCh <- err
+	}()
+
+	var running []*core.RunningQuery
+	for i := 0; i < 200; i++ {
+		running = app.RunningQueries()
+		if len(running) > 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if len(running) != 1 {
+		t.Fatalf("Expected 1 running query, got %d", len(running))
+	}
+
+	if running[0].Id == "" || running[0].Started.IsZero() {
+		t.Fatalf("Expected non-empty id and start time, got %#v", running[0])
+	}
+
+	if !app.CancelRunningQuery(running[0].Id) {
+		t.Fatal("Expected the running query to be canceled")
+	}
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, core.ErrQueryCanceled) {
+			t.Fatalf("Expected ErrQueryCanceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("The canceled query didn't complete")
+	}
+
+	if total := len(app.RunningQueries()); total != 0 {
+		t.Fatalf("Expected no running queries, got %d", total)
+	}
+}
+
+func TestQueriesSettingsTimeout(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Queries.Timeout = 1
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	_, err := app.FindAllRecords("demo1", slowQueryExpr)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Expected the query to be aborted after ~1s, got %v", elapsed)
+	}
+
+	// explicit context deadline takes precedence
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start = time.Now()
+
+	_, err = app.FindAllRecordsWithContext(ctx, "demo1", slowQueryExpr)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Fatalf("Expected the query to be aborted after ~100ms, got %v", elapsed)
+	}
+}
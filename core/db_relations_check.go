@@ -0,0 +1,163 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+)
+
+// DanglingRelation describes a single record relation field
+// value that references missing (e.g. manually deleted) records.
+type DanglingRelation struct {
+	// Collection is the name of the collection with the relation field.
+	Collection string `json:"collection"`
+
+	// Field is the name of the relation field.
+	Field string `json:"field"`
+
+	// RecordId is the id of the record with the dangling reference(s).
+	RecordId string `json:"recordId"`
+
+	// MissingIds lists the referenced ids that don't exist.
+	MissingIds []string `json:"missingIds"`
+}
+
+// FindDanglingRelations scans the relation fields of the specified
+// collections (or of all non-view collections if none are specified)
+// and returns the references to missing records.
+//
+// The result is sorted by collection name, field name and record id.
+func (app *BaseApp) FindDanglingRelations(collectionNamesOrIds ...string) ([]*DanglingRelation, error) {
+	var collections []*Collection
+
+	if len(collectionNamesOrIds) == 0 {
+		all, err := app.FindAllCollections(CollectionTypeBase, CollectionTypeAuth)
+		if err != nil {
+			return nil, err
+		}
+		collections = all
+	} else {
+		for _, nameOrId := range collectionNamesOrIds {
+			collection, err := app.FindCollectionByNameOrId(nameOrId)
+			if err != nil {
+				return nil, fmt.Errorf("missing collection %q: %w", nameOrId, err)
+			}
+			if collection.IsView() {
+				return nil, fmt.Errorf("view collection %q cannot have dangling relations", collection.Name)
+			}
+			collections = append(collections, collection)
+		}
+	}
+
+	result := []*DanglingRelation{}
+
+	for _, collection := range collections {
+		for _, field := range collection.Fields {
+			relField, ok := field.(*RelationField)
+			if !ok {
+				continue
+			}
+
+			dangling, err := app.findDanglingFieldRelations(collection, relField)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check %s.%s: %w", collection.Name, relField.Name, err)
+			}
+
+			result = append(result, dangling...)
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b *DanglingRelation) int {
+		return cmp.Or(
+			cmp.Compare(a.Collection, b.Collection),
+			cmp.Compare(a.Field, b.Field),
+			cmp.Compare(a.RecordId, b.RecordId),
+		)
+	})
+
+	return result, nil
+}
+
+func (app *BaseApp) findDanglingFieldRelations(collection *Collection, field *RelationField) ([]*DanglingRelation, error) {
+	rows := []struct {
+		RecordId  string `db:"recordId"`
+		MissingId string `db:"missingId"`
+	}{}
+
+	joinExpr := dbutils.JSONEach("__r__." + field.Name)
+
+	var query string
+	if target, err := app.FindCachedCollectionByNameOrId(field.CollectionId); err == nil && !target.IsView() {
+		query = fmt.Sprintf(
+			"SELECT [[__r__.id]] AS [[recordId]], [[__je__.value]] AS [[missingId]] "+
+				"FROM {{%s}} __r__, %s __je__ "+
+				"LEFT JOIN {{%s}} __t__ ON [[__t__.id]] = [[__je__.value]] "+
+				"WHERE [[__je__.value]] IS NOT NULL AND [[__je__.value]] != '' AND [[__t__.id]] IS NULL "+
+				"ORDER BY [[__r__.id]]",
+			collection.Name, joinExpr, target.Name,
+		)
+	} else {
+		// all references to a missing (or non-record) collection are dangling
+		query = fmt.Sprintf(
+			"SELECT [[__r__.id]] AS [[recordId]], [[__je__.value]] AS [[missingId]] "+
+				"FROM {{%s}} __r__, %s __je__ "+
+				"WHERE [[__je__.value]] IS NOT NULL AND [[__je__.value]] != '' "+
+				"ORDER BY [[__r__.id]]",
+			collection.Name, joinExpr,
+		)
+	}
+
+	if err := app.DB().NewQuery(query).All(&rows); err != nil {
+		return nil, err
+	}
+
+	result := []*DanglingRelation{}
+
+	var last *DanglingRelation
+	for _, row := range rows {
+		if last == nil || last.RecordId != row.RecordId {
+			last = &DanglingRelation{
+				Collection: collection.Name,
+				Field:      field.Name,
+				RecordId:   row.RecordId,
+			}
+			result = append(result, last)
+		}
+
+		if !slices.Contains(last.MissingIds, row.MissingId) {
+			last.MissingIds = append(last.MissingIds, row.MissingId)
+		}
+	}
+
+	return result, nil
+}
+
+// RepairDanglingRelations removes the missing ids of the provided
+// dangling relations from their records (the single relation values are cleared).
+//
+// The records are saved without validation because removing the
+// dangling references may leave a required relation field empty.
+func (app *BaseApp) RepairDanglingRelations(dangling []*DanglingRelation) error {
+	return app.RunInTransaction(func(txApp App) error {
+		for _, d := range dangling {
+			record, err := txApp.FindRecordById(d.Collection, d.RecordId)
+			if err != nil {
+				return fmt.Errorf("failed to load %s record %q: %w", d.Collection, d.RecordId, err)
+			}
+
+			ids := record.GetStringSlice(d.Field)
+			ids = slices.DeleteFunc(ids, func(id string) bool {
+				return slices.Contains(d.MissingIds, id)
+			})
+			record.Set(d.Field, ids)
+
+			if err := txApp.SaveNoValidate(record); err != nil {
+				return fmt.Errorf("failed to save %s record %q: %w", d.Collection, d.RecordId, err)
+			}
+		}
+
+		return nil
+	})
+}
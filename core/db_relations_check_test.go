@@ -0,0 +1,99 @@
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFindAndRepairDanglingRelations(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// no dangling relations in the test data
+	dangling, err := app.FindDanglingRelations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dangling) != 0 {
+		t.Fatalf("Expected no dangling relations, got %d", len(dangling))
+	}
+
+	updates := map[string]dbx.Params{
+		"al1h9ijdeojtsjy": {
+			"rel_one":  "missing_rel_one",
+			"rel_many": `["bgs820n361vj1qd","missing1","missing1","4q1xlclmfloku33"]`,
+		},
+		"84nmscqy84lsi1t": {
+			"rel_many": `["missing2"]`,
+		},
+	}
+	for id, params := range updates {
+		if _, err := app.DB().Update("demo1", params, dbx.HashExp{"id": id}).Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := app.FindDanglingRelations("missing"); err == nil {
+			t.Fatal("Expected missing collection error")
+		}
+
+		if _, err := app.FindDanglingRelations("view1"); err == nil {
+			t.Fatal("Expected view collection error")
+		}
+	})
+
+	t.Run("filtered collections", func(t *testing.T) {
+		dangling, err := app.FindDanglingRelations("demo2", "users")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dangling) != 0 {
+			t.Fatalf("Expected no dangling relations, got %d", len(dangling))
+		}
+	})
+
+	dangling, err = app.FindDanglingRelations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := json.Marshal(dangling)
+
+	expected := `[` +
+		`{"collection":"demo1","field":"rel_many","recordId":"84nmscqy84lsi1t","missingIds":["missing2"]},` +
+		`{"collection":"demo1","field":"rel_many","recordId":"al1h9ijdeojtsjy","missingIds":["missing1"]},` +
+		`{"collection":"demo1","field":"rel_one","recordId":"al1h9ijdeojtsjy","missingIds":["missing_rel_one"]}` +
+		`]`
+	if string(raw) != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, raw)
+	}
+
+	if err := app.RepairDanglingRelations(dangling); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.FindRecordById("demo1", "al1h9ijdeojtsjy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("rel_one"); v != "" {
+		t.Fatalf("Expected rel_one to be cleared, got %q", v)
+	}
+	if v, _ := json.Marshal(record.GetStringSlice("rel_many")); string(v) != `["bgs820n361vj1qd","4q1xlclmfloku33"]` {
+		t.Fatalf("Unexpected rel_many %s", v)
+	}
+
+	dangling, err = app.FindDanglingRelations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dangling) != 0 {
+		t.Fatalf("Expected no dangling relations after the repair, got %d", len(dangling))
+	}
+}
@@ -17,22 +17,42 @@ var defaultRetryIntervals = []int{50, 100, 150, 200, 300, 400, 500, 700, 1000}
 // default max retry attempts
 const defaultMaxLockRetries = 12
 
-func execLockRetry(timeout time.Duration, maxRetries int) dbx.ExecHookFunc {
+func execLockRetry(timeout time.Duration, maxRetries int, queries *runningQueries) dbx.ExecHookFunc {
 	return func(q *dbx.Query, op func() error) error {
-		if q.Context() == nil {
-			cancelCtx, cancel := context.WithTimeout(context.Background(), timeout)
-			defer func() {
-				cancel()
-				//nolint:staticcheck
-				q.WithContext(nil) // reset
-			}()
-			q.WithContext(cancelCtx)
+		originalCtx := q.Context()
+		parentCtx := originalCtx
+		if parentCtx == nil {
+			parentCtx = context.Background()
+		}
+
+		execCtx, cancel := context.WithCancelCause(parentCtx)
+
+		// apply the default timeout if the query context doesn't have its own deadline
+		if _, ok := parentCtx.Deadline(); !ok {
+			var cancelTimeout context.CancelFunc
+			execCtx, cancelTimeout = context.WithTimeout(execCtx, timeout)
+			defer cancelTimeout()
+		}
+
+		q.WithContext(execCtx)
+		defer func() {
+			cancel(nil)
+			//nolint:staticcheck
+			q.WithContext(originalCtx) // reset
+		}()
+
+		if queries != nil {
+			id := queries.add(q.SQL(), cancel)
+			defer queries.remove(id)
 		}
 
 		execErr := baseLockRetry(func(attempt int) error {
 			return op()
 		}, maxRetries)
 		if execErr != nil && !errors.Is(execErr, sql.ErrNoRows) {
+			if errors.Is(context.Cause(execCtx), ErrQueryCanceled) {
+				execErr = errors.Join(ErrQueryCanceled, execErr)
+			}
 			execErr = fmt.Errorf("%w; failed query: %s", execErr, q.SQL())
 		}
 
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -12,24 +13,42 @@ import (
 //
 // It is safe to nest RunInTransaction calls as long as you use the callback's txApp.
 func (app *BaseApp) RunInTransaction(fn func(txApp App) error) error {
-	return app.runInTransaction(app.NonconcurrentDB(), fn, false)
+	return app.runInTransaction(context.Background(), app.NonconcurrentDB(), fn, false)
+}
+
+// RunInTransactionWithContext is the same as [BaseApp.RunInTransaction]
+// but allows specifying a context to limit the transaction execution.
+//
+// If ctx is canceled (e.g. because of a client disconnect) the
+// transaction is rollbacked and its remaining queries will fail.
+//
+// Note that for nested calls ctx is ignored and the transaction
+// inherits the context of the outermost RunInTransactionWithContext call.
+func (app *BaseApp) RunInTransactionWithContext(ctx context.Context, fn func(txApp App) error) error {
+	return app.runInTransaction(ctx, app.NonconcurrentDB(), fn, false)
 }
 
 // AuxRunInTransaction wraps fn into a transaction for the auxiliary app database.
 //
 // It is safe to nest RunInTransaction calls as long as you use the callback's txApp.
 func (app *BaseApp) AuxRunInTransaction(fn func(txApp App) error) error {
-	return app.runInTransaction(app.AuxNonconcurrentDB(), fn, true)
+	return app.runInTransaction(context.Background(), app.AuxNonconcurrentDB(), fn, true)
 }
 
-func (app *BaseApp) runInTransaction(db dbx.Builder, fn func(txApp App) error, isForAuxDB bool) error {
+// AuxRunInTransactionWithContext is the same as [BaseApp.AuxRunInTransaction]
+// but allows specifying a context to limit the transaction execution.
+func (app *BaseApp) AuxRunInTransactionWithContext(ctx context.Context, fn func(txApp App) error) error {
+	return app.runInTransaction(ctx, app.AuxNonconcurrentDB(), fn, true)
+}
+
+func (app *BaseApp) runInTransaction(ctx context.Context, db dbx.Builder, fn func(txApp App) error, isForAuxDB bool) error {
 	switch txOrDB := db.(type) {
 	case *dbx.Tx:
 		// run as part of the already existing transaction
 		return fn(app)
 	case *dbx.DB:
 		var txApp *BaseApp
-		txErr := txOrDB.Transactional(func(tx *dbx.Tx) error {
+		txErr := txOrDB.TransactionalContext(ctx, nil, func(tx *dbx.Tx) error {
 			txApp = app.createTxApp(tx, isForAuxDB)
 			return fn(txApp)
 		})
@@ -42,12 +61,50 @@ func (app *BaseApp) runInTransaction(db dbx.Builder, fn func(txApp App) error, i
 			}
 		}
 
-		return txErr
+		if txErr != nil {
+			return txErr
+		}
+
+		return app.OnTransactionCommit().Trigger(&TransactionEvent{
+			App:        app,
+			IsForAuxDB: isForAuxDB,
+		})
 	default:
 		return errors.New("failed to start transaction (unknown db type)")
 	}
 }
 
+// AfterCommit executes fn after the current app transaction commits.
+//
+// If the app is not part of a transaction, fn is executed immediately.
+// If the transaction is rollbacked, fn is never executed.
+//
+// The app argument of fn is the non-transactional app instance
+// the transaction was started from.
+//
+// Example:
+//
+//	app.RunInTransaction(func(txApp core.App) error {
+//		// ...
+//
+//		return txApp.AfterCommit(func(app core.App) error {
+//			return app.NewMailClient().Send(message)
+//		})
+//	})
+func (app *BaseApp) AfterCommit(fn func(app App) error) error {
+	if app.txInfo == nil {
+		return fn(app)
+	}
+
+	parent := app.txInfo.parent
+
+	app.txInfo.OnCommit(func() error {
+		return fn(parent)
+	})
+
+	return nil
+}
+
 // createTxApp shallow clones the current app and assigns a new tx state.
 func (app *BaseApp) createTxApp(tx *dbx.Tx, isForAuxDB bool) *BaseApp {
 	clone := *app
@@ -89,6 +146,22 @@ func (a *TxAppInfo) OnComplete(fn func(txErr error) error) {
 	a.afterFuncs = append(a.afterFuncs, fn)
 }
 
+// OnCommit registers the provided callback that will be invoked
+// only if the related transaction commits successfully.
+//
+// It is similar to [TxAppInfo.OnComplete] and could be used for
+// side effects (sending emails, webhooks, etc.) that must not
+// happen if the transaction is rollbacked.
+func (a *TxAppInfo) OnCommit(fn func() error) {
+	a.OnComplete(func(txErr error) error {
+		if txErr != nil {
+			return nil
+		}
+
+		return fn()
+	})
+}
+
 // note: can be called only once because TxAppInfo is cleared
 func (a *TxAppInfo) runAfterFuncs(txErr error) error {
 	a.mu.Lock()
@@ -1,7 +1,9 @@
 package core_test
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/pocketbase/pocketbase/core"
@@ -411,3 +413,207 @@ func TestTransactionFromInnerDeleteHook(t *testing.T) {
 		}
 	}
 }
+
+func TestTransactionCommitHooks(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name          string
+		txErr         error
+		expectedCalls string
+	}{
+		{"rollbacked transaction", errors.New("test_tx_error"), ""},
+		{"committed transaction", nil, "afterCommit1,afterCommit2,onCommit,onTransactionCommit"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			calls := []string{}
+
+			app.OnTransactionCommit().BindFunc(func(e *core.TransactionEvent) error {
+				if e.App.IsTransactional() {
+					t.Fatal("Expected e.App to be non-transactional")
+				}
+
+				if e.IsForAuxDB {
+					t.Fatal("Expected IsForAuxDB to be false")
+				}
+
+				calls = append(calls, "onTransactionCommit")
+
+				return e.Next()
+			})
+
+			txErr := app.RunInTransaction(func(txApp1 core.App) error {
+				err := txApp1.AfterCommit(func(app core.App) error {
+					if app.IsTransactional() {
+						t.Fatal("Expected app to be non-transactional")
+					}
+					calls = append(calls, "afterCommit1")
+					return nil
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return txApp1.RunInTransaction(func(txApp2 core.App) error {
+					err := txApp2.AfterCommit(func(app core.App) error {
+						calls = append(calls, "afterCommit2")
+						return nil
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					txApp2.TxInfo().OnCommit(func() error {
+						calls = append(calls, "onCommit")
+						return nil
+					})
+
+					if len(calls) != 0 {
+						t.Fatalf("Expected no calls before the transaction completion, got %v", calls)
+					}
+
+					return s.txErr
+				})
+			})
+
+			if !errors.Is(txErr, s.txErr) {
+				t.Fatalf("Expected tx error %v, got %v", s.txErr, txErr)
+			}
+
+			if v := strings.Join(calls, ","); v != s.expectedCalls {
+				t.Fatalf("Expected calls %q, got %q", s.expectedCalls, v)
+			}
+		})
+	}
+}
+
+func TestAfterCommitWithoutTransaction(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var commitHookCalls int
+	app.OnTransactionCommit().BindFunc(func(e *core.TransactionEvent) error {
+		commitHookCalls++
+		return e.Next()
+	})
+
+	var called bool
+	err := app.AfterCommit(func(a core.App) error {
+		called = true
+		return errors.New("test")
+	})
+
+	if !called {
+		t.Fatal("Expected fn to be executed immediately")
+	}
+
+	if err == nil || err.Error() != "test" {
+		t.Fatalf("Expected the fn error to be returned, got %v", err)
+	}
+
+	if commitHookCalls != 0 {
+		t.Fatalf("Expected OnTransactionCommit to not be triggered, got %d", commitHookCalls)
+	}
+}
+
+func TestAuxRunInTransactionCommitHook(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var event *core.TransactionEvent
+	app.OnTransactionCommit().BindFunc(func(e *core.TransactionEvent) error {
+		event = e
+		return e.Next()
+	})
+
+	err := app.AuxRunInTransaction(func(txApp core.App) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event == nil || !event.IsForAuxDB {
+		t.Fatalf("Expected OnTransactionCommit to be triggered for the aux db, got %v", event)
+	}
+}
+
+func TestRunInTransactionWithContext(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var called bool
+		err := app.RunInTransactionWithContext(ctx, func(txApp core.App) error {
+			called = true
+			return nil
+		})
+
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled error, got %v", err)
+		}
+
+		if called {
+			t.Fatal("Expected fn to not be called")
+		}
+	})
+
+	t.Run("canceled during the transaction", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		txErr := app.RunInTransactionWithContext(ctx, func(txApp core.App) error {
+			superuser, err := txApp.FindAuthRecordByEmail(core.CollectionNameSuperusers, "test@example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := txApp.Delete(superuser); err != nil {
+				t.Fatal(err)
+			}
+
+			cancel()
+
+			return nil
+		})
+
+		if !errors.Is(txErr, context.Canceled) {
+			t.Fatalf("Expected context.Canceled error, got %v", txErr)
+		}
+
+		// superuser should still exist
+		superuser, _ := app.FindAuthRecordByEmail(core.CollectionNameSuperusers, "test@example.com")
+		if superuser == nil {
+			t.Fatal("Expected superuser test@example.com to not be deleted")
+		}
+	})
+
+	t.Run("aux db", func(t *testing.T) {
+		var called bool
+		err := app.AuxRunInTransactionWithContext(context.Background(), func(txApp core.App) error {
+			called = true
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !called {
+			t.Fatal("Expected fn to be called")
+		}
+	})
+}
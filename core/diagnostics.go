@@ -0,0 +1,401 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+const (
+	DiagnosticSeverityOK      = "ok"
+	DiagnosticSeverityWarning = "warning"
+	DiagnosticSeverityError   = "error"
+)
+
+const (
+	// DefaultDiagnosticsWALSizeThreshold is the WAL file size after
+	// which the diagnostics report a warning (see [BaseApp.Diagnose]).
+	DefaultDiagnosticsWALSizeThreshold int64 = 100 << 20
+
+	// DefaultDiagnosticsClockSkewThreshold is the max allowed difference
+	// between the local and the reference clock (see [DiagnosticsOptions.ClockURL]).
+	DefaultDiagnosticsClockSkewThreshold = 30 * time.Second
+)
+
+// DiagnosticFinding describes the result of a single diagnostics check.
+type DiagnosticFinding struct {
+	// Check is the name of the check that produced the finding (e.g. "integrity").
+	Check string `json:"check"`
+
+	// Severity is one of the DiagnosticSeverity* constants.
+	Severity string `json:"severity"`
+
+	// Message is a short human readable description of the finding.
+	Message string `json:"message"`
+
+	// Hint is an optional suggestion how to resolve the finding.
+	Hint string `json:"hint,omitempty"`
+}
+
+// DiagnosticsOptions defines the optional [BaseApp.Diagnose] settings.
+type DiagnosticsOptions struct {
+	// ClockURL is an optional HTTP(S) url whose "Date" response header
+	// is used as reference for the clock skew check.
+	//
+	// The clock skew check is skipped if not set.
+	ClockURL string
+
+	// SkipNetwork skips the checks that require network access
+	// (S3 storage connectivity, SMTP reachability and clock skew).
+	SkipNetwork bool
+
+	// Timeout is the max duration of a single network check (default to 10s).
+	Timeout time.Duration
+}
+
+// Diagnose runs a set of checks for the most common operational problems
+// (data dir permissions, databases integrity, WAL size, pending migrations,
+// storage connectivity, SMTP reachability, clock skew and known misconfigurations)
+// and returns their findings.
+//
+// The returned error is only for the case when the diagnostics couldn't
+// be performed at all, the failed checks are reported as findings with
+// [DiagnosticSeverityError] severity.
+func (app *BaseApp) Diagnose(ctx context.Context, options DiagnosticsOptions) ([]*DiagnosticFinding, error) {
+	if !app.IsBootstrapped() {
+		return nil, errors.New("the app is not bootstrapped")
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Second
+	}
+
+	findings := []*DiagnosticFinding{}
+
+	add := func(check, severity, message, hint string) {
+		findings = append(findings, &DiagnosticFinding{
+			Check:    check,
+			Severity: severity,
+			Message:  message,
+			Hint:     hint,
+		})
+	}
+
+	app.diagnoseDataDir(add)
+	app.diagnoseDatabases(add)
+	app.diagnoseMigrations(add)
+	app.diagnoseStorage(ctx, options, add)
+	app.diagnoseSMTP(ctx, options, add)
+	app.diagnoseClock(ctx, options, add)
+	app.diagnoseSettings(add)
+
+	return findings, nil
+}
+
+type diagnosticsAddFunc func(check, severity, message, hint string)
+
+func (app *BaseApp) diagnoseDataDir(add diagnosticsAddFunc) {
+	const check = "dataDir"
+
+	info, err := os.Stat(app.DataDir())
+	if err != nil {
+		add(check, DiagnosticSeverityError, fmt.Sprintf("Failed to access the data dir %q: %v.", app.DataDir(), err), "")
+		return
+	}
+
+	if !info.IsDir() {
+		add(check, DiagnosticSeverityError, fmt.Sprintf("The data dir %q is not a directory.", app.DataDir()), "")
+		return
+	}
+
+	probe, err := os.CreateTemp(app.DataDir(), ".pb_doctor_*")
+	if err != nil {
+		add(
+			check,
+			DiagnosticSeverityError,
+			fmt.Sprintf("The data dir %q is not writable: %v.", app.DataDir(), err),
+			"Make sure that the user running the app is the owner of the data dir.",
+		)
+		return
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	if info.Mode().Perm()&0o007 != 0 {
+		add(
+			check,
+			DiagnosticSeverityWarning,
+			fmt.Sprintf("The data dir %q is accessible by other system users (%s).", app.DataDir(), info.Mode().Perm()),
+			fmt.Sprintf("Consider restricting its permissions, e.g. chmod 700 %q.", app.DataDir()),
+		)
+		return
+	}
+
+	add(check, DiagnosticSeverityOK, fmt.Sprintf("The data dir %q is writable.", app.DataDir()), "")
+}
+
+func (app *BaseApp) diagnoseDatabases(add diagnosticsAddFunc) {
+	dbs := []struct {
+		name string
+		db   dbx.Builder
+	}{
+		{"data.db", app.NonconcurrentDB()},
+		{"auxiliary.db", app.AuxNonconcurrentDB()},
+	}
+
+	for _, item := range dbs {
+		if err := checkDBIntegrity(item.db); err != nil {
+			add(
+				"integrity",
+				DiagnosticSeverityError,
+				fmt.Sprintf("The %s integrity check failed: %v.", item.name, err),
+				"Restore the database from a backup or try to recover it with the sqlite3 \".recover\" command.",
+			)
+		} else {
+			add("integrity", DiagnosticSeverityOK, fmt.Sprintf("The %s integrity check passed.", item.name), "")
+		}
+
+		info, err := os.Stat(filepath.Join(app.DataDir(), item.name+"-wal"))
+		if err != nil {
+			continue // no WAL file
+		}
+
+		if info.Size() > DefaultDiagnosticsWALSizeThreshold {
+			add(
+				"wal",
+				DiagnosticSeverityWarning,
+				fmt.Sprintf("The %s WAL file is too large (%d bytes).", item.name, info.Size()),
+				"Make sure that there are no long running read transactions and run \"db optimize\" to checkpoint it.",
+			)
+		} else {
+			add("wal", DiagnosticSeverityOK, fmt.Sprintf("The %s WAL file size is %d bytes.", item.name, info.Size()), "")
+		}
+	}
+}
+
+func (app *BaseApp) diagnoseMigrations(add diagnosticsAddFunc) {
+	lists := []struct {
+		name string
+		list MigrationsList
+	}{
+		{"system", SystemMigrations},
+		{"app", AppMigrations},
+	}
+
+	for _, item := range lists {
+		runner := NewMigrationsRunner(app, item.list)
+
+		pending := []string{}
+		for _, m := range item.list.Items() {
+			if !runner.isMigrationApplied(app, m.File) {
+				pending = append(pending, m.File)
+			}
+		}
+
+		if len(pending) > 0 {
+			add(
+				"migrations",
+				DiagnosticSeverityWarning,
+				fmt.Sprintf("There are %d pending %s migration(s): %s.", len(pending), item.name, strings.Join(pending, ", ")),
+				"Run \"migrate up\" or restart the app to apply them.",
+			)
+		} else {
+			add("migrations", DiagnosticSeverityOK, fmt.Sprintf("All %s migrations are applied.", item.name), "")
+		}
+	}
+}
+
+func (app *BaseApp) diagnoseStorage(ctx context.Context, options DiagnosticsOptions, add diagnosticsAddFunc) {
+	storages := []struct {
+		name    string
+		remote  bool
+		factory func() (*filesystem.System, error)
+	}{
+		{"storage", app.Settings().S3.Enabled, app.NewFilesystem},
+		{"backups storage", app.Settings().Backups.S3.Enabled, app.NewBackupsFilesystem},
+	}
+
+	for _, item := range storages {
+		if item.remote && options.SkipNetwork {
+			add("storage", DiagnosticSeverityWarning, fmt.Sprintf("The S3 %s check was skipped.", item.name), "")
+			continue
+		}
+
+		if err := probeFilesystem(ctx, item.factory, options.Timeout); err != nil {
+			add(
+				"storage",
+				DiagnosticSeverityError,
+				fmt.Sprintf("Failed to write to the %s: %v.", item.name, err),
+				"Verify the storage credentials and permissions in the app settings.",
+			)
+		} else {
+			add("storage", DiagnosticSeverityOK, fmt.Sprintf("The %s is writable.", item.name), "")
+		}
+	}
+}
+
+func probeFilesystem(ctx context.Context, factory func() (*filesystem.System, error), timeout time.Duration) error {
+	fsys, err := factory()
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fsys.SetContext(ctx)
+
+	key := ".pb_doctor_" + security.PseudorandomString(10)
+
+	if err := fsys.Upload([]byte("ok"), key); err != nil {
+		return err
+	}
+
+	return fsys.Delete(key)
+}
+
+func (app *BaseApp) diagnoseSMTP(ctx context.Context, options DiagnosticsOptions, add diagnosticsAddFunc) {
+	const check = "smtp"
+
+	smtp := app.Settings().SMTP
+	if !smtp.Enabled {
+		return // see diagnoseSettings
+	}
+
+	addr := net.JoinHostPort(smtp.Host, strconv.Itoa(smtp.Port))
+
+	if options.SkipNetwork {
+		add(check, DiagnosticSeverityWarning, fmt.Sprintf("The SMTP server %s reachability check was skipped.", addr), "")
+		return
+	}
+
+	dialer := net.Dialer{Timeout: options.Timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		add(
+			check,
+			DiagnosticSeverityError,
+			fmt.Sprintf("The SMTP server %s is not reachable: %v.", addr, err),
+			"Verify the SMTP host and port and that the outgoing connections are not blocked by a firewall.",
+		)
+		return
+	}
+	conn.Close()
+
+	add(check, DiagnosticSeverityOK, fmt.Sprintf("The SMTP server %s is reachable.", addr), "")
+}
+
+func (app *BaseApp) diagnoseClock(ctx context.Context, options DiagnosticsOptions, add diagnosticsAddFunc) {
+	const check = "clock"
+
+	if options.ClockURL == "" || options.SkipNetwork {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, options.ClockURL, nil)
+	if err != nil {
+		add(check, DiagnosticSeverityError, fmt.Sprintf("Invalid clock reference url: %v.", err), "")
+		return
+	}
+
+	start := time.Now()
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		add(check, DiagnosticSeverityError, fmt.Sprintf("Failed to fetch the clock reference url: %v.", err), "")
+		return
+	}
+	res.Body.Close()
+
+	reference, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		add(check, DiagnosticSeverityError, "The clock reference response has missing or invalid Date header.", "")
+		return
+	}
+
+	// compare with the middle of the request roundtrip
+	// (+ truncate because the Date header has only seconds precision)
+	local := start.Add(time.Since(start) / 2).Truncate(time.Second)
+
+	skew := local.Sub(reference)
+	if skew < 0 {
+		skew = -skew
+	}
+
+	if skew > DefaultDiagnosticsClockSkewThreshold {
+		add(
+			check,
+			DiagnosticSeverityError,
+			fmt.Sprintf("The system clock differs from the reference clock with %s.", skew),
+			"Synchronize the system clock (e.g. with NTP), otherwise the tokens and OTPs validation may fail.",
+		)
+		return
+	}
+
+	add(check, DiagnosticSeverityOK, fmt.Sprintf("The system clock differs from the reference clock with %s.", skew), "")
+}
+
+func (app *BaseApp) diagnoseSettings(add diagnosticsAddFunc) {
+	const check = "settings"
+
+	settings := app.Settings()
+
+	total := 0
+	warn := func(message, hint string) {
+		total++
+		add(check, DiagnosticSeverityWarning, message, hint)
+	}
+
+	superusers, err := app.CountRecords(CollectionNameSuperusers, dbx.Not(dbx.HashExp{FieldNameEmail: DefaultInstallerEmail}))
+	if err != nil {
+		add(check, DiagnosticSeverityError, fmt.Sprintf("Failed to count the superusers: %v.", err), "")
+	} else if superusers == 0 {
+		warn("There are no superusers.", "Create one with \"superuser create\".")
+	}
+
+	appURL := strings.ToLower(settings.Meta.AppURL)
+	if appURL == "" || strings.Contains(appURL, "localhost") || strings.Contains(appURL, "127.0.0.1") {
+		warn(
+			fmt.Sprintf("The application URL %q is not publicly accessible.", settings.Meta.AppURL),
+			"Set the public application URL in the settings because it is used in the emails links and OAuth2 redirects.",
+		)
+	}
+
+	if !settings.SMTP.Enabled && settings.Mailer.Provider == "" {
+		warn(
+			"Neither SMTP nor mailer provider is configured.",
+			"The emails are sent with the local sendmail command and they are likely to be rejected or marked as spam.",
+		)
+	}
+
+	if settings.Meta.SenderAddress == "" || strings.HasSuffix(strings.ToLower(settings.Meta.SenderAddress), "@example.com") {
+		warn(
+			fmt.Sprintf("The emails sender address %q is not configured.", settings.Meta.SenderAddress),
+			"Set a sender address from a domain you own.",
+		)
+	}
+
+	if !settings.RateLimits.Enabled {
+		warn("The rate limiting is disabled.", "Consider enabling it to mitigate brute-force and abuse attempts.")
+	}
+
+	if total == 0 {
+		add(check, DiagnosticSeverityOK, "No known misconfigurations found.", "")
+	}
+}
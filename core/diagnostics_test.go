@@ -0,0 +1,172 @@
+package core_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestDiagnose(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	findings, err := app.Diagnose(context.Background(), core.DiagnosticsOptions{SkipNetwork: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOK := []string{
+		"The data.db integrity check passed.",
+		"The auxiliary.db integrity check passed.",
+		"All system migrations are applied.",
+		"The storage is writable.",
+		"The backups storage is writable.",
+	}
+	for _, msg := range expectedOK {
+		f := findDiagnosticFinding(findings, msg)
+		if f == nil {
+			t.Fatalf("Missing finding %q in %v", msg, diagnosticMessages(findings))
+		}
+		if f.Severity != core.DiagnosticSeverityOK {
+			t.Fatalf("Expected finding %q to be ok, got %q", msg, f.Severity)
+		}
+	}
+
+	for _, f := range findings {
+		if f.Severity == core.DiagnosticSeverityError {
+			t.Fatalf("Expected no error findings, got %q", f.Message)
+		}
+	}
+}
+
+func TestDiagnoseSMTP(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// reserve a free port and close it so that the connection is refused
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedPort := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	openPort := listener.Addr().(*net.TCPAddr).Port
+
+	scenarios := []struct {
+		name             string
+		port             int
+		expectedSeverity string
+	}{
+		{"unreachable", closedPort, core.DiagnosticSeverityError},
+		{"reachable", openPort, core.DiagnosticSeverityOK},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app.Settings().SMTP.Enabled = true
+			app.Settings().SMTP.Host = "127.0.0.1"
+			app.Settings().SMTP.Port = s.port
+
+			findings, err := app.Diagnose(context.Background(), core.DiagnosticsOptions{Timeout: 2 * time.Second})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var smtp *core.DiagnosticFinding
+			for _, f := range findings {
+				if f.Check == "smtp" {
+					smtp = f
+				}
+			}
+
+			if smtp == nil {
+				t.Fatalf("Missing smtp finding in %v", diagnosticMessages(findings))
+			}
+
+			if smtp.Severity != s.expectedSeverity {
+				t.Fatalf("Expected severity %q, got %q (%s)", s.expectedSeverity, smtp.Severity, smtp.Message)
+			}
+		})
+	}
+}
+
+func TestDiagnoseClock(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name             string
+		offset           time.Duration
+		expectedSeverity string
+	}{
+		{"in sync", 0, core.DiagnosticSeverityOK},
+		{"skewed", -time.Hour, core.DiagnosticSeverityError},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Date", time.Now().Add(s.offset).UTC().Format(http.TimeFormat))
+			}))
+			defer server.Close()
+
+			findings, err := app.Diagnose(context.Background(), core.DiagnosticsOptions{ClockURL: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var clock *core.DiagnosticFinding
+			for _, f := range findings {
+				if f.Check == "clock" {
+					clock = f
+				}
+			}
+
+			if clock == nil {
+				t.Fatalf("Missing clock finding in %v", diagnosticMessages(findings))
+			}
+
+			if clock.Severity != s.expectedSeverity {
+				t.Fatalf("Expected severity %q, got %q (%s)", s.expectedSeverity, clock.Severity, clock.Message)
+			}
+		})
+	}
+}
+
+func findDiagnosticFinding(findings []*core.DiagnosticFinding, message string) *core.DiagnosticFinding {
+	for _, f := range findings {
+		if f.Message == message {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func diagnosticMessages(findings []*core.DiagnosticFinding) string {
+	messages := make([]string, len(findings))
+	for i, f := range findings {
+		messages[i] = f.Severity + ": " + f.Message
+	}
+
+	return strings.Join(messages, "\n")
+}
@@ -13,6 +13,7 @@ import (
 // Common request store keys used by the middlewares and api handlers.
 const (
 	RequestEventKeyInfoContext = "infoContext"
+	RequestEventKeySpamScore   = "spamScore"
 )
 
 // RequestEvent defines the PocketBase router handler event.
@@ -33,6 +34,10 @@ type RequestEvent struct {
 // If Settings.TrustedProxy is not configured or the found IP is empty,
 // it fallbacks to e.RemoteIP().
 //
+// If Settings.TrustedProxy.CIDRs is set, the trusted headers are checked only
+// when the request remote address is one of the trusted proxies and
+// the trusted proxies IPs are skipped from the right-most headers IP lookup.
+//
 // NB!
 // Be careful when used in a security critical context as it relies on
 // the trusted proxy to be properly configured and your app to be accessible only through it.
@@ -40,6 +45,11 @@ type RequestEvent struct {
 func (e *RequestEvent) RealIP() string {
 	settings := e.App.Settings()
 
+	hasTrustedCIDRs := len(settings.TrustedProxy.CIDRs) > 0
+	if hasTrustedCIDRs && !settings.TrustedProxy.IsTrusted(e.RemoteIP()) {
+		return e.RemoteIP()
+	}
+
 	for _, h := range settings.TrustedProxy.Headers {
 		headerValues := e.Request.Header.Values(h)
 		if len(headerValues) == 0 {
@@ -64,9 +74,16 @@ func (e *RequestEvent) RealIP() string {
 		} else {
 			for i := len(ips) - 1; i >= 0; i-- {
 				parsed, err := netip.ParseAddr(strings.TrimSpace(ips[i]))
-				if err == nil {
-					return parsed.StringExpanded()
+				if err != nil {
+					continue
+				}
+
+				// skip the intermediate trusted proxies
+				if hasTrustedCIDRs && i > 0 && settings.TrustedProxy.IsTrusted(parsed.String()) {
+					continue
 				}
+
+				return parsed.StringExpanded()
 			}
 		}
 	}
@@ -155,6 +172,7 @@ const (
 	RequestInfoContextRealtime      = "realtime"
 	RequestInfoContextProtectedFile = "protectedFile"
 	RequestInfoContextBatch         = "batch"
+	RequestInfoContextGraphQL       = "graphql"
 	RequestInfoContextOAuth2        = "oauth2"
 	RequestInfoContextOTP           = "otp"
 	RequestInfoContextPasswordAuth  = "password"
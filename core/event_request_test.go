@@ -24,6 +24,7 @@ func TestEventRequestRealIP(t *testing.T) {
 		name           string
 		headers        map[string][]string
 		trustedHeaders []string
+		trustedCIDRs   []string
 		useLeftmostIP  bool
 		expected       string
 	}{
@@ -31,6 +32,7 @@ func TestEventRequestRealIP(t *testing.T) {
 			"no trusted headers",
 			headers,
 			nil,
+			nil,
 			false,
 			"127.0.0.1",
 		},
@@ -38,6 +40,7 @@ func TestEventRequestRealIP(t *testing.T) {
 			"non-matching trusted header",
 			headers,
 			[]string{"header1", "header2"},
+			nil,
 			false,
 			"127.0.0.1",
 		},
@@ -45,6 +48,7 @@ func TestEventRequestRealIP(t *testing.T) {
 			"trusted X-Real-IP (rightmost)",
 			headers,
 			[]string{"header1", "x-real-ip", "x-forwarded-for"},
+			nil,
 			false,
 			"1.1.1.4",
 		},
@@ -52,6 +56,7 @@ func TestEventRequestRealIP(t *testing.T) {
 			"trusted X-Real-IP (leftmost)",
 			headers,
 			[]string{"header1", "x-real-ip", "x-forwarded-for"},
+			nil,
 			true,
 			"1.1.1.3",
 		},
@@ -59,6 +64,7 @@ func TestEventRequestRealIP(t *testing.T) {
 			"trusted X-Forwarded-For (rightmost)",
 			headers,
 			[]string{"header1", "x-forwarded-for"},
+			nil,
 			false,
 			"1.1.1.6",
 		},
@@ -66,9 +72,42 @@ func TestEventRequestRealIP(t *testing.T) {
 			"trusted X-Forwarded-For (leftmost)",
 			headers,
 			[]string{"header1", "x-forwarded-for"},
+			nil,
+			true,
+			"1.1.1.5",
+		},
+		{
+			"trusted CIDRs with non-trusted remote address",
+			headers,
+			[]string{"x-forwarded-for"},
+			[]string{"10.0.0.0/8"},
+			false,
+			"127.0.0.1",
+		},
+		{
+			"trusted CIDRs with trusted remote address (rightmost)",
+			headers,
+			[]string{"x-forwarded-for"},
+			[]string{"127.0.0.1", "1.1.1.6/32"},
+			false,
+			"1.1.1.5",
+		},
+		{
+			"trusted CIDRs with trusted remote address (leftmost)",
+			headers,
+			[]string{"x-forwarded-for"},
+			[]string{"127.0.0.0/8", "1.1.1.0/24"},
 			true,
 			"1.1.1.5",
 		},
+		{
+			"trusted CIDRs with all trusted header IPs",
+			headers,
+			[]string{"x-real-ip"},
+			[]string{"127.0.0.1", "1.1.1.0/24"},
+			false,
+			"1.1.1.3",
+		},
 	}
 
 	for _, s := range scenarios {
@@ -80,6 +119,7 @@ func TestEventRequestRealIP(t *testing.T) {
 			defer app.Cleanup()
 
 			app.Settings().TrustedProxy.Headers = s.trustedHeaders
+			app.Settings().TrustedProxy.CIDRs = s.trustedCIDRs
 			app.Settings().TrustedProxy.UseLeftmostIP = s.useLeftmostIP
 
 			event := core.RequestEvent{}
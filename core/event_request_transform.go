@@ -0,0 +1,65 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+var (
+	_ hook.Tagger = (*RequestBodyTransformEvent)(nil)
+	_ hook.Tagger = (*ResponseTransformEvent)(nil)
+)
+
+// RequestBodyTransformEvent defines the event data of the
+// OnRequestBodyTransform hook.
+type RequestBodyTransformEvent struct {
+	hook.Event
+	*RequestEvent
+
+	// Body is the decoded JSON object request body.
+	//
+	// Its modified state is serialized back as the new request body.
+	Body map[string]any
+}
+
+// Tags returns the matched route pattern of the event request
+// with and without the method (e.g. "GET /api/hello" and "/api/hello").
+func (e *RequestBodyTransformEvent) Tags() []string {
+	return routePatternTags(e.RequestEvent)
+}
+
+// ResponseTransformEvent defines the event data of the
+// OnResponseTransform hook.
+type ResponseTransformEvent struct {
+	hook.Event
+	*RequestEvent
+
+	// Status is the response status code.
+	Status int
+
+	// Data is the decoded JSON response payload.
+	//
+	// Its modified state is serialized and sent to the client.
+	Data any
+}
+
+// Tags returns the matched route pattern of the event request
+// with and without the method (e.g. "GET /api/hello" and "/api/hello").
+func (e *ResponseTransformEvent) Tags() []string {
+	return routePatternTags(e.RequestEvent)
+}
+
+func routePatternTags(e *RequestEvent) []string {
+	if e == nil || e.Request == nil || e.Request.Pattern == "" {
+		return nil
+	}
+
+	tags := []string{e.Request.Pattern}
+
+	if _, path, ok := strings.Cut(e.Request.Pattern, " "); ok {
+		tags = append(tags, path)
+	}
+
+	return tags
+}
@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/pocketbase/pocketbase/tools/auth"
+	"github.com/pocketbase/pocketbase/tools/captcha"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/notifier"
+	"github.com/pocketbase/pocketbase/tools/push"
 	"github.com/pocketbase/pocketbase/tools/router"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
@@ -90,12 +93,58 @@ type TerminateEvent struct {
 	IsRestart bool
 }
 
+type TransactionEvent struct {
+	hook.Event
+	App        App  // the app instance the transaction was started from (aka. non-transactional)
+	IsForAuxDB bool // whether the transaction was for the auxiliary app database
+}
+
 type BackupEvent struct {
 	hook.Event
 	App     App
 	Context context.Context
 	Name    string   // the name of the backup to create/restore.
+	Base    string   // the name of the base backup (available only when creating an incremental backup).
 	Exclude []string // list of dir entries to exclude from the backup create/restore.
+
+	// Collections is the list of collection names or ids to restore
+	// (available only for selective restore, aka. [App.RestoreBackupCollections]).
+	Collections []string
+}
+
+type ScheduledTaskEvent struct {
+	hook.Event
+	App  App
+	Task *ScheduledTask
+}
+
+func (e *ScheduledTaskEvent) Tags() []string {
+	if e.Task == nil {
+		return nil
+	}
+
+	return e.Task.HookTags()
+}
+
+type PanicEvent struct {
+	hook.Event
+	App App
+
+	// Source is the origin of the panic (e.g. "request", "cron", "hook").
+	Source string
+
+	// Name is an optional identifier of the panic origin
+	// (e.g. the request "METHOD /path" or the cron job id).
+	Name string
+
+	// Value is the raw recovered panic value.
+	Value any
+
+	// Error is the recovered panic value normalized as error.
+	Error error
+
+	// Stack is the panicking goroutine stack trace.
+	Stack []byte
 }
 
 type ServeEvent struct {
@@ -163,6 +212,58 @@ type MailerEvent struct {
 	Message *mailer.Message
 }
 
+type MailReceiveEvent struct {
+	hook.Event
+	App App
+
+	// Provider is the name of the inbound adapter that received the message
+	// (eg. "mime", "mailgun", "ses").
+	Provider string
+
+	Message *mailer.Message
+}
+
+type PushSendEvent struct {
+	hook.Event
+	App App
+
+	Driver  push.Driver
+	Device  *PushDevice
+	Message *push.Message
+}
+
+type SMSEvent struct {
+	hook.Event
+	App App
+
+	Notifier notifier.Notifier
+	Message  *notifier.Message
+}
+
+// CaptchaVerifyEvent is triggered when verifying a client captcha token
+// for a collection records API action (see [CollectionCaptcha]).
+type CaptchaVerifyEvent struct {
+	hook.Event
+	*RequestEvent
+	baseCollectionEventData
+
+	// Verifier is the captcha verifier that will be used to check the Token
+	// (it is nil if the settings provider is "custom").
+	Verifier captcha.Verifier
+
+	// Token is the client submitted captcha token.
+	Token string
+
+	// Action is the protected collection action (CaptchaActionCreate, CaptchaActionPasswordReset).
+	Action string
+}
+
+type SMSRecordEvent struct {
+	SMSEvent
+	baseRecordEventData
+	Meta map[string]any
+}
+
 type MailerRecordEvent struct {
 	MailerEvent
 	baseRecordEventData
@@ -222,6 +323,46 @@ type RecordErrorEvent struct {
 	RecordEvent
 }
 
+// RecordModerateEvent is triggered when changing the moderation state
+// of a record (see [BaseApp.ModerateRecord]).
+type RecordModerateEvent struct {
+	hook.Event
+	App App
+	baseRecordEventData
+	Context context.Context
+
+	// FromState is the current record moderation state.
+	FromState string
+
+	// ToState is the new record moderation state.
+	ToState string
+
+	// Reviewer is the id of the reviewer record to assign
+	// (it is the same as the current one if not changed).
+	Reviewer string
+}
+
+// RecordPublishEvent is triggered when the publishAt or unpublishAt
+// time of a record is reached (see [BaseApp.PublishScheduledRecords]).
+type RecordPublishEvent struct {
+	hook.Event
+	App App
+	baseRecordEventData
+	Context context.Context
+
+	// Action is the publishing boundary action ("publish" or "unpublish").
+	Action string
+}
+
+// RecordExpireEvent is triggered when purging an expired
+// record (see [BaseApp.PurgeExpiredRecords]).
+type RecordExpireEvent struct {
+	hook.Event
+	App App
+	baseRecordEventData
+	Context context.Context
+}
+
 func syncModelEventWithRecordEvent(me *ModelEvent, re *RecordEvent) {
 	me.App = re.App
 	me.Context = re.Context
@@ -471,6 +612,19 @@ type RecordRequestEvent struct {
 	Record *Record
 }
 
+// RecordCreateScoreEvent is triggered on record create request
+// to calculate the submitted record spam/abuse score (see [CollectionSpamScore]).
+type RecordCreateScoreEvent struct {
+	hook.Event
+	*RequestEvent
+	baseCollectionEventData
+
+	Record *Record
+
+	// Signals stores the individual heuristics scores (name -> score).
+	Signals map[string]float64
+}
+
 type RecordEnrichEvent struct {
 	hook.Event
 	App App
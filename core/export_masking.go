@@ -0,0 +1,209 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/tools/yaml"
+)
+
+const (
+	// ExportMaskEmail replaces the value with a fake deterministic
+	// email address (e.g. "user_1a2b3c4d@example.com").
+	ExportMaskEmail = "email"
+
+	// ExportMaskName replaces the value with a fake deterministic
+	// person name (e.g. "Alice Smith").
+	ExportMaskName = "name"
+
+	// ExportMaskHash replaces the value with its salted deterministic
+	// 15 characters hash (compatible with the default record id format).
+	//
+	// Because the hash is deterministic, masking both a collection "id"
+	// and the relation fields referencing it keeps the relations consistent.
+	ExportMaskHash = "hash"
+
+	// ExportMaskNull replaces the value with null.
+	ExportMaskNull = "null"
+)
+
+// ExportMaskAllCollections is the [ExportMasking.Collections] key
+// of the rules applied to all collections.
+const ExportMaskAllCollections = "*"
+
+var exportMasks = []string{ExportMaskEmail, ExportMaskName, ExportMaskHash, ExportMaskNull}
+
+var (
+	exportMaskFirstNames = []string{"Alice", "Bob", "Carol", "David", "Emma", "Frank", "Grace", "Henry", "Irene", "Jack", "Kate", "Liam", "Mia", "Noah", "Olivia", "Peter"}
+	exportMaskLastNames  = []string{"Smith", "Johnson", "Brown", "Taylor", "Miller", "Wilson", "Moore", "Clark", "Lewis", "Walker", "Hall", "Young", "King", "Wright", "Green", "Baker"}
+)
+
+// ExportMasking defines field-level masking rules used to anonymize
+// the exported records data (see the "export --anonymize" command).
+//
+// Example YAML rules file:
+//
+//	salt: "some-random-secret"
+//	collections:
+//	  users:
+//	    id: hash
+//	    email: email
+//	    name: name
+//	    phone: "null" # note the quotes (a plain null is not a valid mask)
+//	  posts:
+//	    author: hash
+//	  "*":
+//	    ip: "null"
+type ExportMasking struct {
+	// Salt is an optional secret mixed in the deterministic masked values
+	// (it is recommended to be set to prevent brute-forcing the hashed values).
+	Salt string `json:"salt"`
+
+	// Collections defines the field masks (field name -> mask) per
+	// collection name or id ([ExportMaskAllCollections] for all collections).
+	Collections map[string]map[string]string `json:"collections"`
+}
+
+// LoadExportMaskingFile loads and validates a JSON or YAML masking rules file.
+func LoadExportMaskingFile(path string) (*ExportMasking, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &ExportMasking{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(raw, m)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, m)
+	default:
+		return nil, fmt.Errorf("unsupported masking rules file %q (expected .json, .yaml or .yml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse masking rules file %q: %w", path, err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid masking rules file %q: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// Validate makes ExportMasking validatable by implementing [validation.Validatable] interface.
+func (m *ExportMasking) Validate() error {
+	errs := validation.Errors{}
+
+	for collection, fields := range m.Collections {
+		for field, mask := range fields {
+			if !slices.Contains(exportMasks, mask) {
+				errs[collection+"."+field] = validation.NewError(
+					"validation_invalid_export_mask",
+					"Invalid mask {{.mask}} (expected one of "+strings.Join(exportMasks, ", ")+").",
+				).SetParams(map[string]any{"mask": mask})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// FieldMasks returns the field masks of the specified collection
+// (the collection specific rules have precedence over the "*" ones).
+func (m *ExportMasking) FieldMasks(collection *Collection) map[string]string {
+	result := map[string]string{}
+
+	for _, key := range []string{ExportMaskAllCollections, collection.Id, collection.Name} {
+		for field, mask := range m.Collections[key] {
+			result[field] = mask
+		}
+	}
+
+	return result
+}
+
+// CheckFields returns an error if the collection specific rules
+// reference fields that don't exist in the provided collection
+// (e.g. to detect typos before exporting unmasked data).
+func (m *ExportMasking) CheckFields(collection *Collection) error {
+	for _, key := range []string{collection.Id, collection.Name} {
+		for field := range m.Collections[key] {
+			if collection.Fields.GetByName(field) == nil {
+				return fmt.Errorf("unknown %q masking rule field %q", collection.Name, field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply masks in place the exported record data of the specified collection.
+//
+// Empty values are left untouched (except for the [ExportMaskNull] mask)
+// and the multiple values (e.g. multiple relation ids) are masked individually.
+func (m *ExportMasking) Apply(collection *Collection, data map[string]any) {
+	for field, mask := range m.FieldMasks(collection) {
+		value, ok := data[field]
+		if !ok {
+			continue
+		}
+
+		if mask == ExportMaskNull {
+			data[field] = nil
+			continue
+		}
+
+		data[field] = m.maskValue(mask, value)
+	}
+}
+
+func (m *ExportMasking) maskValue(mask string, value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return v
+		}
+	case []string:
+		result := make([]string, len(v))
+		for i, item := range v {
+			result[i], _ = m.maskValue(mask, item).(string)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = m.maskValue(mask, item)
+		}
+		return result
+	}
+
+	sum := sha256.Sum256([]byte(m.Salt + ":" + fmt.Sprint(value)))
+
+	switch mask {
+	case ExportMaskEmail:
+		return "user_" + hex.EncodeToString(sum[:4]) + "@example.com"
+	case ExportMaskName:
+		n := binary.BigEndian.Uint32(sum[:4])
+		first := exportMaskFirstNames[n%uint32(len(exportMaskFirstNames))]
+		last := exportMaskLastNames[(n/uint32(len(exportMaskFirstNames)))%uint32(len(exportMaskLastNames))]
+		return first + " " + last
+	default: // hash
+		return hex.EncodeToString(sum[:])[:15]
+	}
+}
@@ -0,0 +1,178 @@
+package core_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestLoadExportMaskingFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"rules.yaml":  "salt: test\ncollections:\n  users:\n    email: email\n    name: \"null\"\n",
+		"rules.json":  `{"salt":"test","collections":{"*":{"id":"hash"}}}`,
+		"invalid.yml": "collections:\n  users:\n    email: missing\n",
+		"rules.txt":   "",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		file        string
+		expectError bool
+		expected    string
+	}{
+		{"missing.yaml", true, ""},
+		{"rules.txt", true, ""},
+		{"invalid.yml", true, ""},
+		{"rules.yaml", false, `{"salt":"test","collections":{"users":{"email":"email","name":"null"}}}`},
+		{"rules.json", false, `{"salt":"test","collections":{"*":{"id":"hash"}}}`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.file, func(t *testing.T) {
+			m, err := core.LoadExportMaskingFile(filepath.Join(dir, s.file))
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			raw, _ := json.Marshal(m)
+			if string(raw) != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, raw)
+			}
+		})
+	}
+}
+
+func TestExportMaskingApply(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, err := app.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	demo1, err := app.FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &core.ExportMasking{
+		Salt: "test",
+		Collections: map[string]map[string]string{
+			core.ExportMaskAllCollections: {
+				"id":   "hash",
+				"text": "null",
+			},
+			"users": {
+				"email": "email",
+				"name":  "name",
+			},
+			demo1.Id: {
+				"rel_many": "hash",
+				"text":     "name", // overrides the "*" rule
+			},
+		},
+	}
+
+	if err := m.CheckFields(users); err != nil {
+		t.Fatalf("Expected valid users rules, got %v", err)
+	}
+
+	if err := m.CheckFields(demo1); err != nil {
+		t.Fatalf("Expected valid demo1 rules, got %v", err)
+	}
+
+	demo2, err := app.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Collections["demo2"] = map[string]string{"missing": "null"}
+	if err := m.CheckFields(demo2); err == nil {
+		t.Fatal("Expected unknown demo2 field error")
+	}
+
+	user, err := app.FindRecordById(users, "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user.IgnoreEmailVisibility(true)
+
+	userData := user.PublicExport()
+	m.Apply(users, userData)
+
+	hashRegex := regexp.MustCompile(`^[a-f0-9]{15}$`)
+
+	maskedUserId, _ := userData["id"].(string)
+	if !hashRegex.MatchString(maskedUserId) || maskedUserId == user.Id {
+		t.Fatalf("Expected hashed user id, got %q", maskedUserId)
+	}
+
+	if email, _ := userData["email"].(string); !regexp.MustCompile(`^user_[a-f0-9]{8}@example\.com$`).MatchString(email) {
+		t.Fatalf("Expected fake email, got %q", email)
+	}
+
+	if name, _ := userData["name"].(string); !regexp.MustCompile(`^\w+ \w+$`).MatchString(name) || name == user.GetString("name") {
+		t.Fatalf("Expected fake name, got %q", name)
+	}
+
+	record, err := app.FindRecordById(demo1, "al1h9ijdeojtsjy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := record.PublicExport()
+	m.Apply(demo1, data)
+
+	relMany, _ := data["rel_many"].([]string)
+	if len(relMany) != 3 {
+		t.Fatalf("Expected 3 masked rel_many ids, got %v", data["rel_many"])
+	}
+
+	// the hash is deterministic so the relation references remain consistent
+	if relMany[1] != maskedUserId {
+		t.Fatalf("Expected rel_many[1] to be the masked user id %q, got %q", maskedUserId, relMany[1])
+	}
+
+	if text, _ := data["text"].(string); !regexp.MustCompile(`^\w+ \w+$`).MatchString(text) {
+		t.Fatalf("Expected the collection specific text mask, got %v", data["text"])
+	}
+
+	// unchanged fields
+	if data["bool"] != record.Get("bool") {
+		t.Fatalf("Expected the unmasked bool field to be unchanged, got %v", data["bool"])
+	}
+
+	// null mask
+	demo2Record, err := app.FindFirstRecordByFilter(demo2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Collections[core.ExportMaskAllCollections]["title"] = "null"
+	demo2Data := demo2Record.PublicExport()
+	m.Apply(demo2, demo2Data)
+	if v, ok := demo2Data["title"]; !ok || v != nil {
+		t.Fatalf("Expected null title, got %v", v)
+	}
+}
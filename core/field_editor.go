@@ -2,9 +2,13 @@ package core
 
 import (
 	"context"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/core/validators"
+	"github.com/pocketbase/pocketbase/tools/sanitizer"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
@@ -21,11 +25,14 @@ const DefaultEditorFieldMaxSize int64 = 5 << 20
 var (
 	_ Field                 = (*EditorField)(nil)
 	_ MaxBodySizeCalculator = (*EditorField)(nil)
+	_ RecordInterceptor     = (*EditorField)(nil)
+	_ I18nField             = (*EditorField)(nil)
 )
 
 // EditorField defines "editor" type field to store HTML formatted text.
 //
-// The respective zero record field value is empty string.
+// The respective zero record field value is empty string
+// (or empty [types.JSONMap] for localized fields, see [EditorField.I18n]).
 type EditorField struct {
 	// Name (required) is the unique name of the field.
 	Name string `form:"name" json:"name"`
@@ -59,7 +66,38 @@ type EditorField struct {
 	// (see also https://www.tiny.cloud/docs/tinymce/6/url-handling/#convert_urls)
 	ConvertURLs bool `form:"convertURLs" json:"convertURLs"`
 
-	// Required will require the field value to be non-empty string.
+	// Sanitize specifies an optional HTML sanitization preset that is
+	// applied to the field value on record validation and save.
+	//
+	// Supported values: "" (no sanitization), "strict" (strips all tags),
+	// "basic" (inline formatting, links and lists) and "rich" (basic + headings, images, tables, etc.).
+	Sanitize string `form:"sanitize" json:"sanitize"`
+
+	// SanitizeLinkRel overwrites the rel attribute value enforced
+	// on the sanitized anchor elements.
+	//
+	// If empty, fallbacks to "noopener noreferrer nofollow".
+	SanitizeLinkRel string `form:"sanitizeLinkRel" json:"sanitizeLinkRel"`
+
+	// SanitizeImageHosts is an optional list of the allowed external image hosts.
+	//
+	// Images from other hosts are removed from the sanitized value
+	// (relative image sources are always allowed).
+	//
+	// If empty, no image host restrictions are applied.
+	SanitizeImageHosts []string `form:"sanitizeImageHosts" json:"sanitizeImageHosts"`
+
+	// I18n specifies an optional list of locales (e.g. ["en", "de", "de-AT"])
+	// for storing per-locale field values.
+	//
+	// The first locale is the default one and it is used as last fallback
+	// when resolving the value of the requested ?lang= locale (see [LocalizedValue]).
+	//
+	// Leave it empty to store a single plain string value.
+	I18n []string `form:"i18n" json:"i18n,omitempty"`
+
+	// Required will require the field value to be non-empty string
+	// (or a non-empty default locale value for localized fields).
 	Required bool `form:"required" json:"required"`
 }
 
@@ -108,6 +146,11 @@ func (f *EditorField) SetHidden(hidden bool) {
 	f.Hidden = hidden
 }
 
+// I18nLocales implements [I18nField.I18nLocales] interface method.
+func (f *EditorField) I18nLocales() []string {
+	return f.I18n
+}
+
 // ColumnType implements [Field.ColumnType] interface method.
 func (f *EditorField) ColumnType(app App) string {
 	return "TEXT DEFAULT '' NOT NULL"
@@ -115,11 +158,24 @@ func (f *EditorField) ColumnType(app App) string {
 
 // PrepareValue implements [Field.PrepareValue] interface method.
 func (f *EditorField) PrepareValue(record *Record, raw any) (any, error) {
+	if len(f.I18n) > 0 {
+		return normalizeI18nValue(raw, f.I18n), nil
+	}
+
 	return cast.ToString(raw), nil
 }
 
 // ValidateValue implements [Field.ValidateValue] interface method.
 func (f *EditorField) ValidateValue(ctx context.Context, app App, record *Record) error {
+	if len(f.I18n) > 0 {
+		values, ok := record.GetRaw(f.Name).(types.JSONMap[string])
+		if !ok {
+			return validators.ErrUnsupportedValueType
+		}
+
+		return validateI18nValues(values, f.I18n, f.Required, f.validateSize)
+	}
+
 	val, ok := record.GetRaw(f.Name).(string)
 	if !ok {
 		return validators.ErrUnsupportedValueType
@@ -131,7 +187,11 @@ func (f *EditorField) ValidateValue(ctx context.Context, app App, record *Record
 		}
 	}
 
-	maxSize := f.CalculateMaxBodySize()
+	return f.validateSize(val)
+}
+
+func (f *EditorField) validateSize(val string) error {
+	maxSize := f.maxSize()
 
 	if int64(len(val)) > maxSize {
 		return validation.NewError(
@@ -149,11 +209,73 @@ func (f *EditorField) ValidateSettings(ctx context.Context, app App, collection
 		validation.Field(&f.Id, validation.By(DefaultFieldIdValidationRule)),
 		validation.Field(&f.Name, validation.By(DefaultFieldNameValidationRule)),
 		validation.Field(&f.MaxSize, validation.Min(0), validation.Max(maxSafeJSONInt)),
+		validation.Field(&f.Sanitize, validation.In(sanitizer.PresetStrict, sanitizer.PresetBasic, sanitizer.PresetRich)),
+		validation.Field(&f.SanitizeLinkRel, validation.Length(0, 255)),
+		validation.Field(&f.SanitizeImageHosts, validation.Each(is.Host)),
+		validation.Field(&f.I18n, validation.By(validateI18nLocales)),
 	)
 }
 
+// Intercept implements the [RecordInterceptor] interface.
+//
+// It sanitizes the field value before validating or persisting the record
+// in case [EditorField.Sanitize] is set.
+func (f *EditorField) Intercept(
+	ctx context.Context,
+	app App,
+	record *Record,
+	actionName string,
+	actionFunc func() error,
+) error {
+	switch actionName {
+	case InterceptorActionValidate, InterceptorActionCreate, InterceptorActionUpdate:
+		if policy := f.sanitizerPolicy(); policy != nil {
+			switch val := record.GetRaw(f.Name).(type) {
+			case string:
+				if val != "" {
+					record.SetRaw(f.Name, policy.Sanitize(val))
+				}
+			case types.JSONMap[string]:
+				sanitized := make(types.JSONMap[string], len(val))
+				for locale, v := range val {
+					sanitized[locale] = policy.Sanitize(v)
+				}
+				record.SetRaw(f.Name, sanitized)
+			}
+		}
+	}
+
+	return actionFunc()
+}
+
+// sanitizerPolicy returns the field sanitizer policy or nil if sanitization is not enabled.
+func (f *EditorField) sanitizerPolicy() *sanitizer.Policy {
+	policy := sanitizer.NewPolicy(f.Sanitize)
+	if policy == nil {
+		return nil
+	}
+
+	if f.SanitizeLinkRel != "" {
+		policy.LinkRel = f.SanitizeLinkRel
+	}
+
+	if len(f.SanitizeImageHosts) > 0 {
+		policy.ImageHosts = make([]string, len(f.SanitizeImageHosts))
+		for i, host := range f.SanitizeImageHosts {
+			policy.ImageHosts[i] = strings.ToLower(host)
+		}
+	}
+
+	return policy
+}
+
 // CalculateMaxBodySize implements the [MaxBodySizeCalculator] interface.
 func (f *EditorField) CalculateMaxBodySize() int64 {
+	return f.maxSize() * int64(max(1, len(f.I18n)))
+}
+
+// maxSize returns the max allowed size of a single (locale) field value.
+func (f *EditorField) maxSize() int64 {
 	if f.MaxSize <= 0 {
 		return DefaultEditorFieldMaxSize
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 func TestEditorFieldBaseMethods(t *testing.T) {
@@ -146,6 +147,56 @@ func TestEditorFieldValidateValue(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"i18n with plain string value",
+			&core.EditorField{Name: "test", I18n: []string{"en", "de"}},
+			func() *core.Record {
+				record := core.NewRecord(collection)
+				record.SetRaw("test", "abc")
+				return record
+			},
+			true,
+		},
+		{
+			"i18n with missing default locale value (required)",
+			&core.EditorField{Name: "test", Required: true, I18n: []string{"en", "de"}},
+			func() *core.Record {
+				record := core.NewRecord(collection)
+				record.SetRaw("test", types.JSONMap[string]{"de": "abc"})
+				return record
+			},
+			true,
+		},
+		{
+			"i18n with unsupported locale",
+			&core.EditorField{Name: "test", I18n: []string{"en", "de"}},
+			func() *core.Record {
+				record := core.NewRecord(collection)
+				record.SetRaw("test", types.JSONMap[string]{"en": "abc", "fr": "abc"})
+				return record
+			},
+			true,
+		},
+		{
+			"i18n with > MaxSize locale value",
+			&core.EditorField{Name: "test", MaxSize: 5, I18n: []string{"en", "de"}},
+			func() *core.Record {
+				record := core.NewRecord(collection)
+				record.SetRaw("test", types.JSONMap[string]{"en": "abc", "de": "abcdef"})
+				return record
+			},
+			true,
+		},
+		{
+			"i18n with valid values",
+			&core.EditorField{Name: "test", Required: true, MaxSize: 5, I18n: []string{"en", "de"}},
+			func() *core.Record {
+				record := core.NewRecord(collection)
+				record.SetRaw("test", types.JSONMap[string]{"en": "abcde", "de": "abcde"})
+				return record
+			},
+			false,
+		},
 	}
 
 	for _, s := range scenarios {
@@ -217,6 +268,32 @@ func TestEditorFieldValidateSettings(t *testing.T) {
 			},
 			[]string{"maxSize"},
 		},
+		{
+			"invalid sanitize options",
+			func() *core.EditorField {
+				return &core.EditorField{
+					Id:                 "test",
+					Name:               "test",
+					Sanitize:           "invalid",
+					SanitizeLinkRel:    strings.Repeat("a", 256),
+					SanitizeImageHosts: []string{"example.com", "invalid host"},
+				}
+			},
+			[]string{"sanitize", "sanitizeLinkRel", "sanitizeImageHosts"},
+		},
+		{
+			"valid sanitize options",
+			func() *core.EditorField {
+				return &core.EditorField{
+					Id:                 "test",
+					Name:               "test",
+					Sanitize:           "rich",
+					SanitizeLinkRel:    "noopener",
+					SanitizeImageHosts: []string{"example.com", "127.0.0.1"},
+				}
+			},
+			[]string{},
+		},
 	}
 
 	for _, s := range scenarios {
@@ -238,6 +315,7 @@ func TestEditorFieldCalculateMaxBodySize(t *testing.T) {
 	}{
 		{&core.EditorField{}, core.DefaultEditorFieldMaxSize},
 		{&core.EditorField{MaxSize: 10}, 10},
+		{&core.EditorField{MaxSize: 10, I18n: []string{"en", "de"}}, 20},
 	}
 
 	for i, s := range scenarios {
@@ -250,3 +328,74 @@ func TestEditorFieldCalculateMaxBodySize(t *testing.T) {
 		})
 	}
 }
+
+func TestEditorFieldIntercept(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_collection")
+
+	raw := `<p onclick="alert(1)">test<script>alert(2)</script> <a href="javascript:alert(3)" rel="opener">a</a> <img src="https://example.com/a.png"><img src="https://other.com/b.png"></p>`
+
+	scenarios := []struct {
+		name     string
+		field    *core.EditorField
+		action   string
+		expected string
+	}{
+		{
+			"no sanitize",
+			&core.EditorField{Name: "test"},
+			core.InterceptorActionValidate,
+			raw,
+		},
+		{
+			"non-sanitize action",
+			&core.EditorField{Name: "test", Sanitize: "basic"},
+			core.InterceptorActionCreateExecute,
+			raw,
+		},
+		{
+			"strict (validate)",
+			&core.EditorField{Name: "test", Sanitize: "strict"},
+			core.InterceptorActionValidate,
+			`test a `,
+		},
+		{
+			"basic (create)",
+			&core.EditorField{Name: "test", Sanitize: "basic"},
+			core.InterceptorActionCreate,
+			`<p>test <a rel="noopener noreferrer nofollow">a</a> </p>`,
+		},
+		{
+			"rich with custom options (update)",
+			&core.EditorField{Name: "test", Sanitize: "rich", SanitizeLinkRel: "nofollow", SanitizeImageHosts: []string{"EXAMPLE.com"}},
+			core.InterceptorActionUpdate,
+			`<p>test <a rel="nofollow">a</a> <img src="https://example.com/a.png"></p>`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			record := core.NewRecord(collection)
+			record.SetRaw(s.field.Name, raw)
+
+			calls := 0
+			err := s.field.Intercept(context.Background(), app, record, s.action, func() error {
+				calls++
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if calls != 1 {
+				t.Fatalf("Expected actionFunc to be called 1 time, got %d", calls)
+			}
+
+			if v := record.GetString(s.field.Name); v != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// I18nQueryParam is the request query parameter used to
+// select the active locale of the localized record fields.
+const I18nQueryParam = "lang"
+
+var i18nLocaleRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}([_-][a-zA-Z0-9]{2,8})*$`)
+
+// I18nField defines the interface of the fields that support storing per-locale values
+// (currently [TextField] and [EditorField]).
+type I18nField interface {
+	Field
+
+	// I18nLocales returns the supported field locales
+	// (the first one is the default fallback locale).
+	//
+	// Returns empty slice if the field is not localized.
+	I18nLocales() []string
+}
+
+// I18nFallbackChain returns the ordered list of the locales that should be
+// checked when resolving a localized value for the specified lang.
+//
+// The chain consists of the lang itself, its base language (e.g. "de" for "de-AT")
+// and the default locale (aka. the first one), keeping only the supported locales.
+func I18nFallbackChain(lang string, locales []string) []string {
+	if len(locales) == 0 {
+		return nil
+	}
+
+	chain := make([]string, 0, 3)
+
+	candidates := []string{lang}
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		candidates = append(candidates, lang[:i])
+	}
+	candidates = append(candidates, locales[0])
+
+	for _, c := range candidates {
+		for _, locale := range locales {
+			if strings.EqualFold(c, locale) && !slices.Contains(chain, locale) {
+				chain = append(chain, locale)
+			}
+		}
+	}
+
+	return chain
+}
+
+// LocalizedValue returns the first non-empty value from the
+// provided per-locale values following the lang fallback chain
+// (see [I18nFallbackChain]).
+func LocalizedValue(values map[string]string, lang string, locales []string) string {
+	for _, locale := range I18nFallbackChain(lang, locales) {
+		if v := values[locale]; v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// LocalizeRecord replaces the values of the localized record fields
+// with their resolved lang value (see [LocalizedValue]).
+//
+// Note that the record is modified in place and it is intended to be
+// used only for presentation purposes (e.g. before serializing an API response).
+func LocalizeRecord(record *Record, lang string) {
+	for _, f := range record.Collection().Fields {
+		i18nField, ok := f.(I18nField)
+		if !ok || len(i18nField.I18nLocales()) == 0 {
+			continue
+		}
+
+		values, ok := record.GetRaw(f.GetName()).(types.JSONMap[string])
+		if !ok {
+			continue
+		}
+
+		record.SetRaw(f.GetName(), LocalizedValue(values, lang, i18nField.I18nLocales()))
+	}
+}
+
+// normalizeI18nValue casts the provided raw value into a per-locale values map.
+//
+// Plain string values that are not json objects are assigned to the default locale.
+func normalizeI18nValue(raw any, locales []string) types.JSONMap[string] {
+	result := types.JSONMap[string]{}
+
+	switch v := raw.(type) {
+	case nil:
+	case types.JSONMap[string]:
+		for locale, val := range v {
+			result[locale] = val
+		}
+	case map[string]string:
+		for locale, val := range v {
+			result[locale] = val
+		}
+	case map[string]any:
+		for locale, val := range v {
+			result[locale] = cast.ToString(val)
+		}
+	default:
+		str := cast.ToString(raw)
+
+		trimmed := strings.TrimSpace(str)
+		if strings.HasPrefix(trimmed, "{") {
+			if err := json.Unmarshal([]byte(trimmed), &result); err == nil {
+				break
+			}
+			result = types.JSONMap[string]{}
+		}
+
+		if str != "" && len(locales) > 0 {
+			result[locales[0]] = str
+		}
+	}
+
+	return result
+}
+
+// validateI18nValues validates each locale value with the provided validator.
+func validateI18nValues(values types.JSONMap[string], locales []string, required bool, validate func(string) error) error {
+	for locale := range values {
+		if !slices.Contains(locales, locale) {
+			return validation.NewError("validation_invalid_locale", "Unsupported locale {{.locale}}.").
+				SetParams(map[string]any{"locale": locale})
+		}
+	}
+
+	if required && len(locales) > 0 {
+		if err := validation.Required.Validate(values[locales[0]]); err != nil {
+			return validation.NewError("validation_required_default_locale", "The default locale {{.locale}} value is required.").
+				SetParams(map[string]any{"locale": locales[0]})
+		}
+	}
+
+	for _, locale := range locales {
+		if err := validate(values[locale]); err != nil {
+			return validation.Errors{locale: err}
+		}
+	}
+
+	return nil
+}
+
+// validateI18nLocales validates the field i18n locales list.
+func validateI18nLocales(value any) error {
+	locales, _ := value.([]string)
+
+	for i, locale := range locales {
+		if !i18nLocaleRegex.MatchString(locale) {
+			return validation.Errors{
+				cast.ToString(i): validation.NewError("validation_invalid_locale", "Invalid locale format."),
+			}
+		}
+
+		if slices.Index(locales, locale) != i {
+			return validation.Errors{
+				cast.ToString(i): validation.NewError("validation_duplicated_locale", "Duplicated locale."),
+			}
+		}
+	}
+
+	return nil
+}
+
+// i18nIdentifier returns the sql identifier of the lang localized value
+// of the specified json column following the lang fallback chain.
+//
+// Plain (non-json) column values are treated as default locale values.
+func i18nIdentifier(column string, lang string, locales []string) string {
+	parts := []string{}
+
+	for _, locale := range I18nFallbackChain(lang, locales) {
+		parts = append(parts, "NULLIF("+dbutils.JSONExtract(column, `"`+locale+`"`)+", '')")
+	}
+
+	parts = append(parts, "(CASE WHEN json_valid([["+column+"]]) THEN '' ELSE [["+column+"]] END)")
+
+	return "COALESCE(" + strings.Join(parts, ", ") + ")"
+}
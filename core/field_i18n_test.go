@@ -0,0 +1,240 @@
+package core_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestI18nFallbackChain(t *testing.T) {
+	t.Parallel()
+
+	locales := []string{"en", "de", "de-AT", "bg"}
+
+	scenarios := []struct {
+		lang     string
+		locales  []string
+		expected []string
+	}{
+		{"", nil, nil},
+		{"de", nil, nil},
+		{"", locales, []string{"en"}},
+		{"en", locales, []string{"en"}},
+		{"de", locales, []string{"de", "en"}},
+		{"DE", locales, []string{"de", "en"}},
+		{"de-AT", locales, []string{"de-AT", "de", "en"}},
+		{"de_AT", locales, []string{"de", "en"}},
+		{"de-CH", locales, []string{"de", "en"}},
+		{"bg-BG", locales, []string{"bg", "en"}},
+		{"fr", locales, []string{"en"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.lang, func(t *testing.T) {
+			chain := core.I18nFallbackChain(s.lang, s.locales)
+
+			if !slices.Equal(chain, s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, chain)
+			}
+		})
+	}
+}
+
+func TestLocalizedValue(t *testing.T) {
+	t.Parallel()
+
+	locales := []string{"en", "de", "de-AT"}
+	values := map[string]string{"en": "hello", "de": "hallo", "de-AT": ""}
+
+	scenarios := []struct {
+		lang     string
+		expected string
+	}{
+		{"", "hello"},
+		{"en", "hello"},
+		{"de", "hallo"},
+		{"de-AT", "hallo"}, // empty value -> fallback
+		{"fr", "hello"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.lang, func(t *testing.T) {
+			v := core.LocalizedValue(values, s.lang, locales)
+			if v != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestTextFieldI18nPrepareValue(t *testing.T) {
+	t.Parallel()
+
+	f := &core.TextField{Name: "test", I18n: []string{"en", "de"}}
+	record := core.NewRecord(core.NewBaseCollection("test"))
+
+	scenarios := []struct {
+		name     string
+		raw      any
+		expected string
+	}{
+		{"nil", nil, `{}`},
+		{"empty string", "", `{}`},
+		{"plain string", "hello", `{"en":"hello"}`},
+		{"json object string", `{"en":"hello","de":"hallo"}`, `{"de":"hallo","en":"hello"}`},
+		{"invalid json object string", `{"en":`, `{"en":"{\"en\":"}`},
+		{"map[string]any", map[string]any{"de": "hallo", "en": 123}, `{"de":"hallo","en":"123"}`},
+		{"map[string]string", map[string]string{"de": "hallo"}, `{"de":"hallo"}`},
+		{"JSONMap", types.JSONMap[string]{"en": "hello"}, `{"en":"hello"}`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			v, err := f.PrepareValue(record, s.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m, ok := v.(types.JSONMap[string])
+			if !ok {
+				t.Fatalf("Expected JSONMap[string] instance, got %T", v)
+			}
+
+			if m.String() != s.expected {
+				t.Fatalf("Expected %s, got %s", s.expected, m.String())
+			}
+		})
+	}
+}
+
+func TestTextFieldI18nValidateValue(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_collection")
+
+	scenarios := []struct {
+		name        string
+		field       *core.TextField
+		value       any
+		expectError bool
+	}{
+		{"plain string", &core.TextField{Name: "test", I18n: []string{"en"}}, "abc", true},
+		{"empty map", &core.TextField{Name: "test", I18n: []string{"en"}}, types.JSONMap[string]{}, false},
+		{"empty map (required)", &core.TextField{Name: "test", Required: true, I18n: []string{"en"}}, types.JSONMap[string]{}, true},
+		{"non-default locale only (required)", &core.TextField{Name: "test", Required: true, I18n: []string{"en", "de"}}, types.JSONMap[string]{"de": "abc"}, true},
+		{"unsupported locale", &core.TextField{Name: "test", I18n: []string{"en"}}, types.JSONMap[string]{"en": "abc", "de": "abc"}, true},
+		{"locale value > max", &core.TextField{Name: "test", Max: 3, I18n: []string{"en", "de"}}, types.JSONMap[string]{"en": "abc", "de": "abcd"}, true},
+		{"locale value not matching pattern", &core.TextField{Name: "test", Pattern: `^\w+$`, I18n: []string{"en", "de"}}, types.JSONMap[string]{"en": "abc", "de": "a b"}, true},
+		{"valid values", &core.TextField{Name: "test", Required: true, Max: 3, I18n: []string{"en", "de"}}, types.JSONMap[string]{"en": "abc"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			record := core.NewRecord(collection)
+			record.SetRaw("test", s.value)
+
+			err := s.field.ValidateValue(t.Context(), app, record)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestI18nRecordsFilterAndLocalize(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_i18n")
+	collection.Fields.Add(
+		&core.TextField{Name: "title", I18n: []string{"en", "de", "de-AT"}},
+		&core.EditorField{Name: "content", I18n: []string{"en", "de"}},
+	)
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []map[string]any{
+		{"id": "a00000000000000", "title": map[string]any{"en": "apple", "de": "Apfel", "de-AT": "Apfl"}, "content": "<p>en only</p>"},
+		{"id": "b00000000000000", "title": map[string]any{"en": "banana"}, "content": map[string]any{"de": "<p>de</p>"}},
+		{"id": "c00000000000000", "title": map[string]any{"en": "cherry", "de": "Kirsche"}},
+	}
+	for _, d := range data {
+		record := core.NewRecord(collection)
+		record.Load(d)
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		lang     string
+		filter   string
+		expected []string
+	}{
+		{"", "title = 'apple'", []string{"a00000000000000"}},
+		{"", "title = 'Apfel'", []string{}},
+		{"de", "title = 'Apfel'", []string{"a00000000000000"}},
+		{"de", "title = 'banana'", []string{"b00000000000000"}}, // fallback to en
+		{"de-AT", "title ~ 'Apf'", []string{"a00000000000000"}},
+		{"de-AT", "title = 'Kirsche'", []string{"c00000000000000"}},
+		{"fr", "title = 'cherry'", []string{"c00000000000000"}},
+		{"de", "content ~ 'en only'", []string{"a00000000000000"}},
+		{"de", "content = ''", []string{"c00000000000000"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.lang+"_"+s.filter, func(t *testing.T) {
+			requestInfo := &core.RequestInfo{Query: map[string]string{core.I18nQueryParam: s.lang}}
+
+			resolver := core.NewRecordFieldResolver(app, collection, requestInfo, true)
+
+			expr, err := search.FilterData(s.filter).BuildExpr(resolver)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			query := app.RecordQuery(collection).Select("test_i18n.id").AndWhere(expr).OrderBy("test_i18n.id")
+			if err := resolver.UpdateQuery(query); err != nil {
+				t.Fatal(err)
+			}
+
+			ids := []string{}
+			if err := query.Column(&ids); err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(ids, s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, ids)
+			}
+		})
+	}
+
+	t.Run("LocalizeRecord", func(t *testing.T) {
+		record, err := app.FindRecordById(collection, "a00000000000000")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		core.LocalizeRecord(record, "de-AT")
+
+		if v := record.Get("title"); v != "Apfl" {
+			t.Fatalf("Expected title %q, got %v", "Apfl", v)
+		}
+
+		if v := record.Get("content"); v != "<p>en only</p>" {
+			t.Fatalf("Expected content %q, got %v", "<p>en only</p>", v)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package core
+
+import (
+	"context"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/spf13/cast"
+)
+
+func init() {
+	Fields[FieldTypeOrder] = func() Field {
+		return &OrderField{}
+	}
+}
+
+const FieldTypeOrder = "order"
+
+var (
+	_ Field             = (*OrderField)(nil)
+	_ RecordInterceptor = (*OrderField)(nil)
+)
+
+// OrderField defines "order" type field for storing the manual
+// (e.g. drag-and-drop) sequential position of a record.
+//
+// New records without explicit position are appended at the end of
+// their ordering scope and the records could be reordered with [BaseApp.MoveOrderedRecord]
+// which rebalances the positions of the scope records to 1..n.
+//
+// The respective zero record field value is 0.
+type OrderField struct {
+	// Name (required) is the unique name of the field.
+	Name string `form:"name" json:"name"`
+
+	// Id is the unique stable field identifier.
+	//
+	// It is automatically generated from the name when adding to a collection FieldsList.
+	Id string `form:"id" json:"id"`
+
+	// System prevents the renaming and removal of the field.
+	System bool `form:"system" json:"system"`
+
+	// Hidden hides the field from the API response.
+	Hidden bool `form:"hidden" json:"hidden"`
+
+	// Presentable hints the Dashboard UI to use the underlying
+	// field record value in the relation preview label.
+	Presentable bool `form:"presentable" json:"presentable"`
+
+	// ---
+
+	// Scope is the optional name of a single value collection field
+	// whose value partitions the records ordering
+	// (e.g. a "column" relation field of kanban cards).
+	//
+	// Records moved to another scope with a regular update
+	// (aka. without an explicit position change) are appended at its end.
+	Scope string `form:"scope" json:"scope"`
+}
+
+// Type implements [Field.Type] interface method.
+func (f *OrderField) Type() string {
+	return FieldTypeOrder
+}
+
+// GetId implements [Field.GetId] interface method.
+func (f *OrderField) GetId() string {
+	return f.Id
+}
+
+// SetId implements [Field.SetId] interface method.
+func (f *OrderField) SetId(id string) {
+	f.Id = id
+}
+
+// GetName implements [Field.GetName] interface method.
+func (f *OrderField) GetName() string {
+	return f.Name
+}
+
+// SetName implements [Field.SetName] interface method.
+func (f *OrderField) SetName(name string) {
+	f.Name = name
+}
+
+// GetSystem implements [Field.GetSystem] interface method.
+func (f *OrderField) GetSystem() bool {
+	return f.System
+}
+
+// SetSystem implements [Field.SetSystem] interface method.
+func (f *OrderField) SetSystem(system bool) {
+	f.System = system
+}
+
+// GetHidden implements [Field.GetHidden] interface method.
+func (f *OrderField) GetHidden() bool {
+	return f.Hidden
+}
+
+// SetHidden implements [Field.SetHidden] interface method.
+func (f *OrderField) SetHidden(hidden bool) {
+	f.Hidden = hidden
+}
+
+// ColumnType implements [Field.ColumnType] interface method.
+func (f *OrderField) ColumnType(app App) string {
+	return "NUMERIC DEFAULT 0 NOT NULL"
+}
+
+// PrepareValue implements [Field.PrepareValue] interface method.
+func (f *OrderField) PrepareValue(record *Record, raw any) (any, error) {
+	return cast.ToInt(raw), nil
+}
+
+// ValidateValue implements [Field.ValidateValue] interface method.
+func (f *OrderField) ValidateValue(ctx context.Context, app App, record *Record) error {
+	return validation.Min(0).Validate(record.GetInt(f.Name))
+}
+
+// ValidateSettings implements [Field.ValidateSettings] interface method.
+func (f *OrderField) ValidateSettings(ctx context.Context, app App, collection *Collection) error {
+	return validation.ValidateStruct(f,
+		validation.Field(&f.Id, validation.By(DefaultFieldIdValidationRule)),
+		validation.Field(&f.Name, validation.By(DefaultFieldNameValidationRule)),
+		validation.Field(&f.Scope, validation.By(f.checkScope(collection))),
+	)
+}
+
+func (f *OrderField) checkScope(collection *Collection) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+		if v == "" {
+			return nil // nothing to check
+		}
+
+		field := collection.Fields.GetByName(v)
+
+		if field == nil || field.GetName() == f.Name {
+			return validation.NewError("validation_invalid_order_scope", "The scope must be an existing collection field.")
+		}
+
+		if mv, ok := field.(MultiValuer); ok && mv.IsMultiple() {
+			return validation.NewError("validation_invalid_order_scope", "The scope field must be a single value field.")
+		}
+
+		return nil
+	}
+}
+
+// Intercept implements the [RecordInterceptor] interface.
+func (f *OrderField) Intercept(
+	ctx context.Context,
+	app App,
+	record *Record,
+	actionName string,
+	actionFunc func() error,
+) error {
+	switch actionName {
+	case InterceptorActionCreateExecute:
+		if record.GetInt(f.Name) <= 0 {
+			if err := f.appendToScope(app, record); err != nil {
+				return err
+			}
+		}
+	case InterceptorActionUpdateExecute:
+		if f.Scope != "" && ctx.Value(orderMoveCtxKey{}) == nil {
+			original := record.Original()
+
+			if record.GetString(f.Scope) != original.GetString(f.Scope) &&
+				record.GetInt(f.Name) == original.GetInt(f.Name) {
+				if err := f.appendToScope(app, record); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return actionFunc()
+}
+
+// appendToScope sets the record position after the last record in its scope.
+func (f *OrderField) appendToScope(app App, record *Record) error {
+	tableName := inflector.Columnify(record.Collection().Name)
+
+	var maxPosition int
+
+	err := app.RecordQuery(record.Collection()).
+		Select("COALESCE(MAX([[" + tableName + "." + f.Name + "]]), 0)").
+		AndWhere(f.scopeExpr(tableName, record)).
+		AndWhere(dbx.Not(dbx.HashExp{tableName + ".id": record.Id})).
+		Row(&maxPosition)
+	if err != nil {
+		return err
+	}
+
+	record.Set(f.Name, maxPosition+1)
+
+	return nil
+}
+
+// scopeExpr returns the db expression matching the records from the same scope as the provided record.
+func (f *OrderField) scopeExpr(tableName string, record *Record) dbx.Expression {
+	if f.Scope == "" {
+		return dbx.NewExp("1=1")
+	}
+
+	return dbx.HashExp{tableName + "." + f.Scope: record.Get(f.Scope)}
+}
@@ -0,0 +1,195 @@
+package core_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestOrderFieldBaseMethods(t *testing.T) {
+	testFieldBaseMethods(t, core.FieldTypeOrder)
+}
+
+func TestOrderFieldColumnType(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	f := &core.OrderField{}
+
+	expected := "NUMERIC DEFAULT 0 NOT NULL"
+
+	if v := f.ColumnType(app); v != expected {
+		t.Fatalf("Expected\n%q\ngot\n%q", expected, v)
+	}
+}
+
+func TestOrderFieldPrepareValue(t *testing.T) {
+	f := &core.OrderField{}
+	record := core.NewRecord(core.NewBaseCollection("test"))
+
+	scenarios := []struct {
+		raw      any
+		expected int
+	}{
+		{nil, 0},
+		{"", 0},
+		{"test", 0},
+		{"2", 2},
+		{2.6, 2},
+		{-1, -1},
+	}
+
+	for i, s := range scenarios {
+		t.Run(fmt.Sprintf("%d_%#v", i, s.raw), func(t *testing.T) {
+			v, err := f.PrepareValue(record, s.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v != s.expected {
+				t.Fatalf("Expected %d, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestOrderFieldValidateValue(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_collection")
+
+	scenarios := []struct {
+		value       int
+		expectError bool
+	}{
+		{-1, true},
+		{0, false},
+		{10, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(fmt.Sprintf("%d", s.value), func(t *testing.T) {
+			f := &core.OrderField{Name: "test"}
+
+			record := core.NewRecord(collection)
+			record.SetRaw("test", s.value)
+
+			err := f.ValidateValue(context.Background(), app, record)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestOrderFieldValidateSettings(t *testing.T) {
+	testDefaultFieldIdValidation(t, core.FieldTypeOrder)
+	testDefaultFieldNameValidation(t, core.FieldTypeOrder)
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_collection")
+	collection.Fields.Add(
+		&core.TextField{Name: "single"},
+		&core.SelectField{Name: "multiple", Values: []string{"a", "b"}, MaxSelect: 2},
+	)
+
+	scenarios := []struct {
+		name        string
+		field       *core.OrderField
+		expectError bool
+	}{
+		{"zero", &core.OrderField{Id: "test", Name: "test"}, false},
+		{"missing scope field", &core.OrderField{Id: "test", Name: "test", Scope: "missing"}, true},
+		{"self scope field", &core.OrderField{Id: "test", Name: "test", Scope: "test"}, true},
+		{"multiple scope field", &core.OrderField{Id: "test", Name: "test", Scope: "multiple"}, true},
+		{"single scope field", &core.OrderField{Id: "test", Name: "test", Scope: "single"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			collection.Fields.Add(s.field)
+			defer collection.Fields.RemoveById(s.field.Id)
+
+			err := s.field.ValidateSettings(context.Background(), app, collection)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestOrderFieldIntercept(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_order")
+	collection.Fields.Add(
+		&core.TextField{Name: "column"},
+		&core.OrderField{Name: "position", Scope: "column"},
+	)
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(column string, position int) *core.Record {
+		record := core.NewRecord(collection)
+		record.Set("column", column)
+		record.Set("position", position)
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+		return record
+	}
+
+	scenarios := []struct {
+		record   *core.Record
+		expected int
+	}{
+		{create("a", 0), 1},
+		{create("a", 0), 2},
+		{create("b", 0), 1},
+		{create("a", 10), 10}, // explicit position
+		{create("a", 0), 11},
+	}
+
+	for i, s := range scenarios {
+		if v := s.record.GetInt("position"); v != s.expected {
+			t.Fatalf("[%d] Expected position %d, got %d", i, s.expected, v)
+		}
+	}
+
+	// scope change without explicit position
+	record, err := app.FindRecordById(collection, scenarios[0].record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("column", "b")
+	if err := app.Save(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetInt("position"); v != 2 {
+		t.Fatalf("Expected the record to be appended in the new scope, got position %d", v)
+	}
+
+	// scope change with explicit position
+	record.Set("column", "c")
+	record.Set("position", 5)
+	if err := app.Save(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetInt("position"); v != 5 {
+		t.Fatalf("Expected the explicit position to be kept, got %d", v)
+	}
+}
@@ -271,6 +271,11 @@ func (f *RelationField) checkCollectionId(app App, collection *Collection) valid
 			}
 		}
 
+		// allow self references for the new collections
+		if collection.IsNew() && v == collection.Id {
+			return nil
+		}
+
 		relCollection, _ := app.FindCachedCollectionByNameOrId(v)
 
 		// validate collectionId
@@ -0,0 +1,275 @@
+package core
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"unicode/utf8"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func init() {
+	Fields[FieldTypeTags] = func() Field {
+		return &TagsField{}
+	}
+}
+
+const FieldTypeTags = "tags"
+
+// DefaultTagMaxLength is the default max allowed length of a single tag.
+const DefaultTagMaxLength = 50
+
+var (
+	_ Field        = (*TagsField)(nil)
+	_ MultiValuer  = (*TagsField)(nil)
+	_ DriverValuer = (*TagsField)(nil)
+	_ SetterFinder = (*TagsField)(nil)
+)
+
+// TagsField defines "tags" type field for storing a list of free-form string tags.
+//
+// The tags are stored as json array in the record column and their
+// usage counts are maintained automatically in the "_tags" system table
+// (see [BaseApp.FindTagSuggestions], [BaseApp.RenameTag] and [BaseApp.MergeTags]).
+//
+// Each tag is trimmed, its inner whitespaces are collapsed and
+// the empty and duplicated tags are removed.
+//
+// The respective zero record field value is empty string slice.
+//
+// ---
+//
+// The following additional setter keys are available:
+//
+//   - "fieldName+" - append one or more tags to the existing record one. For example:
+//
+//     record.Set("tags+", []string{"new1", "new2"}) // []string{"old1", "old2", "new1", "new2"}
+//
+//   - "+fieldName" - prepend one or more tags to the existing record one. For example:
+//
+//     record.Set("+tags", []string{"new1", "new2"}) // []string{"new1", "new2", "old1", "old2"}
+//
+//   - "fieldName-" - subtract one or more tags from the existing record one. For example:
+//
+//     record.Set("tags-", "old1") // []string{"old2"}
+type TagsField struct {
+	// Name (required) is the unique name of the field.
+	Name string `form:"name" json:"name"`
+
+	// Id is the unique stable field identifier.
+	//
+	// It is automatically generated from the name when adding to a collection FieldsList.
+	Id string `form:"id" json:"id"`
+
+	// System prevents the renaming and removal of the field.
+	System bool `form:"system" json:"system"`
+
+	// Hidden hides the field from the API response.
+	Hidden bool `form:"hidden" json:"hidden"`
+
+	// Presentable hints the Dashboard UI to use the underlying
+	// field record value in the relation preview label.
+	Presentable bool `form:"presentable" json:"presentable"`
+
+	// ---
+
+	// MaxSelect specifies the max allowed number of tags per record.
+	//
+	// Zero means no explicit limit.
+	MaxSelect int `form:"maxSelect" json:"maxSelect"`
+
+	// MaxLength specifies the max allowed length of a single tag
+	// (if zero, fallbacks to DefaultTagMaxLength).
+	MaxLength int `form:"maxLength" json:"maxLength"`
+
+	// Lowercase normalizes the tags to lower case.
+	Lowercase bool `form:"lowercase" json:"lowercase"`
+
+	// Required will require the field value to be non-empty.
+	Required bool `form:"required" json:"required"`
+}
+
+// Type implements [Field.Type] interface method.
+func (f *TagsField) Type() string {
+	return FieldTypeTags
+}
+
+// GetId implements [Field.GetId] interface method.
+func (f *TagsField) GetId() string {
+	return f.Id
+}
+
+// SetId implements [Field.SetId] interface method.
+func (f *TagsField) SetId(id string) {
+	f.Id = id
+}
+
+// GetName implements [Field.GetName] interface method.
+func (f *TagsField) GetName() string {
+	return f.Name
+}
+
+// SetName implements [Field.SetName] interface method.
+func (f *TagsField) SetName(name string) {
+	f.Name = name
+}
+
+// GetSystem implements [Field.GetSystem] interface method.
+func (f *TagsField) GetSystem() bool {
+	return f.System
+}
+
+// SetSystem implements [Field.SetSystem] interface method.
+func (f *TagsField) SetSystem(system bool) {
+	f.System = system
+}
+
+// GetHidden implements [Field.GetHidden] interface method.
+func (f *TagsField) GetHidden() bool {
+	return f.Hidden
+}
+
+// SetHidden implements [Field.SetHidden] interface method.
+func (f *TagsField) SetHidden(hidden bool) {
+	f.Hidden = hidden
+}
+
+// IsMultiple implements [MultiValuer] interface.
+//
+// The tags field is always multiple.
+func (f *TagsField) IsMultiple() bool {
+	return true
+}
+
+// ColumnType implements [Field.ColumnType] interface method.
+func (f *TagsField) ColumnType(app App) string {
+	return "JSON DEFAULT '[]' NOT NULL"
+}
+
+// PrepareValue implements [Field.PrepareValue] interface method.
+func (f *TagsField) PrepareValue(record *Record, raw any) (any, error) {
+	return f.normalizeValue(raw), nil
+}
+
+// NormalizeTag returns the normalized form of a single tag
+// according to the field options.
+func (f *TagsField) NormalizeTag(tag string) string {
+	tag = strings.Join(strings.Fields(tag), " ")
+
+	if f.Lowercase {
+		tag = strings.ToLower(tag)
+	}
+
+	return tag
+}
+
+func (f *TagsField) normalizeValue(raw any) []string {
+	val := list.ToUniqueStringSlice(raw)
+
+	result := make([]string, 0, len(val))
+
+	for _, tag := range val {
+		tag = f.NormalizeTag(tag)
+		if tag != "" {
+			result = append(result, tag)
+		}
+	}
+
+	return list.ToUniqueStringSlice(result)
+}
+
+// DriverValue implements the [DriverValuer] interface.
+func (f *TagsField) DriverValue(record *Record) (driver.Value, error) {
+	// serialize as json string array
+	return append(types.JSONArray[string]{}, f.normalizeValue(record.GetRaw(f.Name))...), nil
+}
+
+// ValidateValue implements [Field.ValidateValue] interface method.
+func (f *TagsField) ValidateValue(ctx context.Context, app App, record *Record) error {
+	tags := f.normalizeValue(record.GetRaw(f.Name))
+	if len(tags) == 0 {
+		if f.Required {
+			return validation.ErrRequired
+		}
+		return nil // nothing to check
+	}
+
+	if f.MaxSelect > 0 && len(tags) > f.MaxSelect {
+		return validation.NewError("validation_too_many_values", "Select no more than {{.maxSelect}}").
+			SetParams(map[string]any{"maxSelect": f.MaxSelect})
+	}
+
+	maxLength := f.MaxLength
+	if maxLength <= 0 {
+		maxLength = DefaultTagMaxLength
+	}
+
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > maxLength {
+			return validation.NewError("validation_tag_too_long", "Tag {{.tag}} must be no more than {{.maxLength}} character(s)").
+				SetParams(map[string]any{"tag": tag, "maxLength": maxLength})
+		}
+	}
+
+	return nil
+}
+
+// ValidateSettings implements [Field.ValidateSettings] interface method.
+func (f *TagsField) ValidateSettings(ctx context.Context, app App, collection *Collection) error {
+	return validation.ValidateStruct(f,
+		validation.Field(&f.Id, validation.By(DefaultFieldIdValidationRule)),
+		validation.Field(&f.Name, validation.By(DefaultFieldNameValidationRule)),
+		validation.Field(&f.MaxSelect, validation.Min(0)),
+		validation.Field(&f.MaxLength, validation.Min(0), validation.Max(255)),
+	)
+}
+
+// FindSetter implements the [SetterFinder] interface.
+func (f *TagsField) FindSetter(key string) SetterFunc {
+	switch key {
+	case f.Name:
+		return f.setValue
+	case "+" + f.Name:
+		return f.prependValue
+	case f.Name + "+":
+		return f.appendValue
+	case f.Name + "-":
+		return f.subtractValue
+	default:
+		return nil
+	}
+}
+
+func (f *TagsField) setValue(record *Record, raw any) {
+	record.SetRaw(f.Name, f.normalizeValue(raw))
+}
+
+func (f *TagsField) appendValue(record *Record, modifierValue any) {
+	val := append(
+		list.ToUniqueStringSlice(record.GetRaw(f.Name)),
+		list.ToUniqueStringSlice(modifierValue)...,
+	)
+
+	f.setValue(record, val)
+}
+
+func (f *TagsField) prependValue(record *Record, modifierValue any) {
+	val := append(
+		list.ToUniqueStringSlice(modifierValue),
+		list.ToUniqueStringSlice(record.GetRaw(f.Name))...,
+	)
+
+	f.setValue(record, val)
+}
+
+func (f *TagsField) subtractValue(record *Record, modifierValue any) {
+	val := list.SubtractSlice(
+		list.ToUniqueStringSlice(record.GetRaw(f.Name)),
+		f.normalizeValue(modifierValue),
+	)
+
+	f.setValue(record, val)
+}
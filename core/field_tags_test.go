@@ -0,0 +1,223 @@
+package core_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestTagsFieldBaseMethods(t *testing.T) {
+	testFieldBaseMethods(t, core.FieldTypeTags)
+}
+
+func TestTagsFieldColumnType(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	f := &core.TagsField{}
+
+	expected := "JSON DEFAULT '[]' NOT NULL"
+
+	if v := f.ColumnType(app); v != expected {
+		t.Fatalf("Expected\n%q\ngot\n%q", expected, v)
+	}
+}
+
+func TestTagsFieldIsMultiple(t *testing.T) {
+	f := &core.TagsField{}
+
+	if !f.IsMultiple() {
+		t.Fatal("Expected IsMultiple true")
+	}
+}
+
+func TestTagsFieldPrepareValue(t *testing.T) {
+	record := core.NewRecord(core.NewBaseCollection("test"))
+
+	scenarios := []struct {
+		raw       any
+		lowercase bool
+		expected  string
+	}{
+		{nil, false, `[]`},
+		{"", false, `[]`},
+		{"a", false, `["a"]`},
+		{`["a", " b  c ", "", "a"]`, false, `["a","b c"]`},
+		{[]string{"Go", "go", " GO "}, false, `["Go","go","GO"]`},
+		{[]string{"Go", "go", " GO "}, true, `["go"]`},
+	}
+
+	for i, s := range scenarios {
+		t.Run("", func(t *testing.T) {
+			f := &core.TagsField{Lowercase: s.lowercase}
+
+			v, err := f.PrepareValue(record, s.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			raw, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(raw) != s.expected {
+				t.Fatalf("[%d] Expected %s, got %s", i, s.expected, raw)
+			}
+		})
+	}
+}
+
+func TestTagsFieldValidateValue(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_collection")
+
+	scenarios := []struct {
+		name        string
+		field       *core.TagsField
+		record      func() *core.Record
+		expectError bool
+	}{
+		{
+			"zero field value (not required)",
+			&core.TagsField{Name: "test"},
+			func() *core.Record {
+				return core.NewRecord(collection)
+			},
+			false,
+		},
+		{
+			"zero field value (required)",
+			&core.TagsField{Name: "test", Required: true},
+			func() *core.Record {
+				return core.NewRecord(collection)
+			},
+			true,
+		},
+		{
+			"> MaxSelect",
+			&core.TagsField{Name: "test", MaxSelect: 2},
+			func() *core.Record {
+				record := core.NewRecord(collection)
+				record.SetRaw("test", []string{"a", "b", "c"})
+				return record
+			},
+			true,
+		},
+		{
+			"> default MaxLength",
+			&core.TagsField{Name: "test"},
+			func() *core.Record {
+				record := core.NewRecord(collection)
+				record.SetRaw("test", []string{"a", string(make([]byte, core.DefaultTagMaxLength+1))})
+				return record
+			},
+			true,
+		},
+		{
+			"> MaxLength",
+			&core.TagsField{Name: "test", MaxLength: 2},
+			func() *core.Record {
+				record := core.NewRecord(collection)
+				record.SetRaw("test", []string{"a", "abc"})
+				return record
+			},
+			true,
+		},
+		{
+			"valid tags",
+			&core.TagsField{Name: "test", Required: true, MaxSelect: 2, MaxLength: 2},
+			func() *core.Record {
+				record := core.NewRecord(collection)
+				record.SetRaw("test", []string{"a", "ab"})
+				return record
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.field.ValidateValue(context.Background(), app, s.record())
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestTagsFieldValidateSettings(t *testing.T) {
+	testDefaultFieldIdValidation(t, core.FieldTypeTags)
+	testDefaultFieldNameValidation(t, core.FieldTypeTags)
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_collection")
+
+	scenarios := []struct {
+		name        string
+		field       *core.TagsField
+		expectError bool
+	}{
+		{"zero", &core.TagsField{Id: "test", Name: "test"}, false},
+		{"negative MaxSelect", &core.TagsField{Id: "test", Name: "test", MaxSelect: -1}, true},
+		{"negative MaxLength", &core.TagsField{Id: "test", Name: "test", MaxLength: -1}, true},
+		{"MaxLength > 255", &core.TagsField{Id: "test", Name: "test", MaxLength: 256}, true},
+		{"valid", &core.TagsField{Id: "test", Name: "test", MaxSelect: 10, MaxLength: 255}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.field.ValidateSettings(context.Background(), app, collection)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestTagsFieldFindSetter(t *testing.T) {
+	field := &core.TagsField{Name: "test", Lowercase: true}
+
+	collection := core.NewBaseCollection("test_collection")
+	collection.Fields.Add(field)
+
+	scenarios := []struct {
+		key      string
+		value    any
+		expected string
+	}{
+		{"test", []string{"B", "a"}, `["b","a"]`},
+		{"test+", []string{"C", "a"}, `["a","b","c"]`},
+		{"+test", []string{"C", "d"}, `["c","d","a","b"]`},
+		{"test-", []string{"A"}, `["b"]`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.key, func(t *testing.T) {
+			record := core.NewRecord(collection)
+			record.SetRaw("test", []string{"a", "b"})
+
+			record.Set(s.key, s.value)
+
+			raw, err := json.Marshal(record.GetRaw("test"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(raw) != s.expected {
+				t.Fatalf("Expected %s, got %s", s.expected, raw)
+			}
+		})
+	}
+}
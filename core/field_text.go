@@ -12,6 +12,7 @@ import (
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core/validators"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
@@ -29,6 +30,7 @@ var (
 	_ Field             = (*TextField)(nil)
 	_ SetterFinder      = (*TextField)(nil)
 	_ RecordInterceptor = (*TextField)(nil)
+	_ I18nField         = (*TextField)(nil)
 )
 
 var forbiddenPKCharacters = []string{
@@ -49,7 +51,8 @@ const largestReservedPKLength = 4
 
 // TextField defines "text" type field for storing any string value.
 //
-// The respective zero record field value is empty string.
+// The respective zero record field value is empty string
+// (or empty [types.JSONMap] for localized fields, see [TextField.I18n]).
 //
 // The following additional setter keys are available:
 //
@@ -100,7 +103,17 @@ type TextField struct {
 	// Note: the generated value still needs to satisfy min, max, pattern (if set)
 	AutogeneratePattern string `form:"autogeneratePattern" json:"autogeneratePattern"`
 
-	// Required will require the field value to be non-empty string.
+	// I18n specifies an optional list of locales (e.g. ["en", "de", "de-AT"])
+	// for storing per-locale field values.
+	//
+	// The first locale is the default one and it is used as last fallback
+	// when resolving the value of the requested ?lang= locale (see [LocalizedValue]).
+	//
+	// Leave it empty to store a single plain string value.
+	I18n []string `form:"i18n" json:"i18n,omitempty"`
+
+	// Required will require the field value to be non-empty string
+	// (or a non-empty default locale value for localized fields).
 	Required bool `form:"required" json:"required"`
 
 	// PrimaryKey will mark the field as primary key.
@@ -154,6 +167,11 @@ func (f *TextField) SetHidden(hidden bool) {
 	f.Hidden = hidden
 }
 
+// I18nLocales implements [I18nField.I18nLocales] interface method.
+func (f *TextField) I18nLocales() []string {
+	return f.I18n
+}
+
 // ColumnType implements [Field.ColumnType] interface method.
 func (f *TextField) ColumnType(app App) string {
 	if f.PrimaryKey {
@@ -168,11 +186,28 @@ func (f *TextField) ColumnType(app App) string {
 
 // PrepareValue implements [Field.PrepareValue] interface method.
 func (f *TextField) PrepareValue(record *Record, raw any) (any, error) {
+	if len(f.I18n) > 0 {
+		return normalizeI18nValue(raw, f.I18n), nil
+	}
+
 	return cast.ToString(raw), nil
 }
 
 // ValidateValue implements [Field.ValidateValue] interface method.
 func (f *TextField) ValidateValue(ctx context.Context, app App, record *Record) error {
+	if len(f.I18n) > 0 {
+		values, ok := record.GetRaw(f.Name).(types.JSONMap[string])
+		if !ok {
+			return validators.ErrUnsupportedValueType
+		}
+
+		// the required check is applied only for the default locale
+		plain := *f
+		plain.Required = false
+
+		return validateI18nValues(values, f.I18n, f.Required, plain.ValidatePlainValue)
+	}
+
 	newVal, ok := record.GetRaw(f.Name).(string)
 	if !ok {
 		return validators.ErrUnsupportedValueType
@@ -283,13 +318,21 @@ func (f *TextField) ValidateSettings(ctx context.Context, app App, collection *C
 			validation.By(DefaultFieldNameValidationRule),
 			validation.When(f.PrimaryKey, validation.In(idColumn).Error(`The primary key must be named "id".`)),
 		),
-		validation.Field(&f.PrimaryKey, validation.By(f.checkOtherFieldsForPK(collection))),
+		validation.Field(&f.PrimaryKey,
+			validation.By(f.checkOtherFieldsForPK(collection)),
+			validation.When(len(f.I18n) > 0, validation.Empty.Error("Localized fields cannot be primary keys.")),
+		),
 		validation.Field(&f.Min, validation.Min(0), validation.Max(maxSafeJSONInt)),
 		validation.Field(&f.Max, validation.Min(f.Min), validation.Max(maxSafeJSONInt)),
 		validation.Field(&f.Pattern, validation.When(f.PrimaryKey, validation.Required), validation.By(validators.IsRegex)),
 		validation.Field(&f.Hidden, validation.When(f.PrimaryKey, validation.Empty)),
 		validation.Field(&f.Required, validation.When(f.PrimaryKey, validation.Required)),
-		validation.Field(&f.AutogeneratePattern, validation.By(validators.IsRegex), validation.By(f.checkAutogeneratePattern)),
+		validation.Field(&f.AutogeneratePattern,
+			validation.By(validators.IsRegex),
+			validation.By(f.checkAutogeneratePattern),
+			validation.When(len(f.I18n) > 0, validation.Empty.Error("Localized fields cannot have autogenerate pattern.")),
+		),
+		validation.Field(&f.I18n, validation.By(validateI18nLocales)),
 	)
 }
 
@@ -351,7 +394,7 @@ func (f *TextField) Intercept(
 	// set autogenerated value if missing for new records
 	switch actionName {
 	case InterceptorActionValidate, InterceptorActionCreate:
-		if f.AutogeneratePattern != "" && f.hasZeroValue(record) && record.IsNew() {
+		if f.AutogeneratePattern != "" && len(f.I18n) == 0 && f.hasZeroValue(record) && record.IsNew() {
 			v, err := security.RandomStringByRegex(f.AutogeneratePattern)
 			if err != nil {
 				return fmt.Errorf("failed to autogenerate %q value: %w", f.Name, err)
@@ -373,6 +416,11 @@ func (f *TextField) FindSetter(key string) SetterFunc {
 	switch key {
 	case f.Name:
 		return func(record *Record, raw any) {
+			if len(f.I18n) > 0 {
+				record.SetRaw(f.Name, normalizeI18nValue(raw, f.I18n))
+				return
+			}
+
 			record.SetRaw(f.Name, cast.ToString(raw))
 		}
 	case f.Name + autogenerateModifier:
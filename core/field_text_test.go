@@ -564,6 +564,51 @@ func TestTextFieldValidateSettings(t *testing.T) {
 			},
 			[]string{"min"},
 		},
+		{
+			"valid i18n locales",
+			func() *core.TextField {
+				return &core.TextField{
+					Id:   "test",
+					Name: "test",
+					I18n: []string{"en", "de", "de-AT", "zh_Hant"},
+				}
+			},
+			[]string{},
+		},
+		{
+			"invalid i18n locales",
+			func() *core.TextField {
+				return &core.TextField{
+					Id:   "test",
+					Name: "test",
+					I18n: []string{"en", "invalid locale"},
+				}
+			},
+			[]string{"i18n"},
+		},
+		{
+			"duplicated i18n locales",
+			func() *core.TextField {
+				return &core.TextField{
+					Id:   "test",
+					Name: "test",
+					I18n: []string{"en", "de", "en"},
+				}
+			},
+			[]string{"i18n"},
+		},
+		{
+			"i18n with autogenerate pattern",
+			func() *core.TextField {
+				return &core.TextField{
+					Id:                  "test",
+					Name:                "test",
+					I18n:                []string{"en"},
+					AutogeneratePattern: "[a-z]+",
+				}
+			},
+			[]string{"autogeneratePattern"},
+		},
 	}
 
 	for _, s := range scenarios {
@@ -0,0 +1,307 @@
+package core
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+// DefaultHookSlowThreshold is the default hook handler execution
+// time in milliseconds after which a slow handler warning is logged.
+const DefaultHookSlowThreshold = 500
+
+// HookTracingConfig defines the app hooks execution tracing settings.
+type HookTracingConfig struct {
+	// Enabled enables the collection of the hook handlers execution
+	// time and error stats (see [BaseApp.HookStats]).
+	//
+	// Note that the tracing has a small performance overhead for every hook call.
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// SlowThreshold is the handler execution time in milliseconds after
+	// which a warning is logged (if not set, fallbacks to DefaultHookSlowThreshold).
+	SlowThreshold int64 `form:"slowThreshold" json:"slowThreshold"`
+}
+
+// Validate makes HookTracingConfig validatable by implementing [validation.Validatable] interface.
+func (c HookTracingConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.SlowThreshold, validation.Min(0)),
+	)
+}
+
+// HookHandlerStats defines the aggregated execution stats of a single hook handler.
+type HookHandlerStats struct {
+	// Hook is the name of the hook (e.g. "OnRecordCreate").
+	Hook string `json:"hook"`
+
+	// Handler is the handler id.
+	Handler string `json:"handler"`
+
+	// Func is the fully qualified name of the handler function
+	// (usually pointing to the plugin or the package that registered it).
+	Func string `json:"func"`
+
+	Calls     int64   `json:"calls"`
+	Errors    int64   `json:"errors"`
+	Slow      int64   `json:"slow"`
+	TotalTime float64 `json:"totalTime"` // in ms
+	AvgTime   float64 `json:"avgTime"`   // in ms
+	MaxTime   float64 `json:"maxTime"`   // in ms
+	LastError string  `json:"lastError"`
+}
+
+// tracedHook defines the hook.Hook methods used by the hooks tracing.
+type tracedHook interface {
+	SetTracer(name string, tracer hook.Tracer)
+}
+
+// hookStatsCollector is the app hooks tracer that aggregates the handlers stats.
+type hookStatsCollector struct {
+	app   App
+	mu    sync.Mutex
+	stats map[string]*HookHandlerStats
+}
+
+// TraceHandler implements the [hook.Tracer] interface.
+func (c *hookStatsCollector) TraceHandler(info *hook.TraceInfo) {
+	threshold := c.app.Settings().HookTracing.SlowThreshold
+	if threshold <= 0 {
+		threshold = DefaultHookSlowThreshold
+	}
+
+	isSlow := info.Duration >= time.Duration(threshold)*time.Millisecond
+	ms := float64(info.Duration) / float64(time.Millisecond)
+
+	key := info.Hook + "\x00" + info.Handler.Id + "\x00" + info.Handler.Func
+
+	c.mu.Lock()
+	stats, ok := c.stats[key]
+	if !ok {
+		stats = &HookHandlerStats{
+			Hook:    info.Hook,
+			Handler: info.Handler.Id,
+			Func:    info.Handler.Func,
+		}
+		c.stats[key] = stats
+	}
+	stats.Calls++
+	stats.TotalTime += ms
+	stats.MaxTime = max(stats.MaxTime, ms)
+	if info.Error != nil {
+		stats.Errors++
+		stats.LastError = info.Error.Error()
+	}
+	if isSlow {
+		stats.Slow++
+	}
+	c.mu.Unlock()
+
+	// skip logging for the logs persistence to prevent infinite write loops
+	if isLogEvent(info.Event) {
+		return
+	}
+
+	attrs := []any{
+		slog.String("hook", info.Hook),
+		slog.String("handler", info.Handler.Id),
+		slog.String("func", info.Handler.Func),
+		slog.Float64("duration", ms),
+	}
+	if info.Error != nil {
+		attrs = append(attrs, slog.String("error", info.Error.Error()))
+	}
+
+	if isSlow {
+		c.app.Logger().Warn("Slow hook handler", attrs...)
+	} else if c.app.IsDev() {
+		c.app.Logger().Debug("Hook handler executed", attrs...)
+	}
+}
+
+func isLogEvent(event any) bool {
+	switch e := event.(type) {
+	case *ModelEvent:
+		_, ok := e.Model.(*Log)
+		return ok
+	case *ModelErrorEvent:
+		_, ok := e.Model.(*Log)
+		return ok
+	}
+
+	return false
+}
+
+// HookStats returns the collected hook handlers execution stats
+// sorted by their total execution time (desc).
+//
+// The stats are collected only while the HookTracing setting is enabled.
+func (app *BaseApp) HookStats() []*HookHandlerStats {
+	app.hookStats.mu.Lock()
+	result := make([]*HookHandlerStats, 0, len(app.hookStats.stats))
+	for _, s := range app.hookStats.stats {
+		clone := *s
+		if clone.Calls > 0 {
+			clone.AvgTime = clone.TotalTime / float64(clone.Calls)
+		}
+		result = append(result, &clone)
+	}
+	app.hookStats.mu.Unlock()
+
+	slices.SortFunc(result, func(a, b *HookHandlerStats) int {
+		if a.TotalTime != b.TotalTime {
+			if a.TotalTime > b.TotalTime {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(a.Hook, b.Hook); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Handler, b.Handler)
+	})
+
+	return result
+}
+
+// ResetHookStats clears the collected hook handlers execution stats.
+func (app *BaseApp) ResetHookStats() {
+	app.hookStats.mu.Lock()
+	app.hookStats.stats = map[string]*HookHandlerStats{}
+	app.hookStats.mu.Unlock()
+}
+
+// namedHooks returns all app hooks indexed by their name.
+func (app *BaseApp) namedHooks() map[string]tracedHook {
+	return map[string]tracedHook{
+		"OnBootstrap":                         app.onBootstrap,
+		"OnServe":                             app.onServe,
+		"OnTerminate":                         app.onTerminate,
+		"OnBackupCreate":                      app.onBackupCreate,
+		"OnBackupRestore":                     app.onBackupRestore,
+		"OnPanic":                             app.onPanic,
+		"OnScheduledTaskRun":                  app.onScheduledTaskRun,
+		"OnTransactionCommit":                 app.onTransactionCommit,
+		"OnModelValidate":                     app.onModelValidate,
+		"OnModelCreate":                       app.onModelCreate,
+		"OnModelCreateExecute":                app.onModelCreateExecute,
+		"OnModelAfterCreateSuccess":           app.onModelAfterCreateSuccess,
+		"OnModelAfterCreateError":             app.onModelAfterCreateError,
+		"OnModelUpdate":                       app.onModelUpdate,
+		"OnModelUpdateWrite":                  app.onModelUpdateWrite,
+		"OnModelAfterUpdateSuccess":           app.onModelAfterUpdateSuccess,
+		"OnModelAfterUpdateError":             app.onModelAfterUpdateError,
+		"OnModelDelete":                       app.onModelDelete,
+		"OnModelDeleteExecute":                app.onModelDeleteExecute,
+		"OnModelAfterDeleteSuccess":           app.onModelAfterDeleteSuccess,
+		"OnModelAfterDeleteError":             app.onModelAfterDeleteError,
+		"OnRecordEnrich":                      app.onRecordEnrich,
+		"OnRecordValidate":                    app.onRecordValidate,
+		"OnRecordCreate":                      app.onRecordCreate,
+		"OnRecordCreateExecute":               app.onRecordCreateExecute,
+		"OnRecordAfterCreateSuccess":          app.onRecordAfterCreateSuccess,
+		"OnRecordAfterCreateError":            app.onRecordAfterCreateError,
+		"OnRecordUpdate":                      app.onRecordUpdate,
+		"OnRecordUpdateExecute":               app.onRecordUpdateExecute,
+		"OnRecordAfterUpdateSuccess":          app.onRecordAfterUpdateSuccess,
+		"OnRecordAfterUpdateError":            app.onRecordAfterUpdateError,
+		"OnRecordDelete":                      app.onRecordDelete,
+		"OnRecordDeleteExecute":               app.onRecordDeleteExecute,
+		"OnRecordAfterDeleteSuccess":          app.onRecordAfterDeleteSuccess,
+		"OnRecordAfterDeleteError":            app.onRecordAfterDeleteError,
+		"OnCollectionValidate":                app.onCollectionValidate,
+		"OnCollectionCreate":                  app.onCollectionCreate,
+		"OnCollectionCreateExecute":           app.onCollectionCreateExecute,
+		"OnCollectionAfterCreateSuccess":      app.onCollectionAfterCreateSuccess,
+		"OnCollectionAfterCreateError":        app.onCollectionAfterCreateError,
+		"OnCollectionUpdate":                  app.onCollectionUpdate,
+		"OnCollectionUpdateExecute":           app.onCollectionUpdateExecute,
+		"OnCollectionAfterUpdateSuccess":      app.onCollectionAfterUpdateSuccess,
+		"OnCollectionAfterUpdateError":        app.onCollectionAfterUpdateError,
+		"OnCollectionDelete":                  app.onCollectionDelete,
+		"OnCollectionAfterDeleteSuccess":      app.onCollectionAfterDeleteSuccess,
+		"OnCollectionDeleteExecute":           app.onCollectionDeleteExecute,
+		"OnCollectionAfterDeleteError":        app.onCollectionAfterDeleteError,
+		"OnMailerSend":                        app.onMailerSend,
+		"OnMailReceive":                       app.onMailReceive,
+		"OnMailerRecordPasswordResetSend":     app.onMailerRecordPasswordResetSend,
+		"OnMailerRecordVerificationSend":      app.onMailerRecordVerificationSend,
+		"OnMailerRecordEmailChangeSend":       app.onMailerRecordEmailChangeSend,
+		"OnMailerRecordOTPSend":               app.onMailerRecordOTPSend,
+		"OnMailerRecordAuthAlertSend":         app.onMailerRecordAuthAlertSend,
+		"OnMailerRecordInviteSend":            app.onMailerRecordInviteSend,
+		"OnSMSSend":                           app.onSMSSend,
+		"OnSMSRecordOTPSend":                  app.onSMSRecordOTPSend,
+		"OnPushSend":                          app.onPushSend,
+		"OnRealtimeConnectRequest":            app.onRealtimeConnectRequest,
+		"OnRealtimeMessageSend":               app.onRealtimeMessageSend,
+		"OnRealtimeSubscribeRequest":          app.onRealtimeSubscribeRequest,
+		"OnSettingsListRequest":               app.onSettingsListRequest,
+		"OnSettingsUpdateRequest":             app.onSettingsUpdateRequest,
+		"OnSettingsReload":                    app.onSettingsReload,
+		"OnFileDownloadRequest":               app.onFileDownloadRequest,
+		"OnFileTokenRequest":                  app.onFileTokenRequest,
+		"OnRecordAuthRequest":                 app.onRecordAuthRequest,
+		"OnRecordAuthWithPasswordRequest":     app.onRecordAuthWithPasswordRequest,
+		"OnRecordAuthWithOAuth2Request":       app.onRecordAuthWithOAuth2Request,
+		"OnRecordAuthRefreshRequest":          app.onRecordAuthRefreshRequest,
+		"OnRecordRequestPasswordResetRequest": app.onRecordRequestPasswordResetRequest,
+		"OnRecordConfirmPasswordResetRequest": app.onRecordConfirmPasswordResetRequest,
+		"OnRecordRequestVerificationRequest":  app.onRecordRequestVerificationRequest,
+		"OnRecordConfirmVerificationRequest":  app.onRecordConfirmVerificationRequest,
+		"OnRecordRequestEmailChangeRequest":   app.onRecordRequestEmailChangeRequest,
+		"OnRecordConfirmEmailChangeRequest":   app.onRecordConfirmEmailChangeRequest,
+		"OnRecordRequestOTPRequest":           app.onRecordRequestOTPRequest,
+		"OnRecordAuthWithOTPRequest":          app.onRecordAuthWithOTPRequest,
+		"OnRecordsListRequest":                app.onRecordsListRequest,
+		"OnRecordViewRequest":                 app.onRecordViewRequest,
+		"OnRecordCreateRequest":               app.onRecordCreateRequest,
+		"OnRecordUpdateRequest":               app.onRecordUpdateRequest,
+		"OnRecordDeleteRequest":               app.onRecordDeleteRequest,
+		"OnCollectionsListRequest":            app.onCollectionsListRequest,
+		"OnCollectionViewRequest":             app.onCollectionViewRequest,
+		"OnCollectionCreateRequest":           app.onCollectionCreateRequest,
+		"OnCollectionUpdateRequest":           app.onCollectionUpdateRequest,
+		"OnCollectionDeleteRequest":           app.onCollectionDeleteRequest,
+		"OnCollectionsImportRequest":          app.onCollectionsImportRequest,
+		"OnBatchRequest":                      app.onBatchRequest,
+	}
+}
+
+func (app *BaseApp) registerHookTracingHooks() {
+	loadTracer := func() {
+		var tracer hook.Tracer
+		if app.Settings().HookTracing.Enabled {
+			tracer = app.hookStats
+		}
+
+		for name, h := range app.namedHooks() {
+			h.SetTracer(name, tracer)
+		}
+	}
+
+	app.OnBootstrap().BindFunc(func(e *BootstrapEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadTracer()
+
+		return nil
+	})
+
+	app.OnSettingsReload().BindFunc(func(e *SettingsReloadEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadTracer()
+
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+package core_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestHookTracing(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.OnRecordCreate("demo3").BindFunc(func(e *core.RecordEvent) error {
+		time.Sleep(5 * time.Millisecond)
+		return e.Next()
+	})
+
+	app.OnRecordValidate("demo3").BindFunc(func(e *core.RecordEvent) error {
+		if e.Record.GetString("title") == "invalid" {
+			return errors.New("test")
+		}
+		return e.Next()
+	})
+
+	createRecord := func(title string) {
+		collection, err := app.FindCachedCollectionByNameOrId("demo3")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		record := core.NewRecord(collection)
+		record.Set("title", title)
+		app.Save(record)
+	}
+
+	findStats := func(hookName string) *core.HookHandlerStats {
+		for _, s := range app.HookStats() {
+			if s.Hook == hookName && strings.Contains(s.Func, "TestHookTracing") {
+				return s
+			}
+		}
+		return nil
+	}
+
+	// disabled
+	createRecord("a")
+	if total := len(app.HookStats()); total != 0 {
+		t.Fatalf("Expected no stats while the tracing is disabled, got %d", total)
+	}
+
+	app.Settings().HookTracing = core.HookTracingConfig{Enabled: true, SlowThreshold: -1}
+	tests.TestValidationErrors(t, app.Save(app.Settings()), []string{"hookTracing"})
+
+	app.Settings().HookTracing.SlowThreshold = 1
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	createRecord("b")
+	createRecord("invalid")
+
+	createStats := findStats("OnRecordCreate")
+	if createStats == nil {
+		t.Fatal("Expected OnRecordCreate handler stats")
+	}
+	if createStats.Calls != 2 || createStats.Errors != 0 || createStats.Slow != 2 {
+		t.Fatalf("Expected 2 calls, 0 errors and 2 slow, got %d, %d, %d", createStats.Calls, createStats.Errors, createStats.Slow)
+	}
+	if createStats.MaxTime < 5 || createStats.AvgTime < 5 {
+		t.Fatalf("Expected at least 5ms execution time, got max %v and avg %v", createStats.MaxTime, createStats.AvgTime)
+	}
+
+	validateStats := findStats("OnRecordValidate")
+	if validateStats == nil {
+		t.Fatal("Expected OnRecordValidate handler stats")
+	}
+	if validateStats.Calls != 2 || validateStats.Errors != 1 || validateStats.LastError != "test" {
+		t.Fatalf("Expected 2 calls and 1 error, got %d, %d (%q)", validateStats.Calls, validateStats.Errors, validateStats.LastError)
+	}
+
+	app.Settings().HookTracing.Enabled = false
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	app.ResetHookStats()
+	if total := len(app.HookStats()); total != 0 {
+		t.Fatalf("Expected no stats after reset, got %d", total)
+	}
+
+	createRecord("c")
+	if total := len(app.HookStats()); total != 0 {
+		t.Fatalf("Expected no stats after disabling the tracing, got %d", total)
+	}
+}
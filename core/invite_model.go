@@ -0,0 +1,140 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var (
+	_ Model         = (*Invite)(nil)
+	_ PreValidator  = (*Invite)(nil)
+	_ PostValidator = (*Invite)(nil)
+)
+
+const InvitesTableName = "_invites"
+
+// inviteReservedDataKeys lists the auth record fields that cannot be preset by an invite.
+var inviteReservedDataKeys = []string{
+	FieldNameId,
+	FieldNameEmail,
+	FieldNameVerified,
+	FieldNamePassword,
+	FieldNameTokenKey,
+}
+
+// Invite defines a single auth collection invitation
+// (see [BaseApp.CreateInvite] and [BaseApp.AcceptInvite]).
+type Invite struct {
+	BaseModel
+
+	CollectionRef string `db:"collectionRef" json:"collectionRef"`
+	Email         string `db:"email" json:"email"`
+
+	// TokenHash is the SHA256 hash of the invite token
+	// (the plain token is available only on its generation).
+	TokenHash string `db:"tokenHash" json:"-"`
+
+	// Data contains the preset field values of the invited auth record (e.g. roles).
+	Data types.JSONMap[any] `db:"data" json:"data"`
+
+	Expires  types.DateTime `db:"expires" json:"expires"`
+	Accepted types.DateTime `db:"accepted" json:"accepted"`
+
+	// RecordRef is the id of the auth record that accepted the invite.
+	RecordRef string `db:"recordRef" json:"recordRef"`
+
+	Created types.DateTime `db:"created" json:"created"`
+	Updated types.DateTime `db:"updated" json:"updated"`
+}
+
+func (m *Invite) TableName() string {
+	return InvitesTableName
+}
+
+// IsAccepted reports whether the invite was already accepted.
+func (m *Invite) IsAccepted() bool {
+	return !m.Accepted.IsZero()
+}
+
+// IsExpired reports whether the invite expiration time has been reached.
+func (m *Invite) IsExpired() bool {
+	return !m.Expires.IsZero() && !m.Expires.Time().After(time.Now())
+}
+
+// PreValidate implements the [PreValidator] interface and normalizes
+// the invite fields before their validation.
+func (m *Invite) PreValidate(ctx context.Context, app App) error {
+	m.Email = normalizeInviteEmail(m.Email)
+
+	if m.Data == nil {
+		m.Data = types.JSONMap[any]{}
+	}
+
+	now := types.NowDateTime()
+	if m.IsNew() {
+		if m.Id == "" {
+			m.Id = GenerateDefaultRandomId()
+		}
+		if m.Created.IsZero() {
+			m.Created = now
+		}
+	}
+	m.Updated = now
+
+	return nil
+}
+
+// PostValidate implements the [PostValidator] interface and defines
+// the Invite model validations.
+func (m *Invite) PostValidate(ctx context.Context, app App) error {
+	return validation.ValidateStructWithContext(ctx, m,
+		validation.Field(&m.Id, validation.Required),
+		validation.Field(&m.CollectionRef, validation.Required, validation.By(m.checkCollection(app))),
+		validation.Field(&m.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
+		validation.Field(&m.TokenHash, validation.Required),
+		validation.Field(&m.Expires, validation.Required),
+		validation.Field(&m.Data, validation.By(m.checkData(app))),
+	)
+}
+
+func (m *Invite) checkCollection(app App) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+
+		collection, err := app.FindCachedCollectionByNameOrId(v)
+		if err != nil || !collection.IsAuth() || collection.Id != v {
+			return validation.NewError("validation_invalid_invite_collection", "The invite collection must be an existing auth collection id.")
+		}
+
+		if !collection.Invites.Enabled {
+			return validation.NewError("validation_invites_disabled", "The collection is not configured to allow invites.")
+		}
+
+		return nil
+	}
+}
+
+func (m *Invite) checkData(app App) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(types.JSONMap[any])
+
+		collection, err := app.FindCachedCollectionByNameOrId(m.CollectionRef)
+		if err != nil {
+			return nil // checked by the collectionRef validator
+		}
+
+		for key := range v {
+			if slices.Contains(inviteReservedDataKeys, key) || collection.Fields.GetByName(key) == nil {
+				return validation.NewError("validation_invalid_invite_data_key", "Unknown or reserved field {{.key}}.").
+					SetParams(map[string]any{"key": key})
+			}
+		}
+
+		return nil
+	}
+}
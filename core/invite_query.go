@@ -0,0 +1,240 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// inviteTokenLength is the length of the auto generated plain invite token.
+const inviteTokenLength = 40
+
+var (
+	ErrInviteAccepted = errors.New("the invite was already accepted")
+	ErrInviteExpired  = errors.New("the invite has expired")
+)
+
+// InviteQuery returns a new Invite select query.
+func (app *BaseApp) InviteQuery() *dbx.SelectQuery {
+	return app.ModelQuery(&Invite{})
+}
+
+// FindInviteById finds a single Invite model by its id.
+func (app *BaseApp) FindInviteById(id string) (*Invite, error) {
+	model := &Invite{}
+
+	err := app.InviteQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindInviteByToken finds a single Invite model of the specified
+// auth collection by its plain token.
+//
+// Note that the returned invite could be already accepted or expired.
+func (app *BaseApp) FindInviteByToken(collectionModelOrIdentifier any, token string) (*Invite, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	model := &Invite{}
+
+	err = app.InviteQuery().
+		AndWhere(dbx.HashExp{
+			"collectionRef": collection.Id,
+			"tokenHash":     security.SHA256(token),
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// CreateInvite creates a new invite for the specified auth collection and email
+// and returns it together with its plain token (only its hash is stored in the db).
+//
+// The optional data argument contains the preset field values
+// (e.g. roles) that will be applied to the auth record on invite acceptance.
+//
+// If there is already a pending (not accepted) invite for the same email,
+// it is updated with the new data, token and expiration.
+func (app *BaseApp) CreateInvite(collectionModelOrIdentifier any, email string, data map[string]any) (*Invite, string, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return nil, "", err
+	}
+
+	invite := &Invite{CollectionRef: collection.Id, Email: normalizeInviteEmail(email)}
+
+	// reuse the existing pending invite (if any)
+	_ = app.InviteQuery().
+		AndWhere(dbx.HashExp{"collectionRef": collection.Id, "email": invite.Email, "accepted": ""}).
+		Limit(1).
+		One(invite)
+
+	invite.Data = types.JSONMap[any](data)
+
+	token, err := app.RefreshInviteToken(invite)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return invite, token, nil
+}
+
+// RefreshInviteToken generates and persists a new invite token
+// and expiration date and returns the new plain token.
+//
+// The previous invite token is invalidated.
+func (app *BaseApp) RefreshInviteToken(invite *Invite) (string, error) {
+	if invite.IsAccepted() {
+		return "", ErrInviteAccepted
+	}
+
+	collection, err := app.FindCachedCollectionByNameOrId(invite.CollectionRef)
+	if err != nil {
+		return "", err
+	}
+
+	token := security.RandomString(inviteTokenLength)
+
+	invite.TokenHash = security.SHA256(token)
+	invite.Expires, err = types.ParseDateTime(time.Now().Add(collection.Invites.DurationTime()))
+	if err != nil {
+		return "", err
+	}
+
+	if err := app.Save(invite); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// AcceptInvite accepts the provided invite and returns the invited auth record.
+//
+// If an auth record with the invite email already exists, it is linked to the invite.
+// Otherwise a new auth record is created using the optional data argument
+// (e.g. name, password, etc.; a random password is set if not provided).
+//
+// In both cases the invite preset fields are applied on top and the
+// auth record is marked as verified since the invite confirms the email ownership.
+func (app *BaseApp) AcceptInvite(invite *Invite, data map[string]any) (*Record, error) {
+	var record *Record
+
+	err := app.RunInTransaction(func(txApp App) error {
+		// reload to prevent concurrent acceptance
+		fresh, err := txApp.FindInviteById(invite.Id)
+		if err != nil {
+			return err
+		}
+
+		if fresh.IsAccepted() {
+			return ErrInviteAccepted
+		}
+
+		if fresh.IsExpired() {
+			return ErrInviteExpired
+		}
+
+		record, err = txApp.FindAuthRecordByEmail(fresh.CollectionRef, fresh.Email)
+		if err != nil {
+			collection, err := txApp.FindCachedCollectionByNameOrId(fresh.CollectionRef)
+			if err != nil {
+				return err
+			}
+
+			record = NewRecord(collection)
+			for k, v := range data {
+				if slices.Contains(inviteReservedDataKeys, k) && k != FieldNamePassword {
+					continue
+				}
+				record.SetIfFieldExists(k, v)
+			}
+			record.SetEmail(fresh.Email)
+
+			if password, _ := data[FieldNamePassword].(string); password == "" {
+				record.SetRandomPassword()
+			}
+		}
+
+		for k, v := range fresh.Data {
+			record.SetIfFieldExists(k, v)
+		}
+		record.SetVerified(true)
+
+		if err := txApp.Save(record); err != nil {
+			return err
+		}
+
+		fresh.Accepted = types.NowDateTime()
+		fresh.RecordRef = record.Id
+
+		if err := txApp.Save(fresh); err != nil {
+			return err
+		}
+
+		*invite = *fresh
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func normalizeInviteEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (app *BaseApp) registerInviteHooks() {
+	app.OnRecordDeleteExecute().BindFunc(func(e *RecordEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		if !e.Record.Collection().IsAuth() {
+			return nil
+		}
+
+		_, err := e.App.DB().Delete(InvitesTableName, dbx.HashExp{
+			"collectionRef": e.Record.Collection().Id,
+			"recordRef":     e.Record.Id,
+		}).Execute()
+
+		return err
+	})
+
+	app.OnCollectionDeleteExecute().BindFunc(func(e *CollectionEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		if !e.Collection.IsAuth() {
+			return nil
+		}
+
+		_, err := e.App.DB().Delete(InvitesTableName, dbx.HashExp{"collectionRef": e.Collection.Id}).Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,229 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func enableUsersInvites(t testing.TB, app core.App) *core.Collection {
+	users, err := app.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users.Invites.Enabled = true
+	if err := app.Save(users); err != nil {
+		t.Fatal(err)
+	}
+
+	return users
+}
+
+func TestCreateInvite(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// disabled invites
+	if _, _, err := app.CreateInvite("users", "new@example.com", nil); err == nil {
+		t.Fatal("Expected disabled invites error")
+	}
+
+	// non-auth collection
+	if _, _, err := app.CreateInvite("demo1", "new@example.com", nil); err == nil {
+		t.Fatal("Expected non-auth collection error")
+	}
+
+	users := enableUsersInvites(t, app)
+
+	scenarios := []struct {
+		name  string
+		email string
+		data  map[string]any
+	}{
+		{"invalid email", "invalid", nil},
+		{"unknown data field", "new@example.com", map[string]any{"missing": 1}},
+		{"reserved data field", "new@example.com", map[string]any{"verified": false}},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if _, _, err := app.CreateInvite(users, s.email, s.data); err == nil {
+				t.Fatal("Expected validation error")
+			}
+		})
+	}
+
+	invite, token, err := app.CreateInvite(users, " New@Example.com ", map[string]any{"name": "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if invite.Email != "new@example.com" {
+		t.Fatalf("Expected normalized email, got %q", invite.Email)
+	}
+
+	if token == "" || invite.TokenHash == token {
+		t.Fatalf("Expected the token hash to be stored, got %q (token %q)", invite.TokenHash, token)
+	}
+
+	expectedExpires := time.Now().Add(time.Duration(core.DefaultInviteDuration) * time.Second)
+	if d := expectedExpires.Sub(invite.Expires.Time()); d < 0 || d > time.Minute {
+		t.Fatalf("Expected expires to be ~%v, got %v", expectedExpires, invite.Expires)
+	}
+
+	found, err := app.FindInviteByToken(users, token)
+	if err != nil || found.Id != invite.Id {
+		t.Fatalf("Expected to find invite %q by token, got %v (%v)", invite.Id, found, err)
+	}
+
+	if _, err := app.FindInviteByToken("clients", token); err == nil {
+		t.Fatal("Expected the invite to be scoped to its collection")
+	}
+
+	// pending invite for the same email
+	again, newToken, err := app.CreateInvite(users, "new@example.com", map[string]any{"name": "test2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if again.Id != invite.Id {
+		t.Fatalf("Expected the pending invite %q to be reused, got %q", invite.Id, again.Id)
+	}
+
+	if again.Data["name"] != "test2" {
+		t.Fatalf("Expected the invite data to be updated, got %v", again.Data)
+	}
+
+	if _, err := app.FindInviteByToken(users, token); err == nil {
+		t.Fatal("Expected the old token to be invalidated")
+	}
+
+	if _, err := app.FindInviteByToken(users, newToken); err != nil {
+		t.Fatalf("Expected to find the invite by the new token, got %v", err)
+	}
+}
+
+func TestAcceptInvite(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users := enableUsersInvites(t, app)
+
+	t.Run("new record", func(t *testing.T) {
+		invite, _, err := app.CreateInvite(users, "new@example.com", map[string]any{"name": "preset"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		record, err := app.AcceptInvite(invite, map[string]any{
+			"name":     "submitted", // overwritten by the preset
+			"verified": false,       // reserved
+			"password": "1234567890",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if record.Email() != "new@example.com" || !record.Verified() || record.GetString("name") != "preset" {
+			t.Fatalf("Unexpected invited record %v", record)
+		}
+
+		if !record.ValidatePassword("1234567890") {
+			t.Fatal("Expected the submitted password to be set")
+		}
+
+		if !invite.IsAccepted() || invite.RecordRef != record.Id {
+			t.Fatalf("Expected the invite to be marked as accepted, got %v", invite)
+		}
+
+		if _, err := app.AcceptInvite(invite, nil); !errors.Is(err, core.ErrInviteAccepted) {
+			t.Fatalf("Expected ErrInviteAccepted, got %v", err)
+		}
+
+		// cleanup on record delete
+		if err := app.Delete(record); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := app.FindInviteById(invite.Id); err == nil {
+			t.Fatal("Expected the invite to be deleted together with its record")
+		}
+	})
+
+	t.Run("existing record", func(t *testing.T) {
+		existing, err := app.FindAuthRecordByEmail(users, "test2@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		invite, _, err := app.CreateInvite(users, "test2@example.com", map[string]any{"name": "preset"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		record, err := app.AcceptInvite(invite, map[string]any{"password": "new_password"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if record.Id != existing.Id {
+			t.Fatalf("Expected the existing record %q to be linked, got %q", existing.Id, record.Id)
+		}
+
+		if record.GetString("name") != "preset" {
+			t.Fatalf("Expected the preset name, got %q", record.GetString("name"))
+		}
+
+		if record.ValidatePassword("new_password") {
+			t.Fatal("Expected the existing record password to remain unchanged")
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		invite, _, err := app.CreateInvite(users, "expired@example.com", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		invite.Expires, _ = types.ParseDateTime(time.Now().Add(-time.Minute))
+		if err := app.Save(invite); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := app.AcceptInvite(invite, nil); !errors.Is(err, core.ErrInviteExpired) {
+			t.Fatalf("Expected ErrInviteExpired, got %v", err)
+		}
+
+		if _, err := app.FindAuthRecordByEmail(users, "expired@example.com"); err == nil {
+			t.Fatal("Expected no record to be created")
+		}
+	})
+
+	t.Run("collection delete", func(t *testing.T) {
+		members := core.NewAuthCollection("members")
+		members.Invites.Enabled = true
+		if err := app.Save(members); err != nil {
+			t.Fatal(err)
+		}
+
+		invite, _, err := app.CreateInvite(members, "new@example.com", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := app.Delete(members); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := app.FindInviteById(invite.Id); err == nil {
+			t.Fatal("Expected the invite to be deleted together with its collection")
+		}
+	})
+}
@@ -0,0 +1,346 @@
+package core
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/store"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const KVTableName = "_kv"
+
+// DefaultKVCacheMaxItems is the max number of the in-memory cached KV items.
+const DefaultKVCacheMaxItems = 10000
+
+// KVItem defines a single persisted key-value store item.
+type KVItem struct {
+	Namespace string         `db:"namespace" json:"namespace"`
+	Key       string         `db:"key" json:"key"`
+	Value     types.JSONRaw  `db:"value" json:"value"`
+	Expires   types.DateTime `db:"expires" json:"expires"`
+	Updated   types.DateTime `db:"updated" json:"updated"`
+}
+
+// IsExpired reports whether the item has an expiration date in the past.
+func (item *KVItem) IsExpired() bool {
+	return !item.Expires.IsZero() && !item.Expires.Time().After(time.Now())
+}
+
+// KVStore is a persistent namespaced key-value store backed by
+// the app auxiliary database "_kv" table.
+//
+// It is intended to be used by app hooks and plugins for storing
+// small pieces of state like counters, feature flags and caches.
+//
+// The values are stored as JSON. When the in-memory cache is enabled
+// (see [BaseAppConfig.KVCache]) the read items are also cached in the app
+// process, so it should be enabled only if the aux database is not
+// shared with other app instances.
+type KVStore struct {
+	app       App
+	namespace string
+	cache     *store.Store[string, *KVItem]
+}
+
+// newKVStore creates a new root KVStore.
+func newKVStore(app App, withCache bool) *KVStore {
+	s := &KVStore{app: app}
+
+	if withCache {
+		s.cache = store.New[string, *KVItem](nil)
+	}
+
+	return s
+}
+
+// Namespace returns a new KVStore instance scoped to the specified namespace
+// (sharing the same in-memory cache).
+//
+// The namespace is usually the name of the plugin or extension using the store.
+func (s *KVStore) Namespace(namespace string) *KVStore {
+	return &KVStore{
+		app:       s.app,
+		namespace: namespace,
+		cache:     s.cache,
+	}
+}
+
+// NamespaceName returns the name of the current store namespace
+// (empty string for the default one).
+func (s *KVStore) NamespaceName() string {
+	return s.namespace
+}
+
+func (s *KVStore) cacheKey(key string) string {
+	return s.namespace + "\x00" + key
+}
+
+// FindItem returns the non-expired item with the specified key.
+//
+// Returns [sql.ErrNoRows] if the item is missing or expired.
+func (s *KVStore) FindItem(key string) (*KVItem, error) {
+	if s.cache != nil {
+		if item, ok := s.cache.GetOk(s.cacheKey(key)); ok {
+			if item.IsExpired() {
+				s.cache.Remove(s.cacheKey(key))
+				return nil, sql.ErrNoRows
+			}
+			return item, nil
+		}
+	}
+
+	item := &KVItem{}
+
+	err := s.app.AuxDB().Select("*").
+		From(KVTableName).
+		Where(dbx.HashExp{"namespace": s.namespace, "key": key}).
+		Limit(1).
+		One(item)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.IsExpired() {
+		return nil, sql.ErrNoRows
+	}
+
+	if s.cache != nil {
+		s.cache.SetIfLessThanLimit(s.cacheKey(key), item, DefaultKVCacheMaxItems)
+	}
+
+	return item, nil
+}
+
+// Get unmarshals the value of the item with the specified key into result.
+//
+// Returns [sql.ErrNoRows] if the item is missing or expired.
+func (s *KVStore) Get(key string, result any) error {
+	item, err := s.FindItem(key)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(item.Value, result)
+}
+
+// Has checks whether a non-expired item with the specified key exists.
+func (s *KVStore) Has(key string) bool {
+	_, err := s.FindItem(key)
+
+	return err == nil
+}
+
+// Set stores the specified value without expiration.
+func (s *KVStore) Set(key string, value any) error {
+	return s.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL stores the specified value that will expire after ttl
+// (zero or negative ttl means no expiration).
+func (s *KVStore) SetWithTTL(key string, value any, ttl time.Duration) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	item := &KVItem{
+		Namespace: s.namespace,
+		Key:       key,
+		Value:     raw,
+		Updated:   types.NowDateTime(),
+	}
+
+	if ttl > 0 {
+		item.Expires, err = types.ParseDateTime(time.Now().Add(ttl))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.app.AuxNonconcurrentDB().NewQuery(
+		"INSERT INTO {{" + KVTableName + "}} ([[namespace]], [[key]], [[value]], [[expires]], [[updated]]) " +
+			"VALUES ({:namespace}, {:key}, {:value}, {:expires}, {:updated}) " +
+			"ON CONFLICT ([[namespace]], [[key]]) DO UPDATE SET " +
+			"[[value]] = excluded.[[value]], [[expires]] = excluded.[[expires]], [[updated]] = excluded.[[updated]]",
+	).Bind(dbx.Params{
+		"namespace": item.Namespace,
+		"key":       item.Key,
+		"value":     item.Value.String(),
+		"expires":   item.Expires.String(),
+		"updated":   item.Updated.String(),
+	}).Execute()
+	if err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		s.cache.Remove(s.cacheKey(key))
+		s.cache.SetIfLessThanLimit(s.cacheKey(key), item, DefaultKVCacheMaxItems)
+	}
+
+	return nil
+}
+
+// Increment atomically increments the integer value of the item with the
+// specified key by delta and returns the new value.
+//
+// Missing or expired items are initialized with delta.
+// The expiration of an existing item is preserved.
+func (s *KVStore) Increment(key string, delta int) (int, error) {
+	return s.IncrementWithTTL(key, delta, 0)
+}
+
+// IncrementWithTTL is similar to [KVStore.Increment] but the missing
+// or expired items are initialized with delta and expiration after ttl
+// (zero or negative ttl means no expiration).
+//
+// The expiration of an existing item is preserved, aka. the ttl is
+// applied atomically only together with the first increment.
+func (s *KVStore) IncrementWithTTL(key string, delta int, ttl time.Duration) (int, error) {
+	now := types.NowDateTime().String()
+
+	var expires string
+	if ttl > 0 {
+		expiresDate, err := types.ParseDateTime(time.Now().Add(ttl))
+		if err != nil {
+			return 0, err
+		}
+		expires = expiresDate.String()
+	}
+
+	var result int
+
+	err := s.app.AuxNonconcurrentDB().NewQuery(
+		"INSERT INTO {{" + KVTableName + "}} ([[namespace]], [[key]], [[value]], [[expires]], [[updated]]) " +
+			"VALUES ({:namespace}, {:key}, {:delta}, {:expires}, {:now}) " +
+			"ON CONFLICT ([[namespace]], [[key]]) DO UPDATE SET " +
+			"[[value]] = CASE WHEN [[expires]] != '' AND [[expires]] <= {:now} THEN excluded.[[value]] ELSE CAST([[value]] AS INTEGER) + excluded.[[value]] END, " +
+			"[[expires]] = CASE WHEN [[expires]] != '' AND [[expires]] <= {:now} THEN excluded.[[expires]] ELSE [[expires]] END, " +
+			"[[updated]] = excluded.[[updated]] " +
+			"RETURNING [[value]]",
+	).Bind(dbx.Params{
+		"namespace": s.namespace,
+		"key":       key,
+		"delta":     delta,
+		"expires":   expires,
+		"now":       now,
+	}).Row(&result)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.cache != nil {
+		s.cache.Remove(s.cacheKey(key))
+	}
+
+	return result, nil
+}
+
+// TTL returns the remaining time until the item with the specified key expires
+// (0 if the item doesn't have an expiration).
+//
+// Returns [sql.ErrNoRows] if the item is missing or expired.
+func (s *KVStore) TTL(key string) (time.Duration, error) {
+	item, err := s.FindItem(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if item.Expires.IsZero() {
+		return 0, nil
+	}
+
+	return time.Until(item.Expires.Time()), nil
+}
+
+// List returns all non-expired items of the current namespace
+// which keys start with the specified prefix (ordered by their key).
+func (s *KVStore) List(prefix string) ([]*KVItem, error) {
+	items := []*KVItem{}
+
+	query := s.app.AuxDB().Select("*").
+		From(KVTableName).
+		Where(dbx.HashExp{"namespace": s.namespace}).
+		AndWhere(dbx.NewExp("([[expires]] = '' OR [[expires]] > {:now})", dbx.Params{"now": types.NowDateTime().String()})).
+		OrderBy("key ASC")
+
+	if prefix != "" {
+		query.AndWhere(dbx.NewExp("substr([[key]], 1, length({:prefix})) = {:prefix}", dbx.Params{"prefix": prefix}))
+	}
+
+	if err := query.All(&items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Delete deletes the item with the specified key (if exists).
+func (s *KVStore) Delete(key string) error {
+	_, err := s.app.AuxNonconcurrentDB().Delete(KVTableName, dbx.HashExp{
+		"namespace": s.namespace,
+		"key":       key,
+	}).Execute()
+	if err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		s.cache.Remove(s.cacheKey(key))
+	}
+
+	return nil
+}
+
+// Clear deletes all items of the current namespace.
+func (s *KVStore) Clear() error {
+	_, err := s.app.AuxNonconcurrentDB().Delete(KVTableName, dbx.HashExp{"namespace": s.namespace}).Execute()
+	if err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		prefix := s.cacheKey("")
+		for k := range s.cache.GetAll() {
+			if strings.HasPrefix(k, prefix) {
+				s.cache.Remove(k)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes all expired items from all namespaces.
+func (s *KVStore) DeleteExpired() error {
+	_, err := s.app.AuxNonconcurrentDB().Delete(KVTableName, dbx.NewExp(
+		"[[expires]] != '' AND [[expires]] <= {:now}",
+		dbx.Params{"now": types.NowDateTime().String()},
+	)).Execute()
+	if err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		for k, item := range s.cache.GetAll() {
+			if item.IsExpired() {
+				s.cache.Remove(k)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (app *BaseApp) registerKVHooks() {
+	// run on every hour to cleanup the expired kv items
+	app.Cron().Add("__pbKVCleanup__", "0 * * * *", func() {
+		if err := app.KV().DeleteExpired(); err != nil {
+			app.Logger().Warn("Failed to delete expired KV items", "error", err)
+		}
+	})
+}
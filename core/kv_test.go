@@ -0,0 +1,190 @@
+package core_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestKVStore(t *testing.T) {
+	t.Parallel()
+
+	for _, withCache := range []bool{false, true} {
+		t.Run("cache_"+map[bool]string{false: "disabled", true: "enabled"}[withCache], func(t *testing.T) {
+			app, _ := tests.NewTestAppWithConfig(core.BaseAppConfig{KVCache: withCache})
+			defer app.Cleanup()
+
+			kv := app.KV()
+
+			var missing string
+			if err := kv.Get("missing", &missing); !errors.Is(err, sql.ErrNoRows) {
+				t.Fatalf("Expected sql.ErrNoRows, got %v", err)
+			}
+
+			if err := kv.Set("a", map[string]any{"enabled": true}); err != nil {
+				t.Fatal(err)
+			}
+
+			var a map[string]bool
+			if err := kv.Get("a", &a); err != nil {
+				t.Fatal(err)
+			}
+			if !a["enabled"] {
+				t.Fatalf("Expected enabled true, got %v", a)
+			}
+
+			if ttl, err := kv.TTL("a"); err != nil || ttl != 0 {
+				t.Fatalf("Expected 0 ttl, got %v (%v)", ttl, err)
+			}
+
+			// override with ttl
+			if err := kv.SetWithTTL("a", "test", time.Hour); err != nil {
+				t.Fatal(err)
+			}
+
+			var aStr string
+			if err := kv.Get("a", &aStr); err != nil || aStr != "test" {
+				t.Fatalf("Expected value %q, got %q (%v)", "test", aStr, err)
+			}
+
+			if ttl, err := kv.TTL("a"); err != nil || ttl <= 59*time.Minute || ttl > time.Hour {
+				t.Fatalf("Expected ~1h ttl, got %v (%v)", ttl, err)
+			}
+
+			// expired
+			if err := kv.SetWithTTL("expired", 1, time.Millisecond); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(5 * time.Millisecond)
+			if kv.Has("expired") {
+				t.Fatal("Expected the expired item to be missing")
+			}
+
+			// counters
+			for i, expected := range []int{2, 5} {
+				v, err := kv.Increment("counter", []int{2, 3}[i])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != expected {
+					t.Fatalf("[%d] Expected counter %d, got %d", i, expected, v)
+				}
+			}
+
+			// namespaces
+			ns := kv.Namespace("test_ns")
+			if ns.Has("a") {
+				t.Fatal("Expected the namespaced store to not have the default namespace items")
+			}
+			if err := ns.Set("prefix_1", 1); err != nil {
+				t.Fatal(err)
+			}
+			if err := ns.Set("prefix_2", 2); err != nil {
+				t.Fatal(err)
+			}
+			if err := ns.Set("other", 3); err != nil {
+				t.Fatal(err)
+			}
+
+			items, err := ns.List("prefix_")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 2 || items[0].Key != "prefix_1" || items[1].Key != "prefix_2" {
+				t.Fatalf("Expected prefix_1 and prefix_2 items, got %v", items)
+			}
+
+			all, err := kv.List("")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != 2 { // a and counter
+				t.Fatalf("Expected 2 default namespace items, got %d", len(all))
+			}
+
+			if err := ns.Delete("other"); err != nil {
+				t.Fatal(err)
+			}
+			if ns.Has("other") {
+				t.Fatal("Expected the deleted item to be missing")
+			}
+
+			if err := ns.Clear(); err != nil {
+				t.Fatal(err)
+			}
+			if ns.Has("prefix_1") || ns.Has("prefix_2") {
+				t.Fatal("Expected the namespace items to be cleared")
+			}
+			if !kv.Has("a") {
+				t.Fatal("Expected the default namespace items to be preserved")
+			}
+
+			// cleanup
+			if err := kv.DeleteExpired(); err != nil {
+				t.Fatal(err)
+			}
+
+			var total int
+			err = app.AuxDB().Select("count(*)").From(core.KVTableName).Row(&total)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != 2 {
+				t.Fatalf("Expected 2 total items after the expired cleanup, got %d", total)
+			}
+		})
+	}
+}
+
+func TestKVStoreIncrementWithTTL(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	kv := app.KV()
+
+	// new counter
+	v, err := kv.IncrementWithTTL("counter", 2, time.Hour)
+	if err != nil || v != 2 {
+		t.Fatalf("Expected counter 2, got %d (%v)", v, err)
+	}
+
+	// the existing expiration must be preserved
+	v, err = kv.IncrementWithTTL("counter", 3, time.Minute)
+	if err != nil || v != 5 {
+		t.Fatalf("Expected counter 5, got %d (%v)", v, err)
+	}
+
+	if ttl, err := kv.TTL("counter"); err != nil || ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Fatalf("Expected ~1h ttl, got %v (%v)", ttl, err)
+	}
+
+	// expired counter
+	if _, err := kv.IncrementWithTTL("expired", 1, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	v, err = kv.IncrementWithTTL("expired", 4, time.Hour)
+	if err != nil || v != 4 {
+		t.Fatalf("Expected the expired counter to be reset to 4, got %d (%v)", v, err)
+	}
+
+	if ttl, err := kv.TTL("expired"); err != nil || ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Fatalf("Expected ~1h ttl, got %v (%v)", ttl, err)
+	}
+
+	// no ttl
+	if _, err := kv.IncrementWithTTL("no_ttl", 1, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if ttl, err := kv.TTL("no_ttl"); err != nil || ttl != 0 {
+		t.Fatalf("Expected 0 ttl, got %v (%v)", ttl, err)
+	}
+}
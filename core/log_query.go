@@ -1,6 +1,9 @@
 package core
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/pocketbase/dbx"
@@ -51,6 +54,123 @@ func (app *BaseApp) LogsStats(expr dbx.Expression) ([]*LogsStatsItem, error) {
 	return result, err
 }
 
+// Supported logs aggregation time intervals.
+const (
+	LogsIntervalMinute = "minute"
+	LogsIntervalHour   = "hour"
+	LogsIntervalDay    = "day"
+)
+
+var logsIntervalFormats = map[string]string{
+	LogsIntervalMinute: "%Y-%m-%d %H:%M:00",
+	LogsIntervalHour:   "%Y-%m-%d %H:00:00",
+	LogsIntervalDay:    "%Y-%m-%d 00:00:00",
+}
+
+var logsFieldRegex = regexp.MustCompile(`^data(\.\w+)+$`)
+
+// LogsAggregateItem defines the total number of logs for a
+// specific time period and group value.
+type LogsAggregateItem struct {
+	Date  types.DateTime `db:"date" json:"date"`
+	Group string         `db:"grp" json:"group"`
+	Total int            `db:"total" json:"total"`
+}
+
+// LogsAggregate returns the logs total grouped by the specified time
+// interval ("minute", "hour" or "day") and optional groupBy field
+// (e.g. "level" to count the logs by level per hour).
+//
+// groupBy could be "level", "message" or a "data.*" JSON path (e.g. "data.status").
+func (app *BaseApp) LogsAggregate(interval string, groupBy string, expr dbx.Expression) ([]*LogsAggregateItem, error) {
+	format, ok := logsIntervalFormats[interval]
+	if !ok {
+		return nil, fmt.Errorf("unsupported logs interval %q", interval)
+	}
+
+	selects := []string{
+		"count(id) as total",
+		"strftime('" + format + "', created) as date",
+	}
+	groups := []string{"date"}
+
+	if groupBy != "" {
+		column, err := logsFieldColumn(groupBy)
+		if err != nil {
+			return nil, err
+		}
+
+		selects = append(selects, "COALESCE(CAST("+column+" AS TEXT), '') as grp")
+		groups = append(groups, "grp")
+	}
+
+	query := app.LogQuery().
+		Select(selects...).
+		GroupBy(groups...).
+		OrderBy(groups...)
+
+	if expr != nil {
+		query.AndWhere(expr)
+	}
+
+	result := []*LogsAggregateItem{}
+
+	err := query.All(&result)
+
+	return result, err
+}
+
+// LogsFacetItem defines the total number of logs with a specific field value.
+type LogsFacetItem struct {
+	Value string `db:"value" json:"value"`
+	Total int    `db:"total" json:"total"`
+}
+
+// LogsFacets returns the most common values (up to limit) of the
+// specified field with their total number of logs (in DESC order).
+//
+// field could be "level", "message" or a "data.*" JSON path (e.g. "data.status").
+func (app *BaseApp) LogsFacets(field string, limit int, expr dbx.Expression) ([]*LogsFacetItem, error) {
+	column, err := logsFieldColumn(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	query := app.LogQuery().
+		Select("count(id) as total", "COALESCE(CAST("+column+" AS TEXT), '') as value").
+		GroupBy("value").
+		OrderBy("total DESC", "value ASC").
+		Limit(int64(limit))
+
+	if expr != nil {
+		query.AndWhere(expr)
+	}
+
+	result := []*LogsFacetItem{}
+
+	err = query.All(&result)
+
+	return result, err
+}
+
+// logsFieldColumn returns the SQL column expression of the specified logs field.
+func logsFieldColumn(field string) (string, error) {
+	switch field {
+	case "level", "message":
+		return "[[" + field + "]]", nil
+	}
+
+	if !logsFieldRegex.MatchString(field) {
+		return "", fmt.Errorf("unsupported logs field %q", field)
+	}
+
+	return "JSON_EXTRACT([[data]], '$" + strings.TrimPrefix(field, "data") + "')", nil
+}
+
 // DeleteOldLogs delete all logs that are created before createdBefore.
 //
 // For better performance the logs delete is executed as plain SQL statement,
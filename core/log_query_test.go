@@ -69,6 +69,172 @@ func TestLogsStats(t *testing.T) {
 	}
 }
 
+func TestLogsAggregate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.StubLogsData(app)
+
+	scenarios := []struct {
+		name        string
+		interval    string
+		groupBy     string
+		expr        dbx.Expression
+		expectError bool
+		expected    string
+	}{
+		{
+			"invalid interval",
+			"invalid",
+			"",
+			nil,
+			true,
+			"",
+		},
+		{
+			"invalid groupBy",
+			core.LogsIntervalHour,
+			"data.a'b",
+			nil,
+			true,
+			"",
+		},
+		{
+			"daily without groupBy",
+			core.LogsIntervalDay,
+			"",
+			nil,
+			false,
+			`[{"date":"2022-05-01 00:00:00.000Z","group":"","total":1},{"date":"2022-05-02 00:00:00.000Z","group":"","total":1}]`,
+		},
+		{
+			"hourly by level",
+			core.LogsIntervalHour,
+			"level",
+			nil,
+			false,
+			`[{"date":"2022-05-01 10:00:00.000Z","group":"0","total":1},{"date":"2022-05-02 10:00:00.000Z","group":"8","total":1}]`,
+		},
+		{
+			"per minute by data field with filter",
+			core.LogsIntervalMinute,
+			"data.status",
+			dbx.HashExp{"level": 8},
+			false,
+			`[{"date":"2022-05-02 10:00:00.000Z","group":"400","total":1}]`,
+		},
+		{
+			"missing data field",
+			core.LogsIntervalHour,
+			"data.missing",
+			nil,
+			false,
+			`[{"date":"2022-05-01 10:00:00.000Z","group":"","total":1},{"date":"2022-05-02 10:00:00.000Z","group":"","total":1}]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := app.LogsAggregate(s.interval, s.groupBy, s.expr)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			encoded, _ := json.Marshal(result)
+			if string(encoded) != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, encoded)
+			}
+		})
+	}
+}
+
+func TestLogsFacets(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.StubLogsData(app)
+
+	scenarios := []struct {
+		name        string
+		field       string
+		limit       int
+		expr        dbx.Expression
+		expectError bool
+		expected    string
+	}{
+		{
+			"empty field",
+			"",
+			0,
+			nil,
+			true,
+			"",
+		},
+		{
+			"unsupported field",
+			"id",
+			0,
+			nil,
+			true,
+			"",
+		},
+		{
+			"level",
+			"level",
+			0,
+			nil,
+			false,
+			`[{"value":"0","total":1},{"value":"8","total":1}]`,
+		},
+		{
+			"data field with limit",
+			"data.status",
+			1,
+			nil,
+			false,
+			`[{"value":"200","total":1}]`,
+		},
+		{
+			"message with filter",
+			"message",
+			10,
+			dbx.HashExp{"level": 8},
+			false,
+			`[{"value":"test_message2","total":1}]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := app.LogsFacets(s.field, s.limit, s.expr)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			encoded, _ := json.Marshal(result)
+			if string(encoded) != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, encoded)
+			}
+		})
+	}
+}
+
 func TestDeleteOldLogs(t *testing.T) {
 	t.Parallel()
 
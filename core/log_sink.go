@@ -0,0 +1,122 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/logger"
+)
+
+// NewLogSink creates a new logs sink from the provided config.
+//
+// The returned sink is not level filtered or buffered - wrap it with
+// [logger.NewAsyncSink] if you want to use it with the app logs handler.
+func NewLogSink(app App, config LogSinkConfig) (logger.Sink, error) {
+	switch config.Type {
+	case LogSinkTypeLoki:
+		return &logger.LokiSink{
+			URL:    config.URL,
+			Token:  config.Token,
+			Labels: map[string]string{"service": app.Settings().Meta.AppName},
+		}, nil
+	case LogSinkTypeOTLP:
+		return &logger.OTLPSink{
+			URL:         config.URL,
+			Token:       config.Token,
+			ServiceName: app.Settings().Meta.AppName,
+		}, nil
+	case LogSinkTypeWebhook:
+		return &logger.WebhookSink{
+			URL:   config.URL,
+			Token: config.Token,
+		}, nil
+	case LogSinkTypeSyslog:
+		u, err := url.Parse(config.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		address := u.Host
+		if strings.HasPrefix(u.Scheme, "unix") {
+			address = u.Path
+		}
+
+		return &logger.SyslogSink{
+			Network: u.Scheme,
+			Address: address,
+			AppName: app.Settings().Meta.AppName,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported log sink type %q", config.Type)
+	}
+}
+
+// logSinks manages the lifecycle of the app logs handler sinks.
+type logSinks struct {
+	mu    sync.Mutex
+	sinks []*logger.AsyncSink
+}
+
+// reload (re)initializes the logs handler sinks based on the current app settings.
+//
+// The previously registered sinks are closed after flushing their pending logs.
+func (ls *logSinks) reload(app App, handler *logger.BatchHandler) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	oldSinks := ls.sinks
+
+	configs := app.Settings().Logs.Sinks
+
+	newSinks := make([]*logger.AsyncSink, 0, len(configs))
+	handlerSinks := make([]logger.Sink, 0, len(configs))
+
+	for _, config := range configs {
+		sink, err := NewLogSink(app, config)
+		if err != nil {
+			// use the std logger to prevent infinite loop
+			log.Println("Failed to initialize log sink", config.Type, err)
+			continue
+		}
+
+		sinkType := config.Type
+
+		asyncSink := logger.NewAsyncSink(sink, logger.AsyncSinkOptions{
+			Level:     slog.Level(config.MinLevel),
+			QueueSize: config.QueueSize,
+			OnError: func(err error) {
+				// use the std logger to prevent infinite loop
+				log.Println("Failed to ship logs to", sinkType, "sink:", err)
+			},
+		})
+
+		newSinks = append(newSinks, asyncSink)
+		handlerSinks = append(handlerSinks, asyncSink)
+	}
+
+	handler.SetSinks(handlerSinks...)
+
+	ls.sinks = newSinks
+
+	for _, s := range oldSinks {
+		s.Close()
+	}
+}
+
+// close flushes and closes all registered logs handler sinks.
+func (ls *logSinks) close(handler *logger.BatchHandler) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	handler.SetSinks()
+
+	for _, s := range ls.sinks {
+		s.Close()
+	}
+
+	ls.sinks = nil
+}
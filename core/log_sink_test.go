@@ -0,0 +1,136 @@
+package core_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/logger"
+)
+
+func TestNewLogSink(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name         string
+		config       core.LogSinkConfig
+		expectedType any
+		expectError  bool
+	}{
+		{"unknown", core.LogSinkConfig{Type: "unknown"}, nil, true},
+		{"loki", core.LogSinkConfig{Type: core.LogSinkTypeLoki, URL: "http://example.com"}, &logger.LokiSink{}, false},
+		{"otlp", core.LogSinkConfig{Type: core.LogSinkTypeOTLP, URL: "http://example.com"}, &logger.OTLPSink{}, false},
+		{"webhook", core.LogSinkConfig{Type: core.LogSinkTypeWebhook, URL: "http://example.com"}, &logger.WebhookSink{}, false},
+		{"syslog", core.LogSinkConfig{Type: core.LogSinkTypeSyslog, URL: "udp://localhost:514"}, &logger.SyslogSink{}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			sink, err := core.NewLogSink(app, s.config)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			switch s.expectedType.(type) {
+			case *logger.LokiSink:
+				if _, ok := sink.(*logger.LokiSink); !ok {
+					t.Fatalf("Expected LokiSink, got %T", sink)
+				}
+			case *logger.OTLPSink:
+				if _, ok := sink.(*logger.OTLPSink); !ok {
+					t.Fatalf("Expected OTLPSink, got %T", sink)
+				}
+			case *logger.WebhookSink:
+				if _, ok := sink.(*logger.WebhookSink); !ok {
+					t.Fatalf("Expected WebhookSink, got %T", sink)
+				}
+			case *logger.SyslogSink:
+				syslogSink, ok := sink.(*logger.SyslogSink)
+				if !ok {
+					t.Fatalf("Expected SyslogSink, got %T", sink)
+				}
+
+				if syslogSink.Network != "udp" || syslogSink.Address != "localhost:514" {
+					t.Fatalf("Expected udp network with localhost:514 address, got %q and %q", syslogSink.Network, syslogSink.Address)
+				}
+			}
+		})
+	}
+}
+
+func TestLogSinksShipping(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var mu sync.Mutex
+	var messages []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+
+		var logs []struct {
+			Message string `json:"message"`
+		}
+		json.Unmarshal(raw, &logs)
+
+		mu.Lock()
+		for _, l := range logs {
+			messages = append(messages, l.Message)
+		}
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	app.Settings().Logs.MinLevel = int(slog.LevelInfo)
+	app.Settings().Logs.Sinks = []core.LogSinkConfig{
+		{Type: core.LogSinkTypeWebhook, URL: server.URL, MinLevel: int(slog.LevelWarn)},
+	}
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Logger().Info("test_info")
+	app.Logger().Warn("test_warn")
+
+	if err := app.FlushLogs(); err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for the async shipping
+	for i := 0; i < 40; i++ {
+		mu.Lock()
+		total := len(messages)
+		mu.Unlock()
+
+		if total > 0 {
+			break
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(messages) != 1 || messages[0] != "test_warn" {
+		t.Fatalf("Expected only the warn log to be shipped, got %v", messages)
+	}
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var (
+	_ Model         = (*MailSuppression)(nil)
+	_ PreValidator  = (*MailSuppression)(nil)
+	_ PostValidator = (*MailSuppression)(nil)
+)
+
+const MailSuppressionsTableName = "_mailSuppressions"
+
+// Supported mail suppression reasons.
+const (
+	MailSuppressionReasonBounce    = "bounce"
+	MailSuppressionReasonComplaint = "complaint"
+	MailSuppressionReasonManual    = "manual"
+)
+
+// MailSuppression defines a single email address that should
+// be excluded from all outgoing app emails (eg. due to a hard bounce).
+type MailSuppression struct {
+	BaseModel
+
+	Email   string         `db:"email" json:"email"`
+	Reason  string         `db:"reason" json:"reason"`
+	Detail  string         `db:"detail" json:"detail"`
+	Created types.DateTime `db:"created" json:"created"`
+	Updated types.DateTime `db:"updated" json:"updated"`
+}
+
+func (m *MailSuppression) TableName() string {
+	return MailSuppressionsTableName
+}
+
+// PreValidate implements the [PreValidator] interface and normalizes
+// the suppression fields before their validation.
+func (m *MailSuppression) PreValidate(ctx context.Context, app App) error {
+	m.Email = NormalizeMailSuppressionEmail(m.Email)
+
+	now := types.NowDateTime()
+	if m.IsNew() {
+		if m.Id == "" {
+			m.Id = GenerateDefaultRandomId()
+		}
+		if m.Created.IsZero() {
+			m.Created = now
+		}
+	}
+	m.Updated = now
+
+	return nil
+}
+
+// PostValidate implements the [PostValidator] interface and defines
+// the MailSuppression model validations.
+func (m *MailSuppression) PostValidate(ctx context.Context, app App) error {
+	return validation.ValidateStructWithContext(ctx, m,
+		validation.Field(&m.Id, validation.Required),
+		validation.Field(&m.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
+		validation.Field(
+			&m.Reason,
+			validation.Required,
+			validation.In(
+				MailSuppressionReasonBounce,
+				MailSuppressionReasonComplaint,
+				MailSuppressionReasonManual,
+			),
+		),
+		validation.Field(&m.Detail, validation.Length(0, 1000)),
+	)
+}
+
+// NormalizeMailSuppressionEmail returns the normalized (trimmed and lowercased) form of email.
+func NormalizeMailSuppressionEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,86 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestMailSuppressionTableName(t *testing.T) {
+	t.Parallel()
+
+	m := &core.MailSuppression{}
+	if m.TableName() != core.MailSuppressionsTableName {
+		t.Fatalf("Expected table %q, got %q", core.MailSuppressionsTableName, m.TableName())
+	}
+}
+
+func TestMailSuppressionPreValidate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	m := &core.MailSuppression{Email: "  Test@Example.com "}
+
+	if err := m.PreValidate(nil, app); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Email != "test@example.com" {
+		t.Fatalf("Expected normalized email, got %q", m.Email)
+	}
+
+	if m.Id == "" {
+		t.Fatal("Expected the id to be autogenerated")
+	}
+
+	if m.Created.IsZero() || m.Updated.IsZero() {
+		t.Fatalf("Expected created and updated to be set, got %v and %v", m.Created, m.Updated)
+	}
+}
+
+func TestMailSuppressionValidate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name           string
+		model          *core.MailSuppression
+		expectedErrors []string
+	}{
+		{
+			"empty model",
+			&core.MailSuppression{},
+			[]string{"email", "reason"},
+		},
+		{
+			"invalid data",
+			&core.MailSuppression{
+				Email:  "invalid",
+				Reason: "invalid",
+				Detail: strings.Repeat("a", 1001),
+			},
+			[]string{"email", "reason", "detail"},
+		},
+		{
+			"valid data",
+			&core.MailSuppression{
+				Email:  "test@example.com",
+				Reason: core.MailSuppressionReasonBounce,
+				Detail: "550 mailbox unavailable",
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			tests.TestValidationErrors(t, app.Validate(s.model), s.expectedErrors)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/mail"
+	"slices"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+)
+
+// MailSuppressionQuery returns a new MailSuppression select query.
+func (app *BaseApp) MailSuppressionQuery() *dbx.SelectQuery {
+	return app.ModelQuery(&MailSuppression{})
+}
+
+// FindMailSuppressionById finds a single MailSuppression entry by its id.
+func (app *BaseApp) FindMailSuppressionById(id string) (*MailSuppression, error) {
+	model := &MailSuppression{}
+
+	err := app.MailSuppressionQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindMailSuppressionByEmail finds a single MailSuppression entry by its email (case insensitive).
+func (app *BaseApp) FindMailSuppressionByEmail(email string) (*MailSuppression, error) {
+	model := &MailSuppression{}
+
+	err := app.MailSuppressionQuery().
+		AndWhere(dbx.HashExp{"email": NormalizeMailSuppressionEmail(email)}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SuppressMailAddress creates (or updates the existing) suppression entry for the specified email.
+func (app *BaseApp) SuppressMailAddress(email string, reason string, detail string) (*MailSuppression, error) {
+	model, err := app.FindMailSuppressionByEmail(email)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		model = &MailSuppression{Email: email}
+	}
+
+	model.Reason = reason
+	model.Detail = detail
+
+	if err := app.Save(model); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FilterSuppressedMailAddresses returns a new slice with only the
+// addresses from the provided list that are not suppressed.
+func (app *BaseApp) FilterSuppressedMailAddresses(addresses []mail.Address) ([]mail.Address, error) {
+	if len(addresses) == 0 {
+		return addresses, nil
+	}
+
+	emails := make([]any, len(addresses))
+	for i, addr := range addresses {
+		emails[i] = NormalizeMailSuppressionEmail(addr.Address)
+	}
+
+	suppressed := []string{}
+
+	err := app.MailSuppressionQuery().
+		Select("email").
+		AndWhere(dbx.In("email", emails...)).
+		Column(&suppressed)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(suppressed) == 0 {
+		return addresses, nil
+	}
+
+	result := make([]mail.Address, 0, len(addresses))
+	for _, addr := range addresses {
+		if !list.ExistInSlice(NormalizeMailSuppressionEmail(addr.Address), suppressed) {
+			result = append(result, addr)
+		}
+	}
+
+	return result, nil
+}
+
+// excludeSuppressedRecipients removes the suppressed addresses from
+// the message recipients and reports whether there are any recipients left.
+func (app *BaseApp) excludeSuppressedRecipients(m *mailer.Message) (bool, error) {
+	var err error
+
+	if m.To, err = app.FilterSuppressedMailAddresses(m.To); err != nil {
+		return false, err
+	}
+
+	if m.Cc, err = app.FilterSuppressedMailAddresses(m.Cc); err != nil {
+		return false, err
+	}
+
+	if m.Bcc, err = app.FilterSuppressedMailAddresses(m.Bcc); err != nil {
+		return false, err
+	}
+
+	return len(m.To)+len(m.Cc)+len(m.Bcc) > 0, nil
+}
+
+// suppressSMTPHardBounce suppresses the message recipient in case of SMTP hard bounce error
+// (aka. a recipient specific permanent failure, see [mailer.IsSMTPHardBounceError]).
+//
+// Messages with more than one recipient are ignored because there is
+// no reliable way to determine which of the recipients has failed.
+func (app *BaseApp) suppressSMTPHardBounce(m *mailer.Message, sendErr error) {
+	if len(m.To)+len(m.Cc)+len(m.Bcc) != 1 || !mailer.IsSMTPHardBounceError(sendErr) {
+		return
+	}
+
+	recipients := slices.Concat(m.To, m.Cc, m.Bcc)
+
+	_, err := app.SuppressMailAddress(recipients[0].Address, MailSuppressionReasonBounce, sendErr.Error())
+	if err != nil {
+		app.Logger().Warn(
+			"Failed to suppress bounced email address",
+			slog.String("email", recipients[0].Address),
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,194 @@
+package core_test
+
+import (
+	"errors"
+	"net/mail"
+	"net/textproto"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+)
+
+func TestSuppressMailAddress(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create
+	m1, err := app.SuppressMailAddress("Test@example.com", core.MailSuppressionReasonBounce, "test1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// update
+	m2, err := app.SuppressMailAddress("test@example.com", core.MailSuppressionReasonComplaint, "test2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m1.Id != m2.Id {
+		t.Fatalf("Expected the existing suppression to be updated, got ids %q and %q", m1.Id, m2.Id)
+	}
+
+	found, err := app.FindMailSuppressionByEmail("TEST@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Reason != core.MailSuppressionReasonComplaint || found.Detail != "test2" {
+		t.Fatalf("Expected the updated reason and detail, got %q and %q", found.Reason, found.Detail)
+	}
+
+	foundById, err := app.FindMailSuppressionById(m1.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if foundById.Email != "test@example.com" {
+		t.Fatalf("Expected email %q, got %q", "test@example.com", foundById.Email)
+	}
+
+	// invalid
+	if _, err := app.SuppressMailAddress("invalid", core.MailSuppressionReasonBounce, ""); err == nil {
+		t.Fatal("Expected validation error, got nil")
+	}
+}
+
+func TestFindMailSuppressionMissing(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.FindMailSuppressionById("missing"); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if _, err := app.FindMailSuppressionByEmail("missing@example.com"); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestFilterSuppressedMailAddresses(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.SuppressMailAddress("a@example.com", core.MailSuppressionReasonBounce, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := app.FilterSuppressedMailAddresses([]mail.Address{
+		{Address: "A@example.com"},
+		{Address: "b@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 1 || result[0].Address != "b@example.com" {
+		t.Fatalf("Expected only b@example.com, got %v", result)
+	}
+}
+
+func TestMailSuppressionOnSend(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.SuppressMailAddress("a@example.com", core.MailSuppressionReasonBounce, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// partially suppressed
+	err := app.NewMailClient().Send(&mailer.Message{
+		From: mail.Address{Address: "from@example.com"},
+		To:   []mail.Address{{Address: "a@example.com"}, {Address: "b@example.com"}},
+		Cc:   []mail.Address{{Address: "a@example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total := app.TestMailer.TotalSend(); total != 1 {
+		t.Fatalf("Expected 1 sent message, got %d", total)
+	}
+
+	last := app.TestMailer.LastMessage()
+	if len(last.To) != 1 || last.To[0].Address != "b@example.com" || len(last.Cc) != 0 {
+		t.Fatalf("Expected the suppressed recipients to be excluded, got To %v and Cc %v", last.To, last.Cc)
+	}
+
+	// fully suppressed
+	err = app.NewMailClient().Send(&mailer.Message{
+		From: mail.Address{Address: "from@example.com"},
+		To:   []mail.Address{{Address: "a@example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total := app.TestMailer.TotalSend(); total != 1 {
+		t.Fatalf("Expected the fully suppressed message to be skipped, got %d sent messages", total)
+	}
+}
+
+func TestMailSuppressionSMTPHardBounce(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	bounceErr := &textproto.Error{Code: 550, Msg: "5.1.1 mailbox unavailable"}
+	policyErr := &textproto.Error{Code: 550, Msg: "5.7.1 message rejected as spam"}
+
+	app.OnMailerSend().BindFunc(func(e *core.MailerEvent) error {
+		switch e.Message.To[0].Address {
+		case "bounce@example.com":
+			return bounceErr
+		case "policy@example.com":
+			return policyErr
+		}
+		return e.Next()
+	})
+
+	send := func(to ...string) error {
+		m := &mailer.Message{From: mail.Address{Address: "from@example.com"}}
+		for _, addr := range to {
+			m.To = append(m.To, mail.Address{Address: addr})
+		}
+		return app.NewMailClient().Send(m)
+	}
+
+	// multiple recipients are not suppressed
+	if err := send("bounce@example.com", "other@example.com"); !errors.Is(err, bounceErr) {
+		t.Fatalf("Expected bounce error, got %v", err)
+	}
+	if _, err := app.FindMailSuppressionByEmail("bounce@example.com"); err == nil {
+		t.Fatal("Expected no suppression for multiple recipients message")
+	}
+
+	// non recipient specific permanent failure
+	if err := send("policy@example.com"); !errors.Is(err, policyErr) {
+		t.Fatalf("Expected policy error, got %v", err)
+	}
+	if _, err := app.FindMailSuppressionByEmail("policy@example.com"); err == nil {
+		t.Fatal("Expected no suppression for a non recipient specific failure")
+	}
+
+	// single recipient
+	if err := send("bounce@example.com"); !errors.Is(err, bounceErr) {
+		t.Fatalf("Expected bounce error, got %v", err)
+	}
+	suppression, err := app.FindMailSuppressionByEmail("bounce@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if suppression.Reason != core.MailSuppressionReasonBounce {
+		t.Fatalf("Expected bounce reason, got %q", suppression.Reason)
+	}
+}
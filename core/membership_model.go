@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var (
+	_ Model         = (*Membership)(nil)
+	_ PreValidator  = (*Membership)(nil)
+	_ PostValidator = (*Membership)(nil)
+)
+
+const MembershipsTableName = "_memberships"
+
+// Membership defines a single organization member
+// (see [CollectionOrganization] and [BaseApp.AddOrganizationMember]).
+type Membership struct {
+	BaseModel
+
+	// CollectionRef and OrganizationRef are the organization collection and record ids.
+	CollectionRef   string `db:"collectionRef" json:"collectionRef"`
+	OrganizationRef string `db:"organizationRef" json:"organizationRef"`
+
+	// MemberCollectionRef and MemberRef are the member auth collection and record ids.
+	MemberCollectionRef string `db:"memberCollectionRef" json:"memberCollectionRef"`
+	MemberRef           string `db:"memberRef" json:"memberRef"`
+
+	Role string `db:"role" json:"role"`
+
+	Created types.DateTime `db:"created" json:"created"`
+	Updated types.DateTime `db:"updated" json:"updated"`
+}
+
+func (m *Membership) TableName() string {
+	return MembershipsTableName
+}
+
+// PreValidate implements the [PreValidator] interface and initializes
+// the membership system fields before their validation.
+func (m *Membership) PreValidate(ctx context.Context, app App) error {
+	now := types.NowDateTime()
+	if m.IsNew() {
+		if m.Id == "" {
+			m.Id = GenerateDefaultRandomId()
+		}
+		if m.Created.IsZero() {
+			m.Created = now
+		}
+	}
+	m.Updated = now
+
+	return nil
+}
+
+// PostValidate implements the [PostValidator] interface and defines
+// the Membership model validations.
+func (m *Membership) PostValidate(ctx context.Context, app App) error {
+	collection, _ := app.FindCachedCollectionByNameOrId(m.CollectionRef)
+
+	return validation.ValidateStructWithContext(ctx, m,
+		validation.Field(&m.Id, validation.Required),
+		validation.Field(&m.CollectionRef, validation.Required, validation.By(m.checkCollection(collection))),
+		validation.Field(&m.OrganizationRef, validation.Required),
+		validation.Field(&m.MemberCollectionRef, validation.Required, validation.By(m.checkMemberCollection(collection))),
+		validation.Field(&m.MemberRef, validation.Required),
+		validation.Field(&m.Role, validation.Length(0, 100), validation.By(m.checkRole(collection))),
+	)
+}
+
+func (m *Membership) checkCollection(collection *Collection) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+
+		if collection == nil || collection.Id != v || !collection.Organization.Enabled {
+			return validation.NewError("validation_invalid_organization_collection", "The collection must be an existing organization collection id.")
+		}
+
+		return nil
+	}
+}
+
+func (m *Membership) checkMemberCollection(collection *Collection) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+
+		if collection == nil {
+			return nil // checked by the collectionRef validator
+		}
+
+		if collection.Organization.MembersCollection != v {
+			return validation.NewError("validation_invalid_members_collection", "The member must be from the organization members collection.")
+		}
+
+		return nil
+	}
+}
+
+func (m *Membership) checkRole(collection *Collection) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+
+		if collection == nil {
+			return nil // checked by the collectionRef validator
+		}
+
+		if !collection.Organization.HasRole(v) {
+			return validation.NewError("validation_invalid_role", "Invalid or not allowed membership role.")
+		}
+
+		return nil
+	}
+}
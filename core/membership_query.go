@@ -0,0 +1,269 @@
+package core
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func init() {
+	// @request.auth.memberOf(org, [role1, role2, ...]) checks whether the current
+	// authenticated record is a member of the organization with the specified id
+	// (see [CollectionOrganization]).
+	//
+	// The first argument could be an organization record id string or an identifier
+	// (e.g. `id` in the organization collection rules or `org` relation field).
+	// The optional remaining string arguments restrict the membership to the listed roles, e.g.
+	// `@request.auth.memberOf(org, "owner", "admin") = true`.
+	//
+	// Similar to geoDistance, no "match-all" constraint is applied for multiple relation fields.
+	search.TokenFunctions["@request.auth.memberOf"] = func(argTokenResolverFunc func(fexpr.Token) (*search.ResolverResult, error), args ...fexpr.Token) (*search.ResolverResult, error) {
+		if len(args) < 1 {
+			return nil, errors.New("[memberOf] expected at least 1 argument")
+		}
+
+		if args[0].Type != fexpr.TokenText && args[0].Type != fexpr.TokenIdentifier {
+			return nil, errors.New("[memberOf] expects the first argument to be a string or identifier")
+		}
+
+		orgResult, err := argTokenResolverFunc(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("[memberOf] failed to resolve the organization argument: %w", err)
+		}
+
+		authIdResult, err := argTokenResolverFunc(fexpr.Token{Type: fexpr.TokenIdentifier, Literal: "@request.auth." + FieldNameId})
+		if err != nil {
+			return nil, fmt.Errorf("[memberOf] failed to resolve the auth record: %w", err)
+		}
+
+		authCollectionResult, err := argTokenResolverFunc(fexpr.Token{Type: fexpr.TokenIdentifier, Literal: "@request.auth." + FieldNameCollectionId})
+		if err != nil {
+			return nil, fmt.Errorf("[memberOf] failed to resolve the auth collection: %w", err)
+		}
+
+		params := dbx.Params{}
+		for _, p := range []dbx.Params{orgResult.Params, authIdResult.Params, authCollectionResult.Params} {
+			for k, v := range p {
+				params[k] = v
+			}
+		}
+
+		alias := "__ms_" + security.PseudorandomString(6)
+
+		identifier := "(EXISTS (SELECT 1 FROM {{" + MembershipsTableName + "}} {{" + alias + "}} " +
+			"WHERE [[" + alias + ".organizationRef]] = " + orgResult.Identifier + " " +
+			"AND [[" + alias + ".memberRef]] = " + authIdResult.Identifier + " " +
+			"AND [[" + alias + ".memberCollectionRef]] = " + authCollectionResult.Identifier
+
+		if len(args) > 1 {
+			identifier += " AND [[" + alias + ".role]] IN ("
+			for i, arg := range args[1:] {
+				if arg.Type != fexpr.TokenText {
+					return nil, fmt.Errorf("[memberOf] expects the role argument %d to be a string", i+1)
+				}
+
+				param := alias + "role" + fmt.Sprint(i)
+				params[param] = arg.Literal
+
+				if i > 0 {
+					identifier += ", "
+				}
+				identifier += "{:" + param + "}"
+			}
+			identifier += ")"
+		}
+
+		identifier += "))"
+
+		return &search.ResolverResult{
+			NullFallback: search.NullFallbackDisabled,
+			Identifier:   identifier,
+			Params:       params,
+		}, nil
+	}
+}
+
+// MembershipQuery returns a new Membership select query.
+func (app *BaseApp) MembershipQuery() *dbx.SelectQuery {
+	return app.ModelQuery(&Membership{})
+}
+
+// FindMembershipById finds a single Membership model by its id.
+func (app *BaseApp) FindMembershipById(id string) (*Membership, error) {
+	model := &Membership{}
+
+	err := app.MembershipQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindOrganizationMembership returns the membership of the
+// member auth record in the specified organization record.
+func (app *BaseApp) FindOrganizationMembership(organization *Record, member *Record) (*Membership, error) {
+	model := &Membership{}
+
+	err := app.MembershipQuery().
+		AndWhere(dbx.HashExp{
+			"collectionRef":       organization.Collection().Id,
+			"organizationRef":     organization.Id,
+			"memberCollectionRef": member.Collection().Id,
+			"memberRef":           member.Id,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindOrganizationMemberships returns all memberships of the
+// specified organization record ordered by their creation date.
+func (app *BaseApp) FindOrganizationMemberships(organization *Record) ([]*Membership, error) {
+	result := []*Membership{}
+
+	err := app.MembershipQuery().
+		AndWhere(dbx.HashExp{
+			"collectionRef":   organization.Collection().Id,
+			"organizationRef": organization.Id,
+		}).
+		OrderBy("created ASC", "id ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindMemberOrganizations returns the organization records of the specified
+// collection in which the member auth record is a member.
+func (app *BaseApp) FindMemberOrganizations(collectionModelOrIdentifier any, member *Record) ([]*Record, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	err = app.MembershipQuery().
+		Select("organizationRef").
+		AndWhere(dbx.HashExp{
+			"collectionRef":       collection.Id,
+			"memberCollectionRef": member.Collection().Id,
+			"memberRef":           member.Id,
+		}).
+		Column(&ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return []*Record{}, nil
+	}
+
+	return app.FindRecordsByIds(collection, ids)
+}
+
+// AddOrganizationMember adds the member auth record to the specified
+// organization record with the provided role.
+//
+// If the auth record is already a member, only its role is updated.
+func (app *BaseApp) AddOrganizationMember(organization *Record, member *Record, role string) (*Membership, error) {
+	membership, err := app.FindOrganizationMembership(organization, member)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		membership = &Membership{
+			CollectionRef:       organization.Collection().Id,
+			OrganizationRef:     organization.Id,
+			MemberCollectionRef: member.Collection().Id,
+			MemberRef:           member.Id,
+		}
+	}
+
+	membership.Role = role
+
+	if err := app.Save(membership); err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// RemoveOrganizationMember removes the member auth record from the specified organization record.
+//
+// Removing a non-member is a no-op.
+func (app *BaseApp) RemoveOrganizationMember(organization *Record, member *Record) error {
+	membership, err := app.FindOrganizationMembership(organization, member)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	return app.Delete(membership)
+}
+
+func (app *BaseApp) registerMembershipHooks() {
+	app.OnRecordDeleteExecute().BindFunc(func(e *RecordEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		collection := e.Record.Collection()
+
+		if collection.Organization.Enabled {
+			_, err := e.App.DB().Delete(MembershipsTableName, dbx.HashExp{
+				"collectionRef":   collection.Id,
+				"organizationRef": e.Record.Id,
+			}).Execute()
+			if err != nil {
+				return err
+			}
+		}
+
+		if collection.IsAuth() {
+			_, err := e.App.DB().Delete(MembershipsTableName, dbx.HashExp{
+				"memberCollectionRef": collection.Id,
+				"memberRef":           e.Record.Id,
+			}).Execute()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	app.OnCollectionDeleteExecute().BindFunc(func(e *CollectionEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		_, err := e.App.DB().Delete(MembershipsTableName, dbx.Or(
+			dbx.HashExp{"collectionRef": e.Collection.Id},
+			dbx.HashExp{"memberCollectionRef": e.Collection.Id},
+		)).Execute()
+
+		return err
+	})
+}
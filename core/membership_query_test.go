@@ -0,0 +1,269 @@
+package core_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createTestOrganizations(t testing.TB, app core.App) (*core.Collection, *core.Collection) {
+	users, err := app.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orgs := core.NewBaseCollection("orgs")
+	orgs.Fields.Add(&core.TextField{Name: "name"})
+	orgs.Organization = core.CollectionOrganization{
+		Enabled:           true,
+		MembersCollection: users.Id,
+		Roles:             []string{"owner", "member"},
+	}
+	if err := app.Save(orgs); err != nil {
+		t.Fatal(err)
+	}
+
+	projects := core.NewBaseCollection("projects")
+	projects.Fields.Add(&core.RelationField{Name: "org", CollectionId: orgs.Id, MaxSelect: 1})
+	if err := app.Save(projects); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"org100000000001", "org200000000002"} {
+		org := core.NewRecord(orgs)
+		org.Id = id
+		if err := app.Save(org); err != nil {
+			t.Fatal(err)
+		}
+
+		project := core.NewRecord(projects)
+		project.Id = "prj" + id[3:]
+		project.Set("org", id)
+		if err := app.Save(project); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return orgs, projects
+}
+
+func TestOrganizationMembers(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	orgs, projects := createTestOrganizations(t, app)
+
+	org1, err := app.FindRecordById(orgs, "org100000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org2, err := app.FindRecordById(orgs, "org200000000002")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user1, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.FindAuthRecordByEmail("users", "test2@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := app.FindAuthRecordByEmail("clients", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := app.FindRecordById(projects, "prj100000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid
+	if _, err := app.AddOrganizationMember(org1, user1, "missing"); err == nil {
+		t.Fatal("Expected not allowed role error")
+	}
+	if _, err := app.AddOrganizationMember(org1, client, "member"); err == nil {
+		t.Fatal("Expected invalid members collection error")
+	}
+	if _, err := app.AddOrganizationMember(project, user1, "member"); err == nil {
+		t.Fatal("Expected non-organization collection error")
+	}
+
+	membership, err := app.AddOrganizationMember(org1, user1, "member")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// update the role of the existing member
+	updated, err := app.AddOrganizationMember(org1, user1, "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Id != membership.Id || updated.Role != "owner" {
+		t.Fatalf("Expected membership %q to be updated with owner role, got %q (%q)", membership.Id, updated.Id, updated.Role)
+	}
+
+	if _, err := app.AddOrganizationMember(org1, user2, "member"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.AddOrganizationMember(org2, user2, "owner"); err != nil {
+		t.Fatal(err)
+	}
+
+	memberships, err := app.FindOrganizationMemberships(org1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the memberships could be created within the same millisecond so don't rely on their order
+	memberRefs := make([]string, len(memberships))
+	for i, m := range memberships {
+		memberRefs[i] = m.MemberRef
+	}
+	if len(memberships) != 2 || !slices.Contains(memberRefs, user1.Id) || !slices.Contains(memberRefs, user2.Id) {
+		t.Fatalf("Expected user1 and user2 org1 memberships, got %v", memberRefs)
+	}
+
+	organizations, err := app.FindMemberOrganizations(orgs, user2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(organizations) != 2 {
+		t.Fatalf("Expected 2 user2 organizations, got %d", len(organizations))
+	}
+
+	organizations, err = app.FindMemberOrganizations(orgs, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(organizations) != 0 {
+		t.Fatalf("Expected no client organizations, got %d", len(organizations))
+	}
+
+	// remove
+	if err := app.RemoveOrganizationMember(org1, user2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.FindOrganizationMembership(org1, user2); err == nil {
+		t.Fatal("Expected the user2 org1 membership to be removed")
+	}
+	if err := app.RemoveOrganizationMember(org1, user2); err != nil {
+		t.Fatalf("Expected removing a non-member to be no-op, got %v", err)
+	}
+
+	// cleanup on organization delete
+	if err := app.Delete(org2); err != nil {
+		t.Fatal(err)
+	}
+	if total := countMemberships(t, app); total != 1 {
+		t.Fatalf("Expected 1 remaining membership after the organization delete, got %d", total)
+	}
+
+	// cleanup on member delete
+	if err := app.Delete(user1); err != nil {
+		t.Fatal(err)
+	}
+	if total := countMemberships(t, app); total != 0 {
+		t.Fatalf("Expected no remaining memberships after the member delete, got %d", total)
+	}
+}
+
+func TestMemberOfFilterFunction(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	orgs, projects := createTestOrganizations(t, app)
+
+	org1, err := app.FindRecordById(orgs, "org100000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := app.FindAuthRecordByEmail("clients", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.AddOrganizationMember(org1, user, "member"); err != nil {
+		t.Fatal(err)
+	}
+
+	project1, err := app.FindRecordById(projects, "prj100000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project2, err := app.FindRecordById(projects, "prj200000000002")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name     string
+		record   *core.Record
+		auth     *core.Record
+		rule     string
+		expected bool
+	}{
+		{"guest", project1, nil, `@request.auth.memberOf(org) = true`, false},
+		{"member via relation", project1, user, `@request.auth.memberOf(org) = true`, true},
+		{"non-member via relation", project2, user, `@request.auth.memberOf(org) = true`, false},
+		{"member via id", org1, user, `@request.auth.memberOf(id) = true`, true},
+		{"member via string", project2, user, `@request.auth.memberOf("org100000000001") = true`, true},
+		{"member of different auth collection", project1, client, `@request.auth.memberOf(org) = true`, false},
+		{"matching role", project1, user, `@request.auth.memberOf(org, "owner", "member") = true`, true},
+		{"non-matching role", project1, user, `@request.auth.memberOf(org, "owner") = true`, false},
+		{"negated", project2, user, `@request.auth.memberOf(org) = false`, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := app.CanAccessRecord(s.record, &core.RequestInfo{Auth: s.auth}, &s.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+
+	// invalid arguments
+	invalidFilters := []string{
+		`@request.auth.memberOf() = true`,
+		`@request.auth.memberOf(1) = true`,
+		`@request.auth.memberOf(org, role) = true`,
+	}
+	for _, filter := range invalidFilters {
+		if _, err := app.FindRecordsByFilter(projects, filter, "", 0, 0); err == nil {
+			t.Fatalf("Expected filter %q to fail", filter)
+		}
+	}
+}
+
+func countMemberships(t testing.TB, app core.App) int {
+	var total int
+
+	err := app.MembershipQuery().Select("count(*)").Row(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return total
+}
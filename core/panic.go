@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/sentry"
+)
+
+// Common panic sources.
+const (
+	PanicSourceRequest       = "request"
+	PanicSourceCron          = "cron"
+	PanicSourceHook          = "hook"
+	PanicSourceScheduledTask = "scheduledTask"
+)
+
+// RecoverPanic recovers from a panic (if any) and reports it via [App.ReportPanic].
+//
+// Note that it must be called directly as a deferred function, e.g.:
+//
+//	go func() {
+//		defer core.RecoverPanic(app, core.PanicSourceHook, "myTask")
+//
+//		// ...
+//	}()
+func RecoverPanic(app App, source string, name string) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	if err := app.ReportPanic(source, name, recovered, debug.Stack()); err != nil {
+		app.Logger().Error("Failed to report panic", slog.String("source", source), slog.String("error", err.Error()))
+	}
+}
+
+// ReportPanic reports a recovered panic value by triggering the [App.OnPanic] hook.
+//
+// The default hook action logs the panic and, if enabled in the settings,
+// forwards it in the background to the configured Sentry DSN.
+func (app *BaseApp) ReportPanic(source string, name string, recovered any, stack []byte) error {
+	recoveredErr, ok := recovered.(error)
+	if !ok {
+		recoveredErr = fmt.Errorf("%v", recovered)
+	}
+
+	event := new(PanicEvent)
+	event.App = app
+	event.Source = source
+	event.Name = name
+	event.Value = recovered
+	event.Error = recoveredErr
+	event.Stack = stack
+
+	return app.OnPanic().Trigger(event, func(e *PanicEvent) error {
+		if e.Error == nil {
+			e.Error = errors.New("unknown panic")
+		}
+
+		e.App.Logger().Error(
+			"Recovered from panic",
+			slog.String("type", "panic"),
+			slog.String("source", e.Source),
+			slog.String("name", e.Name),
+			slog.String("error", e.Error.Error()),
+			slog.String("stack", string(e.Stack)),
+		)
+
+		config := e.App.Settings().PanicReporting
+		if !config.Enabled || config.DSN == "" {
+			return nil
+		}
+
+		client, err := sentry.NewClient(config.DSN)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the panic reporting client: %w", err)
+		}
+
+		sentryEvent := &sentry.Event{
+			Level:       sentry.LevelFatal,
+			Logger:      "pocketbase",
+			Environment: config.Environment,
+			Tags:        map[string]string{"source": e.Source},
+			Exception: &sentry.ExceptionList{
+				Values: []sentry.Exception{{
+					Type:       "panic",
+					Value:      e.Error.Error(),
+					Stacktrace: sentry.ParseStack(e.Stack),
+				}},
+			},
+		}
+		if e.Name != "" {
+			sentryEvent.Tags["name"] = e.Name
+		}
+
+		// don't block on the panic forwarding
+		app := e.App
+		routine.FireAndForget(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), sentry.DefaultHTTPTimeout)
+			defer cancel()
+
+			if err := client.Send(ctx, sentryEvent); err != nil {
+				app.Logger().Warn("Failed to forward panic to Sentry", slog.String("error", err.Error()))
+			}
+		})
+
+		return nil
+	})
+}
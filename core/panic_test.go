@@ -0,0 +1,232 @@
+package core_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestReportPanic(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// reset
+	if err := app.DeleteOldLogs(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().Logs.MaxDays = 1
+
+	var event *core.PanicEvent
+	app.OnPanic().BindFunc(func(e *core.PanicEvent) error {
+		event = e
+		return e.Next()
+	})
+
+	err := app.ReportPanic(core.PanicSourceCron, "test_job", errors.New("test_error"), []byte("test_stack"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event == nil {
+		t.Fatal("Expected the OnPanic hook to be triggered")
+	}
+
+	if event.Source != core.PanicSourceCron || event.Name != "test_job" {
+		t.Fatalf("Expected source %q and name %q, got %q and %q", core.PanicSourceCron, "test_job", event.Source, event.Name)
+	}
+
+	if event.Error == nil || event.Error.Error() != "test_error" {
+		t.Fatalf("Expected test_error, got %v", event.Error)
+	}
+
+	if err := app.FlushLogs(); err != nil {
+		t.Fatal(err)
+	}
+
+	log := &core.Log{}
+	err = app.LogQuery().AndWhere(dbx.HashExp{"message": "Recovered from panic"}).One(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedData := map[string]any{
+		"type":   "panic",
+		"source": core.PanicSourceCron,
+		"name":   "test_job",
+		"error":  "test_error",
+		"stack":  "test_stack",
+	}
+	for k, v := range expectedData {
+		if log.Data[k] != v {
+			t.Fatalf("Expected log data %s %v, got %v", k, v, log.Data[k])
+		}
+	}
+}
+
+func TestReportPanicNonErrorValue(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var event *core.PanicEvent
+	app.OnPanic().BindFunc(func(e *core.PanicEvent) error {
+		event = e
+		return e.Next()
+	})
+
+	if err := app.ReportPanic(core.PanicSourceHook, "", 123, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if event.Value != 123 {
+		t.Fatalf("Expected raw value 123, got %v", event.Value)
+	}
+
+	if event.Error == nil || event.Error.Error() != "123" {
+		t.Fatalf("Expected normalized error 123, got %v", event.Error)
+	}
+}
+
+func TestReportPanicSentry(t *testing.T) {
+	t.Parallel()
+
+	bodyCh := make(chan map[string]any, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/1/store/" || !strings.Contains(r.Header.Get("X-Sentry-Auth"), "sentry_key=test_key") {
+			w.WriteHeader(400)
+			return
+		}
+
+		var body map[string]any
+		raw, _ := io.ReadAll(r.Body)
+		json.Unmarshal(raw, &body)
+
+		bodyCh <- body
+	}))
+	defer server.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().PanicReporting = core.PanicReportingConfig{
+		Enabled:     true,
+		DSN:         strings.Replace(server.URL, "http://", "http://test_key@", 1) + "/1",
+		Environment: "test_env",
+	}
+
+	err := app.ReportPanic(core.PanicSourceRequest, "GET /test", "test_panic", []byte("goroutine 1 [running]:\nmain.main()\n\t/app/main.go:10 +0x1d\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case body := <-bodyCh:
+		if body["level"] != "fatal" {
+			t.Fatalf("Expected fatal level, got %v", body["level"])
+		}
+
+		if body["environment"] != "test_env" {
+			t.Fatalf("Expected environment test_env, got %v", body["environment"])
+		}
+
+		raw, _ := json.Marshal(body["tags"])
+		expectedTags := `{"name":"GET /test","source":"request"}`
+		if string(raw) != expectedTags {
+			t.Fatalf("Expected tags %s, got %s", expectedTags, raw)
+		}
+
+		raw, _ = json.Marshal(body["exception"])
+		expectedException := `{"values":[{"stacktrace":{"frames":[{"filename":"/app/main.go","function":"main.main","lineno":10}]},"type":"panic","value":"test_panic"}]}`
+		if string(raw) != expectedException {
+			t.Fatalf("Expected exception %s, got %s", expectedException, raw)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the panic to be forwarded to the Sentry server")
+	}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var event *core.PanicEvent
+	app.OnPanic().BindFunc(func(e *core.PanicEvent) error {
+		event = e
+		return e.Next()
+	})
+
+	// no panic
+	func() {
+		defer core.RecoverPanic(app, core.PanicSourceHook, "test")
+	}()
+
+	if event != nil {
+		t.Fatalf("Expected no OnPanic hook trigger, got %v", event)
+	}
+
+	func() {
+		defer core.RecoverPanic(app, core.PanicSourceHook, "test")
+
+		panic("test_panic")
+	}()
+
+	if event == nil {
+		t.Fatal("Expected the OnPanic hook to be triggered")
+	}
+
+	if event.Source != core.PanicSourceHook || event.Name != "test" || event.Value != "test_panic" {
+		t.Fatalf("Unexpected panic event data: %q %q %v", event.Source, event.Name, event.Value)
+	}
+
+	if !strings.Contains(string(event.Stack), "TestRecoverPanic") {
+		t.Fatalf("Expected the stack to contain the panicking function, got\n%s", event.Stack)
+	}
+}
+
+func TestBaseAppCronPanic(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	eventCh := make(chan *core.PanicEvent, 1)
+	app.OnPanic().BindFunc(func(e *core.PanicEvent) error {
+		select {
+		case eventCh <- e:
+		default:
+		}
+		return e.Next()
+	})
+
+	app.Cron().SetInterval(50 * time.Millisecond)
+	app.Cron().MustAdd("test_panic_job", "* * * * *", func() {
+		panic("test_panic")
+	})
+	app.Cron().Start()
+	defer app.Cron().Stop()
+
+	select {
+	case e := <-eventCh:
+		if e.Source != core.PanicSourceCron || e.Name != "test_panic_job" {
+			t.Fatalf("Expected cron source and test_panic_job name, got %q and %q", e.Source, e.Name)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the cron job panic to be reported")
+	}
+}
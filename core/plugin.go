@@ -0,0 +1,231 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+var pluginNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][\w\-\.]*$`)
+
+// Plugin defines the interface of a reusable app feature
+// that could be registered with [App.RegisterPlugin].
+//
+// Besides the required methods a plugin could also implement one or more
+// of the optional lifecycle interfaces ([PluginWithMigrations], [PluginWithRoutes],
+// [PluginWithCronJobs], [PluginWithSettings]).
+type Plugin interface {
+	// Name returns the unique plugin name (e.g. "payments").
+	Name() string
+
+	// Init is called once after the app bootstrap if the plugin is enabled.
+	//
+	// This is usually where the plugin binds its app event hooks.
+	Init(app App) error
+}
+
+// PluginWithMigrations defines an optional plugin interface for
+// registering app migrations.
+//
+// The migrations are always applied, even if the plugin is disabled,
+// in order to keep the app schema consistent.
+type PluginWithMigrations interface {
+	Plugin
+
+	// Migrations returns the plugin app migrations.
+	//
+	// The migrations file names are prefixed with the plugin name.
+	Migrations() []*Migration
+}
+
+// PluginWithRoutes defines an optional plugin interface for
+// registering custom routes.
+//
+// The routes of a disabled plugin respond with 404 Not Found.
+type PluginWithRoutes interface {
+	Plugin
+
+	// BindRoutes registers the plugin routes in the provided router group.
+	BindRoutes(rg *router.RouterGroup[*RequestEvent])
+}
+
+// PluginCronJob defines a single plugin cron job.
+type PluginCronJob struct {
+	Func func()
+
+	// Id is the job id unique within the plugin
+	// (the registered app cron job id is "{pluginName}:{id}").
+	Id string
+
+	// Expression is the job cron expression (e.g. "0 * * * *").
+	Expression string
+}
+
+// PluginWithCronJobs defines an optional plugin interface for
+// registering cron jobs.
+//
+// The cron jobs of a disabled plugin are skipped.
+type PluginWithCronJobs interface {
+	Plugin
+
+	// CronJobs returns the plugin cron jobs.
+	CronJobs() []PluginCronJob
+}
+
+// PluginWithSettings defines an optional plugin interface for
+// a custom plugin settings section.
+//
+// The section is registered as app settings extension with the plugin name
+// (see [App.RegisterSettingsExtension]) and could be read with [Settings.Extension].
+type PluginWithSettings interface {
+	Plugin
+
+	// SettingsExtension returns the plugin settings section definition
+	// (its Name is always replaced with the plugin name).
+	SettingsExtension() SettingsExtension
+}
+
+// RegisterPlugin registers the provided plugin and its lifecycle hooks.
+//
+// It is expected to be called before the app bootstrap.
+func (app *BaseApp) RegisterPlugin(p Plugin) error {
+	name := p.Name()
+
+	if !pluginNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid plugin name %q", name)
+	}
+
+	if app.FindPlugin(name) != nil {
+		return fmt.Errorf("plugin %q is already registered", name)
+	}
+
+	if sp, ok := p.(PluginWithSettings); ok {
+		ext := sp.SettingsExtension()
+		ext.Name = name
+
+		if err := app.RegisterSettingsExtension(ext); err != nil {
+			return fmt.Errorf("failed to register plugin %q settings: %w", name, err)
+		}
+	}
+
+	app.plugins = append(app.plugins, p)
+
+	hookId := "__pbPlugin_" + name + "__"
+
+	if mp, ok := p.(PluginWithMigrations); ok {
+		for _, m := range mp.Migrations() {
+			if m.File == "" {
+				return fmt.Errorf("plugin %q migrations must have a File name", name)
+			}
+
+			file := name + "_" + m.File
+
+			// the migrations list is global and the plugin could be
+			// registered multiple times for different app instances
+			exists := slices.ContainsFunc(AppMigrations.Items(), func(item *Migration) bool {
+				return item.File == file
+			})
+			if !exists {
+				AppMigrations.Register(m.Up, m.Down, file)
+			}
+		}
+	}
+
+	app.OnBootstrap().Bind(&hook.Handler[*BootstrapEvent]{
+		Id: hookId,
+		Func: func(e *BootstrapEvent) error {
+			if err := e.Next(); err != nil {
+				return err
+			}
+
+			if !e.App.IsPluginEnabled(name) {
+				return nil
+			}
+
+			if err := p.Init(e.App); err != nil {
+				return fmt.Errorf("failed to initialize plugin %q: %w", name, err)
+			}
+
+			if cp, ok := p.(PluginWithCronJobs); ok {
+				for _, job := range cp.CronJobs() {
+					err := e.App.Cron().Add(name+":"+job.Id, job.Expression, func() {
+						if app.IsPluginEnabled(name) {
+							job.Func()
+						}
+					})
+					if err != nil {
+						return fmt.Errorf("failed to register plugin %q cron job %q: %w", name, job.Id, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	})
+
+	if rp, ok := p.(PluginWithRoutes); ok {
+		app.OnServe().Bind(&hook.Handler[*ServeEvent]{
+			Id: hookId,
+			Func: func(e *ServeEvent) error {
+				group := e.Router.Group("")
+				group.BindFunc(func(re *RequestEvent) error {
+					if !re.App.IsPluginEnabled(name) {
+						return router.NewNotFoundError("", nil)
+					}
+					return re.Next()
+				})
+
+				rp.BindRoutes(group)
+
+				return e.Next()
+			},
+		})
+	}
+
+	return nil
+}
+
+// Plugins returns the registered app plugins.
+func (app *BaseApp) Plugins() []Plugin {
+	return slices.Clone(app.plugins)
+}
+
+// FindPlugin returns the registered plugin with the specified name (or nil if missing).
+func (app *BaseApp) FindPlugin(name string) Plugin {
+	for _, p := range app.plugins {
+		if p.Name() == name {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// IsPluginEnabled reports whether the plugin with the specified name
+// is registered and not disabled in the app settings.
+func (app *BaseApp) IsPluginEnabled(name string) bool {
+	if app.FindPlugin(name) == nil {
+		return false
+	}
+
+	return !slices.Contains(app.Settings().Plugins.Disabled, name)
+}
+
+// PluginSettings returns the settings section of the plugin with the
+// specified name merged on top of its default settings.
+func (app *BaseApp) PluginSettings(name string) map[string]any {
+	result := map[string]any{}
+
+	if _, ok := app.FindPlugin(name).(PluginWithSettings); !ok {
+		return result
+	}
+
+	if err := app.Settings().Extension(name, &result); err != nil {
+		app.Logger().Warn("Failed to load plugin settings", "plugin", name, "error", err)
+	}
+
+	return result
+}
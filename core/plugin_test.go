@@ -0,0 +1,237 @@
+package core_test
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+type testPlugin struct {
+	name      string
+	initCalls int
+}
+
+func (p *testPlugin) Name() string {
+	return p.name
+}
+
+func (p *testPlugin) Init(app core.App) error {
+	p.initCalls++
+	return nil
+}
+
+func (p *testPlugin) Migrations() []*core.Migration {
+	return []*core.Migration{
+		{File: "1_init.go", Up: func(txApp core.App) error { return nil }},
+	}
+}
+
+func (p *testPlugin) BindRoutes(rg *router.RouterGroup[*core.RequestEvent]) {}
+
+func (p *testPlugin) CronJobs() []core.PluginCronJob {
+	return []core.PluginCronJob{
+		{Id: "test", Expression: "* * * * *", Func: func() {}},
+	}
+}
+
+type testPluginSettings struct {
+	A       int  `json:"a"`
+	B       int  `json:"b"`
+	Invalid bool `json:"invalid"`
+}
+
+func (s testPluginSettings) Validate() error {
+	if s.Invalid {
+		return errors.New("invalid settings")
+	}
+	return nil
+}
+
+func (p *testPlugin) SettingsExtension() core.SettingsExtension {
+	return core.SettingsExtension{
+		Name:      "ignored",
+		Defaults:  testPluginSettings{A: 1, B: 2},
+		Sensitive: []string{"b"},
+	}
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := app.RegisterPlugin(&testPlugin{name: "invalid name"}); err == nil {
+		t.Fatal("Expected invalid plugin name error")
+	}
+
+	p := &testPlugin{name: "test_register"}
+
+	if err := app.RegisterPlugin(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.RegisterPlugin(&testPlugin{name: "test_register"}); err == nil {
+		t.Fatal("Expected duplicated plugin error")
+	}
+
+	if plugins := app.Plugins(); len(plugins) != 1 || plugins[0] != p {
+		t.Fatalf("Expected the registered plugin, got %v", plugins)
+	}
+
+	if app.FindPlugin("test_register") != p {
+		t.Fatal("Expected FindPlugin to return the registered plugin")
+	}
+
+	if app.FindPlugin("missing") != nil {
+		t.Fatal("Expected FindPlugin to return nil for missing plugin")
+	}
+
+	hasMigration := slices.ContainsFunc(core.AppMigrations.Items(), func(m *core.Migration) bool {
+		return m.File == "test_register_1_init.go"
+	})
+	if !hasMigration {
+		t.Fatal("Expected the plugin migration to be registered")
+	}
+
+	if err := app.Bootstrap(); err != nil {
+		t.Fatal(err)
+	}
+
+	if p.initCalls != 1 {
+		t.Fatalf("Expected Init to be called once, got %d", p.initCalls)
+	}
+
+	hasJob := slices.ContainsFunc(app.Cron().Jobs(), func(j *cron.Job) bool {
+		return j.Id() == "test_register:test"
+	})
+	if !hasJob {
+		t.Fatal("Expected the plugin cron job to be registered")
+	}
+}
+
+func TestRegisterPluginDisabled(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Plugins.Disabled = []string{"test_disabled"}
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &testPlugin{name: "test_disabled"}
+	if err := app.RegisterPlugin(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Bootstrap(); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.IsPluginEnabled("test_disabled") {
+		t.Fatal("Expected the plugin to be disabled")
+	}
+
+	if p.initCalls != 0 {
+		t.Fatalf("Expected Init to not be called, got %d", p.initCalls)
+	}
+
+	for _, j := range app.Cron().Jobs() {
+		if j.Id() == "test_disabled:test" {
+			t.Fatal("Expected the plugin cron job to not be registered")
+		}
+	}
+}
+
+func TestIsPluginEnabled(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if app.IsPluginEnabled("test") {
+		t.Fatal("Expected missing plugin to be disabled")
+	}
+
+	if err := app.RegisterPlugin(&testPlugin{name: "test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !app.IsPluginEnabled("test") {
+		t.Fatal("Expected the registered plugin to be enabled")
+	}
+
+	app.Settings().Plugins.Disabled = []string{"test"}
+
+	if app.IsPluginEnabled("test") {
+		t.Fatal("Expected the plugin to be disabled")
+	}
+}
+
+func TestPluginSettings(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := app.RegisterPlugin(&testPlugin{name: "test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the plugin settings are registered as settings extension with the plugin name
+	extensions := app.SettingsExtensions()
+	if len(extensions) != 1 || extensions[0].Name != "test" {
+		t.Fatalf("Expected the test plugin settings extension, got %v", extensions)
+	}
+
+	settings := app.PluginSettings("test")
+	if len(settings) != 3 || settings["a"] != 1.0 || settings["b"] != 2.0 {
+		t.Fatalf("Expected the default plugin settings, got %v", settings)
+	}
+
+	if err := app.Settings().SetExtension("test", map[string]any{"b": 3, "c": 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	settings = app.PluginSettings("test")
+	if settings["a"] != 1.0 || settings["b"] != 3.0 || settings["c"] != 4.0 {
+		t.Fatalf("Expected the merged plugin settings, got %v", settings)
+	}
+
+	if settings := app.PluginSettings("missing"); len(settings) != 0 {
+		t.Fatalf("Expected empty missing plugin settings, got %v", settings)
+	}
+
+	// sensitive keys
+	raw, err := json.Marshal(app.Settings())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), `"b":3`) {
+		t.Fatalf("Expected the sensitive plugin settings key to be excluded, got %s", raw)
+	}
+
+	// validation
+	if err := app.Settings().SetExtension("test", map[string]any{"invalid": true}); err != nil {
+		t.Fatal(err)
+	}
+	err = app.Validate(app.Settings())
+	tests.TestValidationErrors(t, err, []string{"extensions"})
+
+	// duplicated settings extension
+	if err := app.RegisterPlugin(&testPlugin{name: "test2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.RegisterSettingsExtension(core.SettingsExtension{Name: "test2", Defaults: map[string]any{}}); err == nil {
+		t.Fatal("Expected duplicated settings extension error")
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var (
+	_ Model         = (*Policy)(nil)
+	_ PreValidator  = (*Policy)(nil)
+	_ PostValidator = (*Policy)(nil)
+
+	_ Model         = (*PolicyAcceptance)(nil)
+	_ PreValidator  = (*PolicyAcceptance)(nil)
+	_ PostValidator = (*PolicyAcceptance)(nil)
+)
+
+const (
+	PoliciesTableName          = "_policies"
+	PolicyAcceptancesTableName = "_policyAcceptances"
+)
+
+// PolicyTerms is the name of the terms of service policy document
+// (its accepted version is available in the API rules as @request.auth.acceptedTerms).
+const PolicyTerms = "terms"
+
+// The auth record API rules macros for the highest accepted policies version
+// (used only if the auth collection doesn't have a field with the same name):
+//
+//	@request.auth.acceptedTerms >= 2
+//	@request.auth.acceptedPolicies.privacy >= 1
+const (
+	FieldNameAcceptedTerms    = "acceptedTerms"
+	FieldNameAcceptedPolicies = "acceptedPolicies"
+)
+
+// Policy defines a single version of a named policy document
+// (eg. terms of service, privacy policy, etc.).
+type Policy struct {
+	BaseModel
+
+	Name    string         `db:"name" json:"name"`
+	Version int            `db:"version" json:"version"`
+	Title   string         `db:"title" json:"title"`
+	Content string         `db:"content" json:"content"`
+	URL     string         `db:"url" json:"url"`
+	Created types.DateTime `db:"created" json:"created"`
+	Updated types.DateTime `db:"updated" json:"updated"`
+}
+
+func (m *Policy) TableName() string {
+	return PoliciesTableName
+}
+
+// PreValidate implements the [PreValidator] interface.
+func (m *Policy) PreValidate(ctx context.Context, app App) error {
+	now := types.NowDateTime()
+	if m.IsNew() {
+		if m.Id == "" {
+			m.Id = GenerateDefaultRandomId()
+		}
+		if m.Created.IsZero() {
+			m.Created = now
+		}
+	}
+	m.Updated = now
+
+	return nil
+}
+
+// PostValidate implements the [PostValidator] interface and defines
+// the Policy model validations.
+func (m *Policy) PostValidate(ctx context.Context, app App) error {
+	return validation.ValidateStructWithContext(ctx, m,
+		validation.Field(&m.Id, validation.Required),
+		validation.Field(&m.Name, validation.Required, validation.Length(1, 100), validation.Match(policyNameRegex)),
+		validation.Field(&m.Version, validation.Required, validation.Min(1)),
+		validation.Field(&m.Title, validation.Length(0, 255)),
+		validation.Field(&m.URL, validation.Length(0, 2000), is.URL),
+		validation.Field(&m.Content, validation.When(m.URL == "", validation.Required)),
+	)
+}
+
+// PolicyAcceptance defines a single auth record acceptance of a policy version.
+type PolicyAcceptance struct {
+	BaseModel
+
+	CollectionRef string         `db:"collectionRef" json:"collectionRef"`
+	RecordRef     string         `db:"recordRef" json:"recordRef"`
+	Policy        string         `db:"policy" json:"policy"`
+	Version       int            `db:"version" json:"version"`
+	IP            string         `db:"ip" json:"ip"`
+	Created       types.DateTime `db:"created" json:"created"`
+	Updated       types.DateTime `db:"updated" json:"updated"`
+}
+
+func (m *PolicyAcceptance) TableName() string {
+	return PolicyAcceptancesTableName
+}
+
+// PreValidate implements the [PreValidator] interface.
+func (m *PolicyAcceptance) PreValidate(ctx context.Context, app App) error {
+	now := types.NowDateTime()
+	if m.IsNew() {
+		if m.Id == "" {
+			m.Id = GenerateDefaultRandomId()
+		}
+		if m.Created.IsZero() {
+			m.Created = now
+		}
+	}
+	m.Updated = now
+
+	return nil
+}
+
+// PostValidate implements the [PostValidator] interface and defines
+// the PolicyAcceptance model validations.
+func (m *PolicyAcceptance) PostValidate(ctx context.Context, app App) error {
+	return validation.ValidateStructWithContext(ctx, m,
+		validation.Field(&m.Id, validation.Required),
+		validation.Field(&m.CollectionRef, validation.Required),
+		validation.Field(&m.RecordRef, validation.Required),
+		validation.Field(&m.Policy, validation.Required),
+		validation.Field(&m.Version, validation.Required, validation.Min(1)),
+	)
+}
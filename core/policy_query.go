@@ -0,0 +1,210 @@
+package core
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+var policyNameRegex = regexp.MustCompile(`^\w[\w\-]*$`)
+
+// PolicyQuery returns a new Policy select query.
+func (app *BaseApp) PolicyQuery() *dbx.SelectQuery {
+	return app.ModelQuery(&Policy{})
+}
+
+// PolicyAcceptanceQuery returns a new PolicyAcceptance select query.
+func (app *BaseApp) PolicyAcceptanceQuery() *dbx.SelectQuery {
+	return app.ModelQuery(&PolicyAcceptance{})
+}
+
+// FindLatestPolicy returns the latest version of the named policy document.
+func (app *BaseApp) FindLatestPolicy(name string) (*Policy, error) {
+	model := &Policy{}
+
+	err := app.PolicyQuery().
+		AndWhere(dbx.HashExp{"name": name}).
+		OrderBy("version DESC").
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindPolicy returns the specified version of the named policy document.
+func (app *BaseApp) FindPolicy(name string, version int) (*Policy, error) {
+	model := &Policy{}
+
+	err := app.PolicyQuery().
+		AndWhere(dbx.HashExp{"name": name, "version": version}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// PublishPolicy saves the provided policy as a new version of its named document
+// (the version number is incremented automatically, starting from 1).
+//
+// The new version doesn't affect the existing acceptances, so the API rules
+// that compare the accepted version (eg. @request.auth.acceptedTerms >= 2)
+// start failing until the auth record accepts the new version.
+func (app *BaseApp) PublishPolicy(policy *Policy) error {
+	return app.RunInTransaction(func(txApp App) error {
+		var latestVersion int
+
+		err := txApp.PolicyQuery().
+			Select("COALESCE(MAX([[version]]), 0)").
+			AndWhere(dbx.HashExp{"name": policy.Name}).
+			Row(&latestVersion)
+		if err != nil {
+			return err
+		}
+
+		policy.MarkAsNew()
+		policy.Id = ""
+		policy.Version = latestVersion + 1
+
+		return txApp.Save(policy)
+	})
+}
+
+// AcceptPolicy stores the auth record acceptance of the specified policy version
+// (if version is <= 0, the latest policy version is accepted).
+//
+// The optional ip argument is stored as part of the acceptance for audit purposes.
+//
+// Accepting an already accepted version returns its existing acceptance.
+func (app *BaseApp) AcceptPolicy(authRecord *Record, name string, version int, ip string) (*PolicyAcceptance, error) {
+	if !authRecord.Collection().IsAuth() {
+		return nil, errors.New("the record must be from an auth collection")
+	}
+
+	var policy *Policy
+	var err error
+	if version <= 0 {
+		policy, err = app.FindLatestPolicy(name)
+	} else {
+		policy, err = app.FindPolicy(name, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("missing policy %q version: %w", name, err)
+	}
+
+	acceptance := &PolicyAcceptance{}
+
+	err = app.PolicyAcceptanceQuery().
+		AndWhere(dbx.HashExp{
+			"collectionRef": authRecord.Collection().Id,
+			"recordRef":     authRecord.Id,
+			"policy":        policy.Name,
+			"version":       policy.Version,
+		}).
+		Limit(1).
+		One(acceptance)
+	if err == nil {
+		return acceptance, nil // already accepted
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	acceptance = &PolicyAcceptance{
+		CollectionRef: authRecord.Collection().Id,
+		RecordRef:     authRecord.Id,
+		Policy:        policy.Name,
+		Version:       policy.Version,
+		IP:            ip,
+	}
+
+	if err := app.Save(acceptance); err != nil {
+		return nil, err
+	}
+
+	return acceptance, nil
+}
+
+// FindAcceptedPolicyVersion returns the highest accepted by the auth record
+// version of the named policy (0 if no version was accepted).
+func (app *BaseApp) FindAcceptedPolicyVersion(authRecord *Record, name string) (int, error) {
+	var version int
+
+	err := app.PolicyAcceptanceQuery().
+		Select("COALESCE(MAX([[version]]), 0)").
+		AndWhere(dbx.HashExp{
+			"collectionRef": authRecord.Collection().Id,
+			"recordRef":     authRecord.Id,
+			"policy":        name,
+		}).
+		Row(&version)
+
+	return version, err
+}
+
+// acceptedPolicyVersionExpr returns a filter resolver result with a subquery
+// that selects the highest accepted by the auth record version of the named policy.
+func acceptedPolicyVersionExpr(authRecord *Record, name string) *search.ResolverResult {
+	suffix := security.PseudorandomString(6)
+
+	collectionParam := "pbPolicyCollection" + suffix
+	recordParam := "pbPolicyRecord" + suffix
+	nameParam := "pbPolicyName" + suffix
+
+	return &search.ResolverResult{
+		Identifier: "(SELECT COALESCE(MAX([[__pa__.version]]), 0) FROM {{" + PolicyAcceptancesTableName + "}} {{__pa__}} " +
+			"WHERE [[__pa__.collectionRef]] = {:" + collectionParam + "} " +
+			"AND [[__pa__.recordRef]] = {:" + recordParam + "} " +
+			"AND [[__pa__.policy]] = {:" + nameParam + "})",
+		Params: dbx.Params{
+			collectionParam: authRecord.Collection().Id,
+			recordParam:     authRecord.Id,
+			nameParam:       name,
+		},
+	}
+}
+
+func (app *BaseApp) registerPolicyHooks() {
+	app.OnRecordDeleteExecute().BindFunc(func(e *RecordEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		if !e.Record.Collection().IsAuth() {
+			return nil
+		}
+
+		_, err := e.App.DB().Delete(PolicyAcceptancesTableName, dbx.HashExp{
+			"collectionRef": e.Record.Collection().Id,
+			"recordRef":     e.Record.Id,
+		}).Execute()
+
+		return err
+	})
+
+	app.OnCollectionDeleteExecute().BindFunc(func(e *CollectionEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		if !e.Collection.IsAuth() {
+			return nil
+		}
+
+		_, err := e.App.DB().Delete(PolicyAcceptancesTableName, dbx.HashExp{"collectionRef": e.Collection.Id}).Execute()
+
+		return err
+	})
+}
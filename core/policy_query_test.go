@@ -0,0 +1,208 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestPublishPolicy(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.FindLatestPolicy(core.PolicyTerms); err == nil {
+		t.Fatal("Expected missing policy error")
+	}
+
+	// invalid
+	if err := app.PublishPolicy(&core.Policy{Name: "invalid name", Content: "test"}); err == nil {
+		t.Fatal("Expected invalid name error")
+	}
+	if err := app.PublishPolicy(&core.Policy{Name: core.PolicyTerms}); err == nil {
+		t.Fatal("Expected missing content or url error")
+	}
+
+	for i := 1; i <= 3; i++ {
+		policy := &core.Policy{Name: core.PolicyTerms, Title: "Terms", Content: "test"}
+		if err := app.PublishPolicy(policy); err != nil {
+			t.Fatal(err)
+		}
+		if policy.Version != i {
+			t.Fatalf("Expected version %d, got %d", i, policy.Version)
+		}
+	}
+
+	// another policy
+	privacy := &core.Policy{Name: "privacy", URL: "https://example.com/privacy"}
+	if err := app.PublishPolicy(privacy); err != nil {
+		t.Fatal(err)
+	}
+	if privacy.Version != 1 {
+		t.Fatalf("Expected privacy version 1, got %d", privacy.Version)
+	}
+
+	latest, err := app.FindLatestPolicy(core.PolicyTerms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Version != 3 {
+		t.Fatalf("Expected latest version 3, got %d", latest.Version)
+	}
+
+	if _, err := app.FindPolicy(core.PolicyTerms, 2); err != nil {
+		t.Fatalf("Expected version 2 to exist, got %v", err)
+	}
+	if _, err := app.FindPolicy(core.PolicyTerms, 4); err == nil {
+		t.Fatal("Expected missing version 4 error")
+	}
+}
+
+func TestAcceptPolicy(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.AcceptPolicy(user, core.PolicyTerms, 0, ""); err == nil {
+		t.Fatal("Expected missing policy error")
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := app.PublishPolicy(&core.Policy{Name: core.PolicyTerms, Content: "test"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	demo1Record, err := app.FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.AcceptPolicy(demo1Record, core.PolicyTerms, 0, ""); err == nil {
+		t.Fatal("Expected non-auth record error")
+	}
+
+	if _, err := app.AcceptPolicy(user, core.PolicyTerms, 3, ""); err == nil {
+		t.Fatal("Expected missing version error")
+	}
+
+	version, err := app.FindAcceptedPolicyVersion(user, core.PolicyTerms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 0 {
+		t.Fatalf("Expected no accepted version, got %d", version)
+	}
+
+	// specific version
+	acceptance, err := app.AcceptPolicy(user, core.PolicyTerms, 1, "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acceptance.Version != 1 || acceptance.IP != "127.0.0.1" || acceptance.RecordRef != user.Id {
+		t.Fatalf("Unexpected acceptance %#v", acceptance)
+	}
+
+	// latest version
+	acceptance, err = app.AcceptPolicy(user, core.PolicyTerms, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acceptance.Version != 2 {
+		t.Fatalf("Expected the latest version 2 to be accepted, got %d", acceptance.Version)
+	}
+
+	// already accepted
+	again, err := app.AcceptPolicy(user, core.PolicyTerms, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Id != acceptance.Id {
+		t.Fatalf("Expected the existing acceptance %q, got %q", acceptance.Id, again.Id)
+	}
+
+	version, err = app.FindAcceptedPolicyVersion(user, core.PolicyTerms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 2 {
+		t.Fatalf("Expected accepted version 2, got %d", version)
+	}
+
+	// acceptances cleanup on auth record delete
+	if err := app.Delete(user); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	err = app.PolicyAcceptanceQuery().Select("count(*)").Row(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 {
+		t.Fatalf("Expected the acceptances to be deleted, got %d", total)
+	}
+}
+
+func TestAcceptedPolicyRuleMacros(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	for _, name := range []string{core.PolicyTerms, core.PolicyTerms, "privacy"} {
+		if err := app.PublishPolicy(&core.Policy{Name: name, Content: "test"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(rule string, expected bool) {
+		t.Helper()
+
+		ok, err := app.CanAccessRecord(record, &core.RequestInfo{Auth: user}, &rule)
+		if err != nil {
+			t.Fatalf("[%s] %v", rule, err)
+		}
+		if ok != expected {
+			t.Fatalf("[%s] Expected %v, got %v", rule, expected, ok)
+		}
+	}
+
+	check("@request.auth.acceptedTerms >= 1", false)
+	check("@request.auth.acceptedTerms = 0", true)
+
+	if _, err := app.AcceptPolicy(user, core.PolicyTerms, 1, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	check("@request.auth.acceptedTerms >= 1", true)
+	check("@request.auth.acceptedTerms >= 2", false)
+	check("@request.auth.acceptedPolicies.privacy >= 1", false)
+	check("@request.auth.acceptedTerms >= 1 && @request.auth.acceptedPolicies.privacy = 0", true)
+
+	if _, err := app.AcceptPolicy(user, core.PolicyTerms, 2, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.AcceptPolicy(user, "privacy", 0, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	check("@request.auth.acceptedTerms >= 2 && @request.auth.acceptedPolicies.privacy >= 1", true)
+}
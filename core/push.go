@@ -0,0 +1,289 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/push"
+	"github.com/pocketbase/pocketbase/tools/routine"
+)
+
+// PushNotifications defines the app push notifications service.
+//
+// Use [App.Notifications()] to access it.
+type PushNotifications struct {
+	app App
+}
+
+// Notifications returns the app push notifications service
+// that could be used to send push messages to the registered
+// devices of auth records.
+//
+// Example:
+//
+//	err := app.Notifications().Send(&push.Message{
+//		Title: "Hello",
+//		Body:  "World",
+//	}, user1, user2)
+func (app *BaseApp) Notifications() *PushNotifications {
+	return &PushNotifications{app: app}
+}
+
+// NewDriver creates a new push driver for the specified platform
+// based on the current app settings.
+//
+// Returns an error if the platform is not supported or not enabled.
+func (n *PushNotifications) NewDriver(platform string) (push.Driver, error) {
+	settings := n.app.Settings().Push
+
+	switch platform {
+	case push.PlatformFCM:
+		if !settings.FCM.Enabled {
+			break
+		}
+		return push.NewFCMClientFromServiceAccount([]byte(settings.FCM.ServiceAccount))
+	case push.PlatformAPNs:
+		if !settings.APNs.Enabled {
+			break
+		}
+		return &push.APNsClient{
+			TeamId:     settings.APNs.TeamId,
+			KeyId:      settings.APNs.KeyId,
+			PrivateKey: settings.APNs.PrivateKey,
+			Topic:      settings.APNs.Topic,
+			Production: settings.APNs.Production,
+		}, nil
+	case push.PlatformWebPush:
+		if !settings.WebPush.Enabled {
+			break
+		}
+		return &push.WebPushClient{
+			PublicKey:  settings.WebPush.PublicKey,
+			PrivateKey: settings.WebPush.PrivateKey,
+			Subject:    settings.WebPush.Subject,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported push platform %q", platform)
+	}
+
+	return nil, fmt.Errorf("the push platform %q is not enabled", platform)
+}
+
+// Send sends the provided message to all registered devices of the specified auth records.
+//
+// Devices that are reported by the push service as no longer valid are automatically deleted.
+//
+// Returns a combined error with the failed sends.
+func (n *PushNotifications) Send(message *push.Message, authRecords ...*Record) error {
+	var devices []*PushDevice
+
+	for _, record := range authRecords {
+		recordDevices, err := n.app.FindAllPushDevicesByRecord(record)
+		if err != nil {
+			return err
+		}
+
+		devices = append(devices, recordDevices...)
+	}
+
+	return n.SendToDevices(message, devices...)
+}
+
+// SendToDevices sends the provided message to each of the specified devices.
+//
+// Devices that are reported by the push service as no longer valid are automatically deleted.
+//
+// Returns a combined error with the failed sends.
+func (n *PushNotifications) SendToDevices(message *push.Message, devices ...*PushDevice) error {
+	// reuse the drivers for the same platform
+	drivers := map[string]push.Driver{}
+
+	var errs []error
+
+	for _, device := range devices {
+		driver, ok := drivers[device.Platform()]
+		if !ok {
+			var err error
+			driver, err = n.NewDriver(device.Platform())
+			if err != nil {
+				// still trigger the send hook to allow registering a custom driver
+				driver = &disabledPushDriver{err: err}
+			}
+			drivers[device.Platform()] = driver
+		}
+
+		event := new(PushSendEvent)
+		event.App = n.app
+		event.Driver = driver
+		event.Device = device
+		event.Message = message
+
+		err := n.app.OnPushSend().Trigger(event, func(e *PushSendEvent) error {
+			return e.Driver.Send(context.Background(), e.Device.Device(), e.Message)
+		})
+		if err == nil {
+			continue
+		}
+
+		if errors.Is(err, push.ErrInvalidDevice) {
+			n.app.Logger().Debug(
+				"Deleting invalid push device",
+				"error", err,
+				"deviceId", device.Id,
+				"platform", device.Platform(),
+			)
+
+			if deleteErr := n.app.Delete(device); deleteErr != nil {
+				errs = append(errs, deleteErr)
+			}
+
+			continue
+		}
+
+		errs = append(errs, fmt.Errorf("failed to send push notification to device %q: %w", device.Id, err))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// disabledPushDriver is a placeholder driver for the not enabled push platforms.
+type disabledPushDriver struct {
+	err error
+}
+
+// Send implements [push.Driver] interface.
+func (d *disabledPushDriver) Send(ctx context.Context, device *push.Device, message *push.Message) error {
+	return d.err
+}
+
+// -------------------------------------------------------------------
+
+func (app *BaseApp) registerPushTriggerHooks() {
+	triggerFunc := func(event string) func(e *RecordEvent) error {
+		return func(e *RecordEvent) error {
+			triggers := e.App.Settings().Push.Triggers
+
+			for _, trigger := range triggers {
+				if trigger.Event != event ||
+					(trigger.Collection != e.Record.Collection().Name && trigger.Collection != e.Record.Collection().Id) {
+					continue
+				}
+
+				app.sendPushTrigger(e.App, trigger, e.Record)
+			}
+
+			return e.Next()
+		}
+	}
+
+	app.OnRecordAfterCreateSuccess().Bind(&hook.Handler[*RecordEvent]{
+		Id:   "__pbPushTriggersCreate__",
+		Func: triggerFunc(PushTriggerEventCreate),
+	})
+
+	app.OnRecordAfterUpdateSuccess().Bind(&hook.Handler[*RecordEvent]{
+		Id:   "__pbPushTriggersUpdate__",
+		Func: triggerFunc(PushTriggerEventUpdate),
+	})
+
+	app.OnRecordAfterDeleteSuccess().Bind(&hook.Handler[*RecordEvent]{
+		Id:   "__pbPushTriggersDelete__",
+		Func: triggerFunc(PushTriggerEventDelete),
+	})
+}
+
+// sendPushTrigger resolves the trigger recipients and sends the trigger message in the background.
+func (app *BaseApp) sendPushTrigger(txApp App, trigger PushTrigger, record *Record) {
+	recipients, err := resolvePushTriggerRecipients(txApp, trigger, record)
+	if err != nil {
+		app.Logger().Warn(
+			"Failed to resolve the push trigger recipients",
+			"error", err,
+			"collectionName", record.Collection().Name,
+			"recordId", record.Id,
+			"event", trigger.Event,
+		)
+		return
+	}
+
+	if len(recipients) == 0 {
+		return
+	}
+
+	message := &push.Message{
+		Title: resolvePushTriggerTemplate(app, trigger.Title, record),
+		Body:  resolvePushTriggerTemplate(app, trigger.Body, record),
+		Data: map[string]string{
+			"event":          trigger.Event,
+			"collectionId":   record.Collection().Id,
+			"collectionName": record.Collection().Name,
+			"recordId":       record.Id,
+		},
+	}
+
+	// note: use the main app instance since the send is performed
+	// after the record event transaction (if any) completes
+	routine.FireAndForget(func() {
+		if err := app.Notifications().Send(message, recipients...); err != nil {
+			app.Logger().Warn(
+				"Failed to send push trigger notification",
+				"error", err,
+				"collectionName", record.Collection().Name,
+				"recordId", record.Id,
+				"event", trigger.Event,
+			)
+		}
+	})
+}
+
+func resolvePushTriggerRecipients(app App, trigger PushTrigger, record *Record) ([]*Record, error) {
+	if trigger.RecipientField == "" {
+		if !record.Collection().IsAuth() {
+			return nil, errors.New("the recipient field must be set for non-auth collections")
+		}
+
+		return []*Record{record}, nil
+	}
+
+	field, ok := record.Collection().Fields.GetByName(trigger.RecipientField).(*RelationField)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a relation field", trigger.RecipientField)
+	}
+
+	ids := record.GetStringSlice(field.Name)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	relCollection, err := app.FindCachedCollectionByNameOrId(field.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !relCollection.IsAuth() {
+		return nil, fmt.Errorf("%q is not a relation to an auth collection", trigger.RecipientField)
+	}
+
+	return app.FindRecordsByIds(relCollection, ids)
+}
+
+func resolvePushTriggerTemplate(app App, template string, record *Record) string {
+	template = strings.ReplaceAll(template, EmailPlaceholderAppName, app.Settings().Meta.AppName)
+
+	for _, field := range record.Collection().Fields {
+		if field.GetHidden() {
+			continue
+		}
+
+		template = strings.ReplaceAll(template, "{RECORD:"+field.GetName()+"}", record.GetString(field.GetName()))
+	}
+
+	return template
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pocketbase/pocketbase/tools/push"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const CollectionNamePushDevices = "_pushDevices"
+
+var (
+	_ Model        = (*PushDevice)(nil)
+	_ PreValidator = (*PushDevice)(nil)
+	_ RecordProxy  = (*PushDevice)(nil)
+)
+
+// PushDevice defines a Record proxy for working with the pushDevices collection.
+type PushDevice struct {
+	*Record
+}
+
+// NewPushDevice instantiates and returns a new blank *PushDevice model.
+//
+// Example usage:
+//
+//	device := core.NewPushDevice(app)
+//	device.SetRecordRef(user.Id)
+//	device.SetCollectionRef(user.Collection().Id)
+//	device.SetPlatform(push.PlatformFCM)
+//	device.SetToken("...")
+//	app.Save(device)
+func NewPushDevice(app App) *PushDevice {
+	m := &PushDevice{}
+
+	c, err := app.FindCachedCollectionByNameOrId(CollectionNamePushDevices)
+	if err != nil {
+		// this is just to make tests easier since pushDevices is a system collection and it is expected to be always accessible
+		// (note: the loaded record is further checked on PushDevice.PreValidate())
+		c = NewBaseCollection("@___invalid___")
+	}
+
+	m.Record = NewRecord(c)
+
+	return m
+}
+
+// PreValidate implements the [PreValidator] interface and checks
+// whether the proxy is properly loaded.
+func (m *PushDevice) PreValidate(ctx context.Context, app App) error {
+	if m.Record == nil || m.Record.Collection().Name != CollectionNamePushDevices {
+		return errors.New("missing or invalid PushDevice ProxyRecord")
+	}
+
+	return nil
+}
+
+// ProxyRecord returns the proxied Record model.
+func (m *PushDevice) ProxyRecord() *Record {
+	return m.Record
+}
+
+// SetProxyRecord loads the specified record model into the current proxy.
+func (m *PushDevice) SetProxyRecord(record *Record) {
+	m.Record = record
+}
+
+// CollectionRef returns the "collectionRef" field value.
+func (m *PushDevice) CollectionRef() string {
+	return m.GetString("collectionRef")
+}
+
+// SetCollectionRef updates the "collectionRef" record field value.
+func (m *PushDevice) SetCollectionRef(collectionId string) {
+	m.Set("collectionRef", collectionId)
+}
+
+// RecordRef returns the "recordRef" record field value.
+func (m *PushDevice) RecordRef() string {
+	return m.GetString("recordRef")
+}
+
+// SetRecordRef updates the "recordRef" record field value.
+func (m *PushDevice) SetRecordRef(recordId string) {
+	m.Set("recordRef", recordId)
+}
+
+// Platform returns the "platform" record field value ("fcm", "apns" or "webpush").
+func (m *PushDevice) Platform() string {
+	return m.GetString("platform")
+}
+
+// SetPlatform updates the "platform" record field value.
+func (m *PushDevice) SetPlatform(platform string) {
+	m.Set("platform", platform)
+}
+
+// Token returns the "token" record field value
+// (the device token or the WebPush subscription endpoint).
+func (m *PushDevice) Token() string {
+	return m.GetString("token")
+}
+
+// SetToken updates the "token" record field value.
+func (m *PushDevice) SetToken(token string) {
+	m.Set("token", token)
+}
+
+// P256dh returns the "p256dh" record field value (WebPush only).
+func (m *PushDevice) P256dh() string {
+	return m.GetString("p256dh")
+}
+
+// SetP256dh updates the "p256dh" record field value.
+func (m *PushDevice) SetP256dh(key string) {
+	m.Set("p256dh", key)
+}
+
+// Auth returns the "auth" record field value (WebPush only).
+func (m *PushDevice) Auth() string {
+	return m.GetString("auth")
+}
+
+// SetAuth updates the "auth" record field value.
+func (m *PushDevice) SetAuth(secret string) {
+	m.Set("auth", secret)
+}
+
+// Created returns the "created" record field value.
+func (m *PushDevice) Created() types.DateTime {
+	return m.GetDateTime("created")
+}
+
+// Updated returns the "updated" record field value.
+func (m *PushDevice) Updated() types.DateTime {
+	return m.GetDateTime("updated")
+}
+
+// Device returns the current model as a [push.Device] target.
+func (m *PushDevice) Device() *push.Device {
+	return &push.Device{
+		Token:  m.Token(),
+		P256dh: m.P256dh(),
+		Auth:   m.Auth(),
+	}
+}
+
+func (app *BaseApp) registerPushDeviceHooks() {
+	recordRefHooks[*PushDevice](app, CollectionNamePushDevices, CollectionTypeAuth)
+}
@@ -0,0 +1,277 @@
+package core_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/push"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestNewPushDevice(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	device := core.NewPushDevice(app)
+
+	if device.Collection().Name != core.CollectionNamePushDevices {
+		t.Fatalf("Expected record with %q collection, got %q", core.CollectionNamePushDevices, device.Collection().Name)
+	}
+}
+
+func TestPushDeviceProxyRecord(t *testing.T) {
+	t.Parallel()
+
+	record := core.NewRecord(core.NewBaseCollection("test"))
+	record.Id = "test_id"
+
+	device := core.PushDevice{}
+	device.SetProxyRecord(record)
+
+	if device.ProxyRecord() == nil || device.ProxyRecord().Id != record.Id {
+		t.Fatalf("Expected proxy record with id %q, got %v", record.Id, device.ProxyRecord())
+	}
+}
+
+func TestPushDeviceStringFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	device := core.NewPushDevice(app)
+
+	fields := []struct {
+		name   string
+		setter func(string)
+		getter func() string
+	}{
+		{"collectionRef", device.SetCollectionRef, device.CollectionRef},
+		{"recordRef", device.SetRecordRef, device.RecordRef},
+		{"platform", device.SetPlatform, device.Platform},
+		{"token", device.SetToken, device.Token},
+		{"p256dh", device.SetP256dh, device.P256dh},
+		{"auth", device.SetAuth, device.Auth},
+	}
+
+	for _, f := range fields {
+		for i, testValue := range []string{push.PlatformFCM, "test2", ""} {
+			t.Run(fmt.Sprintf("%s_%d_%q", f.name, i, testValue), func(t *testing.T) {
+				f.setter(testValue)
+
+				if v := f.getter(); v != testValue {
+					t.Fatalf("Expected getter %q, got %q", testValue, v)
+				}
+
+				if v := device.GetString(f.name); v != testValue {
+					t.Fatalf("Expected field value %q, got %q", testValue, v)
+				}
+			})
+		}
+	}
+}
+
+func TestPushDeviceCreatedAndUpdated(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	device := core.NewPushDevice(app)
+
+	if v := device.Created().String(); v != "" {
+		t.Fatalf("Expected empty created, got %q", v)
+	}
+
+	if v := device.Updated().String(); v != "" {
+		t.Fatalf("Expected empty updated, got %q", v)
+	}
+
+	now := types.NowDateTime()
+	device.SetRaw("created", now)
+	device.SetRaw("updated", now)
+
+	if v := device.Created().String(); v != now.String() {
+		t.Fatalf("Expected %q created, got %q", now.String(), v)
+	}
+
+	if v := device.Updated().String(); v != now.String() {
+		t.Fatalf("Expected %q updated, got %q", now.String(), v)
+	}
+}
+
+func TestPushDeviceDevice(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	device := core.NewPushDevice(app)
+	device.SetToken("test_token")
+	device.SetP256dh("test_p256dh")
+	device.SetAuth("test_auth")
+
+	target := device.Device()
+
+	if target.Token != "test_token" || target.P256dh != "test_p256dh" || target.Auth != "test_auth" {
+		t.Fatalf("Unexpected push device target %#v", target)
+	}
+}
+
+func TestPushDevicePreValidate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	devicesCol, err := app.FindCollectionByNameOrId(core.CollectionNamePushDevices)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("no proxy record", func(t *testing.T) {
+		device := &core.PushDevice{}
+
+		if err := app.Validate(device); err == nil {
+			t.Fatal("Expected collection validation error")
+		}
+	})
+
+	t.Run("non-PushDevice collection", func(t *testing.T) {
+		device := &core.PushDevice{}
+		device.SetProxyRecord(core.NewRecord(core.NewBaseCollection("invalid")))
+		device.SetRecordRef(user.Id)
+		device.SetCollectionRef(user.Collection().Id)
+		device.SetPlatform(push.PlatformFCM)
+		device.SetToken("abc")
+
+		if err := app.Validate(device); err == nil {
+			t.Fatal("Expected collection validation error")
+		}
+	})
+
+	t.Run("PushDevice collection", func(t *testing.T) {
+		device := &core.PushDevice{}
+		device.SetProxyRecord(core.NewRecord(devicesCol))
+		device.SetRecordRef(user.Id)
+		device.SetCollectionRef(user.Collection().Id)
+		device.SetPlatform(push.PlatformFCM)
+		device.SetToken("abc")
+
+		if err := app.Validate(device); err != nil {
+			t.Fatalf("Expected nil validation error, got %v", err)
+		}
+	})
+}
+
+func TestPushDeviceValidateHook(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	demo1, err := app.FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		device         func() *core.PushDevice
+		expectedErrors []string
+	}{
+		{
+			"empty",
+			func() *core.PushDevice {
+				return core.NewPushDevice(app)
+			},
+			[]string{"collectionRef", "recordRef", "platform", "token"},
+		},
+		{
+			"non-auth collection",
+			func() *core.PushDevice {
+				device := core.NewPushDevice(app)
+				device.SetCollectionRef(demo1.Collection().Id)
+				device.SetRecordRef(demo1.Id)
+				device.SetPlatform(push.PlatformFCM)
+				device.SetToken("abc")
+				return device
+			},
+			[]string{"collectionRef"},
+		},
+		{
+			"invalid platform",
+			func() *core.PushDevice {
+				device := core.NewPushDevice(app)
+				device.SetCollectionRef(user.Collection().Id)
+				device.SetRecordRef(user.Id)
+				device.SetPlatform("invalid")
+				device.SetToken("abc")
+				return device
+			},
+			[]string{"platform"},
+		},
+		{
+			"valid data",
+			func() *core.PushDevice {
+				device := core.NewPushDevice(app)
+				device.SetCollectionRef(user.Collection().Id)
+				device.SetRecordRef(user.Id)
+				device.SetPlatform(push.PlatformAPNs)
+				device.SetToken("abc")
+				return device
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			errs := app.Validate(s.device())
+			tests.TestValidationErrors(t, errs, s.expectedErrors)
+		})
+	}
+}
+
+func TestPushDeviceRecordDeleteCascade(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	device := core.NewPushDevice(app)
+	device.SetCollectionRef(user.Collection().Id)
+	device.SetRecordRef(user.Id)
+	device.SetPlatform(push.PlatformFCM)
+	device.SetToken("abc")
+	if err := app.Save(device); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Delete(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.FindPushDeviceById(device.Id); err == nil {
+		t.Fatal("Expected the push device to be deleted together with its auth record")
+	}
+}
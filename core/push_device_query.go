@@ -0,0 +1,83 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/pocketbase/dbx"
+)
+
+// FindAllPushDevicesByRecord returns all PushDevice models linked to the provided auth record (in DESC order).
+func (app *BaseApp) FindAllPushDevicesByRecord(authRecord *Record) ([]*PushDevice, error) {
+	result := []*PushDevice{}
+
+	err := app.RecordQuery(CollectionNamePushDevices).
+		AndWhere(dbx.HashExp{
+			"collectionRef": authRecord.Collection().Id,
+			"recordRef":     authRecord.Id,
+		}).
+		OrderBy("created DESC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindPushDeviceById returns a single PushDevice model by its id.
+func (app *BaseApp) FindPushDeviceById(id string) (*PushDevice, error) {
+	result := &PushDevice{}
+
+	err := app.RecordQuery(CollectionNamePushDevices).
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindPushDeviceByToken returns a single PushDevice model by its platform and token.
+func (app *BaseApp) FindPushDeviceByToken(platform string, token string) (*PushDevice, error) {
+	result := &PushDevice{}
+
+	err := app.RecordQuery(CollectionNamePushDevices).
+		AndWhere(dbx.HashExp{
+			"platform": platform,
+			"token":    token,
+		}).
+		Limit(1).
+		One(result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteAllPushDevicesByRecord deletes all PushDevice models associated with the provided record.
+//
+// Returns a combined error with the failed deletes.
+func (app *BaseApp) DeleteAllPushDevicesByRecord(authRecord *Record) error {
+	models, err := app.FindAllPushDevicesByRecord(authRecord)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, m := range models {
+		if err := app.Delete(m); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/push"
+)
+
+func createTestPushDevice(t testing.TB, app core.App, authRecord *core.Record, platform string, token string) *core.PushDevice {
+	device := core.NewPushDevice(app)
+	device.SetCollectionRef(authRecord.Collection().Id)
+	device.SetRecordRef(authRecord.Id)
+	device.SetPlatform(platform)
+	device.SetToken(token)
+
+	if err := app.Save(device); err != nil {
+		t.Fatal(err)
+	}
+
+	return device
+}
+
+func TestFindAllPushDevicesByRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.FindAuthRecordByEmail("users", "test2@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client1, err := app.FindAuthRecordByEmail("clients", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d1 := createTestPushDevice(t, app, user1, push.PlatformFCM, "token1")
+	d2 := createTestPushDevice(t, app, user1, push.PlatformAPNs, "token2")
+	d3 := createTestPushDevice(t, app, client1, push.PlatformFCM, "token3")
+
+	scenarios := []struct {
+		record   *core.Record
+		expected []string
+	}{
+		{user1, []string{d1.Id, d2.Id}},
+		{user2, nil},
+		{client1, []string{d3.Id}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.record.Collection().Name+"_"+s.record.Id, func(t *testing.T) {
+			result, err := app.FindAllPushDevicesByRecord(s.record)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(result) != len(s.expected) {
+				t.Fatalf("Expected total devices %d, got %d", len(s.expected), len(result))
+			}
+
+			for _, id := range s.expected {
+				var exists bool
+				for _, device := range result {
+					if device.Id == id {
+						exists = true
+						break
+					}
+				}
+				if !exists {
+					t.Errorf("Missing device %q", id)
+				}
+			}
+		})
+	}
+}
+
+func TestFindPushDeviceById(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	device := createTestPushDevice(t, app, user, push.PlatformFCM, "token1")
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{device.Id, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.id, func(t *testing.T) {
+			result, err := app.FindPushDeviceById(s.id)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if result.Id != s.id {
+				t.Fatalf("Expected record with id %q, got %q", s.id, result.Id)
+			}
+		})
+	}
+}
+
+func TestFindPushDeviceByToken(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	device := createTestPushDevice(t, app, user, push.PlatformFCM, "token1")
+
+	scenarios := []struct {
+		name        string
+		platform    string
+		token       string
+		expectError bool
+	}{
+		{"empty", "", "", true},
+		{"missing token", push.PlatformFCM, "missing", true},
+		{"different platform", push.PlatformAPNs, "token1", true},
+		{"existing device", push.PlatformFCM, "token1", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := app.FindPushDeviceByToken(s.platform, s.token)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if result.Id != device.Id {
+				t.Fatalf("Expected record with id %q, got %q", device.Id, result.Id)
+			}
+		})
+	}
+}
+
+func TestDeleteAllPushDevicesByRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.FindAuthRecordByEmail("users", "test2@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createTestPushDevice(t, app, user1, push.PlatformFCM, "token1")
+	createTestPushDevice(t, app, user1, push.PlatformAPNs, "token2")
+	d3 := createTestPushDevice(t, app, user2, push.PlatformFCM, "token3")
+
+	if err := app.DeleteAllPushDevicesByRecord(user1); err != nil {
+		t.Fatal(err)
+	}
+
+	devices, err := app.FindAllPushDevicesByRecord(user1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 0 {
+		t.Fatalf("Expected all user1 devices to be deleted, found %d", len(devices))
+	}
+
+	if _, err := app.FindPushDeviceById(d3.Id); err != nil {
+		t.Fatalf("Expected user2 device to remain, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/push"
+)
+
+func TestPushNotificationsNewDriver(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Push.APNs.Enabled = true
+	app.Settings().Push.WebPush.Enabled = true
+
+	scenarios := []struct {
+		platform    string
+		expectError bool
+	}{
+		{"", true},
+		{"unknown", true},
+		{push.PlatformFCM, true}, // disabled
+		{push.PlatformAPNs, false},
+		{push.PlatformWebPush, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.platform, func(t *testing.T) {
+			driver, err := app.Notifications().NewDriver(s.platform)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !hasErr && driver == nil {
+				t.Fatal("Expected non-nil driver")
+			}
+		})
+	}
+}
+
+func TestPushNotificationsSend(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.FindAuthRecordByEmail("users", "test2@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createTestPushDevice(t, app, user1, push.PlatformFCM, "token1")
+	createTestPushDevice(t, app, user1, push.PlatformAPNs, "token2")
+	createTestPushDevice(t, app, user2, push.PlatformFCM, "token3")
+
+	message := &push.Message{Title: "test_title", Body: "test_body"}
+
+	if err := app.Notifications().Send(message, user1); err != nil {
+		t.Fatal(err)
+	}
+
+	if total := app.TestPushDriver.TotalSend(); total != 2 {
+		t.Fatalf("Expected %d sent notifications, got %d", 2, total)
+	}
+
+	for _, d := range app.TestPushDriver.Deliveries() {
+		if d.Device.Token != "token1" && d.Device.Token != "token2" {
+			t.Fatalf("Unexpected delivery device %q", d.Device.Token)
+		}
+
+		if d.Message != message {
+			t.Fatalf("Expected message %v, got %v", message, d.Message)
+		}
+	}
+}
+
+func TestPushNotificationsSendInvalidDevice(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := createTestPushDevice(t, app, user, push.PlatformFCM, "valid")
+	invalid := createTestPushDevice(t, app, user, push.PlatformFCM, "invalid")
+
+	app.OnPushSend().BindFunc(func(e *core.PushSendEvent) error {
+		if e.Device.Token() == "invalid" {
+			return push.ErrInvalidDevice
+		}
+		return e.Next()
+	})
+
+	if err := app.Notifications().Send(&push.Message{Title: "test"}, user); err != nil {
+		t.Fatalf("Expected the invalid device to be silently removed, got %v", err)
+	}
+
+	if _, err := app.FindPushDeviceById(invalid.Id); err == nil {
+		t.Fatal("Expected the invalid device to be deleted")
+	}
+
+	if _, err := app.FindPushDeviceById(valid.Id); err != nil {
+		t.Fatalf("Expected the valid device to remain, got %v", err)
+	}
+}
+
+func TestPushNotificationsSendError(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	device := createTestPushDevice(t, app, user, push.PlatformFCM, "token1")
+
+	testErr := errors.New("test")
+
+	app.OnPushSend().BindFunc(func(e *core.PushSendEvent) error {
+		return testErr
+	})
+
+	err = app.Notifications().Send(&push.Message{Title: "test"}, user)
+	if !errors.Is(err, testErr) {
+		t.Fatalf("Expected test error, got %v", err)
+	}
+
+	if _, err := app.FindPushDeviceById(device.Id); err != nil {
+		t.Fatalf("Expected the device to remain, got %v", err)
+	}
+}
+
+func TestPushTriggers(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createTestPushDevice(t, app, user, push.PlatformFCM, "token1")
+
+	app.Settings().Push.Triggers = []core.PushTrigger{
+		{
+			Collection: "users",
+			Event:      core.PushTriggerEventUpdate,
+			Title:      "{APP_NAME} update",
+			Body:       "Hello {RECORD:name}",
+		},
+		{
+			Collection: "users",
+			Event:      core.PushTriggerEventCreate,
+			Title:      "create",
+		},
+	}
+
+	user.Set("name", "test_name")
+	if err := app.Save(user); err != nil {
+		t.Fatal(err)
+	}
+
+	// the notification is sent in the background
+	for i := 0; i < 20 && app.TestPushDriver.TotalSend() == 0; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if total := app.TestPushDriver.TotalSend(); total != 1 {
+		t.Fatalf("Expected %d sent notification, got %d", 1, total)
+	}
+
+	message := app.TestPushDriver.LastDelivery().Message
+
+	expectedTitle := app.Settings().Meta.AppName + " update"
+	if message.Title != expectedTitle {
+		t.Fatalf("Expected title %q, got %q", expectedTitle, message.Title)
+	}
+
+	if message.Body != "Hello test_name" {
+		t.Fatalf("Expected body %q, got %q", "Hello test_name", message.Body)
+	}
+
+	if message.Data["event"] != core.PushTriggerEventUpdate || message.Data["recordId"] != user.Id {
+		t.Fatalf("Unexpected message data %v", message.Data)
+	}
+}
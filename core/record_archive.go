@@ -0,0 +1,393 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const ArchivedRecordsTableName = "_archivedRecords"
+
+// archiveBatchSize is the max number of records moved in a single archive/restore batch.
+const archiveBatchSize = 500
+
+// ArchivedRecord defines a single record stored in the aux archive table.
+type ArchivedRecord struct {
+	CollectionId string         `db:"collectionId" json:"collectionId"`
+	RecordId     string         `db:"recordId" json:"recordId"`
+	Archived     types.DateTime `db:"archived" json:"archived"`
+
+	// Data is the gzipped JSON object with the raw record table columns.
+	Data []byte `db:"data" json:"-"`
+}
+
+// Row decompresses and returns the raw archived record table row.
+func (m *ArchivedRecord) Row() (dbx.NullStringMap, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(m.Data))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	raw, err := io.ReadAll(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]*string{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	row := make(dbx.NullStringMap, len(values))
+	for k, v := range values {
+		if v == nil {
+			row[k] = sql.NullString{}
+		} else {
+			row[k] = sql.NullString{String: *v, Valid: true}
+		}
+	}
+
+	return row, nil
+}
+
+func compressArchiveRow(row dbx.NullStringMap) ([]byte, error) {
+	values := make(map[string]*string, len(row))
+	for k, v := range row {
+		if v.Valid {
+			values[k] = &v.String
+		} else {
+			values[k] = nil
+		}
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ArchiveRecords moves the collection records matching the specified
+// filter expression into the aux archive table and returns their total.
+//
+// The archived records are stored compressed and since they are removed
+// from the collection table they are transparently excluded from all
+// regular record queries and API requests (use [BaseApp.RestoreArchivedRecords]
+// to move them back).
+//
+// Note that the records are moved as raw table rows and NO record hooks,
+// cascade delete or files removal are performed (aka. the records files
+// and the relation references to them are preserved).
+//
+// Example:
+//
+//	total, err := app.ArchiveRecords("logs", "created < {:date}", dbx.Params{"date": "2024-01-01 00:00:00.000Z"})
+func (app *BaseApp) ArchiveRecords(collectionModelOrIdentifier any, filter string, params ...dbx.Params) (int, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return 0, err
+	}
+
+	if collection.IsView() {
+		return 0, errors.New("view collection records cannot be archived")
+	}
+
+	var total int
+
+	// the rows are moved without triggering the record hooks
+	defer clearCachedRecords(app, collection.Id)
+
+	for {
+		records, err := app.FindRecordsByFilter(collection, filter, "", archiveBatchSize, 0, params...)
+		if err != nil {
+			return total, err
+		}
+
+		if len(records) == 0 {
+			break
+		}
+
+		ids := make([]any, len(records))
+		for i, r := range records {
+			ids[i] = r.Id
+		}
+
+		moved, err := app.archiveRecordsBatch(collection, ids)
+		total += moved
+		if err != nil {
+			return total, err
+		}
+
+		if len(records) < archiveBatchSize {
+			break
+		}
+	}
+
+	return total, nil
+}
+
+// archiveRecordsBatch moves the collection records with the specified ids
+// into the aux archive table.
+//
+// Since the main and the aux databases can't share a transaction, the move is
+// idempotent - the rows are first upserted in the archive table (keyed by the
+// original record id) and then deleted from the collection table.
+// If the collection rows delete fails, the newly archived rows are removed
+// and in case of a crash in between, the next archive call simply replaces them.
+func (app *BaseApp) archiveRecordsBatch(collection *Collection, ids []any) (int, error) {
+	rows := []dbx.NullStringMap{}
+
+	err := app.ConcurrentDB().Select("*").
+		From(collection.Name).
+		Where(dbx.In("id", ids...)).
+		All(&rows)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	now := types.NowDateTime()
+
+	archivedIds := make([]any, len(rows))
+
+	err = app.AuxRunInTransaction(func(txApp App) error {
+		for i, row := range rows {
+			data, err := compressArchiveRow(row)
+			if err != nil {
+				return err
+			}
+
+			id := row[FieldNameId].String
+
+			_, err = txApp.AuxNonconcurrentDB().NewQuery(
+				"INSERT OR REPLACE INTO {{" + ArchivedRecordsTableName + "}} ([[collectionId]], [[recordId]], [[data]], [[archived]]) " +
+					"VALUES ({:collectionId}, {:recordId}, {:data}, {:archived})",
+			).Bind(dbx.Params{
+				"collectionId": collection.Id,
+				"recordId":     id,
+				"data":         data,
+				"archived":     now.String(),
+			}).Execute()
+			if err != nil {
+				return err
+			}
+
+			archivedIds[i] = id
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = app.NonconcurrentDB().Delete(collection.Name, dbx.In("id", archivedIds...)).Execute()
+	if err != nil {
+		// revert the archive
+		if revertErr := app.deleteArchivedRows(collection, archivedIds); revertErr != nil {
+			return 0, errors.Join(err, fmt.Errorf("failed to revert the archived records: %w", revertErr))
+		}
+
+		return 0, err
+	}
+
+	return len(archivedIds), nil
+}
+
+func (app *BaseApp) deleteArchivedRows(collection *Collection, ids []any) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := app.AuxNonconcurrentDB().Delete(ArchivedRecordsTableName, dbx.And(
+		dbx.HashExp{"collectionId": collection.Id},
+		dbx.In("recordId", ids...),
+	)).Execute()
+
+	return err
+}
+
+// CountArchivedRecords returns the total number of the archived collection records.
+func (app *BaseApp) CountArchivedRecords(collectionModelOrIdentifier any) (int64, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+
+	err = app.AuxDB().Select("count(*)").
+		From(ArchivedRecordsTableName).
+		Where(dbx.HashExp{"collectionId": collection.Id}).
+		Row(&total)
+
+	return total, err
+}
+
+// FindArchivedRecords returns the archived collection records
+// (the most recently archived first).
+//
+// If limit <= 0 all archived collection records are returned.
+func (app *BaseApp) FindArchivedRecords(collectionModelOrIdentifier any, limit int, offset int) ([]*Record, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*ArchivedRecord{}
+
+	query := app.AuxDB().Select("*").
+		From(ArchivedRecordsTableName).
+		Where(dbx.HashExp{"collectionId": collection.Id}).
+		OrderBy("archived DESC", "recordId ASC")
+
+	if limit > 0 {
+		query.Limit(int64(limit))
+	}
+
+	if offset > 0 {
+		query.Offset(int64(offset))
+	}
+
+	if err := query.All(&items); err != nil {
+		return nil, err
+	}
+
+	rows := make([]dbx.NullStringMap, len(items))
+	for i, item := range items {
+		rows[i], err = item.Row()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load archived record %q: %w", item.RecordId, err)
+		}
+	}
+
+	return newRecordsFromNullStringMaps(collection, rows)
+}
+
+// RestoreArchivedRecords moves the archived collection records with the
+// specified ids back into the collection table and returns their total.
+//
+// If no ids are specified, all archived collection records are restored.
+//
+// Only the columns of the current collection fields are restored
+// (the new fields are initialized with their default column value).
+// Similar to [BaseApp.ArchiveRecords] no record hooks are triggered.
+func (app *BaseApp) RestoreArchivedRecords(collectionModelOrIdentifier any, ids ...string) (int, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return 0, err
+	}
+
+	fieldNames := collection.Fields.FieldNames()
+
+	var total int
+
+	for {
+		items := []*ArchivedRecord{}
+
+		query := app.AuxDB().Select("*").
+			From(ArchivedRecordsTableName).
+			Where(dbx.HashExp{"collectionId": collection.Id}).
+			OrderBy("archived ASC", "recordId ASC").
+			Limit(archiveBatchSize)
+
+		if len(ids) > 0 {
+			query.AndWhere(dbx.In("recordId", list.ToInterfaceSlice(ids)...))
+		}
+
+		if err := query.All(&items); err != nil {
+			return total, err
+		}
+
+		if len(items) == 0 {
+			break
+		}
+
+		restoredIds := make([]any, len(items))
+
+		err := app.RunInTransaction(func(txApp App) error {
+			for i, item := range items {
+				row, err := item.Row()
+				if err != nil {
+					return fmt.Errorf("failed to load archived record %q: %w", item.RecordId, err)
+				}
+
+				params := dbx.Params{}
+				for _, name := range fieldNames {
+					if v, ok := row[name]; ok {
+						if v.Valid {
+							params[name] = v.String
+						} else {
+							params[name] = nil
+						}
+					}
+				}
+
+				if _, err := txApp.NonconcurrentDB().Insert(collection.Name, params).Execute(); err != nil {
+					return fmt.Errorf("failed to restore archived record %q: %w", item.RecordId, err)
+				}
+
+				restoredIds[i] = item.RecordId
+			}
+
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+
+		if err := app.deleteArchivedRows(collection, restoredIds); err != nil {
+			return total, err
+		}
+
+		total += len(items)
+
+		if len(items) < archiveBatchSize {
+			break
+		}
+	}
+
+	return total, nil
+}
+
+func (app *BaseApp) registerRecordArchiveHooks() {
+	// delete the archived records of the deleted collections
+	app.OnCollectionAfterDeleteSuccess().BindFunc(func(e *CollectionEvent) error {
+		_, err := e.App.AuxNonconcurrentDB().Delete(
+			ArchivedRecordsTableName,
+			dbx.HashExp{"collectionId": e.Collection.Id},
+		).Execute()
+		if err != nil {
+			e.App.Logger().Warn(
+				"Failed to delete the archived collection records",
+				"collection", e.Collection.Name,
+				"error", err,
+			)
+		}
+
+		return e.Next()
+	})
+}
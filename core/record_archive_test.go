@@ -0,0 +1,220 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestArchiveAndRestoreRecords(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.ArchiveRecords("demo5", "missing = 1"); err == nil {
+		t.Fatal("Expected error for non-existing field filter")
+	}
+
+	if _, err := app.ArchiveRecords("view1", ""); err == nil {
+		t.Fatal("Expected view collection error")
+	}
+
+	original, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total, err := app.ArchiveRecords("users", "email = {:email}", dbx.Params{"email": original.Email()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("Expected 1 archived record, got %d", total)
+	}
+
+	if _, err := app.FindRecordById("users", original.Id); err == nil {
+		t.Fatal("Expected the archived record to be excluded from the regular queries")
+	}
+
+	archivedTotal, err := app.CountArchivedRecords("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archivedTotal != 1 {
+		t.Fatalf("Expected 1 archived users record, got %d", archivedTotal)
+	}
+
+	archived, err := app.FindArchivedRecords("users", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 1 || archived[0].Id != original.Id || archived[0].Email() != original.Email() {
+		t.Fatalf("Expected the archived record %q, got %v", original.Id, archived)
+	}
+
+	// restore of non-archived id
+	total, err = app.RestoreArchivedRecords("users", "missing")
+	if err != nil || total != 0 {
+		t.Fatalf("Expected 0 restored records, got %d (%v)", total, err)
+	}
+
+	total, err = app.RestoreArchivedRecords("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("Expected 1 restored record, got %d", total)
+	}
+
+	restored, err := app.FindRecordById("users", original.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !restored.ValidatePassword("1234567890") {
+		t.Fatal("Expected the restored record password hash to be preserved")
+	}
+
+	if restored.GetString("tokenKey") != original.GetString("tokenKey") ||
+		restored.GetDateTime("updated").String() != original.GetDateTime("updated").String() {
+		t.Fatalf("Expected the restored record to match the original one, got %v", restored.FieldsData())
+	}
+
+	if archivedTotal, _ = app.CountArchivedRecords("users"); archivedTotal != 0 {
+		t.Fatalf("Expected no archived users records, got %d", archivedTotal)
+	}
+}
+
+func TestArchivedRecordsCollectionDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("archive_test")
+	collection.Fields.Add(&core.TextField{Name: "title"})
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, title := range []string{"a", "b"} {
+		record := core.NewRecord(collection)
+		record.Set("title", title)
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	total, err := app.ArchiveRecords(collection, "title != ''")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Fatalf("Expected 2 archived records, got %d", total)
+	}
+
+	if err := app.Delete(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	var remaining int
+	err = app.AuxDB().Select("count(*)").
+		From(core.ArchivedRecordsTableName).
+		Where(dbx.HashExp{"collectionId": collection.Id}).
+		Row(&remaining)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining != 0 {
+		t.Fatalf("Expected the archived records of the deleted collection to be removed, got %d", remaining)
+	}
+}
+
+func TestArchiveRecordsFailedDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("archive_test")
+	collection.Fields.Add(&core.TextField{Name: "title"})
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("title", "a")
+	if err := app.Save(record); err != nil {
+		t.Fatal(err)
+	}
+
+	countArchived := func() int {
+		var total int
+		err := app.AuxDB().Select("count(*)").
+			From(core.ArchivedRecordsTableName).
+			Where(dbx.HashExp{"collectionId": collection.Id}).
+			Row(&total)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return total
+	}
+
+	// simulate a previous archive that crashed before the collection rows delete
+	_, err := app.AuxDB().NewQuery("INSERT INTO {{" + core.ArchivedRecordsTableName + "}} ([[collectionId]], [[recordId]], [[data]]) VALUES ({:collectionId}, {:recordId}, 'stale')").
+		Bind(dbx.Params{"collectionId": collection.Id, "recordId": record.Id}).
+		Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// force the collection rows delete to fail
+	_, err = app.DB().NewQuery("CREATE TRIGGER archive_test_fail BEFORE DELETE ON archive_test BEGIN SELECT RAISE(ABORT, 'test_delete_error'); END;").Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total, err := app.ArchiveRecords(collection, "")
+	if err == nil {
+		t.Fatal("Expected the archive to fail")
+	}
+	if total != 0 {
+		t.Fatalf("Expected 0 archived records, got %d", total)
+	}
+
+	if _, err := app.FindRecordById(collection, record.Id); err != nil {
+		t.Fatalf("Expected the record to remain in the collection table, got %v", err)
+	}
+
+	if n := countArchived(); n != 0 {
+		t.Fatalf("Expected the archived rows to be reverted, got %d", n)
+	}
+
+	// retry after the failure cause is removed
+	if _, err := app.DB().NewQuery("DROP TRIGGER archive_test_fail").Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	total, err = app.ArchiveRecords(collection, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("Expected 1 archived record, got %d", total)
+	}
+
+	if n := countArchived(); n != 1 {
+		t.Fatalf("Expected 1 archived row, got %d", n)
+	}
+
+	archived, err := app.FindArchivedRecords(collection, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 1 || archived[0].GetString("title") != "a" {
+		t.Fatalf("Expected the archived record to be replaced, got %v", archived)
+	}
+}
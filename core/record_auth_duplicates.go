@@ -0,0 +1,479 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// Auth records duplicate match reasons ordered by their strength.
+const (
+	// DuplicateReasonEmail matches verified records with the same normalized email
+	// (e.g. "John.Doe+github@gmail.com" and "johndoe@googlemail.com").
+	DuplicateReasonEmail = "email"
+
+	// DuplicateReasonFuzzyEmail matches records with the same normalized email
+	// (where at least one of them is not verified) or with similar email local
+	// parts of the same domain.
+	DuplicateReasonFuzzyEmail = "fuzzyEmail"
+
+	// DuplicateReasonFuzzyName matches records with the same or similar "name" field value.
+	DuplicateReasonFuzzyName = "fuzzyName"
+)
+
+// duplicateFuzzyMinLength is the min length of the compared email local
+// parts and names to be considered a fuzzy match with a single character difference.
+const duplicateFuzzyMinLength = 6
+
+// AuthRecordDuplicates defines a group of likely duplicated auth records.
+type AuthRecordDuplicates struct {
+	// Reason is the strongest match reason between the group records
+	// (see the DuplicateReason* constants).
+	Reason string `json:"reason"`
+
+	// Records are the group records sorted by their creation date.
+	Records []*Record `json:"records"`
+}
+
+// FindAuthRecordDuplicates returns the groups of likely duplicated
+// records of the specified auth collection.
+//
+// Note that all collection records are loaded and compared in memory
+// so the method is intended to be used only for occasional maintenance tasks.
+func (app *BaseApp) FindAuthRecordDuplicates(collectionModelOrIdentifier any) ([]*AuthRecordDuplicates, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if !collection.IsAuth() {
+		return nil, fmt.Errorf("%q is not an auth collection", collection.Name)
+	}
+
+	records, err := app.FindAllRecords(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].GetDateTime("created").Before(records[j].GetDateTime("created"))
+	})
+
+	hasNameField := collection.Fields.GetByName("name") != nil
+
+	// union-find of the matched records
+	parents := make([]int, len(records))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+
+	reasons := map[int]string{}
+
+	priority := func(reason string) int {
+		return slices.Index([]string{DuplicateReasonEmail, DuplicateReasonFuzzyEmail, DuplicateReasonFuzzyName}, reason)
+	}
+
+	for i := 0; i < len(records); i++ {
+		for j := i + 1; j < len(records); j++ {
+			reason := authRecordsDuplicateReason(records[i], records[j], hasNameField)
+			if reason == "" {
+				continue
+			}
+
+			ri, rj := find(i), find(j)
+
+			reason = strongerReason(reason, reasons[ri], priority)
+			reason = strongerReason(reason, reasons[rj], priority)
+
+			parents[rj] = ri
+			delete(reasons, rj)
+			reasons[ri] = reason
+		}
+	}
+
+	groups := map[int]*AuthRecordDuplicates{}
+	result := []*AuthRecordDuplicates{}
+
+	for i, record := range records {
+		root := find(i)
+
+		reason, ok := reasons[root]
+		if !ok {
+			continue // no duplicates
+		}
+
+		group, ok := groups[root]
+		if !ok {
+			group = &AuthRecordDuplicates{Reason: reason}
+			groups[root] = group
+			result = append(result, group)
+		}
+
+		group.Records = append(group.Records, record)
+	}
+
+	return result, nil
+}
+
+// MergeAuthRecords merges the source auth records into the target one and deletes them.
+//
+// The merge re-points to the target the source records relation references
+// (including the ones from other collections), external auth providers
+// (if the target doesn't have already the same provider linked)
+// and copies the source files into the empty (or not full)
+// target file fields.
+//
+// All other target field values remain unchanged.
+func (app *BaseApp) MergeAuthRecords(target *Record, sources ...*Record) error {
+	return app.MergeAuthRecordsWithContext(context.Background(), target, sources...)
+}
+
+// MergeAuthRecordsWithContext is the same as [BaseApp.MergeAuthRecords]
+// but allows specifying a custom context.
+func (app *BaseApp) MergeAuthRecordsWithContext(ctx context.Context, target *Record, sources ...*Record) error {
+	collection := target.Collection()
+
+	if !collection.IsAuth() {
+		return errors.New("only auth records could be merged")
+	}
+
+	if len(sources) == 0 {
+		return errors.New("at least one source record is required")
+	}
+
+	sourceIds := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if source.Collection().Id != collection.Id {
+			return fmt.Errorf("source record %q is not from the %q collection", source.Id, collection.Name)
+		}
+
+		if source.Id == target.Id {
+			return errors.New("the target record cannot be also a source")
+		}
+
+		sourceIds = append(sourceIds, source.Id)
+	}
+
+	return app.RunInTransactionWithContext(ctx, func(txApp App) error {
+		if err := mergeAuthRecordsRefs(ctx, txApp, target, sourceIds); err != nil {
+			return err
+		}
+
+		if err := mergeAuthRecordsExternalAuths(ctx, txApp, target, sources); err != nil {
+			return err
+		}
+
+		if err := mergeAuthRecordsFiles(ctx, txApp, target, sources); err != nil {
+			return err
+		}
+
+		for _, source := range sources {
+			if err := txApp.DeleteWithContext(ctx, source); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// mergeAuthRecordsRefs replaces the source ids with the target id in all relation fields referencing the target collection.
+func mergeAuthRecordsRefs(ctx context.Context, app App, target *Record, sourceIds []string) error {
+	refs, err := app.FindCollectionReferences(target.Collection())
+	if err != nil {
+		return err
+	}
+
+	// sort the refs for deterministic events order
+	sortedRefKeys := make([]*Collection, 0, len(refs))
+	for k := range refs {
+		sortedRefKeys = append(sortedRefKeys, k)
+	}
+	sort.Slice(sortedRefKeys, func(i, j int) bool {
+		return sortedRefKeys[i].Name < sortedRefKeys[j].Name
+	})
+
+	for _, refCollection := range sortedRefKeys {
+		if refCollection.IsView() {
+			continue
+		}
+
+		tableName := inflector.Columnify(refCollection.Name)
+
+		for _, field := range refs[refCollection] {
+			prefixedFieldName := tableName + "." + inflector.Columnify(field.GetName())
+
+			query := app.RecordQuery(refCollection).WithContext(ctx)
+
+			if opt, ok := field.(MultiValuer); !ok || !opt.IsMultiple() {
+				query.AndWhere(dbx.In(prefixedFieldName, list.ToInterfaceSlice(sourceIds)...))
+			} else {
+				params := dbx.Params{}
+				placeholders := make([]string, len(sourceIds))
+				for i, id := range sourceIds {
+					key := "pbMergeId" + strconv.Itoa(i)
+					params[key] = id
+					placeholders[i] = "{:" + key + "}"
+				}
+
+				query.AndWhere(dbx.Exists(dbx.NewExp(
+					"SELECT 1 FROM "+dbutils.JSONEach(prefixedFieldName)+" {{__je__}} "+
+						"WHERE [[__je__.value]] IN ("+strings.Join(placeholders, ", ")+")",
+					params,
+				)))
+			}
+
+			if refCollection.Id == target.Collection().Id {
+				// the sources will be deleted anyway
+				query.AndWhere(dbx.NotIn(tableName+".id", list.ToInterfaceSlice(sourceIds)...))
+			}
+
+			refRecords := []*Record{}
+			if err := query.All(&refRecords); err != nil {
+				return err
+			}
+
+			for _, refRecord := range refRecords {
+				ids := refRecord.GetStringSlice(field.GetName())
+
+				for i, id := range ids {
+					if slices.Contains(sourceIds, id) {
+						ids[i] = target.Id
+					}
+				}
+
+				refRecord.Set(field.GetName(), list.ToUniqueStringSlice(ids))
+
+				if err := app.SaveNoValidateWithContext(ctx, refRecord); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// mergeAuthRecordsExternalAuths links the source external auth providers to the target
+// (the ones with already linked target provider are left to be deleted with their source).
+func mergeAuthRecordsExternalAuths(ctx context.Context, app App, target *Record, sources []*Record) error {
+	targetAuths, err := app.FindAllExternalAuthsByRecord(target)
+	if err != nil {
+		return err
+	}
+
+	providers := make([]string, 0, len(targetAuths))
+	for _, ea := range targetAuths {
+		providers = append(providers, ea.Provider())
+	}
+
+	for _, source := range sources {
+		sourceAuths, err := app.FindAllExternalAuthsByRecord(source)
+		if err != nil {
+			return err
+		}
+
+		for _, ea := range sourceAuths {
+			if slices.Contains(providers, ea.Provider()) {
+				continue
+			}
+
+			ea.SetRecordRef(target.Id)
+
+			if err := app.SaveWithContext(ctx, ea); err != nil {
+				return err
+			}
+
+			providers = append(providers, ea.Provider())
+		}
+	}
+
+	return nil
+}
+
+// mergeAuthRecordsFiles copies the source files into the target file fields
+// without exceeding their max select limit.
+func mergeAuthRecordsFiles(ctx context.Context, app App, target *Record, sources []*Record) error {
+	var fsys *filesystem.System
+
+	changed := false
+
+	for _, field := range target.Collection().Fields {
+		f, ok := field.(*FileField)
+		if !ok {
+			continue
+		}
+
+		maxSelect := max(f.MaxSelect, 1)
+
+		newFiles := []*filesystem.File{}
+
+		total := len(target.GetStringSlice(f.Name))
+
+		for _, source := range sources {
+			for _, name := range source.GetStringSlice(f.Name) {
+				if total >= maxSelect {
+					break
+				}
+
+				if fsys == nil {
+					var err error
+					fsys, err = app.NewFilesystem()
+					if err != nil {
+						return err
+					}
+					defer fsys.Close()
+
+					fsys.SetContext(ctx)
+				}
+
+				file, err := fsys.GetReuploadableFile(source.BaseFilesPath()+"/"+name, false)
+				if err != nil {
+					return fmt.Errorf("failed to copy file %q: %w", name, err)
+				}
+
+				newFiles = append(newFiles, file)
+				total++
+			}
+		}
+
+		if len(newFiles) > 0 {
+			target.Set(f.Name+"+", newFiles)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return app.SaveNoValidateWithContext(ctx, target)
+}
+
+// authRecordsDuplicateReason returns the duplicate match reason of
+// the two auth records or empty string if they are not considered duplicates.
+func authRecordsDuplicateReason(a *Record, b *Record, compareNames bool) string {
+	emailA := normalizeDuplicateEmail(a.Email())
+	emailB := normalizeDuplicateEmail(b.Email())
+
+	if emailA != "" && emailA == emailB {
+		if a.Verified() && b.Verified() {
+			return DuplicateReasonEmail
+		}
+		return DuplicateReasonFuzzyEmail
+	}
+
+	if localA, domainA, ok := strings.Cut(emailA, "@"); ok {
+		if localB, domainB, ok := strings.Cut(emailB, "@"); ok && domainA == domainB && isFuzzyMatch(localA, localB) {
+			return DuplicateReasonFuzzyEmail
+		}
+	}
+
+	if compareNames {
+		nameA := normalizeDuplicateName(a.GetString("name"))
+		nameB := normalizeDuplicateName(b.GetString("name"))
+
+		if nameA != "" && (nameA == nameB || isFuzzyMatch(nameA, nameB)) {
+			return DuplicateReasonFuzzyName
+		}
+	}
+
+	return ""
+}
+
+// normalizeDuplicateEmail lowercases the email and removes its
+// subaddress ("+tag") part and the gmail specific dots.
+func normalizeDuplicateEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+
+	local, _, _ = strings.Cut(local, "+")
+
+	if domain == "googlemail.com" {
+		domain = "gmail.com"
+	}
+
+	if domain == "gmail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+
+	return local + "@" + domain
+}
+
+// normalizeDuplicateName lowercases the name and sorts its words
+// (e.g. "Doe  John" -> "doe john", "John Doe" -> "doe john").
+func normalizeDuplicateName(name string) string {
+	words := strings.Fields(strings.ToLower(name))
+
+	slices.Sort(words)
+
+	return strings.Join(words, " ")
+}
+
+// isFuzzyMatch reports whether the two strings differ by a single character
+// (both strings must be at least duplicateFuzzyMinLength long).
+func isFuzzyMatch(a string, b string) bool {
+	if utf8.RuneCountInString(a) < duplicateFuzzyMinLength || utf8.RuneCountInString(b) < duplicateFuzzyMinLength {
+		return false
+	}
+
+	return levenshtein([]rune(a), []rune(b)) <= 1
+}
+
+// levenshtein returns the edit distance between the two rune slices.
+func levenshtein(a []rune, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// strongerReason returns the stronger of the two duplicate reasons.
+func strongerReason(a string, b string, priority func(string) int) string {
+	if b == "" || (a != "" && priority(a) <= priority(b)) {
+		return a
+	}
+
+	return b
+}
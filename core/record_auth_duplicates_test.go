@@ -0,0 +1,180 @@
+package core_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFindAuthRecordDuplicates(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.FindAuthRecordDuplicates("demo1"); err == nil {
+		t.Fatal("Expected non-auth collection error")
+	}
+
+	// no duplicates in the default test data
+	groups, err := app.FindAuthRecordDuplicates("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 0 {
+		t.Fatalf("Expected no duplicates, got %d groups", len(groups))
+	}
+
+	collection, err := app.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(id, email, name string, verified bool) {
+		record := core.NewRecord(collection)
+		record.Id = id
+		record.SetEmail(email)
+		record.SetVerified(verified)
+		record.SetRandomPassword()
+		record.Set("name", name)
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	create("dup100000000001", "john.doe@gmail.com", "John Doe", true)
+	create("dup100000000002", "JohnDoe+github@googlemail.com", "", true)
+	create("dup200000000001", "jane.smith@example.com", "", true)
+	create("dup200000000002", "jane.smith+news@example.com", "", false)
+	create("dup300000000001", "markus@example.com", "", false)
+	create("dup300000000002", "marcus@example.com", "", false)
+	create("dup400000000001", "a1@example.com", "Peter Parker", false)
+	create("dup400000000002", "a2@example.com", "parker  peter", false)
+	create("dup500000000001", "b1@example.com", "Maria", false) // too short for fuzzy match
+	create("dup500000000002", "b2@example.com", "Marie", false)
+
+	groups, err = app.FindAuthRecordDuplicates("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]string{
+		core.DuplicateReasonEmail:      {"dup100000000001", "dup100000000002"},
+		core.DuplicateReasonFuzzyEmail: {"dup200000000001", "dup200000000002"},
+		core.DuplicateReasonFuzzyName:  {"dup400000000001", "dup400000000002"},
+	}
+
+	fuzzyEmailGroups := 0
+
+	for _, group := range groups {
+		ids := make([]string, len(group.Records))
+		for i, r := range group.Records {
+			ids[i] = r.Id
+		}
+		slices.Sort(ids)
+
+		if group.Reason == core.DuplicateReasonFuzzyEmail {
+			fuzzyEmailGroups++
+			if slices.Equal(ids, []string{"dup300000000001", "dup300000000002"}) {
+				continue
+			}
+		}
+
+		if !slices.Equal(ids, expected[group.Reason]) {
+			t.Fatalf("Expected %s group %v, got %v", group.Reason, expected[group.Reason], ids)
+		}
+	}
+
+	if len(groups) != 4 || fuzzyEmailGroups != 2 {
+		t.Fatalf("Expected 4 groups (2 fuzzyEmail), got %d (%d fuzzyEmail)", len(groups), fuzzyEmailGroups)
+	}
+}
+
+func TestMergeAuthRecords(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	find := func(collection, id string) *core.Record {
+		record, err := app.FindRecordById(collection, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return record
+	}
+
+	target := find("users", "bgs820n361vj1qd")
+	source1 := find("users", "4q1xlclmfloku33")
+	source2 := find("users", "oap640cot4yru2s")
+
+	// errors
+	if err := app.MergeAuthRecords(find("demo1", "84nmscqy84lsi1t"), source1); err == nil {
+		t.Fatal("Expected non-auth target error")
+	}
+	if err := app.MergeAuthRecords(target); err == nil {
+		t.Fatal("Expected missing sources error")
+	}
+	if err := app.MergeAuthRecords(target, target); err == nil {
+		t.Fatal("Expected target as source error")
+	}
+	if err := app.MergeAuthRecords(target, find("clients", "gk390qegs4y47wn")); err == nil {
+		t.Fatal("Expected different collection source error")
+	}
+
+	if err := app.MergeAuthRecords(target, source1, source2); err != nil {
+		t.Fatal(err)
+	}
+
+	// sources deletion
+	for _, id := range []string{source1.Id, source2.Id} {
+		if _, err := app.FindRecordById("users", id); err == nil {
+			t.Fatalf("Expected source %q to be deleted", id)
+		}
+	}
+
+	// relations
+	for _, id := range []string{"84nmscqy84lsi1t", "al1h9ijdeojtsjy"} {
+		rels := find("demo1", id).GetStringSlice("rel_many")
+		if !slices.Equal(rels, []string{target.Id}) {
+			t.Fatalf("Expected demo1 %q rel_many to be [%s], got %v", id, target.Id, rels)
+		}
+	}
+
+	// external auths
+	auths, err := app.FindAllExternalAuthsByRecord(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	providers := make([]string, len(auths))
+	for i, ea := range auths {
+		providers[i] = ea.Provider()
+	}
+	slices.Sort(providers)
+	if !slices.Equal(providers, []string{"github", "gitlab", "google"}) {
+		t.Fatalf("Expected github, gitlab and google providers, got %v", providers)
+	}
+
+	// files
+	target = find("users", target.Id)
+	if v := target.GetString("avatar"); v == "" {
+		t.Fatal("Expected the avatar to be copied")
+	}
+	if v := target.GetStringSlice("file"); len(v) != 1 {
+		t.Fatalf("Expected 1 copied file, got %v", v)
+	}
+
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+
+	for _, name := range append(target.GetStringSlice("file"), target.GetString("avatar")) {
+		if exists, _ := fsys.Exists(target.BaseFilesPath() + "/" + name); !exists {
+			t.Fatalf("Expected file %q to exist", name)
+		}
+	}
+}
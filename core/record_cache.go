@@ -0,0 +1,190 @@
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/store"
+)
+
+// StoreKeyRecordCache is the app store key of the collections in-memory records cache.
+const StoreKeyRecordCache = "pbAppRecordCache"
+
+type recordCacheItem struct {
+	record  *Record
+	expires time.Time
+}
+
+func (item *recordCacheItem) isExpired() bool {
+	return !item.expires.IsZero() && !item.expires.After(time.Now())
+}
+
+// recordCollectionCache holds the cached records of a single collection.
+type recordCollectionCache struct {
+	records *store.Store[string, *recordCacheItem]
+
+	// generation is incremented on each records invalidation
+	// to prevent caching a record that was loaded before it
+	mu         sync.Mutex
+	generation uint64
+}
+
+func newRecordCollectionCache() *recordCollectionCache {
+	return &recordCollectionCache{records: store.New[string, *recordCacheItem](nil)}
+}
+
+// currentGeneration returns the current collection cache generation.
+func (c *recordCollectionCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// set caches the specified record item only if the cache
+// wasn't invalidated since the loaded generation.
+func (c *recordCollectionCache) set(loadedGeneration uint64, recordId string, item *recordCacheItem, maxRecords int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != loadedGeneration {
+		return
+	}
+
+	c.records.SetIfLessThanLimit(recordId, item, maxRecords)
+}
+
+// invalidate removes the specified cached records
+// (or all of them if no ids are specified).
+func (c *recordCollectionCache) invalidate(recordIds ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+
+	if len(recordIds) == 0 {
+		c.records.RemoveAll()
+		return
+	}
+
+	for _, id := range recordIds {
+		c.records.Remove(id)
+	}
+}
+
+// recordCache returns the app records cache store (collectionId -> collection records cache).
+func recordCache(app App) *store.Store[string, *recordCollectionCache] {
+	cache, _ := app.Store().GetOrSet(StoreKeyRecordCache, func() any {
+		return store.New[string, *recordCollectionCache](nil)
+	}).(*store.Store[string, *recordCollectionCache])
+
+	return cache
+}
+
+// CachedRecord is similar to [BaseApp.FindRecordById] but for collections
+// with enabled RecordCache option the found record is cached in memory
+// and reused by the next calls until the record is updated or deleted.
+//
+// For all other collections, or when called inside a transaction,
+// it fallbacks to a regular db lookup.
+//
+// The returned record is always a clone, so it is safe to be modified.
+//
+// Note that the records cache is not shared between multiple app
+// processes and it is not aware of the raw db writes bypassing the
+// app model hooks (call [BaseApp.ClearCachedRecords] in that case).
+func (app *BaseApp) CachedRecord(collectionModelOrIdentifier any, recordId string) (*Record, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if !collection.RecordCache.Enabled || app.IsTransactional() {
+		return app.FindRecordById(collection, recordId)
+	}
+
+	cache := recordCache(app)
+
+	collectionCache := cache.GetOrSet(collection.Id, newRecordCollectionCache)
+
+	if item, ok := collectionCache.records.GetOk(recordId); ok {
+		if !item.isExpired() {
+			return item.record.Clone(), nil
+		}
+		collectionCache.records.Remove(recordId)
+	}
+
+	// note: the generation must be loaded before the db lookup so that
+	// a stale record from a concurrent update is not cached after its invalidation
+	generation := collectionCache.currentGeneration()
+
+	record, err := app.FindRecordById(collection, recordId)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &recordCacheItem{record: record.Clone()}
+	if collection.RecordCache.TTL > 0 {
+		item.expires = time.Now().Add(time.Duration(collection.RecordCache.TTL) * time.Second)
+	}
+
+	maxRecords := collection.RecordCache.MaxRecords
+	if maxRecords <= 0 {
+		maxRecords = DefaultRecordCacheMaxRecords
+	}
+
+	collectionCache.set(generation, recordId, item, maxRecords)
+
+	return record, nil
+}
+
+// ClearCachedRecords removes the specified records from the in-memory records cache.
+//
+// If no record ids are specified, all cached records of the collection are removed.
+func (app *BaseApp) ClearCachedRecords(collectionModelOrIdentifier any, recordIds ...string) error {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return err
+	}
+
+	clearCachedRecords(app, collection.Id, recordIds...)
+
+	return nil
+}
+
+func clearCachedRecords(app App, collectionId string, recordIds ...string) {
+	collectionCache, ok := recordCache(app).GetOk(collectionId)
+	if !ok {
+		return
+	}
+
+	collectionCache.invalidate(recordIds...)
+}
+
+func (app *BaseApp) registerRecordCacheHooks() {
+	invalidateRecord := func(e *RecordEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		clearCachedRecords(e.App, e.Record.Collection().Id, e.Record.Id)
+
+		return nil
+	}
+
+	app.OnRecordAfterUpdateSuccess().BindFunc(invalidateRecord)
+	app.OnRecordAfterDeleteSuccess().BindFunc(invalidateRecord)
+
+	invalidateCollection := func(e *CollectionEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		clearCachedRecords(e.App, e.Collection.Id)
+
+		return nil
+	}
+
+	app.OnCollectionAfterUpdateSuccess().BindFunc(invalidateCollection)
+	app.OnCollectionAfterDeleteSuccess().BindFunc(invalidateCollection)
+}
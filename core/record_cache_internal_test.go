@@ -0,0 +1,39 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestRecordCollectionCacheStaleSet(t *testing.T) {
+	t.Parallel()
+
+	cache := newRecordCollectionCache()
+
+	item := &recordCacheItem{record: &Record{}}
+
+	// simulate a record invalidation while the record is being loaded
+	generation := cache.currentGeneration()
+	cache.invalidate("test")
+	cache.set(generation, "test", item, 10)
+
+	if cache.records.Has("test") {
+		t.Fatal("Expected the stale record to not be cached")
+	}
+
+	// full invalidation
+	generation = cache.currentGeneration()
+	cache.invalidate()
+	cache.set(generation, "test", item, 10)
+
+	if cache.records.Has("test") {
+		t.Fatal("Expected the stale record to not be cached after full invalidation")
+	}
+
+	// no invalidation
+	generation = cache.currentGeneration()
+	cache.set(generation, "test", item, 10)
+
+	if !cache.records.Has("test") {
+		t.Fatal("Expected the record to be cached")
+	}
+}
@@ -0,0 +1,120 @@
+package core_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCachedRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	rawUpdate := func(id string, title string) {
+		_, err := app.DB().Update("demo3", dbx.Params{"title": title}, dbx.HashExp{"id": id}).Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// disabled cache
+	// ---
+	record, err := app.CachedRecord("demo3", "1tmknxy2868d869")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawUpdate(record.Id, "raw_disabled")
+	record, err = app.CachedRecord("demo3", record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("title"); v != "raw_disabled" {
+		t.Fatalf("Expected the db value to be returned for non-cached collection, got %q", v)
+	}
+
+	// enabled cache
+	// ---
+	collection, err := app.FindCollectionByNameOrId("demo3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.RecordCache.Enabled = true
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err = app.CachedRecord("demo3", "1tmknxy2868d869")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the returned records should be clones
+	record.Set("title", "modified")
+
+	rawUpdate(record.Id, "raw_enabled")
+
+	cached, err := app.CachedRecord("demo3", record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := cached.GetString("title"); v != "raw_disabled" {
+		t.Fatalf("Expected the cached value to be returned, got %q", v)
+	}
+
+	// invalidate on save
+	cached.Set("title", "saved")
+	if err := app.Save(cached); err != nil {
+		t.Fatal(err)
+	}
+	cached, err = app.CachedRecord("demo3", record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := cached.GetString("title"); v != "saved" {
+		t.Fatalf("Expected the cache to be invalidated after save, got %q", v)
+	}
+
+	// manual invalidation
+	rawUpdate(record.Id, "raw_clear")
+	if err := app.ClearCachedRecords("demo3", record.Id); err != nil {
+		t.Fatal(err)
+	}
+	cached, err = app.CachedRecord("demo3", record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := cached.GetString("title"); v != "raw_clear" {
+		t.Fatalf("Expected the cache to be invalidated after ClearCachedRecords, got %q", v)
+	}
+
+	// invalidate on delete
+	if err := app.Delete(cached); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.CachedRecord("demo3", record.Id); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("Expected sql.ErrNoRows after delete, got %v", err)
+	}
+
+	// invalidate on collection change
+	other, err := app.CachedRecord("demo3", "lcl9d87w22ml6jy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawUpdate(other.Id, "raw_collection")
+	collection.RecordCache.TTL = 60
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+	other, err = app.CachedRecord("demo3", other.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := other.GetString("title"); v != "raw_collection" {
+		t.Fatalf("Expected the cache to be invalidated after collection update, got %q", v)
+	}
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// duplicateSuffix is the suffix appended to the duplicated unique field values.
+const duplicateSuffix = "-copy"
+
+// duplicateMaxSuffixAttempts is the max number of the numbered suffix
+// attempts (-copy, -copy-2, ...) after which a random suffix is used.
+const duplicateMaxSuffixAttempts = 10
+
+// DuplicateRecord creates and persists a copy of the provided record.
+//
+// The stored record files are copied under new names and the values
+// of the single column unique text, email and url fields are suffixed
+// (e.g. "title" -> "title-copy", "a@example.com" -> "a-copy@example.com").
+//
+// The optional overrides data is loaded into the duplicate after
+// the record values are copied (the overridden fields are not suffixed).
+//
+// The autodate fields, the password and the auth collection tokenKey
+// and verified fields are never copied (the duplicate of an auth
+// record is assigned a random password).
+func (app *BaseApp) DuplicateRecord(record *Record, overrides map[string]any) (*Record, error) {
+	return app.DuplicateRecordWithContext(context.Background(), record, overrides)
+}
+
+// DuplicateRecordWithContext is the same as [BaseApp.DuplicateRecord]
+// but allows specifying a custom context.
+func (app *BaseApp) DuplicateRecordWithContext(ctx context.Context, record *Record, overrides map[string]any) (*Record, error) {
+	collection := record.Collection()
+	if collection == nil || collection.IsView() {
+		return nil, errors.New("only base and auth collection records could be duplicated")
+	}
+
+	duplicate := NewRecord(collection)
+
+	var fileFields []*FileField
+
+	for _, field := range collection.Fields {
+		name := field.GetName()
+
+		if name == FieldNameId {
+			continue
+		}
+
+		if _, ok := overrides[name]; ok {
+			continue
+		}
+
+		switch f := field.(type) {
+		case *AutodateField, *PasswordField:
+			continue
+		case *FileField:
+			fileFields = append(fileFields, f)
+			continue
+		}
+
+		if collection.IsAuth() && (name == FieldNameTokenKey || name == FieldNameVerified) {
+			continue
+		}
+
+		duplicate.Set(name, record.Get(name))
+
+		switch field.Type() {
+		case FieldTypeText, FieldTypeEmail, FieldTypeURL:
+			if _, ok := dbutils.FindSingleColumnUniqueIndex(collection.Indexes, name); !ok {
+				continue
+			}
+
+			value, err := app.uniqueDuplicateValue(ctx, collection, name, record.GetString(name))
+			if err != nil {
+				return nil, err
+			}
+			duplicate.Set(name, value)
+		}
+	}
+
+	if len(fileFields) > 0 {
+		fsys, err := app.NewFilesystem()
+		if err != nil {
+			return nil, err
+		}
+		defer fsys.Close()
+
+		fsys.SetContext(ctx)
+
+		for _, f := range fileFields {
+			names := record.GetStringSlice(f.Name)
+			if len(names) == 0 {
+				continue
+			}
+
+			files := make([]*filesystem.File, 0, len(names))
+			for _, name := range names {
+				file, err := fsys.GetReuploadableFile(record.BaseFilesPath()+"/"+name, false)
+				if err != nil {
+					return nil, fmt.Errorf("failed to copy file %q: %w", name, err)
+				}
+				files = append(files, file)
+			}
+
+			duplicate.Set(f.Name, files)
+		}
+	}
+
+	if collection.IsAuth() {
+		duplicate.SetRandomPassword()
+	}
+
+	for key, value := range overrides {
+		duplicate.Set(key, value)
+	}
+
+	if err := app.SaveWithContext(ctx, duplicate); err != nil {
+		return nil, err
+	}
+
+	return duplicate, nil
+}
+
+// uniqueDuplicateValue returns a suffixed version of the provided
+// value that doesn't exist yet in the specified collection field.
+func (app *BaseApp) uniqueDuplicateValue(ctx context.Context, collection *Collection, field string, value string) (string, error) {
+	if value == "" {
+		return "", nil // empty values are not considered unique
+	}
+
+	isEmail := collection.Fields.GetByName(field).Type() == FieldTypeEmail
+
+	withSuffix := func(suffix string) string {
+		// insert the suffix before the domain for emails
+		if at := strings.LastIndex(value, "@"); isEmail && at > 0 {
+			return value[:at] + suffix + value[at:]
+		}
+		return value + suffix
+	}
+
+	for i := 1; i <= duplicateMaxSuffixAttempts; i++ {
+		suffix := duplicateSuffix
+		if i > 1 {
+			suffix += "-" + strconv.Itoa(i)
+		}
+
+		candidate := withSuffix(suffix)
+
+		var exists int
+		err := app.RecordQuery(collection).
+			WithContext(ctx).
+			Select("(1)").
+			AndWhere(dbx.HashExp{collection.Name + "." + field: candidate}).
+			Limit(1).
+			Row(&exists)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return "", err
+		}
+
+		if exists == 0 {
+			return candidate, nil
+		}
+	}
+
+	return withSuffix(duplicateSuffix + "-" + security.PseudorandomString(6)), nil
+}
@@ -0,0 +1,155 @@
+package core_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestDuplicateRecordUniqueValues(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.FindRecordById("demo2", "0yxhwia2amd8gec")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"test3-copy", "test3-copy-2", "test3-copy-3"} {
+		duplicate, err := app.DuplicateRecord(record, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if duplicate.Id == "" || duplicate.Id == record.Id {
+			t.Fatalf("Expected new record id, got %q", duplicate.Id)
+		}
+
+		if v := duplicate.GetString("title"); v != expected {
+			t.Fatalf("Expected title %q, got %q", expected, v)
+		}
+
+		if duplicate.GetBool("active") != record.GetBool("active") {
+			t.Fatal("Expected the active field value to be copied")
+		}
+	}
+
+	// with overrides
+	duplicate, err := app.DuplicateRecord(record, map[string]any{"title": "new", "active": false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := duplicate.GetString("title"); v != "new" {
+		t.Fatalf("Expected the overridden title %q, got %q", "new", v)
+	}
+	if duplicate.GetBool("active") {
+		t.Fatal("Expected the overridden active field value")
+	}
+}
+
+func TestDuplicateRecordAuth(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	duplicate, err := app.DuplicateRecord(record, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := duplicate.Email(); v != "test-copy@example.com" {
+		t.Fatalf("Expected email %q, got %q", "test-copy@example.com", v)
+	}
+
+	if v := duplicate.GetString("username"); v != record.GetString("username")+"-copy" {
+		t.Fatalf("Expected username with -copy suffix, got %q", v)
+	}
+
+	if duplicate.TokenKey() == record.TokenKey() {
+		t.Fatal("Expected different tokenKey")
+	}
+
+	if duplicate.Verified() {
+		t.Fatal("Expected the duplicate to be unverified")
+	}
+
+	if duplicate.ValidatePassword("1234567890") {
+		t.Fatal("Expected the original password to not be copied")
+	}
+}
+
+func TestDuplicateRecordFiles(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	duplicate, err := app.DuplicateRecord(record, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+
+	for _, field := range []string{"file_one", "file_many"} {
+		originalNames := record.GetStringSlice(field)
+		names := duplicate.GetStringSlice(field)
+
+		if len(names) != len(originalNames) {
+			t.Fatalf("[%s] Expected %d files, got %v", field, len(originalNames), names)
+		}
+
+		for _, name := range names {
+			if slices.Contains(originalNames, name) {
+				t.Fatalf("[%s] Expected a new file name, got the original %q", field, name)
+			}
+
+			exists, err := fsys.Exists(duplicate.BaseFilesPath() + "/" + name)
+			if err != nil || !exists {
+				t.Fatalf("[%s] Expected file %q to exist (%v)", field, name, err)
+			}
+		}
+	}
+
+	// the original files should be untouched
+	for _, name := range record.GetStringSlice("file_many") {
+		exists, err := fsys.Exists(record.BaseFilesPath() + "/" + name)
+		if err != nil || !exists {
+			t.Fatalf("Expected the original file %q to exist (%v)", name, err)
+		}
+	}
+}
+
+func TestDuplicateRecordView(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.FindFirstRecordByFilter("view1", "id != ''")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.DuplicateRecord(record, nil); err == nil {
+		t.Fatal("Expected view record duplicate error")
+	}
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const expirationCronId = "__pbExpiration__"
+
+// expirationCronExpr is the schedule of the expired records purge.
+const expirationCronExpr = "* * * * *"
+
+// expirationBatchSize is the max number of expired records loaded at once.
+const expirationBatchSize = 500
+
+// IsExpired reports whether the record expiration time has been reached.
+//
+// Always returns false for records of collections without expiration field.
+func (m *Record) IsExpired() bool {
+	return m.IsExpiredAt(time.Now())
+}
+
+// IsExpiredAt reports whether the record expiration field
+// is set and it is <= the specified time.
+//
+// Always returns false for records of collections without expiration field.
+func (m *Record) IsExpiredAt(t time.Time) bool {
+	if m.collection == nil || m.collection.Expiration.Field == "" {
+		return false
+	}
+
+	expireAt := m.GetDateTime(m.collection.Expiration.Field)
+
+	return !expireAt.IsZero() && !expireAt.Time().After(t)
+}
+
+// UnexpiredRecordsExpr returns a db expression that filters the records
+// of the specified collection to only those that are not expired
+// at the specified time (see [Record.IsExpiredAt]).
+func UnexpiredRecordsExpr(collection *Collection, t time.Time) dbx.Expression {
+	expireAt := "[[" + collection.Name + "." + collection.Expiration.Field + "]]"
+
+	dt, _ := types.ParseDateTime(t)
+
+	return dbx.NewExp(
+		"(COALESCE("+expireAt+", '') = '' OR "+expireAt+" > {:pbExpirationTime})",
+		dbx.Params{"pbExpirationTime": dt.String()},
+	)
+}
+
+// PurgeExpiredRecords triggers the [App.OnRecordExpire] hook for all
+// records whose expiration time plus their collection grace period
+// was reached before the specified time and returns the total number
+// of the triggered events.
+//
+// The default hook action deletes the record with the regular
+// [App.Delete] (aka. the delete hooks, cascade delete and files removal are performed).
+func (app *BaseApp) PurgeExpiredRecords(ctx context.Context, t time.Time) (int, error) {
+	collections, err := app.FindAllCollections(CollectionTypeBase, CollectionTypeAuth)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	var errs []error
+
+	for _, collection := range collections {
+		if collection.Expiration.Field == "" {
+			continue
+		}
+
+		purged, err := app.purgeExpiredCollectionRecords(ctx, collection, t)
+		total += purged
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return total, errors.Join(errs...)
+}
+
+func (app *BaseApp) purgeExpiredCollectionRecords(ctx context.Context, collection *Collection, t time.Time) (int, error) {
+	dt, _ := types.ParseDateTime(t.Add(-time.Duration(collection.Expiration.GracePeriod) * time.Second))
+
+	col := "[[" + collection.Name + "." + collection.Expiration.Field + "]]"
+
+	var total int
+	var errs []error
+	var lastId string
+
+	for {
+		records := []*Record{}
+
+		err := app.RecordQuery(collection).
+			WithContext(ctx).
+			AndWhere(dbx.NewExp(
+				col+" != '' AND "+col+" <= {:pbPurgeTime} AND [["+collection.Name+".id]] > {:lastId}",
+				dbx.Params{"pbPurgeTime": dt.String(), "lastId": lastId},
+			)).
+			OrderBy("id ASC").
+			Limit(expirationBatchSize).
+			All(&records)
+		if err != nil {
+			return total, errors.Join(append(errs, err)...)
+		}
+
+		for _, record := range records {
+			event := new(RecordExpireEvent)
+			event.App = app
+			event.Context = ctx
+			event.Record = record
+
+			err := app.OnRecordExpire().Trigger(event, func(e *RecordExpireEvent) error {
+				return e.App.DeleteWithContext(e.Context, e.Record)
+			})
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			total++
+		}
+
+		if len(records) < expirationBatchSize {
+			break
+		}
+
+		lastId = records[len(records)-1].Id
+	}
+
+	return total, errors.Join(errs...)
+}
+
+func (app *BaseApp) registerExpirationHooks() {
+	loadJob := func() {
+		collections, err := app.FindAllCollections(CollectionTypeBase, CollectionTypeAuth)
+		if err != nil {
+			app.Logger().Error("[Expiration cron] Failed to load the collections", slog.String("error", err.Error()))
+			return
+		}
+
+		var hasExpiration bool
+		for _, c := range collections {
+			if c.Expiration.Field != "" {
+				hasExpiration = true
+				break
+			}
+		}
+
+		if !hasExpiration {
+			app.Cron().Remove(expirationCronId)
+			return
+		}
+
+		app.Cron().Add(expirationCronId, expirationCronExpr, func() {
+			total, err := app.PurgeExpiredRecords(context.Background(), time.Now())
+			if err != nil {
+				app.Logger().Error(
+					"[Expiration cron] Failed to purge some of the expired records",
+					slog.String("error", err.Error()),
+				)
+			}
+
+			if total > 0 {
+				app.Logger().Debug("[Expiration cron] Processed expired records", slog.Int("total", total))
+			}
+		})
+	}
+
+	app.OnBootstrap().BindFunc(func(e *BootstrapEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	})
+
+	reloadJob := func(e *CollectionEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	}
+
+	app.OnCollectionAfterCreateSuccess().BindFunc(reloadJob)
+	app.OnCollectionAfterUpdateSuccess().BindFunc(reloadJob)
+	app.OnCollectionAfterDeleteSuccess().BindFunc(reloadJob)
+}
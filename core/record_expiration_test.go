@@ -0,0 +1,183 @@
+package core_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/cron"
+)
+
+func TestRecordIsExpiredAt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	collection := core.NewBaseCollection("test")
+	collection.Expiration.Field = "expires"
+	collection.Fields.Add(&core.DateField{Name: "expires"})
+
+	scenarios := []struct {
+		name     string
+		disabled bool
+		expires  time.Time
+		expected bool
+	}{
+		{"disabled expiration", true, now.Add(-time.Hour), false},
+		{"empty expiration", false, time.Time{}, false},
+		{"future expiration", false, now.Add(time.Hour), false},
+		{"expiration equal to now", false, now, true},
+		{"past expiration", false, now.Add(-time.Hour), true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			c := collection
+			if s.disabled {
+				c = core.NewBaseCollection("test_disabled")
+				c.Fields = collection.Fields
+			}
+
+			record := core.NewRecord(c)
+			if !s.expires.IsZero() {
+				record.Set("expires", s.expires)
+			}
+
+			if v := record.IsExpiredAt(now); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestUnexpiredRecordsExpr(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now()
+
+	collection := core.NewBaseCollection("test_expiration")
+	collection.Fields.Add(&core.DateField{Name: "expires"})
+	collection.Expiration.Field = "expires"
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	expirations := map[string]time.Time{
+		"empty":   {},
+		"active":  now.Add(time.Hour),
+		"expired": now.Add(-time.Hour),
+	}
+
+	for id, expires := range expirations {
+		record := core.NewRecord(collection)
+		record.Id = (id + "000000000000000")[:15]
+		if !expires.IsZero() {
+			record.Set("expires", expires)
+		}
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := []string{}
+	err := app.RecordQuery(collection).
+		Select("id").
+		AndWhere(core.UnexpiredRecordsExpr(collection, now)).
+		OrderBy("id").
+		Column(&ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"active000000000", "empty0000000000"}
+	if !slices.Equal(ids, expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+}
+
+func TestPurgeExpiredRecords(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now()
+
+	collection := core.NewBaseCollection("test_expiration")
+	collection.Fields.Add(&core.DateField{Name: "expires"})
+	collection.Expiration = core.CollectionExpiration{Field: "expires", GracePeriod: 60}
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.ContainsFunc(app.Cron().Jobs(), func(j *cron.Job) bool { return j.Id() == "__pbExpiration__" }) {
+		t.Fatal("Expected the expiration cron job to be registered")
+	}
+
+	expirations := map[string]time.Time{
+		"empty":   {},
+		"active":  now.Add(time.Hour),
+		"grace":   now.Add(-30 * time.Second), // expired but within the grace period
+		"purged1": now.Add(-2 * time.Minute),
+		"purged2": now.Add(-time.Hour),
+		"skipped": now.Add(-time.Hour),
+	}
+
+	for id, expires := range expirations {
+		record := core.NewRecord(collection)
+		record.Id = (id + "000000000000000")[:15]
+		if !expires.IsZero() {
+			record.Set("expires", expires)
+		}
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// keep the record by not calling e.Next()
+	app.OnRecordExpire(collection.Name).BindFunc(func(e *core.RecordExpireEvent) error {
+		if e.Record.Id == "skipped00000000" {
+			return nil
+		}
+		return e.Next()
+	})
+
+	total, err := app.PurgeExpiredRecords(context.Background(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 3 {
+		t.Fatalf("Expected 3 triggered events, got %d", total)
+	}
+
+	if app.EventCalls["OnRecordExpire"] != 3 {
+		t.Fatalf("Expected OnRecordExpire to be called 3 times, got %d", app.EventCalls["OnRecordExpire"])
+	}
+
+	ids := []string{}
+	if err := app.RecordQuery(collection).Select("id").OrderBy("id").Column(&ids); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"active000000000", "empty0000000000", "grace0000000000", "skipped00000000"}
+	if !slices.Equal(ids, expected) {
+		t.Fatalf("Expected remaining records %v, got %v", expected, ids)
+	}
+
+	// disable expiration
+	collection.Expiration = core.CollectionExpiration{}
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if slices.ContainsFunc(app.Cron().Jobs(), func(j *cron.Job) bool { return j.Id() == "__pbExpiration__" }) {
+		t.Fatal("Expected the expiration cron job to be removed")
+	}
+}
@@ -54,6 +54,7 @@ type RecordFieldResolver struct {
 	listRuleJoins       map[string]*Collection // tableAlias->collection
 	joinAliasSuffix     string                 // used for uniqueness in the flatten collection list rule join
 	baseCollectionAlias string
+	recordedJoins       *[]*search.Join // if set, collects the joins registered during a single field resolve or filter build (see recordJoins)
 }
 
 // AllowedFields returns a copy of the resolver's allowed fields.
@@ -276,8 +277,34 @@ func preferGroupBy(info *dbx.QueryInfo, fullUnquotedGroupByCol string) bool {
 //	@request.body.someSelect:each
 //	@request.body.someField:isset
 //	@collection.product.name
+//
+// The resolved collection fields are cached per app (together with the
+// joins they require) and reused across the resolver instances until
+// the next collections cache reload.
 func (r *RecordFieldResolver) Resolve(fieldName string) (*search.ResolverResult, error) {
-	return parseAndRun(fieldName, r)
+	cacheKey, cacheable := r.resolverCacheKey(fieldName)
+	if !cacheable {
+		return parseAndRun(fieldName, r)
+	}
+
+	if result, ok, err := r.resolveFromCache(cacheKey, fieldName); ok {
+		return result, err
+	}
+
+	var result *search.ResolverResult
+
+	joins, err := r.recordJoins(func() error {
+		var resolveErr error
+		result, resolveErr = parseAndRun(fieldName, r)
+		return resolveErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.storeInCache(cacheKey, result, joins)
+
+	return result, nil
 }
 
 func (r *RecordFieldResolver) resolveStaticRequestField(path ...string) (*search.ResolverResult, error) {
@@ -400,6 +427,10 @@ func (r *RecordFieldResolver) registerJoin(tableName string, tableAlias string,
 		}
 	}
 
+	if r.recordedJoins != nil {
+		*r.recordedJoins = append(*r.recordedJoins, newJoin)
+	}
+
 	// replace existing join
 	for i, j := range r.joins {
 		if j.TableAlias == newJoin.TableAlias {
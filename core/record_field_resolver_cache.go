@@ -0,0 +1,216 @@
+package core
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/store"
+)
+
+// StoreKeyResolverCache is the app store key of the resolved record
+// filter fields and built filter expressions cache
+// (it is reset on each collections cache reload).
+const StoreKeyResolverCache = "pbAppResolverCache"
+
+// resolverCacheMaxElements is the max number of resolved fields and expressions to keep
+// (new entries are ignored after the limit is reached until the next reset).
+const resolverCacheMaxElements = 2000
+
+type resolverCacheEntry struct {
+	collection *Collection
+	result     *search.ResolverResult // for the resolved fields
+	expr       dbx.Expression         // for the built filter expressions
+	joins      []*search.Join
+}
+
+// resolverCache returns the app resolved fields cache store.
+func resolverCache(app App) *store.Store[string, *resolverCacheEntry] {
+	cache, _ := app.Store().GetOrSet(StoreKeyResolverCache, func() any {
+		return store.New[string, *resolverCacheEntry](nil)
+	}).(*store.Store[string, *resolverCacheEntry])
+
+	return cache
+}
+
+// resolverCacheKey returns the cache key of the provided field name
+// and whether its resolved result could be cached.
+//
+// Only the collection fields are cacheable since the resolution of the
+// @request.* fields depends on the current request data and the list
+// rule sub-resolvers use a random join alias suffix.
+//
+// The key also includes the requested locale because the i18n fields
+// are resolved to the localized value identifier.
+func (r *RecordFieldResolver) resolverCacheKey(fieldName string) (string, bool) {
+	if r.app == nil || r.baseCollection == nil || r.joinAliasSuffix != "" || strings.HasPrefix(fieldName, "@request") {
+		return "", false
+	}
+
+	var key strings.Builder
+	key.WriteString(r.baseCollection.Id)
+	key.WriteString("|")
+	key.WriteString(r.baseCollectionAlias)
+	if r.allowHiddenFields {
+		key.WriteString("|1|")
+	} else {
+		key.WriteString("|0|")
+	}
+	if r.requestInfo != nil {
+		key.WriteString(r.requestInfo.Query[I18nQueryParam])
+	}
+	key.WriteString("|")
+	key.WriteString(fieldName)
+
+	return key.String(), true
+}
+
+// resolveFromCache loads the cached result of the provided field
+// and replays its registered joins.
+func (r *RecordFieldResolver) resolveFromCache(cacheKey string, fieldName string) (*search.ResolverResult, bool, error) {
+	cache := resolverCache(r.app)
+	if cache == nil {
+		return nil, false, nil
+	}
+
+	entry, ok := cache.GetOk(cacheKey)
+	if !ok || entry.collection != r.baseCollection || entry.result == nil {
+		return nil, false, nil
+	}
+
+	// the field must be still allowed for the current resolver
+	if len(r.allowedFields) > 0 && !list.ExistInSliceWithRegex(fieldName, r.allowedFields) {
+		return nil, false, nil
+	}
+
+	if err := r.replayJoins(entry.joins); err != nil {
+		return nil, true, err
+	}
+
+	// shallow copy to prevent accidental modifications of the cached result
+	result := *entry.result
+	result.Params = maps.Clone(entry.result.Params)
+
+	return &result, true, nil
+}
+
+// storeInCache caches the resolved field result and its joins.
+func (r *RecordFieldResolver) storeInCache(cacheKey string, result *search.ResolverResult, joins []*search.Join) {
+	stored := *result
+	stored.Params = maps.Clone(result.Params)
+
+	r.storeCacheEntry(cacheKey, &resolverCacheEntry{
+		collection: r.baseCollection,
+		result:     &stored,
+		joins:      joins,
+	})
+}
+
+func (r *RecordFieldResolver) storeCacheEntry(cacheKey string, entry *resolverCacheEntry) {
+	cache := resolverCache(r.app)
+	if cache == nil {
+		return
+	}
+
+	// skip non-persisted or modified collection copies (e.g. during collection validation)
+	cached, _ := r.app.FindCachedCollectionByNameOrId(r.baseCollection.Id)
+	if cached != r.baseCollection {
+		return
+	}
+
+	cache.SetIfLessThanLimit(cacheKey, entry, resolverCacheMaxElements)
+}
+
+// replayJoins registers the provided cached joins.
+func (r *RecordFieldResolver) replayJoins(joins []*search.Join) error {
+	for _, j := range joins {
+		if err := r.registerJoin(j.TableName, j.TableAlias, j.On); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordJoins calls fn and returns the joins registered during its execution.
+func (r *RecordFieldResolver) recordJoins(fn func() error) ([]*search.Join, error) {
+	parent := r.recordedJoins
+
+	joins := []*search.Join{}
+	r.recordedJoins = &joins
+	err := fn()
+	r.recordedJoins = parent
+
+	// propagate to the parent recorder (e.g. a field resolved as part of a filter expression)
+	if parent != nil {
+		*parent = append(*parent, joins...)
+	}
+
+	return joins, err
+}
+
+// exprCacheKey returns the cache key of the provided filter
+// expression key and whether its built expression could be cached.
+//
+// Filters with "@" identifiers other than "@collection.*" are not
+// cacheable since the @request.* fields and the datetime macros
+// (e.g. @now) are resolved to the current request and time values.
+func (r *RecordFieldResolver) exprCacheKey(filterKey string) (string, bool) {
+	if strings.Contains(strings.ReplaceAll(filterKey, "@collection.", ""), "@") {
+		return "", false
+	}
+
+	baseKey, ok := r.resolverCacheKey("@expr")
+	if !ok {
+		return "", false
+	}
+
+	return baseKey + "|" + strings.Join(r.allowedFields, ",") + "|" + filterKey, true
+}
+
+// CachedExpr implements the [search.ExprCacheResolver] interface.
+//
+// The request independent filter expressions are cached per app (together
+// with the joins they require) and reused across the resolver instances
+// until the next collections cache reload.
+func (r *RecordFieldResolver) CachedExpr(filterKey string, build func() (dbx.Expression, error)) (dbx.Expression, error) {
+	cacheKey, cacheable := r.exprCacheKey(filterKey)
+	if !cacheable {
+		return build()
+	}
+
+	cache := resolverCache(r.app)
+	if cache == nil {
+		return build()
+	}
+
+	// note: the built expressions are immutable and safe for concurrent use
+	if entry, ok := cache.GetOk(cacheKey); ok && entry.collection == r.baseCollection && entry.expr != nil {
+		if err := r.replayJoins(entry.joins); err != nil {
+			return nil, err
+		}
+
+		return entry.expr, nil
+	}
+
+	var expr dbx.Expression
+
+	joins, err := r.recordJoins(func() error {
+		var buildErr error
+		expr, buildErr = build()
+		return buildErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.storeCacheEntry(cacheKey, &resolverCacheEntry{
+		collection: r.baseCollection,
+		expr:       expr,
+		joins:      joins,
+	})
+
+	return expr, nil
+}
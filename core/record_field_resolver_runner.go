@@ -208,6 +208,12 @@ func (r *runner) processRequestAuthField() (*search.ResolverResult, error) {
 		return r.resolver.resolveStaticRequestField(r.activeProps[1:]...)
 	}
 
+	// resolve the accepted policies macros
+	// ---
+	if policy, ok := r.acceptedPolicyMacro(); ok {
+		return acceptedPolicyVersionExpr(r.resolver.requestInfo.Auth, policy), nil
+	}
+
 	// resolve the auth collection field
 	// ---
 	collection := r.resolver.requestInfo.Auth.Collection()
@@ -248,6 +254,23 @@ func (r *runner) processRequestAuthField() (*search.ResolverResult, error) {
 	return r.processActiveProps()
 }
 
+// acceptedPolicyMacro returns the policy name of an
+// @request.auth.acceptedTerms or @request.auth.acceptedPolicies.{name} macro.
+func (r *runner) acceptedPolicyMacro() (string, bool) {
+	if len(r.activeProps) < 3 || r.resolver.requestInfo.Auth.Collection().Fields.GetByName(r.activeProps[2]) != nil {
+		return "", false
+	}
+
+	switch {
+	case len(r.activeProps) == 3 && r.activeProps[2] == FieldNameAcceptedTerms:
+		return PolicyTerms, true
+	case len(r.activeProps) == 4 && r.activeProps[2] == FieldNameAcceptedPolicies:
+		return r.activeProps[3], true
+	default:
+		return "", false
+	}
+}
+
 // note: nil value is returned as empty slice
 func toSlice(value any) []any {
 	if value == nil {
@@ -461,20 +484,7 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 		// @todo consider moving to the finalizer and converting to "JSONExtractable" interface with optional extra validation for the remaining props?
 		// json or geoPoint field -> treat the rest of the props as json path
 		if field != nil && (field.Type() == FieldTypeJSON || field.Type() == FieldTypeGeoPoint) {
-			var jsonPath strings.Builder
-			for j, p := range r.activeProps[i+1:] {
-				if _, err := strconv.Atoi(p); err == nil {
-					jsonPath.WriteString("[")
-					jsonPath.WriteString(inflector.Columnify(p))
-					jsonPath.WriteString("]")
-				} else {
-					if j > 0 {
-						jsonPath.WriteString(".")
-					}
-					jsonPath.WriteString(inflector.Columnify(p))
-				}
-			}
-			jsonPathStr := jsonPath.String()
+			jsonPathStr := jsonExtractPath(r.activeProps[i+1:])
 
 			result := &search.ResolverResult{
 				NullFallback: search.NullFallbackDisabled,
@@ -841,6 +851,19 @@ func (r *runner) finalizeActivePropsProcessing(collection *Collection, prop stri
 		}
 	}
 
+	// resolve the localized value of the requested (or default) locale
+	if i18nField, ok := field.(I18nField); ok && len(i18nField.I18nLocales()) > 0 {
+		var lang string
+		if r.resolver.requestInfo != nil {
+			lang = r.resolver.requestInfo.Query[I18nQueryParam]
+		}
+
+		result.Identifier = i18nIdentifier(r.activeTableAlias+"."+cleanFieldName, lang, i18nField.I18nLocales())
+		if r.withMultiMatch {
+			r.multiMatch.ValueIdentifier = i18nIdentifier(r.multiMatchActiveTableAlias+"."+cleanFieldName, lang, i18nField.I18nLocales())
+		}
+	}
+
 	// account for the ":lower" modifier
 	if modifier == lowerModifier {
 		result.Identifier = "LOWER(" + result.Identifier + ")"
@@ -851,3 +874,26 @@ func (r *runner) finalizeActivePropsProcessing(collection *Collection, prop stri
 
 	return result, nil
 }
+
+// jsonExtractPath normalizes and combines the specified json props
+// into a JSON_EXTRACT path (numeric props are treated as array indexes).
+//
+// For example: ["a", "b", "0", "c"] -> "a.b[0].c".
+func jsonExtractPath(props []string) string {
+	var jsonPath strings.Builder
+
+	for i, p := range props {
+		if _, err := strconv.Atoi(p); err == nil {
+			jsonPath.WriteString("[")
+			jsonPath.WriteString(inflector.Columnify(p))
+			jsonPath.WriteString("]")
+		} else {
+			if i > 0 {
+				jsonPath.WriteString(".")
+			}
+			jsonPath.WriteString(inflector.Columnify(p))
+		}
+	}
+
+	return jsonPath.String()
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/list"
@@ -955,3 +956,199 @@ func TestRecordFieldResolverResolveStaticRequestInfoFields(t *testing.T) {
 		t.Fatalf("Expected the original authRecord email to not be exported, got %q", v)
 	}
 }
+
+func TestRecordFieldResolverCache(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.FindCachedCollectionByNameOrId("demo4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := "self_rel_many.title = title && self_rel_one.self_rel_many.id != id && @collection.demo1.text = title"
+
+	build := func(allowHiddenFields bool) (string, error) {
+		r := core.NewRecordFieldResolver(app, collection, nil, allowHiddenFields)
+
+		expr, err := search.FilterData(rule).BuildExpr(r)
+		if err != nil {
+			return "", err
+		}
+
+		query := app.RecordQuery(collection)
+		if err := r.UpdateQuery(query); err != nil {
+			return "", err
+		}
+
+		rawQuery := query.AndWhere(expr).Build().SQL()
+
+		// normalize the random multi-match subquery aliases
+		return regexp.MustCompile(`__sm\w+`).ReplaceAllString(rawQuery, "__smTEST"), nil
+	}
+
+	first, err := build(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !app.Store().Has(core.StoreKeyResolverCache) {
+		t.Fatal("Expected the resolver cache to be initialized")
+	}
+
+	second, err := build(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Fatalf("Expected the cached resolution to produce the same query\n%s\ngot\n%s", first, second)
+	}
+
+	// the joins list rule checks must be still applied for the cached fields
+	// (demo1 has a superusers only list rule)
+	if _, err := build(false); err == nil {
+		t.Fatal("Expected the non-hidden fields resolver to fail")
+	}
+
+	if err := app.ReloadCachedCollections(); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.Store().Has(core.StoreKeyResolverCache) {
+		t.Fatal("Expected the resolver cache to be reset after collections reload")
+	}
+}
+
+func TestRecordFieldResolverCacheI18n(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_i18n")
+	collection.Fields.Add(&core.TextField{Name: "title", I18n: []string{"en", "de"}})
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// use the cached collection instance to allow caching the resolved fields
+	cached, err := app.FindCachedCollectionByNameOrId(collection.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	build := func(lang string) string {
+		requestInfo := &core.RequestInfo{Query: map[string]string{core.I18nQueryParam: lang}}
+
+		r := core.NewRecordFieldResolver(app, cached, requestInfo, true)
+
+		expr, err := search.FilterData("title = 'test'").BuildExpr(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return app.RecordQuery(cached).AndWhere(expr).Build().SQL()
+	}
+
+	scenarios := []struct {
+		lang     string
+		expected string
+	}{
+		{"en", `'$."en"'`},
+		{"de", `'$."de"'`},
+		{"en", `'$."en"'`},
+	}
+
+	for i, s := range scenarios {
+		if rawQuery := build(s.lang); !strings.Contains(rawQuery, s.expected) {
+			t.Fatalf("[%d] Expected the %q identifier, got\n%s", i, s.expected, rawQuery)
+		}
+	}
+
+	if !app.Store().Has(core.StoreKeyResolverCache) {
+		t.Fatal("Expected the resolver cache to be initialized")
+	}
+}
+
+func TestRecordFieldResolverExprCache(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.FindCachedCollectionByNameOrId("demo4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err := app.FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestInfo := &core.RequestInfo{Auth: auth}
+
+	build := func(filter string) (dbx.Expression, string) {
+		r := core.NewRecordFieldResolver(app, collection, requestInfo, true)
+
+		expr, err := search.FilterData(filter).BuildExpr(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		query := app.RecordQuery(collection)
+		if err := r.UpdateQuery(query); err != nil {
+			t.Fatal(err)
+		}
+
+		rawQuery := query.AndWhere(expr).Build().SQL()
+
+		// normalize the random multi-match subquery aliases
+		return expr, regexp.MustCompile(`__sm\w+`).ReplaceAllString(rawQuery, "__smTEST")
+	}
+
+	scenarios := []struct {
+		filter         string
+		expectedCached bool
+	}{
+		{"self_rel_one.title = 'test' && self_rel_many.id != id", true},
+		{"@collection.demo1.text = title && title != ''", true},
+		{"@request.auth.id != '' && title != ''", false},
+		{"created > @now && title != ''", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.filter, func(t *testing.T) {
+			expr1, query1 := build(s.filter)
+			expr2, query2 := build(s.filter)
+
+			// the built expression should be reused as it is
+			if cached := expr1 == expr2; cached != s.expectedCached {
+				t.Fatalf("Expected cached %v, got %v", s.expectedCached, cached)
+			}
+
+			// the cached joins must be applied to the new resolver
+			if s.expectedCached && query1 != query2 {
+				t.Fatalf("Expected the cached expression to produce the same query\n%s\ngot\n%s", query1, query2)
+			}
+		})
+	}
+
+	expr1, _ := build("title != ''")
+
+	if err := app.ReloadCachedCollections(); err != nil {
+		t.Fatal(err)
+	}
+
+	collection, err = app.FindCachedCollectionByNameOrId("demo4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expr2, _ := build("title != ''"); expr1 == expr2 {
+		t.Fatal("Expected the cached expressions to be reset after collections reload")
+	}
+}
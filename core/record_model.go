@@ -3,6 +3,8 @@ package core
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -1123,6 +1125,33 @@ func (m *Record) DBExport(app App) (map[string]any, error) {
 	return result, nil
 }
 
+// ETag returns a strong HTTP entity tag (quoted hex string) derived
+// from the collection id and the current record fields db values.
+//
+// The tag changes whenever any of the record fields (including the hidden ones) change,
+// so it could be used for conditional requests and optimistic concurrency checks.
+//
+// Note that the expanded relations and custom data are not part of the tag.
+func (m *Record) ETag() (string, error) {
+	data, err := m.dbExport()
+	if err != nil {
+		return "", err
+	}
+
+	// note: the map keys are sorted when serialized
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(m.Collection().Id))
+	h.Write([]byte{0})
+	h.Write(raw)
+
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`, nil
+}
+
 func (m *Record) dbExport() (map[string]any, error) {
 	fields := m.Collection().Fields
 
@@ -1291,6 +1291,61 @@ func TestRecordDBExport(t *testing.T) {
 	}
 }
 
+func TestRecordETag(t *testing.T) {
+	t.Parallel()
+
+	col1 := core.NewBaseCollection("test1")
+	col1.Id = "test1_id"
+	col1.Fields.Add(&core.TextField{Name: "title"}, &core.TextField{Name: "secret", Hidden: true})
+
+	col2 := core.NewBaseCollection("test2")
+	col2.Id = "test2_id"
+	col2.Fields.Add(&core.TextField{Name: "title"}, &core.TextField{Name: "secret", Hidden: true})
+
+	newRecord := func(col *core.Collection, title string, secret string) *core.Record {
+		record := core.NewRecord(col)
+		record.Id = "test_id"
+		record.Set("title", title)
+		record.Set("secret", secret)
+		record.Set("unknown", "abc") // custom data must be ignored
+		return record
+	}
+
+	base, err := newRecord(col1, "a", "b").ETag()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(base) != 34 || !strings.HasPrefix(base, `"`) || !strings.HasSuffix(base, `"`) {
+		t.Fatalf("Expected 32 hex characters quoted ETag, got %q", base)
+	}
+
+	scenarios := []struct {
+		name          string
+		record        *core.Record
+		expectedEqual bool
+	}{
+		{"same data", newRecord(col1, "a", "b"), true},
+		{"different custom data", newRecord(col1, "a", "b").WithCustomData(true), true},
+		{"different field value", newRecord(col1, "a2", "b"), false},
+		{"different hidden field value", newRecord(col1, "a", "b2"), false},
+		{"different collection", newRecord(col2, "a", "b"), false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			etag, err := s.record.ETag()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if equal := etag == base; equal != s.expectedEqual {
+				t.Fatalf("Expected equal %v, got %v (%q vs %q)", s.expectedEqual, equal, etag, base)
+			}
+		})
+	}
+}
+
 func TestRecordIgnoreUnchangedFields(t *testing.T) {
 	t.Parallel()
 
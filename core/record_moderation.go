@@ -0,0 +1,151 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+// Supported record moderation states.
+const (
+	ModerationStateDraft    = "draft"
+	ModerationStatePending  = "pending"
+	ModerationStateApproved = "approved"
+	ModerationStateRejected = "rejected"
+)
+
+// ModerationStates lists all supported record moderation states.
+var ModerationStates = []string{
+	ModerationStateDraft,
+	ModerationStatePending,
+	ModerationStateApproved,
+	ModerationStateRejected,
+}
+
+// Names of the system fields of collections with enabled moderation.
+const (
+	FieldNameModerationState    = "moderationState"
+	FieldNameModerationReviewer = "moderationReviewer"
+)
+
+// ModerateRecord changes the moderation state of the specified record
+// and persists it by triggering the [App.OnRecordModerate] hook.
+//
+// If optReviewerId is set, the record reviewer is also replaced
+// (an empty string unassigns the current reviewer).
+//
+// Note that only the transition itself is checked and NOT the collection
+// moderation API rules (see [CollectionModeration.TransitionRule]).
+func (app *BaseApp) ModerateRecord(record *Record, state string, optReviewerId ...string) error {
+	return app.ModerateRecordWithContext(context.Background(), record, state, optReviewerId...)
+}
+
+// ModerateRecordWithContext is the same as [BaseApp.ModerateRecord]
+// but allows specifying a custom context.
+func (app *BaseApp) ModerateRecordWithContext(ctx context.Context, record *Record, state string, optReviewerId ...string) error {
+	collection := record.Collection()
+
+	if !collection.Moderation.Enabled {
+		return fmt.Errorf("the moderation of collection %q is not enabled", collection.Name)
+	}
+
+	from := record.GetString(FieldNameModerationState)
+
+	if _, ok := collection.Moderation.TransitionRule(from, state); !ok {
+		return fmt.Errorf("the moderation state transition from %q to %q is not allowed", from, state)
+	}
+
+	event := new(RecordModerateEvent)
+	event.App = app
+	event.Context = ctx
+	event.Record = record
+	event.FromState = from
+	event.ToState = state
+	event.Reviewer = record.GetString(FieldNameModerationReviewer)
+
+	if len(optReviewerId) > 0 {
+		if optReviewerId[0] != "" && collection.Moderation.ReviewersCollection == "" {
+			return errors.New("the collection doesn't have a reviewers collection")
+		}
+		event.Reviewer = optReviewerId[0]
+	}
+
+	return app.OnRecordModerate().Trigger(event, func(e *RecordModerateEvent) error {
+		e.Record.Set(FieldNameModerationState, e.ToState)
+
+		if e.Record.Collection().Fields.GetByName(FieldNameModerationReviewer) != nil {
+			e.Record.Set(FieldNameModerationReviewer, e.Reviewer)
+		}
+
+		return e.App.SaveWithContext(e.Context, e.Record)
+	})
+}
+
+func (app *BaseApp) registerModerationHooks() {
+	// set the default moderation state of the new records
+	app.OnRecordCreate().Bind(&hook.Handler[*RecordEvent]{
+		Func: func(e *RecordEvent) error {
+			moderation := e.Record.Collection().Moderation
+
+			if moderation.Enabled && e.Record.GetString(FieldNameModerationState) == "" {
+				e.Record.Set(FieldNameModerationState, moderation.DefaultState())
+			}
+
+			return e.Next()
+		},
+		Priority: -100, // execute before the field interceptors
+	})
+}
+
+// initModerationFields ensures that the collection moderation system fields exist.
+//
+// If the moderation is disabled, the system flag of the existing
+// moderation fields is removed so that they could be deleted.
+func (c *Collection) initModerationFields() {
+	stateField, _ := c.Fields.GetByName(FieldNameModerationState).(*SelectField)
+	reviewerField, _ := c.Fields.GetByName(FieldNameModerationReviewer).(*RelationField)
+
+	if !c.Moderation.Enabled {
+		if stateField != nil {
+			stateField.System = false
+		}
+		if reviewerField != nil {
+			reviewerField.System = false
+		}
+		return
+	}
+
+	if stateField == nil {
+		c.Fields.Add(&SelectField{
+			Name:      FieldNameModerationState,
+			System:    true,
+			MaxSelect: 1,
+			Values:    slices.Clone(ModerationStates),
+		})
+	} else {
+		// enforce system defaults
+		stateField.System = true
+		stateField.MaxSelect = 1
+		stateField.Values = slices.Clone(ModerationStates)
+	}
+
+	if reviewerField == nil {
+		if c.Moderation.ReviewersCollection != "" {
+			c.Fields.Add(&RelationField{
+				Name:         FieldNameModerationReviewer,
+				System:       true,
+				MaxSelect:    1,
+				CollectionId: c.Moderation.ReviewersCollection,
+			})
+		}
+	} else {
+		reviewerField.System = c.Moderation.ReviewersCollection != ""
+		if reviewerField.System {
+			reviewerField.MaxSelect = 1
+			reviewerField.CollectionId = c.Moderation.ReviewersCollection
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestCollectionModerationTransitionRule(t *testing.T) {
+	t.Parallel()
+
+	moderation := core.CollectionModeration{
+		SubmitRule: types.Pointer("submit"),
+		ReviewRule: types.Pointer("review"),
+	}
+
+	scenarios := []struct {
+		from          string
+		to            string
+		expectedRule  string
+		expectAllowed bool
+	}{
+		{"", "pending", "submit", true},
+		{"", "approved", "", false},
+		{"draft", "pending", "submit", true},
+		{"draft", "approved", "", false},
+		{"draft", "rejected", "", false},
+		{"pending", "draft", "submit", true},
+		{"pending", "approved", "review", true},
+		{"pending", "rejected", "review", true},
+		{"approved", "pending", "review", true},
+		{"approved", "rejected", "review", true},
+		{"approved", "draft", "", false},
+		{"rejected", "draft", "submit", true},
+		{"rejected", "pending", "submit", true},
+		{"rejected", "approved", "", false},
+		{"pending", "pending", "review", true},
+		{"pending", "invalid", "", false},
+		{"invalid", "invalid", "", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.from+"_"+s.to, func(t *testing.T) {
+			rule, allowed := moderation.TransitionRule(s.from, s.to)
+
+			if allowed != s.expectAllowed {
+				t.Fatalf("Expected allowed %v, got %v", s.expectAllowed, allowed)
+			}
+
+			if !allowed {
+				return
+			}
+
+			if rule == nil || *rule != s.expectedRule {
+				t.Fatalf("Expected rule %q, got %v", s.expectedRule, rule)
+			}
+		})
+	}
+}
+
+func TestCollectionModerationFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, err := app.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection := core.NewBaseCollection("test_moderation")
+	collection.Moderation = core.CollectionModeration{Enabled: true, ReviewersCollection: users.Id}
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	stateField, _ := collection.Fields.GetByName(core.FieldNameModerationState).(*core.SelectField)
+	if stateField == nil || !stateField.System || len(stateField.Values) != len(core.ModerationStates) {
+		t.Fatalf("Expected system %q select field, got %#v", core.FieldNameModerationState, stateField)
+	}
+
+	reviewerField, _ := collection.Fields.GetByName(core.FieldNameModerationReviewer).(*core.RelationField)
+	if reviewerField == nil || !reviewerField.System || reviewerField.CollectionId != users.Id {
+		t.Fatalf("Expected system %q relation field, got %#v", core.FieldNameModerationReviewer, reviewerField)
+	}
+
+	// new records should have the default state
+	record := core.NewRecord(collection)
+	if err := app.Save(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString(core.FieldNameModerationState); v != core.ModerationStateDraft {
+		t.Fatalf("Expected the new record state to be %q, got %q", core.ModerationStateDraft, v)
+	}
+
+	// disable moderation
+	collection.Moderation.Enabled = false
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{core.FieldNameModerationState, core.FieldNameModerationReviewer} {
+		if f := collection.Fields.GetByName(name); f == nil || f.GetSystem() {
+			t.Fatalf("Expected %q field to exist and to be non-system, got %#v", name, f)
+		}
+	}
+
+	// the fields should be now removable
+	collection.Fields.RemoveByName(core.FieldNameModerationState)
+	collection.Fields.RemoveByName(core.FieldNameModerationReviewer)
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("Expected the moderation fields to be removed, got %v", err)
+	}
+}
+
+func TestModerateRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, err := app.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	demo2, err := app.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.FindFirstRecordByFilter(demo2, "id != ''")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// not enabled moderation
+	if err := app.ModerateRecord(record, core.ModerationStatePending); err == nil {
+		t.Fatal("Expected error for collection without enabled moderation")
+	}
+
+	demo2.Moderation = core.CollectionModeration{Enabled: true}
+	if err := app.Save(demo2); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err = app.FindRecordById(demo2, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// not allowed transition (the existing record has no state and it is treated as draft)
+	if err := app.ModerateRecord(record, core.ModerationStateApproved); err == nil {
+		t.Fatal("Expected not allowed transition error")
+	}
+
+	// missing reviewers collection
+	if err := app.ModerateRecord(record, core.ModerationStatePending, "test"); err == nil {
+		t.Fatal("Expected missing reviewers collection error")
+	}
+
+	if err := app.ModerateRecord(record, core.ModerationStatePending); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.EventCalls["OnRecordModerate"] != 1 {
+		t.Fatalf("Expected OnRecordModerate to be called once, got %d", app.EventCalls["OnRecordModerate"])
+	}
+
+	// with reviewer
+	demo2.Moderation.ReviewersCollection = users.Id
+	if err := app.Save(demo2); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err = app.FindRecordById(demo2, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app.OnRecordModerate("demo2").BindFunc(func(e *core.RecordModerateEvent) error {
+		if e.FromState != core.ModerationStatePending || e.ToState != core.ModerationStateApproved {
+			t.Fatalf("Expected pending -> approved transition, got %q -> %q", e.FromState, e.ToState)
+		}
+		return e.Next()
+	})
+
+	if err := app.ModerateRecord(record, core.ModerationStateApproved, "4q1xlclmfloku33"); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err = app.FindRecordById(demo2, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := record.GetString(core.FieldNameModerationState); v != core.ModerationStateApproved {
+		t.Fatalf("Expected state %q, got %q", core.ModerationStateApproved, v)
+	}
+
+	if v := record.GetString(core.FieldNameModerationReviewer); v != "4q1xlclmfloku33" {
+		t.Fatalf("Expected reviewer %q, got %q", "4q1xlclmfloku33", v)
+	}
+}
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+)
+
+// orderMoveCtxKey marks the record saves of [BaseApp.MoveOrderedRecord]
+// to skip the OrderField scope change auto positioning.
+type orderMoveCtxKey struct{}
+
+// MoveOrderedRecord moves the record right before (or after) the target record
+// in the ordering of the specified "order" field and rebalances the positions
+// of all records in the target scope to 1..n.
+//
+// When moved to another scope, the positions of the previous scope records are also rebalanced.
+//
+// If the field has a scope, the record is moved in the target record scope.
+//
+// If targetId is empty, the record is moved at the start (or the end if after is true) of its current scope.
+func (app *BaseApp) MoveOrderedRecord(record *Record, fieldName string, targetId string, after bool) error {
+	return app.MoveOrderedRecordWithContext(context.Background(), record, fieldName, targetId, after)
+}
+
+// MoveOrderedRecordWithContext is the same as [BaseApp.MoveOrderedRecord]
+// but allows specifying a custom context.
+func (app *BaseApp) MoveOrderedRecordWithContext(ctx context.Context, record *Record, fieldName string, targetId string, after bool) error {
+	collection := record.Collection()
+
+	field, ok := collection.Fields.GetByName(fieldName).(*OrderField)
+	if !ok {
+		return fmt.Errorf("missing order field %q in collection %q", fieldName, collection.Name)
+	}
+
+	if targetId == record.Id {
+		return errors.New("the record cannot be moved relative to itself")
+	}
+
+	ctx = context.WithValue(ctx, orderMoveCtxKey{}, true)
+
+	return app.RunInTransactionWithContext(ctx, func(txApp App) error {
+		// the record copy with its current scope
+		oldScopeRecord := record.Fresh()
+
+		if targetId != "" {
+			target, err := txApp.FindRecordById(collection, targetId)
+			if err != nil {
+				return fmt.Errorf("missing target record %q: %w", targetId, err)
+			}
+
+			if field.Scope != "" {
+				record.Set(field.Scope, target.Get(field.Scope))
+			}
+		}
+
+		records, err := findOrderScopeRecords(ctx, txApp, field, record)
+		if err != nil {
+			return err
+		}
+
+		index := 0
+		if after {
+			index = len(records)
+		}
+
+		if targetId != "" {
+			index = slices.IndexFunc(records, func(r *Record) bool {
+				return r.Id == targetId
+			})
+			if after {
+				index++
+			}
+		}
+
+		records = slices.Insert(records, index, record)
+
+		// save first the moved record because of its possible scope change and validations
+		record.Set(field.Name, index+1)
+		if err := txApp.SaveWithContext(ctx, record); err != nil {
+			return err
+		}
+
+		if err := rebalanceOrderPositions(ctx, txApp, field, records, record); err != nil {
+			return err
+		}
+
+		// close the gap in the previous scope
+		if field.Scope != "" && oldScopeRecord.GetString(field.Scope) != record.GetString(field.Scope) {
+			oldScopeRecords, err := findOrderScopeRecords(ctx, txApp, field, oldScopeRecord)
+			if err != nil {
+				return err
+			}
+
+			return rebalanceOrderPositions(ctx, txApp, field, oldScopeRecords, nil)
+		}
+
+		return nil
+	})
+}
+
+// findOrderScopeRecords returns the records from the same order scope as
+// the provided record (excluding the record itself) sorted by their position.
+func findOrderScopeRecords(ctx context.Context, app App, field *OrderField, record *Record) ([]*Record, error) {
+	collection := record.Collection()
+
+	tableName := inflector.Columnify(collection.Name)
+
+	records := []*Record{}
+
+	err := app.RecordQuery(collection).
+		WithContext(ctx).
+		AndWhere(field.scopeExpr(tableName, record)).
+		AndWhere(dbx.Not(dbx.HashExp{tableName + ".id": record.Id})).
+		OrderBy(
+			"[["+tableName+"."+field.Name+"]] ASC",
+			"[["+tableName+".id]] ASC",
+		).
+		All(&records)
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// rebalanceOrderPositions sets the positions of the provided sorted records to 1..n
+// saving only the changed ones (the skip record is expected to be already saved).
+func rebalanceOrderPositions(ctx context.Context, app App, field *OrderField, records []*Record, skip *Record) error {
+	for i, r := range records {
+		if r == skip || r.GetInt(field.Name) == i+1 {
+			continue
+		}
+
+		r.Set(field.Name, i+1)
+
+		if err := app.SaveNoValidateWithContext(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package core_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createOrderCollection(t testing.TB, app core.App) *core.Collection {
+	collection := core.NewBaseCollection("test_order")
+	collection.Fields.Add(
+		&core.TextField{Name: "column"},
+		&core.OrderField{Name: "position", Scope: "column"},
+	)
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []struct{ id, column string }{
+		{"a00000000000000", "x"},
+		{"b00000000000000", "x"},
+		{"c00000000000000", "x"},
+		{"d00000000000000", "y"},
+	}
+	for _, d := range data {
+		record := core.NewRecord(collection)
+		record.Id = d.id
+		record.Set("column", d.column)
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return collection
+}
+
+func checkOrder(t *testing.T, app core.App, column string, expected []string) {
+	t.Helper()
+
+	records, err := app.FindRecordsByFilter("test_order", "column = {:col}", "position", 0, 0, map[string]any{"col": column})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i] = r.Id
+
+		if v := r.GetInt("position"); v != i+1 {
+			t.Fatalf("Expected %s position %d, got %d", r.Id, i+1, v)
+		}
+	}
+
+	if !slices.Equal(ids, expected) {
+		t.Fatalf("Expected %v order, got %v", expected, ids)
+	}
+}
+
+func TestMoveOrderedRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createOrderCollection(t, app)
+
+	find := func(id string) *core.Record {
+		record, err := app.FindRecordById(collection, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return record
+	}
+
+	// c before a
+	if err := app.MoveOrderedRecord(find("c00000000000000"), "position", "a00000000000000", false); err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(t, app, "x", []string{"c00000000000000", "a00000000000000", "b00000000000000"})
+
+	// c after a
+	if err := app.MoveOrderedRecord(find("c00000000000000"), "position", "a00000000000000", true); err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(t, app, "x", []string{"a00000000000000", "c00000000000000", "b00000000000000"})
+
+	// b at the start
+	if err := app.MoveOrderedRecord(find("b00000000000000"), "position", "", false); err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(t, app, "x", []string{"b00000000000000", "a00000000000000", "c00000000000000"})
+
+	// b at the end
+	if err := app.MoveOrderedRecord(find("b00000000000000"), "position", "", true); err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(t, app, "x", []string{"a00000000000000", "c00000000000000", "b00000000000000"})
+
+	// a before d (another scope)
+	if err := app.MoveOrderedRecord(find("a00000000000000"), "position", "d00000000000000", false); err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(t, app, "x", []string{"c00000000000000", "b00000000000000"})
+	checkOrder(t, app, "y", []string{"a00000000000000", "d00000000000000"})
+
+	// errors
+	if err := app.MoveOrderedRecord(find("a00000000000000"), "position", "a00000000000000", false); err == nil {
+		t.Fatal("Expected self target error")
+	}
+	if err := app.MoveOrderedRecord(find("a00000000000000"), "position", "missing", false); err == nil {
+		t.Fatal("Expected missing target error")
+	}
+	if err := app.MoveOrderedRecord(find("a00000000000000"), "column", "d00000000000000", false); err == nil {
+		t.Fatal("Expected non-order field error")
+	}
+}
@@ -0,0 +1,242 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// Names of the system fields of collections with enabled scheduled publishing.
+const (
+	FieldNamePublishAt   = "publishAt"
+	FieldNameUnpublishAt = "unpublishAt"
+)
+
+// Supported [RecordPublishEvent] actions.
+const (
+	RecordPublishActionPublish   = "publish"
+	RecordPublishActionUnpublish = "unpublish"
+)
+
+const publishingCronId = "__pbPublishing__"
+
+// publishingCronExpr is the schedule of the publishing boundaries check.
+const publishingCronExpr = "* * * * *"
+
+// publishingLastRunStoreKey is the app store key of the last publishing cron run time.
+const publishingLastRunStoreKey = "pbAppPublishingLastRun"
+
+// IsPublished reports whether the record is currently within its publishing window.
+//
+// Always returns true for records of collections without enabled publishing.
+func (m *Record) IsPublished() bool {
+	return m.IsPublishedAt(time.Now())
+}
+
+// IsPublishedAt reports whether the record is within its publishing window at the specified time,
+// aka. its publishAt is empty or <= t and its unpublishAt is empty or > t.
+//
+// Always returns true for records of collections without enabled publishing.
+func (m *Record) IsPublishedAt(t time.Time) bool {
+	if m.collection == nil || !m.collection.Publishing.Enabled {
+		return true
+	}
+
+	publishAt := m.GetDateTime(FieldNamePublishAt)
+	if !publishAt.IsZero() && publishAt.Time().After(t) {
+		return false
+	}
+
+	unpublishAt := m.GetDateTime(FieldNameUnpublishAt)
+	if !unpublishAt.IsZero() && !unpublishAt.Time().After(t) {
+		return false
+	}
+
+	return true
+}
+
+// PublishedRecordsExpr returns a db expression that filters the records
+// of the specified collection table to only those within their
+// publishing window at the specified time (see [Record.IsPublishedAt]).
+func PublishedRecordsExpr(tableName string, t time.Time) dbx.Expression {
+	publishAt := "[[" + tableName + "." + FieldNamePublishAt + "]]"
+	unpublishAt := "[[" + tableName + "." + FieldNameUnpublishAt + "]]"
+
+	dt, _ := types.ParseDateTime(t)
+
+	return dbx.NewExp(
+		"(COALESCE("+publishAt+", '') = '' OR "+publishAt+" <= {:pbPublishingTime}) AND "+
+			"(COALESCE("+unpublishAt+", '') = '' OR "+unpublishAt+" > {:pbPublishingTime})",
+		dbx.Params{"pbPublishingTime": dt.String()},
+	)
+}
+
+// PublishScheduledRecords triggers the [App.OnRecordPublish] hook for all records
+// whose publishAt or unpublishAt time is within the (from, to] interval
+// and returns the total number of the triggered events.
+//
+// The "publish" events are triggered before the "unpublish" ones.
+func (app *BaseApp) PublishScheduledRecords(ctx context.Context, from time.Time, to time.Time) (int, error) {
+	collections, err := app.FindAllCollections(CollectionTypeBase, CollectionTypeAuth)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	var errs []error
+
+	fromDate, _ := types.ParseDateTime(from)
+	toDate, _ := types.ParseDateTime(to)
+
+	params := dbx.Params{
+		"pbPublishingFrom": fromDate.String(),
+		"pbPublishingTo":   toDate.String(),
+	}
+
+	for _, collection := range collections {
+		if !collection.Publishing.Enabled {
+			continue
+		}
+
+		for _, item := range []struct{ field, action string }{
+			{FieldNamePublishAt, RecordPublishActionPublish},
+			{FieldNameUnpublishAt, RecordPublishActionUnpublish},
+		} {
+			col := "[[" + collection.Name + "." + item.field + "]]"
+
+			records := []*Record{}
+
+			err := app.RecordQuery(collection).
+				WithContext(ctx).
+				AndWhere(dbx.NewExp(col+" > {:pbPublishingFrom} AND "+col+" <= {:pbPublishingTo}", params)).
+				OrderBy(col + " ASC").
+				All(&records)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			for _, record := range records {
+				event := new(RecordPublishEvent)
+				event.App = app
+				event.Context = ctx
+				event.Record = record
+				event.Action = item.action
+
+				err := app.OnRecordPublish().Trigger(event, func(e *RecordPublishEvent) error {
+					return nil
+				})
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+
+				total++
+			}
+		}
+	}
+
+	return total, errors.Join(errs...)
+}
+
+func (app *BaseApp) registerPublishingHooks() {
+	loadJob := func() {
+		collections, err := app.FindAllCollections(CollectionTypeBase, CollectionTypeAuth)
+		if err != nil {
+			app.Logger().Error("[Publishing cron] Failed to load the collections", slog.String("error", err.Error()))
+			return
+		}
+
+		var hasPublishing bool
+		for _, c := range collections {
+			if c.Publishing.Enabled {
+				hasPublishing = true
+				break
+			}
+		}
+
+		if !hasPublishing {
+			app.Cron().Remove(publishingCronId)
+			app.Store().Remove(publishingLastRunStoreKey)
+			return
+		}
+
+		app.Cron().Add(publishingCronId, publishingCronExpr, func() {
+			now := time.Now()
+
+			from, ok := app.Store().Get(publishingLastRunStoreKey).(time.Time)
+			if !ok {
+				from = now.Add(-1 * time.Minute)
+			}
+
+			app.Store().Set(publishingLastRunStoreKey, now)
+
+			total, err := app.PublishScheduledRecords(context.Background(), from, now)
+			if err != nil {
+				app.Logger().Error(
+					"[Publishing cron] Failed to process some of the scheduled records",
+					slog.String("error", err.Error()),
+				)
+			}
+
+			if total > 0 {
+				app.Logger().Debug("[Publishing cron] Processed scheduled records", slog.Int("total", total))
+			}
+		})
+	}
+
+	app.OnBootstrap().BindFunc(func(e *BootstrapEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	})
+
+	reloadJob := func(e *CollectionEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		loadJob()
+
+		return nil
+	}
+
+	app.OnCollectionAfterCreateSuccess().BindFunc(reloadJob)
+	app.OnCollectionAfterUpdateSuccess().BindFunc(reloadJob)
+	app.OnCollectionAfterDeleteSuccess().BindFunc(reloadJob)
+}
+
+// initPublishingFields ensures that the collection publishing system fields exist.
+//
+// If the publishing is disabled, the system flag of the existing
+// publishing fields is removed so that they could be deleted.
+func (c *Collection) initPublishingFields() {
+	for _, name := range []string{FieldNamePublishAt, FieldNameUnpublishAt} {
+		field, _ := c.Fields.GetByName(name).(*DateField)
+
+		if !c.Publishing.Enabled {
+			if field != nil {
+				field.System = false
+			}
+			continue
+		}
+
+		if field == nil {
+			c.Fields.Add(&DateField{
+				Name:   name,
+				System: true,
+			})
+		} else {
+			// enforce system defaults
+			field.System = true
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package core_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/cron"
+)
+
+func TestRecordIsPublishedAt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	collection := core.NewBaseCollection("test")
+	collection.Publishing.Enabled = true
+	collection.Fields.Add(
+		&core.DateField{Name: core.FieldNamePublishAt},
+		&core.DateField{Name: core.FieldNameUnpublishAt},
+	)
+
+	scenarios := []struct {
+		name        string
+		disabled    bool
+		publishAt   time.Time
+		unpublishAt time.Time
+		expected    bool
+	}{
+		{"disabled publishing", true, now.Add(time.Hour), time.Time{}, true},
+		{"empty window", false, time.Time{}, time.Time{}, true},
+		{"past publishAt", false, now.Add(-time.Hour), time.Time{}, true},
+		{"publishAt equal to now", false, now, time.Time{}, true},
+		{"future publishAt", false, now.Add(time.Hour), time.Time{}, false},
+		{"future unpublishAt", false, time.Time{}, now.Add(time.Hour), true},
+		{"unpublishAt equal to now", false, time.Time{}, now, false},
+		{"past unpublishAt", false, time.Time{}, now.Add(-time.Hour), false},
+		{"within window", false, now.Add(-time.Hour), now.Add(time.Hour), true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			c := collection
+			if s.disabled {
+				c = core.NewBaseCollection("test_disabled")
+				c.Fields = collection.Fields
+			}
+
+			record := core.NewRecord(c)
+			if !s.publishAt.IsZero() {
+				record.Set(core.FieldNamePublishAt, s.publishAt)
+			}
+			if !s.unpublishAt.IsZero() {
+				record.Set(core.FieldNameUnpublishAt, s.unpublishAt)
+			}
+
+			if v := record.IsPublishedAt(now); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestCollectionPublishingFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := core.NewBaseCollection("test_publishing")
+	collection.Publishing.Enabled = true
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{core.FieldNamePublishAt, core.FieldNameUnpublishAt} {
+		if f, _ := collection.Fields.GetByName(name).(*core.DateField); f == nil || !f.System {
+			t.Fatalf("Expected system %q date field, got %#v", name, f)
+		}
+	}
+
+	// disable publishing
+	collection.Publishing.Enabled = false
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{core.FieldNamePublishAt, core.FieldNameUnpublishAt} {
+		if f := collection.Fields.GetByName(name); f == nil || f.GetSystem() {
+			t.Fatalf("Expected %q field to exist and to be non-system, got %#v", name, f)
+		}
+	}
+
+	// the fields should be now removable
+	collection.Fields.RemoveByName(core.FieldNamePublishAt)
+	collection.Fields.RemoveByName(core.FieldNameUnpublishAt)
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("Expected the publishing fields to be removed, got %v", err)
+	}
+}
+
+func TestPublishedRecordsExpr(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now()
+
+	collection := core.NewBaseCollection("test_publishing")
+	collection.Publishing.Enabled = true
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	windows := map[string][2]time.Time{
+		"empty":     {},
+		"published": {now.Add(-time.Hour), now.Add(time.Hour)},
+		"scheduled": {now.Add(time.Hour), time.Time{}},
+		"expired":   {time.Time{}, now.Add(-time.Hour)},
+	}
+
+	for id, w := range windows {
+		record := core.NewRecord(collection)
+		record.Id = (id + "000000000000000")[:15]
+		if !w[0].IsZero() {
+			record.Set(core.FieldNamePublishAt, w[0])
+		}
+		if !w[1].IsZero() {
+			record.Set(core.FieldNameUnpublishAt, w[1])
+		}
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records := []*core.Record{}
+	err := app.RecordQuery(collection).
+		AndWhere(core.PublishedRecordsExpr(collection.Name, now)).
+		OrderBy("id").
+		All(&records)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i] = r.Id
+	}
+
+	expected := []string{"empty0000000000", "published000000"}
+	if !slices.Equal(ids, expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+}
+
+func TestPublishScheduledRecords(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now()
+
+	collection := core.NewBaseCollection("test_publishing")
+	collection.Publishing.Enabled = true
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.ContainsFunc(app.Cron().Jobs(), func(j *cron.Job) bool { return j.Id() == "__pbPublishing__" }) {
+		t.Fatal("Expected the publishing cron job to be registered")
+	}
+
+	windows := [][2]time.Time{
+		{now.Add(-30 * time.Second), time.Time{}},                // publish
+		{now.Add(-2 * time.Minute), now.Add(-10 * time.Second)},  // unpublish
+		{now.Add(-20 * time.Second), now.Add(-10 * time.Second)}, // publish + unpublish
+		{now.Add(10 * time.Second), time.Time{}},                 // not reached yet
+		{now.Add(-2 * time.Minute), now.Add(time.Minute)},        // already published
+	}
+
+	for _, w := range windows {
+		record := core.NewRecord(collection)
+		if !w[0].IsZero() {
+			record.Set(core.FieldNamePublishAt, w[0])
+		}
+		if !w[1].IsZero() {
+			record.Set(core.FieldNameUnpublishAt, w[1])
+		}
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	actions := []string{}
+	app.OnRecordPublish(collection.Name).BindFunc(func(e *core.RecordPublishEvent) error {
+		actions = append(actions, e.Action)
+		return e.Next()
+	})
+
+	total, err := app.PublishScheduledRecords(context.Background(), now.Add(-time.Minute), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 4 {
+		t.Fatalf("Expected 4 triggered events, got %d", total)
+	}
+
+	expectedActions := []string{
+		core.RecordPublishActionPublish,
+		core.RecordPublishActionPublish,
+		core.RecordPublishActionUnpublish,
+		core.RecordPublishActionUnpublish,
+	}
+	if !slices.Equal(actions, expectedActions) {
+		t.Fatalf("Expected actions %v, got %v", expectedActions, actions)
+	}
+
+	if app.EventCalls["OnRecordPublish"] != 4 {
+		t.Fatalf("Expected OnRecordPublish to be called 4 times, got %d", app.EventCalls["OnRecordPublish"])
+	}
+
+	// disable publishing
+	collection.Publishing.Enabled = false
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if slices.ContainsFunc(app.Cron().Jobs(), func(j *cron.Job) bool { return j.Id() == "__pbPublishing__" }) {
+		t.Fatal("Expected the publishing cron job to be removed")
+	}
+}
@@ -45,7 +45,7 @@ func (app *BaseApp) RecordQuery(collectionModelOrIdentifier any) *dbx.SelectQuer
 	}
 
 	return query.WithBuildHook(func(q *dbx.Query) {
-		q.WithExecHook(execLockRetry(app.config.QueryTimeout, defaultMaxLockRetries)).
+		q.WithExecHook(app.queryExecHook()).
 			WithOneHook(func(q *dbx.Query, a any, op func(b any) error) error {
 				if a == nil {
 					return op(a)
@@ -205,6 +205,20 @@ func getCollectionByModelOrIdentifier(app App, collectionModelOrIdentifier any)
 	}
 }
 
+// recordQueryWithContext creates a new Record select query and associates ctx with it.
+//
+// The ctx is not applied if the query was already canceled because of an
+// invalid collection identifier (see [BaseApp.RecordQuery]).
+func recordQueryWithContext(app App, ctx context.Context, collectionModelOrIdentifier any) *dbx.SelectQuery {
+	query := app.RecordQuery(collectionModelOrIdentifier)
+
+	if queryCtx := query.Context(); queryCtx != nil && queryCtx.Err() != nil {
+		return query
+	}
+
+	return query.WithContext(ctx)
+}
+
 // -------------------------------------------------------------------
 
 // FindRecordById finds the Record model by its id.
@@ -212,6 +226,17 @@ func (app *BaseApp) FindRecordById(
 	collectionModelOrIdentifier any,
 	recordId string,
 	optFilters ...func(q *dbx.SelectQuery) error,
+) (*Record, error) {
+	return app.FindRecordByIdWithContext(context.Background(), collectionModelOrIdentifier, recordId, optFilters...)
+}
+
+// FindRecordByIdWithContext is the same as [BaseApp.FindRecordById]
+// but allows specifying a context to limit the db execution.
+func (app *BaseApp) FindRecordByIdWithContext(
+	ctx context.Context,
+	collectionModelOrIdentifier any,
+	recordId string,
+	optFilters ...func(q *dbx.SelectQuery) error,
 ) (*Record, error) {
 	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
 	if err != nil {
@@ -221,6 +246,7 @@ func (app *BaseApp) FindRecordById(
 	record := &Record{}
 
 	query := app.RecordQuery(collection).
+		WithContext(ctx).
 		AndWhere(dbx.HashExp{collection.Name + ".id": recordId})
 
 	// apply filter funcs (if any)
@@ -247,6 +273,17 @@ func (app *BaseApp) FindRecordsByIds(
 	collectionModelOrIdentifier any,
 	recordIds []string,
 	optFilters ...func(q *dbx.SelectQuery) error,
+) ([]*Record, error) {
+	return app.FindRecordsByIdsWithContext(context.Background(), collectionModelOrIdentifier, recordIds, optFilters...)
+}
+
+// FindRecordsByIdsWithContext is the same as [BaseApp.FindRecordsByIds]
+// but allows specifying a context to limit the db execution.
+func (app *BaseApp) FindRecordsByIdsWithContext(
+	ctx context.Context,
+	collectionModelOrIdentifier any,
+	recordIds []string,
+	optFilters ...func(q *dbx.SelectQuery) error,
 ) ([]*Record, error) {
 	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
 	if err != nil {
@@ -254,6 +291,7 @@ func (app *BaseApp) FindRecordsByIds(
 	}
 
 	query := app.RecordQuery(collection).
+		WithContext(ctx).
 		AndWhere(dbx.In(
 			collection.Name+".id",
 			list.ToInterfaceSlice(recordIds)...,
@@ -294,7 +332,13 @@ func (app *BaseApp) FindRecordsByIds(
 //	expr2 := dbx.NewExp("LOWER(username) = {:username}", dbx.Params{"username": "test"})
 //	app.FindAllRecords("example", expr1, expr2)
 func (app *BaseApp) FindAllRecords(collectionModelOrIdentifier any, exprs ...dbx.Expression) ([]*Record, error) {
-	query := app.RecordQuery(collectionModelOrIdentifier)
+	return app.FindAllRecordsWithContext(context.Background(), collectionModelOrIdentifier, exprs...)
+}
+
+// FindAllRecordsWithContext is the same as [BaseApp.FindAllRecords]
+// but allows specifying a context to limit the db execution.
+func (app *BaseApp) FindAllRecordsWithContext(ctx context.Context, collectionModelOrIdentifier any, exprs ...dbx.Expression) ([]*Record, error) {
+	query := recordQueryWithContext(app, ctx, collectionModelOrIdentifier)
 
 	for _, expr := range exprs {
 		if expr != nil { // add only the non-nil expressions
@@ -314,6 +358,12 @@ func (app *BaseApp) FindAllRecords(collectionModelOrIdentifier any, exprs ...dbx
 // FindFirstRecordByData returns the first found record matching
 // the provided key-value pair.
 func (app *BaseApp) FindFirstRecordByData(collectionModelOrIdentifier any, key string, value any) (*Record, error) {
+	return app.FindFirstRecordByDataWithContext(context.Background(), collectionModelOrIdentifier, key, value)
+}
+
+// FindFirstRecordByDataWithContext is the same as [BaseApp.FindFirstRecordByData]
+// but allows specifying a context to limit the db execution.
+func (app *BaseApp) FindFirstRecordByDataWithContext(ctx context.Context, collectionModelOrIdentifier any, key string, value any) (*Record, error) {
 	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
 	if err != nil {
 		return nil, err
@@ -327,6 +377,7 @@ func (app *BaseApp) FindFirstRecordByData(collectionModelOrIdentifier any, key s
 	record := &Record{}
 
 	err = app.RecordQuery(collection).
+		WithContext(ctx).
 		AndWhere(dbx.HashExp{inflector.Columnify(key): value}).
 		Limit(1).
 		One(record)
@@ -370,13 +421,27 @@ func (app *BaseApp) FindRecordsByFilter(
 	limit int,
 	offset int,
 	params ...dbx.Params,
+) ([]*Record, error) {
+	return app.FindRecordsByFilterWithContext(context.Background(), collectionModelOrIdentifier, filter, sort, limit, offset, params...)
+}
+
+// FindRecordsByFilterWithContext is the same as [BaseApp.FindRecordsByFilter]
+// but allows specifying a context to limit the db execution.
+func (app *BaseApp) FindRecordsByFilterWithContext(
+	ctx context.Context,
+	collectionModelOrIdentifier any,
+	filter string,
+	sort string,
+	limit int,
+	offset int,
+	params ...dbx.Params,
 ) ([]*Record, error) {
 	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
 	if err != nil {
 		return nil, err
 	}
 
-	q := app.RecordQuery(collection)
+	q := app.RecordQuery(collection).WithContext(ctx)
 
 	// build a fields resolver and attach the generated conditions to the query
 	// ---
@@ -445,7 +510,18 @@ func (app *BaseApp) FindFirstRecordByFilter(
 	filter string,
 	params ...dbx.Params,
 ) (*Record, error) {
-	result, err := app.FindRecordsByFilter(collectionModelOrIdentifier, filter, "", 1, 0, params...)
+	return app.FindFirstRecordByFilterWithContext(context.Background(), collectionModelOrIdentifier, filter, params...)
+}
+
+// FindFirstRecordByFilterWithContext is the same as [BaseApp.FindFirstRecordByFilter]
+// but allows specifying a context to limit the db execution.
+func (app *BaseApp) FindFirstRecordByFilterWithContext(
+	ctx context.Context,
+	collectionModelOrIdentifier any,
+	filter string,
+	params ...dbx.Params,
+) (*Record, error) {
+	result, err := app.FindRecordsByFilterWithContext(ctx, collectionModelOrIdentifier, filter, "", 1, 0, params...)
 	if err != nil {
 		return nil, err
 	}
@@ -459,9 +535,15 @@ func (app *BaseApp) FindFirstRecordByFilter(
 
 // CountRecords returns the total number of records in a collection.
 func (app *BaseApp) CountRecords(collectionModelOrIdentifier any, exprs ...dbx.Expression) (int64, error) {
+	return app.CountRecordsWithContext(context.Background(), collectionModelOrIdentifier, exprs...)
+}
+
+// CountRecordsWithContext is the same as [BaseApp.CountRecords]
+// but allows specifying a context to limit the db execution.
+func (app *BaseApp) CountRecordsWithContext(ctx context.Context, collectionModelOrIdentifier any, exprs ...dbx.Expression) (int64, error) {
 	var total int64
 
-	q := app.RecordQuery(collectionModelOrIdentifier).Select("count(*)")
+	q := recordQueryWithContext(app, ctx, collectionModelOrIdentifier).Select("count(*)")
 
 	for _, expr := range exprs {
 		if expr != nil { // add only the non-nil expressions
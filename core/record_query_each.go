@@ -0,0 +1,103 @@
+package core
+
+import (
+	"errors"
+	"iter"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+)
+
+// EachRecord executes the provided record select query (usually created
+// with [BaseApp.RecordQuery]) and invokes fn for each result row.
+//
+// Unlike query.All(&records), the rows are streamed and only a single
+// Record is loaded in memory at a time, making it suitable for
+// exports and batch jobs over large collections.
+//
+// The iteration stops on the first fn error and that error is returned.
+//
+// Example:
+//
+//	err := app.EachRecord(
+//		app.RecordQuery("logs").AndWhere(dbx.HashExp{"level": "error"}),
+//		func(record *core.Record) error {
+//			// ...
+//			return nil
+//		},
+//	)
+func (app *BaseApp) EachRecord(query *dbx.SelectQuery, fn func(record *Record) error) error {
+	for record, err := range app.IterateRecords(query) {
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IterateRecords returns an iterator that streams the result records
+// of the provided record select query (usually created with [BaseApp.RecordQuery]).
+//
+// The query result is read lazily and the underlying db rows are
+// closed when the iteration completes or the loop is terminated early.
+//
+// Example:
+//
+//	for record, err := range app.IterateRecords(app.RecordQuery("logs")) {
+//		if err != nil {
+//			return err
+//		}
+//		// ...
+//	}
+func (app *BaseApp) IterateRecords(query *dbx.SelectQuery) iter.Seq2[*Record, error] {
+	return func(yield func(*Record, error) bool) {
+		collection, err := recordQueryCollection(app, query)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		rows, err := query.Rows()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			row := dbx.NullStringMap{}
+			if err := rows.ScanMap(row); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			record, err := newRecordFromNullStringMap(collection, row)
+			if !yield(record, err) || err != nil {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// recordQueryCollection resolves the collection from the FROM clause of a record select query.
+func recordQueryCollection(app App, query *dbx.SelectQuery) (*Collection, error) {
+	from := query.Info().From
+	if len(from) == 0 {
+		return nil, errors.New("missing record query FROM table")
+	}
+
+	// normalize the table name (strip the alias and the quotes, e.g. `{{demo1}} d` -> `demo1`)
+	name, _, _ := strings.Cut(strings.TrimSpace(from[0]), " ")
+	name = strings.Trim(name, "{}[]`\"")
+
+	return app.FindCachedCollectionByNameOrId(name)
+}
@@ -0,0 +1,127 @@
+package core_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestEachRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	stopErr := errors.New("stop")
+
+	scenarios := []struct {
+		name        string
+		query       *dbx.SelectQuery
+		stopAfter   int
+		expectedIds []string
+		expectError error
+	}{
+		{
+			"missing collection",
+			app.RecordQuery("missing"),
+			0,
+			nil,
+			nil, // any error
+		},
+		{
+			"all records",
+			app.RecordQuery("demo3").OrderBy("id ASC"),
+			0,
+			[]string{"1tmknxy2868d869", "7nwo8tuiatetxdm", "lcl9d87w22ml6jy", "mk5fmymtx4wsprk"},
+			nil,
+		},
+		{
+			"filtered records with alias",
+			app.RecordQuery("demo3").Select("d.*").From("demo3 d").AndWhere(dbx.HashExp{"d.title": "test2"}),
+			0,
+			[]string{"lcl9d87w22ml6jy"},
+			nil,
+		},
+		{
+			"stop on fn error",
+			app.RecordQuery("demo3").OrderBy("id ASC"),
+			2,
+			[]string{"1tmknxy2868d869", "7nwo8tuiatetxdm"},
+			stopErr,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			var ids []string
+
+			err := app.EachRecord(s.query, func(record *core.Record) error {
+				if record.Collection().Name != "demo3" {
+					t.Fatalf("Expected demo3 record, got %q", record.Collection().Name)
+				}
+
+				ids = append(ids, record.Id)
+
+				if s.stopAfter > 0 && len(ids) == s.stopAfter {
+					return stopErr
+				}
+
+				return nil
+			})
+
+			if s.name == "missing collection" {
+				if err == nil {
+					t.Fatal("Expected missing collection error")
+				}
+				return
+			}
+
+			if !errors.Is(err, s.expectError) {
+				t.Fatalf("Expected error %v, got %v", s.expectError, err)
+			}
+
+			if !slices.Equal(ids, s.expectedIds) {
+				t.Fatalf("Expected ids %v, got %v", s.expectedIds, ids)
+			}
+		})
+	}
+}
+
+func TestIterateRecordsBreak(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var total int
+	for record, err := range app.IterateRecords(app.RecordQuery("demo3")) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if record.Id == "" || record.GetString("title") == "" {
+			t.Fatalf("Expected loaded record, got %v", record)
+		}
+
+		total++
+		break
+	}
+
+	if total != 1 {
+		t.Fatalf("Expected 1 iteration, got %d", total)
+	}
+
+	// the rows of the terminated loop should be closed and not block the writes
+	record, err := app.FindRecordById("demo3", "lcl9d87w22ml6jy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("title", "updated")
+	if err := app.Save(record); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,10 +1,10 @@
 package core
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/pocketbase/dbx"
@@ -30,19 +30,20 @@ func (app *BaseApp) ExpandRecord(record *Record, expands []string, optFetchFunc
 // If optFetchFunc is not set, then a default function will be used
 // that returns all relation records.
 //
+// The expand paths are grouped by their relation fields so that each
+// relation (including the nested ones) is fetched only once for the
+// entire records list with a single batched fetchFunc call.
+//
 // Returns a map with the failed expand parameters and their errors.
 func (app *BaseApp) ExpandRecords(records []*Record, expands []string, optFetchFunc ExpandFetchFunc) map[string]error {
-	normalized := normalizeExpands(expands)
-
-	failed := map[string]error{}
-
-	for _, expand := range normalized {
-		if err := app.expandRecords(records, expand, optFetchFunc, 1); err != nil {
-			failed[expand] = err
+	if optFetchFunc == nil {
+		// load a default fetchFunc
+		optFetchFunc = func(relCollection *Collection, relIds []string) ([]*Record, error) {
+			return app.FindRecordsByIds(relCollection.Id, relIds)
 		}
 	}
 
-	return failed
+	return app.expandRecordsPaths(records, normalizeExpands(expands), optFetchFunc, 1)
 }
 
 // Deprecated
@@ -50,20 +51,66 @@ var indirectExpandRegexOld = regexp.MustCompile(`^(\w+)\((\w+)\)$`)
 
 var indirectExpandRegex = regexp.MustCompile(`^(\w+)_via_(\w+)$`)
 
-// notes:
-// - if fetchFunc is nil, dao.FindRecordsByIds will be used
-// - all records are expected to be from the same collection
-// - if maxNestedRels(6) is reached, the function returns nil ignoring the remaining expand path
-func (app *BaseApp) expandRecords(records []*Record, expandPath string, fetchFunc ExpandFetchFunc, recursionLevel int) error {
-	if fetchFunc == nil {
-		// load a default fetchFunc
-		fetchFunc = func(relCollection *Collection, relIds []string) ([]*Record, error) {
-			return app.FindRecordsByIds(relCollection.Id, relIds)
+// backRelationsLimit is the max number of back-related records that
+// will be expanded for a single record (the limit is arbitrary chosen
+// and may change in the future).
+const backRelationsLimit = 1000
+
+// expandRecordsPaths groups the provided expand paths by their first
+// segment and expands each group with expandRecords.
+//
+// Returns a map with the failed expand paths and their errors.
+func (app *BaseApp) expandRecordsPaths(records []*Record, paths []string, fetchFunc ExpandFetchFunc, recursionLevel int) map[string]error {
+	failed := map[string]error{}
+
+	groups := map[string][]string{}
+	order := make([]string, 0, len(paths))
+	for _, path := range paths {
+		first, rest, _ := strings.Cut(path, ".")
+		if _, ok := groups[first]; !ok {
+			order = append(order, first)
+		}
+		if rest != "" {
+			groups[first] = append(groups[first], rest)
+		} else if groups[first] == nil {
+			groups[first] = []string{}
 		}
 	}
 
-	if expandPath == "" || recursionLevel > maxNestedRels || len(records) == 0 {
-		return nil
+	for _, first := range order {
+		nestedFailed, err := app.expandRecords(records, first, groups[first], fetchFunc, recursionLevel)
+		if err != nil {
+			if len(groups[first]) == 0 {
+				failed[first] = err
+			}
+			for _, rest := range groups[first] {
+				failed[first+"."+rest] = err
+			}
+			continue
+		}
+
+		for rest, err := range nestedFailed {
+			failed[first+"."+rest] = err
+		}
+	}
+
+	return failed
+}
+
+// notes:
+// - all records are expected to be from the same collection
+// - if maxNestedRels(6) is reached, the function returns nil ignoring the remaining expand path
+// - nestedPaths are expanded for all fetched relations at once
+// - the relation is not expanded if all of its nested paths have failed
+func (app *BaseApp) expandRecords(
+	records []*Record,
+	relName string,
+	nestedPaths []string,
+	fetchFunc ExpandFetchFunc,
+	recursionLevel int,
+) (map[string]error, error) {
+	if relName == "" || recursionLevel > maxNestedRels || len(records) == 0 {
+		return nil, nil
 	}
 
 	mainCollection := records[0].Collection()
@@ -71,12 +118,11 @@ func (app *BaseApp) expandRecords(records []*Record, expandPath string, fetchFun
 	var relField *RelationField
 	var relCollection *Collection
 
-	parts := strings.SplitN(expandPath, ".", 2)
 	var matches []string
 
 	// @todo remove the old syntax support
-	if strings.Contains(parts[0], "(") {
-		matches = indirectExpandRegexOld.FindStringSubmatch(parts[0])
+	if strings.Contains(relName, "(") {
+		matches = indirectExpandRegexOld.FindStringSubmatch(relName)
 		if len(matches) == 3 {
 			log.Printf(
 				"%s expand format is deprecated and will be removed in the future. Consider replacing it with %s_via_%s.\n",
@@ -86,60 +132,29 @@ func (app *BaseApp) expandRecords(records []*Record, expandPath string, fetchFun
 			)
 		}
 	} else {
-		matches = indirectExpandRegex.FindStringSubmatch(parts[0])
+		matches = indirectExpandRegex.FindStringSubmatch(relName)
 	}
 
 	if len(matches) == 3 {
 		indirectRel, _ := getCollectionByModelOrIdentifier(app, matches[1])
 		if indirectRel == nil {
-			return fmt.Errorf("couldn't find back-related collection %q", matches[1])
+			return nil, fmt.Errorf("couldn't find back-related collection %q", matches[1])
 		}
 
 		indirectRelField, _ := indirectRel.Fields.GetByName(matches[2]).(*RelationField)
 		if indirectRelField == nil || indirectRelField.CollectionId != mainCollection.Id {
-			return fmt.Errorf("couldn't find back-relation field %q in collection %q", matches[2], indirectRel.Name)
+			return nil, fmt.Errorf("couldn't find back-relation field %q in collection %q", matches[2], indirectRel.Name)
 		}
 
 		// add the related id(s) as a dynamic relation field value to
 		// allow further expand checks at later stage in a more unified manner
-		prepErr := func() error {
-			q := app.ConcurrentDB().Select("id").
-				From(indirectRel.Name).
-				Limit(1000) // the limit is arbitrary chosen and may change in the future
-
-			if indirectRelField.IsMultiple() {
-				q.AndWhere(dbx.Exists(dbx.NewExp(fmt.Sprintf(
-					"SELECT 1 FROM %s je WHERE je.value = {:id}",
-					dbutils.JSONEach(indirectRelField.Name),
-				))))
-			} else {
-				q.AndWhere(dbx.NewExp("[[" + indirectRelField.Name + "]] = {:id}"))
-			}
-
-			pq := q.Build().Prepare()
-
-			for _, record := range records {
-				var relIds []string
-
-				err := pq.Bind(dbx.Params{"id": record.Id}).Column(&relIds)
-				if err != nil {
-					return errors.Join(err, pq.Close())
-				}
-
-				if len(relIds) > 0 {
-					record.Set(parts[0], relIds)
-				}
-			}
-
-			return pq.Close()
-		}()
-		if prepErr != nil {
-			return prepErr
+		if err := app.loadBackRelationIds(records, relName, indirectRel, indirectRelField); err != nil {
+			return nil, err
 		}
 
 		// indirect/back relation
 		relField = &RelationField{
-			Name:         parts[0],
+			Name:         relName,
 			MaxSelect:    2147483647,
 			CollectionId: indirectRel.Id,
 		}
@@ -149,36 +164,38 @@ func (app *BaseApp) expandRecords(records []*Record, expandPath string, fetchFun
 		relCollection = indirectRel
 	} else {
 		// direct relation
-		relField, _ = mainCollection.Fields.GetByName(parts[0]).(*RelationField)
+		relField, _ = mainCollection.Fields.GetByName(relName).(*RelationField)
 		if relField == nil {
-			return fmt.Errorf("couldn't find relation field %q in collection %q", parts[0], mainCollection.Name)
+			return nil, fmt.Errorf("couldn't find relation field %q in collection %q", relName, mainCollection.Name)
 		}
 
 		relCollection, _ = getCollectionByModelOrIdentifier(app, relField.CollectionId)
 		if relCollection == nil {
-			return fmt.Errorf("couldn't find related collection %q", relField.CollectionId)
+			return nil, fmt.Errorf("couldn't find related collection %q", relField.CollectionId)
 		}
 	}
 
 	// ---------------------------------------------------------------
 
-	// extract the id of the relations to expand
+	// extract the unique ids of the relations to expand
 	relIds := make([]string, 0, len(records))
 	for _, record := range records {
 		relIds = append(relIds, record.GetStringSlice(relField.Name)...)
 	}
+	relIds = list.ToUniqueStringSlice(relIds)
 
 	// fetch rels
 	rels, relsErr := fetchFunc(relCollection, relIds)
 	if relsErr != nil {
-		return relsErr
+		return nil, relsErr
 	}
 
 	// expand nested fields
-	if len(parts) > 1 {
-		err := app.expandRecords(rels, parts[1], fetchFunc, recursionLevel+1)
-		if err != nil {
-			return err
+	var nestedFailed map[string]error
+	if len(nestedPaths) > 0 {
+		nestedFailed = app.expandRecordsPaths(rels, nestedPaths, fetchFunc, recursionLevel+1)
+		if len(nestedFailed) == len(nestedPaths) {
+			return nestedFailed, nil
 		}
 	}
 
@@ -243,6 +260,66 @@ func (app *BaseApp) expandRecords(records []*Record, expandPath string, fetchFun
 		model.SetExpand(expandData)
 	}
 
+	return nestedFailed, nil
+}
+
+// loadBackRelationIds loads with a single query the ids of the
+// indirectRel records referencing each of the provided records and
+// sets them as a dynamic relName field value.
+func (app *BaseApp) loadBackRelationIds(records []*Record, relName string, indirectRel *Collection, indirectRelField *RelationField) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	params := dbx.Params{"limit": backRelationsLimit}
+
+	placeholders := make([]string, len(records))
+	for i, record := range records {
+		key := "id" + strconv.Itoa(i)
+		params[key] = record.Id
+		placeholders[i] = "{:" + key + "}"
+	}
+
+	relColumn := "[[t." + indirectRelField.Name + "]]"
+	from := "{{" + indirectRel.Name + "}} [[t]]"
+	if indirectRelField.IsMultiple() {
+		relColumn = "[[je.value]]"
+		from += " INNER JOIN " + dbutils.JSONEach("t."+indirectRelField.Name) + " [[je]]"
+	}
+
+	// note: the back-relations limit is applied per record with a window
+	// function to avoid loading all rows of the popular records in memory
+	query := app.ConcurrentDB().NewQuery(fmt.Sprintf(
+		"SELECT [[id]], [[relId]] FROM ("+
+			"SELECT [[t.id]] AS [[id]], %s AS [[relId]], ROW_NUMBER() OVER (PARTITION BY %s) AS [[rowNum]] "+
+			"FROM %s WHERE %s IN (%s)"+
+			") WHERE [[rowNum]] <= {:limit}",
+		relColumn,
+		relColumn,
+		from,
+		relColumn,
+		strings.Join(placeholders, ","),
+	)).Bind(params)
+
+	rows := []struct {
+		Id    string `db:"id"`
+		RelId string `db:"relId"`
+	}{}
+	if err := query.All(&rows); err != nil {
+		return err
+	}
+
+	grouped := make(map[string][]string, len(records))
+	for _, row := range rows {
+		grouped[row.RelId] = append(grouped[row.RelId], row.Id)
+	}
+
+	for _, record := range records {
+		if relIds := grouped[record.Id]; len(relIds) > 0 {
+			record.Set(relName, relIds)
+		}
+	}
+
 	return nil
 }
 
@@ -3,9 +3,11 @@ package core_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/list"
@@ -484,3 +486,152 @@ func TestBackRelationExpandSingeVsArrayResult(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandRecordsBatchedFetch(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expands := []string{
+		"demo4_via_rel_many_no_cascade_required.self_rel_many",
+		"demo4_via_rel_many_no_cascade_required.rel_one_cascade",
+		"demo4_via_rel_one_cascade",
+	}
+
+	records, err := app.FindAllRecords("demo3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int
+	failed := app.ExpandRecords(records, expands, func(c *core.Collection, ids []string) ([]*core.Record, error) {
+		calls++
+
+		if unique := list.ToUniqueStringSlice(ids); len(unique) != len(ids) {
+			t.Errorf("Expected unique fetch ids, got %v", ids)
+		}
+
+		return app.FindRecordsByIds(c.Id, ids)
+	})
+	if len(failed) > 0 {
+		t.Fatal(failed)
+	}
+
+	// 1 for each of the back-relations + 1 for each of the shared path nested relations
+	if calls != 4 {
+		t.Fatalf("Expected %d fetch calls, got %d", 4, calls)
+	}
+
+	// compare with the individually expanded records
+	var totalExpanded int
+	for _, record := range records {
+		single, err := app.FindRecordById("demo3", record.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if failed := app.ExpandRecord(single, expands, nil); len(failed) > 0 {
+			t.Fatal(failed)
+		}
+
+		batchedJSON, _ := json.Marshal(record)
+		singleJSON, _ := json.Marshal(single)
+		if string(batchedJSON) != string(singleJSON) {
+			t.Fatalf("Expected record %q to be expanded as\n%s\ngot\n%s", record.Id, singleJSON, batchedJSON)
+		}
+
+		totalExpanded += len(record.Expand())
+	}
+
+	if totalExpanded == 0 {
+		t.Fatal("Expected at least one expanded relation")
+	}
+}
+
+func TestBackRelationExpandLimit(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo3, err := app.FindCollectionByNameOrId("demo3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection := core.NewBaseCollection("back_rel_test")
+	collection.Fields.Add(&core.RelationField{Name: "rel_one", CollectionId: demo3.Id, MaxSelect: 1})
+	collection.Fields.Add(&core.RelationField{Name: "rel_many", CollectionId: demo3.Id, MaxSelect: 5})
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := app.FindRecordsByIds(demo3, []string{"lcl9d87w22ml6jy", "1tmknxy2868d869"})
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Failed to load the demo3 records: %v", err)
+	}
+	popular, other := records[0], records[1]
+	if popular.Id != "lcl9d87w22ml6jy" {
+		popular, other = other, popular
+	}
+
+	err = app.RunInTransaction(func(txApp core.App) error {
+		for i := 0; i < 1005; i++ {
+			rels := `["` + popular.Id + `"]`
+			if i < 3 {
+				rels = `["` + popular.Id + `","` + other.Id + `"]`
+			}
+
+			_, err := txApp.DB().Insert(collection.Name, dbx.Params{
+				"id":       fmt.Sprintf("r%014d", i),
+				"rel_one":  popular.Id,
+				"rel_many": rels,
+			}).Execute()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		expand        string
+		expectedOther int
+	}{
+		{"back_rel_test_via_rel_one", 0},
+		{"back_rel_test_via_rel_many", 3},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.expand, func(t *testing.T) {
+			for _, r := range records {
+				r.SetExpand(nil)
+			}
+
+			var fetched int
+			failed := app.ExpandRecords(records, []string{s.expand}, func(c *core.Collection, ids []string) ([]*core.Record, error) {
+				fetched += len(ids)
+				return app.FindRecordsByIds(c.Id, ids)
+			})
+			if len(failed) > 0 {
+				t.Fatal(failed)
+			}
+
+			if total := len(popular.ExpandedAll(s.expand)); total != 1000 {
+				t.Fatalf("Expected 1000 expanded records for the popular record, got %d", total)
+			}
+
+			if total := len(other.ExpandedAll(s.expand)); total != s.expectedOther {
+				t.Fatalf("Expected %d expanded records for the other record, got %d", s.expectedOther, total)
+			}
+
+			if max := 1000 + s.expectedOther; fetched > max {
+				t.Fatalf("Expected at most %d fetched ids, got %d", max, fetched)
+			}
+		})
+	}
+}
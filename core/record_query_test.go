@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -894,6 +895,91 @@ func TestCountRecords(t *testing.T) {
 	}
 }
 
+func TestFindRecordsWithContext(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	scenarios := []struct {
+		name string
+		find func(ctx context.Context) error
+	}{
+		{
+			"FindRecordByIdWithContext",
+			func(ctx context.Context) error {
+				_, err := app.FindRecordByIdWithContext(ctx, "demo3", "lcl9d87w22ml6jy")
+				return err
+			},
+		},
+		{
+			"FindRecordsByIdsWithContext",
+			func(ctx context.Context) error {
+				_, err := app.FindRecordsByIdsWithContext(ctx, "demo3", []string{"lcl9d87w22ml6jy"})
+				return err
+			},
+		},
+		{
+			"FindAllRecordsWithContext",
+			func(ctx context.Context) error {
+				_, err := app.FindAllRecordsWithContext(ctx, "demo3")
+				return err
+			},
+		},
+		{
+			"FindFirstRecordByDataWithContext",
+			func(ctx context.Context) error {
+				_, err := app.FindFirstRecordByDataWithContext(ctx, "demo3", "id", "lcl9d87w22ml6jy")
+				return err
+			},
+		},
+		{
+			"FindRecordsByFilterWithContext",
+			func(ctx context.Context) error {
+				_, err := app.FindRecordsByFilterWithContext(ctx, "demo3", "id != ''", "", 0, 0)
+				return err
+			},
+		},
+		{
+			"FindFirstRecordByFilterWithContext",
+			func(ctx context.Context) error {
+				_, err := app.FindFirstRecordByFilterWithContext(ctx, "demo3", "id != ''")
+				return err
+			},
+		},
+		{
+			"CountRecordsWithContext",
+			func(ctx context.Context) error {
+				_, err := app.CountRecordsWithContext(ctx, "demo3")
+				return err
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if err := s.find(context.Background()); err != nil {
+				t.Fatalf("Expected nil error with active context, got %v", err)
+			}
+
+			if err := s.find(canceledCtx); !errors.Is(err, context.Canceled) {
+				t.Fatalf("Expected context.Canceled error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("missing collection", func(t *testing.T) {
+		// the canceled query context of the invalid collection identifier should be preserved
+		_, err := app.FindAllRecordsWithContext(context.Background(), "missing")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled error, got %v", err)
+		}
+	})
+}
+
 func TestFindAuthRecordByToken(t *testing.T) {
 	t.Parallel()
 
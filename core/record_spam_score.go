@@ -0,0 +1,219 @@
+package core
+
+import (
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+// Spam score signal names of the builtin heuristics.
+const (
+	SpamSignalLinkDensity     = "linkDensity"
+	SpamSignalDisposableEmail = "disposableEmail"
+	SpamSignalVelocity        = "velocity"
+)
+
+// Hook ids of the builtin OnRecordCreateScore heuristics handlers
+// (could be used to unbind a specific heuristic).
+const (
+	SpamHeuristicLinkDensityHookId     = "__pbSpamLinkDensity__"
+	SpamHeuristicDisposableEmailHookId = "__pbSpamDisposableEmail__"
+	SpamHeuristicVelocityHookId        = "__pbSpamVelocity__"
+)
+
+// StoreKeySpamVelocity is the app store key of the per IP record create requests tracker.
+const StoreKeySpamVelocity = "pbAppSpamVelocity"
+
+// Max score of each builtin heuristic (their sum is 1).
+const (
+	spamLinkDensityWeight     = 0.4
+	spamDisposableEmailWeight = 0.3
+	spamVelocityWeight        = 0.3
+)
+
+const (
+	// spamVelocityWindow is the sliding window of the tracked create requests per IP.
+	spamVelocityWindow = 1 * time.Minute
+
+	// spamVelocityMaxHits is the number of previous create requests within
+	// the velocity window after which the max velocity score is applied.
+	spamVelocityMaxHits = 10
+
+	// spamVelocityMaxIPs is the number of tracked IPs after which
+	// all expired entries are removed from the tracker.
+	spamVelocityMaxIPs = 10000
+)
+
+// DisposableEmailDomains is the list of known disposable/temporary email
+// domains used by the builtin disposable email heuristic.
+//
+// Subdomains of the listed domains are also matched.
+// Users could extend it during the app initialization.
+var DisposableEmailDomains = []string{
+	"10minutemail.com",
+	"dispostable.com",
+	"emailondeck.com",
+	"fakeinbox.com",
+	"getnada.com",
+	"guerrillamail.com",
+	"guerrillamailblock.com",
+	"mailinator.com",
+	"maildrop.cc",
+	"mohmal.com",
+	"sharklasers.com",
+	"temp-mail.org",
+	"tempmail.com",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+}
+
+var spamLinkRegex = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// AddSignal adds (or replaces) the named heuristic score.
+func (e *RecordCreateScoreEvent) AddSignal(name string, score float64) {
+	if e.Signals == nil {
+		e.Signals = map[string]float64{}
+	}
+
+	e.Signals[name] = score
+}
+
+// Score returns the total record score, aka. the sum of all signals
+// normalized in the range [0, 1].
+func (e *RecordCreateScoreEvent) Score() float64 {
+	var total float64
+
+	// sum in a stable order for consistent floating point results
+	for _, name := range slices.Sorted(maps.Keys(e.Signals)) {
+		total += e.Signals[name]
+	}
+
+	return math.Min(1, math.Max(0, total))
+}
+
+func (app *BaseApp) registerSpamScoreHooks() {
+	app.OnRecordCreateScore().Bind(&hook.Handler[*RecordCreateScoreEvent]{
+		Id: SpamHeuristicLinkDensityHookId,
+		Func: func(e *RecordCreateScoreEvent) error {
+			if score := spamLinkDensityScore(e.Record); score > 0 {
+				e.AddSignal(SpamSignalLinkDensity, score)
+			}
+
+			return e.Next()
+		},
+	})
+
+	app.OnRecordCreateScore().Bind(&hook.Handler[*RecordCreateScoreEvent]{
+		Id: SpamHeuristicDisposableEmailHookId,
+		Func: func(e *RecordCreateScoreEvent) error {
+			if score := spamDisposableEmailScore(e.Record); score > 0 {
+				e.AddSignal(SpamSignalDisposableEmail, score)
+			}
+
+			return e.Next()
+		},
+	})
+
+	app.OnRecordCreateScore().Bind(&hook.Handler[*RecordCreateScoreEvent]{
+		Id: SpamHeuristicVelocityHookId,
+		Func: func(e *RecordCreateScoreEvent) error {
+			tracker, _ := e.App.Store().GetOrSet(StoreKeySpamVelocity, func() any {
+				return &spamVelocityTracker{hits: map[string][]time.Time{}}
+			}).(*spamVelocityTracker)
+
+			prevHits := tracker.hit(e.Collection.Id+":"+e.RealIP(), time.Now())
+			if prevHits > 0 {
+				e.AddSignal(SpamSignalVelocity, spamVelocityWeight*math.Min(1, float64(prevHits)/spamVelocityMaxHits))
+			}
+
+			return e.Next()
+		},
+	})
+}
+
+// spamLinkDensityScore returns the record text and editor fields links density score.
+func spamLinkDensityScore(record *Record) float64 {
+	var links, words int
+
+	for _, f := range record.Collection().Fields {
+		switch f.Type() {
+		case FieldTypeText, FieldTypeEditor:
+			val := record.GetString(f.GetName())
+			links += len(spamLinkRegex.FindAllStringIndex(val, -1))
+			words += len(strings.Fields(val))
+		}
+	}
+
+	if links == 0 || words == 0 {
+		return 0
+	}
+
+	// 1 link per 4 words (or more) results in the max score
+	return spamLinkDensityWeight * math.Min(1, 4*float64(links)/float64(words))
+}
+
+// spamDisposableEmailScore returns the record email fields disposable domains score.
+func spamDisposableEmailScore(record *Record) float64 {
+	for _, f := range record.Collection().Fields {
+		if f.Type() != FieldTypeEmail {
+			continue
+		}
+
+		email := strings.ToLower(record.GetString(f.GetName()))
+
+		at := strings.LastIndex(email, "@")
+		if at < 0 {
+			continue
+		}
+
+		domain := email[at+1:]
+
+		isDisposable := slices.ContainsFunc(DisposableEmailDomains, func(d string) bool {
+			d = strings.ToLower(d)
+			return domain == d || strings.HasSuffix(domain, "."+d)
+		})
+		if isDisposable {
+			return spamDisposableEmailWeight
+		}
+	}
+
+	return 0
+}
+
+// spamVelocityTracker tracks the recent record create requests per key (collection + IP).
+type spamVelocityTracker struct {
+	hits map[string][]time.Time
+	mu   sync.Mutex
+}
+
+// hit registers a new hit for the specified key and returns
+// the number of the previous hits within the velocity window.
+func (t *spamVelocityTracker) hit(key string, now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	minTime := now.Add(-spamVelocityWindow)
+
+	if len(t.hits) >= spamVelocityMaxIPs {
+		for k, v := range t.hits {
+			if len(v) == 0 || !v[len(v)-1].After(minTime) {
+				delete(t.hits, k)
+			}
+		}
+	}
+
+	recent := slices.DeleteFunc(t.hits[key], func(hitTime time.Time) bool {
+		return !hitTime.After(minTime)
+	})
+
+	t.hits[key] = append(recent, now)
+
+	return len(recent)
+}
@@ -0,0 +1,135 @@
+package core_test
+
+import (
+	"math"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordCreateScoreEventScore(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		signals  map[string]float64
+		expected float64
+	}{
+		{nil, 0},
+		{map[string]float64{"a": 0.2, "b": 0.3}, 0.5},
+		{map[string]float64{"a": 0.8, "b": 0.5}, 1},
+		{map[string]float64{"a": -0.8, "b": 0.5}, 0},
+	}
+
+	for _, s := range scenarios {
+		event := &core.RecordCreateScoreEvent{}
+		for k, v := range s.signals {
+			event.AddSignal(k, v)
+		}
+
+		if score := event.Score(); math.Abs(score-s.expected) > 1e-9 {
+			t.Fatalf("[%v] Expected score %v, got %v", s.signals, s.expected, score)
+		}
+	}
+}
+
+func TestRecordCreateScoreBuiltinHeuristics(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trigger := func(ip string, data map[string]any) map[string]float64 {
+		req := httptest.NewRequest("POST", "/", nil)
+		req.RemoteAddr = ip + ":1234"
+
+		record := core.NewRecord(collection)
+		record.Load(data)
+
+		event := new(core.RecordCreateScoreEvent)
+		event.RequestEvent = &core.RequestEvent{App: app}
+		event.Request = req
+		event.Collection = collection
+		event.Record = record
+
+		if err := app.OnRecordCreateScore().Trigger(event); err != nil {
+			t.Fatal(err)
+		}
+
+		return event.Signals
+	}
+
+	keys := func(signals map[string]float64) []string {
+		result := make([]string, 0, len(signals))
+		for k := range signals {
+			result = append(result, k)
+		}
+		slices.Sort(result)
+		return result
+	}
+
+	t.Run("no signals", func(t *testing.T) {
+		signals := trigger("1.1.1.1", map[string]any{"text": "hello world", "email": "test@example.com"})
+		if len(signals) != 0 {
+			t.Fatalf("Expected no signals, got %v", signals)
+		}
+	})
+
+	t.Run("link density", func(t *testing.T) {
+		signals := trigger("1.1.1.2", map[string]any{"text": "buy https://a.com www.b.com now"})
+		if v := signals[core.SpamSignalLinkDensity]; math.Abs(v-0.4) > 1e-9 {
+			t.Fatalf("Expected link density signal 0.4, got %v", signals)
+		}
+
+		signals = trigger("1.1.1.3", map[string]any{"text": "some longer text message with only a single https://example.com link"})
+		if v := signals[core.SpamSignalLinkDensity]; v <= 0 || v >= 0.4 {
+			t.Fatalf("Expected link density signal between 0 and 0.4, got %v", signals)
+		}
+	})
+
+	t.Run("disposable email", func(t *testing.T) {
+		signals := trigger("1.1.1.4", map[string]any{"email": "test@SUB.Mailinator.com"})
+		if v := signals[core.SpamSignalDisposableEmail]; math.Abs(v-0.3) > 1e-9 {
+			t.Fatalf("Expected disposable email signal 0.3, got %v", signals)
+		}
+	})
+
+	t.Run("velocity", func(t *testing.T) {
+		for i := 0; i < 11; i++ {
+			signals := trigger("1.1.1.5", nil)
+
+			if i == 0 {
+				if len(signals) != 0 {
+					t.Fatalf("Expected no signals for the first request, got %v", signals)
+				}
+				continue
+			}
+
+			expected := 0.3 * math.Min(1, float64(i)/10)
+			if v := signals[core.SpamSignalVelocity]; math.Abs(v-expected) > 1e-9 {
+				t.Fatalf("[%d] Expected velocity signal %v, got %v", i, expected, signals)
+			}
+		}
+
+		// different ip
+		if signals := trigger("1.1.1.6", nil); len(signals) != 0 {
+			t.Fatalf("Expected no signals for a different ip, got %v", signals)
+		}
+	})
+
+	t.Run("unbind builtin heuristic", func(t *testing.T) {
+		app.OnRecordCreateScore().Unbind(core.SpamHeuristicDisposableEmailHookId)
+
+		signals := trigger("1.1.1.7", map[string]any{"text": "https://a.com", "email": "test@yopmail.com"})
+		if k := keys(signals); len(k) != 1 || k[0] != core.SpamSignalLinkDensity {
+			t.Fatalf("Expected only the link density signal, got %v", signals)
+		}
+	})
+}
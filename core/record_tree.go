@@ -0,0 +1,370 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ganigeorgiev/fexpr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// Names of the system fields of collections with enabled tree hierarchy.
+const (
+	FieldNameTreeParent   = "parent"
+	FieldNameTreePath     = "treePath"
+	FieldNameTreePosition = "treePosition"
+)
+
+// treePathSeparator is the separator of the record ids in the materialized tree path.
+const treePathSeparator = "/"
+
+func init() {
+	// descendantsOf(id, [relField]) checks whether a record is a descendant of the
+	// tree record with the specified id (the record itself is not included).
+	//
+	// The optional second argument is an identifier of a single or multiple
+	// relation field pointing to a tree collection, e.g.
+	// `descendantsOf("CATEGORY_ID", category) = true`.
+	//
+	// Similar to geoDistance, no "match-all" constraint is applied for multiple relation fields.
+	search.TokenFunctions["descendantsOf"] = func(argTokenResolverFunc func(fexpr.Token) (*search.ResolverResult, error), args ...fexpr.Token) (*search.ResolverResult, error) {
+		if len(args) < 1 || len(args) > 2 {
+			return nil, fmt.Errorf("[descendantsOf] expected 1 or 2 arguments, got %d", len(args))
+		}
+
+		if args[0].Type != fexpr.TokenText && args[0].Type != fexpr.TokenIdentifier {
+			return nil, errors.New("[descendantsOf] expects the first argument to be a string or identifier")
+		}
+
+		idResult, err := argTokenResolverFunc(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("[descendantsOf] failed to resolve the id argument: %w", err)
+		}
+
+		pathIdentifier := FieldNameTreePath
+		if len(args) == 2 {
+			if args[1].Type != fexpr.TokenIdentifier {
+				return nil, errors.New("[descendantsOf] expects the second argument to be a relation field identifier")
+			}
+			pathIdentifier = args[1].Literal + "." + FieldNameTreePath
+		}
+
+		pathResult, err := argTokenResolverFunc(fexpr.Token{Type: fexpr.TokenIdentifier, Literal: pathIdentifier})
+		if err != nil {
+			return nil, fmt.Errorf("[descendantsOf] failed to resolve the tree path: %w", err)
+		}
+
+		params := dbx.Params{}
+		for _, p := range []dbx.Params{idResult.Params, pathResult.Params} {
+			for k, v := range p {
+				params[k] = v
+			}
+		}
+
+		return &search.ResolverResult{
+			NullFallback: search.NullFallbackDisabled,
+			Identifier: "(instr(COALESCE(" + pathResult.Identifier + ", ''), " +
+				"'" + treePathSeparator + "' || " + idResult.Identifier + " || '" + treePathSeparator + "') > 0)",
+			Params: params,
+		}, nil
+	}
+}
+
+// TreeAncestorIds returns the ids of the record tree ancestors
+// ordered from the root to the direct parent.
+//
+// Returns empty slice for root records and records of collections without enabled tree.
+func (m *Record) TreeAncestorIds() []string {
+	result := []string{}
+
+	for _, id := range strings.Split(m.GetString(FieldNameTreePath), treePathSeparator) {
+		if id != "" {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// FindRecordTreeAncestors returns the tree ancestors of the specified
+// record ordered from the root to the direct parent.
+func (app *BaseApp) FindRecordTreeAncestors(record *Record) ([]*Record, error) {
+	ids := record.TreeAncestorIds()
+	if len(ids) == 0 {
+		return []*Record{}, nil
+	}
+
+	records, err := app.FindRecordsByIds(record.Collection(), ids)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(records, func(a, b *Record) int {
+		return slices.Index(ids, a.Id) - slices.Index(ids, b.Id)
+	})
+
+	return records, nil
+}
+
+// MoveTreeRecord moves the specified record under the parent with parentId
+// (empty string for root) at the specified 1-based siblings position,
+// shifting the positions of the following siblings.
+//
+// If position is out of range, the record is moved as last sibling.
+func (app *BaseApp) MoveTreeRecord(record *Record, parentId string, position int) error {
+	return app.MoveTreeRecordWithContext(context.Background(), record, parentId, position)
+}
+
+// MoveTreeRecordWithContext is the same as [BaseApp.MoveTreeRecord]
+// but allows specifying a custom context.
+func (app *BaseApp) MoveTreeRecordWithContext(ctx context.Context, record *Record, parentId string, position int) error {
+	collection := record.Collection()
+
+	if !collection.Tree.Enabled {
+		return fmt.Errorf("the tree hierarchy of collection %q is not enabled", collection.Name)
+	}
+
+	return app.RunInTransactionWithContext(ctx, func(txApp App) error {
+		siblings := []*Record{}
+
+		tableName := inflector.Columnify(collection.Name)
+
+		err := txApp.RecordQuery(collection).
+			WithContext(ctx).
+			AndWhere(treeSiblingsExpr(tableName, parentId)).
+			AndWhere(dbx.Not(dbx.HashExp{tableName + ".id": record.Id})).
+			OrderBy(
+				"[["+tableName+"."+FieldNameTreePosition+"]] ASC",
+				"[["+tableName+".id]] ASC",
+			).
+			All(&siblings)
+		if err != nil {
+			return err
+		}
+
+		if position < 1 || position > len(siblings)+1 {
+			position = len(siblings) + 1
+		}
+
+		record.Set(FieldNameTreeParent, parentId)
+		record.Set(FieldNameTreePosition, position)
+
+		if err := txApp.SaveWithContext(ctx, record); err != nil {
+			return err
+		}
+
+		for i, sibling := range siblings {
+			siblingPosition := i + 1
+			if siblingPosition >= position {
+				siblingPosition++
+			}
+
+			if sibling.GetInt(FieldNameTreePosition) == siblingPosition {
+				continue
+			}
+
+			sibling.Set(FieldNameTreePosition, siblingPosition)
+
+			if err := txApp.SaveNoValidateWithContext(ctx, sibling); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (app *BaseApp) registerTreeHooks() {
+	syncTree := func(e *RecordEvent, isNew bool) error {
+		collection := e.Record.Collection()
+
+		if !collection.Tree.Enabled {
+			return e.Next()
+		}
+
+		tableName := inflector.Columnify(collection.Name)
+
+		oldPath := normalizeTreePath(e.Record.Original().GetString(FieldNameTreePath))
+
+		path, err := resolveTreePath(e.App, e.Record)
+		if err != nil {
+			return err
+		}
+		e.Record.Set(FieldNameTreePath, path)
+
+		// append the new records as last sibling
+		if isNew && e.Record.GetInt(FieldNameTreePosition) == 0 {
+			var maxPosition int
+
+			err := e.App.RecordQuery(collection).
+				Select("COALESCE(MAX([[" + tableName + "." + FieldNameTreePosition + "]]), 0)").
+				AndWhere(treeSiblingsExpr(tableName, e.Record.GetString(FieldNameTreeParent))).
+				Row(&maxPosition)
+			if err != nil {
+				return err
+			}
+
+			e.Record.Set(FieldNameTreePosition, maxPosition+1)
+		}
+
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		if isNew || oldPath == path {
+			return nil
+		}
+
+		// update the descendants path prefix
+		oldPrefix := oldPath + e.Record.Id + treePathSeparator
+		newPrefix := path + e.Record.Id + treePathSeparator
+
+		column := "[[" + FieldNameTreePath + "]]"
+
+		_, err = e.App.DB().NewQuery(
+			"UPDATE {{" + tableName + "}} SET " + column + " = {:newPrefix} || substr(" + column + ", {:offset}) " +
+				"WHERE substr(" + column + ", 1, {:length}) = {:oldPrefix}",
+		).Bind(dbx.Params{
+			"newPrefix": newPrefix,
+			"oldPrefix": oldPrefix,
+			"offset":    len(oldPrefix) + 1,
+			"length":    len(oldPrefix),
+		}).Execute()
+		if err != nil {
+			return err
+		}
+
+		clearCachedRecords(e.App, collection.Id)
+
+		return nil
+	}
+
+	app.OnRecordCreateExecute().Bind(&hook.Handler[*RecordEvent]{
+		Func: func(e *RecordEvent) error {
+			return syncTree(e, true)
+		},
+		Priority: -99,
+	})
+
+	app.OnRecordUpdateExecute().Bind(&hook.Handler[*RecordEvent]{
+		Func: func(e *RecordEvent) error {
+			return syncTree(e, false)
+		},
+		Priority: -99,
+	})
+}
+
+// resolveTreePath returns the materialized tree path of the record
+// based on its current parent, aka. "/rootId/.../parentId/".
+func resolveTreePath(app App, record *Record) (string, error) {
+	parentId := record.GetString(FieldNameTreeParent)
+	if parentId == "" {
+		return treePathSeparator, nil
+	}
+
+	if parentId == record.Id {
+		return "", validation.Errors{
+			FieldNameTreeParent: validation.NewError("validation_tree_self_parent", "The record cannot be its own parent."),
+		}
+	}
+
+	parent, err := app.FindRecordById(record.Collection(), parentId)
+	if err != nil {
+		return "", validation.Errors{
+			FieldNameTreeParent: validation.NewError("validation_missing_tree_parent", "Missing or invalid parent record."),
+		}
+	}
+
+	parentPath := normalizeTreePath(parent.GetString(FieldNameTreePath))
+
+	if !record.IsNew() && strings.Contains(parentPath, treePathSeparator+record.Id+treePathSeparator) {
+		return "", validation.Errors{
+			FieldNameTreeParent: validation.NewError("validation_tree_cycle", "The record cannot be moved under its own descendant."),
+		}
+	}
+
+	return parentPath + parent.Id + treePathSeparator, nil
+}
+
+// normalizeTreePath returns the root path for empty paths
+// (e.g. records created before enabling the collection tree).
+func normalizeTreePath(path string) string {
+	if path == "" {
+		return treePathSeparator
+	}
+
+	return path
+}
+
+func treeSiblingsExpr(tableName string, parentId string) dbx.Expression {
+	return dbx.NewExp(
+		"COALESCE([["+tableName+"."+FieldNameTreeParent+"]], '') = {:pbTreeParent}",
+		dbx.Params{"pbTreeParent": parentId},
+	)
+}
+
+// initTreeFields ensures that the collection tree system fields exist.
+//
+// If the tree is disabled, the system flag of the existing
+// tree fields is removed so that they could be deleted.
+func (c *Collection) initTreeFields() {
+	parentField, _ := c.Fields.GetByName(FieldNameTreeParent).(*RelationField)
+	pathField, _ := c.Fields.GetByName(FieldNameTreePath).(*TextField)
+	positionField, _ := c.Fields.GetByName(FieldNameTreePosition).(*NumberField)
+
+	if !c.Tree.Enabled {
+		if parentField != nil {
+			parentField.System = false
+		}
+		if pathField != nil {
+			pathField.System = false
+		}
+		if positionField != nil {
+			positionField.System = false
+		}
+		return
+	}
+
+	if parentField == nil {
+		c.Fields.Add(&RelationField{
+			Name:          FieldNameTreeParent,
+			System:        true,
+			MaxSelect:     1,
+			CollectionId:  c.Id,
+			CascadeDelete: c.Tree.CascadeDelete,
+		})
+	} else {
+		// enforce system defaults
+		parentField.System = true
+		parentField.MaxSelect = 1
+		parentField.CollectionId = c.Id
+		parentField.CascadeDelete = c.Tree.CascadeDelete
+	}
+
+	if pathField == nil {
+		c.Fields.Add(&TextField{
+			Name:   FieldNameTreePath,
+			System: true,
+		})
+	} else {
+		pathField.System = true
+	}
+
+	if positionField == nil {
+		c.Fields.Add(&NumberField{
+			Name:    FieldNameTreePosition,
+			System:  true,
+			OnlyInt: true,
+		})
+	} else {
+		positionField.System = true
+		positionField.OnlyInt = true
+	}
+}
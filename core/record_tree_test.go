@@ -0,0 +1,342 @@
+package core_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createTreeCollection(t testing.TB, app core.App, cascadeDelete bool) *core.Collection {
+	collection := core.NewBaseCollection("test_tree")
+	collection.Tree.Enabled = true
+	collection.Tree.CascadeDelete = cascadeDelete
+	collection.Fields.Add(&core.TextField{Name: "title"})
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// a
+	// ├── b
+	// │   └── c
+	// └── d
+	// e
+	data := []struct{ id, parent string }{
+		{"a00000000000000", ""},
+		{"b00000000000000", "a00000000000000"},
+		{"c00000000000000", "b00000000000000"},
+		{"d00000000000000", "a00000000000000"},
+		{"e00000000000000", ""},
+	}
+	for _, d := range data {
+		record := core.NewRecord(collection)
+		record.Id = d.id
+		record.Set(core.FieldNameTreeParent, d.parent)
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return collection
+}
+
+func checkTreeRecords(t *testing.T, app core.App, expected map[string][2]any) {
+	t.Helper()
+
+	for id, v := range expected {
+		record, err := app.FindRecordById("test_tree", id)
+		if err != nil {
+			t.Fatalf("[%s] %v", id, err)
+		}
+
+		if path := record.GetString(core.FieldNameTreePath); path != v[0] {
+			t.Fatalf("[%s] Expected path %q, got %q", id, v[0], path)
+		}
+
+		if position := record.GetInt(core.FieldNameTreePosition); position != v[1] {
+			t.Fatalf("[%s] Expected position %d, got %d", id, v[1], position)
+		}
+	}
+}
+
+func TestCollectionTreeFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTreeCollection(t, app, false)
+
+	parent, ok := collection.Fields.GetByName(core.FieldNameTreeParent).(*core.RelationField)
+	if !ok || !parent.System || parent.CollectionId != collection.Id || parent.MaxSelect != 1 {
+		t.Fatalf("Expected system self relation parent field, got %#v", parent)
+	}
+
+	for _, name := range []string{core.FieldNameTreePath, core.FieldNameTreePosition} {
+		if f := collection.Fields.GetByName(name); f == nil || !f.GetSystem() {
+			t.Fatalf("Expected system field %q", name)
+		}
+	}
+
+	collection.Tree.Enabled = false
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{core.FieldNameTreeParent, core.FieldNameTreePath, core.FieldNameTreePosition} {
+		if f := collection.Fields.GetByName(name); f == nil || f.GetSystem() {
+			t.Fatalf("Expected non-system field %q", name)
+		}
+	}
+}
+
+func TestRecordTreePaths(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	createTreeCollection(t, app, false)
+
+	checkTreeRecords(t, app, map[string][2]any{
+		"a00000000000000": {"/", 1},
+		"b00000000000000": {"/a00000000000000/", 1},
+		"c00000000000000": {"/a00000000000000/b00000000000000/", 1},
+		"d00000000000000": {"/a00000000000000/", 2},
+		"e00000000000000": {"/", 2},
+	})
+
+	// move b (with its descendants) under e
+	b, err := app.FindRecordById("test_tree", "b00000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Set(core.FieldNameTreeParent, "e00000000000000")
+	if err := app.Save(b); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTreeRecords(t, app, map[string][2]any{
+		"b00000000000000": {"/e00000000000000/", 1},
+		"c00000000000000": {"/e00000000000000/b00000000000000/", 1},
+	})
+
+	// cycles
+	for _, parent := range []string{"b00000000000000", "c00000000000000", "missing"} {
+		b.Set(core.FieldNameTreeParent, parent)
+		if err := app.Save(b); err == nil {
+			t.Fatalf("Expected error for parent %q", parent)
+		}
+	}
+}
+
+func TestRecordTreeDelete(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without cascade delete", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		createTreeCollection(t, app, false)
+
+		a, err := app.FindRecordById("test_tree", "a00000000000000")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := app.Delete(a); err != nil {
+			t.Fatal(err)
+		}
+
+		checkTreeRecords(t, app, map[string][2]any{
+			"b00000000000000": {"/", 1},
+			"c00000000000000": {"/b00000000000000/", 1},
+			"d00000000000000": {"/", 2},
+		})
+	})
+
+	t.Run("with cascade delete", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		collection := createTreeCollection(t, app, true)
+
+		a, err := app.FindRecordById(collection, "a00000000000000")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := app.Delete(a); err != nil {
+			t.Fatal(err)
+		}
+
+		total, err := app.CountRecords(collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 {
+			t.Fatalf("Expected only 1 remaining record, got %d", total)
+		}
+	})
+}
+
+func TestMoveTreeRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	createTreeCollection(t, app, false)
+
+	e, err := app.FindRecordById("test_tree", "e00000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// move e as first child of a
+	if err := app.MoveTreeRecord(e, "a00000000000000", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTreeRecords(t, app, map[string][2]any{
+		"e00000000000000": {"/a00000000000000/", 1},
+		"b00000000000000": {"/a00000000000000/", 2},
+		"d00000000000000": {"/a00000000000000/", 3},
+	})
+
+	// move d between e and b
+	d, err := app.FindRecordById("test_tree", "d00000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.MoveTreeRecord(d, "a00000000000000", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTreeRecords(t, app, map[string][2]any{
+		"e00000000000000": {"/a00000000000000/", 1},
+		"d00000000000000": {"/a00000000000000/", 2},
+		"b00000000000000": {"/a00000000000000/", 3},
+	})
+
+	// out of range position
+	if err := app.MoveTreeRecord(d, "", 100); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTreeRecords(t, app, map[string][2]any{
+		"a00000000000000": {"/", 1},
+		"d00000000000000": {"/", 2},
+	})
+
+	// move under own descendant
+	a, err := app.FindRecordById("test_tree", "a00000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.MoveTreeRecord(a, "b00000000000000", 1); err == nil {
+		t.Fatal("Expected cycle error")
+	}
+
+	// non-tree collection
+	demo, err := app.FindRecordById("demo2", "0yxhwia2amd8gec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.MoveTreeRecord(demo, "", 1); err == nil {
+		t.Fatal("Expected non-tree collection error")
+	}
+}
+
+func TestFindRecordTreeAncestors(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	createTreeCollection(t, app, false)
+
+	scenarios := []struct {
+		id       string
+		expected []string
+	}{
+		{"a00000000000000", []string{}},
+		{"b00000000000000", []string{"a00000000000000"}},
+		{"c00000000000000", []string{"a00000000000000", "b00000000000000"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.id, func(t *testing.T) {
+			record, err := app.FindRecordById("test_tree", s.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ancestors, err := app.FindRecordTreeAncestors(record)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]string, len(ancestors))
+			for i, r := range ancestors {
+				ids[i] = r.Id
+			}
+
+			if !slices.Equal(ids, s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, ids)
+			}
+		})
+	}
+}
+
+func TestDescendantsOfFilterFunction(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTreeCollection(t, app, false)
+
+	posts := core.NewBaseCollection("test_tree_posts")
+	posts.Fields.Add(&core.RelationField{Name: "category", CollectionId: collection.Id, MaxSelect: 1})
+	if err := app.Save(posts); err != nil {
+		t.Fatal(err)
+	}
+	for _, category := range []string{"b00000000000000", "e00000000000000"} {
+		post := core.NewRecord(posts)
+		post.Id = "p" + category[:14]
+		post.Set("category", category)
+		if err := app.Save(post); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		collection string
+		filter     string
+		expected   []string
+	}{
+		{"test_tree", "descendantsOf('a00000000000000') = true", []string{"b00000000000000", "c00000000000000", "d00000000000000"}},
+		{"test_tree", "descendantsOf('b00000000000000') = true", []string{"c00000000000000"}},
+		{"test_tree", "descendantsOf('c00000000000000') = true", []string{}},
+		{"test_tree", "descendantsOf('a00000000000000') = false", []string{"a00000000000000", "e00000000000000"}},
+		{"test_tree_posts", "descendantsOf('a00000000000000', category) = true", []string{"pb0000000000000"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.collection+"_"+s.filter, func(t *testing.T) {
+			records, err := app.FindRecordsByFilter(s.collection, s.filter, "id", 0, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]string, len(records))
+			for i, r := range records {
+				ids[i] = r.Id
+			}
+
+			if !slices.Equal(ids, s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,496 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const (
+	// DefaultRecordsGenerateBatchSize is the default number of records
+	// created in a single transaction by [BaseApp.GenerateRecords].
+	DefaultRecordsGenerateBatchSize = 500
+
+	// DefaultRecordsGeneratePassword is the default plain password
+	// of the generated auth records.
+	DefaultRecordsGeneratePassword = "1234567890"
+)
+
+// the max number of the existing related record ids loaded per relation field
+const recordsGenerateRelationPoolSize = 1000
+
+// the max number of attempts to generate a single valid record
+// (e.g. in case of an unique index or a pattern constraint collision)
+const recordsGenerateAttempts = 5
+
+var (
+	recordsGenerateWords = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+		"eiusmod", "tempor", "incididunt", "labore", "dolore", "magna", "aliqua", "enim", "minim", "veniam",
+		"quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip", "commodo", "consequat", "duis",
+		"aute", "irure", "voluptate", "velit", "esse", "cillum", "fugiat", "nulla", "pariatur", "excepteur",
+	}
+	recordsGenerateCities    = []string{"London", "Paris", "Berlin", "Madrid", "Rome", "Vienna", "Prague", "Lisbon", "Athens", "Sofia", "Oslo", "Dublin"}
+	recordsGenerateCountries = []string{"United Kingdom", "France", "Germany", "Spain", "Italy", "Austria", "Czechia", "Portugal", "Greece", "Bulgaria", "Norway", "Ireland"}
+	recordsGenerateDomains   = []string{"example.com", "example.org", "example.net"}
+)
+
+// RecordsGenerateOptions defines the optional [BaseApp.GenerateRecords] settings.
+type RecordsGenerateOptions struct {
+	// Count is the number of the records to generate.
+	Count int
+
+	// BatchSize is the number of records to create in a single transaction
+	// (default to [DefaultRecordsGenerateBatchSize]).
+	BatchSize int
+
+	// Password is the plain password of the generated auth records
+	// (default to [DefaultRecordsGeneratePassword]).
+	//
+	// The password is hashed only once and all generated records share it.
+	Password string
+}
+
+// GenerateRecords creates options.Count fake records in the specified
+// collection (e.g. for demo environments and performance testing).
+//
+// The generated values are based on the field types and constraints
+// (min/max, patterns, select values, allowed domains, etc.) and on
+// common field names (e.g. "name", "title", "phone", "description").
+// The relation fields are populated with randomly chosen ids of
+// the existing related records.
+//
+// File, autodate and order fields, as well as text fields with
+// autogenerate pattern, are left to their default values.
+//
+// The records are created in batches of options.BatchSize records per
+// transaction and the returned count contains the number of records
+// created before an eventual error.
+func (app *BaseApp) GenerateRecords(ctx context.Context, collectionModelOrIdentifier any, options RecordsGenerateOptions) (int, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return 0, err
+	}
+
+	if collection.IsView() {
+		return 0, errors.New("records cannot be generated for view collections")
+	}
+
+	if options.Count <= 0 {
+		return 0, errors.New("the records count must be greater than 0")
+	}
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultRecordsGenerateBatchSize
+	}
+
+	if options.Password == "" {
+		options.Password = DefaultRecordsGeneratePassword
+	}
+
+	g := &recordsGenerator{
+		app:            app,
+		collection:     collection,
+		password:       options.Password,
+		passwordHashes: map[string]string{},
+		relationPools:  map[string][]string{},
+	}
+
+	if err := g.loadRelationPools(); err != nil {
+		return 0, err
+	}
+
+	var created int
+
+	for created < options.Count {
+		if err := ctx.Err(); err != nil {
+			return created, err
+		}
+
+		batch := min(options.BatchSize, options.Count-created)
+
+		var newIds []string
+
+		err := app.RunInTransactionWithContext(ctx, func(txApp App) error {
+			newIds = make([]string, 0, batch)
+
+			for range batch {
+				record, err := g.saveRecord(ctx, txApp)
+				if err != nil {
+					return err
+				}
+
+				newIds = append(newIds, record.Id)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return created, err
+		}
+
+		created += len(newIds)
+
+		// allow self-referencing relations to the already generated records
+		if _, ok := g.relationPools[collection.Id]; ok {
+			g.relationPools[collection.Id] = append(g.relationPools[collection.Id], newIds...)
+		}
+	}
+
+	return created, nil
+}
+
+type recordsGenerator struct {
+	app            App
+	collection     *Collection
+	password       string
+	passwordHashes map[string]string   // field name -> hash
+	relationPools  map[string][]string // collection id -> record ids
+}
+
+// loadRelationPools loads a random sample of the existing record ids
+// of each collection referenced by the generated collection relation fields.
+func (g *recordsGenerator) loadRelationPools() error {
+	for _, f := range g.collection.Fields {
+		relField, ok := f.(*RelationField)
+		if !ok {
+			continue
+		}
+
+		if _, ok := g.relationPools[relField.CollectionId]; ok {
+			continue // already loaded
+		}
+
+		relCollection, err := g.app.FindCachedCollectionByNameOrId(relField.CollectionId)
+		if err != nil {
+			return fmt.Errorf("failed to load the %q relation collection: %w", relField.Name, err)
+		}
+
+		ids := []string{}
+		err = g.app.DB().Select("id").
+			From(relCollection.Name).
+			OrderBy("random()").
+			Limit(recordsGenerateRelationPoolSize).
+			Column(&ids)
+		if err != nil {
+			return err
+		}
+
+		g.relationPools[relField.CollectionId] = ids
+
+		if len(ids) == 0 && relField.CollectionId != g.collection.Id && (relField.Required || relField.MinSelect > 0) {
+			return fmt.Errorf("the required relation field %q references the empty collection %q", relField.Name, relCollection.Name)
+		}
+	}
+
+	return nil
+}
+
+// saveRecord generates and saves a single valid record,
+// retrying with new random values on failure.
+func (g *recordsGenerator) saveRecord(ctx context.Context, txApp App) (*Record, error) {
+	var lastErr error
+
+	for range recordsGenerateAttempts {
+		record := NewRecord(g.collection)
+
+		for _, f := range g.collection.Fields {
+			if value, ok := g.fieldValue(record, f); ok {
+				record.Set(f.GetName(), value)
+			}
+		}
+
+		lastErr = txApp.SaveWithContext(ctx, record)
+		if lastErr == nil {
+			g.cachePasswordHashes(record)
+			return record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to generate a valid record after %d attempts: %w", recordsGenerateAttempts, lastErr)
+}
+
+// cachePasswordHashes stores the password fields hash of the first
+// successfully saved record so that the (slow) hashing is performed only once.
+func (g *recordsGenerator) cachePasswordHashes(record *Record) {
+	for _, f := range g.collection.Fields {
+		if f.Type() != FieldTypePassword {
+			continue
+		}
+
+		if _, ok := g.passwordHashes[f.GetName()]; !ok {
+			g.passwordHashes[f.GetName()] = record.GetString(f.GetName() + ":hash")
+		}
+	}
+}
+
+// fieldValue returns a random value for the specified field
+// or false if the field should be left with its default value.
+func (g *recordsGenerator) fieldValue(record *Record, field Field) (any, bool) {
+	switch f := field.(type) {
+	case *TextField:
+		if f.PrimaryKey || f.AutogeneratePattern != "" {
+			return nil, false
+		}
+		return generateI18nValue(f.I18n, func() string { return generateTextValue(f) }), true
+	case *EditorField:
+		return generateI18nValue(f.I18n, func() string { return "<p>" + generateSentences(1+rand.IntN(3)) + "</p>" }), true
+	case *EmailField:
+		return generateEmailValue(f.OnlyDomains, f.ExceptDomains), true
+	case *URLField:
+		return "https://" + generateDomain(f.OnlyDomains, f.ExceptDomains) + "/" + generateWords(1+rand.IntN(3), "-"), true
+	case *NumberField:
+		return generateNumberValue(f), true
+	case *BoolField:
+		return rand.IntN(2) == 1, true
+	case *DateField:
+		return generateDateValue(f.Min, f.Max), true
+	case *SelectField:
+		if len(f.Values) == 0 {
+			return nil, false
+		}
+		if !f.IsMultiple() {
+			return f.Values[rand.IntN(len(f.Values))], true
+		}
+		return randomSubset(f.Values, 1, f.MaxSelect), true
+	case *TagsField:
+		maxTags := 3
+		if f.MaxSelect > 0 {
+			maxTags = min(maxTags, f.MaxSelect)
+		}
+		return randomSubset(recordsGenerateWords, 1, maxTags), true
+	case *JSONField:
+		return map[string]any{
+			"key":   generateWords(1, ""),
+			"value": rand.IntN(1000),
+		}, true
+	case *GeoPointField:
+		return types.GeoPoint{
+			Lon: math.Round((rand.Float64()*360-180)*1e6) / 1e6,
+			Lat: math.Round((rand.Float64()*180-90)*1e6) / 1e6,
+		}, true
+	case *PasswordField:
+		if hash, ok := g.passwordHashes[f.Name]; ok {
+			record.SetRaw(f.Name, &PasswordFieldValue{Hash: hash})
+			return nil, false
+		}
+		return g.password, true
+	case *RelationField:
+		return g.relationValue(f)
+	default:
+		// file, autodate, order and unknown custom fields
+		return nil, false
+	}
+}
+
+func (g *recordsGenerator) relationValue(f *RelationField) (any, bool) {
+	pool := g.relationPools[f.CollectionId]
+	if len(pool) == 0 {
+		return nil, false
+	}
+
+	if !f.IsMultiple() {
+		return pool[rand.IntN(len(pool))], true
+	}
+
+	return randomSubset(pool, max(1, f.MinSelect), f.MaxSelect), true
+}
+
+// -------------------------------------------------------------------
+
+// randomSubset returns between minItems and maxItems unique random items
+// from the provided list (maxItems <= 0 is treated as no limit).
+func randomSubset(items []string, minItems, maxItems int) []string {
+	if maxItems <= 0 || maxItems > len(items) {
+		maxItems = len(items)
+	}
+	minItems = min(minItems, maxItems)
+
+	n := minItems + rand.IntN(maxItems-minItems+1)
+
+	result := make([]string, 0, n)
+	for _, i := range rand.Perm(len(items))[:n] {
+		result = append(result, items[i])
+	}
+
+	return result
+}
+
+func generateI18nValue(locales []string, generate func() string) any {
+	if len(locales) == 0 {
+		return generate()
+	}
+
+	values := make(map[string]string, len(locales))
+	for _, locale := range locales {
+		values[locale] = generate()
+	}
+
+	return values
+}
+
+func generateTextValue(f *TextField) string {
+	if f.Pattern != "" {
+		// the random generator doesn't support anchors
+		pattern := strings.TrimPrefix(f.Pattern, "^")
+		if strings.HasSuffix(pattern, "$") && !strings.HasSuffix(pattern, `\$`) {
+			pattern = pattern[:len(pattern)-1]
+		}
+
+		if str, err := security.RandomStringByRegex(pattern); err == nil {
+			return str
+		}
+	}
+
+	name := strings.ToLower(f.Name)
+
+	var val string
+
+	switch {
+	case strings.Contains(name, "username"):
+		val = strings.ToLower(randomItem(exportMaskFirstNames)) + strconv.Itoa(rand.IntN(10000))
+	case strings.Contains(name, "first"):
+		val = randomItem(exportMaskFirstNames)
+	case strings.Contains(name, "last"), strings.Contains(name, "surname"):
+		val = randomItem(exportMaskLastNames)
+	case strings.Contains(name, "name"), strings.Contains(name, "author"):
+		val = randomItem(exportMaskFirstNames) + " " + randomItem(exportMaskLastNames)
+	case strings.Contains(name, "email"):
+		val = generateEmailValue(nil, nil)
+	case strings.Contains(name, "phone"):
+		val = fmt.Sprintf("+1 555 %03d %04d", rand.IntN(1000), rand.IntN(10000))
+	case strings.Contains(name, "city"):
+		val = randomItem(recordsGenerateCities)
+	case strings.Contains(name, "country"):
+		val = randomItem(recordsGenerateCountries)
+	case strings.Contains(name, "slug"):
+		val = generateWords(3, "-") + "-" + strconv.Itoa(rand.IntN(10000))
+	case strings.Contains(name, "title"), strings.Contains(name, "subject"):
+		val = strings.TrimSuffix(generateSentences(1), ".")
+	case slices.ContainsFunc([]string{"description", "content", "body", "bio", "summary", "comment", "note", "message"}, func(s string) bool {
+		return strings.Contains(name, s)
+	}):
+		val = generateSentences(2 + rand.IntN(3))
+	default:
+		val = generateWords(2+rand.IntN(3), " ")
+	}
+
+	// adjust the value length to the field constraints
+	for len([]rune(val)) < f.Min {
+		val += " " + generateWords(1, "")
+	}
+
+	maxLength := f.Max
+	if maxLength <= 0 {
+		maxLength = 5000
+	}
+	if runes := []rune(val); len(runes) > maxLength {
+		val = strings.TrimSpace(string(runes[:maxLength]))
+	}
+
+	return val
+}
+
+func generateNumberValue(f *NumberField) float64 {
+	minVal, maxVal := 0.0, 1000.0
+
+	switch {
+	case f.Min != nil && f.Max != nil:
+		minVal, maxVal = *f.Min, *f.Max
+	case f.Min != nil:
+		minVal, maxVal = *f.Min, *f.Min+1000
+	case f.Max != nil:
+		minVal, maxVal = *f.Max-1000, *f.Max
+	}
+
+	if f.OnlyInt {
+		minVal, maxVal = math.Ceil(minVal), math.Floor(maxVal)
+		if maxVal < minVal {
+			return minVal
+		}
+		return minVal + float64(rand.Int64N(int64(maxVal-minVal)+1))
+	}
+
+	return math.Round((minVal+rand.Float64()*(maxVal-minVal))*100) / 100
+}
+
+func generateDateValue(minDate, maxDate types.DateTime) types.DateTime {
+	const defaultRange = 365 * 24 * time.Hour
+
+	start, end := minDate.Time(), maxDate.Time()
+
+	switch {
+	case minDate.IsZero() && maxDate.IsZero():
+		end = time.Now()
+		start = end.Add(-defaultRange)
+	case maxDate.IsZero():
+		end = start.Add(defaultRange)
+	case minDate.IsZero():
+		start = end.Add(-defaultRange)
+	}
+
+	var offset time.Duration
+	if diff := end.Sub(start); diff > 0 {
+		offset = time.Duration(rand.Int64N(int64(diff)))
+	}
+
+	dt, _ := types.ParseDateTime(start.Add(offset).Truncate(time.Second))
+
+	return dt
+}
+
+func generateEmailValue(onlyDomains, exceptDomains []string) string {
+	return fmt.Sprintf(
+		"%s.%s%d@%s",
+		strings.ToLower(randomItem(exportMaskFirstNames)),
+		strings.ToLower(randomItem(exportMaskLastNames)),
+		rand.IntN(100000),
+		generateDomain(onlyDomains, exceptDomains),
+	)
+}
+
+func generateDomain(onlyDomains, exceptDomains []string) string {
+	if len(onlyDomains) > 0 {
+		return randomItem(onlyDomains)
+	}
+
+	for _, i := range rand.Perm(len(recordsGenerateDomains)) {
+		if !slices.Contains(exceptDomains, recordsGenerateDomains[i]) {
+			return recordsGenerateDomains[i]
+		}
+	}
+
+	return "example.dev"
+}
+
+func generateWords(n int, separator string) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = randomItem(recordsGenerateWords)
+	}
+
+	return strings.Join(words, separator)
+}
+
+func generateSentences(n int) string {
+	sentences := make([]string, n)
+	for i := range sentences {
+		words := generateWords(4+rand.IntN(6), " ")
+		sentences[i] = strings.ToUpper(words[:1]) + words[1:] + "."
+	}
+
+	return strings.Join(sentences, " ")
+}
+
+func randomItem(items []string) string {
+	return items[rand.IntN(len(items))]
+}
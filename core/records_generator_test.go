@@ -0,0 +1,168 @@
+package core_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestGenerateRecords(t *testing.T) {
+	t.Parallel()
+
+	categories := core.NewBaseCollection("categories")
+	categories.Fields.Add(&core.TextField{Name: "name", Required: true})
+
+	minPrice, maxPrice := 10.0, 20.0
+	minDate, _ := types.ParseDateTime("2020-01-01 00:00:00.000Z")
+	maxDate, _ := types.ParseDateTime("2020-12-31 00:00:00.000Z")
+
+	posts := core.NewBaseCollection("posts")
+	posts.Fields.Add(
+		&core.TextField{Name: "title", Required: true, Min: 10, Max: 30},
+		&core.TextField{Name: "slug", Required: true},
+		&core.TextField{Name: "code", Required: true, Pattern: `^[A-Z]{3}-\d{4}$`},
+		&core.EmailField{Name: "contact", Required: true, OnlyDomains: []string{"pocketbase.io"}},
+		&core.URLField{Name: "website", Required: true},
+		&core.NumberField{Name: "price", Required: true, Min: &minPrice, Max: &maxPrice, OnlyInt: true},
+		&core.DateField{Name: "published", Required: true, Min: minDate, Max: maxDate},
+		&core.SelectField{Name: "labels", Required: true, Values: []string{"a", "b", "c"}, MaxSelect: 2},
+		&core.BoolField{Name: "active"},
+		&core.JSONField{Name: "meta", Required: true},
+		&core.GeoPointField{Name: "location"},
+		&core.EditorField{Name: "content", Required: true},
+		&core.TagsField{Name: "tags", Required: true, MaxSelect: 2},
+	)
+	posts.AddIndex("idx_posts_slug", true, "slug", "")
+
+	app := tests.NewTestAppBuilder(t).InMemory().WithCollections(categories, posts).Build()
+
+	ctx := context.Background()
+
+	categoriesCollection, err := app.FindCollectionByNameOrId("categories")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// relation fields are added after the referenced collection creation
+	postsCollection, err := app.FindCollectionByNameOrId("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	postsCollection.Fields.Add(&core.RelationField{
+		Name:         "categories",
+		CollectionId: categoriesCollection.Id,
+		Required:     true,
+		MinSelect:    2,
+		MaxSelect:    3,
+	})
+	if err := app.Save(postsCollection); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := app.GenerateRecords(ctx, "missing", core.RecordsGenerateOptions{Count: 1}); err == nil {
+			t.Fatal("Expected missing collection error")
+		}
+
+		if _, err := app.GenerateRecords(ctx, "posts", core.RecordsGenerateOptions{}); err == nil {
+			t.Fatal("Expected zero count error")
+		}
+
+		// no categories to reference yet
+		if _, err := app.GenerateRecords(ctx, "posts", core.RecordsGenerateOptions{Count: 1}); err == nil {
+			t.Fatal("Expected empty required relation collection error")
+		}
+	})
+
+	created, err := app.GenerateRecords(ctx, "categories", core.RecordsGenerateOptions{Count: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 5 {
+		t.Fatalf("Expected 5 created categories, got %d", created)
+	}
+
+	created, err = app.GenerateRecords(ctx, "posts", core.RecordsGenerateOptions{Count: 50, BatchSize: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 50 {
+		t.Fatalf("Expected 50 created posts, got %d", created)
+	}
+
+	total, err := app.CountRecords("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 50 {
+		t.Fatalf("Expected 50 stored posts, got %d", total)
+	}
+
+	// all generated records must satisfy the field constraints
+	report, err := app.ValidateRecords(ctx, "posts", core.RecordsValidationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Invalid != 0 {
+		t.Fatalf("Expected no invalid posts, got %d: %v", report.Invalid, report.Issues[0].Errors)
+	}
+
+	records, err := app.FindAllRecords("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	categoryIds := []string{}
+	if err := app.DB().Select("id").From("categories").Column(&categoryIds); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, record := range records {
+		if !strings.HasSuffix(record.GetString("contact"), "@pocketbase.io") {
+			t.Fatalf("Expected @pocketbase.io contact, got %q", record.GetString("contact"))
+		}
+
+		if year := record.GetDateTime("published").Time().Year(); year != 2020 {
+			t.Fatalf("Expected published date in 2020, got %v", record.GetDateTime("published"))
+		}
+
+		rels := record.GetStringSlice("categories")
+		if len(rels) < 2 || len(rels) > 3 {
+			t.Fatalf("Expected 2-3 categories, got %v", rels)
+		}
+		for _, id := range rels {
+			if !slices.Contains(categoryIds, id) {
+				t.Fatalf("Expected existing category id, got %q", id)
+			}
+		}
+	}
+
+	t.Run("auth collection", func(t *testing.T) {
+		created, err := app.GenerateRecords(ctx, "users", core.RecordsGenerateOptions{Count: 3, Password: "test_password"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created != 3 {
+			t.Fatalf("Expected 3 created users, got %d", created)
+		}
+
+		users, err := app.FindAllRecords("users")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != 3 {
+			t.Fatalf("Expected 3 users, got %d", len(users))
+		}
+
+		for _, user := range users {
+			if user.Email() == "" || !user.ValidatePassword("test_password") {
+				t.Fatalf("Expected user with email and the generated password, got %q", user.Email())
+			}
+		}
+	})
+}
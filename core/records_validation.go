@@ -0,0 +1,291 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+const (
+	// RecordsValidationFixTrim trims the surrounding whitespaces of
+	// the invalid plain text, email and url values.
+	RecordsValidationFixTrim = "trim"
+
+	// RecordsValidationFixTruncate truncates the plain text values
+	// exceeding the field max length.
+	RecordsValidationFixTruncate = "truncate"
+
+	// RecordsValidationFixClamp rounds the decimal numbers of the "onlyInt"
+	// number fields and clamps the values outside of the field min/max.
+	RecordsValidationFixClamp = "clamp"
+
+	// RecordsValidationFixSelect removes the unknown select options and
+	// the selected options exceeding the field maxSelect.
+	RecordsValidationFixSelect = "select"
+)
+
+// RecordsValidationFixes lists all supported [RecordsValidationOptions.Fixes].
+var RecordsValidationFixes = []string{
+	RecordsValidationFixTrim,
+	RecordsValidationFixTruncate,
+	RecordsValidationFixClamp,
+	RecordsValidationFixSelect,
+}
+
+// DefaultRecordsValidationBatchSize is the default number of records
+// loaded at once by [BaseApp.ValidateRecords].
+const DefaultRecordsValidationBatchSize = 500
+
+// RecordsValidationOptions defines the optional [BaseApp.ValidateRecords] settings.
+type RecordsValidationOptions struct {
+	// Fixes specifies the trivial coercions (see RecordsValidationFix* constants)
+	// to apply on the invalid record fields.
+	//
+	// The fixed records are saved only if they pass the validation after
+	// the coercions, otherwise they are left untouched.
+	Fixes []string
+
+	// BatchSize is the number of records to load at once
+	// (default to [DefaultRecordsValidationBatchSize]).
+	BatchSize int
+}
+
+// RecordValidationIssue describes the validation errors of a single stored record.
+type RecordValidationIssue struct {
+	// RecordId is the id of the invalid record.
+	RecordId string `json:"recordId"`
+
+	// Errors contains the field validation error messages (the key is the field name).
+	Errors map[string]string `json:"errors"`
+
+	// Fixed lists the names of the fixed fields
+	// (non-empty only if the record was successfully saved).
+	Fixed []string `json:"fixed,omitempty"`
+
+	// FixError is the error message of a failed fix attempt (if any).
+	FixError string `json:"fixError,omitempty"`
+}
+
+// RecordsValidationReport defines the result of [BaseApp.ValidateRecords].
+type RecordsValidationReport struct {
+	// Collection is the name of the validated collection.
+	Collection string `json:"collection"`
+
+	// Total is the number of the checked records.
+	Total int `json:"total"`
+
+	// Invalid is the number of the records that are still invalid.
+	Invalid int `json:"invalid"`
+
+	// Fixed is the number of the invalid records that were fixed.
+	Fixed int `json:"fixed"`
+
+	// Issues lists the invalid (including the fixed) records.
+	Issues []*RecordValidationIssue `json:"issues"`
+}
+
+// ValidateRecords re-runs the field validators over all stored records
+// of the specified collection (e.g. after a schema change or a raw import)
+// and reports the records that no longer satisfy the field constraints.
+//
+// Note that only the field validators are executed, aka. the
+// OnRecordValidate hooks are not triggered for the checks.
+// The optional fixes however are persisted with the regular [BaseApp.Save].
+func (app *BaseApp) ValidateRecords(ctx context.Context, collectionModelOrIdentifier any, options RecordsValidationOptions) (*RecordsValidationReport, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection.IsView() {
+		return nil, errors.New("the records of view collections cannot be validated")
+	}
+
+	for _, fix := range options.Fixes {
+		if !slices.Contains(RecordsValidationFixes, fix) {
+			return nil, fmt.Errorf("unsupported fix %q", fix)
+		}
+	}
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultRecordsValidationBatchSize
+	}
+
+	report := &RecordsValidationReport{
+		Collection: collection.Name,
+		Issues:     []*RecordValidationIssue{},
+	}
+
+	var lastId string
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		records := []*Record{}
+
+		err := app.RecordQuery(collection).
+			AndWhere(dbx.NewExp("[["+collection.Name+".id]] > {:lastId}", dbx.Params{"lastId": lastId})).
+			OrderBy("id ASC").
+			Limit(int64(options.BatchSize)).
+			All(&records)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			report.Total++
+
+			issue := app.validateStoredRecord(ctx, record, options.Fixes)
+			if issue == nil {
+				continue
+			}
+
+			if len(issue.Fixed) > 0 {
+				report.Fixed++
+			} else {
+				report.Invalid++
+			}
+
+			report.Issues = append(report.Issues, issue)
+		}
+
+		if len(records) < options.BatchSize {
+			break
+		}
+
+		lastId = records[len(records)-1].Id
+	}
+
+	return report, nil
+}
+
+func (app *BaseApp) validateStoredRecord(ctx context.Context, record *Record, fixes []string) *RecordValidationIssue {
+	fieldErrors := map[string]error{}
+
+	for _, f := range record.Collection().Fields {
+		if err := f.ValidateValue(ctx, app, record); err != nil {
+			fieldErrors[f.GetName()] = err
+		}
+	}
+
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	issue := &RecordValidationIssue{
+		RecordId: record.Id,
+		Errors:   make(map[string]string, len(fieldErrors)),
+	}
+
+	for name, err := range fieldErrors {
+		issue.Errors[name] = err.Error()
+	}
+
+	if len(fixes) == 0 {
+		return issue
+	}
+
+	fixed := []string{}
+	for name := range fieldErrors {
+		field := record.Collection().Fields.GetByName(name)
+
+		if !fixRecordFieldValue(record, field, fixes) || field.ValidateValue(ctx, app, record) != nil {
+			return issue // the record can't be fully fixed
+		}
+
+		fixed = append(fixed, name)
+	}
+
+	if err := app.SaveWithContext(ctx, record); err != nil {
+		issue.FixError = err.Error()
+		return issue
+	}
+
+	slices.Sort(fixed)
+	issue.Fixed = fixed
+
+	return issue
+}
+
+// fixRecordFieldValue applies the enabled trivial coercions to
+// the record field value and reports whether the value was changed.
+func fixRecordFieldValue(record *Record, field Field, fixes []string) bool {
+	raw := record.GetRaw(field.GetName())
+
+	var newVal any
+
+	switch f := field.(type) {
+	case *TextField, *EmailField, *URLField:
+		val, ok := raw.(string)
+		if !ok {
+			return false // e.g. i18n text
+		}
+
+		if slices.Contains(fixes, RecordsValidationFixTrim) {
+			val = strings.TrimSpace(val)
+		}
+
+		if tf, ok := f.(*TextField); ok && slices.Contains(fixes, RecordsValidationFixTruncate) {
+			maxLen := tf.Max
+			if maxLen == 0 {
+				maxLen = 5000
+			}
+			if runes := []rune(val); len(runes) > maxLen {
+				val = string(runes[:maxLen])
+			}
+		}
+
+		newVal = val
+	case *NumberField:
+		val, ok := raw.(float64)
+		if !ok || !slices.Contains(fixes, RecordsValidationFixClamp) || math.IsNaN(val) {
+			return false
+		}
+
+		if f.OnlyInt {
+			val = math.Round(val)
+		}
+		if f.Min != nil && val < *f.Min {
+			val = *f.Min
+		}
+		if f.Max != nil && val > *f.Max {
+			val = *f.Max
+		}
+
+		newVal = val
+	case *SelectField:
+		if !slices.Contains(fixes, RecordsValidationFixSelect) {
+			return false
+		}
+
+		values := list.ToUniqueStringSlice(raw)
+		values = slices.DeleteFunc(values, func(v string) bool {
+			return !slices.Contains(f.Values, v)
+		})
+		if maxSelect := max(f.MaxSelect, 1); len(values) > maxSelect {
+			values = values[:maxSelect]
+		}
+
+		if f.IsMultiple() {
+			newVal = values
+		} else if len(values) > 0 {
+			newVal = values[0]
+		} else {
+			newVal = ""
+		}
+	default:
+		return false
+	}
+
+	record.Set(field.GetName(), newVal)
+
+	return fmt.Sprint(record.GetRaw(field.GetName())) != fmt.Sprint(raw)
+}
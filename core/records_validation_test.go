@@ -0,0 +1,122 @@
+package core_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func newRecordsValidationTestApp(t *testing.T) *tests.TestApp {
+	posts := core.NewBaseCollection("posts")
+	posts.Fields.Add(
+		&core.TextField{Name: "title", Required: true, Max: 5},
+		&core.NumberField{Name: "views", OnlyInt: true, Min: types.Pointer(0.0), Max: types.Pointer(10.0)},
+		&core.SelectField{Name: "status", Values: []string{"a", "b"}, MaxSelect: 1},
+		&core.SelectField{Name: "tags", Values: []string{"x", "y", "z"}, MaxSelect: 2},
+	)
+
+	app := tests.NewTestAppBuilder(t).InMemory().WithCollections(posts).Build()
+
+	rows := []dbx.Params{
+		{"id": "r1_valid_000000", "title": "abc", "views": 5, "status": "a", "tags": `["x"]`},
+		{"id": "r2_fixable_0000", "title": "  abc  ", "views": 12.5, "status": "c", "tags": `["x","q","y","z"]`},
+		{"id": "r3_invalid_0000", "title": "", "views": 3, "status": "b", "tags": `[]`},
+	}
+	for _, row := range rows {
+		if _, err := app.DB().Insert("posts", row).Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return app
+}
+
+func TestValidateRecords(t *testing.T) {
+	t.Parallel()
+
+	t.Run("errors", func(t *testing.T) {
+		app := newRecordsValidationTestApp(t)
+
+		if _, err := app.ValidateRecords(context.Background(), "missing", core.RecordsValidationOptions{}); err == nil {
+			t.Fatal("Expected missing collection error")
+		}
+
+		view := core.NewViewCollection("posts_view")
+		view.ViewQuery = "select id, title from posts"
+		if err := app.Save(view); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := app.ValidateRecords(context.Background(), view, core.RecordsValidationOptions{}); err == nil {
+			t.Fatal("Expected view collection error")
+		}
+
+		if _, err := app.ValidateRecords(context.Background(), "posts", core.RecordsValidationOptions{Fixes: []string{"missing"}}); err == nil {
+			t.Fatal("Expected unsupported fix error")
+		}
+	})
+
+	t.Run("report only", func(t *testing.T) {
+		app := newRecordsValidationTestApp(t)
+
+		report, err := app.ValidateRecords(context.Background(), "posts", core.RecordsValidationOptions{BatchSize: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		raw, _ := json.Marshal(report)
+
+		expected := `{"collection":"posts","total":3,"invalid":2,"fixed":0,"issues":[` +
+			`{"recordId":"r2_fixable_0000","errors":{"status":"Invalid value c","tags":"Select no more than 2","title":"Must be no more than 5 character(s).","views":"Decimal numbers are not allowed"}},` +
+			`{"recordId":"r3_invalid_0000","errors":{"title":"cannot be blank"}}]}`
+		if string(raw) != expected {
+			t.Fatalf("Expected report\n%s\ngot\n%s", expected, raw)
+		}
+
+		if len(app.EventCalls) != 0 {
+			t.Fatalf("Expected no triggered events, got %v", app.EventCalls)
+		}
+	})
+
+	t.Run("with fixes", func(t *testing.T) {
+		app := newRecordsValidationTestApp(t)
+
+		report, err := app.ValidateRecords(context.Background(), "posts", core.RecordsValidationOptions{Fixes: core.RecordsValidationFixes})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if report.Total != 3 || report.Invalid != 1 || report.Fixed != 1 {
+			t.Fatalf("Expected 3 total, 1 invalid and 1 fixed records, got %d, %d and %d", report.Total, report.Invalid, report.Fixed)
+		}
+
+		fixed, _ := json.Marshal(report.Issues[0].Fixed)
+		if string(fixed) != `["status","tags","title","views"]` {
+			t.Fatalf("Unexpected fixed fields %s", fixed)
+		}
+
+		record, err := app.FindRecordById("posts", "r2_fixable_0000")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, _ := json.Marshal(record)
+		expected := `{"collectionId":"` + record.Collection().Id + `","collectionName":"posts","id":"r2_fixable_0000","status":"","tags":["x","y"],"title":"abc","views":10}`
+		if string(data) != expected {
+			t.Fatalf("Expected fixed record\n%s\ngot\n%s", expected, data)
+		}
+
+		// the unfixable record should be untouched
+		record, err = app.FindRecordById("posts", "r3_invalid_0000")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.GetString("title") != "" || record.GetInt("views") != 3 {
+			t.Fatalf("Expected the invalid record to be untouched, got %v", record)
+		}
+	})
+}
@@ -0,0 +1,321 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/logger"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*CapturedRequest)(nil)
+
+const CapturedRequestsTableName = "_capturedRequests"
+
+// DefaultCaptureMaxBodySize is the default max size in bytes of
+// the captured request and response bodies.
+const DefaultCaptureMaxBodySize = 64 << 10
+
+// captureRedactedKeys is the list of the header names and JSON, form and
+// multipart body field names (case-insensitive) that are always masked in the captured requests.
+var captureRedactedKeys = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"Proxy-Authorization",
+	"X-Api-Key",
+	"password",
+	"passwordConfirm",
+	"oldPassword",
+	"newPassword",
+	"token",
+	"refreshToken",
+	"secret",
+	"clientSecret",
+	"otp",
+}
+
+// CaptureConfig defines the debug traffic capture settings.
+//
+// When enabled, the full request/response pairs of the matching routes
+// are stored (redacted) in a ring buffer so that they can be inspected
+// and replayed later to help reproducing reported API bugs.
+type CaptureConfig struct {
+	// Routes is a list of request paths to capture, optionally prefixed
+	// with the request method (see [LogsSamplingRule.Path] for the format).
+	Routes []string `form:"routes" json:"routes"`
+
+	// MaxItems is the max number of the stored captured requests
+	// (the oldest ones are deleted first).
+	MaxItems int `form:"maxItems" json:"maxItems"`
+
+	// MaxBodySize is the max size in bytes of the stored
+	// request and response bodies (the bodies are truncated).
+	MaxBodySize int `form:"maxBodySize" json:"maxBodySize"`
+
+	Enabled bool `form:"enabled" json:"enabled"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c CaptureConfig) MarshalJSON() ([]byte, error) {
+	type alias CaptureConfig
+
+	// serialize as empty array
+	if c.Routes == nil {
+		c.Routes = []string{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes CaptureConfig validatable by implementing [validation.Validatable] interface.
+func (c CaptureConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Routes, validation.When(c.Enabled, validation.Required), validation.Each(validation.Required, validation.Match(logsSamplingPathRegex))),
+		validation.Field(&c.MaxItems, validation.When(c.Enabled, validation.Required), validation.Min(0), validation.Max(10000)),
+		validation.Field(&c.MaxBodySize, validation.Min(0)),
+	)
+}
+
+// Match reports whether a request with the specified method and path should be captured.
+func (c CaptureConfig) Match(method string, path string) bool {
+	for _, route := range c.Routes {
+		if (LogsSamplingRule{Path: route}).Match(method, path, 0) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CapturedRequest defines a single captured request/response pair.
+type CapturedRequest struct {
+	BaseModel
+
+	Created types.DateTime `db:"created" json:"created"`
+	Method  string         `db:"method" json:"method"`
+
+	// URL is the request path with its query parameters.
+	URL string `db:"url" json:"url"`
+
+	// Route is the matched route pattern (if any).
+	Route string `db:"route" json:"route"`
+
+	RequestHeaders  types.JSONMap[string] `db:"requestHeaders" json:"requestHeaders"`
+	RequestBody     string                `db:"requestBody" json:"requestBody"`
+	Status          int                   `db:"status" json:"status"`
+	ResponseHeaders types.JSONMap[string] `db:"responseHeaders" json:"responseHeaders"`
+	ResponseBody    string                `db:"responseBody" json:"responseBody"`
+
+	// AuthCollection and AuthRecord are the request auth record
+	// collection and id (if any) used when replaying "as auth".
+	AuthCollection string `db:"authCollection" json:"authCollection"`
+	AuthRecord     string `db:"authRecord" json:"authRecord"`
+
+	// RequestTruncated and ResponseTruncated indicate that the related body
+	// exceeded the configured max body size and was not fully stored.
+	RequestTruncated  bool `db:"requestTruncated" json:"requestTruncated"`
+	ResponseTruncated bool `db:"responseTruncated" json:"responseTruncated"`
+
+	Duration float64 `db:"duration" json:"duration"` // in ms
+}
+
+func (m *CapturedRequest) TableName() string {
+	return CapturedRequestsTableName
+}
+
+// SaveCapturedRequest redacts and stores the provided captured request
+// (it does nothing if the app traffic capture is disabled).
+//
+// Only the most recent Settings().Capture.MaxItems captured requests are kept.
+func (app *BaseApp) SaveCapturedRequest(capture *CapturedRequest) error {
+	config := app.Settings().Capture
+	if !config.Enabled {
+		return nil
+	}
+
+	if capture.Id == "" {
+		capture.Id = GenerateDefaultRandomId()
+	}
+
+	if capture.Created.IsZero() {
+		capture.Created = types.NowDateTime()
+	}
+
+	app.RedactCapturedRequest(capture)
+
+	if err := app.AuxSaveNoValidate(capture); err != nil {
+		return err
+	}
+
+	// keep only the most recent captures
+	_, err := app.AuxNonconcurrentDB().NewQuery(
+		"DELETE FROM {{" + CapturedRequestsTableName + "}} WHERE [[id]] NOT IN (" +
+			"SELECT [[id]] FROM {{" + CapturedRequestsTableName + "}} ORDER BY [[created]] DESC, [[rowid]] DESC LIMIT {:limit}" +
+			")",
+	).Bind(dbx.Params{"limit": config.MaxItems}).Execute()
+
+	return err
+}
+
+// RedactCapturedRequest masks in place the sensitive information
+// of the provided captured request headers and bodies.
+//
+// The credential headers and common secret body fields are always masked.
+// The tokens, emails and custom patterns and fields of the
+// Settings().Logs.Redaction are applied too.
+func (app *BaseApp) RedactCapturedRequest(capture *CapturedRequest) {
+	redactor := app.Settings().Logs.Redaction.Redactor()
+	redactor.Keys = append(redactor.Keys, captureRedactedKeys...)
+	if !app.Settings().Logs.Redaction.Tokens {
+		redactor.Patterns = append(redactor.Patterns, logger.RedactTokensPattern)
+	}
+
+	for k, v := range capture.RequestHeaders {
+		capture.RequestHeaders[k], _ = redactor.RedactValue(k, v).(string)
+	}
+
+	for k, v := range capture.ResponseHeaders {
+		capture.ResponseHeaders[k], _ = redactor.RedactValue(k, v).(string)
+	}
+
+	capture.URL, _ = redactor.RedactValue("", capture.URL).(string)
+	capture.RequestBody = redactCaptureBody(redactor, capture.RequestBody, captureHeader(capture.RequestHeaders, "Content-Type"))
+	capture.ResponseBody = redactCaptureBody(redactor, capture.ResponseBody, captureHeader(capture.ResponseHeaders, "Content-Type"))
+}
+
+// captureHeader returns the value of the specified captured header
+// (the header name is matched case-insensitively).
+func captureHeader(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// redactCaptureBody redacts the provided raw body based on its content type
+// so that the sensitive JSON, form and multipart fields could be masked,
+// otherwise it is redacted as plain text.
+//
+// Form and multipart bodies that couldn't be parsed are not stored at all
+// to avoid leaking their sensitive fields.
+func redactCaptureBody(redactor *logger.Redactor, body string, contentType string) string {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return body
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(trimmed)
+		if err != nil {
+			return ""
+		}
+
+		for k, v := range values {
+			for i, item := range v {
+				v[i], _ = redactor.RedactValue(k, item).(string)
+			}
+		}
+
+		return values.Encode()
+	case strings.HasPrefix(mediaType, "multipart/"):
+		if params["boundary"] == "" {
+			return ""
+		}
+
+		return redactMultipartBody(redactor, body, params["boundary"])
+	}
+
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		var data any
+		if err := json.Unmarshal([]byte(trimmed), &data); err == nil {
+			raw, err := json.Marshal(redactor.RedactValue("", data))
+			if err == nil {
+				return string(raw)
+			}
+		}
+	}
+
+	result, _ := redactor.RedactValue("", body).(string)
+
+	return result
+}
+
+// redactMultipartBody rebuilds the provided multipart body
+// with redacted non-file parts.
+//
+// The file parts are stored as they are and the last incomplete part
+// of a truncated body is skipped.
+func redactMultipartBody(redactor *logger.Redactor, body string, boundary string) string {
+	reader := multipart.NewReader(strings.NewReader(body), boundary)
+
+	var result strings.Builder
+
+	writer := multipart.NewWriter(&result)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return ""
+	}
+
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			writer.Close()
+			break
+		}
+		if err != nil {
+			break
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			break
+		}
+
+		if part.FileName() == "" {
+			value, _ := redactor.RedactValue(part.FormName(), string(data)).(string)
+			data = []byte(value)
+		}
+
+		pw, err := writer.CreatePart(part.Header)
+		if err != nil {
+			break
+		}
+		pw.Write(data)
+	}
+
+	return result.String()
+}
+
+// FindCapturedRequestById finds a single captured request by its id.
+func (app *BaseApp) FindCapturedRequestById(id string) (*CapturedRequest, error) {
+	model := &CapturedRequest{}
+
+	err := app.AuxModelQuery(model).
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// DeleteCapturedRequests deletes all stored captured requests.
+func (app *BaseApp) DeleteCapturedRequests() error {
+	_, err := app.AuxNonconcurrentDB().Delete(CapturedRequestsTableName, nil).Execute()
+
+	return err
+}
@@ -0,0 +1,261 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestCaptureConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		config         core.CaptureConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.CaptureConfig{},
+			[]string{},
+		},
+		{
+			"enabled with empty data",
+			core.CaptureConfig{Enabled: true},
+			[]string{"routes", "maxItems"},
+		},
+		{
+			"invalid data",
+			core.CaptureConfig{
+				Routes:      []string{"", "api/test"},
+				MaxItems:    10001,
+				MaxBodySize: -1,
+			},
+			[]string{"routes", "maxItems", "maxBodySize"},
+		},
+		{
+			"enabled with valid data",
+			core.CaptureConfig{
+				Enabled:     true,
+				Routes:      []string{"/api/health", "POST /api/collections/*"},
+				MaxItems:    100,
+				MaxBodySize: 100,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			tests.TestValidationErrors(t, s.config.Validate(), s.expectedErrors)
+		})
+	}
+}
+
+func TestCaptureConfigMatch(t *testing.T) {
+	t.Parallel()
+
+	config := core.CaptureConfig{
+		Routes: []string{"/api/health", "POST /api/collections/"},
+	}
+
+	scenarios := []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{"GET", "/api/health", true},
+		{"POST", "/api/health", true},
+		{"GET", "/api/health/test", false},
+		{"POST", "/api/collections/demo1/records", true},
+		{"GET", "/api/collections/demo1/records", false},
+		{"POST", "/api/settings", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.method+" "+s.path, func(t *testing.T) {
+			if v := config.Match(s.method, s.path); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestSaveCapturedRequest(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	countCaptures := func() int {
+		var total int
+		if err := app.AuxDB().Select("count(*)").From(core.CapturedRequestsTableName).Row(&total); err != nil {
+			t.Fatal(err)
+		}
+		return total
+	}
+
+	newCapture := func() *core.CapturedRequest {
+		return &core.CapturedRequest{
+			Method: "POST",
+			URL:    "/api/test?token=eyJhbGciOiJIUzI1NiJ9.eyJpZCI6InRlc3QifQ.abc",
+			RequestHeaders: types.JSONMap[string]{
+				"Authorization": "abc",
+				"Content-Type":  "application/json",
+			},
+			RequestBody:     `{"email":"test@example.com","password":"1234567890","nested":{"Token":"abc"}}`,
+			Status:          200,
+			ResponseHeaders: types.JSONMap[string]{"Set-Cookie": "session=abc"},
+			ResponseBody:    "plain Bearer abc",
+		}
+	}
+
+	// disabled
+	if err := app.SaveCapturedRequest(newCapture()); err != nil {
+		t.Fatal(err)
+	}
+	if total := countCaptures(); total != 0 {
+		t.Fatalf("Expected no captures to be stored while disabled, got %d", total)
+	}
+
+	app.Settings().Capture.Enabled = true
+	app.Settings().Capture.MaxItems = 2
+	app.Settings().Logs.Redaction.Emails = true
+
+	capture := newCapture()
+	if err := app.SaveCapturedRequest(capture); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := app.FindCapturedRequestById(capture.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectations := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"url", stored.URL, "/api/test?token=[REDACTED]"},
+		{"authorization header", stored.RequestHeaders["Authorization"], "[REDACTED]"},
+		{"content-type header", stored.RequestHeaders["Content-Type"], "application/json"},
+		{"request body", stored.RequestBody, `{"email":"[REDACTED]","nested":{"Token":"[REDACTED]"},"password":"[REDACTED]"}`},
+		{"set-cookie header", stored.ResponseHeaders["Set-Cookie"], "[REDACTED]"},
+		{"response body", stored.ResponseBody, "plain [REDACTED]"},
+	}
+	for _, e := range expectations {
+		if e.value != e.expected {
+			t.Errorf("Expected %s %q, got %q", e.name, e.expected, e.value)
+		}
+	}
+
+	// ring buffer
+	ids := []string{capture.Id}
+	for i := 0; i < 2; i++ {
+		c := newCapture()
+		if err := app.SaveCapturedRequest(c); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, c.Id)
+	}
+
+	if total := countCaptures(); total != 2 {
+		t.Fatalf("Expected only the 2 most recent captures, got %d", total)
+	}
+
+	if _, err := app.FindCapturedRequestById(ids[0]); err == nil {
+		t.Fatal("Expected the oldest capture to be deleted")
+	}
+
+	for _, id := range ids[1:] {
+		if _, err := app.FindCapturedRequestById(id); err != nil {
+			t.Fatalf("Expected capture %q to exist: %v", id, err)
+		}
+	}
+
+	// delete all
+	if err := app.DeleteCapturedRequests(); err != nil {
+		t.Fatal(err)
+	}
+	if total := countCaptures(); total != 0 {
+		t.Fatalf("Expected all captures to be deleted, got %d", total)
+	}
+}
+
+func TestRedactCapturedRequestBodies(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	multipartBody := "--abc\r\n" +
+		"Content-Disposition: form-data; name=\"email\"\r\n\r\n" +
+		"test@example.com\r\n" +
+		"--abc\r\n" +
+		"Content-Disposition: form-data; name=\"password\"\r\n\r\n" +
+		"1234567890\r\n" +
+		"--abc\r\n" +
+		"Content-Disposition: form-data; name=\"file\"; filename=\"secret.txt\"\r\n" +
+		"Content-Type: text/plain\r\n\r\n" +
+		"file content\r\n" +
+		"--abc--\r\n"
+
+	scenarios := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    string
+	}{
+		{
+			"urlencoded",
+			"application/x-www-form-urlencoded",
+			"email=test%40example.com&password=1234567890&Token=abc",
+			"Token=%5BREDACTED%5D&email=test%40example.com&password=%5BREDACTED%5D",
+		},
+		{
+			"invalid urlencoded",
+			"application/x-www-form-urlencoded; charset=utf-8",
+			"password=%zz",
+			"",
+		},
+		{
+			"multipart",
+			"multipart/form-data; boundary=abc",
+			multipartBody,
+			strings.Replace(multipartBody, "1234567890", "[REDACTED]", 1),
+		},
+		{
+			"truncated multipart",
+			"multipart/form-data; boundary=abc",
+			multipartBody[:strings.Index(multipartBody, "1234567890")+4],
+			"--abc\r\n" +
+				"Content-Disposition: form-data; name=\"email\"\r\n\r\n" +
+				"test@example.com",
+		},
+		{
+			"multipart without boundary",
+			"multipart/form-data",
+			multipartBody,
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			capture := &core.CapturedRequest{
+				RequestHeaders: types.JSONMap[string]{"Content-Type": s.contentType},
+				RequestBody:    s.body,
+			}
+
+			app.RedactCapturedRequest(capture)
+
+			if capture.RequestBody != s.expected {
+				t.Fatalf("Expected body\n%q\ngot\n%q", s.expected, capture.RequestBody)
+			}
+		})
+	}
+}
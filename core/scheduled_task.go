@@ -0,0 +1,390 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var (
+	_ Model      = (*ScheduledTask)(nil)
+	_ HookTagger = (*ScheduledTask)(nil)
+)
+
+const ScheduledTasksTableName = "_scheduledTasks"
+
+// The supported ScheduledTask.Status values.
+const (
+	ScheduledTaskStatusPending = "pending"
+	ScheduledTaskStatusFailed  = "failed"
+)
+
+// MaxScheduledTaskAttempts is the max number of failed executions
+// after which the scheduled task is marked as failed.
+const MaxScheduledTaskAttempts = 5
+
+const (
+	// scheduledTaskLockDuration is the duration for which a claimed task
+	// is not picked by other workers (if the process dies during the task
+	// execution, the task is retried after the lock expiration).
+	scheduledTaskLockDuration = 5 * time.Minute
+
+	// scheduledTasksMaxWait is the max duration between two due tasks checks
+	// (e.g. to pick the tasks scheduled by other app instances).
+	scheduledTasksMaxWait = 1 * time.Minute
+
+	// scheduledTasksBatchSize is the max number of due tasks loaded at once.
+	scheduledTasksBatchSize = 100
+)
+
+// ScheduledTask defines a persisted one-off task that is executed
+// at least once after its RunAt time by triggering the [App.OnScheduledTaskRun] hook.
+type ScheduledTask struct {
+	BaseModel
+
+	Created     types.DateTime `db:"created" json:"created"`
+	Updated     types.DateTime `db:"updated" json:"updated"`
+	RunAt       types.DateTime `db:"runAt" json:"runAt"`
+	LockedUntil types.DateTime `db:"lockedUntil" json:"lockedUntil"`
+	Name        string         `db:"name" json:"name"`
+	Status      string         `db:"status" json:"status"`
+	Error       string         `db:"error" json:"error"`
+	Payload     types.JSONRaw  `db:"payload" json:"payload"`
+	Attempts    int            `db:"attempts" json:"attempts"`
+}
+
+func (m *ScheduledTask) TableName() string {
+	return ScheduledTasksTableName
+}
+
+// HookTags returns the hook tags associated with the current task.
+func (m *ScheduledTask) HookTags() []string {
+	return []string{m.Name}
+}
+
+// UnmarshalPayload unmarshals the task JSON payload into result.
+func (m *ScheduledTask) UnmarshalPayload(result any) error {
+	if len(m.Payload) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(m.Payload, result)
+}
+
+// ScheduleAt persists a new one-off task with the specified name and
+// payload (it must be JSON serializable) to be executed at the specified time.
+//
+// The task is executed at least once (including after app restarts) by triggering
+// the [App.OnScheduledTaskRun] hook with the task name as hook tag, e.g.:
+//
+//	app.OnScheduledTaskRun("sendReminder").BindFunc(func(e *core.ScheduledTaskEvent) error {
+//		var payload struct{ UserId string }
+//		if err := e.Task.UnmarshalPayload(&payload); err != nil {
+//			return err
+//		}
+//
+//		// ...
+//
+//		return e.Next()
+//	})
+//
+//	app.ScheduleAt(time.Now().Add(24*time.Hour), "sendReminder", map[string]any{"userId": "..."})
+//
+// If the hook returns an error, the task is retried with increasing delay
+// up to [MaxScheduledTaskAttempts] times before being marked as failed.
+//
+// Note that the tasks are executed only while the app is serving.
+func (app *BaseApp) ScheduleAt(runAt time.Time, name string, payload any) (*ScheduledTask, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("missing scheduled task name")
+	}
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize the scheduled task payload: %w", err)
+	}
+
+	task := &ScheduledTask{
+		Name:    name,
+		Status:  ScheduledTaskStatusPending,
+		Payload: rawPayload,
+	}
+	task.Id = GenerateDefaultRandomId()
+	task.RunAt, _ = types.ParseDateTime(runAt)
+	task.Created = types.NowDateTime()
+	task.Updated = task.Created
+
+	if err := app.AuxSaveNoValidate(task); err != nil {
+		return nil, err
+	}
+
+	app.scheduledTasks.notify()
+
+	return task, nil
+}
+
+// FindScheduledTask finds the scheduled task with the specified id.
+func (app *BaseApp) FindScheduledTask(id string) (*ScheduledTask, error) {
+	model := &ScheduledTask{}
+
+	err := app.AuxModelQuery(model).
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// CancelScheduledTask deletes the scheduled task with the specified id.
+//
+// Note that a task which execution has already started can't be canceled.
+func (app *BaseApp) CancelScheduledTask(id string) error {
+	task, err := app.FindScheduledTask(id)
+	if err != nil {
+		return err
+	}
+
+	return app.AuxDelete(task)
+}
+
+// RunDueScheduledTasks executes all pending scheduled tasks which RunAt time has passed.
+//
+// The due tasks are usually executed automatically by the app while serving
+// and normally you don't have to call this method manually.
+func (app *BaseApp) RunDueScheduledTasks() error {
+	for {
+		tasks := []*ScheduledTask{}
+
+		now := types.NowDateTime().String()
+
+		err := app.AuxModelQuery(&ScheduledTask{}).
+			AndWhere(dbx.HashExp{"status": ScheduledTaskStatusPending}).
+			AndWhere(dbx.NewExp("[[runAt]] <= {:now} AND [[lockedUntil]] <= {:now}", dbx.Params{"now": now})).
+			OrderBy("runAt ASC").
+			Limit(scheduledTasksBatchSize).
+			All(&tasks)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			if err := runScheduledTask(app, task); err != nil {
+				return err
+			}
+		}
+
+		if len(tasks) < scheduledTasksBatchSize {
+			return nil
+		}
+	}
+}
+
+// runScheduledTask claims and executes a single due task.
+//
+// Returns an error only if the task state couldn't be persisted.
+func runScheduledTask(app App, task *ScheduledTask) error {
+	now := types.NowDateTime()
+
+	lockedUntil, _ := types.ParseDateTime(now.Time().Add(scheduledTaskLockDuration))
+
+	// claim the task (it could have been already picked by another app instance)
+	result, err := app.AuxNonconcurrentDB().Update(
+		ScheduledTasksTableName,
+		dbx.Params{
+			"lockedUntil": lockedUntil.String(),
+			"attempts":    dbx.NewExp("[[attempts]] + 1"),
+			"updated":     now.String(),
+		},
+		dbx.And(
+			dbx.HashExp{"id": task.Id, "status": ScheduledTaskStatusPending},
+			dbx.NewExp("[[lockedUntil]] <= {:now}", dbx.Params{"now": now.String()}),
+		),
+	).Execute()
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil // already claimed
+	}
+
+	task.Attempts++
+	task.LockedUntil = lockedUntil
+
+	runErr := triggerScheduledTask(app, task)
+	if runErr == nil {
+		return app.AuxDelete(task)
+	}
+
+	// schedule a retry
+	task.Error = runErr.Error()
+	task.LockedUntil = types.DateTime{}
+	task.Updated = types.NowDateTime()
+	if task.Attempts >= MaxScheduledTaskAttempts {
+		task.Status = ScheduledTaskStatusFailed
+	} else {
+		task.RunAt, _ = types.ParseDateTime(time.Now().Add(scheduledTaskRetryDelay(task.Attempts)))
+	}
+
+	app.Logger().Warn(
+		"Scheduled task execution failed",
+		slog.String("id", task.Id),
+		slog.String("name", task.Name),
+		slog.Int("attempts", task.Attempts),
+		slog.String("error", runErr.Error()),
+	)
+
+	return app.AuxSaveNoValidate(task)
+}
+
+// triggerScheduledTask triggers the OnScheduledTaskRun hook
+// for the specified task, recovering from its panics (if any).
+func triggerScheduledTask(app App, task *ScheduledTask) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			if reportErr := app.ReportPanic(PanicSourceScheduledTask, task.Name, r, debug.Stack()); reportErr != nil {
+				app.Logger().Error("Failed to report panic", slog.String("source", PanicSourceScheduledTask), slog.String("error", reportErr.Error()))
+			}
+		}
+	}()
+
+	event := new(ScheduledTaskEvent)
+	event.App = app
+	event.Task = task
+
+	return app.OnScheduledTaskRun().Trigger(event, func(e *ScheduledTaskEvent) error {
+		return nil
+	})
+}
+
+// scheduledTaskRetryDelay returns the delay before the next
+// execution of a task that has failed the specified number of times.
+func scheduledTaskRetryDelay(attempts int) time.Duration {
+	return time.Duration(attempts*attempts) * 30 * time.Second
+}
+
+// nextScheduledTaskWait returns the duration until the next pending task run
+// (capped to scheduledTasksMaxWait).
+func nextScheduledTaskWait(app App) time.Duration {
+	var next string
+
+	// the tasks claimed by another worker are rechecked after their lock expiration
+	err := app.AuxDB().Select("min(max([[runAt]], [[lockedUntil]]))").
+		From(ScheduledTasksTableName).
+		AndWhere(dbx.HashExp{"status": ScheduledTaskStatusPending}).
+		Row(&next)
+	if err != nil || next == "" {
+		return scheduledTasksMaxWait
+	}
+
+	runAt, err := types.ParseDateTime(next)
+	if err != nil {
+		return scheduledTasksMaxWait
+	}
+
+	return max(0, min(time.Until(runAt.Time()), scheduledTasksMaxWait))
+}
+
+// scheduledTasksWorker periodically executes the due scheduled tasks.
+type scheduledTasksWorker struct {
+	stop chan struct{}
+	wake chan struct{}
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+}
+
+func newScheduledTasksWorker() *scheduledTasksWorker {
+	return &scheduledTasksWorker{
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// start starts the worker loop (if not already).
+func (w *scheduledTasksWorker) start(app App) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stop != nil {
+		return // already running
+	}
+
+	w.stop = make(chan struct{})
+
+	w.wg.Add(1)
+	go func(stop chan struct{}) {
+		defer w.wg.Done()
+
+		for {
+			if err := app.RunDueScheduledTasks(); err != nil {
+				app.Logger().Error(
+					"Failed to run the due scheduled tasks",
+					slog.String("error", err.Error()),
+				)
+			}
+
+			timer := time.NewTimer(nextScheduledTaskWait(app))
+
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case <-w.wake:
+				timer.Stop()
+			case <-timer.C:
+			}
+		}
+	}(w.stop)
+}
+
+// stopAndWait stops the worker loop (if running)
+// and waits for the currently executing tasks to complete.
+func (w *scheduledTasksWorker) stopAndWait() {
+	w.mu.Lock()
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+	w.mu.Unlock()
+
+	w.wg.Wait()
+}
+
+// notify wakes up the worker (if running) to recheck the pending tasks.
+func (w *scheduledTasksWorker) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// registerScheduledTasksHooks registers the scheduled tasks worker app hooks.
+func (app *BaseApp) registerScheduledTasksHooks() {
+	app.OnServe().BindFunc(func(e *ServeEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		app.scheduledTasks.start(app)
+
+		return nil
+	})
+
+	app.OnTerminate().BindFunc(func(e *TerminateEvent) error {
+		app.scheduledTasks.stopAndWait()
+
+		return e.Next()
+	})
+}
@@ -0,0 +1,221 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestScheduleAt(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.ScheduleAt(time.Now(), " ", nil); err == nil {
+		t.Fatal("Expected missing name error")
+	}
+
+	if _, err := app.ScheduleAt(time.Now(), "test", func() {}); err == nil {
+		t.Fatal("Expected payload serialization error")
+	}
+
+	task, err := app.ScheduleAt(time.Now().Add(1*time.Hour), "test", map[string]any{"a": 123})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := app.FindScheduledTask(task.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Name != "test" || found.Status != core.ScheduledTaskStatusPending || found.Attempts != 0 {
+		t.Fatalf("Unexpected task %#v", found)
+	}
+
+	var payload struct {
+		A int `json:"a"`
+	}
+	if err := found.UnmarshalPayload(&payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.A != 123 {
+		t.Fatalf("Expected payload a 123, got %d", payload.A)
+	}
+
+	if err := app.CancelScheduledTask(task.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.FindScheduledTask(task.Id); err == nil {
+		t.Fatal("Expected the canceled task to be deleted")
+	}
+}
+
+func TestRunDueScheduledTasks(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	calls := map[string]int{}
+
+	app.OnScheduledTaskRun("success").BindFunc(func(e *core.ScheduledTaskEvent) error {
+		calls[e.Task.Name]++
+		return e.Next()
+	})
+
+	app.OnScheduledTaskRun("fail").BindFunc(func(e *core.ScheduledTaskEvent) error {
+		calls[e.Task.Name]++
+		return errors.New("test_error")
+	})
+
+	app.OnScheduledTaskRun("panic").BindFunc(func(e *core.ScheduledTaskEvent) error {
+		calls[e.Task.Name]++
+		panic("test_panic")
+	})
+
+	past := time.Now().Add(-1 * time.Second)
+
+	success, _ := app.ScheduleAt(past, "success", nil)
+	future, _ := app.ScheduleAt(time.Now().Add(1*time.Hour), "success", nil)
+	fail, _ := app.ScheduleAt(past, "fail", nil)
+	panicked, _ := app.ScheduleAt(past, "panic", nil)
+
+	if err := app.RunDueScheduledTasks(); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls["success"] != 1 || calls["fail"] != 1 || calls["panic"] != 1 {
+		t.Fatalf("Expected each due task to be executed once, got %v", calls)
+	}
+
+	if _, err := app.FindScheduledTask(success.Id); err == nil {
+		t.Fatal("Expected the succeeded task to be deleted")
+	}
+
+	if _, err := app.FindScheduledTask(future.Id); err != nil {
+		t.Fatalf("Expected the future task to be kept, got %v", err)
+	}
+
+	for _, s := range []struct {
+		id    string
+		error string
+	}{
+		{fail.Id, "test_error"},
+		{panicked.Id, "panic: test_panic"},
+	} {
+		task, err := app.FindScheduledTask(s.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if task.Attempts != 1 || task.Error != s.error || task.Status != core.ScheduledTaskStatusPending {
+			t.Fatalf("Unexpected failed task state %#v", task)
+		}
+
+		if !task.RunAt.Time().After(time.Now()) {
+			t.Fatalf("Expected the failed task to be rescheduled, got %v", task.RunAt)
+		}
+
+		if !task.LockedUntil.IsZero() {
+			t.Fatalf("Expected the failed task to be unlocked, got %v", task.LockedUntil)
+		}
+	}
+
+	// the rescheduled tasks shouldn't be due yet
+	if err := app.RunDueScheduledTasks(); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls["fail"] != 1 {
+		t.Fatalf("Expected the failed task to not be retried yet, got %d calls", calls["fail"])
+	}
+}
+
+func TestRunDueScheduledTasksMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.OnScheduledTaskRun("fail").BindFunc(func(e *core.ScheduledTaskEvent) error {
+		return errors.New("test_error")
+	})
+
+	task, err := app.ScheduleAt(time.Now().Add(-1*time.Second), "fail", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < core.MaxScheduledTaskAttempts; i++ {
+		// make the task due
+		_, err := app.AuxNonconcurrentDB().Update(
+			core.ScheduledTasksTableName,
+			map[string]any{"runAt": ""},
+			nil,
+		).Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := app.RunDueScheduledTasks(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found, err := app.FindScheduledTask(task.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Status != core.ScheduledTaskStatusFailed || found.Attempts != core.MaxScheduledTaskAttempts {
+		t.Fatalf("Expected failed task with %d attempts, got %#v", core.MaxScheduledTaskAttempts, found)
+	}
+}
+
+func TestScheduledTasksWorker(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// skip the OnServe http server start
+	app.OnServe().BindFunc(func(e *core.ServeEvent) error {
+		return nil
+	})
+
+	done := make(chan string, 1)
+
+	app.OnScheduledTaskRun("test").BindFunc(func(e *core.ScheduledTaskEvent) error {
+		done <- e.Task.Id
+		return e.Next()
+	})
+
+	// start the worker
+	err := app.OnServe().Trigger(&core.ServeEvent{App: app}, func(e *core.ServeEvent) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.OnTerminate().Trigger(&core.TerminateEvent{App: app})
+
+	task, err := app.ScheduleAt(time.Now().Add(100*time.Millisecond), "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case id := <-done:
+		if id != task.Id {
+			t.Fatalf("Expected task %q to be executed, got %q", task.Id, id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the scheduled task to be executed by the worker")
+	}
+}
@@ -0,0 +1,389 @@
+package core
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/yaml"
+)
+
+// SeedRefPrefix is the prefix of the seed record data string values
+// that reference another seeded record.
+//
+// "@ref:name" is replaced with the id of the record with ref "name",
+// and "@ref:name.field" with the value of its "field".
+const SeedRefPrefix = "@ref:"
+
+// AppSeeds defines the app Go seed sets (usually registered in an init function).
+//
+// Example:
+//
+//	func init() {
+//		core.AppSeeds.Register("demo", func(s *core.SeedContext) error {
+//			_, err := s.Save("posts", "welcome", map[string]any{"title": "Welcome"})
+//			return err
+//		}, "dev")
+//	}
+var AppSeeds Seeder
+
+// SeedSet defines a named set of fixture records.
+type SeedSet struct {
+	// Build is an optional Go builder that is invoked after the Records are seeded.
+	Build func(s *SeedContext) error `json:"-"`
+
+	// Name is the unique seed set name.
+	//
+	// It is also used as part of the deterministic ids of the seeded records.
+	Name string `json:"name"`
+
+	// Profiles is an optional list of environment profiles (eg. "dev", "test")
+	// for which the set should be seeded.
+	//
+	// If empty, the set is seeded for all profiles.
+	Profiles []string `json:"profiles"`
+
+	// Records is a list with the set fixture records.
+	Records []*SeedRecord `json:"records"`
+}
+
+// HasProfile reports whether the set should be seeded for the specified profile.
+func (s *SeedSet) HasProfile(profile string) bool {
+	return len(s.Profiles) == 0 || slices.Contains(s.Profiles, profile)
+}
+
+// SeedRecord defines a single fixture record.
+type SeedRecord struct {
+	// Data is the record data to load.
+	//
+	// String values (including nested ones) in the format "@ref:name"
+	// or "@ref:name.field" are replaced with the referenced record id or field value.
+	Data map[string]any `json:"data"`
+
+	// Collection is the name or id of the record collection.
+	Collection string `json:"collection"`
+
+	// Ref is an optional unique (within the seeding run) name
+	// that other records could use to reference the current one.
+	Ref string `json:"ref"`
+}
+
+// Seeder defines a list with seed sets.
+type Seeder struct {
+	sets []*SeedSet
+}
+
+// Sets returns the registered seed sets.
+func (s *Seeder) Sets() []*SeedSet {
+	return s.sets
+}
+
+// Add adds one or more seed sets to the seeder.
+//
+// Sets with the same name are replaced.
+func (s *Seeder) Add(sets ...*SeedSet) {
+	for _, set := range sets {
+		index := slices.IndexFunc(s.sets, func(item *SeedSet) bool {
+			return item.Name == set.Name
+		})
+		if index >= 0 {
+			s.sets[index] = set
+		} else {
+			s.sets = append(s.sets, set)
+		}
+	}
+}
+
+// Register adds a new Go builder seed set.
+func (s *Seeder) Register(name string, build func(s *SeedContext) error, profiles ...string) {
+	s.Add(&SeedSet{
+		Name:     name,
+		Build:    build,
+		Profiles: profiles,
+	})
+}
+
+// Copy copies all provided seeder sets into the current one.
+func (s *Seeder) Copy(seeder Seeder) {
+	s.Add(seeder.Sets()...)
+}
+
+// LoadFile loads a single JSON or YAML seed set file.
+//
+// If the file doesn't have a set name, the file name without the extension is used.
+func (s *Seeder) LoadFile(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	set := &SeedSet{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, set)
+	case ".json":
+		err = json.Unmarshal(raw, set)
+	default:
+		return fmt.Errorf("unsupported seed file %q (expected .json, .yaml or .yml)", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse seed file %q: %w", path, err)
+	}
+
+	if set.Name == "" {
+		set.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	s.Add(set)
+
+	return nil
+}
+
+// LoadDir loads all JSON and YAML seed set files from the specified directory
+// (sorted by their file name).
+//
+// Nonexisting directory is ignored.
+func (s *Seeder) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+			if err := s.LoadFile(filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Run seeds in a single transaction the sets for the specified profile
+// (in the order of their registration).
+//
+// If optSetNames are provided, only the sets with the specified names are seeded.
+//
+// The seeding is idempotent - each seeded record has a deterministic id
+// (unless explicitly set) and rerunning the seeder updates the existing records.
+func (s *Seeder) Run(app App, profile string, optSetNames ...string) (*SeedContext, error) {
+	for _, name := range optSetNames {
+		if !slices.ContainsFunc(s.sets, func(set *SeedSet) bool { return set.Name == name }) {
+			return nil, fmt.Errorf("missing seed set %q", name)
+		}
+	}
+
+	var result *SeedContext
+
+	err := app.RunInTransaction(func(txApp App) error {
+		sc := &SeedContext{
+			App:     txApp,
+			Profile: profile,
+			refs:    map[string]*Record{},
+		}
+
+		for _, set := range s.sets {
+			if len(optSetNames) > 0 {
+				if !slices.Contains(optSetNames, set.Name) {
+					continue
+				}
+			} else if !set.HasProfile(profile) {
+				continue
+			}
+
+			sc.set = set
+			sc.counter = 0
+
+			for i, item := range set.Records {
+				_, err := sc.Save(item.Collection, item.Ref, item.Data)
+				if err != nil {
+					return fmt.Errorf("seed set %q record %d: %w", set.Name, i, err)
+				}
+			}
+
+			if set.Build != nil {
+				if err := set.Build(sc); err != nil {
+					return fmt.Errorf("seed set %q: %w", set.Name, err)
+				}
+			}
+
+			sc.Seeded = append(sc.Seeded, set.Name)
+		}
+
+		result = sc
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// -------------------------------------------------------------------
+
+// SeedContext defines the state of a single seeding run.
+type SeedContext struct {
+	App App
+
+	set     *SeedSet
+	refs    map[string]*Record
+	counter int
+
+	// Profile is the seeding profile.
+	Profile string
+
+	// Seeded is a list with the names of the already seeded sets.
+	Seeded []string
+}
+
+// Ref returns the seeded record with the specified ref (or nil if missing).
+func (sc *SeedContext) Ref(name string) *Record {
+	return sc.refs[name]
+}
+
+// Save creates or updates a seed record with the provided data
+// and registers it under the specified ref name (could be empty).
+//
+// If the data doesn't have an "id", a deterministic one is generated
+// from the set name and the ref (or the record position within the set).
+func (sc *SeedContext) Save(collectionNameOrId string, ref string, data map[string]any) (*Record, error) {
+	collection, err := sc.App.FindCachedCollectionByNameOrId(collectionNameOrId)
+	if err != nil {
+		return nil, fmt.Errorf("missing collection %q: %w", collectionNameOrId, err)
+	}
+
+	if ref != "" && sc.refs[ref] != nil {
+		return nil, fmt.Errorf("duplicated seed ref %q", ref)
+	}
+
+	resolved, err := sc.resolveRefs(data)
+	if err != nil {
+		return nil, err
+	}
+	resolvedData, _ := resolved.(map[string]any)
+
+	sc.counter++
+
+	id, _ := resolvedData[FieldNameId].(string)
+	if id == "" {
+		key := ref
+		if key == "" {
+			key = fmt.Sprintf("#%d", sc.counter)
+		}
+		setName := ""
+		if sc.set != nil {
+			setName = sc.set.Name
+		}
+		id = seedRecordId(setName, collection.Id, key)
+	}
+
+	record, err := sc.App.FindRecordById(collection, id)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		record = NewRecord(collection)
+		record.Id = id
+	}
+
+	record.Load(resolvedData)
+
+	if err := sc.App.Save(record); err != nil {
+		return nil, err
+	}
+
+	if ref != "" {
+		sc.refs[ref] = record
+	}
+
+	return record, nil
+}
+
+// resolveRefs returns a copy of the provided value with replaced "@ref:" strings.
+func (sc *SeedContext) resolveRefs(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.HasPrefix(v, SeedRefPrefix) {
+			return v, nil
+		}
+
+		name, field, _ := strings.Cut(strings.TrimPrefix(v, SeedRefPrefix), ".")
+
+		record := sc.refs[name]
+		if record == nil {
+			return nil, fmt.Errorf("unknown seed ref %q", name)
+		}
+
+		if field == "" {
+			return record.Id, nil
+		}
+
+		return record.Get(field), nil
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			resolved, err := sc.resolveRefs(item)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = resolved
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := sc.resolveRefs(item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	case []string:
+		result := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := sc.resolveRefs(item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	default:
+		return v, nil
+	}
+}
+
+// seedRecordId generates a deterministic 15 characters [a-z0-9] record id.
+func seedRecordId(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+
+	id := new(big.Int).SetBytes(hash[:]).Text(36)
+
+	return id[len(id)-15:]
+}
@@ -0,0 +1,204 @@
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSeederAdd(t *testing.T) {
+	t.Parallel()
+
+	s := core.Seeder{}
+	s.Add(&core.SeedSet{Name: "a"}, &core.SeedSet{Name: "b"})
+	s.Register("a", func(sc *core.SeedContext) error { return nil }, "test")
+
+	sets := s.Sets()
+	if len(sets) != 2 {
+		t.Fatalf("Expected 2 sets, got %d", len(sets))
+	}
+
+	if sets[0].Name != "a" || sets[0].Build == nil || !slices.Equal(sets[0].Profiles, []string{"test"}) {
+		t.Fatalf("Expected the first set to be replaced, got %#v", sets[0])
+	}
+}
+
+func TestSeederLoadDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"b_posts.yaml": "name: posts\nprofiles: [dev]\nrecords:\n  - collection: demo1\n    data:\n      text: test\n",
+		"a_users.json": `{"records":[{"collection":"users","ref":"u1","data":{"email":"seed@example.com"}}]}`,
+		"c_ignore.txt": "ignore",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := core.Seeder{}
+
+	if err := s.LoadDir(filepath.Join(dir, "missing")); err != nil {
+		t.Fatalf("Expected missing dir to be ignored, got %v", err)
+	}
+
+	if err := s.LoadDir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	sets := s.Sets()
+	if len(sets) != 2 {
+		t.Fatalf("Expected 2 sets, got %d", len(sets))
+	}
+
+	if sets[0].Name != "a_users" || len(sets[0].Records) != 1 || sets[0].Records[0].Ref != "u1" {
+		t.Fatalf("Unexpected first set %#v", sets[0])
+	}
+
+	if sets[1].Name != "posts" || !slices.Equal(sets[1].Profiles, []string{"dev"}) || sets[1].Records[0].Data["text"] != "test" {
+		t.Fatalf("Unexpected second set %#v", sets[1])
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "invalid.yml"), []byte("a: [1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.LoadDir(dir); err == nil {
+		t.Fatal("Expected invalid yaml file error")
+	}
+}
+
+func TestSeederRun(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	s := core.Seeder{}
+	s.Add(&core.SeedSet{
+		Name: "users",
+		Records: []*core.SeedRecord{
+			{
+				Collection: "users",
+				Ref:        "alice",
+				Data: map[string]any{
+					"email":           "alice@example.com",
+					"password":        "1234567890",
+					"passwordConfirm": "1234567890",
+				},
+			},
+		},
+	})
+	s.Add(&core.SeedSet{
+		Name:     "demo",
+		Profiles: []string{"dev"},
+		Records: []*core.SeedRecord{
+			{
+				Collection: "demo1",
+				Ref:        "post",
+				Data: map[string]any{
+					"text":     "@ref:alice.email",
+					"rel_many": []any{"@ref:alice"},
+				},
+			},
+		},
+		Build: func(sc *core.SeedContext) error {
+			_, err := sc.Save("demo1", "", map[string]any{"rel_one": sc.Ref("post").Id})
+			return err
+		},
+	})
+
+	countRecords := func() (int64, int64) {
+		users, _ := app.CountRecords("users")
+		demo1, _ := app.CountRecords("demo1")
+		return users, demo1
+	}
+
+	initialUsers, initialDemo1 := countRecords()
+
+	t.Run("test profile", func(t *testing.T) {
+		result, err := s.Run(app, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(result.Seeded, []string{"users"}) {
+			t.Fatalf("Expected only the users set to be seeded, got %v", result.Seeded)
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		result, err := s.Run(app, "dev")
+		if err != nil {
+			t.Fatalf("[run %d] %v", i, err)
+		}
+
+		if !slices.Equal(result.Seeded, []string{"users", "demo"}) {
+			t.Fatalf("[run %d] Expected both sets to be seeded, got %v", i, result.Seeded)
+		}
+
+		alice := result.Ref("alice")
+		post := result.Ref("post")
+
+		if post.GetString("text") != "alice@example.com" {
+			t.Fatalf("[run %d] Expected the post text to be resolved, got %q", i, post.GetString("text"))
+		}
+
+		if rel := post.GetStringSlice("rel_many"); !slices.Equal(rel, []string{alice.Id}) {
+			t.Fatalf("[run %d] Expected rel_many [%s], got %v", i, alice.Id, rel)
+		}
+
+		if len(alice.Id) != 15 || len(post.Id) != 15 {
+			t.Fatalf("[run %d] Expected 15 characters ids, got %q and %q", i, alice.Id, post.Id)
+		}
+	}
+
+	// rerunning shouldn't create duplicates
+	users, demo1 := countRecords()
+	if users != initialUsers+1 || demo1 != initialDemo1+2 {
+		t.Fatalf("Expected %d users and %d demo1 records, got %d and %d", initialUsers+1, initialDemo1+2, users, demo1)
+	}
+
+	t.Run("specific set names", func(t *testing.T) {
+		result, err := s.Run(app, "test", "demo", "users")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(result.Seeded, []string{"users", "demo"}) {
+			t.Fatalf("Expected both sets to be seeded, got %v", result.Seeded)
+		}
+
+		_, err = s.Run(app, "dev", "missing")
+		if err == nil || !strings.Contains(err.Error(), "missing") {
+			t.Fatalf("Expected missing set error, got %v", err)
+		}
+	})
+
+	t.Run("unknown ref rollback", func(t *testing.T) {
+		invalid := core.Seeder{}
+		invalid.Add(&core.SeedSet{
+			Name: "invalid",
+			Records: []*core.SeedRecord{
+				{Collection: "demo1", Data: map[string]any{"text": "new"}},
+				{Collection: "demo1", Data: map[string]any{"rel_one": "@ref:missing"}},
+			},
+		})
+
+		_, err := invalid.Run(app, "dev")
+		if err == nil || !strings.Contains(err.Error(), "unknown seed ref") {
+			t.Fatalf("Expected unknown ref error, got %v", err)
+		}
+
+		if _, total := countRecords(); total != demo1 {
+			t.Fatalf("Expected the seeding to be rolled back (%d demo1 records), got %d", demo1, total)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SettingsEnvPrefix is the prefix of the environment variables
+// overriding the app settings.
+//
+// The rest of the variable name is the double underscore separated
+// settings path, e.g. PB_SETTINGS__SMTP__HOST overrides "smtp.host"
+// (see [Settings.SetPath] for the supported values format).
+const SettingsEnvPrefix = "PB_SETTINGS__"
+
+// EnvOverrides returns the sorted settings paths that are
+// overridden by the SettingsEnvPrefix environment variables.
+//
+// The overridden values take precedence over the stored ones
+// and they are never persisted in the database.
+func (s *Settings) EnvOverrides() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	paths := make([]string, 0, len(s.envOverrides))
+	for path := range s.envOverrides {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	return paths
+}
+
+// applyEnvOverrides overrides the current settings with the
+// values of the SettingsEnvPrefix environment variables.
+//
+// The overridden settings original values are stored
+// so that they can be restored on db persistence.
+func (s *Settings) applyEnvOverrides() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	overrides := map[string][]byte{}
+
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(name, SettingsEnvPrefix) {
+			continue
+		}
+
+		path := strings.ReplaceAll(strings.TrimPrefix(name, SettingsEnvPrefix), "__", ".")
+
+		var original []byte
+		if v, err := resolveSettingsPath(reflect.ValueOf(&s.settings).Elem(), splitSettingsPath(path)); err == nil {
+			original, _ = json.Marshal(v.Interface())
+		}
+
+		err := setSettingsPath(&s.settings, path, func(target reflect.Value) error {
+			return assignSettingsValue(target, value)
+		})
+		if err != nil {
+			return fmt.Errorf("invalid settings env variable %s: %w", name, err)
+		}
+
+		overrides[path] = original
+	}
+
+	s.envOverrides = overrides
+
+	return nil
+}
+
+// withoutEnvOverrides returns a deep copy of the current settings
+// with restored original values of the env overridden paths.
+//
+// Note that the caller must hold the settings lock.
+func (s *Settings) withoutEnvOverrides() (settings, error) {
+	var result settings
+
+	raw, err := json.Marshal(s.settings)
+	if err != nil {
+		return result, err
+	}
+
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return result, err
+	}
+
+	for path, original := range s.envOverrides {
+		// the path didn't exist before the override (aka. a new map entry)
+		if original == nil {
+			keys := splitSettingsPath(path)
+			parent, err := resolveSettingsPath(reflect.ValueOf(&result).Elem(), keys[:len(keys)-1])
+			if err == nil && parent.Kind() == reflect.Map && !parent.IsNil() {
+				parent.SetMapIndex(reflect.ValueOf(keys[len(keys)-1]).Convert(parent.Type().Key()), reflect.Value{})
+			}
+			continue
+		}
+
+		err := setSettingsPath(&result, path, func(target reflect.Value) error {
+			target.Set(reflect.Zero(target.Type()))
+
+			return json.Unmarshal(original, target.Addr().Interface())
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package core_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSettingsEnvOverrides(t *testing.T) {
+	t.Setenv(core.SettingsEnvPrefix+"META__APPNAME", "env_app")
+	t.Setenv(core.SettingsEnvPrefix+"SMTP__PORT", "2525")
+	t.Setenv(core.SettingsEnvPrefix+"EXTENSIONS__envtest", `{"a":1}`)
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	settings := app.Settings()
+
+	if settings.Meta.AppName != "env_app" {
+		t.Fatalf("Expected meta.appName %q, got %q", "env_app", settings.Meta.AppName)
+	}
+
+	if settings.SMTP.Port != 2525 {
+		t.Fatalf("Expected smtp.port %d, got %d", 2525, settings.SMTP.Port)
+	}
+
+	if v := settings.Extensions["envtest"].String(); v != `{"a":1}` {
+		t.Fatalf("Expected extensions.envtest %q, got %q", `{"a":1}`, v)
+	}
+
+	expectedOverrides := []string{"EXTENSIONS.envtest", "META.APPNAME", "SMTP.PORT"}
+	if overrides := settings.EnvOverrides(); !slices.Equal(overrides, expectedOverrides) {
+		t.Fatalf("Expected overrides %v, got %v", expectedOverrides, overrides)
+	}
+
+	// save with a regular change
+	clone, err := settings.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone.SMTP.Host = "example.com"
+	if err := app.Save(clone); err != nil {
+		t.Fatal(err)
+	}
+
+	// the overrides should be still applied after the save reload
+	if settings := app.Settings(); settings.Meta.AppName != "env_app" || settings.SMTP.Host != "example.com" {
+		t.Fatalf("Expected the env overrides and the saved change to be applied, got %q and %q", settings.Meta.AppName, settings.SMTP.Host)
+	}
+
+	// the overridden values should not be persisted
+	param := &core.Param{}
+	if err := app.ModelQuery(param).Model("settings", param); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := struct {
+		SMTP       core.SMTPConfig               `json:"smtp"`
+		Meta       core.MetaConfig               `json:"meta"`
+		Extensions core.SettingsExtensionsConfig `json:"extensions"`
+	}{}
+	if err := json.Unmarshal(param.Value, &stored); err != nil {
+		t.Fatal(err)
+	}
+
+	if stored.Meta.AppName == "env_app" {
+		t.Fatal("Expected meta.appName env override to not be persisted")
+	}
+
+	if stored.SMTP.Port == 2525 {
+		t.Fatal("Expected smtp.port env override to not be persisted")
+	}
+
+	if _, ok := stored.Extensions["envtest"]; ok {
+		t.Fatal("Expected extensions.envtest env override to not be persisted")
+	}
+
+	if stored.SMTP.Host != "example.com" {
+		t.Fatalf("Expected the persisted smtp.host %q, got %q", "example.com", stored.SMTP.Host)
+	}
+
+	// invalid env override
+	t.Setenv(core.SettingsEnvPrefix+"MISSING", "abc")
+	if err := app.ReloadSettings(); err == nil {
+		t.Fatal("Expected error for invalid settings env variable")
+	}
+}
@@ -0,0 +1,375 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// SettingsExtension defines a custom typed app settings section
+// that could be registered with [App.RegisterSettingsExtension].
+//
+// The section value is persisted together with the other app settings
+// (aka. it is also encrypted if the app EncryptionEnv is set) and it is
+// available in the settings API under the "extensions.{name}" key.
+type SettingsExtension struct {
+	// Name is the unique section name (e.g. "stripe").
+	Name string
+
+	// Defaults is the default section value.
+	//
+	// It also defines the section type and it is usually a struct
+	// that could optionally implement [validation.Validatable].
+	Defaults any
+
+	// Sensitive is an optional list with the top-level section JSON keys
+	// that are excluded from the settings API responses (e.g. "apiKey").
+	Sensitive []string
+
+	// OnReload is an optional callback that is invoked after
+	// an app settings reload that has changed the section value.
+	OnReload func(app App) error
+}
+
+// settingsExtensions is the registry of the app settings extensions.
+type settingsExtensions struct {
+	mu      sync.RWMutex
+	items   []*SettingsExtension
+	lastRaw map[string]string
+}
+
+func newSettingsExtensions() *settingsExtensions {
+	return &settingsExtensions{lastRaw: map[string]string{}}
+}
+
+func (r *settingsExtensions) find(name string) *SettingsExtension {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, ext := range r.items {
+		if ext.Name == name {
+			return ext
+		}
+	}
+
+	return nil
+}
+
+func (r *settingsExtensions) all() []*SettingsExtension {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.items)
+}
+
+// RegisterSettingsExtension registers a new custom typed app settings section.
+//
+// Use [Settings.Extension] to read the section value and [Settings.SetExtension] to change it.
+//
+// Example:
+//
+//	type StripeSettings struct {
+//		Enabled bool   `json:"enabled"`
+//		APIKey  string `json:"apiKey"`
+//	}
+//
+//	app.RegisterSettingsExtension(core.SettingsExtension{
+//		Name:      "stripe",
+//		Defaults:  StripeSettings{},
+//		Sensitive: []string{"apiKey"},
+//	})
+//
+//	var stripe StripeSettings
+//	app.Settings().Extension("stripe", &stripe)
+func (app *BaseApp) RegisterSettingsExtension(ext SettingsExtension) error {
+	if !pluginNameRegex.MatchString(ext.Name) {
+		return fmt.Errorf("invalid settings extension name %q", ext.Name)
+	}
+
+	if ext.Defaults == nil {
+		return fmt.Errorf("settings extension %q must have Defaults value", ext.Name)
+	}
+
+	registry := app.Settings().extensions
+
+	if registry.find(ext.Name) != nil {
+		return fmt.Errorf("settings extension %q is already registered", ext.Name)
+	}
+
+	registry.mu.Lock()
+	registry.items = append(registry.items, &ext)
+	registry.mu.Unlock()
+
+	return nil
+}
+
+// SettingsExtensions returns the registered app settings extensions.
+func (app *BaseApp) SettingsExtensions() []SettingsExtension {
+	items := app.Settings().extensions.all()
+
+	result := make([]SettingsExtension, len(items))
+	for i, ext := range items {
+		result[i] = *ext
+	}
+
+	return result
+}
+
+func (app *BaseApp) registerSettingsExtensionsHooks() {
+	app.OnSettingsReload().Bind(&hook.Handler[*SettingsReloadEvent]{
+		Id: "__pbSettingsExtensionsReload__",
+		Func: func(e *SettingsReloadEvent) error {
+			if err := e.Next(); err != nil {
+				return err
+			}
+
+			settings := e.App.Settings()
+			registry := settings.extensions
+			if registry == nil {
+				return nil
+			}
+
+			var errs []error
+
+			for _, ext := range registry.all() {
+				settings.mu.RLock()
+				raw := settings.Extensions[ext.Name].String()
+				settings.mu.RUnlock()
+
+				registry.mu.Lock()
+				changed := registry.lastRaw[ext.Name] != raw
+				registry.lastRaw[ext.Name] = raw
+				registry.mu.Unlock()
+
+				if changed && ext.OnReload != nil {
+					if err := ext.OnReload(e.App); err != nil {
+						errs = append(errs, fmt.Errorf("settings extension %q reload error: %w", ext.Name, err))
+					}
+				}
+			}
+
+			return errors.Join(errs...)
+		},
+	})
+}
+
+// Extension unmarshals the value of the specified custom settings section
+// into result (the registered extension defaults are applied first).
+func (s *Settings) Extension(name string, result any) error {
+	ext := s.extensions.find(name)
+
+	s.mu.RLock()
+	raw, ok := s.Extensions[name]
+	s.mu.RUnlock()
+
+	if ext == nil && !ok {
+		return fmt.Errorf("missing settings extension %q", name)
+	}
+
+	if ext != nil {
+		defaults, err := json.Marshal(ext.Defaults)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(defaults, result); err != nil {
+			return err
+		}
+	}
+
+	if len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
+
+// SetExtension replaces the value of the specified custom settings section.
+//
+// Note that the change is not persisted until the settings are saved.
+func (s *Settings) SetExtension(name string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Extensions == nil {
+		s.Extensions = SettingsExtensionsConfig{}
+	}
+
+	s.Extensions[name] = raw
+
+	return nil
+}
+
+// validateExtensions validates the registered custom settings sections.
+//
+// Note that it is expected to be called with an already acquired s.mu lock.
+func (s *Settings) validateExtensions() error {
+	errs := validation.Errors{}
+
+	for _, ext := range s.extensions.all() {
+		value := reflect.New(reflect.TypeOf(ext.Defaults))
+
+		defaults, err := json.Marshal(ext.Defaults)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(defaults, value.Interface()); err != nil {
+			return err
+		}
+
+		if raw := s.Extensions[ext.Name]; len(raw) > 0 {
+			if err := json.Unmarshal(raw, value.Interface()); err != nil {
+				errs[ext.Name] = validation.NewError("validation_invalid_settings_extension", "Invalid section value.")
+				continue
+			}
+		}
+
+		v, ok := value.Interface().(validation.Validatable)
+		if !ok {
+			v, ok = value.Elem().Interface().(validation.Validatable)
+		}
+		if !ok {
+			continue
+		}
+
+		if err := v.Validate(); err != nil {
+			errs[ext.Name] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// maskedExtensions returns a copy of the provided custom settings sections
+// with the applied registered defaults and excluded sensitive keys.
+func (s *Settings) maskedExtensions(sections SettingsExtensionsConfig) (SettingsExtensionsConfig, error) {
+	result := make(SettingsExtensionsConfig, len(sections))
+
+	for name, raw := range sections {
+		result[name] = raw
+	}
+
+	for _, ext := range s.extensions.all() {
+		defaults, err := json.Marshal(ext.Defaults)
+		if err != nil {
+			return nil, err
+		}
+
+		merged := mergeJSONObjects(defaults, result[ext.Name])
+
+		if len(ext.Sensitive) > 0 {
+			obj := map[string]json.RawMessage{}
+			if json.Unmarshal(merged, &obj) == nil {
+				for _, key := range ext.Sensitive {
+					delete(obj, key)
+				}
+				merged, err = json.Marshal(obj)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		result[ext.Name] = merged
+	}
+
+	return result, nil
+}
+
+// -------------------------------------------------------------------
+
+// SettingsExtensionsConfig defines the persisted custom settings
+// sections (see [App.RegisterSettingsExtension]).
+type SettingsExtensionsConfig map[string]types.JSONRaw
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c SettingsExtensionsConfig) MarshalJSON() ([]byte, error) {
+	type alias SettingsExtensionsConfig // prevent recursion
+
+	// ensure that it is always serialized as object
+	if c == nil {
+		c = SettingsExtensionsConfig{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+//
+// Similar to the other settings sections, the top-level keys of the
+// loaded object sections are merged with the existing ones
+// (so that the omitted sensitive keys are preserved on update).
+// A null section value deletes the section.
+func (c *SettingsExtensionsConfig) UnmarshalJSON(data []byte) error {
+	sections := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return err
+	}
+
+	if *c == nil {
+		*c = SettingsExtensionsConfig{}
+	}
+
+	for name, raw := range sections {
+		if string(raw) == "null" {
+			delete(*c, name)
+			continue
+		}
+
+		(*c)[name] = mergeJSONObjects((*c)[name], raw)
+	}
+
+	return nil
+}
+
+// mergeJSONObjects shallow merges the top-level keys of the "b" JSON object into "a".
+//
+// If any of the arguments is not a JSON object, "b" is returned (or "a" if "b" is empty).
+func mergeJSONObjects(a, b []byte) types.JSONRaw {
+	if len(b) == 0 {
+		return a
+	}
+
+	objA := map[string]json.RawMessage{}
+	objB := map[string]json.RawMessage{}
+
+	if len(a) == 0 || json.Unmarshal(a, &objA) != nil || json.Unmarshal(b, &objB) != nil || objA == nil || objB == nil {
+		return b
+	}
+
+	for k, v := range objB {
+		objA[k] = v
+	}
+
+	merged, err := json.Marshal(objA)
+	if err != nil {
+		return b
+	}
+
+	return merged
+}
@@ -0,0 +1,152 @@
+package core_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+type testExtensionSettings struct {
+	Enabled bool   `json:"enabled"`
+	Limit   int    `json:"limit"`
+	APIKey  string `json:"apiKey"`
+}
+
+func (s testExtensionSettings) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Limit, validation.Max(10)),
+		validation.Field(&s.APIKey, validation.When(s.Enabled, validation.Required)),
+	)
+}
+
+func TestRegisterSettingsExtension(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		ext         core.SettingsExtension
+		expectError bool
+	}{
+		{"invalid name", core.SettingsExtension{Name: "invalid name", Defaults: testExtensionSettings{}}, true},
+		{"missing defaults", core.SettingsExtension{Name: "test"}, true},
+		{"valid", core.SettingsExtension{Name: "test", Defaults: testExtensionSettings{}}, false},
+		{"duplicated", core.SettingsExtension{Name: "test", Defaults: testExtensionSettings{}}, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := app.RegisterSettingsExtension(s.ext)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+
+	if total := len(app.SettingsExtensions()); total != 1 {
+		t.Fatalf("Expected 1 registered extension, got %d", total)
+	}
+}
+
+func TestSettingsExtension(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var reloadCalls int
+
+	err := app.RegisterSettingsExtension(core.SettingsExtension{
+		Name:      "test",
+		Defaults:  testExtensionSettings{Limit: 5},
+		Sensitive: []string{"apiKey"},
+		OnReload: func(app core.App) error {
+			reloadCalls++
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// defaults
+	var value testExtensionSettings
+	if err := app.Settings().Extension("test", &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.Limit != 5 || value.Enabled {
+		t.Fatalf("Expected the default section value, got %#v", value)
+	}
+
+	if err := app.Settings().Extension("missing", &value); err == nil {
+		t.Fatal("Expected missing extension error")
+	}
+
+	// validation
+	if err := app.Settings().SetExtension("test", map[string]any{"enabled": true, "limit": 11}); err != nil {
+		t.Fatal(err)
+	}
+	err = app.Save(app.Settings())
+	tests.TestValidationErrors(t, err, []string{"extensions"})
+
+	// save
+	if err := app.Settings().SetExtension("test", testExtensionSettings{Enabled: true, Limit: 10, APIKey: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+	if reloadCalls != 1 {
+		t.Fatalf("Expected 1 OnReload call, got %d", reloadCalls)
+	}
+
+	// unchanged section save shouldn't trigger OnReload
+	app.Settings().Meta.AppName = "test_reload"
+	if err := app.Save(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+	if reloadCalls != 1 {
+		t.Fatalf("Expected OnReload to not be called again, got %d", reloadCalls)
+	}
+
+	// masked sensitive key
+	raw, err := json.Marshal(app.Settings())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedJSON := `"extensions":{"test":{"enabled":true,"limit":10}}`
+	if !strings.Contains(string(raw), expectedJSON) {
+		t.Fatalf("Expected %s in\n%s", expectedJSON, raw)
+	}
+
+	// the omitted sensitive key should be preserved on partial update
+	clone, err := app.Settings().Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"extensions":{"test":{"limit":3}}}`), clone); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Save(clone); err != nil {
+		t.Fatal(err)
+	}
+
+	value = testExtensionSettings{}
+	if err := app.Settings().Extension("test", &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.Limit != 3 || !value.Enabled || value.APIKey != "secret" {
+		t.Fatalf("Expected the merged section value, got %#v", value)
+	}
+	if reloadCalls != 2 {
+		t.Fatalf("Expected 2 OnReload calls, got %d", reloadCalls)
+	}
+}
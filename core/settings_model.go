@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
 	"os"
 	"regexp"
 	"slices"
@@ -16,10 +19,15 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/core/validators"
+	"github.com/pocketbase/pocketbase/tools/age"
+	"github.com/pocketbase/pocketbase/tools/cache"
+	"github.com/pocketbase/pocketbase/tools/captcha"
 	"github.com/pocketbase/pocketbase/tools/cron"
 	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/logger"
 	"github.com/pocketbase/pocketbase/tools/mailer"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/sentry"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -121,26 +129,58 @@ var (
 
 type settings struct {
 	SMTP         SMTPConfig         `form:"smtp" json:"smtp"`
+	Mailer       MailerConfig       `form:"mailer" json:"mailer"`
+	SMS          SMSConfig          `form:"sms" json:"sms"`
+	Push         PushConfig         `form:"push" json:"push"`
 	Backups      BackupsConfig      `form:"backups" json:"backups"`
 	S3           S3Config           `form:"s3" json:"s3"`
 	Meta         MetaConfig         `form:"meta" json:"meta"`
 	RateLimits   RateLimitsConfig   `form:"rateLimits" json:"rateLimits"`
 	TrustedProxy TrustedProxyConfig `form:"trustedProxy" json:"trustedProxy"`
 	Batch        BatchConfig        `form:"batch" json:"batch"`
+	GraphQL      GraphQLConfig      `form:"graphql" json:"graphql"`
 	Logs         LogsConfig         `form:"logs" json:"logs"`
+
+	PanicReporting PanicReportingConfig `form:"panicReporting" json:"panicReporting"`
+	ResponseCache  ResponseCacheConfig  `form:"responseCache" json:"responseCache"`
+	Routes         RoutesConfig         `form:"routes" json:"routes"`
+	Plugins        PluginsConfig        `form:"plugins" json:"plugins"`
+	DataRetention  DataRetentionConfig  `form:"dataRetention" json:"dataRetention"`
+	DBMaintenance  DBMaintenanceConfig  `form:"dbMaintenance" json:"dbMaintenance"`
+	Profiling      ProfilingConfig      `form:"profiling" json:"profiling"`
+	HookTracing    HookTracingConfig    `form:"hookTracing" json:"hookTracing"`
+	Queries        QueriesConfig        `form:"queries" json:"queries"`
+	ACME           ACMEConfig           `form:"acme" json:"acme"`
+	Maintenance    MaintenanceConfig    `form:"maintenance" json:"maintenance"`
+	IPFilter       IPFilterConfig       `form:"ipFilter" json:"ipFilter"`
+	Captcha        CaptchaConfig        `form:"captcha" json:"captcha"`
+	UserData       UserDataConfig       `form:"userData" json:"userData"`
+	Analytics      AnalyticsConfig      `form:"analytics" json:"analytics"`
+	Capture        CaptureConfig        `form:"capture" json:"capture"`
+
+	Extensions SettingsExtensionsConfig `form:"extensions" json:"extensions"`
 }
 
 // Settings defines the PocketBase app settings.
 type Settings struct {
 	settings
 
+	// extensions is the registry of the custom settings sections
+	// (see [App.RegisterSettingsExtension]).
+	extensions *settingsExtensions
+
+	// envOverrides stores the original values of the
+	// env overridden settings paths (see [SettingsEnvPrefix]).
+	envOverrides map[string][]byte
+
 	mu    sync.RWMutex
 	isNew bool
 }
 
 func newDefaultSettings() *Settings {
 	return &Settings{
-		isNew: true,
+		isNew:      true,
+		extensions: newSettingsExtensions(),
 		settings: settings{
 			Meta: MetaConfig{
 				AppName:       "Acme",
@@ -150,8 +190,11 @@ func newDefaultSettings() *Settings {
 				SenderAddress: "support@example.com",
 			},
 			Logs: LogsConfig{
-				MaxDays: 5,
-				LogIP:   true,
+				MaxDays:       5,
+				LogIP:         true,
+				BatchSize:     DefaultLogsBatchSize,
+				FlushInterval: DefaultLogsFlushInterval,
+				MaxBuffered:   DefaultLogsMaxBuffered,
 			},
 			SMTP: SMTPConfig{
 				Enabled:  false,
@@ -169,6 +212,18 @@ func newDefaultSettings() *Settings {
 				MaxRequests: 50,
 				Timeout:     3,
 			},
+			ResponseCache: ResponseCacheConfig{
+				Backend: ResponseCacheBackendMemory,
+				TTL:     60,
+			},
+			Analytics: AnalyticsConfig{
+				EventsMaxDays:  7,
+				RollupsMaxDays: 365,
+			},
+			Capture: CaptureConfig{
+				MaxItems:    200,
+				MaxBodySize: DefaultCaptureMaxBodySize,
+			},
 			RateLimits: RateLimitsConfig{
 				Enabled: false, // @todo once tested enough enable by default for new installations
 				Rules: []RateLimitRule{
@@ -252,7 +307,16 @@ func (s *Settings) DBExport(app App) (map[string]any, error) {
 	}
 	result["updated"] = now
 
-	encoded, err := json.Marshal(s.settings)
+	toExport := s.settings
+	if len(s.envOverrides) > 0 {
+		var err error
+		toExport, err = s.withoutEnvOverrides()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	encoded, err := json.Marshal(toExport)
 	if err != nil {
 		return nil, err
 	}
@@ -282,11 +346,35 @@ func (s *Settings) PostValidate(ctx context.Context, app App) error {
 		validation.Field(&s.Meta),
 		validation.Field(&s.Logs),
 		validation.Field(&s.SMTP),
+		validation.Field(&s.Mailer),
+		validation.Field(&s.SMS),
+		validation.Field(&s.Push),
 		validation.Field(&s.S3),
 		validation.Field(&s.Backups),
 		validation.Field(&s.Batch),
 		validation.Field(&s.RateLimits),
 		validation.Field(&s.TrustedProxy),
+		validation.Field(&s.PanicReporting),
+		validation.Field(&s.ResponseCache),
+		validation.Field(&s.Routes),
+		validation.Field(&s.DataRetention, validation.By(func(value any) error {
+			return validateDataRetentionSettings(app, s.DataRetention)
+		})),
+		validation.Field(&s.DBMaintenance),
+		validation.Field(&s.HookTracing),
+		validation.Field(&s.Queries),
+		validation.Field(&s.ACME),
+		validation.Field(&s.Maintenance),
+		validation.Field(&s.IPFilter),
+		validation.Field(&s.Captcha),
+		validation.Field(&s.UserData, validation.By(func(value any) error {
+			return validateUserDataSettings(app, s.UserData)
+		})),
+		validation.Field(&s.Analytics),
+		validation.Field(&s.Capture),
+		validation.Field(&s.Extensions, validation.By(func(value any) error {
+			return s.validateExtensions()
+		})),
 	)
 }
 
@@ -308,9 +396,13 @@ func (s *Settings) Merge(other *Settings) error {
 
 // Clone creates a new deep copy of the current settings.
 func (s *Settings) Clone() (*Settings, error) {
+	s.mu.RLock()
 	clone := &Settings{
-		isNew: s.isNew,
+		isNew:        s.isNew,
+		extensions:   s.extensions,
+		envOverrides: s.envOverrides,
 	}
+	s.mu.RUnlock()
 
 	if err := clone.Merge(s); err != nil {
 		return nil, err
@@ -329,8 +421,26 @@ func (s *Settings) MarshalJSON() ([]byte, error) {
 
 	sensitiveFields := []*string{
 		&copy.SMTP.Password,
+		&copy.Mailer.APIKey,
+		&copy.Mailer.Secret,
+		&copy.Mailer.InboundToken,
+		&copy.SMS.Secret,
+		&copy.Captcha.Secret,
+		&copy.Push.FCM.ServiceAccount,
+		&copy.Push.APNs.PrivateKey,
+		&copy.Push.WebPush.PrivateKey,
 		&copy.S3.Secret,
 		&copy.Backups.S3.Secret,
+		&copy.ACME.Cloudflare.APIToken,
+		&copy.ACME.Route53.Secret,
+		&copy.ACME.RFC2136.TSIGSecret,
+		&copy.PanicReporting.DSN,
+		&copy.ResponseCache.RedisPassword,
+	}
+
+	copy.Logs.Sinks = slices.Clone(copy.Logs.Sinks)
+	for i := range copy.Logs.Sinks {
+		sensitiveFields = append(sensitiveFields, &copy.Logs.Sinks[i].Token)
 	}
 
 	// mask all sensitive fields
@@ -340,6 +450,12 @@ func (s *Settings) MarshalJSON() ([]byte, error) {
 		}
 	}
 
+	extensions, err := s.maskedExtensions(copy.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	copy.Extensions = extensions
+
 	return json.Marshal(copy)
 }
 
@@ -394,259 +510,1727 @@ func (c SMTPConfig) Validate() error {
 
 // -------------------------------------------------------------------
 
-type S3Config struct {
-	Enabled        bool   `form:"enabled" json:"enabled"`
-	Bucket         string `form:"bucket" json:"bucket"`
-	Region         string `form:"region" json:"region"`
-	Endpoint       string `form:"endpoint" json:"endpoint"`
-	AccessKey      string `form:"accessKey" json:"accessKey"`
-	Secret         string `form:"secret" json:"secret,omitempty"`
-	ForcePathStyle bool   `form:"forcePathStyle" json:"forcePathStyle"`
-}
+// Supported HTTP API mail providers.
+const (
+	MailerProviderSES      = "ses"
+	MailerProviderSendGrid = "sendgrid"
+	MailerProviderMailgun  = "mailgun"
+	MailerProviderResend   = "resend"
+)
 
-// Validate makes S3Config validatable by implementing [validation.Validatable] interface.
-func (c S3Config) Validate() error {
-	return validation.ValidateStruct(&c,
-		validation.Field(&c.Endpoint, is.URL, validation.When(c.Enabled, validation.Required)),
-		validation.Field(&c.Bucket, validation.When(c.Enabled, validation.Required)),
-		validation.Field(&c.Region, validation.When(c.Enabled, validation.Required)),
-		validation.Field(&c.AccessKey, validation.When(c.Enabled, validation.Required)),
-		validation.Field(&c.Secret, validation.When(c.Enabled, validation.Required)),
-	)
-}
+// MailerConfig defines the settings of the optional HTTP API mail provider.
+//
+// When Provider is set it takes precedence over the SMTP and sendmail clients.
+type MailerConfig struct {
+	// Provider is the name of the HTTP API mail provider to use
+	// (leave empty to fallback to SMTP or sendmail).
+	Provider string `form:"provider" json:"provider"`
 
-// -------------------------------------------------------------------
+	// APIKey is the SendGrid, Mailgun or Resend API key.
+	APIKey string `form:"apiKey" json:"apiKey,omitempty"`
 
-type BatchConfig struct {
-	Enabled bool `form:"enabled" json:"enabled"`
+	// AccessKey is the AWS access key id (SES only).
+	AccessKey string `form:"accessKey" json:"accessKey"`
 
-	// MaxRequests is the maximum allowed batch request to execute.
-	MaxRequests int `form:"maxRequests" json:"maxRequests"`
+	// Secret is the AWS secret access key (SES only).
+	Secret string `form:"secret" json:"secret,omitempty"`
 
-	// Timeout is the max duration in seconds to wait before cancelling the batch transaction.
-	Timeout int64 `form:"timeout" json:"timeout"`
+	// Region is the AWS region (SES) or the account region ("us" or "eu") (Mailgun).
+	Region string `form:"region" json:"region"`
 
-	// MaxBodySize is the maximum allowed batch request body size in bytes.
-	//
-	// If not set, fallbacks to max ~128MB.
-	MaxBodySize int64 `form:"maxBodySize" json:"maxBodySize"`
-}
+	// Domain is the sending domain name (Mailgun only).
+	Domain string `form:"domain" json:"domain"`
 
-// Validate makes BatchConfig validatable by implementing [validation.Validatable] interface.
-func (c BatchConfig) Validate() error {
-	return validation.ValidateStruct(&c,
-		validation.Field(&c.MaxRequests, validation.When(c.Enabled, validation.Required), validation.Min(0)),
-		validation.Field(&c.Timeout, validation.When(c.Enabled, validation.Required), validation.Min(0)),
-		validation.Field(&c.MaxBodySize, validation.Min(0)),
-	)
-}
+	// Endpoint is an optional custom API base url.
+	Endpoint string `form:"endpoint" json:"endpoint"`
 
-// -------------------------------------------------------------------
+	// ConfigurationSet is an optional configuration set name (SES only).
+	ConfigurationSet string `form:"configurationSet" json:"configurationSet"`
 
-type BackupsConfig struct {
-	// Cron is a cron expression to schedule auto backups, eg. "* * * * *".
+	// Tags is an optional list of tags to attach to each sent message.
 	//
-	// Leave it empty to disable the auto backups functionality.
-	Cron string `form:"cron" json:"cron"`
+	// For SES and Resend the tags could be specified in "name:value" format.
+	Tags []string `form:"tags" json:"tags"`
 
-	// CronMaxKeep is the max number of cron generated backups to
-	// keep before removing older entries.
-	//
-	// This field works only when the cron config has valid cron expression.
-	CronMaxKeep int `form:"cronMaxKeep" json:"cronMaxKeep"`
+	// TrackOpens enables the provider open tracking (SendGrid and Mailgun only).
+	TrackOpens bool `form:"trackOpens" json:"trackOpens"`
 
-	// S3 is an optional S3 storage config specifying where to store the app backups.
-	S3 S3Config `form:"s3" json:"s3"`
+	// TrackClicks enables the provider click tracking (SendGrid and Mailgun only).
+	TrackClicks bool `form:"trackClicks" json:"trackClicks"`
+
+	// InboundToken is the secret token that the inbound email and delivery
+	// events webhooks must provide (leave empty to disable the webhooks).
+	InboundToken string `form:"inboundToken" json:"inboundToken,omitempty"`
 }
 
-// Validate makes BackupsConfig validatable by implementing [validation.Validatable] interface.
-func (c BackupsConfig) Validate() error {
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c MailerConfig) MarshalJSON() ([]byte, error) {
+	type alias MailerConfig
+
+	// serialize as empty array
+	if c.Tags == nil {
+		c.Tags = []string{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes MailerConfig validatable by implementing [validation.Validatable] interface.
+func (c MailerConfig) Validate() error {
+	usesAPIKey := c.Provider == MailerProviderSendGrid ||
+		c.Provider == MailerProviderMailgun ||
+		c.Provider == MailerProviderResend
+
 	return validation.ValidateStruct(&c,
-		validation.Field(&c.S3),
-		validation.Field(&c.Cron, validation.By(checkCronExpression)),
 		validation.Field(
-			&c.CronMaxKeep,
-			validation.When(c.Cron != "", validation.Required),
-			validation.Min(1),
+			&c.Provider,
+			validation.In(
+				MailerProviderSES,
+				MailerProviderSendGrid,
+				MailerProviderMailgun,
+				MailerProviderResend,
+			),
+		),
+		validation.Field(&c.APIKey, validation.When(usesAPIKey, validation.Required)),
+		validation.Field(&c.AccessKey, validation.When(c.Provider == MailerProviderSES, validation.Required)),
+		validation.Field(&c.Secret, validation.When(c.Provider == MailerProviderSES, validation.Required)),
+		validation.Field(
+			&c.Region,
+			validation.When(c.Provider == MailerProviderSES, validation.Required),
+			validation.When(c.Provider == MailerProviderMailgun, validation.In("us", "eu")),
+		),
+		validation.Field(
+			&c.Domain,
+			validation.When(c.Provider == MailerProviderMailgun, validation.Required),
+			is.Host,
 		),
+		validation.Field(&c.Endpoint, is.URL),
+		validation.Field(&c.Tags, validation.Each(validation.Required, validation.Length(1, 255))),
+		validation.Field(&c.InboundToken, validation.Length(10, 255)),
 	)
 }
 
-func checkCronExpression(value any) error {
-	v, _ := value.(string)
-	if v == "" {
-		return nil // nothing to check
-	}
+// -------------------------------------------------------------------
 
-	_, err := cron.NewSchedule(v)
-	if err != nil {
-		return validation.NewError("validation_invalid_cron", err.Error())
-	}
+// Supported SMS providers.
+const (
+	SMSProviderTwilio = "twilio"
+	SMSProviderVonage = "vonage"
+	SMSProviderPlivo  = "plivo"
+	SMSProviderHTTP   = "http"
+)
 
-	return nil
-}
+// SMSConfig defines the text messages (SMS) delivery settings.
+type SMSConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
 
-// -------------------------------------------------------------------
+	// Provider is the name of the SMS provider to use.
+	Provider string `form:"provider" json:"provider"`
 
-type MetaConfig struct {
-	AppName       string `form:"appName" json:"appName"`
-	AppURL        string `form:"appURL" json:"appURL"`
-	SenderName    string `form:"senderName" json:"senderName"`
-	SenderAddress string `form:"senderAddress" json:"senderAddress"`
-	HideControls  bool   `form:"hideControls" json:"hideControls"`
+	// AccountId is the Twilio account SID, Vonage API key or Plivo auth id
+	// (not used by the generic "http" provider).
+	AccountId string `form:"accountId" json:"accountId"`
+
+	// Secret is the Twilio auth token, Vonage API secret, Plivo auth token
+	// or the optional Bearer token of the generic "http" provider.
+	Secret string `form:"secret" json:"secret,omitempty"`
+
+	// From is the default sender phone number or alphanumeric sender id.
+	From string `form:"from" json:"from"`
+
+	// Endpoint is an optional custom API base url
+	// (for the generic "http" provider this is the full url where the messages will be submitted).
+	Endpoint string `form:"endpoint" json:"endpoint"`
 }
 
-// Validate makes MetaConfig validatable by implementing [validation.Validatable] interface.
-func (c MetaConfig) Validate() error {
+// Validate makes SMSConfig validatable by implementing [validation.Validatable] interface.
+func (c SMSConfig) Validate() error {
+	isHTTP := c.Provider == SMSProviderHTTP
+
 	return validation.ValidateStruct(&c,
-		validation.Field(&c.AppName, validation.Required, validation.Length(1, 255)),
-		validation.Field(&c.AppURL, validation.Required, is.URL),
-		validation.Field(&c.SenderName, validation.Required, validation.Length(1, 255)),
-		validation.Field(&c.SenderAddress, is.EmailFormat, validation.Required),
+		validation.Field(
+			&c.Provider,
+			validation.When(c.Enabled, validation.Required),
+			validation.In(SMSProviderTwilio, SMSProviderVonage, SMSProviderPlivo, SMSProviderHTTP),
+		),
+		validation.Field(&c.AccountId, validation.When(c.Enabled && !isHTTP, validation.Required)),
+		validation.Field(&c.Secret, validation.When(c.Enabled && !isHTTP, validation.Required)),
+		validation.Field(&c.From, validation.When(c.Enabled && !isHTTP, validation.Required), validation.Length(0, 50)),
+		validation.Field(&c.Endpoint, validation.When(c.Enabled && isHTTP, validation.Required), is.URL),
 	)
 }
 
 // -------------------------------------------------------------------
 
-type LogsConfig struct {
-	MaxDays   int  `form:"maxDays" json:"maxDays"`
-	MinLevel  int  `form:"minLevel" json:"minLevel"`
-	LogIP     bool `form:"logIP" json:"logIP"`
-	LogAuthId bool `form:"logAuthId" json:"logAuthId"`
+// Supported captcha providers.
+const (
+	CaptchaProviderTurnstile = captcha.ProviderTurnstile
+	CaptchaProviderHCaptcha  = captcha.ProviderHCaptcha
+	CaptchaProviderReCaptcha = captcha.ProviderReCaptcha
+
+	// CaptchaProviderCustom indicates that the captcha tokens
+	// are verified by a custom OnCaptchaVerify hook handler.
+	CaptchaProviderCustom = "custom"
+)
+
+// CaptchaConfig defines the captcha verification settings of
+// the collection actions with enabled [CollectionCaptcha] protection.
+type CaptchaConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Provider is the name of the captcha provider to use.
+	Provider string `form:"provider" json:"provider"`
+
+	// Secret is the provider secret key (not used by the "custom" provider).
+	Secret string `form:"secret" json:"secret,omitempty"`
+
+	// Endpoint is an optional custom siteverify url.
+	Endpoint string `form:"endpoint" json:"endpoint"`
+
+	// MinScore is the optional min required score (0-1)
+	// for the score based providers like reCAPTCHA v3.
+	MinScore float64 `form:"minScore" json:"minScore"`
 }
 
-// Validate makes LogsConfig validatable by implementing [validation.Validatable] interface.
-func (c LogsConfig) Validate() error {
+// Validate makes CaptchaConfig validatable by implementing [validation.Validatable] interface.
+func (c CaptchaConfig) Validate() error {
+	isCustom := c.Provider == CaptchaProviderCustom
+
 	return validation.ValidateStruct(&c,
-		validation.Field(&c.MaxDays, validation.Min(0)),
+		validation.Field(
+			&c.Provider,
+			validation.When(c.Enabled, validation.Required),
+			validation.In(CaptchaProviderTurnstile, CaptchaProviderHCaptcha, CaptchaProviderReCaptcha, CaptchaProviderCustom),
+		),
+		validation.Field(&c.Secret, validation.When(c.Enabled && !isCustom, validation.Required)),
+		validation.Field(&c.Endpoint, is.URL),
+		validation.Field(&c.MinScore, validation.Min(0.0), validation.Max(1.0)),
 	)
 }
 
 // -------------------------------------------------------------------
 
-type TrustedProxyConfig struct {
-	// Headers is a list of explicit trusted header(s) to check.
-	Headers []string `form:"headers" json:"headers"`
+// Supported push notification trigger events.
+const (
+	PushTriggerEventCreate = "create"
+	PushTriggerEventUpdate = "update"
+	PushTriggerEventDelete = "delete"
+)
 
-	// UseLeftmostIP specifies to use the left-mostish IP from the trusted headers.
-	//
-	// Note that this could be insecure when used with X-Forwarded-For header
-	// because some proxies like AWS ELB allow users to prepend their own header value
-	// before appending the trusted ones.
-	UseLeftmostIP bool `form:"useLeftmostIP" json:"useLeftmostIP"`
+// PushConfig defines the push notifications delivery settings.
+type PushConfig struct {
+	FCM     PushFCMConfig     `form:"fcm" json:"fcm"`
+	APNs    PushAPNsConfig    `form:"apns" json:"apns"`
+	WebPush PushWebPushConfig `form:"webPush" json:"webPush"`
+
+	// Triggers is an optional list of collection record events that
+	// will send automatically a push notification to the related auth records.
+	Triggers []PushTrigger `form:"triggers" json:"triggers"`
 }
 
 // MarshalJSON implements the [json.Marshaler] interface.
-func (c TrustedProxyConfig) MarshalJSON() ([]byte, error) {
-	type alias TrustedProxyConfig
+func (c PushConfig) MarshalJSON() ([]byte, error) {
+	type alias PushConfig
 
 	// serialize as empty array
-	if c.Headers == nil {
-		c.Headers = []string{}
+	if c.Triggers == nil {
+		c.Triggers = []PushTrigger{}
 	}
 
 	return json.Marshal(alias(c))
 }
 
-// Validate makes RateLimitRule validatable by implementing [validation.Validatable] interface.
-func (c TrustedProxyConfig) Validate() error {
-	return nil
+// Validate makes PushConfig validatable by implementing [validation.Validatable] interface.
+func (c PushConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.FCM),
+		validation.Field(&c.APNs),
+		validation.Field(&c.WebPush),
+		validation.Field(&c.Triggers),
+	)
 }
 
-// -------------------------------------------------------------------
+// PushFCMConfig defines the Firebase Cloud Messaging settings.
+type PushFCMConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
 
-type RateLimitsConfig struct {
-	Rules   []RateLimitRule `form:"rules" json:"rules"`
-	Enabled bool            `form:"enabled" json:"enabled"`
+	// ServiceAccount is the JSON content of the Google service account key file.
+	ServiceAccount string `form:"serviceAccount" json:"serviceAccount,omitempty"`
 }
 
-// FindRateLimitRule returns the first matching rule based on the provided labels.
-//
-// Optionally you can further specify a list of valid RateLimitRule.Audience values to further filter the matching rule
-// (aka. the rule Audience will have to exist in one of the specified options).
-func (c *RateLimitsConfig) FindRateLimitRule(searchLabels []string, optOnlyAudience ...string) (RateLimitRule, bool) {
-	var prefixRules []int
+// Validate makes PushFCMConfig validatable by implementing [validation.Validatable] interface.
+func (c PushFCMConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.ServiceAccount, validation.When(c.Enabled, validation.Required), is.JSON),
+	)
+}
 
-	for i, label := range searchLabels {
-		// check for direct match
-		for j := range c.Rules {
-			if label == c.Rules[j].Label &&
-				(len(optOnlyAudience) == 0 || slices.Contains(optOnlyAudience, c.Rules[j].Audience)) {
-				return c.Rules[j], true
-			}
+// PushAPNsConfig defines the Apple Push Notification service settings.
+type PushAPNsConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
 
-			if i == 0 && strings.HasSuffix(c.Rules[j].Label, "/") {
-				prefixRules = append(prefixRules, j)
-			}
-		}
+	// TeamId is the Apple developer team id.
+	TeamId string `form:"teamId" json:"teamId"`
 
-		// check for prefix match
-		if len(prefixRules) > 0 {
-			for j := range prefixRules {
-				if strings.HasPrefix(label+"/", c.Rules[prefixRules[j]].Label) &&
-					(len(optOnlyAudience) == 0 || slices.Contains(optOnlyAudience, c.Rules[prefixRules[j]].Audience)) {
-					return c.Rules[prefixRules[j]], true
-				}
-			}
-		}
-	}
+	// KeyId is the id of the APNs authentication key.
+	KeyId string `form:"keyId" json:"keyId"`
 
-	return RateLimitRule{}, false
+	// PrivateKey is the PEM encoded content of the APNs authentication key (.p8 file).
+	PrivateKey string `form:"privateKey" json:"privateKey,omitempty"`
+
+	// Topic is the app bundle id.
+	Topic string `form:"topic" json:"topic"`
+
+	// Production specifies whether to use the production or the sandbox APNs environment.
+	Production bool `form:"production" json:"production"`
 }
 
-// MarshalJSON implements the [json.Marshaler] interface.
-func (c RateLimitsConfig) MarshalJSON() ([]byte, error) {
-	type alias RateLimitsConfig
+// Validate makes PushAPNsConfig validatable by implementing [validation.Validatable] interface.
+func (c PushAPNsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.TeamId, validation.When(c.Enabled, validation.Required), validation.Length(0, 100)),
+		validation.Field(&c.KeyId, validation.When(c.Enabled, validation.Required), validation.Length(0, 100)),
+		validation.Field(&c.PrivateKey, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.Topic, validation.When(c.Enabled, validation.Required), validation.Length(0, 255)),
+	)
+}
 
-	// serialize as empty array
-	if c.Rules == nil {
-		c.Rules = []RateLimitRule{}
-	}
+var vapidSubjectRegex = regexp.MustCompile(`^(mailto:|https://)`)
 
-	return json.Marshal(alias(c))
+// PushWebPushConfig defines the browsers WebPush (VAPID) settings.
+type PushWebPushConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// PublicKey is the base64url encoded VAPID public key.
+	PublicKey string `form:"publicKey" json:"publicKey"`
+
+	// PrivateKey is the base64url encoded VAPID private key.
+	PrivateKey string `form:"privateKey" json:"privateKey,omitempty"`
+
+	// Subject is the VAPID contact subject ("mailto:" or "https:" url).
+	Subject string `form:"subject" json:"subject"`
 }
 
-// Validate makes RateLimitsConfig validatable by implementing [validation.Validatable] interface.
-func (c RateLimitsConfig) Validate() error {
+// Validate makes PushWebPushConfig validatable by implementing [validation.Validatable] interface.
+func (c PushWebPushConfig) Validate() error {
 	return validation.ValidateStruct(&c,
+		validation.Field(&c.PublicKey, validation.When(c.Enabled, validation.Required), validation.Length(0, 255)),
+		validation.Field(&c.PrivateKey, validation.When(c.Enabled, validation.Required), validation.Length(0, 255)),
 		validation.Field(
-			&c.Rules,
+			&c.Subject,
 			validation.When(c.Enabled, validation.Required),
-			validation.By(checkUniqueRuleLabel),
+			validation.Match(vapidSubjectRegex).Error("Must start with mailto: or https://."),
 		),
 	)
 }
 
-func checkUniqueRuleLabel(value any) error {
-	rules, ok := value.([]RateLimitRule)
-	if !ok {
-		return validators.ErrUnsupportedValueType
-	}
-
-	existing := make([]string, 0, len(rules))
+// PushTrigger defines a single collection record event that sends a push notification.
+type PushTrigger struct {
+	// Collection is the name or id of the collection whose record events to observe.
+	Collection string `form:"collection" json:"collection"`
 
-	for i, rule := range rules {
-		fullKey := rule.Label + "@@" + rule.Audience
+	// Event is the record event that triggers the notification ("create", "update" or "delete").
+	Event string `form:"event" json:"event"`
 
-		var conflicts bool
-		for _, key := range existing {
-			if strings.HasPrefix(key, fullKey) || strings.HasPrefix(fullKey, key) {
-				conflicts = true
-				break
-			}
-		}
+	// RecipientField is the name of a relation field (to an auth collection)
+	// with the notification recipients.
+	//
+	// Leave it empty to notify the changed record itself (applicable only for auth collections).
+	RecipientField string `form:"recipientField" json:"recipientField"`
 
-		if conflicts {
-			return validation.Errors{
-				strconv.Itoa(i): validation.Errors{
-					"label": validation.NewError("validation_conflicting_rate_limit_rule", "Rate limit rule configuration with label {{.label}} already exists or conflicts with another rule.").
-						SetParams(map[string]any{"label": rule.Label}),
-				},
-			}
-		} else {
-			existing = append(existing, fullKey)
-		}
-	}
+	// Title is the notification title.
+	//
+	// It could contain {RECORD:fieldName} placeholders.
+	Title string `form:"title" json:"title"`
+
+	// Body is the notification body.
+	//
+	// It could contain {RECORD:fieldName} placeholders.
+	Body string `form:"body" json:"body"`
+}
+
+// Validate makes PushTrigger validatable by implementing [validation.Validatable] interface.
+func (c PushTrigger) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Collection, validation.Required, validation.Length(1, 255)),
+		validation.Field(
+			&c.Event,
+			validation.Required,
+			validation.In(PushTriggerEventCreate, PushTriggerEventUpdate, PushTriggerEventDelete),
+		),
+		validation.Field(&c.RecipientField, validation.Length(0, 255)),
+		validation.Field(&c.Title, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.Body, validation.Length(0, 1000)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+type S3Config struct {
+	Enabled        bool   `form:"enabled" json:"enabled"`
+	Bucket         string `form:"bucket" json:"bucket"`
+	Region         string `form:"region" json:"region"`
+	Endpoint       string `form:"endpoint" json:"endpoint"`
+	AccessKey      string `form:"accessKey" json:"accessKey"`
+	Secret         string `form:"secret" json:"secret,omitempty"`
+	ForcePathStyle bool   `form:"forcePathStyle" json:"forcePathStyle"`
+}
+
+// Validate makes S3Config validatable by implementing [validation.Validatable] interface.
+func (c S3Config) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Endpoint, is.URL, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.Bucket, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.Region, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.AccessKey, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.Secret, validation.When(c.Enabled, validation.Required)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// Supported ACME DNS-01 challenge providers.
+const (
+	ACMEDNSProviderCloudflare = "cloudflare"
+	ACMEDNSProviderRoute53    = "route53"
+	ACMEDNSProviderRFC2136    = "rfc2136"
+)
+
+// ACMEConfig defines the settings for issuing the TLS certificates
+// with the ACME DNS-01 challenge (e.g. for wildcard certificates or
+// for instances that are not reachable from the internet).
+//
+// The DNS-01 certificates are used only when the app is started with HTTPS
+// and only for the configured domains (the rest of the hosts fallback to the
+// default TLS-ALPN-01/HTTP-01 autocert challenges).
+type ACMEConfig struct {
+	// DNSProvider is the name of the DNS provider to use for the
+	// DNS-01 challenge records (empty value disables the DNS-01 challenge).
+	DNSProvider string `form:"dnsProvider" json:"dnsProvider"`
+
+	// Domains is the list of the certificate domains
+	// (wildcard domains like "*.example.com" are allowed).
+	Domains []string `form:"domains" json:"domains"`
+
+	// Email is an optional ACME account contact email address.
+	Email string `form:"email" json:"email"`
+
+	// DirectoryURL is an optional custom ACME directory url
+	// (default to the Let's Encrypt production directory).
+	DirectoryURL string `form:"directoryURL" json:"directoryURL"`
+
+	Cloudflare ACMECloudflareConfig `form:"cloudflare" json:"cloudflare"`
+	Route53    ACMERoute53Config    `form:"route53" json:"route53"`
+	RFC2136    ACMERFC2136Config    `form:"rfc2136" json:"rfc2136"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c ACMEConfig) MarshalJSON() ([]byte, error) {
+	type alias ACMEConfig
+
+	// serialize as empty array
+	if c.Domains == nil {
+		c.Domains = []string{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes ACMEConfig validatable by implementing [validation.Validatable] interface.
+func (c ACMEConfig) Validate() error {
+	enabled := c.DNSProvider != ""
+
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.DNSProvider,
+			validation.In(ACMEDNSProviderCloudflare, ACMEDNSProviderRoute53, ACMEDNSProviderRFC2136),
+		),
+		validation.Field(
+			&c.Domains,
+			validation.When(enabled, validation.Required),
+			validation.Each(validation.Required, validation.By(checkACMEDomain)),
+		),
+		validation.Field(&c.Email, is.EmailFormat),
+		validation.Field(&c.DirectoryURL, is.URL),
+		// validate only the selected provider settings
+		validation.Field(&c.Cloudflare, validation.Skip.When(c.DNSProvider != ACMEDNSProviderCloudflare)),
+		validation.Field(&c.Route53, validation.Skip.When(c.DNSProvider != ACMEDNSProviderRoute53)),
+		validation.Field(&c.RFC2136, validation.Skip.When(c.DNSProvider != ACMEDNSProviderRFC2136)),
+	)
+}
+
+func checkACMEDomain(value any) error {
+	v, _ := value.(string)
+
+	if err := is.Domain.Validate(strings.TrimPrefix(v, "*.")); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ACMECloudflareConfig defines the Cloudflare DNS-01 provider settings.
+type ACMECloudflareConfig struct {
+	// APIToken is a Cloudflare API token with "Zone.DNS:Edit" permissions.
+	APIToken string `form:"apiToken" json:"apiToken,omitempty"`
+}
+
+// Validate makes ACMECloudflareConfig validatable by implementing [validation.Validatable] interface.
+func (c ACMECloudflareConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.APIToken, validation.Required),
+	)
+}
+
+// ACMERoute53Config defines the AWS Route53 DNS-01 provider settings.
+type ACMERoute53Config struct {
+	HostedZoneId string `form:"hostedZoneId" json:"hostedZoneId"`
+	AccessKey    string `form:"accessKey" json:"accessKey"`
+	Secret       string `form:"secret" json:"secret,omitempty"`
+}
+
+// Validate makes ACMERoute53Config validatable by implementing [validation.Validatable] interface.
+func (c ACMERoute53Config) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.HostedZoneId, validation.Required),
+		validation.Field(&c.AccessKey, validation.Required),
+		validation.Field(&c.Secret, validation.Required),
+	)
+}
+
+// ACMERFC2136Config defines the generic RFC2136 (DNS UPDATE) DNS-01 provider settings.
+type ACMERFC2136Config struct {
+	// Nameserver is the authoritative nameserver address (e.g. "ns1.example.com:53").
+	Nameserver string `form:"nameserver" json:"nameserver"`
+
+	// Zone is the DNS zone of the certificate domains (e.g. "example.com").
+	Zone string `form:"zone" json:"zone"`
+
+	// TSIGKey is the optional TSIG key name used to sign the update requests.
+	TSIGKey string `form:"tsigKey" json:"tsigKey"`
+
+	// TSIGSecret is the base64 encoded TSIG key secret.
+	TSIGSecret string `form:"tsigSecret" json:"tsigSecret,omitempty"`
+
+	// TSIGAlgorithm is the TSIG algorithm ("hmac-sha256" or "hmac-sha512", default to "hmac-sha256").
+	TSIGAlgorithm string `form:"tsigAlgorithm" json:"tsigAlgorithm"`
+}
+
+// Validate makes ACMERFC2136Config validatable by implementing [validation.Validatable] interface.
+func (c ACMERFC2136Config) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Nameserver, validation.Required),
+		validation.Field(&c.Zone, validation.Required, is.Domain),
+		validation.Field(&c.TSIGSecret, validation.When(c.TSIGKey != "", validation.Required), is.Base64),
+		validation.Field(&c.TSIGAlgorithm, validation.In("hmac-sha256", "hmac-sha512")),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// DefaultMaintenanceMessage is the default maintenance mode API error message.
+const DefaultMaintenanceMessage = "The service is temporarily unavailable due to maintenance. Please try again later."
+
+// MaintenanceConfig defines the app maintenance mode settings.
+//
+// When enabled, the API responds with 503 to all non-superuser requests
+// except the superusers auth and the health check endpoints.
+type MaintenanceConfig struct {
+	// Message is an optional custom API error message
+	// (default to [DefaultMaintenanceMessage]).
+	Message string `form:"message" json:"message"`
+
+	// RetryAfter is an optional Retry-After response header value in seconds.
+	RetryAfter int `form:"retryAfter" json:"retryAfter"`
+
+	Enabled bool `form:"enabled" json:"enabled"`
+}
+
+// Validate makes MaintenanceConfig validatable by implementing [validation.Validatable] interface.
+func (c MaintenanceConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Message, validation.Length(0, 1000)),
+		validation.Field(&c.RetryAfter, validation.Min(0)),
+	)
+}
+
+// ErrorMessage returns the maintenance API error message.
+func (c MaintenanceConfig) ErrorMessage() string {
+	if c.Message == "" {
+		return DefaultMaintenanceMessage
+	}
+
+	return c.Message
+}
+
+// -------------------------------------------------------------------
+
+type BatchConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// MaxRequests is the maximum allowed batch request to execute.
+	MaxRequests int `form:"maxRequests" json:"maxRequests"`
+
+	// Timeout is the max duration in seconds to wait before cancelling the batch transaction.
+	Timeout int64 `form:"timeout" json:"timeout"`
+
+	// MaxBodySize is the maximum allowed batch request body size in bytes.
+	//
+	// If not set, fallbacks to max ~128MB.
+	MaxBodySize int64 `form:"maxBodySize" json:"maxBodySize"`
+}
+
+// Validate makes BatchConfig validatable by implementing [validation.Validatable] interface.
+func (c BatchConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxRequests, validation.When(c.Enabled, validation.Required), validation.Min(0)),
+		validation.Field(&c.Timeout, validation.When(c.Enabled, validation.Required), validation.Min(0)),
+		validation.Field(&c.MaxBodySize, validation.Min(0)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// GraphQLConfig defines the settings of the collections GraphQL API.
+type GraphQLConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+}
+
+// -------------------------------------------------------------------
+
+const (
+	ResponseCacheBackendMemory = "memory"
+	ResponseCacheBackendRedis  = "redis"
+)
+
+// ResponseCacheConfig defines the settings of the public records
+// list and view API responses cache.
+type ResponseCacheConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Backend is the cache storage backend ("memory" or "redis").
+	Backend string `form:"backend" json:"backend"`
+
+	// RedisURL is the Redis connection url (applicable only for the "redis" backend),
+	// e.g. "redis://localhost:6379/0".
+	RedisURL string `form:"redisURL" json:"redisURL"`
+
+	// RedisPassword is an optional Redis password
+	// (it takes precedence over the one specified in RedisURL).
+	RedisPassword string `form:"redisPassword" json:"redisPassword,omitempty"`
+
+	// TTL is the default cached response lifetime in seconds.
+	TTL int64 `form:"ttl" json:"ttl"`
+
+	// MaxItems is the max number of cached responses for the "memory"
+	// backend (if not set, fallbacks to 10000).
+	MaxItems int `form:"maxItems" json:"maxItems"`
+
+	// Collections is the list of collections whose responses are cached.
+	Collections []ResponseCacheCollection `form:"collections" json:"collections"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c ResponseCacheConfig) MarshalJSON() ([]byte, error) {
+	type alias ResponseCacheConfig
+
+	// serialize as empty array
+	if c.Collections == nil {
+		c.Collections = []ResponseCacheCollection{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes ResponseCacheConfig validatable by implementing [validation.Validatable] interface.
+func (c ResponseCacheConfig) Validate() error {
+	isRedis := c.Backend == ResponseCacheBackendRedis
+
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Backend,
+			validation.When(c.Enabled, validation.Required),
+			validation.In(ResponseCacheBackendMemory, ResponseCacheBackendRedis),
+		),
+		validation.Field(
+			&c.RedisURL,
+			validation.When(c.Enabled && isRedis, validation.Required),
+			validation.Length(0, 1000),
+			validation.By(checkRedisURL),
+		),
+		validation.Field(&c.RedisPassword, validation.Length(0, 255)),
+		validation.Field(&c.TTL, validation.When(c.Enabled, validation.Required), validation.Min(0)),
+		validation.Field(&c.MaxItems, validation.Min(0)),
+		validation.Field(&c.Collections),
+	)
+}
+
+// CollectionTTL returns the cached responses lifetime of the specified collection.
+//
+// Returns false if the collection responses shouldn't be cached.
+func (c ResponseCacheConfig) CollectionTTL(collection *Collection) (time.Duration, bool) {
+	if !c.Enabled || collection == nil {
+		return 0, false
+	}
+
+	for _, item := range c.Collections {
+		if item.Collection != collection.Id && !strings.EqualFold(item.Collection, collection.Name) {
+			continue
+		}
+
+		ttl := item.TTL
+		if ttl <= 0 {
+			ttl = c.TTL
+		}
+
+		if ttl <= 0 {
+			return 0, false
+		}
+
+		return time.Duration(ttl) * time.Second, true
+	}
+
+	return 0, false
+}
+
+func checkRedisURL(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := cache.NewRedis(v); err != nil {
+		return validation.NewError("validation_invalid_redis_url", "Invalid Redis url.")
+	}
+
+	return nil
+}
+
+// ResponseCacheCollection defines the response cache options of a single collection.
+type ResponseCacheCollection struct {
+	// Collection is the name or id of the collection whose list and view responses to cache.
+	Collection string `form:"collection" json:"collection"`
+
+	// TTL is an optional collection specific cached response lifetime in seconds
+	// (if not set, fallbacks to the default ResponseCacheConfig.TTL).
+	TTL int64 `form:"ttl" json:"ttl"`
+}
+
+// Validate makes ResponseCacheCollection validatable by implementing [validation.Validatable] interface.
+func (c ResponseCacheCollection) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Collection, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.TTL, validation.Min(0)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+type BackupsConfig struct {
+	// Cron is a cron expression to schedule auto backups, eg. "* * * * *".
+	//
+	// Leave it empty to disable the auto backups functionality.
+	Cron string `form:"cron" json:"cron"`
+
+	// CronMaxKeep is the max number of cron generated backups to
+	// keep before removing older entries.
+	//
+	// This field works only when the cron config has valid cron expression.
+	//
+	// It could be zero if a Retention policy is configured
+	// (in this case it is the number of the most recent backups to keep
+	// in addition to the Retention ones).
+	CronMaxKeep int `form:"cronMaxKeep" json:"cronMaxKeep"`
+
+	// Retention is an optional policy for keeping the cron generated
+	// backups based on their creation date (see [BaseApp.PruneBackups]).
+	Retention BackupsRetentionConfig `form:"retention" json:"retention"`
+
+	// CronMode specifies the type of the cron generated backups:
+	//  - "full" (default) - every backup contains the full pb_data content
+	//  - "incremental" - stores only the changes since the previous auto backup
+	//  - "differential" - stores only the changes since the last full auto backup
+	//
+	// Note that the incremental and differential backups require their
+	// base backups chain in order to be restored (the chain is preserved
+	// when removing the old auto backups, even if it exceeds CronMaxKeep).
+	CronMode string `form:"cronMode" json:"cronMode"`
+
+	// CronFullEvery is the max number of consecutive incremental or differential
+	// auto backups before creating a new full one (default to 7).
+	CronFullEvery int `form:"cronFullEvery" json:"cronFullEvery"`
+
+	// CronVerify enables the verification of each cron generated backup
+	// right after its creation (see [BaseApp.VerifyBackup]).
+	CronVerify bool `form:"cronVerify" json:"cronVerify"`
+
+	// S3 is an optional S3 storage config specifying where to store the app backups.
+	S3 S3Config `form:"s3" json:"s3"`
+
+	// Encryption is an optional config for encrypting the backup archives
+	// before storing them (useful to prevent the off-site storage provider
+	// from reading the backups content).
+	Encryption BackupsEncryptionConfig `form:"encryption" json:"encryption"`
+
+	// PITR is an optional config for the continuous archiving of the
+	// main database changes, allowing point-in-time recovery
+	// (see [BaseApp.RestorePointInTime]).
+	PITR BackupsPITRConfig `form:"pitr" json:"pitr"`
+}
+
+// Validate makes BackupsConfig validatable by implementing [validation.Validatable] interface.
+func (c BackupsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.S3),
+		validation.Field(&c.Encryption),
+		validation.Field(&c.PITR),
+		validation.Field(&c.Cron, validation.By(checkCronExpression)),
+		validation.Field(&c.Retention),
+		validation.Field(
+			&c.CronMaxKeep,
+			validation.When(c.Cron != "" && !c.Retention.IsEnabled(), validation.Required),
+			validation.Min(1),
+		),
+		validation.Field(
+			&c.CronMode,
+			validation.In(BackupModeFull, BackupModeIncremental, BackupModeDifferential),
+		),
+		validation.Field(&c.CronFullEvery, validation.Min(0), validation.Max(1000)),
+	)
+}
+
+// cronFullEvery returns the configured CronFullEvery or its default value if not set.
+func (c BackupsConfig) cronFullEvery() int {
+	if c.CronFullEvery <= 0 {
+		return DefaultBackupsCronFullEvery
+	}
+	return c.CronFullEvery
+}
+
+// The supported BackupsConfig.CronMode values.
+const (
+	BackupModeFull         = "full"
+	BackupModeIncremental  = "incremental"
+	BackupModeDifferential = "differential"
+)
+
+// DefaultBackupsCronFullEvery is the default max number of consecutive
+// incremental or differential auto backups before creating a new full one.
+const DefaultBackupsCronFullEvery = 7
+
+// BackupsEncryptionConfig defines the backups encryption settings.
+//
+// The backups are encrypted using the age file format
+// (https://age-encryption.org/v1) for the configured Recipient and/or
+// for an X25519 key derived from the app EncryptionEnv secret.
+//
+// The backups encrypted only for the Recipient can't be read by the app
+// (e.g. they cannot be used as incremental backups base or restored)
+// until they are manually decrypted with the Recipient private key, e.g.:
+//
+//	age -d -i key.txt backup.zip > decrypted.zip
+type BackupsEncryptionConfig struct {
+	// Enabled enables the encryption of the newly created backups.
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Recipient is an optional age X25519 public key ("age1...")
+	// to encrypt the backups for.
+	//
+	// If not set, the backups are encrypted only with the key
+	// derived from the app EncryptionEnv secret.
+	Recipient string `form:"recipient" json:"recipient"`
+}
+
+// Validate makes BackupsEncryptionConfig validatable by implementing [validation.Validatable] interface.
+func (c BackupsEncryptionConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Recipient, validation.Length(1, 255), validation.By(checkAgeRecipient)),
+	)
+}
+
+// BackupsRetentionConfig defines the cron generated backups retention policy.
+//
+// Similar to the grandfather-father-son rotation scheme, the newest backup
+// of each of the most recent KeepDaily days, KeepWeekly weeks and KeepMonthly
+// months (counting only the periods with at least one backup) is kept.
+type BackupsRetentionConfig struct {
+	// KeepDaily is the number of daily backups to keep.
+	KeepDaily int `form:"keepDaily" json:"keepDaily"`
+
+	// KeepWeekly is the number of weekly backups to keep.
+	KeepWeekly int `form:"keepWeekly" json:"keepWeekly"`
+
+	// KeepMonthly is the number of monthly backups to keep.
+	KeepMonthly int `form:"keepMonthly" json:"keepMonthly"`
+}
+
+// IsEnabled reports whether at least one of the retention periods is configured.
+func (c BackupsRetentionConfig) IsEnabled() bool {
+	return c.KeepDaily > 0 || c.KeepWeekly > 0 || c.KeepMonthly > 0
+}
+
+// Validate makes BackupsRetentionConfig validatable by implementing [validation.Validatable] interface.
+func (c BackupsRetentionConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.KeepDaily, validation.Min(0), validation.Max(1000)),
+		validation.Field(&c.KeepWeekly, validation.Min(0), validation.Max(1000)),
+		validation.Field(&c.KeepMonthly, validation.Min(0), validation.Max(1000)),
+	)
+}
+
+// BackupsPITRConfig defines the point-in-time recovery settings.
+//
+// When enabled, on every Interval the main database WAL is checkpointed
+// and the changed data.db blocks since the previous segment (or since the
+// latest backup) are archived in the backups filesystem as a "@pitr_*.zip"
+// incremental backup.
+//
+// Note that the segments contain only the data.db changes
+// (the auxiliary.db and the uploaded files are restored in the state
+// of the base backup) and that they are not removed automatically.
+type BackupsPITRConfig struct {
+	// Enabled enables the continuous archiving while the app is serving.
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Interval is the archiving interval in seconds (default to 60).
+	Interval int `form:"interval" json:"interval"`
+
+	// MaxDays is the number of days to keep the archived segments (default to 7).
+	//
+	// The segments are removed per chain (aka. all segments created after
+	// the same backup) once the newest segment of the chain is older than MaxDays.
+	MaxDays int `form:"maxDays" json:"maxDays"`
+}
+
+// Validate makes BackupsPITRConfig validatable by implementing [validation.Validatable] interface.
+func (c BackupsPITRConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Interval, validation.Min(0), validation.Max(86400)),
+		validation.Field(&c.MaxDays, validation.Min(0)),
+	)
+}
+
+// maxDays returns the configured segments MaxDays or its default value if not set.
+func (c BackupsPITRConfig) maxDays() int {
+	if c.MaxDays <= 0 {
+		return DefaultBackupsPITRMaxDays
+	}
+	return c.MaxDays
+}
+
+// interval returns the configured archiving Interval or its default value if not set.
+func (c BackupsPITRConfig) interval() time.Duration {
+	if c.Interval <= 0 {
+		return DefaultBackupsPITRInterval
+	}
+	return time.Duration(c.Interval) * time.Second
+}
+
+// DefaultBackupsPITRInterval is the default point-in-time recovery archiving interval.
+const DefaultBackupsPITRInterval = 60 * time.Second
+
+// DefaultBackupsPITRMaxDays is the default number of days to keep the point-in-time recovery segments.
+const DefaultBackupsPITRMaxDays = 7
+
+func checkAgeRecipient(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := age.ParseX25519Recipient(v); err != nil {
+		return validation.NewError("validation_invalid_age_recipient", "Must be a valid age X25519 public key (age1...).")
+	}
+
+	return nil
+}
+
+func checkCronExpression(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	_, err := cron.NewSchedule(v)
+	if err != nil {
+		return validation.NewError("validation_invalid_cron", err.Error())
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+type MetaConfig struct {
+	AppName       string `form:"appName" json:"appName"`
+	AppURL        string `form:"appURL" json:"appURL"`
+	SenderName    string `form:"senderName" json:"senderName"`
+	SenderAddress string `form:"senderAddress" json:"senderAddress"`
+	HideControls  bool   `form:"hideControls" json:"hideControls"`
+}
+
+// Validate makes MetaConfig validatable by implementing [validation.Validatable] interface.
+func (c MetaConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.AppName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.AppURL, validation.Required, is.URL),
+		validation.Field(&c.SenderName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.SenderAddress, is.EmailFormat, validation.Required),
+	)
+}
+
+// -------------------------------------------------------------------
+
+type LogsConfig struct {
+	MaxDays   int  `form:"maxDays" json:"maxDays"`
+	MinLevel  int  `form:"minLevel" json:"minLevel"`
+	LogIP     bool `form:"logIP" json:"logIP"`
+	LogAuthId bool `form:"logAuthId" json:"logAuthId"`
+
+	// BatchSize specifies how many logs to accumulate before
+	// writing them to the logs db and sinks (default to 200).
+	BatchSize int `form:"batchSize" json:"batchSize"`
+
+	// FlushInterval specifies the max seconds the accumulated logs
+	// could wait before their write (default to 3).
+	FlushInterval int `form:"flushInterval" json:"flushInterval"`
+
+	// MaxBuffered specifies the max number of logs that could be
+	// accumulated while a previous batch write is still in progress.
+	//
+	// Once reached, the new logs are discarded until the queue is written.
+	// Set it to 0 for unbounded queue.
+	MaxBuffered int `form:"maxBuffered" json:"maxBuffered"`
+
+	// Sinks is an optional list of external destinations where
+	// the app logs will be shipped in addition to the logs db.
+	Sinks []LogSinkConfig `form:"sinks" json:"sinks"`
+
+	// Enrichment specifies the extra request attributes
+	// to attach to the request-scoped logs.
+	Enrichment LogsEnrichmentConfig `form:"enrichment" json:"enrichment"`
+
+	// Redaction specifies the rules to mask sensitive information
+	// in the logs before their persistence or export.
+	Redaction LogsRedactionConfig `form:"redaction" json:"redaction"`
+
+	// ExcludedLevels is an optional list of log levels
+	// that will be neither persisted nor exported.
+	ExcludedLevels []int `form:"excludedLevels" json:"excludedLevels"`
+
+	// Sampling is an optional list of request logs sampling rules
+	// (the first matching rule is applied).
+	//
+	// Could be used to reduce or fully exclude the logs of noisy routes,
+	// e.g. keep only 10% of the successful "GET /api/files/" requests.
+	Sampling []LogsSamplingRule `form:"sampling" json:"sampling"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c LogsConfig) MarshalJSON() ([]byte, error) {
+	type alias LogsConfig
+
+	// serialize as empty array
+	if c.Sinks == nil {
+		c.Sinks = []LogSinkConfig{}
+	}
+	if c.ExcludedLevels == nil {
+		c.ExcludedLevels = []int{}
+	}
+	if c.Sampling == nil {
+		c.Sampling = []LogsSamplingRule{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes LogsConfig validatable by implementing [validation.Validatable] interface.
+func (c LogsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxDays, validation.Min(0)),
+		validation.Field(&c.BatchSize, validation.Min(0), validation.Max(10000)),
+		validation.Field(&c.FlushInterval, validation.Min(0), validation.Max(3600)),
+		validation.Field(
+			&c.MaxBuffered,
+			validation.Min(0),
+			validation.When(c.MaxBuffered > 0, validation.Min(c.batchSize())),
+		),
+		validation.Field(&c.Sinks),
+		validation.Field(&c.Enrichment),
+		validation.Field(&c.Redaction),
+		validation.Field(&c.Sampling),
+	)
+}
+
+// RequestSampleRate returns the sample rate of the first
+// [LogsConfig.Sampling] rule matching the specified request data.
+//
+// Returns 1 if there is no matching rule (aka. keep all logs).
+func (c LogsConfig) RequestSampleRate(method string, path string, status int) float64 {
+	for _, rule := range c.Sampling {
+		if rule.Match(method, path, status) {
+			return rule.Rate
+		}
+	}
+
+	return 1
+}
+
+// batchSize returns the configured logs batch size or its default value if not set.
+func (c LogsConfig) batchSize() int {
+	if c.BatchSize <= 0 {
+		return DefaultLogsBatchSize
+	}
+	return c.BatchSize
+}
+
+// flushInterval returns the configured logs flush interval or its default value if not set.
+func (c LogsConfig) flushInterval() time.Duration {
+	if c.FlushInterval <= 0 {
+		return DefaultLogsFlushInterval * time.Second
+	}
+	return time.Duration(c.FlushInterval) * time.Second
+}
+
+// Default logs batching settings.
+const (
+	DefaultLogsBatchSize     = 200
+	DefaultLogsFlushInterval = 3 // seconds
+	DefaultLogsMaxBuffered   = 10000
+)
+
+// LogsEnrichmentConfig defines the request logs enrichment settings.
+type LogsEnrichmentConfig struct {
+	// RequestId enables attaching a unique "requestId" to the request-scoped logs.
+	//
+	// The id is taken from the "X-Request-Id" request header (if valid)
+	// or otherwise it is generated and returned as response header.
+	RequestId bool `form:"requestId" json:"requestId"`
+
+	// TenantHeader is an optional request header name whose value
+	// will be attached as "tenant" to the request-scoped logs.
+	TenantHeader string `form:"tenantHeader" json:"tenantHeader"`
+}
+
+// Validate makes LogsEnrichmentConfig validatable by implementing [validation.Validatable] interface.
+func (c LogsEnrichmentConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.TenantHeader, validation.Length(0, 100), validation.Match(headerNameRegex)),
+	)
+}
+
+var headerNameRegex = regexp.MustCompile(`^[\w\-]+$`)
+
+// LogsRedactionConfig defines the logs redaction settings.
+type LogsRedactionConfig struct {
+	// Tokens enables masking of JWTs and "Bearer" authorization values.
+	Tokens bool `form:"tokens" json:"tokens"`
+
+	// Emails enables masking of email addresses.
+	Emails bool `form:"emails" json:"emails"`
+
+	// Fields is a list of log data keys (case-insensitive)
+	// whose values will be fully masked (e.g. "password").
+	Fields []string `form:"fields" json:"fields"`
+
+	// Patterns is a list of custom regular expressions whose
+	// matches will be masked in the log message and data.
+	Patterns []string `form:"patterns" json:"patterns"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c LogsRedactionConfig) MarshalJSON() ([]byte, error) {
+	type alias LogsRedactionConfig
+
+	// serialize as empty arrays
+	if c.Fields == nil {
+		c.Fields = []string{}
+	}
+	if c.Patterns == nil {
+		c.Patterns = []string{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes LogsRedactionConfig validatable by implementing [validation.Validatable] interface.
+func (c LogsRedactionConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Fields, validation.Each(validation.Required, validation.Length(1, 255))),
+		validation.Field(&c.Patterns, validation.Each(validation.Required, validation.By(validators.IsRegex))),
+	)
+}
+
+// Redactor creates a new [logger.Redactor] based on the current redaction settings.
+//
+// The invalid custom patterns are ignored.
+func (c LogsRedactionConfig) Redactor() *logger.Redactor {
+	r := &logger.Redactor{
+		Keys: slices.Clone(c.Fields),
+	}
+
+	if c.Tokens {
+		r.Patterns = append(r.Patterns, logger.RedactTokensPattern)
+	}
+
+	if c.Emails {
+		r.Patterns = append(r.Patterns, logger.RedactEmailsPattern)
+	}
+
+	for _, p := range c.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			continue
+		}
+		r.Patterns = append(r.Patterns, re)
+	}
+
+	return r
+}
+
+// LogsSamplingRule defines a single request logs sampling rule.
+type LogsSamplingRule struct {
+	// Path is the request path to match, optionally prefixed with the request method.
+	//
+	// It is matched as prefix if it ends with "/" or "*", for example:
+	//  - "/api/health" (exact match for all methods)
+	//  - "/api/files/" (all requests starting with "/api/files/")
+	//  - "GET /api/files/*" (only the GET requests starting with "/api/files/")
+	Path string `form:"path" json:"path"`
+
+	// Status is an optional response status code to match.
+	//
+	// It could be either an exact code (e.g. "404")
+	// or a status class (e.g. "2xx").
+	Status string `form:"status" json:"status"`
+
+	// Rate is the fraction of the matching request logs to keep (0-1).
+	//
+	// Set it to 0 to exclude all matching request logs.
+	Rate float64 `form:"rate" json:"rate"`
+}
+
+// Validate makes LogsSamplingRule validatable by implementing [validation.Validatable] interface.
+func (c LogsSamplingRule) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Path, validation.Required, validation.Match(logsSamplingPathRegex)),
+		validation.Field(&c.Status, validation.Match(logsSamplingStatusRegex)),
+		validation.Field(&c.Rate, validation.Min(0.0), validation.Max(1.0)),
+	)
+}
+
+// Match checks whether the rule matches the provided request data.
+func (c LogsSamplingRule) Match(method string, path string, status int) bool {
+	rulePath := c.Path
+
+	if ruleMethod, p, ok := strings.Cut(rulePath, " "); ok {
+		if !strings.EqualFold(ruleMethod, method) {
+			return false
+		}
+		rulePath = p
+	}
+
+	if prefix, ok := strings.CutSuffix(rulePath, "*"); ok {
+		if !strings.HasPrefix(path, prefix) {
+			return false
+		}
+	} else if strings.HasSuffix(rulePath, "/") {
+		if !strings.HasPrefix(path, rulePath) {
+			return false
+		}
+	} else if path != rulePath {
+		return false
+	}
+
+	if c.Status == "" {
+		return true
+	}
+
+	statusStr := strconv.Itoa(status)
+	if len(statusStr) != 3 {
+		return false
+	}
+
+	if strings.HasSuffix(c.Status, "xx") {
+		return c.Status[0] == statusStr[0]
+	}
+
+	return c.Status == statusStr
+}
+
+var (
+	logsSamplingPathRegex   = regexp.MustCompile(`^(\w+\ )?\/[\w\/\-\.]*\*?$`)
+	logsSamplingStatusRegex = regexp.MustCompile(`^[1-5](xx|\d\d)$`)
+)
+
+// Supported log sink types.
+const (
+	LogSinkTypeLoki    = "loki"
+	LogSinkTypeOTLP    = "otlp"
+	LogSinkTypeSyslog  = "syslog"
+	LogSinkTypeWebhook = "webhook"
+)
+
+var syslogURLRegex = regexp.MustCompile(`^(udp|tcp|unix|unixgram)://.+`)
+
+// LogSinkConfig defines a single external logs destination.
+type LogSinkConfig struct {
+	// Type is the sink type ("loki", "otlp", "syslog" or "webhook").
+	Type string `form:"type" json:"type"`
+
+	// URL is the sink endpoint.
+	//
+	// For the "syslog" type the url scheme specifies the network,
+	// e.g. "udp://localhost:514", "tcp://localhost:514", "unix:///dev/log".
+	URL string `form:"url" json:"url"`
+
+	// Token is an optional bearer token used for authorization (not applicable for "syslog").
+	Token string `form:"token" json:"token,omitempty"`
+
+	// MinLevel is the minimum level of the logs to ship.
+	//
+	// Note that the logs are first filtered by the global [LogsConfig.MinLevel] setting.
+	MinLevel int `form:"minLevel" json:"minLevel"`
+
+	// QueueSize specifies how many logs batches could wait to be shipped
+	// before starting to drop the new ones (default to 100).
+	QueueSize int `form:"queueSize" json:"queueSize"`
+}
+
+// Validate makes LogSinkConfig validatable by implementing [validation.Validatable] interface.
+func (c LogSinkConfig) Validate() error {
+	isSyslog := c.Type == LogSinkTypeSyslog
+
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Type,
+			validation.Required,
+			validation.In(LogSinkTypeLoki, LogSinkTypeOTLP, LogSinkTypeSyslog, LogSinkTypeWebhook),
+		),
+		validation.Field(
+			&c.URL,
+			validation.Required,
+			validation.When(!isSyslog, is.URL),
+			validation.When(isSyslog, validation.Match(syslogURLRegex).Error("Must be a udp://, tcp://, unix:// or unixgram:// address.")),
+		),
+		validation.Field(&c.Token, validation.When(isSyslog, validation.Empty)),
+		validation.Field(&c.QueueSize, validation.Min(0), validation.Max(10000)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// ProfilingConfig defines the settings of the superusers only
+// runtime profiling endpoints (/api/debug/pprof/*).
+type ProfilingConfig struct {
+	// Enabled enables the net/http/pprof endpoints and the runtime trace capture.
+	//
+	// Note that collecting profiles has a performance overhead so it
+	// is recommended to keep it enabled only while debugging.
+	Enabled bool `form:"enabled" json:"enabled"`
+}
+
+// -------------------------------------------------------------------
+
+// PanicReportingConfig defines the settings for forwarding the
+// recovered panics to Sentry (or Sentry compatible service).
+type PanicReportingConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// DSN is the Sentry project Data Source Name
+	// (e.g. "https://public_key@o0.ingest.sentry.io/123").
+	DSN string `form:"dsn" json:"dsn,omitempty"`
+
+	// Environment is an optional environment name to attach
+	// to the reported events (e.g. "production").
+	Environment string `form:"environment" json:"environment"`
+}
+
+// Validate makes PanicReportingConfig validatable by implementing [validation.Validatable] interface.
+func (c PanicReportingConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.DSN, validation.When(c.Enabled, validation.Required), validation.By(checkSentryDSN)),
+		validation.Field(&c.Environment, validation.Length(0, 64)),
+	)
+}
+
+func checkSentryDSN(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := sentry.ParseDSN(v); err != nil {
+		return validation.NewError("validation_invalid_sentry_dsn", "Invalid Sentry DSN.")
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+type TrustedProxyConfig struct {
+	// Headers is a list of explicit trusted header(s) to check.
+	Headers []string `form:"headers" json:"headers"`
+
+	// CIDRs is an optional list of the trusted proxies IPs or CIDR ranges
+	// (e.g. "10.0.0.0/8", "127.0.0.1").
+	//
+	// If set, the trusted Headers are checked only for requests
+	// coming directly from one of the listed proxies and the proxies
+	// IPs are skipped when extracting the client IP from the headers.
+	CIDRs []string `form:"cidrs" json:"cidrs"`
+
+	// UseLeftmostIP specifies to use the left-mostish IP from the trusted headers.
+	//
+	// Note that this could be insecure when used with X-Forwarded-For header
+	// because some proxies like AWS ELB allow users to prepend their own header value
+	// before appending the trusted ones.
+	UseLeftmostIP bool `form:"useLeftmostIP" json:"useLeftmostIP"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c TrustedProxyConfig) MarshalJSON() ([]byte, error) {
+	type alias TrustedProxyConfig
+
+	// serialize as empty array
+	if c.Headers == nil {
+		c.Headers = []string{}
+	}
+	if c.CIDRs == nil {
+		c.CIDRs = []string{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes RateLimitRule validatable by implementing [validation.Validatable] interface.
+func (c TrustedProxyConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.CIDRs, validation.Each(validation.By(checkIPOrCIDR))),
+	)
+}
+
+// IsTrusted reports whether the provided ip matches one of the trusted proxies CIDRs.
+func (c TrustedProxyConfig) IsTrusted(ip string) bool {
+	return MatchIPOrCIDR(c.CIDRs, ip)
+}
+
+// -------------------------------------------------------------------
+
+// IPFilterConfig defines the global and per-route client IP allow/deny lists.
+//
+// The client IP is resolved with [RequestEvent.RealIP] so make sure that
+// the TrustedProxy settings are properly configured when behind a reverse proxy.
+//
+// The deny lists have precedence over the allow lists and
+// an empty allow list means that all IPs are allowed.
+//
+// Note that the IP filter applies also for the superusers settings API
+// so in case of lockout use the "settings" command to update the filter.
+type IPFilterConfig struct {
+	// Allow is the global list of allowed IPs or CIDR ranges.
+	Allow []string `form:"allow" json:"allow"`
+
+	// Deny is the global list of denied IPs or CIDR ranges.
+	Deny []string `form:"deny" json:"deny"`
+
+	// SuperusersAllow is a list of allowed IPs or CIDR ranges for the
+	// superuser authenticated requests, the superusers auth endpoints
+	// and the dashboard (e.g. to lock the admin access to a VPN range).
+	SuperusersAllow []string `form:"superusersAllow" json:"superusersAllow"`
+
+	// Rules is a list of additional per-route allow/deny lists.
+	Rules []IPFilterRule `form:"rules" json:"rules"`
+
+	Enabled bool `form:"enabled" json:"enabled"`
+}
+
+// Validate makes IPFilterConfig validatable by implementing [validation.Validatable] interface.
+func (c IPFilterConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Allow, validation.Each(validation.By(checkIPOrCIDR))),
+		validation.Field(&c.Deny, validation.Each(validation.By(checkIPOrCIDR))),
+		validation.Field(&c.SuperusersAllow, validation.Each(validation.By(checkIPOrCIDR))),
+		validation.Field(&c.Rules),
+	)
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c IPFilterConfig) MarshalJSON() ([]byte, error) {
+	type alias IPFilterConfig
+
+	// serialize as empty arrays
+	if c.Allow == nil {
+		c.Allow = []string{}
+	}
+	if c.Deny == nil {
+		c.Deny = []string{}
+	}
+	if c.SuperusersAllow == nil {
+		c.SuperusersAllow = []string{}
+	}
+	if c.Rules == nil {
+		c.Rules = []IPFilterRule{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// IsAllowed reports whether the ip is allowed by the global allow/deny lists
+// and the first IPFilterRule matching one of the provided labels
+// (see [IPFilterConfig.FindIPFilterRule]).
+//
+// It always returns true if the filter is not enabled.
+func (c *IPFilterConfig) IsAllowed(ip string, searchLabels ...string) bool {
+	if !c.Enabled {
+		return true
+	}
+
+	if !isIPAllowed(c.Allow, c.Deny, ip) {
+		return false
+	}
+
+	if rule, ok := c.FindIPFilterRule(searchLabels); ok {
+		return isIPAllowed(rule.Allow, rule.Deny, ip)
+	}
+
+	return true
+}
+
+// FindIPFilterRule returns the first rule matching one of the provided labels
+// (with the same label format and precedence as [RateLimitsConfig.FindRateLimitRule]).
+func (c *IPFilterConfig) FindIPFilterRule(searchLabels []string) (IPFilterRule, bool) {
+	var prefixRules []int
+
+	for i, label := range searchLabels {
+		// check for direct match
+		for j := range c.Rules {
+			if label == c.Rules[j].Label {
+				return c.Rules[j], true
+			}
+
+			if i == 0 && strings.HasSuffix(c.Rules[j].Label, "/") {
+				prefixRules = append(prefixRules, j)
+			}
+		}
+
+		// check for prefix match
+		for _, j := range prefixRules {
+			if strings.HasPrefix(label+"/", c.Rules[j].Label) {
+				return c.Rules[j], true
+			}
+		}
+	}
+
+	return IPFilterRule{}, false
+}
+
+func isIPAllowed(allow []string, deny []string, ip string) bool {
+	if MatchIPOrCIDR(deny, ip) {
+		return false
+	}
+
+	return len(allow) == 0 || MatchIPOrCIDR(allow, ip)
+}
+
+var ipFilterRuleLabelRegex = regexp.MustCompile(`^(\w+\ \/[\w\/\.-]*|\/[\w\/\.-]*)$`)
+
+// IPFilterRule defines a single route IP allow/deny rule.
+type IPFilterRule struct {
+	// Label is the route path or path prefix (when ends with `/`)
+	// optionally prefixed with the request method.
+	//
+	// Example supported labels:
+	//   - /api/settings
+	//   - /api/collections/
+	//   - POST /api/collections/
+	Label string `form:"label" json:"label"`
+
+	// Allow is the list of the allowed IPs or CIDR ranges for the route
+	// (all IPs if empty).
+	Allow []string `form:"allow" json:"allow"`
+
+	// Deny is the list of the denied IPs or CIDR ranges for the route.
+	Deny []string `form:"deny" json:"deny"`
+}
+
+// Validate makes IPFilterRule validatable by implementing [validation.Validatable] interface.
+func (c IPFilterRule) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Label, validation.Required, validation.Match(ipFilterRuleLabelRegex)),
+		validation.Field(&c.Allow, validation.Each(validation.By(checkIPOrCIDR))),
+		validation.Field(&c.Deny, validation.Each(validation.By(checkIPOrCIDR))),
+	)
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c IPFilterRule) MarshalJSON() ([]byte, error) {
+	type alias IPFilterRule
+
+	// serialize as empty arrays
+	if c.Allow == nil {
+		c.Allow = []string{}
+	}
+	if c.Deny == nil {
+		c.Deny = []string{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// MatchIPOrCIDR reports whether ip matches one of the list IP or CIDR range items.
+//
+// Invalid list items and ip are ignored.
+func MatchIPOrCIDR(list []string, ip string) bool {
+	if len(list) == 0 {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, item := range list {
+		if prefix, err := parseIPOrCIDR(item); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseIPOrCIDR(str string) (netip.Prefix, error) {
+	str = strings.TrimSpace(str)
+
+	if strings.Contains(str, "/") {
+		prefix, err := netip.ParsePrefix(str)
+		if err != nil {
+			return prefix, err
+		}
+
+		if prefix.Addr().Is4In6() {
+			return netip.PrefixFrom(prefix.Addr().Unmap(), max(prefix.Bits()-96, 0)), nil
+		}
+
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(str)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func checkIPOrCIDR(value any) error {
+	v, _ := value.(string)
+
+	if _, err := parseIPOrCIDR(v); err != nil {
+		return validation.NewError("validation_invalid_ip_or_cidr", "Must be a valid IP address or CIDR range.")
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+type RateLimitsConfig struct {
+	Rules   []RateLimitRule `form:"rules" json:"rules"`
+	Enabled bool            `form:"enabled" json:"enabled"`
+}
+
+// FindRateLimitRule returns the first matching rule based on the provided labels.
+//
+// Optionally you can further specify a list of valid RateLimitRule.Audience values to further filter the matching rule
+// (aka. the rule Audience will have to exist in one of the specified options).
+func (c *RateLimitsConfig) FindRateLimitRule(searchLabels []string, optOnlyAudience ...string) (RateLimitRule, bool) {
+	var prefixRules []int
+
+	for i, label := range searchLabels {
+		// check for direct match
+		for j := range c.Rules {
+			if label == c.Rules[j].Label &&
+				(len(optOnlyAudience) == 0 || slices.Contains(optOnlyAudience, c.Rules[j].Audience)) {
+				return c.Rules[j], true
+			}
+
+			if i == 0 && strings.HasSuffix(c.Rules[j].Label, "/") {
+				prefixRules = append(prefixRules, j)
+			}
+		}
+
+		// check for prefix match
+		if len(prefixRules) > 0 {
+			for j := range prefixRules {
+				if strings.HasPrefix(label+"/", c.Rules[prefixRules[j]].Label) &&
+					(len(optOnlyAudience) == 0 || slices.Contains(optOnlyAudience, c.Rules[prefixRules[j]].Audience)) {
+					return c.Rules[prefixRules[j]], true
+				}
+			}
+		}
+	}
+
+	return RateLimitRule{}, false
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c RateLimitsConfig) MarshalJSON() ([]byte, error) {
+	type alias RateLimitsConfig
+
+	// serialize as empty array
+	if c.Rules == nil {
+		c.Rules = []RateLimitRule{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes RateLimitsConfig validatable by implementing [validation.Validatable] interface.
+func (c RateLimitsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Rules,
+			validation.When(c.Enabled, validation.Required),
+			validation.By(checkUniqueRuleLabel),
+		),
+	)
+}
+
+func checkUniqueRuleLabel(value any) error {
+	rules, ok := value.([]RateLimitRule)
+	if !ok {
+		return validators.ErrUnsupportedValueType
+	}
+
+	existing := make([]string, 0, len(rules))
+
+	for i, rule := range rules {
+		fullKey := rule.Label + "@@" + rule.Audience
+
+		var conflicts bool
+		for _, key := range existing {
+			if strings.HasPrefix(key, fullKey) || strings.HasPrefix(fullKey, key) {
+				conflicts = true
+				break
+			}
+		}
+
+		if conflicts {
+			return validation.Errors{
+				strconv.Itoa(i): validation.Errors{
+					"label": validation.NewError("validation_conflicting_rate_limit_rule", "Rate limit rule configuration with label {{.label}} already exists or conflicts with another rule.").
+						SetParams(map[string]any{"label": rule.Label}),
+				},
+			}
+		} else {
+			existing = append(existing, fullKey)
+		}
+	}
 
 	return nil
 }
@@ -714,3 +2298,228 @@ func (c RateLimitRule) String() string {
 
 	return string(raw)
 }
+
+// -------------------------------------------------------------------
+
+// The allowed RouteRule.Type values
+const (
+	RouteRuleTypeRedirect = "redirect"
+	RouteRuleTypeRewrite  = "rewrite"
+	RouteRuleTypeProxy    = "proxy"
+)
+
+// RoutesConfig defines the settings of the custom redirect,
+// rewrite and reverse proxy routes.
+type RoutesConfig struct {
+	Rules   []RouteRule `form:"rules" json:"rules"`
+	Enabled bool        `form:"enabled" json:"enabled"`
+}
+
+// FindRouteRule returns the first enabled rule matching the specified
+// request path and its resolved target (see [RouteRule.Match]).
+//
+// Optionally you can specify a list of valid RouteRule.Type values
+// to further filter the matching rule.
+func (c *RoutesConfig) FindRouteRule(path string, optOnlyTypes ...string) (RouteRule, string, bool) {
+	if !c.Enabled {
+		return RouteRule{}, "", false
+	}
+
+	for _, rule := range c.Rules {
+		if len(optOnlyTypes) > 0 && !slices.Contains(optOnlyTypes, rule.Type) {
+			continue
+		}
+
+		if target, ok := rule.Match(path); ok {
+			return rule, target, true
+		}
+	}
+
+	return RouteRule{}, "", false
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c RoutesConfig) MarshalJSON() ([]byte, error) {
+	type alias RoutesConfig
+
+	// serialize as empty array
+	if c.Rules == nil {
+		c.Rules = []RouteRule{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes RoutesConfig validatable by implementing [validation.Validatable] interface.
+func (c RoutesConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Rules, validation.When(c.Enabled, validation.Required)),
+	)
+}
+
+var routeRulePathRegex = regexp.MustCompile(`^(/|(/[\w\-\.~%@]+)+/?(/\*)?)$`)
+
+// reservedRoutePaths are the path prefixes that cannot be overwritten
+// by a custom route rule (to prevent locking out the dashboard and the APIs).
+var reservedRoutePaths = []string{"/api", "/_"}
+
+type RouteRule struct {
+	// Type specifies the route action:
+	//   - "redirect" - responds with a redirect to the Target url
+	//   - "rewrite"  - internally handles the request as if it was sent to the Target path
+	//   - "proxy"    - forwards the request to the Target url and returns its response
+	Type string `form:"type" json:"type"`
+
+	// Path is the request url path to match.
+	//
+	// It could be an exact path or a path prefix when ends with "/*",
+	// e.g. "/docs/*" matches "/docs", "/docs/" and "/docs/a/b".
+	Path string `form:"path" json:"path"`
+
+	// Target is the redirect url, the rewrite path or the proxy url.
+	//
+	// The "*" character in the Target is replaced with the remaining
+	// part of the request path matched by the Path wildcard, e.g.
+	// Path "/docs/*" with Target "https://example.com/*" will redirect
+	// "/docs/a/b" to "https://example.com/a/b".
+	Target string `form:"target" json:"target"`
+
+	// Status is the redirect response status code
+	// (applicable only for the "redirect" type; default to 302).
+	Status int `form:"status" json:"status"`
+
+	// ForwardAuth specifies whether to forward the client Authorization
+	// and Cookie headers to the Target url (applicable only for the "proxy" type).
+	//
+	// By default they are removed to prevent leaking the app auth tokens to third-party services.
+	ForwardAuth bool `form:"forwardAuth" json:"forwardAuth"`
+}
+
+// Validate makes RouteRule validatable by implementing [validation.Validatable] interface.
+func (c RouteRule) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Type,
+			validation.Required,
+			validation.In(RouteRuleTypeRedirect, RouteRuleTypeRewrite, RouteRuleTypeProxy),
+		),
+		validation.Field(
+			&c.Path,
+			validation.Required,
+			validation.Length(1, 255),
+			validation.Match(routeRulePathRegex),
+			validation.By(checkReservedRoutePath),
+		),
+		validation.Field(
+			&c.Target,
+			validation.Required,
+			validation.Length(1, 1000),
+			validation.By(c.checkTarget),
+		),
+		validation.Field(
+			&c.Status,
+			validation.When(c.Type != RouteRuleTypeRedirect, validation.Empty),
+			validation.In(
+				http.StatusMovedPermanently,
+				http.StatusFound,
+				http.StatusSeeOther,
+				http.StatusTemporaryRedirect,
+				http.StatusPermanentRedirect,
+			),
+		),
+		validation.Field(
+			&c.ForwardAuth,
+			validation.When(c.Type != RouteRuleTypeProxy, validation.Empty),
+		),
+	)
+}
+
+// Match checks whether the specified request path matches the rule Path
+// and returns the resolved Target (with replaced wildcard, if any).
+func (c RouteRule) Match(path string) (string, bool) {
+	prefix, isWildcard := strings.CutSuffix(c.Path, "/*")
+	if !isWildcard {
+		if path != c.Path {
+			return "", false
+		}
+
+		return strings.Replace(c.Target, "*", "", 1), true
+	}
+
+	if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+		return "", false
+	}
+
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, prefix), "/")
+
+	return strings.Replace(c.Target, "*", rest, 1), true
+}
+
+func (c RouteRule) checkTarget(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	isPath := strings.HasPrefix(v, "/") && !strings.HasPrefix(v, "//")
+
+	switch c.Type {
+	case RouteRuleTypeRewrite:
+		if !isPath {
+			return validation.NewError("validation_invalid_route_target", "The rewrite target must be a path starting with /.")
+		}
+	case RouteRuleTypeProxy:
+		u, err := url.Parse(strings.Replace(v, "*", "", 1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return validation.NewError("validation_invalid_route_target", "The proxy target must be a valid http(s) url.")
+		}
+	default:
+		if isPath {
+			return nil
+		}
+		if err := is.URL.Validate(strings.Replace(v, "*", "", 1)); err != nil {
+			return validation.NewError("validation_invalid_route_target", "The redirect target must be a valid url or a path starting with /.")
+		}
+	}
+
+	return nil
+}
+
+func checkReservedRoutePath(value any) error {
+	v, _ := value.(string)
+
+	prefix := strings.TrimSuffix(v, "/*")
+
+	for _, reserved := range reservedRoutePaths {
+		if prefix == reserved || strings.HasPrefix(prefix, reserved+"/") {
+			return validation.NewError("validation_reserved_route_path", "The {{.path}} routes cannot be overwritten.").
+				SetParams(map[string]any{"path": reserved + "/"})
+		}
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// PluginsConfig defines the persisted state of the registered app plugins
+// (see [App.RegisterPlugin]).
+//
+// The plugins settings sections are stored as settings extensions
+// (see [PluginWithSettings]).
+type PluginsConfig struct {
+	// Disabled is a list with the names of the disabled plugins.
+	Disabled []string `form:"disabled" json:"disabled"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c PluginsConfig) MarshalJSON() ([]byte, error) {
+	type alias PluginsConfig // prevent recursion
+
+	// ensure that it is always serialized as array
+	if c.Disabled == nil {
+		c.Disabled = []string{}
+	}
+
+	return json.Marshal(alias(c))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/logger"
 	"github.com/pocketbase/pocketbase/tools/mailer"
 )
 
@@ -97,8 +98,22 @@ func TestSettingsMarshalJSON(t *testing.T) {
 	// secrets
 	testSecret := "test_secret"
 	settings.SMTP.Password = testSecret
+	settings.Mailer.APIKey = testSecret
+	settings.Mailer.Secret = testSecret
+	settings.Mailer.InboundToken = testSecret
 	settings.S3.Secret = testSecret
 	settings.Backups.S3.Secret = testSecret
+	settings.SMS.Secret = testSecret
+	settings.Captcha.Secret = testSecret
+	settings.Push.FCM.ServiceAccount = testSecret
+	settings.Push.APNs.PrivateKey = testSecret
+	settings.Push.WebPush.PrivateKey = testSecret
+	settings.Logs.Sinks = []core.LogSinkConfig{{Type: core.LogSinkTypeWebhook, URL: "https://example.com", Token: testSecret}}
+	settings.PanicReporting.DSN = testSecret
+	settings.ResponseCache.RedisPassword = testSecret
+	settings.ACME.Cloudflare.APIToken = testSecret
+	settings.ACME.Route53.Secret = testSecret
+	settings.ACME.RFC2136.TSIGSecret = testSecret
 
 	raw, err := json.Marshal(settings)
 	if err != nil {
@@ -106,11 +121,16 @@ func TestSettingsMarshalJSON(t *testing.T) {
 	}
 	rawStr := string(raw)
 
-	expected := `{"smtp":{"enabled":false,"port":0,"host":"","username":"abc","authMethod":"","tls":false,"localName":""},"backups":{"cron":"","cronMaxKeep":0,"s3":{"enabled":false,"bucket":"","region":"","endpoint":"","accessKey":"","forcePathStyle":false}},"s3":{"enabled":false,"bucket":"","region":"","endpoint":"","accessKey":"","forcePathStyle":false},"meta":{"appName":"test123","appURL":"","senderName":"","senderAddress":"","hideControls":false},"rateLimits":{"rules":[],"enabled":false},"trustedProxy":{"headers":[],"useLeftmostIP":false},"batch":{"enabled":false,"maxRequests":0,"timeout":0,"maxBodySize":0},"logs":{"maxDays":0,"minLevel":0,"logIP":false,"logAuthId":false}}`
+	expected := `{"smtp":{"enabled":false,"port":0,"host":"","username":"abc","authMethod":"","tls":false,"localName":""},"mailer":{"provider":"","accessKey":"","region":"","domain":"","endpoint":"","configurationSet":"","tags":[],"trackOpens":false,"trackClicks":false},"sms":{"enabled":false,"provider":"","accountId":"","from":"","endpoint":""},"push":{"fcm":{"enabled":false},"apns":{"enabled":false,"teamId":"","keyId":"","topic":"","production":false},"webPush":{"enabled":false,"publicKey":"","subject":""},"triggers":[]},"backups":{"cron":"","cronMaxKeep":0,"retention":{"keepDaily":0,"keepWeekly":0,"keepMonthly":0},"cronMode":"","cronFullEvery":0,"cronVerify":false,"s3":{"enabled":false,"bucket":"","region":"","endpoint":"","accessKey":"","forcePathStyle":false},"encryption":{"enabled":false,"recipient":""},"pitr":{"enabled":false,"interval":0,"maxDays":0}},"s3":{"enabled":false,"bucket":"","region":"","endpoint":"","accessKey":"","forcePathStyle":false},"meta":{"appName":"test123","appURL":"","senderName":"","senderAddress":"","hideControls":false},"rateLimits":{"rules":[],"enabled":false},"trustedProxy":{"headers":[],"cidrs":[],"useLeftmostIP":false},"batch":{"enabled":false,"maxRequests":0,"timeout":0,"maxBodySize":0},"graphql":{"enabled":false},"logs":{"maxDays":0,"minLevel":0,"logIP":false,"logAuthId":false,"batchSize":0,"flushInterval":0,"maxBuffered":0,"sinks":[{"type":"webhook","url":"https://example.com","minLevel":0,"queueSize":0}],"enrichment":{"requestId":false,"tenantHeader":""},"redaction":{"tokens":false,"emails":false,"fields":[],"patterns":[]},"excludedLevels":[],"sampling":[]},"panicReporting":{"enabled":false,"environment":""},"responseCache":{"enabled":false,"backend":"","redisURL":"","ttl":0,"maxItems":0,"collections":[]},"routes":{"rules":[],"enabled":false},"plugins":{"disabled":[]},"dataRetention":{"enabled":false,"cron":"","batchSize":0,"rules":[]},"dbMaintenance":{"enabled":false,"cron":"","vacuum":false},"profiling":{"enabled":false},"hookTracing":{"enabled":false,"slowThreshold":0},"queries":{"timeout":0,"slowThreshold":0},"acme":{"dnsProvider":"","domains":[],"email":"","directoryURL":"","cloudflare":{},"route53":{"hostedZoneId":"","accessKey":""},"rfc2136":{"nameserver":"","zone":"","tsigKey":"","tsigAlgorithm":""}},"maintenance":{"message":"","retryAfter":0,"enabled":false},"ipFilter":{"allow":[],"deny":[],"superusersAllow":[],"rules":[],"enabled":false},"captcha":{"enabled":false,"provider":"","endpoint":"","minScore":0},"userData":{"selfService":false,"erasure":[]},"analytics":{"excludedPaths":[],"eventsMaxDays":0,"rollupsMaxDays":0,"enabled":false},"capture":{"routes":[],"maxItems":0,"maxBodySize":0,"enabled":false},"extensions":{}}`
 
 	if rawStr != expected {
 		t.Fatalf("Expected\n%v\ngot\n%v", expected, rawStr)
 	}
+
+	// the original settings shouldn't be masked
+	if settings.Logs.Sinks[0].Token != testSecret {
+		t.Fatalf("Expected the original log sink token to remain unchanged, got %q", settings.Logs.Sinks[0].Token)
+	}
 }
 
 func TestSettingsValidate(t *testing.T) {
@@ -126,6 +146,9 @@ func TestSettingsValidate(t *testing.T) {
 	s.Logs.MaxDays = -10
 	s.SMTP.Enabled = true
 	s.SMTP.Host = ""
+	s.Mailer.Provider = "invalid"
+	s.SMS.Enabled = true
+	s.Push.WebPush.Enabled = true
 	s.S3.Enabled = true
 	s.S3.Endpoint = "invalid"
 	s.Backups.Cron = "invalid"
@@ -135,6 +158,11 @@ func TestSettingsValidate(t *testing.T) {
 	s.Batch.Timeout = -1
 	s.RateLimits.Enabled = true
 	s.RateLimits.Rules = nil
+	s.PanicReporting.Enabled = true
+	s.ResponseCache.Enabled = true
+	s.ResponseCache.Backend = "invalid"
+	s.Routes.Enabled = true
+	s.Routes.Rules = nil
 
 	// check if Validate() is triggering the members validate methods.
 	err := app.Validate(s)
@@ -146,10 +174,16 @@ func TestSettingsValidate(t *testing.T) {
 		`"meta":{`,
 		`"logs":{`,
 		`"smtp":{`,
+		`"mailer":{`,
+		`"sms":{`,
+		`"push":{`,
 		`"s3":{`,
 		`"backups":{`,
 		`"batch":{`,
 		`"rateLimits":{`,
+		`"panicReporting":{`,
+		`"responseCache":{`,
+		`"routes":{`,
 	}
 
 	errBytes, _ := json.Marshal(err)
@@ -226,12 +260,382 @@ func TestLogsConfigValidate(t *testing.T) {
 		},
 		{
 			"invalid data",
-			core.LogsConfig{MaxDays: -1},
-			[]string{"maxDays"},
+			core.LogsConfig{
+				MaxDays:    -1,
+				Sinks:      []core.LogSinkConfig{{}},
+				Enrichment: core.LogsEnrichmentConfig{TenantHeader: "invalid header"},
+				Redaction:  core.LogsRedactionConfig{Patterns: []string{"("}},
+				Sampling:   []core.LogsSamplingRule{{}},
+			},
+			[]string{"maxDays", "sinks", "enrichment", "redaction", "sampling"},
+		},
+		{
+			"invalid batching options",
+			core.LogsConfig{BatchSize: 10001, FlushInterval: 3601, MaxBuffered: -1},
+			[]string{"batchSize", "flushInterval", "maxBuffered"},
+		},
+		{
+			"negative batching options",
+			core.LogsConfig{BatchSize: -1, FlushInterval: -1},
+			[]string{"batchSize", "flushInterval"},
+		},
+		{
+			"maxBuffered less than the default batch size",
+			core.LogsConfig{MaxBuffered: core.DefaultLogsBatchSize - 1},
+			[]string{"maxBuffered"},
+		},
+		{
+			"maxBuffered less than the custom batch size",
+			core.LogsConfig{BatchSize: 10, MaxBuffered: 9},
+			[]string{"maxBuffered"},
+		},
+		{
+			"valid batching options",
+			core.LogsConfig{BatchSize: 10, FlushInterval: 1, MaxBuffered: 10},
+			[]string{},
+		},
+		{
+			"valid data",
+			core.LogsConfig{MaxDays: 2, Sinks: []core.LogSinkConfig{{Type: core.LogSinkTypeWebhook, URL: "https://example.com"}}},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestLogsEnrichmentConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.LogsEnrichmentConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values",
+			core.LogsEnrichmentConfig{},
+			[]string{},
+		},
+		{
+			"invalid tenant header",
+			core.LogsEnrichmentConfig{TenantHeader: "X Tenant"},
+			[]string{"tenantHeader"},
+		},
+		{
+			"too long tenant header",
+			core.LogsEnrichmentConfig{TenantHeader: strings.Repeat("a", 101)},
+			[]string{"tenantHeader"},
+		},
+		{
+			"valid data",
+			core.LogsEnrichmentConfig{RequestId: true, TenantHeader: "X-Tenant-Id"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestLogsRedactionConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.LogsRedactionConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values",
+			core.LogsRedactionConfig{},
+			[]string{},
+		},
+		{
+			"invalid data",
+			core.LogsRedactionConfig{
+				Fields:   []string{"", strings.Repeat("a", 256)},
+				Patterns: []string{"", "("},
+			},
+			[]string{"fields", "patterns"},
+		},
+		{
+			"valid data",
+			core.LogsRedactionConfig{
+				Tokens:   true,
+				Emails:   true,
+				Fields:   []string{"password"},
+				Patterns: []string{`\d{16}`},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestLogsConfigRequestSampleRate(t *testing.T) {
+	config := core.LogsConfig{
+		Sampling: []core.LogsSamplingRule{
+			{Path: "/api/health", Rate: 0},
+			{Path: "GET /api/files/*", Status: "2xx", Rate: 0.1},
+			{Path: "/api/files/", Rate: 0.5},
+		},
+	}
+
+	scenarios := []struct {
+		method   string
+		path     string
+		status   int
+		expected float64
+	}{
+		{"GET", "/api/collections", 200, 1},
+		{"GET", "/api/health", 200, 0},
+		{"GET", "/api/files/a/b/c.png", 200, 0.1},
+		{"GET", "/api/files/a/b/c.png", 404, 0.5},
+		{"POST", "/api/files/token", 200, 0.5},
+	}
+
+	for _, s := range scenarios {
+		t.Run(fmt.Sprintf("%s_%s_%d", s.method, s.path, s.status), func(t *testing.T) {
+			result := config.RequestSampleRate(s.method, s.path, s.status)
+
+			if result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestLogsSamplingRuleValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.LogsSamplingRule
+		expectedErrors []string
+	}{
+		{
+			"zero values",
+			core.LogsSamplingRule{},
+			[]string{"path"},
+		},
+		{
+			"invalid data",
+			core.LogsSamplingRule{Path: "api/files", Status: "6xx", Rate: 1.1},
+			[]string{"path", "status", "rate"},
+		},
+		{
+			"negative rate and invalid status",
+			core.LogsSamplingRule{Path: "/api/files/", Status: "20", Rate: -0.1},
+			[]string{"status", "rate"},
+		},
+		{
+			"valid data (exact path)",
+			core.LogsSamplingRule{Path: "/api/health", Status: "200"},
+			[]string{},
+		},
+		{
+			"valid data (method and path prefix)",
+			core.LogsSamplingRule{Path: "GET /api/files/*", Status: "2xx", Rate: 0.1},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestLogsSamplingRuleMatch(t *testing.T) {
+	scenarios := []struct {
+		rule     core.LogsSamplingRule
+		method   string
+		path     string
+		status   int
+		expected bool
+	}{
+		{core.LogsSamplingRule{Path: "/api/health"}, "GET", "/api/health", 200, true},
+		{core.LogsSamplingRule{Path: "/api/health"}, "GET", "/api/health/", 200, false},
+		{core.LogsSamplingRule{Path: "/api/files/"}, "GET", "/api/files/a/b", 200, true},
+		{core.LogsSamplingRule{Path: "/api/files/"}, "GET", "/api/files", 200, false},
+		{core.LogsSamplingRule{Path: "/api/files*"}, "GET", "/api/files", 200, true},
+		{core.LogsSamplingRule{Path: "GET /api/files/"}, "get", "/api/files/a", 200, true},
+		{core.LogsSamplingRule{Path: "GET /api/files/"}, "POST", "/api/files/a", 200, false},
+		{core.LogsSamplingRule{Path: "/api/files/", Status: "2xx"}, "GET", "/api/files/a", 204, true},
+		{core.LogsSamplingRule{Path: "/api/files/", Status: "2xx"}, "GET", "/api/files/a", 404, false},
+		{core.LogsSamplingRule{Path: "/api/files/", Status: "404"}, "GET", "/api/files/a", 404, true},
+		{core.LogsSamplingRule{Path: "/api/files/", Status: "404"}, "GET", "/api/files/a", 400, false},
+		{core.LogsSamplingRule{Path: "/api/files/", Status: "2xx"}, "GET", "/api/files/a", 0, false},
+	}
+
+	for i, s := range scenarios {
+		t.Run(fmt.Sprintf("%d_%s_%s_%s_%d", i, s.rule.Path, s.rule.Status, s.path, s.status), func(t *testing.T) {
+			result := s.rule.Match(s.method, s.path, s.status)
+
+			if result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestPanicReportingConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.PanicReportingConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values",
+			core.PanicReportingConfig{},
+			[]string{},
+		},
+		{
+			"enabled with empty DSN",
+			core.PanicReportingConfig{Enabled: true},
+			[]string{"dsn"},
+		},
+		{
+			"disabled with invalid data",
+			core.PanicReportingConfig{DSN: "invalid", Environment: strings.Repeat("a", 65)},
+			[]string{"dsn", "environment"},
+		},
+		{
+			"enabled with DSN without project id",
+			core.PanicReportingConfig{Enabled: true, DSN: "https://key@example.com"},
+			[]string{"dsn"},
 		},
 		{
 			"valid data",
-			core.LogsConfig{MaxDays: 2},
+			core.PanicReportingConfig{Enabled: true, DSN: "https://key@example.com/1", Environment: "production"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestLogsRedactionConfigRedactor(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		config           core.LogsRedactionConfig
+		expectedPatterns []string
+		expectedKeys     []string
+	}{
+		{
+			"zero values",
+			core.LogsRedactionConfig{},
+			nil,
+			nil,
+		},
+		{
+			"all options",
+			core.LogsRedactionConfig{
+				Tokens:   true,
+				Emails:   true,
+				Fields:   []string{"a", "b"},
+				Patterns: []string{"(", `\d+`},
+			},
+			[]string{
+				logger.RedactTokensPattern.String(),
+				logger.RedactEmailsPattern.String(),
+				`\d+`,
+			},
+			[]string{"a", "b"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			r := s.config.Redactor()
+
+			if len(r.Patterns) != len(s.expectedPatterns) {
+				t.Fatalf("Expected %d patterns, got %d", len(s.expectedPatterns), len(r.Patterns))
+			}
+			for i, p := range s.expectedPatterns {
+				if r.Patterns[i].String() != p {
+					t.Errorf("[%d] Expected pattern %q, got %q", i, p, r.Patterns[i].String())
+				}
+			}
+
+			if len(r.Keys) != len(s.expectedKeys) {
+				t.Fatalf("Expected %d keys, got %d", len(s.expectedKeys), len(r.Keys))
+			}
+			for i, k := range s.expectedKeys {
+				if r.Keys[i] != k {
+					t.Errorf("[%d] Expected key %q, got %q", i, k, r.Keys[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLogSinkConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.LogSinkConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values",
+			core.LogSinkConfig{},
+			[]string{"type", "url"},
+		},
+		{
+			"invalid data",
+			core.LogSinkConfig{Type: "invalid", URL: "invalid", QueueSize: -1},
+			[]string{"type", "url", "queueSize"},
+		},
+		{
+			"invalid http url",
+			core.LogSinkConfig{Type: core.LogSinkTypeLoki, URL: "invalid", QueueSize: 10001},
+			[]string{"url", "queueSize"},
+		},
+		{
+			"invalid syslog url and token",
+			core.LogSinkConfig{Type: core.LogSinkTypeSyslog, URL: "https://example.com", Token: "test"},
+			[]string{"url", "token"},
+		},
+		{
+			"valid data (loki)",
+			core.LogSinkConfig{Type: core.LogSinkTypeLoki, URL: "http://localhost:3100/loki/api/v1/push", Token: "test"},
+			[]string{},
+		},
+		{
+			"valid data (otlp)",
+			core.LogSinkConfig{Type: core.LogSinkTypeOTLP, URL: "http://localhost:4318/v1/logs", MinLevel: 4},
+			[]string{},
+		},
+		{
+			"valid data (webhook)",
+			core.LogSinkConfig{Type: core.LogSinkTypeWebhook, URL: "https://example.com", QueueSize: 100},
+			[]string{},
+		},
+		{
+			"valid data (syslog)",
+			core.LogSinkConfig{Type: core.LogSinkTypeSyslog, URL: "udp://localhost:514"},
 			[]string{},
 		},
 	}
@@ -304,64 +708,944 @@ func TestSMTPConfigValidate(t *testing.T) {
 	}
 }
 
-func TestS3ConfigValidate(t *testing.T) {
+func TestMailerConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.MailerConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values (no provider)",
+			core.MailerConfig{},
+			[]string{},
+		},
+		{
+			"unknown provider",
+			core.MailerConfig{Provider: "invalid"},
+			[]string{"provider"},
+		},
+		{
+			"invalid common fields",
+			core.MailerConfig{Endpoint: "invalid", Domain: "invalid!", Tags: []string{""}, InboundToken: "short"},
+			[]string{"endpoint", "domain", "tags", "inboundToken"},
+		},
+		{
+			"zero values (ses)",
+			core.MailerConfig{Provider: core.MailerProviderSES},
+			[]string{"accessKey", "secret", "region"},
+		},
+		{
+			"valid data (ses)",
+			core.MailerConfig{
+				Provider:  core.MailerProviderSES,
+				AccessKey: "test",
+				Secret:    "test",
+				Region:    "us-east-1",
+				Tags:      []string{"a:1"},
+			},
+			[]string{},
+		},
+		{
+			"zero values (sendgrid)",
+			core.MailerConfig{Provider: core.MailerProviderSendGrid},
+			[]string{"apiKey"},
+		},
+		{
+			"valid data (sendgrid)",
+			core.MailerConfig{Provider: core.MailerProviderSendGrid, APIKey: "test", TrackOpens: true},
+			[]string{},
+		},
+		{
+			"zero values (mailgun)",
+			core.MailerConfig{Provider: core.MailerProviderMailgun},
+			[]string{"apiKey", "domain"},
+		},
+		{
+			"invalid region (mailgun)",
+			core.MailerConfig{Provider: core.MailerProviderMailgun, APIKey: "test", Domain: "example.com", Region: "invalid"},
+			[]string{"region"},
+		},
+		{
+			"valid data (mailgun)",
+			core.MailerConfig{Provider: core.MailerProviderMailgun, APIKey: "test", Domain: "example.com", Region: "eu"},
+			[]string{},
+		},
+		{
+			"zero values (resend)",
+			core.MailerConfig{Provider: core.MailerProviderResend},
+			[]string{"apiKey"},
+		},
+		{
+			"valid data (resend)",
+			core.MailerConfig{Provider: core.MailerProviderResend, APIKey: "test", Endpoint: "https://example.com"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestSMSConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.SMSConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values (disabled)",
+			core.SMSConfig{},
+			[]string{},
+		},
+		{
+			"zero values (enabled)",
+			core.SMSConfig{Enabled: true},
+			[]string{"provider", "accountId", "secret", "from"},
+		},
+		{
+			"unknown provider",
+			core.SMSConfig{Provider: "invalid"},
+			[]string{"provider"},
+		},
+		{
+			"invalid endpoint",
+			core.SMSConfig{Endpoint: "invalid"},
+			[]string{"endpoint"},
+		},
+		{
+			"zero values (twilio)",
+			core.SMSConfig{Enabled: true, Provider: core.SMSProviderTwilio},
+			[]string{"accountId", "secret", "from"},
+		},
+		{
+			"valid data (twilio)",
+			core.SMSConfig{Enabled: true, Provider: core.SMSProviderTwilio, AccountId: "test", Secret: "test", From: "+12345"},
+			[]string{},
+		},
+		{
+			"valid data (vonage)",
+			core.SMSConfig{Enabled: true, Provider: core.SMSProviderVonage, AccountId: "test", Secret: "test", From: "Test"},
+			[]string{},
+		},
+		{
+			"valid data (plivo)",
+			core.SMSConfig{Enabled: true, Provider: core.SMSProviderPlivo, AccountId: "test", Secret: "test", From: "+12345"},
+			[]string{},
+		},
+		{
+			"zero values (http)",
+			core.SMSConfig{Enabled: true, Provider: core.SMSProviderHTTP},
+			[]string{"endpoint"},
+		},
+		{
+			"valid data (http)",
+			core.SMSConfig{Enabled: true, Provider: core.SMSProviderHTTP, Endpoint: "https://example.com"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestPushConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.PushConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values",
+			core.PushConfig{},
+			[]string{},
+		},
+		{
+			"enabled platforms with missing settings",
+			core.PushConfig{
+				FCM:     core.PushFCMConfig{Enabled: true},
+				APNs:    core.PushAPNsConfig{Enabled: true},
+				WebPush: core.PushWebPushConfig{Enabled: true},
+			},
+			[]string{"fcm", "apns", "webPush"},
+		},
+		{
+			"invalid trigger",
+			core.PushConfig{
+				Triggers: []core.PushTrigger{{}},
+			},
+			[]string{"triggers"},
+		},
+		{
+			"valid data",
+			core.PushConfig{
+				FCM: core.PushFCMConfig{Enabled: true, ServiceAccount: `{"project_id":"test"}`},
+				Triggers: []core.PushTrigger{
+					{Collection: "demo1", Event: core.PushTriggerEventCreate, RecipientField: "rel", Title: "test"},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestPushFCMConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.PushFCMConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values (disabled)",
+			core.PushFCMConfig{},
+			[]string{},
+		},
+		{
+			"zero values (enabled)",
+			core.PushFCMConfig{Enabled: true},
+			[]string{"serviceAccount"},
+		},
+		{
+			"invalid service account json",
+			core.PushFCMConfig{ServiceAccount: "invalid"},
+			[]string{"serviceAccount"},
+		},
+		{
+			"valid data",
+			core.PushFCMConfig{Enabled: true, ServiceAccount: `{"project_id":"test"}`},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestPushAPNsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.PushAPNsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values (disabled)",
+			core.PushAPNsConfig{},
+			[]string{},
+		},
+		{
+			"zero values (enabled)",
+			core.PushAPNsConfig{Enabled: true},
+			[]string{"teamId", "keyId", "privateKey", "topic"},
+		},
+		{
+			"invalid data",
+			core.PushAPNsConfig{
+				TeamId: strings.Repeat("a", 101),
+				KeyId:  strings.Repeat("a", 101),
+				Topic:  strings.Repeat("a", 256),
+			},
+			[]string{"teamId", "keyId", "topic"},
+		},
+		{
+			"valid data",
+			core.PushAPNsConfig{Enabled: true, TeamId: "test", KeyId: "test", PrivateKey: "test", Topic: "com.example.app"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestPushWebPushConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.PushWebPushConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values (disabled)",
+			core.PushWebPushConfig{},
+			[]string{},
+		},
+		{
+			"zero values (enabled)",
+			core.PushWebPushConfig{Enabled: true},
+			[]string{"publicKey", "privateKey", "subject"},
+		},
+		{
+			"invalid subject",
+			core.PushWebPushConfig{Subject: "test@example.com"},
+			[]string{"subject"},
+		},
+		{
+			"valid data (mailto)",
+			core.PushWebPushConfig{Enabled: true, PublicKey: "test", PrivateKey: "test", Subject: "mailto:test@example.com"},
+			[]string{},
+		},
+		{
+			"valid data (https)",
+			core.PushWebPushConfig{Enabled: true, PublicKey: "test", PrivateKey: "test", Subject: "https://example.com"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestPushTriggerValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.PushTrigger
+		expectedErrors []string
+	}{
+		{
+			"zero values",
+			core.PushTrigger{},
+			[]string{"collection", "event", "title"},
+		},
+		{
+			"invalid data",
+			core.PushTrigger{
+				Collection:     strings.Repeat("a", 256),
+				Event:          "invalid",
+				RecipientField: strings.Repeat("a", 256),
+				Title:          strings.Repeat("a", 256),
+				Body:           strings.Repeat("a", 1001),
+			},
+			[]string{"collection", "event", "recipientField", "title", "body"},
+		},
+		{
+			"valid data",
+			core.PushTrigger{Collection: "demo1", Event: core.PushTriggerEventDelete, Title: "test"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestS3ConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.S3Config
+		expectedErrors []string
+	}{
+		{
+			"zero values (disabled)",
+			core.S3Config{},
+			[]string{},
+		},
+		{
+			"zero values (enabled)",
+			core.S3Config{Enabled: true},
+			[]string{
+				"bucket",
+				"region",
+				"endpoint",
+				"accessKey",
+				"secret",
+			},
+		},
+		{
+			"invalid data",
+			core.S3Config{
+				Enabled:  true,
+				Endpoint: "test:test:test",
+			},
+			[]string{
+				"bucket",
+				"region",
+				"endpoint",
+				"accessKey",
+				"secret",
+			},
+		},
+		{
+			"valid data (url endpoint)",
+			core.S3Config{
+				Enabled:   true,
+				Endpoint:  "https://localhost:8090",
+				Bucket:    "test",
+				Region:    "test",
+				AccessKey: "test",
+				Secret:    "test",
+			},
+			[]string{},
+		},
+		{
+			"valid data (hostname endpoint)",
+			core.S3Config{
+				Enabled:   true,
+				Endpoint:  "example.com",
+				Bucket:    "test",
+				Region:    "test",
+				AccessKey: "test",
+				Secret:    "test",
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestACMEConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.ACMEConfig
+		expectedErrors []string
+	}{
+		{
+			"zero values (disabled)",
+			core.ACMEConfig{},
+			[]string{},
+		},
+		{
+			"invalid provider",
+			core.ACMEConfig{DNSProvider: "invalid", Domains: []string{"example.com"}},
+			[]string{"dnsProvider"},
+		},
+		{
+			"cloudflare with zero values",
+			core.ACMEConfig{DNSProvider: core.ACMEDNSProviderCloudflare},
+			[]string{"domains", "cloudflare"},
+		},
+		{
+			"route53 with zero values",
+			core.ACMEConfig{DNSProvider: core.ACMEDNSProviderRoute53},
+			[]string{"domains", "route53"},
+		},
+		{
+			"rfc2136 with zero values",
+			core.ACMEConfig{DNSProvider: core.ACMEDNSProviderRFC2136},
+			[]string{"domains", "rfc2136"},
+		},
+		{
+			"invalid data",
+			core.ACMEConfig{
+				DNSProvider:  core.ACMEDNSProviderRFC2136,
+				Domains:      []string{"example.com", "*.*.example.com", ""},
+				Email:        "invalid",
+				DirectoryURL: "invalid",
+				RFC2136: core.ACMERFC2136Config{
+					Nameserver:    "127.0.0.1:53",
+					Zone:          "example.com",
+					TSIGKey:       "test",
+					TSIGSecret:    "invalid!",
+					TSIGAlgorithm: "invalid",
+				},
+			},
+			[]string{"domains", "email", "directoryURL", "rfc2136"},
+		},
+		{
+			"valid data",
+			core.ACMEConfig{
+				DNSProvider:  core.ACMEDNSProviderCloudflare,
+				Domains:      []string{"example.com", "*.example.com"},
+				Email:        "test@example.com",
+				DirectoryURL: "https://acme-staging-v02.api.letsencrypt.org/directory",
+				Cloudflare:   core.ACMECloudflareConfig{APIToken: "test"},
+				RFC2136:      core.ACMERFC2136Config{TSIGAlgorithm: "invalid"}, // not validated
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestBackupsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.BackupsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.BackupsConfig{},
+			[]string{},
+		},
+		{
+			"invalid cron",
+			core.BackupsConfig{
+				Cron:        "invalid",
+				CronMaxKeep: 0,
+			},
+			[]string{"cron", "cronMaxKeep"},
+		},
+		{
+			"invalid enabled S3",
+			core.BackupsConfig{
+				S3: core.S3Config{
+					Enabled: true,
+				},
+			},
+			[]string{"s3"},
+		},
+		{
+			"invalid cron mode and full every",
+			core.BackupsConfig{
+				CronMode:      "invalid",
+				CronFullEvery: -1,
+			},
+			[]string{"cronMode", "cronFullEvery"},
+		},
+		{
+			"too large cron full every",
+			core.BackupsConfig{
+				CronMode:      core.BackupModeIncremental,
+				CronFullEvery: 1001,
+			},
+			[]string{"cronFullEvery"},
+		},
+		{
+			"invalid encryption recipient",
+			core.BackupsConfig{
+				Encryption: core.BackupsEncryptionConfig{
+					Enabled:   true,
+					Recipient: "invalid",
+				},
+			},
+			[]string{"encryption"},
+		},
+		{
+			"cron with retention policy and without max keep",
+			core.BackupsConfig{
+				Cron: "*/10 * * * *",
+				Retention: core.BackupsRetentionConfig{
+					KeepDaily: 7,
+				},
+			},
+			[]string{},
+		},
+		{
+			"invalid retention",
+			core.BackupsConfig{
+				Retention: core.BackupsRetentionConfig{
+					KeepWeekly: -1,
+				},
+			},
+			[]string{"retention"},
+		},
+		{
+			"invalid pitr interval",
+			core.BackupsConfig{
+				PITR: core.BackupsPITRConfig{
+					Enabled:  true,
+					Interval: -1,
+				},
+			},
+			[]string{"pitr"},
+		},
+		{
+			"valid data",
+			core.BackupsConfig{
+				S3: core.S3Config{
+					Enabled:   true,
+					Endpoint:  "example.com",
+					Bucket:    "test",
+					Region:    "test",
+					AccessKey: "test",
+					Secret:    "test",
+				},
+				Cron:          "*/10 * * * *",
+				CronMaxKeep:   1,
+				CronMode:      core.BackupModeDifferential,
+				CronFullEvery: 3,
+				Encryption: core.BackupsEncryptionConfig{
+					Enabled:   true,
+					Recipient: "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p",
+				},
+				PITR: core.BackupsPITRConfig{
+					Enabled:  true,
+					Interval: 10,
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestBackupsRetentionConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.BackupsRetentionConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.BackupsRetentionConfig{},
+			[]string{},
+		},
+		{
+			"negative values",
+			core.BackupsRetentionConfig{KeepDaily: -1, KeepWeekly: -1, KeepMonthly: -1},
+			[]string{"keepDaily", "keepWeekly", "keepMonthly"},
+		},
+		{
+			"too large values",
+			core.BackupsRetentionConfig{KeepDaily: 1001, KeepWeekly: 1001, KeepMonthly: 1001},
+			[]string{"keepDaily", "keepWeekly", "keepMonthly"},
+		},
+		{
+			"valid values",
+			core.BackupsRetentionConfig{KeepDaily: 7, KeepWeekly: 4, KeepMonthly: 12},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestBackupsRetentionConfigIsEnabled(t *testing.T) {
+	scenarios := []struct {
+		config   core.BackupsRetentionConfig
+		expected bool
+	}{
+		{core.BackupsRetentionConfig{}, false},
+		{core.BackupsRetentionConfig{KeepDaily: 1}, true},
+		{core.BackupsRetentionConfig{KeepWeekly: 1}, true},
+		{core.BackupsRetentionConfig{KeepMonthly: 1}, true},
+	}
+
+	for i, s := range scenarios {
+		if v := s.config.IsEnabled(); v != s.expected {
+			t.Fatalf("[%d] Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestBackupsPITRConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.BackupsPITRConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.BackupsPITRConfig{},
+			[]string{},
+		},
+		{
+			"negative interval",
+			core.BackupsPITRConfig{Enabled: true, Interval: -1},
+			[]string{"interval"},
+		},
+		{
+			"too large interval",
+			core.BackupsPITRConfig{Enabled: true, Interval: 86401},
+			[]string{"interval"},
+		},
+		{
+			"valid interval",
+			core.BackupsPITRConfig{Enabled: true, Interval: 5},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestBackupsEncryptionConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.BackupsEncryptionConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.BackupsEncryptionConfig{},
+			[]string{},
+		},
+		{
+			"enabled without recipient",
+			core.BackupsEncryptionConfig{Enabled: true},
+			[]string{},
+		},
+		{
+			"invalid recipient",
+			core.BackupsEncryptionConfig{
+				Enabled:   true,
+				Recipient: "AGE-SECRET-KEY-1",
+			},
+			[]string{"recipient"},
+		},
+		{
+			"valid recipient",
+			core.BackupsEncryptionConfig{
+				Enabled:   true,
+				Recipient: "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p",
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestBatchConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.BatchConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.BatchConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			core.BatchConfig{Enabled: true},
+			[]string{"maxRequests", "timeout"},
+		},
+		{
+			"invalid data (negative values)",
+			core.BatchConfig{
+				MaxRequests: -1,
+				Timeout:     -1,
+				MaxBodySize: -1,
+			},
+			[]string{"maxRequests", "timeout", "maxBodySize"},
+		},
+		{
+			"min fields valid data",
+			core.BatchConfig{
+				Enabled:     true,
+				MaxRequests: 1,
+				Timeout:     1,
+			},
+			[]string{},
+		},
+		{
+			"all fields valid data",
+			core.BatchConfig{
+				Enabled:     true,
+				MaxRequests: 10,
+				Timeout:     1,
+				MaxBodySize: 1,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestMaintenanceConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.MaintenanceConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.MaintenanceConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			core.MaintenanceConfig{Enabled: true},
+			[]string{},
+		},
+		{
+			"invalid data",
+			core.MaintenanceConfig{
+				Message:    strings.Repeat("a", 1001),
+				RetryAfter: -1,
+			},
+			[]string{"message", "retryAfter"},
+		},
+		{
+			"valid data",
+			core.MaintenanceConfig{
+				Enabled:    true,
+				Message:    strings.Repeat("a", 1000),
+				RetryAfter: 60,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestMaintenanceConfigErrorMessage(t *testing.T) {
+	scenarios := []struct {
+		message  string
+		expected string
+	}{
+		{"", core.DefaultMaintenanceMessage},
+		{"test", "test"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.message, func(t *testing.T) {
+			c := core.MaintenanceConfig{Message: s.message}
+
+			if v := c.ErrorMessage(); v != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestCaptchaConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.CaptchaConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.CaptchaConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			core.CaptchaConfig{Enabled: true},
+			[]string{"provider", "secret"},
+		},
+		{
+			"invalid data",
+			core.CaptchaConfig{Provider: "invalid", Endpoint: "invalid", MinScore: 1.1},
+			[]string{"provider", "endpoint", "minScore"},
+		},
+		{
+			"builtin provider without secret",
+			core.CaptchaConfig{Enabled: true, Provider: core.CaptchaProviderTurnstile},
+			[]string{"secret"},
+		},
+		{
+			"builtin provider with secret",
+			core.CaptchaConfig{Enabled: true, Provider: core.CaptchaProviderReCaptcha, Secret: "test", MinScore: 0.5, Endpoint: "https://example.com"},
+			[]string{},
+		},
+		{
+			"custom provider without secret",
+			core.CaptchaConfig{Enabled: true, Provider: core.CaptchaProviderCustom},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestTrustedProxyConfigValidate(t *testing.T) {
 	scenarios := []struct {
 		name           string
-		config         core.S3Config
+		config         core.TrustedProxyConfig
 		expectedErrors []string
 	}{
 		{
-			"zero values (disabled)",
-			core.S3Config{},
+			"zero value",
+			core.TrustedProxyConfig{},
 			[]string{},
 		},
 		{
-			"zero values (enabled)",
-			core.S3Config{Enabled: true},
-			[]string{
-				"bucket",
-				"region",
-				"endpoint",
-				"accessKey",
-				"secret",
-			},
-		},
-		{
-			"invalid data",
-			core.S3Config{
-				Enabled:  true,
-				Endpoint: "test:test:test",
-			},
-			[]string{
-				"bucket",
-				"region",
-				"endpoint",
-				"accessKey",
-				"secret",
-			},
-		},
-		{
-			"valid data (url endpoint)",
-			core.S3Config{
-				Enabled:   true,
-				Endpoint:  "https://localhost:8090",
-				Bucket:    "test",
-				Region:    "test",
-				AccessKey: "test",
-				Secret:    "test",
-			},
-			[]string{},
+			"invalid CIDRs",
+			core.TrustedProxyConfig{CIDRs: []string{"10.0.0.0/8", "invalid", "1.2.3.4/33"}},
+			[]string{"cidrs"},
 		},
 		{
-			"valid data (hostname endpoint)",
-			core.S3Config{
-				Enabled:   true,
-				Endpoint:  "example.com",
-				Bucket:    "test",
-				Region:    "test",
-				AccessKey: "test",
-				Secret:    "test",
-			},
+			"valid CIDRs",
+			core.TrustedProxyConfig{CIDRs: []string{"10.0.0.0/8", "127.0.0.1", "::1", "fd00::/8"}},
 			[]string{},
 		},
 	}
@@ -375,47 +1659,42 @@ func TestS3ConfigValidate(t *testing.T) {
 	}
 }
 
-func TestBackupsConfigValidate(t *testing.T) {
+func TestIPFilterConfigValidate(t *testing.T) {
 	scenarios := []struct {
 		name           string
-		config         core.BackupsConfig
+		config         core.IPFilterConfig
 		expectedErrors []string
 	}{
 		{
 			"zero value",
-			core.BackupsConfig{},
+			core.IPFilterConfig{},
 			[]string{},
 		},
 		{
-			"invalid cron",
-			core.BackupsConfig{
-				Cron:        "invalid",
-				CronMaxKeep: 0,
-			},
-			[]string{"cron", "cronMaxKeep"},
-		},
-		{
-			"invalid enabled S3",
-			core.BackupsConfig{
-				S3: core.S3Config{
-					Enabled: true,
+			"invalid data",
+			core.IPFilterConfig{
+				Enabled:         true,
+				Allow:           []string{"invalid"},
+				Deny:            []string{"1.2.3"},
+				SuperusersAllow: []string{"10.0.0.0/99"},
+				Rules: []core.IPFilterRule{
+					{Label: "invalid label"},
+					{Label: "/api/", Allow: []string{"invalid"}},
 				},
 			},
-			[]string{"s3"},
+			[]string{"allow", "deny", "superusersAllow", "rules"},
 		},
 		{
 			"valid data",
-			core.BackupsConfig{
-				S3: core.S3Config{
-					Enabled:   true,
-					Endpoint:  "example.com",
-					Bucket:    "test",
-					Region:    "test",
-					AccessKey: "test",
-					Secret:    "test",
+			core.IPFilterConfig{
+				Enabled:         true,
+				Allow:           []string{"10.0.0.0/8", "127.0.0.1"},
+				Deny:            []string{"10.0.0.1"},
+				SuperusersAllow: []string{"10.8.0.0/16"},
+				Rules: []core.IPFilterRule{
+					{Label: "/api/settings", Allow: []string{"10.8.0.0/16"}},
+					{Label: "POST /api/collections/", Deny: []string{"::1"}},
 				},
-				Cron:        "*/10 * * * *",
-				CronMaxKeep: 1,
 			},
 			[]string{},
 		},
@@ -430,47 +1709,140 @@ func TestBackupsConfigValidate(t *testing.T) {
 	}
 }
 
-func TestBatchConfigValidate(t *testing.T) {
+func TestIPFilterConfigIsAllowed(t *testing.T) {
+	config := core.IPFilterConfig{
+		Enabled: true,
+		Allow:   []string{"10.0.0.0/8", "::1"},
+		Deny:    []string{"10.0.0.1"},
+		Rules: []core.IPFilterRule{
+			{Label: "/api/settings", Allow: []string{"10.8.0.0/16"}},
+			{Label: "POST /api/collections/", Deny: []string{"10.1.0.0/16"}},
+			{Label: "/api/collections/", Deny: []string{"10.2.0.0/16"}},
+		},
+	}
+
+	scenarios := []struct {
+		name     string
+		enabled  bool
+		ip       string
+		labels   []string
+		expected bool
+	}{
+		{"disabled", false, "1.2.3.4", nil, true},
+		{"invalid ip", true, "invalid", nil, false},
+		{"not in the global allow list", true, "1.2.3.4", nil, false},
+		{"in the global deny list", true, "10.0.0.1", nil, false},
+		{"in the global allow list (ipv4)", true, "10.0.0.2", nil, true},
+		{"in the global allow list (ipv6)", true, "0000:0000:0000:0000:0000:0000:0000:0001", nil, true},
+		{"in the global allow list (ipv4-mapped ipv6)", true, "::ffff:10.0.0.2", nil, true},
+		{"not in the route allow list", true, "10.0.0.2", []string{"GET /api/settings", "/api/settings"}, false},
+		{"in the route allow list", true, "10.8.0.2", []string{"GET /api/settings", "/api/settings"}, true},
+		{"in the route allow list but globally denied", true, "10.0.0.1", []string{"/api/settings"}, false},
+		{"method route prefix deny", true, "10.1.0.1", []string{"POST /api/collections/demo1/records", "/api/collections/demo1/records"}, false},
+		{"method route prefix deny (different method)", true, "10.1.0.1", []string{"GET /api/collections/demo1/records", "/api/collections/demo1/records"}, true},
+		{"route prefix deny", true, "10.2.0.1", []string{"GET /api/collections/demo1/records", "/api/collections/demo1/records"}, false},
+		{"no matching route", true, "10.2.0.1", []string{"GET /api/health", "/api/health"}, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			config.Enabled = s.enabled
+
+			if v := config.IsAllowed(s.ip, s.labels...); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestMatchIPOrCIDR(t *testing.T) {
+	list := []string{"invalid", "10.0.0.0/8", " 192.168.1.1 ", "fd00::/8", "::ffff:172.16.0.0/108"}
+
+	scenarios := []struct {
+		ip       string
+		expected bool
+	}{
+		{"", false},
+		{"invalid", false},
+		{"10.1.2.3", true},
+		{"11.1.2.3", false},
+		{"192.168.1.1", true},
+		{"192.168.1.2", false},
+		{"::ffff:192.168.1.1", true},
+		{"fd00::1", true},
+		{"fe00::1", false},
+		{"172.16.0.1", true},
+		{"172.32.0.1", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.ip, func(t *testing.T) {
+			if v := core.MatchIPOrCIDR(list, s.ip); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+
+	if core.MatchIPOrCIDR(nil, "10.1.2.3") {
+		t.Fatal("Expected false for empty list")
+	}
+}
+
+func TestResponseCacheConfigValidate(t *testing.T) {
 	scenarios := []struct {
 		name           string
-		config         core.BatchConfig
+		config         core.ResponseCacheConfig
 		expectedErrors []string
 	}{
 		{
 			"zero value",
-			core.BatchConfig{},
+			core.ResponseCacheConfig{},
 			[]string{},
 		},
 		{
 			"zero value (enabled)",
-			core.BatchConfig{Enabled: true},
-			[]string{"maxRequests", "timeout"},
+			core.ResponseCacheConfig{Enabled: true},
+			[]string{"backend", "ttl"},
 		},
 		{
-			"invalid data (negative values)",
-			core.BatchConfig{
-				MaxRequests: -1,
-				Timeout:     -1,
-				MaxBodySize: -1,
+			"invalid data",
+			core.ResponseCacheConfig{
+				Backend:     "invalid",
+				RedisURL:    "http://example.com",
+				TTL:         -1,
+				MaxItems:    -1,
+				Collections: []core.ResponseCacheCollection{{TTL: -1}},
 			},
-			[]string{"maxRequests", "timeout", "maxBodySize"},
+			[]string{"backend", "redisURL", "ttl", "maxItems", "collections"},
 		},
 		{
-			"min fields valid data",
-			core.BatchConfig{
+			"enabled redis backend without url",
+			core.ResponseCacheConfig{
+				Enabled: true,
+				Backend: core.ResponseCacheBackendRedis,
+				TTL:     10,
+			},
+			[]string{"redisURL"},
+		},
+		{
+			"valid memory backend data",
+			core.ResponseCacheConfig{
 				Enabled:     true,
-				MaxRequests: 1,
-				Timeout:     1,
+				Backend:     core.ResponseCacheBackendMemory,
+				TTL:         10,
+				MaxItems:    100,
+				Collections: []core.ResponseCacheCollection{{Collection: "demo1"}, {Collection: "demo2", TTL: 5}},
 			},
 			[]string{},
 		},
 		{
-			"all fields valid data",
-			core.BatchConfig{
-				Enabled:     true,
-				MaxRequests: 10,
-				Timeout:     1,
-				MaxBodySize: 1,
+			"valid redis backend data",
+			core.ResponseCacheConfig{
+				Enabled:       true,
+				Backend:       core.ResponseCacheBackendRedis,
+				RedisURL:      "redis://localhost:6379/1",
+				RedisPassword: "test",
+				TTL:           10,
 			},
 			[]string{},
 		},
@@ -485,6 +1857,50 @@ func TestBatchConfigValidate(t *testing.T) {
 	}
 }
 
+func TestResponseCacheConfigCollectionTTL(t *testing.T) {
+	demo1 := core.NewBaseCollection("demo1", "id1")
+	demo2 := core.NewBaseCollection("demo2", "id2")
+	demo3 := core.NewBaseCollection("demo3", "id3")
+
+	config := core.ResponseCacheConfig{
+		Enabled: true,
+		TTL:     10,
+		Collections: []core.ResponseCacheCollection{
+			{Collection: "DEMO1"},
+			{Collection: "id2", TTL: 5},
+		},
+	}
+
+	scenarios := []struct {
+		name          string
+		config        core.ResponseCacheConfig
+		collection    *core.Collection
+		expectedTTL   time.Duration
+		expectedFound bool
+	}{
+		{"nil collection", config, nil, 0, false},
+		{"disabled", core.ResponseCacheConfig{TTL: 10, Collections: config.Collections}, demo1, 0, false},
+		{"default ttl (by name)", config, demo1, 10 * time.Second, true},
+		{"collection ttl (by id)", config, demo2, 5 * time.Second, true},
+		{"missing collection", config, demo3, 0, false},
+		{"zero ttl", core.ResponseCacheConfig{Enabled: true, Collections: config.Collections}, demo1, 0, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			ttl, found := s.config.CollectionTTL(s.collection)
+
+			if found != s.expectedFound {
+				t.Fatalf("Expected found %v, got %v", s.expectedFound, found)
+			}
+
+			if ttl != s.expectedTTL {
+				t.Fatalf("Expected ttl %v, got %v", s.expectedTTL, ttl)
+			}
+		})
+	}
+}
+
 func TestRateLimitsConfigValidate(t *testing.T) {
 	scenarios := []struct {
 		name           string
@@ -844,3 +2260,182 @@ func TestRateLimitRuleString(t *testing.T) {
 		})
 	}
 }
+
+func TestRoutesConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.RoutesConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			core.RoutesConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			core.RoutesConfig{Enabled: true},
+			[]string{"rules"},
+		},
+		{
+			"invalid rule",
+			core.RoutesConfig{Rules: []core.RouteRule{{}}},
+			[]string{"rules"},
+		},
+		{
+			"valid data",
+			core.RoutesConfig{
+				Enabled: true,
+				Rules:   []core.RouteRule{{Type: core.RouteRuleTypeRewrite, Path: "/a", Target: "/b"}},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
+
+func TestRoutesConfigFindRouteRule(t *testing.T) {
+	rules := []core.RouteRule{
+		{Type: core.RouteRuleTypeRedirect, Path: "/old", Target: "/new"},
+		{Type: core.RouteRuleTypeRewrite, Path: "/docs/*", Target: "/files/*"},
+		{Type: core.RouteRuleTypeProxy, Path: "/svc/*", Target: "http://localhost:3000/api/*"},
+		{Type: core.RouteRuleTypeRedirect, Path: "/docs/x", Target: "/unreachable"},
+	}
+
+	scenarios := []struct {
+		name           string
+		config         core.RoutesConfig
+		path           string
+		types          []string
+		expectedFound  bool
+		expectedPath   string
+		expectedTarget string
+	}{
+		{"disabled", core.RoutesConfig{Rules: rules}, "/old", nil, false, "", ""},
+		{"missing", core.RoutesConfig{Enabled: true, Rules: rules}, "/missing", nil, false, "", ""},
+		{"exact match", core.RoutesConfig{Enabled: true, Rules: rules}, "/old", nil, true, "/old", "/new"},
+		{"exact match with trailing slash", core.RoutesConfig{Enabled: true, Rules: rules}, "/old/", nil, false, "", ""},
+		{"wildcard match (no rest)", core.RoutesConfig{Enabled: true, Rules: rules}, "/docs", nil, true, "/docs/*", "/files/"},
+		{"wildcard match (nested)", core.RoutesConfig{Enabled: true, Rules: rules}, "/docs/x", nil, true, "/docs/*", "/files/x"},
+		{"wildcard partial segment", core.RoutesConfig{Enabled: true, Rules: rules}, "/docsx", nil, false, "", ""},
+		{"type filter", core.RoutesConfig{Enabled: true, Rules: rules}, "/docs/x", []string{core.RouteRuleTypeRedirect}, true, "/docs/x", "/unreachable"},
+		{"proxy", core.RoutesConfig{Enabled: true, Rules: rules}, "/svc/a/b", nil, true, "/svc/*", "http://localhost:3000/api/a/b"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			rule, target, found := s.config.FindRouteRule(s.path, s.types...)
+
+			if found != s.expectedFound {
+				t.Fatalf("Expected found %v, got %v", s.expectedFound, found)
+			}
+
+			if rule.Path != s.expectedPath {
+				t.Fatalf("Expected rule path %q, got %q", s.expectedPath, rule.Path)
+			}
+
+			if target != s.expectedTarget {
+				t.Fatalf("Expected target %q, got %q", s.expectedTarget, target)
+			}
+		})
+	}
+}
+
+func TestRouteRuleValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         core.RouteRule
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.RouteRule{},
+			[]string{"type", "path", "target"},
+		},
+		{
+			"invalid type and path",
+			core.RouteRule{Type: "invalid", Path: "invalid", Target: "/a"},
+			[]string{"type", "path"},
+		},
+		{
+			"reserved paths",
+			core.RouteRule{Type: core.RouteRuleTypeRewrite, Path: "/api/*", Target: "/a"},
+			[]string{"path"},
+		},
+		{
+			"reserved nested path",
+			core.RouteRule{Type: core.RouteRuleTypeRedirect, Path: "/_/test", Target: "/a"},
+			[]string{"path"},
+		},
+		{
+			"non-reserved similar path",
+			core.RouteRule{Type: core.RouteRuleTypeRedirect, Path: "/apis/*", Target: "/a"},
+			[]string{},
+		},
+		{
+			"redirect with invalid target and status",
+			core.RouteRule{Type: core.RouteRuleTypeRedirect, Path: "/a", Target: "invalid", Status: 200},
+			[]string{"target", "status"},
+		},
+		{
+			"redirect with valid url target and status",
+			core.RouteRule{Type: core.RouteRuleTypeRedirect, Path: "/a/*", Target: "https://example.com/*", Status: 301},
+			[]string{},
+		},
+		{
+			"redirect to root",
+			core.RouteRule{Type: core.RouteRuleTypeRedirect, Path: "/", Target: "/a"},
+			[]string{},
+		},
+		{
+			"rewrite with url target and status",
+			core.RouteRule{Type: core.RouteRuleTypeRewrite, Path: "/a", Target: "https://example.com", Status: 301},
+			[]string{"target", "status"},
+		},
+		{
+			"valid rewrite",
+			core.RouteRule{Type: core.RouteRuleTypeRewrite, Path: "/posts/*", Target: "/api/collections/posts/records/*"},
+			[]string{},
+		},
+		{
+			"proxy with path target",
+			core.RouteRule{Type: core.RouteRuleTypeProxy, Path: "/a", Target: "/b"},
+			[]string{"target"},
+		},
+		{
+			"proxy with non-http target",
+			core.RouteRule{Type: core.RouteRuleTypeProxy, Path: "/a", Target: "ftp://example.com"},
+			[]string{"target"},
+		},
+		{
+			"redirect with forwardAuth",
+			core.RouteRule{Type: core.RouteRuleTypeRedirect, Path: "/a", Target: "/b", ForwardAuth: true},
+			[]string{"forwardAuth"},
+		},
+		{
+			"proxy with forwardAuth",
+			core.RouteRule{Type: core.RouteRuleTypeProxy, Path: "/a", Target: "http://127.0.0.1:3000", ForwardAuth: true},
+			[]string{},
+		},
+		{
+			"valid proxy",
+			core.RouteRule{Type: core.RouteRuleTypeProxy, Path: "/a/*", Target: "http://127.0.0.1:3000/*"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
+
+			tests.TestValidationErrors(t, result, s.expectedErrors)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// GetPath returns the value of the settings field at the specified
+// dot-notation path of json keys (e.g. "smtp.host", "logs.sinks.0.url").
+//
+// The path keys are matched case-insensitively.
+//
+// Note that the sensitive fields (SMTP password, S3 secret, etc.) are returned unmasked.
+func (s *Settings) GetPath(path string) (any, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, err := resolveSettingsPath(reflect.ValueOf(&s.settings).Elem(), splitSettingsPath(path))
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Interface(), nil
+}
+
+// SetPath updates the settings field at the specified dot-notation
+// path of json keys (e.g. "smtp.enabled") with the provided raw value.
+//
+// String fields are assigned with the raw value as it is, while for
+// all other field types the raw value is parsed as JSON (e.g. "true", "10", `["a","b"]`).
+//
+// Note that SetPath doesn't validate or persist the settings (see [App.Save]).
+func (s *Settings) SetPath(path string, rawValue string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return setSettingsPath(&s.settings, path, func(target reflect.Value) error {
+		return assignSettingsValue(target, rawValue)
+	})
+}
+
+// setSettingsPath resolves the specified settings path and invokes assign
+// with the path target value (or with a new zero value for map entries).
+func setSettingsPath(root *settings, path string, assign func(target reflect.Value) error) error {
+	keys := splitSettingsPath(path)
+	if len(keys) == 0 {
+		return errors.New("missing settings path")
+	}
+
+	parent, err := resolveSettingsPath(reflect.ValueOf(root).Elem(), keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	lastKey := keys[len(keys)-1]
+
+	// map entry
+	if parent.Kind() == reflect.Map {
+		if parent.Type().Key().Kind() != reflect.String || !parent.CanSet() {
+			return fmt.Errorf("settings path %q is not settable", path)
+		}
+
+		elem := reflect.New(parent.Type().Elem()).Elem()
+		if err := assign(elem); err != nil {
+			return fmt.Errorf("invalid settings path %q value: %w", path, err)
+		}
+
+		if parent.IsNil() {
+			parent.Set(reflect.MakeMap(parent.Type()))
+		}
+		parent.SetMapIndex(reflect.ValueOf(lastKey).Convert(parent.Type().Key()), elem)
+
+		return nil
+	}
+
+	target, err := resolveSettingsPath(parent, []string{lastKey})
+	if err != nil {
+		return err
+	}
+
+	if !target.CanSet() {
+		return fmt.Errorf("settings path %q is not settable", path)
+	}
+
+	if err := assign(target); err != nil {
+		return fmt.Errorf("invalid settings path %q value: %w", path, err)
+	}
+
+	return nil
+}
+
+func splitSettingsPath(path string) []string {
+	path = strings.Trim(path, ". ")
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, ".")
+}
+
+// resolveSettingsPath walks the struct fields (by their json tag name),
+// slice items (by their index) and map entries of v following the specified keys.
+func resolveSettingsPath(v reflect.Value, keys []string) (reflect.Value, error) {
+	for i, key := range keys {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, fmt.Errorf("missing settings path %q", strings.Join(keys[:i+1], "."))
+			}
+			v = v.Elem()
+		}
+
+		var next reflect.Value
+
+		switch v.Kind() {
+		case reflect.Struct:
+			next = settingsStructField(v, key)
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(key)
+			if err == nil && index >= 0 && index < v.Len() {
+				next = v.Index(index)
+			}
+		case reflect.Map:
+			if v.Type().Key().Kind() == reflect.String {
+				next = v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+			}
+		}
+
+		if !next.IsValid() {
+			return reflect.Value{}, fmt.Errorf("missing settings path %q", strings.Join(keys[:i+1], "."))
+		}
+
+		v = next
+	}
+
+	return v, nil
+}
+
+// settingsStructField returns the struct field matching
+// case-insensitively the specified json key name.
+func settingsStructField(v reflect.Value, key string) reflect.Value {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if strings.EqualFold(name, key) {
+			return v.Field(i)
+		}
+	}
+
+	return reflect.Value{}
+}
+
+func assignSettingsValue(target reflect.Value, rawValue string) error {
+	if target.Kind() == reflect.String {
+		target.SetString(rawValue)
+		return nil
+	}
+
+	return json.Unmarshal([]byte(rawValue), target.Addr().Interface())
+}
@@ -0,0 +1,123 @@
+package core_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSettingsGetPath(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	s, err := app.Settings().Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SMTP.Port = 25
+	s.SMTP.Host = "example.com"
+	s.SMTP.Password = "secret"
+	s.Logs.Sinks = []core.LogSinkConfig{{Type: "webhook", URL: "https://example.com"}}
+
+	scenarios := []struct {
+		path        string
+		expected    any
+		expectError bool
+	}{
+		{"", nil, false}, // the entire settings
+		{"missing", nil, true},
+		{"smtp.missing", nil, true},
+		{"smtp.host", "example.com", false},
+		{"SMTP.HOST", "example.com", false},
+		{"smtp.password", "secret", false},
+		{"smtp.port", 25, false},
+		{"logs.sinks.0.url", "https://example.com", false},
+		{"logs.sinks.1.url", nil, true},
+	}
+
+	for _, s2 := range scenarios {
+		t.Run(s2.path, func(t *testing.T) {
+			value, err := s.GetPath(s2.path)
+
+			hasErr := err != nil
+			if hasErr != s2.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s2.expectError, hasErr, err)
+			}
+
+			if hasErr || s2.path == "" {
+				return
+			}
+
+			if !reflect.DeepEqual(value, s2.expected) {
+				t.Fatalf("Expected %#v, got %#v", s2.expected, value)
+			}
+		})
+	}
+}
+
+func TestSettingsSetPath(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		path        string
+		value       string
+		expectError bool
+		check       func(t *testing.T, s *core.Settings)
+	}{
+		{"", "abc", true, nil},
+		{"missing", "abc", true, nil},
+		{"smtp.port", "invalid", true, nil},
+		{"smtp.host", "example.com", false, func(t *testing.T, s *core.Settings) {
+			if s.SMTP.Host != "example.com" {
+				t.Fatalf("Expected smtp.host %q, got %q", "example.com", s.SMTP.Host)
+			}
+		}},
+		{"smtp.enabled", "true", false, func(t *testing.T, s *core.Settings) {
+			if !s.SMTP.Enabled {
+				t.Fatal("Expected smtp.enabled to be true")
+			}
+		}},
+		{"Meta.AppName", "test", false, func(t *testing.T, s *core.Settings) {
+			if s.Meta.AppName != "test" {
+				t.Fatalf("Expected meta.appName %q, got %q", "test", s.Meta.AppName)
+			}
+		}},
+		{"trustedProxy.headers", `["X-Real-IP"]`, false, func(t *testing.T, s *core.Settings) {
+			if len(s.TrustedProxy.Headers) != 1 || s.TrustedProxy.Headers[0] != "X-Real-IP" {
+				t.Fatalf("Expected trustedProxy.headers [X-Real-IP], got %v", s.TrustedProxy.Headers)
+			}
+		}},
+		{"extensions.demo", `{"a":1}`, false, func(t *testing.T, s *core.Settings) {
+			if v := s.Extensions["demo"].String(); v != `{"a":1}` {
+				t.Fatalf("Expected extensions.demo %q, got %q", `{"a":1}`, v)
+			}
+		}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			settings, err := app.Settings().Clone()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = settings.SetPath(s.path, s.value)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if s.check != nil {
+				s.check(t, settings)
+			}
+		})
+	}
+}
@@ -84,5 +84,10 @@ func (s *Settings) loadParam(app App, param *Param) error {
 		}
 	}
 
+	// the env variables take precedence over the stored values
+	if err := s.applyEnvOverrides(); err != nil {
+		return err
+	}
+
 	return s.PostScan()
 }
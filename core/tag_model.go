@@ -0,0 +1,28 @@
+package core
+
+import (
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*Tag)(nil)
+
+const TagsTableName = "_tags"
+
+// Tag defines a single usage counter entry of a collection "tags" field value
+// (see [TagsField]).
+//
+// The tag entries are maintained automatically on record save/delete.
+type Tag struct {
+	BaseModel
+
+	CollectionRef string         `db:"collectionRef" json:"collectionRef"`
+	FieldRef      string         `db:"fieldRef" json:"fieldRef"`
+	Tag           string         `db:"tag" json:"tag"`
+	Count         int            `db:"count" json:"count"`
+	Created       types.DateTime `db:"created" json:"created"`
+	Updated       types.DateTime `db:"updated" json:"updated"`
+}
+
+func (m *Tag) TableName() string {
+	return TagsTableName
+}
@@ -0,0 +1,365 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// DefaultTagSuggestionsLimit is the default max number of returned tag suggestions.
+const DefaultTagSuggestionsLimit = 10
+
+// TagQuery returns a new Tag select query.
+func (app *BaseApp) TagQuery() *dbx.SelectQuery {
+	return app.ModelQuery(&Tag{})
+}
+
+// FindTagSuggestions returns the most used tags of the specified collection tags field
+// that start with the provided prefix (ASCII case-insensitive).
+//
+// If limit <= 0, fallbacks to DefaultTagSuggestionsLimit.
+func (app *BaseApp) FindTagSuggestions(collectionModelOrIdentifier any, fieldName string, prefix string, limit int) ([]*Tag, error) {
+	collection, field, err := findTagsField(app, collectionModelOrIdentifier, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = DefaultTagSuggestionsLimit
+	}
+
+	query := app.TagQuery().
+		AndWhere(dbx.HashExp{
+			"collectionRef": collection.Id,
+			"fieldRef":      field.Id,
+		}).
+		AndWhere(dbx.NewExp("[[count]] > 0")).
+		OrderBy("count DESC", "tag ASC").
+		Limit(int64(limit))
+
+	if prefix = field.NormalizeTag(prefix); prefix != "" {
+		query.AndWhere(dbx.Like("tag", prefix).Match(false, true))
+	}
+
+	result := []*Tag{}
+
+	if err := query.All(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RenameTag replaces the oldTag with newTag in all records of the specified
+// collection tags field and returns the number of the updated records.
+//
+// If newTag is already used by a record, the two tags are merged.
+func (app *BaseApp) RenameTag(collectionModelOrIdentifier any, fieldName string, oldTag string, newTag string) (int, error) {
+	return app.MergeTags(collectionModelOrIdentifier, fieldName, newTag, oldTag)
+}
+
+// MergeTags replaces the source tags with the target tag in all records of the specified
+// collection tags field and returns the number of the updated records.
+//
+// The records are saved without validations but the target tag must satisfy the field max length.
+func (app *BaseApp) MergeTags(collectionModelOrIdentifier any, fieldName string, target string, sources ...string) (int, error) {
+	collection, field, err := findTagsField(app, collectionModelOrIdentifier, fieldName)
+	if err != nil {
+		return 0, err
+	}
+
+	target = field.NormalizeTag(target)
+	if target == "" {
+		return 0, errors.New("the target tag must be non-empty")
+	}
+
+	maxLength := field.MaxLength
+	if maxLength <= 0 {
+		maxLength = DefaultTagMaxLength
+	}
+	if utf8.RuneCountInString(target) > maxLength {
+		return 0, fmt.Errorf("the target tag must be no more than %d character(s)", maxLength)
+	}
+
+	normalizedSources := make([]string, 0, len(sources))
+	for _, s := range sources {
+		s = field.NormalizeTag(s)
+		if s != "" && s != target {
+			normalizedSources = append(normalizedSources, s)
+		}
+	}
+	normalizedSources = list.ToUniqueStringSlice(normalizedSources)
+
+	if len(normalizedSources) == 0 {
+		return 0, nil // nothing to merge
+	}
+
+	var total int
+
+	err = app.RunInTransaction(func(txApp App) error {
+		tableName := inflector.Columnify(collection.Name)
+
+		params := dbx.Params{}
+		placeholders := make([]string, len(normalizedSources))
+		for i, source := range normalizedSources {
+			key := "pbMergeTag" + strconv.Itoa(i)
+			params[key] = source
+			placeholders[i] = "{:" + key + "}"
+		}
+
+		records := []*Record{}
+
+		err := txApp.RecordQuery(collection).
+			AndWhere(dbx.Exists(dbx.NewExp(
+				"SELECT 1 FROM "+dbutils.JSONEach(tableName+"."+field.Name)+" {{__je__}} "+
+					"WHERE [[__je__.value]] IN ("+strings.Join(placeholders, ", ")+")",
+				params,
+			))).
+			All(&records)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			tags := record.GetStringSlice(field.Name)
+
+			for i, tag := range tags {
+				if slices.Contains(normalizedSources, tag) {
+					tags[i] = target
+				}
+			}
+
+			record.Set(field.Name, tags)
+
+			if err := txApp.SaveNoValidate(record); err != nil {
+				return err
+			}
+
+			total++
+		}
+
+		return nil
+	})
+
+	return total, err
+}
+
+// RebuildTagsCounts recalculates the usage counts of all tags fields
+// of the specified collection from the stored records values
+// (e.g. after importing records with raw db queries).
+func (app *BaseApp) RebuildTagsCounts(collectionModelOrIdentifier any) error {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return err
+	}
+
+	return app.RunInTransaction(func(txApp App) error {
+		for _, f := range collection.Fields {
+			if f.Type() != FieldTypeTags {
+				continue
+			}
+
+			if err := rebuildTagsFieldCounts(txApp, collection, f); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func rebuildTagsFieldCounts(app App, collection *Collection, field Field) error {
+	_, err := app.DB().Delete(TagsTableName, dbx.HashExp{
+		"collectionRef": collection.Id,
+		"fieldRef":      field.GetId(),
+	}).Execute()
+	if err != nil {
+		return err
+	}
+
+	tableName := inflector.Columnify(collection.Name)
+
+	_, err = app.DB().NewQuery(
+		"INSERT INTO {{" + TagsTableName + "}} ([[collectionRef]], [[fieldRef]], [[tag]], [[count]]) " +
+			"SELECT {:collectionRef}, {:fieldRef}, [[__je__.value]], COUNT(*) " +
+			"FROM {{" + tableName + "}}, " + dbutils.JSONEach(tableName+"."+field.GetName()) + " {{__je__}} " +
+			"WHERE COALESCE([[__je__.value]], '') != '' " +
+			"GROUP BY [[__je__.value]]",
+	).Bind(dbx.Params{
+		"collectionRef": collection.Id,
+		"fieldRef":      field.GetId(),
+	}).Execute()
+
+	return err
+}
+
+// updateTagsCounts increments (or decrements) the usage counts of the specified tags
+// and removes the unused ones.
+func updateTagsCounts(app App, collectionId string, fieldId string, deltas map[string]int) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	now := types.NowDateTime().String()
+
+	for tag, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+
+		_, err := app.DB().NewQuery(
+			"INSERT INTO {{" + TagsTableName + "}} ([[collectionRef]], [[fieldRef]], [[tag]], [[count]], [[created]], [[updated]]) " +
+				"VALUES ({:collectionRef}, {:fieldRef}, {:tag}, {:delta}, {:now}, {:now}) " +
+				"ON CONFLICT ([[collectionRef]], [[fieldRef]], [[tag]]) DO UPDATE SET " +
+				"[[count]] = [[count]] + {:delta}, [[updated]] = {:now}",
+		).Bind(dbx.Params{
+			"collectionRef": collectionId,
+			"fieldRef":      fieldId,
+			"tag":           tag,
+			"delta":         delta,
+			"now":           now,
+		}).Execute()
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := app.DB().Delete(TagsTableName, dbx.And(
+		dbx.HashExp{"collectionRef": collectionId, "fieldRef": fieldId},
+		dbx.NewExp("[[count]] <= 0"),
+	)).Execute()
+
+	return err
+}
+
+// tagsDeltas returns the usage count changes between the old and new tags lists.
+func tagsDeltas(oldTags []string, newTags []string) map[string]int {
+	deltas := map[string]int{}
+
+	for _, tag := range oldTags {
+		deltas[tag]--
+	}
+
+	for _, tag := range newTags {
+		deltas[tag]++
+	}
+
+	for tag, delta := range deltas {
+		if delta == 0 {
+			delete(deltas, tag)
+		}
+	}
+
+	return deltas
+}
+
+func findTagsField(app App, collectionModelOrIdentifier any, fieldName string) (*Collection, *TagsField, error) {
+	collection, err := getCollectionByModelOrIdentifier(app, collectionModelOrIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	field, ok := collection.Fields.GetByName(fieldName).(*TagsField)
+	if !ok {
+		return nil, nil, fmt.Errorf("missing tags field %q in collection %q", fieldName, collection.Name)
+	}
+
+	return collection, field, nil
+}
+
+func (app *BaseApp) registerTagsHooks() {
+	syncCounts := func(e *RecordEvent, oldTags map[string][]string, newTags map[string][]string) error {
+		for _, f := range e.Record.Collection().Fields {
+			if f.Type() != FieldTypeTags {
+				continue
+			}
+
+			err := updateTagsCounts(e.App, e.Record.Collection().Id, f.GetId(), tagsDeltas(oldTags[f.GetId()], newTags[f.GetId()]))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	recordTags := func(record *Record) map[string][]string {
+		result := map[string][]string{}
+
+		for _, f := range record.Collection().Fields {
+			if f.Type() == FieldTypeTags {
+				result[f.GetId()] = record.GetStringSlice(f.GetName())
+			}
+		}
+
+		return result
+	}
+
+	app.OnRecordCreateExecute().BindFunc(func(e *RecordEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		return syncCounts(e, nil, recordTags(e.Record))
+	})
+
+	app.OnRecordUpdateExecute().BindFunc(func(e *RecordEvent) error {
+		oldTags := recordTags(e.Record.Original())
+
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		return syncCounts(e, oldTags, recordTags(e.Record))
+	})
+
+	app.OnRecordDeleteExecute().BindFunc(func(e *RecordEvent) error {
+		oldTags := recordTags(e.Record)
+
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		return syncCounts(e, oldTags, nil)
+	})
+
+	app.OnCollectionUpdateExecute().BindFunc(func(e *CollectionEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		fieldIds := []any{}
+		for _, f := range e.Collection.Fields {
+			if f.Type() == FieldTypeTags {
+				fieldIds = append(fieldIds, f.GetId())
+			}
+		}
+
+		// remove the counts of the deleted tags fields
+		_, err := e.App.DB().Delete(TagsTableName, dbx.And(
+			dbx.HashExp{"collectionRef": e.Collection.Id},
+			dbx.Not(dbx.In("fieldRef", fieldIds...)),
+		)).Execute()
+
+		return err
+	})
+
+	app.OnCollectionDeleteExecute().BindFunc(func(e *CollectionEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		_, err := e.App.DB().Delete(TagsTableName, dbx.HashExp{"collectionRef": e.Collection.Id}).Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,199 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createTagsCollection(t testing.TB, app core.App) *core.Collection {
+	collection := core.NewBaseCollection("test_tags")
+	collection.Fields.Add(&core.TagsField{Name: "tags", Lowercase: true})
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string][]string{
+		"a00000000000000": {"go", "sqlite"},
+		"b00000000000000": {"Go", "golang"},
+		"c00000000000000": {"go", "js"},
+	}
+	for id, tags := range data {
+		record := core.NewRecord(collection)
+		record.Id = id
+		record.Set("tags", tags)
+		if err := app.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return collection
+}
+
+func checkTagsCounts(t *testing.T, app core.App, collection *core.Collection, expected map[string]int) {
+	t.Helper()
+
+	tags := []*core.Tag{}
+	if err := app.TagQuery().AndWhere(dbx.HashExp{"collectionRef": collection.Id}).All(&tags); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tags) != len(expected) {
+		t.Fatalf("Expected %d tags, got %d", len(expected), len(tags))
+	}
+
+	for _, tag := range tags {
+		if count, ok := expected[tag.Tag]; !ok || count != tag.Count {
+			t.Fatalf("Expected tag %q count %d, got %d", tag.Tag, count, tag.Count)
+		}
+	}
+}
+
+func TestTagsCounts(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTagsCollection(t, app)
+
+	checkTagsCounts(t, app, collection, map[string]int{"go": 3, "sqlite": 1, "golang": 1, "js": 1})
+
+	// update
+	record, err := app.FindRecordById(collection, "a00000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("tags", []string{"go", "js", "new"})
+	if err := app.Save(record); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTagsCounts(t, app, collection, map[string]int{"go": 3, "golang": 1, "js": 2, "new": 1})
+
+	// delete
+	if err := app.Delete(record); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTagsCounts(t, app, collection, map[string]int{"go": 2, "golang": 1, "js": 1})
+
+	// field delete
+	collection.Fields.RemoveByName("tags")
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTagsCounts(t, app, collection, map[string]int{})
+}
+
+func TestRebuildTagsCounts(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTagsCollection(t, app)
+
+	// raw import bypassing the record hooks
+	_, err := app.DB().NewQuery(`INSERT INTO {{test_tags}} ([[id]], [[tags]]) VALUES ('d00000000000000', '["js","new"]')`).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkTagsCounts(t, app, collection, map[string]int{"go": 3, "sqlite": 1, "golang": 1, "js": 1})
+
+	if err := app.RebuildTagsCounts(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTagsCounts(t, app, collection, map[string]int{"go": 3, "sqlite": 1, "golang": 1, "js": 2, "new": 1})
+}
+
+func TestFindTagSuggestions(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTagsCollection(t, app)
+
+	scenarios := []struct {
+		prefix   string
+		limit    int
+		expected []string
+	}{
+		{"", 0, []string{"go", "golang", "js", "sqlite"}},
+		{"", 2, []string{"go", "golang"}},
+		{"GO", 0, []string{"go", "golang"}},
+		{"gol", 0, []string{"golang"}},
+		{"%", 0, []string{}},
+		{"missing", 0, []string{}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.prefix, func(t *testing.T) {
+			tags, err := app.FindTagSuggestions(collection, "tags", s.prefix, s.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(tags) != len(s.expected) {
+				t.Fatalf("Expected %d tags, got %d", len(s.expected), len(tags))
+			}
+
+			for i, tag := range tags {
+				if tag.Tag != s.expected[i] {
+					t.Fatalf("Expected tag %d to be %q, got %q", i, s.expected[i], tag.Tag)
+				}
+			}
+		})
+	}
+
+	if _, err := app.FindTagSuggestions(collection, "missing", "", 0); err == nil {
+		t.Fatal("Expected missing field error")
+	}
+}
+
+func TestRenameAndMergeTags(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTagsCollection(t, app)
+
+	total, err := app.RenameTag(collection, "tags", "GOLANG", "Go-Lang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("Expected 1 updated record, got %d", total)
+	}
+
+	checkTagsCounts(t, app, collection, map[string]int{"go": 3, "sqlite": 1, "go-lang": 1, "js": 1})
+
+	total, err = app.MergeTags(collection, "tags", "go", "go-lang", "js", "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Fatalf("Expected 2 updated records, got %d", total)
+	}
+
+	checkTagsCounts(t, app, collection, map[string]int{"go": 3, "sqlite": 1})
+
+	record, err := app.FindRecordById(collection, "c00000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags := record.GetStringSlice("tags"); len(tags) != 1 || tags[0] != "go" {
+		t.Fatalf("Expected the merged tags to be deduplicated, got %v", tags)
+	}
+
+	if _, err := app.MergeTags(collection, "tags", "", "go"); err == nil {
+		t.Fatal("Expected empty target error")
+	}
+}
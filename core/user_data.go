@@ -0,0 +1,486 @@
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+)
+
+// The supported UserDataErasureRule.Strategy values.
+const (
+	// UserDataErasureDelete deletes the records referencing the erased user.
+	UserDataErasureDelete = "delete"
+
+	// UserDataErasureUnlink removes the erased user id from the
+	// records relation fields but otherwise keeps the records unchanged
+	// (this is the default strategy).
+	UserDataErasureUnlink = "unlink"
+
+	// UserDataErasureAnonymize removes the erased user id from the
+	// records relation fields and resets the rule fields to their zero value.
+	UserDataErasureAnonymize = "anonymize"
+)
+
+// UserDataConfig defines the auth records data export and erasure settings.
+type UserDataConfig struct {
+	// SelfService enables the auth records self-service
+	// "export-data" and "erase-data" endpoints.
+	SelfService bool `form:"selfService" json:"selfService"`
+
+	// Erasure is the list of the per collection erasure rules.
+	//
+	// The referencing records of collections without an explicit rule are unlinked.
+	Erasure []UserDataErasureRule `form:"erasure" json:"erasure"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c UserDataConfig) MarshalJSON() ([]byte, error) {
+	type alias UserDataConfig
+
+	// serialize as empty array
+	if c.Erasure == nil {
+		c.Erasure = []UserDataErasureRule{}
+	}
+
+	return json.Marshal(alias(c))
+}
+
+// Validate makes UserDataConfig validatable by implementing [validation.Validatable] interface.
+func (c UserDataConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Erasure),
+	)
+}
+
+// FindErasureRule returns the erasure rule of the specified collection
+// (fallbacks to an "unlink" strategy rule if there is no explicit one).
+func (c UserDataConfig) FindErasureRule(collection *Collection) UserDataErasureRule {
+	for _, rule := range c.Erasure {
+		if rule.Collection == collection.Name || rule.Collection == collection.Id {
+			if rule.Strategy == "" {
+				rule.Strategy = UserDataErasureUnlink
+			}
+			return rule
+		}
+	}
+
+	return UserDataErasureRule{Collection: collection.Name, Strategy: UserDataErasureUnlink}
+}
+
+// UserDataErasureRule defines the erasure strategy of the records
+// from a single collection that reference the erased user.
+type UserDataErasureRule struct {
+	// Collection is the name or id of the referencing collection.
+	Collection string `form:"collection" json:"collection"`
+
+	// Strategy specifies what to do with the referencing records
+	// ("unlink" (default), "delete" or "anonymize").
+	Strategy string `form:"strategy" json:"strategy"`
+
+	// Fields is the list of the field names to reset with the "anonymize" strategy.
+	Fields []string `form:"fields" json:"fields"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (r UserDataErasureRule) MarshalJSON() ([]byte, error) {
+	type alias UserDataErasureRule
+
+	// serialize as empty array
+	if r.Fields == nil {
+		r.Fields = []string{}
+	}
+
+	return json.Marshal(alias(r))
+}
+
+// Validate makes UserDataErasureRule validatable by implementing [validation.Validatable] interface.
+func (r UserDataErasureRule) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Collection, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.Strategy, validation.In(UserDataErasureDelete, UserDataErasureUnlink, UserDataErasureAnonymize)),
+		validation.Field(&r.Fields, validation.When(r.Strategy == UserDataErasureAnonymize, validation.Required)),
+	)
+}
+
+// validateUserDataSettings checks whether the erasure rules collections and fields exist.
+func validateUserDataSettings(app App, config UserDataConfig) error {
+	errs := validation.Errors{}
+
+	for i, rule := range config.Erasure {
+		if rule.Collection == "" {
+			continue // already checked by the rule Validate
+		}
+
+		collection, err := app.FindCachedCollectionByNameOrId(rule.Collection)
+		if err != nil {
+			errs[fmt.Sprint(i)] = validation.Errors{
+				"collection": validation.NewError("validation_missing_collection", "Missing or invalid collection."),
+			}
+			continue
+		}
+
+		if collection.IsView() {
+			errs[fmt.Sprint(i)] = validation.Errors{
+				"collection": validation.NewError("validation_view_collection", "View collections are not supported."),
+			}
+			continue
+		}
+
+		for _, name := range rule.Fields {
+			field := collection.Fields.GetByName(name)
+			if field == nil || field.GetName() == FieldNameId {
+				errs[fmt.Sprint(i)] = validation.Errors{
+					"fields": validation.NewError("validation_invalid_erasure_field", "Missing or invalid field "+name+"."),
+				}
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return validation.Errors{"erasure": errs}
+	}
+
+	return nil
+}
+
+// ErrUserDataAccessDenied is returned by the self-service user data
+// erasure when the referencing collection API rule doesn't allow the change.
+var ErrUserDataAccessDenied = errors.New("the user data erasure is not allowed by the collection API rules")
+
+// -------------------------------------------------------------------
+
+// ExportUserData returns a zip archive with all data of the specified
+// auth record and the records referencing it, including their files.
+//
+// The archive has the following structure:
+//
+//	user.json                                  - the auth record data
+//	externalAuths.json                         - the linked OAuth2 providers
+//	records/{collection}.json                  - the referencing records of each collection
+//	files/{collection}/{recordId}/{filename}   - the auth and referencing records files
+//
+// All record fields, including the hidden ones, are exported except
+// the auth password and tokenKey.
+func (app *BaseApp) ExportUserData(authRecord *Record) ([]byte, error) {
+	return app.ExportUserDataWithContext(context.Background(), authRecord)
+}
+
+// ExportUserDataWithContext is the same as [BaseApp.ExportUserData]
+// but allows specifying a custom context.
+func (app *BaseApp) ExportUserDataWithContext(ctx context.Context, authRecord *Record) ([]byte, error) {
+	return app.exportUserData(ctx, authRecord, true)
+}
+
+// ExportUserDataWithRequestInfo is the self-service version of
+// [BaseApp.ExportUserDataWithContext] that exports the data of requestInfo.Auth.
+//
+// The hidden fields of the referencing records are not exported
+// (unless requestInfo.Auth is a superuser).
+func (app *BaseApp) ExportUserDataWithRequestInfo(ctx context.Context, requestInfo *RequestInfo) ([]byte, error) {
+	if requestInfo == nil || requestInfo.Auth == nil {
+		return nil, errors.New("missing request info auth record")
+	}
+
+	return app.exportUserData(ctx, requestInfo.Auth, requestInfo.HasSuperuserAuth())
+}
+
+func (app *BaseApp) exportUserData(ctx context.Context, authRecord *Record, unhideRefs bool) ([]byte, error) {
+	if !authRecord.Collection().IsAuth() {
+		return nil, errors.New("the record must be from an auth collection")
+	}
+
+	refs, err := findUserDataReferences(ctx, app, authRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	externalAuths, err := app.FindAllExternalAuthsByRecord(authRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		return nil, err
+	}
+	defer fsys.Close()
+
+	fsys.SetContext(ctx)
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	writeJSON := func(name string, data any) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(data)
+	}
+
+	if err := writeJSON("user.json", userDataExport(authRecord, true)); err != nil {
+		return nil, err
+	}
+
+	if err := writeJSON("externalAuths.json", externalAuths); err != nil {
+		return nil, err
+	}
+
+	if err := zipAddRecordFiles(zw, fsys, authRecord, true); err != nil {
+		return nil, err
+	}
+
+	for _, ref := range refs {
+		exports := make([]map[string]any, len(ref.records))
+		for i, record := range ref.records {
+			exports[i] = userDataExport(record, unhideRefs)
+
+			if err := zipAddRecordFiles(zw, fsys, record, unhideRefs); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := writeJSON("records/"+ref.collection.Name+".json", exports); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// EraseUserData deletes the specified auth record and applies the
+// configured in the app settings erasure strategy to each of its
+// referencing records (by default the referencing records are unlinked).
+func (app *BaseApp) EraseUserData(authRecord *Record) error {
+	return app.EraseUserDataWithContext(context.Background(), authRecord)
+}
+
+// EraseUserDataWithContext is the same as [BaseApp.EraseUserData]
+// but allows specifying a custom context.
+func (app *BaseApp) EraseUserDataWithContext(ctx context.Context, authRecord *Record) error {
+	return app.eraseUserData(ctx, authRecord, nil)
+}
+
+// EraseUserDataWithRequestInfo is the self-service version of
+// [BaseApp.EraseUserDataWithContext] that erases the data of requestInfo.Auth.
+//
+// Before applying the erasure strategy of a referencing record, its collection
+// DeleteRule (for the "delete" strategy) or UpdateRule (for the "unlink" and
+// "anonymize" strategies) is checked against requestInfo and
+// [ErrUserDataAccessDenied] is returned if the rule doesn't match.
+func (app *BaseApp) EraseUserDataWithRequestInfo(ctx context.Context, requestInfo *RequestInfo) error {
+	if requestInfo == nil || requestInfo.Auth == nil {
+		return errors.New("missing request info auth record")
+	}
+
+	return app.eraseUserData(ctx, requestInfo.Auth, requestInfo)
+}
+
+// eraseUserData erases the auth record data.
+//
+// If requestInfo is not nil, the referencing collections API rules are checked.
+func (app *BaseApp) eraseUserData(ctx context.Context, authRecord *Record, requestInfo *RequestInfo) error {
+	if !authRecord.Collection().IsAuth() {
+		return errors.New("the record must be from an auth collection")
+	}
+
+	config := app.Settings().UserData
+
+	return app.RunInTransactionWithContext(ctx, func(txApp App) error {
+		refs, err := findUserDataReferences(ctx, txApp, authRecord)
+		if err != nil {
+			return err
+		}
+
+		for _, ref := range refs {
+			rule := config.FindErasureRule(ref.collection)
+
+			accessRule := ref.collection.UpdateRule
+			if rule.Strategy == UserDataErasureDelete {
+				accessRule = ref.collection.DeleteRule
+			}
+
+			for _, record := range ref.records {
+				if requestInfo != nil {
+					canAccess, err := txApp.CanAccessRecord(record, requestInfo, accessRule)
+					if err != nil {
+						return err
+					}
+					if !canAccess {
+						return fmt.Errorf("%w (%q record %q)", ErrUserDataAccessDenied, ref.collection.Name, record.Id)
+					}
+				}
+
+				if rule.Strategy == UserDataErasureDelete {
+					if err := txApp.DeleteWithContext(ctx, record); err != nil {
+						return fmt.Errorf("failed to delete %q record %q: %w", ref.collection.Name, record.Id, err)
+					}
+					continue
+				}
+
+				for _, field := range ref.fields {
+					ids := slices.DeleteFunc(record.GetStringSlice(field.GetName()), func(id string) bool {
+						return id == authRecord.Id
+					})
+					record.Set(field.GetName(), ids)
+				}
+
+				if rule.Strategy == UserDataErasureAnonymize {
+					for _, name := range rule.Fields {
+						record.Set(name, nil)
+					}
+				}
+
+				if err := txApp.SaveNoValidateWithContext(ctx, record); err != nil {
+					return fmt.Errorf("failed to update %q record %q: %w", ref.collection.Name, record.Id, err)
+				}
+			}
+		}
+
+		return txApp.DeleteWithContext(ctx, authRecord)
+	})
+}
+
+type userDataReference struct {
+	collection *Collection
+	fields     []Field
+	records    []*Record
+}
+
+// findUserDataReferences returns the non-view collections records
+// referencing the provided auth record (the auth record itself is excluded).
+func findUserDataReferences(ctx context.Context, app App, authRecord *Record) ([]*userDataReference, error) {
+	refs, err := app.FindCollectionReferences(authRecord.Collection())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*userDataReference, 0, len(refs))
+
+	for refCollection, fields := range refs {
+		if refCollection.IsView() {
+			continue
+		}
+
+		tableName := inflector.Columnify(refCollection.Name)
+
+		exprs := make([]dbx.Expression, 0, len(fields))
+		for _, field := range fields {
+			prefixedFieldName := tableName + "." + inflector.Columnify(field.GetName())
+
+			if opt, ok := field.(MultiValuer); !ok || !opt.IsMultiple() {
+				exprs = append(exprs, dbx.HashExp{prefixedFieldName: authRecord.Id})
+			} else {
+				exprs = append(exprs, dbx.Exists(dbx.NewExp(
+					"SELECT 1 FROM "+dbutils.JSONEach(prefixedFieldName)+" {{__je__}} WHERE [[__je__.value]]={:pbUserId}",
+					dbx.Params{"pbUserId": authRecord.Id},
+				)))
+			}
+		}
+
+		records := []*Record{}
+
+		err := app.RecordQuery(refCollection).
+			WithContext(ctx).
+			AndWhere(dbx.Or(exprs...)).
+			AndWhere(dbx.Not(dbx.HashExp{tableName + ".id": authRecord.Id})).
+			OrderBy("[[" + tableName + ".id]] ASC").
+			All(&records)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(records) == 0 {
+			continue
+		}
+
+		result = append(result, &userDataReference{
+			collection: refCollection,
+			fields:     fields,
+			records:    records,
+		})
+	}
+
+	// sort for deterministic export and events order
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].collection.Name < result[j].collection.Name
+	})
+
+	return result, nil
+}
+
+// userDataExport returns the record public export.
+//
+// If unhide is set, all record fields are exported
+// (except the auth password and tokenKey).
+func userDataExport(record *Record, unhide bool) map[string]any {
+	if !unhide {
+		return record.Fresh().PublicExport()
+	}
+
+	return record.Fresh().
+		IgnoreEmailVisibility(true).
+		Unhide(record.Collection().Fields.FieldNames()...).
+		PublicExport()
+}
+
+// zipAddRecordFiles writes the record files in the zip archive
+// (the missing storage files are skipped).
+//
+// If includeHidden is not set, the hidden file fields are skipped.
+func zipAddRecordFiles(zw *zip.Writer, fsys *filesystem.System, record *Record, includeHidden bool) error {
+	for _, field := range record.Collection().Fields {
+		if field.Type() != FieldTypeFile || (!includeHidden && field.GetHidden()) {
+			continue
+		}
+
+		for _, name := range record.GetStringSlice(field.GetName()) {
+			err := func() error {
+				r, err := fsys.GetReader(record.BaseFilesPath() + "/" + name)
+				if err != nil {
+					if errors.Is(err, filesystem.ErrNotFound) {
+						return nil
+					}
+					return err
+				}
+				defer r.Close()
+
+				w, err := zw.Create("files/" + record.Collection().Name + "/" + record.Id + "/" + name)
+				if err != nil {
+					return err
+				}
+
+				_, err = io.Copy(w, r)
+
+				return err
+			}()
+			if err != nil {
+				return fmt.Errorf("failed to export file %q: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,400 @@
+package core_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestUserDataConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		config         core.UserDataConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			core.UserDataConfig{},
+			[]string{},
+		},
+		{
+			"invalid rules",
+			core.UserDataConfig{
+				Erasure: []core.UserDataErasureRule{
+					{},
+					{Collection: "demo1", Strategy: "invalid"},
+					{Collection: "demo1", Strategy: core.UserDataErasureAnonymize},
+				},
+			},
+			[]string{"erasure"},
+		},
+		{
+			"valid rules",
+			core.UserDataConfig{
+				SelfService: true,
+				Erasure: []core.UserDataErasureRule{
+					{Collection: "demo1"},
+					{Collection: "demo2", Strategy: core.UserDataErasureUnlink},
+					{Collection: "demo3", Strategy: core.UserDataErasureAnonymize, Fields: []string{"title"}},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			tests.TestValidationErrors(t, s.config.Validate(), s.expectedErrors)
+		})
+	}
+}
+
+func TestUserDataSettingsValidation(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().UserData.Erasure = []core.UserDataErasureRule{
+		{Collection: "missing"},
+		{Collection: "view1"},
+		{Collection: "demo1", Strategy: core.UserDataErasureAnonymize, Fields: []string{"text", "missing"}},
+		{Collection: "demo1", Strategy: core.UserDataErasureAnonymize, Fields: []string{"text"}},
+	}
+
+	err := app.Validate(app.Settings())
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	for _, expected := range []string{"0:", "1:", "2:", "erasure"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected error to contain %q, got %v", expected, err)
+		}
+	}
+	if strings.Contains(err.Error(), "3:") {
+		t.Fatalf("Didn't expect the valid rule to fail, got %v", err)
+	}
+}
+
+func TestExportUserData(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo1Record, err := app.FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.ExportUserData(demo1Record); err == nil {
+		t.Fatal("Expected non-auth record error")
+	}
+
+	user, err := app.FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := app.ExportUserData(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(content)
+	}
+
+	expectedFiles := []string{
+		"user.json",
+		"externalAuths.json",
+		"records/demo1.json",
+		"files/users/4q1xlclmfloku33/300_1SEi6Q6U72.png",
+	}
+	for _, name := range expectedFiles {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("Missing archive file %q in %v", name, slices.Collect(maps.Keys(files)))
+		}
+	}
+
+	// user data
+	userData := map[string]any{}
+	if err := json.Unmarshal([]byte(files["user.json"]), &userData); err != nil {
+		t.Fatal(err)
+	}
+	if userData["email"] != "test@example.com" {
+		t.Fatalf("Expected the email to be exported, got %v", userData["email"])
+	}
+	for _, secret := range []string{"password", "tokenKey"} {
+		if _, ok := userData[secret]; ok {
+			t.Fatalf("Didn't expect %q to be exported", secret)
+		}
+	}
+
+	// external auths
+	if !strings.Contains(files["externalAuths.json"], `"provider": "gitlab"`) ||
+		!strings.Contains(files["externalAuths.json"], `"provider": "google"`) {
+		t.Fatalf("Expected the gitlab and google external auths, got %s", files["externalAuths.json"])
+	}
+
+	// referencing records
+	demo1Records := []map[string]any{}
+	if err := json.Unmarshal([]byte(files["records/demo1.json"]), &demo1Records); err != nil {
+		t.Fatal(err)
+	}
+	if len(demo1Records) != 1 || demo1Records[0]["id"] != "al1h9ijdeojtsjy" {
+		t.Fatalf("Expected only the al1h9ijdeojtsjy demo1 record, got %v", demo1Records)
+	}
+}
+
+func TestEraseUserData(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name   string
+		config core.UserDataConfig
+		check  func(t *testing.T, app core.App)
+	}{
+		{
+			"default unlink strategy",
+			core.UserDataConfig{},
+			func(t *testing.T, app core.App) {
+				for _, id := range []string{"84nmscqy84lsi1t", "al1h9ijdeojtsjy"} {
+					record, err := app.FindRecordById("demo1", id)
+					if err != nil {
+						t.Fatalf("Expected demo1 record %q to remain, got %v", id, err)
+					}
+					if slices.Contains(record.GetStringSlice("rel_many"), "oap640cot4yru2s") {
+						t.Fatalf("Expected the erased user to be unlinked from %q", id)
+					}
+				}
+			},
+		},
+		{
+			"delete strategy",
+			core.UserDataConfig{
+				Erasure: []core.UserDataErasureRule{{Collection: "demo1", Strategy: core.UserDataErasureDelete}},
+			},
+			func(t *testing.T, app core.App) {
+				for _, id := range []string{"84nmscqy84lsi1t", "al1h9ijdeojtsjy"} {
+					if _, err := app.FindRecordById("demo1", id); err == nil {
+						t.Fatalf("Expected demo1 record %q to be deleted", id)
+					}
+				}
+			},
+		},
+		{
+			"unlink strategy",
+			core.UserDataConfig{
+				Erasure: []core.UserDataErasureRule{{Collection: "demo1", Strategy: core.UserDataErasureUnlink}},
+			},
+			func(t *testing.T, app core.App) {
+				record, err := app.FindRecordById("demo1", "al1h9ijdeojtsjy")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if v := record.GetStringSlice("rel_many"); !slices.Equal(v, []string{"bgs820n361vj1qd", "4q1xlclmfloku33"}) {
+					t.Fatalf("Expected the erased user to be unlinked, got %v", v)
+				}
+
+				if v := record.GetString("text"); v == "" {
+					t.Fatal("Expected the text field to remain unchanged")
+				}
+			},
+		},
+		{
+			"anonymize strategy",
+			core.UserDataConfig{
+				Erasure: []core.UserDataErasureRule{{Collection: "demo1", Strategy: core.UserDataErasureAnonymize, Fields: []string{"text"}}},
+			},
+			func(t *testing.T, app core.App) {
+				record, err := app.FindRecordById("demo1", "84nmscqy84lsi1t")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if v := record.GetStringSlice("rel_many"); len(v) != 0 {
+					t.Fatalf("Expected the erased user to be unlinked, got %v", v)
+				}
+
+				if v := record.GetString("text"); v != "" {
+					t.Fatalf("Expected the text field to be reset, got %q", v)
+				}
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			app.Settings().UserData = s.config
+
+			user, err := app.FindRecordById("users", "oap640cot4yru2s")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := app.EraseUserData(user); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := app.FindRecordById("users", user.Id); err == nil {
+				t.Fatal("Expected the user record to be deleted")
+			}
+
+			s.check(t, app)
+		})
+	}
+}
+
+func TestEraseUserDataWithRequestInfo(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name        string
+		strategy    string
+		updateRule  *string
+		deleteRule  *string
+		expectError bool
+	}{
+		{"unlink with superusers only update rule", core.UserDataErasureUnlink, nil, types.Pointer(""), true},
+		{"unlink with non-matching update rule", core.UserDataErasureUnlink, types.Pointer("id = ''"), types.Pointer(""), true},
+		{"unlink with matching update rule", core.UserDataErasureUnlink, types.Pointer("rel_many.id ?= @request.auth.id"), nil, false},
+		{"delete with superusers only delete rule", core.UserDataErasureDelete, types.Pointer(""), nil, true},
+		{"delete with matching delete rule", core.UserDataErasureDelete, nil, types.Pointer("rel_many.id ?= @request.auth.id"), false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			app.Settings().UserData.Erasure = []core.UserDataErasureRule{{Collection: "demo1", Strategy: s.strategy}}
+
+			demo1, err := app.FindCollectionByNameOrId("demo1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			demo1.UpdateRule = s.updateRule
+			demo1.DeleteRule = s.deleteRule
+			if err := app.Save(demo1); err != nil {
+				t.Fatal(err)
+			}
+
+			user, err := app.FindRecordById("users", "oap640cot4yru2s")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = app.EraseUserDataWithRequestInfo(context.Background(), &core.RequestInfo{Auth: user})
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			_, findErr := app.FindRecordById("users", user.Id)
+			if s.expectError {
+				if !errors.Is(err, core.ErrUserDataAccessDenied) {
+					t.Fatalf("Expected ErrUserDataAccessDenied, got %v", err)
+				}
+				if findErr != nil {
+					t.Fatal("Expected the user record to remain")
+				}
+			} else if findErr == nil {
+				t.Fatal("Expected the user record to be deleted")
+			}
+		})
+	}
+}
+
+func TestExportUserDataWithRequestInfo(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo1, err := app.FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo1.Fields.GetByName("text").SetHidden(true)
+	if err := app.Save(demo1); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := app.FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readDemo1 := func(data []byte) []map[string]any {
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := zr.Open("records/demo1.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		result := []map[string]any{}
+		if err := json.NewDecoder(f).Decode(&result); err != nil {
+			t.Fatal(err)
+		}
+
+		return result
+	}
+
+	// privileged export
+	data, err := app.ExportUserData(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records := readDemo1(data); len(records) != 1 || records[0]["text"] == nil {
+		t.Fatalf("Expected the hidden text field to be exported, got %v", records)
+	}
+
+	// self-service export
+	data, err = app.ExportUserDataWithRequestInfo(context.Background(), &core.RequestInfo{Auth: user})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records := readDemo1(data); len(records) != 1 || records[0]["text"] != nil {
+		t.Fatalf("Expected the hidden text field to not be exported, got %v", records)
+	}
+}
@@ -1,14 +1,18 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pocketbase/pocketbase"
 	"github.com/pocketbase/pocketbase/apis"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/plugins/extplugin"
 	"github.com/pocketbase/pocketbase/plugins/ghupdate"
 	"github.com/pocketbase/pocketbase/plugins/jsvm"
 	"github.com/pocketbase/pocketbase/plugins/migratecmd"
@@ -63,6 +67,14 @@ func main() {
 		"enable/disable auto migrations",
 	)
 
+	var pluginsDir string
+	app.RootCmd.PersistentFlags().StringVar(
+		&pluginsDir,
+		"pluginsDir",
+		"",
+		"the directory with the external plugins executables",
+	)
+
 	var publicDir string
 	app.RootCmd.PersistentFlags().StringVar(
 		&publicDir,
@@ -79,6 +91,46 @@ func main() {
 		"fallback the request to index.html on missing static path, e.g. when pretty urls are used with SPA",
 	)
 
+	var publicMounts []string
+	app.RootCmd.PersistentFlags().StringArrayVar(
+		&publicMounts,
+		"publicMount",
+		nil,
+		"additional static files mount point in the format PREFIX=DIR, e.g. /docs=./pb_docs (could be repeated)",
+	)
+
+	var publicSPAs []string
+	app.RootCmd.PersistentFlags().StringSliceVar(
+		&publicSPAs,
+		"publicSPA",
+		nil,
+		"comma separated list of publicMount prefixes with index.html fallback on missing static path",
+	)
+
+	var publicCache []string
+	app.RootCmd.PersistentFlags().StringArrayVar(
+		&publicCache,
+		"publicCache",
+		nil,
+		"static files Cache-Control rule in the format PATTERN=VALUE, e.g. \"assets/*=max-age=31536000, immutable\" (could be repeated)",
+	)
+
+	var publicPrecompressed bool
+	app.RootCmd.PersistentFlags().BoolVar(
+		&publicPrecompressed,
+		"publicPrecompressed",
+		true,
+		"serve the precompressed .br and .gz static files variants (if they exist)",
+	)
+
+	var publicGzip bool
+	app.RootCmd.PersistentFlags().BoolVar(
+		&publicGzip,
+		"publicGzip",
+		false,
+		"gzip the static files responses on the fly",
+	)
+
 	app.RootCmd.ParseFlags(os.Args[1:])
 
 	// ---------------------------------------------------------------
@@ -93,6 +145,10 @@ func main() {
 		HooksPoolSize: hooksPool,
 	})
 
+	// interactive JS shell and eval commands
+	app.RootCmd.AddCommand(jsvm.NewShellCommand(app, jsvm.Config{HooksDir: hooksDir}))
+	app.RootCmd.AddCommand(jsvm.NewEvalCommand(app, jsvm.Config{HooksDir: hooksDir}))
+
 	// migrate command (with js templates)
 	migratecmd.MustRegister(app, app.RootCmd, migratecmd.Config{
 		TemplateLang: migratecmd.TemplateLangJS,
@@ -100,15 +156,56 @@ func main() {
 		Dir:          migrationsDir,
 	})
 
+	// external process plugins (pb_plugins)
+	extplugin.MustRegister(app, extplugin.Config{
+		Dir: pluginsDir,
+	})
+
 	// GitHub selfupdate
 	ghupdate.MustRegister(app, app.RootCmd, ghupdate.Config{})
 
-	// static route to serves files from the provided public dir
-	// (if publicDir exists and the route path is not already defined)
+	// static routes to serves files from the provided public dir and mount points
+	// (if the route path is not already defined)
 	app.OnServe().Bind(&hook.Handler[*core.ServeEvent]{
 		Func: func(e *core.ServeEvent) error {
-			if !e.Router.HasRoute(http.MethodGet, "/{path...}") {
-				e.Router.GET("/{path...}", apis.Static(os.DirFS(publicDir), indexFallback))
+			mounts := map[string]string{"": publicDir}
+			for _, m := range publicMounts {
+				prefix, dir, ok := strings.Cut(m, "=")
+				prefix = strings.TrimRight(prefix, "/")
+				if !ok || !strings.HasPrefix(prefix, "/") || dir == "" {
+					return fmt.Errorf("invalid --publicMount %q, expected PREFIX=DIR", m)
+				}
+				mounts[prefix] = dir
+			}
+
+			var cacheRules []apis.StaticCacheControlRule
+			for _, c := range publicCache {
+				pattern, value, ok := strings.Cut(c, "=")
+				if !ok || pattern == "" {
+					return fmt.Errorf("invalid --publicCache %q, expected PATTERN=VALUE", c)
+				}
+				cacheRules = append(cacheRules, apis.StaticCacheControlRule{Pattern: pattern, Value: value})
+			}
+
+			for prefix, dir := range mounts {
+				route := prefix + "/{path...}"
+				if e.Router.HasRoute(http.MethodGet, route) {
+					continue
+				}
+
+				fallback := indexFallback
+				if prefix != "" {
+					fallback = slices.Contains(publicSPAs, prefix) || slices.Contains(publicSPAs, prefix+"/")
+				}
+
+				r := e.Router.GET(route, apis.StaticWithConfig(os.DirFS(dir), apis.StaticConfig{
+					IndexFallback: fallback,
+					Precompressed: publicPrecompressed,
+					CacheControl:  cacheRules,
+				}))
+				if publicGzip {
+					r.Bind(apis.Gzip())
+				}
 			}
 
 			return e.Next()
@@ -241,6 +241,56 @@ func SendRecordChangeEmail(app core.App, authRecord *core.Record, newEmail strin
 	})
 }
 
+// SendRecordInvite sends an auth collection invite email to the invite email address.
+//
+// The token argument is the plain invite token as returned by [core.App.CreateInvite].
+func SendRecordInvite(app core.App, invite *core.Invite, token string) error {
+	collection, err := app.FindCachedCollectionByNameOrId(invite.CollectionRef)
+	if err != nil {
+		return err
+	}
+
+	// dummy auth record used for the {RECORD:*} placeholders
+	invitedRecord := core.NewRecord(collection)
+	for k, v := range invite.Data {
+		invitedRecord.SetIfFieldExists(k, v)
+	}
+	invitedRecord.SetEmail(invite.Email)
+
+	mailClient := app.NewMailClient()
+
+	subject, body, err := resolveEmailTemplate(app, invitedRecord, collection.Invites.Template(), map[string]any{
+		core.EmailPlaceholderToken: token,
+	})
+	if err != nil {
+		return err
+	}
+
+	message := &mailer.Message{
+		From: mail.Address{
+			Name:    app.Settings().Meta.SenderName,
+			Address: app.Settings().Meta.SenderAddress,
+		},
+		To:      []mail.Address{{Address: invite.Email}},
+		Subject: subject,
+		HTML:    body,
+	}
+
+	event := new(core.MailerRecordEvent)
+	event.App = app
+	event.Mailer = mailClient
+	event.Message = message
+	event.Record = invitedRecord
+	event.Meta = map[string]any{
+		"token":  token,
+		"invite": invite,
+	}
+
+	return app.OnMailerRecordInviteSend().Trigger(event, func(e *core.MailerRecordEvent) error {
+		return e.Mailer.Send(e.Message)
+	})
+}
+
 var nonescapeTypes = []string{
 	core.FieldTypeAutodate,
 	core.FieldTypeDate,
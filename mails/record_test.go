@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/mails"
 	"github.com/pocketbase/pocketbase/tests"
 )
@@ -169,3 +170,70 @@ func TestSendRecordOTP(t *testing.T) {
 		}
 	}
 }
+
+func TestSendRecordInvite(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	users, err := testApp.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	users.Invites.Enabled = true
+	if err := testApp.Save(users); err != nil {
+		t.Fatal(err)
+	}
+
+	invite, token, err := testApp.CreateInvite(users, "invited@example.com", map[string]any{"name": "<p>Invited</p>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mails.SendRecordInvite(testApp, invite, token); err != nil {
+		t.Fatal(err)
+	}
+
+	if testApp.TestMailer.TotalSend() != 1 {
+		t.Fatalf("Expected one email to be sent, got %d", testApp.TestMailer.TotalSend())
+	}
+
+	message := testApp.TestMailer.LastMessage()
+
+	if len(message.To) != 1 || message.To[0].Address != "invited@example.com" {
+		t.Fatalf("Expected the invite email recipient, got %v", message.To)
+	}
+
+	expectedParts := []string{
+		"invited to join",
+		"http://localhost:8090/accept-invite?token=" + token,
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(message.HTML, part) {
+			t.Fatalf("Couldn't find %s \nin\n %s", part, message.HTML)
+		}
+	}
+
+	// custom template
+	users.Invites.EmailTemplate = core.EmailTemplate{
+		Subject: "Join us",
+		Body:    "Hi {RECORD:name}, your token is {TOKEN}",
+	}
+	if err := testApp.Save(users); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mails.SendRecordInvite(testApp, invite, token); err != nil {
+		t.Fatal(err)
+	}
+
+	message = testApp.TestMailer.LastMessage()
+	if message.Subject != "Join us" {
+		t.Fatalf("Expected the custom subject, got %q", message.Subject)
+	}
+	expectedBody := "Hi " + html.EscapeString("<p>Invited</p>") + ", your token is " + token
+	if !strings.Contains(message.HTML, expectedBody) {
+		t.Fatalf("Couldn't find %s \nin\n %s", expectedBody, message.HTML)
+	}
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Register(func(txApp core.App) error {
+		_, execErr := txApp.DB().NewQuery(`
+			CREATE TABLE IF NOT EXISTS {{_mailSuppressions}} (
+				[[id]]      TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[email]]   TEXT NOT NULL,
+				[[reason]]  TEXT DEFAULT "" NOT NULL,
+				[[detail]]  TEXT DEFAULT "" NOT NULL,
+				[[created]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_mailSuppressions_email on {{_mailSuppressions}} ([[email]]);
+		`).Execute()
+
+		return execErr
+	}, func(txApp core.App) error {
+		_, err := txApp.DB().DropTable("_mailSuppressions").Execute()
+		return err
+	})
+}
@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/push"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func init() {
+	core.SystemMigrations.Register(func(txApp core.App) error {
+		col := core.NewBaseCollection(core.CollectionNamePushDevices)
+		col.System = true
+
+		ownerRule := "@request.auth.id != '' && recordRef = @request.auth.id && collectionRef = @request.auth.collectionId"
+		col.ListRule = types.Pointer(ownerRule)
+		col.ViewRule = types.Pointer(ownerRule)
+		col.DeleteRule = types.Pointer(ownerRule)
+
+		col.Fields.Add(&core.TextField{
+			Name:     "collectionRef",
+			System:   true,
+			Required: true,
+		})
+		col.Fields.Add(&core.TextField{
+			Name:     "recordRef",
+			System:   true,
+			Required: true,
+		})
+		col.Fields.Add(&core.SelectField{
+			Name:      "platform",
+			System:    true,
+			Required:  true,
+			MaxSelect: 1,
+			Values:    []string{push.PlatformFCM, push.PlatformAPNs, push.PlatformWebPush},
+		})
+		col.Fields.Add(&core.TextField{
+			Name:     "token",
+			System:   true,
+			Required: true,
+			Max:      2000,
+		})
+		col.Fields.Add(&core.TextField{
+			Name:   "p256dh",
+			System: true,
+			Hidden: true,
+			Max:    255,
+		})
+		col.Fields.Add(&core.TextField{
+			Name:   "auth",
+			System: true,
+			Hidden: true,
+			Max:    255,
+		})
+		col.Fields.Add(&core.AutodateField{
+			Name:     "created",
+			System:   true,
+			OnCreate: true,
+		})
+		col.Fields.Add(&core.AutodateField{
+			Name:     "updated",
+			System:   true,
+			OnCreate: true,
+			OnUpdate: true,
+		})
+		col.AddIndex("idx_pushDevices_unique_token", true, "platform, token", "")
+		col.AddIndex("idx_pushDevices_collectionRef_recordRef", false, "collectionRef, recordRef", "")
+
+		return txApp.Save(col)
+	}, func(txApp core.App) error {
+		col, err := txApp.FindCollectionByNameOrId(core.CollectionNamePushDevices)
+		if err != nil {
+			return err
+		}
+
+		// unmark the collection to allow its deletion
+		col.System = false
+
+		return txApp.Delete(col)
+	})
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Add(&core.Migration{
+		Up: func(txApp core.App) error {
+			_, execErr := txApp.AuxDB().NewQuery(`
+				CREATE TABLE IF NOT EXISTS {{_cronJobs}} (
+					[[id]]            TEXT PRIMARY KEY NOT NULL,
+					[[expression]]    TEXT DEFAULT "" NOT NULL,
+					[[disabled]]      BOOLEAN DEFAULT FALSE NOT NULL,
+					[[disabledUntil]] TEXT DEFAULT "" NOT NULL,
+					[[created]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+					[[updated]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+				);
+
+				CREATE TABLE IF NOT EXISTS {{_cronRuns}} (
+					[[id]]       TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+					[[job]]      TEXT NOT NULL,
+					[[trigger]]  TEXT DEFAULT "" NOT NULL,
+					[[status]]   TEXT DEFAULT "" NOT NULL,
+					[[attempts]] INTEGER DEFAULT 0 NOT NULL,
+					[[error]]    TEXT DEFAULT "" NOT NULL,
+					[[duration]] INTEGER DEFAULT 0 NOT NULL,
+					[[started]]  TEXT DEFAULT "" NOT NULL
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_cronRuns_job_started on {{_cronRuns}} ([[job]], [[started]]);
+			`).Execute()
+
+			return execErr
+		},
+		Down: func(txApp core.App) error {
+			if _, err := txApp.AuxDB().DropTable("_cronRuns").Execute(); err != nil {
+				return err
+			}
+
+			_, err := txApp.AuxDB().DropTable("_cronJobs").Execute()
+			return err
+		},
+		ReapplyCondition: func(txApp core.App, runner *core.MigrationsRunner, fileName string) (bool, error) {
+			// reapply only if any of the _cronJobs and _cronRuns tables doesn't exist
+			return !txApp.AuxHasTable("_cronJobs") || !txApp.AuxHasTable("_cronRuns"), nil
+		},
+	})
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Add(&core.Migration{
+		Up: func(txApp core.App) error {
+			_, execErr := txApp.AuxDB().NewQuery(`
+				CREATE TABLE IF NOT EXISTS {{_cronLocks}} (
+					[[id]]      TEXT PRIMARY KEY NOT NULL,
+					[[expires]] TEXT DEFAULT "" NOT NULL
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_cronLocks_expires on {{_cronLocks}} ([[expires]]);
+			`).Execute()
+
+			return execErr
+		},
+		Down: func(txApp core.App) error {
+			_, err := txApp.AuxDB().DropTable("_cronLocks").Execute()
+			return err
+		},
+		ReapplyCondition: func(txApp core.App, runner *core.MigrationsRunner, fileName string) (bool, error) {
+			// reapply only if the _cronLocks table doesn't exist
+			exists := txApp.AuxHasTable("_cronLocks")
+			return !exists, nil
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Add(&core.Migration{
+		Up: func(txApp core.App) error {
+			_, execErr := txApp.AuxDB().NewQuery(`
+				CREATE TABLE IF NOT EXISTS {{_scheduledTasks}} (
+					[[id]]          TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+					[[name]]        TEXT NOT NULL,
+					[[status]]      TEXT DEFAULT "pending" NOT NULL,
+					[[payload]]     JSON DEFAULT NULL,
+					[[attempts]]    INTEGER DEFAULT 0 NOT NULL,
+					[[error]]       TEXT DEFAULT "" NOT NULL,
+					[[runAt]]       TEXT DEFAULT "" NOT NULL,
+					[[lockedUntil]] TEXT DEFAULT "" NOT NULL,
+					[[created]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+					[[updated]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_scheduledTasks_status_runAt on {{_scheduledTasks}} ([[status]], [[runAt]]);
+			`).Execute()
+
+			return execErr
+		},
+		Down: func(txApp core.App) error {
+			_, err := txApp.AuxDB().DropTable("_scheduledTasks").Execute()
+			return err
+		},
+		ReapplyCondition: func(txApp core.App, runner *core.MigrationsRunner, fileName string) (bool, error) {
+			// reapply only if the _scheduledTasks table doesn't exist
+			exists := txApp.AuxHasTable("_scheduledTasks")
+			return !exists, nil
+		},
+	})
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Add(&core.Migration{
+		Up: func(txApp core.App) error {
+			_, execErr := txApp.AuxDB().NewQuery(`
+				CREATE TABLE IF NOT EXISTS {{_collectionSnapshots}} (
+					[[id]]          TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+					[[trigger]]     TEXT DEFAULT "" NOT NULL,
+					[[collection]]  TEXT DEFAULT "" NOT NULL,
+					[[hash]]        TEXT DEFAULT "" NOT NULL,
+					[[collections]] JSON DEFAULT NULL,
+					[[created]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_collectionSnapshots_created on {{_collectionSnapshots}} ([[created]]);
+			`).Execute()
+
+			return execErr
+		},
+		Down: func(txApp core.App) error {
+			_, err := txApp.AuxDB().DropTable("_collectionSnapshots").Execute()
+			return err
+		},
+		ReapplyCondition: func(txApp core.App, runner *core.MigrationsRunner, fileName string) (bool, error) {
+			// reapply only if the _collectionSnapshots table doesn't exist
+			exists := txApp.AuxHasTable("_collectionSnapshots")
+			return !exists, nil
+		},
+	})
+}
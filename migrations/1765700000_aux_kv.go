@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Add(&core.Migration{
+		Up: func(txApp core.App) error {
+			_, execErr := txApp.AuxDB().NewQuery(`
+				CREATE TABLE IF NOT EXISTS {{_kv}} (
+					[[namespace]] TEXT DEFAULT "" NOT NULL,
+					[[key]]       TEXT NOT NULL,
+					[[value]]     JSON DEFAULT NULL,
+					[[expires]]   TEXT DEFAULT "" NOT NULL,
+					[[updated]]   TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+					PRIMARY KEY ([[namespace]], [[key]])
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_kv_expires on {{_kv}} ([[expires]]);
+			`).Execute()
+
+			return execErr
+		},
+		Down: func(txApp core.App) error {
+			_, err := txApp.AuxDB().DropTable("_kv").Execute()
+			return err
+		},
+		ReapplyCondition: func(txApp core.App, runner *core.MigrationsRunner, fileName string) (bool, error) {
+			// reapply only if the _kv table doesn't exist
+			exists := txApp.AuxHasTable("_kv")
+			return !exists, nil
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Add(&core.Migration{
+		Up: func(txApp core.App) error {
+			_, execErr := txApp.AuxDB().NewQuery(`
+				CREATE TABLE IF NOT EXISTS {{_archivedRecords}} (
+					[[collectionId]] TEXT NOT NULL,
+					[[recordId]]     TEXT NOT NULL,
+					[[data]]         BLOB NOT NULL,
+					[[archived]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+					PRIMARY KEY ([[collectionId]], [[recordId]])
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_archivedRecords_archived on {{_archivedRecords}} ([[collectionId]], [[archived]]);
+			`).Execute()
+
+			return execErr
+		},
+		Down: func(txApp core.App) error {
+			_, err := txApp.AuxDB().DropTable("_archivedRecords").Execute()
+			return err
+		},
+		ReapplyCondition: func(txApp core.App, runner *core.MigrationsRunner, fileName string) (bool, error) {
+			// reapply only if the _archivedRecords table doesn't exist
+			exists := txApp.AuxHasTable("_archivedRecords")
+			return !exists, nil
+		},
+	})
+}
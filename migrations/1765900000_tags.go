@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Register(func(txApp core.App) error {
+		_, execErr := txApp.DB().NewQuery(`
+			CREATE TABLE IF NOT EXISTS {{_tags}} (
+				[[id]]            TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[collectionRef]] TEXT NOT NULL,
+				[[fieldRef]]      TEXT NOT NULL,
+				[[tag]]           TEXT NOT NULL,
+				[[count]]         INTEGER DEFAULT 0 NOT NULL,
+				[[created]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_collectionRef_fieldRef_tag on {{_tags}} ([[collectionRef]], [[fieldRef]], [[tag]]);
+		`).Execute()
+
+		return execErr
+	}, func(txApp core.App) error {
+		_, err := txApp.DB().DropTable("_tags").Execute()
+		return err
+	})
+}
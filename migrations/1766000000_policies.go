@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Register(func(txApp core.App) error {
+		_, execErr := txApp.DB().NewQuery(`
+			CREATE TABLE IF NOT EXISTS {{_policies}} (
+				[[id]]      TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[name]]    TEXT NOT NULL,
+				[[version]] INTEGER DEFAULT 1 NOT NULL,
+				[[title]]   TEXT DEFAULT "" NOT NULL,
+				[[content]] TEXT DEFAULT "" NOT NULL,
+				[[url]]     TEXT DEFAULT "" NOT NULL,
+				[[created]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_policies_name_version on {{_policies}} ([[name]], [[version]]);
+
+			CREATE TABLE IF NOT EXISTS {{_policyAcceptances}} (
+				[[id]]            TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[collectionRef]] TEXT NOT NULL,
+				[[recordRef]]     TEXT NOT NULL,
+				[[policy]]        TEXT NOT NULL,
+				[[version]]       INTEGER NOT NULL,
+				[[ip]]            TEXT DEFAULT "" NOT NULL,
+				[[created]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_policyAcceptances_record_policy_version on {{_policyAcceptances}} ([[collectionRef]], [[recordRef]], [[policy]], [[version]]);
+		`).Execute()
+
+		return execErr
+	}, func(txApp core.App) error {
+		if _, err := txApp.DB().DropTable("_policyAcceptances").Execute(); err != nil {
+			return err
+		}
+
+		_, err := txApp.DB().DropTable("_policies").Execute()
+		return err
+	})
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Add(&core.Migration{
+		Up: func(txApp core.App) error {
+			_, execErr := txApp.AuxDB().NewQuery(`
+				CREATE TABLE IF NOT EXISTS {{_analyticsEvents}} (
+					[[id]]       TEXT PRIMARY KEY NOT NULL,
+					[[type]]     TEXT DEFAULT "" NOT NULL,
+					[[method]]   TEXT DEFAULT "" NOT NULL,
+					[[path]]     TEXT DEFAULT "" NOT NULL,
+					[[visitor]]  TEXT DEFAULT "" NOT NULL,
+					[[status]]   INTEGER DEFAULT 0 NOT NULL,
+					[[duration]] REAL DEFAULT 0 NOT NULL,
+					[[created]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_analyticsEvents_created on {{_analyticsEvents}} ([[created]]);
+
+				CREATE TABLE IF NOT EXISTS {{_analyticsRollups}} (
+					[[day]]    TEXT NOT NULL,
+					[[metric]] TEXT NOT NULL,
+					[[key]]    TEXT DEFAULT "" NOT NULL,
+					[[value]]  REAL DEFAULT 0 NOT NULL,
+					PRIMARY KEY ([[day]], [[metric]], [[key]])
+				);
+			`).Execute()
+
+			return execErr
+		},
+		Down: func(txApp core.App) error {
+			if _, err := txApp.AuxDB().DropTable("_analyticsRollups").Execute(); err != nil {
+				return err
+			}
+
+			_, err := txApp.AuxDB().DropTable("_analyticsEvents").Execute()
+			return err
+		},
+		ReapplyCondition: func(txApp core.App, runner *core.MigrationsRunner, fileName string) (bool, error) {
+			// reapply only if any of the _analyticsEvents and _analyticsRollups tables doesn't exist
+			return !txApp.AuxHasTable("_analyticsEvents") || !txApp.AuxHasTable("_analyticsRollups"), nil
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Add(&core.Migration{
+		Up: func(txApp core.App) error {
+			_, execErr := txApp.AuxDB().NewQuery(`
+				CREATE TABLE IF NOT EXISTS {{_capturedRequests}} (
+					[[id]]                TEXT PRIMARY KEY NOT NULL,
+					[[method]]            TEXT DEFAULT "" NOT NULL,
+					[[url]]               TEXT DEFAULT "" NOT NULL,
+					[[route]]             TEXT DEFAULT "" NOT NULL,
+					[[requestHeaders]]    JSON DEFAULT "{}" NOT NULL,
+					[[requestBody]]       TEXT DEFAULT "" NOT NULL,
+					[[status]]            INTEGER DEFAULT 0 NOT NULL,
+					[[responseHeaders]]   JSON DEFAULT "{}" NOT NULL,
+					[[responseBody]]      TEXT DEFAULT "" NOT NULL,
+					[[authCollection]]    TEXT DEFAULT "" NOT NULL,
+					[[authRecord]]        TEXT DEFAULT "" NOT NULL,
+					[[requestTruncated]]  BOOLEAN DEFAULT FALSE NOT NULL,
+					[[responseTruncated]] BOOLEAN DEFAULT FALSE NOT NULL,
+					[[duration]]          REAL DEFAULT 0 NOT NULL,
+					[[created]]           TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_capturedRequests_created on {{_capturedRequests}} ([[created]]);
+			`).Execute()
+
+			return execErr
+		},
+		Down: func(txApp core.App) error {
+			_, err := txApp.AuxDB().DropTable("_capturedRequests").Execute()
+			return err
+		},
+		ReapplyCondition: func(txApp core.App, runner *core.MigrationsRunner, fileName string) (bool, error) {
+			// reapply only if the _capturedRequests table doesn't exist
+			exists := txApp.AuxHasTable("_capturedRequests")
+			return !exists, nil
+		},
+	})
+}
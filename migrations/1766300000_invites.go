@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Register(func(txApp core.App) error {
+		_, execErr := txApp.DB().NewQuery(`
+			CREATE TABLE IF NOT EXISTS {{_invites}} (
+				[[id]]            TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[collectionRef]] TEXT NOT NULL,
+				[[email]]         TEXT NOT NULL,
+				[[tokenHash]]     TEXT NOT NULL,
+				[[data]]          JSON DEFAULT "{}" NOT NULL,
+				[[expires]]       TEXT DEFAULT "" NOT NULL,
+				[[accepted]]      TEXT DEFAULT "" NOT NULL,
+				[[recordRef]]     TEXT DEFAULT "" NOT NULL,
+				[[created]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_invites_tokenHash on {{_invites}} ([[tokenHash]]);
+			CREATE INDEX IF NOT EXISTS idx_invites_collectionRef_email on {{_invites}} ([[collectionRef]], [[email]]);
+		`).Execute()
+
+		return execErr
+	}, func(txApp core.App) error {
+		_, err := txApp.DB().DropTable("_invites").Execute()
+		return err
+	})
+}
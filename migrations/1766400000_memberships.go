@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	core.SystemMigrations.Register(func(txApp core.App) error {
+		_, execErr := txApp.DB().NewQuery(`
+			CREATE TABLE IF NOT EXISTS {{_memberships}} (
+				[[id]]                  TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[collectionRef]]       TEXT NOT NULL,
+				[[organizationRef]]     TEXT NOT NULL,
+				[[memberCollectionRef]] TEXT NOT NULL,
+				[[memberRef]]           TEXT NOT NULL,
+				[[role]]                TEXT DEFAULT "" NOT NULL,
+				[[created]]             TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]             TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_memberships_organization_member on {{_memberships}} (
+				[[organizationRef]],
+				[[collectionRef]],
+				[[memberRef]],
+				[[memberCollectionRef]]
+			);
+			CREATE INDEX IF NOT EXISTS idx_memberships_member on {{_memberships}} ([[memberRef]], [[memberCollectionRef]]);
+		`).Execute()
+
+		return execErr
+	}, func(txApp core.App) error {
+		_, err := txApp.DB().DropTable("_memberships").Execute()
+		return err
+	})
+}
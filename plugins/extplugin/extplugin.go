@@ -0,0 +1,459 @@
+// Package extplugin implements loading of out-of-process app plugins
+// from executables in a "pb_plugins" directory, allowing users of the
+// prebuilt PocketBase binary to register hooks and routes without recompiling.
+//
+// Each executable is started as a child process and communicates with the
+// app through a JSON-RPC 1.0 bridge over its stdin/stdout. The plugin must
+// expose the following methods:
+//
+//   - "Plugin.Manifest" - returns the plugin [Manifest]
+//   - "Plugin.HandleHook" - handles a single [HookRequest]
+//   - "Plugin.HandleRoute" - handles a single [RouteRequest]
+//
+// Go plugins could use the [Serve] helper, but any language with a
+// JSON-RPC implementation could be used. WASM plugins are not supported.
+//
+// The loaded plugins are registered as regular [core.Plugin] so they
+// could be disabled from the app settings as any other plugin.
+//
+// Example:
+//
+//	extplugin.MustRegister(app, extplugin.Config{})
+package extplugin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// Config defines the config options of the extplugin plugin.
+type Config struct {
+	// Dir specifies the external plugins executables directory.
+	//
+	// If not set it fallbacks to a relative "pb_data/../pb_plugins" directory.
+	Dir string
+
+	// Timeout specifies the max duration of a single plugin RPC call.
+	//
+	// If not set it fallbacks to 30 seconds.
+	Timeout time.Duration
+
+	// MaxBodySize specifies the max allowed forwarded route request body size in bytes.
+	//
+	// If not set it fallbacks to 32MB.
+	MaxBodySize int64
+}
+
+// MustRegister registers the extplugin plugin to the provided app instance
+// and panic if it fails.
+func MustRegister(app core.App, config Config) {
+	if err := Register(app, config); err != nil {
+		panic(err)
+	}
+}
+
+// Register starts the executables from the configured plugins directory
+// and registers them as app plugins.
+//
+// It is expected to be called before the app bootstrap.
+// A missing plugins directory is not considered an error.
+func Register(app core.App, config Config) error {
+	if config.Dir == "" {
+		config.Dir = filepath.Join(app.DataDir(), "../pb_plugins")
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = 32 << 20
+	}
+
+	files, err := executables(config.Dir)
+	if err != nil {
+		return err
+	}
+
+	loaded := make([]*plugin, 0, len(files))
+
+	// stop all started processes in case of an error
+	cleanup := func() {
+		for _, p := range loaded {
+			p.stop()
+		}
+	}
+
+	for _, file := range files {
+		p, err := start(config, file)
+		if err != nil {
+			cleanup()
+			return fmt.Errorf("failed to load external plugin %q: %w", file, err)
+		}
+
+		loaded = append(loaded, p)
+
+		if err := app.RegisterPlugin(p); err != nil {
+			cleanup()
+			return err
+		}
+	}
+
+	if len(loaded) > 0 {
+		app.OnTerminate().Bind(&hook.Handler[*core.TerminateEvent]{
+			Id: "__pbExtPluginsTerminate__",
+			Func: func(e *core.TerminateEvent) error {
+				cleanup()
+				return e.Next()
+			},
+		})
+	}
+
+	return nil
+}
+
+// executables returns the sorted executable files paths from the specified directory.
+func executables(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		if runtime.GOOS == "windows" {
+			if !strings.EqualFold(filepath.Ext(name), ".exe") {
+				continue
+			}
+		} else if info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+
+		result = append(result, filepath.Join(dir, name))
+	}
+
+	return result, nil
+}
+
+var (
+	_ core.Plugin           = (*plugin)(nil)
+	_ core.PluginWithRoutes = (*plugin)(nil)
+)
+
+// plugin is a single external plugin process.
+type plugin struct {
+	config   Config
+	cmd      *exec.Cmd
+	client   *rpc.Client
+	manifest *Manifest
+	stopOnce sync.Once
+}
+
+// start starts the specified executable and loads its manifest.
+func start(config Config, file string) (*plugin, error) {
+	cmd := exec.Command(file)
+	cmd.Dir = filepath.Dir(file)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &plugin{
+		config: config,
+		cmd:    cmd,
+		client: jsonrpc.NewClient(&stdioConn{Reader: stdout, Writer: stdin}),
+	}
+
+	manifest := &Manifest{}
+	if err := p.call("Manifest", &struct{}{}, manifest); err != nil {
+		p.stop()
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	for _, h := range manifest.Hooks {
+		if !isSupportedHook(h.Event) {
+			p.stop()
+			return nil, fmt.Errorf("unsupported hook event %q", h.Event)
+		}
+	}
+
+	p.manifest = manifest
+
+	return p, nil
+}
+
+// stop closes the RPC connection and terminates the plugin process.
+func (p *plugin) stop() {
+	p.stopOnce.Do(func() {
+		// closing stdin should gracefully stop the plugin serve loop
+		p.client.Close()
+
+		done := make(chan struct{})
+		go func() {
+			p.cmd.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			p.cmd.Process.Kill()
+			<-done
+		}
+	})
+}
+
+// call invokes the specified plugin RPC method with the configured timeout.
+func (p *plugin) call(method string, args any, reply any) error {
+	call := p.client.Go(rpcServiceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+
+	timer := time.NewTimer(p.config.Timeout)
+	defer timer.Stop()
+
+	select {
+	case <-call.Done:
+		return call.Error
+	case <-timer.C:
+		return fmt.Errorf("%s call timeout after %s", method, p.config.Timeout)
+	}
+}
+
+// Name implements [core.Plugin.Name].
+func (p *plugin) Name() string {
+	return p.manifest.Name
+}
+
+// Init implements [core.Plugin.Init] and binds the manifest hooks.
+func (p *plugin) Init(app core.App) error {
+	for _, h := range p.manifest.Hooks {
+		switch h.Event {
+		case HookRecordViewRequest:
+			app.OnRecordViewRequest(h.Collections...).BindFunc(p.requestHookHandler(h.Event, false))
+		case HookRecordCreateRequest:
+			app.OnRecordCreateRequest(h.Collections...).BindFunc(p.requestHookHandler(h.Event, true))
+		case HookRecordUpdateRequest:
+			app.OnRecordUpdateRequest(h.Collections...).BindFunc(p.requestHookHandler(h.Event, true))
+		case HookRecordDeleteRequest:
+			app.OnRecordDeleteRequest(h.Collections...).BindFunc(p.requestHookHandler(h.Event, false))
+		case HookRecordAfterCreateSuccess:
+			app.OnRecordAfterCreateSuccess(h.Collections...).BindFunc(p.successHookHandler(h.Event))
+		case HookRecordAfterUpdateSuccess:
+			app.OnRecordAfterUpdateSuccess(h.Collections...).BindFunc(p.successHookHandler(h.Event))
+		case HookRecordAfterDeleteSuccess:
+			app.OnRecordAfterDeleteSuccess(h.Collections...).BindFunc(p.successHookHandler(h.Event))
+		}
+	}
+
+	return nil
+}
+
+func (p *plugin) requestHookHandler(event string, allowChanges bool) func(e *core.RecordRequestEvent) error {
+	return func(e *core.RecordRequestEvent) error {
+		if !e.App.IsPluginEnabled(p.Name()) {
+			return e.Next()
+		}
+
+		req := &HookRequest{
+			Event:      event,
+			Collection: e.Collection.Name,
+			Record:     e.Record.FieldsData(),
+		}
+		if e.Auth != nil {
+			req.AuthId = e.Auth.Id
+			req.AuthCollection = e.Auth.Collection().Name
+		}
+
+		res := &HookResponse{}
+		if err := p.call("HandleHook", req, res); err != nil {
+			return e.InternalServerError("", fmt.Errorf("external plugin %q hook error: %w", p.Name(), err))
+		}
+
+		if res.Error != "" {
+			status := res.Status
+			if status == 0 {
+				status = http.StatusBadRequest
+			}
+			return router.NewApiError(status, res.Error, nil)
+		}
+
+		if allowChanges {
+			for k, v := range res.Record {
+				e.Record.Set(k, v)
+			}
+		}
+
+		return e.Next()
+	}
+}
+
+func (p *plugin) successHookHandler(event string) func(e *core.RecordEvent) error {
+	return func(e *core.RecordEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		if !e.App.IsPluginEnabled(p.Name()) {
+			return nil
+		}
+
+		req := &HookRequest{
+			Event:      event,
+			Collection: e.Record.Collection().Name,
+			Record:     e.Record.FieldsData(),
+		}
+
+		if err := p.call("HandleHook", req, &HookResponse{}); err != nil {
+			e.App.Logger().Error(
+				"External plugin hook error",
+				slog.String("plugin", p.Name()),
+				slog.String("event", event),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		return nil
+	}
+}
+
+// BindRoutes implements [core.PluginWithRoutes.BindRoutes] and
+// registers the manifest routes as proxies to the plugin process.
+func (p *plugin) BindRoutes(rg *router.RouterGroup[*core.RequestEvent]) {
+	for _, r := range p.manifest.Routes {
+		rg.Route(strings.ToUpper(r.Method), r.Path, p.routeHandler(r.Path))
+	}
+}
+
+func (p *plugin) routeHandler(path string) func(e *core.RequestEvent) error {
+	paramNames := routePathParams(path)
+
+	return func(e *core.RequestEvent) error {
+		body, err := io.ReadAll(io.LimitReader(e.Request.Body, p.config.MaxBodySize+1))
+		if err != nil {
+			return e.BadRequestError("Failed to read the request body.", err)
+		}
+		if int64(len(body)) > p.config.MaxBodySize {
+			return router.NewApiError(http.StatusRequestEntityTooLarge, "Request entity too large.", nil)
+		}
+
+		req := &RouteRequest{
+			Method:     e.Request.Method,
+			Path:       e.Request.URL.Path,
+			Query:      e.Request.URL.RawQuery,
+			PathParams: make(map[string]string, len(paramNames)),
+			Headers:    e.Request.Header,
+			Body:       string(body),
+		}
+		for _, name := range paramNames {
+			req.PathParams[name] = e.Request.PathValue(name)
+		}
+		if e.Auth != nil {
+			req.AuthId = e.Auth.Id
+			req.AuthCollection = e.Auth.Collection().Name
+		}
+
+		res := &RouteResponse{}
+		if err := p.call("HandleRoute", req, res); err != nil {
+			return e.InternalServerError("", fmt.Errorf("external plugin %q route error: %w", p.Name(), err))
+		}
+
+		for k, v := range res.Headers {
+			e.Response.Header().Set(k, v)
+		}
+
+		status := res.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		contentType := e.Response.Header().Get("Content-Type")
+		if contentType == "" {
+			contentType = http.DetectContentType([]byte(res.Body))
+		}
+
+		return e.Blob(status, contentType, []byte(res.Body))
+	}
+}
+
+// routePathParams extracts the wildcard names from the specified route path pattern
+// (e.g. "/api/{a}/{b...}" -> ["a", "b"]).
+func routePathParams(path string) []string {
+	result := []string{}
+
+	for {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			break
+		}
+
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			break
+		}
+
+		name := strings.TrimSuffix(path[start+1:start+end], "...")
+		if name != "$" && name != "" {
+			result = append(result, name)
+		}
+
+		path = path[start+end+1:]
+	}
+
+	return result
+}
+
+func isSupportedHook(event string) bool {
+	switch event {
+	case HookRecordViewRequest,
+		HookRecordCreateRequest,
+		HookRecordUpdateRequest,
+		HookRecordDeleteRequest,
+		HookRecordAfterCreateSuccess,
+		HookRecordAfterUpdateSuccess,
+		HookRecordAfterDeleteSuccess:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,239 @@
+package extplugin_test
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/plugins/extplugin"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+const helperEnv = "PB_EXTPLUGIN_TEST_HELPER"
+
+// TestMain allows the test binary to act as an external plugin executable.
+func TestMain(m *testing.M) {
+	if os.Getenv(helperEnv) == "1" {
+		if err := extplugin.Serve(&testHandler{}); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+type testHandler struct{}
+
+func (h *testHandler) Manifest() (*extplugin.Manifest, error) {
+	return &extplugin.Manifest{
+		Name: "ext_test",
+		Hooks: []extplugin.ManifestHook{
+			{Event: extplugin.HookRecordCreateRequest, Collections: []string{"demo2"}},
+		},
+		Routes: []extplugin.ManifestRoute{
+			{Method: http.MethodPost, Path: "/ext-test/{name}"},
+		},
+	}, nil
+}
+
+func (h *testHandler) HandleHook(req *extplugin.HookRequest) (*extplugin.HookResponse, error) {
+	title, _ := req.Record["title"].(string)
+
+	switch title {
+	case "reject":
+		return &extplugin.HookResponse{Error: "rejected", Status: http.StatusForbidden}, nil
+	case "fail":
+		return nil, errors.New("hook failure")
+	}
+
+	return &extplugin.HookResponse{
+		Record: map[string]any{"title": strings.ToUpper(title)},
+	}, nil
+}
+
+func (h *testHandler) HandleRoute(req *extplugin.RouteRequest) (*extplugin.RouteResponse, error) {
+	return &extplugin.RouteResponse{
+		Status:  http.StatusCreated,
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    `{"name":"` + req.PathParams["name"] + `","query":"` + req.Query + `","body":` + req.Body + `}`,
+	}, nil
+}
+
+// createPluginsDir creates a plugins dir with an executable
+// wrapper script that runs the test binary in helper mode.
+func createPluginsDir(t testing.TB) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("the wrapper script requires a POSIX shell")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+
+	script := "#!/bin/sh\n" + helperEnv + "=1 exec '" + exe + "'\n"
+	if err := os.WriteFile(filepath.Join(dir, "test_plugin"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// non-executable and hidden files should be ignored
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".hidden"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestRegisterMissingDir(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	err := extplugin.Register(app, extplugin.Config{Dir: filepath.Join(t.TempDir(), "missing")})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if total := len(app.Plugins()); total != 0 {
+		t.Fatalf("Expected no registered plugins, got %d", total)
+	}
+}
+
+func TestRegisterHooks(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := extplugin.Register(app, extplugin.Config{Dir: createPluginsDir(t)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if total := len(app.Plugins()); total != 1 {
+		t.Fatalf("Expected 1 registered plugin, got %d", total)
+	}
+
+	if app.FindPlugin("ext_test") == nil {
+		t.Fatal("Expected the ext_test plugin to be registered")
+	}
+
+	if err := app.Bootstrap(); err != nil {
+		t.Fatal(err)
+	}
+
+	collection, err := app.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		title          string
+		expectedTitle  string
+		expectedStatus int
+	}{
+		{"test", "TEST", 0},
+		{"reject", "reject", http.StatusForbidden},
+		{"fail", "fail", http.StatusInternalServerError},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.title, func(t *testing.T) {
+			record := core.NewRecord(collection)
+			record.Set("title", s.title)
+
+			event := &core.RecordRequestEvent{
+				RequestEvent: &core.RequestEvent{App: app},
+				Record:       record,
+			}
+			event.Collection = collection
+
+			err := app.OnRecordCreateRequest(collection.Name).Trigger(event)
+
+			var status int
+			if err != nil {
+				var apiErr *router.ApiError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("Expected ApiError, got %v", err)
+				}
+				status = apiErr.Status
+			}
+
+			if status != s.expectedStatus {
+				t.Fatalf("Expected error status %d, got %d (%v)", s.expectedStatus, status, err)
+			}
+
+			if v := record.GetString("title"); v != s.expectedTitle {
+				t.Fatalf("Expected title %q, got %q", s.expectedTitle, v)
+			}
+		})
+	}
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Parallel()
+
+	pluginsDir := createPluginsDir(t)
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "enabled plugin",
+			Method: http.MethodPost,
+			URL:    "/ext-test/hello?a=1",
+			Body:   strings.NewReader(`{"b":2}`),
+			TestAppFactory: func(t testing.TB) *tests.TestApp {
+				app, err := tests.NewTestApp()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err := extplugin.Register(app, extplugin.Config{Dir: pluginsDir}); err != nil {
+					t.Fatal(err)
+				}
+
+				return app
+			},
+			ExpectedStatus:  201,
+			ExpectedContent: []string{`{"name":"hello","query":"a=1","body":{"b":2}}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+		{
+			Name:   "disabled plugin",
+			Method: http.MethodPost,
+			URL:    "/ext-test/hello",
+			TestAppFactory: func(t testing.TB) *tests.TestApp {
+				app, err := tests.NewTestApp()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err := extplugin.Register(app, extplugin.Config{Dir: pluginsDir}); err != nil {
+					t.Fatal(err)
+				}
+
+				app.Settings().Plugins.Disabled = []string{"ext_test"}
+
+				return app
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents:  map[string]int{"*": 0},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,194 @@
+package extplugin
+
+import (
+	"io"
+	"net/http"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+)
+
+// Supported external plugin hook events.
+const (
+	HookRecordViewRequest        = "recordViewRequest"
+	HookRecordCreateRequest      = "recordCreateRequest"
+	HookRecordUpdateRequest      = "recordUpdateRequest"
+	HookRecordDeleteRequest      = "recordDeleteRequest"
+	HookRecordAfterCreateSuccess = "recordAfterCreateSuccess"
+	HookRecordAfterUpdateSuccess = "recordAfterUpdateSuccess"
+	HookRecordAfterDeleteSuccess = "recordAfterDeleteSuccess"
+)
+
+// rpcServiceName is the JSON-RPC service name exposed by the external plugins
+// (aka. the methods are called as "Plugin.Manifest", "Plugin.HandleHook", etc.).
+const rpcServiceName = "Plugin"
+
+// Manifest describes the external plugin and its hooks and routes subscriptions.
+//
+// It is returned by the "Plugin.Manifest" RPC method right after the plugin process start.
+type Manifest struct {
+	// Name is the unique plugin name (see [core.Plugin]).
+	Name string `json:"name"`
+
+	Hooks  []ManifestHook  `json:"hooks"`
+	Routes []ManifestRoute `json:"routes"`
+}
+
+// ManifestHook defines a single external plugin hook subscription.
+type ManifestHook struct {
+	// Event is one of the Hook* event constants (e.g. "recordCreateRequest").
+	Event string `json:"event"`
+
+	// Collections is an optional list of collection names or ids to
+	// limit the hook to (empty means all collections).
+	Collections []string `json:"collections"`
+}
+
+// ManifestRoute defines a single external plugin route.
+type ManifestRoute struct {
+	Method string `json:"method"`
+
+	// Path is the route path pattern (e.g. "/api/myplugin/{id}").
+	Path string `json:"path"`
+}
+
+// HookRequest is the argument of the "Plugin.HandleHook" RPC method.
+type HookRequest struct {
+	Event      string         `json:"event"`
+	Collection string         `json:"collection"`
+	Record     map[string]any `json:"record"`
+
+	// AuthId and AuthCollection are the request auth record id and
+	// collection name (available only for the request hook events).
+	AuthId         string `json:"authId"`
+	AuthCollection string `json:"authCollection"`
+}
+
+// HookResponse is the reply of the "Plugin.HandleHook" RPC method.
+type HookResponse struct {
+	// Error is an optional error message that aborts the request
+	// (ignored for the after success hook events).
+	Error string `json:"error"`
+
+	// Status is the optional error response status code (default to 400).
+	Status int `json:"status"`
+
+	// Record is an optional map with record fields to set
+	// (applicable only for the create and update request hook events).
+	Record map[string]any `json:"record"`
+}
+
+// RouteRequest is the argument of the "Plugin.HandleRoute" RPC method.
+type RouteRequest struct {
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	Query      string            `json:"query"`
+	PathParams map[string]string `json:"pathParams"`
+	Headers    http.Header       `json:"headers"`
+	Body       string            `json:"body"`
+
+	AuthId         string `json:"authId"`
+	AuthCollection string `json:"authCollection"`
+}
+
+// RouteResponse is the reply of the "Plugin.HandleRoute" RPC method.
+type RouteResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// Handler defines the interface that Go external plugins implement
+// in order to be served with [Serve].
+type Handler interface {
+	Manifest() (*Manifest, error)
+	HandleHook(req *HookRequest) (*HookResponse, error)
+	HandleRoute(req *RouteRequest) (*RouteResponse, error)
+}
+
+// Serve serves the provided handler over the process stdin/stdout
+// and blocks until the PocketBase host closes the connection.
+//
+// It is intended to be called from the main function of a Go external plugin executable.
+// Note that because stdout is used by the RPC bridge, the plugin
+// should write its logs only to stderr.
+func Serve(h Handler) error {
+	return ServeConn(h, &stdioConn{Reader: os.Stdin, Writer: os.Stdout})
+}
+
+// ServeConn is similar to [Serve] but serves the handler over the provided connection.
+func ServeConn(h Handler, conn io.ReadWriteCloser) error {
+	server := rpc.NewServer()
+
+	if err := server.RegisterName(rpcServiceName, &rpcHandler{h}); err != nil {
+		return err
+	}
+
+	server.ServeCodec(jsonrpc.NewServerCodec(conn))
+
+	return nil
+}
+
+// rpcHandler adapts [Handler] to the net/rpc methods signature.
+type rpcHandler struct {
+	h Handler
+}
+
+func (r *rpcHandler) Manifest(args *struct{}, reply *Manifest) error {
+	m, err := r.h.Manifest()
+	if err != nil {
+		return err
+	}
+
+	*reply = *m
+
+	return nil
+}
+
+func (r *rpcHandler) HandleHook(args *HookRequest, reply *HookResponse) error {
+	res, err := r.h.HandleHook(args)
+	if err != nil {
+		return err
+	}
+
+	if res != nil {
+		*reply = *res
+	}
+
+	return nil
+}
+
+func (r *rpcHandler) HandleRoute(args *RouteRequest, reply *RouteResponse) error {
+	res, err := r.h.HandleRoute(args)
+	if err != nil {
+		return err
+	}
+
+	if res != nil {
+		*reply = *res
+	}
+
+	return nil
+}
+
+// stdioConn combines a reader and writer into a single io.ReadWriteCloser.
+type stdioConn struct {
+	io.Reader
+	io.Writer
+}
+
+func (c *stdioConn) Close() error {
+	var err error
+
+	if rc, ok := c.Reader.(io.Closer); ok {
+		err = rc.Close()
+	}
+
+	if wc, ok := c.Writer.(io.Closer); ok {
+		if wErr := wc.Close(); wErr != nil && err == nil {
+			err = wErr
+		}
+	}
+
+	return err
+}
@@ -555,6 +555,14 @@ func baseBinds(vm *goja.Runtime) {
 		instance := &core.SelectField{}
 		return structConstructorUnmarshal(vm, call, instance)
 	})
+	vm.Set("TagsField", func(call goja.ConstructorCall) *goja.Object {
+		instance := &core.TagsField{}
+		return structConstructorUnmarshal(vm, call, instance)
+	})
+	vm.Set("OrderField", func(call goja.ConstructorCall) *goja.Object {
+		instance := &core.OrderField{}
+		return structConstructorUnmarshal(vm, call, instance)
+	})
 	vm.Set("FileField", func(call goja.ConstructorCall) *goja.Object {
 		instance := &core.FileField{}
 		return structConstructorUnmarshal(vm, call, instance)
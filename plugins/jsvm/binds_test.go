@@ -46,7 +46,7 @@ func TestBaseBindsCount(t *testing.T) {
 	vm := goja.New()
 	baseBinds(vm)
 
-	testBindsCount(vm, "this", 41, t)
+	testBindsCount(vm, "this", 43, t)
 }
 
 func TestBaseBindsSleep(t *testing.T) {
@@ -440,6 +440,14 @@ func TestBaseBindsNamedFields(t *testing.T) {
 			"new FileField({name: 'test'})",
 			isType[*core.FileField],
 		},
+		{
+			"new TagsField({name: 'test'})",
+			isType[*core.TagsField],
+		},
+		{
+			"new OrderField({name: 'test'})",
+			isType[*core.OrderField],
+		},
 		{
 			"new GeoPointField({name: 'test'})",
 			isType[*core.GeoPointField],
@@ -1579,7 +1587,7 @@ func TestHooksBindsCount(t *testing.T) {
 	vm := goja.New()
 	hooksBinds(app, vm, nil)
 
-	testBindsCount(vm, "this", 82, t)
+	testBindsCount(vm, "this", 97, t)
 }
 
 func TestHooksBinds(t *testing.T) {
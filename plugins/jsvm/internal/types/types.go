@@ -565,6 +565,26 @@ declare class FileField implements core.FileField {
   constructor(data?: Partial<core.FileField>)
 }
 
+interface TagsField extends core.TagsField{} // merge
+/**
+ * {@inheritDoc core.TagsField}
+ *
+ * @group PocketBase
+ */
+declare class TagsField implements core.TagsField {
+  constructor(data?: Partial<core.TagsField>)
+}
+
+interface OrderField extends core.OrderField{} // merge
+/**
+ * {@inheritDoc core.OrderField}
+ *
+ * @group PocketBase
+ */
+declare class OrderField implements core.OrderField {
+  constructor(data?: Partial<core.OrderField>)
+}
+
 interface GeoPointField extends core.GeoPointField{} // merge
 /**
  * {@inheritDoc core.GeoPointField}
@@ -126,7 +126,7 @@ func Register(app core.App, config Config) error {
 	p := &plugin{app: app, config: config}
 
 	if p.config.HooksDir == "" {
-		p.config.HooksDir = filepath.Join(app.DataDir(), "../pb_hooks")
+		p.config.HooksDir = defaultHooksDir(app)
 	}
 
 	if p.config.MigrationsDir == "" {
@@ -184,42 +184,20 @@ func (p *plugin) registerMigrations() error {
 		return err
 	}
 
-	absHooksDir, err := filepath.Abs(p.config.HooksDir)
+	// note: the migrations don't have $app since they receive their own txApp
+	initializer, err := newRuntimeInitializer(nil, p.config.HooksDir, p.config.OnInit)
 	if err != nil {
 		return err
 	}
 
-	registry := new(require.Registry) // this can be shared by multiple runtimes
-	templateRegistry := template.NewRegistry()
-
 	for file, content := range files {
 		vm := goja.New()
 
-		registry.Enable(vm)
-		console.Enable(vm)
-		process.Enable(vm)
-		buffer.Enable(vm)
-
-		baseBinds(vm)
-		dbxBinds(vm)
-		securityBinds(vm)
-		osBinds(vm)
-		filepathBinds(vm)
-		httpClientBinds(vm)
-		filesystemBinds(vm)
-		formsBinds(vm)
-		mailsBinds(vm)
-
-		vm.Set("$template", templateRegistry)
-		vm.Set("__hooks", absHooksDir)
-
 		vm.Set("migrate", func(up, down func(txApp core.App) error) {
 			core.AppMigrations.Register(up, down, file)
 		})
 
-		if p.config.OnInit != nil {
-			p.config.OnInit(vm)
-		}
+		initializer.init(vm)
 
 		_, err := vm.RunScript(defaultScriptPath, string(content))
 		if err != nil {
@@ -267,7 +245,8 @@ func (p *plugin) registerHooks() error {
 		return nil
 	}
 
-	absHooksDir, err := filepath.Abs(p.config.HooksDir)
+	// safe to be shared across multiple vms
+	initializer, err := newRuntimeInitializer(p.app, p.config.HooksDir, p.config.OnInit)
 	if err != nil {
 		return err
 	}
@@ -278,46 +257,16 @@ func (p *plugin) registerHooks() error {
 		return e.Next()
 	})
 
-	// safe to be shared across multiple vms
-	requireRegistry := new(require.Registry)
-	templateRegistry := template.NewRegistry()
-
-	sharedBinds := func(vm *goja.Runtime) {
-		requireRegistry.Enable(vm)
-		console.Enable(vm)
-		process.Enable(vm)
-		buffer.Enable(vm)
-
-		baseBinds(vm)
-		dbxBinds(vm)
-		filesystemBinds(vm)
-		securityBinds(vm)
-		osBinds(vm)
-		filepathBinds(vm)
-		httpClientBinds(vm)
-		formsBinds(vm)
-		apisBinds(vm)
-		mailsBinds(vm)
-
-		vm.Set("$app", p.app)
-		vm.Set("$template", templateRegistry)
-		vm.Set("__hooks", absHooksDir)
-
-		if p.config.OnInit != nil {
-			p.config.OnInit(vm)
-		}
-	}
-
 	// initiliaze the executor vms
 	executors := newPool(p.config.HooksPoolSize, func() *goja.Runtime {
 		executor := goja.New()
-		sharedBinds(executor)
+		initializer.init(executor)
 		return executor
 	})
 
 	// initialize the loader vm
 	loader := goja.New()
-	sharedBinds(loader)
+	initializer.init(loader)
 	hooksBinds(p.app, loader, executors)
 	cronBinds(p.app, loader, executors)
 	routerBinds(p.app, loader, executors)
@@ -346,6 +295,74 @@ func (p *plugin) registerHooks() error {
 	return nil
 }
 
+// runtimeInitializer registers the common bindings of the plugin JS runtimes
+// (hooks, migrations, shell, etc.).
+//
+// It is safe to be shared across multiple runtimes.
+type runtimeInitializer struct {
+	app              core.App
+	onInit           func(vm *goja.Runtime)
+	absHooksDir      string
+	requireRegistry  *require.Registry
+	templateRegistry *template.Registry
+}
+
+// newRuntimeInitializer creates a new shared runtime initializer.
+//
+// The non-relative require(module) calls are resolved also from the hooks dir.
+//
+// If app is nil, the app specific bindings ($app and $apis) are not registered.
+func newRuntimeInitializer(app core.App, hooksDir string, onInit func(vm *goja.Runtime)) (*runtimeInitializer, error) {
+	absHooksDir, err := filepath.Abs(hooksDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runtimeInitializer{
+		app:              app,
+		onInit:           onInit,
+		absHooksDir:      absHooksDir,
+		requireRegistry:  require.NewRegistry(require.WithGlobalFolders(absHooksDir)),
+		templateRegistry: template.NewRegistry(),
+	}, nil
+}
+
+// init registers the common bindings in the provided runtime
+// and calls the OnInit callback (if any).
+func (ri *runtimeInitializer) init(vm *goja.Runtime) {
+	ri.requireRegistry.Enable(vm)
+	console.Enable(vm)
+	process.Enable(vm)
+	buffer.Enable(vm)
+
+	baseBinds(vm)
+	dbxBinds(vm)
+	filesystemBinds(vm)
+	securityBinds(vm)
+	osBinds(vm)
+	filepathBinds(vm)
+	httpClientBinds(vm)
+	formsBinds(vm)
+	mailsBinds(vm)
+
+	if ri.app != nil {
+		apisBinds(vm)
+		vm.Set("$app", ri.app)
+	}
+
+	vm.Set("$template", ri.templateRegistry)
+	vm.Set("__hooks", ri.absHooksDir)
+
+	if ri.onInit != nil {
+		ri.onInit(vm)
+	}
+}
+
+// defaultHooksDir returns the default app hooks directory ("pb_data/../pb_hooks").
+func defaultHooksDir(app core.App) string {
+	return filepath.Join(app.DataDir(), "../pb_hooks")
+}
+
 // normalizeExceptions registers a global error handler that
 // wraps the extracted goja exception error value for consistency
 // when throwing or returning errors.
@@ -563,32 +580,14 @@ func filesContent(dirPath string, pattern string) (map[string][]byte, error) {
 
 // 执行指定的 JavaScript 文件
 func RunJSFile(app core.App, filepath string) error {
-	vm := goja.New()
-
-	// 共享的 registry
-	requireRegistry := new(require.Registry)
-	templateRegistry := template.NewRegistry()
-
-	// 添加标准绑定
-	requireRegistry.Enable(vm)
-	console.Enable(vm)
-	process.Enable(vm)
-	buffer.Enable(vm)
-
-	baseBinds(vm)
-	dbxBinds(vm)
-	filesystemBinds(vm)
-	securityBinds(vm)
-	osBinds(vm)
-	filepathBinds(vm)
-	httpClientBinds(vm)
-	formsBinds(vm)
-	apisBinds(vm)
-	mailsBinds(vm)
+	initializer, err := newRuntimeInitializer(app, defaultHooksDir(app), nil)
+	if err != nil {
+		return err
+	}
 
-	// 注入全局变量
-	vm.Set("$app", app)
-	vm.Set("$template", templateRegistry)
+	// 添加标准绑定和全局变量
+	vm := goja.New()
+	initializer.init(vm)
 
 	// 读取并执行文件
 	content, err := os.ReadFile(filepath)
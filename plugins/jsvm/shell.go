@@ -0,0 +1,210 @@
+package jsvm
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+const shellHelp = `Available globals (in addition to the standard pb_hooks bindings):
+  $app                                 - the current app instance
+  $collections()                       - returns the names of all collections
+  $find(collection, [filter], [limit]) - returns the collection records matching the filter (default limit 30)
+  $settings([path])                    - returns the app settings or the value at the specified path (e.g. "smtp.host")
+
+Shell commands:
+  .help - prints this help
+  .exit - exits the shell
+`
+
+// NewShellCommand creates and returns new command for starting an
+// interactive JS shell (REPL) with access to the app instance.
+//
+// The shell runtime has the same bindings as the pb_hooks handlers
+// (plus a few helpers, see ".help") and it is intended mainly for
+// quick inspection and debugging of the application state.
+//
+// Example usage:
+//
+//	app.RootCmd.AddCommand(jsvm.NewShellCommand(app, jsvm.Config{}))
+func NewShellCommand(app core.App, config Config) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "shell",
+		Short:        "Starts an interactive JS shell with access to the app instance",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			vm, err := newShellVM(app, config)
+			if err != nil {
+				return err
+			}
+
+			return runShell(vm, command.InOrStdin(), command.OutOrStdout())
+		},
+	}
+
+	return command
+}
+
+// NewEvalCommand creates and returns new command for evaluating
+// a single JS expression with access to the app instance
+// (see also [NewShellCommand]).
+//
+// Example usage:
+//
+//	app.RootCmd.AddCommand(jsvm.NewEvalCommand(app, jsvm.Config{}))
+func NewEvalCommand(app core.App, config Config) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "eval",
+		Example:      `eval '$find("users", "verified = true", 10)'`,
+		Short:        "Evaluates a single JS expression and prints its result",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			vm, err := newShellVM(app, config)
+			if err != nil {
+				return err
+			}
+
+			result, err := vm.RunString(args[0])
+			if err != nil {
+				return err
+			}
+
+			return printShellValue(command.OutOrStdout(), result)
+		},
+	}
+
+	return command
+}
+
+// newShellVM initializes a new shell goja runtime with the
+// pb_hooks bindings and the shell helpers.
+func newShellVM(app core.App, config Config) (*goja.Runtime, error) {
+	hooksDir := config.HooksDir
+	if hooksDir == "" {
+		hooksDir = defaultHooksDir(app)
+	}
+
+	initializer, err := newRuntimeInitializer(app, hooksDir, config.OnInit)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := goja.New()
+
+	shellBinds(app, vm)
+
+	initializer.init(vm)
+
+	return vm, nil
+}
+
+func shellBinds(app core.App, vm *goja.Runtime) {
+	vm.Set("$collections", func() ([]string, error) {
+		collections, err := app.FindAllCollections()
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, len(collections))
+		for i, c := range collections {
+			names[i] = c.Name
+		}
+
+		return names, nil
+	})
+
+	vm.Set("$find", func(collectionNameOrId string, filter string, limit int) ([]*core.Record, error) {
+		if limit <= 0 {
+			limit = 30
+		}
+
+		return app.FindRecordsByFilter(collectionNameOrId, filter, "", limit, 0)
+	})
+
+	vm.Set("$settings", func(path string) (any, error) {
+		return app.Settings().GetPath(path)
+	})
+}
+
+// runShell starts a read-eval-print loop reading the JS statements from r.
+//
+// Incomplete statements (e.g. an unclosed function body) are
+// buffered until the following lines complete them.
+func runShell(vm *goja.Runtime, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+
+	var pending strings.Builder
+
+	fmt.Fprint(w, "> ")
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if pending.Len() == 0 {
+			switch strings.TrimSpace(line) {
+			case "":
+				fmt.Fprint(w, "> ")
+				continue
+			case ".exit":
+				return nil
+			case ".help":
+				fmt.Fprint(w, shellHelp)
+				fmt.Fprint(w, "> ")
+				continue
+			}
+		}
+
+		pending.WriteString(line)
+		pending.WriteString("\n")
+
+		result, err := vm.RunString(pending.String())
+		if err != nil {
+			// incomplete statement
+			if strings.Contains(err.Error(), "SyntaxError") && strings.Contains(err.Error(), "Unexpected end of input") {
+				fmt.Fprint(w, "... ")
+				continue
+			}
+
+			fmt.Fprintf(w, "Error: %v\n", err)
+		} else if err := printShellValue(w, result); err != nil {
+			fmt.Fprintf(w, "Error: %v\n", err)
+		}
+
+		pending.Reset()
+
+		fmt.Fprint(w, "> ")
+	}
+
+	return scanner.Err()
+}
+
+// printShellValue writes the JSON representation of the
+// specified JS value to w (strings are written as they are).
+func printShellValue(w io.Writer, value goja.Value) error {
+	if value == nil || goja.IsUndefined(value) {
+		_, err := fmt.Fprintln(w, "undefined")
+		return err
+	}
+
+	if str, ok := value.Export().(string); ok {
+		_, err := fmt.Fprintln(w, str)
+		return err
+	}
+
+	raw, err := json.MarshalIndent(value.Export(), "", "  ")
+	if err != nil {
+		// not serializable (e.g. a function)
+		_, err = fmt.Fprintln(w, value.String())
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(raw))
+	return err
+}
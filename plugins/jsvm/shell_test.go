@@ -0,0 +1,181 @@
+package jsvm
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestEvalCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name           string
+		expr           string
+		expectError    bool
+		expectedOutput []string
+	}{
+		{
+			"invalid expression",
+			"1 +",
+			true,
+			nil,
+		},
+		{
+			"thrown error",
+			"throw new Error('test_error')",
+			true,
+			nil,
+		},
+		{
+			"undefined result",
+			"let a = 1",
+			false,
+			[]string{"undefined"},
+		},
+		{
+			"string result",
+			"'a' + 'b'",
+			false,
+			[]string{"ab\n"},
+		},
+		{
+			"collections helper",
+			"$collections()",
+			false,
+			[]string{`"demo1"`, `"users"`},
+		},
+		{
+			"find helper",
+			`$find("demo1", "id = '84nmscqy84lsi1t'")`,
+			false,
+			[]string{`"id": "84nmscqy84lsi1t"`},
+		},
+		{
+			"find helper with invalid filter",
+			`$find("demo1", "missing = 1")`,
+			true,
+			nil,
+		},
+		{
+			"settings helper",
+			`$settings("meta.appName")`,
+			false,
+			[]string{app.Settings().Meta.AppName},
+		},
+		{
+			"app instance",
+			`$app.findCollectionByNameOrId("demo2").name`,
+			false,
+			[]string{"demo2"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+
+			command := NewEvalCommand(app, Config{})
+			command.SetOut(out)
+			command.SetErr(&bytes.Buffer{})
+			command.SetArgs([]string{s.expr})
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in\n%s", str, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestEvalCommandRequireFromHooksDir(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	hooksDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(hooksDir, "utils.js"), []byte(`module.exports = { hello: (name) => "Hello " + name }`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+
+	command := NewEvalCommand(app, Config{HooksDir: hooksDir})
+	command.SetOut(out)
+	command.SetErr(&bytes.Buffer{})
+	command.SetArgs([]string{`require("utils.js").hello("test") + " " + __hooks`})
+
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Hello test " + hooksDir + "\n"
+	if out.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestShellCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	input := strings.Join([]string{
+		".help",
+		"",
+		"function sum(a, b) {",
+		"  return a + b",
+		"}",
+		"sum(2, 3)",
+		"missingVar",
+		"$settings('meta').appName",
+		".exit",
+		"'after exit'",
+	}, "\n")
+
+	out := &bytes.Buffer{}
+
+	command := NewShellCommand(app, Config{})
+	command.SetIn(strings.NewReader(input))
+	command.SetOut(out)
+
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectations := []string{
+		"$collections()",
+		"... ",
+		"> 5\n",
+		"Error: ReferenceError: missingVar is not defined",
+		app.Settings().Meta.AppName,
+	}
+	for _, str := range expectations {
+		if !strings.Contains(out.String(), str) {
+			t.Fatalf("Cannot find %q in\n%s", str, out.String())
+		}
+	}
+
+	if strings.Contains(out.String(), "after exit") {
+		t.Fatalf("Expected the shell to stop after .exit, got\n%s", out.String())
+	}
+}
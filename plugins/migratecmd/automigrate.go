@@ -70,7 +70,7 @@ func (p *plugin) automigrateOnCollectionChange(e *core.CollectionRequestEvent) e
 	name := fmt.Sprintf("%d_%s.%s", time.Now().Unix(), action, p.config.TemplateLang)
 	filePath := filepath.Join(p.config.Dir, name)
 
-	return p.app.RunInTransaction(func(txApp core.App) error {
+	err = p.app.RunInTransaction(func(txApp core.App) error {
 		// insert the migration entry
 		_, err := txApp.DB().Insert(core.DefaultMigrationsTable, dbx.Params{
 			"file": name,
@@ -93,6 +93,11 @@ func (p *plugin) automigrateOnCollectionChange(e *core.CollectionRequestEvent) e
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	return p.syncTypeScript()
 }
 
 func normalizeCollectionName(name string) string {
@@ -0,0 +1,361 @@
+package migratecmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// diffRemotePerPage is the number of collections requested per page
+// when loading the schema of a remote PocketBase instance.
+const diffRemotePerPage = 200
+
+// collectionsDiff describes a single collection change between two schemas.
+type collectionsDiff struct {
+	new *core.Collection
+	old *core.Collection
+}
+
+// migrateDiffHandler generates migration files that apply the differences
+// between the collections of the "from" source (aka. the target environment)
+// and the local app collections.
+//
+// The from argument could be either a path to an exported collections
+// JSON file or the url of a PocketBase instance (in which case the token
+// argument is expected to be a valid superuser auth token).
+//
+// It returns the names of the generated migration files (if any).
+func (p *plugin) migrateDiffHandler(from string, token string, interactive bool) ([]string, error) {
+	if from == "" {
+		return nil, errors.New("missing --from schema source (exported collections file or PocketBase url)")
+	}
+
+	remote, err := loadDiffSource(from, token)
+	if err != nil {
+		return nil, err
+	}
+
+	local := []*core.Collection{}
+	if err := p.app.CollectionQuery().OrderBy("created ASC").All(&local); err != nil {
+		return nil, fmt.Errorf("failed to fetch the local collections: %w", err)
+	}
+
+	changes := diffCollections(remote, local)
+
+	// generate the migration templates
+	templates := make([]string, 0, len(changes))
+	names := make([]string, 0, len(changes))
+	for _, change := range changes {
+		var template string
+		var templateErr error
+		if p.config.TemplateLang == TemplateLangJS {
+			template, templateErr = p.jsDiffTemplate(change.new, change.old)
+		} else {
+			template, templateErr = p.goDiffTemplate(change.new, change.old)
+		}
+		if templateErr != nil {
+			if errors.Is(templateErr, ErrEmptyTemplate) {
+				continue // no changes
+			}
+			return nil, fmt.Errorf("failed to resolve template: %w", templateErr)
+		}
+
+		var action string
+		switch {
+		case change.new == nil:
+			action = "deleted_" + normalizeCollectionName(change.old.Name)
+		case change.old == nil:
+			action = "created_" + normalizeCollectionName(change.new.Name)
+		default:
+			action = "updated_" + normalizeCollectionName(change.old.Name)
+		}
+
+		templates = append(templates, template)
+		names = append(names, action)
+	}
+
+	if len(templates) == 0 {
+		if interactive {
+			fmt.Println("No collection differences found.")
+		}
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(p.config.Dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create migration dir: %w", err)
+	}
+
+	// increment the timestamp of each file to preserve the changes order
+	now := time.Now().Unix()
+	for i, template := range templates {
+		names[i] = fmt.Sprintf("%d_%s.%s", now+int64(i), names[i], p.config.TemplateLang)
+
+		filePath := filepath.Join(p.config.Dir, names[i])
+
+		if err := os.WriteFile(filePath, []byte(template), 0644); err != nil {
+			return nil, fmt.Errorf("failed to save migration file %q: %w", filePath, err)
+		}
+
+		if interactive {
+			fmt.Printf("Successfully created file %q\n", filePath)
+		}
+	}
+
+	return names, nil
+}
+
+// diffCollections compares the old and new collections lists and
+// returns the changes in the order they should be applied.
+//
+// The collections (and their fields) are matched by their id with fallback to their name.
+//
+// Note that the newList collections could be modified in place.
+func diffCollections(oldList []*core.Collection, newList []*core.Collection) []collectionsDiff {
+	var creates, updates, deletes []collectionsDiff
+
+	findOld := func(c *core.Collection) *core.Collection {
+		var byName *core.Collection
+		for _, old := range oldList {
+			if old.Id == c.Id {
+				return old
+			}
+			if byName == nil && strings.EqualFold(old.Name, c.Name) {
+				byName = old
+			}
+		}
+		return byName
+	}
+
+	matched := map[*core.Collection]bool{}
+
+	for _, c := range newList {
+		old := findOld(c)
+		if old == nil {
+			creates = append(creates, collectionsDiff{new: c})
+			continue
+		}
+
+		matched[old] = true
+
+		// ensure that the collections and fields matched by their name
+		// are updated in place (aka. without recreating them)
+		c.Id = old.Id
+		for _, f := range c.Fields {
+			if old.Fields.GetById(f.GetId()) != nil {
+				continue
+			}
+
+			oldField := old.Fields.GetByName(f.GetName())
+			if oldField != nil && c.Fields.GetById(oldField.GetId()) == nil {
+				f.SetId(oldField.GetId())
+			}
+		}
+
+		updates = append(updates, collectionsDiff{new: c, old: old})
+	}
+
+	for _, old := range oldList {
+		if !matched[old] {
+			deletes = append(deletes, collectionsDiff{old: old})
+		}
+	}
+
+	// for now exclude OAuth2 configs from the migrations (similar to the automigrations)
+	for _, list := range [][]collectionsDiff{creates, updates, deletes} {
+		for _, change := range list {
+			for _, c := range []*core.Collection{change.new, change.old} {
+				if c != nil && c.IsAuth() {
+					c.OAuth2.Providers = nil
+				}
+			}
+		}
+	}
+
+	sortByDependencies(creates, func(d collectionsDiff) *core.Collection { return d.new })
+
+	// update the views last since they may depend on the other changes
+	slices.SortStableFunc(updates, func(a, b collectionsDiff) int {
+		return boolToInt(a.new.IsView()) - boolToInt(b.new.IsView())
+	})
+
+	// delete in reverse dependencies order
+	sortByDependencies(deletes, func(d collectionsDiff) *core.Collection { return d.old })
+	slices.Reverse(deletes)
+
+	result := make([]collectionsDiff, 0, len(creates)+len(updates)+len(deletes))
+	result = append(result, creates...)
+	result = append(result, updates...)
+	result = append(result, deletes...)
+
+	return result
+}
+
+// sortByDependencies sorts in place the provided changes so that the
+// collections referenced by relation fields are before the ones referencing them
+// (views are always last).
+//
+// Circular relations are left in their original order.
+func sortByDependencies(changes []collectionsDiff, get func(collectionsDiff) *core.Collection) {
+	sorted := make([]collectionsDiff, 0, len(changes))
+	added := map[string]bool{}
+
+	var visit func(d collectionsDiff, visiting map[string]bool)
+	visit = func(d collectionsDiff, visiting map[string]bool) {
+		c := get(d)
+		if added[c.Id] || visiting[c.Id] {
+			return
+		}
+		visiting[c.Id] = true
+
+		for _, f := range c.Fields {
+			rel, ok := f.(*core.RelationField)
+			if !ok || rel.CollectionId == c.Id {
+				continue
+			}
+			for _, dep := range changes {
+				if get(dep).Id == rel.CollectionId {
+					visit(dep, visiting)
+				}
+			}
+		}
+
+		added[c.Id] = true
+		sorted = append(sorted, d)
+	}
+
+	for _, isView := range []bool{false, true} {
+		for _, d := range changes {
+			if get(d).IsView() == isView {
+				visit(d, map[string]bool{})
+			}
+		}
+	}
+
+	copy(changes, sorted)
+}
+
+func boolToInt(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// -------------------------------------------------------------------
+
+// loadDiffSource loads the collections from an exported collections
+// JSON file or from the specified PocketBase instance url.
+func loadDiffSource(from string, token string) ([]*core.Collection, error) {
+	if _, err := os.Stat(from); err == nil {
+		raw, err := os.ReadFile(from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", from, err)
+		}
+
+		collections, err := unmarshalCollections(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %w", from, err)
+		}
+
+		return collections, nil
+	}
+
+	if !strings.Contains(from, "://") {
+		from = "https://" + from
+	}
+
+	baseURL, err := url.Parse(from)
+	if err != nil || baseURL.Host == "" {
+		return nil, fmt.Errorf("%q is neither an existing file nor a valid url", from)
+	}
+
+	if token == "" {
+		return nil, errors.New("missing --token superuser auth token for the remote instance")
+	}
+
+	return fetchRemoteCollections(baseURL, token)
+}
+
+func fetchRemoteCollections(baseURL *url.URL, token string) ([]*core.Collection, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	result := []*core.Collection{}
+
+	for page := 1; ; page++ {
+		pageURL := baseURL.JoinPath("/api/collections")
+		pageURL.RawQuery = url.Values{
+			"page":      {fmt.Sprint(page)},
+			"perPage":   {fmt.Sprint(diffRemotePerPage)},
+			"sort":      {"created"},
+			"skipTotal": {"1"},
+		}.Encode()
+
+		req, err := http.NewRequest(http.MethodGet, pageURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", token)
+
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the remote collections: %w", err)
+		}
+
+		raw, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the remote collections response: %w", err)
+		}
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch the remote collections (%d): %s", res.StatusCode, raw)
+		}
+
+		data := struct {
+			Items json.RawMessage `json:"items"`
+		}{}
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("failed to parse the remote collections response: %w", err)
+		}
+
+		items, err := unmarshalCollections(data.Items)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the remote collections response: %w", err)
+		}
+
+		result = append(result, items...)
+
+		if len(items) < diffRemotePerPage {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func unmarshalCollections(raw []byte) ([]*core.Collection, error) {
+	items := []json.RawMessage{}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+
+	collections := make([]*core.Collection, len(items))
+	for i, item := range items {
+		collections[i] = &core.Collection{}
+		if err := json.Unmarshal(item, collections[i]); err != nil {
+			return nil, fmt.Errorf("collection %d: %w", i, err)
+		}
+	}
+
+	return collections, nil
+}
@@ -0,0 +1,213 @@
+package migratecmd_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/plugins/migratecmd"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cobra"
+)
+
+// diffSourceCollections returns the app collections modified so that
+// compared to the app they have deleted "demo3", updated "demo2" and extra "remote_only" collection.
+func diffSourceCollections(t *testing.T, app core.App) []*core.Collection {
+	collections := []*core.Collection{}
+	if err := app.CollectionQuery().OrderBy("created ASC").All(&collections); err != nil {
+		t.Fatal(err)
+	}
+
+	result := make([]*core.Collection, 0, len(collections))
+	for _, c := range collections {
+		switch c.Name {
+		case "demo3":
+			continue
+		case "demo2":
+			c.ListRule = types.Pointer("id = 'remote'")
+		}
+		result = append(result, c)
+	}
+
+	extra := core.NewBaseCollection("remote_only", "remote_only_id")
+	extra.Fields.Add(&core.TextField{Name: "title", Id: "remote_title"})
+	result = append(result, extra)
+
+	return result
+}
+
+func runMigrateDiff(t *testing.T, app core.App, dir string, lang string, args ...string) error {
+	rootCmd := &cobra.Command{}
+	migratecmd.MustRegister(app, rootCmd, migratecmd.Config{
+		Dir:          dir,
+		TemplateLang: lang,
+	})
+
+	rootCmd.SetArgs(append([]string{"migrate", "diff"}, args...))
+
+	return rootCmd.Execute()
+}
+
+func checkDiffFiles(t *testing.T, dir string, lang string) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSuffixes := []string{
+		"_created_demo3." + lang,
+		"_updated_demo2." + lang,
+		"_deleted_remote_only." + lang,
+	}
+
+	if len(files) != len(expectedSuffixes) {
+		names := make([]string, len(files))
+		for i, f := range files {
+			names[i] = f.Name()
+		}
+		t.Fatalf("Expected %d migration files, got %d: %v", len(expectedSuffixes), len(files), names)
+	}
+
+	// the files are sorted by name
+	for i, suffix := range expectedSuffixes {
+		if !strings.HasSuffix(files[i].Name(), suffix) {
+			t.Fatalf("Expected file %d to end with %q, got %q", i, suffix, files[i].Name())
+		}
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, files[1].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `"listRule": ""`) || !strings.Contains(string(raw), `"listRule": "id = 'remote'"`) {
+		t.Fatalf("Expected the demo2 listRule up and down changes, got\n%s", raw)
+	}
+}
+
+func TestMigrateDiffFromFile(t *testing.T) {
+	t.Parallel()
+
+	for _, lang := range []string{migratecmd.TemplateLangJS, migratecmd.TemplateLangGo} {
+		t.Run(lang, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			migrationsDir := filepath.Join(app.DataDir(), "_test_migrations")
+
+			raw, err := json.Marshal(diffSourceCollections(t, app))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			schemaFile := filepath.Join(app.DataDir(), "_test_schema.json")
+			if err := os.WriteFile(schemaFile, raw, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := runMigrateDiff(t, app, migrationsDir, lang, "--from", schemaFile); err != nil {
+				t.Fatal(err)
+			}
+
+			checkDiffFiles(t, migrationsDir, lang)
+		})
+	}
+}
+
+func TestMigrateDiffFromURL(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	remoteCollections := diffSourceCollections(t, app)
+
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?page="+r.URL.Query().Get("page"))
+
+		if r.Header.Get("Authorization") != "test_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"unauthorized"}`))
+			return
+		}
+
+		items := []*core.Collection{}
+		if r.URL.Query().Get("page") == "1" {
+			items = remoteCollections
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"items": items})
+	}))
+	defer server.Close()
+
+	migrationsDir := filepath.Join(app.DataDir(), "_test_migrations")
+
+	t.Run("missing token", func(t *testing.T) {
+		err := runMigrateDiff(t, app, migrationsDir, migratecmd.TemplateLangJS, "--from", server.URL)
+		if err == nil || !strings.Contains(err.Error(), "--token") {
+			t.Fatalf("Expected missing token error, got %v", err)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		err := runMigrateDiff(t, app, migrationsDir, migratecmd.TemplateLangJS, "--from", server.URL, "--token", "invalid")
+		if err == nil || !strings.Contains(err.Error(), "401") {
+			t.Fatalf("Expected unauthorized error, got %v", err)
+		}
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		requests = nil
+
+		err := runMigrateDiff(t, app, migrationsDir, migratecmd.TemplateLangJS, "--from", server.URL, "--token", "test_token")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(requests, []string{"/api/collections?page=1"}) {
+			t.Fatalf("Unexpected remote requests %v", requests)
+		}
+
+		checkDiffFiles(t, migrationsDir, migratecmd.TemplateLangJS)
+	})
+}
+
+func TestMigrateDiffNoChanges(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collections := []*core.Collection{}
+	if err := app.CollectionQuery().All(&collections); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := json.Marshal(collections)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaFile := filepath.Join(app.DataDir(), "_test_schema.json")
+	if err := os.WriteFile(schemaFile, raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	migrationsDir := filepath.Join(app.DataDir(), "_test_migrations")
+
+	if err := runMigrateDiff(t, app, migrationsDir, migratecmd.TemplateLangGo, "--from", schemaFile); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(migrationsDir); !os.IsNotExist(err) {
+		t.Fatalf("Expected no migrations dir to be created, got %v", err)
+	}
+}
@@ -0,0 +1,635 @@
+package migratecmd
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/osutils"
+	"github.com/spf13/cast"
+)
+
+// FromSQLConfig defines the options of the "migrate from-sql" command.
+type FromSQLConfig struct {
+	// Driver is the registered [database/sql] driver name of the source database.
+	//
+	// Only the "sqlite" driver is available by default. For MySQL and
+	// PostgreSQL you'll have to register the related driver in your app
+	// (eg. github.com/go-sql-driver/mysql or github.com/jackc/pgx/v5/stdlib).
+	Driver string
+
+	// DSN is the source database connection string.
+	DSN string
+
+	// Tables is an optional list with the source tables to import (default to all).
+	Tables []string
+
+	// DryRun prints the proposed collections mapping without importing anything.
+	DryRun bool
+}
+
+type sqlDialect string
+
+const (
+	sqlDialectSQLite   sqlDialect = "sqlite"
+	sqlDialectMySQL    sqlDialect = "mysql"
+	sqlDialectPostgres sqlDialect = "postgres"
+)
+
+type sqlTable struct {
+	Name        string
+	Columns     []*sqlColumn
+	ForeignKeys []*sqlForeignKey
+}
+
+// primaryKey returns the single column primary key of the table (if any).
+func (t *sqlTable) primaryKey() *sqlColumn {
+	var pk *sqlColumn
+	for _, c := range t.Columns {
+		if c.PrimaryKey {
+			if pk != nil {
+				return nil // composite
+			}
+			pk = c
+		}
+	}
+	return pk
+}
+
+type sqlColumn struct {
+	Name       string
+	Type       string
+	PrimaryKey bool
+}
+
+type sqlForeignKey struct {
+	Column    string
+	RefTable  string
+	RefColumn string
+}
+
+// sqlTableMapping describes the proposed mapping of a single source table.
+type sqlTableMapping struct {
+	table      *sqlTable
+	collection *core.Collection
+	fields     map[string]string // column name -> field name (missing for the skipped columns)
+	relations  map[string]*core.RelationField
+}
+
+// migrateFromSQLHandler introspects the source database tables, creates
+// the corresponding collections (converting the single column foreign keys
+// to relation fields) and copies the tables data.
+//
+// The source tables with single column primary key are imported
+// with deterministic record ids generated from their primary key value.
+func (p *plugin) migrateFromSQLHandler(config FromSQLConfig, interactive bool) ([]*core.Collection, error) {
+	if config.Driver == "" || config.DSN == "" {
+		return nil, errors.New("missing --driver or --dsn source database argument")
+	}
+
+	dialect, err := resolveSQLDialect(config.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(config.Driver, config.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the source database: %w", err)
+	}
+	defer db.Close()
+
+	tables, err := introspectSQLTables(db, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to introspect the source database: %w", err)
+	}
+
+	if len(config.Tables) > 0 {
+		tables = slices.DeleteFunc(tables, func(t *sqlTable) bool {
+			return !slices.Contains(config.Tables, t.Name)
+		})
+	}
+
+	if len(tables) == 0 {
+		return nil, errors.New("no source tables to import")
+	}
+
+	mappings, err := proposeSQLMappings(p.app, tables)
+	if err != nil {
+		return nil, err
+	}
+
+	if interactive || config.DryRun {
+		fmt.Print(describeSQLMappings(mappings))
+	}
+
+	if config.DryRun {
+		return nil, nil
+	}
+
+	if interactive {
+		confirm := osutils.YesNoPrompt("Do you really want to create the above collections and import their data?", false)
+		if !confirm {
+			fmt.Println("The command has been cancelled")
+			return nil, nil
+		}
+	}
+
+	err = p.app.RunInTransaction(func(txApp core.App) error {
+		// create the collections without the relation fields
+		// (they are added after all collections are created to allow circular references)
+		for _, m := range mappings {
+			if err := txApp.Save(m.collection); err != nil {
+				return fmt.Errorf("failed to create collection %q: %w", m.collection.Name, err)
+			}
+		}
+
+		for _, m := range mappings {
+			if len(m.relations) == 0 {
+				continue
+			}
+
+			for _, column := range slices.Sorted(maps.Keys(m.relations)) {
+				m.collection.Fields.Add(m.relations[column])
+			}
+
+			if err := txApp.Save(m.collection); err != nil {
+				return fmt.Errorf("failed to add the %q relation fields: %w", m.collection.Name, err)
+			}
+		}
+
+		for _, m := range mappings {
+			total, err := importSQLTableData(txApp, db, dialect, m)
+			if err != nil {
+				return fmt.Errorf("failed to import table %q: %w", m.table.Name, err)
+			}
+
+			if interactive {
+				fmt.Printf("Imported %d %q records\n", total, m.collection.Name)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*core.Collection, len(mappings))
+	for i, m := range mappings {
+		result[i] = m.collection
+	}
+
+	return result, nil
+}
+
+func resolveSQLDialect(driver string) (sqlDialect, error) {
+	d := strings.ToLower(driver)
+
+	switch {
+	case strings.Contains(d, "sqlite"):
+		return sqlDialectSQLite, nil
+	case strings.Contains(d, "mysql"):
+		return sqlDialectMySQL, nil
+	case strings.Contains(d, "postgres"), strings.Contains(d, "pgx"), d == "pq":
+		return sqlDialectPostgres, nil
+	}
+
+	return "", fmt.Errorf("unsupported source database driver %q (expected sqlite, mysql or postgres compatible)", driver)
+}
+
+func introspectSQLTables(db *sql.DB, dialect sqlDialect) ([]*sqlTable, error) {
+	switch dialect {
+	case sqlDialectSQLite:
+		return introspectSQLiteTables(db)
+	case sqlDialectMySQL:
+		return introspectInformationSchema(db, dialect, "DATABASE()")
+	default:
+		return introspectInformationSchema(db, dialect, "current_schema()")
+	}
+}
+
+func introspectSQLiteTables(db *sql.DB) ([]*sqlTable, error) {
+	var names []string
+	if err := queryColumn(db, &names, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name"); err != nil {
+		return nil, err
+	}
+
+	tables := make([]*sqlTable, 0, len(names))
+
+	for _, name := range names {
+		table := &sqlTable{Name: name}
+
+		rows, err := db.Query("SELECT name, type, pk FROM pragma_table_info(?)", name)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			c := &sqlColumn{}
+			var pk int
+			if err := rows.Scan(&c.Name, &c.Type, &pk); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			c.PrimaryKey = pk > 0
+			table.Columns = append(table.Columns, c)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		rows, err = db.Query(`SELECT "id", "from", "table", COALESCE("to", '') FROM pragma_foreign_key_list(?) ORDER BY "id", "seq"`, name)
+		if err != nil {
+			return nil, err
+		}
+		fks := map[int][]*sqlForeignKey{}
+		for rows.Next() {
+			fk := &sqlForeignKey{}
+			var id int
+			if err := rows.Scan(&id, &fk.Column, &fk.RefTable, &fk.RefColumn); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			fks[id] = append(fks[id], fk)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, id := range slices.Sorted(maps.Keys(fks)) {
+			// ignore composite foreign keys
+			if len(fks[id]) == 1 {
+				table.ForeignKeys = append(table.ForeignKeys, fks[id][0])
+			}
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+func introspectInformationSchema(db *sql.DB, dialect sqlDialect, schemaExpr string) ([]*sqlTable, error) {
+	placeholder := "?"
+	if dialect == sqlDialectPostgres {
+		placeholder = "$1"
+	}
+
+	var schema string
+	if err := db.QueryRow("SELECT " + schemaExpr).Scan(&schema); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	err := queryColumn(db, &names, "SELECT table_name FROM information_schema.tables WHERE table_schema = "+placeholder+" AND table_type = 'BASE TABLE' ORDER BY table_name", schema)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]*sqlTable, len(names))
+	tablesMap := make(map[string]*sqlTable, len(names))
+	for i, name := range names {
+		tables[i] = &sqlTable{Name: name}
+		tablesMap[name] = tables[i]
+	}
+
+	rows, err := db.Query("SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = "+placeholder+" ORDER BY table_name, ordinal_position", schema)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var tableName string
+		c := &sqlColumn{}
+		if err := rows.Scan(&tableName, &c.Name, &c.Type); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if t := tablesMap[tableName]; t != nil {
+			t.Columns = append(t.Columns, c)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// primary and foreign keys
+	keysQuery := `
+		SELECT tc.constraint_type, kcu.constraint_name, kcu.table_name, kcu.column_name,
+			COALESCE(ccu.table_name, ''), COALESCE(ccu.column_name, '')
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema AND kcu.table_name = tc.table_name
+		LEFT JOIN information_schema.constraint_column_usage ccu
+			ON tc.constraint_type = 'FOREIGN KEY' AND ccu.constraint_name = tc.constraint_name AND ccu.table_schema = tc.table_schema
+		WHERE tc.table_schema = ` + placeholder + ` AND tc.constraint_type IN ('PRIMARY KEY', 'FOREIGN KEY')`
+	if dialect == sqlDialectMySQL {
+		// MySQL doesn't have constraint_column_usage
+		keysQuery = `
+			SELECT tc.constraint_type, kcu.constraint_name, kcu.table_name, kcu.column_name,
+				COALESCE(kcu.referenced_table_name, ''), COALESCE(kcu.referenced_column_name, '')
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema AND kcu.table_name = tc.table_name
+			WHERE tc.table_schema = ? AND tc.constraint_type IN ('PRIMARY KEY', 'FOREIGN KEY')`
+	}
+
+	rows, err = db.Query(keysQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fkColumns := map[string]int{}
+	for rows.Next() {
+		var constraintType, constraintName, tableName, columnName, refTable, refColumn string
+		if err := rows.Scan(&constraintType, &constraintName, &tableName, &columnName, &refTable, &refColumn); err != nil {
+			return nil, err
+		}
+
+		t := tablesMap[tableName]
+		if t == nil {
+			continue
+		}
+
+		if constraintType == "PRIMARY KEY" {
+			for _, c := range t.Columns {
+				if c.Name == columnName {
+					c.PrimaryKey = true
+				}
+			}
+			continue
+		}
+
+		fkColumns[tableName+"."+constraintName]++
+		if fkColumns[tableName+"."+constraintName] > 1 {
+			// composite foreign key
+			t.ForeignKeys = slices.DeleteFunc(t.ForeignKeys, func(fk *sqlForeignKey) bool {
+				return fk.RefTable == refTable
+			})
+			continue
+		}
+
+		t.ForeignKeys = append(t.ForeignKeys, &sqlForeignKey{Column: columnName, RefTable: refTable, RefColumn: refColumn})
+	}
+
+	return tables, rows.Err()
+}
+
+func queryColumn(db *sql.DB, dest *[]string, query string, args ...any) error {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return err
+		}
+		*dest = append(*dest, v)
+	}
+
+	return rows.Err()
+}
+
+// -------------------------------------------------------------------
+
+var invalidNameCharsRegex = regexp.MustCompile(`\W+`)
+
+func normalizeSQLName(name string) string {
+	return strings.Trim(invalidNameCharsRegex.ReplaceAllString(name, "_"), "_")
+}
+
+// proposeSQLMappings creates the (unsaved) collections for the provided source tables.
+func proposeSQLMappings(app core.App, tables []*sqlTable) ([]*sqlTableMapping, error) {
+	mappings := make([]*sqlTableMapping, 0, len(tables))
+	byTable := make(map[string]*sqlTableMapping, len(tables))
+
+	for _, table := range tables {
+		name := normalizeSQLName(table.Name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid collection name for table %q", table.Name)
+		}
+
+		if existing, _ := app.FindCollectionByNameOrId(name); existing != nil {
+			return nil, fmt.Errorf("collection %q for table %q already exists", name, table.Name)
+		}
+
+		m := &sqlTableMapping{
+			table:      table,
+			collection: core.NewBaseCollection(name),
+			fields:     map[string]string{},
+			relations:  map[string]*core.RelationField{},
+		}
+
+		mappings = append(mappings, m)
+		byTable[table.Name] = m
+	}
+
+	for _, m := range mappings {
+		pk := m.table.primaryKey()
+
+		for _, column := range m.table.Columns {
+			fieldName := normalizeSQLName(column.Name)
+			if fieldName == "" || strings.EqualFold(fieldName, core.FieldNameId) {
+				fieldName = "legacy_" + fieldName
+			}
+
+			// the single column foreign keys with imported target table are converted to relations
+			if fk := findForeignKey(m.table, column.Name); fk != nil && byTable[fk.RefTable] != nil {
+				target := byTable[fk.RefTable]
+				if targetPK := target.table.primaryKey(); targetPK != nil && (fk.RefColumn == "" || fk.RefColumn == targetPK.Name) {
+					m.relations[column.Name] = &core.RelationField{
+						Name:         fieldName,
+						CollectionId: target.collection.Id,
+						MaxSelect:    1,
+					}
+					m.fields[column.Name] = fieldName
+					continue
+				}
+			}
+
+			field := sqlColumnField(column, fieldName)
+			if field == nil {
+				continue // unsupported
+			}
+
+			if column == pk {
+				// keep the original primary key values unique
+				m.collection.Indexes = append(m.collection.Indexes, fmt.Sprintf(
+					"CREATE UNIQUE INDEX `idx_%s_%s` ON `%s` (`%s`)",
+					m.collection.Name, fieldName, m.collection.Name, fieldName,
+				))
+			}
+
+			m.collection.Fields.Add(field)
+			m.fields[column.Name] = fieldName
+		}
+	}
+
+	return mappings, nil
+}
+
+func findForeignKey(table *sqlTable, column string) *sqlForeignKey {
+	for _, fk := range table.ForeignKeys {
+		if fk.Column == column {
+			return fk
+		}
+	}
+	return nil
+}
+
+// sqlColumnField returns the collection field that corresponds to the column type
+// (or nil for the unsupported binary column types).
+func sqlColumnField(column *sqlColumn, name string) core.Field {
+	t := strings.ToLower(column.Type)
+
+	switch {
+	case strings.Contains(t, "blob"), strings.Contains(t, "binary"), strings.Contains(t, "bytea"):
+		return nil
+	case strings.Contains(t, "bool"), t == "bit":
+		return &core.BoolField{Name: name}
+	case strings.Contains(t, "int"):
+		return &core.NumberField{Name: name, OnlyInt: true}
+	case strings.Contains(t, "real"),
+		strings.Contains(t, "floa"),
+		strings.Contains(t, "doub"),
+		strings.Contains(t, "dec"),
+		strings.Contains(t, "numeric"):
+		return &core.NumberField{Name: name}
+	case strings.Contains(t, "date"), strings.Contains(t, "time"):
+		return &core.DateField{Name: name}
+	case strings.Contains(t, "json"):
+		return &core.JSONField{Name: name}
+	default:
+		return &core.TextField{Name: name}
+	}
+}
+
+func describeSQLMappings(mappings []*sqlTableMapping) string {
+	var sb strings.Builder
+
+	for _, m := range mappings {
+		fmt.Fprintf(&sb, "%s -> collection %q\n", m.table.Name, m.collection.Name)
+
+		for _, column := range m.table.Columns {
+			fieldName, ok := m.fields[column.Name]
+			if !ok {
+				fmt.Fprintf(&sb, "  %s (%s) -> skipped (unsupported type)\n", column.Name, column.Type)
+				continue
+			}
+
+			var fieldType string
+			if rel := m.relations[column.Name]; rel != nil {
+				fk := findForeignKey(m.table, column.Name)
+				fieldType = "relation to " + normalizeSQLName(fk.RefTable)
+			} else {
+				fieldType = m.collection.Fields.GetByName(fieldName).Type()
+			}
+
+			fmt.Fprintf(&sb, "  %s (%s) -> %s (%s)\n", column.Name, column.Type, fieldName, fieldType)
+		}
+	}
+
+	return sb.String()
+}
+
+// -------------------------------------------------------------------
+
+func importSQLTableData(
+	txApp core.App,
+	db *sql.DB,
+	dialect sqlDialect,
+	m *sqlTableMapping,
+) (int, error) {
+	rows, err := db.Query("SELECT * FROM " + quoteSQLIdentifier(dialect, m.table.Name))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	pk := m.table.primaryKey()
+
+	var total int
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return total, err
+		}
+
+		record := core.NewRecord(m.collection)
+
+		for i, column := range columns {
+			value := values[i]
+			if b, ok := value.([]byte); ok {
+				value = string(b)
+			}
+
+			if pk != nil && column == pk.Name {
+				record.Id = sqlRecordId(m.table.Name, value)
+			}
+
+			fieldName, ok := m.fields[column]
+			if !ok || value == nil {
+				continue
+			}
+
+			if rel := m.relations[column]; rel != nil {
+				fk := findForeignKey(m.table, column)
+				record.Set(fieldName, sqlRecordId(fk.RefTable, value))
+				continue
+			}
+
+			record.Set(fieldName, value)
+		}
+
+		// skip the validations since the relations could reference
+		// not imported yet records and the legacy data may not be strictly valid
+		if err := txApp.SaveNoValidate(record); err != nil {
+			return total, err
+		}
+
+		total++
+	}
+
+	return total, rows.Err()
+}
+
+func quoteSQLIdentifier(dialect sqlDialect, name string) string {
+	if dialect == sqlDialectMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqlRecordId generates a deterministic 15 characters [a-z0-9] record id
+// from the source table name and primary key value.
+func sqlRecordId(table string, pkValue any) string {
+	hash := sha256.Sum256([]byte(table + "\x00" + cast.ToString(pkValue)))
+
+	id := new(big.Int).SetBytes(hash[:]).Text(36)
+
+	return id[len(id)-15:]
+}
@@ -0,0 +1,215 @@
+package migratecmd
+
+import (
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createLegacySQLiteDB(t *testing.T) string {
+	dsn := filepath.Join(t.TempDir(), "legacy.db")
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE authors (
+			id INTEGER PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			active BOOLEAN,
+			avatar BLOB
+		);
+		CREATE TABLE "blog-posts" (
+			id INTEGER PRIMARY KEY,
+			title TEXT,
+			rating REAL,
+			published_at DATETIME,
+			meta JSON,
+			author_id INTEGER REFERENCES authors(id)
+		);
+		CREATE TABLE tags (name TEXT, post_id INTEGER REFERENCES "blog-posts"(id));
+
+		INSERT INTO authors (id, name, active) VALUES (1, 'John', 1), (2, 'Jane', 0);
+		INSERT INTO "blog-posts" (id, title, rating, published_at, meta, author_id) VALUES
+			(10, 'First', 4.5, '2024-01-02 03:04:05', '{"a":1}', 2),
+			(11, 'Second', NULL, NULL, NULL, NULL);
+		INSERT INTO tags (name, post_id) VALUES ('go', 10), ('db', 10);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dsn
+}
+
+func TestMigrateFromSQLDryRun(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	p := &plugin{app: app}
+
+	result, err := p.migrateFromSQLHandler(FromSQLConfig{
+		Driver: "sqlite",
+		DSN:    createLegacySQLiteDB(t),
+		DryRun: true,
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result != nil {
+		t.Fatalf("Expected nil result, got %v", result)
+	}
+
+	if _, err := app.FindCollectionByNameOrId("authors"); err == nil {
+		t.Fatal("Expected the authors collection to not be created")
+	}
+}
+
+func TestMigrateFromSQLInvalid(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	p := &plugin{app: app}
+
+	scenarios := []struct {
+		name          string
+		config        FromSQLConfig
+		expectedError string
+	}{
+		{"missing dsn", FromSQLConfig{Driver: "sqlite"}, "missing"},
+		{"unsupported driver", FromSQLConfig{Driver: "oracle", DSN: "test"}, "unsupported"},
+		{"missing tables", FromSQLConfig{Driver: "sqlite", DSN: createLegacySQLiteDB(t), Tables: []string{"missing"}}, "no source tables"},
+		{"existing collection", FromSQLConfig{Driver: "sqlite", DSN: createLegacySQLiteDB(t), Tables: []string{"tags"}}, ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if s.expectedError == "" {
+				// create a conflicting collection
+				if err := app.Save(core.NewBaseCollection("tags")); err != nil {
+					t.Fatal(err)
+				}
+				s.expectedError = "already exists"
+			}
+
+			_, err := p.migrateFromSQLHandler(s.config, false)
+			if err == nil || !strings.Contains(err.Error(), s.expectedError) {
+				t.Fatalf("Expected error containing %q, got %v", s.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestMigrateFromSQL(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	p := &plugin{app: app}
+
+	collections, err := p.migrateFromSQLHandler(FromSQLConfig{
+		Driver: "sqlite",
+		DSN:    createLegacySQLiteDB(t),
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make([]string, len(collections))
+	for i, c := range collections {
+		names[i] = c.Name
+	}
+	if !slices.Equal(names, []string{"authors", "blog_posts", "tags"}) {
+		t.Fatalf("Unexpected collections %v", names)
+	}
+
+	// schema
+	// ---
+	authors, err := app.FindCollectionByNameOrId("authors")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFields := map[string]string{
+		"id":        core.FieldTypeText,
+		"legacy_id": core.FieldTypeNumber,
+		"name":      core.FieldTypeText,
+		"active":    core.FieldTypeBool,
+	}
+	if len(authors.Fields) != len(expectedFields) {
+		t.Fatalf("Expected %d authors fields, got %d", len(expectedFields), len(authors.Fields))
+	}
+	for name, typ := range expectedFields {
+		f := authors.Fields.GetByName(name)
+		if f == nil || f.Type() != typ {
+			t.Fatalf("Expected authors field %q of type %q, got %v", name, typ, f)
+		}
+	}
+
+	posts, err := app.FindCollectionByNameOrId("blog_posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rel, ok := posts.Fields.GetByName("author_id").(*core.RelationField)
+	if !ok || rel.CollectionId != authors.Id || rel.MaxSelect != 1 {
+		t.Fatalf("Expected author_id relation field to authors, got %#v", posts.Fields.GetByName("author_id"))
+	}
+
+	for name, typ := range map[string]string{"rating": core.FieldTypeNumber, "published_at": core.FieldTypeDate, "meta": core.FieldTypeJSON} {
+		if f := posts.Fields.GetByName(name); f == nil || f.Type() != typ {
+			t.Fatalf("Expected posts field %q of type %q, got %v", name, typ, f)
+		}
+	}
+
+	// data
+	// ---
+	jane, err := app.FindFirstRecordByData("authors", "legacy_id", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jane.GetString("name") != "Jane" || jane.GetBool("active") {
+		t.Fatalf("Unexpected jane record %v", jane)
+	}
+
+	first, err := app.FindFirstRecordByData("blog_posts", "legacy_id", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.GetString("author_id") != jane.Id {
+		t.Fatalf("Expected author_id %q, got %q", jane.Id, first.GetString("author_id"))
+	}
+	if first.GetFloat("rating") != 4.5 || first.GetDateTime("published_at").String() != "2024-01-02 03:04:05.000Z" {
+		t.Fatalf("Unexpected first post data %v", first)
+	}
+	if raw := first.GetString("meta"); raw != `{"a":1}` {
+		t.Fatalf("Expected meta {\"a\":1}, got %q", raw)
+	}
+
+	tags, err := app.FindAllRecords("tags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("Expected 2 tags, got %d", len(tags))
+	}
+	for _, tag := range tags {
+		if tag.GetString("post_id") != first.Id {
+			t.Fatalf("Expected tag post_id %q, got %q", first.Id, tag.GetString("post_id"))
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/pocketbase/pocketbase/cmd"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/osutils"
@@ -43,6 +44,15 @@ type Config struct {
 	// TemplateLang specifies the template language to use when
 	// generating migrations - js or go (default).
 	TemplateLang string
+
+	// TypeScriptFile specifies an optional TypeScript types file
+	// (see the "codegen ts" command) that is regenerated on each
+	// automigration and after applying or reverting migrations.
+	TypeScriptFile string
+
+	// TypeScriptSDK specifies whether to generate also the typed
+	// JS SDK wrapper in the TypeScriptFile.
+	TypeScriptSDK bool
 }
 
 // MustRegister registers the migratecmd plugin to the provided app instance
@@ -99,14 +109,22 @@ func (p *plugin) createCommand() *cobra.Command {
 - down [number] - reverts the last [number] applied migrations
 - create name   - creates new blank migration template file
 - collections   - creates new migration file with snapshot of the local collections configuration
+- diff          - creates new migration files with the differences between the --from schema
+                  (exported collections file or PocketBase url + --token) and the local collections
+- from-sql      - creates collections from the tables of the --driver and --dsn database and imports their data
+                  (use --tables to limit the imported tables and --dry-run to only print the proposed mapping)
 - history-sync  - ensures that the _migrations history table doesn't have references to deleted migration files
 `
 
+	var diffFrom string
+	var diffToken string
+	var fromSQL FromSQLConfig
+
 	command := &cobra.Command{
 		Use:          "migrate",
 		Short:        "Executes app DB migration scripts",
 		Long:         cmdDesc,
-		ValidArgs:    []string{"up", "down", "create", "collections"},
+		ValidArgs:    []string{"up", "down", "create", "collections", "diff", "from-sql"},
 		SilenceUsage: true,
 		RunE: func(command *cobra.Command, args []string) error {
 			cmd := ""
@@ -123,6 +141,14 @@ func (p *plugin) createCommand() *cobra.Command {
 				if _, err := p.migrateCollectionsHandler(args[1:], true); err != nil {
 					return err
 				}
+			case "diff":
+				if _, err := p.migrateDiffHandler(diffFrom, diffToken, true); err != nil {
+					return err
+				}
+			case "from-sql":
+				if _, err := p.migrateFromSQLHandler(fromSQL, true); err != nil {
+					return err
+				}
 			default:
 				// note: system migrations are always applied as part of the bootstrap process
 				var list = core.MigrationsList{}
@@ -134,15 +160,39 @@ func (p *plugin) createCommand() *cobra.Command {
 				if err := runner.Run(args...); err != nil {
 					return err
 				}
+
+				if err := p.syncTypeScript(); err != nil {
+					return err
+				}
 			}
 
 			return nil
 		},
 	}
 
+	command.Flags().StringVar(&diffFrom, "from", "", "the diff schema source - exported collections JSON file or PocketBase url (used only with diff)")
+	command.Flags().StringVar(&diffToken, "token", "", "the superuser auth token of the diff --from PocketBase url (used only with diff)")
+	command.Flags().StringVar(&fromSQL.Driver, "driver", "", "the source database driver - sqlite, mysql or postgres (used only with from-sql)")
+	command.Flags().StringVar(&fromSQL.DSN, "dsn", "", "the source database connection string (used only with from-sql)")
+	command.Flags().StringSliceVar(&fromSQL.Tables, "tables", nil, "comma separated source tables to import (used only with from-sql)")
+	command.Flags().BoolVar(&fromSQL.DryRun, "dry-run", false, "only print the proposed collections mapping (used only with from-sql)")
+
 	return command
 }
 
+// syncTypeScript regenerates the configured TypeScript types file (if any).
+func (p *plugin) syncTypeScript() error {
+	if p.config.TypeScriptFile == "" {
+		return nil
+	}
+
+	if err := cmd.WriteTypeScript(p.app, p.config.TypeScriptFile, p.config.TypeScriptSDK); err != nil {
+		return fmt.Errorf("failed to regenerate the TypeScript types: %w", err)
+	}
+
+	return nil
+}
+
 func (p *plugin) migrateCreateHandler(template string, args []string, interactive bool) (string, error) {
 	if len(args) < 1 {
 		return "", errors.New("missing migration file name")
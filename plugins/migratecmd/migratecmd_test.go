@@ -152,7 +152,10 @@ migrate((app) => {
         "subject": "OTP for {APP_NAME}"
       },
       "enabled": false,
-      "length": 8
+      "length": 8,
+      "maxAttempts": 5,
+      "phoneField": "",
+      "smsTemplate": ""
     },
     "passwordAuth": {
       "enabled": true,
@@ -328,7 +331,10 @@ func init() {
 					"subject": "OTP for {APP_NAME}"
 				},
 				"enabled": false,
-				"length": 8
+				"length": 8,
+				"maxAttempts": 5,
+				"phoneField": "",
+				"smsTemplate": ""
 			},
 			"passwordAuth": {
 				"enabled": true,
@@ -593,7 +599,10 @@ migrate((app) => {
         "subject": "OTP for {APP_NAME}"
       },
       "enabled": false,
-      "length": 8
+      "length": 8,
+      "maxAttempts": 5,
+      "phoneField": "",
+      "smsTemplate": ""
     },
     "passwordAuth": {
       "enabled": true,
@@ -772,7 +781,10 @@ func init() {
 					"subject": "OTP for {APP_NAME}"
 				},
 				"enabled": false,
-				"length": 8
+				"length": 8,
+				"maxAttempts": 5,
+				"phoneField": "",
+				"smsTemplate": ""
 			},
 			"passwordAuth": {
 				"enabled": true,
@@ -1335,3 +1347,53 @@ func TestAutomigrateCollectionNoChanges(t *testing.T) {
 		})
 	}
 }
+
+func TestAutomigrateTypeScriptSync(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	migrationsDir := filepath.Join(app.DataDir(), "_test_migrations")
+	typesFile := filepath.Join(app.DataDir(), "_test_types", "pb_types.ts")
+
+	migratecmd.MustRegister(app, nil, migratecmd.Config{
+		TemplateLang:   migratecmd.TemplateLangJS,
+		Automigrate:    true,
+		Dir:            migrationsDir,
+		TypeScriptFile: typesFile,
+		TypeScriptSDK:  true,
+	})
+
+	app.Bootstrap()
+
+	collection := core.NewBaseCollection("ts_sync")
+	collection.Fields.Add(&core.TextField{Name: "title"})
+
+	event := new(core.CollectionRequestEvent)
+	event.RequestEvent = &core.RequestEvent{}
+	event.App = app
+	event.Collection = collection
+	err := app.OnCollectionCreateRequest().Trigger(event, func(e *core.CollectionRequestEvent) error {
+		return e.App.Save(e.Collection)
+	})
+	if err != nil {
+		t.Fatalf("Failed to save the created dummy collection, got: %v", err)
+	}
+
+	content, err := os.ReadFile(typesFile)
+	if err != nil {
+		t.Fatalf("Expected the TypeScript types file to be generated, got %v", err)
+	}
+
+	expected := []string{
+		"export interface TsSyncRecord extends BaseRecord {",
+		"\ttitle: string\n",
+		`collection(idOrName: "ts_sync"): RecordService<TsSyncResponse>`,
+	}
+	for _, str := range expected {
+		if !strings.Contains(string(content), str) {
+			t.Fatalf("Missing %q in\n%s", str, content)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Package payments implements a Stripe compatible payments integration
+// that mirrors the customers subscriptions state onto the app auth records.
+//
+// The plugin registers:
+//   - a signed "POST /api/payments/webhook" receiver route
+//   - a public "GET /api/payments/config" route with the publishable key
+//   - a migration that creates the processed webhook events table and
+//     the subscription state fields of the configured auth collection
+//   - a "payments" plugin settings section with the provider keys
+//
+// The subscription state is stored in the following auth record fields
+// (missing fields are skipped and they could be changed only by superusers):
+//   - [FieldCustomer]
+//   - [FieldSubscription]
+//   - [FieldSubscriptionStatus]
+//   - [FieldSubscriptionPlan]
+//   - [FieldSubscriptionPeriodEnd]
+//
+// The auth record is resolved from the "client_reference_id" of the completed
+// checkout session, the "recordId" subscription metadata key or the stored customer id.
+//
+// Example:
+//
+//	p := payments.MustRegister(app, payments.Config{Collection: "users"})
+//
+//	p.OnSubscriptionChange().BindFunc(func(e *payments.SubscriptionEvent) error {
+//		if e.OldStatus != e.Subscription.Status {
+//			log.Println(e.Record.Id, e.OldStatus, "->", e.Subscription.Status)
+//		}
+//		return e.Next()
+//	})
+package payments
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// PluginName is the name of the payments plugin (and its settings section).
+const PluginName = "payments"
+
+// EventsTableName is the name of the processed webhook events table.
+const EventsTableName = "_paymentEvents"
+
+// The auth record fields that mirror the subscription state.
+const (
+	FieldCustomer              = "paymentCustomer"
+	FieldSubscription          = "subscriptionId"
+	FieldSubscriptionStatus    = "subscriptionStatus"
+	FieldSubscriptionPlan      = "subscriptionPlan"
+	FieldSubscriptionPeriodEnd = "subscriptionPeriodEnd"
+)
+
+var mirroredFields = []string{
+	FieldCustomer,
+	FieldSubscription,
+	FieldSubscriptionStatus,
+	FieldSubscriptionPlan,
+	FieldSubscriptionPeriodEnd,
+}
+
+// Config defines the config options of the payments plugin.
+type Config struct {
+	// Collection is the name or id of the auth collection
+	// whose records mirror the subscriptions state.
+	//
+	// If not set it fallbacks to "users".
+	Collection string
+
+	// Tolerance specifies the max allowed age of a signed webhook request.
+	//
+	// If not set it fallbacks to 5 minutes.
+	Tolerance time.Duration
+
+	// MaxBodySize specifies the max allowed webhook request body size in bytes.
+	//
+	// If not set it fallbacks to 1MB.
+	MaxBodySize int64
+}
+
+// Settings defines the payments plugin settings section.
+type Settings struct {
+	// SecretKey is the provider API secret key (e.g. "sk_live_...").
+	SecretKey string `json:"secretKey"`
+
+	// PublishableKey is the provider public key (e.g. "pk_live_...")
+	// that is exposed with the "GET /api/payments/config" route.
+	PublishableKey string `json:"publishableKey"`
+
+	// WebhookSecret is the webhook endpoint signing secret (e.g. "whsec_...").
+	WebhookSecret string `json:"webhookSecret"`
+}
+
+// Validate makes Settings validatable by implementing [validation.Validatable] interface.
+func (s Settings) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.SecretKey, validation.By(checkKeyPrefix("sk_", "rk_"))),
+		validation.Field(&s.PublishableKey, validation.By(checkKeyPrefix("pk_"))),
+		validation.Field(&s.WebhookSecret, validation.By(checkKeyPrefix("whsec_"))),
+	)
+}
+
+// MustRegister registers the payments plugin to the provided app instance
+// and panic if it fails.
+func MustRegister(app core.App, config Config) *Plugin {
+	p, err := Register(app, config)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// Register registers the payments plugin to the provided app instance.
+//
+// It is expected to be called before the app bootstrap.
+func Register(app core.App, config Config) (*Plugin, error) {
+	if config.Collection == "" {
+		config.Collection = "users"
+	}
+
+	if config.Tolerance <= 0 {
+		config.Tolerance = 5 * time.Minute
+	}
+
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = 1 << 20
+	}
+
+	p := &Plugin{
+		config:               config,
+		onWebhookEvent:       &hook.Hook[*WebhookEvent]{},
+		onSubscriptionChange: &hook.Hook[*SubscriptionEvent]{},
+	}
+
+	if err := app.RegisterPlugin(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+var (
+	_ core.Plugin               = (*Plugin)(nil)
+	_ core.PluginWithMigrations = (*Plugin)(nil)
+	_ core.PluginWithRoutes     = (*Plugin)(nil)
+	_ core.PluginWithSettings   = (*Plugin)(nil)
+)
+
+// Plugin is the registered payments plugin instance.
+type Plugin struct {
+	onWebhookEvent       *hook.Hook[*WebhookEvent]
+	onSubscriptionChange *hook.Hook[*SubscriptionEvent]
+	config               Config
+}
+
+// OnWebhookEvent hook is triggered for every new verified webhook event.
+//
+// Call e.Next() to continue with the default subscription state processing.
+func (p *Plugin) OnWebhookEvent() *hook.Hook[*WebhookEvent] {
+	return p.onWebhookEvent
+}
+
+// OnSubscriptionChange hook is triggered before mirroring a subscription
+// state change onto its auth record.
+//
+// Call e.Next() to continue with the auth record persistence.
+func (p *Plugin) OnSubscriptionChange() *hook.Hook[*SubscriptionEvent] {
+	return p.onSubscriptionChange
+}
+
+// Settings returns the current payments plugin settings.
+func (p *Plugin) Settings(app core.App) Settings {
+	settings := Settings{}
+
+	if err := app.Settings().Extension(PluginName, &settings); err != nil {
+		app.Logger().Warn("Failed to load the payments settings", "error", err)
+	}
+
+	return settings
+}
+
+// Name implements [core.Plugin.Name].
+func (p *Plugin) Name() string {
+	return PluginName
+}
+
+// Init implements [core.Plugin.Init].
+func (p *Plugin) Init(app core.App) error {
+	app.OnRecordCreateRequest(p.config.Collection).BindFunc(protectMirroredFields)
+	app.OnRecordUpdateRequest(p.config.Collection).BindFunc(protectMirroredFields)
+
+	return nil
+}
+
+// Migrations implements [core.PluginWithMigrations.Migrations].
+func (p *Plugin) Migrations() []*core.Migration {
+	return []*core.Migration{
+		{
+			File: "1766100000_init.go",
+			Up: func(txApp core.App) error {
+				_, err := txApp.DB().NewQuery(`
+					CREATE TABLE {{` + EventsTableName + `}} (
+						[[id]]      TEXT PRIMARY KEY NOT NULL,
+						[[type]]    TEXT DEFAULT "" NOT NULL,
+						[[created]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+					);
+				`).Execute()
+				if err != nil {
+					return err
+				}
+
+				collection, err := txApp.FindCollectionByNameOrId(p.config.Collection)
+				if err != nil || !collection.IsAuth() {
+					return nil // nothing to mirror
+				}
+
+				collection.Fields.Add(
+					&core.TextField{Name: FieldCustomer, Hidden: true},
+					&core.TextField{Name: FieldSubscription, Hidden: true},
+					&core.TextField{Name: FieldSubscriptionStatus},
+					&core.TextField{Name: FieldSubscriptionPlan},
+					&core.DateField{Name: FieldSubscriptionPeriodEnd},
+				)
+
+				return txApp.Save(collection)
+			},
+			Down: func(txApp core.App) error {
+				collection, err := txApp.FindCollectionByNameOrId(p.config.Collection)
+				if err == nil {
+					for _, name := range mirroredFields {
+						collection.Fields.RemoveByName(name)
+					}
+
+					if err := txApp.Save(collection); err != nil {
+						return err
+					}
+				}
+
+				_, err = txApp.DB().DropTable(EventsTableName).Execute()
+
+				return err
+			},
+		},
+	}
+}
+
+// BindRoutes implements [core.PluginWithRoutes.BindRoutes].
+func (p *Plugin) BindRoutes(rg *router.RouterGroup[*core.RequestEvent]) {
+	sub := rg.Group("/api/payments")
+	sub.GET("/config", p.publicConfig)
+	sub.POST("/webhook", p.webhook)
+}
+
+// SettingsExtension implements [core.PluginWithSettings.SettingsExtension].
+func (p *Plugin) SettingsExtension() core.SettingsExtension {
+	return core.SettingsExtension{
+		Defaults: Settings{},
+		// exclude the provider secrets from the settings API responses
+		Sensitive: []string{"secretKey", "webhookSecret"},
+	}
+}
+
+// -------------------------------------------------------------------
+
+func checkKeyPrefix(prefixes ...string) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+		if v == "" {
+			return nil
+		}
+
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(v, prefix) {
+				return nil
+			}
+		}
+
+		return validation.NewError(
+			"validation_invalid_key_prefix",
+			fmt.Sprintf("The key must start with %s.", strings.Join(prefixes, " or ")),
+		)
+	}
+}
+
+// protectMirroredFields rejects the subscription state fields changes
+// of non-superuser record create and update requests.
+func protectMirroredFields(e *core.RecordRequestEvent) error {
+	if e.HasSuperuserAuth() {
+		return e.Next()
+	}
+
+	original := e.Record.Original()
+
+	errs := validation.Errors{}
+
+	for _, name := range mirroredFields {
+		if e.Collection.Fields.GetByName(name) == nil {
+			continue
+		}
+
+		if e.Record.GetString(name) != original.GetString(name) {
+			errs[name] = validation.NewError("validation_payments_field", "The field could be changed only by superusers.")
+		}
+	}
+
+	if len(errs) > 0 {
+		return e.BadRequestError("Failed to save record.", errs)
+	}
+
+	return e.Next()
+}
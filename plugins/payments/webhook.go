@@ -0,0 +1,357 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// SignatureHeader is the name of the webhook request signature header.
+const SignatureHeader = "Stripe-Signature"
+
+// WebhookEvent defines a single verified provider webhook event.
+type WebhookEvent struct {
+	hook.Event
+
+	App core.App
+
+	// Id is the unique provider event id (e.g. "evt_...").
+	Id string
+
+	// Type is the provider event type (e.g. "customer.subscription.updated").
+	Type string
+
+	// Object is the raw event "data.object" payload.
+	Object json.RawMessage
+}
+
+// SubscriptionEvent defines a single subscription state change.
+type SubscriptionEvent struct {
+	hook.Event
+
+	App          core.App
+	Record       *core.Record
+	Subscription *Subscription
+
+	// OldStatus is the auth record subscription status before the change.
+	OldStatus string
+}
+
+// Subscription defines the normalized state of a provider subscription.
+type Subscription struct {
+	Metadata         map[string]string `json:"metadata"`
+	Id               string            `json:"id"`
+	Customer         string            `json:"customer"`
+	Status           string            `json:"status"`
+	Plan             string            `json:"plan"`
+	CurrentPeriodEnd types.DateTime    `json:"currentPeriodEnd"`
+}
+
+// IsActive reports whether the subscription is in an active or trialing status.
+func (s *Subscription) IsActive() bool {
+	return s.Status == "active" || s.Status == "trialing"
+}
+
+// SignPayload returns the signature header value of the provided
+// webhook payload (it could be used for testing webhook handlers).
+func SignPayload(payload []byte, secret string, t time.Time) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+
+	return "t=" + timestamp + ",v1=" + computeSignature(payload, secret, timestamp)
+}
+
+// VerifySignature checks whether the signature header value is valid
+// for the provided webhook payload and it is not older than tolerance.
+func VerifySignature(payload []byte, header string, secret string, tolerance time.Duration) error {
+	var timestamp string
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		return errors.New("missing signature timestamp or value")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signature timestamp: %w", err)
+	}
+
+	if age := time.Since(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return errors.New("the signature timestamp is outside of the allowed tolerance")
+	}
+
+	expected := computeSignature(payload, secret, timestamp)
+
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return errors.New("no matching signature")
+}
+
+func computeSignature(payload []byte, secret string, timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// -------------------------------------------------------------------
+
+func (p *Plugin) publicConfig(e *core.RequestEvent) error {
+	return e.JSON(http.StatusOK, map[string]any{
+		"publishableKey": p.Settings(e.App).PublishableKey,
+	})
+}
+
+func (p *Plugin) webhook(e *core.RequestEvent) error {
+	secret := p.Settings(e.App).WebhookSecret
+	if secret == "" {
+		return e.BadRequestError("The payments webhook secret is not configured.", nil)
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(e.Request.Body, p.config.MaxBodySize+1))
+	if err != nil {
+		return e.BadRequestError("Failed to read the webhook payload.", err)
+	}
+	if int64(len(payload)) > p.config.MaxBodySize {
+		return e.BadRequestError("The webhook payload is too large.", nil)
+	}
+
+	err = VerifySignature(payload, e.Request.Header.Get(SignatureHeader), secret, p.config.Tolerance)
+	if err != nil {
+		return e.BadRequestError("Invalid webhook signature.", err)
+	}
+
+	raw := struct {
+		Id   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			Object json.RawMessage `json:"object"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(payload, &raw); err != nil || raw.Id == "" {
+		return e.BadRequestError("Invalid webhook payload.", err)
+	}
+
+	err = e.App.RunInTransaction(func(txApp core.App) error {
+		var exists bool
+		err := txApp.DB().Select("(1)").
+			From(EventsTableName).
+			Where(dbx.HashExp{"id": raw.Id}).
+			Limit(1).
+			Row(&exists)
+		if err == nil && exists {
+			return nil // already processed
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		event := &WebhookEvent{
+			App:    txApp,
+			Id:     raw.Id,
+			Type:   raw.Type,
+			Object: raw.Data.Object,
+		}
+
+		err = p.onWebhookEvent.Trigger(event, func(e *WebhookEvent) error {
+			return p.processEvent(e)
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = txApp.DB().Insert(EventsTableName, dbx.Params{
+			"id":      raw.Id,
+			"type":    raw.Type,
+			"created": types.NowDateTime().String(),
+		}).Execute()
+
+		return err
+	})
+	if err != nil {
+		return e.InternalServerError("Failed to process the webhook event.", err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// processEvent mirrors the subscription state of the supported
+// webhook event types onto the related auth record.
+func (p *Plugin) processEvent(e *WebhookEvent) error {
+	switch {
+	case e.Type == "checkout.session.completed":
+		session := struct {
+			ClientReferenceId string          `json:"client_reference_id"`
+			Customer          json.RawMessage `json:"customer"`
+			Subscription      json.RawMessage `json:"subscription"`
+		}{}
+		if err := json.Unmarshal(e.Object, &session); err != nil {
+			return err
+		}
+
+		record := p.findRecord(e.App, session.ClientReferenceId, "")
+		if record == nil {
+			return nil // not related to an auth record
+		}
+
+		setIfExists(record, FieldCustomer, objectId(session.Customer))
+		if subscription := objectId(session.Subscription); subscription != "" {
+			setIfExists(record, FieldSubscription, subscription)
+		}
+
+		return e.App.Save(record)
+	case strings.HasPrefix(e.Type, "customer.subscription."):
+		subscription, err := parseSubscription(e.Object)
+		if err != nil {
+			return err
+		}
+
+		record := p.findRecord(e.App, subscription.Metadata["recordId"], subscription.Customer)
+		if record == nil {
+			return nil // not related to an auth record
+		}
+
+		event := &SubscriptionEvent{
+			App:          e.App,
+			Record:       record,
+			Subscription: subscription,
+			OldStatus:    record.GetString(FieldSubscriptionStatus),
+		}
+
+		return p.onSubscriptionChange.Trigger(event, func(e *SubscriptionEvent) error {
+			setIfExists(e.Record, FieldCustomer, e.Subscription.Customer)
+			setIfExists(e.Record, FieldSubscription, e.Subscription.Id)
+			setIfExists(e.Record, FieldSubscriptionStatus, e.Subscription.Status)
+			setIfExists(e.Record, FieldSubscriptionPlan, e.Subscription.Plan)
+			setIfExists(e.Record, FieldSubscriptionPeriodEnd, e.Subscription.CurrentPeriodEnd)
+
+			return e.App.Save(e.Record)
+		})
+	}
+
+	return nil
+}
+
+// findRecord returns the auth record with the specified id or
+// the one with the specified customer (or nil if there is no such record).
+func (p *Plugin) findRecord(app core.App, recordId string, customer string) *core.Record {
+	if recordId != "" {
+		record, err := app.FindRecordById(p.config.Collection, recordId)
+		if err == nil {
+			return record
+		}
+	}
+
+	if customer != "" {
+		record, err := app.FindFirstRecordByData(p.config.Collection, FieldCustomer, customer)
+		if err == nil {
+			return record
+		}
+	}
+
+	return nil
+}
+
+// parseSubscription parses a raw provider subscription object.
+func parseSubscription(data []byte) (*Subscription, error) {
+	raw := struct {
+		Metadata         map[string]string `json:"metadata"`
+		Id               string            `json:"id"`
+		Status           string            `json:"status"`
+		Customer         json.RawMessage   `json:"customer"`
+		CurrentPeriodEnd int64             `json:"current_period_end"`
+		Items            struct {
+			Data []struct {
+				Price struct {
+					Id        string `json:"id"`
+					LookupKey string `json:"lookup_key"`
+				} `json:"price"`
+				CurrentPeriodEnd int64 `json:"current_period_end"`
+			} `json:"data"`
+		} `json:"items"`
+	}{}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{
+		Id:       raw.Id,
+		Customer: objectId(raw.Customer),
+		Status:   raw.Status,
+		Metadata: raw.Metadata,
+	}
+
+	periodEnd := raw.CurrentPeriodEnd
+
+	if len(raw.Items.Data) > 0 {
+		item := raw.Items.Data[0]
+
+		subscription.Plan = item.Price.LookupKey
+		if subscription.Plan == "" {
+			subscription.Plan = item.Price.Id
+		}
+
+		// newer API versions store the period per subscription item
+		if periodEnd == 0 {
+			periodEnd = item.CurrentPeriodEnd
+		}
+	}
+
+	if periodEnd > 0 {
+		subscription.CurrentPeriodEnd, _ = types.ParseDateTime(time.Unix(periodEnd, 0))
+	}
+
+	return subscription, nil
+}
+
+// objectId returns the id of a raw provider object reference
+// that could be either a plain id string or an expanded object.
+func objectId(raw json.RawMessage) string {
+	var id string
+	if err := json.Unmarshal(raw, &id); err == nil {
+		return id
+	}
+
+	obj := struct {
+		Id string `json:"id"`
+	}{}
+	json.Unmarshal(raw, &obj)
+
+	return obj.Id
+}
+
+func setIfExists(record *core.Record, field string, value any) {
+	if record.Collection().Fields.GetByName(field) != nil {
+		record.Set(field, value)
+	}
+}
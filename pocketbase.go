@@ -37,6 +37,7 @@ type PocketBase struct {
 	dataDirFlag        string
 	encryptionEnvFlag  string
 	queryTimeout       int
+	cronLockFlag       bool
 	hideStartBanner    bool
 	staticRouteEnabled bool
 
@@ -64,6 +65,11 @@ type Config struct {
 	AuxMaxOpenConns  int                // default to core.DefaultAuxMaxOpenConns
 	AuxMaxIdleConns  int                // default to core.DefaultAuxMaxIdleConns
 	DBConnect        core.DBConnectFunc // default to core.dbConnect
+
+	// optional advisory lock factory for running the scheduled cron jobs
+	// on a single instance (if not set and the --cronLock flag is used,
+	// it will fallback to core.NewDBCronLocker)
+	CronLocker func(app core.App) core.CronLocker
 }
 
 // New creates a new PocketBase instance with the default configuration.
@@ -130,6 +136,10 @@ func NewWithConfig(config Config) *PocketBase {
 	// (errors are ignored, since the full flags parsing happens on Execute())
 	pb.eagerParseFlags(&config)
 
+	if config.CronLocker == nil && pb.cronLockFlag {
+		config.CronLocker = core.NewDBCronLocker
+	}
+
 	// initialize the app instance
 	pb.App = core.NewBaseApp(core.BaseAppConfig{
 		IsDev:            pb.devFlag,
@@ -141,6 +151,7 @@ func NewWithConfig(config Config) *PocketBase {
 		AuxMaxOpenConns:  config.AuxMaxOpenConns,
 		AuxMaxIdleConns:  config.AuxMaxIdleConns,
 		DBConnect:        config.DBConnect,
+		CronLocker:       config.CronLocker,
 	})
 
 	// hide the default help command (allow only `--help` flag)
@@ -168,11 +179,24 @@ func NewWithConfig(config Config) *PocketBase {
 }
 
 // Start starts the application, aka. registers the default system
-// commands (serve, superuser, version) and executes pb.RootCmd.
+// commands (serve, superuser, backup, restore, version) and executes pb.RootCmd.
 func (pb *PocketBase) Start() error {
 	// register system commands
 	pb.RootCmd.AddCommand(cmd.NewSuperuserCommand(pb))
 	pb.RootCmd.AddCommand(cmd.NewServeCommand(pb, !pb.hideStartBanner, pb.staticRouteEnabled))
+	pb.RootCmd.AddCommand(cmd.NewRestoreCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewBackupCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewDBCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewSettingsCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewDoctorCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewValidateCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewMaintenanceCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewProfileCommand())
+	pb.RootCmd.AddCommand(cmd.NewBenchCommand())
+	pb.RootCmd.AddCommand(cmd.NewOpenAPICommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewSeedCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewGenerateCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewCodegenCommand(pb))
 	// add by yyy
 	pb.RootCmd.AddCommand(cmd.NewImportCommand(pb))
 	pb.RootCmd.AddCommand(cmd.NewExportCommand(pb))
@@ -254,6 +278,13 @@ func (pb *PocketBase) eagerParseFlags(config *Config) error {
 		"the default SELECT queries timeout in seconds",
 	)
 
+	pb.RootCmd.PersistentFlags().BoolVar(
+		&pb.cronLockFlag,
+		"cronLock",
+		false,
+		"use the auxiliary db as advisory lock to ensure that the scheduled cron jobs \nrun only once when several instances share the same pb_data",
+	)
+
 	return pb.RootCmd.ParseFlags(os.Args[1:])
 }
 
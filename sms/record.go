@@ -0,0 +1,132 @@
+package sms
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/notifier"
+	"github.com/spf13/cast"
+)
+
+// DefaultOTPTemplate is the default OTP text message template used
+// when the auth collection doesn't have a custom OTP.SMSTemplate.
+const DefaultOTPTemplate = "Your " + core.EmailPlaceholderAppName + " one-time password is: " + core.EmailPlaceholderOTP
+
+// SendRecordOTP sends OTP text message to the phone number of the specified auth record.
+//
+// The phone number is read from the auth collection OTP.PhoneField.
+//
+// This method will also update the "sentTo" field of the related OTP record to the message To phone number (if the OTP exists and not already assigned).
+func SendRecordOTP(app core.App, authRecord *core.Record, otpId string, pass string) error {
+	collection := authRecord.Collection()
+
+	if collection.OTP.PhoneField == "" {
+		return errors.New("the OTP phone field of the auth collection is not set")
+	}
+
+	phone := authRecord.GetString(collection.OTP.PhoneField)
+	if phone == "" {
+		return errors.New("the auth record doesn't have a phone number")
+	}
+
+	template := collection.OTP.SMSTemplate
+	if template == "" {
+		template = DefaultOTPTemplate
+	}
+
+	body := resolveTemplate(app, authRecord, template, map[string]any{
+		core.EmailPlaceholderOTPId: otpId,
+		core.EmailPlaceholderOTP:   pass,
+	})
+
+	message := &notifier.Message{
+		From: app.Settings().SMS.From,
+		To:   phone,
+		Body: body,
+	}
+
+	event := new(core.SMSRecordEvent)
+	event.App = app
+	event.Notifier = app.NewSMSClient()
+	event.Message = message
+	event.Record = authRecord
+	event.Meta = map[string]any{
+		"otpId":    otpId,
+		"password": pass,
+	}
+
+	return app.OnSMSRecordOTPSend().Trigger(event, func(e *core.SMSRecordEvent) error {
+		err := e.Notifier.Send(e.Message)
+		if err != nil {
+			return err
+		}
+
+		if e.Message.To == "" {
+			return nil
+		}
+
+		otp, err := e.App.FindOTPById(otpId)
+		if err != nil {
+			e.App.Logger().Warn(
+				"Unable to find OTP to update its sentTo field (either it was already deleted or the id is nonexisting)",
+				"error", err,
+				"otpId", otpId,
+			)
+			return nil
+		}
+
+		if otp.SentTo() != "" {
+			return nil // was already sent to another target
+		}
+
+		otp.SetSentTo(e.Message.To)
+		if err = e.App.Save(otp); err != nil {
+			e.App.Logger().Error(
+				"Failed to update OTP sentTo field",
+				"error", err,
+				"otpId", otpId,
+				"to", e.Message.To,
+			)
+		}
+
+		return nil
+	})
+}
+
+func resolveTemplate(
+	app core.App,
+	authRecord *core.Record,
+	template string,
+	placeholders map[string]any,
+) string {
+	if placeholders == nil {
+		placeholders = map[string]any{}
+	}
+
+	// register default system placeholders
+	if _, ok := placeholders[core.EmailPlaceholderAppName]; !ok {
+		placeholders[core.EmailPlaceholderAppName] = app.Settings().Meta.AppName
+	}
+	if _, ok := placeholders[core.EmailPlaceholderAppURL]; !ok {
+		placeholders[core.EmailPlaceholderAppURL] = app.Settings().Meta.AppURL
+	}
+
+	// register default auth record placeholders
+	for _, field := range authRecord.Collection().Fields {
+		if field.GetHidden() {
+			continue
+		}
+
+		fieldPlacehodler := "{RECORD:" + field.GetName() + "}"
+		if _, ok := placeholders[fieldPlacehodler]; !ok {
+			placeholders[fieldPlacehodler] = authRecord.GetString(field.GetName())
+		}
+	}
+
+	for k, v := range placeholders {
+		template = strings.ReplaceAll(template, k, cast.ToString(v))
+	}
+
+	return template
+}
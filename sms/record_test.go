@@ -0,0 +1,98 @@
+package sms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/sms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSendRecordOTP(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	user, err := testApp.FindFirstRecordByData("users", "email", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// missing phone field
+	err = sms.SendRecordOTP(testApp, user, "test_otp_id", "test_otp_code")
+	if err == nil {
+		t.Fatal("Expected error due to missing OTP phone field")
+	}
+
+	collection, err := testApp.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Fields.Add(&core.TextField{Name: "phone"})
+	collection.AddIndex("idx_users_phone", true, "phone", "phone != ''")
+	collection.OTP.PhoneField = "phone"
+	if err = testApp.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err = testApp.FindRecordById("users", user.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// missing phone number
+	err = sms.SendRecordOTP(testApp, user, "test_otp_id", "test_otp_code")
+	if err == nil {
+		t.Fatal("Expected error due to missing phone number")
+	}
+
+	user.Set("phone", "+359888123456")
+	if err = testApp.Save(user); err != nil {
+		t.Fatal(err)
+	}
+
+	otp := core.NewOTP(testApp)
+	otp.SetCollectionRef(user.Collection().Id)
+	otp.SetRecordRef(user.Id)
+	otp.SetPassword("test_otp_code")
+	if err = testApp.Save(otp); err != nil {
+		t.Fatal(err)
+	}
+
+	err = sms.SendRecordOTP(testApp, user, otp.Id, "test_otp_code")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if testApp.TestNotifier.TotalSend() != 1 {
+		t.Fatalf("Expected one message to be sent, got %d", testApp.TestNotifier.TotalSend())
+	}
+
+	message := testApp.TestNotifier.LastMessage()
+
+	if message.To != "+359888123456" {
+		t.Fatalf("Expected To %q, got %q", "+359888123456", message.To)
+	}
+
+	expectedParts := []string{
+		testApp.Settings().Meta.AppName,
+		"one-time password",
+		"test_otp_code",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(message.Body, part) {
+			t.Fatalf("Couldn't find %s \nin\n %s", part, message.Body)
+		}
+	}
+
+	otp, err = testApp.FindOTPById(otp.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if otp.SentTo() != "+359888123456" {
+		t.Fatalf("Expected OTP sentTo %q, got %q", "+359888123456", otp.SentTo())
+	}
+}
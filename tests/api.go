@@ -6,9 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"maps"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -86,6 +86,21 @@ type ApiScenario struct {
 	//	map[string]int{ "EventA": 2, "EventB": 0 } // ensures that EventA was fired exactly 2 times and EventB exactly 0 times.
 	ExpectedEvents map[string]int
 
+	// ExpectedEmails is a list of the expected sent emails recipients
+	// (the first To address of each message in the send order).
+	//
+	// Leave it nil to skip the check or set it to an empty slice
+	// to ensure that no emails were sent.
+	ExpectedEmails []string
+
+	// ExpectedSnapshot is an optional JSON snapshot file path to compare
+	// the response body with (see [AssertJSONSnapshot]).
+	ExpectedSnapshot string
+
+	// SnapshotMaskFields is a list of response body keys whose values are
+	// masked before the snapshot comparison (e.g. "id", "created", "updated").
+	SnapshotMaskFields []string
+
 	// test hooks
 	// ---------------------------------------------------------------
 
@@ -252,7 +267,11 @@ func (scenario *ApiScenario) test(t testing.TB) {
 			time.Sleep(scenario.Delay)
 		}
 
-		if len(scenario.ExpectedContent) == 0 && len(scenario.NotExpectedContent) == 0 {
+		if scenario.ExpectedSnapshot != "" {
+			AssertJSONSnapshot(t, scenario.ExpectedSnapshot, recorder.Body.Bytes(), scenario.SnapshotMaskFields...)
+		}
+
+		if len(scenario.ExpectedContent) == 0 && len(scenario.NotExpectedContent) == 0 && scenario.ExpectedSnapshot == "" {
 			if len(recorder.Body.Bytes()) != 0 {
 				t.Errorf("Expected empty body, got \n%v", recorder.Body.String())
 			}
@@ -283,25 +302,12 @@ func (scenario *ApiScenario) test(t testing.TB) {
 			}
 		}
 
-		remainingEvents := maps.Clone(testApp.EventCalls)
+		AssertEvents(t, testApp, scenario.ExpectedEvents)
 
-		var noOtherEventsShouldRemain bool
-		for event, expectedNum := range scenario.ExpectedEvents {
-			if event == "*" && expectedNum <= 0 {
-				noOtherEventsShouldRemain = true
-				continue
+		if scenario.ExpectedEmails != nil {
+			if recipients := testApp.TestMailer.Recipients(); !slices.Equal(recipients, scenario.ExpectedEmails) {
+				t.Errorf("Expected sent emails to %v, got %v", scenario.ExpectedEmails, recipients)
 			}
-
-			actualNum := remainingEvents[event]
-			if actualNum != expectedNum {
-				t.Errorf("Expected event %s to be called %d, got %d", event, expectedNum, actualNum)
-			}
-
-			delete(remainingEvents, event)
-		}
-
-		if noOtherEventsShouldRemain && len(remainingEvents) > 0 {
-			t.Errorf("Missing expected remaining events:\n%#v\nAll triggered app events are:\n%#v", remainingEvents, testApp.EventCalls)
 		}
 
 		if scenario.AfterTestFunc != nil {
@@ -26,6 +26,10 @@ type TestApp struct {
 	EventCalls map[string]int
 
 	TestMailer *TestMailer
+
+	TestNotifier *TestNotifier
+
+	TestPushDriver *TestPushDriver
 }
 
 // Cleanup resets the test application state and removes the test
@@ -38,6 +42,8 @@ func (t *TestApp) Cleanup() {
 
 	t.OnTerminate().Trigger(event, func(e *core.TerminateEvent) error {
 		t.TestMailer.Reset()
+		t.TestNotifier.Reset()
+		t.TestPushDriver.Reset()
 		t.ResetEventCalls()
 		t.ResetBootstrapState()
 
@@ -106,6 +112,12 @@ func NewTestAppWithConfig(config core.BaseAppConfig) (*TestApp, error) {
 	// replace with the clone
 	config.DataDir = tempDir
 
+	return initTestApp(config)
+}
+
+// initTestApp initializes a test application instance
+// from the provided config with already isolated config.DataDir.
+func initTestApp(config core.BaseAppConfig) (*TestApp, error) {
 	app := core.NewBaseApp(config)
 
 	// load data dir and db connections
@@ -131,9 +143,11 @@ func NewTestAppWithConfig(config core.BaseAppConfig) (*TestApp, error) {
 	app.Settings().Logs.MaxDays = 0
 
 	t := &TestApp{
-		BaseApp:    app,
-		EventCalls: make(map[string]int),
-		TestMailer: &TestMailer{},
+		BaseApp:        app,
+		EventCalls:     make(map[string]int),
+		TestMailer:     &TestMailer{},
+		TestNotifier:   &TestNotifier{},
+		TestPushDriver: &TestPushDriver{},
 	}
 
 	t.OnBootstrap().Bind(&hook.Handler[*core.BootstrapEvent]{
@@ -177,6 +191,22 @@ func NewTestAppWithConfig(config core.BaseAppConfig) (*TestApp, error) {
 		Priority: -99999,
 	})
 
+	t.OnPanic().Bind(&hook.Handler[*core.PanicEvent]{
+		Func: func(e *core.PanicEvent) error {
+			t.registerEventCall("OnPanic")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
+	t.OnScheduledTaskRun().Bind(&hook.Handler[*core.ScheduledTaskEvent]{
+		Func: func(e *core.ScheduledTaskEvent) error {
+			t.registerEventCall("OnScheduledTaskRun")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
 	t.OnModelCreate().Bind(&hook.Handler[*core.ModelEvent]{
 		Func: func(e *core.ModelEvent) error {
 			t.registerEventCall("OnModelCreate")
@@ -393,6 +423,30 @@ func NewTestAppWithConfig(config core.BaseAppConfig) (*TestApp, error) {
 		Priority: -99999,
 	})
 
+	t.OnRecordModerate().Bind(&hook.Handler[*core.RecordModerateEvent]{
+		Func: func(e *core.RecordModerateEvent) error {
+			t.registerEventCall("OnRecordModerate")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
+	t.OnRecordPublish().Bind(&hook.Handler[*core.RecordPublishEvent]{
+		Func: func(e *core.RecordPublishEvent) error {
+			t.registerEventCall("OnRecordPublish")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
+	t.OnRecordExpire().Bind(&hook.Handler[*core.RecordExpireEvent]{
+		Func: func(e *core.RecordExpireEvent) error {
+			t.registerEventCall("OnRecordExpire")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
 	t.OnCollectionValidate().Bind(&hook.Handler[*core.CollectionEvent]{
 		Func: func(e *core.CollectionEvent) error {
 			t.registerEventCall("OnCollectionValidate")
@@ -509,6 +563,14 @@ func NewTestAppWithConfig(config core.BaseAppConfig) (*TestApp, error) {
 		Priority: -99999,
 	})
 
+	t.OnMailReceive().Bind(&hook.Handler[*core.MailReceiveEvent]{
+		Func: func(e *core.MailReceiveEvent) error {
+			t.registerEventCall("OnMailReceive")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
 	t.OnMailerRecordAuthAlertSend().Bind(&hook.Handler[*core.MailerRecordEvent]{
 		Func: func(e *core.MailerRecordEvent) error {
 			t.registerEventCall("OnMailerRecordAuthAlertSend")
@@ -517,6 +579,14 @@ func NewTestAppWithConfig(config core.BaseAppConfig) (*TestApp, error) {
 		Priority: -99999,
 	})
 
+	t.OnMailerRecordInviteSend().Bind(&hook.Handler[*core.MailerRecordEvent]{
+		Func: func(e *core.MailerRecordEvent) error {
+			t.registerEventCall("OnMailerRecordInviteSend")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
 	t.OnMailerRecordPasswordResetSend().Bind(&hook.Handler[*core.MailerRecordEvent]{
 		Func: func(e *core.MailerRecordEvent) error {
 			t.registerEventCall("OnMailerRecordPasswordResetSend")
@@ -549,6 +619,46 @@ func NewTestAppWithConfig(config core.BaseAppConfig) (*TestApp, error) {
 		Priority: -99999,
 	})
 
+	t.OnSMSSend().Bind(&hook.Handler[*core.SMSEvent]{
+		Func: func(e *core.SMSEvent) error {
+			if t.TestNotifier == nil {
+				t.TestNotifier = &TestNotifier{}
+			}
+			e.Notifier = t.TestNotifier
+			t.registerEventCall("OnSMSSend")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
+	t.OnSMSRecordOTPSend().Bind(&hook.Handler[*core.SMSRecordEvent]{
+		Func: func(e *core.SMSRecordEvent) error {
+			t.registerEventCall("OnSMSRecordOTPSend")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
+	t.OnCaptchaVerify().Bind(&hook.Handler[*core.CaptchaVerifyEvent]{
+		Func: func(e *core.CaptchaVerifyEvent) error {
+			t.registerEventCall("OnCaptchaVerify")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
+	t.OnPushSend().Bind(&hook.Handler[*core.PushSendEvent]{
+		Func: func(e *core.PushSendEvent) error {
+			if t.TestPushDriver == nil {
+				t.TestPushDriver = &TestPushDriver{}
+			}
+			e.Driver = t.TestPushDriver
+			t.registerEventCall("OnPushSend")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
 	t.OnRealtimeConnectRequest().Bind(&hook.Handler[*core.RealtimeConnectRequestEvent]{
 		Func: func(e *core.RealtimeConnectRequestEvent) error {
 			t.registerEventCall("OnRealtimeConnectRequest")
@@ -725,6 +835,14 @@ func NewTestAppWithConfig(config core.BaseAppConfig) (*TestApp, error) {
 		Priority: -99999,
 	})
 
+	t.OnRecordCreateScore().Bind(&hook.Handler[*core.RecordCreateScoreEvent]{
+		Func: func(e *core.RecordCreateScoreEvent) error {
+			t.registerEventCall("OnRecordCreateScore")
+			return e.Next()
+		},
+		Priority: -99999,
+	})
+
 	t.OnRecordCreateRequest().Bind(&hook.Handler[*core.RecordRequestEvent]{
 		Func: func(e *core.RecordRequestEvent) error {
 			t.registerEventCall("OnRecordCreateRequest")
@@ -0,0 +1,276 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// TestAppBuilder defines a fluent builder for test application
+// instances with custom fixtures.
+//
+// Unlike [NewTestApp], by default the builder starts from an empty
+// data directory (aka. only the system collections and settings)
+// so that the tests don't depend on the bundled test data.
+//
+// Example:
+//
+//	app := tests.NewTestAppBuilder(t).
+//		InMemory().
+//		WithCollections(posts).
+//		WithRecords("posts", map[string]any{"title": "a"}, map[string]any{"title": "b"}).
+//		WithSettings(func(s *core.Settings) {
+//			s.Meta.AppName = "test"
+//		}).
+//		Build()
+type TestAppBuilder struct {
+	tb          testing.TB
+	dataDir     string
+	collections []*core.Collection
+	records     []testAppRecords
+	settings    []func(s *core.Settings)
+	inMemory    bool
+}
+
+type testAppRecords struct {
+	collection string
+	data       []map[string]any
+}
+
+// NewTestAppBuilder creates a new test application builder.
+//
+// The built application is isolated per test and it is
+// automatically cleaned up when the test and its subtests complete.
+func NewTestAppBuilder(tb testing.TB) *TestAppBuilder {
+	return &TestAppBuilder{tb: tb}
+}
+
+// WithDataDir specifies a data directory to start from (e.g. a copy of
+// an existing pb_data), instead of the default empty one.
+//
+// The directory is cloned for each built application.
+func (b *TestAppBuilder) WithDataDir(dataDir string) *TestAppBuilder {
+	b.dataDir = dataDir
+	return b
+}
+
+// InMemory enables the in-memory SQLite mode for the app databases.
+//
+// Note that the data directory is still created because it is used
+// for the local file storage, backups, etc.
+func (b *TestAppBuilder) InMemory() *TestAppBuilder {
+	b.inMemory = true
+	return b
+}
+
+// WithCollections registers collections to create (in the specified order)
+// when building the application.
+func (b *TestAppBuilder) WithCollections(collections ...*core.Collection) *TestAppBuilder {
+	b.collections = append(b.collections, collections...)
+	return b
+}
+
+// WithRecords registers records data to create in the specified
+// collection when building the application.
+//
+// The records are created after the collections in the order of the WithRecords calls.
+func (b *TestAppBuilder) WithRecords(collectionModelOrIdentifier any, records ...map[string]any) *TestAppBuilder {
+	var collection string
+	switch c := collectionModelOrIdentifier.(type) {
+	case *core.Collection:
+		collection = c.Name
+	case string:
+		collection = c
+	}
+
+	b.records = append(b.records, testAppRecords{collection: collection, data: records})
+	return b
+}
+
+// WithSettings registers a function to modify and persist the
+// application settings when building the application.
+func (b *TestAppBuilder) WithSettings(fn func(s *core.Settings)) *TestAppBuilder {
+	b.settings = append(b.settings, fn)
+	return b
+}
+
+// Build creates and initializes a new test application instance
+// loaded with the registered fixtures.
+//
+// It fails the test on error and registers the app.Cleanup() call
+// with the test Cleanup.
+func (b *TestAppBuilder) Build() *TestApp {
+	b.tb.Helper()
+
+	app, err := b.build()
+	if err != nil {
+		b.tb.Fatalf("Failed to build test app: %v", err)
+	}
+
+	b.tb.Cleanup(app.Cleanup)
+
+	return app
+}
+
+func (b *TestAppBuilder) build() (*TestApp, error) {
+	var dataDir string
+	var err error
+	if b.dataDir != "" {
+		dataDir, err = TempDirClone(b.dataDir)
+	} else {
+		dataDir, err = os.MkdirTemp("", "pb_test_*")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	config := core.BaseAppConfig{
+		DataDir:       dataDir,
+		EncryptionEnv: "pb_test_env",
+	}
+
+	var memory *memoryDBs
+	if b.inMemory {
+		memory = &memoryDBs{}
+		config.DBConnect = memory.connect
+	}
+
+	app, err := initTestApp(config)
+	if err != nil {
+		memory.close()
+		os.RemoveAll(dataDir)
+		return nil, err
+	}
+
+	if memory != nil {
+		app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+			err := e.Next()
+
+			// release the memory dbs after the app connections are closed
+			memory.close()
+
+			return err
+		})
+	}
+
+	if err := b.loadFixtures(app); err != nil {
+		app.Cleanup()
+		return nil, err
+	}
+
+	// reset the event counters triggered by the fixtures
+	app.ResetEventCalls()
+
+	return app, nil
+}
+
+func (b *TestAppBuilder) loadFixtures(app *TestApp) error {
+	if len(b.settings) > 0 {
+		settings := app.Settings()
+		for _, fn := range b.settings {
+			fn(settings)
+		}
+
+		if err := app.Save(settings); err != nil {
+			return fmt.Errorf("failed to save the fixture settings: %w", err)
+		}
+	}
+
+	for _, collection := range b.collections {
+		if err := app.Save(collection); err != nil {
+			return fmt.Errorf("failed to create fixture collection %q: %w", collection.Name, err)
+		}
+	}
+
+	for _, group := range b.records {
+		collection, err := app.FindCachedCollectionByNameOrId(group.collection)
+		if err != nil {
+			return fmt.Errorf("missing fixture records collection %q: %w", group.collection, err)
+		}
+
+		for i, data := range group.data {
+			record := core.NewRecord(collection)
+			record.Load(data)
+
+			if err := app.Save(record); err != nil {
+				return fmt.Errorf("failed to create fixture record %d of %q: %w", i, group.collection, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// memoryDBs manages the in-memory SQLite databases of a single test app.
+//
+// The "memdb" VFS databases are shared between the connections with
+// the same name and are discarded once their last connection is closed,
+// so an extra anchor connection is kept open for the entire app lifetime
+// (the regular pools close their idle connections).
+type memoryDBs struct {
+	mu      sync.Mutex
+	anchors map[string]*sql.Conn
+	dbs     []*sql.DB
+}
+
+func (m *memoryDBs) connect(dbPath string) (*dbx.DB, error) {
+	name := filepath.ToSlash(dbPath)
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+
+	dsn := "file:" + name + "?vfs=memdb&_pragma=busy_timeout(10000)&_pragma=foreign_keys(ON)&_pragma=temp_store(MEMORY)"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.anchors[name]; !ok {
+		anchorDB, err := sql.Open("sqlite", dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		conn, err := anchorDB.Conn(context.Background())
+		if err != nil {
+			anchorDB.Close()
+			return nil, err
+		}
+
+		if m.anchors == nil {
+			m.anchors = map[string]*sql.Conn{}
+		}
+		m.anchors[name] = conn
+		m.dbs = append(m.dbs, anchorDB)
+	}
+
+	return dbx.Open("sqlite", dsn)
+}
+
+func (m *memoryDBs) close() {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, conn := range m.anchors {
+		conn.Close()
+	}
+	m.anchors = nil
+
+	for _, db := range m.dbs {
+		db.Close()
+	}
+	m.dbs = nil
+}
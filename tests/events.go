@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"maps"
+	"testing"
+)
+
+// AssertEvents checks whether the app hook events were fired
+// the expected number of times.
+//
+// You can use the wildcard "*" event key if you want to ensure
+// that no other hook events except those listed have been fired.
+//
+// For example:
+//
+//	tests.AssertEvents(t, app, map[string]int{"*": 0, "OnRecordCreate": 1})
+func AssertEvents(t testing.TB, app *TestApp, expected map[string]int) {
+	t.Helper()
+
+	app.mux.Lock()
+	remainingEvents := maps.Clone(app.EventCalls)
+	app.mux.Unlock()
+
+	allEvents := maps.Clone(remainingEvents)
+
+	var noOtherEventsShouldRemain bool
+	for event, expectedNum := range expected {
+		if event == "*" && expectedNum <= 0 {
+			noOtherEventsShouldRemain = true
+			continue
+		}
+
+		actualNum := remainingEvents[event]
+		if actualNum != expectedNum {
+			t.Errorf("Expected event %s to be called %d, got %d", event, expectedNum, actualNum)
+		}
+
+		delete(remainingEvents, event)
+	}
+
+	if noOtherEventsShouldRemain && len(remainingEvents) > 0 {
+		t.Errorf("Missing expected remaining events:\n%#v\nAll triggered app events are:\n%#v", remainingEvents, allEvents)
+	}
+}
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"slices"
+	"strings"
 	"sync"
 
 	"github.com/pocketbase/pocketbase/tools/mailer"
@@ -48,6 +49,44 @@ func (tm *TestMailer) Messages() []*mailer.Message {
 	return slices.Clone(tm.messages)
 }
 
+// MessagesTo returns a shallow copy of the collected test messages
+// with the specified To, Cc or Bcc address (case-insensitive).
+func (tm *TestMailer) MessagesTo(address string) []*mailer.Message {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+
+	var result []*mailer.Message
+
+	for _, m := range tm.messages {
+		for _, to := range slices.Concat(m.To, m.Cc, m.Bcc) {
+			if strings.EqualFold(to.Address, address) {
+				result = append(result, m)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// Recipients returns the first To address of each collected test message (in the send order).
+func (tm *TestMailer) Recipients() []string {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+
+	result := make([]string, 0, len(tm.messages))
+
+	for _, m := range tm.messages {
+		if len(m.To) > 0 {
+			result = append(result, m.To[0].Address)
+		} else {
+			result = append(result, "")
+		}
+	}
+
+	return result
+}
+
 // FirstMessage returns a shallow copy of the first sent message.
 //
 // Returns an empty mailer.Message struct if there are no sent messages.
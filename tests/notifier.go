@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/notifier"
+)
+
+var _ notifier.Notifier = (*TestNotifier)(nil)
+
+// TestNotifier is a mock [notifier.Notifier] implementation.
+type TestNotifier struct {
+	mux      sync.Mutex
+	messages []*notifier.Message
+}
+
+// Send implements [notifier.Notifier] interface.
+func (tm *TestNotifier) Send(m *notifier.Message) error {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+
+	tm.messages = append(tm.messages, m)
+	return nil
+}
+
+// Reset clears any previously test collected data.
+func (tm *TestNotifier) Reset() {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+
+	tm.messages = nil
+}
+
+// TotalSend returns the total number of sent messages.
+func (tm *TestNotifier) TotalSend() int {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+
+	return len(tm.messages)
+}
+
+// Messages returns a shallow copy of all of the collected test messages.
+func (tm *TestNotifier) Messages() []*notifier.Message {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+
+	return slices.Clone(tm.messages)
+}
+
+// FirstMessage returns a shallow copy of the first sent message.
+//
+// Returns an empty notifier.Message struct if there are no sent messages.
+func (tm *TestNotifier) FirstMessage() notifier.Message {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+
+	var m notifier.Message
+
+	if len(tm.messages) > 0 {
+		return *tm.messages[0]
+	}
+
+	return m
+}
+
+// LastMessage returns a shallow copy of the last sent message.
+//
+// Returns an empty notifier.Message struct if there are no sent messages.
+func (tm *TestNotifier) LastMessage() notifier.Message {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+
+	var m notifier.Message
+
+	if len(tm.messages) > 0 {
+		return *tm.messages[len(tm.messages)-1]
+	}
+
+	return m
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/push"
+)
+
+var _ push.Driver = (*TestPushDriver)(nil)
+
+// TestPushDelivery defines a single push notification sent with [TestPushDriver].
+type TestPushDelivery struct {
+	Device  *push.Device
+	Message *push.Message
+}
+
+// TestPushDriver is a mock [push.Driver] implementation.
+type TestPushDriver struct {
+	mux        sync.Mutex
+	deliveries []*TestPushDelivery
+}
+
+// Send implements [push.Driver] interface.
+func (td *TestPushDriver) Send(ctx context.Context, device *push.Device, message *push.Message) error {
+	td.mux.Lock()
+	defer td.mux.Unlock()
+
+	td.deliveries = append(td.deliveries, &TestPushDelivery{Device: device, Message: message})
+	return nil
+}
+
+// Reset clears any previously test collected data.
+func (td *TestPushDriver) Reset() {
+	td.mux.Lock()
+	defer td.mux.Unlock()
+
+	td.deliveries = nil
+}
+
+// TotalSend returns the total number of sent notifications.
+func (td *TestPushDriver) TotalSend() int {
+	td.mux.Lock()
+	defer td.mux.Unlock()
+
+	return len(td.deliveries)
+}
+
+// Deliveries returns a shallow copy of all of the collected test deliveries.
+func (td *TestPushDriver) Deliveries() []*TestPushDelivery {
+	td.mux.Lock()
+	defer td.mux.Unlock()
+
+	return slices.Clone(td.deliveries)
+}
+
+// LastDelivery returns a shallow copy of the last sent notification.
+//
+// Returns an empty TestPushDelivery struct if there are no sent notifications.
+func (td *TestPushDriver) LastDelivery() TestPushDelivery {
+	td.mux.Lock()
+	defer td.mux.Unlock()
+
+	var d TestPushDelivery
+
+	if len(td.deliveries) > 0 {
+		return *td.deliveries[len(td.deliveries)-1]
+	}
+
+	return d
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+)
+
+// TestRealtimeClient is a registered realtime subscriptions client
+// that collects all messages sent to it (e.g. the record changes).
+type TestRealtimeClient struct {
+	*subscriptions.DefaultClient
+
+	app      core.App
+	mux      sync.Mutex
+	messages []subscriptions.Message
+}
+
+// NewTestRealtimeClient creates a new realtime test client subscribed to
+// the specified topics (e.g. "demo1/*") and registers it in the app broker.
+//
+// authRecord is optional and it is used as the client auth state.
+//
+// Note that the realtime messages are broadcasted by the hooks registered
+// with the app router, so usually the client is created in an
+// [ApiScenario.BeforeTestFunc] and checked in [ApiScenario.AfterTestFunc].
+//
+// Call client.Close() to unregister the client once done.
+func NewTestRealtimeClient(app core.App, authRecord *core.Record, subs ...string) *TestRealtimeClient {
+	client := &TestRealtimeClient{
+		DefaultClient: subscriptions.NewDefaultClient(),
+		app:           app,
+	}
+
+	if authRecord != nil {
+		client.Set(apis.RealtimeClientAuthKey, authRecord)
+	}
+
+	client.Subscribe(subs...)
+
+	// the channel is closed on discard
+	channel := client.Channel()
+	go func() {
+		for m := range channel {
+			client.mux.Lock()
+			client.messages = append(client.messages, m)
+			client.mux.Unlock()
+		}
+	}()
+
+	app.SubscriptionsBroker().Register(client)
+
+	return client
+}
+
+// Close unregisters and discards the client.
+func (c *TestRealtimeClient) Close() {
+	c.app.SubscriptionsBroker().Unregister(c.Id())
+}
+
+// Messages returns a shallow copy of all collected messages.
+func (c *TestRealtimeClient) Messages() []subscriptions.Message {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return slices.Clone(c.messages)
+}
+
+// MessageNames returns the names of all collected messages (in the receive order).
+func (c *TestRealtimeClient) MessageNames() []string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	names := make([]string, len(c.messages))
+	for i, m := range c.messages {
+		names[i] = m.Name
+	}
+
+	return names
+}
+
+// WaitMessages waits until at least n messages are collected
+// or the timeout expires and returns the collected messages.
+//
+// It is usually used because the realtime messages are sent asynchronously.
+func (c *TestRealtimeClient) WaitMessages(n int, timeout time.Duration) []subscriptions.Message {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		messages := c.Messages()
+		if len(messages) >= n || time.Now().After(deadline) {
+			return messages
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
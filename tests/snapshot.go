@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// SnapshotUpdateEnv is the name of the environment variable that
+// forces the JSON snapshots to be (re)written instead of compared
+// (e.g. PB_UPDATE_SNAPSHOTS=1 go test ./...).
+const SnapshotUpdateEnv = "PB_UPDATE_SNAPSHOTS"
+
+// SnapshotMaskValue is the replacement value of the masked snapshot fields.
+const SnapshotMaskValue = "[MASKED]"
+
+// AssertJSONSnapshot compares the provided raw JSON with the stored
+// snapshot file (usually in a "testdata" directory).
+//
+// The values of the specified mask fields (case-sensitive keys at any
+// nesting level, e.g. "id", "created", "updated") are replaced
+// with [SnapshotMaskValue] to allow comparing non-deterministic data.
+//
+// If the snapshot file doesn't exist or the [SnapshotUpdateEnv]
+// env variable is set, the snapshot is (re)written.
+func AssertJSONSnapshot(t testing.TB, snapshotPath string, rawJSON []byte, maskFields ...string) {
+	t.Helper()
+
+	actual, err := normalizeSnapshot(rawJSON, maskFields)
+	if err != nil {
+		t.Fatalf("Failed to normalize the snapshot JSON: %v\n%s", err, rawJSON)
+	}
+
+	expected, err := os.ReadFile(snapshotPath)
+	if err != nil || os.Getenv(SnapshotUpdateEnv) != "" {
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("Failed to read snapshot %q: %v", snapshotPath, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(snapshotPath), os.ModePerm); err != nil {
+			t.Fatalf("Failed to create snapshot dir: %v", err)
+		}
+
+		if err := os.WriteFile(snapshotPath, actual, 0644); err != nil {
+			t.Fatalf("Failed to write snapshot %q: %v", snapshotPath, err)
+		}
+
+		t.Logf("Written snapshot %q", snapshotPath)
+
+		return
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(expected), bytes.TrimSpace(actual)) {
+		t.Errorf("Snapshot %q mismatch (set %s=1 to update it)\nExpected:\n%s\nGot:\n%s", snapshotPath, SnapshotUpdateEnv, expected, actual)
+	}
+}
+
+// normalizeSnapshot masks the specified fields of the provided raw
+// JSON and returns its indented version with sorted object keys.
+func normalizeSnapshot(rawJSON []byte, maskFields []string) ([]byte, error) {
+	var data any
+
+	if err := json.Unmarshal(rawJSON, &data); err != nil {
+		return nil, err
+	}
+
+	data = maskSnapshotValue(data, maskFields)
+
+	result, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result, '\n'), nil
+}
+
+func maskSnapshotValue(value any, maskFields []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, item := range v {
+			if slices.Contains(maskFields, k) {
+				// keep the empty values to differentiate them from the missing ones
+				if item != nil && item != "" {
+					v[k] = SnapshotMaskValue
+				}
+				continue
+			}
+			v[k] = maskSnapshotValue(item, maskFields)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = maskSnapshotValue(item, maskFields)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,455 @@
+// Package acmedns implements a minimal ACME certificates manager
+// that solves the DNS-01 challenges with pluggable DNS providers
+// (allowing wildcard certificates and instances behind firewalls).
+//
+// Example:
+//
+//	m := &acmedns.Manager{
+//		Provider: &acmedns.Cloudflare{APIToken: "..."},
+//		Domains:  []string{"example.com", "*.example.com"},
+//		Cache:    autocert.DirCache("certs"),
+//	}
+//
+//	server.TLSConfig.GetCertificate = m.GetCertificate
+package acmedns
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	// DefaultRenewBefore is the default [Manager.RenewBefore] value.
+	DefaultRenewBefore = 30 * 24 * time.Hour
+
+	// DefaultPropagationTimeout is the default [Manager.PropagationTimeout] value.
+	DefaultPropagationTimeout = 2 * time.Minute
+
+	challengeRecordPrefix = "_acme-challenge."
+	accountKeyCacheKey    = "acme_dns_account+key"
+)
+
+// Provider defines a DNS provider capable of managing the DNS-01 challenge TXT records.
+type Provider interface {
+	// Present creates a TXT record with the specified fqdn name and value.
+	//
+	// Note that the same fqdn could have multiple values at the same time
+	// (e.g. when issuing a certificate for both "example.com" and "*.example.com").
+	Present(ctx context.Context, fqdn string, value string) error
+
+	// CleanUp removes the TXT record with the specified fqdn name and value.
+	CleanUp(ctx context.Context, fqdn string, value string) error
+}
+
+// Manager is a TLS certificate manager that obtains and renews a single
+// certificate for all [Manager.Domains] using the ACME DNS-01 challenge.
+type Manager struct {
+	// Provider is the DNS provider used to solve the challenges.
+	Provider Provider
+
+	// Domains is the list of certificate domains (wildcards are allowed, e.g. "*.example.com").
+	Domains []string
+
+	// Email is an optional ACME account contact email address.
+	Email string
+
+	// DirectoryURL is an optional custom ACME directory
+	// url (default to [acme.LetsEncryptURL]).
+	DirectoryURL string
+
+	// Cache is used to store the ACME account key and the issued certificate.
+	//
+	// If not set, the certificate will be obtained on every app start.
+	Cache autocert.Cache
+
+	// RenewBefore specifies how early the certificate should be renewed
+	// before it expires (default to [DefaultRenewBefore]).
+	RenewBefore time.Duration
+
+	// PropagationTimeout is the max duration to wait for the challenge TXT
+	// records to become visible in the DNS (default to [DefaultPropagationTimeout]).
+	//
+	// The challenge is submitted even if the records are still not visible
+	// when the timeout is reached.
+	PropagationTimeout time.Duration
+
+	mu       sync.Mutex
+	obtainMu sync.Mutex
+	cert     *tls.Certificate
+}
+
+// HasDomain reports whether the specified host name
+// is covered by one of the manager domains.
+func (m *Manager) HasDomain(name string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "" {
+		return false
+	}
+
+	for _, d := range m.Domains {
+		d = strings.ToLower(d)
+
+		if d == name {
+			return true
+		}
+
+		// wildcards match exactly one label
+		if base, ok := strings.CutPrefix(d, "*."); ok {
+			label, rest, found := strings.Cut(name, ".")
+			if found && label != "" && rest == base {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// GetCertificate implements the [tls.Config.GetCertificate] signature.
+//
+// If there is no valid certificate yet, it will be obtained synchronously.
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if !m.HasDomain(hello.ServerName) {
+		return nil, fmt.Errorf("acmedns: host %q is not configured", hello.ServerName)
+	}
+
+	if cert := m.currentCert(); cert != nil && time.Now().Before(cert.Leaf.NotAfter) {
+		return cert, nil
+	}
+
+	ctx := hello.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := m.Renew(ctx); err != nil {
+		return nil, err
+	}
+
+	return m.currentCert(), nil
+}
+
+// Renew loads the cached certificate and obtains a new
+// one if it is missing or it is about to expire.
+func (m *Manager) Renew(ctx context.Context) error {
+	// prevent concurrent orders
+	m.obtainMu.Lock()
+	defer m.obtainMu.Unlock()
+
+	if m.currentCert() == nil {
+		cert, err := m.loadCachedCert(ctx)
+		if err == nil {
+			m.setCert(cert)
+		} else if !errors.Is(err, autocert.ErrCacheMiss) {
+			return err
+		}
+	}
+
+	if cert := m.currentCert(); cert != nil && !m.shouldRenew(cert) {
+		return nil
+	}
+
+	cert, err := m.obtain(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.setCert(cert)
+
+	return nil
+}
+
+func (m *Manager) currentCert() *tls.Certificate {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.cert
+}
+
+func (m *Manager) setCert(cert *tls.Certificate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cert = cert
+}
+
+func (m *Manager) shouldRenew(cert *tls.Certificate) bool {
+	renewBefore := m.RenewBefore
+	if renewBefore <= 0 {
+		renewBefore = DefaultRenewBefore
+	}
+
+	return time.Now().Add(renewBefore).After(cert.Leaf.NotAfter)
+}
+
+// certCacheKey returns the cache key of the domains certificate.
+func (m *Manager) certCacheKey() string {
+	domains := slices.Clone(m.Domains)
+	slices.Sort(domains)
+
+	h := sha256.Sum256([]byte(strings.Join(domains, ",")))
+
+	return "acme_dns_" + hex.EncodeToString(h[:8])
+}
+
+func (m *Manager) loadCachedCert(ctx context.Context) (*tls.Certificate, error) {
+	if m.Cache == nil {
+		return nil, autocert.ErrCacheMiss
+	}
+
+	data, err := m.Cache.Get(ctx, m.certCacheKey())
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(data, data)
+	if err != nil {
+		return nil, fmt.Errorf("acmedns: invalid cached certificate: %w", err)
+	}
+
+	// ensure that the cached certificate still covers all domains
+	for _, d := range m.Domains {
+		if !slices.Contains(cert.Leaf.DNSNames, d) {
+			return nil, autocert.ErrCacheMiss
+		}
+	}
+
+	return &cert, nil
+}
+
+func (m *Manager) accountKey(ctx context.Context) (crypto.Signer, error) {
+	if m.Cache != nil {
+		data, err := m.Cache.Get(ctx, accountKeyCacheKey)
+		if err == nil {
+			block, _ := pem.Decode(data)
+			if block == nil {
+				return nil, errors.New("acmedns: invalid cached account key")
+			}
+
+			return x509.ParseECPrivateKey(block.Bytes)
+		}
+
+		if !errors.Is(err, autocert.ErrCacheMiss) {
+			return nil, err
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Cache != nil {
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		err = m.Cache.Put(ctx, accountKeyCacheKey, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// obtain orders a new certificate for all manager domains.
+func (m *Manager) obtain(ctx context.Context) (*tls.Certificate, error) {
+	if m.Provider == nil {
+		return nil, errors.New("acmedns: missing DNS provider")
+	}
+
+	if len(m.Domains) == 0 {
+		return nil, errors.New("acmedns: missing certificate domains")
+	}
+
+	key, err := m.accountKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acmedns: failed to load the account key: %w", err)
+	}
+
+	client := &acme.Client{
+		Key:          key,
+		DirectoryURL: m.DirectoryURL,
+		UserAgent:    "pocketbase-acmedns",
+	}
+	if client.DirectoryURL == "" {
+		client.DirectoryURL = acme.LetsEncryptURL
+	}
+
+	account := &acme.Account{}
+	if m.Email != "" {
+		account.Contact = []string{"mailto:" + m.Email}
+	}
+
+	_, err = client.Register(ctx, account, acme.AcceptTOS)
+	if err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("acmedns: failed to register the ACME account: %w", err)
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(m.Domains...))
+	if err != nil {
+		return nil, fmt.Errorf("acmedns: failed to create the certificate order: %w", err)
+	}
+
+	for _, authzURL := range order.AuthzURLs {
+		if err := m.authorize(ctx, client, authzURL); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return nil, fmt.Errorf("acmedns: the certificate order failed: %w", err)
+	}
+
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: m.Domains}, certKey)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("acmedns: failed to finalize the certificate order: %w", err)
+	}
+
+	pemData, err := encodeCertPEM(certKey, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(pemData, pemData)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Cache != nil {
+		if err := m.Cache.Put(ctx, m.certCacheKey(), pemData); err != nil {
+			return nil, fmt.Errorf("acmedns: failed to cache the certificate: %w", err)
+		}
+	}
+
+	return &cert, nil
+}
+
+// authorize solves the DNS-01 challenge of a single order authorization.
+func (m *Manager) authorize(ctx context.Context, client *acme.Client, authzURL string) error {
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("acmedns: failed to fetch authorization: %w", err)
+	}
+
+	if authz.Status == acme.StatusValid {
+		return nil // already authorized
+	}
+
+	var challenge *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("acmedns: missing dns-01 challenge for %q", authz.Identifier.Value)
+	}
+
+	value, err := client.DNS01ChallengeRecord(challenge.Token)
+	if err != nil {
+		return err
+	}
+
+	// note: for wildcard domains the identifier is the base domain
+	fqdn := challengeRecordPrefix + strings.TrimPrefix(authz.Identifier.Value, "*.")
+
+	if err := m.Provider.Present(ctx, fqdn, value); err != nil {
+		return fmt.Errorf("acmedns: failed to create the %q challenge record: %w", fqdn, err)
+	}
+	defer m.Provider.CleanUp(context.WithoutCancel(ctx), fqdn, value)
+
+	m.waitPropagation(ctx, fqdn, value)
+
+	if _, err := client.Accept(ctx, challenge); err != nil {
+		return fmt.Errorf("acmedns: failed to accept the %q challenge: %w", fqdn, err)
+	}
+
+	if _, err := client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("acmedns: the %q authorization failed: %w", authz.Identifier.Value, err)
+	}
+
+	return nil
+}
+
+// waitPropagation polls the DNS until the TXT record becomes
+// visible or the PropagationTimeout is reached.
+func (m *Manager) waitPropagation(ctx context.Context, fqdn string, value string) {
+	timeout := m.PropagationTimeout
+	if timeout <= 0 {
+		timeout = DefaultPropagationTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		records, _ := net.DefaultResolver.LookupTXT(ctx, fqdn)
+		if slices.Contains(records, value) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func encodeCertPEM(key *ecdsa.PrivateKey, chain [][]byte) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	if err := pem.Encode(&buf, &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}); err != nil {
+		return nil, err
+	}
+
+	for _, c := range chain {
+		if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: c}); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
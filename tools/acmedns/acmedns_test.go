@@ -0,0 +1,200 @@
+package acmedns
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+type memoryCache struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.items[key]
+	if !ok {
+		return nil, autocert.ErrCacheMiss
+	}
+
+	return data, nil
+}
+
+func (c *memoryCache) Put(ctx context.Context, key string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.items == nil {
+		c.items = map[string][]byte{}
+	}
+	c.items[key] = data
+
+	return nil
+}
+
+func (c *memoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.items, key)
+
+	return nil
+}
+
+func selfSignedCertPEM(t *testing.T, domains []string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domains[0]},
+		DNSNames:     domains,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := encodeCertPEM(key, [][]byte{der})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestManagerHasDomain(t *testing.T) {
+	t.Parallel()
+
+	m := &Manager{Domains: []string{"example.com", "*.Wildcard.com"}}
+
+	scenarios := []struct {
+		name     string
+		expected bool
+	}{
+		{"", false},
+		{"example.com", true},
+		{"EXAMPLE.com.", true},
+		{"www.example.com", false},
+		{"wildcard.com", false},
+		{"a.wildcard.com", true},
+		{"A.WILDCARD.COM", true},
+		{"a.b.wildcard.com", false},
+		{".wildcard.com", false},
+		{"missing.com", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if v := m.HasDomain(s.name); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestManagerCertCacheKey(t *testing.T) {
+	t.Parallel()
+
+	m1 := &Manager{Domains: []string{"example.com", "*.example.com"}}
+	m2 := &Manager{Domains: []string{"*.example.com", "example.com"}}
+	m3 := &Manager{Domains: []string{"example.com"}}
+
+	if m1.certCacheKey() != m2.certCacheKey() {
+		t.Fatalf("Expected the same cache keys for the same domains, got %q and %q", m1.certCacheKey(), m2.certCacheKey())
+	}
+
+	if m1.certCacheKey() == m3.certCacheKey() {
+		t.Fatalf("Expected different cache keys for different domains, got %q", m1.certCacheKey())
+	}
+
+	if strings.ContainsAny(m1.certCacheKey(), "*/\\") {
+		t.Fatalf("Expected file name safe cache key, got %q", m1.certCacheKey())
+	}
+}
+
+func TestManagerRenew(t *testing.T) {
+	t.Parallel()
+
+	domains := []string{"example.com", "*.example.com"}
+
+	scenarios := []struct {
+		name        string
+		certDomains []string
+		notAfter    time.Time
+		expectError bool
+	}{
+		{
+			"valid cached certificate",
+			domains,
+			time.Now().Add(60 * 24 * time.Hour),
+			false,
+		},
+		{
+			"expiring cached certificate",
+			domains,
+			time.Now().Add(5 * 24 * time.Hour),
+			true, // requires obtain
+		},
+		{
+			"cached certificate with missing domain",
+			domains[:1],
+			time.Now().Add(60 * 24 * time.Hour),
+			true, // requires obtain
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			cache := &memoryCache{}
+
+			// no provider so that the obtain attempts fail
+			m := &Manager{Domains: domains, Cache: cache}
+
+			cache.Put(context.Background(), m.certCacheKey(), selfSignedCertPEM(t, s.certDomains, s.notAfter))
+
+			err := m.Renew(context.Background())
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			cert, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "test.example.com"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !cert.Leaf.NotAfter.Equal(s.notAfter.Truncate(time.Second)) {
+				t.Fatalf("Expected the cached certificate, got certificate expiring at %v", cert.Leaf.NotAfter)
+			}
+
+			if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "missing.com"}); err == nil {
+				t.Fatal("Expected error for not configured host")
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package acmedns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+var _ Provider = (*Cloudflare)(nil)
+
+// Cloudflare defines a DNS-01 provider that manages the
+// challenge records via the Cloudflare DNS API.
+type Cloudflare struct {
+	// APIToken is a Cloudflare API token with "Zone.DNS:Edit" permissions.
+	APIToken string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to "https://api.cloudflare.com/client/v4").
+	Endpoint string
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+
+	mu sync.Mutex
+
+	// records stores the created records ids ([zoneId, recordId])
+	// by their fqdn and value.
+	records map[string][2]string
+}
+
+type cloudflareResponse struct {
+	Result json.RawMessage `json:"result"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+	Success bool `json:"success"`
+}
+
+// Present implements [Provider.Present] interface method.
+func (c *Cloudflare) Present(ctx context.Context, fqdn string, value string) error {
+	zoneId, err := c.findZoneId(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"type":    "TXT",
+		"name":    strings.TrimSuffix(fqdn, "."),
+		"content": `"` + value + `"`,
+		"ttl":     120,
+	}
+
+	var record struct {
+		Id string `json:"id"`
+	}
+
+	err = c.send(ctx, http.MethodPost, "/zones/"+url.PathEscape(zoneId)+"/dns_records", payload, &record)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.records == nil {
+		c.records = map[string][2]string{}
+	}
+	c.records[fqdn+"|"+value] = [2]string{zoneId, record.Id}
+
+	return nil
+}
+
+// CleanUp implements [Provider.CleanUp] interface method.
+func (c *Cloudflare) CleanUp(ctx context.Context, fqdn string, value string) error {
+	c.mu.Lock()
+	ids, ok := c.records[fqdn+"|"+value]
+	delete(c.records, fqdn+"|"+value)
+	c.mu.Unlock()
+
+	if !ok {
+		return nil // nothing to delete
+	}
+
+	return c.send(ctx, http.MethodDelete, "/zones/"+url.PathEscape(ids[0])+"/dns_records/"+url.PathEscape(ids[1]), nil, nil)
+}
+
+// findZoneId returns the id of the closest Cloudflare zone of the fqdn.
+func (c *Cloudflare) findZoneId(ctx context.Context, fqdn string) (string, error) {
+	name := strings.TrimSuffix(fqdn, ".")
+
+	for {
+		var zones []struct {
+			Id string `json:"id"`
+		}
+
+		err := c.send(ctx, http.MethodGet, "/zones?name="+url.QueryEscape(name), nil, &zones)
+		if err != nil {
+			return "", err
+		}
+
+		if len(zones) > 0 {
+			return zones[0].Id, nil
+		}
+
+		_, parent, found := strings.Cut(name, ".")
+		if !found || !strings.Contains(parent, ".") {
+			return "", fmt.Errorf("cloudflare: missing zone for %q", fqdn)
+		}
+		name = parent
+	}
+}
+
+func (c *Cloudflare) send(ctx context.Context, method string, path string, payload any, result any) error {
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = "https://api.cloudflare.com/client/v4"
+	}
+
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(endpoint, "/")+path, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.APIToken)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data := cloudflareResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
+		return fmt.Errorf("cloudflare: failed to decode the response (status %d): %w", res.StatusCode, err)
+	}
+
+	if !data.Success || res.StatusCode >= 400 {
+		messages := make([]string, 0, len(data.Errors))
+		for _, e := range data.Errors {
+			messages = append(messages, e.Message)
+		}
+
+		return fmt.Errorf("cloudflare: request failed with status %d: %w", res.StatusCode, errors.New(strings.Join(messages, "; ")))
+	}
+
+	if result != nil && len(data.Result) > 0 {
+		return json.Unmarshal(data.Result, result)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package acmedns
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestCloudflare(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	calls := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+
+		if r.Header.Get("Authorization") != "Bearer test_token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"success":false,"errors":[{"message":"invalid token"}]}`))
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("name") == "example.com":
+			w.Write([]byte(`{"success":true,"result":[{"id":"zone1"}]}`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"success":true,"result":[]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/zones/zone1/dns_records":
+			w.Write([]byte(`{"success":true,"result":{"id":"record1"}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/zones/zone1/dns_records/record1":
+			w.Write([]byte(`{"success":true,"result":{"id":"record1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success":false,"errors":[{"message":"not found"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("invalid token", func(t *testing.T) {
+		p := &Cloudflare{APIToken: "invalid", Endpoint: server.URL}
+
+		if err := p.Present(context.Background(), "_acme-challenge.example.com", "abc"); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+
+	t.Run("present and cleanup", func(t *testing.T) {
+		mu.Lock()
+		calls = calls[:0]
+		mu.Unlock()
+
+		p := &Cloudflare{APIToken: "test_token", Endpoint: server.URL}
+
+		if err := p.Present(context.Background(), "_acme-challenge.example.com", "abc"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := p.CleanUp(context.Background(), "_acme-challenge.example.com", "abc"); err != nil {
+			t.Fatal(err)
+		}
+
+		// unknown record
+		if err := p.CleanUp(context.Background(), "_acme-challenge.example.com", "abc"); err != nil {
+			t.Fatal(err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		expectedPayload, _ := json.Marshal(map[string]any{
+			"type":    "TXT",
+			"name":    "_acme-challenge.example.com",
+			"content": `"abc"`,
+			"ttl":     120,
+		})
+
+		expected := []string{
+			"GET /zones?name=_acme-challenge.example.com ",
+			"GET /zones?name=example.com ",
+			"POST /zones/zone1/dns_records " + string(expectedPayload),
+			"DELETE /zones/zone1/dns_records/record1 ",
+		}
+
+		if !slices.Equal(calls, expected) {
+			t.Fatalf("Expected calls\n%v\ngot\n%v", expected, calls)
+		}
+	})
+}
@@ -0,0 +1,311 @@
+package acmedns
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net"
+	"strings"
+	"time"
+)
+
+var _ Provider = (*RFC2136)(nil)
+
+const (
+	dnsTypeSOA  = 6
+	dnsTypeTXT  = 16
+	dnsTypeTSIG = 250
+
+	dnsClassIN   = 1
+	dnsClassNONE = 254
+	dnsClassANY  = 255
+
+	dnsOpcodeUpdate = 5
+
+	tsigFudge = 300
+)
+
+var dnsRcodeNames = map[int]string{
+	1:  "FORMERR",
+	2:  "SERVFAIL",
+	3:  "NXDOMAIN",
+	4:  "NOTIMP",
+	5:  "REFUSED",
+	6:  "YXDOMAIN",
+	7:  "YXRRSET",
+	8:  "NXRRSET",
+	9:  "NOTAUTH",
+	10: "NOTZONE",
+}
+
+// RFC2136 defines a DNS-01 provider that manages the challenge
+// records via dynamic DNS UPDATE requests (RFC 2136) optionally
+// signed with a TSIG key (RFC 8945).
+//
+// Note that the server responses signatures are not verified.
+type RFC2136 struct {
+	// Nameserver is the authoritative nameserver address (e.g. "ns1.example.com:53").
+	//
+	// If the port is missing, defaults to 53.
+	Nameserver string
+
+	// Zone is the DNS zone of the challenge records (e.g. "example.com").
+	Zone string
+
+	// TSIGKey is the optional TSIG key name.
+	TSIGKey string
+
+	// TSIGSecret is the base64 encoded TSIG key secret.
+	TSIGSecret string
+
+	// TSIGAlgorithm is the TSIG algorithm ("hmac-sha256" or "hmac-sha512", default to "hmac-sha256").
+	TSIGAlgorithm string
+
+	// Timeout is the max duration to wait for the server response (default to 10s).
+	Timeout time.Duration
+}
+
+// Present implements [Provider.Present] interface method.
+func (p *RFC2136) Present(ctx context.Context, fqdn string, value string) error {
+	return p.update(ctx, fqdn, value, false)
+}
+
+// CleanUp implements [Provider.CleanUp] interface method.
+func (p *RFC2136) CleanUp(ctx context.Context, fqdn string, value string) error {
+	return p.update(ctx, fqdn, value, true)
+}
+
+func (p *RFC2136) update(ctx context.Context, fqdn string, value string, remove bool) error {
+	msg, id, err := p.buildUpdateMessage(fqdn, value, remove, time.Now())
+	if err != nil {
+		return err
+	}
+
+	addr := p.Nameserver
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "53")
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+
+	conn, err := dialer.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return fmt.Errorf("rfc2136: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	if _, err := conn.Write(msg); err != nil {
+		return fmt.Errorf("rfc2136: %w", err)
+	}
+
+	resp := make([]byte, 4096)
+	for {
+		n, err := conn.Read(resp)
+		if err != nil {
+			return fmt.Errorf("rfc2136: %w", err)
+		}
+
+		if n < 12 || binary.BigEndian.Uint16(resp[0:2]) != id {
+			continue // not our response
+		}
+
+		if rcode := int(binary.BigEndian.Uint16(resp[2:4]) & 0x000f); rcode != 0 {
+			name, ok := dnsRcodeNames[rcode]
+			if !ok {
+				name = fmt.Sprintf("RCODE%d", rcode)
+			}
+			return fmt.Errorf("rfc2136: the update request failed with %s", name)
+		}
+
+		return nil
+	}
+}
+
+// buildUpdateMessage builds a DNS UPDATE message that adds
+// (or removes) the specified TXT record value.
+func (p *RFC2136) buildUpdateMessage(fqdn string, value string, remove bool, now time.Time) ([]byte, uint16, error) {
+	if p.Zone == "" {
+		return nil, 0, errors.New("rfc2136: missing zone")
+	}
+
+	zone, err := encodeDNSName(p.Zone)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	name, err := encodeDNSName(fqdn)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(value) > 255 {
+		return nil, 0, errors.New("rfc2136: too long TXT value")
+	}
+
+	var idBytes [2]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, 0, err
+	}
+	id := binary.BigEndian.Uint16(idBytes[:])
+
+	// header
+	msg := binary.BigEndian.AppendUint16(nil, id)
+	msg = binary.BigEndian.AppendUint16(msg, dnsOpcodeUpdate<<11)
+	msg = binary.BigEndian.AppendUint16(msg, 1) // ZOCOUNT
+	msg = binary.BigEndian.AppendUint16(msg, 0) // PRCOUNT
+	msg = binary.BigEndian.AppendUint16(msg, 1) // UPCOUNT
+	msg = binary.BigEndian.AppendUint16(msg, 0) // ADCOUNT
+
+	// zone section
+	msg = append(msg, zone...)
+	msg = binary.BigEndian.AppendUint16(msg, dnsTypeSOA)
+	msg = binary.BigEndian.AppendUint16(msg, dnsClassIN)
+
+	// update section
+	class, ttl := uint16(dnsClassIN), uint32(60)
+	if remove {
+		// delete an RR from an RRset (RFC 2136 2.5.4)
+		class, ttl = dnsClassNONE, 0
+	}
+	rdata := append([]byte{byte(len(value))}, value...)
+
+	msg = append(msg, name...)
+	msg = binary.BigEndian.AppendUint16(msg, dnsTypeTXT)
+	msg = binary.BigEndian.AppendUint16(msg, class)
+	msg = binary.BigEndian.AppendUint32(msg, ttl)
+	msg = binary.BigEndian.AppendUint16(msg, uint16(len(rdata)))
+	msg = append(msg, rdata...)
+
+	if p.TSIGKey == "" {
+		return msg, id, nil
+	}
+
+	msg, err = p.signTSIG(msg, id, now)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return msg, id, nil
+}
+
+// signTSIG appends a TSIG record to the specified message (RFC 8945 4.3).
+func (p *RFC2136) signTSIG(msg []byte, id uint16, now time.Time) ([]byte, error) {
+	secret, err := base64.StdEncoding.DecodeString(p.TSIGSecret)
+	if err != nil {
+		return nil, fmt.Errorf("rfc2136: invalid TSIG secret: %w", err)
+	}
+
+	algorithm := strings.ToLower(p.TSIGAlgorithm)
+	if algorithm == "" {
+		algorithm = "hmac-sha256"
+	}
+
+	var hashFunc func() hash.Hash
+	switch algorithm {
+	case "hmac-sha256":
+		hashFunc = sha256.New
+	case "hmac-sha512":
+		hashFunc = sha512.New
+	default:
+		return nil, fmt.Errorf("rfc2136: unsupported TSIG algorithm %q", p.TSIGAlgorithm)
+	}
+
+	keyName, err := encodeDNSName(strings.ToLower(p.TSIGKey))
+	if err != nil {
+		return nil, err
+	}
+
+	algorithmName, err := encodeDNSName(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	timeSigned := uint64(now.Unix())
+
+	// the time signed is 48-bit unsigned integer
+	appendTime := func(b []byte) []byte {
+		b = binary.BigEndian.AppendUint16(b, uint16(timeSigned>>32))
+		return binary.BigEndian.AppendUint32(b, uint32(timeSigned))
+	}
+
+	// TSIG variables
+	vars := append([]byte{}, keyName...)
+	vars = binary.BigEndian.AppendUint16(vars, dnsClassANY)
+	vars = binary.BigEndian.AppendUint32(vars, 0) // TTL
+	vars = append(vars, algorithmName...)
+	vars = appendTime(vars)
+	vars = binary.BigEndian.AppendUint16(vars, tsigFudge)
+	vars = binary.BigEndian.AppendUint16(vars, 0) // error
+	vars = binary.BigEndian.AppendUint16(vars, 0) // other len
+
+	mac := hmac.New(hashFunc, secret)
+	mac.Write(msg)
+	mac.Write(vars)
+	signature := mac.Sum(nil)
+
+	rdata := append([]byte{}, algorithmName...)
+	rdata = appendTime(rdata)
+	rdata = binary.BigEndian.AppendUint16(rdata, tsigFudge)
+	rdata = binary.BigEndian.AppendUint16(rdata, uint16(len(signature)))
+	rdata = append(rdata, signature...)
+	rdata = binary.BigEndian.AppendUint16(rdata, id) // original id
+	rdata = binary.BigEndian.AppendUint16(rdata, 0)  // error
+	rdata = binary.BigEndian.AppendUint16(rdata, 0)  // other len
+
+	signed := append([]byte{}, msg...)
+	signed = append(signed, keyName...)
+	signed = binary.BigEndian.AppendUint16(signed, dnsTypeTSIG)
+	signed = binary.BigEndian.AppendUint16(signed, dnsClassANY)
+	signed = binary.BigEndian.AppendUint32(signed, 0) // TTL
+	signed = binary.BigEndian.AppendUint16(signed, uint16(len(rdata)))
+	signed = append(signed, rdata...)
+
+	// increment ADCOUNT
+	binary.BigEndian.PutUint16(signed[10:12], binary.BigEndian.Uint16(signed[10:12])+1)
+
+	return signed, nil
+}
+
+// encodeDNSName encodes the specified domain name in the DNS wire format.
+func encodeDNSName(name string) ([]byte, error) {
+	name = strings.TrimSuffix(name, ".")
+
+	result := []byte{}
+
+	if name != "" {
+		for _, label := range strings.Split(name, ".") {
+			if label == "" || len(label) > 63 {
+				return nil, fmt.Errorf("invalid domain name %q", name)
+			}
+
+			result = append(result, byte(len(label)))
+			result = append(result, label...)
+		}
+	}
+
+	result = append(result, 0)
+
+	if len(result) > 255 {
+		return nil, fmt.Errorf("too long domain name %q", name)
+	}
+
+	return result, nil
+}
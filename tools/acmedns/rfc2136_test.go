@@ -0,0 +1,182 @@
+package acmedns
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestRFC2136(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("test_secret")
+
+	scenarios := []struct {
+		name        string
+		rcode       byte
+		remove      bool
+		expectError bool
+	}{
+		{"add", 0, false, false},
+		{"remove", 0, true, false},
+		{"refused", 5, false, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			received := make(chan []byte, 1)
+
+			go func() {
+				buf := make([]byte, 4096)
+
+				n, addr, err := conn.ReadFrom(buf)
+				if err != nil {
+					return
+				}
+				received <- buf[:n]
+
+				// response header with the same id
+				resp := make([]byte, 12)
+				copy(resp, buf[:2])
+				resp[2] = 0x80 | dnsOpcodeUpdate<<3
+				resp[3] = s.rcode
+				conn.WriteTo(resp, addr)
+			}()
+
+			p := &RFC2136{
+				Nameserver: conn.LocalAddr().String(),
+				Zone:       "example.com",
+				TSIGKey:    "test.key",
+				TSIGSecret: base64.StdEncoding.EncodeToString(secret),
+				Timeout:    2 * time.Second,
+			}
+
+			if s.remove {
+				err = p.CleanUp(context.Background(), "_acme-challenge.example.com", "abc")
+			} else {
+				err = p.Present(context.Background(), "_acme-challenge.example.com", "abc")
+			}
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			msg := <-received
+
+			// header
+			if opcode := (binary.BigEndian.Uint16(msg[2:4]) >> 11) & 0xf; opcode != dnsOpcodeUpdate {
+				t.Fatalf("Expected UPDATE opcode, got %d", opcode)
+			}
+			counts := []uint16{
+				binary.BigEndian.Uint16(msg[4:6]),
+				binary.BigEndian.Uint16(msg[6:8]),
+				binary.BigEndian.Uint16(msg[8:10]),
+				binary.BigEndian.Uint16(msg[10:12]),
+			}
+			if counts[0] != 1 || counts[1] != 0 || counts[2] != 1 || counts[3] != 1 {
+				t.Fatalf("Expected counts [1 0 1 1], got %v", counts)
+			}
+
+			// update record class
+			name, _ := encodeDNSName("_acme-challenge.example.com")
+			idx := bytes.Index(msg[12:], append(name, 0, dnsTypeTXT))
+			if idx < 0 {
+				t.Fatal("Missing the TXT update record")
+			}
+			class := binary.BigEndian.Uint16(msg[12+idx+len(name)+2:])
+			expectedClass := uint16(dnsClassIN)
+			if s.remove {
+				expectedClass = dnsClassNONE
+			}
+			if class != expectedClass {
+				t.Fatalf("Expected update class %d, got %d", expectedClass, class)
+			}
+
+			verifyTSIG(t, msg, "test.key", "hmac-sha256", secret)
+		})
+	}
+}
+
+func verifyTSIG(t *testing.T, msg []byte, keyName string, algorithm string, secret []byte) {
+	keyWire, _ := encodeDNSName(keyName)
+	algorithmWire, _ := encodeDNSName(algorithm)
+
+	idx := bytes.LastIndex(msg, append(keyWire, 0, dnsTypeTSIG))
+	if idx < 0 {
+		t.Fatal("Missing TSIG record")
+	}
+
+	// restore the unsigned message
+	unsigned := bytes.Clone(msg[:idx])
+	binary.BigEndian.PutUint16(unsigned[10:12], binary.BigEndian.Uint16(unsigned[10:12])-1)
+
+	// name + type + class + ttl + rdlength
+	rdata := msg[idx+len(keyWire)+10:]
+	if !bytes.HasPrefix(rdata, algorithmWire) {
+		t.Fatal("Invalid TSIG algorithm")
+	}
+	timeSigned := rdata[len(algorithmWire) : len(algorithmWire)+6]
+	macSize := int(binary.BigEndian.Uint16(rdata[len(algorithmWire)+8:]))
+	signature := rdata[len(algorithmWire)+10 : len(algorithmWire)+10+macSize]
+
+	vars := append([]byte{}, keyWire...)
+	vars = binary.BigEndian.AppendUint16(vars, dnsClassANY)
+	vars = binary.BigEndian.AppendUint32(vars, 0)
+	vars = append(vars, algorithmWire...)
+	vars = append(vars, timeSigned...)
+	vars = binary.BigEndian.AppendUint16(vars, tsigFudge)
+	vars = binary.BigEndian.AppendUint16(vars, 0)
+	vars = binary.BigEndian.AppendUint16(vars, 0)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(unsigned)
+	mac.Write(vars)
+
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		t.Fatal("Invalid TSIG signature")
+	}
+}
+
+func TestEncodeDNSName(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name        string
+		expected    []byte
+		expectError bool
+	}{
+		{"", []byte{0}, false},
+		{".", []byte{0}, false},
+		{"example.com", []byte("\x07example\x03com\x00"), false},
+		{"example.com.", []byte("\x07example\x03com\x00"), false},
+		{"a..com", nil, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := encodeDNSName(s.name)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !bytes.Equal(result, s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
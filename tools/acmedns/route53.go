@@ -0,0 +1,202 @@
+package acmedns
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/internal/sigv4"
+)
+
+var _ Provider = (*Route53)(nil)
+
+const (
+	route53ServiceCode = "route53"
+	route53Region      = "us-east-1"
+)
+
+// Route53 defines a DNS-01 provider that manages the
+// challenge records via the AWS Route53 API.
+type Route53 struct {
+	// HostedZoneId is the id of the Route53 hosted zone of the certificate domains.
+	HostedZoneId string
+
+	// AccessKey is the AWS access key id.
+	AccessKey string
+
+	// Secret is the AWS secret access key.
+	Secret string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to "https://route53.amazonaws.com").
+	Endpoint string
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+
+	mu sync.Mutex
+
+	// values stores the current challenge values by their fqdn
+	// (Route53 stores all TXT values of a name in a single record set).
+	values map[string][]string
+}
+
+// Present implements [Provider.Present] interface method.
+func (r *Route53) Present(ctx context.Context, fqdn string, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.values == nil {
+		r.values = map[string][]string{}
+	}
+
+	values := r.values[fqdn]
+	if !slices.Contains(values, value) {
+		values = append(slices.Clone(values), value)
+	}
+
+	if err := r.change(ctx, "UPSERT", fqdn, values); err != nil {
+		return err
+	}
+
+	r.values[fqdn] = values
+
+	return nil
+}
+
+// CleanUp implements [Provider.CleanUp] interface method.
+func (r *Route53) CleanUp(ctx context.Context, fqdn string, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values := r.values[fqdn]
+	if !slices.Contains(values, value) {
+		return nil // nothing to delete
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(values), func(v string) bool {
+		return v == value
+	})
+
+	var err error
+	if len(remaining) == 0 {
+		err = r.change(ctx, "DELETE", fqdn, values)
+	} else {
+		err = r.change(ctx, "UPSERT", fqdn, remaining)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) == 0 {
+		delete(r.values, fqdn)
+	} else {
+		r.values[fqdn] = remaining
+	}
+
+	return nil
+}
+
+type route53ResourceRecord struct {
+	Value string `xml:"Value"`
+}
+
+type route53RecordSet struct {
+	Name            string                  `xml:"Name"`
+	Type            string                  `xml:"Type"`
+	TTL             int                     `xml:"TTL"`
+	ResourceRecords []route53ResourceRecord `xml:"ResourceRecords>ResourceRecord"`
+}
+
+type route53Change struct {
+	Action            string           `xml:"Action"`
+	ResourceRecordSet route53RecordSet `xml:"ResourceRecordSet"`
+}
+
+type route53ChangeRequest struct {
+	XMLName xml.Name        `xml:"https://route53.amazonaws.com/doc/2013-04-01/ ChangeResourceRecordSetsRequest"`
+	Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+}
+
+func (r *Route53) change(ctx context.Context, action string, fqdn string, values []string) error {
+	recordSet := route53RecordSet{
+		Name: strings.TrimSuffix(fqdn, ".") + ".",
+		Type: "TXT",
+		TTL:  60,
+	}
+	for _, v := range values {
+		recordSet.ResourceRecords = append(recordSet.ResourceRecords, route53ResourceRecord{Value: `"` + v + `"`})
+	}
+
+	payload := route53ChangeRequest{
+		Changes: []route53Change{{Action: action, ResourceRecordSet: recordSet}},
+	}
+
+	raw, err := xml.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	raw = append([]byte(xml.Header), raw...)
+
+	endpoint := r.Endpoint
+	if endpoint == "" {
+		endpoint = "https://route53.amazonaws.com"
+	}
+
+	zoneId := strings.TrimPrefix(r.HostedZoneId, "/hostedzone/")
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		strings.TrimRight(endpoint, "/")+"/2013-04-01/hostedzone/"+url.PathEscape(zoneId)+"/rrset/",
+		bytes.NewReader(raw),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("content-type", "text/xml")
+
+	r.sign(req, raw, time.Now().UTC())
+
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 2048))
+		return fmt.Errorf("route53: request failed with status %d: %s", res.StatusCode, body)
+	}
+
+	return nil
+}
+
+// sign signs the provided request per AWS Signature v4.
+//
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html
+func (r *Route53) sign(req *http.Request, payload []byte, now time.Time) {
+	req.Header.Set("x-amz-date", now.Format(sigv4.DateTimeFormat))
+	req.Header.Set("x-amz-content-sha256", sigv4.PayloadHash(payload))
+
+	signer := &sigv4.Signer{
+		Region:    route53Region,
+		Service:   route53ServiceCode,
+		AccessKey: r.AccessKey,
+		SecretKey: r.Secret,
+	}
+	signer.Sign(req)
+}
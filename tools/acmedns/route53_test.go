@@ -0,0 +1,95 @@
+package acmedns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRoute53(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	bodies := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method != http.MethodPost || r.URL.Path != "/2013-04-01/hostedzone/Z123/rrset/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test_access/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		w.Write([]byte(`<ChangeResourceRecordSetsResponse/>`))
+	}))
+	defer server.Close()
+
+	p := &Route53{
+		HostedZoneId: "/hostedzone/Z123",
+		AccessKey:    "test_access",
+		Secret:       "test_secret",
+		Endpoint:     server.URL,
+	}
+
+	fqdn := "_acme-challenge.example.com"
+
+	steps := []struct {
+		action           func() error
+		expectedContains []string
+	}{
+		{
+			func() error { return p.Present(context.Background(), fqdn, "a") },
+			[]string{"<Action>UPSERT</Action>", "<Name>_acme-challenge.example.com.</Name>", "<Value>&#34;a&#34;</Value>"},
+		},
+		{
+			func() error { return p.Present(context.Background(), fqdn, "b") },
+			[]string{"<Action>UPSERT</Action>", "<Value>&#34;a&#34;</Value>", "<Value>&#34;b&#34;</Value>"},
+		},
+		{
+			func() error { return p.CleanUp(context.Background(), fqdn, "a") },
+			[]string{"<Action>UPSERT</Action>", "<Value>&#34;b&#34;</Value>"},
+		},
+		{
+			func() error { return p.CleanUp(context.Background(), fqdn, "b") },
+			[]string{"<Action>DELETE</Action>", "<Value>&#34;b&#34;</Value>"},
+		},
+	}
+
+	for i, step := range steps {
+		if err := step.action(); err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		mu.Lock()
+		body := bodies[len(bodies)-1]
+		mu.Unlock()
+
+		for _, str := range step.expectedContains {
+			if !strings.Contains(body, str) {
+				t.Fatalf("[%d] Cannot find %q in\n%s", i, str, body)
+			}
+		}
+	}
+
+	// cleanup of already removed value
+	if err := p.CleanUp(context.Background(), fqdn, "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != len(steps) {
+		t.Fatalf("Expected %d requests, got %d", len(steps), len(bodies))
+	}
+}
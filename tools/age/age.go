@@ -0,0 +1,302 @@
+// Package age implements a minimal subset of the age v1 file encryption format
+// (https://age-encryption.org/v1) supporting only the native X25519 recipients.
+//
+// The encrypted files are compatible with the official age CLI, e.g.:
+//
+//	age -d -i key.txt encrypted.zip > decrypted.zip
+package age
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Intro is the first line of every age v1 encrypted file.
+const Intro = "age-encryption.org/v1\n"
+
+const (
+	fileKeySize    = 16
+	stanzaPrefix   = "-> "
+	footerPrefix   = "---"
+	columnsPerLine = 64
+)
+
+// ErrIncorrectIdentity is returned when none of the provided
+// identities is able to decrypt the file.
+var ErrIncorrectIdentity = errors.New("no identity matched any of the recipients")
+
+var b64 = base64.RawStdEncoding.Strict()
+
+// Stanza is a single recipient header block.
+type Stanza struct {
+	Type string
+	Args []string
+	Body []byte
+}
+
+// Recipient is a generic age recipient that wraps the file key.
+type Recipient interface {
+	Wrap(fileKey []byte) (*Stanza, error)
+}
+
+// Identity is a generic age identity that unwraps the file key.
+//
+// Unwrap must return [ErrIncorrectIdentity] if none of the stanzas
+// matches the identity.
+type Identity interface {
+	Unwrap(stanzas []*Stanza) ([]byte, error)
+}
+
+// IsEncrypted reports whether the provided data starts with the age v1 intro line.
+func IsEncrypted(header []byte) bool {
+	return bytes.HasPrefix(header, []byte(Intro))
+}
+
+// Encrypt returns a WriteCloser that encrypts the written data for the
+// specified recipients and writes the result to dst.
+//
+// The returned writer must be closed in order to flush the last chunk.
+func Encrypt(dst io.Writer, recipients ...Recipient) (io.WriteCloser, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("at least one recipient is required")
+	}
+
+	fileKey := make([]byte, fileKeySize)
+	if _, err := rand.Read(fileKey); err != nil {
+		return nil, err
+	}
+
+	stanzas := make([]*Stanza, 0, len(recipients))
+	for _, r := range recipients {
+		s, err := r.Wrap(fileKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wrap the file key: %w", err)
+		}
+		stanzas = append(stanzas, s)
+	}
+
+	header, err := marshalHeader(stanzas, fileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, payloadNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	if _, err := dst.Write(header); err != nil {
+		return nil, err
+	}
+
+	if _, err := dst.Write(nonce); err != nil {
+		return nil, err
+	}
+
+	payloadKey, err := hkdf.Key(sha256.New, fileKey, nonce, "payload", chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStreamWriter(payloadKey, dst)
+}
+
+// Decrypt returns a Reader that decrypts the age encrypted src data
+// using the first matching identity.
+func Decrypt(src io.Reader, identities ...Identity) (io.Reader, error) {
+	if len(identities) == 0 {
+		return nil, errors.New("at least one identity is required")
+	}
+
+	br := bufio.NewReader(src)
+
+	stanzas, macMessage, mac, err := parseHeader(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	var fileKey []byte
+	for _, identity := range identities {
+		fileKey, err = identity.Unwrap(stanzas)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrIncorrectIdentity) {
+			return nil, err
+		}
+	}
+	if fileKey == nil {
+		return nil, ErrIncorrectIdentity
+	}
+
+	expectedMAC, err := headerMAC(fileKey, macMessage)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(mac, expectedMAC) {
+		return nil, errors.New("bad header MAC")
+	}
+
+	nonce := make([]byte, payloadNonceSize)
+	if _, err := io.ReadFull(br, nonce); err != nil {
+		return nil, fmt.Errorf("failed to read payload nonce: %w", err)
+	}
+
+	payloadKey, err := hkdf.Key(sha256.New, fileKey, nonce, "payload", chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStreamReader(payloadKey, br)
+}
+
+// -------------------------------------------------------------------
+
+func headerMAC(fileKey []byte, message []byte) ([]byte, error) {
+	key, err := hkdf.Key(sha256.New, fileKey, nil, "header", 32)
+	if err != nil {
+		return nil, err
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write(message)
+
+	return h.Sum(nil), nil
+}
+
+func marshalHeader(stanzas []*Stanza, fileKey []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString(Intro)
+
+	for _, s := range stanzas {
+		buf.WriteString(stanzaPrefix)
+		buf.WriteString(strings.Join(append([]string{s.Type}, s.Args...), " "))
+		buf.WriteByte('\n')
+
+		// wrap the body at 64 columns
+		// (the last line is always shorter, even if it has to be empty)
+		body := b64.EncodeToString(s.Body)
+		for len(body) >= columnsPerLine {
+			buf.WriteString(body[:columnsPerLine])
+			buf.WriteByte('\n')
+			body = body[columnsPerLine:]
+		}
+		buf.WriteString(body)
+		buf.WriteByte('\n')
+	}
+
+	buf.WriteString(footerPrefix)
+
+	mac, err := headerMAC(fileKey, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte(' ')
+	buf.WriteString(b64.EncodeToString(mac))
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// parseHeader parses the age header from br and returns its recipient stanzas,
+// the raw MAC message (up to and including the "---" footer prefix) and the MAC itself.
+func parseHeader(br *bufio.Reader) ([]*Stanza, []byte, []byte, error) {
+	var raw bytes.Buffer
+
+	readLine := func() (string, error) {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return "", errors.New("unexpected end of header")
+		}
+		if len(line) > 1024 {
+			return "", errors.New("header line is too long")
+		}
+		raw.WriteString(line)
+		return strings.TrimSuffix(line, "\n"), nil
+	}
+
+	intro, err := readLine()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if intro+"\n" != Intro {
+		return nil, nil, nil, errors.New("unsupported format or version")
+	}
+
+	var stanzas []*Stanza
+
+	for {
+		line, err := readLine()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if rawMAC, ok := strings.CutPrefix(line, footerPrefix+" "); ok {
+			mac, err := b64.DecodeString(rawMAC)
+			if err != nil || len(mac) != sha256.Size {
+				return nil, nil, nil, errors.New("invalid header MAC")
+			}
+
+			if len(stanzas) == 0 {
+				return nil, nil, nil, errors.New("missing recipient stanzas")
+			}
+
+			// exclude the space and the encoded MAC
+			message := raw.Bytes()[:raw.Len()-len(rawMAC)-2]
+
+			return stanzas, message, mac, nil
+		}
+
+		args, ok := strings.CutPrefix(line, stanzaPrefix)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("malformed header line %q", line)
+		}
+
+		parts := strings.Split(args, " ")
+		for _, p := range parts {
+			if p == "" {
+				return nil, nil, nil, fmt.Errorf("malformed stanza line %q", line)
+			}
+		}
+
+		stanza := &Stanza{Type: parts[0], Args: parts[1:]}
+
+		var body strings.Builder
+		for {
+			bodyLine, err := readLine()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			if len(bodyLine) > columnsPerLine {
+				return nil, nil, nil, errors.New("stanza body line is too long")
+			}
+
+			body.WriteString(bodyLine)
+
+			if len(bodyLine) < columnsPerLine {
+				break
+			}
+		}
+
+		stanza.Body, err = b64.DecodeString(body.String())
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("malformed stanza body: %w", err)
+		}
+
+		stanzas = append(stanzas, stanza)
+	}
+}
@@ -0,0 +1,228 @@
+package age_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/age"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sizes := []int{0, 1, 100, 64 * 1024, 64*1024 + 1, 3*64*1024 + 123}
+
+	for _, size := range sizes {
+		t.Run(fmt.Sprintf("size_%d", size), func(t *testing.T) {
+			plaintext := make([]byte, size)
+			if _, err := rand.Read(plaintext); err != nil {
+				t.Fatal(err)
+			}
+
+			encrypted := encryptTestData(t, plaintext, identity.Recipient())
+
+			if !age.IsEncrypted(encrypted) {
+				t.Fatal("Expected the encrypted data to start with the age intro")
+			}
+
+			r, err := age.Decrypt(bytes.NewReader(encrypted), identity)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decrypted, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(decrypted, plaintext) {
+				t.Fatalf("Expected the decrypted data to match the original %d bytes, got %d bytes", size, len(decrypted))
+			}
+		})
+	}
+}
+
+func TestEncryptMultipleRecipients(t *testing.T) {
+	t.Parallel()
+
+	identity1, _ := age.GenerateX25519Identity()
+	identity2, _ := age.GenerateX25519Identity()
+	identity3, _ := age.GenerateX25519Identity()
+
+	encrypted := encryptTestData(t, []byte("test"), identity1.Recipient(), identity2.Recipient())
+
+	for i, identity := range []*age.X25519Identity{identity1, identity2} {
+		r, err := age.Decrypt(bytes.NewReader(encrypted), identity3, identity)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		decrypted, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if string(decrypted) != "test" {
+			t.Fatalf("[%d] Expected %q, got %q", i, "test", decrypted)
+		}
+	}
+
+	_, err := age.Decrypt(bytes.NewReader(encrypted), identity3)
+	if !errors.Is(err, age.ErrIncorrectIdentity) {
+		t.Fatalf("Expected ErrIncorrectIdentity, got %v", err)
+	}
+}
+
+func TestEncryptNoRecipients(t *testing.T) {
+	t.Parallel()
+
+	if _, err := age.Encrypt(io.Discard); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestDecryptInvalid(t *testing.T) {
+	t.Parallel()
+
+	identity, _ := age.GenerateX25519Identity()
+
+	plaintext := bytes.Repeat([]byte("a"), 100*1024)
+
+	encrypted := encryptTestData(t, plaintext, identity.Recipient())
+
+	headerEnd := bytes.Index(encrypted, []byte("\n---")) + 1
+
+	scenarios := []struct {
+		name   string
+		data   func() []byte
+		header bool // whether the error is expected on Decrypt or on Read
+	}{
+		{
+			"non age data",
+			func() []byte { return []byte("test") },
+			true,
+		},
+		{
+			"tampered header",
+			func() []byte {
+				data := bytes.Clone(encrypted)
+				data[len(age.Intro)+3] = 'Y' // "-> Y25519"
+				return data
+			},
+			true,
+		},
+		{
+			"tampered mac",
+			func() []byte {
+				data := bytes.Clone(encrypted)
+				data[headerEnd+5] ^= 1
+				return data
+			},
+			true,
+		},
+		{
+			"tampered payload",
+			func() []byte {
+				data := bytes.Clone(encrypted)
+				data[len(data)-100] ^= 1
+				return data
+			},
+			false,
+		},
+		{
+			"truncated payload at chunk boundary",
+			func() []byte {
+				// remove the last (non full) chunk
+				return bytes.Clone(encrypted[:len(encrypted)-(100*1024-64*1024)-16])
+			},
+			false,
+		},
+		{
+			"appended data",
+			func() []byte {
+				return append(bytes.Clone(encrypted), 1, 2, 3)
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			r, err := age.Decrypt(bytes.NewReader(s.data()), identity)
+			if s.header {
+				if err == nil {
+					t.Fatal("Expected Decrypt error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected the header to be valid, got %v", err)
+			}
+
+			if _, err := io.ReadAll(r); err == nil {
+				t.Fatal("Expected Read error, got nil")
+			}
+		})
+	}
+}
+
+func TestIsEncrypted(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		data     string
+		expected bool
+	}{
+		{"", false},
+		{"age-encryption.org/v1", false},
+		{"age-encryption.org/v2\n", false},
+		{"PK\x03\x04", false},
+		{"age-encryption.org/v1\n", true},
+		{"age-encryption.org/v1\n-> X25519 abc\n", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(strings.ReplaceAll(s.data, "\n", "\\n"), func(t *testing.T) {
+			if v := age.IsEncrypted([]byte(s.data)); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+// -------------------------------------------------------------------
+
+func encryptTestData(t *testing.T, data []byte, recipients ...age.Recipient) []byte {
+	var buf bytes.Buffer
+
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// write in multiple uneven parts to ensure that the chunking is not affected
+	for len(data) > 0 {
+		n := min(len(data), 1000)
+		if _, err := w.Write(data[:n]); err != nil {
+			t.Fatal(err)
+		}
+		data = data[n:]
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,68 @@
+package age
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBech32Decode(t *testing.T) {
+	t.Parallel()
+
+	// BIP173 test vectors
+	scenarios := []struct {
+		value       string
+		expectError bool
+		expectedHRP string
+	}{
+		{"A12UEL5L", false, "a"},
+		{"a12uel5l", false, "a"},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", false, "abcdef"},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", false, "split"},
+		{"?1ezyfcl", false, "?"},
+		{"A12uEL5L", true, ""},      // mixed case
+		{"pzry9x0s0muk", true, ""},  // no separator
+		{"1pzry9x0s0muk", true, ""}, // empty hrp
+		{"x1b4n0q5v", true, ""},     // invalid data character
+		{"li1dgmt3", true, ""},      // too short checksum
+		{"A1G7SGD8", true, ""},      // invalid checksum
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			hrp, _, err := bech32Decode(s.value)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hrp != s.expectedHRP {
+				t.Fatalf("Expected hrp %q, got %q", s.expectedHRP, hrp)
+			}
+		})
+	}
+}
+
+func TestBech32EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	data := []byte{0, 1, 2, 3, 250, 251, 252, 253, 254, 255}
+
+	encoded, err := bech32Encode("Test", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if encoded[:5] != "test1" {
+		t.Fatalf("Expected lowercased hrp, got %q", encoded)
+	}
+
+	hrp, decoded, err := bech32Decode(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hrp != "test" || !bytes.Equal(decoded, data) {
+		t.Fatalf("Expected test hrp and %v, got %q and %v", data, hrp, decoded)
+	}
+}
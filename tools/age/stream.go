@@ -0,0 +1,222 @@
+package age
+
+import (
+	"crypto/cipher"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	payloadChunkSize    = 64 * 1024
+	payloadEncChunkSize = payloadChunkSize + chacha20poly1305.Overhead
+	payloadNonceSize    = 16
+)
+
+// streamNonce is the STREAM construction nonce
+// (11 bytes big endian chunk counter + 1 byte last chunk flag).
+type streamNonce [chacha20poly1305.NonceSize]byte
+
+func (n *streamNonce) setLast(last bool) {
+	if last {
+		n[len(n)-1] = 1
+	} else {
+		n[len(n)-1] = 0
+	}
+}
+
+func (n *streamNonce) increment() error {
+	for i := len(n) - 2; i >= 0; i-- {
+		n[i]++
+		if n[i] != 0 {
+			return nil
+		}
+	}
+
+	return errors.New("stream chunks counter overflow")
+}
+
+// -------------------------------------------------------------------
+
+var _ io.WriteCloser = (*streamWriter)(nil)
+
+// streamWriter encrypts the written data in chunks of [payloadChunkSize].
+type streamWriter struct {
+	aead  cipher.AEAD
+	dst   io.Writer
+	nonce streamNonce
+	buf   []byte
+	err   error
+}
+
+func newStreamWriter(key []byte, dst io.Writer) (*streamWriter, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamWriter{
+		aead: aead,
+		dst:  dst,
+		buf:  make([]byte, 0, payloadEncChunkSize),
+	}, nil
+}
+
+// Write implements [io.Writer].
+func (w *streamWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	var total int
+
+	for len(p) > 0 {
+		// flush only when there is more data so that the last chunk
+		// could be marked accordingly on Close
+		if len(w.buf) == payloadChunkSize {
+			if err := w.flushChunk(false); err != nil {
+				w.err = err
+				return total, err
+			}
+		}
+
+		n := min(payloadChunkSize-len(w.buf), len(p))
+		w.buf = append(w.buf, p[:n]...)
+		p = p[n:]
+		total += n
+	}
+
+	return total, nil
+}
+
+// Close flushes the last chunk.
+//
+// Note that it doesn't close the underlying writer.
+func (w *streamWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.err = w.flushChunk(true)
+	if w.err != nil {
+		return w.err
+	}
+
+	w.err = errors.New("stream writer is already closed")
+
+	return nil
+}
+
+func (w *streamWriter) flushChunk(last bool) error {
+	w.nonce.setLast(last)
+
+	chunk := w.aead.Seal(w.buf[:0], w.nonce[:], w.buf, nil)
+	if _, err := w.dst.Write(chunk); err != nil {
+		return err
+	}
+
+	w.buf = w.buf[:0]
+
+	return w.nonce.increment()
+}
+
+// -------------------------------------------------------------------
+
+var _ io.Reader = (*streamReader)(nil)
+
+// streamReader decrypts the chunks written by [streamWriter].
+type streamReader struct {
+	aead      cipher.AEAD
+	src       io.Reader
+	nonce     streamNonce
+	buf       []byte
+	plain     []byte
+	carry     int
+	last      bool
+	firstRead bool
+	err       error
+}
+
+func newStreamReader(key []byte, src io.Reader) (*streamReader, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamReader{
+		aead:      aead,
+		src:       src,
+		buf:       make([]byte, payloadEncChunkSize+1),
+		firstRead: true,
+	}, nil
+}
+
+// Read implements [io.Reader].
+func (r *streamReader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		if r.last {
+			return 0, io.EOF
+		}
+
+		r.err = r.readChunk()
+	}
+
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+
+	return n, nil
+}
+
+func (r *streamReader) readChunk() error {
+	// the extra byte from the previous read (if any) is already at the buffer start
+	n := r.carry
+
+	// read 1 extra byte to determine whether the current chunk is the last one
+	m, err := io.ReadFull(r.src, r.buf[n:])
+	n += m
+
+	switch {
+	case err == nil:
+		r.carry = 1
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		r.last = true
+		r.carry = 0
+	default:
+		return err
+	}
+
+	chunk := r.buf[:n-r.carry]
+	if len(chunk) < chacha20poly1305.Overhead {
+		return errors.New("truncated encrypted payload")
+	}
+
+	// only the payload of an empty plaintext could have an empty last chunk
+	if r.last && len(chunk) == chacha20poly1305.Overhead && !r.firstRead {
+		return errors.New("unexpected empty last payload chunk")
+	}
+
+	r.nonce.setLast(r.last)
+
+	plain, err := r.aead.Open(nil, r.nonce[:], chunk, nil)
+	if err != nil {
+		return errors.New("failed to decrypt payload chunk")
+	}
+
+	if err := r.nonce.increment(); err != nil {
+		return err
+	}
+
+	if r.carry > 0 {
+		r.buf[0] = r.buf[n-1]
+	}
+
+	r.plain = plain
+	r.firstRead = false
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package age
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	x25519StanzaType = "X25519"
+	x25519Label      = "age-encryption.org/v1/X25519"
+	recipientHRP     = "age"
+	identityHRP      = "age-secret-key-"
+)
+
+// -------------------------------------------------------------------
+
+var _ Recipient = (*X25519Recipient)(nil)
+
+// X25519Recipient is the standard age public key recipient ("age1...").
+type X25519Recipient struct {
+	theirPublicKey *ecdh.PublicKey
+}
+
+// ParseX25519Recipient parses a Bech32 encoded age public key ("age1...").
+func ParseX25519Recipient(s string) (*X25519Recipient, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed recipient %q: %w", s, err)
+	}
+
+	if hrp != recipientHRP {
+		return nil, fmt.Errorf("malformed recipient %q: invalid type %q", s, hrp)
+	}
+
+	publicKey, err := ecdh.X25519().NewPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("malformed recipient %q: %w", s, err)
+	}
+
+	return &X25519Recipient{theirPublicKey: publicKey}, nil
+}
+
+// String returns the Bech32 encoded public key.
+func (r *X25519Recipient) String() string {
+	s, _ := bech32Encode(recipientHRP, r.theirPublicKey.Bytes())
+	return s
+}
+
+// Wrap implements the [Recipient] interface.
+func (r *X25519Recipient) Wrap(fileKey []byte) (*Stanza, error) {
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedSecret, err := ephemeral.ECDH(r.theirPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ourPublicKey := ephemeral.PublicKey().Bytes()
+
+	wrappingKey, err := x25519WrappingKey(sharedSecret, ourPublicKey, r.theirPublicKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := aeadEncrypt(wrappingKey, fileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stanza{
+		Type: x25519StanzaType,
+		Args: []string{b64.EncodeToString(ourPublicKey)},
+		Body: body,
+	}, nil
+}
+
+// -------------------------------------------------------------------
+
+var _ Identity = (*X25519Identity)(nil)
+
+// X25519Identity is the standard age private key identity ("AGE-SECRET-KEY-1...").
+type X25519Identity struct {
+	privateKey *ecdh.PrivateKey
+}
+
+// GenerateX25519Identity generates a new random X25519Identity.
+func GenerateX25519Identity() (*X25519Identity, error) {
+	privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &X25519Identity{privateKey: privateKey}, nil
+}
+
+// NewX25519Identity creates a new X25519Identity from the provided 32 bytes secret scalar.
+func NewX25519Identity(secret []byte) (*X25519Identity, error) {
+	privateKey, err := ecdh.X25519().NewPrivateKey(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &X25519Identity{privateKey: privateKey}, nil
+}
+
+// ParseX25519Identity parses a Bech32 encoded age private key ("AGE-SECRET-KEY-1...").
+func ParseX25519Identity(s string) (*X25519Identity, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed secret key: %w", err)
+	}
+
+	if hrp != identityHRP {
+		return nil, fmt.Errorf("malformed secret key: invalid type %q", hrp)
+	}
+
+	identity, err := NewX25519Identity(data)
+	if err != nil {
+		return nil, fmt.Errorf("malformed secret key: %w", err)
+	}
+
+	return identity, nil
+}
+
+// Recipient returns the public X25519Recipient of the current identity.
+func (i *X25519Identity) Recipient() *X25519Recipient {
+	return &X25519Recipient{theirPublicKey: i.privateKey.PublicKey()}
+}
+
+// String returns the Bech32 encoded private key.
+func (i *X25519Identity) String() string {
+	s, _ := bech32Encode(identityHRP, i.privateKey.Bytes())
+	return strings.ToUpper(s)
+}
+
+// Unwrap implements the [Identity] interface.
+func (i *X25519Identity) Unwrap(stanzas []*Stanza) ([]byte, error) {
+	ourPublicKey := i.privateKey.PublicKey().Bytes()
+
+	for _, s := range stanzas {
+		if s.Type != x25519StanzaType {
+			continue
+		}
+
+		if len(s.Args) != 1 {
+			return nil, errors.New("invalid X25519 recipient stanza")
+		}
+
+		rawShare, err := b64.DecodeString(s.Args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid X25519 recipient stanza: %w", err)
+		}
+
+		share, err := ecdh.X25519().NewPublicKey(rawShare)
+		if err != nil {
+			return nil, fmt.Errorf("invalid X25519 recipient stanza: %w", err)
+		}
+
+		sharedSecret, err := i.privateKey.ECDH(share)
+		if err != nil {
+			return nil, fmt.Errorf("invalid X25519 recipient stanza: %w", err)
+		}
+
+		wrappingKey, err := x25519WrappingKey(sharedSecret, rawShare, ourPublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		fileKey, err := aeadDecrypt(wrappingKey, s.Body, fileKeySize)
+		if err != nil {
+			continue // wrapped for another recipient
+		}
+
+		return fileKey, nil
+	}
+
+	return nil, ErrIncorrectIdentity
+}
+
+// -------------------------------------------------------------------
+
+func x25519WrappingKey(sharedSecret, share, recipient []byte) ([]byte, error) {
+	salt := make([]byte, 0, len(share)+len(recipient))
+	salt = append(salt, share...)
+	salt = append(salt, recipient...)
+
+	return hkdf.Key(sha256.New, sharedSecret, salt, x25519Label, chacha20poly1305.KeySize)
+}
+
+// aeadEncrypt encrypts plaintext with ChaCha20-Poly1305 and a zero nonce
+// (the key is expected to be used only once).
+func aeadEncrypt(key []byte, plaintext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+
+	return aead.Seal(nil, nonce, plaintext, nil), nil
+}
+
+// aeadDecrypt decrypts a ciphertext produced by [aeadEncrypt]
+// ensuring that it matches the expected plaintext size.
+func aeadDecrypt(key []byte, ciphertext []byte, size int) ([]byte, error) {
+	if len(ciphertext) != size+chacha20poly1305.Overhead {
+		return nil, errors.New("invalid ciphertext size")
+	}
+
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
@@ -0,0 +1,103 @@
+package age_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/age"
+)
+
+func TestX25519IdentityString(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	str := identity.String()
+	if !strings.HasPrefix(str, "AGE-SECRET-KEY-1") || strings.ToUpper(str) != str {
+		t.Fatalf("Expected uppercase AGE-SECRET-KEY-1 identity, got %q", str)
+	}
+
+	parsed, err := age.ParseX25519Identity(str)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed.String() != str {
+		t.Fatalf("Expected %q, got %q", str, parsed.String())
+	}
+
+	if parsed.Recipient().String() != identity.Recipient().String() {
+		t.Fatalf("Expected recipient %q, got %q", identity.Recipient().String(), parsed.Recipient().String())
+	}
+
+	// lowercase should be also accepted
+	if _, err := age.ParseX25519Identity(strings.ToLower(str)); err != nil {
+		t.Fatalf("Expected lowercase identity to be valid, got %v", err)
+	}
+}
+
+func TestNewX25519Identity(t *testing.T) {
+	t.Parallel()
+
+	if _, err := age.NewX25519Identity([]byte("short")); err == nil {
+		t.Fatal("Expected error for invalid secret size, got nil")
+	}
+
+	secret := bytes.Repeat([]byte{1}, 32)
+
+	identity1, err := age.NewX25519Identity(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity2, err := age.NewX25519Identity(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if identity1.Recipient().String() != identity2.Recipient().String() {
+		t.Fatal("Expected the same secret to produce the same recipient")
+	}
+}
+
+func TestParseX25519Recipient(t *testing.T) {
+	t.Parallel()
+
+	identity, _ := age.GenerateX25519Identity()
+	recipient := identity.Recipient().String()
+
+	// change the last checksum character
+	invalidChecksum := recipient[:len(recipient)-1] + "q"
+	if invalidChecksum == recipient {
+		invalidChecksum = recipient[:len(recipient)-1] + "p"
+	}
+
+	scenarios := []struct {
+		value       string
+		expectError bool
+	}{
+		{"", true},
+		{"invalid", true},
+		{identity.String(), true}, // secret key
+		{invalidChecksum, true},
+		{strings.Replace(recipient, "age1", "abc1", 1), true},
+		{recipient, false},
+	}
+
+	for i, s := range scenarios {
+		r, err := age.ParseX25519Recipient(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+
+		if !hasErr && r.String() != s.value {
+			t.Fatalf("[%d] Expected %q, got %q", i, s.value, r.String())
+		}
+	}
+}
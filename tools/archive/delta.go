@@ -0,0 +1,546 @@
+package archive
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ManifestName is the name of the manifest file stored in the root of the delta aware archives.
+const ManifestName = ".pb_manifest.json"
+
+// DefaultBlockSize is the default size of the blocks used for
+// detecting the changed file parts between two archives.
+const DefaultBlockSize = 1 << 20 // 1MB
+
+// blocksDir is the archive dir where the changed blocks of an incremental archive are stored.
+const blocksDir = ".pb_blocks"
+
+// ErrMissingManifest is returned when the archive doesn't have a manifest file.
+var ErrMissingManifest = errors.New("the archive manifest file is missing")
+
+// Manifest describes the full content of a delta aware archive.
+//
+// Note that the manifest of an incremental archive describes the full state
+// of the archived directory and not only the changed files.
+type Manifest struct {
+	// Base is the name of the archive that the current one depends on
+	// (empty for full archives).
+	Base string `json:"base,omitempty"`
+
+	// BlockSize is the size of the hashed file blocks.
+	BlockSize int `json:"blockSize"`
+
+	// Files is a map with the archived files (relative to the archive root).
+	Files map[string]*ManifestFile `json:"files"`
+
+	// Paths is an optional list of the archived root directories and files
+	// (empty if the entire directory was archived).
+	//
+	// When applying an incremental archive with Paths only the dest
+	// files within the listed paths are created, updated or removed.
+	Paths []string `json:"paths,omitempty"`
+}
+
+// ManifestFile describes a single archived file.
+type ManifestFile struct {
+	Size   int64       `json:"size"`
+	Mode   fs.FileMode `json:"mode"`
+	Blocks []string    `json:"blocks"` // the sha256 hex hashes of the file blocks
+}
+
+// IsIncremental reports whether the manifest is of an incremental archive.
+func (m *Manifest) IsIncremental() bool {
+	return m.Base != ""
+}
+
+// DeltaOptions defines the [CreateDelta] options.
+type DeltaOptions struct {
+	// Base is the manifest of the archive to compare with.
+	//
+	// If nil, a full archive will be created.
+	Base *Manifest
+
+	// BaseName is the name of the base archive that will be stored in the new manifest.
+	BaseName string
+
+	// BlockSize is the size of the hashed file blocks (default to [DefaultBlockSize]).
+	//
+	// It is ignored when Base is set (the base block size is used instead).
+	BlockSize int
+
+	// SkipPaths is an optional list of directories and files (relative to src)
+	// to skip/ignore from the final archive.
+	SkipPaths []string
+
+	// Paths is an optional list of directories and files (relative to src)
+	// to archive (if empty, the entire src directory is archived).
+	Paths []string
+}
+
+// CreateDelta creates a new zip archive from src dir content and saves it in dest path.
+//
+// Similar to [Create] but it also stores a [Manifest] file with the hashes of the archived files blocks.
+//
+// If opts.Base is set, only the blocks that differ from the base manifest are stored
+// in the archive (aka. an incremental archive that could be applied with [ApplyDelta]).
+// Otherwise the files are archived as they are, allowing the archive to be extracted with [Extract].
+func CreateDelta(src string, dest string, opts DeltaOptions) (*Manifest, error) {
+	manifest := &Manifest{
+		BlockSize: opts.BlockSize,
+		Files:     map[string]*ManifestFile{},
+	}
+
+	for _, p := range opts.Paths {
+		manifest.Paths = append(manifest.Paths, path.Clean(filepath.ToSlash(p)))
+	}
+
+	if opts.Base != nil {
+		if opts.BaseName == "" {
+			return nil, errors.New("the base archive name is required for incremental archives")
+		}
+		manifest.Base = opts.BaseName
+		manifest.BlockSize = opts.Base.BlockSize
+	}
+
+	if manifest.BlockSize <= 0 {
+		manifest.BlockSize = DefaultBlockSize
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	zf, err := os.Create(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	zw := zip.NewWriter(zf)
+
+	// register a custom Deflate compressor
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, flate.BestSpeed)
+	})
+
+	err = zipAddDeltaFS(zw, os.DirFS(src), manifest, opts)
+	if err == nil {
+		err = zipAddManifest(zw, manifest)
+	}
+	if err != nil {
+		// try to cleanup at least the created zip file
+		return nil, errors.Join(err, zw.Close(), zf.Close(), os.Remove(dest))
+	}
+
+	if err := errors.Join(zw.Close(), zf.Close()); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// ReadManifest reads the manifest of the zip archive at src.
+//
+// Returns [ErrMissingManifest] if the archive doesn't have a manifest.
+func ReadManifest(src string) (*Manifest, error) {
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return readZipManifest(&zr.Reader)
+}
+
+// ApplyDelta applies the incremental zip archive at src to
+// the dest dir (usually containing the extracted base archive content).
+//
+// The dest files that are not part of the archive manifest are removed
+// (only those within the manifest Paths, if any) and after the changed blocks
+// are written the files are verified against the manifest hashes.
+func ApplyDelta(src string, dest string) error {
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	manifest, err := readZipManifest(&zr.Reader)
+	if err != nil {
+		return err
+	}
+
+	if !manifest.IsIncremental() {
+		return errors.New("the archive is not incremental")
+	}
+
+	// normalize dest path to check later for Zip Slip
+	dest = filepath.Clean(dest) + string(os.PathSeparator)
+
+	// remove the deleted files
+	err = filepath.WalkDir(dest, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dest, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if !withinPaths(rel, manifest.Paths) {
+			return nil
+		}
+
+		if _, ok := manifest.Files[rel]; !ok {
+			return os.Remove(p)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// resize the existing files and create the new ones
+	for name, mf := range manifest.Files {
+		p, err := deltaTargetPath(dest, name)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(p, os.O_RDWR|os.O_CREATE, mf.Mode.Perm())
+		if err != nil {
+			return err
+		}
+
+		err = f.Truncate(mf.Size)
+		if err = errors.Join(err, f.Close()); err != nil {
+			return err
+		}
+	}
+
+	// write the changed blocks
+	for _, zf := range zr.File {
+		if !strings.HasPrefix(zf.Name, blocksDir+"/") {
+			continue
+		}
+
+		name, index, err := parseBlockName(zf.Name)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := manifest.Files[name]; !ok {
+			return fmt.Errorf("block %q is not part of the archive manifest", zf.Name)
+		}
+
+		p, err := deltaTargetPath(dest, name)
+		if err != nil {
+			return err
+		}
+
+		if err := writeBlock(zf, p, int64(index)*int64(manifest.BlockSize)); err != nil {
+			return err
+		}
+	}
+
+	// verify the final files state
+	for name, mf := range manifest.Files {
+		p, err := deltaTargetPath(dest, name)
+		if err != nil {
+			return err
+		}
+
+		hashes, err := hashFileBlocks(p, manifest.BlockSize)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Equal(hashes, mf.Blocks) {
+			return fmt.Errorf("%q doesn't match the archive manifest (invalid or missing base archive?)", name)
+		}
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+func zipAddDeltaFS(w *zip.Writer, fsys fs.FS, manifest *Manifest, opts DeltaOptions) error {
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if name != "." && len(opts.Paths) > 0 && !withinPaths(name, manifest.Paths) && !parentOfPaths(name, manifest.Paths) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if name == ManifestName || !withinPaths(name, manifest.Paths) {
+			return nil
+		}
+
+		// skip
+		for _, ignore := range opts.SkipPaths {
+			if ignore == name ||
+				strings.HasPrefix(filepath.Clean(name)+string(os.PathSeparator), filepath.Clean(ignore)+string(os.PathSeparator)) {
+				return nil
+			}
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		mf := &ManifestFile{
+			Size:   info.Size(),
+			Mode:   info.Mode(),
+			Blocks: []string{},
+		}
+		manifest.Files[name] = mf
+
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		// full archive -> store the file as it is while hashing its blocks
+		if opts.Base == nil {
+			h, err := zip.FileInfoHeader(info)
+			if err != nil {
+				return err
+			}
+			h.Name = name
+			h.Method = zip.Deflate
+
+			fw, err := w.CreateHeader(h)
+			if err != nil {
+				return err
+			}
+
+			return eachBlock(io.TeeReader(f, fw), manifest.BlockSize, func(i int, block []byte) error {
+				mf.Blocks = append(mf.Blocks, hashBlock(block))
+				return nil
+			})
+		}
+
+		// incremental archive -> store only the changed blocks
+		var baseBlocks []string
+		if baseFile, ok := opts.Base.Files[name]; ok {
+			baseBlocks = baseFile.Blocks
+		}
+
+		return eachBlock(f, manifest.BlockSize, func(i int, block []byte) error {
+			hash := hashBlock(block)
+			mf.Blocks = append(mf.Blocks, hash)
+
+			if i < len(baseBlocks) && baseBlocks[i] == hash {
+				return nil // unchanged
+			}
+
+			bw, err := w.CreateHeader(&zip.FileHeader{
+				Name:   blocksDir + "/" + name + "/" + strconv.Itoa(i),
+				Method: zip.Deflate,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = bw.Write(block)
+			return err
+		})
+	})
+}
+
+func zipAddManifest(w *zip.Writer, manifest *Manifest) error {
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	mw, err := w.CreateHeader(&zip.FileHeader{Name: ManifestName, Method: zip.Deflate})
+	if err != nil {
+		return err
+	}
+
+	_, err = mw.Write(raw)
+	return err
+}
+
+func readZipManifest(zr *zip.Reader) (*Manifest, error) {
+	f, err := zr.Open(ManifestName)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrMissingManifest
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	manifest := &Manifest{}
+	if err := json.NewDecoder(f).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode the archive manifest: %w", err)
+	}
+
+	if manifest.BlockSize <= 0 {
+		return nil, errors.New("invalid archive manifest block size")
+	}
+
+	return manifest, nil
+}
+
+// withinPaths reports whether the slash separated name is one of the
+// specified paths or is nested under one of them.
+//
+// Returns true if paths is empty.
+func withinPaths(name string, paths []string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+
+	for _, p := range paths {
+		if name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parentOfPaths reports whether the slash separated dir name is
+// a parent of at least one of the specified paths.
+func parentOfPaths(dir string, paths []string) bool {
+	for _, p := range paths {
+		if strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eachBlock reads r in blockSize chunks and calls fn for each of them.
+func eachBlock(r io.Reader, blockSize int, fn func(i int, block []byte) error) error {
+	buf := make([]byte, blockSize)
+
+	for i := 0; ; i++ {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if fnErr := fn(i, buf[:n]); fnErr != nil {
+				return fnErr
+			}
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func hashBlock(block []byte) string {
+	h := sha256.Sum256(block)
+	return hex.EncodeToString(h[:])
+}
+
+func hashFileBlocks(p string, blockSize int) ([]string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hashes := []string{}
+
+	err = eachBlock(f, blockSize, func(i int, block []byte) error {
+		hashes = append(hashes, hashBlock(block))
+		return nil
+	})
+
+	return hashes, err
+}
+
+// parseBlockName extracts the file name and block index from a block zip entry name
+// (e.g. ".pb_blocks/data.db/12" -> "data.db", 12).
+func parseBlockName(blockName string) (string, int, error) {
+	trimmed := strings.TrimPrefix(blockName, blocksDir+"/")
+
+	name, rawIndex := path.Split(trimmed)
+	name = strings.TrimSuffix(name, "/")
+
+	index, err := strconv.Atoi(rawIndex)
+	if err != nil || index < 0 || name == "" {
+		return "", 0, fmt.Errorf("invalid block entry %q", blockName)
+	}
+
+	return name, index, nil
+}
+
+// deltaTargetPath returns the dest path of the specified archived file name.
+//
+// dest is expected to be cleaned and to end with a path separator.
+func deltaTargetPath(dest string, name string) (string, error) {
+	p := filepath.Join(dest, filepath.FromSlash(name))
+
+	// check for Zip Slip
+	if !strings.HasPrefix(p, dest) {
+		return "", fmt.Errorf("invalid file path: %s", p)
+	}
+
+	return p, nil
+}
+
+func writeBlock(zf *zip.File, p string, offset int64) error {
+	r, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	f, err := os.OpenFile(p, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return errors.Join(err, f.Close())
+	}
+
+	_, err = io.Copy(f, r)
+
+	return errors.Join(err, f.Close())
+}
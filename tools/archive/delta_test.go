@@ -0,0 +1,310 @@
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/archive"
+)
+
+func TestCreateDeltaFull(t *testing.T) {
+	srcDir := t.TempDir()
+	writeTestFile(t, srcDir, "data.db", bytes.Repeat([]byte("a"), 25))
+	writeTestFile(t, srcDir, "sub/file.txt", []byte("test"))
+	writeTestFile(t, srcDir, "skip/file.txt", []byte("test"))
+	writeTestFile(t, srcDir, "empty", nil)
+
+	zipPath := filepath.Join(t.TempDir(), "full.zip")
+
+	manifest, err := archive.CreateDelta(srcDir, zipPath, archive.DeltaOptions{
+		BlockSize: 10,
+		SkipPaths: []string{"skip"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if manifest.IsIncremental() {
+		t.Fatal("Expected full archive manifest")
+	}
+
+	if manifest.BlockSize != 10 {
+		t.Fatalf("Expected block size 10, got %d", manifest.BlockSize)
+	}
+
+	expectedBlocks := map[string]int{"data.db": 3, "sub/file.txt": 1, "empty": 0}
+	if len(manifest.Files) != len(expectedBlocks) {
+		t.Fatalf("Expected %d manifest files, got %d", len(expectedBlocks), len(manifest.Files))
+	}
+	for name, total := range expectedBlocks {
+		f, ok := manifest.Files[name]
+		if !ok {
+			t.Fatalf("Missing manifest file %q", name)
+		}
+		if len(f.Blocks) != total {
+			t.Fatalf("Expected %d %q blocks, got %d", total, name, len(f.Blocks))
+		}
+	}
+
+	// the stored manifest should match the returned one
+	stored, err := archive.ReadManifest(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(stored.Files["data.db"].Blocks, manifest.Files["data.db"].Blocks) {
+		t.Fatal("Expected the stored manifest to match the returned one")
+	}
+
+	// the full archive should be extractable as regular archive
+	extractDir := t.TempDir()
+	if err := archive.Extract(zipPath, extractDir); err != nil {
+		t.Fatal(err)
+	}
+	assertTestFile(t, extractDir, "data.db", bytes.Repeat([]byte("a"), 25))
+	assertTestFile(t, extractDir, "sub/file.txt", []byte("test"))
+	if _, err := os.Stat(filepath.Join(extractDir, "skip")); err == nil {
+		t.Fatal("Expected the skipped dir to not be archived")
+	}
+}
+
+func TestCreateDeltaIncrementalAndApply(t *testing.T) {
+	srcDir := t.TempDir()
+	writeTestFile(t, srcDir, "data.db", bytes.Repeat([]byte("a"), 25))
+	writeTestFile(t, srcDir, "deleted.txt", []byte("test"))
+	writeTestFile(t, srcDir, "unchanged.txt", []byte("test"))
+
+	fullPath := filepath.Join(t.TempDir(), "full.zip")
+	fullManifest, err := archive.CreateDelta(srcDir, fullPath, archive.DeltaOptions{BlockSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// base name is required
+	if _, err := archive.CreateDelta(srcDir, filepath.Join(t.TempDir(), "invalid.zip"), archive.DeltaOptions{Base: fullManifest}); err == nil {
+		t.Fatal("Expected error for missing base name")
+	}
+
+	// change only the second block and append a new one, remove and add files
+	newData := append(bytes.Repeat([]byte("a"), 10), bytes.Repeat([]byte("b"), 10)...)
+	newData = append(newData, bytes.Repeat([]byte("a"), 5)...)
+	newData = append(newData, []byte("ccc")...)
+	writeTestFile(t, srcDir, "data.db", newData)
+	writeTestFile(t, srcDir, "new/file.txt", []byte("new"))
+	if err := os.Remove(filepath.Join(srcDir, "deleted.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	incPath := filepath.Join(t.TempDir(), "inc.zip")
+	incManifest, err := archive.CreateDelta(srcDir, incPath, archive.DeltaOptions{
+		Base:      fullManifest,
+		BaseName:  "full.zip",
+		BlockSize: 999, // should be ignored
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !incManifest.IsIncremental() || incManifest.Base != "full.zip" {
+		t.Fatalf("Expected incremental manifest with base full.zip, got %q", incManifest.Base)
+	}
+
+	if incManifest.BlockSize != 10 {
+		t.Fatalf("Expected the base block size to be used, got %d", incManifest.BlockSize)
+	}
+
+	// check the stored blocks
+	zr, err := zip.OpenReader(incPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	zr.Close()
+	slices.Sort(names)
+
+	expectedNames := []string{
+		".pb_blocks/data.db/1",
+		".pb_blocks/data.db/2",
+		".pb_blocks/new/file.txt/0",
+		archive.ManifestName,
+	}
+	if !slices.Equal(names, expectedNames) {
+		t.Fatalf("Expected archive entries\n%v\ngot\n%v", expectedNames, names)
+	}
+
+	// apply
+	restoreDir := t.TempDir()
+	if err := archive.Extract(fullPath, restoreDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.ApplyDelta(incPath, restoreDir); err != nil {
+		t.Fatal(err)
+	}
+
+	assertTestFile(t, restoreDir, "data.db", newData)
+	assertTestFile(t, restoreDir, "unchanged.txt", []byte("test"))
+	assertTestFile(t, restoreDir, "new/file.txt", []byte("new"))
+	if _, err := os.Stat(filepath.Join(restoreDir, "deleted.txt")); err == nil {
+		t.Fatal("Expected deleted.txt to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(restoreDir, archive.ManifestName)); err == nil {
+		t.Fatal("Expected the base manifest file to be removed")
+	}
+}
+
+func TestCreateDeltaPathsAndApply(t *testing.T) {
+	srcDir := t.TempDir()
+	writeTestFile(t, srcDir, "data.db", bytes.Repeat([]byte("a"), 25))
+	writeTestFile(t, srcDir, "sub/nested/file.txt", []byte("test"))
+	writeTestFile(t, srcDir, "other.txt", []byte("test"))
+
+	fullPath := filepath.Join(t.TempDir(), "full.zip")
+	fullManifest, err := archive.CreateDelta(srcDir, fullPath, archive.DeltaOptions{BlockSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestFile(t, srcDir, "data.db", bytes.Repeat([]byte("b"), 15))
+	writeTestFile(t, srcDir, "sub/nested/file.txt", []byte("changed"))
+	writeTestFile(t, srcDir, "other.txt", []byte("changed"))
+
+	incPath := filepath.Join(t.TempDir(), "inc.zip")
+	incManifest, err := archive.CreateDelta(srcDir, incPath, archive.DeltaOptions{
+		Base:     fullManifest,
+		BaseName: "full.zip",
+		Paths:    []string{"data.db", "sub/nested"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPaths := []string{"data.db", "sub/nested"}
+	if !slices.Equal(incManifest.Paths, expectedPaths) {
+		t.Fatalf("Expected manifest paths %v, got %v", expectedPaths, incManifest.Paths)
+	}
+
+	expectedFiles := []string{"data.db", "sub/nested/file.txt"}
+	files := []string{}
+	for name := range incManifest.Files {
+		files = append(files, name)
+	}
+	slices.Sort(files)
+	if !slices.Equal(files, expectedFiles) {
+		t.Fatalf("Expected manifest files %v, got %v", expectedFiles, files)
+	}
+
+	// apply
+	restoreDir := t.TempDir()
+	if err := archive.Extract(fullPath, restoreDir); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, restoreDir, "sub/nested/extra.txt", []byte("extra"))
+	writeTestFile(t, restoreDir, "sub/extra.txt", []byte("extra"))
+	if err := archive.ApplyDelta(incPath, restoreDir); err != nil {
+		t.Fatal(err)
+	}
+
+	assertTestFile(t, restoreDir, "data.db", bytes.Repeat([]byte("b"), 15))
+	assertTestFile(t, restoreDir, "sub/nested/file.txt", []byte("changed"))
+	// the files outside of the manifest paths should be left untouched
+	assertTestFile(t, restoreDir, "other.txt", []byte("test"))
+	assertTestFile(t, restoreDir, "sub/extra.txt", []byte("extra"))
+	if _, err := os.Stat(filepath.Join(restoreDir, "sub/nested/extra.txt")); err == nil {
+		t.Fatal("Expected sub/nested/extra.txt to be removed")
+	}
+}
+
+func TestApplyDeltaInvalidBase(t *testing.T) {
+	srcDir := t.TempDir()
+	writeTestFile(t, srcDir, "data.db", bytes.Repeat([]byte("a"), 25))
+
+	fullPath := filepath.Join(t.TempDir(), "full.zip")
+	fullManifest, err := archive.CreateDelta(srcDir, fullPath, archive.DeltaOptions{BlockSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestFile(t, srcDir, "data.db", bytes.Repeat([]byte("b"), 25))
+
+	incPath := filepath.Join(t.TempDir(), "inc.zip")
+	if _, err := archive.CreateDelta(srcDir, incPath, archive.DeltaOptions{Base: fullManifest, BaseName: "full.zip"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// full archive is not incremental
+	if err := archive.ApplyDelta(fullPath, t.TempDir()); err == nil {
+		t.Fatal("Expected error when applying full archive")
+	}
+
+	// apply to a different base state
+	restoreDir := t.TempDir()
+	writeTestFile(t, restoreDir, "data.db", bytes.Repeat([]byte("c"), 30))
+	if err := archive.ApplyDelta(incPath, restoreDir); err != nil {
+		t.Fatal(err) // all blocks are changed so the result is still valid
+	}
+	assertTestFile(t, restoreDir, "data.db", bytes.Repeat([]byte("b"), 25))
+
+	// partial change over a mismatched base
+	writeTestFile(t, srcDir, "data.db", append(bytes.Repeat([]byte("a"), 10), bytes.Repeat([]byte("d"), 15)...))
+	incPath2 := filepath.Join(t.TempDir(), "inc2.zip")
+	incManifest, err := archive.CreateDelta(srcDir, incPath2, archive.DeltaOptions{Base: fullManifest, BaseName: "full.zip"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incManifest.Files["data.db"].Blocks) != 3 {
+		t.Fatalf("Expected 3 blocks, got %d", len(incManifest.Files["data.db"].Blocks))
+	}
+
+	mismatchedDir := t.TempDir()
+	writeTestFile(t, mismatchedDir, "data.db", bytes.Repeat([]byte("x"), 25))
+	if err := archive.ApplyDelta(incPath2, mismatchedDir); err == nil {
+		t.Fatal("Expected verification error for mismatched base")
+	}
+}
+
+func TestReadManifestMissing(t *testing.T) {
+	testDir := createTestDir(t)
+	defer os.RemoveAll(testDir)
+
+	zipPath := filepath.Join(t.TempDir(), "plain.zip")
+	if err := archive.Create(testDir, zipPath); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := archive.ReadManifest(zipPath)
+	if !errors.Is(err, archive.ErrMissingManifest) {
+		t.Fatalf("Expected ErrMissingManifest, got %v", err)
+	}
+}
+
+// -------------------------------------------------------------------
+
+func writeTestFile(t *testing.T, dir string, name string, data []byte) {
+	p := filepath.Join(dir, name)
+
+	if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertTestFile(t *testing.T, dir string, name string, expected []byte) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("Expected %q content\n%q\ngot\n%q", name, expected, data)
+	}
+}
@@ -0,0 +1,32 @@
+// Package cache implements simple key-value cache backends with
+// expiration support (in-memory and Redis).
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned when the requested cache key is missing or has expired.
+var ErrMiss = errors.New("cache: key not found")
+
+// Backend defines the common interface for the cache storage implementations.
+type Backend interface {
+	// Get returns the value of the specified key.
+	//
+	// Returns [ErrMiss] if the key is missing or has expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores the specified key-value pair.
+	//
+	// Zero or negative ttl means that the key will never expire.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Incr atomically increments the integer value of the specified key
+	// (missing keys start from 0) and returns the new value.
+	Incr(ctx context.Context, key string) (int64, error)
+
+	// Close releases the resources associated with the backend.
+	Close() error
+}
@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var _ Backend = (*Memory)(nil)
+
+// DefaultMemoryMaxItems is the default max number of entries
+// that the in-memory cache can hold.
+const DefaultMemoryMaxItems = 10000
+
+type memoryItem struct {
+	expires time.Time
+	value   []byte
+}
+
+func (item memoryItem) expired(now time.Time) bool {
+	return !item.expires.IsZero() && !now.Before(item.expires)
+}
+
+// Memory is a concurrent safe in-memory cache [Backend] implementation.
+type Memory struct {
+	items    map[string]memoryItem
+	maxItems int
+	mu       sync.Mutex
+}
+
+// NewMemory creates a new in-memory cache backend that holds up to maxItems entries.
+//
+// If maxItems is <= 0, fallbacks to [DefaultMemoryMaxItems].
+//
+// When the limit is reached, the expired entries are removed first
+// and if there is still no room, random entries are evicted.
+func NewMemory(maxItems int) *Memory {
+	if maxItems <= 0 {
+		maxItems = DefaultMemoryMaxItems
+	}
+
+	return &Memory{
+		items:    map[string]memoryItem{},
+		maxItems: maxItems,
+	}
+}
+
+// Get implements [Backend.Get].
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.items[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+
+	if item.expired(time.Now()) {
+		delete(m.items, key)
+		return nil, ErrMiss
+	}
+
+	return item.value, nil
+}
+
+// Set implements [Backend.Set].
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item := memoryItem{value: value}
+	if ttl > 0 {
+		item.expires = time.Now().Add(ttl)
+	}
+
+	if _, ok := m.items[key]; !ok {
+		m.ensureRoom()
+	}
+
+	m.items[key] = item
+
+	return nil
+}
+
+// Incr implements [Backend.Incr].
+func (m *Memory) Incr(ctx context.Context, key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.items[key]
+	if !ok || item.expired(time.Now()) {
+		if !ok {
+			m.ensureRoom()
+		}
+		item = memoryItem{}
+	}
+
+	var current int64
+	if len(item.value) > 0 {
+		var err error
+		current, err = strconv.ParseInt(string(item.value), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	current++
+
+	item.value = []byte(strconv.FormatInt(current, 10))
+	m.items[key] = item
+
+	return current, nil
+}
+
+// Len returns the number of the currently stored entries (including the expired but not yet removed ones).
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.items)
+}
+
+// Close implements [Backend.Close] and clears the stored entries.
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items = map[string]memoryItem{}
+
+	return nil
+}
+
+// ensureRoom makes room for a single new entry.
+//
+// note: expects the caller to have acquired the lock
+func (m *Memory) ensureRoom() {
+	if len(m.items) < m.maxItems {
+		return
+	}
+
+	now := time.Now()
+	for k, item := range m.items {
+		if item.expired(now) {
+			delete(m.items, k)
+		}
+	}
+
+	// evict random entries (map iteration order is not specified)
+	for k := range m.items {
+		if len(m.items) < m.maxItems {
+			break
+		}
+		delete(m.items, k)
+	}
+}
@@ -0,0 +1,116 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/cache"
+)
+
+func TestMemoryGetSet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	m := cache.NewMemory(0)
+
+	if _, err := m.Get(ctx, "missing"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("Expected ErrMiss, got %v", err)
+	}
+
+	if err := m.Set(ctx, "a", []byte("1"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Set(ctx, "b", []byte("2"), 5*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := m.Get(ctx, "a"); err != nil || string(v) != "1" {
+		t.Fatalf("Expected a value 1, got %q (%v)", v, err)
+	}
+
+	if v, err := m.Get(ctx, "b"); err != nil || string(v) != "2" {
+		t.Fatalf("Expected b value 2, got %q (%v)", v, err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, err := m.Get(ctx, "b"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("Expected ErrMiss for the expired key, got %v", err)
+	}
+
+	if v, err := m.Get(ctx, "a"); err != nil || string(v) != "1" {
+		t.Fatalf("Expected a value 1 to remain, got %q (%v)", v, err)
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Len() != 0 {
+		t.Fatalf("Expected no entries after close, got %d", m.Len())
+	}
+}
+
+func TestMemoryIncr(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	m := cache.NewMemory(0)
+
+	for i := 1; i <= 3; i++ {
+		v, err := m.Incr(ctx, "counter")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != int64(i) {
+			t.Fatalf("Expected %d, got %d", i, v)
+		}
+	}
+
+	m.Set(ctx, "invalid", []byte("abc"), 0)
+	if _, err := m.Incr(ctx, "invalid"); err == nil {
+		t.Fatal("Expected non-integer incr error")
+	}
+}
+
+func TestMemoryMaxItems(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	m := cache.NewMemory(2)
+
+	m.Set(ctx, "a", []byte("1"), time.Millisecond)
+	m.Set(ctx, "b", []byte("2"), 0)
+
+	time.Sleep(5 * time.Millisecond)
+
+	// should remove the expired "a"
+	m.Set(ctx, "c", []byte("3"), 0)
+
+	if m.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", m.Len())
+	}
+
+	for _, key := range []string{"b", "c"} {
+		if _, err := m.Get(ctx, key); err != nil {
+			t.Fatalf("Expected key %q to exist, got %v", key, err)
+		}
+	}
+
+	// should evict one of the existing entries
+	m.Set(ctx, "d", []byte("4"), 0)
+
+	if m.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", m.Len())
+	}
+
+	if _, err := m.Get(ctx, "d"); err != nil {
+		t.Fatalf("Expected key d to exist, got %v", err)
+	}
+}
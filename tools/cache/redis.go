@@ -0,0 +1,344 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Backend = (*Redis)(nil)
+
+// DefaultRedisPoolSize is the default max number of idle Redis connections.
+const DefaultRedisPoolSize = 10
+
+// DefaultRedisTimeout is the default Redis dial and command timeout
+// (when the command context doesn't have a deadline).
+const DefaultRedisTimeout = 5 * time.Second
+
+// RedisError represents a Redis server error reply.
+type RedisError string
+
+// Error implements the [error] interface.
+func (e RedisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// Redis is a minimal Redis (or Redis protocol compatible server) cache [Backend]
+// implementation that uses only the GET, SET and INCR commands.
+type Redis struct {
+	tlsConfig *tls.Config
+	pool      chan *redisConn
+	addr      string
+	username  string
+	password  string
+	db        int
+
+	// Timeout is the dial and command timeout used when the command
+	// context doesn't have a deadline (default to [DefaultRedisTimeout]).
+	Timeout time.Duration
+}
+
+// NewRedis creates a new Redis cache backend from the specified connection url.
+//
+// The url must be in the format:
+//
+//	redis://[[username]:password@]host[:port][/db]
+//	rediss://[[username]:password@]host[:port][/db] (TLS)
+//
+// The optPassword argument could be used to specify the password
+// separately (it takes precedence over the url one).
+//
+// The connections are established lazily on the first command.
+func NewRedis(rawURL string, optPassword ...string) (*Redis, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	r := &Redis{
+		pool:    make(chan *redisConn, DefaultRedisPoolSize),
+		Timeout: DefaultRedisTimeout,
+	}
+
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		r.tlsConfig = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	default:
+		return nil, fmt.Errorf("unsupported redis url scheme %q", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return nil, errors.New("missing redis host")
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "6379"
+	}
+	r.addr = net.JoinHostPort(u.Hostname(), port)
+
+	if u.User != nil {
+		r.username = u.User.Username()
+		r.password, _ = u.User.Password()
+	}
+
+	if len(optPassword) > 0 && optPassword[0] != "" {
+		r.password = optPassword[0]
+	}
+
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		r.db, err = strconv.Atoi(db)
+		if err != nil || r.db < 0 {
+			return nil, fmt.Errorf("invalid redis db %q", db)
+		}
+	}
+
+	return r, nil
+}
+
+// Get implements [Backend.Get].
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := reply.(type) {
+	case nil:
+		return nil, ErrMiss
+	case []byte:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+}
+
+// Set implements [Backend.Set].
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+
+	_, err := r.do(ctx, args...)
+
+	return err
+}
+
+// Incr implements [Backend.Incr].
+func (r *Redis) Incr(ctx context.Context, key string) (int64, error) {
+	reply, err := r.do(ctx, "INCR", key)
+	if err != nil {
+		return 0, err
+	}
+
+	v, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected INCR reply %T", reply)
+	}
+
+	return v, nil
+}
+
+// Close implements [Backend.Close] and closes all idle connections.
+func (r *Redis) Close() error {
+	var errs []error
+
+	for {
+		select {
+		case c := <-r.pool:
+			if err := c.conn.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		default:
+			return errors.Join(errs...)
+		}
+	}
+}
+
+// do executes a single Redis command and returns its decoded reply.
+//
+// The reply could be nil, string (simple string), int64, []byte (bulk string) or []any.
+func (r *Redis) do(ctx context.Context, args ...string) (any, error) {
+	c, err := r.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(r.Timeout)
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		c.conn.Close()
+		return nil, err
+	}
+
+	reply, err := c.exec(args...)
+	if err != nil {
+		var redisErr RedisError
+		if !errors.As(err, &redisErr) {
+			// the connection state is unknown
+			c.conn.Close()
+			return nil, err
+		}
+	}
+
+	r.release(c)
+
+	return reply, err
+}
+
+func (r *Redis) acquire(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-r.pool:
+		return c, nil
+	default:
+	}
+
+	dialer := &net.Dialer{Timeout: r.Timeout}
+
+	var conn net.Conn
+	var err error
+	if r.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: r.tlsConfig}).DialContext(ctx, "tcp", r.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", r.addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	if err := conn.SetDeadline(time.Now().Add(r.Timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if r.password != "" {
+		authArgs := []string{"AUTH", r.password}
+		if r.username != "" {
+			authArgs = []string{"AUTH", r.username, r.password}
+		}
+		if _, err := c.exec(authArgs...); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if r.db > 0 {
+		if _, err := c.exec("SELECT", strconv.Itoa(r.db)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (r *Redis) release(c *redisConn) {
+	select {
+	case r.pool <- c:
+	default:
+		c.conn.Close() // the pool is full
+	}
+}
+
+// -------------------------------------------------------------------
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// exec writes the command as RESP array of bulk strings and reads its reply.
+func (c *redisConn) exec(args ...string) (any, error) {
+	var sb strings.Builder
+
+	sb.WriteString("*")
+	sb.WriteString(strconv.Itoa(len(args)))
+	sb.WriteString("\r\n")
+	for _, arg := range args {
+		sb.WriteString("$")
+		sb.WriteString(strconv.Itoa(len(arg)))
+		sb.WriteString("\r\n")
+		sb.WriteString(arg)
+		sb.WriteString("\r\n")
+	}
+
+	if _, err := io.WriteString(c.conn, sb.String()); err != nil {
+		return nil, err
+	}
+
+	return readRedisReply(c.reader)
+}
+
+func readRedisReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, RedisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+
+		buf := make([]byte, n+2) // +\r\n
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+
+		items := make([]any, n)
+		for i := range items {
+			items[i], err = readRedisReply(r)
+			if err != nil {
+				var redisErr RedisError
+				if !errors.As(err, &redisErr) {
+					return nil, err
+				}
+				items[i] = redisErr
+			}
+		}
+
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unsupported reply type %q", line[0])
+	}
+}
@@ -0,0 +1,192 @@
+package cache_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/cache"
+)
+
+// fakeRedis is a minimal in-memory server that understands
+// the subset of the Redis protocol used by the cache backend.
+type fakeRedis struct {
+	listener net.Listener
+	data     map[string]string
+	commands []string
+	mu       sync.Mutex
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeRedis{listener: listener, data: map[string]string{}}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	t.Cleanup(func() { listener.Close() })
+
+	return s
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		args := make([]string, n)
+		for i := range args {
+			sizeLine, _ := r.ReadString('\n')
+			size, _ := strconv.Atoi(strings.TrimSpace(sizeLine[1:]))
+			buf := make([]byte, size+2)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			args[i] = string(buf[:size])
+		}
+
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.Join(args, " "))
+		var reply string
+		switch strings.ToUpper(args[0]) {
+		case "AUTH", "SELECT":
+			reply = "+OK\r\n"
+		case "GET":
+			if v, ok := s.data[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case "SET":
+			s.data[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case "INCR":
+			v, ok := s.data[args[1]]
+			if !ok {
+				v = "0"
+			}
+			current, err := strconv.Atoi(v)
+			if err != nil {
+				reply = "-ERR value is not an integer or out of range\r\n"
+			} else {
+				s.data[args[1]] = strconv.Itoa(current + 1)
+				reply = ":" + s.data[args[1]] + "\r\n"
+			}
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		s.mu.Unlock()
+
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func TestNewRedisInvalidURL(t *testing.T) {
+	t.Parallel()
+
+	urls := []string{
+		"",
+		"http://localhost:6379",
+		"redis://",
+		"redis://localhost:6379/abc",
+	}
+
+	for _, u := range urls {
+		if _, err := cache.NewRedis(u); err == nil {
+			t.Errorf("Expected error for url %q", u)
+		}
+	}
+}
+
+func TestRedis(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeRedis(t)
+
+	r, err := cache.NewRedis("redis://user:old@"+server.listener.Addr().String()+"/2", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := r.Get(ctx, "missing"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("Expected ErrMiss, got %v", err)
+	}
+
+	if err := r.Set(ctx, "a", []byte("hello\r\nworld"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Set(ctx, "b", []byte("1"), 1500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := r.Get(ctx, "a"); err != nil || string(v) != "hello\r\nworld" {
+		t.Fatalf("Expected a value, got %q (%v)", v, err)
+	}
+
+	if v, err := r.Incr(ctx, "b"); err != nil || v != 2 {
+		t.Fatalf("Expected incremented value 2, got %d (%v)", v, err)
+	}
+
+	var redisErr cache.RedisError
+	if _, err := r.Incr(ctx, "a"); !errors.As(err, &redisErr) {
+		t.Fatalf("Expected RedisError, got %v", err)
+	}
+
+	// the connection should remain usable after a server error reply
+	if v, err := r.Incr(ctx, "c"); err != nil || v != 1 {
+		t.Fatalf("Expected incremented value 1, got %d (%v)", v, err)
+	}
+
+	server.mu.Lock()
+	commands := strings.Join(server.commands, "\n")
+	server.mu.Unlock()
+
+	expectedCommands := strings.Join([]string{
+		"AUTH user secret",
+		"SELECT 2",
+		"GET missing",
+		"SET a hello\r\nworld",
+		"SET b 1 PX 1500",
+		"GET a",
+		"INCR b",
+		"INCR a",
+		"INCR c",
+	}, "\n")
+
+	if commands != expectedCommands {
+		t.Fatalf("Expected commands\n%q\ngot\n%q", expectedCommands, commands)
+	}
+}
@@ -0,0 +1,151 @@
+// Package captcha implements a minimal server-side verification client
+// for the Cloudflare Turnstile, hCaptcha and Google reCAPTCHA providers.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Supported builtin captcha providers.
+const (
+	ProviderTurnstile = "turnstile"
+	ProviderHCaptcha  = "hcaptcha"
+	ProviderReCaptcha = "recaptcha"
+)
+
+// Default siteverify endpoints of the builtin providers.
+const (
+	DefaultTurnstileEndpoint = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+	DefaultHCaptchaEndpoint  = "https://api.hcaptcha.com/siteverify"
+	DefaultReCaptchaEndpoint = "https://www.google.com/recaptcha/api/siteverify"
+)
+
+// DefaultHTTPTimeout is the default verification request timeout used
+// when no custom [http.Client] is specified.
+const DefaultHTTPTimeout = 10 * time.Second
+
+// ErrInvalidToken is returned when the provider rejects the captcha token.
+var ErrInvalidToken = errors.New("invalid or expired captcha token")
+
+// Verifier defines a captcha token verification interface.
+type Verifier interface {
+	// Verify checks the client captcha token and returns
+	// a non-nil error if the token is not valid.
+	//
+	// remoteIP is optional and it is forwarded to the provider if set.
+	Verify(ctx context.Context, token string, remoteIP string) error
+}
+
+var _ Verifier = (*Client)(nil)
+
+// Client defines a [Verifier] that uses the siteverify API
+// of one of the builtin providers.
+type Client struct {
+	// Provider is the name of the captcha provider
+	// (ProviderTurnstile, ProviderHCaptcha or ProviderReCaptcha).
+	Provider string
+
+	// Secret is the provider secret key.
+	Secret string
+
+	// Endpoint is an optional custom siteverify url
+	// (if not explicitly set, defaults to the provider's one).
+	Endpoint string
+
+	// MinScore is the optional min required score of the score based
+	// providers like reCAPTCHA v3 (the score is not checked if zero).
+	MinScore float64
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+}
+
+type verifyResponse struct {
+	Score      *float64 `json:"score"`
+	ErrorCodes []string `json:"error-codes"`
+	Success    bool     `json:"success"`
+}
+
+// Verify implements [Verifier] interface.
+func (c *Client) Verify(ctx context.Context, token string, remoteIP string) error {
+	if token == "" {
+		return ErrInvalidToken
+	}
+
+	endpoint, err := c.endpoint()
+	if err != nil {
+		return err
+	}
+
+	data := url.Values{}
+	data.Set("secret", c.Secret)
+	data.Set("response", token)
+	if remoteIP != "" {
+		data.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		return fmt.Errorf("%s: failed to verify captcha token (status %d): %s", c.Provider, res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	result := verifyResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return fmt.Errorf("%s: failed to decode the verification response: %w", c.Provider, err)
+	}
+
+	if !result.Success {
+		if len(result.ErrorCodes) > 0 {
+			return fmt.Errorf("%w (%s)", ErrInvalidToken, strings.Join(result.ErrorCodes, ", "))
+		}
+		return ErrInvalidToken
+	}
+
+	if c.MinScore > 0 && result.Score != nil && *result.Score < c.MinScore {
+		return fmt.Errorf("%w (score %v is below %v)", ErrInvalidToken, *result.Score, c.MinScore)
+	}
+
+	return nil
+}
+
+func (c *Client) endpoint() (string, error) {
+	if c.Endpoint != "" {
+		return c.Endpoint, nil
+	}
+
+	switch c.Provider {
+	case ProviderTurnstile:
+		return DefaultTurnstileEndpoint, nil
+	case ProviderHCaptcha:
+		return DefaultHCaptchaEndpoint, nil
+	case ProviderReCaptcha:
+		return DefaultReCaptchaEndpoint, nil
+	}
+
+	return "", fmt.Errorf("unsupported captcha provider %q", c.Provider)
+}
@@ -0,0 +1,112 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestClientVerify(t *testing.T) {
+	t.Parallel()
+
+	var lastForm url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lastForm, _ = url.ParseQuery(string(body))
+
+		switch lastForm.Get("response") {
+		case "valid":
+			w.Write([]byte(`{"success":true}`))
+		case "low_score":
+			w.Write([]byte(`{"success":true,"score":0.3}`))
+		case "high_score":
+			w.Write([]byte(`{"success":true,"score":0.9}`))
+		case "server_error":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`internal error`))
+		case "invalid_json":
+			w.Write([]byte(`{`))
+		default:
+			w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+		}
+	}))
+	defer server.Close()
+
+	scenarios := []struct {
+		name        string
+		client      *Client
+		token       string
+		remoteIP    string
+		expectError bool
+		expectToken bool
+	}{
+		{"empty token", &Client{Provider: ProviderTurnstile, Endpoint: server.URL}, "", "", true, true},
+		{"unsupported provider", &Client{Provider: "invalid"}, "valid", "", true, false},
+		{"valid token", &Client{Provider: ProviderTurnstile, Secret: "test", Endpoint: server.URL}, "valid", "1.2.3.4", false, false},
+		{"rejected token", &Client{Provider: ProviderHCaptcha, Secret: "test", Endpoint: server.URL}, "invalid", "", true, true},
+		{"server error", &Client{Provider: ProviderHCaptcha, Secret: "test", Endpoint: server.URL}, "server_error", "", true, false},
+		{"invalid response", &Client{Provider: ProviderHCaptcha, Secret: "test", Endpoint: server.URL}, "invalid_json", "", true, false},
+		{"score without min score", &Client{Provider: ProviderReCaptcha, Secret: "test", Endpoint: server.URL}, "low_score", "", false, false},
+		{"score below min score", &Client{Provider: ProviderReCaptcha, Secret: "test", Endpoint: server.URL, MinScore: 0.5}, "low_score", "", true, true},
+		{"score above min score", &Client{Provider: ProviderReCaptcha, Secret: "test", Endpoint: server.URL, MinScore: 0.5}, "high_score", "", false, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			lastForm = nil
+
+			err := s.client.Verify(context.Background(), s.token, s.remoteIP)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if isTokenErr := errors.Is(err, ErrInvalidToken); isTokenErr != s.expectToken {
+				t.Fatalf("Expected ErrInvalidToken %v, got %v (%v)", s.expectToken, isTokenErr, err)
+			}
+
+			if s.token == "valid" && lastForm != nil {
+				if v := lastForm.Get("secret"); v != s.client.Secret {
+					t.Fatalf("Expected secret %q, got %q", s.client.Secret, v)
+				}
+				if v := lastForm.Get("remoteip"); v != s.remoteIP {
+					t.Fatalf("Expected remoteip %q, got %q", s.remoteIP, v)
+				}
+			}
+		})
+	}
+}
+
+func TestClientDefaultEndpoints(t *testing.T) {
+	t.Parallel()
+
+	scenarios := map[string]string{
+		ProviderTurnstile: DefaultTurnstileEndpoint,
+		ProviderHCaptcha:  DefaultHCaptchaEndpoint,
+		ProviderReCaptcha: DefaultReCaptchaEndpoint,
+	}
+
+	for provider, expected := range scenarios {
+		t.Run(provider, func(t *testing.T) {
+			endpoint, err := (&Client{Provider: provider}).endpoint()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if endpoint != expected {
+				t.Fatalf("Expected endpoint %q, got %q", expected, endpoint)
+			}
+
+			custom, _ := (&Client{Provider: provider, Endpoint: "https://example.com"}).endpoint()
+			if custom != "https://example.com" {
+				t.Fatalf("Expected the custom endpoint, got %q", custom)
+			}
+		})
+	}
+}
@@ -9,22 +9,50 @@
 package cron
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"slices"
 	"sync"
 	"time"
 )
 
+// AfterJobId is the id of the ephemeral job passed to the [PanicHandler]
+// when a one-off task registered with [Cron.After] panics.
+const AfterJobId = "@after"
+
+// PanicHandler defines a function that is called when a scheduled job panics.
+//
+// The stack is the panicking goroutine stack trace.
+type PanicHandler func(job *Job, recovered any, stack []byte)
+
+// RunWrapper defines a function that wraps the scheduled jobs execution
+// (e.g. to skip or to track the individual runs).
+//
+// scheduled is the job tick time truncated to the cron interval
+// (or to the second for the jobs with seconds granularity)
+// and it is the same for all app instances with synchronized clocks, so it could be used
+// for example as a key to ensure that a scheduled run is executed only once.
+//
+// run executes the job (applying its options) and returns the run outcome
+// (the panics are still reported to the registered [PanicHandler], if any).
+type RunWrapper func(job *Job, scheduled time.Time, run func() RunResult)
+
 // Cron is a crontab-like struct for tasks/jobs scheduling.
 type Cron struct {
-	timezone   *time.Location
-	ticker     *time.Ticker
-	startTimer *time.Timer
-	tickerDone chan bool
-	jobs       []*Job
-	interval   time.Duration
-	mux        sync.RWMutex
+	lastSlot     time.Time
+	lastSecond   time.Time
+	timezone     *time.Location
+	ticker       *time.Ticker
+	startTimer   *time.Timer
+	tickerDone   chan struct{}
+	jobs         []*Job
+	panicHandler PanicHandler
+	runWrapper   RunWrapper
+	interval     time.Duration
+	tickInterval time.Duration
+	mux          sync.RWMutex
 }
 
 // New create a new Cron struct with default tick interval of 1 minute
@@ -34,10 +62,9 @@ type Cron struct {
 // You can change the default timezone with Cron.SetTimezone().
 func New() *Cron {
 	return &Cron{
-		interval:   1 * time.Minute,
-		timezone:   time.UTC,
-		jobs:       []*Job{},
-		tickerDone: make(chan bool),
+		interval: 1 * time.Minute,
+		timezone: time.UTC,
+		jobs:     []*Job{},
 	}
 }
 
@@ -64,6 +91,26 @@ func (c *Cron) SetTimezone(l *time.Location) {
 	c.timezone = l
 }
 
+// SetPanicHandler registers a handler that will be called when
+// a scheduled job panics (set it to nil to remove the current one).
+//
+// If there is no handler, the job panics are not recovered.
+func (c *Cron) SetPanicHandler(handler PanicHandler) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.panicHandler = handler
+}
+
+// SetRunWrapper registers a function that will wrap
+// the execution of the scheduled jobs (set it to nil to remove the current one).
+func (c *Cron) SetRunWrapper(wrapper RunWrapper) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.runWrapper = wrapper
+}
+
 // MustAdd is similar to Add() but panic on failure.
 func (c *Cron) MustAdd(jobId string, cronExpr string, run func()) {
 	if err := c.Add(jobId, cronExpr, run); err != nil {
@@ -79,6 +126,29 @@ func (c *Cron) MustAdd(jobId string, cronExpr string, run func()) {
 // cronExpr is a regular cron expression, eg. "0 */3 * * *" (aka. at minute 0 past every 3rd hour).
 // Check cron.NewSchedule() for the supported tokens.
 func (c *Cron) Add(jobId string, cronExpr string, fn func()) error {
+	return c.AddWithOptions(jobId, cronExpr, JobOptions{}, fn)
+}
+
+// AddWithOptions is similar to Add() but allows specifying
+// per job settings like timezone, random jitter, timeout, retries and overlap policy.
+//
+// Note that the jobs with seconds granularity (6 segments cron expression)
+// will make the cron ticker run every second.
+func (c *Cron) AddWithOptions(jobId string, cronExpr string, options JobOptions, fn func()) error {
+	if fn == nil {
+		return errors.New("failed to add new cron job: fn must be non-nil function")
+	}
+
+	return c.AddWithContext(jobId, cronExpr, options, func(ctx context.Context) error {
+		fn()
+		return nil
+	})
+}
+
+// AddWithContext is similar to AddWithOptions() but registers a job
+// function that accepts a context (canceled on options.Timeout) and
+// returns an error (the failed runs are retried based on options.Retries).
+func (c *Cron) AddWithContext(jobId string, cronExpr string, options JobOptions, fn func(ctx context.Context) error) error {
 	if fn == nil {
 		return errors.New("failed to add new cron job: fn must be non-nil function")
 	}
@@ -88,8 +158,11 @@ func (c *Cron) Add(jobId string, cronExpr string, fn func()) error {
 		return fmt.Errorf("failed to add new cron job: %w", err)
 	}
 
+	if err := options.Validate(); err != nil {
+		return fmt.Errorf("failed to add new cron job: %w", err)
+	}
+
 	c.mux.Lock()
-	defer c.mux.Unlock()
 
 	// remove previous (if any)
 	c.jobs = slices.DeleteFunc(c.jobs, func(j *Job) bool {
@@ -101,11 +174,45 @@ func (c *Cron) Add(jobId string, cronExpr string, fn func()) error {
 		id:       jobId,
 		fn:       fn,
 		schedule: schedule,
+		options:  options,
+		running:  make(chan struct{}, 1),
 	})
 
+	// restart the ticker to switch to seconds granularity
+	restart := (c.ticker != nil || c.startTimer != nil) && c.tickInterval > time.Second && schedule.HasSeconds()
+
+	c.mux.Unlock()
+
+	if restart {
+		c.Start()
+	}
+
 	return nil
 }
 
+// After schedules fn to be executed once after the specified duration
+// (its panics are recovered and reported to the registered [PanicHandler], if any).
+//
+// The returned timer could be used to cancel the execution with timer.Stop().
+//
+// Note that the one-off tasks are kept only in memory and they are
+// not affected by Stop() and Start(). For delayed tasks that should survive
+// the process restarts use a persistent queue instead (e.g. app.ScheduleAt()).
+func (c *Cron) After(d time.Duration, fn func()) *time.Timer {
+	c.mux.RLock()
+	panicHandler := c.panicHandler
+	c.mux.RUnlock()
+
+	j := &Job{id: AfterJobId, fn: func(ctx context.Context) error {
+		fn()
+		return nil
+	}}
+
+	return time.AfterFunc(d, func() {
+		runJob(j, time.Time{}, panicHandler, nil)
+	})
+}
+
 // Remove removes a single cron job by its id.
 func (c *Cron) Remove(jobId string) {
 	c.mux.Lock()
@@ -161,11 +268,18 @@ func (c *Cron) Stop() {
 		c.startTimer = nil
 	}
 
+	// note: closed instead of sending a value to avoid blocking
+	// in case the ticker loop is not started yet or it is waiting
+	// for the mutex to process a tick
+	if c.tickerDone != nil {
+		close(c.tickerDone)
+		c.tickerDone = nil
+	}
+
 	if c.ticker == nil {
 		return // already stopped
 	}
 
-	c.tickerDone <- true
 	c.ticker.Stop()
 	c.ticker = nil
 }
@@ -176,33 +290,56 @@ func (c *Cron) Stop() {
 func (c *Cron) Start() {
 	c.Stop()
 
-	// delay the ticker to start at 00 of 1 c.interval duration
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	// tick every second if there are jobs with seconds granularity
+	c.tickInterval = c.interval
+	if c.tickInterval > time.Second && slices.ContainsFunc(c.jobs, func(j *Job) bool {
+		return j.schedule.HasSeconds()
+	}) {
+		c.tickInterval = time.Second
+	}
+
+	// delay the ticker to start at 00 of 1 c.tickInterval duration
 	now := time.Now()
-	next := now.Add(c.interval).Truncate(c.interval)
+	next := now.Add(c.tickInterval).Truncate(c.tickInterval)
 	delay := next.Sub(now)
 
-	c.mux.Lock()
+	// the current interval slot is considered as already processed
+	c.lastSlot = now.Truncate(c.interval)
+	c.lastSecond = now.Truncate(time.Second)
+
+	done := make(chan struct{})
+	c.tickerDone = done
+
 	c.startTimer = time.AfterFunc(delay, func() {
 		c.mux.Lock()
-		c.ticker = time.NewTicker(c.interval)
+		select {
+		case <-done:
+			c.mux.Unlock()
+			return // stopped before the timer callback acquired the lock
+		default:
+		}
+		ticker := time.NewTicker(c.tickInterval)
+		c.ticker = ticker
 		c.mux.Unlock()
 
 		// run immediately at 00
-		c.runDue(time.Now())
+		c.tick(time.Now())
 
 		// run after each tick
 		go func() {
 			for {
 				select {
-				case <-c.tickerDone:
+				case <-done:
 					return
-				case t := <-c.ticker.C:
-					c.runDue(t)
+				case t := <-ticker.C:
+					c.tick(t)
 				}
 			}
 		}()
 	})
-	c.mux.Unlock()
 }
 
 // HasStarted checks whether the current Cron ticker has been started.
@@ -213,16 +350,83 @@ func (c *Cron) HasStarted() bool {
 	return c.ticker != nil
 }
 
+// tick runs the registered jobs that are scheduled for the provided ticker time
+// ensuring that each interval slot (or second for the jobs with seconds granularity)
+// is processed only once.
+func (c *Cron) tick(t time.Time) {
+	c.mux.Lock()
+
+	slot := t.Truncate(c.interval)
+	newSlot := slot.After(c.lastSlot)
+	if newSlot {
+		c.lastSlot = slot
+	}
+
+	second := t.Truncate(time.Second)
+	newSecond := second.After(c.lastSecond)
+	if newSecond {
+		c.lastSecond = second
+	}
+
+	c.mux.Unlock()
+
+	c.runDueJobs(t, newSlot, newSecond)
+}
+
 // runDue runs all registered jobs that are scheduled for the provided time.
 func (c *Cron) runDue(t time.Time) {
+	c.runDueJobs(t, true, true)
+}
+
+// runDueJobs runs the registered jobs that are scheduled for the provided time.
+//
+// regular and withSeconds specify whether to run the jobs
+// without and with seconds granularity respectively.
+func (c *Cron) runDueJobs(t time.Time, regular bool, withSeconds bool) {
 	c.mux.RLock()
 	defer c.mux.RUnlock()
 
-	moment := NewMoment(t.In(c.timezone))
-
 	for _, j := range c.jobs {
-		if j.schedule.IsDue(moment) {
-			go j.Run()
+		var scheduled time.Time
+		if j.schedule.HasSeconds() {
+			if !withSeconds {
+				continue
+			}
+			scheduled = t.Truncate(min(c.interval, time.Second))
+		} else {
+			if !regular {
+				continue
+			}
+			scheduled = t.Truncate(c.interval)
+		}
+
+		timezone := c.timezone
+		if tz := j.Timezone(); tz != nil {
+			timezone = tz
 		}
+
+		if j.schedule.IsDue(NewMoment(t.In(timezone))) {
+			go runJob(j, scheduled, c.panicHandler, c.runWrapper)
+		}
+	}
+}
+
+// runJob runs the provided job (optionally through the runWrapper)
+// applying its options and recovering from its panic (if any) when panicHandler is set.
+//
+// The job execution is delayed with a random duration up to the job jitter (if any).
+func runJob(j *Job, scheduled time.Time, panicHandler PanicHandler, runWrapper RunWrapper) {
+	if jitter := j.Jitter(); jitter > 0 {
+		time.Sleep(rand.N(jitter))
+	}
+
+	run := func() RunResult {
+		return j.runScheduled(panicHandler)
+	}
+
+	if runWrapper != nil {
+		runWrapper(j, scheduled, run)
+	} else {
+		run()
 	}
 }
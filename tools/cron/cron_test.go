@@ -60,6 +60,115 @@ func TestCronSetTimezone(t *testing.T) {
 	}
 }
 
+func TestCronSetPanicHandler(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+
+	type panicInfo struct {
+		jobId     string
+		recovered any
+		stack     []byte
+	}
+
+	ch := make(chan panicInfo, 1)
+
+	c.SetPanicHandler(func(job *Job, recovered any, stack []byte) {
+		ch <- panicInfo{job.Id(), recovered, stack}
+	})
+
+	c.MustAdd("test", "* * * * *", func() {
+		panic("test_panic")
+	})
+
+	c.runDue(time.Now())
+
+	select {
+	case info := <-ch:
+		if info.jobId != "test" {
+			t.Fatalf("Expected job id %q, got %q", "test", info.jobId)
+		}
+		if info.recovered != "test_panic" {
+			t.Fatalf("Expected recovered value %q, got %v", "test_panic", info.recovered)
+		}
+		if len(info.stack) == 0 {
+			t.Fatal("Expected non-empty stack")
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("Expected the panic handler to be called")
+	}
+
+	// remove
+	c.SetPanicHandler(nil)
+	if c.panicHandler != nil {
+		t.Fatal("Expected the panic handler to be removed")
+	}
+}
+
+func TestCronSetRunWrapper(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+
+	c.SetPanicHandler(func(job *Job, recovered any, stack []byte) {})
+
+	type runInfo struct {
+		jobId     string
+		scheduled time.Time
+		err       error
+	}
+
+	ch := make(chan runInfo, 2)
+
+	c.SetRunWrapper(func(job *Job, scheduled time.Time, run func() RunResult) {
+		if job.Id() == "skip" {
+			ch <- runInfo{job.Id(), scheduled, nil}
+			return
+		}
+		ch <- runInfo{job.Id(), scheduled, run().Error}
+	})
+
+	c.MustAdd("panic", "* * * * *", func() {
+		panic("test_panic")
+	})
+
+	c.MustAdd("skip", "* * * * *", func() {
+		t.Error("Expected the skip job to not run")
+	})
+
+	tick := time.Date(2025, 1, 1, 10, 30, 15, 0, time.UTC)
+	expectedScheduled := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	c.runDue(tick)
+
+	results := map[string]error{}
+	for range 2 {
+		select {
+		case info := <-ch:
+			if !info.scheduled.Equal(expectedScheduled) {
+				t.Errorf("[%s] Expected scheduled time %v, got %v", info.jobId, expectedScheduled, info.scheduled)
+			}
+			results[info.jobId] = info.err
+		case <-time.After(1 * time.Second):
+			t.Fatal("Expected the run wrapper to be called")
+		}
+	}
+
+	if err, ok := results["panic"]; !ok || err == nil || err.Error() != "panic: test_panic" {
+		t.Fatalf("Expected panic job error, got %v", err)
+	}
+
+	if err, ok := results["skip"]; !ok || err != nil {
+		t.Fatalf("Expected skipped job without error, got %v", err)
+	}
+
+	// remove
+	c.SetRunWrapper(nil)
+	if c.runWrapper != nil {
+		t.Fatal("Expected the run wrapper to be removed")
+	}
+}
+
 func TestCronAddAndRemove(t *testing.T) {
 	t.Parallel()
 
@@ -140,6 +249,176 @@ func TestCronAddAndRemove(t *testing.T) {
 	}
 }
 
+func TestCronAddWithOptions(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+
+	if err := c.AddWithOptions("test0", "* * * * *", JobOptions{Jitter: -1}, func() {}); err == nil {
+		t.Fatal("Expected negative jitter error")
+	}
+
+	timezone, _ := time.LoadLocation("Asia/Tokyo")
+
+	options := JobOptions{Timezone: timezone, Jitter: 5 * time.Second}
+
+	if err := c.AddWithOptions("test1", "*/10 * * * * *", options, func() {}); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs := c.Jobs()
+	if len(jobs) != 1 {
+		t.Fatalf("Expected 1 job, got %d", len(jobs))
+	}
+
+	if jobs[0].Timezone() != timezone {
+		t.Fatalf("Expected timezone %v, got %v", timezone, jobs[0].Timezone())
+	}
+
+	if jobs[0].Jitter() != options.Jitter {
+		t.Fatalf("Expected jitter %v, got %v", options.Jitter, jobs[0].Jitter())
+	}
+}
+
+func TestCronJobTimezone(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+
+	timezone, _ := time.LoadLocation("Asia/Tokyo") // UTC+9
+
+	ch := make(chan string, 2)
+
+	c.MustAdd("utc", "0 10 * * *", func() { ch <- "utc" })
+	c.AddWithOptions("tokyo", "0 19 * * *", JobOptions{Timezone: timezone}, func() { ch <- "tokyo" })
+
+	c.runDue(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC))
+
+	results := map[string]bool{}
+	for range 2 {
+		select {
+		case id := <-ch:
+			results[id] = true
+		case <-time.After(1 * time.Second):
+			t.Fatalf("Expected both jobs to run, got %v", results)
+		}
+	}
+}
+
+func TestCronTick(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+
+	track := func(id string) func() {
+		return func() {
+			mu.Lock()
+			calls[id]++
+			mu.Unlock()
+		}
+	}
+
+	c.MustAdd("regular", "* * * * *", track("regular"))
+	c.MustAdd("seconds", "*/2 * * * * *", track("seconds"))
+
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	c.lastSlot = base.Add(-1 * time.Minute)
+	c.lastSecond = base.Add(-1 * time.Second)
+
+	ticks := []time.Time{
+		base,                             // regular, seconds
+		base.Add(100 * time.Millisecond), // same second
+		base.Add(1 * time.Second),        // not due
+		base.Add(2 * time.Second),        // seconds
+		base.Add(2 * time.Second),        // same second
+		base.Add(1 * time.Minute),        // regular, seconds
+	}
+	for _, tick := range ticks {
+		c.tick(tick)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if calls["regular"] != 2 {
+		t.Fatalf("Expected 2 regular calls, got %d", calls["regular"])
+	}
+
+	if calls["seconds"] != 3 {
+		t.Fatalf("Expected 3 seconds calls, got %d", calls["seconds"])
+	}
+}
+
+func TestCronStartWithSeconds(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+
+	c.Start()
+	defer c.Stop()
+
+	if c.tickInterval != c.interval {
+		t.Fatalf("Expected tick interval %v, got %v", c.interval, c.tickInterval)
+	}
+
+	// should restart the ticker with seconds granularity
+	c.MustAdd("test", "* * * * * *", func() {})
+
+	c.mux.RLock()
+	tickInterval := c.tickInterval
+	c.mux.RUnlock()
+
+	if tickInterval != time.Second {
+		t.Fatalf("Expected tick interval %v, got %v", time.Second, tickInterval)
+	}
+}
+
+func TestCronAfter(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+
+	panicked := make(chan string, 1)
+	c.SetPanicHandler(func(job *Job, recovered any, stack []byte) {
+		panicked <- job.Id()
+	})
+
+	calls := make(chan struct{}, 2)
+
+	c.After(10*time.Millisecond, func() {
+		calls <- struct{}{}
+	})
+
+	canceled := c.After(10*time.Millisecond, func() {
+		calls <- struct{}{}
+	})
+	canceled.Stop()
+
+	c.After(10*time.Millisecond, func() {
+		panic("test_panic")
+	})
+
+	select {
+	case id := <-panicked:
+		if id != AfterJobId {
+			t.Fatalf("Expected panic job id %q, got %q", AfterJobId, id)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("Expected the panic handler to be called")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if total := len(calls); total != 1 {
+		t.Fatalf("Expected 1 call, got %d", total)
+	}
+}
+
 func TestCronMustAdd(t *testing.T) {
 	t.Parallel()
 
@@ -314,3 +593,37 @@ func TestCronStartStop(t *testing.T) {
 	}
 	mu.Unlock()
 }
+
+func TestCronStopDuringStart(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+	c.SetInterval(time.Millisecond)
+	c.MustAdd("test", "* * * * *", func() {})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		// stop the cron at various stages of the start timer callback
+		for i := 0; i < 300; i++ {
+			c.Start()
+			time.Sleep(time.Duration(i%3) * time.Millisecond)
+			c.Stop()
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Start/Stop deadlock")
+	}
+
+	// wait for eventual pending start timer callbacks
+	time.Sleep(10 * time.Millisecond)
+
+	if c.HasStarted() {
+		t.Fatal("Expected the cron to be stopped")
+	}
+}
@@ -1,11 +1,97 @@
 package cron
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// The supported JobOptions.Overlap policies.
+const (
+	// OverlapParallel allows a new scheduled run to start
+	// while the previous one is still running (default).
+	OverlapParallel = "parallel"
+
+	// OverlapSkip skips the scheduled run if the previous one is still running.
+	OverlapSkip = "skip"
+
+	// OverlapQueue delays the scheduled run until the previous one completes.
+	OverlapQueue = "queue"
+)
+
+// ErrRunSkipped is the error of a scheduled run skipped due to the [OverlapSkip] policy.
+var ErrRunSkipped = errors.New("skipped - the previous run is still in progress")
+
+// RunResult describes the outcome of a single scheduled job run.
+type RunResult struct {
+	// Error is the error of the last run attempt
+	// (e.g. the job error, recovered panic, timeout or [ErrRunSkipped]).
+	Error error
+
+	// Attempts is the number of the executed run attempts
+	// (0 if the run was skipped).
+	Attempts int
+}
+
+// JobOptions defines the optional per job settings.
+type JobOptions struct {
+	// Timezone is the timezone used to evaluate the job schedule
+	// (if not set, it will fallback to the Cron timezone).
+	Timezone *time.Location
+
+	// Jitter is the max random delay applied before each scheduled job run
+	// (e.g. to prevent multiple instances or jobs from running at the exact same moment).
+	Jitter time.Duration
+
+	// Timeout is the max duration of a single run attempt after which
+	// the job context is canceled and the attempt is considered failed.
+	//
+	// Note that the job function is not forcefully terminated and
+	// it is expected to handle the context cancellation itself.
+	Timeout time.Duration
+
+	// Retries is the number of times that a failed scheduled run
+	// (error, panic or timeout) will be retried.
+	Retries int
+
+	// RetryBackoff is the delay before the first retry which
+	// is doubled after each subsequent failed attempt.
+	RetryBackoff time.Duration
+
+	// Overlap specifies the behavior when a new scheduled run is due
+	// while the previous one is still running
+	// ([OverlapParallel] (default), [OverlapSkip] or [OverlapQueue]).
+	Overlap string
+}
+
+// Validate checks whether the job options are valid.
+func (o JobOptions) Validate() error {
+	if o.Jitter < 0 || o.Timeout < 0 || o.RetryBackoff < 0 {
+		return errors.New("jitter, timeout and retry backoff must be non-negative durations")
+	}
+
+	if o.Retries < 0 {
+		return errors.New("retries must be non-negative number")
+	}
+
+	switch o.Overlap {
+	case "", OverlapParallel, OverlapSkip, OverlapQueue:
+	default:
+		return fmt.Errorf("invalid overlap policy %q", o.Overlap)
+	}
+
+	return nil
+}
 
 // Job defines a single registered cron job.
 type Job struct {
-	fn       func()
+	fn       func(ctx context.Context) error
 	schedule *Schedule
+	running  chan struct{} // used as semaphore for the overlap policies
+	options  JobOptions
 	id       string
 }
 
@@ -19,13 +105,114 @@ func (j *Job) Expression() string {
 	return j.schedule.rawExpr
 }
 
-// Run runs the cron job function.
+// Timezone returns the job specific schedule timezone
+// (nil if the job uses the Cron timezone).
+func (j *Job) Timezone() *time.Location {
+	return j.options.Timezone
+}
+
+// Jitter returns the max random delay applied before each scheduled job run.
+func (j *Job) Jitter() time.Duration {
+	return j.options.Jitter
+}
+
+// Options returns the job options.
+func (j *Job) Options() JobOptions {
+	return j.options
+}
+
+// Run runs the cron job function (without applying the job options).
 func (j *Job) Run() {
-	if j.fn != nil {
-		j.fn()
+	j.RunContext(context.Background())
+}
+
+// RunContext runs the cron job function with the specified context
+// (without applying the job options).
+func (j *Job) RunContext(ctx context.Context) error {
+	if j.fn == nil {
+		return nil
+	}
+
+	return j.fn(ctx)
+}
+
+// runScheduled executes a scheduled job run applying
+// the job overlap, timeout and retry options.
+//
+// The panics are recovered and reported to panicHandler (if set)
+// otherwise they are propagated.
+func (j *Job) runScheduled(panicHandler PanicHandler) RunResult {
+	switch j.options.Overlap {
+	case OverlapSkip:
+		select {
+		case j.running <- struct{}{}:
+		default:
+			return RunResult{Error: ErrRunSkipped}
+		}
+		defer func() { <-j.running }()
+	case OverlapQueue:
+		j.running <- struct{}{}
+		defer func() { <-j.running }()
+	}
+
+	var result RunResult
+
+	backoff := j.options.RetryBackoff
+
+	for {
+		result.Attempts++
+
+		result.Error = j.runAttempt(panicHandler)
+		if result.Error == nil || result.Attempts > j.options.Retries {
+			return result
+		}
+
+		if backoff > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
 	}
 }
 
+// runAttempt executes a single job run attempt.
+func (j *Job) runAttempt(panicHandler PanicHandler) error {
+	ctx := context.Background()
+
+	if j.options.Timeout <= 0 {
+		return j.runRecover(ctx, panicHandler)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, j.options.Timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- j.runRecover(ctx, panicHandler)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timeout - the job didn't complete in %s", j.options.Timeout)
+	}
+}
+
+// runRecover runs the job function and recovers from its panic (if panicHandler is set).
+func (j *Job) runRecover(ctx context.Context, panicHandler PanicHandler) (err error) {
+	if panicHandler != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+				panicHandler(j, r, debug.Stack())
+			}
+		}()
+	}
+
+	return j.RunContext(ctx)
+}
+
 // MarshalJSON implements [json.Marshaler] and export the current
 // jobs data into valid JSON.
 func (j Job) MarshalJSON() ([]byte, error) {
@@ -1,8 +1,13 @@
 package cron
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestJobId(t *testing.T) {
@@ -40,7 +45,7 @@ func TestJobRun(t *testing.T) {
 	calls := ""
 
 	j1 := Job{}
-	j2 := Job{fn: func() { calls += "2" }}
+	j2 := Job{fn: func(ctx context.Context) error { calls += "2"; return nil }}
 
 	j1.Run()
 	j2.Run()
@@ -69,3 +74,158 @@ func TestJobMarshalJSON(t *testing.T) {
 		t.Fatalf("Expected\n%s\ngot\n%s", expected, str)
 	}
 }
+
+func TestJobOptionsValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		options     JobOptions
+		expectError bool
+	}{
+		{"zero", JobOptions{}, false},
+		{"valid", JobOptions{Jitter: 1, Timeout: 1, Retries: 1, RetryBackoff: 1, Overlap: OverlapQueue}, false},
+		{"negative jitter", JobOptions{Jitter: -1}, true},
+		{"negative timeout", JobOptions{Timeout: -1}, true},
+		{"negative retries", JobOptions{Retries: -1}, true},
+		{"negative backoff", JobOptions{RetryBackoff: -1}, true},
+		{"invalid overlap", JobOptions{Overlap: "invalid"}, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.options.Validate()
+
+			if hasErr := err != nil; hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestJobRunScheduledRetries(t *testing.T) {
+	var calls int
+
+	j := &Job{
+		options: JobOptions{Retries: 2, RetryBackoff: time.Millisecond},
+		fn: func(ctx context.Context) error {
+			calls++
+			if calls == 2 {
+				panic("test_panic")
+			}
+			return errors.New("test_error")
+		},
+	}
+
+	var panics int
+	result := j.runScheduled(func(job *Job, recovered any, stack []byte) {
+		panics++
+	})
+
+	if result.Attempts != 3 || calls != 3 {
+		t.Fatalf("Expected 3 attempts, got %d (calls %d)", result.Attempts, calls)
+	}
+
+	if panics != 1 {
+		t.Fatalf("Expected 1 reported panic, got %d", panics)
+	}
+
+	if result.Error == nil || result.Error.Error() != "test_error" {
+		t.Fatalf("Expected the last attempt error, got %v", result.Error)
+	}
+
+	// success on the first attempt
+	calls = 0
+	j.fn = func(ctx context.Context) error {
+		calls++
+		return nil
+	}
+
+	result = j.runScheduled(nil)
+	if result.Attempts != 1 || result.Error != nil || calls != 1 {
+		t.Fatalf("Expected 1 successful attempt, got %#v (calls %d)", result, calls)
+	}
+}
+
+func TestJobRunScheduledTimeout(t *testing.T) {
+	canceled := make(chan struct{})
+
+	j := &Job{
+		options: JobOptions{Timeout: 10 * time.Millisecond},
+		fn: func(ctx context.Context) error {
+			<-ctx.Done()
+			close(canceled)
+			return ctx.Err()
+		},
+	}
+
+	result := j.runScheduled(nil)
+	if result.Error == nil || !strings.Contains(result.Error.Error(), "timeout") {
+		t.Fatalf("Expected timeout error, got %v", result.Error)
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(1 * time.Second):
+		t.Fatal("Expected the job context to be canceled")
+	}
+}
+
+func TestJobRunScheduledOverlap(t *testing.T) {
+	scenarios := []struct {
+		overlap         string
+		expectedSkipped int
+		expectedMax     int32
+	}{
+		{OverlapParallel, 0, 3},
+		{OverlapSkip, 2, 1},
+		{OverlapQueue, 0, 1},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.overlap, func(t *testing.T) {
+			var current, maxConcurrent atomic.Int32
+
+			j := &Job{
+				running: make(chan struct{}, 1),
+				options: JobOptions{Overlap: s.overlap},
+				fn: func(ctx context.Context) error {
+					v := current.Add(1)
+					for {
+						m := maxConcurrent.Load()
+						if v <= m || maxConcurrent.CompareAndSwap(m, v) {
+							break
+						}
+					}
+					time.Sleep(100 * time.Millisecond)
+					current.Add(-1)
+					return nil
+				},
+			}
+
+			results := make(chan RunResult, 3)
+			for range 3 {
+				go func() {
+					results <- j.runScheduled(nil)
+				}()
+			}
+
+			var skipped int
+			for range 3 {
+				r := <-results
+				if errors.Is(r.Error, ErrRunSkipped) {
+					if r.Attempts != 0 {
+						t.Fatalf("Expected 0 attempts for the skipped run, got %d", r.Attempts)
+					}
+					skipped++
+				}
+			}
+
+			if skipped != s.expectedSkipped {
+				t.Fatalf("Expected %d skipped runs, got %d", s.expectedSkipped, skipped)
+			}
+
+			if v := maxConcurrent.Load(); v != s.expectedMax {
+				t.Fatalf("Expected max %d concurrent runs, got %d", s.expectedMax, v)
+			}
+		})
+	}
+}
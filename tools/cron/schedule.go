@@ -10,6 +10,7 @@ import (
 
 // Moment represents a parsed single time moment.
 type Moment struct {
+	Second    int `json:"second"`
 	Minute    int `json:"minute"`
 	Hour      int `json:"hour"`
 	Day       int `json:"day"`
@@ -20,6 +21,7 @@ type Moment struct {
 // NewMoment creates a new Moment from the specified time.
 func NewMoment(t time.Time) *Moment {
 	return &Moment{
+		Second:    t.Second(),
 		Minute:    t.Minute(),
 		Hour:      t.Hour(),
 		Day:       t.Day(),
@@ -30,6 +32,9 @@ func NewMoment(t time.Time) *Moment {
 
 // Schedule stores parsed information for each time component when a cron job should run.
 type Schedule struct {
+	// Seconds is nil for the regular 5 segments expressions
+	// (aka. the schedule is due for every second of the matching minute).
+	Seconds    map[int]struct{} `json:"seconds,omitempty"`
 	Minutes    map[int]struct{} `json:"minutes"`
 	Hours      map[int]struct{} `json:"hours"`
 	Days       map[int]struct{} `json:"days"`
@@ -39,8 +44,20 @@ type Schedule struct {
 	rawExpr string
 }
 
+// HasSeconds reports whether the current Schedule has seconds granularity
+// (aka. it was created from a 6 segments cron expression).
+func (s *Schedule) HasSeconds() bool {
+	return s.Seconds != nil
+}
+
 // IsDue checks whether the provided Moment satisfies the current Schedule.
 func (s *Schedule) IsDue(m *Moment) bool {
+	if s.Seconds != nil {
+		if _, ok := s.Seconds[m.Second]; !ok {
+			return false
+		}
+	}
+
 	if _, ok := s.Minutes[m.Minute]; !ok {
 		return false
 	}
@@ -79,6 +96,9 @@ var macros = map[string]string{
 // A cron expression could be a macro OR 5 segments separated by space,
 // representing: minute, hour, day of the month, month and day of the week.
 //
+// Optionally, a 6 segments expression could be used for second-level granularity,
+// where the first segment represents the second (e.g. "*/15 * * * * *" - every 15 seconds).
+//
 // The following segment formats are supported:
 //   - wildcard: *
 //   - range:    1-30
@@ -97,8 +117,18 @@ func NewSchedule(cronExpr string) (*Schedule, error) {
 	}
 
 	segments := strings.Split(cronExpr, " ")
-	if len(segments) != 5 {
-		return nil, errors.New("invalid cron expression - must be a valid macro or to have exactly 5 space separated segments")
+	if len(segments) != 5 && len(segments) != 6 {
+		return nil, errors.New("invalid cron expression - must be a valid macro or to have exactly 5 (or 6 with seconds) space separated segments")
+	}
+
+	var seconds map[int]struct{}
+	if len(segments) == 6 {
+		var err error
+		seconds, err = parseCronSegment(segments[0], 0, 59)
+		if err != nil {
+			return nil, err
+		}
+		segments = segments[1:]
 	}
 
 	minutes, err := parseCronSegment(segments[0], 0, 59)
@@ -127,6 +157,7 @@ func NewSchedule(cronExpr string) (*Schedule, error) {
 	}
 
 	return &Schedule{
+		Seconds:    seconds,
 		Minutes:    minutes,
 		Hours:      hours,
 		Days:       days,
@@ -19,6 +19,10 @@ func TestNewMoment(t *testing.T) {
 
 	m := cron.NewMoment(date)
 
+	if m.Second != 0 {
+		t.Fatalf("Expected m.Second %d, got %d", 0, m.Second)
+	}
+
 	if m.Minute != 20 {
 		t.Fatalf("Expected m.Minute %d, got %d", 20, m.Minute)
 	}
@@ -59,10 +63,20 @@ func TestNewSchedule(t *testing.T) {
 			"",
 		},
 		{
-			"* * * * * *",
+			"* * * * * * *",
+			true,
+			"",
+		},
+		{
+			"60 * * * * *",
 			true,
 			"",
 		},
+		{
+			"*/20 5 3 2 1 0",
+			false,
+			`{"seconds":{"0":{},"20":{},"40":{}},"minutes":{"5":{}},"hours":{"3":{}},"days":{"2":{}},"months":{"1":{}},"daysOfWeek":{"0":{}}}`,
+		},
 		{
 			"2/3 * * * *",
 			true,
@@ -390,6 +404,30 @@ func TestScheduleIsDue(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"*/15 * * * * *",
+			&cron.Moment{
+				Second:    10,
+				Minute:    1,
+				Hour:      1,
+				Day:       1,
+				Month:     1,
+				DayOfWeek: 1,
+			},
+			false,
+		},
+		{
+			"*/15 * * * * *",
+			&cron.Moment{
+				Second:    45,
+				Minute:    1,
+				Hour:      1,
+				Day:       1,
+				Month:     1,
+				DayOfWeek: 1,
+			},
+			true,
+		},
 	}
 
 	for i, s := range scenarios {
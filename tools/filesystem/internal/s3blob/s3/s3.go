@@ -21,26 +21,20 @@
 package s3
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"slices"
 	"strings"
-	"time"
+
+	"github.com/pocketbase/pocketbase/tools/internal/sigv4"
 )
 
 const (
-	awsS3ServiceCode     = "s3"
-	awsSignAlgorithm     = "AWS4-HMAC-SHA256"
-	awsTerminationString = "aws4_request"
-	metadataPrefix       = "x-amz-meta-"
-	dateTimeFormat       = "20060102T150405Z"
+	awsS3ServiceCode = "s3"
+	metadataPrefix   = "x-amz-meta-"
 )
 
 type HTTPClient interface {
@@ -84,7 +78,7 @@ func (s3 *S3) URL(path string) string {
 	if err != nil {
 		// truly rare case, keep the path as it is
 	} else {
-		path = escapePath(parsed.Path)
+		path = sigv4.EscapePath(parsed.Path)
 
 		// the rest is usually not expected to be part of the S3 path but it is kept to avoid surprises
 		// (it will be further escaped if necessery by the Go HTTP client)
@@ -111,7 +105,13 @@ func (s3 *S3) URL(path string) string {
 //
 // Note: Don't forget to call resp.Body.Close() after done with the result.
 func (s3 *S3) SignAndSend(req *http.Request) (*http.Response, error) {
-	s3.sign(req)
+	signer := &sigv4.Signer{
+		Region:    s3.Region,
+		Service:   awsS3ServiceCode,
+		AccessKey: s3.AccessKey,
+		SecretKey: s3.SecretKey,
+	}
+	signer.Sign(req)
 
 	client := s3.Client
 	if client == nil {
@@ -148,136 +148,6 @@ func (s3 *S3) SignAndSend(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
-// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html#create-signed-request-steps
-func (s3 *S3) sign(req *http.Request) {
-	// fallback to the Unsigned payload option
-	// (data integrity checks could be still applied via the content-md5 or x-amz-checksum-* headers)
-	if req.Header.Get("x-amz-content-sha256") == "" {
-		req.Header.Set("x-amz-content-sha256", "UNSIGNED-PAYLOAD")
-	}
-
-	reqDateTime, _ := time.Parse(dateTimeFormat, req.Header.Get("x-amz-date"))
-	if reqDateTime.IsZero() {
-		reqDateTime = time.Now().UTC()
-		req.Header.Set("x-amz-date", reqDateTime.Format(dateTimeFormat))
-	}
-
-	req.Header.Set("host", req.URL.Host)
-
-	date := reqDateTime.Format("20060102")
-
-	dateTime := reqDateTime.Format(dateTimeFormat)
-
-	// 1. Create canonical request
-	// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html#create-canonical-request
-	// ---------------------------------------------------------------
-	canonicalHeaders, signedHeaders := canonicalAndSignedHeaders(req)
-
-	canonicalParts := []string{
-		req.Method,
-		escapePath(req.URL.Path),
-		escapeQuery(req.URL.Query()),
-		canonicalHeaders,
-		signedHeaders,
-		req.Header.Get("x-amz-content-sha256"),
-	}
-
-	// 2. Create a hash of the canonical request
-	// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html#create-canonical-request-hash
-	// ---------------------------------------------------------------
-	hashedCanonicalRequest := sha256Hex([]byte(strings.Join(canonicalParts, "\n")))
-
-	// 3. Create a string to sign
-	// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html#create-string-to-sign
-	// ---------------------------------------------------------------
-	scope := strings.Join([]string{
-		date,
-		s3.Region,
-		awsS3ServiceCode,
-		awsTerminationString,
-	}, "/")
-
-	stringToSign := strings.Join([]string{
-		awsSignAlgorithm,
-		dateTime,
-		scope,
-		hashedCanonicalRequest,
-	}, "\n")
-
-	// 4. Derive a signing key for SigV4
-	// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html#derive-signing-key
-	// ---------------------------------------------------------------
-	dateKey := hmacSHA256([]byte("AWS4"+s3.SecretKey), date)
-	dateRegionKey := hmacSHA256(dateKey, s3.Region)
-	dateRegionServiceKey := hmacSHA256(dateRegionKey, awsS3ServiceCode)
-	signingKey := hmacSHA256(dateRegionServiceKey, awsTerminationString)
-	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
-
-	// 5. Add the signature to the request
-	// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html#add-signature-to-request
-	authorization := fmt.Sprintf(
-		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
-		awsSignAlgorithm,
-		s3.AccessKey,
-		scope,
-		signedHeaders,
-		signature,
-	)
-
-	req.Header.Set("authorization", authorization)
-}
-
-func sha256Hex(content []byte) string {
-	h := sha256.New()
-	h.Write(content)
-	return hex.EncodeToString(h.Sum(nil))
-}
-
-func hmacSHA256(key []byte, content string) []byte {
-	mac := hmac.New(sha256.New, key)
-	mac.Write([]byte(content))
-	return mac.Sum(nil)
-}
-
-func canonicalAndSignedHeaders(req *http.Request) (string, string) {
-	signed := []string{}
-	canonical := map[string]string{}
-
-	for key, values := range req.Header {
-		normalizedKey := strings.ToLower(key)
-
-		if normalizedKey != "host" &&
-			normalizedKey != "content-type" &&
-			!strings.HasPrefix(normalizedKey, "x-amz-") {
-			continue
-		}
-
-		signed = append(signed, normalizedKey)
-
-		// for each value:
-		// trim any leading or trailing spaces
-		// convert sequential spaces to a single space
-		normalizedValues := make([]string, len(values))
-		for i, v := range values {
-			normalizedValues[i] = strings.ReplaceAll(strings.TrimSpace(v), "  ", " ")
-		}
-
-		canonical[normalizedKey] = strings.Join(normalizedValues, ",")
-	}
-
-	slices.Sort(signed)
-
-	var sortedCanonical strings.Builder
-	for _, key := range signed {
-		sortedCanonical.WriteString(key)
-		sortedCanonical.WriteString(":")
-		sortedCanonical.WriteString(canonical[key])
-		sortedCanonical.WriteString("\n")
-	}
-
-	return sortedCanonical.String(), strings.Join(signed, ";")
-}
-
 // extractMetadata parses and extracts and the metadata from the specified request headers.
 //
 // The metadata keys are all lowercased and without the "x-amz-meta-" prefix.
@@ -299,96 +169,3 @@ func extractMetadata(headers http.Header) map[string]string {
 
 	return result
 }
-
-// escapeQuery returns the URI encoded request query parameters according to the AWS S3 spec requirements
-// (it is similar to url.Values.Encode but instead of url.QueryEscape uses our own escape method).
-func escapeQuery(values url.Values) string {
-	if len(values) == 0 {
-		return ""
-	}
-
-	var buf strings.Builder
-
-	keys := make([]string, 0, len(values))
-	for k := range values {
-		keys = append(keys, k)
-	}
-	slices.Sort(keys)
-
-	for _, k := range keys {
-		vs := values[k]
-		keyEscaped := escape(k)
-		for _, values := range vs {
-			if buf.Len() > 0 {
-				buf.WriteByte('&')
-			}
-			buf.WriteString(keyEscaped)
-			buf.WriteByte('=')
-			buf.WriteString(escape(values))
-		}
-	}
-
-	return buf.String()
-}
-
-// escapePath returns the URI encoded request path according to the AWS S3 spec requirements.
-func escapePath(path string) string {
-	parts := strings.Split(path, "/")
-
-	for i, part := range parts {
-		parts[i] = escape(part)
-	}
-
-	return strings.Join(parts, "/")
-}
-
-const upperhex = "0123456789ABCDEF"
-
-// escape is similar to the std url.escape but implements the AWS [UriEncode requirements]:
-//   - URI encode every byte except the unreserved characters: 'A'-'Z', 'a'-'z', '0'-'9', '-', '.', '_', and '~'.
-//   - The space character is a reserved character and must be encoded as "%20" (and not as "+").
-//   - Each URI encoded byte is formed by a '%' and the two-digit hexadecimal value of the byte.
-//   - Letters in the hexadecimal value must be uppercase, for example "%1A".
-//
-// [UriEncode requirements]: https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html
-func escape(s string) string {
-	hexCount := 0
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if shouldEscape(c) {
-			hexCount++
-		}
-	}
-
-	if hexCount == 0 {
-		return s
-	}
-
-	result := make([]byte, len(s)+2*hexCount)
-
-	j := 0
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if shouldEscape(c) {
-			result[j] = '%'
-			result[j+1] = upperhex[c>>4]
-			result[j+2] = upperhex[c&15]
-			j += 3
-		} else {
-			result[j] = c
-			j++
-		}
-	}
-
-	return string(result)
-}
-
-// > "URI encode every byte except the unreserved characters: 'A'-'Z', 'a'-'z', '0'-'9', '-', '.', '_', and '~'."
-func shouldEscape(c byte) bool {
-	isUnreserved := (c >= 'A' && c <= 'Z') ||
-		(c >= 'a' && c <= 'z') ||
-		(c >= '0' && c <= '9') ||
-		c == '-' || c == '.' || c == '_' || c == '~'
-
-	return !isUnreserved
-}
@@ -0,0 +1,262 @@
+// Package graphql implements a minimal GraphQL query language parser
+// (operations, variables, aliases, arguments, fragments and the
+// @skip/@include directives) without any schema type system.
+package graphql
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The supported operation types.
+const (
+	OperationQuery        = "query"
+	OperationMutation     = "mutation"
+	OperationSubscription = "subscription"
+)
+
+// Document defines a parsed GraphQL executable document.
+type Document struct {
+	Fragments  map[string]*Fragment
+	Operations []*Operation
+}
+
+// Operation finds the document operation to execute.
+//
+// If name is empty, the document must contain only a single operation.
+func (d *Document) Operation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) != 1 {
+			return nil, errors.New("the operation name is required when the document contains multiple operations")
+		}
+
+		return d.Operations[0], nil
+	}
+
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// CollectFields flattens the provided selection set by resolving its
+// fragment spreads and inline fragments and excluding the fields
+// marked with the @skip or @include directives.
+//
+// Note that the fragments type conditions are not checked.
+func (d *Document) CollectFields(selectionSet []Selection, variables map[string]any) ([]*Field, error) {
+	result := []*Field{}
+
+	err := d.collectFields(selectionSet, variables, map[string]struct{}{}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (d *Document) collectFields(selectionSet []Selection, variables map[string]any, visited map[string]struct{}, result *[]*Field) error {
+	for _, selection := range selectionSet {
+		switch s := selection.(type) {
+		case *Field:
+			if isExcluded(s.Directives, variables) {
+				continue
+			}
+
+			*result = append(*result, s)
+		case *InlineFragment:
+			if isExcluded(s.Directives, variables) {
+				continue
+			}
+
+			if err := d.collectFields(s.SelectionSet, variables, visited, result); err != nil {
+				return err
+			}
+		case *FragmentSpread:
+			if isExcluded(s.Directives, variables) {
+				continue
+			}
+
+			if _, ok := visited[s.Name]; ok {
+				return fmt.Errorf("fragment %q cannot be spread within itself", s.Name)
+			}
+
+			fragment, ok := d.Fragments[s.Name]
+			if !ok {
+				return fmt.Errorf("unknown fragment %q", s.Name)
+			}
+
+			visited[s.Name] = struct{}{}
+			err := d.collectFields(fragment.SelectionSet, variables, visited, result)
+			delete(visited, s.Name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isExcluded checks whether the @skip/@include directives exclude the selection.
+func isExcluded(directives []*Directive, variables map[string]any) bool {
+	for _, d := range directives {
+		switch d.Name {
+		case "skip":
+			if v, _ := d.ArgumentValues(variables)["if"].(bool); v {
+				return true
+			}
+		case "include":
+			if v, _ := d.ArgumentValues(variables)["if"].(bool); !v {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Operation defines a single query, mutation or subscription operation.
+type Operation struct {
+	Type         string
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+}
+
+// VariableDefinition defines a single operation variable.
+type VariableDefinition struct {
+	DefaultValue any
+	Name         string
+
+	// Type is the raw variable type (e.g. "[String!]!").
+	Type string
+
+	HasDefault bool
+}
+
+// IsRequired reports whether the variable type is non-null.
+func (v *VariableDefinition) IsRequired() bool {
+	return len(v.Type) > 0 && v.Type[len(v.Type)-1] == '!'
+}
+
+// Fragment defines a named fragment definition.
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+}
+
+// Selection defines a single selection set item
+// (one of *Field, *FragmentSpread or *InlineFragment).
+type Selection interface {
+	isSelection()
+}
+
+// Field defines a single selection set field.
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+}
+
+func (*Field) isSelection() {}
+
+// ResponseKey returns the field alias or its name if no alias is set.
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+
+	return f.Name
+}
+
+// ArgumentValues returns the field arguments resolved with the provided variables.
+func (f *Field) ArgumentValues(variables map[string]any) map[string]any {
+	return argumentValues(f.Arguments, variables)
+}
+
+// FragmentSpread defines a named fragment spread (e.g. "...userFields").
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+func (*FragmentSpread) isSelection() {}
+
+// InlineFragment defines an inline fragment (e.g. "... on User { id }").
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (*InlineFragment) isSelection() {}
+
+// Argument defines a single field or directive argument.
+//
+// Value could be a scalar (string, int64, float64, bool, nil),
+// an [Enum], a [Variable], a []any list or a map[string]any object.
+type Argument struct {
+	Value any
+	Name  string
+}
+
+// Directive defines a single field or fragment directive (e.g. "@skip(if: true)").
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+// ArgumentValues returns the directive arguments resolved with the provided variables.
+func (d *Directive) ArgumentValues(variables map[string]any) map[string]any {
+	return argumentValues(d.Arguments, variables)
+}
+
+// Variable defines a variable reference value (e.g. "$id").
+type Variable string
+
+// Enum defines an enum value (e.g. "ASC").
+type Enum string
+
+// ResolveValue replaces the variable references in the provided
+// argument value with their actual values.
+//
+// Enum values are resolved to their plain string representation.
+func ResolveValue(value any, variables map[string]any) any {
+	switch v := value.(type) {
+	case Variable:
+		return variables[string(v)]
+	case Enum:
+		return string(v)
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = ResolveValue(item, variables)
+		}
+		return result
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			result[k] = ResolveValue(item, variables)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+func argumentValues(args []*Argument, variables map[string]any) map[string]any {
+	result := make(map[string]any, len(args))
+
+	for _, arg := range args {
+		result[arg.Name] = ResolveValue(arg.Value, variables)
+	}
+
+	return result
+}
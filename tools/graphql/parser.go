@@ -0,0 +1,892 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxDepth is the max allowed selection sets and values nesting.
+const MaxDepth = 50
+
+// SyntaxError defines a document parse error.
+type SyntaxError struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+// Error implements the [error] interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error: %s (line %d, column %d)", e.Message, e.Line, e.Column)
+}
+
+// Parse parses the provided GraphQL executable document.
+//
+// Type system definitions (type, schema, etc.) are not supported.
+func Parse(query string) (*Document, error) {
+	p := &parser{lexer: &lexer{src: query}}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Fragments: map[string]*Fragment{}}
+
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.token.is(tokenPunct, "{"):
+			selectionSet, err := p.parseSelectionSet(0)
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{
+				Type:         OperationQuery,
+				SelectionSet: selectionSet,
+			})
+		case p.token.is(tokenName, OperationQuery),
+			p.token.is(tokenName, OperationMutation),
+			p.token.is(tokenName, OperationSubscription):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.token.is(tokenName, "fragment"):
+			fragment, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[fragment.Name]; ok {
+				return nil, fmt.Errorf("duplicated fragment %q", fragment.Name)
+			}
+			doc.Fragments[fragment.Name] = fragment
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, p.errorf("the document must contain at least one operation")
+	}
+
+	names := make(map[string]struct{}, len(doc.Operations))
+	for _, op := range doc.Operations {
+		if op.Name == "" && len(doc.Operations) > 1 {
+			return nil, fmt.Errorf("anonymous operations must be the only defined operation")
+		}
+		if _, ok := names[op.Name]; ok {
+			return nil, fmt.Errorf("duplicated operation %q", op.Name)
+		}
+		names[op.Name] = struct{}{}
+	}
+
+	return doc, nil
+}
+
+type parser struct {
+	lexer *lexer
+	token token
+}
+
+func (p *parser) advance() error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+
+	p.token = t
+
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	line, column := p.lexer.position(p.token.pos)
+
+	return &SyntaxError{
+		Message: fmt.Sprintf(format, args...),
+		Line:    line,
+		Column:  column,
+	}
+}
+
+func (p *parser) unexpected() error {
+	if p.token.kind == tokenEOF {
+		return p.errorf("unexpected end of document")
+	}
+
+	return p.errorf("unexpected %q", p.token.value)
+}
+
+// expect checks whether the current token is the specified punctuator and advances.
+func (p *parser) expect(punct string) error {
+	if !p.token.is(tokenPunct, punct) {
+		if p.token.kind == tokenEOF {
+			return p.errorf("expected %q, got end of document", punct)
+		}
+		return p.errorf("expected %q, got %q", punct, p.token.value)
+	}
+
+	return p.advance()
+}
+
+// skip advances if the current token is the specified punctuator.
+func (p *parser) skip(punct string) (bool, error) {
+	if !p.token.is(tokenPunct, punct) {
+		return false, nil
+	}
+
+	return true, p.advance()
+}
+
+func (p *parser) parseName() (string, error) {
+	if p.token.kind != tokenName {
+		return "", p.unexpected()
+	}
+
+	name := p.token.value
+
+	return name, p.advance()
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Type: p.token.value}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.token.kind == tokenName {
+		op.Name = p.token.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for {
+			if ok, err := p.skip(")"); err != nil {
+				return nil, err
+			} else if ok {
+				break
+			}
+
+			def, err := p.parseVariableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = append(op.Variables, def)
+		}
+	}
+
+	// operation directives are parsed but ignored
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	selectionSet, err := p.parseSelectionSet(0)
+	if err != nil {
+		return nil, err
+	}
+	op.SelectionSet = selectionSet
+
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinition() (*VariableDefinition, error) {
+	if err := p.expect("$"); err != nil {
+		return nil, err
+	}
+
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+
+	typ, err := p.parseType(0)
+	if err != nil {
+		return nil, err
+	}
+
+	def := &VariableDefinition{Name: name, Type: typ}
+
+	if ok, err := p.skip("="); err != nil {
+		return nil, err
+	} else if ok {
+		def.DefaultValue, err = p.parseValue(true, 0)
+		if err != nil {
+			return nil, err
+		}
+		def.HasDefault = true
+	}
+
+	// variable directives are parsed but ignored
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+func (p *parser) parseType(depth int) (string, error) {
+	if depth > MaxDepth {
+		return "", p.errorf("max nesting depth reached")
+	}
+
+	var typ string
+
+	if ok, err := p.skip("["); err != nil {
+		return "", err
+	} else if ok {
+		inner, err := p.parseType(depth + 1)
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else {
+		typ, err = p.parseName()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if ok, err := p.skip("!"); err != nil {
+		return "", err
+	} else if ok {
+		typ += "!"
+	}
+
+	return typ, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	// skip the "fragment" keyword
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.token.is(tokenName, "on") {
+		return nil, p.errorf("missing fragment name")
+	}
+
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.token.is(tokenName, "on") {
+		return nil, p.errorf("expected fragment type condition")
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	typeCondition, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	selectionSet, err := p.parseSelectionSet(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fragment{
+		Name:          name,
+		TypeCondition: typeCondition,
+		SelectionSet:  selectionSet,
+	}, nil
+}
+
+func (p *parser) parseSelectionSet(depth int) ([]Selection, error) {
+	if depth > MaxDepth {
+		return nil, p.errorf("max nesting depth reached")
+	}
+
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	result := []Selection{}
+
+	for {
+		if ok, err := p.skip("}"); err != nil {
+			return nil, err
+		} else if ok {
+			break
+		}
+
+		var selection Selection
+		var err error
+
+		if p.token.is(tokenPunct, "...") {
+			selection, err = p.parseFragmentSelection(depth)
+		} else {
+			selection, err = p.parseField(depth)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, selection)
+	}
+
+	if len(result) == 0 {
+		return nil, p.errorf("empty selection set")
+	}
+
+	return result, nil
+}
+
+func (p *parser) parseFragmentSelection(depth int) (Selection, error) {
+	// skip "..."
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.token.kind == tokenName && p.token.value != "on" {
+		name := p.token.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		directives, err := p.parseDirectives()
+		if err != nil {
+			return nil, err
+		}
+
+		return &FragmentSpread{Name: name, Directives: directives}, nil
+	}
+
+	fragment := &InlineFragment{}
+
+	if p.token.is(tokenName, "on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		typeCondition, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		fragment.TypeCondition = typeCondition
+	}
+
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	fragment.Directives = directives
+
+	fragment.SelectionSet, err = p.parseSelectionSet(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return fragment, nil
+}
+
+func (p *parser) parseField(depth int) (*Field, error) {
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+
+	field := &Field{Name: name}
+
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = name
+		field.Name, err = p.parseName()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	field.Arguments, err = p.parseArguments(false)
+	if err != nil {
+		return nil, err
+	}
+
+	field.Directives, err = p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.token.is(tokenPunct, "{") {
+		field.SelectionSet, err = p.parseSelectionSet(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return field, nil
+}
+
+func (p *parser) parseArguments(isConst bool) ([]*Argument, error) {
+	if ok, err := p.skip("("); err != nil || !ok {
+		return nil, err
+	}
+
+	result := []*Argument{}
+
+	for {
+		if ok, err := p.skip(")"); err != nil {
+			return nil, err
+		} else if ok {
+			break
+		}
+
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseValue(isConst, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, &Argument{Name: name, Value: value})
+	}
+
+	if len(result) == 0 {
+		return nil, p.errorf("empty arguments list")
+	}
+
+	return result, nil
+}
+
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	var result []*Directive
+
+	for p.token.is(tokenPunct, "@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+
+		args, err := p.parseArguments(false)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, &Directive{Name: name, Arguments: args})
+	}
+
+	return result, nil
+}
+
+func (p *parser) parseValue(isConst bool, depth int) (any, error) {
+	if depth > MaxDepth {
+		return nil, p.errorf("max nesting depth reached")
+	}
+
+	t := p.token
+
+	switch t.kind {
+	case tokenInt:
+		v, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid int value %q", t.value)
+		}
+		return v, p.advance()
+	case tokenFloat:
+		v, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, p.errorf("invalid float value %q", t.value)
+		}
+		return v, p.advance()
+	case tokenString:
+		return t.value, p.advance()
+	case tokenName:
+		var v any
+		switch t.value {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		default:
+			v = Enum(t.value)
+		}
+		return v, p.advance()
+	case tokenPunct:
+		switch t.value {
+		case "$":
+			if isConst {
+				return nil, p.errorf("unexpected variable in constant value")
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			return Variable(name), nil
+		case "[":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			list := []any{}
+			for {
+				if ok, err := p.skip("]"); err != nil {
+					return nil, err
+				} else if ok {
+					break
+				}
+				item, err := p.parseValue(isConst, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			}
+			return list, nil
+		case "{":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			obj := map[string]any{}
+			for {
+				if ok, err := p.skip("}"); err != nil {
+					return nil, err
+				} else if ok {
+					break
+				}
+				name, err := p.parseName()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				obj[name], err = p.parseValue(isConst, depth+1)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return obj, nil
+		}
+	}
+
+	return nil, p.unexpected()
+}
+
+// -------------------------------------------------------------------
+
+const (
+	tokenEOF = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	value string
+	kind  int
+	pos   int
+}
+
+func (t token) is(kind int, value string) bool {
+	return t.kind == kind && t.value == value
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+// position returns the 1-based line and column of the specified offset.
+func (l *lexer) position(offset int) (int, int) {
+	offset = min(offset, len(l.src))
+
+	before := l.src[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := utf8.RuneCountInString(before[strings.LastIndexByte(before, '\n')+1:]) + 1
+
+	return line, column
+}
+
+func (l *lexer) errorf(offset int, format string, args ...any) error {
+	line, column := l.position(offset)
+
+	return &SyntaxError{
+		Message: fmt.Sprintf(format, args...),
+		Line:    line,
+		Column:  column,
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+
+	start := l.pos
+
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	c := l.src[l.pos]
+
+	switch {
+	case strings.IndexByte("!$&()=:@[]{}|", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunct, value: string(c), pos: start}, nil
+	case c == '.':
+		if strings.HasPrefix(l.src[l.pos:], "...") {
+			l.pos += 3
+			return token{kind: tokenPunct, value: "...", pos: start}, nil
+		}
+		return token{}, l.errorf(start, "unexpected character %q", c)
+	case isNameStart(c):
+		for l.pos < len(l.src) && isNameContinue(l.src[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], pos: start}, nil
+	case c == '-' || isDigit(c):
+		return l.readNumber()
+	case c == '"':
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			return l.readBlockString()
+		}
+		return l.readString()
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+
+	return token{}, l.errorf(start, "unexpected character %q", r)
+}
+
+// skipIgnored skips the whitespaces, line terminators, commas, comments and BOM.
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; c {
+		case ' ', '\t', '\n', '\r', ',':
+			l.pos++
+		case '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' && l.src[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			if strings.HasPrefix(l.src[l.pos:], "\uFEFF") {
+				l.pos += len("\uFEFF")
+				continue
+			}
+			return
+		}
+	}
+}
+
+func (l *lexer) readNumber() (token, error) {
+	start := l.pos
+	kind := tokenInt
+
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+
+	if l.pos >= len(l.src) || !isDigit(l.src[l.pos]) {
+		return token{}, l.errorf(start, "invalid number")
+	}
+
+	if l.src[l.pos] == '0' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1]) {
+		return token{}, l.errorf(start, "invalid number, unexpected leading zero")
+	}
+
+	l.readDigits()
+
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.pos++
+		if l.readDigits() == 0 {
+			return token{}, l.errorf(start, "invalid number, expected digit after '.'")
+		}
+	}
+
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if l.readDigits() == 0 {
+			return token{}, l.errorf(start, "invalid number, expected exponent digit")
+		}
+	}
+
+	if l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || l.src[l.pos] == '.') {
+		return token{}, l.errorf(start, "invalid number, unexpected %q", l.src[l.pos])
+	}
+
+	return token{kind: kind, value: l.src[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) readDigits() int {
+	start := l.pos
+
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+
+	return l.pos - start
+}
+
+func (l *lexer) readString() (token, error) {
+	start := l.pos
+	l.pos++ // opening quote
+
+	var sb strings.Builder
+
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+
+		switch c {
+		case '"':
+			l.pos++
+			return token{kind: tokenString, value: sb.String(), pos: start}, nil
+		case '\n', '\r':
+			return token{}, l.errorf(l.pos, "unterminated string")
+		case '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, l.errorf(l.pos, "unterminated string")
+			}
+
+			escaped := l.src[l.pos+1]
+			l.pos += 2
+
+			switch escaped {
+			case '"', '\\', '/':
+				sb.WriteByte(escaped)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return token{}, l.errorf(l.pos, "invalid unicode escape sequence")
+				}
+				code, err := strconv.ParseUint(l.src[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, l.errorf(l.pos, "invalid unicode escape sequence")
+				}
+				sb.WriteRune(rune(code))
+				l.pos += 4
+			default:
+				return token{}, l.errorf(l.pos-2, "invalid escape sequence \\%c", escaped)
+			}
+		default:
+			sb.WriteByte(c)
+			l.pos++
+		}
+	}
+
+	return token{}, l.errorf(start, "unterminated string")
+}
+
+func (l *lexer) readBlockString() (token, error) {
+	start := l.pos
+	l.pos += 3 // opening quotes
+
+	var sb strings.Builder
+
+	for l.pos < len(l.src) {
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			l.pos += 3
+			return token{kind: tokenString, value: blockStringValue(sb.String()), pos: start}, nil
+		}
+
+		if strings.HasPrefix(l.src[l.pos:], `\"""`) {
+			sb.WriteString(`"""`)
+			l.pos += 4
+			continue
+		}
+
+		sb.WriteByte(l.src[l.pos])
+		l.pos++
+	}
+
+	return token{}, l.errorf(start, "unterminated block string")
+}
+
+// blockStringValue normalizes the raw block string value by removing
+// the common indentation and the leading and trailing blank lines.
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(strings.ReplaceAll(raw, "\r\n", "\n"), "\r", "\n"), "\n")
+
+	commonIndent := -1
+	for i, line := range lines {
+		if i == 0 {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < len(line) && (commonIndent == -1 || indent < commonIndent) {
+			commonIndent = indent
+		}
+	}
+
+	if commonIndent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= commonIndent {
+				lines[i] = lines[i][commonIndent:]
+			} else {
+				lines[i] = ""
+			}
+		}
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
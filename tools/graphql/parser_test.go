@@ -0,0 +1,238 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/graphql"
+)
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{"empty", "", "at least one operation"},
+		{"only fragment", "fragment a on B { id }", "at least one operation"},
+		{"empty selection set", "{}", "empty selection set"},
+		{"missing closing brace", "{ a { b }", "unexpected end of document"},
+		{"invalid character", "{ a % }", "unexpected character"},
+		{"unterminated string", `{ a(b: "c) }`, "unterminated string"},
+		{"invalid escape", `{ a(b: "\x") }`, "invalid escape sequence"},
+		{"invalid number", `{ a(b: 01) }`, "leading zero"},
+		{"variable in default value", `query ($a: Int = $b) { a }`, "unexpected variable"},
+		{"empty arguments", `{ a() }`, "empty arguments list"},
+		{"multiple anonymous operations", "{ a } { b }", "anonymous operations"},
+		{"duplicated operation", "query a { a } query a { b }", `duplicated operation "a"`},
+		{"duplicated fragment", "{ a } fragment f on A { a } fragment f on A { b }", `duplicated fragment "f"`},
+		{"type definition", "type A { a: String }", `unexpected "type"`},
+		{"error position", "{\n  a(b: ) }", "line 2, column 8"},
+		{"max depth", strings.Repeat("{ a ", graphql.MaxDepth+2) + strings.Repeat("}", graphql.MaxDepth+2), "max nesting depth"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			_, err := graphql.Parse(s.query)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if !strings.Contains(err.Error(), s.expectedError) {
+				t.Fatalf("Expected error containing %q, got %q", s.expectedError, err.Error())
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		# comment
+		query Test($id: String!, $limit: Int = 10, $tags: [String!]) @test {
+			alias: demo1View(id: $id) {
+				id
+				... on demo1 { title }
+				...extra @include(if: true)
+			},
+			demo1List(filter: "a = \"b\"\n", perPage: $limit, sort: DESC, ratio: -1.5e1, obj: {a: [1, null, false]}) {
+				items { id }
+			}
+			desc: demo1List(filter: """
+				multi
+				  line
+			""") { totalItems }
+		}
+
+		fragment extra on demo1 {
+			expand { rel { id } }
+		}
+
+		mutation Other { demo1Delete(id: "abc") }
+	`
+
+	doc, err := graphql.Parse(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Operations) != 2 || len(doc.Fragments) != 1 {
+		t.Fatalf("Expected 2 operations and 1 fragment, got %d and %d", len(doc.Operations), len(doc.Fragments))
+	}
+
+	if _, err := doc.Operation(""); err == nil {
+		t.Fatal("Expected error for missing operation name")
+	}
+
+	if _, err := doc.Operation("missing"); err == nil {
+		t.Fatal("Expected error for unknown operation name")
+	}
+
+	op, err := doc.Operation("Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if op.Type != graphql.OperationQuery {
+		t.Fatalf("Expected query operation, got %q", op.Type)
+	}
+
+	vars, _ := json.Marshal(op.Variables)
+	expectedVars := `[{"DefaultValue":null,"Name":"id","Type":"String!","HasDefault":false},{"DefaultValue":10,"Name":"limit","Type":"Int","HasDefault":true},{"DefaultValue":null,"Name":"tags","Type":"[String!]","HasDefault":false}]`
+	if string(vars) != expectedVars {
+		t.Fatalf("Expected variables\n%s\ngot\n%s", expectedVars, vars)
+	}
+
+	if !op.Variables[0].IsRequired() || op.Variables[1].IsRequired() || op.Variables[2].IsRequired() {
+		t.Fatal("Unexpected variables required state")
+	}
+
+	variables := map[string]any{"id": "test_id", "limit": 5}
+
+	fields, err := doc.CollectFields(op.SelectionSet, variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fields) != 3 {
+		t.Fatalf("Expected 3 root fields, got %d", len(fields))
+	}
+
+	view := fields[0]
+	if view.Name != "demo1View" || view.ResponseKey() != "alias" {
+		t.Fatalf("Unexpected view field %q (%q)", view.Name, view.ResponseKey())
+	}
+
+	if args := view.ArgumentValues(variables); args["id"] != "test_id" {
+		t.Fatalf("Expected id argument test_id, got %v", args["id"])
+	}
+
+	viewFields, err := doc.CollectFields(view.SelectionSet, variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make([]string, 0, len(viewFields))
+	for _, f := range viewFields {
+		names = append(names, f.ResponseKey())
+	}
+	if strings.Join(names, ",") != "id,title,expand" {
+		t.Fatalf("Expected view fields id,title,expand, got %v", names)
+	}
+
+	list := fields[1]
+	rawArgs, _ := json.Marshal(list.ArgumentValues(variables))
+	expectedArgs := `{"filter":"a = \"b\"\n","obj":{"a":[1,null,false]},"perPage":5,"ratio":-15,"sort":"DESC"}`
+	if string(rawArgs) != expectedArgs {
+		t.Fatalf("Expected arguments\n%s\ngot\n%s", expectedArgs, rawArgs)
+	}
+
+	if filter := fields[2].ArgumentValues(nil)["filter"]; filter != "multi\n  line" {
+		t.Fatalf("Expected block string filter, got %q", filter)
+	}
+
+	mutation, err := doc.Operation("Other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mutation.Type != graphql.OperationMutation {
+		t.Fatalf("Expected mutation operation, got %q", mutation.Type)
+	}
+}
+
+func TestCollectFields(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name          string
+		query         string
+		variables     map[string]any
+		expected      string
+		expectedError bool
+	}{
+		{
+			"skip and include directives",
+			`query ($a: Boolean) { a @skip(if: true) b @skip(if: $a) c @include(if: $a) d @include(if: false) e }`,
+			map[string]any{"a": true},
+			"c,e",
+			false,
+		},
+		{
+			"skipped fragments",
+			`{ a ...f @skip(if: true) ... @include(if: false) { b } ... { c } } fragment f on A { d }`,
+			nil,
+			"a,c",
+			false,
+		},
+		{
+			"nested fragments",
+			`{ ...f1 } fragment f1 on A { a ...f2 } fragment f2 on A { b }`,
+			nil,
+			"a,b",
+			false,
+		},
+		{
+			"unknown fragment",
+			`{ ...missing }`,
+			nil,
+			"",
+			true,
+		},
+		{
+			"fragments cycle",
+			`{ ...f1 } fragment f1 on A { a ...f2 } fragment f2 on A { ...f1 }`,
+			nil,
+			"",
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			doc, err := graphql.Parse(s.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fields, err := doc.CollectFields(doc.Operations[0].SelectionSet, s.variables)
+
+			hasErr := err != nil
+			if hasErr != s.expectedError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectedError, hasErr, err)
+			}
+
+			names := make([]string, 0, len(fields))
+			for _, f := range fields {
+				names = append(names, f.ResponseKey())
+			}
+
+			if v := strings.Join(names, ","); v != s.expected {
+				t.Fatalf("Expected fields %q, got %q", s.expected, v)
+			}
+		})
+	}
+}
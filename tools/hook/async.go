@@ -0,0 +1,290 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ErrAsyncPoolFull is reported when an async handler task couldn't be
+// scheduled because the worker pool queue has reached its limit.
+var ErrAsyncPoolFull = errors.New("the async hook handlers queue is full")
+
+// ErrAsyncPoolClosed is reported when an async handler task couldn't be
+// scheduled because the worker pool was closed.
+var ErrAsyncPoolClosed = errors.New("the async hook handlers pool is closed")
+
+// AsyncOptions defines the execution options of an async hook handler.
+type AsyncOptions struct {
+	// Pool is the worker pool where the handler will be executed
+	// (if not set, fallbacks to DefaultAsyncPool()).
+	Pool *AsyncPool
+
+	// Retries is the number of additional handler executions in case
+	// it returns an error or panics.
+	Retries int
+
+	// RetryDelay is the delay before the first retry, doubled after
+	// each next failed attempt (if not set, fallbacks to 1s).
+	RetryDelay time.Duration
+
+	// OnError is an optional callback invoked with the error of the
+	// last failed attempt (or if the handler couldn't be scheduled).
+	//
+	// If not set, the error is written to the default logger.
+	OnError func(err error)
+}
+
+// BindAsync registers the provided handler to the current hooks queue
+// as an "async" handler.
+//
+// The async handler doesn't block the hook chain - the remaining
+// handlers are executed as usual and only if they complete successfully
+// the handler function is scheduled to be executed in the background on
+// a bounded worker pool (with panic recovery and optional retries).
+//
+// The handler function receives a shallow copy of the event and
+// calling e.Next() inside it is a no-op. Its returned error doesn't
+// affect the hook Trigger result and it is reported via opts.OnError.
+//
+// Note that the async handler could be executed after the original
+// request/operation completes, so it shouldn't rely on the event
+// context (e.g. a request context that may be already canceled).
+//
+// It is intended for non-critical tasks like analytics, webhooks,
+// emails, etc. that otherwise will add latency to the operation.
+func (h *Hook[T]) BindAsync(handler *Handler[T], opts ...AsyncOptions) string {
+	if handler.funcName == "" {
+		handler.funcName = funcName(handler.Func)
+	}
+
+	handler.Func = asyncHandlerFunc(handler.Func, opts...)
+
+	return h.Bind(handler)
+}
+
+// BindAsyncFunc is similar to BindAsync but registers a new handler
+// from just the provided function and the default async options.
+func (h *Hook[T]) BindAsyncFunc(fn func(e T) error) string {
+	return h.BindAsync(&Handler[T]{Func: fn})
+}
+
+func asyncHandlerFunc[T Resolver](fn func(T) error, opts ...AsyncOptions) func(T) error {
+	var options AsyncOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	return func(e T) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		eventCopy := shallowCopyEvent(e)
+
+		options.schedule(func() error {
+			return fn(eventCopy)
+		})
+
+		return nil
+	}
+}
+
+// shallowCopyEvent creates a shallow copy of a pointer to struct event
+// and replaces its Next function with a no-op.
+func shallowCopyEvent[T Resolver](e T) T {
+	eventCopy := e
+
+	rv := reflect.ValueOf(e)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct {
+		copyPtr := reflect.New(rv.Elem().Type())
+		copyPtr.Elem().Set(rv.Elem())
+		eventCopy, _ = copyPtr.Interface().(T)
+	}
+
+	eventCopy.setNextFunc(func() error { return nil })
+
+	return eventCopy
+}
+
+func (o AsyncOptions) schedule(task func() error) {
+	pool := o.Pool
+	if pool == nil {
+		pool = DefaultAsyncPool()
+	}
+
+	delay := o.RetryDelay
+	if delay <= 0 {
+		delay = time.Second
+	}
+
+	var attempt func(n int)
+	attempt = func(n int) {
+		err := pool.Submit(func() {
+			err := runRecovered(task)
+			if err == nil {
+				return
+			}
+
+			if n < o.Retries {
+				pool.wg.Add(1) // keep the pool busy until the retry is scheduled
+				time.AfterFunc(delay*time.Duration(1<<n), func() {
+					defer pool.wg.Done()
+					attempt(n + 1)
+				})
+				return
+			}
+
+			o.reportError(err)
+		})
+		if err != nil {
+			o.reportError(err)
+		}
+	}
+
+	attempt(0)
+}
+
+func (o AsyncOptions) reportError(err error) {
+	if o.OnError != nil {
+		o.OnError(err)
+		return
+	}
+
+	log.Printf("Async hook handler error: %v", err)
+}
+
+func runRecovered(task func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("async hook handler panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return task()
+}
+
+// -------------------------------------------------------------------
+
+var (
+	defaultAsyncPool     *AsyncPool
+	defaultAsyncPoolOnce sync.Once
+)
+
+// DefaultAsyncPool returns the shared worker pool used by the async hook
+// handlers when AsyncOptions.Pool is not set.
+//
+// It is created on first use with runtime.NumCPU()*2 workers and a queue of 1000 tasks.
+func DefaultAsyncPool() *AsyncPool {
+	defaultAsyncPoolOnce.Do(func() {
+		defaultAsyncPool = NewAsyncPool(runtime.NumCPU()*2, 1000)
+	})
+
+	return defaultAsyncPool
+}
+
+// AsyncPool is a bounded worker pool for executing async hook handlers.
+type AsyncPool struct {
+	tasks  chan func()
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAsyncPool creates and starts a new AsyncPool with the specified
+// number of workers and max number of queued tasks.
+func NewAsyncPool(workers int, queueSize int) *AsyncPool {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	p := &AsyncPool{
+		tasks: make(chan func(), queueSize),
+	}
+
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+func (p *AsyncPool) work() {
+	for task := range p.tasks {
+		func() {
+			defer p.wg.Done()
+			task()
+		}()
+	}
+}
+
+// Submit schedules the provided task for execution.
+//
+// Returns ErrAsyncPoolFull if the pool queue is full (the task is not
+// scheduled) and ErrAsyncPoolClosed if the pool was already closed.
+func (p *AsyncPool) Submit(task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrAsyncPoolClosed
+	}
+
+	p.wg.Add(1)
+
+	select {
+	case p.tasks <- task:
+		return nil
+	default:
+		p.wg.Done()
+		return ErrAsyncPoolFull
+	}
+}
+
+// Wait blocks until all submitted tasks (including their scheduled
+// retries) complete or ctx is done.
+func (p *AsyncPool) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new tasks and waits for the pending ones
+// to complete (or until ctx is done).
+func (p *AsyncPool) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	err := p.Wait(ctx)
+
+	p.mu.Lock()
+	close(p.tasks)
+	p.mu.Unlock()
+
+	return err
+}
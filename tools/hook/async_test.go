@@ -0,0 +1,231 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type mockAsyncEvent struct {
+	Event
+	value string
+}
+
+func TestHookBindAsync(t *testing.T) {
+	t.Parallel()
+
+	pool := NewAsyncPool(2, 10)
+	defer pool.Close(context.Background())
+
+	var mu sync.Mutex
+	calls := []string{}
+	addCall := func(c string) {
+		mu.Lock()
+		calls = append(calls, c)
+		mu.Unlock()
+	}
+
+	release := make(chan struct{})
+
+	h := Hook[*mockAsyncEvent]{}
+	h.BindAsync(&Handler[*mockAsyncEvent]{
+		Func: func(e *mockAsyncEvent) error {
+			<-release
+			addCall("async_" + e.value)
+			return e.Next() // no-op
+		},
+	}, AsyncOptions{Pool: pool})
+	h.BindFunc(func(e *mockAsyncEvent) error {
+		addCall("sync_" + e.value)
+		e.value = "changed"
+		return e.Next()
+	})
+
+	event := &mockAsyncEvent{value: "a"}
+
+	if err := h.Trigger(event, func(e *mockAsyncEvent) error { addCall("oneoff"); return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	// mutate after trigger (the async handler should have its own event copy)
+	event.value = "after"
+
+	close(release)
+
+	if err := pool.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "sync_a,oneoff,async_changed"
+	if v := strings.Join(calls, ","); v != expected {
+		t.Fatalf("Expected calls %q, got %q", expected, v)
+	}
+}
+
+func TestHookBindAsyncChainError(t *testing.T) {
+	t.Parallel()
+
+	pool := NewAsyncPool(1, 10)
+	defer pool.Close(context.Background())
+
+	var called atomic.Bool
+
+	h := Hook[*mockAsyncEvent]{}
+	h.BindAsync(&Handler[*mockAsyncEvent]{
+		Func: func(e *mockAsyncEvent) error {
+			called.Store(true)
+			return nil
+		},
+	}, AsyncOptions{Pool: pool})
+	h.BindFunc(func(e *mockAsyncEvent) error {
+		return errors.New("test")
+	})
+
+	if err := h.Trigger(&mockAsyncEvent{}); err == nil {
+		t.Fatal("Expected the chain error to be returned")
+	}
+
+	pool.Wait(context.Background())
+
+	if called.Load() {
+		t.Fatal("Expected the async handler to not be scheduled")
+	}
+}
+
+func TestHookBindAsyncRetries(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name          string
+		retries       int
+		failures      int32
+		panics        bool
+		expectedCalls int32
+		expectError   bool
+	}{
+		{"success", 2, 0, false, 1, false},
+		{"success after retry", 2, 2, false, 3, false},
+		{"retries exhausted", 1, 5, false, 2, true},
+		{"panic recovery", 0, 1, true, 1, true},
+		{"panic retry", 1, 1, true, 2, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			pool := NewAsyncPool(1, 10)
+			defer pool.Close(context.Background())
+
+			var calls atomic.Int32
+			var reported atomic.Value
+
+			h := Hook[*mockAsyncEvent]{}
+			h.BindAsync(&Handler[*mockAsyncEvent]{
+				Func: func(e *mockAsyncEvent) error {
+					n := calls.Add(1)
+					if n <= s.failures {
+						if s.panics {
+							panic("test panic")
+						}
+						return errors.New("test error")
+					}
+					return nil
+				},
+			}, AsyncOptions{
+				Pool:       pool,
+				Retries:    s.retries,
+				RetryDelay: time.Millisecond,
+				OnError: func(err error) {
+					reported.Store(err)
+				},
+			})
+
+			if err := h.Trigger(&mockAsyncEvent{}); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := pool.Wait(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if v := calls.Load(); v != s.expectedCalls {
+				t.Fatalf("Expected %d calls, got %d", s.expectedCalls, v)
+			}
+
+			hasErr := reported.Load() != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, reported.Load())
+			}
+		})
+	}
+}
+
+func TestAsyncPoolSubmit(t *testing.T) {
+	t.Parallel()
+
+	pool := NewAsyncPool(1, 1)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	if err := pool.Submit(func() { close(started); <-release }); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	// fill the queue
+	if err := pool.Submit(func() {}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pool.Submit(func() {}); !errors.Is(err, ErrAsyncPoolFull) {
+		t.Fatalf("Expected ErrAsyncPoolFull, got %v", err)
+	}
+
+	close(release)
+
+	if err := pool.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pool.Submit(func() {}); !errors.Is(err, ErrAsyncPoolClosed) {
+		t.Fatalf("Expected ErrAsyncPoolClosed, got %v", err)
+	}
+}
+
+func TestTaggedHookBindAsync(t *testing.T) {
+	t.Parallel()
+
+	pool := NewAsyncPool(1, 10)
+	defer pool.Close(context.Background())
+
+	var mu sync.Mutex
+	calls := ""
+
+	base := &Hook[*mockTagsEvent]{}
+
+	hA := NewTaggedHook(base, "a")
+	hA.BindAsync(&Handler[*mockTagsEvent]{
+		Func: func(e *mockTagsEvent) error { mu.Lock(); calls += "a"; mu.Unlock(); return nil },
+	}, AsyncOptions{Pool: pool})
+
+	hB := NewTaggedHook(base, "b")
+	hB.BindAsync(&Handler[*mockTagsEvent]{
+		Func: func(e *mockTagsEvent) error { mu.Lock(); calls += "b"; mu.Unlock(); return nil },
+	}, AsyncOptions{Pool: pool})
+
+	if err := base.Trigger(&mockTagsEvent{tags: []string{"b"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	pool.Wait(context.Background())
+
+	if calls != "b" {
+		t.Fatalf("Expected calls %q, got %q", "b", calls)
+	}
+}
@@ -1,7 +1,6 @@
 package hook
 
 import (
-	"sort"
 	"sync"
 
 	"github.com/pocketbase/pocketbase/tools/security"
@@ -29,6 +28,26 @@ type Handler[T Resolver] struct {
 	//
 	// If 0, the handler will be executed in the same order it was registered.
 	Priority int
+
+	// Before is an optional list of handler ids that this handler
+	// must be executed before (it takes precedence over Priority).
+	//
+	// Ids of handlers that are not registered in the hook are ignored
+	// (the constraint is applied once a handler with the id is bound).
+	Before []string
+
+	// After is an optional list of handler ids that this handler
+	// must be executed after (it takes precedence over Priority).
+	//
+	// Ids of handlers that are not registered in the hook are ignored
+	// (the constraint is applied once a handler with the id is bound).
+	After []string
+
+	// funcName is the name of the original handler function (used for tracing).
+	funcName string
+
+	// seq is the handler registration sequence number (used for sorting).
+	seq uint64
 }
 
 // Hook defines a generic concurrent safe structure for managing event hooks.
@@ -54,6 +73,9 @@ type Handler[T Resolver] struct {
 type Hook[T Resolver] struct {
 	handlers []*Handler[T]
 	mu       sync.RWMutex
+	name     string
+	tracer   Tracer
+	seq      uint64
 }
 
 // Bind registers the provided handler to the current hooks queue.
@@ -63,6 +85,10 @@ type Hook[T Resolver] struct {
 // If a handler from the current hook list has Id matching handler.Id
 // then the old handler is replaced with the new one.
 func (h *Hook[T]) Bind(handler *Handler[T]) string {
+	if handler.funcName == "" {
+		handler.funcName = funcName(handler.Func)
+	}
+
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -80,9 +106,10 @@ func (h *Hook[T]) Bind(handler *Handler[T]) string {
 			}
 		}
 	} else {
-		// replace existing
+		// replace existing (preserving its registration position)
 		for i, existing := range h.handlers {
 			if existing.Id == handler.Id {
+				handler.seq = existing.seq
 				h.handlers[i] = handler
 				exists = true
 				break
@@ -92,17 +119,64 @@ func (h *Hook[T]) Bind(handler *Handler[T]) string {
 
 	// append new
 	if !exists {
+		h.seq++
+		handler.seq = h.seq
 		h.handlers = append(h.handlers, handler)
 	}
 
-	// sort handlers by Priority, preserving the original order of equal items
-	sort.SliceStable(h.handlers, func(i, j int) bool {
-		return h.handlers[i].Priority < h.handlers[j].Priority
-	})
+	h.handlers = sortHandlers(h.handlers)
 
 	return handler.Id
 }
 
+// Replace replaces the function of an existing handler with the specified id
+// preserving its priority and ordering constraints.
+//
+// Returns false if there is no handler with the specified id.
+func (h *Hook[T]) Replace(id string, fn func(e T) error) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, existing := range h.handlers {
+		if existing.Id == id {
+			replacement := *existing
+			replacement.Func = fn
+			replacement.funcName = funcName(fn)
+			h.handlers[i] = &replacement
+			return true
+		}
+	}
+
+	return false
+}
+
+// Has checks whether a handler with the specified id is registered in the hook.
+func (h *Hook[T]) Has(id string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, existing := range h.handlers {
+		if existing.Id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HandlerIds returns the ids of the registered handlers in their execution order.
+func (h *Hook[T]) HandlerIds() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ids := make([]string, len(h.handlers))
+	for i, handler := range h.handlers {
+		ids[i] = handler.Id
+	}
+
+	return ids
+}
+
 // BindFunc is similar to Bind but registers a new handler from just the provided function.
 //
 // The registered handler is added with a default 0 priority and the id will be autogenerated.
@@ -157,8 +231,25 @@ func (h *Hook[T]) Trigger(event T, oneOffHandlerFuncs ...func(T) error) error {
 		handlers = append(handlers, handler.Func)
 	}
 	handlers = append(handlers, oneOffHandlerFuncs...)
+
+	var traced []TraceHandlerInfo
+	tracer := h.tracer
+	if tracer != nil {
+		traced = make([]TraceHandlerInfo, 0, len(handlers))
+		for _, handler := range h.handlers {
+			traced = append(traced, TraceHandlerInfo{Id: handler.Id, Func: handler.funcName})
+		}
+		for _, fn := range oneOffHandlerFuncs {
+			traced = append(traced, TraceHandlerInfo{Id: "oneOff", Func: funcName(fn)})
+		}
+	}
+	hookName := h.name
 	h.mu.RUnlock()
 
+	if tracer != nil {
+		handlers = traceHandlers(hookName, tracer, traced, handlers)
+	}
+
 	event.setNextFunc(nil) // reset in case the event is being reused
 
 	for i := len(handlers) - 1; i >= 0; i-- {
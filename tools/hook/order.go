@@ -0,0 +1,108 @@
+package hook
+
+import (
+	"slices"
+)
+
+// Common handler priorities (lower values are executed first).
+const (
+	PriorityFirst   = -999999
+	PriorityDefault = 0
+	PriorityLast    = 999999
+)
+
+// sortHandlers sorts the handlers by their Priority (preserving the
+// registration order of the handlers with equal priority) and then
+// applies their Before/After ordering constraints.
+//
+// If the constraints form a cycle, the cycle is broken using the
+// priority order of the involved handlers.
+func sortHandlers[T Resolver](handlers []*Handler[T]) []*Handler[T] {
+	slices.SortStableFunc(handlers, func(a, b *Handler[T]) int {
+		if a.Priority != b.Priority {
+			if a.Priority < b.Priority {
+				return -1
+			}
+			return 1
+		}
+		if a.seq < b.seq {
+			return -1
+		}
+		if a.seq > b.seq {
+			return 1
+		}
+		return 0
+	})
+
+	total := len(handlers)
+
+	index := make(map[string]int, total)
+	for i, h := range handlers {
+		index[h.Id] = i
+	}
+
+	// edges[i] contains the handlers that must be executed after handlers[i]
+	edges := make([][]int, total)
+	indegree := make([]int, total)
+	addEdge := func(from, to int) {
+		if from == to || slices.Contains(edges[from], to) {
+			return
+		}
+		edges[from] = append(edges[from], to)
+		indegree[to]++
+	}
+
+	var hasConstraints bool
+	for i, h := range handlers {
+		for _, id := range h.Before {
+			if j, ok := index[id]; ok {
+				addEdge(i, j)
+				hasConstraints = true
+			}
+		}
+		for _, id := range h.After {
+			if j, ok := index[id]; ok {
+				addEdge(j, i)
+				hasConstraints = true
+			}
+		}
+	}
+
+	if !hasConstraints {
+		return handlers
+	}
+
+	result := make([]*Handler[T], 0, total)
+	done := make([]bool, total)
+
+	for len(result) < total {
+		next := -1
+
+		// pick the first (in priority order) handler without pending dependencies
+		for i := range handlers {
+			if !done[i] && indegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+
+		// cycle - fallback to the first remaining handler
+		if next == -1 {
+			for i := range handlers {
+				if !done[i] {
+					next = i
+					break
+				}
+			}
+		}
+
+		done[next] = true
+		result = append(result, handlers[next])
+
+		for _, j := range edges[next] {
+			indegree[j]--
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,164 @@
+package hook
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHookBeforeAfterOrdering(t *testing.T) {
+	t.Parallel()
+
+	newHandler := func(id string, priority int, before []string, after []string, calls *[]string) *Handler[*Event] {
+		return &Handler[*Event]{
+			Id:       id,
+			Priority: priority,
+			Before:   before,
+			After:    after,
+			Func: func(e *Event) error {
+				*calls = append(*calls, id)
+				return e.Next()
+			},
+		}
+	}
+
+	scenarios := []struct {
+		name     string
+		handlers func(calls *[]string) []*Handler[*Event]
+		expected string
+	}{
+		{
+			"priority and registration order",
+			func(calls *[]string) []*Handler[*Event] {
+				return []*Handler[*Event]{
+					newHandler("a", 0, nil, nil, calls),
+					newHandler("b", -1, nil, nil, calls),
+					newHandler("c", 0, nil, nil, calls),
+					newHandler("d", PriorityLast, nil, nil, calls),
+					newHandler("e", PriorityFirst, nil, nil, calls),
+				}
+			},
+			"e,b,a,c,d",
+		},
+		{
+			"before constraint overrides priority",
+			func(calls *[]string) []*Handler[*Event] {
+				return []*Handler[*Event]{
+					newHandler("a", -10, nil, nil, calls),
+					newHandler("b", 10, []string{"a"}, nil, calls),
+					newHandler("c", 0, nil, nil, calls),
+				}
+			},
+			"c,b,a",
+		},
+		{
+			"after constraint overrides priority",
+			func(calls *[]string) []*Handler[*Event] {
+				return []*Handler[*Event]{
+					newHandler("a", -10, nil, []string{"c"}, calls),
+					newHandler("b", 0, nil, nil, calls),
+					newHandler("c", 10, nil, nil, calls),
+				}
+			},
+			"b,c,a",
+		},
+		{
+			"constraints to missing handlers",
+			func(calls *[]string) []*Handler[*Event] {
+				return []*Handler[*Event]{
+					newHandler("a", 0, []string{"missing"}, []string{"missing2"}, calls),
+					newHandler("b", -1, nil, nil, calls),
+				}
+			},
+			"b,a",
+		},
+		{
+			"constraint to a later registered handler",
+			func(calls *[]string) []*Handler[*Event] {
+				return []*Handler[*Event]{
+					newHandler("a", 0, nil, []string{"b"}, calls),
+					newHandler("c", 0, nil, nil, calls),
+					newHandler("b", 0, nil, nil, calls),
+				}
+			},
+			"c,b,a",
+		},
+		{
+			"chained constraints",
+			func(calls *[]string) []*Handler[*Event] {
+				return []*Handler[*Event]{
+					newHandler("a", 0, nil, []string{"b"}, calls),
+					newHandler("b", 0, nil, []string{"c"}, calls),
+					newHandler("c", 0, nil, nil, calls),
+					newHandler("d", 0, []string{"c"}, nil, calls),
+				}
+			},
+			"d,c,b,a",
+		},
+		{
+			"cycle fallbacks to priority",
+			func(calls *[]string) []*Handler[*Event] {
+				return []*Handler[*Event]{
+					newHandler("a", 0, nil, []string{"b"}, calls),
+					newHandler("b", 0, nil, []string{"a"}, calls),
+					newHandler("c", -1, nil, nil, calls),
+				}
+			},
+			"c,a,b",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			calls := []string{}
+
+			h := Hook[*Event]{}
+			for _, handler := range s.handlers(&calls) {
+				h.Bind(handler)
+			}
+
+			if ids := strings.Join(h.HandlerIds(), ","); ids != s.expected {
+				t.Fatalf("Expected handler ids %q, got %q", s.expected, ids)
+			}
+
+			if err := h.Trigger(&Event{}); err != nil {
+				t.Fatal(err)
+			}
+
+			if v := strings.Join(calls, ","); v != s.expected {
+				t.Fatalf("Expected calls %q, got %q", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestHookReplace(t *testing.T) {
+	t.Parallel()
+
+	calls := ""
+
+	h := Hook[*Event]{}
+	h.Bind(&Handler[*Event]{Id: "a", Func: func(e *Event) error { calls += "a"; return e.Next() }})
+	h.Bind(&Handler[*Event]{Id: "b", Priority: -1, Func: func(e *Event) error { calls += "b"; return e.Next() }})
+	h.Bind(&Handler[*Event]{Id: "c", Func: func(e *Event) error { calls += "c"; return e.Next() }})
+
+	if h.Replace("missing", func(e *Event) error { return e.Next() }) {
+		t.Fatal("Expected Replace to return false for missing handler")
+	}
+
+	if !h.Replace("b", func(e *Event) error { calls += "b2"; return e.Next() }) {
+		t.Fatal("Expected Replace to return true")
+	}
+
+	// rebind with the same id should preserve the registration position
+	h.Bind(&Handler[*Event]{Id: "a", Func: func(e *Event) error { calls += "a2"; return e.Next() }})
+
+	h.Trigger(&Event{})
+
+	if calls != "b2a2c" {
+		t.Fatalf("Expected calls %q, got %q", "b2a2c", calls)
+	}
+
+	if !h.Has("c") || h.Has("missing") {
+		t.Fatal("Unexpected Has result")
+	}
+}
@@ -57,6 +57,9 @@ func (h *TaggedHook[T]) CanTriggerOn(tagsToCheck []string) bool {
 // function is invoked only if the event data tags satisfy h.CanTriggerOn.
 func (h *TaggedHook[T]) Bind(handler *Handler[T]) string {
 	fn := handler.Func
+	if handler.funcName == "" {
+		handler.funcName = funcName(fn)
+	}
 
 	handler.Func = func(e T) error {
 		if h.CanTriggerOn(e.Tags()) {
@@ -74,11 +77,35 @@ func (h *TaggedHook[T]) Bind(handler *Handler[T]) string {
 // It is similar to [Hook.Bind] with the difference that the handler
 // function is invoked only if the event data tags satisfy h.CanTriggerOn.
 func (h *TaggedHook[T]) BindFunc(fn func(e T) error) string {
-	return h.mainHook.BindFunc(func(e T) error {
+	return h.Bind(&Handler[T]{Func: fn})
+}
+
+// BindAsync registers the provided handler as an async handler.
+//
+// It is similar to [Hook.BindAsync] with the difference that the handler
+// function is scheduled only if the event data tags satisfy h.CanTriggerOn.
+func (h *TaggedHook[T]) BindAsync(handler *Handler[T], opts ...AsyncOptions) string {
+	if handler.funcName == "" {
+		handler.funcName = funcName(handler.Func)
+	}
+
+	fn := asyncHandlerFunc(handler.Func, opts...)
+
+	handler.Func = func(e T) error {
 		if h.CanTriggerOn(e.Tags()) {
 			return fn(e)
 		}
 
 		return e.Next()
-	})
+	}
+
+	return h.mainHook.Bind(handler)
+}
+
+// BindAsyncFunc registers a new async handler with the specified function.
+//
+// It is similar to [Hook.BindAsyncFunc] with the difference that the handler
+// function is scheduled only if the event data tags satisfy h.CanTriggerOn.
+func (h *TaggedHook[T]) BindAsyncFunc(fn func(e T) error) string {
+	return h.BindAsync(&Handler[T]{Func: fn})
 }
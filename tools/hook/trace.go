@@ -0,0 +1,145 @@
+package hook
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"time"
+)
+
+// Tracer defines an interface for collecting the hook handlers
+// execution diagnostics (see [Hook.SetTracer]).
+//
+// Note that the tracer is called synchronously after each handler
+// execution and it must be safe for concurrent use.
+type Tracer interface {
+	TraceHandler(info *TraceInfo)
+}
+
+// TracerFunc is an adapter to allow the use of ordinary functions as [Tracer].
+type TracerFunc func(info *TraceInfo)
+
+// TraceHandler implements the [Tracer] interface.
+func (f TracerFunc) TraceHandler(info *TraceInfo) {
+	f(info)
+}
+
+// TraceHandlerInfo describes a single traced hook handler.
+type TraceHandlerInfo struct {
+	// Id is the handler id ("oneOff" for the Trigger one-off handler funcs).
+	Id string
+
+	// Func is the fully qualified name of the handler function
+	// (e.g. "github.com/example/myplugin.Register.func1").
+	Func string
+}
+
+// TraceInfo describes a single hook handler execution.
+type TraceInfo struct {
+	// Event is the hook event that was passed to the handler.
+	Event any
+
+	// Hook is the name of the hook (see [Hook.SetTracer]).
+	Hook string
+
+	Handler TraceHandlerInfo
+
+	// Duration is the handler "self" execution time,
+	// excluding the time of the next handlers called with e.Next().
+	Duration time.Duration
+
+	// Error is the error returned by the handler
+	// (errors returned by the next handlers are not included).
+	Error error
+}
+
+// SetTracer sets (or removes if nil) the tracer of the hook handlers executions.
+//
+// The name is used to identify the hook in the tracer reports.
+func (h *Hook[T]) SetTracer(name string, tracer Tracer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.name = name
+	h.tracer = tracer
+}
+
+// traceHandlers wraps the provided hook handlers chain with the tracer calls.
+func traceHandlers[T Resolver](
+	hookName string,
+	tracer Tracer,
+	infos []TraceHandlerInfo,
+	handlers []func(T) error,
+) []func(T) error {
+	total := len(handlers)
+
+	// the inclusive execution time and the returned error of
+	// the next handler called from the handler at the same index
+	nextTimes := make([]time.Duration, total)
+	nextErrs := make([]error, total)
+
+	wrapped := make([]func(T) error, total)
+
+	for i, fn := range handlers {
+		wrapped[i] = func(e T) error {
+			nextTimes[i] = 0
+			nextErrs[i] = nil
+
+			start := time.Now()
+			err := fn(e)
+			elapsed := time.Since(start)
+
+			if i > 0 {
+				nextTimes[i-1] += elapsed
+				nextErrs[i-1] = err
+			}
+
+			info := &TraceInfo{
+				Event:    e,
+				Hook:     hookName,
+				Handler:  infos[i],
+				Duration: max(elapsed-nextTimes[i], 0),
+			}
+			if err != nil && !isSameError(err, nextErrs[i]) {
+				info.Error = err
+			}
+
+			tracer.TraceHandler(info)
+
+			return err
+		}
+	}
+
+	return wrapped
+}
+
+// isSameError reports whether err is (or wraps) target.
+//
+// Unlike errors.Is it also handles non-comparable map based errors
+// (e.g. validation.Errors) by comparing their underlying pointers.
+func isSameError(err, target error) bool {
+	if target == nil {
+		return false
+	}
+
+	errType := reflect.TypeOf(err)
+	if errType == reflect.TypeOf(target) && errType.Kind() == reflect.Map {
+		return reflect.ValueOf(err).UnsafePointer() == reflect.ValueOf(target).UnsafePointer()
+	}
+
+	return errors.Is(err, target)
+}
+
+// funcName returns the fully qualified name of the provided function.
+func funcName(fn any) string {
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return ""
+	}
+
+	if f := runtime.FuncForPC(rv.Pointer()); f != nil {
+		return f.Name()
+	}
+
+	return ""
+}
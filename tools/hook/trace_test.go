@@ -0,0 +1,138 @@
+package hook
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHookSetTracer(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	traces := []*TraceInfo{}
+
+	h := Hook[*Event]{}
+
+	h.Bind(&Handler[*Event]{
+		Id: "slow",
+		Func: func(e *Event) error {
+			time.Sleep(20 * time.Millisecond)
+			return e.Next()
+		},
+	})
+	h.Bind(&Handler[*Event]{
+		Id: "failing",
+		Func: func(e *Event) error {
+			e.Next()
+			return errors.New("test")
+		},
+	})
+	h.Bind(&Handler[*Event]{
+		Id: "fast",
+		Func: func(e *Event) error {
+			return e.Next()
+		},
+	})
+
+	// without tracer
+	h.Trigger(&Event{})
+
+	h.SetTracer("onTest", TracerFunc(func(info *TraceInfo) {
+		mu.Lock()
+		traces = append(traces, info)
+		mu.Unlock()
+	}))
+
+	err := h.Trigger(&Event{}, func(e *Event) error {
+		time.Sleep(20 * time.Millisecond)
+		return e.Next()
+	})
+	if err == nil || err.Error() != "test" {
+		t.Fatalf("Expected the chain error to be returned, got %v", err)
+	}
+
+	if len(traces) != 4 {
+		t.Fatalf("Expected %d traces, got %d", 4, len(traces))
+	}
+
+	// the traces are reported in reverse order (the inner most handler first)
+	expected := []struct {
+		id         string
+		minTime    time.Duration
+		maxTime    time.Duration
+		expectsErr bool
+	}{
+		{"oneOff", 20 * time.Millisecond, time.Second, false},
+		// the self time shouldn't include the 20ms of the one-off handler
+		{"fast", 0, 19 * time.Millisecond, false},
+		{"failing", 0, 19 * time.Millisecond, true},
+		{"slow", 20 * time.Millisecond, 39 * time.Millisecond, false},
+	}
+
+	for i, e := range expected {
+		trace := traces[i]
+
+		if trace.Hook != "onTest" {
+			t.Fatalf("[%d] Expected hook name %q, got %q", i, "onTest", trace.Hook)
+		}
+
+		if trace.Handler.Id != e.id {
+			t.Fatalf("[%d] Expected handler %q, got %q", i, e.id, trace.Handler.Id)
+		}
+
+		if !strings.Contains(trace.Handler.Func, "TestHookSetTracer") {
+			t.Fatalf("[%d] Expected the handler func name to be resolved, got %q", i, trace.Handler.Func)
+		}
+
+		if trace.Duration < e.minTime || trace.Duration > e.maxTime {
+			t.Fatalf("[%d] Expected duration between %v and %v, got %v", i, e.minTime, e.maxTime, trace.Duration)
+		}
+
+		hasErr := trace.Error != nil
+		if hasErr != e.expectsErr {
+			t.Fatalf("[%d] Expected hasErr %v, got %v (%v)", i, e.expectsErr, hasErr, trace.Error)
+		}
+	}
+
+	// remove the tracer
+	h.SetTracer("onTest", nil)
+	h.Trigger(&Event{})
+	if len(traces) != 4 {
+		t.Fatalf("Expected no new traces, got %d", len(traces))
+	}
+}
+
+func TestIsSameError(t *testing.T) {
+	t.Parallel()
+
+	errA := errors.New("a")
+	mapErr := mockMapError{"a": errA}
+
+	scenarios := []struct {
+		err      error
+		target   error
+		expected bool
+	}{
+		{errA, nil, false},
+		{errA, errA, true},
+		{errors.Join(errA), errA, true},
+		{errA, errors.New("a"), false},
+		{mapErr, mapErr, true},
+		{mapErr, mockMapError{"a": errA}, false},
+	}
+
+	for i, s := range scenarios {
+		if v := isSameError(s.err, s.target); v != s.expected {
+			t.Errorf("[%d] Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+type mockMapError map[string]error
+
+func (m mockMapError) Error() string {
+	return "map error"
+}
@@ -0,0 +1,114 @@
+// Package httpx implements common helpers for the HTTP API
+// based provider clients (mailer, notifier, push, etc.).
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the default request timeout used by [Send]
+// when no custom [http.Client] is specified.
+const DefaultTimeout = 30 * time.Second
+
+// maxErrorBodySize is the max number of the response body bytes stored in [ResponseError].
+const maxErrorBodySize = 4096
+
+// ResponseError defines a provider HTTP response error.
+type ResponseError struct {
+	// Provider is the name of the provider that returned the error.
+	Provider string
+
+	// Action is a short description of the failed operation
+	// used in the error message, e.g. "send email".
+	Action string
+
+	Status int
+	Body   []byte
+}
+
+// Error implements the std error interface.
+func (err *ResponseError) Error() string {
+	action := err.Action
+	if action == "" {
+		action = "send request"
+	}
+
+	return fmt.Sprintf("%s: failed to %s (status %d): %s", err.Provider, action, err.Status, strings.TrimSpace(string(err.Body)))
+}
+
+// Send sends the provided request and normalizes any non 2xx response
+// to [ResponseError] with the specified provider and action.
+//
+// If result is not nil, the response body is decoded as JSON into it.
+func Send(client *http.Client, provider string, action string, req *http.Request, result any) error {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+		return &ResponseError{
+			Provider: provider,
+			Action:   action,
+			Status:   res.StatusCode,
+			Body:     body,
+		}
+	}
+
+	if result != nil {
+		return json.NewDecoder(res.Body).Decode(result)
+	}
+
+	// drain the body to allow connection reuse
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	return nil
+}
+
+// NewJSONRequest creates a new POST request with the provided data as JSON body.
+//
+// Note that the HTML characters are not escaped to preserve the content as it is.
+func NewJSONRequest(ctx context.Context, url string, data any) (*http.Request, error) {
+	body := new(bytes.Buffer)
+
+	encoder := json.NewEncoder(body)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(data); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bytes.TrimSpace(body.Bytes())))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+// NewFormRequest creates a new POST request with the provided data as urlencoded body.
+func NewFormRequest(ctx context.Context, url string, data url.Values) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return req, nil
+}
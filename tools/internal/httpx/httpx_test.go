@@ -0,0 +1,137 @@
+package httpx_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestResponseError(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		err      *httpx.ResponseError
+		expected string
+	}{
+		{
+			&httpx.ResponseError{Provider: "test", Status: 400, Body: []byte(" invalid \n")},
+			"test: failed to send request (status 400): invalid",
+		},
+		{
+			&httpx.ResponseError{Provider: "test", Action: "send email", Status: 500, Body: []byte("error")},
+			"test: failed to send email (status 500): error",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.expected, func(t *testing.T) {
+			if str := s.err.Error(); str != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, str)
+			}
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error response", func(t *testing.T) {
+		server, _ := httpxtest.NewCaptureServer(t, 400, "test_error")
+
+		req, err := httpx.NewJSONRequest(context.Background(), server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = httpx.Send(nil, "test", "send message", req, nil)
+
+		var respErr *httpx.ResponseError
+		if !errors.As(err, &respErr) {
+			t.Fatalf("Expected ResponseError, got %v", err)
+		}
+
+		if respErr.Provider != "test" || respErr.Action != "send message" || respErr.Status != 400 || string(respErr.Body) != "test_error" {
+			t.Fatalf("Invalid response error %v", respErr)
+		}
+	})
+
+	t.Run("JSON result", func(t *testing.T) {
+		server, _ := httpxtest.NewCaptureServer(t, 200, `{"a":123}`)
+
+		req, err := httpx.NewJSONRequest(context.Background(), server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result struct {
+			A int `json:"a"`
+		}
+
+		if err := httpx.Send(server.Client(), "test", "", req, &result); err != nil {
+			t.Fatal(err)
+		}
+
+		if result.A != 123 {
+			t.Fatalf("Expected result 123, got %d", result.A)
+		}
+	})
+}
+
+func TestNewJSONRequest(t *testing.T) {
+	t.Parallel()
+
+	req, err := httpx.NewJSONRequest(context.Background(), "https://example.com", map[string]any{"html": "<b>a&b</b>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBody := `{"html":"<b>a&b</b>"}`
+	if str := string(body); str != expectedBody {
+		t.Fatalf("Expected body %q, got %q", expectedBody, str)
+	}
+
+	if req.Method != "POST" {
+		t.Fatalf("Expected POST method, got %q", req.Method)
+	}
+
+	if ct := req.Header.Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Expected JSON content type, got %q", ct)
+	}
+}
+
+func TestNewFormRequest(t *testing.T) {
+	t.Parallel()
+
+	req, err := httpx.NewFormRequest(context.Background(), "https://example.com", url.Values{"b": {"2"}, "a": {"1 2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBody := "a=1+2&b=2"
+	if str := string(body); str != expectedBody {
+		t.Fatalf("Expected body %q, got %q", expectedBody, str)
+	}
+
+	if req.Method != "POST" {
+		t.Fatalf("Expected POST method, got %q", req.Method)
+	}
+
+	if ct := req.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+		t.Fatalf("Expected form content type, got %q", ct)
+	}
+}
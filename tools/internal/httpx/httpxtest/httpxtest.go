@@ -0,0 +1,73 @@
+// Package httpxtest provides test utilities for the HTTP API based provider clients.
+package httpxtest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// CapturedRequest stores the essential parts of a single test server request.
+type CapturedRequest struct {
+	Method  string
+	Path    string
+	Headers http.Header
+	Body    string
+}
+
+// NewCaptureServer creates a new test HTTP server that stores the
+// last received request and responds with the specified status and body.
+//
+// The server is closed automatically on test cleanup.
+func NewCaptureServer(t testing.TB, status int, response string) (*httptest.Server, *CapturedRequest) {
+	return startCaptureServer(t, status, response, false)
+}
+
+// NewTLSCaptureServer is similar to [NewCaptureServer] but starts a TLS server
+// (use its Client() method to send requests to it).
+func NewTLSCaptureServer(t testing.TB, status int, response string) (*httptest.Server, *CapturedRequest) {
+	return startCaptureServer(t, status, response, true)
+}
+
+func startCaptureServer(t testing.TB, status int, response string, useTLS bool) (*httptest.Server, *CapturedRequest) {
+	captured := &CapturedRequest{}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read the request body: %v", err)
+		}
+
+		captured.Method = r.Method
+		captured.Path = r.URL.Path
+		captured.Headers = r.Header.Clone()
+		captured.Body = string(body)
+
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+
+	if useTLS {
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+
+	t.Cleanup(server.Close)
+
+	return server, captured
+}
+
+// CheckContains reports a test error for each of the expectations
+// that is not found in the specified content.
+func CheckContains(t testing.TB, content string, expectations []string) {
+	t.Helper()
+
+	for _, expected := range expectations {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Missing %q in\n%s", expected, content)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package sigv4_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/internal/sigv4"
+)
+
+func TestPayloadHash(t *testing.T) {
+	t.Parallel()
+
+	hash := sigv4.PayloadHash(nil)
+
+	expected := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if hash != expected {
+		t.Fatalf("Expected %q, got %q", expected, hash)
+	}
+}
+
+func TestSignerSign(t *testing.T) {
+	t.Parallel()
+
+	signer := &sigv4.Signer{
+		Region:    "us-east-1",
+		Service:   "test",
+		AccessKey: "AKIDEXAMPLE",
+		SecretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "https://example.com/a b?x=1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		before := time.Now().UTC().Add(-1 * time.Second)
+
+		signer.Sign(req)
+
+		if h := req.Header.Get("x-amz-content-sha256"); h != sigv4.UnsignedPayload {
+			t.Fatalf("Expected unsigned payload header, got %q", h)
+		}
+
+		date, err := time.Parse(sigv4.DateTimeFormat, req.Header.Get("x-amz-date"))
+		if err != nil || date.Before(before) {
+			t.Fatalf("Expected the x-amz-date header to be set to the current time, got %q (%v)", req.Header.Get("x-amz-date"), err)
+		}
+
+		if h := req.Header.Get("host"); h != "example.com" {
+			t.Fatalf("Expected host header %q, got %q", "example.com", h)
+		}
+	})
+
+	t.Run("signature", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "https://example.com/test", strings.NewReader("test"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "text/plain")
+		req.Header.Set("X-Custom", "ignored")
+		req.Header.Set("x-amz-date", "20240102T030405Z")
+		req.Header.Set("x-amz-content-sha256", sigv4.PayloadHash([]byte("test")))
+
+		signer.Sign(req)
+
+		expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/us-east-1/test/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="
+		if auth := req.Header.Get("Authorization"); !strings.HasPrefix(auth, expected) || len(auth) != len(expected)+64 {
+			t.Fatalf("Expected authorization header starting with\n%s\ngot\n%s", expected, auth)
+		}
+
+		// the signature must be deterministic for the same request and time
+		req2 := req.Clone(req.Context())
+		req2.Header.Del("Authorization")
+		signer.Sign(req2)
+
+		if req.Header.Get("Authorization") != req2.Header.Get("Authorization") {
+			t.Fatalf("Expected the same signatures, got\n%s\n%s", req.Header.Get("Authorization"), req2.Header.Get("Authorization"))
+		}
+	})
+}
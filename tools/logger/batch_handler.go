@@ -32,6 +32,21 @@ type BatchOptions struct {
 	// BatchSize specifies how many logs to accumulate before calling WriteFunc.
 	// If not set or 0, fallback to 100 by default.
 	BatchSize int
+
+	// MaxBuffered specifies the max number of logs that could be
+	// accumulated while a previous batch write is still in progress.
+	//
+	// Once the limit is reached, the new logs are discarded until
+	// the queue is written (see [BatchHandler.Dropped]).
+	// If not set or 0, the queue is unbounded.
+	MaxBuffered int
+
+	// Sinks is an optional list of external destinations where
+	// the batched logs will be shipped after calling WriteFunc.
+	//
+	// Consider wrapping the sinks with [NewAsyncSink] to avoid
+	// blocking the handler on slow or unavailable destinations.
+	Sinks []Sink
 }
 
 // NewBatchHandler creates a slog compatible handler that writes JSON
@@ -85,6 +100,8 @@ type BatchHandler struct {
 	group   string
 	attrs   []slog.Attr
 	logs    []*Log
+	writes  int // number of the currently running batch writes
+	dropped int64
 }
 
 // Enabled reports whether the handler handles records at the given level.
@@ -127,6 +144,9 @@ func (h *BatchHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 // Handle formats the slog.Record argument as JSON object and adds it
 // to the batch queue.
 //
+// The attributes stored in ctx with [ContextWithAttrs] are also
+// included in the formatted log data.
+//
 // If the batch queue threshold has been reached, the WriteFunc option
 // is invoked with the accumulated logs which in turn will reset the batch queue.
 func (h *BatchHandler) Handle(ctx context.Context, r slog.Record) error {
@@ -157,7 +177,15 @@ func (h *BatchHandler) Handle(ctx context.Context, r slog.Record) error {
 		return h.parent.Handle(ctx, r)
 	}
 
-	data := make(map[string]any, r.NumAttrs())
+	ctxAttrs := AttrsFromContext(ctx)
+
+	data := make(map[string]any, r.NumAttrs()+len(ctxAttrs))
+
+	// resolve first the context attributes so that they could be
+	// overwritten by the explicitly specified record ones
+	for _, a := range ctxAttrs {
+		h.resolveAttr(data, a)
+	}
 
 	r.Attrs(func(a slog.Attr) bool {
 		if err := h.resolveAttr(data, a); err != nil {
@@ -178,11 +206,23 @@ func (h *BatchHandler) Handle(ctx context.Context, r slog.Record) error {
 	}
 
 	h.mux.Lock()
+
+	if h.options.MaxBuffered > 0 && len(h.logs) >= h.options.MaxBuffered {
+		h.dropped++
+		h.mux.Unlock()
+		return nil
+	}
+
 	h.logs = append(h.logs, log)
-	totalLogs := len(h.logs)
+
+	// postpone the batch write if there is another one still in progress
+	// and let the logs accumulate until it completes (up to MaxBuffered)
+	shouldWrite := len(h.logs) >= h.options.BatchSize &&
+		(h.writes == 0 || h.options.MaxBuffered <= 0)
+
 	h.mux.Unlock()
 
-	if totalLogs >= h.options.BatchSize {
+	if shouldWrite {
 		if err := h.WriteAll(ctx); err != nil {
 			return err
 		}
@@ -198,7 +238,51 @@ func (h *BatchHandler) SetLevel(level slog.Level) {
 	h.mux.Unlock()
 }
 
+// SetBatchSize updates the handler options batch size to the specified one.
+//
+// If size is <= 0, fallback to 100 by default.
+func (h *BatchHandler) SetBatchSize(size int) {
+	if size <= 0 {
+		size = 100
+	}
+
+	h.mux.Lock()
+	h.options.BatchSize = size
+	h.mux.Unlock()
+}
+
+// SetMaxBuffered updates the handler options max buffered logs limit to the specified one.
+//
+// Set it to 0 for unbounded logs queue.
+func (h *BatchHandler) SetMaxBuffered(limit int) {
+	h.mux.Lock()
+	h.options.MaxBuffered = limit
+	h.mux.Unlock()
+}
+
+// Dropped returns the total number of logs that were discarded
+// because the [BatchOptions.MaxBuffered] limit was reached.
+func (h *BatchHandler) Dropped() int64 {
+	if h.parent != nil {
+		return h.parent.Dropped()
+	}
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	return h.dropped
+}
+
+// SetSinks replaces the handler options sinks with the specified ones.
+func (h *BatchHandler) SetSinks(sinks ...Sink) {
+	h.mux.Lock()
+	h.options.Sinks = sinks
+	h.mux.Unlock()
+}
+
 // WriteAll writes all accumulated Log entries and resets the batch queue.
+//
+// The logs are also shipped to each of the configured sinks (if any).
 func (h *BatchHandler) WriteAll(ctx context.Context) error {
 	if h.parent != nil {
 		// invoke recursively the parent level handler since the most
@@ -221,9 +305,31 @@ func (h *BatchHandler) WriteAll(ctx context.Context) error {
 	copy(logs, h.logs)
 	h.logs = h.logs[:0] // reset
 
+	sinks := h.options.Sinks
+
+	h.writes++
+
 	h.mux.Unlock()
 
-	return h.options.WriteFunc(ctx, logs)
+	defer func() {
+		h.mux.Lock()
+		h.writes--
+		h.mux.Unlock()
+	}()
+
+	var errs []error
+
+	if err := h.options.WriteFunc(ctx, logs); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, sink := range sinks {
+		if err := sink.Write(ctx, logs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 // resolveAttr writes attr into data.
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"testing"
 	"time"
 
@@ -107,6 +108,93 @@ func TestBatchHandlerSetLevel(t *testing.T) {
 	}
 }
 
+func TestBatchHandlerSetBatchSize(t *testing.T) {
+	h := NewBatchHandler(BatchOptions{
+		BatchSize: 5,
+		WriteFunc: func(ctx context.Context, logs []*Log) error {
+			return nil
+		},
+	})
+
+	if h.options.BatchSize != 5 {
+		t.Fatalf("Expected the initial BatchSize %d, got %d", 5, h.options.BatchSize)
+	}
+
+	h.SetBatchSize(10)
+	if h.options.BatchSize != 10 {
+		t.Fatalf("Expected the new BatchSize %d, got %d", 10, h.options.BatchSize)
+	}
+
+	h.SetBatchSize(0)
+	if h.options.BatchSize != 100 {
+		t.Fatalf("Expected the default BatchSize %d, got %d", 100, h.options.BatchSize)
+	}
+}
+
+func TestBatchHandlerMaxBuffered(t *testing.T) {
+	ctx := context.Background()
+
+	writeStarted := make(chan struct{}, 1)
+	releaseWrite := make(chan struct{})
+
+	var mu sync.Mutex
+	writes := [][]*Log{}
+
+	h := NewBatchHandler(BatchOptions{
+		BatchSize:   2,
+		MaxBuffered: 3,
+		WriteFunc: func(_ context.Context, logs []*Log) error {
+			mu.Lock()
+			writes = append(writes, logs)
+			total := len(writes)
+			mu.Unlock()
+
+			// block only the first write
+			if total == 1 {
+				writeStarted <- struct{}{}
+				<-releaseWrite
+			}
+
+			return nil
+		},
+	})
+
+	h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test1", 0))
+
+	// triggers the first (blocking) batch write
+	go h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test2", 0))
+	<-writeStarted
+
+	// should be accumulated because there is a write in progress
+	h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test3", 0))
+	h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test4", 0))
+	h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test5", 0))
+
+	// should be dropped
+	h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test6", 0))
+
+	checkLogMessages([]string{"test3", "test4", "test5"}, h.logs, t)
+
+	if v := h.Dropped(); v != 1 {
+		t.Fatalf("Expected %d dropped log, got %d", 1, v)
+	}
+
+	close(releaseWrite)
+
+	if err := h.WriteAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(writes) != 2 {
+		t.Fatalf("Expected %d writes, got %d", 2, len(writes))
+	}
+	checkLogMessages([]string{"test1", "test2"}, writes[0], t)
+	checkLogMessages([]string{"test3", "test4", "test5"}, writes[1], t)
+}
+
 func TestBatchHandlerWithAttrsAndWithGroup(t *testing.T) {
 	h0 := NewBatchHandler(BatchOptions{
 		WriteFunc: func(ctx context.Context, logs []*Log) error {
@@ -252,6 +340,55 @@ func TestBatchHandlerWriteAll(t *testing.T) {
 	checkLogMessages([]string{"test1", "test2"}, writeLogs, t)
 }
 
+type testSink struct {
+	logs []*Log
+	err  error
+}
+
+func (s *testSink) Write(ctx context.Context, logs []*Log) error {
+	s.logs = append(s.logs, logs...)
+	return s.err
+}
+
+func TestBatchHandlerWriteAllWithSinks(t *testing.T) {
+	ctx := context.Background()
+
+	writeErr := errors.New("write_error")
+	sinkErr := errors.New("sink_error")
+
+	sink1 := &testSink{}
+	sink2 := &testSink{err: sinkErr}
+	sink3 := &testSink{}
+
+	h := NewBatchHandler(BatchOptions{
+		Sinks: []Sink{sink1},
+		WriteFunc: func(_ context.Context, logs []*Log) error {
+			return writeErr
+		},
+	})
+
+	h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test1", 0))
+
+	err := h.WriteAll(ctx)
+	if !errors.Is(err, writeErr) {
+		t.Fatalf("Expected write error, got %v", err)
+	}
+	checkLogMessages([]string{"test1"}, sink1.logs, t)
+
+	// replace the sinks
+	h.SetSinks(sink2, sink3)
+
+	h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test2", 0))
+
+	err = h.WriteAll(ctx)
+	if !errors.Is(err, writeErr) || !errors.Is(err, sinkErr) {
+		t.Fatalf("Expected both write and sink errors, got %v", err)
+	}
+	checkLogMessages([]string{"test1"}, sink1.logs, t)
+	checkLogMessages([]string{"test2"}, sink2.logs, t)
+	checkLogMessages([]string{"test2"}, sink3.logs, t)
+}
+
 func TestBatchHandlerAttrsFormat(t *testing.T) {
 	ctx := context.Background()
 
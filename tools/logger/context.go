@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+type ctxAttrsKey struct{}
+
+// ContextWithAttrs returns a copy of ctx with the specified attributes
+// appended to the ones already stored in the parent context (if any).
+//
+// The context attributes are added to every log record
+// handled by [BatchHandler] with the returned context.
+//
+// Example:
+//
+//	ctx = logger.ContextWithAttrs(ctx, slog.String("requestId", "abc"))
+//	app.Logger().InfoContext(ctx, "Example message") // will include requestId
+func ContextWithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	existing := AttrsFromContext(ctx)
+
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+
+	return context.WithValue(ctx, ctxAttrsKey{}, merged)
+}
+
+// AttrsFromContext returns the log attributes stored in ctx with [ContextWithAttrs].
+func AttrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+
+	attrs, _ := ctx.Value(ctxAttrsKey{}).([]slog.Attr)
+
+	return slices.Clip(attrs)
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestContextWithAttrs(t *testing.T) {
+	ctx := context.Background()
+
+	if attrs := AttrsFromContext(ctx); len(attrs) != 0 {
+		t.Fatalf("Expected no attrs, got %v", attrs)
+	}
+
+	if v := ContextWithAttrs(ctx); v != ctx {
+		t.Fatal("Expected the same context when no attrs are provided")
+	}
+
+	ctx1 := ContextWithAttrs(ctx, slog.String("a", "1"))
+	ctx2 := ContextWithAttrs(ctx1, slog.String("b", "2"), slog.Int("c", 3))
+
+	attrs1 := AttrsFromContext(ctx1)
+	if len(attrs1) != 1 || attrs1[0].Key != "a" {
+		t.Fatalf("Expected only attr a, got %v", attrs1)
+	}
+
+	attrs2 := AttrsFromContext(ctx2)
+	if len(attrs2) != 3 || attrs2[0].Key != "a" || attrs2[1].Key != "b" || attrs2[2].Key != "c" {
+		t.Fatalf("Expected attrs a, b, c, got %v", attrs2)
+	}
+}
+
+func TestBatchHandlerHandleWithContextAttrs(t *testing.T) {
+	var logs []*Log
+
+	h := NewBatchHandler(BatchOptions{
+		BeforeAddFunc: func(_ context.Context, log *Log) bool {
+			logs = append(logs, log)
+			return false
+		},
+		WriteFunc: func(_ context.Context, logs []*Log) error {
+			return nil
+		},
+	})
+
+	ctx := ContextWithAttrs(context.Background(), slog.String("requestId", "abc"), slog.String("b", "ctx"))
+
+	l := slog.New(h).With("a", 1).WithGroup("sub")
+	l.InfoContext(ctx, "test", "b", "record")
+
+	if len(logs) != 1 {
+		t.Fatalf("Expected 1 log, got %d", len(logs))
+	}
+
+	raw, err := logs[0].Data.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"a":1,"b":"ctx","requestId":"abc","sub":{"b":"record"}}`
+	if string(raw) != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, raw)
+	}
+
+	// explicit top level record attrs take precedence
+	logs = nil
+	slog.New(h).Log(ctx, slog.LevelInfo, "test", "b", "record")
+
+	raw, _ = logs[0].Data.MarshalJSON()
+	expected = `{"b":"record","requestId":"abc"}`
+	if string(raw) != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, raw)
+	}
+}
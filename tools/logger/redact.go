@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactReplacement is the default replacement of the redacted values.
+const DefaultRedactReplacement = "[REDACTED]"
+
+// Common redaction patterns.
+var (
+	// RedactTokensPattern matches JWTs and the value of the "Bearer" authorization scheme.
+	RedactTokensPattern = regexp.MustCompile(`eyJ[\w-]*\.[\w-]+\.[\w-]*|(?i:bearer\s+)[\w\-.~+/]+=*`)
+
+	// RedactEmailsPattern matches email addresses.
+	RedactEmailsPattern = regexp.MustCompile(`[\w.%+\-]+@[\w\-]+(?:\.[\w\-]+)+`)
+)
+
+// Redactor masks sensitive information in the logs message and data.
+type Redactor struct {
+	// Patterns is a list of regular expressions whose matches
+	// will be replaced in the log message and string data values.
+	Patterns []*regexp.Regexp
+
+	// Keys is a list of data keys (case-insensitive) whose values
+	// will be fully replaced, no matter of their type and nesting level.
+	Keys []string
+
+	// Replacement is the string to use for the redacted values.
+	// If empty, fallbacks to [DefaultRedactReplacement].
+	Replacement string
+}
+
+// IsEmpty reports whether the redactor has no rules and therefore is no-op.
+func (r *Redactor) IsEmpty() bool {
+	return r == nil || (len(r.Patterns) == 0 && len(r.Keys) == 0)
+}
+
+// Redact replaces in place the sensitive information of the provided log.
+func (r *Redactor) Redact(log *Log) {
+	if r.IsEmpty() || log == nil {
+		return
+	}
+
+	log.Message = r.redactString(log.Message)
+
+	for k, v := range log.Data {
+		log.Data[k] = r.redactValue(k, v)
+	}
+}
+
+// RedactValue returns a redacted copy of the provided value.
+//
+// If key is one of the redacted keys the entire value is replaced,
+// otherwise the patterns are applied to the string values of
+// the value and its nested maps and slices.
+func (r *Redactor) RedactValue(key string, value any) any {
+	if r.IsEmpty() {
+		return value
+	}
+
+	return r.redactValue(key, value)
+}
+
+func (r *Redactor) replacement() string {
+	if r.Replacement == "" {
+		return DefaultRedactReplacement
+	}
+	return r.Replacement
+}
+
+func (r *Redactor) isRedactedKey(key string) bool {
+	for _, k := range r.Keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) redactString(str string) string {
+	for _, p := range r.Patterns {
+		str = p.ReplaceAllString(str, r.replacement())
+	}
+	return str
+}
+
+func (r *Redactor) redactValue(key string, value any) any {
+	if key != "" && r.isRedactedKey(key) {
+		return r.replacement()
+	}
+
+	switch v := value.(type) {
+	case string:
+		return r.redactString(v)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for subKey, subValue := range v {
+			out[subKey] = r.redactValue(subKey, subValue)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = r.redactValue("", item)
+		}
+		return out
+	case []string:
+		out := make([]string, len(v))
+		for i, item := range v {
+			out[i] = r.redactString(item)
+		}
+		return out
+	case error:
+		return r.redactString(v.Error())
+	default:
+		return value
+	}
+}
@@ -0,0 +1,142 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestRedactorIsEmpty(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		redactor *Redactor
+		expected bool
+	}{
+		{"nil", nil, true},
+		{"zero value", &Redactor{}, true},
+		{"with replacement only", &Redactor{Replacement: "***"}, true},
+		{"with patterns", &Redactor{Patterns: []*regexp.Regexp{RedactEmailsPattern}}, false},
+		{"with keys", &Redactor{Keys: []string{"password"}}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if v := s.redactor.IsEmpty(); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestRedactorRedact(t *testing.T) {
+	newLog := func() *Log {
+		return &Log{
+			Message: "GET /api/test?email=test@example.com",
+			Data: map[string]any{
+				"auth":     "Bearer abc.def-123",
+				"token":    "eyJhbGciOiJIUzI1NiJ9.eyJpZCI6InRlc3QifQ.abc",
+				"password": 123456,
+				"nested": map[string]any{
+					"PASSWORD": "secret",
+					"emails":   []any{"a@example.com", 1},
+					"list":     []string{"b@example.com"},
+				},
+				"error": errors.New("invalid user test@example.com"),
+				"other": 123,
+			},
+		}
+	}
+
+	scenarios := []struct {
+		name            string
+		redactor        *Redactor
+		expectedMessage string
+		expectedData    string
+	}{
+		{
+			"empty redactor",
+			&Redactor{},
+			"GET /api/test?email=test@example.com",
+			`{"auth":"Bearer abc.def-123","error":{},"nested":{"PASSWORD":"secret","emails":["a@example.com",1],"list":["b@example.com"]},"other":123,"password":123456,"token":"eyJhbGciOiJIUzI1NiJ9.eyJpZCI6InRlc3QifQ.abc"}`,
+		},
+		{
+			"tokens and keys",
+			&Redactor{
+				Patterns: []*regexp.Regexp{RedactTokensPattern},
+				Keys:     []string{"password"},
+			},
+			"GET /api/test?email=test@example.com",
+			`{"auth":"[REDACTED]","error":"invalid user test@example.com","nested":{"PASSWORD":"[REDACTED]","emails":["a@example.com",1],"list":["b@example.com"]},"other":123,"password":"[REDACTED]","token":"[REDACTED]"}`,
+		},
+		{
+			"emails with custom replacement",
+			&Redactor{
+				Patterns:    []*regexp.Regexp{RedactEmailsPattern},
+				Replacement: "***",
+			},
+			"GET /api/test?email=***",
+			`{"auth":"Bearer abc.def-123","error":"invalid user ***","nested":{"PASSWORD":"secret","emails":["***",1],"list":["***"]},"other":123,"password":123456,"token":"eyJhbGciOiJIUzI1NiJ9.eyJpZCI6InRlc3QifQ.abc"}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			log := newLog()
+
+			s.redactor.Redact(log)
+
+			if log.Message != s.expectedMessage {
+				t.Fatalf("Expected message %q, got %q", s.expectedMessage, log.Message)
+			}
+
+			raw, err := json.Marshal(log.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(raw) != s.expectedData {
+				t.Fatalf("Expected data\n%s\ngot\n%s", s.expectedData, raw)
+			}
+		})
+	}
+}
+
+func TestRedactorRedactValue(t *testing.T) {
+	redactor := &Redactor{
+		Patterns: []*regexp.Regexp{RedactTokensPattern},
+		Keys:     []string{"password"},
+	}
+
+	scenarios := []struct {
+		name     string
+		redactor *Redactor
+		key      string
+		value    any
+		expected string
+	}{
+		{"empty redactor", &Redactor{}, "password", "123", `"123"`},
+		{"redacted key", redactor, "Password", 123, `"[REDACTED]"`},
+		{"string pattern", redactor, "", "Bearer abc", `"[REDACTED]"`},
+		{
+			"nested value",
+			redactor,
+			"",
+			map[string]any{"password": "123", "list": []any{"Bearer abc", 1}},
+			`{"list":["[REDACTED]",1],"password":"[REDACTED]"}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			raw, err := json.Marshal(s.redactor.RedactValue(s.key, s.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(raw) != s.expected {
+				t.Fatalf("Expected %s, got %s", s.expected, raw)
+			}
+		})
+	}
+}
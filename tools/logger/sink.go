@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sink defines an external destination for the batched logs
+// (e.g. Loki, OTLP collector, syslog server, etc.).
+type Sink interface {
+	// Write ships the provided logs batch to the sink destination.
+	Write(ctx context.Context, logs []*Log) error
+}
+
+// DefaultSinkHTTPTimeout is the default request timeout used by the
+// HTTP based sinks when no custom [http.Client] is specified.
+const DefaultSinkHTTPTimeout = 30 * time.Second
+
+// ErrSinkClosed is returned when trying to write to an already closed sink.
+var ErrSinkClosed = errors.New("the log sink is closed")
+
+// SinkResponseError defines a log sink HTTP response error.
+type SinkResponseError struct {
+	Sink   string
+	Status int
+	Body   []byte
+}
+
+// Error implements the std error interface.
+func (err *SinkResponseError) Error() string {
+	return fmt.Sprintf("%s: failed to ship logs (status %d): %s", err.Sink, err.Status, strings.TrimSpace(string(err.Body)))
+}
+
+// postSinkJSON sends a POST request with the JSON encoded data to the specified url.
+//
+// Non 2xx responses are normalized to [SinkResponseError].
+func postSinkJSON(ctx context.Context, client *http.Client, sink string, url string, token string, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: DefaultSinkHTTPTimeout}
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		resBody, _ := io.ReadAll(io.LimitReader(res.Body, 1<<16))
+		return &SinkResponseError{Sink: sink, Status: res.StatusCode, Body: resBody}
+	}
+
+	// drain the body to allow the connection reuse
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+var _ Sink = (*AsyncSink)(nil)
+
+// AsyncSinkOptions are options for the AsyncSink.
+type AsyncSinkOptions struct {
+	// Level reports the minimum level of the logs to ship.
+	// Logs with lower levels are discarded.
+	// If nil, all logs are shipped.
+	Level slog.Leveler
+
+	// QueueSize specifies how many log batches could wait to be shipped
+	// before starting to drop the new ones.
+	// If not set or 0, fallback to 100 by default.
+	QueueSize int
+
+	// OnError is an optional function that is invoked when the
+	// wrapped sink fails to ship a logs batch.
+	OnError func(err error)
+}
+
+// NewAsyncSink wraps the provided sink so that the logs are filtered
+// by level and shipped in a separate goroutine, without blocking
+// the logs handler.
+//
+// When the wrapped sink can't keep up and the queue is full,
+// the new batches are dropped (see [AsyncSink.Dropped]).
+//
+// Call [AsyncSink.Close] to flush the pending batches and stop the worker goroutine.
+func NewAsyncSink(sink Sink, options AsyncSinkOptions) *AsyncSink {
+	if options.QueueSize <= 0 {
+		options.QueueSize = 100
+	}
+
+	s := &AsyncSink{
+		sink:    sink,
+		options: options,
+		queue:   make(chan []*Log, options.QueueSize),
+		done:    make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+// AsyncSink is a level filtering, non-blocking [Sink] wrapper.
+type AsyncSink struct {
+	sink    Sink
+	options AsyncSinkOptions
+	queue   chan []*Log
+	done    chan struct{}
+	dropped atomic.Int64
+	mux     sync.RWMutex
+	closed  bool
+}
+
+// Sink returns the wrapped sink.
+func (s *AsyncSink) Sink() Sink {
+	return s.sink
+}
+
+// Dropped returns the total number of logs that were
+// discarded because the sink queue was full.
+func (s *AsyncSink) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Write filters the logs by the configured level and enqueues them for shipping.
+//
+// It never blocks - if the queue is full the logs are dropped.
+func (s *AsyncSink) Write(ctx context.Context, logs []*Log) error {
+	filtered := make([]*Log, 0, len(logs))
+	for _, l := range logs {
+		if s.options.Level == nil || l.Level >= s.options.Level.Level() {
+			filtered = append(filtered, l)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if s.closed {
+		return ErrSinkClosed
+	}
+
+	select {
+	case s.queue <- filtered:
+	default:
+		s.dropped.Add(int64(len(filtered)))
+	}
+
+	return nil
+}
+
+// Close stops accepting new logs and waits for the already queued ones to be shipped.
+func (s *AsyncSink) Close() error {
+	s.mux.Lock()
+	if s.closed {
+		s.mux.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.queue)
+	s.mux.Unlock()
+
+	<-s.done
+
+	return nil
+}
+
+func (s *AsyncSink) run() {
+	defer close(s.done)
+
+	for logs := range s.queue {
+		if err := s.sink.Write(context.Background(), logs); err != nil && s.options.OnError != nil {
+			s.options.OnError(err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var _ Sink = (*LokiSink)(nil)
+
+// LokiSink ships logs to the Grafana Loki push API.
+//
+// The logs are grouped in streams by their level.
+type LokiSink struct {
+	// URL is the Loki push API endpoint (e.g. "http://localhost:3100/loki/api/v1/push").
+	URL string
+
+	// Token is an optional bearer token used for authorization.
+	Token string
+
+	// Labels are optional static labels attached to every stream.
+	Labels map[string]string
+
+	// HTTPClient is an optional custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// Write implements the [Sink] interface.
+func (s *LokiSink) Write(ctx context.Context, logs []*Log) error {
+	streams := map[string]*lokiStream{}
+	order := []string{}
+
+	for _, l := range logs {
+		level := strings.ToLower(l.Level.String())
+
+		stream, ok := streams[level]
+		if !ok {
+			labels := make(map[string]string, len(s.Labels)+1)
+			for k, v := range s.Labels {
+				labels[k] = v
+			}
+			labels["level"] = level
+
+			stream = &lokiStream{Stream: labels}
+			streams[level] = stream
+			order = append(order, level)
+		}
+
+		line, err := json.Marshal(map[string]any{
+			"message": l.Message,
+			"data":    l.Data,
+		})
+		if err != nil {
+			return err
+		}
+
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(l.Time.UnixNano(), 10),
+			string(line),
+		})
+	}
+
+	payload := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{
+		Streams: make([]*lokiStream, 0, len(order)),
+	}
+	for _, level := range order {
+		payload.Streams = append(payload.Streams, streams[level])
+	}
+
+	return postSinkJSON(ctx, s.HTTPClient, "loki", s.URL, s.Token, payload)
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLokiSinkWrite(t *testing.T) {
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	now := time.Unix(0, 1700000000000000000)
+
+	sink := &LokiSink{
+		URL:    server.URL,
+		Labels: map[string]string{"service": "test"},
+	}
+
+	err := sink.Write(context.Background(), []*Log{
+		{Time: now, Level: slog.LevelInfo, Message: "test1", Data: map[string]any{"a": 1}},
+		{Time: now, Level: slog.LevelError, Message: "test2"},
+		{Time: now, Level: slog.LevelInfo, Message: "test3"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"streams":[` +
+		`{"stream":{"level":"info","service":"test"},"values":[["1700000000000000000","{\"data\":{\"a\":1},\"message\":\"test1\"}"],["1700000000000000000","{\"data\":{},\"message\":\"test3\"}"]]},` +
+		`{"stream":{"level":"error","service":"test"},"values":[["1700000000000000000","{\"data\":{},\"message\":\"test2\"}"]]}` +
+		`]}`
+
+	if body != expected {
+		t.Fatalf("Expected body\n%s\ngot\n%s", expected, body)
+	}
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+var _ Sink = (*OTLPSink)(nil)
+
+// OTLPSink ships logs to an OpenTelemetry collector using
+// the OTLP/HTTP protocol with JSON encoding.
+type OTLPSink struct {
+	// URL is the OTLP logs endpoint (e.g. "http://localhost:4318/v1/logs").
+	URL string
+
+	// Token is an optional bearer token used for authorization.
+	Token string
+
+	// ServiceName is the "service.name" resource attribute value.
+	ServiceName string
+
+	// HTTPClient is an optional custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// Write implements the [Sink] interface.
+func (s *OTLPSink) Write(ctx context.Context, logs []*Log) error {
+	records := make([]map[string]any, 0, len(logs))
+
+	for _, l := range logs {
+		records = append(records, map[string]any{
+			"timeUnixNano":   strconv.FormatInt(l.Time.UnixNano(), 10),
+			"severityNumber": otlpSeverityNumber(l.Level),
+			"severityText":   l.Level.String(),
+			"body":           map[string]any{"stringValue": l.Message},
+			"attributes":     otlpAttributes(l.Data),
+		})
+	}
+
+	payload := map[string]any{
+		"resourceLogs": []any{
+			map[string]any{
+				"resource": map[string]any{
+					"attributes": otlpAttributes(map[string]any{"service.name": s.ServiceName}),
+				},
+				"scopeLogs": []any{
+					map[string]any{
+						"scope":      map[string]any{"name": "pocketbase"},
+						"logRecords": records,
+					},
+				},
+			},
+		},
+	}
+
+	return postSinkJSON(ctx, s.HTTPClient, "otlp", s.URL, s.Token, payload)
+}
+
+// otlpSeverityNumber maps the slog level to the OTLP severity number
+// (DEBUG=5, INFO=9, WARN=13, ERROR=17).
+func otlpSeverityNumber(level slog.Level) int {
+	n := int(level) + 9
+
+	return max(1, min(24, n))
+}
+
+// otlpAttributes converts the log data into OTLP key-value attributes (sorted by key).
+//
+// Non-scalar values are serialized as JSON strings.
+func otlpAttributes(data map[string]any) []map[string]any {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]map[string]any, 0, len(keys))
+
+	for _, k := range keys {
+		var value map[string]any
+
+		switch v := data[k].(type) {
+		case string:
+			value = map[string]any{"stringValue": v}
+		case bool:
+			value = map[string]any{"boolValue": v}
+		case int:
+			value = map[string]any{"intValue": strconv.Itoa(v)}
+		case int64:
+			value = map[string]any{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]any{"doubleValue": v}
+		default:
+			raw, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			value = map[string]any{"stringValue": string(raw)}
+		}
+
+		result = append(result, map[string]any{"key": k, "value": value})
+	}
+
+	return result
+}
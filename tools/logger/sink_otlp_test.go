@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOTLPSinkWrite(t *testing.T) {
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+	}))
+	defer server.Close()
+
+	now := time.Unix(0, 1700000000000000000)
+
+	sink := &OTLPSink{
+		URL:         server.URL,
+		ServiceName: "test",
+	}
+
+	err := sink.Write(context.Background(), []*Log{
+		{Time: now, Level: slog.LevelWarn, Message: "test1", Data: map[string]any{
+			"b": "abc",
+			"a": 1.5,
+			"c": true,
+			"d": map[string]any{"x": 1},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"test"}}]},"scopeLogs":[{"logRecords":[{` +
+		`"attributes":[{"key":"a","value":{"doubleValue":1.5}},{"key":"b","value":{"stringValue":"abc"}},{"key":"c","value":{"boolValue":true}},{"key":"d","value":{"stringValue":"{\"x\":1}"}}],` +
+		`"body":{"stringValue":"test1"},"severityNumber":13,"severityText":"WARN","timeUnixNano":"1700000000000000000"}],"scope":{"name":"pocketbase"}}]}]}`
+
+	if body != expected {
+		t.Fatalf("Expected body\n%s\ngot\n%s", expected, body)
+	}
+}
+
+func TestOTLPSeverityNumber(t *testing.T) {
+	scenarios := []struct {
+		level    slog.Level
+		expected int
+	}{
+		{-100, 1},
+		{slog.LevelDebug, 5},
+		{slog.LevelInfo, 9},
+		{slog.LevelWarn, 13},
+		{slog.LevelError, 17},
+		{100, 24},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.level.String(), func(t *testing.T) {
+			if v := otlpSeverityNumber(s.level); v != s.expected {
+				t.Fatalf("Expected %d, got %d", s.expected, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+var _ Sink = (*SyslogSink)(nil)
+
+// syslogFacilityLocal0 is the syslog "local0" facility code.
+const syslogFacilityLocal0 = 16
+
+// SyslogSink ships logs to a syslog server using the RFC 5424 message format.
+//
+// A new connection is established for each logs batch.
+// With stream based networks ("tcp", "unix") the messages are
+// newline delimited, otherwise each message is sent as a separate datagram.
+type SyslogSink struct {
+	// Network is the connection network ("udp", "tcp", "unix", "unixgram").
+	Network string
+
+	// Address is the syslog server address (e.g. "localhost:514" or "/dev/log").
+	Address string
+
+	// AppName is the syslog APP-NAME header value.
+	AppName string
+
+	// Hostname is the syslog HOSTNAME header value.
+	// If empty, fallbacks to [os.Hostname].
+	Hostname string
+
+	// DialTimeout is the max connection timeout.
+	// If not set or 0, fallback to 10s by default.
+	DialTimeout time.Duration
+}
+
+// Write implements the [Sink] interface.
+func (s *SyslogSink) Write(ctx context.Context, logs []*Log) error {
+	timeout := s.DialTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+
+	conn, err := dialer.DialContext(ctx, s.Network, s.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+
+	hostname := s.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+
+	isStream := s.Network == "tcp" || s.Network == "tcp4" || s.Network == "tcp6" || s.Network == "unix"
+
+	for _, l := range logs {
+		msg := s.format(hostname, l)
+
+		if isStream {
+			msg = append(msg, '\n')
+		}
+
+		if _, err := conn.Write(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SyslogSink) format(hostname string, l *Log) []byte {
+	var buf bytes.Buffer
+
+	priority := syslogFacilityLocal0*8 + syslogSeverity(l.Level)
+
+	fmt.Fprintf(
+		&buf,
+		"<%d>1 %s %s %s %d - - %s",
+		priority,
+		l.Time.UTC().Format(time.RFC3339Nano),
+		syslogHeaderValue(hostname),
+		syslogHeaderValue(s.AppName),
+		os.Getpid(),
+		l.Message,
+	)
+
+	if len(l.Data) > 0 {
+		if raw, err := json.Marshal(l.Data); err == nil {
+			buf.WriteByte(' ')
+			buf.Write(raw)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// syslogSeverity maps the slog level to the syslog severity code.
+func syslogSeverity(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return 3 // err
+	case level >= slog.LevelWarn:
+		return 4 // warning
+	case level >= slog.LevelInfo:
+		return 6 // info
+	default:
+		return 7 // debug
+	}
+}
+
+// syslogHeaderValue normalizes the header field value by replacing
+// the whitespaces and returning the NILVALUE ("-") for empty fields.
+func syslogHeaderValue(v string) string {
+	v = strings.Join(strings.Fields(v), "_")
+	if v == "" {
+		return "-"
+	}
+	return v
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSyslogSinkWriteTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	lines := make(chan string, 10)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	sink := &SyslogSink{
+		Network:  "tcp",
+		Address:  listener.Addr().String(),
+		AppName:  "test app",
+		Hostname: "localhost",
+	}
+
+	err = sink.Write(context.Background(), []*Log{
+		{Time: now, Level: slog.LevelError, Message: "test1", Data: map[string]any{"a": 1}},
+		{Time: now, Level: slog.LevelDebug, Message: "test2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pid := os.Getpid()
+
+	expected := []string{
+		fmt.Sprintf(`<131>1 2024-01-02T03:04:05Z localhost test_app %d - - test1 {"a":1}`, pid),
+		fmt.Sprintf(`<135>1 2024-01-02T03:04:05Z localhost test_app %d - - test2`, pid),
+	}
+
+	var result []string
+	for line := range lines {
+		result = append(result, line)
+	}
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d messages, got %d: %v", len(expected), len(result), result)
+	}
+
+	for i, line := range expected {
+		if result[i] != line {
+			t.Errorf("[%d] Expected\n%s\ngot\n%s", i, line, result[i])
+		}
+	}
+}
+
+func TestSyslogSinkWriteUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sink := &SyslogSink{
+		Network: "udp",
+		Address: conn.LocalAddr().String(),
+	}
+
+	err = sink.Write(context.Background(), []*Log{
+		{Time: time.Now(), Level: slog.LevelWarn, Message: "test"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// local0.warning with NILVALUE app name
+	expectedPrefix := "<132>1 "
+	if msg := string(buf[:n]); len(msg) < len(expectedPrefix) || msg[:len(expectedPrefix)] != expectedPrefix {
+		t.Fatalf("Expected message starting with %q, got %q", expectedPrefix, msg)
+	}
+}
+
+func TestSyslogSinkWriteFailure(t *testing.T) {
+	sink := &SyslogSink{
+		Network:     "tcp",
+		Address:     "127.0.0.1:1",
+		DialTimeout: 100 * time.Millisecond,
+	}
+
+	err := sink.Write(context.Background(), []*Log{{Message: "test"}})
+	if err == nil {
+		t.Fatal("Expected dial error")
+	}
+}
@@ -0,0 +1,177 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type blockingSink struct {
+	mu      sync.Mutex
+	logs    []*Log
+	release chan struct{}
+	err     error
+}
+
+func (s *blockingSink) Write(ctx context.Context, logs []*Log) error {
+	if s.release != nil {
+		<-s.release
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logs = append(s.logs, logs...)
+
+	return s.err
+}
+
+func TestAsyncSinkLevelFilter(t *testing.T) {
+	sink := &blockingSink{}
+
+	s := NewAsyncSink(sink, AsyncSinkOptions{Level: slog.LevelWarn})
+
+	err := s.Write(context.Background(), []*Log{
+		{Message: "debug", Level: slog.LevelDebug},
+		{Message: "info", Level: slog.LevelInfo},
+		{Message: "warn", Level: slog.LevelWarn},
+		{Message: "error", Level: slog.LevelError},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Close()
+
+	checkLogMessages([]string{"warn", "error"}, sink.logs, t)
+}
+
+func TestAsyncSinkDropOnFullQueue(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{})}
+
+	s := NewAsyncSink(sink, AsyncSinkOptions{QueueSize: 1})
+
+	// the first batch is consumed by the worker (and blocks),
+	// the second one waits in the queue and the rest are dropped
+	for i := 0; i < 4; i++ {
+		if err := s.Write(context.Background(), []*Log{{Message: "test"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		// give the worker time to pick the first batch
+		if i == 0 {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	if v := s.Dropped(); v != 2 {
+		t.Fatalf("Expected %d dropped logs, got %d", 2, v)
+	}
+
+	close(sink.release)
+	s.Close()
+
+	if len(sink.logs) != 2 {
+		t.Fatalf("Expected %d shipped logs, got %d", 2, len(sink.logs))
+	}
+}
+
+func TestAsyncSinkOnError(t *testing.T) {
+	sinkErr := errors.New("test")
+
+	var reportedErr error
+
+	s := NewAsyncSink(&blockingSink{err: sinkErr}, AsyncSinkOptions{
+		OnError: func(err error) {
+			reportedErr = err
+		},
+	})
+
+	s.Write(context.Background(), []*Log{{Message: "test"}})
+	s.Close()
+
+	if !errors.Is(reportedErr, sinkErr) {
+		t.Fatalf("Expected the sink error to be reported, got %v", reportedErr)
+	}
+}
+
+func TestAsyncSinkClose(t *testing.T) {
+	s := NewAsyncSink(&blockingSink{}, AsyncSinkOptions{})
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// multiple close calls should be no-op
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := s.Write(context.Background(), []*Log{{Message: "test"}})
+	if !errors.Is(err, ErrSinkClosed) {
+		t.Fatalf("Expected ErrSinkClosed, got %v", err)
+	}
+}
+
+func TestPostSinkJSON(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		status      int
+		token       string
+		expectError bool
+	}{
+		{"2xx response without token", 204, "", false},
+		{"2xx response with token", 200, "test_token", false},
+		{"non 2xx response", 400, "", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+
+				if v := r.Header.Get("Content-Type"); v != "application/json" {
+					t.Errorf("Expected JSON content type, got %q", v)
+				}
+
+				expectedAuth := ""
+				if s.token != "" {
+					expectedAuth = "Bearer " + s.token
+				}
+				if v := r.Header.Get("Authorization"); v != expectedAuth {
+					t.Errorf("Expected Authorization header %q, got %q", expectedAuth, v)
+				}
+
+				w.WriteHeader(s.status)
+				w.Write([]byte("test_body"))
+			}))
+			defer server.Close()
+
+			err := postSinkJSON(context.Background(), nil, "test", server.URL, s.token, map[string]any{"a": 1})
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				var resErr *SinkResponseError
+				if !errors.As(err, &resErr) {
+					t.Fatalf("Expected SinkResponseError, got %T", err)
+				}
+
+				if resErr.Status != s.status || !strings.Contains(resErr.Error(), "test_body") {
+					t.Fatalf("Unexpected response error %v", resErr)
+				}
+			}
+		})
+	}
+}
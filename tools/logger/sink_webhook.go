@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Sink = (*WebhookSink)(nil)
+
+// WebhookSink ships logs as JSON array to an arbitrary HTTP endpoint.
+//
+// The request body has the following format:
+//
+//	[{"time":"...", "level":0, "message":"...", "data":{...}}, ...]
+type WebhookSink struct {
+	// URL is the webhook endpoint.
+	URL string
+
+	// Token is an optional bearer token used for authorization.
+	Token string
+
+	// HTTPClient is an optional custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// Write implements the [Sink] interface.
+func (s *WebhookSink) Write(ctx context.Context, logs []*Log) error {
+	type webhookLog struct {
+		Time    time.Time          `json:"time"`
+		Data    types.JSONMap[any] `json:"data"`
+		Message string             `json:"message"`
+		Level   int                `json:"level"`
+	}
+
+	payload := make([]webhookLog, 0, len(logs))
+	for _, l := range logs {
+		payload = append(payload, webhookLog{
+			Time:    l.Time,
+			Data:    l.Data,
+			Message: l.Message,
+			Level:   int(l.Level),
+		})
+	}
+
+	return postSinkJSON(ctx, s.HTTPClient, "webhook", s.URL, s.Token, payload)
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookSinkWrite(t *testing.T) {
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	sink := &WebhookSink{URL: server.URL}
+
+	err := sink.Write(context.Background(), []*Log{
+		{Time: now, Level: slog.LevelInfo, Message: "test1", Data: map[string]any{"a": 1}},
+		{Time: now, Level: slog.LevelError, Message: "test2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `[{"time":"2024-01-02T03:04:05Z","data":{"a":1},"message":"test1","level":0},{"time":"2024-01-02T03:04:05Z","data":{},"message":"test2","level":8}]`
+
+	if body != expected {
+		t.Fatalf("Expected body\n%s\ngot\n%s", expected, body)
+	}
+}
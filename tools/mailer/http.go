@@ -0,0 +1,104 @@
+package mailer
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+// DefaultHTTPTimeout is the default request timeout used by the HTTP API mail clients
+// when no custom [http.Client] is specified.
+const DefaultHTTPTimeout = httpx.DefaultTimeout
+
+// HTTPResponseError defines a HTTP API mail provider response error.
+type HTTPResponseError = httpx.ResponseError
+
+// httpSendAction is the HTTP API mail clients [HTTPResponseError] action.
+const httpSendAction = "send email"
+
+// httpAttachment defines a single already read message attachment.
+type httpAttachment struct {
+	Name        string
+	ContentType string
+	Content     []byte
+	Inline      bool
+}
+
+// readAttachments reads and returns all regular and inline attachments of the provided message
+// sorted by their name (to ensure deterministic payloads).
+func readAttachments(m *Message) ([]*httpAttachment, error) {
+	result := make([]*httpAttachment, 0, len(m.Attachments)+len(m.InlineAttachments))
+
+	read := func(list map[string]io.Reader, inline bool) error {
+		names := make([]string, 0, len(list))
+		for name := range list {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			r, mime, err := detectReaderMimeType(list[name])
+			if err != nil {
+				return err
+			}
+
+			content, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, &httpAttachment{
+				Name:        name,
+				ContentType: mime,
+				Content:     content,
+				Inline:      inline,
+			})
+		}
+
+		return nil
+	}
+
+	if err := read(m.Attachments, false); err != nil {
+		return nil, err
+	}
+
+	if err := read(m.InlineAttachments, true); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ParseTags converts the provided list of "name:value" tags into a map.
+//
+// Tags without explicit value are stored with value "true".
+func ParseTags(tags []string) map[string]string {
+	result := make(map[string]string, len(tags))
+
+	for _, tag := range tags {
+		name, value, ok := strings.Cut(tag, ":")
+
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if !ok {
+			value = "true"
+		}
+
+		result[name] = strings.TrimSpace(value)
+	}
+
+	return result
+}
+
+// errMissingRecipients is returned when a message doesn't have any recipient.
+var errMissingRecipients = errors.New("the message must have at least one recipient")
+
+func hasRecipients(m *Message) bool {
+	return len(m.To) > 0 || len(m.Cc) > 0 || len(m.Bcc) > 0
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	t.Parallel()
+
+	result := ParseTags([]string{"a", "b:1", " c : 2 ", ":invalid", ""})
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"a":"true","b":"1","c":"2"}`
+	if str := string(raw); str != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, str)
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// newRoundTripClient creates a new HTTP client that doesn't make real
+// network requests and only reports the requested url.
+func newRoundTripClient(onRequest func(url string)) *http.Client {
+	return &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			onRequest(req.URL.String())
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	}
+}
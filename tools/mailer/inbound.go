@@ -0,0 +1,196 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+)
+
+// maxMIMEDepth limits the nesting level of the multipart MIME parts.
+const maxMIMEDepth = 10
+
+var mimeWordDecoder = &mime.WordDecoder{}
+
+// ParseMIME parses a raw RFC 5322 email message into [Message].
+//
+// The top level message headers are stored in [Message.Headers]
+// (multiple header values are joined with ", ").
+//
+// Parts with "Content-Disposition: inline" and "Content-ID" header are
+// stored in [Message.InlineAttachments] (keyed by their content id),
+// while all other non-body parts are stored in [Message.Attachments].
+func ParseMIME(r io.Reader) (*Message, error) {
+	raw, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Message{
+		Subject:           decodeMIMEHeader(raw.Header.Get("Subject")),
+		Headers:           make(map[string]string, len(raw.Header)),
+		Attachments:       map[string]io.Reader{},
+		InlineAttachments: map[string]io.Reader{},
+	}
+
+	for k, v := range raw.Header {
+		m.Headers[k] = decodeMIMEHeader(strings.Join(v, ", "))
+	}
+
+	if from, _ := parseAddressList(raw.Header, "From"); len(from) > 0 {
+		m.From = from[0]
+	}
+
+	if m.To, err = parseAddressList(raw.Header, "To"); err != nil {
+		return nil, err
+	}
+
+	if m.Cc, err = parseAddressList(raw.Header, "Cc"); err != nil {
+		return nil, err
+	}
+
+	if m.Bcc, err = parseAddressList(raw.Header, "Bcc"); err != nil {
+		return nil, err
+	}
+
+	err = parseMIMEPart(m, raw.Header.Get("Content-Type"), raw.Header.Get("Content-Transfer-Encoding"), "", "", raw.Body, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func parseAddressList(h mail.Header, key string) ([]mail.Address, error) {
+	list, err := h.AddressList(key)
+	if err != nil {
+		if errors.Is(err, mail.ErrHeaderNotPresent) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid %s header: %w", key, err)
+	}
+
+	result := make([]mail.Address, len(list))
+	for i, addr := range list {
+		result[i] = *addr
+	}
+
+	return result, nil
+}
+
+func parseMIMEPart(
+	m *Message,
+	contentType string,
+	transferEncoding string,
+	disposition string,
+	contentId string,
+	body io.Reader,
+	depth int,
+) error {
+	if depth > maxMIMEDepth {
+		return errors.New("too many nested MIME parts")
+	}
+
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// fallback to a plain text (similar to most email clients)
+		mediaType = "text/plain"
+		params = map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			err = parseMIMEPart(
+				m,
+				part.Header.Get("Content-Type"),
+				part.Header.Get("Content-Transfer-Encoding"),
+				part.Header.Get("Content-Disposition"),
+				part.Header.Get("Content-ID"),
+				part,
+				depth+1,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransferEncoding(body, transferEncoding))
+	if err != nil {
+		return err
+	}
+
+	dispositionType, dispositionParams, _ := mime.ParseMediaType(disposition)
+
+	if dispositionType != "attachment" {
+		switch {
+		case mediaType == "text/plain" && m.Text == "":
+			m.Text = string(content)
+			return nil
+		case mediaType == "text/html" && m.HTML == "":
+			m.HTML = string(content)
+			return nil
+		}
+	}
+
+	contentId = strings.Trim(contentId, "<>")
+
+	if dispositionType == "inline" && contentId != "" {
+		m.InlineAttachments[contentId] = bytes.NewReader(content)
+		return nil
+	}
+
+	name := decodeMIMEHeader(dispositionParams["filename"])
+	if name == "" {
+		name = decodeMIMEHeader(params["name"])
+	}
+	if name == "" {
+		name = contentId
+	}
+	if name == "" {
+		name = fmt.Sprintf("attachment%d", len(m.Attachments)+1)
+	}
+
+	m.Attachments[name] = bytes.NewReader(content)
+
+	return nil
+}
+
+func decodeTransferEncoding(r io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r) // new lines are ignored
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+func decodeMIMEHeader(value string) string {
+	decoded, err := mimeWordDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
@@ -0,0 +1,151 @@
+package mailer
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseMIME(t *testing.T) {
+	t.Parallel()
+
+	raw := strings.ReplaceAll(`From: "John Doe" <john@example.com>
+To: support@example.com, "Test" <test@example.com>
+Cc: cc@example.com
+Subject: =?UTF-8?B?SGVsbG8gd29ybGQ=?=
+Message-ID: <abc@example.com>
+In-Reply-To: <xyz@example.com>
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="mixed"
+
+--mixed
+Content-Type: multipart/related; boundary="related"
+
+--related
+Content-Type: multipart/alternative; boundary="alt"
+
+--alt
+Content-Type: text/plain; charset=UTF-8
+Content-Transfer-Encoding: quoted-printable
+
+test=20text
+--alt
+Content-Type: text/html; charset=UTF-8
+
+<p>test html</p>
+--alt--
+--related
+Content-Type: image/png
+Content-Disposition: inline
+Content-ID: <logo>
+Content-Transfer-Encoding: base64
+
+aW5s
+aW5l
+--related--
+--mixed
+Content-Type: text/plain; name="a.txt"
+Content-Disposition: attachment; filename="a.txt"
+Content-Transfer-Encoding: base64
+
+YWJj
+--mixed--
+`, "\n", "\r\n")
+
+	m, err := ParseMIME(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.From.Address != "john@example.com" || m.From.Name != "John Doe" {
+		t.Fatalf("Invalid From address %v", m.From)
+	}
+
+	if len(m.To) != 2 || m.To[0].Address != "support@example.com" || m.To[1].Address != "test@example.com" {
+		t.Fatalf("Invalid To addresses %v", m.To)
+	}
+
+	if len(m.Cc) != 1 || m.Cc[0].Address != "cc@example.com" {
+		t.Fatalf("Invalid Cc addresses %v", m.Cc)
+	}
+
+	if len(m.Bcc) != 0 {
+		t.Fatalf("Expected no Bcc addresses, got %v", m.Bcc)
+	}
+
+	if m.Subject != "Hello world" {
+		t.Fatalf("Expected decoded subject, got %q", m.Subject)
+	}
+
+	if m.Text != "test text" {
+		t.Fatalf("Expected text %q, got %q", "test text", m.Text)
+	}
+
+	if m.HTML != "<p>test html</p>" {
+		t.Fatalf("Expected html %q, got %q", "<p>test html</p>", m.HTML)
+	}
+
+	if v := m.Headers["In-Reply-To"]; v != "<xyz@example.com>" {
+		t.Fatalf("Expected In-Reply-To header, got %q", v)
+	}
+
+	checkReader := func(list map[string]io.Reader, name string, expected string) {
+		r, ok := list[name]
+		if !ok {
+			t.Fatalf("Missing %q attachment in %v", name, list)
+		}
+
+		content, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(content) != expected {
+			t.Fatalf("Expected %q attachment content %q, got %q", name, expected, content)
+		}
+	}
+
+	if len(m.Attachments) != 1 {
+		t.Fatalf("Expected 1 attachment, got %d", len(m.Attachments))
+	}
+	checkReader(m.Attachments, "a.txt", "abc")
+
+	if len(m.InlineAttachments) != 1 {
+		t.Fatalf("Expected 1 inline attachment, got %d", len(m.InlineAttachments))
+	}
+	checkReader(m.InlineAttachments, "logo", "inline")
+}
+
+func TestParseMIMEPlain(t *testing.T) {
+	t.Parallel()
+
+	m, err := ParseMIME(strings.NewReader("From: a@example.com\r\nTo: b@example.com\r\nSubject: test\r\n\r\nhello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Text != "hello" || m.HTML != "" {
+		t.Fatalf("Expected only plain text body, got text %q and html %q", m.Text, m.HTML)
+	}
+}
+
+func TestParseMIMEInvalid(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name string
+		raw  string
+	}{
+		{"empty", ""},
+		{"invalid To header", "From: a@example.com\r\nTo: invalid\r\n\r\nhello"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			_, err := ParseMIME(strings.NewReader(s.raw))
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+var _ Mailer = (*MailgunClient)(nil)
+
+const (
+	// DefaultMailgunEndpoint is the default (US region) Mailgun API base url.
+	DefaultMailgunEndpoint = "https://api.mailgun.net"
+
+	// DefaultMailgunEUEndpoint is the EU region Mailgun API base url.
+	DefaultMailgunEUEndpoint = "https://api.eu.mailgun.net"
+)
+
+// MailgunClient defines a mail client that sends emails
+// via the Mailgun Messages HTTP API.
+type MailgunClient struct {
+	onSend *hook.Hook[*SendEvent]
+
+	// Domain is the Mailgun sending domain name.
+	Domain string
+
+	// APIKey is the Mailgun private API key.
+	APIKey string
+
+	// Region is the optional Mailgun account region ("us" or "eu").
+	//
+	// It is used only when no explicit Endpoint is set.
+	Region string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to [DefaultMailgunEndpoint] or [DefaultMailgunEUEndpoint]).
+	Endpoint string
+
+	// Tags is an optional list of tags to attach to each sent message.
+	Tags []string
+
+	// TrackOpens enables the Mailgun open tracking.
+	TrackOpens bool
+
+	// TrackClicks enables the Mailgun click tracking.
+	TrackClicks bool
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+}
+
+// OnSend implements [mailer.SendInterceptor] interface.
+func (c *MailgunClient) OnSend() *hook.Hook[*SendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [mailer.Mailer] interface.
+func (c *MailgunClient) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SendEvent{Message: m}, func(e *SendEvent) error {
+			return c.send(e.Message)
+		})
+	}
+
+	return c.send(m)
+}
+
+func (c *MailgunClient) send(m *Message) error {
+	if !hasRecipients(m) {
+		return errMissingRecipients
+	}
+
+	body := new(bytes.Buffer)
+	mp := multipart.NewWriter(body)
+
+	fields := [][2]string{
+		{"from", m.From.String()},
+		{"subject", m.Subject},
+		{"o:tracking-opens", yesNo(c.TrackOpens)},
+		{"o:tracking-clicks", yesNo(c.TrackClicks)},
+	}
+
+	for _, addr := range addressesToStrings(m.To, true) {
+		fields = append(fields, [2]string{"to", addr})
+	}
+	for _, addr := range addressesToStrings(m.Cc, true) {
+		fields = append(fields, [2]string{"cc", addr})
+	}
+	for _, addr := range addressesToStrings(m.Bcc, true) {
+		fields = append(fields, [2]string{"bcc", addr})
+	}
+
+	if m.HTML != "" {
+		fields = append(fields, [2]string{"html", m.HTML})
+	}
+	if m.Text != "" {
+		fields = append(fields, [2]string{"text", m.Text})
+	} else if plain, err := html2Text(m.HTML); err == nil && plain != "" {
+		fields = append(fields, [2]string{"text", plain})
+	}
+
+	for _, tag := range c.Tags {
+		fields = append(fields, [2]string{"o:tag", tag})
+	}
+
+	headerKeys := make([]string, 0, len(m.Headers))
+	for k := range m.Headers {
+		headerKeys = append(headerKeys, k)
+	}
+	sort.Strings(headerKeys)
+	for _, k := range headerKeys {
+		fields = append(fields, [2]string{"h:" + k, m.Headers[k]})
+	}
+
+	for _, f := range fields {
+		if err := mp.WriteField(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+
+	attachments, err := readAttachments(m)
+	if err != nil {
+		return err
+	}
+	for _, a := range attachments {
+		field := "attachment"
+		if a.Inline {
+			field = "inline"
+		}
+
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, quoteEscaper.Replace(a.Name)))
+		h.Set("Content-Type", a.ContentType)
+
+		part, err := mp.CreatePart(h)
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(a.Content); err != nil {
+			return err
+		}
+	}
+
+	if err := mp.Close(); err != nil {
+		return err
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		if strings.EqualFold(c.Region, "eu") {
+			endpoint = DefaultMailgunEUEndpoint
+		} else {
+			endpoint = DefaultMailgunEndpoint
+		}
+	}
+
+	url := strings.TrimRight(endpoint, "/") + "/v3/" + c.Domain + "/messages"
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", mp.FormDataContentType())
+	req.SetBasicAuth("api", c.APIKey)
+
+	return httpx.Send(c.HTTPClient, "mailgun", httpSendAction, req, nil)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
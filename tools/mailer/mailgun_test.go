@@ -0,0 +1,98 @@
+package mailer
+
+import (
+	"io"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestMailgunClientSend(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewCaptureServer(t, 200, "test_response")
+
+	client := &MailgunClient{
+		Domain:     "example.com",
+		APIKey:     "test_key",
+		Endpoint:   server.URL,
+		Tags:       []string{"tag1", "tag2"},
+		TrackOpens: true,
+	}
+
+	err := client.Send(&Message{
+		From:              mail.Address{Name: "Sender", Address: "sender@example.com"},
+		To:                []mail.Address{{Address: "to1@example.com"}, {Name: "To2", Address: "to2@example.com"}},
+		Subject:           "test_subject",
+		HTML:              "<p>test_html</p>",
+		Text:              "test_text",
+		Headers:           map[string]string{"X-Test": "123"},
+		InlineAttachments: map[string]io.Reader{"b.txt": strings.NewReader("abc")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/v3/example.com/messages" {
+		t.Fatalf("Expected /v3/example.com/messages path, got %q", captured.Path)
+	}
+
+	if auth := captured.Headers.Get("Authorization"); auth != "Basic YXBpOnRlc3Rfa2V5" {
+		t.Fatalf("Expected basic authorization header, got %q", auth)
+	}
+
+	if !strings.HasPrefix(captured.Headers.Get("Content-Type"), "multipart/form-data") {
+		t.Fatalf("Expected multipart/form-data content type, got %q", captured.Headers.Get("Content-Type"))
+	}
+
+	httpxtest.CheckContains(t, captured.Body, []string{
+		"name=\"from\"\r\n\r\n\"Sender\" <sender@example.com>\r\n",
+		"name=\"to\"\r\n\r\nto1@example.com\r\n",
+		"name=\"to\"\r\n\r\n\"To2\" <to2@example.com>\r\n",
+		"name=\"subject\"\r\n\r\ntest_subject\r\n",
+		"name=\"html\"\r\n\r\n<p>test_html</p>\r\n",
+		"name=\"text\"\r\n\r\ntest_text\r\n",
+		"name=\"o:tag\"\r\n\r\ntag1\r\n",
+		"name=\"o:tag\"\r\n\r\ntag2\r\n",
+		"name=\"o:tracking-opens\"\r\n\r\nyes\r\n",
+		"name=\"o:tracking-clicks\"\r\n\r\nno\r\n",
+		"name=\"h:X-Test\"\r\n\r\n123\r\n",
+		`name="inline"; filename="b.txt"`,
+	})
+}
+
+func TestMailgunClientEndpoint(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		region   string
+		expected string
+	}{
+		{"", DefaultMailgunEndpoint},
+		{"us", DefaultMailgunEndpoint},
+		{"EU", DefaultMailgunEUEndpoint},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.region, func(t *testing.T) {
+			var url string
+
+			client := &MailgunClient{
+				Domain: "example.com",
+				Region: s.region,
+				HTTPClient: newRoundTripClient(func(u string) {
+					url = u
+				}),
+			}
+
+			_ = client.Send(&Message{To: []mail.Address{{Address: "to@example.com"}}})
+
+			expected := s.expected + "/v3/example.com/messages"
+			if url != expected {
+				t.Fatalf("Expected url %q, got %q", expected, url)
+			}
+		})
+	}
+}
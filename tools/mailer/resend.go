@@ -0,0 +1,143 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+var _ Mailer = (*ResendClient)(nil)
+
+// DefaultResendEndpoint is the default Resend API base url.
+const DefaultResendEndpoint = "https://api.resend.com"
+
+// ResendClient defines a mail client that sends emails
+// via the Resend HTTP API.
+type ResendClient struct {
+	onSend *hook.Hook[*SendEvent]
+
+	// APIKey is the Resend API key.
+	APIKey string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to [DefaultResendEndpoint]).
+	Endpoint string
+
+	// Tags is an optional name-value map with tags to attach to each sent message.
+	Tags map[string]string
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+}
+
+// OnSend implements [mailer.SendInterceptor] interface.
+func (c *ResendClient) OnSend() *hook.Hook[*SendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [mailer.Mailer] interface.
+func (c *ResendClient) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SendEvent{Message: m}, func(e *SendEvent) error {
+			return c.send(e.Message)
+		})
+	}
+
+	return c.send(m)
+}
+
+type resendAttachment struct {
+	Filename    string `json:"filename"`
+	Content     string `json:"content"`
+	ContentType string `json:"content_type,omitempty"`
+	ContentId   string `json:"content_id,omitempty"`
+}
+
+type resendTag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (c *ResendClient) send(m *Message) error {
+	if !hasRecipients(m) {
+		return errMissingRecipients
+	}
+
+	payload := map[string]any{
+		"from":    m.From.String(),
+		"subject": m.Subject,
+	}
+
+	if len(m.To) > 0 {
+		payload["to"] = addressesToStrings(m.To, true)
+	}
+	if len(m.Cc) > 0 {
+		payload["cc"] = addressesToStrings(m.Cc, true)
+	}
+	if len(m.Bcc) > 0 {
+		payload["bcc"] = addressesToStrings(m.Bcc, true)
+	}
+	if m.HTML != "" {
+		payload["html"] = m.HTML
+	}
+	if m.Text != "" {
+		payload["text"] = m.Text
+	}
+	if len(m.Headers) > 0 {
+		payload["headers"] = m.Headers
+	}
+
+	if len(c.Tags) > 0 {
+		names := make([]string, 0, len(c.Tags))
+		for name := range c.Tags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		tags := make([]resendTag, len(names))
+		for i, name := range names {
+			tags[i] = resendTag{Name: name, Value: c.Tags[name]}
+		}
+		payload["tags"] = tags
+	}
+
+	attachments, err := readAttachments(m)
+	if err != nil {
+		return err
+	}
+	if len(attachments) > 0 {
+		list := make([]resendAttachment, len(attachments))
+		for i, a := range attachments {
+			list[i] = resendAttachment{
+				Filename:    a.Name,
+				Content:     base64.StdEncoding.EncodeToString(a.Content),
+				ContentType: a.ContentType,
+			}
+			if a.Inline {
+				list[i].ContentId = a.Name
+			}
+		}
+		payload["attachments"] = list
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultResendEndpoint
+	}
+
+	req, err := httpx.NewJSONRequest(context.Background(), strings.TrimRight(endpoint, "/")+"/emails", payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+
+	return httpx.Send(c.HTTPClient, "resend", httpSendAction, req, nil)
+}
@@ -0,0 +1,54 @@
+package mailer
+
+import (
+	"io"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestResendClientSend(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewCaptureServer(t, 200, "test_response")
+
+	client := &ResendClient{
+		APIKey:   "test_key",
+		Endpoint: server.URL,
+		Tags:     map[string]string{"b": "2", "a": "1"},
+	}
+
+	err := client.Send(&Message{
+		From:        mail.Address{Name: "Sender", Address: "sender@example.com"},
+		To:          []mail.Address{{Address: "to@example.com"}},
+		Cc:          []mail.Address{{Name: "Cc", Address: "cc@example.com"}},
+		Subject:     "test_subject",
+		HTML:        "<p>test_html</p>",
+		Text:        "test_text",
+		Attachments: map[string]io.Reader{"a.txt": strings.NewReader("abc")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/emails" {
+		t.Fatalf("Expected /emails path, got %q", captured.Path)
+	}
+
+	if auth := captured.Headers.Get("Authorization"); auth != "Bearer test_key" {
+		t.Fatalf("Expected bearer authorization header, got %q", auth)
+	}
+
+	httpxtest.CheckContains(t, captured.Body, []string{
+		`"from":"\"Sender\" <sender@example.com>"`,
+		`"to":["to@example.com"]`,
+		`"cc":["\"Cc\" <cc@example.com>"]`,
+		`"subject":"test_subject"`,
+		`"html":"<p>test_html</p>"`,
+		`"text":"test_text"`,
+		`"tags":[{"name":"a","value":"1"},{"name":"b","value":"2"}]`,
+		`"attachments":[{"filename":"a.txt","content":"YWJj","content_type":"text/plain; charset=utf-8"}]`,
+	})
+}
@@ -0,0 +1,179 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+var _ Mailer = (*SendGridClient)(nil)
+
+// DefaultSendGridEndpoint is the default SendGrid API base url.
+const DefaultSendGridEndpoint = "https://api.sendgrid.com"
+
+// SendGridClient defines a mail client that sends emails
+// via the SendGrid v3 Mail Send HTTP API.
+type SendGridClient struct {
+	onSend *hook.Hook[*SendEvent]
+
+	// APIKey is the SendGrid API key with "Mail Send" access.
+	APIKey string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to [DefaultSendGridEndpoint]).
+	Endpoint string
+
+	// Categories is an optional list of categories (aka. tags)
+	// to attach to each sent message.
+	Categories []string
+
+	// TrackOpens enables the SendGrid open tracking.
+	TrackOpens bool
+
+	// TrackClicks enables the SendGrid click tracking.
+	TrackClicks bool
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+}
+
+// OnSend implements [mailer.SendInterceptor] interface.
+func (c *SendGridClient) OnSend() *hook.Hook[*SendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [mailer.Mailer] interface.
+func (c *SendGridClient) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SendEvent{Message: m}, func(e *SendEvent) error {
+			return c.send(e.Message)
+		})
+	}
+
+	return c.send(m)
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content     string `json:"content"`
+	Type        string `json:"type,omitempty"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition,omitempty"`
+	ContentId   string `json:"content_id,omitempty"`
+}
+
+type sendGridTracking struct {
+	Enable bool `json:"enable"`
+}
+
+func (c *SendGridClient) send(m *Message) error {
+	if !hasRecipients(m) {
+		return errMissingRecipients
+	}
+
+	personalization := map[string][]sendGridAddress{}
+	if len(m.To) > 0 {
+		personalization["to"] = toSendGridAddresses(m.To)
+	}
+	if len(m.Cc) > 0 {
+		personalization["cc"] = toSendGridAddresses(m.Cc)
+	}
+	if len(m.Bcc) > 0 {
+		personalization["bcc"] = toSendGridAddresses(m.Bcc)
+	}
+
+	text := m.Text
+	if text == "" && m.HTML != "" {
+		// try to generate a plain text version of the HTML
+		text, _ = html2Text(m.HTML)
+	}
+
+	// note: the plain text content must be first
+	content := []sendGridContent{}
+	if text != "" {
+		content = append(content, sendGridContent{Type: "text/plain", Value: text})
+	}
+	if m.HTML != "" {
+		content = append(content, sendGridContent{Type: "text/html", Value: m.HTML})
+	}
+
+	payload := map[string]any{
+		"personalizations": []any{personalization},
+		"from":             sendGridAddress{Email: m.From.Address, Name: m.From.Name},
+		"subject":          m.Subject,
+		"content":          content,
+		"tracking_settings": map[string]any{
+			"open_tracking":  sendGridTracking{Enable: c.TrackOpens},
+			"click_tracking": sendGridTracking{Enable: c.TrackClicks},
+		},
+	}
+
+	if len(m.Headers) > 0 {
+		payload["headers"] = m.Headers
+	}
+
+	if len(c.Categories) > 0 {
+		payload["categories"] = c.Categories
+	}
+
+	attachments, err := readAttachments(m)
+	if err != nil {
+		return err
+	}
+	if len(attachments) > 0 {
+		list := make([]sendGridAttachment, len(attachments))
+		for i, a := range attachments {
+			list[i] = sendGridAttachment{
+				Content:     base64.StdEncoding.EncodeToString(a.Content),
+				Type:        a.ContentType,
+				Filename:    a.Name,
+				Disposition: "attachment",
+			}
+			if a.Inline {
+				list[i].Disposition = "inline"
+				list[i].ContentId = a.Name
+			}
+		}
+		payload["attachments"] = list
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultSendGridEndpoint
+	}
+
+	req, err := httpx.NewJSONRequest(context.Background(), strings.TrimRight(endpoint, "/")+"/v3/mail/send", payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+
+	return httpx.Send(c.HTTPClient, "sendgrid", httpSendAction, req, nil)
+}
+
+func toSendGridAddresses(addresses []mail.Address) []sendGridAddress {
+	result := make([]sendGridAddress, len(addresses))
+
+	for i, addr := range addresses {
+		result[i] = sendGridAddress{Email: addr.Address, Name: addr.Name}
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+package mailer
+
+import (
+	"errors"
+	"io"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestSendGridClientSend(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewCaptureServer(t, 202, "test_response")
+
+	client := &SendGridClient{
+		APIKey:      "test_key",
+		Endpoint:    server.URL,
+		Categories:  []string{"a", "b"},
+		TrackClicks: true,
+	}
+
+	var hookCalls int
+	client.OnSend().BindFunc(func(e *SendEvent) error {
+		hookCalls++
+		return e.Next()
+	})
+
+	err := client.Send(&Message{
+		From:        mail.Address{Name: "Sender", Address: "sender@example.com"},
+		To:          []mail.Address{{Address: "to@example.com"}},
+		Bcc:         []mail.Address{{Name: "Bcc", Address: "bcc@example.com"}},
+		Subject:     "test_subject",
+		HTML:        "<p>test_html</p>",
+		Headers:     map[string]string{"X-Test": "123"},
+		Attachments: map[string]io.Reader{"a.txt": strings.NewReader("abc")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hookCalls != 1 {
+		t.Fatalf("Expected OnSend hook to be called once, got %d", hookCalls)
+	}
+
+	if captured.Path != "/v3/mail/send" {
+		t.Fatalf("Expected /v3/mail/send path, got %q", captured.Path)
+	}
+
+	if auth := captured.Headers.Get("Authorization"); auth != "Bearer test_key" {
+		t.Fatalf("Expected bearer authorization header, got %q", auth)
+	}
+
+	httpxtest.CheckContains(t, captured.Body, []string{
+		`"personalizations":[{"bcc":[{"email":"bcc@example.com","name":"Bcc"}],"to":[{"email":"to@example.com"}]}]`,
+		`"from":{"email":"sender@example.com","name":"Sender"}`,
+		`"subject":"test_subject"`,
+		`"content":[{"type":"text/plain","value":"test_html"},{"type":"text/html","value":"<p>test_html</p>"}]`,
+		`"categories":["a","b"]`,
+		`"headers":{"X-Test":"123"}`,
+		`"tracking_settings":{"click_tracking":{"enable":true},"open_tracking":{"enable":false}}`,
+		`"attachments":[{"content":"YWJj","type":"text/plain; charset=utf-8","filename":"a.txt","disposition":"attachment"}]`,
+	})
+}
+
+func TestSendGridClientSendErrors(t *testing.T) {
+	t.Parallel()
+
+	server, _ := httpxtest.NewCaptureServer(t, 400, "test_response")
+
+	client := &SendGridClient{Endpoint: server.URL}
+
+	// missing recipients
+	err := client.Send(&Message{From: mail.Address{Address: "sender@example.com"}})
+	if !errors.Is(err, errMissingRecipients) {
+		t.Fatalf("Expected errMissingRecipients, got %v", err)
+	}
+
+	// response error
+	err = client.Send(&Message{
+		From: mail.Address{Address: "sender@example.com"},
+		To:   []mail.Address{{Address: "to@example.com"}},
+	})
+	var respErr *HTTPResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("Expected HTTPResponseError, got %v", err)
+	}
+	if respErr.Status != 400 || string(respErr.Body) != "test_response" {
+		t.Fatalf("Unexpected response error %v", respErr)
+	}
+}
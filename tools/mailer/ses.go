@@ -0,0 +1,176 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/domodwyer/mailyak/v3"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+	"github.com/pocketbase/pocketbase/tools/internal/sigv4"
+)
+
+var _ Mailer = (*SESClient)(nil)
+
+const sesServiceCode = "ses"
+
+// SESClient defines a mail client that sends emails
+// via the Amazon SES v2 SendEmail HTTP API (as raw MIME messages).
+type SESClient struct {
+	onSend *hook.Hook[*SendEvent]
+
+	// Region is the AWS region of the SES service (eg. "us-east-1").
+	Region string
+
+	// AccessKey is the AWS access key id.
+	AccessKey string
+
+	// Secret is the AWS secret access key.
+	Secret string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to "https://email.{Region}.amazonaws.com").
+	Endpoint string
+
+	// ConfigurationSet is an optional SES configuration set name
+	// (could be used for example to enable open and click tracking).
+	ConfigurationSet string
+
+	// Tags is an optional name-value map with SES message tags to attach to each sent message.
+	Tags map[string]string
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+}
+
+// OnSend implements [mailer.SendInterceptor] interface.
+func (c *SESClient) OnSend() *hook.Hook[*SendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [mailer.Mailer] interface.
+func (c *SESClient) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SendEvent{Message: m}, func(e *SendEvent) error {
+			return c.send(e.Message)
+		})
+	}
+
+	return c.send(m)
+}
+
+type sesTag struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+func (c *SESClient) send(m *Message) error {
+	if !hasRecipients(m) {
+		return errMissingRecipients
+	}
+
+	yak := mailyak.New("", nil)
+	if err := applyMailYakMessage(yak, m); err != nil {
+		return err
+	}
+
+	raw, err := yak.MimeBuf()
+	if err != nil {
+		return err
+	}
+
+	destination := map[string][]string{}
+	if len(m.To) > 0 {
+		destination["ToAddresses"] = addressesToStrings(m.To, true)
+	}
+	if len(m.Cc) > 0 {
+		destination["CcAddresses"] = addressesToStrings(m.Cc, true)
+	}
+	if len(m.Bcc) > 0 {
+		destination["BccAddresses"] = addressesToStrings(m.Bcc, true)
+	}
+
+	payload := map[string]any{
+		"FromEmailAddress": m.From.String(),
+		"Destination":      destination,
+		"Content": map[string]any{
+			"Raw": map[string]any{
+				"Data": base64.StdEncoding.EncodeToString(raw.Bytes()),
+			},
+		},
+	}
+
+	if c.ConfigurationSet != "" {
+		payload["ConfigurationSetName"] = c.ConfigurationSet
+	}
+
+	if len(c.Tags) > 0 {
+		names := make([]string, 0, len(c.Tags))
+		for name := range c.Tags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		tags := make([]sesTag, len(names))
+		for i, name := range names {
+			tags[i] = sesTag{Name: name, Value: c.Tags[name]}
+		}
+		payload["EmailTags"] = tags
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = "https://email." + c.Region + ".amazonaws.com"
+	}
+
+	req, err := httpx.NewJSONRequest(context.Background(), strings.TrimRight(endpoint, "/")+"/v2/email/outbound-emails", payload)
+	if err != nil {
+		return err
+	}
+
+	if err := c.sign(req, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	return httpx.Send(c.HTTPClient, "ses", httpSendAction, req, nil)
+}
+
+// sign signs the provided request per AWS Signature v4.
+//
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html
+func (c *SESClient) sign(req *http.Request, now time.Time) error {
+	var payload []byte
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("x-amz-date", now.Format(sigv4.DateTimeFormat))
+	req.Header.Set("x-amz-content-sha256", sigv4.PayloadHash(payload))
+
+	signer := &sigv4.Signer{
+		Region:    c.Region,
+		Service:   sesServiceCode,
+		AccessKey: c.AccessKey,
+		SecretKey: c.Secret,
+	}
+	signer.Sign(req)
+
+	return nil
+}
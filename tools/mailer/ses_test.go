@@ -0,0 +1,101 @@
+package mailer
+
+import (
+	"context"
+	"net/http"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+	"github.com/pocketbase/pocketbase/tools/internal/sigv4"
+)
+
+func TestSESClientSend(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewCaptureServer(t, 200, "test_response")
+
+	client := &SESClient{
+		Region:           "us-east-1",
+		AccessKey:        "test_access",
+		Secret:           "test_secret",
+		Endpoint:         server.URL,
+		ConfigurationSet: "test_set",
+		Tags:             map[string]string{"a": "1"},
+	}
+
+	err := client.Send(&Message{
+		From:    mail.Address{Name: "Sender", Address: "sender@example.com"},
+		To:      []mail.Address{{Address: "to@example.com"}},
+		Bcc:     []mail.Address{{Address: "bcc@example.com"}},
+		Subject: "test_subject",
+		HTML:    "<p>test_html</p>",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/v2/email/outbound-emails" {
+		t.Fatalf("Expected /v2/email/outbound-emails path, got %q", captured.Path)
+	}
+
+	auth := captured.Headers.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=test_access/") ||
+		!strings.Contains(auth, "/us-east-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature=") {
+		t.Fatalf("Invalid authorization header %q", auth)
+	}
+
+	httpxtest.CheckContains(t, captured.Body, []string{
+		`"FromEmailAddress":"\"Sender\" <sender@example.com>"`,
+		`"Destination":{"BccAddresses":["bcc@example.com"],"ToAddresses":["to@example.com"]}`,
+		`"Content":{"Raw":{"Data":"`,
+		`"ConfigurationSetName":"test_set"`,
+		`"EmailTags":[{"Name":"a","Value":"1"}]`,
+	})
+}
+
+func TestSESClientSign(t *testing.T) {
+	t.Parallel()
+
+	client := &SESClient{
+		Region:    "us-east-1",
+		AccessKey: "AKIDEXAMPLE",
+		Secret:    "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+
+	req, err := httpx.NewJSONRequest(context.Background(), "https://email.us-east-1.amazonaws.com/v2/email/outbound-emails", map[string]any{"test": 123})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := client.sign(req, now); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHeaders := map[string]string{
+		"x-amz-date":           "20240102T030405Z",
+		"x-amz-content-sha256": sigv4.PayloadHash([]byte(`{"test":123}`)),
+		"host":                 "email.us-east-1.amazonaws.com",
+	}
+	for k, v := range expectedHeaders {
+		if h := req.Header.Get(k); h != v {
+			t.Errorf("Expected header %q to be %q, got %q", k, v, h)
+		}
+	}
+
+	// the signature must be deterministic for the same request and time
+	req2, _ := http.NewRequest(req.Method, req.URL.String(), strings.NewReader(`{"test":123}`))
+	req2.Header.Set("Content-Type", "application/json")
+	if err := client.sign(req2, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if req.Header.Get("Authorization") != req2.Header.Get("Authorization") {
+		t.Fatalf("Expected the same signatures, got\n%s\n%s", req.Header.Get("Authorization"), req2.Header.Get("Authorization"))
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/smtp"
+	"net/textproto"
+	"regexp"
 	"strings"
 
 	"github.com/domodwyer/mailyak/v3"
@@ -86,6 +88,16 @@ func (c *SMTPClient) send(m *Message) error {
 		yak.LocalName(c.LocalName)
 	}
 
+	if err := applyMailYakMessage(yak, m); err != nil {
+		return err
+	}
+
+	return yak.Send()
+}
+
+// applyMailYakMessage populates the provided mailyak instance with the Message data
+// (addresses, subject, body, attachments and headers).
+func applyMailYakMessage(yak *mailyak.MailYak, m *Message) error {
 	if m.From.Name != "" {
 		yak.FromName(m.From.Name)
 	}
@@ -151,7 +163,7 @@ func (c *SMTPClient) send(m *Message) error {
 		}
 	}
 
-	return yak.Send()
+	return nil
 }
 
 // -------------------------------------------------------------------
@@ -206,6 +218,64 @@ func (a *smtpLoginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
 	return nil, nil
 }
 
+// smtpEnhancedStatusRegex matches the RFC 3463 enhanced status code
+// at the beginning of a SMTP reply text (eg. "5.1.1").
+var smtpEnhancedStatusRegex = regexp.MustCompile(`^([245])\.(\d{1,3})\.(\d{1,3})\b`)
+
+// smtpRecipientErrorPhrases lists common (lowercased) SMTP reply texts
+// of a nonexistent or invalid recipient mailbox.
+var smtpRecipientErrorPhrases = []string{
+	"user unknown",
+	"unknown user",
+	"no such user",
+	"user not found",
+	"user not local",
+	"unknown recipient",
+	"invalid recipient",
+	"recipient address rejected",
+	"no such mailbox",
+	"mailbox not found",
+	"mailbox does not exist",
+	"address does not exist",
+	"account does not exist",
+}
+
+// IsSMTPHardBounceError reports whether err is a permanent SMTP
+// recipient failure (eg. "550 5.1.1 user unknown").
+//
+// Only the 550, 551 and 553 reply codes are considered and because they
+// are also used for policy and sender rejections, the reply must also have
+// a 5.1.x (address status) enhanced code or a recipient specific message.
+func IsSMTPHardBounceError(err error) bool {
+	var tpErr *textproto.Error
+	if !errors.As(err, &tpErr) {
+		return false
+	}
+
+	switch tpErr.Code {
+	case 550, 551, 553:
+	default:
+		return false
+	}
+
+	msg := strings.TrimSpace(tpErr.Msg)
+
+	if match := smtpEnhancedStatusRegex.FindStringSubmatch(msg); match != nil {
+		// 5.1.7 and 5.1.8 are related to the sender address
+		return match[1] == "5" && match[2] == "1" && match[3] != "7" && match[3] != "8"
+	}
+
+	msg = strings.ToLower(msg)
+
+	for _, phrase := range smtpRecipientErrorPhrases {
+		if strings.Contains(msg, phrase) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isLocalhost(name string) bool {
 	return name == "localhost" || name == "127.0.0.1" || name == "::1"
 }
@@ -1,7 +1,10 @@
 package mailer
 
 import (
+	"errors"
+	"fmt"
 	"net/smtp"
+	"net/textproto"
 	"testing"
 )
 
@@ -164,3 +167,33 @@ func TestLoginAuthNext(t *testing.T) {
 		}
 	}
 }
+
+func TestIsSMTPHardBounceError(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil error", nil, false},
+		{"non SMTP error", errors.New("test"), false},
+		{"temporary failure", &textproto.Error{Code: 450, Msg: "mailbox busy"}, false},
+		{"unrelated permanent failure", &textproto.Error{Code: 554, Msg: "transaction failed"}, false},
+		{"generic mailbox unavailable", &textproto.Error{Code: 550, Msg: "mailbox unavailable"}, false},
+		{"policy rejection", &textproto.Error{Code: 550, Msg: "5.7.1 message rejected as spam"}, false},
+		{"sender address rejection", &textproto.Error{Code: 553, Msg: "5.1.8 sender address domain does not exist"}, false},
+		{"temporary enhanced code", &textproto.Error{Code: 550, Msg: "4.1.1 user unknown"}, false},
+		{"enhanced address code", &textproto.Error{Code: 550, Msg: "5.1.1 mailbox unavailable"}, true},
+		{"wrapped user not local", fmt.Errorf("wrap: %w", &textproto.Error{Code: 551, Msg: "User not local; please try <forward@example.com>"}), true},
+		{"recipient specific message", &textproto.Error{Code: 553, Msg: "Recipient address rejected: invalid mailbox"}, true},
+		{"recipient message with non 5xx code", &textproto.Error{Code: 554, Msg: "user unknown"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := IsSMTPHardBounceError(s.err)
+			if result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"errors"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+var _ Notifier = (*DisabledClient)(nil)
+
+// ErrDisabled is returned by [DisabledClient] on every send attempt.
+var ErrDisabled = errors.New("the text messages delivery is not configured")
+
+// DisabledClient defines a notifier client that fails on every send attempt.
+//
+// It is usually used as a placeholder when there is no configured provider
+// (the send hooks are still triggered allowing to register a custom client).
+type DisabledClient struct {
+	onSend *hook.Hook[*SendEvent]
+}
+
+// OnSend implements [notifier.SendInterceptor] interface.
+func (c *DisabledClient) OnSend() *hook.Hook[*SendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [notifier.Notifier] interface.
+func (c *DisabledClient) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SendEvent{Message: m}, func(e *SendEvent) error {
+			return ErrDisabled
+		})
+	}
+
+	return ErrDisabled
+}
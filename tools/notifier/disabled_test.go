@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDisabledClientSend(t *testing.T) {
+	t.Parallel()
+
+	client := &DisabledClient{}
+
+	if err := client.Send(&Message{To: "+123"}); !errors.Is(err, ErrDisabled) {
+		t.Fatalf("Expected ErrDisabled, got %v", err)
+	}
+
+	// with hook
+	var called bool
+	client.OnSend().BindFunc(func(e *SendEvent) error {
+		called = true
+		return e.Next()
+	})
+
+	if err := client.Send(&Message{To: "+123"}); !errors.Is(err, ErrDisabled) {
+		t.Fatalf("Expected ErrDisabled, got %v", err)
+	}
+
+	if !called {
+		t.Fatal("Expected the OnSend hook to be called")
+	}
+}
@@ -0,0 +1,86 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+var _ Notifier = (*HTTPClient)(nil)
+
+// HTTPClient defines a generic notifier client that sends the text
+// messages as JSON POST request to a custom HTTP endpoint.
+//
+// The request body has the following format:
+//
+//	{"from": "...", "to": "...", "body": "..."}
+type HTTPClient struct {
+	onSend *hook.Hook[*SendEvent]
+
+	// URL is the endpoint url where the messages will be submitted.
+	URL string
+
+	// Token is an optional token that will be sent as "Authorization: Bearer {Token}" header.
+	Token string
+
+	// Headers is an optional list with extra request headers.
+	Headers map[string]string
+
+	// From is the default sender used when the message doesn't have an explicit sender.
+	From string
+
+	// HTTPClient is an optional custom HTTP client to use for the requests.
+	HTTPClient *http.Client
+}
+
+// OnSend implements [notifier.SendInterceptor] interface.
+func (c *HTTPClient) OnSend() *hook.Hook[*SendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [notifier.Notifier] interface.
+func (c *HTTPClient) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SendEvent{Message: m}, func(e *SendEvent) error {
+			return c.send(e.Message)
+		})
+	}
+
+	return c.send(m)
+}
+
+func (c *HTTPClient) send(m *Message) error {
+	if m.To == "" {
+		return errMissingRecipient
+	}
+
+	if c.URL == "" {
+		return errors.New("missing notifier HTTP endpoint url")
+	}
+
+	payload := *m
+	if payload.From == "" {
+		payload.From = c.From
+	}
+
+	req, err := httpx.NewJSONRequest(context.Background(), c.URL, payload)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	return httpx.Send(c.HTTPClient, "http", httpSendAction, req, nil)
+}
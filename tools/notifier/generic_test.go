@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestHTTPClientSend(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewCaptureServer(t, 200, ``)
+
+	client := &HTTPClient{
+		URL:     server.URL + "/sms",
+		Token:   "test_token",
+		Headers: map[string]string{"X-Test": "123"},
+		From:    "+100",
+	}
+
+	if err := client.Send(&Message{To: "+200", Body: "test body"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/sms" {
+		t.Fatalf("Invalid request path %q", captured.Path)
+	}
+
+	if auth := captured.Headers.Get("Authorization"); auth != "Bearer test_token" {
+		t.Fatalf("Expected bearer authorization, got %q", auth)
+	}
+
+	if h := captured.Headers.Get("X-Test"); h != "123" {
+		t.Fatalf("Expected X-Test header, got %q", h)
+	}
+
+	expectedBody := `{"from":"+100","to":"+200","body":"test body"}`
+	if captured.Body != expectedBody {
+		t.Fatalf("Expected body\n%s\ngot\n%s", expectedBody, captured.Body)
+	}
+}
+
+func TestHTTPClientSendMissingURL(t *testing.T) {
+	t.Parallel()
+
+	client := &HTTPClient{}
+
+	if err := client.Send(&Message{To: "+200", Body: "test"}); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
@@ -0,0 +1,20 @@
+package notifier
+
+import (
+	"errors"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+// DefaultHTTPTimeout is the default request timeout used by the notifier
+// drivers when no custom [http.Client] is specified.
+const DefaultHTTPTimeout = httpx.DefaultTimeout
+
+// HTTPResponseError defines a notifier provider HTTP response error.
+type HTTPResponseError = httpx.ResponseError
+
+// httpSendAction is the notifier drivers [HTTPResponseError] action.
+const httpSendAction = "send message"
+
+// errMissingRecipient is returned when a message doesn't have a recipient.
+var errMissingRecipient = errors.New("the message must have a recipient")
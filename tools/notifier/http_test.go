@@ -0,0 +1,72 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestSendErrors(t *testing.T) {
+	t.Parallel()
+
+	server, _ := httpxtest.NewCaptureServer(t, 400, "test_error")
+
+	clients := map[string]Notifier{
+		"twilio": &TwilioClient{Endpoint: server.URL},
+		"vonage": &VonageClient{Endpoint: server.URL},
+		"plivo":  &PlivoClient{Endpoint: server.URL},
+		"http":   &HTTPClient{URL: server.URL},
+	}
+
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			if err := client.Send(&Message{Body: "test"}); err != errMissingRecipient {
+				t.Fatalf("Expected missing recipient error, got %v", err)
+			}
+
+			err := client.Send(&Message{To: "+123", Body: "test"})
+
+			respErr, ok := err.(*HTTPResponseError)
+			if !ok {
+				t.Fatalf("Expected HTTPResponseError, got %v", err)
+			}
+
+			if respErr.Provider != name || respErr.Status != 400 || string(respErr.Body) != "test_error" {
+				t.Fatalf("Invalid response error %v", respErr)
+			}
+		})
+	}
+}
+
+func TestSendInterceptors(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewCaptureServer(t, 200, `{}`)
+
+	clients := map[string]Notifier{
+		"twilio": &TwilioClient{Endpoint: server.URL},
+		"vonage": &VonageClient{Endpoint: server.URL},
+		"plivo":  &PlivoClient{Endpoint: server.URL},
+		"http":   &HTTPClient{URL: server.URL},
+	}
+
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			interceptor, ok := client.(SendInterceptor)
+			if !ok {
+				t.Fatalf("Expected %s client to implement SendInterceptor", name)
+			}
+
+			interceptor.OnSend().BindFunc(func(e *SendEvent) error {
+				e.Message.Body = "changed_" + name
+				return e.Next()
+			})
+
+			if err := client.Send(&Message{To: "+123", Body: "test"}); err != nil {
+				t.Fatal(err)
+			}
+
+			httpxtest.CheckContains(t, captured.Body, []string{"changed_" + name})
+		})
+	}
+}
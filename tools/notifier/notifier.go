@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+// Message defines a generic text (SMS) message struct.
+type Message struct {
+	// From is the sender phone number or alphanumeric sender id
+	// (some drivers could fallback to their configured default sender).
+	From string `json:"from"`
+
+	// To is the recipient phone number (preferably in E.164 format, eg. "+15551234567").
+	To string `json:"to"`
+
+	// Body is the plain text message content.
+	Body string `json:"body"`
+}
+
+// Notifier defines a base text message (SMS) client interface.
+type Notifier interface {
+	// Send sends a text message with the provided Message.
+	Send(message *Message) error
+}
+
+// SendInterceptor is optional interface for registering message send hooks.
+type SendInterceptor interface {
+	OnSend() *hook.Hook[*SendEvent]
+}
+
+type SendEvent struct {
+	hook.Event
+	Message *Message
+}
@@ -0,0 +1,90 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+var _ Notifier = (*PlivoClient)(nil)
+
+// DefaultPlivoEndpoint is the default Plivo API base url.
+const DefaultPlivoEndpoint = "https://api.plivo.com"
+
+// PlivoClient defines a notifier client that sends text messages
+// via the Plivo Messaging API.
+type PlivoClient struct {
+	onSend *hook.Hook[*SendEvent]
+
+	// AuthId is the Plivo account auth id.
+	AuthId string
+
+	// AuthToken is the Plivo account auth token.
+	AuthToken string
+
+	// From is the default sender phone number or alphanumeric id
+	// used when the message doesn't have an explicit sender.
+	From string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to [DefaultPlivoEndpoint]).
+	Endpoint string
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+}
+
+// OnSend implements [notifier.SendInterceptor] interface.
+func (c *PlivoClient) OnSend() *hook.Hook[*SendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [notifier.Notifier] interface.
+func (c *PlivoClient) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SendEvent{Message: m}, func(e *SendEvent) error {
+			return c.send(e.Message)
+		})
+	}
+
+	return c.send(m)
+}
+
+func (c *PlivoClient) send(m *Message) error {
+	if m.To == "" {
+		return errMissingRecipient
+	}
+
+	from := m.From
+	if from == "" {
+		from = c.From
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultPlivoEndpoint
+	}
+
+	req, err := httpx.NewJSONRequest(
+		context.Background(),
+		strings.TrimRight(endpoint, "/")+"/v1/Account/"+url.PathEscape(c.AuthId)+"/Message/",
+		map[string]string{
+			"src":  from,
+			"dst":  m.To,
+			"text": m.Body,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.AuthId, c.AuthToken)
+
+	return httpx.Send(c.HTTPClient, "plivo", httpSendAction, req, nil)
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestPlivoClientSend(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewCaptureServer(t, 202, `{}`)
+
+	client := &PlivoClient{
+		AuthId:    "MA123",
+		AuthToken: "test_token",
+		From:      "+100",
+		Endpoint:  server.URL,
+	}
+
+	if err := client.Send(&Message{To: "+200", Body: "test body"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/v1/Account/MA123/Message/" {
+		t.Fatalf("Invalid request path %q", captured.Path)
+	}
+
+	expectedAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("MA123:test_token"))
+	if auth := captured.Headers.Get("Authorization"); auth != expectedAuth {
+		t.Fatalf("Expected authorization %q, got %q", expectedAuth, auth)
+	}
+
+	expectedBody := `{"dst":"+200","src":"+100","text":"test body"}`
+	if captured.Body != expectedBody {
+		t.Fatalf("Expected body\n%s\ngot\n%s", expectedBody, captured.Body)
+	}
+}
@@ -0,0 +1,95 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+var _ Notifier = (*TwilioClient)(nil)
+
+// DefaultTwilioEndpoint is the default Twilio API base url.
+const DefaultTwilioEndpoint = "https://api.twilio.com"
+
+// TwilioClient defines a notifier client that sends text messages
+// via the Twilio Programmable Messaging API.
+type TwilioClient struct {
+	onSend *hook.Hook[*SendEvent]
+
+	// AccountSID is the Twilio account SID.
+	AccountSID string
+
+	// AuthToken is the Twilio account auth token.
+	AuthToken string
+
+	// From is the default sender phone number (or messaging service SID)
+	// used when the message doesn't have an explicit sender.
+	From string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to [DefaultTwilioEndpoint]).
+	Endpoint string
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+}
+
+// OnSend implements [notifier.SendInterceptor] interface.
+func (c *TwilioClient) OnSend() *hook.Hook[*SendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [notifier.Notifier] interface.
+func (c *TwilioClient) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SendEvent{Message: m}, func(e *SendEvent) error {
+			return c.send(e.Message)
+		})
+	}
+
+	return c.send(m)
+}
+
+func (c *TwilioClient) send(m *Message) error {
+	if m.To == "" {
+		return errMissingRecipient
+	}
+
+	from := m.From
+	if from == "" {
+		from = c.From
+	}
+
+	data := url.Values{}
+	data.Set("To", m.To)
+	data.Set("Body", m.Body)
+	if strings.HasPrefix(from, "MG") {
+		data.Set("MessagingServiceSid", from)
+	} else {
+		data.Set("From", from)
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultTwilioEndpoint
+	}
+
+	req, err := httpx.NewFormRequest(
+		context.Background(),
+		strings.TrimRight(endpoint, "/")+"/2010-04-01/Accounts/"+url.PathEscape(c.AccountSID)+"/Messages.json",
+		data,
+	)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.AccountSID, c.AuthToken)
+
+	return httpx.Send(c.HTTPClient, "twilio", httpSendAction, req, nil)
+}
@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestTwilioClientSend(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewCaptureServer(t, 201, `{}`)
+
+	client := &TwilioClient{
+		AccountSID: "AC123",
+		AuthToken:  "test_token",
+		From:       "+100",
+		Endpoint:   server.URL,
+	}
+
+	if err := client.Send(&Message{To: "+200", Body: "test body"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/2010-04-01/Accounts/AC123/Messages.json" {
+		t.Fatalf("Invalid request path %q", captured.Path)
+	}
+
+	expectedAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("AC123:test_token"))
+	if auth := captured.Headers.Get("Authorization"); auth != expectedAuth {
+		t.Fatalf("Expected authorization %q, got %q", expectedAuth, auth)
+	}
+
+	httpxtest.CheckContains(t, captured.Body, []string{"To=%2B200", "From=%2B100", "Body=test+body"})
+
+	// messaging service
+	if err := client.Send(&Message{From: "MG123", To: "+200", Body: "test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	httpxtest.CheckContains(t, captured.Body, []string{"MessagingServiceSid=MG123"})
+}
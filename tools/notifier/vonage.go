@@ -0,0 +1,109 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+var _ Notifier = (*VonageClient)(nil)
+
+// DefaultVonageEndpoint is the default Vonage SMS API base url.
+const DefaultVonageEndpoint = "https://rest.nexmo.com"
+
+// VonageClient defines a notifier client that sends text messages
+// via the Vonage (formerly Nexmo) SMS API.
+type VonageClient struct {
+	onSend *hook.Hook[*SendEvent]
+
+	// APIKey is the Vonage API key.
+	APIKey string
+
+	// APISecret is the Vonage API secret.
+	APISecret string
+
+	// From is the default sender phone number or alphanumeric id
+	// used when the message doesn't have an explicit sender.
+	From string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to [DefaultVonageEndpoint]).
+	Endpoint string
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+}
+
+// OnSend implements [notifier.SendInterceptor] interface.
+func (c *VonageClient) OnSend() *hook.Hook[*SendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [notifier.Notifier] interface.
+func (c *VonageClient) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SendEvent{Message: m}, func(e *SendEvent) error {
+			return c.send(e.Message)
+		})
+	}
+
+	return c.send(m)
+}
+
+type vonageResponse struct {
+	Messages []struct {
+		Status    string `json:"status"`
+		ErrorText string `json:"error-text"`
+	} `json:"messages"`
+}
+
+func (c *VonageClient) send(m *Message) error {
+	if m.To == "" {
+		return errMissingRecipient
+	}
+
+	from := m.From
+	if from == "" {
+		from = c.From
+	}
+
+	data := url.Values{}
+	data.Set("api_key", c.APIKey)
+	data.Set("api_secret", c.APISecret)
+	data.Set("from", from)
+	// Vonage expects the number without the leading "+"
+	data.Set("to", strings.TrimPrefix(m.To, "+"))
+	data.Set("text", m.Body)
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultVonageEndpoint
+	}
+
+	req, err := httpx.NewFormRequest(context.Background(), strings.TrimRight(endpoint, "/")+"/sms/json", data)
+	if err != nil {
+		return err
+	}
+
+	result := vonageResponse{}
+	if err := httpx.Send(c.HTTPClient, "vonage", httpSendAction, req, &result); err != nil {
+		return err
+	}
+
+	// Vonage responds with 200 even on failure
+	for _, msg := range result.Messages {
+		if msg.Status != "0" {
+			return fmt.Errorf("vonage: failed to send message (status %s): %s", msg.Status, msg.ErrorText)
+		}
+	}
+
+	return nil
+}
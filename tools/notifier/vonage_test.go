@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestVonageClientSend(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewCaptureServer(t, 200, `{"messages":[{"status":"0"}]}`)
+
+	client := &VonageClient{
+		APIKey:    "test_key",
+		APISecret: "test_secret",
+		From:      "Acme",
+		Endpoint:  server.URL,
+	}
+
+	if err := client.Send(&Message{To: "+200", Body: "test body"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/sms/json" {
+		t.Fatalf("Invalid request path %q", captured.Path)
+	}
+
+	httpxtest.CheckContains(t, captured.Body, []string{
+		"api_key=test_key",
+		"api_secret=test_secret",
+		"from=Acme",
+		"to=200",
+		"text=test+body",
+	})
+}
+
+func TestVonageClientSendFailedStatus(t *testing.T) {
+	t.Parallel()
+
+	server, _ := httpxtest.NewCaptureServer(t, 200, `{"messages":[{"status":"2","error-text":"Missing to param"}]}`)
+
+	client := &VonageClient{Endpoint: server.URL}
+
+	err := client.Send(&Message{To: "+200", Body: "test"})
+	if err == nil || !strings.Contains(err.Error(), "Missing to param") {
+		t.Fatalf("Expected Vonage status error, got %v", err)
+	}
+}
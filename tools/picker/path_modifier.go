@@ -0,0 +1,70 @@
+package picker
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Modifiers["path"] = func(args ...string) (Modifier, error) {
+		return newPathModifier(args...)
+	}
+}
+
+var pathSegmentRegex = regexp.MustCompile(`^[\w\-]+$`)
+
+var _ Modifier = (*pathModifier)(nil)
+
+type pathModifier struct {
+	segments []string
+}
+
+// newPathModifier validates the specified raw string arguments and
+// initializes a new pathModifier.
+//
+// This method is usually invoked in initModifer().
+func newPathModifier(args ...string) (*pathModifier, error) {
+	if len(args) != 1 {
+		return nil, errors.New("expected a single path argument - expected (path)")
+	}
+
+	path := strings.Trim(strings.TrimSpace(args[0]), `"'`)
+	if path == "" {
+		return nil, errors.New("path argument must be non-empty")
+	}
+
+	segments := strings.Split(path, ".")
+	for _, s := range segments {
+		if !pathSegmentRegex.MatchString(s) {
+			return nil, errors.New("invalid path segment " + strconv.Quote(s))
+		}
+	}
+
+	return &pathModifier{segments}, nil
+}
+
+// Modify implements the [Modifier.Modify] interface method.
+//
+// It extracts the value at the modifier dot-notation path from a json
+// object/array value (numeric segments are used as array indexes).
+// Missing paths resolve to nil.
+func (m *pathModifier) Modify(value any) (any, error) {
+	for _, s := range m.segments {
+		switch v := value.(type) {
+		case map[string]any:
+			value = v[s]
+		case []any:
+			i, err := strconv.Atoi(s)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, nil
+			}
+			value = v[i]
+		default:
+			return nil, nil
+		}
+	}
+
+	return value, nil
+}
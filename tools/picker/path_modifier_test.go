@@ -0,0 +1,106 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestNewPathModifier(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			"no arguments",
+			nil,
+			"",
+		},
+		{
+			"too many arguments",
+			[]string{"a", "b"},
+			"",
+		},
+		{
+			"empty path",
+			[]string{`""`},
+			"",
+		},
+		{
+			"invalid path segment",
+			[]string{"a..b"},
+			"",
+		},
+		{
+			"valid path",
+			[]string{" a.b_c.0 "},
+			`["a","b_c","0"]`,
+		},
+		{
+			"quoted path",
+			[]string{`"a.b-c"`},
+			`["a","b-c"]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			m, err := newPathModifier(s.args...)
+
+			hasErr := err != nil
+			if hasErr != (s.expected == "") {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expected == "", hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			raw, _ := json.Marshal(m.segments)
+			if v := string(raw); v != s.expected {
+				t.Fatalf("Expected segments %s, got %s", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestPathModifierModify(t *testing.T) {
+	data := map[string]any{
+		"a": map[string]any{
+			"b": []any{"x", map[string]any{"c": 123}},
+		},
+		"d": "test",
+	}
+
+	scenarios := []struct {
+		path     string
+		expected any
+	}{
+		{"a.b.1.c", 123},
+		{"a.b.0", "x"},
+		{"a.b.2", nil},
+		{"a.b.c", nil},
+		{"a.missing", nil},
+		{"d.e", nil},
+		{"d", "test"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			m, err := newPathModifier(s.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := m.Modify(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if fmt.Sprint(result) != fmt.Sprint(s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
@@ -2,25 +2,30 @@ package picker
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/pocketbase/pocketbase/tools/search"
-	"github.com/pocketbase/pocketbase/tools/tokenizer"
 )
 
 // Pick converts data into a []any, map[string]any, etc. (using json marshal->unmarshal)
 // containing only the fields from the parsed rawFields expression.
 //
 // rawFields is a comma separated string of the fields to include.
-// Nested fields should be listed with dot-notation.
+// Nested fields should be listed with dot-notation or grouped with braces
+// (e.g. "rel{id,title}" is the same as "rel.id,rel.title").
+// Fields prefixed with "-" are excluded from the result (if there are
+// only excluded fields, all other fields are picked as with "*").
 // Fields value modifiers are also supported using the `:modifier(args)` format (see Modifiers).
 //
 // Example:
 //
 //	data := map[string]any{"a": 1, "b": 2, "c": map[string]any{"c1": 11, "c2": 22}}
-//	Pick(data, "a,c.c1") // map[string]any{"a": 1, "c": map[string]any{"c1": 11}}
+//	Pick(data, "a,c.c1")  // map[string]any{"a": 1, "c": map[string]any{"c1": 11}}
+//	Pick(data, "a,c{c1}") // map[string]any{"a": 1, "c": map[string]any{"c1": 11}}
+//	Pick(data, "-a,-c.c1") // map[string]any{"b": 2, "c": map[string]any{"c2": 22}}
 func Pick(data any, rawFields string) (any, error) {
-	parsedFields, err := parseFields(rawFields)
+	parsedFields, excludedFields, err := parseFields(rawFields)
 	if err != nil {
 		return nil, err
 	}
@@ -49,34 +54,49 @@ func Pick(data any, rawFields string) (any, error) {
 		isSearchResult = true
 	}
 
+	target := decoded
 	if isSearchResult {
 		if decodedMap, ok := decoded.(map[string]any); ok {
-			pickParsedFields(decodedMap["items"], parsedFields)
+			target = decodedMap["items"]
 		}
-	} else {
-		pickParsedFields(decoded, parsedFields)
+	}
+
+	pickParsedFields(target, parsedFields)
+
+	for _, path := range excludedFields {
+		excludeField(target, path)
 	}
 
 	return decoded, nil
 }
 
-func parseFields(rawFields string) (map[string]Modifier, error) {
-	t := tokenizer.NewFromString(rawFields)
-
-	fields, err := t.ScanAll()
+// parseFields parses the rawFields expression and returns the picked
+// fields with their modifiers and the list of the excluded field paths.
+func parseFields(rawFields string) (map[string]Modifier, []string, error) {
+	fields, err := expandFieldsGroups(rawFields)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	result := make(map[string]Modifier, len(fields))
+	excluded := []string{}
 
 	for _, f := range fields {
-		parts := strings.SplitN(strings.TrimSpace(f), ":", 2)
+		if path, ok := strings.CutPrefix(f, "-"); ok {
+			path = strings.TrimSpace(path)
+			if path == "" || strings.Contains(path, ":") {
+				return nil, nil, fmt.Errorf("invalid excluded field %q", f)
+			}
+			excluded = append(excluded, path)
+			continue
+		}
+
+		parts := strings.SplitN(f, ":", 2)
 
 		if len(parts) > 1 {
 			m, err := initModifer(parts[1])
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			result[parts[0]] = m
 		} else {
@@ -84,6 +104,135 @@ func parseFields(rawFields string) (map[string]Modifier, error) {
 		}
 	}
 
+	// only exclusions -> pick everything else
+	if len(result) == 0 && len(excluded) > 0 {
+		result["*"] = nil
+	}
+
+	return result, excluded, nil
+}
+
+// expandFieldsGroups splits rawFields by its top level commas and
+// flattens the braces groups, e.g. "a,b{c,d{e}}" -> ["a", "b.c", "b.d.e"].
+//
+// Excluded fields groups, e.g. "-b{c,d}", are flattened as ["-b.c", "-b.d"].
+func expandFieldsGroups(rawFields string) ([]string, error) {
+	items, err := splitFields(rawFields)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(items))
+
+	for _, item := range items {
+		openIndex := strings.IndexByte(item, '{')
+		if openIndex < 0 {
+			result = append(result, item)
+			continue
+		}
+
+		// the modifier separator is not allowed before the group
+		prefix := strings.TrimSpace(item[:openIndex])
+		if !strings.HasSuffix(item, "}") || strings.Contains(prefix, ":") {
+			return nil, fmt.Errorf("invalid fields group %q", item)
+		}
+
+		var exclude string
+		if trimmed, ok := strings.CutPrefix(prefix, "-"); ok {
+			exclude = "-"
+			prefix = strings.TrimSpace(trimmed)
+		}
+
+		if prefix == "" {
+			return nil, fmt.Errorf("missing fields group key in %q", item)
+		}
+
+		nested, err := expandFieldsGroups(item[openIndex+1 : len(item)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(nested) == 0 {
+			return nil, fmt.Errorf("empty fields group %q", item)
+		}
+
+		for _, n := range nested {
+			if exclude == "" {
+				// propagate the nested exclusion marker
+				if trimmed, ok := strings.CutPrefix(n, "-"); ok {
+					result = append(result, "-"+prefix+"."+trimmed)
+					continue
+				}
+			} else if strings.HasPrefix(n, "-") {
+				return nil, fmt.Errorf("nested exclusion in excluded fields group %q", item)
+			}
+
+			result = append(result, exclude+prefix+"."+n)
+		}
+	}
+
+	return result, nil
+}
+
+// splitFields splits rawFields by its top level commas, aka. ignoring
+// the ones inside parenthesis, braces and quotes.
+//
+// Empty items are skipped.
+func splitFields(rawFields string) ([]string, error) {
+	result := []string{}
+
+	var parenthesis, braces int
+	var quoteCh rune
+	var prevCh rune
+	var buf strings.Builder
+
+	flush := func() {
+		if item := strings.TrimSpace(buf.String()); item != "" {
+			result = append(result, item)
+		}
+		buf.Reset()
+	}
+
+	for _, ch := range rawFields {
+		if prevCh != '\\' {
+			switch {
+			case quoteCh != 0:
+				if ch == quoteCh {
+					quoteCh = 0
+				}
+			case ch == '"' || ch == '\'' || ch == '`':
+				quoteCh = ch
+			case ch == '(':
+				parenthesis++
+			case ch == ')':
+				parenthesis--
+			case ch == '{':
+				braces++
+			case ch == '}':
+				braces--
+			}
+		}
+
+		if parenthesis < 0 || braces < 0 {
+			return nil, fmt.Errorf("unbalanced parenthesis or braces in %q", rawFields)
+		}
+
+		prevCh = ch
+
+		if ch == ',' && parenthesis == 0 && braces == 0 && quoteCh == 0 {
+			flush()
+			continue
+		}
+
+		buf.WriteRune(ch)
+	}
+
+	if parenthesis > 0 || braces > 0 || quoteCh != 0 {
+		return nil, fmt.Errorf("unbalanced parenthesis, braces or quoted expression in %q", rawFields)
+	}
+
+	flush()
+
 	return result, nil
 }
 
@@ -182,3 +331,23 @@ DataLoop:
 
 	return nil
 }
+
+// excludeField removes the dot-notation path from the data map(s).
+//
+// Similar to the picked fields, slices of maps are processed item by item.
+func excludeField(data any, path string) {
+	key, remains, hasRemains := strings.Cut(path, ".")
+
+	switch v := data.(type) {
+	case map[string]any:
+		if !hasRemains {
+			delete(v, key)
+			return
+		}
+		excludeField(v[key], remains)
+	case []any:
+		for _, item := range v {
+			excludeField(item, path)
+		}
+	}
+}
@@ -248,6 +248,98 @@ func TestPickFields(t *testing.T) {
 			false,
 			`{"id":"12","rel":{"title":"rel..."},"title":"lo"}`,
 		},
+		{
+			"fields groups",
+			map[string]any{
+				"id":    "123",
+				"title": "lorem",
+				"rel": map[string]any{
+					"id":    "456",
+					"title": "<p>rel_title</p>",
+					"sub": []any{
+						map[string]any{"id": "789", "title": "sub_title1"},
+						map[string]any{"id": "012", "title": "sub_title2"},
+					},
+				},
+			},
+			"id, rel{ title:excerpt(3, true), sub{title} }",
+			false,
+			`{"id":"123","rel":{"sub":[{"title":"sub_title1"},{"title":"sub_title2"}],"title":"rel..."}}`,
+		},
+		{
+			"invalid fields group",
+			map[string]any{"a": 1},
+			"a{b}c",
+			true,
+			``,
+		},
+		{
+			"empty fields group",
+			map[string]any{"a": 1},
+			"a{}",
+			true,
+			``,
+		},
+		{
+			"unbalanced fields group",
+			map[string]any{"a": 1},
+			"a{b,c",
+			true,
+			``,
+		},
+		{
+			"only excluded fields",
+			&search.Result{
+				Page:       1,
+				PerPage:    10,
+				TotalItems: 20,
+				TotalPages: 30,
+				Items: []any{
+					map[string]any{"a": 11, "b": 11, "c": map[string]any{"c1": 1, "c2": 2}},
+					map[string]any{"a": 22, "b": 22, "c": map[string]any{"c1": 3, "c2": 4}},
+				},
+			},
+			"-a,-c.c1,-missing",
+			false,
+			`{"items":[{"b":11,"c":{"c2":2}},{"b":22,"c":{"c2":4}}],"page":1,"perPage":10,"totalItems":20,"totalPages":30}`,
+		},
+		{
+			"picked and excluded fields",
+			map[string]any{
+				"id":  "123",
+				"rel": map[string]any{"id": "456", "title": "rel_title", "secret": "abc"},
+			},
+			"rel.*,-rel{secret,id}",
+			false,
+			`{"rel":{"title":"rel_title"}}`,
+		},
+		{
+			"excluded fields group",
+			map[string]any{
+				"id":  "123",
+				"rel": map[string]any{"id": "456", "title": "rel_title", "secret": "abc"},
+			},
+			"*,rel{*,-secret}",
+			false,
+			`{"id":"123","rel":{"id":"456","title":"rel_title"}}`,
+		},
+		{
+			"excluded field with modifier",
+			map[string]any{"a": 1},
+			"-a:excerpt(1)",
+			true,
+			``,
+		},
+		{
+			"path modifier",
+			map[string]any{
+				"id":   "123",
+				"data": map[string]any{"a": map[string]any{"b": []any{"x", map[string]any{"c": true}}}},
+			},
+			"id,data:path(a.b.1.c)",
+			false,
+			`{"data":true,"id":"123"}`,
+		},
 	}
 
 	for _, s := range scenarios {
@@ -0,0 +1,66 @@
+package picker
+
+import (
+	"slices"
+	"strings"
+)
+
+// ProjectedKey describes a root level data key required to resolve a fields expression.
+type ProjectedKey struct {
+	// Name is the root level key name.
+	Name string
+
+	// Path holds the "path" modifier segments in case the key is
+	// projected only through a single `key:path(...)` expression
+	// (aka. only a part of the key value is needed).
+	Path []string
+}
+
+// Projection returns the list of the available root level keys that are
+// required to resolve the rawFields expression (see [Pick]).
+//
+// It could be used for example to limit the selected db columns
+// before fetching the data that will be picked.
+//
+// The returned keys are in the same order as the available ones.
+func Projection(rawFields string, available []string) ([]ProjectedKey, error) {
+	fields, excluded, err := parseFields(rawFields)
+	if err != nil {
+		return nil, err
+	}
+
+	_, hasWildcard := fields["*"]
+
+	result := make([]ProjectedKey, 0, len(available))
+
+	for _, key := range available {
+		// fully excluded key
+		if slices.Contains(excluded, key) {
+			continue
+		}
+
+		var total int
+		var modifier Modifier
+		for f, m := range fields {
+			if f == key || strings.HasPrefix(f, key+".") {
+				total++
+				if f == key {
+					modifier = m
+				}
+			}
+		}
+
+		if total == 0 && !hasWildcard {
+			continue
+		}
+
+		projected := ProjectedKey{Name: key}
+		if pm, ok := modifier.(*pathModifier); ok && total == 1 {
+			projected.Path = slices.Clone(pm.segments)
+		}
+
+		result = append(result, projected)
+	}
+
+	return result, nil
+}
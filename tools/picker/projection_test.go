@@ -0,0 +1,76 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/picker"
+)
+
+func TestProjection(t *testing.T) {
+	available := []string{"id", "title", "data", "rel", "expand"}
+
+	scenarios := []struct {
+		name        string
+		fields      string
+		expectError bool
+		expected    string
+	}{
+		{
+			"invalid fields",
+			"a{",
+			true,
+			"",
+		},
+		{
+			"explicit fields",
+			"title,missing,expand.rel.id",
+			false,
+			`[{"Name":"title","Path":null},{"Name":"expand","Path":null}]`,
+		},
+		{
+			"wildcard",
+			"*,title:excerpt(10)",
+			false,
+			`[{"Name":"id","Path":null},{"Name":"title","Path":null},{"Name":"data","Path":null},{"Name":"rel","Path":null},{"Name":"expand","Path":null}]`,
+		},
+		{
+			"exclusions",
+			"-data,-rel.id,-missing",
+			false,
+			`[{"Name":"id","Path":null},{"Name":"title","Path":null},{"Name":"rel","Path":null},{"Name":"expand","Path":null}]`,
+		},
+		{
+			"path modifier",
+			"id,data:path(a.b)",
+			false,
+			`[{"Name":"id","Path":null},{"Name":"data","Path":["a","b"]}]`,
+		},
+		{
+			"path modifier with other key fields",
+			"data:path(a.b),data.c",
+			false,
+			`[{"Name":"data","Path":null}]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := picker.Projection(s.fields, available)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			raw, _ := json.Marshal(result)
+			if v := string(raw); v != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, v)
+			}
+		})
+	}
+}
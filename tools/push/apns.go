@@ -0,0 +1,171 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+var _ Driver = (*APNsClient)(nil)
+
+const (
+	// DefaultAPNsEndpoint is the default production APNs API base url.
+	DefaultAPNsEndpoint = "https://api.push.apple.com"
+
+	// DefaultAPNsSandboxEndpoint is the development (sandbox) APNs API base url.
+	DefaultAPNsSandboxEndpoint = "https://api.sandbox.push.apple.com"
+
+	// apnsTokenTTL is the duration for which a generated provider token is reused.
+	//
+	// Apple requires the token to be refreshed no more often than every
+	// 20 minutes and no less often than every 60 minutes.
+	apnsTokenTTL = 50 * time.Minute
+)
+
+// APNsClient defines a push driver that sends notifications
+// via the Apple Push Notification service HTTP/2 API
+// (using token-based provider authentication).
+type APNsClient struct {
+	// TeamId is the Apple developer team id.
+	TeamId string
+
+	// KeyId is the id of the APNs authentication key.
+	KeyId string
+
+	// PrivateKey is the PEM encoded content of the APNs authentication key (.p8 file).
+	PrivateKey string
+
+	// Topic is the app bundle id.
+	Topic string
+
+	// Production specifies whether to use the production or the sandbox APNs environment.
+	//
+	// It is used only when no explicit Endpoint is set.
+	Production bool
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to [DefaultAPNsEndpoint] or [DefaultAPNsSandboxEndpoint]).
+	Endpoint string
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+
+	mux          sync.Mutex
+	token        string
+	tokenExpires time.Time
+}
+
+// Send implements [push.Driver] interface.
+func (c *APNsClient) Send(ctx context.Context, device *Device, message *Message) error {
+	payload := map[string]any{}
+
+	// custom data is placed at the top level next to the reserved "aps" key
+	for k, v := range message.Data {
+		payload[k] = v
+	}
+
+	payload["aps"] = map[string]any{
+		"alert": map[string]any{
+			"title": message.Title,
+			"body":  message.Body,
+		},
+		"sound": "default",
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		if c.Production {
+			endpoint = DefaultAPNsEndpoint
+		} else {
+			endpoint = DefaultAPNsSandboxEndpoint
+		}
+	}
+
+	req, err := httpx.NewJSONRequest(ctx, strings.TrimRight(endpoint, "/")+"/3/device/"+url.PathEscape(device.Token), payload)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.providerToken(time.Now())
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("authorization", "bearer "+token)
+	req.Header.Set("apns-topic", c.Topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+
+	err = httpx.Send(c.HTTPClient, "apns", httpSendAction, req, nil)
+
+	var httpErr *HTTPResponseError
+	if errors.As(err, &httpErr) && isInvalidAPNsDevice(httpErr) {
+		return fmt.Errorf("%w: %w", ErrInvalidDevice, err)
+	}
+
+	return err
+}
+
+// providerToken returns a cached (or a new) signed APNs provider token.
+func (c *APNsClient) providerToken(now time.Time) (string, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.token != "" && now.Before(c.tokenExpires) {
+		return c.token, nil
+	}
+
+	key, err := jwt.ParseECPrivateKeyFromPEM([]byte(c.PrivateKey))
+	if err != nil {
+		return "", fmt.Errorf("apns: invalid private key: %w", err)
+	}
+
+	token, err := signAPNsToken(key, c.KeyId, c.TeamId, now)
+	if err != nil {
+		return "", err
+	}
+
+	c.token = token
+	c.tokenExpires = now.Add(apnsTokenTTL)
+
+	return c.token, nil
+}
+
+func signAPNsToken(key *ecdsa.PrivateKey, keyId string, teamId string, now time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": teamId,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = keyId
+
+	return token.SignedString(key)
+}
+
+// isInvalidAPNsDevice checks whether the APNs error response
+// indicates an unregistered or malformed device token.
+func isInvalidAPNsDevice(err *HTTPResponseError) bool {
+	if err.Status == http.StatusGone {
+		return true
+	}
+
+	if err.Status != http.StatusBadRequest {
+		return false
+	}
+
+	data := struct {
+		Reason string `json:"reason"`
+	}{}
+	_ = json.Unmarshal(err.Body, &data)
+
+	return data.Reason == "BadDeviceToken" || data.Reason == "DeviceTokenNotForTopic"
+}
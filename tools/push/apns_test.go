@@ -0,0 +1,173 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func newTestECKeyPEM(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestAPNsClientSend(t *testing.T) {
+	t.Parallel()
+
+	key, keyPEM := newTestECKeyPEM(t)
+
+	server, captured := httpxtest.NewCaptureServer(t, 200, "")
+
+	client := &APNsClient{
+		TeamId:     "test_team",
+		KeyId:      "test_key",
+		PrivateKey: keyPEM,
+		Topic:      "com.example.app",
+		Endpoint:   server.URL,
+	}
+
+	err := client.Send(context.Background(), &Device{Token: "abc123"}, &Message{
+		Title: "test_title",
+		Body:  "test_body",
+		Data:  map[string]string{"id": "123"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Method != http.MethodPost {
+		t.Fatalf("Expected POST method, got %q", captured.Method)
+	}
+
+	if captured.Path != "/3/device/abc123" {
+		t.Fatalf("Expected path /3/device/abc123, got %q", captured.Path)
+	}
+
+	if v := captured.Headers.Get("apns-topic"); v != "com.example.app" {
+		t.Fatalf("Expected apns-topic header com.example.app, got %q", v)
+	}
+
+	if v := captured.Headers.Get("apns-push-type"); v != "alert" {
+		t.Fatalf("Expected apns-push-type header alert, got %q", v)
+	}
+
+	httpxtest.CheckContains(t, captured.Body, []string{
+		`"aps":{"alert":{"body":"test_body","title":"test_title"}`,
+		`"id":"123"`,
+	})
+
+	// verify the provider token
+	rawToken := strings.TrimPrefix(captured.Headers.Get("authorization"), "bearer ")
+	token, err := jwt.Parse(rawToken, func(token *jwt.Token) (any, error) {
+		if token.Header["kid"] != "test_key" {
+			t.Errorf("Expected kid test_key, got %v", token.Header["kid"])
+		}
+		return &key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	if err != nil {
+		t.Fatalf("Invalid provider token: %v", err)
+	}
+
+	if iss, _ := token.Claims.GetIssuer(); iss != "test_team" {
+		t.Fatalf("Expected iss test_team, got %q", iss)
+	}
+}
+
+func TestAPNsClientProviderTokenCache(t *testing.T) {
+	t.Parallel()
+
+	_, keyPEM := newTestECKeyPEM(t)
+
+	client := &APNsClient{TeamId: "test_team", KeyId: "test_key", PrivateKey: keyPEM}
+
+	now := time.Now()
+
+	token1, err := client.providerToken(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token2, err := client.providerToken(now.Add(10 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token1 != token2 {
+		t.Fatal("Expected the provider token to be reused")
+	}
+
+	token3, err := client.providerToken(now.Add(apnsTokenTTL + time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token1 == token3 {
+		t.Fatal("Expected a new provider token to be generated")
+	}
+}
+
+func TestAPNsClientSendInvalidDevice(t *testing.T) {
+	t.Parallel()
+
+	_, keyPEM := newTestECKeyPEM(t)
+
+	scenarios := []struct {
+		status          int
+		response        string
+		expectedInvalid bool
+	}{
+		{400, `{"reason":"BadDeviceToken"}`, true},
+		{400, `{"reason":"DeviceTokenNotForTopic"}`, true},
+		{400, `{"reason":"BadTopic"}`, false},
+		{410, `{"reason":"Unregistered"}`, true},
+		{500, `{"reason":"InternalServerError"}`, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.response, func(t *testing.T) {
+			server, _ := httpxtest.NewCaptureServer(t, s.status, s.response)
+
+			client := &APNsClient{PrivateKey: keyPEM, Endpoint: server.URL}
+
+			err := client.Send(context.Background(), &Device{Token: "abc"}, &Message{})
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if isInvalid := errors.Is(err, ErrInvalidDevice); isInvalid != s.expectedInvalid {
+				t.Fatalf("Expected ErrInvalidDevice %v, got %v (%v)", s.expectedInvalid, isInvalid, err)
+			}
+		})
+	}
+}
+
+func TestAPNsClientInvalidPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	client := &APNsClient{PrivateKey: "invalid", Endpoint: "http://127.0.0.1:0"}
+
+	err := client.Send(context.Background(), &Device{Token: "abc"}, &Message{})
+	if err == nil || !strings.Contains(err.Error(), "invalid private key") {
+		t.Fatalf("Expected invalid private key error, got %v", err)
+	}
+}
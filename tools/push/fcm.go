@@ -0,0 +1,163 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+var _ Driver = (*FCMClient)(nil)
+
+const (
+	// DefaultFCMEndpoint is the default Firebase Cloud Messaging API base url.
+	DefaultFCMEndpoint = "https://fcm.googleapis.com"
+
+	// DefaultFCMTokenURL is the default Google OAuth2 token url
+	// used for exchanging the service account JWT assertion.
+	DefaultFCMTokenURL = "https://oauth2.googleapis.com/token"
+
+	fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+)
+
+// FCMClient defines a push driver that sends notifications
+// via the Firebase Cloud Messaging HTTP v1 API.
+type FCMClient struct {
+	// ProjectId is the Firebase project id.
+	ProjectId string
+
+	// ClientEmail is the service account email.
+	ClientEmail string
+
+	// PrivateKey is the service account PEM encoded private key.
+	PrivateKey string
+
+	// TokenURL is an optional custom OAuth2 token url
+	// (if not explicitly set, defaults to [DefaultFCMTokenURL]).
+	TokenURL string
+
+	// Endpoint is an optional custom API base url
+	// (if not explicitly set, defaults to [DefaultFCMEndpoint]).
+	Endpoint string
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	HTTPClient *http.Client
+
+	mux         sync.Mutex
+	tokenSource oauth2.TokenSource
+}
+
+type fcmServiceAccount struct {
+	ProjectId   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// NewFCMClientFromServiceAccount creates a new [FCMClient] from the
+// JSON content of a Google service account key file.
+func NewFCMClientFromServiceAccount(serviceAccountJSON []byte) (*FCMClient, error) {
+	sa := fcmServiceAccount{}
+	if err := json.Unmarshal(serviceAccountJSON, &sa); err != nil {
+		return nil, fmt.Errorf("invalid FCM service account: %w", err)
+	}
+
+	if sa.ProjectId == "" || sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return nil, errors.New("invalid FCM service account: missing project_id, client_email or private_key")
+	}
+
+	return &FCMClient{
+		ProjectId:   sa.ProjectId,
+		ClientEmail: sa.ClientEmail,
+		PrivateKey:  sa.PrivateKey,
+		TokenURL:    sa.TokenURI,
+	}, nil
+}
+
+// Send implements [push.Driver] interface.
+func (c *FCMClient) Send(ctx context.Context, device *Device, message *Message) error {
+	payload := map[string]any{
+		"token": device.Token,
+		"notification": map[string]any{
+			"title": message.Title,
+			"body":  message.Body,
+		},
+	}
+	if len(message.Data) > 0 {
+		payload["data"] = message.Data
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultFCMEndpoint
+	}
+
+	req, err := httpx.NewJSONRequest(
+		ctx,
+		strings.TrimRight(endpoint, "/")+"/v1/projects/"+url.PathEscape(c.ProjectId)+"/messages:send",
+		map[string]any{"message": payload},
+	)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.accessToken()
+	if err != nil {
+		return err
+	}
+	token.SetAuthHeader(req)
+
+	err = httpx.Send(c.HTTPClient, "fcm", httpSendAction, req, nil)
+
+	var httpErr *HTTPResponseError
+	if errors.As(err, &httpErr) && (httpErr.Status == http.StatusNotFound ||
+		strings.Contains(string(httpErr.Body), "UNREGISTERED")) {
+		return fmt.Errorf("%w: %w", ErrInvalidDevice, err)
+	}
+
+	return err
+}
+
+// accessToken returns a cached (or a new) OAuth2 access token for the configured service account.
+func (c *FCMClient) accessToken() (*oauth2.Token, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.tokenSource == nil {
+		tokenURL := c.TokenURL
+		if tokenURL == "" {
+			tokenURL = DefaultFCMTokenURL
+		}
+
+		config := &jwt.Config{
+			Email:      c.ClientEmail,
+			PrivateKey: []byte(c.PrivateKey),
+			Scopes:     []string{fcmScope},
+			TokenURL:   tokenURL,
+		}
+
+		// note: the token source is reused for the lifetime of the client
+		// so it shouldn't be bound to the (possibly short lived) request context
+		tokenCtx := context.Background()
+		if c.HTTPClient != nil {
+			tokenCtx = context.WithValue(tokenCtx, oauth2.HTTPClient, c.HTTPClient)
+		}
+
+		c.tokenSource = config.TokenSource(tokenCtx)
+	}
+
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("fcm: failed to obtain access token: %w", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,164 @@
+package push
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func newTestRSAKeyPEM(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestNewFCMClientFromServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name        string
+		json        string
+		expectError bool
+	}{
+		{"invalid json", `{`, true},
+		{"missing fields", `{"project_id":"test"}`, true},
+		{
+			"valid",
+			`{"project_id":"test_project","client_email":"test@example.com","private_key":"test_key","token_uri":"https://example.com/token"}`,
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			client, err := NewFCMClientFromServiceAccount([]byte(s.json))
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if client.ProjectId != "test_project" ||
+				client.ClientEmail != "test@example.com" ||
+				client.PrivateKey != "test_key" ||
+				client.TokenURL != "https://example.com/token" {
+				t.Fatalf("Unexpected client fields: %#v", client)
+			}
+		})
+	}
+}
+
+func TestFCMClientSend(t *testing.T) {
+	t.Parallel()
+
+	var tokenRequests atomic.Int32
+	var sendStatus atomic.Int32
+	sendStatus.Store(200)
+
+	var lastAuth string
+	var lastPath string
+	var lastBody []byte
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"test_access_token","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		lastAuth = r.Header.Get("Authorization")
+		lastPath = r.URL.Path
+		lastBody, _ = io.ReadAll(r.Body)
+
+		status := int(sendStatus.Load())
+		w.WriteHeader(status)
+		if status == 404 {
+			w.Write([]byte(`{"error":{"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &FCMClient{
+		ProjectId:   "test_project",
+		ClientEmail: "test@example.com",
+		PrivateKey:  newTestRSAKeyPEM(t),
+		TokenURL:    server.URL + "/token",
+		Endpoint:    server.URL,
+	}
+
+	message := &Message{
+		Title: "test_title",
+		Body:  "test_body",
+		Data:  map[string]string{"id": "123"},
+	}
+
+	for i := 0; i < 2; i++ {
+		err := client.Send(context.Background(), &Device{Token: "test_device"}, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if total := tokenRequests.Load(); total != 1 {
+		t.Fatalf("Expected the access token to be requested once, got %d", total)
+	}
+
+	if lastAuth != "Bearer test_access_token" {
+		t.Fatalf("Expected Bearer access token, got %q", lastAuth)
+	}
+
+	if lastPath != "/v1/projects/test_project/messages:send" {
+		t.Fatalf("Unexpected path %q", lastPath)
+	}
+
+	body := map[string]any{}
+	if err := json.Unmarshal(lastBody, &body); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := json.Marshal(body)
+	httpxtest.CheckContains(t, string(raw), []string{
+		`"token":"test_device"`,
+		`"notification":{"body":"test_body","title":"test_title"}`,
+		`"data":{"id":"123"}`,
+	})
+
+	// unregistered device
+	sendStatus.Store(404)
+	err := client.Send(context.Background(), &Device{Token: "test_device"}, message)
+	if !errors.Is(err, ErrInvalidDevice) {
+		t.Fatalf("Expected ErrInvalidDevice, got %v", err)
+	}
+
+	// other errors
+	sendStatus.Store(500)
+	err = client.Send(context.Background(), &Device{Token: "test_device"}, message)
+	if err == nil || errors.Is(err, ErrInvalidDevice) {
+		t.Fatalf("Expected non ErrInvalidDevice error, got %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+package push
+
+import "github.com/pocketbase/pocketbase/tools/internal/httpx"
+
+// DefaultHTTPTimeout is the default request timeout used by the push
+// drivers when no custom [http.Client] is specified.
+const DefaultHTTPTimeout = httpx.DefaultTimeout
+
+// HTTPResponseError defines a push service HTTP response error.
+type HTTPResponseError = httpx.ResponseError
+
+// httpSendAction is the push drivers [HTTPResponseError] action.
+const httpSendAction = "send notification"
@@ -0,0 +1,52 @@
+package push
+
+import (
+	"context"
+	"errors"
+)
+
+// Supported device platforms.
+const (
+	PlatformFCM     = "fcm"
+	PlatformAPNs    = "apns"
+	PlatformWebPush = "webpush"
+)
+
+// ErrInvalidDevice is returned by the drivers when the push service reports
+// that the device token (or WebPush subscription) is no longer valid
+// and should be removed.
+var ErrInvalidDevice = errors.New("the device token is invalid or expired")
+
+// Message defines a generic push notification message.
+type Message struct {
+	// Title is the notification title.
+	Title string `json:"title"`
+
+	// Body is the notification body text.
+	Body string `json:"body"`
+
+	// Data is an optional custom key-value payload delivered with the notification.
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// Device defines a single push notification target.
+type Device struct {
+	// Token is the FCM registration token, the APNs device token
+	// or the WebPush subscription endpoint url.
+	Token string
+
+	// P256dh is the WebPush subscription public key (base64url encoded).
+	P256dh string
+
+	// Auth is the WebPush subscription auth secret (base64url encoded).
+	Auth string
+}
+
+// Driver defines a base push notifications client interface.
+type Driver interface {
+	// Send delivers the provided message to a single device.
+	//
+	// Returns [ErrInvalidDevice] (or an error wrapping it) if the
+	// device is no longer registered with the push service.
+	Send(ctx context.Context, device *Device, message *Message) error
+}
@@ -0,0 +1,354 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx"
+)
+
+var _ Driver = (*WebPushClient)(nil)
+
+const (
+	// DefaultWebPushTTL is the default time (in seconds) for which
+	// the push service will retain the message if the user agent is offline.
+	DefaultWebPushTTL = 2419200 // 4 weeks
+
+	// webPushRecordSize is the aes128gcm encoding record size.
+	webPushRecordSize = 4096
+)
+
+// WebPushClient defines a push driver that sends notifications
+// to the browsers WebPush subscriptions (using VAPID authentication
+// and "aes128gcm" payload encryption as defined in RFC 8291 and RFC 8292).
+type WebPushClient struct {
+	// PublicKey is the base64url encoded (uncompressed P-256 point) VAPID public key.
+	PublicKey string
+
+	// PrivateKey is the base64url encoded VAPID private key.
+	PrivateKey string
+
+	// Subject is the VAPID contact subject ("mailto:" or "https:" url).
+	Subject string
+
+	// TTL is an optional message TTL in seconds
+	// (if not explicitly set, defaults to [DefaultWebPushTTL]).
+	TTL int
+
+	// HTTPClient is an optional custom HTTP client to use for the API requests.
+	//
+	// Note that the subscription endpoints are provided by the clients and
+	// the default HTTP client refuses to connect to non-public IP addresses.
+	HTTPClient *http.Client
+}
+
+// webPushHTTPClient is the default WebPush HTTP client.
+//
+// It ignores the environment proxy settings and refuses to dial
+// loopback, private, link-local and other non-public IP addresses
+// (the check is performed after the DNS resolution and for every redirect).
+var webPushHTTPClient = &http.Client{
+	Timeout: DefaultHTTPTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 10 * time.Second,
+			Control: func(network, address string, c syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+
+				if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+					return fmt.Errorf("webpush: dialing non-public address %q is not allowed", host)
+				}
+
+				return nil
+			},
+		}).DialContext,
+		ForceAttemptHTTP2:   true,
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	},
+}
+
+// cgnatRange is the shared address space (RFC 6598) that is not publicly routable.
+var cgnatRange = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublicIP reports whether the provided ip is a publicly routable unicast address.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!cgnatRange.Contains(ip)
+}
+
+// ValidateWebPushEndpoint checks whether the provided WebPush
+// subscription endpoint is an absolute https url without a
+// localhost or non-public IP host.
+//
+// Note that the hostnames are not resolved here and the final
+// check is performed when dialing with the default client.
+func ValidateWebPushEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return errors.New("invalid WebPush endpoint url")
+	}
+
+	if u.Scheme != "https" {
+		return errors.New("the WebPush endpoint must be an https url")
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.New("the WebPush endpoint host is not allowed")
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return errors.New("the WebPush endpoint host is not allowed")
+	}
+
+	return nil
+}
+
+// GenerateVAPIDKeys generates a new base64url encoded VAPID key pair.
+func GenerateVAPIDKeys() (publicKey string, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey = base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+	privateKey = base64.RawURLEncoding.EncodeToString(key.Bytes())
+
+	return publicKey, privateKey, nil
+}
+
+// Send implements [push.Driver] interface.
+func (c *WebPushClient) Send(ctx context.Context, device *Device, message *Message) error {
+	endpoint, err := url.Parse(device.Token)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("%w: invalid WebPush endpoint (must be an https url)", ErrInvalidDevice)
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	body, err := encryptWebPushPayload(payload, device.P256dh, device.Auth)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := c.vapidAuthorization(endpoint, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = DefaultWebPushTTL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(ttl))
+	req.Header.Set("Urgency", "normal")
+	req.Header.Set("Authorization", authorization)
+
+	client := c.HTTPClient
+	if client == nil {
+		client = webPushHTTPClient
+	}
+
+	err = httpx.Send(client, "webpush", httpSendAction, req, nil)
+
+	var httpErr *HTTPResponseError
+	if errors.As(err, &httpErr) && (httpErr.Status == http.StatusNotFound || httpErr.Status == http.StatusGone) {
+		return fmt.Errorf("%w: %w", ErrInvalidDevice, err)
+	}
+
+	return err
+}
+
+// vapidAuthorization returns the "vapid" Authorization header value for the specified push service endpoint.
+func (c *WebPushClient) vapidAuthorization(endpoint *url.URL, now time.Time) (string, error) {
+	key, err := parseVAPIDPrivateKey(c.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": now.Add(12 * time.Hour).Unix(),
+		"sub": c.Subject,
+	})
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", err
+	}
+
+	return "vapid t=" + signed + ", k=" + c.PublicKey, nil
+}
+
+func parseVAPIDPrivateKey(encoded string) (*ecdsa.PrivateKey, error) {
+	raw, err := decodeBase64URL(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("webpush: invalid VAPID private key: %w", err)
+	}
+
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("webpush: invalid VAPID private key: %w", err)
+	}
+
+	// uncompressed point: 0x04 || X || Y
+	pub := key.PublicKey().Bytes()
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(pub[1:33]),
+			Y:     new(big.Int).SetBytes(pub[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}, nil
+}
+
+// encryptWebPushPayload encrypts the plaintext for the specified
+// subscription keys using the "aes128gcm" content encoding (RFC 8291).
+func encryptWebPushPayload(plaintext []byte, p256dh string, auth string) ([]byte, error) {
+	rawUAPublic, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid WebPush p256dh key", ErrInvalidDevice)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(rawUAPublic)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid WebPush p256dh key", ErrInvalidDevice)
+	}
+
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil || len(authSecret) == 0 {
+		return nil, fmt.Errorf("%w: invalid WebPush auth secret", ErrInvalidDevice)
+	}
+
+	// most push services limit the whole encrypted body to 4096 bytes
+	// (86 bytes header + 16 bytes AEAD tag + 1 byte padding delimiter)
+	if len(plaintext) > webPushRecordSize-103 {
+		return nil, errors.New("webpush: the message payload is too large")
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	cek, nonce, err := deriveWebPushKeys(ecdhSecret, authSecret, salt, rawUAPublic, asPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// single record with the 0x02 "last record" padding delimiter
+	record := append(append([]byte{}, plaintext...), 0x02)
+
+	// header: salt (16) || rs (4) || idlen (1) || keyid (65)
+	header := make([]byte, 0, 21+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+// deriveWebPushKeys derives the content encryption key and nonce as defined in RFC 8291 Section 3.4.
+func deriveWebPushKeys(ecdhSecret, authSecret, salt, uaPublic, asPublic []byte) (cek []byte, nonce []byte, err error) {
+	prkKey, err := hkdf.Extract(sha256.New, ecdhSecret, authSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyInfo := "WebPush: info\x00" + string(uaPublic) + string(asPublic)
+
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cek, err = hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce, err = hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cek, nonce, nil
+}
+
+// decodeBase64URL decodes the provided base64url string (with or without padding).
+func decodeBase64URL(str string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(str, "="))
+}
@@ -0,0 +1,295 @@
+package push
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pocketbase/pocketbase/tools/internal/httpx/httpxtest"
+)
+
+func TestGenerateVAPIDKeys(t *testing.T) {
+	t.Parallel()
+
+	public, private, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawPublic, err := decodeBase64URL(public)
+	if err != nil || len(rawPublic) != 65 {
+		t.Fatalf("Expected 65 bytes public key, got %d (%v)", len(rawPublic), err)
+	}
+
+	key, err := parseVAPIDPrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the public key should match the private one
+	ecdhKey, err := key.ECDH()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoded := base64.RawURLEncoding.EncodeToString(ecdhKey.PublicKey().Bytes()); encoded != public {
+		t.Fatalf("Expected public key %q, got %q", public, encoded)
+	}
+}
+
+// decryptWebPushPayload is the user agent side of [encryptWebPushPayload].
+func decryptWebPushPayload(t *testing.T, body []byte, uaPrivate *ecdh.PrivateKey, authSecret []byte) []byte {
+	salt := body[:16]
+	rs := binary.BigEndian.Uint32(body[16:20])
+	idLen := int(body[20])
+	asPublicRaw := body[21 : 21+idLen]
+	ciphertext := body[21+idLen:]
+
+	if rs != webPushRecordSize {
+		t.Fatalf("Expected record size %d, got %d", webPushRecordSize, rs)
+	}
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicRaw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecdhSecret, err := uaPrivate.ECDH(asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cek, nonce, err := deriveWebPushKeys(ecdhSecret, authSecret, salt, uaPrivate.PublicKey().Bytes(), asPublicRaw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatalf("Failed to decrypt the payload: %v", err)
+	}
+
+	if record[len(record)-1] != 0x02 {
+		t.Fatalf("Expected last record delimiter, got %v", record[len(record)-1])
+	}
+
+	return record[:len(record)-1]
+}
+
+func TestWebPushClientSend(t *testing.T) {
+	t.Parallel()
+
+	vapidPublic, vapidPrivate, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authSecret := make([]byte, 16)
+	rand.Read(authSecret)
+
+	server, captured := httpxtest.NewTLSCaptureServer(t, 201, "")
+
+	client := &WebPushClient{
+		PublicKey:  vapidPublic,
+		PrivateKey: vapidPrivate,
+		Subject:    "mailto:test@example.com",
+		TTL:        60,
+		HTTPClient: server.Client(),
+	}
+
+	device := &Device{
+		Token:  server.URL + "/push/abc",
+		P256dh: base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+		Auth:   base64.RawURLEncoding.EncodeToString(authSecret),
+	}
+
+	err = client.Send(context.Background(), device, &Message{
+		Title: "test_title",
+		Body:  "test_body",
+		Data:  map[string]string{"id": "123"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/push/abc" {
+		t.Fatalf("Expected path /push/abc, got %q", captured.Path)
+	}
+
+	expectedHeaders := map[string]string{
+		"Content-Encoding": "aes128gcm",
+		"Content-Type":     "application/octet-stream",
+		"Ttl":              "60",
+	}
+	for k, v := range expectedHeaders {
+		if h := captured.Headers.Get(k); h != v {
+			t.Fatalf("Expected header %s %q, got %q", k, v, h)
+		}
+	}
+
+	plaintext := decryptWebPushPayload(t, []byte(captured.Body), uaPrivate, authSecret)
+	httpxtest.CheckContains(t, string(plaintext), []string{
+		`"title":"test_title"`,
+		`"body":"test_body"`,
+		`"data":{"id":"123"}`,
+	})
+
+	// verify the VAPID authorization
+	authorization := captured.Headers.Get("Authorization")
+	if !strings.HasSuffix(authorization, ", k="+vapidPublic) {
+		t.Fatalf("Expected the VAPID public key in %q", authorization)
+	}
+
+	rawToken := strings.TrimSuffix(strings.TrimPrefix(authorization, "vapid t="), ", k="+vapidPublic)
+
+	vapidKey, _ := parseVAPIDPrivateKey(vapidPrivate)
+
+	token, err := jwt.Parse(rawToken, func(token *jwt.Token) (any, error) {
+		return &vapidKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	if err != nil {
+		t.Fatalf("Invalid VAPID token: %v", err)
+	}
+
+	if aud, _ := token.Claims.GetAudience(); len(aud) != 1 || aud[0] != server.URL {
+		t.Fatalf("Expected aud %q, got %v", server.URL, aud)
+	}
+
+	if sub, _ := token.Claims.GetSubject(); sub != "mailto:test@example.com" {
+		t.Fatalf("Expected sub mailto:test@example.com, got %q", sub)
+	}
+}
+
+func TestWebPushClientSendInvalidDevice(t *testing.T) {
+	t.Parallel()
+
+	vapidPublic, vapidPrivate, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uaPrivate, _ := ecdh.P256().GenerateKey(rand.Reader)
+
+	validKeys := func(token string) *Device {
+		return &Device{
+			Token:  token,
+			P256dh: base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+			Auth:   "dGVzdF9hdXRoX3NlY3JldA",
+		}
+	}
+
+	goneServer, _ := httpxtest.NewTLSCaptureServer(t, 410, "")
+	errorServer, _ := httpxtest.NewTLSCaptureServer(t, 500, "")
+
+	scenarios := []struct {
+		name            string
+		device          *Device
+		expectedInvalid bool
+	}{
+		{"invalid endpoint", validKeys("invalid"), true},
+		{"non-https endpoint", validKeys("http://example.com/push"), true},
+		{"invalid p256dh", &Device{Token: goneServer.URL, P256dh: "invalid", Auth: "dGVzdA"}, true},
+		{"missing auth", &Device{Token: goneServer.URL, P256dh: validKeys("").P256dh}, true},
+		{"expired subscription", validKeys(goneServer.URL), true},
+		{"server error", validKeys(errorServer.URL), false},
+	}
+
+	client := &WebPushClient{PublicKey: vapidPublic, PrivateKey: vapidPrivate, HTTPClient: goneServer.Client()}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := client.Send(context.Background(), s.device, &Message{Title: "test"})
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if isInvalid := errors.Is(err, ErrInvalidDevice); isInvalid != s.expectedInvalid {
+				t.Fatalf("Expected ErrInvalidDevice %v, got %v (%v)", s.expectedInvalid, isInvalid, err)
+			}
+		})
+	}
+}
+
+func TestWebPushClientSendTooLargePayload(t *testing.T) {
+	t.Parallel()
+
+	uaPrivate, _ := ecdh.P256().GenerateKey(rand.Reader)
+
+	_, err := encryptWebPushPayload(
+		[]byte(strings.Repeat("a", 4000)),
+		base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+		"dGVzdA",
+	)
+	if err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Fatalf("Expected too large error, got %v", err)
+	}
+}
+
+func TestValidateWebPushEndpoint(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		endpoint    string
+		expectError bool
+	}{
+		{"", true},
+		{"invalid", true},
+		{"http://example.com/push", true},
+		{"ftp://example.com/push", true},
+		{"https://localhost/push", true},
+		{"https://test.localhost/push", true},
+		{"https://127.0.0.1/push", true},
+		{"https://10.0.0.1/push", true},
+		{"https://192.168.1.1/push", true},
+		{"https://169.254.169.254/latest", true},
+		{"https://100.64.0.1/push", true},
+		{"https://[::1]/push", true},
+		{"https://[fe80::1]/push", true},
+		{"https://[fc00::1]/push", true},
+		{"https://0.0.0.0/push", true},
+		{"https://8.8.8.8/push", false},
+		{"https://fcm.googleapis.com/fcm/send/abc", false},
+		{"https://updates.push.services.mozilla.com/wpush/v2/abc", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.endpoint, func(t *testing.T) {
+			err := ValidateWebPushEndpoint(s.endpoint)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestWebPushDefaultClientNonPublicAddress(t *testing.T) {
+	t.Parallel()
+
+	server, captured := httpxtest.NewTLSCaptureServer(t, 201, "")
+
+	_, err := webPushHTTPClient.Get(server.URL)
+	if err == nil || !strings.Contains(err.Error(), "non-public address") {
+		t.Fatalf("Expected non-public address dial error, got %v", err)
+	}
+
+	if captured.Method != "" {
+		t.Fatalf("Expected no request to be sent, got %q", captured.Method)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"slices"
 
 	"github.com/pocketbase/pocketbase/tools/hook"
 )
@@ -47,6 +48,7 @@ type Router[T hook.Resolver] struct {
 	*RouterGroup[T]
 
 	eventFactory EventFactoryFunc[T]
+	rewriters    []func(r *http.Request)
 }
 
 // NewRouter creates a new Router instance with the provided event factory function.
@@ -76,7 +78,27 @@ func (r *Router[T]) BuildMux() (http.Handler, error) {
 		return nil, err
 	}
 
-	return mux, nil
+	if len(r.rewriters) == 0 {
+		return mux, nil
+	}
+
+	rewriters := slices.Clone(r.rewriters)
+
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		for _, rewrite := range rewriters {
+			rewrite(req)
+		}
+
+		mux.ServeHTTP(resp, req)
+	}), nil
+}
+
+// Rewrite registers one or more request rewrite functions that are
+// invoked before the route matching (e.g. to change the request url path).
+//
+// Note that the rewrite functions are executed in the order of their registration.
+func (r *Router[T]) Rewrite(fns ...func(req *http.Request)) {
+	r.rewriters = append(r.rewriters, fns...)
 }
 
 func (r *Router[T]) loadMux(mux *http.ServeMux, group *RouterGroup[T], parents []*RouterGroup[T]) error {
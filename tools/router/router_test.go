@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/pocketbase/pocketbase/tools/hook"
@@ -251,3 +252,67 @@ func TestRouterUnbind(t *testing.T) {
 		})
 	}
 }
+
+func TestRouterRewrite(t *testing.T) {
+	r := router.NewRouter(func(w http.ResponseWriter, r *http.Request) (*router.Event, router.EventCleanupFunc) {
+		return &router.Event{
+			Response: w,
+			Request:  r,
+		}, nil
+	})
+
+	r.GET("/a", func(e *router.Event) error {
+		return e.String(200, "a:"+e.Request.URL.RawQuery)
+	})
+	r.GET("/b/{name}", func(e *router.Event) error {
+		return e.String(200, "b:"+e.Request.PathValue("name"))
+	})
+
+	r.Rewrite(func(req *http.Request) {
+		if req.URL.Path == "/old_a" {
+			req.URL.Path = "/a"
+		}
+	}, func(req *http.Request) {
+		if req.URL.Path == "/a" && req.URL.RawQuery == "" {
+			req.URL.RawQuery = "rewritten=1"
+		}
+	})
+	r.Rewrite(func(req *http.Request) {
+		if name, ok := strings.CutPrefix(req.URL.Path, "/old_b/"); ok {
+			req.URL.Path = "/b/" + name
+		}
+	})
+
+	mux, err := r.BuildMux()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"/a", 200, "a:rewritten=1"},
+		{"/a?x=1", 200, "a:x=1"},
+		{"/old_a", 200, "a:rewritten=1"},
+		{"/old_b/test", 200, "b:test"},
+		{"/b/test", 200, "b:test"},
+		{"/missing", 404, ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, s.path, nil))
+
+			if rec.Code != s.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", s.expectedStatus, rec.Code)
+			}
+
+			if s.expectedBody != "" && rec.Body.String() != s.expectedBody {
+				t.Fatalf("Expected body %q, got %q", s.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Package sanitizer implements a minimal allowlist based HTML sanitizer.
+package sanitizer
+
+import (
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Builtin policy presets.
+const (
+	PresetStrict = "strict"
+	PresetBasic  = "basic"
+	PresetRich   = "rich"
+)
+
+// DefaultLinkRel is the default rel attribute value enforced on
+// the anchor elements by the builtin policy presets.
+const DefaultLinkRel = "noopener noreferrer nofollow"
+
+// dropContentTags lists the elements whose content is always
+// removed together with the element (even if they are allowed).
+var dropContentTags = []string{
+	"script", "style", "iframe", "frame", "frameset", "object", "embed",
+	"applet", "template", "noscript", "noembed", "title", "svg", "math",
+	"textarea", "select", "xmp", "plaintext",
+}
+
+// urlAttrs lists the attributes that are expected to contain an url.
+var urlAttrs = []string{"href", "src", "cite", "poster", "action", "formaction", "background"}
+
+// Policy defines the allowed elements and attributes of the sanitized HTML.
+type Policy struct {
+	// Elements maps the allowed element names to their allowed attributes.
+	Elements map[string][]string
+
+	// GlobalAttributes lists the attributes allowed for every allowed element.
+	GlobalAttributes []string
+
+	// URLSchemes lists the allowed url schemes of the href, src, etc. attributes.
+	//
+	// Relative urls are always allowed.
+	URLSchemes []string
+
+	// LinkRel is the optional rel attribute value enforced on every anchor element.
+	LinkRel string
+
+	// ImageHosts restricts the allowed img sources to the listed hosts
+	// (relative sources are always allowed).
+	//
+	// If empty, no host restrictions are applied.
+	ImageHosts []string
+}
+
+// NewPolicy returns a new [Policy] initialized from the specified preset name.
+//
+// Returns nil if preset is not one of the builtin presets.
+func NewPolicy(preset string) *Policy {
+	switch preset {
+	case PresetStrict:
+		return &Policy{}
+	case PresetBasic:
+		return &Policy{
+			Elements: map[string][]string{
+				"a":          {"href", "target"},
+				"b":          nil,
+				"blockquote": {"cite"},
+				"br":         nil,
+				"code":       nil,
+				"em":         nil,
+				"i":          nil,
+				"li":         nil,
+				"ol":         {"start"},
+				"p":          nil,
+				"s":          nil,
+				"span":       nil,
+				"strike":     nil,
+				"strong":     nil,
+				"sub":        nil,
+				"sup":        nil,
+				"u":          nil,
+				"ul":         nil,
+			},
+			GlobalAttributes: []string{"title", "dir", "lang"},
+			URLSchemes:       []string{"http", "https", "mailto", "tel"},
+			LinkRel:          DefaultLinkRel,
+		}
+	case PresetRich:
+		p := NewPolicy(PresetBasic)
+		p.GlobalAttributes = append(p.GlobalAttributes, "class")
+		for _, tag := range []string{
+			"h1", "h2", "h3", "h4", "h5", "h6", "hr", "pre", "div",
+			"del", "ins", "mark", "small", "abbr", "kbd", "figure", "figcaption",
+			"table", "caption", "thead", "tbody", "tfoot", "tr",
+		} {
+			p.Elements[tag] = nil
+		}
+		p.Elements["img"] = []string{"src", "alt", "width", "height"}
+		p.Elements["th"] = []string{"colspan", "rowspan", "scope"}
+		p.Elements["td"] = []string{"colspan", "rowspan"}
+		return p
+	}
+
+	return nil
+}
+
+// Sanitize parses the provided HTML string and returns a new one containing
+// only the policy allowed elements and attributes.
+//
+// The text content of the not allowed elements is preserved
+// (except for elements like script, style, iframe, etc.
+// which are always removed together with their content).
+func (p *Policy) Sanitize(str string) string {
+	var builder strings.Builder
+	builder.Grow(len(str))
+
+	var skipTag string
+	var skipDepth int
+
+	tokenizer := html.NewTokenizer(strings.NewReader(str))
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				// the tokenizer recovers from all syntax errors
+				// so this generally should never happen
+				return ""
+			}
+			break
+		}
+
+		token := tokenizer.Token()
+
+		if skipDepth > 0 {
+			switch {
+			case tokenType == html.StartTagToken && token.Data == skipTag:
+				skipDepth++
+			case tokenType == html.EndTagToken && token.Data == skipTag:
+				skipDepth--
+			}
+			continue
+		}
+
+		switch tokenType {
+		case html.TextToken:
+			builder.WriteString(html.EscapeString(token.Data))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if slices.Contains(dropContentTags, token.Data) {
+				if tokenType == html.StartTagToken {
+					skipTag = token.Data
+					skipDepth = 1
+				}
+				continue
+			}
+
+			attrs, ok := p.allowedAttrs(token)
+			if !ok {
+				continue
+			}
+
+			builder.WriteString("<")
+			builder.WriteString(token.Data)
+			for _, attr := range attrs {
+				builder.WriteString(" ")
+				builder.WriteString(attr.Key)
+				builder.WriteString(`="`)
+				builder.WriteString(html.EscapeString(attr.Val))
+				builder.WriteString(`"`)
+			}
+			if tokenType == html.SelfClosingTagToken {
+				builder.WriteString(" /")
+			}
+			builder.WriteString(">")
+		case html.EndTagToken:
+			if _, ok := p.Elements[token.Data]; ok {
+				builder.WriteString("</")
+				builder.WriteString(token.Data)
+				builder.WriteString(">")
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// allowedAttrs returns the allowed attributes of the start tag token.
+//
+// It returns false if the element itself is not allowed.
+func (p *Policy) allowedAttrs(token html.Token) ([]html.Attribute, bool) {
+	elemAttrs, ok := p.Elements[token.Data]
+	if !ok {
+		return nil, false
+	}
+
+	result := make([]html.Attribute, 0, len(token.Attr))
+
+	for _, attr := range token.Attr {
+		if attr.Namespace != "" || (!slices.Contains(elemAttrs, attr.Key) && !slices.Contains(p.GlobalAttributes, attr.Key)) {
+			continue
+		}
+
+		if slices.Contains(urlAttrs, attr.Key) {
+			u, ok := p.parseURL(attr.Val)
+			if !ok {
+				continue
+			}
+
+			if token.Data == "img" && attr.Key == "src" && len(p.ImageHosts) > 0 && u.Host != "" &&
+				!slices.Contains(p.ImageHosts, strings.ToLower(u.Hostname())) {
+				return nil, false // remove the entire image
+			}
+		}
+
+		result = append(result, attr)
+	}
+
+	// enforce the link rel value
+	if token.Data == "a" && p.LinkRel != "" {
+		result = slices.DeleteFunc(result, func(attr html.Attribute) bool {
+			return attr.Key == "rel"
+		})
+		result = append(result, html.Attribute{Key: "rel", Val: p.LinkRel})
+	}
+
+	return result, true
+}
+
+// parseURL parses and checks whether the provided url value is allowed.
+func (p *Policy) parseURL(rawURL string) (*url.URL, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, false
+	}
+
+	// relative or protocol-relative url
+	// (the latter is still subject to the ImageHosts check)
+	if u.Scheme == "" {
+		return u, true
+	}
+
+	return u, slices.Contains(p.URLSchemes, strings.ToLower(u.Scheme))
+}
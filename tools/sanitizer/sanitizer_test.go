@@ -0,0 +1,125 @@
+package sanitizer_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/sanitizer"
+)
+
+func TestNewPolicy(t *testing.T) {
+	scenarios := []struct {
+		preset    string
+		expectNil bool
+	}{
+		{"", true},
+		{"invalid", true},
+		{sanitizer.PresetStrict, false},
+		{sanitizer.PresetBasic, false},
+		{sanitizer.PresetRich, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.preset, func(t *testing.T) {
+			policy := sanitizer.NewPolicy(s.preset)
+
+			if isNil := policy == nil; isNil != s.expectNil {
+				t.Fatalf("Expected nil %v, got %v", s.expectNil, isNil)
+			}
+		})
+	}
+
+	// ensure that the presets are not shared
+	p1 := sanitizer.NewPolicy(sanitizer.PresetRich)
+	p1.Elements["custom"] = nil
+	p2 := sanitizer.NewPolicy(sanitizer.PresetRich)
+	if _, ok := p2.Elements["custom"]; ok {
+		t.Fatal("Expected the preset elements to not be shared between the policy instances")
+	}
+}
+
+func TestPolicySanitize(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		policy   *sanitizer.Policy
+		html     string
+		expected string
+	}{
+		{
+			"empty",
+			sanitizer.NewPolicy(sanitizer.PresetRich),
+			"",
+			"",
+		},
+		{
+			"strict",
+			sanitizer.NewPolicy(sanitizer.PresetStrict),
+			`<p class="a">Hello <b>world</b> &amp; 1 &lt; 2<br/></p><!-- comment --><style>p{}</style>`,
+			`Hello world &amp; 1 &lt; 2`,
+		},
+		{
+			"basic disallowed elements and attributes",
+			sanitizer.NewPolicy(sanitizer.PresetBasic),
+			`<h1 onclick="x()" title="t">A</h1><p style="color:red" dir="rtl">B<img src="a.png"></p><iframe src="https://example.com"><p>C</p></iframe>`,
+			`A<p dir="rtl">B</p>`,
+		},
+		{
+			"nested dropped content elements",
+			sanitizer.NewPolicy(sanitizer.PresetBasic),
+			`<svg><svg><b>a</b></svg><b>b</b></svg><b>c</b>`,
+			`<b>c</b>`,
+		},
+		{
+			"link urls and rel",
+			sanitizer.NewPolicy(sanitizer.PresetBasic),
+			`<a href="https://example.com?a=1&amp;b=2" rel="opener">1</a><a href=" JaVaScRiPt:alert(1)">2</a><a href="java&#09;script:alert(1)">3</a><a href="/relative">4</a><a href="mailto:test@example.com">5</a>`,
+			`<a href="https://example.com?a=1&amp;b=2" rel="noopener noreferrer nofollow">1</a>` +
+				`<a rel="noopener noreferrer nofollow">2</a>` +
+				`<a rel="noopener noreferrer nofollow">3</a>` +
+				`<a href="/relative" rel="noopener noreferrer nofollow">4</a>` +
+				`<a href="mailto:test@example.com" rel="noopener noreferrer nofollow">5</a>`,
+		},
+		{
+			"without link rel",
+			&sanitizer.Policy{Elements: map[string][]string{"a": {"href", "rel"}}, URLSchemes: []string{"https"}},
+			`<a href="https://example.com" rel="external">1</a>`,
+			`<a href="https://example.com" rel="external">1</a>`,
+		},
+		{
+			"rich images without host restrictions",
+			sanitizer.NewPolicy(sanitizer.PresetRich),
+			`<img src="https://a.com/1.png" alt="1" onerror="x()"><img src="/2.png"/><img src="data:image/png;base64,abc">`,
+			`<img src="https://a.com/1.png" alt="1"><img src="/2.png" /><img>`,
+		},
+		{
+			"rich images with host restrictions",
+			func() *sanitizer.Policy {
+				p := sanitizer.NewPolicy(sanitizer.PresetRich)
+				p.ImageHosts = []string{"a.com"}
+				return p
+			}(),
+			`<img src="https://A.com/1.png"><img src="https://b.com/2.png"><img src="//b.com/3.png"><img src="/4.png">`,
+			`<img src="https://A.com/1.png"><img src="/4.png">`,
+		},
+		{
+			"escaped attribute values",
+			sanitizer.NewPolicy(sanitizer.PresetRich),
+			`<p title='"><script>alert(1)</script>'>a</p>`,
+			`<p title="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;">a</p>`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.policy.Sanitize(s.html)
+
+			if result != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, result)
+			}
+
+			// should be idempotent
+			if again := s.policy.Sanitize(result); again != result {
+				t.Fatalf("Expected the sanitized value to remain the same after a second pass, got\n%s", again)
+			}
+		})
+	}
+}
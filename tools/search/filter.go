@@ -81,6 +81,16 @@ func (f FilterData) BuildExprWithLimit(
 
 	cacheKey := raw + "/" + strconv.Itoa(maxExpressions)
 
+	if cacher, ok := fieldResolver.(ExprCacheResolver); ok {
+		return cacher.CachedExpr(cacheKey, func() (dbx.Expression, error) {
+			return buildRawFilterExpr(raw, cacheKey, fieldResolver, maxExpressions)
+		})
+	}
+
+	return buildRawFilterExpr(raw, cacheKey, fieldResolver, maxExpressions)
+}
+
+func buildRawFilterExpr(raw string, cacheKey string, fieldResolver FieldResolver, maxExpressions int) (dbx.Expression, error) {
 	if data, ok := parsedFilterData.GetOk(cacheKey); ok {
 		return buildParsedFilterExpr(data, fieldResolver, &maxExpressions)
 	}
@@ -104,6 +114,18 @@ func (f FilterData) BuildExprWithLimit(
 	return buildParsedFilterExpr(data, fieldResolver, &maxExpressions)
 }
 
+// BuildParsedExpr returns a new db WHERE expression from an already
+// parsed filter data (see [fexpr.Parse]).
+//
+// It could be used for example to evaluate individually the different parts of a filter.
+//
+// The parsed expressions are limited up to DefaultFilterExprLimit.
+func BuildParsedExpr(data []fexpr.ExprGroup, fieldResolver FieldResolver) (dbx.Expression, error) {
+	maxExpressions := DefaultFilterExprLimit
+
+	return buildParsedFilterExpr(data, fieldResolver, &maxExpressions)
+}
+
 func buildParsedFilterExpr(data []fexpr.ExprGroup, fieldResolver FieldResolver, maxExpressions *int) (dbx.Expression, error) {
 	if len(data) == 0 {
 		return nil, fexpr.ErrEmpty
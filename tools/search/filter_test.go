@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ganigeorgiev/fexpr"
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/tools/search"
 )
@@ -275,6 +276,74 @@ func TestFilterDataBuildExprWithLimit(t *testing.T) {
 	}
 }
 
+type testExprCacheResolver struct {
+	*search.SimpleFieldResolver
+	keys []string
+}
+
+func (r *testExprCacheResolver) CachedExpr(key string, build func() (dbx.Expression, error)) (dbx.Expression, error) {
+	r.keys = append(r.keys, key)
+
+	if key == "cached/200" {
+		return dbx.NewExp("cached"), nil
+	}
+
+	return build()
+}
+
+func TestFilterDataBuildExprWithCacheResolver(t *testing.T) {
+	resolver := &testExprCacheResolver{SimpleFieldResolver: search.NewSimpleFieldResolver(`^\w+$`)}
+
+	expr, err := search.FilterData("cached").BuildExpr(resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw := expr.Build(&dbx.DB{}, dbx.Params{}); raw != "cached" {
+		t.Fatalf("Expected the cached expression, got %q", raw)
+	}
+
+	expr, err = search.FilterData("a = {:a}").BuildExpr(resolver, dbx.Params{"a": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw := expr.Build(&dbx.DB{}, dbx.Params{}); !strings.HasPrefix(raw, "[[a]] = ") {
+		t.Fatalf("Expected the built expression, got %q", raw)
+	}
+
+	expectedKeys := []string{"cached/200", "a = 1/200"}
+	if strings.Join(resolver.keys, ",") != strings.Join(expectedKeys, ",") {
+		t.Fatalf("Expected keys %v, got %v", expectedKeys, resolver.keys)
+	}
+}
+
+func TestBuildParsedExpr(t *testing.T) {
+	resolver := search.NewSimpleFieldResolver(`^\w+$`)
+
+	if _, err := search.BuildParsedExpr(nil, resolver); err == nil {
+		t.Fatal("Expected error for empty parsed data")
+	}
+
+	data, err := fexpr.Parse("a = 1 && (b > 2 || c ~ 'test')")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// evaluate only the second (group) expression
+	expr, err := search.BuildParsedExpr(data[1:], resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dummyDB := &dbx.DB{}
+
+	rawSql := expr.Build(dummyDB, dbx.Params{})
+
+	pattern := regexp.MustCompile(`^\(\(\[\[b\]\] > \{:\w+\} OR \[\[c\]\] LIKE \{:\w+\} ESCAPE '\\'\)\)$`)
+	if !pattern.MatchString(rawSql) {
+		t.Fatalf("Pattern %v don't match %v", pattern, rawSql)
+	}
+}
+
 func TestLikeParamsWrapping(t *testing.T) {
 	// create a dummy db
 	sqlDB, err := sql.Open("sqlite", "file::memory:?cache=shared")
@@ -58,6 +58,10 @@ type Result struct {
 	PerPage    int `json:"perPage"`
 	TotalItems int `json:"totalItems"`
 	TotalPages int `json:"totalPages"`
+
+	// NextCursor is an optional opaque cursor for fetching the next
+	// result items (set only by the keyset/cursor paginated searches).
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // Provider represents a single configured search provider instance.
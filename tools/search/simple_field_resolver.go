@@ -57,6 +57,14 @@ type FieldResolver interface {
 	Resolve(field string) (*ResolverResult, error)
 }
 
+// ExprCacheResolver is an optional [FieldResolver] interface that
+// allows the resolver to cache and reuse the built filter expressions.
+type ExprCacheResolver interface {
+	// CachedExpr returns the cached filter expression associated with the
+	// provided key or calls build to create (and eventually cache) a new one.
+	CachedExpr(key string, build func() (dbx.Expression, error)) (dbx.Expression, error)
+}
+
 // NewSimpleFieldResolver creates a new `SimpleFieldResolver` with the
 // provided `allowedFields`.
 //
@@ -0,0 +1,226 @@
+// Package sentry implements a minimal client for reporting errors and
+// panics to Sentry (or any other Sentry compatible service, e.g. GlitchTip).
+//
+// Example:
+//
+//	client, err := sentry.NewClient("https://public_key@o0.ingest.sentry.io/123")
+//	if err != nil {
+//		return err
+//	}
+//
+//	err = client.Send(ctx, &sentry.Event{
+//		Level:   sentry.LevelFatal,
+//		Message: "something went wrong",
+//	})
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// DefaultHTTPTimeout is the default request timeout used
+// when no custom [Client.HTTPClient] is specified.
+const DefaultHTTPTimeout = 10 * time.Second
+
+// clientName is the name of the client reported to the Sentry server.
+const clientName = "pocketbase-sentry/1.0"
+
+// Supported event levels.
+const (
+	LevelDebug   = "debug"
+	LevelInfo    = "info"
+	LevelWarning = "warning"
+	LevelError   = "error"
+	LevelFatal   = "fatal"
+)
+
+// DSN defines a parsed Sentry Data Source Name.
+type DSN struct {
+	Scheme    string
+	PublicKey string
+	Host      string
+	Path      string
+	ProjectId string
+}
+
+// ParseDSN parses the provided raw DSN string
+// (e.g. "https://public_key@o0.ingest.sentry.io/123").
+func ParseDSN(rawDSN string) (*DSN, error) {
+	u, err := url.Parse(rawDSN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DSN: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.New("invalid DSN: the scheme must be http or https")
+	}
+
+	if u.Host == "" {
+		return nil, errors.New("invalid DSN: missing host")
+	}
+
+	publicKey := u.User.Username()
+	if publicKey == "" {
+		return nil, errors.New("invalid DSN: missing public key")
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 || path[idx+1:] == "" {
+		return nil, errors.New("invalid DSN: missing project id")
+	}
+
+	return &DSN{
+		Scheme:    u.Scheme,
+		PublicKey: publicKey,
+		Host:      u.Host,
+		Path:      path[:idx],
+		ProjectId: path[idx+1:],
+	}, nil
+}
+
+// StoreURL returns the DSN project events store endpoint.
+func (dsn *DSN) StoreURL() string {
+	return fmt.Sprintf("%s://%s%s/api/%s/store/", dsn.Scheme, dsn.Host, dsn.Path, dsn.ProjectId)
+}
+
+// AuthHeader returns the DSN "X-Sentry-Auth" header value.
+func (dsn *DSN) AuthHeader() string {
+	return fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", clientName, dsn.PublicKey)
+}
+
+// Event defines a single Sentry event.
+type Event struct {
+	EventId     string            `json:"event_id"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Level       string            `json:"level,omitempty"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+	Exception   *ExceptionList    `json:"exception,omitempty"`
+}
+
+// ExceptionList defines the event exception interface.
+type ExceptionList struct {
+	Values []Exception `json:"values"`
+}
+
+// Exception defines a single event exception.
+type Exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Stacktrace *Stacktrace `json:"stacktrace,omitempty"`
+}
+
+// Stacktrace defines an exception stack trace.
+//
+// Note that the frames are sorted from oldest to newest.
+type Stacktrace struct {
+	Frames []Frame `json:"frames"`
+}
+
+// Frame defines a single stack trace frame.
+type Frame struct {
+	Function string `json:"function"`
+	Filename string `json:"filename,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+}
+
+// ResponseError defines a Sentry server response error.
+type ResponseError struct {
+	Status int
+	Body   []byte
+}
+
+// Error implements the std error interface.
+func (err *ResponseError) Error() string {
+	return fmt.Sprintf("sentry: failed to send event (status %d): %s", err.Status, strings.TrimSpace(string(err.Body)))
+}
+
+// Client defines a Sentry events client.
+type Client struct {
+	// HTTPClient is an optional HTTP client to use for sending the events
+	// (default to a client with [DefaultHTTPTimeout]).
+	HTTPClient *http.Client
+
+	dsn *DSN
+}
+
+// NewClient creates a new Sentry client for the specified raw DSN.
+func NewClient(rawDSN string) (*Client, error) {
+	dsn, err := ParseDSN(rawDSN)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{dsn: dsn}, nil
+}
+
+// DSN returns the parsed client DSN.
+func (c *Client) DSN() *DSN {
+	return c.dsn
+}
+
+// Send sends the provided event to the Sentry server.
+//
+// The event id, timestamp and platform are autopopulated if missing.
+func (c *Client) Send(ctx context.Context, event *Event) error {
+	if event.EventId == "" {
+		event.EventId = security.RandomStringWithAlphabet(32, "0123456789abcdef")
+	}
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	if event.Platform == "" {
+		event.Platform = "go"
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.dsn.StoreURL(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", c.dsn.AuthHeader())
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		resBody, _ := io.ReadAll(io.LimitReader(res.Body, 1<<10))
+		return &ResponseError{Status: res.StatusCode, Body: resBody}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package sentry
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseStack parses a goroutine stack trace (e.g. as returned by [runtime/debug.Stack])
+// into a list of Sentry stack frames ordered from oldest to newest.
+//
+// Unrecognized lines are ignored.
+func ParseStack(stack []byte) *Stacktrace {
+	result := &Stacktrace{Frames: []Frame{}}
+
+	lines := strings.Split(string(stack), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		// skip the goroutine header, empty and location lines without a function
+		if line == "" || strings.HasPrefix(line, "goroutine ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		frame := Frame{Function: trimFunctionArgs(line)}
+
+		// the next line is expected to be the function location
+		// (e.g. "\t/path/to/file.go:12 +0x1d")
+		if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\t") {
+			i++
+
+			location := strings.TrimSpace(lines[i])
+			if idx := strings.LastIndex(location, " +0x"); idx > 0 {
+				location = location[:idx]
+			}
+
+			if idx := strings.LastIndex(location, ":"); idx > 0 {
+				frame.Filename = location[:idx]
+				frame.Lineno, _ = strconv.Atoi(location[idx+1:])
+			} else {
+				frame.Filename = location
+			}
+		}
+
+		result.Frames = append(result.Frames, frame)
+	}
+
+	// reverse to oldest -> newest
+	for i, j := 0, len(result.Frames)-1; i < j; i, j = i+1, j-1 {
+		result.Frames[i], result.Frames[j] = result.Frames[j], result.Frames[i]
+	}
+
+	return result
+}
+
+// trimFunctionArgs removes the trailing call arguments from a stack trace
+// function line (e.g. "main.run(0x1, ...)" -> "main.run").
+func trimFunctionArgs(line string) string {
+	if strings.HasPrefix(line, "created by ") {
+		line = strings.TrimPrefix(line, "created by ")
+		if idx := strings.Index(line, " in goroutine "); idx > 0 {
+			line = line[:idx]
+		}
+		return line
+	}
+
+	if idx := strings.LastIndex(line, "("); idx > 0 && strings.HasSuffix(line, ")") {
+		return line[:idx]
+	}
+
+	return line
+}
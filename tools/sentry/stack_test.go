@@ -0,0 +1,73 @@
+package sentry_test
+
+import (
+	"encoding/json"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/sentry"
+)
+
+func TestParseStack(t *testing.T) {
+	stack := `goroutine 7 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:26 +0x5e
+panic({0x10a2e40?, 0x1187ab0?})
+	/usr/local/go/src/runtime/panic.go:785 +0x132
+main.(*worker).run(0xc000010000, {0x1, 0x2})
+	/app/main.go:42 +0x1d
+created by main.start in goroutine 1
+	/app/main.go:10 +0x65
+`
+
+	result := sentry.ParseStack([]byte(stack))
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"frames":[` +
+		`{"function":"main.start","filename":"/app/main.go","lineno":10},` +
+		`{"function":"main.(*worker).run","filename":"/app/main.go","lineno":42},` +
+		`{"function":"panic","filename":"/usr/local/go/src/runtime/panic.go","lineno":785},` +
+		`{"function":"runtime/debug.Stack","filename":"/usr/local/go/src/runtime/debug/stack.go","lineno":26}` +
+		`]}`
+
+	if string(raw) != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, raw)
+	}
+}
+
+func TestParseStackEmpty(t *testing.T) {
+	result := sentry.ParseStack(nil)
+
+	if len(result.Frames) != 0 {
+		t.Fatalf("Expected no frames, got %v", result.Frames)
+	}
+}
+
+func TestParseStackReal(t *testing.T) {
+	result := sentry.ParseStack(debug.Stack())
+
+	if len(result.Frames) == 0 {
+		t.Fatal("Expected at least 1 frame")
+	}
+
+	last := result.Frames[len(result.Frames)-1]
+	if last.Function != "runtime/debug.Stack" {
+		t.Fatalf("Expected the newest frame to be runtime/debug.Stack, got %q", last.Function)
+	}
+
+	var found bool
+	for _, f := range result.Frames {
+		if strings.HasSuffix(f.Function, "TestParseStackReal") && strings.HasSuffix(f.Filename, "stack_test.go") && f.Lineno > 0 {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("Expected to find the test function frame, got %v", result.Frames)
+	}
+}
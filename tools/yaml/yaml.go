@@ -0,0 +1,630 @@
+// Package yaml implements a minimal YAML decoder for simple configuration
+// and fixture files.
+//
+// Only the most commonly used subset of YAML 1.2 is supported:
+//   - block mappings and sequences (including "- key: value" compact items)
+//   - flow mappings and sequences (eg. {a: 1, b: [1, 2]})
+//   - plain, single and double quoted scalars
+//   - literal (|) and folded (>) block scalars
+//   - comments and a single document ("---" and "..." markers are ignored)
+//
+// Anchors, aliases, tags, complex keys and multi-line plain scalars are not supported.
+package yaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Unmarshal decodes the YAML data into v.
+//
+// The decoded data is converted to v by following the [json.Unmarshal]
+// rules, aka. the json struct tags are used for the field names.
+func Unmarshal(data []byte, v any) error {
+	value, err := Decode(data)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+// Decode decodes the YAML data into a generic value
+// (map[string]any, []any, string, bool, int64, float64 or nil).
+func Decode(data []byte) (any, error) {
+	p := &parser{}
+
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		l := newLine(i+1, raw)
+		if l.text != "" && strings.HasPrefix(raw, "\t") {
+			return nil, l.errorf("tabs are not allowed for indentation")
+		}
+		p.lines = append(p.lines, l)
+	}
+
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+
+	value, err := p.parseNode(p.lines[p.pos].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+	if p.pos < len(p.lines) {
+		return nil, p.lines[p.pos].errorf("unexpected content")
+	}
+
+	return value, nil
+}
+
+// -------------------------------------------------------------------
+
+type line struct {
+	raw    string
+	text   string // the trimmed line content without comments
+	number int
+	indent int
+}
+
+func newLine(number int, raw string) *line {
+	l := &line{number: number, raw: raw}
+
+	trimmed := strings.TrimLeft(raw, " ")
+	l.indent = len(raw) - len(trimmed)
+	l.text = strings.TrimSpace(stripComment(trimmed))
+
+	if l.indent == 0 && (l.text == "---" || l.text == "...") {
+		l.text = ""
+	}
+
+	return l
+}
+
+func (l *line) errorf(format string, args ...any) error {
+	return fmt.Errorf("yaml: line %d: %s", l.number, fmt.Sprintf(format, args...))
+}
+
+type parser struct {
+	lines []*line
+	pos   int
+}
+
+func (p *parser) skipBlank() {
+	for p.pos < len(p.lines) && p.lines[p.pos].text == "" {
+		p.pos++
+	}
+}
+
+// parseNode parses the block node starting at the current line.
+func (p *parser) parseNode(indent int) (any, error) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+
+	l := p.lines[p.pos]
+
+	if isSequenceItem(l.text) {
+		return p.parseSequence(l.indent)
+	}
+
+	if _, _, ok := splitMappingKey(l.text); ok {
+		return p.parseMapping(l.indent)
+	}
+
+	p.pos++
+
+	return p.parseInlineValue(l, l.text)
+}
+
+func (p *parser) parseSequence(indent int) (any, error) {
+	result := []any{}
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			break
+		}
+
+		l := p.lines[p.pos]
+		if l.indent < indent || (l.indent == indent && !isSequenceItem(l.text)) {
+			break
+		}
+		if l.indent > indent {
+			return nil, l.errorf("bad sequence indentation")
+		}
+
+		rest := strings.TrimLeft(l.raw[l.indent+1:], " ")
+		if strings.TrimSpace(stripComment(rest)) == "" {
+			// nested block
+			p.pos++
+			item, err := p.parseNestedNode(indent)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, item)
+			continue
+		}
+
+		// reparse the rest of the line as a separate more indented line
+		p.lines[p.pos] = newLine(l.number, strings.Repeat(" ", len(l.raw)-len(rest))+rest)
+
+		item, err := p.parseNode(p.lines[p.pos].indent)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+func (p *parser) parseMapping(indent int) (any, error) {
+	result := map[string]any{}
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			break
+		}
+
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, l.errorf("bad mapping indentation")
+		}
+
+		key, rest, ok := splitMappingKey(l.text)
+		if !ok {
+			return nil, l.errorf("expected a mapping key")
+		}
+
+		if _, exists := result[key]; exists {
+			return nil, l.errorf("duplicated mapping key %q", key)
+		}
+
+		p.pos++
+
+		var value any
+		var err error
+
+		switch {
+		case rest == "":
+			p.skipBlank()
+			if p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isSequenceItem(p.lines[p.pos].text) {
+				// sequence with the same indentation as its parent key
+				value, err = p.parseSequence(indent)
+			} else {
+				value, err = p.parseNestedNode(indent)
+			}
+		case isBlockScalarIndicator(rest):
+			value, err = p.parseBlockScalar(l, rest, indent)
+		default:
+			value, err = p.parseInlineValue(l, rest)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// parseNestedNode parses the node that is more indented than the parent indent (if any).
+func (p *parser) parseNestedNode(parentIndent int) (any, error) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) || p.lines[p.pos].indent <= parentIndent {
+		return nil, nil
+	}
+
+	return p.parseNode(p.lines[p.pos].indent)
+}
+
+// parseInlineValue parses a single line scalar or a (possible multi-line) flow collection.
+func (p *parser) parseInlineValue(l *line, text string) (any, error) {
+	if text[0] == '[' || text[0] == '{' {
+		// join the next lines until the flow collection is closed
+		for !isFlowBalanced(text) {
+			if p.pos >= len(p.lines) {
+				return nil, l.errorf("unterminated flow collection")
+			}
+			text += " " + p.lines[p.pos].text
+			p.pos++
+		}
+
+		fp := &flowParser{input: text}
+		value, err := fp.parseValue()
+		if err != nil {
+			return nil, l.errorf("%v", err)
+		}
+
+		fp.skipSpaces()
+		if fp.pos < len(fp.input) {
+			return nil, l.errorf("unexpected flow content %q", fp.input[fp.pos:])
+		}
+
+		return value, nil
+	}
+
+	if text[0] == '"' || text[0] == '\'' {
+		str, n, err := parseQuoted(text)
+		if err != nil {
+			return nil, l.errorf("%v", err)
+		}
+		if strings.TrimSpace(text[n:]) != "" {
+			return nil, l.errorf("unexpected content after the quoted string")
+		}
+		return str, nil
+	}
+
+	return resolvePlainScalar(text), nil
+}
+
+func (p *parser) parseBlockScalar(l *line, indicator string, parentIndent int) (any, error) {
+	folded := indicator[0] == '>'
+	chomping := ""
+	if len(indicator) > 1 {
+		chomping = indicator[1:]
+	}
+
+	var lines []string
+	blockIndent := -1
+
+	for p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+
+		if strings.TrimSpace(next.raw) == "" {
+			lines = append(lines, "")
+			p.pos++
+			continue
+		}
+
+		if next.indent <= parentIndent {
+			break
+		}
+
+		if blockIndent == -1 {
+			blockIndent = next.indent
+		} else if next.indent < blockIndent {
+			return nil, next.errorf("bad block scalar indentation")
+		}
+
+		lines = append(lines, next.raw[blockIndent:])
+		p.pos++
+	}
+
+	// trailing blank lines belong to the next node
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+	p.pos -= trailing
+	if p.pos < 0 {
+		p.pos = 0
+	}
+
+	var sb strings.Builder
+	for i, item := range lines {
+		if i > 0 {
+			switch {
+			case !folded || item == "" || strings.HasPrefix(item, " ") || strings.HasPrefix(lines[i-1], " "):
+				sb.WriteString("\n")
+			case lines[i-1] != "":
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString(item)
+	}
+
+	result := sb.String()
+
+	switch chomping {
+	case "-":
+		// strip
+	case "+":
+		result += "\n" + strings.Repeat("\n", trailing)
+	case "":
+		if len(lines) > 0 {
+			result += "\n"
+		}
+	default:
+		return nil, l.errorf("unsupported block scalar indicator %q", indicator)
+	}
+
+	return result, nil
+}
+
+// -------------------------------------------------------------------
+
+type flowParser struct {
+	input string
+	pos   int
+}
+
+func (fp *flowParser) skipSpaces() {
+	for fp.pos < len(fp.input) && (fp.input[fp.pos] == ' ' || fp.input[fp.pos] == '\t') {
+		fp.pos++
+	}
+}
+
+func (fp *flowParser) parseValue() (any, error) {
+	fp.skipSpaces()
+	if fp.pos >= len(fp.input) {
+		return nil, fmt.Errorf("unexpected end of flow collection")
+	}
+
+	switch fp.input[fp.pos] {
+	case '[':
+		return fp.parseSequence()
+	case '{':
+		return fp.parseMapping()
+	case '"', '\'':
+		str, n, err := parseQuoted(fp.input[fp.pos:])
+		if err != nil {
+			return nil, err
+		}
+		fp.pos += n
+		return str, nil
+	default:
+		start := fp.pos
+		for fp.pos < len(fp.input) && !strings.ContainsRune(",]}", rune(fp.input[fp.pos])) {
+			if fp.input[fp.pos] == ':' && (fp.pos+1 == len(fp.input) || fp.input[fp.pos+1] == ' ') {
+				break
+			}
+			fp.pos++
+		}
+		return resolvePlainScalar(strings.TrimSpace(fp.input[start:fp.pos])), nil
+	}
+}
+
+func (fp *flowParser) parseSequence() (any, error) {
+	fp.pos++ // [
+
+	result := []any{}
+
+	for {
+		fp.skipSpaces()
+		if fp.pos < len(fp.input) && fp.input[fp.pos] == ']' {
+			fp.pos++
+			return result, nil
+		}
+
+		item, err := fp.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+
+		fp.skipSpaces()
+		if fp.pos >= len(fp.input) {
+			return nil, fmt.Errorf("unterminated flow sequence")
+		}
+
+		switch fp.input[fp.pos] {
+		case ',':
+			fp.pos++
+		case ']':
+		default:
+			return nil, fmt.Errorf("unexpected flow sequence character %q", fp.input[fp.pos])
+		}
+	}
+}
+
+func (fp *flowParser) parseMapping() (any, error) {
+	fp.pos++ // {
+
+	result := map[string]any{}
+
+	for {
+		fp.skipSpaces()
+		if fp.pos < len(fp.input) && fp.input[fp.pos] == '}' {
+			fp.pos++
+			return result, nil
+		}
+
+		key, err := fp.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		keyStr, ok := key.(string)
+		if !ok {
+			keyStr = fmt.Sprint(key)
+		}
+
+		fp.skipSpaces()
+		if fp.pos >= len(fp.input) || fp.input[fp.pos] != ':' {
+			return nil, fmt.Errorf("missing flow mapping value for key %q", keyStr)
+		}
+		fp.pos++
+
+		value, err := fp.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		result[keyStr] = value
+
+		fp.skipSpaces()
+		if fp.pos >= len(fp.input) {
+			return nil, fmt.Errorf("unterminated flow mapping")
+		}
+
+		switch fp.input[fp.pos] {
+		case ',':
+			fp.pos++
+		case '}':
+		default:
+			return nil, fmt.Errorf("unexpected flow mapping character %q", fp.input[fp.pos])
+		}
+	}
+}
+
+// -------------------------------------------------------------------
+
+var (
+	intRegex   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	floatRegex = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+func resolvePlainScalar(text string) any {
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	}
+
+	if intRegex.MatchString(text) {
+		if v, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return v
+		}
+	}
+
+	if floatRegex.MatchString(text) {
+		if v, err := strconv.ParseFloat(text, 64); err == nil {
+			return v
+		}
+	}
+
+	return text
+}
+
+// parseQuoted parses the single or double quoted string at the start
+// of text and returns the unquoted string and the number of consumed bytes.
+func parseQuoted(text string) (string, int, error) {
+	quote := text[0]
+
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '\'' && text[i] == '\'':
+			if i+1 < len(text) && text[i+1] == '\'' {
+				i++ // escaped quote
+				continue
+			}
+			return strings.ReplaceAll(text[1:i], "''", "'"), i + 1, nil
+		case quote == '"' && text[i] == '\\':
+			i++ // skip the escaped character
+		case quote == '"' && text[i] == '"':
+			str, err := strconv.Unquote(text[:i+1])
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid double quoted string %s", text[:i+1])
+			}
+			return str, i + 1, nil
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated quoted string")
+}
+
+// stripComment removes the "#" line comment (if any) outside of quoted strings.
+func stripComment(text string) string {
+	var quote byte
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			// quotes are recognized only at the start of a scalar
+			if i == 0 || strings.ContainsRune(" [{,:-", rune(text[i-1])) {
+				quote = c
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		}
+	}
+
+	return text
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func isBlockScalarIndicator(text string) bool {
+	switch text {
+	case "|", "|-", "|+", ">", ">-", ">+":
+		return true
+	}
+	return false
+}
+
+// splitMappingKey splits a "key: value" line.
+func splitMappingKey(text string) (string, string, bool) {
+	if text == "" || text[0] == '[' || text[0] == '{' || isSequenceItem(text) {
+		return "", "", false
+	}
+
+	if text[0] == '"' || text[0] == '\'' {
+		key, n, err := parseQuoted(text)
+		if err != nil {
+			return "", "", false
+		}
+
+		rest := strings.TrimLeft(text[n:], " ")
+		if !strings.HasPrefix(rest, ":") || (len(rest) > 1 && rest[1] != ' ') {
+			return "", "", false
+		}
+
+		return key, strings.TrimSpace(rest[1:]), true
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+
+	return "", "", false
+}
+
+// isFlowBalanced reports whether all flow brackets (outside of quotes) are closed.
+func isFlowBalanced(text string) bool {
+	depth := 0
+	var quote byte
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+
+	return depth <= 0
+}
@@ -0,0 +1,125 @@
+package yaml_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/yaml"
+)
+
+func TestDecode(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		data        string
+		expectError bool
+		expected    string
+	}{
+		{"empty", "", false, `null`},
+		{"only comments", "# test\n\n  # test2", false, `null`},
+		{"plain scalar", "abc", false, `"abc"`},
+		{
+			"scalars",
+			`
+a: 1
+b: -1.5
+c: true
+d: FALSE
+e: ~
+f: null
+g:
+h: "x\ty # not a comment"
+i: 'it''s'
+j: plain text # comment
+k: http://example.com:8090/a#b
+l: "123"
+m: 1e3
+`,
+			false,
+			`{"a":1,"b":-1.5,"c":true,"d":false,"e":null,"f":null,"g":null,"h":"x\ty # not a comment","i":"it's","j":"plain text","k":"http://example.com:8090/a#b","l":"123","m":1000}`,
+		},
+		{
+			"nested mappings and sequences",
+			`
+---
+name: test
+items:
+  - a
+  - 2
+  - key: value
+    other: [1, "two", {x: y}]
+  -
+    - nested
+same_indent:
+- 1
+- 2
+obj:
+  a:
+    b: c
+  "quoted key": 1
+empty_flow: {}
+...
+`,
+			false,
+			`{"empty_flow":{},"items":["a",2,{"key":"value","other":[1,"two",{"x":"y"}]},["nested"]],"name":"test","obj":{"a":{"b":"c"},"quoted key":1},"same_indent":[1,2]}`,
+		},
+		{
+			"multi-line flow collection",
+			"a: [1,\n  2,\n  3]\nb: 4",
+			false,
+			`{"a":[1,2,3],"b":4}`,
+		},
+		{
+			"block scalars",
+			"lit: |\n  line1\n    line2 # not a comment\n\n  line3\nfolded: >-\n  a\n  b\n\n  c\nkeep: |+\n  x\n\nnext: 1\n",
+			false,
+			`{"folded":"a b\nc","keep":"x\n\n","lit":"line1\n  line2 # not a comment\n\nline3\n","next":1}`,
+		},
+		{"top level sequence", "- a\n- b: 1\n  c: 2", false, `["a",{"b":1,"c":2}]`},
+		{"duplicated key", "a: 1\na: 2", true, ``},
+		{"bad indentation", "a: 1\n  b: 2", true, ``},
+		{"unterminated quote", `a: "abc`, true, ``},
+		{"unterminated flow", "a: [1, 2", true, ``},
+		{"tab indentation", "a:\n\t b: 1", true, ``},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := yaml.Decode([]byte(s.data))
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			raw, err := json.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(raw) != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, raw)
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	data := struct {
+		Name  string   `json:"name"`
+		Tags  []string `json:"tags"`
+		Count int      `json:"count"`
+	}{}
+
+	err := yaml.Unmarshal([]byte("name: test\ntags: [a, b]\ncount: 3"), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.Name != "test" || len(data.Tags) != 2 || data.Tags[1] != "b" || data.Count != 3 {
+		t.Fatalf("Unexpected unmarshaled data %#v", data)
+	}
+}